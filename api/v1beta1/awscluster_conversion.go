@@ -60,13 +60,31 @@ func (src *AWSCluster) ConvertTo(dstRaw conversion.Hub) error {
 		dst.Status.Bastion.PrivateDNSName = restored.Status.Bastion.PrivateDNSName
 		dst.Status.Bastion.PublicIPOnLaunch = restored.Status.Bastion.PublicIPOnLaunch
 		dst.Status.Bastion.CapacityReservationID = restored.Status.Bastion.CapacityReservationID
+		dst.Status.Bastion.CapacityReservationResourceGroupARN = restored.Status.Bastion.CapacityReservationResourceGroupARN
+		dst.Status.Bastion.MarketType = restored.Status.Bastion.MarketType
+		restoreVolumes(restored.Status.Bastion.RootVolume, restored.Status.Bastion.NonRootVolumes, dst.Status.Bastion.RootVolume, dst.Status.Bastion.NonRootVolumes)
 	}
 	dst.Spec.Partition = restored.Spec.Partition
+	dst.Spec.OwnershipTagPrefix = restored.Spec.OwnershipTagPrefix
+	dst.Spec.SSHKey = restored.Spec.SSHKey
+	dst.Status.SSHKeyPair = restored.Status.SSHKeyPair
+	dst.Spec.NodeTerminationHandling = restored.Spec.NodeTerminationHandling
+	dst.Status.NodeTerminationHandling = restored.Status.NodeTerminationHandling
+	dst.Spec.NodeRoleManagement = restored.Spec.NodeRoleManagement
+	dst.Status.NodeRole = restored.Status.NodeRole
+	dst.Status.VolumeEncryption = restored.Status.VolumeEncryption
+	dst.Status.NetworkSummary = restored.Status.NetworkSummary
+	dst.Spec.CostSavings = restored.Spec.CostSavings
+	dst.Spec.ResourceNaming = restored.Spec.ResourceNaming
+	dst.Status.CostSavings = restored.Status.CostSavings
+	dst.Status.NamespaceIdentityRoleARN = restored.Status.NamespaceIdentityRoleARN
+	dst.Status.IdentityCredentialsExpiration = restored.Status.IdentityCredentialsExpiration
 
 	for role, sg := range restored.Status.Network.SecurityGroups {
 		dst.Status.Network.SecurityGroups[role] = sg
 	}
 	dst.Status.Network.NatGatewaysIPs = restored.Status.Network.NatGatewaysIPs
+	dst.Status.Network.EgressPrefixListID = restored.Status.Network.EgressPrefixListID
 
 	if restored.Spec.NetworkSpec.VPC.IPAMPool != nil {
 		if dst.Spec.NetworkSpec.VPC.IPAMPool == nil {
@@ -84,8 +102,16 @@ func (src *AWSCluster) ConvertTo(dstRaw conversion.Hub) error {
 		restoreIPAMPool(restored.Spec.NetworkSpec.VPC.IPv6.IPAMPool, dst.Spec.NetworkSpec.VPC.IPv6.IPAMPool)
 	}
 
+	dst.Spec.NetworkSpec.ApplyRulesToUnmanagedGroups = restored.Spec.NetworkSpec.ApplyRulesToUnmanagedGroups
 	dst.Spec.NetworkSpec.AdditionalControlPlaneIngressRules = restored.Spec.NetworkSpec.AdditionalControlPlaneIngressRules
 	dst.Spec.NetworkSpec.NodePortIngressRuleCidrBlocks = restored.Spec.NetworkSpec.NodePortIngressRuleCidrBlocks
+	dst.Spec.NetworkSpec.LocalGatewayRouteTables = restored.Spec.NetworkSpec.LocalGatewayRouteTables
+	dst.Spec.NetworkSpec.PublishEgressPrefixList = restored.Spec.NetworkSpec.PublishEgressPrefixList
+	dst.Spec.NetworkSpec.EgressPrefixListIncludeAPIServerLB = restored.Spec.NetworkSpec.EgressPrefixListIncludeAPIServerLB
+	dst.Spec.NetworkSpec.LegacyClusterTag = restored.Spec.NetworkSpec.LegacyClusterTag
+	if restored.Spec.NetworkSpec.CNI != nil && dst.Spec.NetworkSpec.CNI != nil {
+		dst.Spec.NetworkSpec.CNI.Preset = restored.Spec.NetworkSpec.CNI.Preset
+	}
 
 	if restored.Spec.NetworkSpec.VPC.IPAMPool != nil {
 		if dst.Spec.NetworkSpec.VPC.IPAMPool == nil {
@@ -107,6 +133,9 @@ func (src *AWSCluster) ConvertTo(dstRaw conversion.Hub) error {
 	dst.Spec.NetworkSpec.VPC.PrivateDNSHostnameTypeOnLaunch = restored.Spec.NetworkSpec.VPC.PrivateDNSHostnameTypeOnLaunch
 	dst.Spec.NetworkSpec.VPC.CarrierGatewayID = restored.Spec.NetworkSpec.VPC.CarrierGatewayID
 	dst.Spec.NetworkSpec.VPC.SubnetSchema = restored.Spec.NetworkSpec.VPC.SubnetSchema
+	dst.Spec.NetworkSpec.VPC.PrivateOnly = restored.Spec.NetworkSpec.VPC.PrivateOnly
+	dst.Spec.NetworkSpec.VPC.DHCPOptions = restored.Spec.NetworkSpec.VPC.DHCPOptions
+	dst.Spec.NetworkSpec.VPC.DHCPOptionsID = restored.Spec.NetworkSpec.VPC.DHCPOptionsID
 	dst.Spec.NetworkSpec.VPC.SecondaryCidrBlocks = restored.Spec.NetworkSpec.VPC.SecondaryCidrBlocks
 
 	if restored.Spec.NetworkSpec.VPC.ElasticIPPool != nil {
@@ -121,7 +150,7 @@ func (src *AWSCluster) ConvertTo(dstRaw conversion.Hub) error {
 		}
 	}
 
-	// Restore SubnetSpec.ResourceID, SubnetSpec.ParentZoneName, and SubnetSpec.ZoneType fields, if any.
+	// Restore SubnetSpec.ResourceID, SubnetSpec.ParentZoneName, SubnetSpec.ZoneType, and SubnetSpec.OutpostARN fields, if any.
 	for _, subnet := range restored.Spec.NetworkSpec.Subnets {
 		for i, dstSubnet := range dst.Spec.NetworkSpec.Subnets {
 			if dstSubnet.ID == subnet.ID {
@@ -134,6 +163,9 @@ func (src *AWSCluster) ConvertTo(dstRaw conversion.Hub) error {
 				if subnet.ZoneType != nil {
 					dstSubnet.ZoneType = subnet.ZoneType
 				}
+				if subnet.OutpostARN != nil {
+					dstSubnet.OutpostARN = subnet.OutpostARN
+				}
 				dstSubnet.DeepCopyInto(&dst.Spec.NetworkSpec.Subnets[i])
 			}
 		}
@@ -42,6 +42,12 @@ func (src *AWSMachine) ConvertTo(dstRaw conversion.Hub) error {
 	dst.Spec.PrivateDNSName = restored.Spec.PrivateDNSName
 	dst.Spec.SecurityGroupOverrides = restored.Spec.SecurityGroupOverrides
 	dst.Spec.CapacityReservationID = restored.Spec.CapacityReservationID
+	dst.Spec.CapacityReservationResourceGroupARN = restored.Spec.CapacityReservationResourceGroupARN
+	dst.Spec.MarketType = restored.Spec.MarketType
+	dst.Spec.AMI.SourceRegion = restored.Spec.AMI.SourceRegion
+	dst.Spec.AMI.CopyEncryptionKey = restored.Spec.AMI.CopyEncryptionKey
+	dst.Spec.AMI.GPUCompatible = restored.Spec.AMI.GPUCompatible
+	restoreVolumes(restored.Spec.RootVolume, restored.Spec.NonRootVolumes, dst.Spec.RootVolume, dst.Spec.NonRootVolumes)
 	if restored.Spec.ElasticIPPool != nil {
 		if dst.Spec.ElasticIPPool == nil {
 			dst.Spec.ElasticIPPool = &infrav1.ElasticIPPool{}
@@ -104,6 +110,12 @@ func (r *AWSMachineTemplate) ConvertTo(dstRaw conversion.Hub) error {
 	dst.Spec.Template.Spec.PrivateDNSName = restored.Spec.Template.Spec.PrivateDNSName
 	dst.Spec.Template.Spec.SecurityGroupOverrides = restored.Spec.Template.Spec.SecurityGroupOverrides
 	dst.Spec.Template.Spec.CapacityReservationID = restored.Spec.Template.Spec.CapacityReservationID
+	dst.Spec.Template.Spec.CapacityReservationResourceGroupARN = restored.Spec.Template.Spec.CapacityReservationResourceGroupARN
+	dst.Spec.Template.Spec.MarketType = restored.Spec.Template.Spec.MarketType
+	dst.Spec.Template.Spec.AMI.SourceRegion = restored.Spec.Template.Spec.AMI.SourceRegion
+	dst.Spec.Template.Spec.AMI.CopyEncryptionKey = restored.Spec.Template.Spec.AMI.CopyEncryptionKey
+	dst.Spec.Template.Spec.AMI.GPUCompatible = restored.Spec.Template.Spec.AMI.GPUCompatible
+	restoreVolumes(restored.Spec.Template.Spec.RootVolume, restored.Spec.Template.Spec.NonRootVolumes, dst.Spec.Template.Spec.RootVolume, dst.Spec.Template.Spec.NonRootVolumes)
 	if restored.Spec.Template.Spec.ElasticIPPool != nil {
 		if dst.Spec.Template.Spec.ElasticIPPool == nil {
 			dst.Spec.Template.Spec.ElasticIPPool = &infrav1.ElasticIPPool{}
@@ -147,3 +159,21 @@ func (dst *AWSMachineTemplateList) ConvertFrom(srcRaw conversion.Hub) error {
 
 	return Convert_v1beta2_AWSMachineTemplateList_To_v1beta1_AWSMachineTemplateList(src, dst, nil)
 }
+
+// restoreVolumes restores the volume fields that don't exist in v1beta1.
+func restoreVolumes(restoredRoot *infrav1.Volume, restoredNonRoot []infrav1.Volume, dstRoot *infrav1.Volume, dstNonRoot []infrav1.Volume) {
+	restoreVolume := func(restored, dst *infrav1.Volume) {
+		dst.DeleteOnTermination = restored.DeleteOnTermination
+		dst.SnapshotID = restored.SnapshotID
+	}
+
+	if restoredRoot != nil && dstRoot != nil {
+		restoreVolume(restoredRoot, dstRoot)
+	}
+	if len(restoredNonRoot) != len(dstNonRoot) {
+		return
+	}
+	for i := range dstNonRoot {
+		restoreVolume(&restoredNonRoot[i], &dstNonRoot[i])
+	}
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package v1beta2
 
 import (
+	"sort"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -47,6 +49,12 @@ type AWSClusterSpec struct {
 	// +optional
 	SSHKeyName *string `json:"sshKeyName,omitempty"`
 
+	// SSHKey is an SSH key pair that the provider imports into EC2 and manages for the lifetime of
+	// the cluster. The key pair is used by the bastion host and by machines that do not specify
+	// an SSH key name of their own. Mutually exclusive with SSHKeyName.
+	// +optional
+	SSHKey *SSHKey `json:"sshKey,omitempty"`
+
 	// ControlPlaneEndpoint represents the endpoint used to communicate with the control plane.
 	// +optional
 	ControlPlaneEndpoint clusterv1.APIEndpoint `json:"controlPlaneEndpoint"`
@@ -56,6 +64,13 @@ type AWSClusterSpec struct {
 	// +optional
 	AdditionalTags Tags `json:"additionalTags,omitempty"`
 
+	// OwnershipTagPrefix overrides the prefix of the tag key used to mark AWS resources as owned by
+	// this cluster. The cluster name is appended to the prefix. Defaults to the controller's
+	// --ownership-tag-prefix flag, or "sigs.k8s.io/cluster-api-provider-aws/cluster/" if unset.
+	// Changing the prefix of an existing cluster migrates the tags of its resources to the new key.
+	// +optional
+	OwnershipTagPrefix string `json:"ownershipTagPrefix,omitempty"`
+
 	// ControlPlaneLoadBalancer is optional configuration for customizing control plane behavior.
 	// +optional
 	ControlPlaneLoadBalancer *AWSLoadBalancerSpec `json:"controlPlaneLoadBalancer,omitempty"`
@@ -111,6 +126,26 @@ type AWSClusterSpec struct {
 	// BootstrapFormatIgnition feature flag to be enabled).
 	// +optional
 	S3Bucket *S3Bucket `json:"s3Bucket,omitempty"`
+
+	// NodeTerminationHandling configures the AWS resources used by aws-node-termination-handler
+	// in queue-processor mode. Only the SQS queue, the EventBridge rules and the Auto Scaling group
+	// lifecycle hooks are managed; the handler itself must be installed separately.
+	// +optional
+	NodeTerminationHandling *NodeTerminationHandling `json:"nodeTerminationHandling,omitempty"`
+
+	// NodeRoleManagement configures the IAM role and instance profile created for the nodes of the cluster.
+	// Machines which don't specify an IAM instance profile use the created one.
+	// +optional
+	NodeRoleManagement *NodeRoleManagement `json:"nodeRoleManagement,omitempty"`
+
+	// CostSavings suspends resources of the cluster which aren't needed outside of working hours, such as the
+	// NAT gateways and the bastion host of development clusters.
+	// +optional
+	CostSavings *CostSavings `json:"costSavings,omitempty"`
+
+	// ResourceNaming configures the Name tag of the resources created for the cluster.
+	// +optional
+	ResourceNaming *ResourceNaming `json:"resourceNaming,omitempty"`
 }
 
 // AWSIdentityKind defines allowed AWS identity types.
@@ -166,6 +201,323 @@ type Bastion struct {
 	AMI string `json:"ami,omitempty"`
 }
 
+// SSHKey defines an SSH key pair imported into EC2 by the provider.
+type SSHKey struct {
+	// Name is the name of the EC2 key pair.
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=255
+	Name string `json:"name"`
+
+	// PublicKeyMaterial is the public key to import, in OpenSSH authorized_keys format.
+	// +kubebuilder:validation:MinLength:=1
+	PublicKeyMaterial string `json:"publicKeyMaterial"`
+}
+
+// SSHKeyPairStatus defines the observed state of a key pair imported by the provider.
+type SSHKeyPairStatus struct {
+	// KeyPairID is the ID of the EC2 key pair.
+	KeyPairID string `json:"keyPairID"`
+
+	// Fingerprint is the fingerprint EC2 computed for the key pair when it was imported.
+	Fingerprint string `json:"fingerprint"`
+}
+
+// NodeTerminationHandlingRule is a kind of event forwarded to the node termination handler queue.
+// +kubebuilder:validation:Enum=spotInterruption;rebalance;scheduledChange;asgLifecycle
+type NodeTerminationHandlingRule string
+
+const (
+	// NodeTerminationHandlingRuleSpotInterruption forwards EC2 Spot Instance interruption warnings.
+	NodeTerminationHandlingRuleSpotInterruption = NodeTerminationHandlingRule("spotInterruption")
+	// NodeTerminationHandlingRuleRebalance forwards EC2 instance rebalance recommendations.
+	NodeTerminationHandlingRuleRebalance = NodeTerminationHandlingRule("rebalance")
+	// NodeTerminationHandlingRuleScheduledChange forwards AWS Health scheduled change events for EC2.
+	NodeTerminationHandlingRuleScheduledChange = NodeTerminationHandlingRule("scheduledChange")
+	// NodeTerminationHandlingRuleASGLifecycle forwards Auto Scaling termination lifecycle actions, and adds
+	// a termination lifecycle hook to the Auto Scaling groups of the cluster.
+	NodeTerminationHandlingRuleASGLifecycle = NodeTerminationHandlingRule("asgLifecycle")
+)
+
+// NodeTerminationHandling defines the AWS resources managed for the node termination handler.
+type NodeTerminationHandling struct {
+	// Enabled creates the queue and the rules when true, and removes them when false.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// QueueName is the name of the SQS queue the events are sent to.
+	// Defaults to "<cluster-name>-nth-queue".
+	// +kubebuilder:validation:MaxLength:=80
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9_-]+$`
+	// +optional
+	QueueName string `json:"queueName,omitempty"`
+
+	// ManagedRules is the list of events forwarded to the queue. Defaults to all of them.
+	// +listType=set
+	// +optional
+	ManagedRules []NodeTerminationHandlingRule `json:"managedRules,omitempty"`
+}
+
+// HasRule returns true if the events of the given rule are forwarded to the queue.
+func (n *NodeTerminationHandling) HasRule(rule NodeTerminationHandlingRule) bool {
+	if n == nil || !n.Enabled {
+		return false
+	}
+	if len(n.ManagedRules) == 0 {
+		return true
+	}
+	for _, r := range n.ManagedRules {
+		if r == rule {
+			return true
+		}
+	}
+	return false
+}
+
+// NodeTerminationHandlingStatus defines the observed state of the resources managed for the node termination handler.
+type NodeTerminationHandlingStatus struct {
+	// QueueURL is the URL of the SQS queue, to be passed to the node termination handler.
+	QueueURL string `json:"queueURL"`
+
+	// QueueARN is the ARN of the SQS queue.
+	QueueARN string `json:"queueARN"`
+}
+
+// NodeRoleManagement defines the IAM role and instance profile created for the nodes of a cluster.
+type NodeRoleManagement struct {
+	// Create makes the controller create the role and the instance profile when true, and removes them
+	// when false.
+	// +optional
+	Create bool `json:"create,omitempty"`
+
+	// NamePrefix is the prefix of the names of the role and of the instance profile, which are named
+	// "<namePrefix>-nodes". Defaults to "<namespace>-<cluster name>", truncated and followed by a hash
+	// of it when longer than 58 characters.
+	// +kubebuilder:validation:MaxLength:=58
+	// +kubebuilder:validation:Pattern=`^[\w+=,.@-]+$`
+	// +optional
+	NamePrefix string `json:"namePrefix,omitempty"`
+
+	// PermissionsBoundary is the ARN of the IAM policy set as the permissions boundary of the role.
+	// +optional
+	PermissionsBoundary string `json:"permissionsBoundary,omitempty"`
+
+	// AdditionalPolicies is a list of ARNs of IAM policies attached to the role in addition to the
+	// policies required by the nodes. Policies removed from the list are detached from the role.
+	// +listType=set
+	// +optional
+	AdditionalPolicies []string `json:"additionalPolicies,omitempty"`
+
+	// AttachCNIPolicy attaches the AmazonEKS_CNI_Policy managed policy to the role, required by the
+	// Amazon VPC CNI plugin when it uses the role of the nodes.
+	// +optional
+	AttachCNIPolicy bool `json:"attachCNIPolicy,omitempty"`
+
+	// AttachECRReadOnlyPolicy attaches the AmazonEC2ContainerRegistryReadOnly managed policy to the role.
+	// +optional
+	AttachECRReadOnlyPolicy bool `json:"attachECRReadOnlyPolicy,omitempty"`
+
+	// AttachSSMPolicy attaches the AmazonSSMManagedInstanceCore managed policy to the role, so that the
+	// nodes can be reached with AWS Systems Manager Session Manager.
+	// +optional
+	AttachSSMPolicy bool `json:"attachSSMPolicy,omitempty"`
+}
+
+// NodeRoleStatus defines the observed state of the IAM role and instance profile created for the nodes.
+type NodeRoleStatus struct {
+	// RoleName is the name of the IAM role.
+	RoleName string `json:"roleName"`
+
+	// RoleARN is the ARN of the IAM role.
+	RoleARN string `json:"roleARN"`
+
+	// InstanceProfileName is the name of the instance profile, used by the machines which don't specify one.
+	InstanceProfileName string `json:"instanceProfileName"`
+
+	// InstanceProfileARN is the ARN of the instance profile.
+	InstanceProfileARN string `json:"instanceProfileARN"`
+}
+
+// CostSavingsResource is a resource of the cluster which can be suspended.
+// +kubebuilder:validation:Enum=bastion;natGateways
+type CostSavingsResource string
+
+const (
+	// CostSavingsResourceBastion is the bastion host, which is terminated while suspended and recreated on resume.
+	CostSavingsResourceBastion CostSavingsResource = "bastion"
+	// CostSavingsResourceNatGateways are the NAT gateways, which are deleted while suspended and recreated with
+	// the same Elastic IPs on resume.
+	CostSavingsResourceNatGateways CostSavingsResource = "natGateways"
+)
+
+// CostSavings defines the resources of a cluster suspended to save costs.
+type CostSavings struct {
+	// Schedule suspends and resumes resources of the cluster on a schedule.
+	// +optional
+	Schedule *CostSavingsSchedule `json:"schedule,omitempty"`
+}
+
+// ResourceNaming configures the Name tag of the resources created for the cluster.
+type ResourceNaming struct {
+	// Template is a Go template rendering the Name tag of the resources created for the cluster, with the fields
+	// ClusterName, ResourceType, AZ, Role and Index, such as "{{.ClusterName}}-{{.ResourceType}}{{with .AZ}}-{{.}}{{end}}".
+	// ResourceType is one of vpc, subnet, route-table, internet-gateway, carrier-gateway, egress-only-internet-gateway,
+	// nat-gateway, elastic-ip, dhcp-options, security-group or load-balancer. AZ is only set for the resources of an
+	// availability zone, Role is the role of the resource, such as public or private for subnets, and Index is the
+	// position of a subnet among the subnets of the same role in its availability zone, 0 for the other resources.
+	// The template only names the resources created after it is set, the resources which already exist keep their
+	// Name tag.
+	// +kubebuilder:validation:MinLength=1
+	Template string `json:"template"`
+}
+
+// CostSavingsSchedule suspends resources of a cluster between the activations of two cron expressions.
+type CostSavingsSchedule struct {
+	// Suspend is the cron expression, in UTC, of the times the resources are suspended, such as "0 20 * * 1-5".
+	// +kubebuilder:validation:MinLength=1
+	Suspend string `json:"suspend"`
+
+	// Resume is the cron expression, in UTC, of the times the resources are resumed, such as "0 7 * * 1-5".
+	// +kubebuilder:validation:MinLength=1
+	Resume string `json:"resume"`
+
+	// Resources are the resources suspended.
+	// +kubebuilder:validation:MinItems=1
+	// +listType=set
+	Resources []CostSavingsResource `json:"resources"`
+}
+
+// CostSavingsStatus defines the observed state of the resources suspended to save costs.
+type CostSavingsStatus struct {
+	// SuspendedResources are the resources currently suspended.
+	// +optional
+	SuspendedResources []CostSavingsResource `json:"suspendedResources,omitempty"`
+
+	// LastTransitionTime is the time resources were last suspended or resumed.
+	// +optional
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
+
+	// NextTransitionTime is the next time the schedule suspends or resumes the resources.
+	// +optional
+	NextTransitionTime *metav1.Time `json:"nextTransitionTime,omitempty"`
+
+	// NatGatewayAllocations are the allocation IDs of the Elastic IPs of the suspended NAT gateways by ID of
+	// their public subnet, which are kept so that the NAT gateways are recreated with the same public IPs.
+	// +optional
+	NatGatewayAllocations map[string]string `json:"natGatewayAllocations,omitempty"`
+}
+
+// VolumeEncryptionStatus reports the encryption of the root volumes of the instances of the cluster.
+type VolumeEncryptionStatus struct {
+	// LastCheckTime is the time the root volumes were last checked.
+	LastCheckTime metav1.Time `json:"lastCheckTime"`
+
+	// CheckedVolumes is the number of root volumes checked.
+	CheckedVolumes int32 `json:"checkedVolumes"`
+
+	// UnencryptedControlPlaneVolumes is the number of unencrypted root volumes of control plane instances.
+	UnencryptedControlPlaneVolumes int32 `json:"unencryptedControlPlaneVolumes"`
+
+	// UnencryptedNodeVolumes is the number of unencrypted root volumes of the other instances of the cluster.
+	UnencryptedNodeVolumes int32 `json:"unencryptedNodeVolumes"`
+
+	// UnencryptedInstanceIDs lists the instances with an unencrypted root volume. The list is truncated
+	// for large clusters, the counts include all the instances.
+	// +optional
+	UnencryptedInstanceIDs []string `json:"unencryptedInstanceIDs,omitempty"`
+}
+
+// NetworkSummaryVersion is the version of the layout of the network summary. It changes when fields of the summary
+// are renamed or removed, so that consumers can tell the layouts apart.
+const NetworkSummaryVersion = "v1"
+
+// NetworkSummary is a consolidated document of the network resources of a cluster.
+type NetworkSummary struct {
+	// Version is the version of the layout of the summary.
+	Version string `json:"version"`
+
+	// LastUpdateTime is the time the network resources in the summary last changed.
+	LastUpdateTime metav1.Time `json:"lastUpdateTime"`
+
+	// VPC is the VPC of the cluster.
+	VPC NetworkSummaryResource `json:"vpc"`
+
+	// CidrBlocks are the IPv4 CIDR blocks associated with the VPC.
+	// +optional
+	CidrBlocks []string `json:"cidrBlocks,omitempty"`
+
+	// Zones are the network resources of each availability zone of the cluster, sorted by name.
+	// +optional
+	Zones []NetworkSummaryZone `json:"zones,omitempty"`
+
+	// SecurityGroups are the security groups of the cluster, sorted by role.
+	// +optional
+	SecurityGroups []NetworkSummarySecurityGroup `json:"securityGroups,omitempty"`
+
+	// NatGatewaysIPs are the public IPs of the NAT gateways of all the availability zones.
+	// +optional
+	NatGatewaysIPs []string `json:"natGatewaysIPs,omitempty"`
+}
+
+// NetworkSummaryResource identifies an AWS resource in the network summary.
+type NetworkSummaryResource struct {
+	// ID is the ID of the resource.
+	ID string `json:"id"`
+
+	// ARN is the Amazon Resource Name of the resource.
+	ARN string `json:"arn"`
+}
+
+// NetworkSummaryZone is the network resources of an availability zone in the network summary.
+type NetworkSummaryZone struct {
+	// Name is the name of the availability zone.
+	Name string `json:"name"`
+
+	// PublicSubnets are the public subnets of the availability zone.
+	// +optional
+	PublicSubnets []NetworkSummarySubnet `json:"publicSubnets,omitempty"`
+
+	// PrivateSubnets are the private subnets of the availability zone.
+	// +optional
+	PrivateSubnets []NetworkSummarySubnet `json:"privateSubnets,omitempty"`
+
+	// NatGateways are the NAT gateways of the availability zone.
+	// +optional
+	NatGateways []NetworkSummaryNatGateway `json:"natGateways,omitempty"`
+}
+
+// NetworkSummarySubnet is a subnet in the network summary.
+type NetworkSummarySubnet struct {
+	NetworkSummaryResource `json:",inline"`
+
+	// CidrBlock is the IPv4 CIDR block of the subnet.
+	// +optional
+	CidrBlock string `json:"cidrBlock,omitempty"`
+
+	// IPv6CidrBlock is the IPv6 CIDR block of the subnet.
+	// +optional
+	IPv6CidrBlock string `json:"ipv6CidrBlock,omitempty"`
+}
+
+// NetworkSummaryNatGateway is a NAT gateway in the network summary.
+type NetworkSummaryNatGateway struct {
+	NetworkSummaryResource `json:",inline"`
+
+	// SubnetID is the ID of the public subnet of the NAT gateway.
+	SubnetID string `json:"subnetID"`
+
+	// PublicIP is the public IP of the NAT gateway.
+	// +optional
+	PublicIP string `json:"publicIP,omitempty"`
+}
+
+// NetworkSummarySecurityGroup is a security group in the network summary.
+type NetworkSummarySecurityGroup struct {
+	NetworkSummaryResource `json:",inline"`
+
+	// Role is the role of the security group in the cluster.
+	Role SecurityGroupRole `json:"role"`
+}
+
 // LoadBalancerType defines the type of load balancer to use.
 type LoadBalancerType string
 
@@ -237,6 +589,7 @@ type AWSLoadBalancerSpec struct {
 	AdditionalListeners []AdditionalListenerSpec `json:"additionalListeners,omitempty"`
 
 	// IngressRules sets the ingress rules for the control plane load balancer.
+	// When the load balancer is disabled, they are applied to the control plane instances instead.
 	// +optional
 	IngressRules []IngressRule `json:"ingressRules,omitempty"`
 
@@ -280,7 +633,53 @@ type AWSClusterStatus struct {
 	Network        NetworkStatus            `json:"networkStatus,omitempty"`
 	FailureDomains clusterv1.FailureDomains `json:"failureDomains,omitempty"`
 	Bastion        *Instance                `json:"bastion,omitempty"`
-	Conditions     clusterv1.Conditions     `json:"conditions,omitempty"`
+	SSHKeyPair     *SSHKeyPairStatus        `json:"sshKeyPair,omitempty"`
+
+	// NodeTerminationHandling is the observed state of the resources managed for the node termination handler.
+	// +optional
+	NodeTerminationHandling *NodeTerminationHandlingStatus `json:"nodeTerminationHandling,omitempty"`
+
+	// NodeRole is the observed state of the IAM role and instance profile created for the nodes.
+	// +optional
+	NodeRole *NodeRoleStatus `json:"nodeRole,omitempty"`
+
+	// VolumeEncryption reports the encryption of the root volumes of the instances of the cluster. It is only
+	// set when the volume encryption report is enabled.
+	// +optional
+	VolumeEncryption *VolumeEncryptionStatus `json:"volumeEncryption,omitempty"`
+
+	// NamespaceIdentityRoleARN is the ARN of the role the controller assumes for the cluster, configured for its
+	// namespace, as it doesn't set an identityRef other than the controller identity.
+	// +optional
+	NamespaceIdentityRoleARN string `json:"namespaceIdentityRoleARN,omitempty"`
+
+	// IdentityCredentialsExpiration is when the credentials of the role assumed for the cluster expire, and the
+	// role is assumed again. It is not set when the cluster uses credentials which don't expire.
+	// +optional
+	IdentityCredentialsExpiration *metav1.Time `json:"identityCredentialsExpiration,omitempty"`
+
+	// NetworkSummary is a consolidated document of the network resources of the cluster, for the automation
+	// deploying add-ons. It is only set when the network summary is enabled.
+	// +optional
+	NetworkSummary *NetworkSummary `json:"networkSummary,omitempty"`
+
+	// CostSavings is the observed state of the resources suspended by spec.costSavings.
+	// +optional
+	CostSavings *CostSavingsStatus `json:"costSavings,omitempty"`
+
+	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
+}
+
+// ControlPlaneZones returns the sorted availability zones of the failure domains used by control plane machines.
+func (s *AWSClusterStatus) ControlPlaneZones() []string {
+	zones := []string{}
+	for zone, fd := range s.FailureDomains {
+		if fd.ControlPlane {
+			zones = append(zones, zone)
+		}
+	}
+	sort.Strings(zones)
+	return zones
 }
 
 // S3Bucket defines a supporting S3 bucket for the cluster, currently can be optionally used for Ignition.
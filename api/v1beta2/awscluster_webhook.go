@@ -21,6 +21,7 @@ import (
 	"net"
 	"strings"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/google/go-cmp/cmp"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -56,10 +57,17 @@ func (r *AWSCluster) ValidateCreate() (admission.Warnings, error) {
 
 	allErrs = append(allErrs, r.Spec.Bastion.Validate()...)
 	allErrs = append(allErrs, r.validateSSHKeyName()...)
+	allErrs = append(allErrs, r.validateSSHKey()...)
 	allErrs = append(allErrs, r.Spec.AdditionalTags.Validate()...)
+	allErrs = append(allErrs, ValidateOwnershipTagPrefix(r.Spec.OwnershipTagPrefix, r.Labels[clusterv1.ClusterNameLabel], field.NewPath("spec", "ownershipTagPrefix"))...)
 	allErrs = append(allErrs, r.Spec.S3Bucket.Validate()...)
+	allErrs = append(allErrs, r.Spec.NodeRoleManagement.Validate(field.NewPath("spec", "nodeRoleManagement"))...)
+	allErrs = append(allErrs, r.Spec.CostSavings.Validate(field.NewPath("spec", "costSavings"))...)
+	allErrs = append(allErrs, r.Spec.ResourceNaming.Validate(r.Name, field.NewPath("spec", "resourceNaming"))...)
 	allErrs = append(allErrs, r.validateNetwork()...)
 	allErrs = append(allErrs, r.validateControlPlaneLBs()...)
+	allErrs = append(allErrs, r.validateControlPlaneLBSubnets()...)
+	allErrs = append(allErrs, r.validatePrivateOnlyVPC()...)
 
 	return nil, aggregateObjErrors(r.GroupVersionKind().GroupKind(), r.Name, allErrs)
 }
@@ -128,6 +136,34 @@ func (r *AWSCluster) ValidateUpdate(old runtime.Object) (admission.Warnings, err
 		)
 	}
 
+	// The key pair is imported once, replacing it would leave the existing instances with the old key.
+	if !cmp.Equal(oldC.Spec.SSHKey, r.Spec.SSHKey) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "sshKey"), r.Spec.SSHKey, "field is immutable"),
+		)
+	}
+
+	allErrs = append(allErrs, r.Spec.NodeTerminationHandling.ValidateUpdate(oldC.Spec.NodeTerminationHandling, field.NewPath("spec", "nodeTerminationHandling"))...)
+
+	// The role and the instance profile are created once, renaming them would leave the existing instances
+	// with the old instance profile.
+	if oldC.Spec.NodeRoleManagement != nil && oldC.Spec.NodeRoleManagement.Create &&
+		r.Spec.NodeRoleManagement != nil && r.Spec.NodeRoleManagement.Create &&
+		oldC.Spec.NodeRoleManagement.NamePrefix != r.Spec.NodeRoleManagement.NamePrefix {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "nodeRoleManagement", "namePrefix"),
+				r.Spec.NodeRoleManagement.NamePrefix, "field is immutable while the node role is created"),
+		)
+	}
+
+	// The internet gateway and the NAT gateways of the VPC aren't reconciled once created or deleted.
+	if oldC.Spec.NetworkSpec.VPC.PrivateOnly != r.Spec.NetworkSpec.VPC.PrivateOnly {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "network", "vpc", "privateOnly"),
+				r.Spec.NetworkSpec.VPC.PrivateOnly, "field is immutable"),
+		)
+	}
+
 	if annotations.IsExternallyManaged(oldC) && !annotations.IsExternallyManaged(r) {
 		allErrs = append(allErrs,
 			field.Invalid(field.NewPath("metadata", "annotations"),
@@ -136,8 +172,15 @@ func (r *AWSCluster) ValidateUpdate(old runtime.Object) (admission.Warnings, err
 	}
 
 	allErrs = append(allErrs, r.Spec.Bastion.Validate()...)
+	allErrs = append(allErrs, r.validateSSHKey()...)
+	allErrs = append(allErrs, r.validateControlPlaneLBSubnets()...)
 	allErrs = append(allErrs, r.Spec.AdditionalTags.Validate()...)
+	allErrs = append(allErrs, ValidateOwnershipTagPrefix(r.Spec.OwnershipTagPrefix, r.Labels[clusterv1.ClusterNameLabel], field.NewPath("spec", "ownershipTagPrefix"))...)
 	allErrs = append(allErrs, r.Spec.S3Bucket.Validate()...)
+	allErrs = append(allErrs, r.Spec.NodeRoleManagement.Validate(field.NewPath("spec", "nodeRoleManagement"))...)
+	allErrs = append(allErrs, r.Spec.CostSavings.Validate(field.NewPath("spec", "costSavings"))...)
+	allErrs = append(allErrs, r.Spec.ResourceNaming.Validate(r.Name, field.NewPath("spec", "resourceNaming"))...)
+	allErrs = append(allErrs, r.validatePrivateOnlyVPC()...)
 
 	return nil, aggregateObjErrors(r.GroupVersionKind().GroupKind(), r.Name, allErrs)
 }
@@ -211,7 +254,7 @@ func (r *AWSCluster) validateGCTasksAnnotation() field.ErrorList {
 	if gcTasksAnnotationValue := annotations[ExternalResourceGCTasksAnnotation]; gcTasksAnnotationValue != "" {
 		gcTasks := strings.Split(gcTasksAnnotationValue, ",")
 
-		supportedGCTasks := []GCTask{GCTaskLoadBalancer, GCTaskTargetGroup, GCTaskSecurityGroup}
+		supportedGCTasks := []GCTask{GCTaskLoadBalancer, GCTaskTargetGroup, GCTaskSecurityGroup, GCTaskVolume}
 
 		for _, gcTask := range gcTasks {
 			found := false
@@ -240,12 +283,44 @@ func (r *AWSCluster) validateSSHKeyName() field.ErrorList {
 	return validateSSHKeyName(r.Spec.SSHKeyName)
 }
 
+func (r *AWSCluster) validateSSHKey() field.ErrorList {
+	var allErrs field.ErrorList
+	if r.Spec.SSHKey == nil {
+		return allErrs
+	}
+
+	if r.Spec.SSHKeyName != nil {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "sshKeyName"), "sshKeyName and sshKey are mutually exclusive"))
+	}
+	if !sshKeyValidNameRegex.MatchString(r.Spec.SSHKey.Name) {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "sshKey", "name"), r.Spec.SSHKey.Name, "Name is invalid. Must be specified in ASCII and must not start or end in whitespace"))
+	}
+	if strings.TrimSpace(r.Spec.SSHKey.PublicKeyMaterial) == "" {
+		allErrs = append(allErrs, field.Required(field.NewPath("spec", "sshKey", "publicKeyMaterial"), "public key material must be provided"))
+	}
+	return allErrs
+}
+
 func (r *AWSCluster) validateNetwork() field.ErrorList {
 	var allErrs field.ErrorList
 	if r.Spec.NetworkSpec.VPC.IsIPv6Enabled() {
 		allErrs = append(allErrs, field.Invalid(field.NewPath("ipv6"), r.Spec.NetworkSpec.VPC.IPv6, "IPv6 cannot be used with unmanaged clusters at this time."))
 	}
-	for _, subnet := range r.Spec.NetworkSpec.Subnets {
+	localGateways := make(map[string]bool, len(r.Spec.NetworkSpec.LocalGatewayRouteTables))
+	for _, lgwrt := range r.Spec.NetworkSpec.LocalGatewayRouteTables {
+		localGateways[lgwrt.OutpostARN] = true
+	}
+	for i, subnet := range r.Spec.NetworkSpec.Subnets {
+		if subnet.IsOutpost() {
+			outpostPath := field.NewPath("spec", "network", "subnets").Index(i).Child("outpostArn")
+			if subnet.IsPublic {
+				allErrs = append(allErrs, field.Invalid(outpostPath, *subnet.OutpostARN, "subnets on an AWS Outpost cannot be public"))
+			}
+			// Route tables are only managed for subnets created by the provider.
+			if !strings.HasPrefix(subnet.GetResourceID(), "subnet-") && !localGateways[*subnet.OutpostARN] {
+				allErrs = append(allErrs, field.Invalid(outpostPath, *subnet.OutpostARN, "a local gateway must be set in spec.network.localGatewayRouteTables for the subnet's outpost"))
+			}
+		}
 		if subnet.IsIPv6 || subnet.IPv6CidrBlock != "" {
 			allErrs = append(allErrs, field.Invalid(field.NewPath("subnets"), r.Spec.NetworkSpec.Subnets, "IPv6 cannot be used with unmanaged clusters at this time."))
 		}
@@ -264,6 +339,10 @@ func (r *AWSCluster) validateNetwork() field.ErrorList {
 		allErrs = append(allErrs, field.Invalid(field.NewPath("ipamPool"), r.Spec.NetworkSpec.VPC.IPAMPool, "ipamPool must have either id or name"))
 	}
 
+	if r.Spec.NetworkSpec.EgressPrefixListIncludeAPIServerLB && !r.Spec.NetworkSpec.PublishEgressPrefixList {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "network", "egressPrefixListIncludeAPIServerLB"), r.Spec.NetworkSpec.EgressPrefixListIncludeAPIServerLB, "publishEgressPrefixList must be enabled to include the API server load balancer"))
+	}
+
 	for _, rule := range r.Spec.NetworkSpec.AdditionalControlPlaneIngressRules {
 		allErrs = append(allErrs, r.validateIngressRule(rule)...)
 	}
@@ -290,6 +369,23 @@ func (r *AWSCluster) validateNetwork() field.ErrorList {
 		}
 	}
 
+	if dhcpOptions := r.Spec.NetworkSpec.VPC.DHCPOptions; dhcpOptions != nil {
+		dhcpOptionsField := field.NewPath("spec", "network", "vpc", "dhcpOptions")
+		if aws.StringValue(dhcpOptions.DomainName) == "" && len(dhcpOptions.DomainNameServers) == 0 && len(dhcpOptions.NTPServers) == 0 {
+			allErrs = append(allErrs, field.Invalid(dhcpOptionsField, dhcpOptions, "at least one of domainName, domainNameServers or ntpServers must be set"))
+		}
+		for i, server := range dhcpOptions.DomainNameServers {
+			if server != "AmazonProvidedDNS" && net.ParseIP(server) == nil {
+				allErrs = append(allErrs, field.Invalid(dhcpOptionsField.Child("domainNameServers").Index(i), server, "must be an IP address or AmazonProvidedDNS"))
+			}
+		}
+		for i, server := range dhcpOptions.NTPServers {
+			if net.ParseIP(server) == nil {
+				allErrs = append(allErrs, field.Invalid(dhcpOptionsField.Child("ntpServers").Index(i), server, "must be an IP address"))
+			}
+		}
+	}
+
 	secondaryCidrBlocks := r.Spec.NetworkSpec.VPC.SecondaryCidrBlocks
 	secondaryCidrBlocksField := field.NewPath("spec", "network", "vpc", "secondaryCidrBlocks")
 	for _, cidrBlock := range secondaryCidrBlocks {
@@ -301,6 +397,86 @@ func (r *AWSCluster) validateNetwork() field.ErrorList {
 	return allErrs
 }
 
+// validateControlPlaneLBSubnets checks the subnets selected for the control plane load balancers using the
+// availability zones given in the network spec. Subnets that aren't listed there are checked by the
+// controller once they are discovered.
+func (r *AWSCluster) validateControlPlaneLBSubnets() field.ErrorList {
+	var allErrs field.ErrorList
+
+	lbs := []struct {
+		name string
+		spec *AWSLoadBalancerSpec
+	}{
+		{name: "controlPlaneLoadBalancer", spec: r.Spec.ControlPlaneLoadBalancer},
+		{name: "secondaryControlPlaneLoadBalancer", spec: r.Spec.SecondaryControlPlaneLoadBalancer},
+	}
+	for _, lb := range lbs {
+		if lb.spec == nil || len(lb.spec.Subnets) == 0 || lb.spec.LoadBalancerType == LoadBalancerTypeDisabled {
+			continue
+		}
+
+		subnets := Subnets{}
+		allZonesKnown := true
+		for _, id := range lb.spec.Subnets {
+			subnet := r.Spec.NetworkSpec.Subnets.FindByID(id)
+			if subnet == nil || subnet.AvailabilityZone == "" {
+				allZonesKnown = false
+				continue
+			}
+			subnets = append(subnets, *subnet)
+		}
+
+		// A subnet of unknown availability zone may cover any of the control plane zones.
+		var requiredZones []string
+		if allZonesKnown {
+			requiredZones = r.Status.ControlPlaneZones()
+		}
+		if problems := subnets.ValidateLoadBalancerZones(requiredZones); len(problems) > 0 {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", lb.name, "subnets"), lb.spec.Subnets, strings.Join(problems, "; ")))
+		}
+	}
+
+	return allErrs
+}
+
+// validatePrivateOnlyVPC checks that nothing requires a public subnet when the VPC is private only.
+func (r *AWSCluster) validatePrivateOnlyVPC() field.ErrorList {
+	var allErrs field.ErrorList
+
+	if !r.Spec.NetworkSpec.VPC.PrivateOnly {
+		return allErrs
+	}
+
+	for i, subnet := range r.Spec.NetworkSpec.Subnets {
+		if subnet.IsPublic {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "network", "subnets").Index(i).Child("isPublic"), subnet.IsPublic,
+				"public subnets cannot be used when spec.network.vpc.privateOnly is set, set isPublic to false or disable privateOnly"))
+		}
+	}
+
+	lbs := []struct {
+		name string
+		spec *AWSLoadBalancerSpec
+	}{
+		{name: "controlPlaneLoadBalancer", spec: r.Spec.ControlPlaneLoadBalancer},
+		{name: "secondaryControlPlaneLoadBalancer", spec: r.Spec.SecondaryControlPlaneLoadBalancer},
+	}
+	for _, lb := range lbs {
+		if lb.spec == nil || lb.spec.LoadBalancerType == LoadBalancerTypeDisabled || !ELBSchemeInternetFacing.Equals(lb.spec.Scheme) {
+			continue
+		}
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", lb.name, "scheme"), lb.spec.Scheme,
+			fmt.Sprintf("internet-facing load balancers cannot be used when spec.network.vpc.privateOnly is set, set spec.%s.scheme to internal or disable privateOnly", lb.name)))
+	}
+
+	if r.Spec.Bastion.Enabled {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "bastion", "enabled"), r.Spec.Bastion.Enabled,
+			"the bastion host requires a public subnet and cannot be enabled when spec.network.vpc.privateOnly is set, reach the instances through a VPN or AWS Systems Manager instead"))
+	}
+
+	return allErrs
+}
+
 func (r *AWSCluster) validateControlPlaneLBs() field.ErrorList {
 	var allErrs field.ErrorList
 
@@ -365,8 +541,10 @@ func (r *AWSCluster) validateControlPlaneLBs() field.ErrorList {
 			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "controlPlaneLoadBalancer", "additionalListeners"), r.Spec.ControlPlaneLoadBalancer.AdditionalListeners, "cannot set additional listeners if the LoadBalancer reconciliation is disabled"))
 		}
 
-		if len(r.Spec.ControlPlaneLoadBalancer.IngressRules) > 0 {
-			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "controlPlaneLoadBalancer", "ingressRules"), r.Spec.ControlPlaneLoadBalancer.IngressRules, "ingress rules cannot be set if the LoadBalancer reconciliation is disabled"))
+		// An endpoint provided by an external load balancer is either set upfront, or set at once later on by its
+		// provider, an endpoint with only a host or a port is a mistake.
+		if endpoint := r.Spec.ControlPlaneEndpoint; (endpoint.Host == "") != (endpoint.Port == 0) {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "controlPlaneEndpoint"), endpoint, "both the host and the port of the control plane endpoint must be set if the LoadBalancer reconciliation is disabled"))
 		}
 
 		if r.Spec.ControlPlaneLoadBalancer.PreserveClientIP {
@@ -394,3 +572,12 @@ func (r *AWSCluster) validateIngressRule(rule IngressRule) field.ErrorList {
 	}
 	return allErrs
 }
+
+// ValidateUpdate validates an update of the node termination handling configuration of a cluster.
+func (n *NodeTerminationHandling) ValidateUpdate(old *NodeTerminationHandling, fldPath *field.Path) field.ErrorList {
+	// Renaming the queue while it is in use would leave the node termination handler polling the old one.
+	if old != nil && old.Enabled && n != nil && n.Enabled && old.QueueName != n.QueueName {
+		return field.ErrorList{field.Invalid(fldPath.Child("queueName"), n.QueueName, "field is immutable while node termination handling is enabled")}
+	}
+	return nil
+}
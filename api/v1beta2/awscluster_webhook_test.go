@@ -52,6 +52,98 @@ func TestAWSClusterValidateCreate(t *testing.T) {
 		wantErr bool
 		expect  func(g *WithT, res *AWSLoadBalancerSpec)
 	}{
+		{
+			name: "ownership tag prefix with invalid characters is rejected",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					OwnershipTagPrefix: "example.com/cluster*",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid ownership tag prefix is accepted",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					OwnershipTagPrefix: "example.com/cluster/",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "sshKey is accepted",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					SSHKey: &SSHKey{Name: "my-cluster", PublicKeyMaterial: "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIC9z"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "sshKey and sshKeyName are mutually exclusive",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					SSHKeyName: aws.String("existing-key"),
+					SSHKey:     &SSHKey{Name: "my-cluster", PublicKeyMaterial: "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIC9z"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "nodeRoleManagement with policy ARNs is accepted",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					NodeRoleManagement: &NodeRoleManagement{
+						Create:              true,
+						PermissionsBoundary: "arn:aws:iam::123456789012:policy/boundary",
+						AdditionalPolicies:  []string{"arn:aws:iam::aws:policy/CloudWatchAgentServerPolicy"},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "nodeRoleManagement additional policies must be policy ARNs",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					NodeRoleManagement: &NodeRoleManagement{
+						Create:             true,
+						AdditionalPolicies: []string{"arn:aws:iam::123456789012:role/my-role"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "costSavings schedule is accepted",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					CostSavings: &CostSavings{
+						Schedule: &CostSavingsSchedule{
+							Suspend:   "0 20 * * 1-5",
+							Resume:    "0 7 * * 1-5",
+							Resources: []CostSavingsResource{CostSavingsResourceBastion, CostSavingsResourceNatGateways},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "costSavings schedule must be a cron expression",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					CostSavings: &CostSavings{
+						Schedule: &CostSavingsSchedule{
+							Suspend:   "every evening",
+							Resume:    "0 7 * * 1-5",
+							Resources: []CostSavingsResource{CostSavingsResourceNatGateways},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "No options are allowed when LoadBalancer is disabled (name)",
 			cluster: &AWSCluster{
@@ -130,7 +222,7 @@ func TestAWSClusterValidateCreate(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name: "No options are allowed when LoadBalancer is disabled (ingressRules)",
+			name: "Ingress rules are allowed when LoadBalancer is disabled",
 			cluster: &AWSCluster{
 				Spec: AWSClusterSpec{
 					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
@@ -140,14 +232,39 @@ func TestAWSClusterValidateCreate(t *testing.T) {
 								Protocol:    SecurityGroupProtocolTCP,
 								FromPort:    6443,
 								ToPort:      6443,
+								CidrBlocks:  []string{"10.0.0.0/8"},
 							},
 						},
 						LoadBalancerType: LoadBalancerTypeDisabled,
 					},
 				},
 			},
+			wantErr: false,
+		},
+		{
+			name: "The control plane endpoint must be complete when LoadBalancer is disabled",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneEndpoint: clusterv1.APIEndpoint{Host: "api.example.com"},
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeDisabled,
+					},
+				},
+			},
 			wantErr: true,
 		},
+		{
+			name: "A control plane endpoint can be set when LoadBalancer is disabled",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneEndpoint: clusterv1.APIEndpoint{Host: "api.example.com", Port: 6443},
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeDisabled,
+					},
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "No options are allowed when LoadBalancer is disabled (disableHostsRewrite)",
 			cluster: &AWSCluster{
@@ -172,6 +289,42 @@ func TestAWSClusterValidateCreate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "controlPlaneLoadBalancer subnets must not share an availability zone",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeNLB,
+						Subnets:          []string{"subnet-1", "subnet-2"},
+					},
+					NetworkSpec: NetworkSpec{
+						Subnets: Subnets{
+							{ID: "subnet-1", AvailabilityZone: "us-east-1a", IsPublic: true},
+							{ID: "subnet-2", AvailabilityZone: "us-east-1a", IsPublic: true},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "controlPlaneLoadBalancer subnets in distinct or unknown availability zones are accepted",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeNLB,
+						Subnets:          []string{"subnet-1", "subnet-2", "subnet-3"},
+					},
+					NetworkSpec: NetworkSpec{
+						Subnets: Subnets{
+							{ID: "subnet-1", AvailabilityZone: "us-east-1a", IsPublic: true},
+							{ID: "subnet-2", AvailabilityZone: "us-east-1b", IsPublic: true},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
 		// The SSHKeyName tests were moved to sshkeyname_test.go
 		{
 			name: "Supported schemes are 'internet-facing, Internet-facing, internal, or nil', rest will be rejected",
@@ -539,6 +692,29 @@ func TestAWSClusterValidateCreate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "accepts egress prefix list with the API server load balancer",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					NetworkSpec: NetworkSpec{
+						PublishEgressPrefixList:            true,
+						EgressPrefixListIncludeAPIServerLB: true,
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "rejects the API server load balancer without egress prefix list",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					NetworkSpec: NetworkSpec{
+						EgressPrefixListIncludeAPIServerLB: true,
+					},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "rejects cidrBlock and ipamPool if set together",
 			cluster: &AWSCluster{
@@ -660,6 +836,178 @@ func TestAWSClusterValidateCreate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "accepts outpost subnet with a local gateway",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					NetworkSpec: NetworkSpec{
+						Subnets: Subnets{
+							{
+								ID:         "outpost-subnet",
+								CidrBlock:  "10.0.10.0/24",
+								OutpostARN: aws.String("arn:aws:outposts:us-east-1:123456789012:outpost/op-0123456789abcdef0"),
+							},
+						},
+						LocalGatewayRouteTables: []LocalGatewayRouteTable{
+							{
+								OutpostARN:     "arn:aws:outposts:us-east-1:123456789012:outpost/op-0123456789abcdef0",
+								LocalGatewayID: "lgw-0123456789abcdef0",
+							},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "rejects managed outpost subnet without a local gateway",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					NetworkSpec: NetworkSpec{
+						Subnets: Subnets{
+							{
+								ID:         "outpost-subnet",
+								CidrBlock:  "10.0.10.0/24",
+								OutpostARN: aws.String("arn:aws:outposts:us-east-1:123456789012:outpost/op-0123456789abcdef0"),
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "rejects public outpost subnet",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					NetworkSpec: NetworkSpec{
+						Subnets: Subnets{
+							{
+								ID:         "subnet-0123456789abcdef0",
+								IsPublic:   true,
+								OutpostARN: aws.String("arn:aws:outposts:us-east-1:123456789012:outpost/op-0123456789abcdef0"),
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "accepts DHCP options with custom name servers",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					NetworkSpec: NetworkSpec{
+						VPC: VPCSpec{
+							DHCPOptions: &DHCPOptions{
+								DomainName:        aws.String("corp.example.com"),
+								DomainNameServers: []string{"10.0.0.2", "AmazonProvidedDNS"},
+								NTPServers:        []string{"169.254.169.123"},
+							},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "rejects empty DHCP options",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					NetworkSpec: NetworkSpec{
+						VPC: VPCSpec{
+							DHCPOptions: &DHCPOptions{},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "rejects DHCP options with an invalid name server",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					NetworkSpec: NetworkSpec{
+						VPC: VPCSpec{
+							DHCPOptions: &DHCPOptions{
+								DomainNameServers: []string{"dns.corp.example.com"},
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "defaults the control plane load balancer of a private only VPC to internal",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					NetworkSpec: NetworkSpec{
+						VPC: VPCSpec{
+							PrivateOnly: true,
+						},
+					},
+				},
+			},
+			wantErr: false,
+			expect: func(g *WithT, res *AWSLoadBalancerSpec) {
+				g.Expect(res.Scheme).To(Equal(&ELBSchemeInternal))
+			},
+		},
+		{
+			name: "rejects an internet-facing control plane load balancer in a private only VPC",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						Scheme: &ELBSchemeInternetFacing,
+					},
+					NetworkSpec: NetworkSpec{
+						VPC: VPCSpec{
+							PrivateOnly: true,
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "rejects public subnets in a private only VPC",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						Scheme: &ELBSchemeInternal,
+					},
+					NetworkSpec: NetworkSpec{
+						VPC: VPCSpec{
+							PrivateOnly: true,
+						},
+						Subnets: Subnets{
+							{ID: "public-subnet", AvailabilityZone: "us-east-1a", CidrBlock: "10.0.0.0/24", IsPublic: true},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "rejects a bastion host in a private only VPC",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						Scheme: &ELBSchemeInternal,
+					},
+					NetworkSpec: NetworkSpec{
+						VPC: VPCSpec{
+							PrivateOnly: true,
+						},
+					},
+					Bastion: Bastion{
+						Enabled: true,
+					},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1030,6 +1378,62 @@ func TestAWSClusterValidateUpdate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "sshKey is immutable",
+			oldCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					SSHKey: &SSHKey{Name: "my-cluster", PublicKeyMaterial: "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIC9z"},
+				},
+			},
+			newCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					SSHKey: &SSHKey{Name: "my-cluster", PublicKeyMaterial: "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIOther"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "nodeTerminationHandling queueName is immutable while enabled",
+			oldCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					NodeTerminationHandling: &NodeTerminationHandling{Enabled: true, QueueName: "my-queue"},
+				},
+			},
+			newCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					NodeTerminationHandling: &NodeTerminationHandling{Enabled: true, QueueName: "other-queue"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "nodeTerminationHandling queueName can be changed while disabled",
+			oldCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					NodeTerminationHandling: &NodeTerminationHandling{Enabled: false, QueueName: "my-queue"},
+				},
+			},
+			newCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					NodeTerminationHandling: &NodeTerminationHandling{Enabled: true, QueueName: "other-queue"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "nodeRoleManagement namePrefix is immutable while the node role is created",
+			oldCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					NodeRoleManagement: &NodeRoleManagement{Create: true, NamePrefix: "my-cluster"},
+				},
+			},
+			newCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					NodeRoleManagement: &NodeRoleManagement{Create: true, NamePrefix: "other-cluster"},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "empty GC tasks annotation",
 			oldCluster: &AWSCluster{
@@ -1058,6 +1462,22 @@ func TestAWSClusterValidateUpdate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "private only VPC is immutable",
+			oldCluster: &AWSCluster{
+				Spec: AWSClusterSpec{},
+			},
+			newCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					NetworkSpec: NetworkSpec{
+						VPC: VPCSpec{
+							PrivateOnly: true,
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -31,6 +31,11 @@ type AWSClusterIdentitySpec struct {
 	// +optional
 	// +nullable
 	AllowedNamespaces *AllowedNamespaces `json:"allowedNamespaces"`
+
+	// AllowedRegions is the list of regions the clusters using the identity can be created in.
+	// An empty list allows any region.
+	// +optional
+	AllowedRegions []string `json:"allowedRegions,omitempty"`
 }
 
 // AllowedNamespaces is a selector of namespaces that AWSClusters can
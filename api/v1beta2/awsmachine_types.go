@@ -173,6 +173,14 @@ type AWSMachineSpec struct {
 	// +optional
 	SpotMarketOptions *SpotMarketOptions `json:"spotMarketOptions,omitempty"`
 
+	// MarketType is the purchasing option of the instance. on-demand forbids spotMarketOptions, spot launches
+	// a spot instance even without spotMarketOptions, and capacity-block launches the instance in the Capacity
+	// Block for ML set with capacityReservationId. Defaults to spot when spotMarketOptions is set, to on-demand
+	// otherwise.
+	// +kubebuilder:validation:Enum:=on-demand;spot;capacity-block
+	// +optional
+	MarketType MarketType `json:"marketType,omitempty"`
+
 	// PlacementGroupName specifies the name of the placement group in which to launch the instance.
 	// +optional
 	PlacementGroupName string `json:"placementGroupName,omitempty"`
@@ -197,6 +205,11 @@ type AWSMachineSpec struct {
 	// CapacityReservationID specifies the target Capacity Reservation into which the instance should be launched.
 	// +optional
 	CapacityReservationID *string `json:"capacityReservationId,omitempty"`
+
+	// CapacityReservationResourceGroupARN is the ARN of the Capacity Reservation resource group into which the
+	// instance should be launched. It can't be combined with CapacityReservationID.
+	// +optional
+	CapacityReservationResourceGroupARN *string `json:"capacityReservationResourceGroupArn,omitempty"`
 }
 
 // CloudInit defines options related to the bootstrapping systems where
@@ -66,6 +66,8 @@ func (r *AWSMachine) ValidateCreate() (admission.Warnings, error) {
 	allErrs = append(allErrs, r.validateAdditionalSecurityGroups()...)
 	allErrs = append(allErrs, r.Spec.AdditionalTags.Validate()...)
 	allErrs = append(allErrs, r.validateNetworkElasticIPPool()...)
+	allErrs = append(allErrs, r.validateAMI()...)
+	allErrs = append(allErrs, r.validateMarketType()...)
 
 	return nil, aggregateObjErrors(r.GroupVersionKind().GroupKind(), r.Name, allErrs)
 }
@@ -333,6 +335,8 @@ func (r *AWSMachine) validateRootVolume() field.ErrorList {
 		log.Info("root volume shouldn't have a device name (this can be ignored if performing a `clusterctl move`)")
 	}
 
+	allErrs = append(allErrs, r.Spec.RootVolume.ValidateSnapshot(field.NewPath("spec", "rootVolume"), true)...)
+
 	return allErrs
 }
 
@@ -364,11 +368,13 @@ func (r *AWSMachine) validateNetworkElasticIPPool() field.ErrorList {
 func (r *AWSMachine) validateNonRootVolumes() field.ErrorList {
 	var allErrs field.ErrorList
 
-	for _, volume := range r.Spec.NonRootVolumes {
+	for i, volume := range r.Spec.NonRootVolumes {
 		if VolumeTypesProvisioned.Has(string(volume.Type)) && volume.IOPS == 0 {
 			allErrs = append(allErrs, field.Required(field.NewPath("spec.nonRootVolumes.iops"), "iops required if type is 'io1' or 'io2'"))
 		}
 
+		allErrs = append(allErrs, volume.ValidateSnapshot(field.NewPath("spec", "nonRootVolumes").Index(i), false)...)
+
 		if volume.Throughput != nil {
 			if volume.Type != VolumeTypeGP3 {
 				allErrs = append(allErrs, field.Required(field.NewPath("spec.nonRootVolumes.throughput"), "throughput is valid only for type 'gp3'"))
@@ -418,6 +424,40 @@ func (r *AWSMachine) validateAdditionalSecurityGroups() field.ErrorList {
 	return allErrs
 }
 
+func (r *AWSMachine) validateAMI() field.ErrorList {
+	return validateMachineAMI(r.Spec.AMI, field.NewPath("spec", "ami"))
+}
+
+// validateMachineAMI rejects the AMI fields only supported by the launch templates of machine pools.
+func validateMachineAMI(ami AMIReference, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if ami.SourceRegion != "" {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("sourceRegion"), "sourceRegion is only supported by machine pools"))
+	}
+	if ami.CopyEncryptionKey != "" {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("copyEncryptionKey"), "copyEncryptionKey is only supported by machine pools"))
+	}
+	if ami.GPUCompatible != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("gpuCompatible"), "gpuCompatible is only supported by machine pools"))
+	}
+	return allErrs
+}
+
+func (r *AWSMachine) validateMarketType() field.ErrorList {
+	return validateMachineMarketType(r.Spec, field.NewPath("spec"))
+}
+
+// validateMachineMarketType checks the market type and the capacity reservation target of a machine spec, and that
+// its instance type can be launched in a capacity block.
+func validateMachineMarketType(spec AWSMachineSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := ValidateMarketType(spec.MarketType, spec.SpotMarketOptions, spec.CapacityReservationID, fldPath)
+	allErrs = append(allErrs, ValidateCapacityReservationTarget(spec.CapacityReservationID, spec.CapacityReservationResourceGroupARN, fldPath)...)
+	if spec.MarketType == MarketTypeCapacityBlock {
+		allErrs = append(allErrs, ValidateCapacityBlockInstanceType(spec.InstanceType, fldPath.Child("instanceType"))...)
+	}
+	return allErrs
+}
+
 func (r *AWSMachine) validateSSHKeyName() field.ErrorList {
 	return validateSSHKeyName(r.Spec.SSHKeyName)
 }
@@ -137,6 +137,53 @@ func TestAWSMachineCreate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "allow non root volume created from a snapshot",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					NonRootVolumes: []Volume{
+						{
+							DeviceName:          "name",
+							Size:                10,
+							SnapshotID:          aws.String("snap-0123456789abcdef0"),
+							DeleteOnTermination: aws.Bool(false),
+						},
+					},
+					InstanceType: "test",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "ensure non root volume created from a snapshot doesn't turn off encryption",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					NonRootVolumes: []Volume{
+						{
+							DeviceName: "name",
+							Size:       10,
+							SnapshotID: aws.String("snap-0123456789abcdef0"),
+							Encrypted:  aws.Bool(false),
+						},
+					},
+					InstanceType: "test",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "ensure root volume isn't created from a snapshot",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					RootVolume: &Volume{
+						Size:       10,
+						SnapshotID: aws.String("snap-0123456789abcdef0"),
+					},
+					InstanceType: "test",
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "ensure non root volume throughput is nonnegative",
 			machine: &AWSMachine{
@@ -368,6 +415,32 @@ func TestAWSMachineCreate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "error when the AMI sets a source region",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType: "type",
+					AMI: AMIReference{
+						ID:           aws.String("ami-source"),
+						SourceRegion: "eu-west-1",
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "error when the AMI declares its GPU compatibility",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType: "type",
+					AMI: AMIReference{
+						ID:            aws.String("ami-gpu"),
+						GPUCompatible: aws.Bool(true),
+					},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "create with valid BYOIPv4",
 			machine: &AWSMachine{
@@ -436,6 +509,71 @@ func TestAWSMachineCreate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "capacity block with a supported instance type is accepted",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType:          "p5.48xlarge",
+					MarketType:            MarketTypeCapacityBlock,
+					CapacityReservationID: aws.String("cr-0123456789abcdef0"),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "error when capacity block without capacity reservation",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType: "p5.48xlarge",
+					MarketType:   MarketTypeCapacityBlock,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "error when capacity block with spot market options",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType:          "p5.48xlarge",
+					MarketType:            MarketTypeCapacityBlock,
+					CapacityReservationID: aws.String("cr-0123456789abcdef0"),
+					SpotMarketOptions:     &SpotMarketOptions{},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "error when capacity block with an unsupported instance type",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType:          "m5.large",
+					MarketType:            MarketTypeCapacityBlock,
+					CapacityReservationID: aws.String("cr-0123456789abcdef0"),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "capacity reservation resource group is accepted",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType:                        "m5.large",
+					CapacityReservationResourceGroupARN: aws.String("arn:aws:resource-groups:us-east-1:123456789012:group/reservations"),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "error when both a capacity reservation and a capacity reservation resource group are set",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType:                        "m5.large",
+					CapacityReservationID:               aws.String("cr-0123456789abcdef0"),
+					CapacityReservationResourceGroupARN: aws.String("arn:aws:resource-groups:us-east-1:123456789012:group/reservations"),
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -73,6 +73,8 @@ func (r *AWSMachineTemplate) validateRootVolume() field.ErrorList {
 		log.Info("root volume shouldn't have a device name (this can be ignored if performing a `clusterctl move`)")
 	}
 
+	allErrs = append(allErrs, spec.RootVolume.ValidateSnapshot(field.NewPath("spec", "template", "spec", "rootVolume"), true)...)
+
 	return allErrs
 }
 
@@ -81,11 +83,13 @@ func (r *AWSMachineTemplate) validateNonRootVolumes() field.ErrorList {
 
 	spec := r.Spec.Template.Spec
 
-	for _, volume := range spec.NonRootVolumes {
+	for i, volume := range spec.NonRootVolumes {
 		if VolumeTypesProvisioned.Has(string(volume.Type)) && volume.IOPS == 0 {
 			allErrs = append(allErrs, field.Required(field.NewPath("spec.template.spec.nonRootVolumes.iops"), "iops required if type is 'io1' or 'io2'"))
 		}
 
+		allErrs = append(allErrs, volume.ValidateSnapshot(field.NewPath("spec", "template", "spec", "nonRootVolumes").Index(i), false)...)
+
 		if volume.Throughput != nil {
 			if volume.Type != VolumeTypeGP3 {
 				allErrs = append(allErrs, field.Required(field.NewPath("spec.template.spec.nonRootVolumes.throughput"), "throughput is valid only for type 'gp3'"))
@@ -171,6 +175,14 @@ func (r *AWSMachineTemplate) validateIgnitionAndCloudInit() field.ErrorList {
 
 	return allErrs
 }
+func (r *AWSMachineTemplate) validateAMI() field.ErrorList {
+	return validateMachineAMI(r.Spec.Template.Spec.AMI, field.NewPath("spec", "template", "spec", "ami"))
+}
+
+func (r *AWSMachineTemplate) validateMarketType() field.ErrorList {
+	return validateMachineMarketType(r.Spec.Template.Spec, field.NewPath("spec", "template", "spec"))
+}
+
 func (r *AWSMachineTemplate) validateSSHKeyName() field.ErrorList {
 	return validateSSHKeyName(r.Spec.Template.Spec.SSHKeyName)
 }
@@ -203,6 +215,8 @@ func (r *AWSMachineTemplateWebhook) ValidateCreate(_ context.Context, raw runtim
 	allErrs = append(allErrs, obj.validateNonRootVolumes()...)
 	allErrs = append(allErrs, obj.validateSSHKeyName()...)
 	allErrs = append(allErrs, obj.validateAdditionalSecurityGroups()...)
+	allErrs = append(allErrs, obj.validateAMI()...)
+	allErrs = append(allErrs, obj.validateMarketType()...)
 	allErrs = append(allErrs, obj.Spec.Template.Spec.AdditionalTags.Validate()...)
 
 	return nil, aggregateObjErrors(obj.GroupVersionKind().GroupKind(), obj.Name, allErrs)
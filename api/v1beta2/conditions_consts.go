@@ -31,9 +31,18 @@ const (
 	PrincipalUsageAllowedCondition clusterv1.ConditionType = "PrincipalUsageAllowed"
 	// PrincipalUsageUnauthorizedReason used when AWSCluster namespace is not in the identity's allowed namespaces list.
 	PrincipalUsageUnauthorizedReason = "PrincipalUsageUnauthorized"
+	// PrincipalRegionNotAllowedReason used when the region of the AWSCluster is not in the identity's allowed regions list.
+	PrincipalRegionNotAllowedReason = "PrincipalRegionNotAllowed"
 	// SourcePrincipalUsageUnauthorizedReason used when AWSCluster is not in the intersection of source identity allowed namespaces
 	// and allowed namespaces of the identities that source identity depends to.
 	SourcePrincipalUsageUnauthorizedReason = "SourcePrincipalUsageUnauthorized"
+	// IdentityResolvedCondition reports on whether the credentials of the identity of the cluster, including
+	// all the roles it chains, could be retrieved at the start of the last reconciliation. It doesn't count
+	// towards the readiness of the cluster.
+	IdentityResolvedCondition clusterv1.ConditionType = "IdentityResolved"
+	// IdentityResolutionFailedReason used when the identity of the cluster could not be resolved. The message tells
+	// the role which could not be assumed and the AWS error code.
+	IdentityResolutionFailedReason = "IdentityResolutionFailed"
 )
 
 const (
@@ -44,6 +53,8 @@ const (
 	VpcCreationStartedReason = "VpcCreationStarted"
 	// VpcReconciliationFailedReason used when errors occur during VPC reconciliation.
 	VpcReconciliationFailedReason = "VpcReconciliationFailed"
+	// DHCPOptionsReconciliationFailedReason used when errors occur during reconciliation of the DHCP options of a managed VPC.
+	DHCPOptionsReconciliationFailedReason = "DHCPOptionsReconciliationFailed"
 )
 
 const (
@@ -85,6 +96,10 @@ const (
 	NatGatewaysCreationStartedReason = "NatGatewaysCreationStarted"
 	// NatGatewaysReconciliationFailedReason used when any errors occur during reconciliation of NAT gateways.
 	NatGatewaysReconciliationFailedReason = "NatGatewaysReconciliationFailed"
+	// NatGatewaysSuspendedReason used when the NAT gateways are deleted by the cost savings schedule.
+	NatGatewaysSuspendedReason = "NatGatewaysSuspended"
+	// EgressPrefixListReconciliationFailedReason used when any errors occur during reconciliation of the egress prefix list.
+	EgressPrefixListReconciliationFailedReason = "EgressPrefixListReconciliationFailed"
 )
 
 const (
@@ -126,6 +141,20 @@ const (
 	BastionCreationStartedReason = "BastionCreationStarted"
 	// BastionHostFailedReason used when an error occurs during the creation of a bastion host.
 	BastionHostFailedReason = "BastionHostFailed"
+	// BastionHostSuspendedReason used when the bastion host is terminated by the cost savings schedule.
+	BastionHostSuspendedReason = "BastionHostSuspended"
+)
+
+const (
+	// SSHKeyPairReadyCondition reports whether the SSH key pair managed by the provider is in place and
+	// unchanged since it was imported. The condition is only set for clusters using spec.sshKey.
+	SSHKeyPairReadyCondition clusterv1.ConditionType = "SSHKeyPairReady"
+	// SSHKeyPairFailedReason used when an error occurs during reconciliation of the SSH key pair.
+	SSHKeyPairFailedReason = "SSHKeyPairFailed"
+	// SSHKeyPairNotOwnedReason used when a key pair with the requested name exists but wasn't created by the provider.
+	SSHKeyPairNotOwnedReason = "SSHKeyPairNotOwned"
+	// SSHKeyPairDriftedReason used when the fingerprint of the key pair no longer matches the one recorded at import.
+	SSHKeyPairDriftedReason = "SSHKeyPairDrifted"
 )
 
 const (
@@ -140,6 +169,9 @@ const (
 	WaitForDNSNameResolveReason = "WaitForDNSNameResolve"
 	// LoadBalancerFailedReason used when an error occurs during load balancer reconciliation.
 	LoadBalancerFailedReason = "LoadBalancerFailed"
+	// LoadBalancerSubnetInvalidReason used when the subnets selected for a load balancer share an availability zone
+	// or don't cover the availability zones of the control plane machines.
+	LoadBalancerSubnetInvalidReason = "LoadBalancerSubnetInvalid"
 )
 
 const (
@@ -164,6 +196,27 @@ const (
 	WaitingForBootstrapDataReason = "WaitingForBootstrapData"
 )
 
+const (
+	// ReconciliationSkippedCondition is set while a controller skips reconciling an object, e.g. because the object
+	// is paused, in a failed state, or still waiting on a dependency. It is removed once reconciliation resumes.
+	ReconciliationSkippedCondition clusterv1.ConditionType = "ReconciliationSkipped"
+
+	// ReconciliationPausedReason used when the object or its cluster is paused.
+	ReconciliationPausedReason = "Paused"
+	// FailedStateReason used when the object is in a failed state that requires manual intervention.
+	FailedStateReason = "FailedState"
+)
+
+const (
+	// DriftDetectedCondition is set when the instance drift audit finds live attributes of the instances of an
+	// AWSMachine or AWSMachinePool that differ from the values applied by the controller. It is removed once an
+	// audit finds no difference.
+	DriftDetectedCondition clusterv1.ConditionType = "DriftDetected"
+
+	// InstanceDriftDetectedReason used when attributes of an instance were changed outside of the controller.
+	InstanceDriftDetectedReason = "InstanceDriftDetected"
+)
+
 const (
 	// SecurityGroupsReadyCondition indicates the security groups are up to date on the AWSMachine.
 	SecurityGroupsReadyCondition clusterv1.ConditionType = "SecurityGroupsReady"
@@ -183,6 +236,11 @@ const (
 	ELBAttachFailedReason = "ELBAttachFailed"
 	// ELBDetachFailedReason used when a control plane node fails to detach from an ELB.
 	ELBDetachFailedReason = "ELBDetachFailed"
+	// ELBTargetUnhealthyReason used when a control plane node is registered with the API server target group,
+	// but its target isn't healthy yet.
+	ELBTargetUnhealthyReason = "ELBTargetUnhealthy"
+	// ELBTargetHealthTimeoutReason used when the target of a control plane node stays unhealthy for too long.
+	ELBTargetHealthTimeoutReason = "ELBTargetHealthTimeout"
 )
 
 const (
@@ -192,3 +250,80 @@ const (
 	// S3BucketFailedReason is used when any errors occur during reconciliation of an S3 bucket.
 	S3BucketFailedReason = "S3BucketCreationFailed"
 )
+
+const (
+	// ControllerPermissionsCondition reports whether the principal used by the controllers for the cluster
+	// is allowed to perform the actions required by the enabled features. It is only set when the
+	// permission precheck is enabled.
+	ControllerPermissionsCondition clusterv1.ConditionType = "ControllerPermissions"
+
+	// MissingControllerPermissionsReason used when the policy simulation denies actions required by the controllers.
+	MissingControllerPermissionsReason = "MissingControllerPermissions"
+	// ControllerPermissionsCheckFailedReason used when the permissions of the principal could not be simulated.
+	ControllerPermissionsCheckFailedReason = "ControllerPermissionsCheckFailed"
+)
+
+const (
+	// AccountQuotasCondition reports whether the resources of the cluster waiting to be created fit in the quotas
+	// of its AWS account and region. It is only set when the account quota check is enabled.
+	AccountQuotasCondition clusterv1.ConditionType = "AccountQuotas"
+
+	// AccountQuotaExceededReason used when creating the pending resources of the cluster would exceed a quota.
+	AccountQuotaExceededReason = "AccountQuotaExceeded"
+	// AccountQuotasCheckFailedReason used when the quotas or the usage of the account could not be fetched.
+	AccountQuotasCheckFailedReason = "AccountQuotasCheckFailed"
+)
+
+const (
+	// APIEndpointReachableCondition reports whether the API server endpoint of the cluster accepts TLS connections
+	// from the management cluster, with the latency of the last probe. It is only set when endpoint probing is enabled.
+	APIEndpointReachableCondition clusterv1.ConditionType = "APIEndpointReachable"
+
+	// APIEndpointUnreachableReason used when the API server endpoint could not be reached from the management cluster.
+	APIEndpointUnreachableReason = "APIEndpointUnreachable"
+)
+
+const (
+	// VolumeEncryptionCompliantCondition reports whether the root volumes of all the instances of the cluster are
+	// encrypted. It is only set when the volume encryption report is enabled.
+	VolumeEncryptionCompliantCondition clusterv1.ConditionType = "VolumeEncryptionCompliant"
+
+	// UnencryptedVolumesReason used when root volumes of instances of the cluster are not encrypted.
+	UnencryptedVolumesReason = "UnencryptedVolumes"
+	// VolumeEncryptionCheckFailedReason used when the root volumes of the cluster could not be described.
+	VolumeEncryptionCheckFailedReason = "VolumeEncryptionCheckFailed"
+)
+
+const (
+	// ResourcesSuspendedCondition reports whether resources of the cluster are suspended by the cost savings
+	// schedule. It is only set when a schedule is configured.
+	ResourcesSuspendedCondition clusterv1.ConditionType = "ResourcesSuspended"
+
+	// ResourcesResumedReason used when the resources suspended by the cost savings schedule are resumed.
+	ResourcesResumedReason = "ResourcesResumed"
+	// SuspensionDeferredReason used when the suspension of resources is deferred while machines of the cluster
+	// are provisioning, as they may need the NAT gateways to bootstrap.
+	SuspensionDeferredReason = "SuspensionDeferred"
+	// CostSavingsScheduleFailedReason used when the cost savings schedule could not be evaluated.
+	CostSavingsScheduleFailedReason = "CostSavingsScheduleFailed"
+)
+
+const (
+	// PendingChangesCondition reports the mutating requests refused while the cluster is in read-only mode, which
+	// would have been sent to AWS otherwise. It is only set while the ReadOnlyAnnotation is set.
+	PendingChangesCondition clusterv1.ConditionType = "PendingChanges"
+
+	// ReadOnlyModeReason used when mutating requests are refused as the cluster is in read-only mode.
+	ReadOnlyModeReason = "ReadOnlyMode"
+)
+
+const (
+	// NodeRoleReadyCondition reports whether the IAM role and instance profile of the nodes are created, or removed
+	// when they aren't created anymore. It is only set when the node role is managed.
+	NodeRoleReadyCondition clusterv1.ConditionType = "NodeRoleReady"
+
+	// NodeRoleReconciliationFailedReason used when the node role or instance profile could not be reconciled.
+	NodeRoleReconciliationFailedReason = "NodeRoleReconciliationFailed"
+	// NodeRoleInUseReason used when the node instance profile can't be removed yet, as instances still use it.
+	NodeRoleInUseReason = "NodeRoleInUse"
+)
@@ -0,0 +1,61 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cron"
+)
+
+// Validate validates CostSavings fields.
+func (c *CostSavings) Validate(fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	if c == nil || c.Schedule == nil {
+		return errs
+	}
+
+	schedulePath := fldPath.Child("schedule")
+	if _, err := cron.Parse(c.Schedule.Suspend); err != nil {
+		errs = append(errs, field.Invalid(schedulePath.Child("suspend"), c.Schedule.Suspend, err.Error()))
+	}
+	if _, err := cron.Parse(c.Schedule.Resume); err != nil {
+		errs = append(errs, field.Invalid(schedulePath.Child("resume"), c.Schedule.Resume, err.Error()))
+	}
+	if c.Schedule.Suspend == c.Schedule.Resume {
+		errs = append(errs, field.Invalid(schedulePath.Child("resume"), c.Schedule.Resume, "must differ from the suspend schedule"))
+	}
+	if len(c.Schedule.Resources) == 0 {
+		errs = append(errs, field.Required(schedulePath.Child("resources"), "at least one resource must be suspended"))
+	}
+
+	return errs
+}
+
+// Suspended returns whether the resource is suspended.
+func (s *CostSavingsStatus) Suspended(resource CostSavingsResource) bool {
+	if s == nil {
+		return false
+	}
+	for _, r := range s.SuspendedResources {
+		if r == resource {
+			return true
+		}
+	}
+	return false
+}
@@ -65,6 +65,10 @@ func SetDefaults_AWSClusterSpec(s *AWSClusterSpec) { //nolint:golint,stylecheck
 		s.ControlPlaneLoadBalancer = &AWSLoadBalancerSpec{
 			Scheme: &ELBSchemeInternetFacing,
 		}
+		// A private only VPC has no public subnets to place an internet-facing load balancer in.
+		if s.NetworkSpec.VPC.PrivateOnly {
+			s.ControlPlaneLoadBalancer.Scheme = &ELBSchemeInternal
+		}
 	}
 	if s.ControlPlaneLoadBalancer.LoadBalancerType == "" {
 		s.ControlPlaneLoadBalancer.LoadBalancerType = LoadBalancerTypeClassic
@@ -0,0 +1,84 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// GetPermissionsBoundary returns the permissions boundary of the role management options, or an empty string
+// when no options are set.
+func (m *IAMRoleManagement) GetPermissionsBoundary() string {
+	if m == nil {
+		return ""
+	}
+	return m.PermissionsBoundary
+}
+
+// Validate validates IAMRoleManagement fields.
+func (m *IAMRoleManagement) Validate(fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	if m == nil {
+		return errs
+	}
+
+	if m.PermissionsBoundary != "" && !isPolicyARN(m.PermissionsBoundary) {
+		errs = append(errs, field.Invalid(fldPath.Child("permissionsBoundary"), m.PermissionsBoundary, "must be the ARN of an IAM policy"))
+	}
+
+	for i, statement := range m.AdditionalTrustStatements {
+		statementPath := fldPath.Child("additionalTrustStatements").Index(i)
+		// Trust policies only allow the actions of STS.
+		for j, action := range statement.Actions {
+			if !strings.HasPrefix(action, "sts:") {
+				errs = append(errs, field.Invalid(statementPath.Child("actions").Index(j), action, "must be an sts action"))
+			}
+		}
+	}
+
+	return errs
+}
+
+// Validate validates NodeRoleManagement fields.
+func (m *NodeRoleManagement) Validate(fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	if m == nil {
+		return errs
+	}
+
+	if m.PermissionsBoundary != "" && !isPolicyARN(m.PermissionsBoundary) {
+		errs = append(errs, field.Invalid(fldPath.Child("permissionsBoundary"), m.PermissionsBoundary, "must be the ARN of an IAM policy"))
+	}
+
+	for i, policy := range m.AdditionalPolicies {
+		if !isPolicyARN(policy) {
+			errs = append(errs, field.Invalid(fldPath.Child("additionalPolicies").Index(i), policy, "must be the ARN of an IAM policy"))
+		}
+	}
+
+	return errs
+}
+
+func isPolicyARN(value string) bool {
+	parsedARN, err := arn.Parse(value)
+	return err == nil && parsedARN.Service == "iam" && strings.HasPrefix(parsedARN.Resource, "policy/")
+}
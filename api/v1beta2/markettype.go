@@ -0,0 +1,100 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// MarketType is the purchasing option instances are launched with.
+type MarketType string
+
+const (
+	// MarketTypeOnDemand launches on-demand instances.
+	MarketTypeOnDemand = MarketType("on-demand")
+
+	// MarketTypeSpot launches spot instances, with the spot market options if set.
+	MarketTypeSpot = MarketType("spot")
+
+	// MarketTypeCapacityBlock launches instances in a Capacity Block for ML, identified by the capacity reservation ID.
+	MarketTypeCapacityBlock = MarketType("capacity-block")
+)
+
+// CapacityBlockInstanceFamilies lists the instance families which can be launched in Capacity Blocks for ML.
+var CapacityBlockInstanceFamilies = []string{"p4d", "p4de", "p5", "p5e", "p5en", "trn1", "trn2"}
+
+// ValidateMarketType checks the combination of the market type with the spot market options and the capacity
+// reservation of a spec. A capacity block requires a capacity reservation and can't be combined with spot
+// market options.
+func ValidateMarketType(marketType MarketType, spotMarketOptions *SpotMarketOptions, capacityReservationID *string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	switch marketType {
+	case MarketTypeCapacityBlock:
+		if capacityReservationID == nil || *capacityReservationID == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("capacityReservationId"), "must be set when marketType is capacity-block"))
+		}
+		if spotMarketOptions != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("spotMarketOptions"), "can't be set when marketType is capacity-block"))
+		}
+	case MarketTypeOnDemand:
+		if spotMarketOptions != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("spotMarketOptions"), "can't be set when marketType is on-demand"))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateCapacityBlockInstanceType checks that an instance type can be launched in a capacity block.
+func ValidateCapacityBlockInstanceType(instanceType string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	family, _, _ := strings.Cut(instanceType, ".")
+	if !slices.Contains(CapacityBlockInstanceFamilies, family) {
+		allErrs = append(allErrs, field.Invalid(fldPath, instanceType,
+			fmt.Sprintf("can't be launched in a capacity block, the supported instance families are %s", strings.Join(CapacityBlockInstanceFamilies, ", "))))
+	}
+
+	return allErrs
+}
+
+// ValidateCapacityReservationTarget checks that a spec targets either a capacity reservation or a capacity
+// reservation resource group, and that the resource group is referenced by a resource groups ARN.
+func ValidateCapacityReservationTarget(capacityReservationID, resourceGroupARN *string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if resourceGroupARN == nil {
+		return allErrs
+	}
+
+	groupPath := fldPath.Child("capacityReservationResourceGroupArn")
+	if capacityReservationID != nil {
+		allErrs = append(allErrs, field.Forbidden(groupPath, "can't be set along with capacityReservationId"))
+	}
+	parsed, err := arn.Parse(*resourceGroupARN)
+	if err != nil {
+		allErrs = append(allErrs, field.Invalid(groupPath, *resourceGroupARN, "must be a valid ARN"))
+	} else if parsed.Service != "resource-groups" || !strings.HasPrefix(parsed.Resource, "group/") {
+		allErrs = append(allErrs, field.Invalid(groupPath, *resourceGroupARN, "must be the ARN of a resource group"))
+	}
+	return allErrs
+}
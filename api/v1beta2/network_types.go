@@ -19,6 +19,7 @@ package v1beta2
 import (
 	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -63,6 +64,11 @@ type NetworkStatus struct {
 
 	// NatGatewaysIPs contains the public IPs of the NAT Gateways
 	NatGatewaysIPs []string `json:"natGatewaysIPs,omitempty"`
+
+	// EgressPrefixListID is the ID of the customer-managed prefix list created by the controller
+	// for the egress IPs of the cluster.
+	// +optional
+	EgressPrefixListID string `json:"egressPrefixListId,omitempty"`
 }
 
 // ELBScheme defines the scheme of a load balancer.
@@ -292,13 +298,13 @@ type LoadBalancer struct {
 }
 
 // IsUnmanaged returns true if the Classic ELB is unmanaged.
-func (b *LoadBalancer) IsUnmanaged(clusterName string) bool {
-	return b.Name != "" && !Tags(b.Tags).HasOwned(clusterName)
+func (b *LoadBalancer) IsUnmanaged(clusterName string, ownershipTagPrefixes ...string) bool {
+	return b.Name != "" && !Tags(b.Tags).HasOwned(clusterName, ownershipTagPrefixes...)
 }
 
 // IsManaged returns true if Classic ELB is managed.
-func (b *LoadBalancer) IsManaged(clusterName string) bool {
-	return !b.IsUnmanaged(clusterName)
+func (b *LoadBalancer) IsManaged(clusterName string, ownershipTagPrefixes ...string) bool {
+	return !b.IsUnmanaged(clusterName, ownershipTagPrefixes...)
 }
 
 // ClassicELBAttributes defines extra attributes associated with a classic load balancer.
@@ -348,6 +354,12 @@ type NetworkSpec struct {
 	// +optional
 	SecurityGroupOverrides map[SecurityGroupRole]string `json:"securityGroupOverrides,omitempty"`
 
+	// ApplyRulesToUnmanagedGroups, if true, makes the controller reconcile ingress rules on the security
+	// groups provided in SecurityGroupOverrides instead of leaving them untouched.
+	// This is required when additional ingress rules should be applied to an overridden group.
+	// +optional
+	ApplyRulesToUnmanagedGroups bool `json:"applyRulesToUnmanagedGroups,omitempty"`
+
 	// AdditionalControlPlaneIngressRules is an optional set of ingress rules to add to the control plane
 	// +optional
 	AdditionalControlPlaneIngressRules []IngressRule `json:"additionalControlPlaneIngressRules,omitempty"`
@@ -356,6 +368,57 @@ type NetworkSpec struct {
 	// If none are specified here, all IPs are allowed to connect.
 	// +optional
 	NodePortIngressRuleCidrBlocks []string `json:"nodePortIngressRuleCidrBlocks,omitempty"`
+
+	// LocalGatewayRouteTables configures the local gateway used as the default route
+	// for private subnets created on an AWS Outpost, one entry per Outpost.
+	// +optional
+	LocalGatewayRouteTables []LocalGatewayRouteTable `json:"localGatewayRouteTables,omitempty"`
+
+	// PublishEgressPrefixList, if true, makes the controller maintain a customer-managed prefix list
+	// containing the public IPs of the NAT gateways of the cluster, so that other accounts can allow
+	// the egress traffic of the cluster by referencing it. The ID of the prefix list is published in
+	// the status.
+	// +optional
+	PublishEgressPrefixList bool `json:"publishEgressPrefixList,omitempty"`
+
+	// EgressPrefixListIncludeAPIServerLB, if true, adds the IPs of the API server load balancer to the
+	// egress prefix list. It requires PublishEgressPrefixList to be set.
+	// +optional
+	EgressPrefixListIncludeAPIServerLB bool `json:"egressPrefixListIncludeAPIServerLB,omitempty"`
+
+	// LegacyClusterTag configures the kubernetes.io/cluster/<name> tag used by the Kubernetes cloud
+	// providers on the subnets, the load balancer security group, the instances and their network
+	// interfaces. When it isn't set, the tag is applied as it always has been and never removed.
+	// +optional
+	LegacyClusterTag *LegacyClusterTag `json:"legacyClusterTag,omitempty"`
+}
+
+// LegacyClusterTag configures the kubernetes.io/cluster/<name> tag of the resources of a cluster.
+type LegacyClusterTag struct {
+	// Enabled, if true, makes the controller apply the tag to the resources it manages, and to the
+	// subnets of an unmanaged VPC when tagging unmanaged network resources is enabled. If false,
+	// the tag is removed from these resources.
+	// +kubebuilder:default=true
+	Enabled bool `json:"enabled"`
+
+	// Value is the value of the tag. When it isn't set, the resources managed by the controller are
+	// tagged as owned and the subnets of an unmanaged VPC as shared.
+	// Switching it to shared before removing the resources from the cluster, or back to owned, is
+	// typically needed when migrating from the in-tree to the external cloud controller manager.
+	// +kubebuilder:validation:Enum=owned;shared
+	// +optional
+	Value ResourceLifecycle `json:"value,omitempty"`
+}
+
+// LocalGatewayRouteTable defines the local gateway of an AWS Outpost.
+type LocalGatewayRouteTable struct {
+	// OutpostARN is the Amazon Resource Name (ARN) of the AWS Outpost.
+	// +kubebuilder:validation:MinLength=1
+	OutpostARN string `json:"outpostArn"`
+
+	// LocalGatewayID is the ID of the local gateway of the Outpost, e.g. lgw-0123456789abcdef0.
+	// +kubebuilder:validation:MinLength=1
+	LocalGatewayID string `json:"localGatewayId"`
 }
 
 // IPv6 contains ipv6 specific settings for the network.
@@ -489,6 +552,47 @@ type VPCSpec struct {
 	// +kubebuilder:default=PreferPrivate
 	// +kubebuilder:validation:Enum=PreferPrivate;PreferPublic
 	SubnetSchema *SubnetSchemaType `json:"subnetSchema,omitempty"`
+
+	// PrivateOnly specifies that the managed VPC only has private subnets. The default subnets are all
+	// private, and neither an internet gateway nor NAT gateways are created, the egress traffic of the
+	// subnets being expected to go through a transit gateway or a proxy. The control plane load balancers
+	// must be internal, and the bastion host and the machines with a public IP aren't supported.
+	// This field is immutable.
+	//
+	// NOTE: This only applies when the VPC is managed by the Cluster API AWS controller.
+	//
+	// +optional
+	PrivateOnly bool `json:"privateOnly,omitempty"`
+
+	// DHCPOptions configures a DHCP options set which is created by the provider and
+	// associated with the VPC. DHCP options sets are immutable, changes are applied by
+	// creating a new set and associating it with the VPC.
+	//
+	// NOTE: This only applies when the VPC is managed by the Cluster API AWS controller.
+	//
+	// +optional
+	DHCPOptions *DHCPOptions `json:"dhcpOptions,omitempty"`
+
+	// DHCPOptionsID is the id of the DHCP options set created by the provider and associated with the VPC.
+	// +optional
+	DHCPOptionsID *string `json:"dhcpOptionsId,omitempty"`
+}
+
+// DHCPOptions defines the DHCP options set associated with a managed VPC.
+type DHCPOptions struct {
+	// DomainName is the domain name instances in the VPC use to complete unqualified DNS hostnames.
+	// +optional
+	DomainName *string `json:"domainName,omitempty"`
+
+	// DomainNameServers are the IP addresses of up to four domain name servers, or AmazonProvidedDNS.
+	// +optional
+	// +kubebuilder:validation:MaxItems=4
+	DomainNameServers []string `json:"domainNameServers,omitempty"`
+
+	// NTPServers are the IP addresses of up to four Network Time Protocol (NTP) servers.
+	// +optional
+	// +kubebuilder:validation:MaxItems=4
+	NTPServers []string `json:"ntpServers,omitempty"`
 }
 
 // String returns a string representation of the VPC.
@@ -497,13 +601,13 @@ func (v *VPCSpec) String() string {
 }
 
 // IsUnmanaged returns true if the VPC is unmanaged.
-func (v *VPCSpec) IsUnmanaged(clusterName string) bool {
-	return v.ID != "" && !v.Tags.HasOwned(clusterName)
+func (v *VPCSpec) IsUnmanaged(clusterName string, ownershipTagPrefixes ...string) bool {
+	return v.ID != "" && !v.Tags.HasOwned(clusterName, ownershipTagPrefixes...)
 }
 
 // IsManaged returns true if VPC is managed.
-func (v *VPCSpec) IsManaged(clusterName string) bool {
-	return !v.IsUnmanaged(clusterName)
+func (v *VPCSpec) IsManaged(clusterName string, ownershipTagPrefixes ...string) bool {
+	return !v.IsUnmanaged(clusterName, ownershipTagPrefixes...)
 }
 
 // IsIPv6Enabled returns true if the IPv6 block is defined on the network spec.
@@ -612,6 +716,15 @@ type SubnetSpec struct {
 	//
 	// +optional
 	ParentZoneName *string `json:"parentZoneName,omitempty"`
+
+	// OutpostARN is the Amazon Resource Name (ARN) of the AWS Outpost where the subnet is created.
+	//
+	// Subnets on an Outpost are always private, they are not eligible to automatically create
+	// regular cluster resources, and their private route table egresses traffic through the
+	// Outpost's local gateway set in NetworkSpec.LocalGatewayRouteTables instead of a NAT Gateway.
+	//
+	// +optional
+	OutpostARN *string `json:"outpostArn,omitempty"`
 }
 
 // GetResourceID returns the identifier for this subnet,
@@ -643,6 +756,11 @@ func (s *SubnetSpec) IsEdge() bool {
 	return false
 }
 
+// IsOutpost returns true when the subnet is created on an AWS Outpost.
+func (s *SubnetSpec) IsOutpost() bool {
+	return s.OutpostARN != nil && *s.OutpostARN != ""
+}
+
 // IsEdgeWavelength returns true only when the subnet is created in Wavelength Zone.
 func (s *SubnetSpec) IsEdgeWavelength() bool {
 	if s.ZoneType == nil {
@@ -703,8 +821,8 @@ func (s Subnets) IDs() []string {
 		// Prevent returning edge zones (Local Zone) to regular Subnet IDs.
 		// Edge zones should not deploy control plane nodes, and does not support Nat Gateway and
 		// Network Load Balancers. Any resource for the core infrastructure should not consume edge
-		// zones.
-		if subnet.IsEdge() {
+		// zones. The same applies to subnets on an AWS Outpost.
+		if subnet.IsEdge() || subnet.IsOutpost() {
 			continue
 		}
 		res = append(res, subnet.GetResourceID())
@@ -754,8 +872,8 @@ func (s Subnets) FindEqual(spec *SubnetSpec) *SubnetSpec {
 // FilterPrivate returns a slice containing all subnets marked as private.
 func (s Subnets) FilterPrivate() (res Subnets) {
 	for _, x := range s {
-		// Subnets in AWS Local Zones or Wavelength should not be used by core infrastructure.
-		if x.IsEdge() {
+		// Subnets in AWS Local Zones, Wavelength or Outposts should not be used by core infrastructure.
+		if x.IsEdge() || x.IsOutpost() {
 			continue
 		}
 		if !x.IsPublic {
@@ -779,8 +897,8 @@ func (s Subnets) FilterNonCni() (res Subnets) {
 // FilterPublic returns a slice containing all subnets marked as public.
 func (s Subnets) FilterPublic() (res Subnets) {
 	for _, x := range s {
-		// Subnets in AWS Local Zones or Wavelength should not be used by core infrastructure.
-		if x.IsEdge() {
+		// Subnets in AWS Local Zones, Wavelength or Outposts should not be used by core infrastructure.
+		if x.IsEdge() || x.IsOutpost() {
 			continue
 		}
 		if x.IsPublic {
@@ -813,6 +931,36 @@ func (s Subnets) GetUniqueZones() []string {
 	return zones
 }
 
+// ValidateLoadBalancerZones checks that the subnets can be attached to the same load balancer: AWS accepts
+// at most one subnet per availability zone, and the load balancer only reaches targets in the zones of its
+// subnets, so each of requiredZones must be covered. Subnets without an availability zone are ignored.
+// It returns a description of each problem found, naming the offending subnets.
+func (s Subnets) ValidateLoadBalancerZones(requiredZones []string) []string {
+	var problems []string
+	zones := []string{}
+	subnetsByZone := make(map[string][]string)
+	for _, x := range s {
+		if x.AvailabilityZone == "" {
+			continue
+		}
+		if _, ok := subnetsByZone[x.AvailabilityZone]; !ok {
+			zones = append(zones, x.AvailabilityZone)
+		}
+		subnetsByZone[x.AvailabilityZone] = append(subnetsByZone[x.AvailabilityZone], x.GetResourceID())
+	}
+	for _, zone := range zones {
+		if ids := subnetsByZone[zone]; len(ids) > 1 {
+			problems = append(problems, fmt.Sprintf("subnets %s are in the same availability zone %s", strings.Join(ids, ", "), zone))
+		}
+	}
+	for _, zone := range requiredZones {
+		if _, ok := subnetsByZone[zone]; !ok {
+			problems = append(problems, fmt.Sprintf("no subnet in availability zone %s, which is used by control plane machines", zone))
+		}
+	}
+	return problems
+}
+
 // SetZoneInfo updates the subnets with zone information.
 func (s Subnets) SetZoneInfo(zones []*ec2.AvailabilityZone) error {
 	for i := range s {
@@ -838,11 +986,35 @@ func (s Subnets) HasPublicSubnetWavelength() bool {
 
 // CNISpec defines configuration for CNI.
 type CNISpec struct {
+	// Preset selects the well-known ingress rules of a CNI, which are applied to the control plane and worker
+	// node security groups together with the CNIIngressRules. The rules of a preset are versioned: a cluster
+	// keeps the version recorded in its aws.cluster.x-k8s.io/cni-preset annotation until the annotation is removed.
+	// +kubebuilder:validation:Enum=cilium;calico;flannel;none
+	// +optional
+	Preset CNIPreset `json:"preset,omitempty"`
+
 	// CNIIngressRules specify rules to apply to control plane and worker node security groups.
 	// The source for the rule will be set to control plane and worker security group IDs.
 	CNIIngressRules CNIIngressRules `json:"cniIngressRules,omitempty"`
 }
 
+// CNIPreset is the name of a set of well-known ingress rules for a CNI.
+type CNIPreset string
+
+const (
+	// CNIPresetCilium opens the VXLAN overlay, health check and Hubble ports of Cilium.
+	CNIPresetCilium = CNIPreset("cilium")
+
+	// CNIPresetCalico opens the BGP, IP-in-IP, VXLAN and Typha ports of Calico.
+	CNIPresetCalico = CNIPreset("calico")
+
+	// CNIPresetFlannel opens the VXLAN overlay port of Flannel.
+	CNIPresetFlannel = CNIPreset("flannel")
+
+	// CNIPresetNone doesn't open any port, only the CNIIngressRules are applied.
+	CNIPresetNone = CNIPreset("none")
+)
+
 // CNIIngressRules is a slice of CNIIngressRule.
 type CNIIngressRules []CNIIngressRule
 
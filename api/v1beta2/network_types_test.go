@@ -800,6 +800,49 @@ func TestSubnets_GetUniqueZones(t *testing.T) {
 	}
 }
 
+func TestSubnets_ValidateLoadBalancerZones(t *testing.T) {
+	tests := []struct {
+		name          string
+		subnets       Subnets
+		requiredZones []string
+		want          []string
+	}{
+		{
+			name: "one subnet per zone covering the required zones",
+			subnets: Subnets{
+				{ID: "subnet-1", AvailabilityZone: "us-east-1a"},
+				{ID: "subnet-2", AvailabilityZone: "us-east-1b"},
+			},
+			requiredZones: []string{"us-east-1a", "us-east-1b"},
+		},
+		{
+			name: "subnets sharing a zone",
+			subnets: Subnets{
+				{ID: "subnet-1", AvailabilityZone: "us-east-1a"},
+				{ID: "subnet-2", AvailabilityZone: "us-east-1b"},
+				{ID: "subnet-3", AvailabilityZone: "us-east-1a"},
+			},
+			want: []string{"subnets subnet-1, subnet-3 are in the same availability zone us-east-1a"},
+		},
+		{
+			name: "required zone not covered",
+			subnets: Subnets{
+				{ID: "subnet-1", AvailabilityZone: "us-east-1a"},
+				{ID: "subnet-2"},
+			},
+			requiredZones: []string{"us-east-1a", "us-east-1c"},
+			want:          []string{"no subnet in availability zone us-east-1c, which is used by control plane machines"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.subnets.ValidateLoadBalancerZones(tt.requiredZones); !cmp.Equal(got, tt.want) {
+				t.Errorf("Subnets.ValidateLoadBalancerZones() got unwanted value:\n %v", cmp.Diff(got, tt.want))
+			}
+		})
+	}
+}
+
 func TestSubnets_HasPublicSubnetWavelength(t *testing.T) {
 	stub := testStubNetworkTypes{}
 	tests := []struct {
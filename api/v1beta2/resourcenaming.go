@@ -0,0 +1,163 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	// ResourceTypeVPC is the resource type of the VPC in the resource naming template.
+	ResourceTypeVPC = "vpc"
+	// ResourceTypeSubnet is the resource type of the subnets in the resource naming template.
+	ResourceTypeSubnet = "subnet"
+	// ResourceTypeRouteTable is the resource type of the route tables in the resource naming template.
+	ResourceTypeRouteTable = "route-table"
+	// ResourceTypeInternetGateway is the resource type of the internet gateway in the resource naming template.
+	ResourceTypeInternetGateway = "internet-gateway"
+	// ResourceTypeCarrierGateway is the resource type of the carrier gateway in the resource naming template.
+	ResourceTypeCarrierGateway = "carrier-gateway"
+	// ResourceTypeEgressOnlyInternetGateway is the resource type of the egress-only internet gateway in the
+	// resource naming template.
+	ResourceTypeEgressOnlyInternetGateway = "egress-only-internet-gateway"
+	// ResourceTypeNatGateway is the resource type of the NAT gateways in the resource naming template.
+	ResourceTypeNatGateway = "nat-gateway"
+	// ResourceTypeElasticIP is the resource type of the Elastic IPs in the resource naming template.
+	ResourceTypeElasticIP = "elastic-ip"
+	// ResourceTypeDHCPOptions is the resource type of the DHCP options set in the resource naming template.
+	ResourceTypeDHCPOptions = "dhcp-options"
+	// ResourceTypeSecurityGroup is the resource type of the security groups in the resource naming template.
+	ResourceTypeSecurityGroup = "security-group"
+	// ResourceTypeLoadBalancer is the resource type of the load balancers in the resource naming template.
+	ResourceTypeLoadBalancer = "load-balancer"
+)
+
+// resourceNameMaxLengths are the maximum lengths of the Name tag of the resources, which is the maximum length of a
+// tag value of the EC2 and Elastic Load Balancing APIs.
+var resourceNameMaxLengths = map[string]int{
+	ResourceTypeVPC:                       256,
+	ResourceTypeSubnet:                    256,
+	ResourceTypeRouteTable:                256,
+	ResourceTypeInternetGateway:           256,
+	ResourceTypeCarrierGateway:            256,
+	ResourceTypeEgressOnlyInternetGateway: 256,
+	ResourceTypeNatGateway:                256,
+	ResourceTypeElasticIP:                 256,
+	ResourceTypeDHCPOptions:               256,
+	ResourceTypeSecurityGroup:             256,
+	ResourceTypeLoadBalancer:              256,
+}
+
+// longestAvailabilityZoneName is the availability zone the template is validated with, as long as the names of
+// the Wavelength Zones.
+const longestAvailabilityZoneName = "ap-northeast-1-wl1-kix-wlz-1"
+
+// ResourceNameData are the fields of the resource naming template.
+type ResourceNameData struct {
+	// ClusterName is the name of the cluster.
+	ClusterName string
+	// ResourceType is the type of the resource, such as subnet.
+	ResourceType string
+	// AZ is the availability zone of the resource, if any.
+	AZ string
+	// Role is the role of the resource, such as public or private.
+	Role string
+	// Index is the position of a subnet among the subnets of the same role in its availability zone.
+	Index int
+}
+
+// Name returns the Name tag of a new resource rendered from the template, or defaultName when there is no template
+// or the template fails to render.
+func (n *ResourceNaming) Name(defaultName string, data ResourceNameData) string {
+	if n == nil {
+		return defaultName
+	}
+	name, err := n.render(data)
+	if err != nil || name == "" {
+		return defaultName
+	}
+	return name
+}
+
+// ExistingName returns the Name tag of an existing resource, which is kept when there is a template so that the
+// template only names the resources created after it is set.
+func (n *ResourceNaming) ExistingName(current Tags) (string, bool) {
+	if n == nil {
+		return "", false
+	}
+	name, ok := current["Name"]
+	return name, ok
+}
+
+// KeepName keeps the Name tag of an existing resource in the build params when there is a template.
+func (n *ResourceNaming) KeepName(params *BuildParams, current Tags) {
+	if name, ok := n.ExistingName(current); ok {
+		params.Name = &name
+	}
+}
+
+func (n *ResourceNaming) render(data ResourceNameData) (string, error) {
+	tmpl, err := template.New("name").Option("missingkey=error").Parse(n.Template)
+	if err != nil {
+		return "", err
+	}
+	var name strings.Builder
+	if err := tmpl.Execute(&name, data); err != nil {
+		return "", err
+	}
+	return name.String(), nil
+}
+
+// Validate checks that the template renders the Name tags of all the resource types within their maximum length.
+func (n *ResourceNaming) Validate(clusterName string, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if n == nil {
+		return errs
+	}
+
+	templatePath := fldPath.Child("template")
+	if _, err := template.New("name").Parse(n.Template); err != nil {
+		return append(errs, field.Invalid(templatePath, n.Template, err.Error()))
+	}
+	for _, resourceType := range []string{
+		ResourceTypeVPC, ResourceTypeSubnet, ResourceTypeRouteTable, ResourceTypeInternetGateway, ResourceTypeCarrierGateway,
+		ResourceTypeEgressOnlyInternetGateway, ResourceTypeNatGateway, ResourceTypeElasticIP, ResourceTypeDHCPOptions,
+		ResourceTypeSecurityGroup, ResourceTypeLoadBalancer,
+	} {
+		name, err := n.render(ResourceNameData{
+			ClusterName:  clusterName,
+			ResourceType: resourceType,
+			AZ:           longestAvailabilityZoneName,
+			Role:         PrivateRoleTagValue,
+			Index:        99,
+		})
+		switch {
+		case err != nil:
+			return append(errs, field.Invalid(templatePath, n.Template, err.Error()))
+		case strings.TrimSpace(name) == "":
+			return append(errs, field.Invalid(templatePath, n.Template, fmt.Sprintf("renders an empty name for %s resources", resourceType)))
+		case len(name) > resourceNameMaxLengths[resourceType]:
+			return append(errs, field.Invalid(templatePath, n.Template,
+				fmt.Sprintf("renders a name longer than %d characters for %s resources", resourceNameMaxLengths[resourceType], resourceType)))
+		}
+	}
+	return errs
+}
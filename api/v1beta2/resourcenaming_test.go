@@ -0,0 +1,127 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+)
+
+func TestResourceNamingName(t *testing.T) {
+	data := ResourceNameData{
+		ClusterName:  "prod",
+		ResourceType: ResourceTypeSubnet,
+		AZ:           "us-east-1a",
+		Role:         PrivateRoleTagValue,
+		Index:        1,
+	}
+
+	tests := []struct {
+		name   string
+		naming *ResourceNaming
+		want   string
+	}{
+		{
+			name: "default name without a template",
+			want: "prod-subnet-private-us-east-1a",
+		},
+		{
+			name:   "name rendered from the template",
+			naming: &ResourceNaming{Template: "{{.ClusterName}}-{{.ResourceType}}-{{.Role}}{{with .AZ}}-{{.}}{{end}}-{{.Index}}"},
+			want:   "prod-subnet-private-us-east-1a-1",
+		},
+		{
+			name:   "default name when the template renders an empty name",
+			naming: &ResourceNaming{Template: "{{if false}}x{{end}}"},
+			want:   "prod-subnet-private-us-east-1a",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(tt.naming.Name("prod-subnet-private-us-east-1a", data)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestResourceNamingKeepName(t *testing.T) {
+	g := NewWithT(t)
+
+	params := BuildParams{Name: ptr.To("prod-vpc")}
+	var naming *ResourceNaming
+	naming.KeepName(&params, Tags{"Name": "old-vpc"})
+	g.Expect(*params.Name).To(Equal("prod-vpc"), "the default name is applied to existing resources without a template")
+
+	naming = &ResourceNaming{Template: "{{.ClusterName}}-{{.ResourceType}}"}
+	naming.KeepName(&params, Tags{"Name": "old-vpc"})
+	g.Expect(*params.Name).To(Equal("old-vpc"), "existing resources keep their name with a template")
+
+	params = BuildParams{Name: ptr.To("prod-vpc")}
+	naming.KeepName(&params, Tags{})
+	g.Expect(*params.Name).To(Equal("prod-vpc"), "existing resources without a name are named")
+}
+
+func TestResourceNamingValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		naming  *ResourceNaming
+		wantErr string
+	}{
+		{
+			name: "no template",
+		},
+		{
+			name:   "valid template",
+			naming: &ResourceNaming{Template: "{{.ClusterName}}-{{.ResourceType}}{{with .AZ}}-{{.}}{{end}}"},
+		},
+		{
+			name:    "template failing to parse",
+			naming:  &ResourceNaming{Template: "{{.ClusterName"},
+			wantErr: "unclosed action",
+		},
+		{
+			name:    "template referring to an unknown field",
+			naming:  &ResourceNaming{Template: "{{.Zone}}"},
+			wantErr: "can't evaluate field Zone",
+		},
+		{
+			name:    "template rendering an empty name",
+			naming:  &ResourceNaming{Template: "{{if eq .ResourceType \"vpc\"}}{{.ClusterName}}{{end}}"},
+			wantErr: "renders an empty name for subnet resources",
+		},
+		{
+			name:    "template rendering a name too long",
+			naming:  &ResourceNaming{Template: strings.Repeat("x", 235) + "-{{.ResourceType}}"},
+			wantErr: "renders a name longer than 256 characters for egress-only-internet-gateway resources",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			errs := tt.naming.Validate("prod", field.NewPath("spec", "resourceNaming"))
+			if tt.wantErr == "" {
+				g.Expect(errs).To(BeEmpty())
+				return
+			}
+			g.Expect(errs.ToAggregate().Error()).To(ContainSubstring(tt.wantErr))
+		})
+	}
+}
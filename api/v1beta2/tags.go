@@ -19,6 +19,7 @@ package v1beta2
 import (
 	"fmt"
 	"regexp"
+	"strings"
 
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -30,9 +31,14 @@ import (
 type Tags map[string]string
 
 // HasOwned returns true if the tags contains a tag that marks the resource as owned by the cluster from the perspective of this management tooling.
-func (t Tags) HasOwned(cluster string) bool {
-	value, ok := t[ClusterTagKey(cluster)]
-	return ok && ResourceLifecycle(value) == ResourceLifecycleOwned
+// The ownership tag keys are built from the given prefixes, in order, or from NameAWSProviderOwned without prefixes.
+func (t Tags) HasOwned(cluster string, ownershipTagPrefixes ...string) bool {
+	for _, key := range OwnershipTagKeys(cluster, ownershipTagPrefixes...) {
+		if value, ok := t[key]; ok {
+			return ResourceLifecycle(value) == ResourceLifecycleOwned
+		}
+	}
+	return false
 }
 
 // HasAWSCloudProviderOwned returns true if the tags contains a tag that marks the resource as owned by the cluster from the perspective of the in-tree cloud provider.
@@ -170,6 +176,10 @@ const (
 	// dedicated to this cluster api provider implementation.
 	NameAWSSubnetAssociation = NameAWSProviderPrefix + "association"
 
+	// NameAWSMachinePool is the tag name we use to mark the resources owned by a machine pool,
+	// with the name of the machine pool as value.
+	NameAWSMachinePool = NameAWSProviderPrefix + "machine-pool"
+
 	// SecondarySubnetTagValue is the secondary subnet tag constant value.
 	SecondarySubnetTagValue = "secondary"
 
@@ -202,11 +212,84 @@ func ClusterTagKey(name string) string {
 	return fmt.Sprintf("%s%s", NameAWSProviderOwned, name)
 }
 
+// OwnershipTagKey generates the key for resources associated with a cluster whose ownership tag key
+// uses the given prefix. An empty prefix stands for NameAWSProviderOwned.
+func OwnershipTagKey(prefix, name string) string {
+	if prefix == "" {
+		return ClusterTagKey(name)
+	}
+	return prefix + name
+}
+
+// OwnershipTagKeys returns the keys that mark resources as associated with a cluster, one per prefix.
+// Without prefixes, the key is the one returned by ClusterTagKey.
+func OwnershipTagKeys(name string, prefixes ...string) []string {
+	if len(prefixes) == 0 {
+		return []string{ClusterTagKey(name)}
+	}
+	keys := make([]string, 0, len(prefixes))
+	for _, prefix := range prefixes {
+		keys = append(keys, OwnershipTagKey(prefix, name))
+	}
+	return keys
+}
+
+// ValidateOwnershipTagPrefix checks that the ownership tag key built from the prefix and the cluster name
+// is a valid AWS tag key.
+func ValidateOwnershipTagPrefix(prefix, clusterName string, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if prefix == "" {
+		return errs
+	}
+
+	re := regexp.MustCompile(`^[a-zA-Z0-9\s\_\.\:\=\+\-\@\/]*$`)
+	if !re.MatchString(prefix) {
+		errs = append(errs, field.Invalid(fldPath, prefix, "prefix cannot have characters other than alphabets, numbers, spaces and _ . : / = + - @ ."))
+	}
+	if strings.HasPrefix(prefix, "aws:") {
+		errs = append(errs, field.Invalid(fldPath, prefix, "prefix cannot start with aws:"))
+	}
+	if key := prefix + clusterName; len(key) > 128 {
+		errs = append(errs, field.Invalid(fldPath, prefix, fmt.Sprintf("ownership tag key %q cannot be longer than 128 characters", key)))
+	}
+	return errs
+}
+
 // ClusterAWSCloudProviderTagKey generates the key for resources associated a cluster's AWS cloud provider.
 func ClusterAWSCloudProviderTagKey(name string) string {
 	return fmt.Sprintf("%s%s", NameKubernetesAWSCloudProviderPrefix, name)
 }
 
+// Apply sets the kubernetes.io/cluster/<name> tag of the cluster in the tags when the tag is enabled, with the
+// configured value or defaultValue, and removes it from the tags when the tag is disabled. The tags are left
+// unchanged when the tag isn't configured.
+func (l *LegacyClusterTag) Apply(tags Tags, clusterName string, defaultValue ResourceLifecycle) {
+	if l == nil {
+		return
+	}
+
+	key := ClusterAWSCloudProviderTagKey(clusterName)
+	if !l.Enabled {
+		delete(tags, key)
+		return
+	}
+	if l.Value != "" {
+		tags[key] = string(l.Value)
+		return
+	}
+	tags[key] = string(defaultValue)
+}
+
+// Removes returns whether the kubernetes.io/cluster/<name> tag of the cluster has to be removed from a resource
+// having the tags.
+func (l *LegacyClusterTag) Removes(tags Tags, clusterName string) bool {
+	if l == nil || l.Enabled {
+		return false
+	}
+	_, ok := tags[ClusterAWSCloudProviderTagKey(clusterName)]
+	return ok
+}
+
 // BuildParams is used to build tags around an aws resource.
 type BuildParams struct {
 	// Lifecycle determines the resource lifecycle.
@@ -215,6 +298,11 @@ type BuildParams struct {
 	// ClusterName is the cluster associated with the resource.
 	ClusterName string
 
+	// OwnershipTagPrefix is the prefix of the key of the tag associating the resource with the cluster.
+	// Defaults to NameAWSProviderOwned.
+	// +optional
+	OwnershipTagPrefix string
+
 	// ResourceID is the unique identifier of the resource to be tagged.
 	ResourceID string
 
@@ -259,7 +347,7 @@ func Build(params BuildParams) Tags {
 	}
 
 	if params.ClusterName != "" {
-		tags[ClusterTagKey(params.ClusterName)] = string(params.Lifecycle)
+		tags[OwnershipTagKey(params.OwnershipTagPrefix, params.ClusterName)] = string(params.Lifecycle)
 	}
 	if params.Role != nil {
 		tags[NameAWSClusterAPIRole] = *params.Role
@@ -370,3 +370,164 @@ func getSortFieldErrorsFunc(errs []*field.Error) func(i, j int) bool {
 		return iBV < jBV
 	}
 }
+
+func TestOwnershipTagKeys(t *testing.T) {
+	tests := []struct {
+		name          string
+		prefixes      []string
+		tags          Tags
+		expectedKeys  []string
+		expectedOwned bool
+	}{
+		{
+			name:          "built-in prefix",
+			tags:          Tags{NameAWSProviderOwned + "test-cluster": string(ResourceLifecycleOwned)},
+			expectedKeys:  []string{NameAWSProviderOwned + "test-cluster"},
+			expectedOwned: true,
+		},
+		{
+			name:          "empty prefix stands for the built-in prefix",
+			prefixes:      []string{""},
+			tags:          Tags{NameAWSProviderOwned + "test-cluster": string(ResourceLifecycleOwned)},
+			expectedKeys:  []string{NameAWSProviderOwned + "test-cluster"},
+			expectedOwned: true,
+		},
+		{
+			name:          "custom prefix",
+			prefixes:      []string{"example.com/cluster/"},
+			tags:          Tags{NameAWSProviderOwned + "test-cluster": string(ResourceLifecycleOwned)},
+			expectedKeys:  []string{"example.com/cluster/test-cluster"},
+			expectedOwned: false,
+		},
+		{
+			name:          "migration in progress recognizes the previous prefix",
+			prefixes:      []string{"team.example.com/cluster/", NameAWSProviderOwned},
+			tags:          Tags{NameAWSProviderOwned + "test-cluster": string(ResourceLifecycleOwned)},
+			expectedKeys:  []string{"team.example.com/cluster/test-cluster", NameAWSProviderOwned + "test-cluster"},
+			expectedOwned: true,
+		},
+		{
+			name:          "migration in progress prefers the current prefix",
+			prefixes:      []string{"team.example.com/cluster/", NameAWSProviderOwned},
+			tags:          Tags{"team.example.com/cluster/test-cluster": string(ResourceLifecycleShared), NameAWSProviderOwned + "test-cluster": string(ResourceLifecycleOwned)},
+			expectedKeys:  []string{"team.example.com/cluster/test-cluster", NameAWSProviderOwned + "test-cluster"},
+			expectedOwned: false,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			keys := OwnershipTagKeys("test-cluster", tc.prefixes...)
+			if !cmp.Equal(keys, tc.expectedKeys) {
+				t.Errorf("expected keys %v, got %v", tc.expectedKeys, keys)
+			}
+			if owned := tc.tags.HasOwned("test-cluster", tc.prefixes...); owned != tc.expectedOwned {
+				t.Errorf("expected HasOwned to be %t, got %t", tc.expectedOwned, owned)
+			}
+		})
+	}
+}
+
+func TestBuildOwnershipTagPrefix(t *testing.T) {
+	tags := Build(BuildParams{
+		ClusterName:        "test-cluster",
+		OwnershipTagPrefix: "example.com/cluster/",
+		Lifecycle:          ResourceLifecycleOwned,
+	})
+	expected := Tags{"example.com/cluster/test-cluster": string(ResourceLifecycleOwned)}
+	if !cmp.Equal(tags, expected) {
+		t.Errorf("expected tags %v, got %v", expected, tags)
+	}
+}
+
+func TestLegacyClusterTagApply(t *testing.T) {
+	key := ClusterAWSCloudProviderTagKey("test-cluster")
+
+	tests := []struct {
+		name            string
+		config          *LegacyClusterTag
+		tags            Tags
+		expected        Tags
+		expectedRemoves bool
+	}{
+		{
+			name:     "not configured",
+			tags:     Tags{key: string(ResourceLifecycleShared)},
+			expected: Tags{key: string(ResourceLifecycleShared)},
+		},
+		{
+			name:     "enabled with the default value",
+			config:   &LegacyClusterTag{Enabled: true},
+			tags:     Tags{},
+			expected: Tags{key: string(ResourceLifecycleOwned)},
+		},
+		{
+			name:     "enabled with a value",
+			config:   &LegacyClusterTag{Enabled: true, Value: ResourceLifecycleShared},
+			tags:     Tags{key: string(ResourceLifecycleOwned)},
+			expected: Tags{key: string(ResourceLifecycleShared)},
+		},
+		{
+			name:            "disabled",
+			config:          &LegacyClusterTag{Value: ResourceLifecycleShared},
+			tags:            Tags{key: string(ResourceLifecycleOwned), "k1": "v1"},
+			expected:        Tags{"k1": "v1"},
+			expectedRemoves: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if removes := tc.config.Removes(tc.tags, "test-cluster"); removes != tc.expectedRemoves {
+				t.Errorf("expected Removes to be %t, got %t", tc.expectedRemoves, removes)
+			}
+			tc.config.Apply(tc.tags, "test-cluster", ResourceLifecycleOwned)
+			if !cmp.Equal(tc.tags, tc.expected) {
+				t.Errorf("expected tags %v, got %v", tc.expected, tc.tags)
+			}
+		})
+	}
+}
+
+func TestValidateOwnershipTagPrefix(t *testing.T) {
+	tests := []struct {
+		name        string
+		prefix      string
+		clusterName string
+		expectErr   bool
+	}{
+		{
+			name:        "empty prefix",
+			clusterName: "test-cluster",
+		},
+		{
+			name:        "valid prefix",
+			prefix:      "example.com/cluster/",
+			clusterName: "test-cluster",
+		},
+		{
+			name:        "prefix with wrong characters",
+			prefix:      "example.com/cluster*",
+			clusterName: "test-cluster",
+			expectErr:   true,
+		},
+		{
+			name:        "prefix with aws: prefix",
+			prefix:      "aws:cluster/",
+			clusterName: "test-cluster",
+			expectErr:   true,
+		},
+		{
+			name:        "key too long",
+			prefix:      strings.Repeat("CAPI", 30) + "/",
+			clusterName: "test-cluster",
+			expectErr:   true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := ValidateOwnershipTagPrefix(tc.prefix, tc.clusterName, field.NewPath("spec", "ownershipTagPrefix"))
+			if tc.expectErr != (len(errs) > 0) {
+				t.Errorf("expected error: %t, got %v", tc.expectErr, errs)
+			}
+		})
+	}
+}
@@ -57,6 +57,24 @@ type AMIReference struct {
 	// +kubebuilder:validation:Enum:=AmazonLinux;AmazonLinuxGPU
 	// +optional
 	EKSOptimizedLookupType *EKSAMILookupType `json:"eksLookupType,omitempty"`
+
+	// SourceRegion is the region the AMI referenced by ID is copied from when it doesn't exist
+	// in the region of the cluster. The copy is used once it is available.
+	// Only supported by the launch templates of machine pools.
+	// +optional
+	SourceRegion string `json:"sourceRegion,omitempty"`
+
+	// CopyEncryptionKey is the KMS key used to encrypt the snapshots of the AMI copied from SourceRegion.
+	// Can be either a KMS key ID or ARN. It is needed to copy AMIs whose snapshots are encrypted with
+	// a key which isn't available in the region of the cluster.
+	// +optional
+	CopyEncryptionKey string `json:"copyEncryptionKey,omitempty"`
+
+	// GPUCompatible declares whether the AMI ships the NVIDIA drivers needed by the instance types with GPUs.
+	// Without it, the AMI is assumed to lack them when it is an EKS optimized AMI without GPU support, or is looked
+	// up among the default images. Only supported by the launch templates of machine pools.
+	// +optional
+	GPUCompatible *bool `json:"gpuCompatible,omitempty"`
 }
 
 // Filter is a filter used to identify an AWS resource.
@@ -86,6 +104,21 @@ const (
 	// ExternalResourceGCTasksAnnotation is the name of an annotation that indicates what
 	// external resources tasks should be executed by garbage collector for the cluster.
 	ExternalResourceGCTasksAnnotation = "aws.cluster.x-k8s.io/external-resource-tasks-gc"
+
+	// OwnershipTagPrefixAnnotation is the name of an annotation that records the ownership tag prefix
+	// the cluster's resources are tagged with. If the annotation is missing, NameAWSProviderOwned is assumed.
+	// When it differs from the cluster's current prefix, the resources are migrated to the current prefix.
+	OwnershipTagPrefixAnnotation = "aws.cluster.x-k8s.io/ownership-tag-prefix"
+
+	// CNIPresetAnnotation is the name of an annotation that records the CNI preset and the version of its rules
+	// applied to the cluster's security groups, in the format <preset>/v<version>. The cluster keeps the rules of
+	// this version when a newer version is released, and switches to the latest version once the annotation is removed.
+	CNIPresetAnnotation = "aws.cluster.x-k8s.io/cni-preset"
+
+	// ReadOnlyAnnotation is the name of an annotation that puts the cluster in read-only mode when set to "true" on
+	// the AWSCluster or AWSManagedControlPlane. The controllers then only describe the AWS resources of the cluster
+	// to populate the status, and record the mutating requests they would have sent in the PendingChangesCondition.
+	ReadOnlyAnnotation = "aws.cluster.x-k8s.io/read-only"
 )
 
 // GCTask defines a task to be executed by the garbage collector.
@@ -100,6 +133,9 @@ var (
 
 	// GCTaskSecurityGroup defines a task to cleaning up resources for AWS security groups.
 	GCTaskSecurityGroup = GCTask("security-group")
+
+	// GCTaskVolume defines a task to cleaning up EBS volumes kept after their instances were terminated.
+	GCTaskVolume = GCTask("volume")
 )
 
 // AZSelectionScheme defines the scheme of selecting AZs.
@@ -226,6 +262,10 @@ type Instance struct {
 	// SpotMarketOptions option for configuring instances to be run using AWS Spot instances.
 	SpotMarketOptions *SpotMarketOptions `json:"spotMarketOptions,omitempty"`
 
+	// MarketType is the purchasing option the instance was launched with.
+	// +optional
+	MarketType MarketType `json:"marketType,omitempty"`
+
 	// PlacementGroupName specifies the name of the placement group in which to launch the instance.
 	// +optional
 	PlacementGroupName string `json:"placementGroupName,omitempty"`
@@ -261,6 +301,11 @@ type Instance struct {
 	// CapacityReservationID specifies the target Capacity Reservation into which the instance should be launched.
 	// +optional
 	CapacityReservationID *string `json:"capacityReservationId,omitempty"`
+
+	// CapacityReservationResourceGroupARN is the ARN of the Capacity Reservation resource group into which the
+	// instance should be launched.
+	// +optional
+	CapacityReservationResourceGroupARN *string `json:"capacityReservationResourceGroupArn,omitempty"`
 }
 
 // InstanceMetadataState describes the state of InstanceMetadataOptions.HttpEndpoint and InstanceMetadataOptions.InstanceMetadataTags
@@ -386,6 +431,17 @@ type Volume struct {
 	// The key must already exist and be accessible by the controller.
 	// +optional
 	EncryptionKey string `json:"encryptionKey,omitempty"`
+
+	// DeleteOnTermination is whether the volume is deleted when the instance is terminated. Defaults to true.
+	// Volumes that are kept are tagged with the cluster and machine they belonged to, so the garbage collector
+	// can clean them up when the "volume" task is enabled for the cluster.
+	// +optional
+	DeleteOnTermination *bool `json:"deleteOnTermination,omitempty"`
+
+	// SnapshotID is the ID of the EBS snapshot the volume is created from.
+	// The volume inherits the encryption state of the snapshot.
+	// +optional
+	SnapshotID *string `json:"snapshotID,omitempty"`
 }
 
 // VolumeType describes the EBS volume type.
@@ -467,3 +523,59 @@ var (
 	// SubnetSchemaPreferPublic allocates more subnets in the VPC to public subnets.
 	SubnetSchemaPreferPublic = SubnetSchemaType("PreferPublic")
 )
+
+// IAMRoleManagement configures the IAM roles created by CAPA.
+type IAMRoleManagement struct {
+	// PermissionsBoundary is the ARN of the managed policy set as permissions boundary of the role.
+	// +optional
+	PermissionsBoundary string `json:"permissionsBoundary,omitempty"`
+
+	// Path is the IAM path of the role. As the path of a role can't be changed, it is only used when
+	// the role is created.
+	// +kubebuilder:validation:MaxLength:=512
+	// +kubebuilder:validation:Pattern:=`^/([\x21-\x7E]+/)?$`
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// AdditionalTrustStatements are added to the trust policy of the role, on top of the statement
+	// allowing the AWS service to assume it.
+	// +optional
+	AdditionalTrustStatements []IAMTrustStatement `json:"additionalTrustStatements,omitempty"`
+}
+
+// IAMTrustStatement is a statement of the trust policy of an IAM role.
+type IAMTrustStatement struct {
+	// Sid is the identifier of the statement.
+	// +kubebuilder:validation:Pattern:=`^[a-zA-Z0-9]*$`
+	// +optional
+	Sid string `json:"sid,omitempty"`
+
+	// PrincipalType is the type of the principals allowed by the statement.
+	// +kubebuilder:validation:Enum:=AWS;Service;Federated
+	PrincipalType string `json:"principalType"`
+
+	// Principals are the principals allowed by the statement, e.g. the ARNs of IAM roles.
+	// +kubebuilder:validation:MinItems:=1
+	Principals []string `json:"principals"`
+
+	// Actions are the actions allowed by the statement. Defaults to sts:AssumeRole.
+	// +optional
+	Actions []string `json:"actions,omitempty"`
+
+	// Conditions restrict when the statement applies.
+	// +optional
+	Conditions []IAMTrustCondition `json:"conditions,omitempty"`
+}
+
+// IAMTrustCondition is a condition of a statement of the trust policy of an IAM role.
+type IAMTrustCondition struct {
+	// Operator is the condition operator, e.g. StringEquals or Bool.
+	Operator string `json:"operator"`
+
+	// Key is the condition key, e.g. aws:MultiFactorAuthPresent.
+	Key string `json:"key"`
+
+	// Values are the values the condition key is compared to.
+	// +kubebuilder:validation:MinItems:=1
+	Values []string `json:"values"`
+}
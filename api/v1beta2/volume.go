@@ -0,0 +1,52 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var snapshotIDPrefix = "snap-"
+
+// ValidateSnapshot validates the snapshot the volume is created from, fldPath being the path of the volume.
+// The encryption state of a volume created from an encrypted snapshot can't be turned off, so a snapshot-based
+// volume may only request encryption, not opt out of it.
+func (v *Volume) ValidateSnapshot(fldPath *field.Path, isRoot bool) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if v == nil || v.SnapshotID == nil {
+		return allErrs
+	}
+
+	if isRoot {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("snapshotID"), "the root volume is created from the snapshot of the AMI"))
+		return allErrs
+	}
+
+	if !strings.HasPrefix(*v.SnapshotID, snapshotIDPrefix) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("snapshotID"), *v.SnapshotID, "must start with "+snapshotIDPrefix))
+	}
+
+	if v.Encrypted != nil && !*v.Encrypted {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("encrypted"),
+			"can't be false for a volume created from a snapshot, omit it to keep the encryption state of the snapshot"))
+	}
+
+	return allErrs
+}
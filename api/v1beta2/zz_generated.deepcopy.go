@@ -41,6 +41,11 @@ func (in *AMIReference) DeepCopyInto(out *AMIReference) {
 		*out = new(EKSAMILookupType)
 		**out = **in
 	}
+	if in.GPUCompatible != nil {
+		in, out := &in.GPUCompatible, &out.GPUCompatible
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AMIReference.
@@ -162,6 +167,11 @@ func (in *AWSClusterIdentitySpec) DeepCopyInto(out *AWSClusterIdentitySpec) {
 		*out = new(AllowedNamespaces)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AllowedRegions != nil {
+		in, out := &in.AllowedRegions, &out.AllowedRegions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSClusterIdentitySpec.
@@ -295,6 +305,11 @@ func (in *AWSClusterSpec) DeepCopyInto(out *AWSClusterSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.SSHKey != nil {
+		in, out := &in.SSHKey, &out.SSHKey
+		*out = new(SSHKey)
+		**out = **in
+	}
 	out.ControlPlaneEndpoint = in.ControlPlaneEndpoint
 	if in.AdditionalTags != nil {
 		in, out := &in.AdditionalTags, &out.AdditionalTags
@@ -324,6 +339,26 @@ func (in *AWSClusterSpec) DeepCopyInto(out *AWSClusterSpec) {
 		*out = new(S3Bucket)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.NodeTerminationHandling != nil {
+		in, out := &in.NodeTerminationHandling, &out.NodeTerminationHandling
+		*out = new(NodeTerminationHandling)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeRoleManagement != nil {
+		in, out := &in.NodeRoleManagement, &out.NodeRoleManagement
+		*out = new(NodeRoleManagement)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CostSavings != nil {
+		in, out := &in.CostSavings, &out.CostSavings
+		*out = new(CostSavings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResourceNaming != nil {
+		in, out := &in.ResourceNaming, &out.ResourceNaming
+		*out = new(ResourceNaming)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSClusterSpec.
@@ -426,6 +461,40 @@ func (in *AWSClusterStatus) DeepCopyInto(out *AWSClusterStatus) {
 		*out = new(Instance)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SSHKeyPair != nil {
+		in, out := &in.SSHKeyPair, &out.SSHKeyPair
+		*out = new(SSHKeyPairStatus)
+		**out = **in
+	}
+	if in.NodeTerminationHandling != nil {
+		in, out := &in.NodeTerminationHandling, &out.NodeTerminationHandling
+		*out = new(NodeTerminationHandlingStatus)
+		**out = **in
+	}
+	if in.NodeRole != nil {
+		in, out := &in.NodeRole, &out.NodeRole
+		*out = new(NodeRoleStatus)
+		**out = **in
+	}
+	if in.VolumeEncryption != nil {
+		in, out := &in.VolumeEncryption, &out.VolumeEncryption
+		*out = new(VolumeEncryptionStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IdentityCredentialsExpiration != nil {
+		in, out := &in.IdentityCredentialsExpiration, &out.IdentityCredentialsExpiration
+		*out = (*in).DeepCopy()
+	}
+	if in.NetworkSummary != nil {
+		in, out := &in.NetworkSummary, &out.NetworkSummary
+		*out = new(NetworkSummary)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CostSavings != nil {
+		in, out := &in.CostSavings, &out.CostSavings
+		*out = new(CostSavingsStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make(v1beta1.Conditions, len(*in))
@@ -772,6 +841,11 @@ func (in *AWSMachineSpec) DeepCopyInto(out *AWSMachineSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.CapacityReservationResourceGroupARN != nil {
+		in, out := &in.CapacityReservationResourceGroupARN, &out.CapacityReservationResourceGroupARN
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSMachineSpec.
@@ -1291,6 +1365,111 @@ func (in *CloudInit) DeepCopy() *CloudInit {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CostSavings) DeepCopyInto(out *CostSavings) {
+	*out = *in
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(CostSavingsSchedule)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CostSavings.
+func (in *CostSavings) DeepCopy() *CostSavings {
+	if in == nil {
+		return nil
+	}
+	out := new(CostSavings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CostSavingsSchedule) DeepCopyInto(out *CostSavingsSchedule) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]CostSavingsResource, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CostSavingsSchedule.
+func (in *CostSavingsSchedule) DeepCopy() *CostSavingsSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(CostSavingsSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CostSavingsStatus) DeepCopyInto(out *CostSavingsStatus) {
+	*out = *in
+	if in.SuspendedResources != nil {
+		in, out := &in.SuspendedResources, &out.SuspendedResources
+		*out = make([]CostSavingsResource, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastTransitionTime != nil {
+		in, out := &in.LastTransitionTime, &out.LastTransitionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextTransitionTime != nil {
+		in, out := &in.NextTransitionTime, &out.NextTransitionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NatGatewayAllocations != nil {
+		in, out := &in.NatGatewayAllocations, &out.NatGatewayAllocations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CostSavingsStatus.
+func (in *CostSavingsStatus) DeepCopy() *CostSavingsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CostSavingsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DHCPOptions) DeepCopyInto(out *DHCPOptions) {
+	*out = *in
+	if in.DomainName != nil {
+		in, out := &in.DomainName, &out.DomainName
+		*out = new(string)
+		**out = **in
+	}
+	if in.DomainNameServers != nil {
+		in, out := &in.DomainNameServers, &out.DomainNameServers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NTPServers != nil {
+		in, out := &in.NTPServers, &out.NTPServers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DHCPOptions.
+func (in *DHCPOptions) DeepCopy() *DHCPOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(DHCPOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ElasticIPPool) DeepCopyInto(out *ElasticIPPool) {
 	*out = *in
@@ -1336,6 +1515,80 @@ func (in *Filter) DeepCopy() *Filter {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IAMRoleManagement) DeepCopyInto(out *IAMRoleManagement) {
+	*out = *in
+	if in.AdditionalTrustStatements != nil {
+		in, out := &in.AdditionalTrustStatements, &out.AdditionalTrustStatements
+		*out = make([]IAMTrustStatement, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IAMRoleManagement.
+func (in *IAMRoleManagement) DeepCopy() *IAMRoleManagement {
+	if in == nil {
+		return nil
+	}
+	out := new(IAMRoleManagement)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IAMTrustCondition) DeepCopyInto(out *IAMTrustCondition) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IAMTrustCondition.
+func (in *IAMTrustCondition) DeepCopy() *IAMTrustCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(IAMTrustCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IAMTrustStatement) DeepCopyInto(out *IAMTrustStatement) {
+	*out = *in
+	if in.Principals != nil {
+		in, out := &in.Principals, &out.Principals
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Actions != nil {
+		in, out := &in.Actions, &out.Actions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]IAMTrustCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IAMTrustStatement.
+func (in *IAMTrustStatement) DeepCopy() *IAMTrustStatement {
+	if in == nil {
+		return nil
+	}
+	out := new(IAMTrustStatement)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IPAMPool) DeepCopyInto(out *IPAMPool) {
 	*out = *in
@@ -1604,6 +1857,11 @@ func (in *Instance) DeepCopyInto(out *Instance) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.CapacityReservationResourceGroupARN != nil {
+		in, out := &in.CapacityReservationResourceGroupARN, &out.CapacityReservationResourceGroupARN
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Instance.
@@ -1631,6 +1889,21 @@ func (in *InstanceMetadataOptions) DeepCopy() *InstanceMetadataOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LegacyClusterTag) DeepCopyInto(out *LegacyClusterTag) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LegacyClusterTag.
+func (in *LegacyClusterTag) DeepCopy() *LegacyClusterTag {
+	if in == nil {
+		return nil
+	}
+	out := new(LegacyClusterTag)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Listener) DeepCopyInto(out *Listener) {
 	*out = *in
@@ -1718,6 +1991,21 @@ func (in *LoadBalancer) DeepCopy() *LoadBalancer {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalGatewayRouteTable) DeepCopyInto(out *LocalGatewayRouteTable) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalGatewayRouteTable.
+func (in *LocalGatewayRouteTable) DeepCopy() *LocalGatewayRouteTable {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalGatewayRouteTable)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkSpec) DeepCopyInto(out *NetworkSpec) {
 	*out = *in
@@ -1753,6 +2041,16 @@ func (in *NetworkSpec) DeepCopyInto(out *NetworkSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.LocalGatewayRouteTables != nil {
+		in, out := &in.LocalGatewayRouteTables, &out.LocalGatewayRouteTables
+		*out = make([]LocalGatewayRouteTable, len(*in))
+		copy(*out, *in)
+	}
+	if in.LegacyClusterTag != nil {
+		in, out := &in.LegacyClusterTag, &out.LegacyClusterTag
+		*out = new(LegacyClusterTag)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkSpec.
@@ -1794,6 +2092,208 @@ func (in *NetworkStatus) DeepCopy() *NetworkStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkSummary) DeepCopyInto(out *NetworkSummary) {
+	*out = *in
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+	out.VPC = in.VPC
+	if in.CidrBlocks != nil {
+		in, out := &in.CidrBlocks, &out.CidrBlocks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]NetworkSummaryZone, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SecurityGroups != nil {
+		in, out := &in.SecurityGroups, &out.SecurityGroups
+		*out = make([]NetworkSummarySecurityGroup, len(*in))
+		copy(*out, *in)
+	}
+	if in.NatGatewaysIPs != nil {
+		in, out := &in.NatGatewaysIPs, &out.NatGatewaysIPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkSummary.
+func (in *NetworkSummary) DeepCopy() *NetworkSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkSummaryNatGateway) DeepCopyInto(out *NetworkSummaryNatGateway) {
+	*out = *in
+	out.NetworkSummaryResource = in.NetworkSummaryResource
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkSummaryNatGateway.
+func (in *NetworkSummaryNatGateway) DeepCopy() *NetworkSummaryNatGateway {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkSummaryNatGateway)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkSummaryResource) DeepCopyInto(out *NetworkSummaryResource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkSummaryResource.
+func (in *NetworkSummaryResource) DeepCopy() *NetworkSummaryResource {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkSummaryResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkSummarySecurityGroup) DeepCopyInto(out *NetworkSummarySecurityGroup) {
+	*out = *in
+	out.NetworkSummaryResource = in.NetworkSummaryResource
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkSummarySecurityGroup.
+func (in *NetworkSummarySecurityGroup) DeepCopy() *NetworkSummarySecurityGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkSummarySecurityGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkSummarySubnet) DeepCopyInto(out *NetworkSummarySubnet) {
+	*out = *in
+	out.NetworkSummaryResource = in.NetworkSummaryResource
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkSummarySubnet.
+func (in *NetworkSummarySubnet) DeepCopy() *NetworkSummarySubnet {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkSummarySubnet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkSummaryZone) DeepCopyInto(out *NetworkSummaryZone) {
+	*out = *in
+	if in.PublicSubnets != nil {
+		in, out := &in.PublicSubnets, &out.PublicSubnets
+		*out = make([]NetworkSummarySubnet, len(*in))
+		copy(*out, *in)
+	}
+	if in.PrivateSubnets != nil {
+		in, out := &in.PrivateSubnets, &out.PrivateSubnets
+		*out = make([]NetworkSummarySubnet, len(*in))
+		copy(*out, *in)
+	}
+	if in.NatGateways != nil {
+		in, out := &in.NatGateways, &out.NatGateways
+		*out = make([]NetworkSummaryNatGateway, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkSummaryZone.
+func (in *NetworkSummaryZone) DeepCopy() *NetworkSummaryZone {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkSummaryZone)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeRoleManagement) DeepCopyInto(out *NodeRoleManagement) {
+	*out = *in
+	if in.AdditionalPolicies != nil {
+		in, out := &in.AdditionalPolicies, &out.AdditionalPolicies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeRoleManagement.
+func (in *NodeRoleManagement) DeepCopy() *NodeRoleManagement {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeRoleManagement)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeRoleStatus) DeepCopyInto(out *NodeRoleStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeRoleStatus.
+func (in *NodeRoleStatus) DeepCopy() *NodeRoleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeRoleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeTerminationHandling) DeepCopyInto(out *NodeTerminationHandling) {
+	*out = *in
+	if in.ManagedRules != nil {
+		in, out := &in.ManagedRules, &out.ManagedRules
+		*out = make([]NodeTerminationHandlingRule, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeTerminationHandling.
+func (in *NodeTerminationHandling) DeepCopy() *NodeTerminationHandling {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeTerminationHandling)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeTerminationHandlingStatus) DeepCopyInto(out *NodeTerminationHandlingStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeTerminationHandlingStatus.
+func (in *NodeTerminationHandlingStatus) DeepCopy() *NodeTerminationHandlingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeTerminationHandlingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PrivateDNSName) DeepCopyInto(out *PrivateDNSName) {
 	*out = *in
@@ -1824,6 +2324,36 @@ func (in *PrivateDNSName) DeepCopy() *PrivateDNSName {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceNameData) DeepCopyInto(out *ResourceNameData) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceNameData.
+func (in *ResourceNameData) DeepCopy() *ResourceNameData {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceNameData)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceNaming) DeepCopyInto(out *ResourceNaming) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceNaming.
+func (in *ResourceNaming) DeepCopy() *ResourceNaming {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceNaming)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RouteTable) DeepCopyInto(out *RouteTable) {
 	*out = *in
@@ -1869,6 +2399,36 @@ func (in *S3Bucket) DeepCopy() *S3Bucket {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSHKey) DeepCopyInto(out *SSHKey) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSHKey.
+func (in *SSHKey) DeepCopy() *SSHKey {
+	if in == nil {
+		return nil
+	}
+	out := new(SSHKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSHKeyPairStatus) DeepCopyInto(out *SSHKeyPairStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSHKeyPairStatus.
+func (in *SSHKeyPairStatus) DeepCopy() *SSHKeyPairStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SSHKeyPairStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecurityGroup) DeepCopyInto(out *SecurityGroup) {
 	*out = *in
@@ -1948,6 +2508,11 @@ func (in *SubnetSpec) DeepCopyInto(out *SubnetSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.OutpostARN != nil {
+		in, out := &in.OutpostARN, &out.OutpostARN
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetSpec.
@@ -2217,6 +2782,16 @@ func (in *VPCSpec) DeepCopyInto(out *VPCSpec) {
 		*out = new(SubnetSchemaType)
 		**out = **in
 	}
+	if in.DHCPOptions != nil {
+		in, out := &in.DHCPOptions, &out.DHCPOptions
+		*out = new(DHCPOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DHCPOptionsID != nil {
+		in, out := &in.DHCPOptionsID, &out.DHCPOptionsID
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VPCSpec.
@@ -2242,6 +2817,16 @@ func (in *Volume) DeepCopyInto(out *Volume) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.DeleteOnTermination != nil {
+		in, out := &in.DeleteOnTermination, &out.DeleteOnTermination
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SnapshotID != nil {
+		in, out := &in.SnapshotID, &out.SnapshotID
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Volume.
@@ -2254,6 +2839,27 @@ func (in *Volume) DeepCopy() *Volume {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeEncryptionStatus) DeepCopyInto(out *VolumeEncryptionStatus) {
+	*out = *in
+	in.LastCheckTime.DeepCopyInto(&out.LastCheckTime)
+	if in.UnencryptedInstanceIDs != nil {
+		in, out := &in.UnencryptedInstanceIDs, &out.UnencryptedInstanceIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeEncryptionStatus.
+func (in *VolumeEncryptionStatus) DeepCopy() *VolumeEncryptionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeEncryptionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VpcCidrBlock) DeepCopyInto(out *VpcCidrBlock) {
 	*out = *in
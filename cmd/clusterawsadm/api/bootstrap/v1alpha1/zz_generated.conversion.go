@@ -208,6 +208,7 @@ func autoConvert_v1beta1_AWSIAMConfigurationSpec_To_v1alpha1_AWSIAMConfiguration
 	out.SecureSecretsBackends = *(*[]v1beta2.SecretBackend)(unsafe.Pointer(&in.SecureSecretsBackends))
 	// WARNING: in.S3Buckets requires manual conversion: does not exist in peer-type
 	// WARNING: in.AllowAssumeRole requires manual conversion: does not exist in peer-type
+	// WARNING: in.AllowNodeRoleCreation requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -227,6 +227,10 @@ type AWSIAMConfigurationSpec struct {
 
 	// AllowAssumeRole enables the sts:AssumeRole permission within the CAPA policies
 	AllowAssumeRole bool `json:"allowAssumeRole,omitempty"`
+
+	// AllowNodeRoleCreation grants the permissions to create an IAM role and an instance profile for
+	// the nodes of each cluster, required by AWSClusters setting spec.nodeRoleManagement.create.
+	AllowNodeRoleCreation bool `json:"allowNodeRoleCreation,omitempty"`
 }
 
 // GetObjectKind returns the AAWSIAMConfiguration's TypeMeta.
@@ -19,6 +19,7 @@ package bootstrap
 import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	iamv1 "sigs.k8s.io/cluster-api-provider-aws/v2/iam/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/iam/policies"
 )
 
 func (t Template) secretPolicy(secureSecretsBackend infrav1.SecretBackend) iamv1.StatementEntry {
@@ -98,5 +99,5 @@ func (t Template) nodePolicy() *iamv1.PolicyDocument {
 }
 
 func (t Template) generateAWSManagedPolicyARN(name string) string {
-	return "arn:" + t.Spec.Partition + ":iam::aws:policy/" + name
+	return policies.ManagedPolicyARN(t.Spec.Partition, name)
 }
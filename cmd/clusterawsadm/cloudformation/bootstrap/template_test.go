@@ -183,6 +183,14 @@ func TestRenderCloudformation(t *testing.T) {
 				return t
 			},
 		},
+		{
+			fixture: "with_node_role_creation",
+			template: func() Template {
+				t := NewTemplate()
+				t.Spec.AllowNodeRoleCreation = true
+				return t
+			},
+		},
 	}
 
 	for _, c := range cases {
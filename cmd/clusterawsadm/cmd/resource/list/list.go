@@ -22,7 +22,9 @@ import (
 	"os"
 
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/cmd/flags"
 	cmdout "sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/printers"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/resource"
@@ -34,6 +36,7 @@ func ListAWSResourceCmd() *cobra.Command {
 	outputPrinterType := ""
 	clusterName := ""
 	region := ""
+	ownershipTagPrefix := ""
 	newCmd := &cobra.Command{
 		Use:   "list",
 		Short: "List all AWS resources created by CAPA",
@@ -45,6 +48,9 @@ func ListAWSResourceCmd() *cobra.Command {
 		Example: cmd.Examples(`
 		# List AWS resources directly created by CAPA in given region and clustername
 		clusterawsadm resource list --region=us-east-1 --cluster-name=test-cluster
+
+		# List AWS resources of a cluster whose ownership tag uses a custom prefix
+		clusterawsadm resource list --region=us-east-1 --cluster-name=test-cluster --ownership-tag-prefix=example.com/cluster/
 		`),
 		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -53,9 +59,12 @@ func ListAWSResourceCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			if errs := infrav1.ValidateOwnershipTagPrefix(ownershipTagPrefix, clusterName, field.NewPath("ownership-tag-prefix")); len(errs) > 0 {
+				return errs.ToAggregate()
+			}
 
 			fmt.Fprintf(os.Stdout, "Attempting to fetch resources created by CAPA for cluster:%s present in %s\n\n", clusterName, region)
-			resourceList, err := resource.ListAWSResource(&region, &clusterName)
+			resourceList, err := resource.ListAWSResource(&region, &clusterName, &ownershipTagPrefix)
 			if err != nil || len(resourceList.AWSResources) == 0 {
 				return err
 			}
@@ -79,6 +88,7 @@ func ListAWSResourceCmd() *cobra.Command {
 
 	newCmd.Flags().StringVarP(&region, "region", "r", "", "The AWS region where resources are created by CAPA")
 	newCmd.Flags().StringVarP(&clusterName, "cluster-name", "n", "", "The name of the cluster where AWS resources created by CAPA")
+	newCmd.Flags().StringVar(&ownershipTagPrefix, "ownership-tag-prefix", "", fmt.Sprintf("The prefix of the ownership tag key of the cluster, if it was changed from %s", infrav1.NameAWSProviderOwned))
 	newCmd.Flags().StringVarP(&outputPrinterType, "output", "o", "table", "The output format of the results. Possible values: table, json, yaml")
 	newCmd.MarkFlagRequired("cluster-name") //nolint: errcheck
 	return newCmd
@@ -124,7 +124,7 @@ func (c *CmdProcessor) Disable(ctx context.Context) error {
 
 // Configure is used to configure external resource garbage collection for a cluster.
 func (c *CmdProcessor) Configure(ctx context.Context, gcTasks []string) error {
-	supportedGCTasks := []infrav1.GCTask{infrav1.GCTaskLoadBalancer, infrav1.GCTaskTargetGroup, infrav1.GCTaskSecurityGroup}
+	supportedGCTasks := []infrav1.GCTask{infrav1.GCTaskLoadBalancer, infrav1.GCTaskTargetGroup, infrav1.GCTaskSecurityGroup, infrav1.GCTaskVolume}
 
 	for _, gcTask := range gcTasks {
 		found := false
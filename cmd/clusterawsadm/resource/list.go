@@ -27,8 +27,9 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 )
 
-// ListAWSResource fetches all AWS resources created by CAPA.
-func ListAWSResource(region, clusterName *string) (AWSResourceList, error) {
+// ListAWSResource fetches all AWS resources created by CAPA. The resources are looked up by their ownership tag, whose
+// key is made of the ownership tag prefix and the cluster name, NameAWSProviderOwned being used if the prefix is empty.
+func ListAWSResource(region, clusterName, ownershipTagPrefix *string) (AWSResourceList, error) {
 	var resourceList AWSResourceList
 	cfg := aws.Config{}
 	if *region != "" {
@@ -49,8 +50,9 @@ func ListAWSResource(region, clusterName *string) (AWSResourceList, error) {
 	}
 
 	awsResourceTags := infrav1.Build(infrav1.BuildParams{
-		ClusterName: *clusterName,
-		Lifecycle:   infrav1.ResourceLifecycleOwned,
+		ClusterName:        *clusterName,
+		Lifecycle:          infrav1.ResourceLifecycleOwned,
+		OwnershipTagPrefix: *ownershipTagPrefix,
 	})
 
 	for tagKey, tagValue := range awsResourceTags {
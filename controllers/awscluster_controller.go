@@ -39,16 +39,26 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/feature"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/costsavings"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/ec2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/elb"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/endpointprobe"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/gc"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/instancestate"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/network"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/networksummary"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/noderole"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/nodetermination"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/ownershiptags"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/permissions"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/quotas"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/s3"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/securitygroup"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/volumeencryption"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
 	infrautilconditions "sigs.k8s.io/cluster-api-provider-aws/v2/util/conditions"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -65,6 +75,10 @@ var defaultAWSSecurityGroupRoles = []infrav1.SecurityGroupRole{
 	infrav1.SecurityGroupNode,
 }
 
+// nodeRoleInUseRequeueAfter is how long to wait before retrying the removal of a node instance profile which is still
+// used by instances.
+const nodeRoleInUseRequeueAfter = time.Minute
+
 // AWSClusterReconciler reconciles a AwsCluster object.
 type AWSClusterReconciler struct {
 	client.Client
@@ -78,6 +92,17 @@ type AWSClusterReconciler struct {
 	ExternalResourceGC           bool
 	AlternativeGCStrategy        bool
 	TagUnmanagedNetworkResources bool
+	OwnershipTagPrefix           string
+	// PermissionsChecker checks the IAM permissions of the controllers for each cluster when set.
+	PermissionsChecker *permissions.Checker
+	// EndpointProber probes the API server endpoint of each cluster from the management cluster when set.
+	EndpointProber *endpointprobe.Prober
+	// VolumeEncryptionReporter reports the encryption of the root volumes of each cluster when set.
+	VolumeEncryptionReporter *volumeencryption.Reporter
+	// NetworkSummary publishes the network resources of each cluster in its status when set.
+	NetworkSummary bool
+	// QuotaChecker checks the account quotas of the resources pending for each cluster when set.
+	QuotaChecker *quotas.Checker
 }
 
 // getEC2Service factory func is added for testing purpose so that we can inject mocked EC2Service to the AWSClusterReconciler.
@@ -127,6 +152,8 @@ func (r *AWSClusterReconciler) getSecurityGroupService(scope scope.ClusterScope)
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsclusters,verbs=get;list;watch;update;patch;delete
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsclusters/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters;clusters/status,verbs=get;list;watch
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machines;machinepools,verbs=get;list;watch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsmachinepools,verbs=get;list;watch
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsclusterroleidentities;awsclusterstaticidentities,verbs=get;list;watch
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsclustercontrolleridentities,verbs=get;list;watch;create
 
@@ -177,6 +204,7 @@ func (r *AWSClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		ControllerName:               "awscluster",
 		Endpoints:                    r.Endpoints,
 		TagUnmanagedNetworkResources: r.TagUnmanagedNetworkResources,
+		OwnershipTagPrefix:           r.OwnershipTagPrefix,
 	})
 	if err != nil {
 		return reconcile.Result{}, errors.Errorf("failed to create scope: %+v", err)
@@ -191,6 +219,9 @@ func (r *AWSClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 	// Handle deleted clusters
 	if !awsCluster.DeletionTimestamp.IsZero() {
+		if scope.DeferDeletion(clusterScope, clusterScope) {
+			return ctrl.Result{RequeueAfter: scope.ReadOnlyRequeueAfter}, nil
+		}
 		return ctrl.Result{}, r.reconcileDelete(ctx, clusterScope)
 	}
 
@@ -241,6 +272,28 @@ func (r *AWSClusterReconciler) reconcileDelete(ctx context.Context, clusterScope
 		allErrs = append(allErrs, errors.Wrapf(err, "error deleting bastion"))
 	}
 
+	if clusterScope.SSHKey() != nil {
+		if err := ec2svc.DeleteSSHKeyPair(clusterScope); err != nil {
+			allErrs = append(allErrs, errors.Wrapf(err, "error deleting SSH key pair"))
+		}
+	}
+
+	if nth := clusterScope.NodeTerminationHandling(); (nth != nil && nth.Enabled) || clusterScope.NodeTerminationHandlingStatus() != nil {
+		if err := nodetermination.NewService(clusterScope).DeleteNodeTerminationHandling(); err != nil {
+			allErrs = append(allErrs, errors.Wrap(err, "error deleting node termination handler resources"))
+		}
+	}
+
+	if m := clusterScope.NodeRoleManagement(); (m != nil && m.Create) || clusterScope.NodeRoleStatus() != nil {
+		if err := noderole.NewService(clusterScope).DeleteNodeRole(); err != nil {
+			allErrs = append(allErrs, errors.Wrap(err, "error deleting node role"))
+		}
+	}
+
+	if r.EndpointProber != nil {
+		endpointprobe.NewService(clusterScope, r.EndpointProber).DeleteAPIEndpoint()
+	}
+
 	if err := sgService.DeleteSecurityGroups(); err != nil {
 		allErrs = append(allErrs, errors.Wrap(err, "error deleting security groups"))
 	}
@@ -277,6 +330,10 @@ func (r *AWSClusterReconciler) reconcileLoadBalancer(clusterScope *scope.Cluster
 
 	if err := elbService.ReconcileLoadbalancers(); err != nil {
 		clusterScope.Error(err, "failed to reconcile load balancer")
+		if elb.IsLoadBalancerSubnetInvalid(err) {
+			conditions.MarkFalse(awsCluster, infrav1.LoadBalancerReadyCondition, infrav1.LoadBalancerSubnetInvalidReason, clusterv1.ConditionSeverityError, err.Error())
+			return nil, err
+		}
 		conditions.MarkFalse(awsCluster, infrav1.LoadBalancerReadyCondition, infrav1.LoadBalancerFailedReason, infrautilconditions.ErrorConditionAfterInit(clusterScope.ClusterObj()), err.Error())
 		return nil, err
 	}
@@ -297,6 +354,32 @@ func (r *AWSClusterReconciler) reconcileLoadBalancer(clusterScope *scope.Cluster
 	return nil, nil
 }
 
+// reconcileQuotas checks that the ASGs and launch templates the AWSMachinePools of the cluster are waiting for
+// fit in the quotas of its account and region.
+func (r *AWSClusterReconciler) reconcileQuotas(clusterScope *scope.ClusterScope) error {
+	pending := map[quotas.Resource]int{}
+	if feature.Gates.Enabled(feature.MachinePool) {
+		machinePools := &expinfrav1.AWSMachinePoolList{}
+		if err := r.List(context.TODO(), machinePools, client.InNamespace(clusterScope.Namespace()),
+			client.MatchingLabels{clusterv1.ClusterNameLabel: clusterScope.Name()}); err != nil {
+			return errors.Wrap(err, "failed to list AWSMachinePools")
+		}
+		for _, machinePool := range machinePools.Items {
+			if !machinePool.DeletionTimestamp.IsZero() {
+				continue
+			}
+			if machinePool.Spec.ProviderID == "" && machinePool.Status.ASG == nil {
+				pending[quotas.ResourceAutoScalingGroups]++
+			}
+			if machinePool.Status.LaunchTemplateID == "" && machinePool.Spec.AWSLaunchTemplate.Ref == nil {
+				pending[quotas.ResourceLaunchTemplates]++
+			}
+		}
+	}
+
+	return quotas.NewService(clusterScope, r.QuotaChecker).ReconcileQuotas(pending)
+}
+
 func (r *AWSClusterReconciler) reconcileNormal(clusterScope *scope.ClusterScope) (reconcile.Result, error) {
 	clusterScope.Info("Reconciling AWSCluster")
 
@@ -310,11 +393,36 @@ func (r *AWSClusterReconciler) reconcileNormal(clusterScope *scope.ClusterScope)
 		}
 	}
 
+	if r.PermissionsChecker != nil {
+		if err := permissions.NewService(clusterScope, r.PermissionsChecker).ReconcilePermissions(); err != nil {
+			// non fatal error, so we continue
+			clusterScope.Error(err, "non-fatal: failed to check controller permissions")
+		}
+	}
+
 	ec2Service := r.getEC2Service(clusterScope)
 	networkSvc := r.getNetworkService(*clusterScope)
 	sgService := r.getSecurityGroupService(*clusterScope)
 	s3Service := s3.NewService(clusterScope)
 
+	// Resources must carry the ownership tag with the current prefix before they're looked up by it.
+	// Otherwise, the prefix is recorded so that a later change of the default prefix migrates them as well.
+	if clusterScope.PreviousOwnershipTagPrefix() != "" {
+		if err := ownershiptags.NewService(clusterScope).ReconcileOwnershipTags(); err != nil {
+			clusterScope.Error(err, "failed to migrate ownership tags")
+			return reconcile.Result{}, err
+		}
+	} else {
+		clusterScope.CompleteOwnershipTagMigration()
+	}
+
+	// The resources suspended by the cost savings schedule are acted on by the network and bastion reconciliations.
+	costSavingsRequeue, err := costsavings.NewService(clusterScope, r.Client).ReconcileSchedule(context.TODO())
+	if err != nil {
+		// non fatal error, so we continue
+		clusterScope.Error(err, "non-fatal: failed to reconcile the cost savings schedule")
+	}
+
 	if err := networkSvc.ReconcileNetwork(); err != nil {
 		clusterScope.Error(err, "failed to reconcile network")
 		return reconcile.Result{}, err
@@ -326,6 +434,13 @@ func (r *AWSClusterReconciler) reconcileNormal(clusterScope *scope.ClusterScope)
 		return reconcile.Result{}, err
 	}
 
+	if clusterScope.SSHKey() != nil {
+		if err := ec2Service.ReconcileSSHKeyPair(clusterScope); err != nil {
+			clusterScope.Error(err, "failed to reconcile SSH key pair")
+			return reconcile.Result{}, err
+		}
+	}
+
 	if err := ec2Service.ReconcileBastion(); err != nil {
 		conditions.MarkFalse(awsCluster, infrav1.BastionHostReadyCondition, infrav1.BastionHostFailedReason, infrautilconditions.ErrorConditionAfterInit(clusterScope.ClusterObj()), err.Error())
 		clusterScope.Error(err, "failed to reconcile bastion host")
@@ -340,6 +455,32 @@ func (r *AWSClusterReconciler) reconcileNormal(clusterScope *scope.ClusterScope)
 		}
 	}
 
+	if clusterScope.NodeTerminationHandling() != nil || clusterScope.NodeTerminationHandlingStatus() != nil {
+		if err := nodetermination.NewService(clusterScope).ReconcileNodeTerminationHandling(); err != nil {
+			clusterScope.Error(err, "failed to reconcile node termination handler resources")
+			return reconcile.Result{}, err
+		}
+	}
+
+	var nodeRoleRequeue time.Duration
+	if clusterScope.NodeRoleManagement() != nil || clusterScope.NodeRoleStatus() != nil {
+		switch err := noderole.NewService(clusterScope).ReconcileNodeRole(); {
+		case errors.Is(err, noderole.ErrNodeRoleInUse):
+			// The instance profile is removed once the instances using it are gone, which doesn't block the
+			// rest of the cluster.
+			conditions.MarkFalse(awsCluster, infrav1.NodeRoleReadyCondition, infrav1.NodeRoleInUseReason, clusterv1.ConditionSeverityWarning, err.Error())
+			nodeRoleRequeue = nodeRoleInUseRequeueAfter
+		case err != nil:
+			clusterScope.Error(err, "failed to reconcile node role")
+			conditions.MarkFalse(awsCluster, infrav1.NodeRoleReadyCondition, infrav1.NodeRoleReconciliationFailedReason, clusterv1.ConditionSeverityError, err.Error())
+			return reconcile.Result{}, err
+		case clusterScope.NodeRoleStatus() != nil:
+			conditions.MarkTrue(awsCluster, infrav1.NodeRoleReadyCondition)
+		default:
+			conditions.Delete(awsCluster, infrav1.NodeRoleReadyCondition)
+		}
+	}
+
 	if requeueAfter, err := r.reconcileLoadBalancer(clusterScope, awsCluster); err != nil {
 		return reconcile.Result{}, err
 	} else if requeueAfter != nil {
@@ -352,6 +493,36 @@ func (r *AWSClusterReconciler) reconcileNormal(clusterScope *scope.ClusterScope)
 	}
 	conditions.MarkTrue(awsCluster, infrav1.S3BucketReadyCondition)
 
+	var endpointProbeRequeue time.Duration
+	if r.EndpointProber != nil {
+		endpointProbeRequeue, err = endpointprobe.NewService(clusterScope, r.EndpointProber).ReconcileAPIEndpoint()
+		if err != nil {
+			// non fatal error, so we continue
+			clusterScope.Error(err, "non-fatal: failed to probe API server endpoint")
+		}
+	}
+
+	if r.VolumeEncryptionReporter != nil {
+		if err := volumeencryption.NewService(clusterScope, r.VolumeEncryptionReporter).ReconcileVolumeEncryption(); err != nil {
+			// non fatal error, so we continue
+			clusterScope.Error(err, "non-fatal: failed to report volume encryption")
+		}
+	}
+
+	if r.NetworkSummary {
+		if err := networksummary.NewService(clusterScope).ReconcileNetworkSummary(); err != nil {
+			// non fatal error, so we continue
+			clusterScope.Error(err, "non-fatal: failed to publish network summary")
+		}
+	}
+
+	if r.QuotaChecker != nil {
+		if err := r.reconcileQuotas(clusterScope); err != nil {
+			// non fatal error, so we continue
+			clusterScope.Error(err, "non-fatal: failed to check account quotas")
+		}
+	}
+
 	for _, subnet := range clusterScope.Subnets().FilterPrivate() {
 		found := false
 		for _, az := range awsCluster.Status.Network.APIServerELB.AvailabilityZones {
@@ -367,7 +538,14 @@ func (r *AWSClusterReconciler) reconcileNormal(clusterScope *scope.ClusterScope)
 	}
 
 	awsCluster.Status.Ready = true
-	return reconcile.Result{}, nil
+	requeueAfter := costSavingsRequeue
+	if endpointProbeRequeue > 0 && (requeueAfter == 0 || endpointProbeRequeue < requeueAfter) {
+		requeueAfter = endpointProbeRequeue
+	}
+	if nodeRoleRequeue > 0 && (requeueAfter == 0 || nodeRoleRequeue < requeueAfter) {
+		requeueAfter = nodeRoleRequeue
+	}
+	return reconcile.Result{RequeueAfter: requeueAfter}, nil
 }
 
 func (r *AWSClusterReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
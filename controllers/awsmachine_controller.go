@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/blang/semver"
 	ignTypes "github.com/coreos/ignition/config/v2_3/types"
 	ignV3Types "github.com/coreos/ignition/v2/config/v3_4/types"
@@ -51,6 +52,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/drift"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/ec2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/elb"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/instancestate"
@@ -59,6 +61,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/ssm"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/userdata"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
+	infrautilconditions "sigs.k8s.io/cluster-api-provider-aws/v2/util/conditions"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	capierrors "sigs.k8s.io/cluster-api/errors"
 	"sigs.k8s.io/cluster-api/util"
@@ -73,6 +76,10 @@ const (
 
 	// DefaultReconcilerRequeue is the default value for the reconcile retry.
 	DefaultReconcilerRequeue = 30 * time.Second
+
+	// ELBTargetHealthTimeout is how long the target of a control plane machine may stay unhealthy
+	// after its registration before the ELBAttached condition reports a timeout.
+	ELBTargetHealthTimeout = 10 * time.Minute
 )
 
 // AWSMachineReconciler reconciles a AwsMachine object.
@@ -88,6 +95,9 @@ type AWSMachineReconciler struct {
 	Endpoints                    []scope.ServiceEndpoint
 	WatchFilterValue             string
 	TagUnmanagedNetworkResources bool
+	OwnershipTagPrefix           string
+	// DriftAuditor reports the attributes of the instances changed outside of the controller when set.
+	DriftAuditor *drift.Auditor
 }
 
 const (
@@ -185,7 +195,8 @@ func (r *AWSMachineReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 	if annotations.IsPaused(cluster, awsMachine) {
 		log.Info("AWSMachine or linked Cluster is marked as paused. Won't reconcile")
-		return ctrl.Result{}, nil
+		return ctrl.Result{}, infrautilconditions.PatchReconciliationSkipped(ctx, r.Client, r.Recorder, awsMachine,
+			infrav1.ReconciliationPausedReason, clusterv1.ConditionSeverityInfo, "AWSMachine or linked Cluster is marked as paused")
 	}
 
 	log = log.WithValues("cluster", klog.KObj(cluster))
@@ -224,12 +235,18 @@ func (r *AWSMachineReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	switch infraScope := infraCluster.(type) {
 	case *scope.ManagedControlPlaneScope:
 		if !awsMachine.ObjectMeta.DeletionTimestamp.IsZero() {
+			if scope.DeferDeletion(machineScope, infraScope) {
+				return ctrl.Result{RequeueAfter: scope.ReadOnlyRequeueAfter}, nil
+			}
 			return r.reconcileDelete(machineScope, infraScope, infraScope, nil, nil)
 		}
 
 		return r.reconcileNormal(ctx, machineScope, infraScope, infraScope, nil, nil)
 	case *scope.ClusterScope:
 		if !awsMachine.ObjectMeta.DeletionTimestamp.IsZero() {
+			if scope.DeferDeletion(machineScope, infraScope) {
+				return ctrl.Result{RequeueAfter: scope.ReadOnlyRequeueAfter}, nil
+			}
 			return r.reconcileDelete(machineScope, infraScope, infraScope, infraScope, infraScope)
 		}
 
@@ -303,9 +320,12 @@ func (r *AWSMachineReconciler) reconcileDelete(machineScope *scope.MachineScope,
 
 	ec2Service := r.getEC2Service(ec2Scope)
 
-	if err := r.deleteBootstrapData(machineScope, clusterScope, objectStoreScope); err != nil {
-		machineScope.Error(err, "unable to delete machine")
-		return ctrl.Result{}, err
+	// The instances of machine pools are launched without bootstrap data of their machine.
+	if !machineScope.IsMachinePoolMachine() {
+		if err := r.deleteBootstrapData(machineScope, clusterScope, objectStoreScope); err != nil {
+			machineScope.Error(err, "unable to delete machine")
+			return ctrl.Result{}, err
+		}
 	}
 
 	instance, err := r.findInstance(machineScope, ec2Service)
@@ -465,6 +485,8 @@ func (r *AWSMachineReconciler) reconcileNormal(_ context.Context, machineScope *
 	// If the AWSMachine is in an error state, return early.
 	if machineScope.HasFailed() {
 		machineScope.Info("Error state detected, skipping reconciliation")
+		infrautilconditions.MarkReconciliationSkipped(r.Recorder, machineScope.AWSMachine, infrav1.FailedStateReason, clusterv1.ConditionSeverityWarning,
+			"AWSMachine is in a failed state: %s", ptr.Deref(machineScope.AWSMachine.Status.FailureMessage, ""))
 
 		// If we are in a failed state, delete the secret regardless of instance state.
 		if err := r.deleteBootstrapData(machineScope, clusterScope, objectStoreScope); err != nil {
@@ -478,20 +500,33 @@ func (r *AWSMachineReconciler) reconcileNormal(_ context.Context, machineScope *
 	if !machineScope.Cluster.Status.InfrastructureReady {
 		machineScope.Info("Cluster infrastructure is not ready yet")
 		conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, infrav1.WaitingForClusterInfrastructureReason, clusterv1.ConditionSeverityInfo, "")
+		infrautilconditions.MarkReconciliationSkipped(r.Recorder, machineScope.AWSMachine, infrav1.WaitingForClusterInfrastructureReason, clusterv1.ConditionSeverityInfo,
+			"Cluster infrastructure is not ready yet")
 		return ctrl.Result{}, nil
 	}
 
-	// Make sure bootstrap data is available and populated.
-	if machineScope.Machine.Spec.Bootstrap.DataSecretName == nil {
+	// Make sure bootstrap data is available and populated. The instances of machine pools are launched by the pools.
+	if !machineScope.IsMachinePoolMachine() && machineScope.Machine.Spec.Bootstrap.DataSecretName == nil {
 		machineScope.Info("Bootstrap data secret reference is not yet available")
 		conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, infrav1.WaitingForBootstrapDataReason, clusterv1.ConditionSeverityInfo, "")
+		infrautilconditions.MarkReconciliationSkipped(r.Recorder, machineScope.AWSMachine, infrav1.WaitingForBootstrapDataReason, clusterv1.ConditionSeverityInfo,
+			"Bootstrap data secret reference is not yet available")
 		return ctrl.Result{}, nil
 	}
 
+	infrautilconditions.ClearReconciliationSkipped(r.Recorder, machineScope.AWSMachine)
+
 	ec2svc := r.getEC2Service(ec2Scope)
 
 	// Find existing instance
 	instance, err := r.findInstance(machineScope, ec2svc)
+	if machineScope.IsMachinePoolMachine() && (errors.Is(err, ec2.ErrInstanceNotFoundByID) || (err == nil && instance == nil)) {
+		// The machine pool deletes the machines of its instances which are gone.
+		machineScope.Info("Machine pool instance not found", "provider-id", machineScope.GetProviderID())
+		machineScope.SetNotReady()
+		conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, infrav1.InstanceNotFoundReason, clusterv1.ConditionSeverityWarning, "")
+		return ctrl.Result{}, nil
+	}
 	if err != nil {
 		machineScope.Error(err, "unable to find instance")
 		conditions.MarkUnknown(machineScope.AWSMachine, infrav1.InstanceReadyCondition, infrav1.InstanceNotFoundReason, err.Error())
@@ -598,6 +633,17 @@ func (r *AWSMachineReconciler) reconcileNormal(_ context.Context, machineScope *
 		conditions.MarkUnknown(machineScope.AWSMachine, infrav1.InstanceReadyCondition, "", "")
 	}
 
+	// The machine pool manages its instances, the state of which is only reported.
+	if machineScope.IsMachinePoolMachine() {
+		if machineScope.InstanceIsOperational() {
+			machineScope.SetAddresses(instance.Addresses)
+		}
+		if shouldRequeue {
+			return ctrl.Result{RequeueAfter: DefaultReconcilerRequeue}, nil
+		}
+		return ctrl.Result{}, nil
+	}
+
 	// reconcile the deletion of the bootstrap data secret now that we have updated instance state
 	if deleteSecretErr := r.deleteBootstrapData(machineScope, clusterScope, objectStoreScope); deleteSecretErr != nil {
 		r.Log.Error(deleteSecretErr, "unable to delete secrets")
@@ -619,15 +665,31 @@ func (r *AWSMachineReconciler) reconcileNormal(_ context.Context, machineScope *
 
 		if instance != nil {
 			r.ensureStorageTags(ec2svc, instance, machineScope.AWSMachine, machineScope.AdditionalTags())
+
+			if ec2Scope.LegacyClusterTag() != nil {
+				if err := ec2svc.ReconcileLegacyClusterTag(instance); err != nil {
+					machineScope.Error(err, "failed to reconcile the legacy cluster tag")
+					return ctrl.Result{}, err
+				}
+			}
+
+			// The audit runs before the security groups are reconciled, so that their changes are reported before
+			// they are reverted.
+			r.reconcileInstanceDrift(ec2svc, ec2Scope, machineScope, instance)
 		}
 
 		if err := r.reconcileLBAttachment(machineScope, elbScope, instance); err != nil {
-			// We are tolerating InstanceNotRunning error, so we don't report it as an error condition.
+			// We are tolerating InstanceNotRunning and InstanceNotHealthy errors, so we don't report them as an error condition.
 			// Because we are reconciling all load balancers, attempt to treat the error as a list of errors.
-			if err := kerrors.FilterOut(err, elb.IsInstanceNotRunning); err != nil {
+			if err := kerrors.FilterOut(err, elb.IsInstanceNotRunning, elb.IsInstanceNotHealthy); err != nil {
 				machineScope.Error(err, "failed to reconcile LB attachment")
 				return ctrl.Result{}, err
 			}
+			// Hold the machine until its target is healthy, so the control plane isn't rolled while the
+			// load balancer can't route to it yet.
+			if kerrors.FilterOut(err, elb.IsInstanceNotRunning) != nil {
+				machineScope.SetNotReady()
+			}
 			// Cannot attach non-running instances to LB
 			shouldRequeue = true
 		}
@@ -676,6 +738,34 @@ func (r *AWSMachineReconciler) reconcileOperationalState(ec2svc services.EC2Inte
 	return nil
 }
 
+// reconcileInstanceDrift compares the instance with the spec of the AWSMachine when the drift audit is enabled.
+func (r *AWSMachineReconciler) reconcileInstanceDrift(ec2svc services.EC2Interface, ec2Scope scope.EC2Scope, machineScope *scope.MachineScope, instance *infrav1.Instance) {
+	if r.DriftAuditor == nil {
+		return
+	}
+
+	expected := func() (*drift.Expected, error) {
+		core, err := ec2svc.GetCoreSecurityGroups(machineScope)
+		if err != nil {
+			return nil, err
+		}
+		additional, err := ec2svc.GetAdditionalSecurityGroupsIDs(machineScope.AWSMachine.Spec.AdditionalSecurityGroups)
+		if err != nil {
+			return nil, err
+		}
+		return &drift.Expected{
+			InstanceType:       machineScope.AWSMachine.Spec.InstanceType,
+			IAMInstanceProfile: machineScope.AWSMachine.Spec.IAMInstanceProfile,
+			SecurityGroupIDs:   append(core, additional...),
+		}, nil
+	}
+
+	if err := drift.NewService(ec2Scope, r.DriftAuditor).ReconcileInstanceDrift(machineScope.AWSMachine, []string{instance.ID}, expected); err != nil {
+		// non fatal error, so we continue
+		machineScope.Error(err, "non-fatal: failed to audit instance drift")
+	}
+}
+
 func (r *AWSMachineReconciler) deleteEncryptedBootstrapDataSecret(machineScope *scope.MachineScope, clusterScope cloud.ClusterScoper) error {
 	secretSvc, secretBackendErr := r.getSecretService(machineScope, clusterScope)
 	if secretBackendErr != nil {
@@ -939,7 +1029,8 @@ func (r *AWSMachineReconciler) reconcileLBAttachment(machineScope *scope.Machine
 
 	errs := []error{}
 	for _, lbSpec := range elbScope.ControlPlaneLoadBalancers() {
-		if lbSpec == nil {
+		// The instances are registered with a load balancer managed outside of CAPA by its own tooling.
+		if lbSpec == nil || lbSpec.LoadBalancerType == infrav1.LoadBalancerTypeDisabled {
 			continue
 		}
 		// In order to prevent sending request to a "not-ready" control plane machines, it is required to remove the machine
@@ -1007,8 +1098,11 @@ func (r *AWSMachineReconciler) registerInstanceToV2LB(machineScope *scope.Machin
 		return errors.Wrapf(err, "could not register control plane instance %q with load balancer - error determining registration status", instance.ID)
 	}
 	if registered {
-		machineScope.Logger.Debug("Instance is already registered.", "instance", instance.ID)
-		return nil
+		if conditions.IsTrue(machineScope.AWSMachine, infrav1.ELBAttachedCondition) {
+			machineScope.Logger.Debug("Instance is already registered.", "instance", instance.ID)
+			return nil
+		}
+		return r.reconcileV2LBTargetHealth(machineScope, elbsvc, instance, lb)
 	}
 
 	// See https://docs.aws.amazon.com/elasticloadbalancing/latest/application/target-group-register-targets.html#register-instances
@@ -1027,8 +1121,31 @@ func (r *AWSMachineReconciler) registerInstanceToV2LB(machineScope *scope.Machin
 	}
 	r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeNormal, "SuccessfulAttachControlPlaneELB",
 		"Control plane instance %q is registered with load balancer", instance.ID)
-	conditions.MarkTrue(machineScope.AWSMachine, infrav1.ELBAttachedCondition)
-	return nil
+	return r.reconcileV2LBTargetHealth(machineScope, elbsvc, instance, lb)
+}
+
+// reconcileV2LBTargetHealth marks the ELBAttached condition true once the target of the instance in the API server
+// target group is healthy. Until then, it returns an InstanceNotHealthy error so the machine isn't reported as ready.
+func (r *AWSMachineReconciler) reconcileV2LBTargetHealth(machineScope *scope.MachineScope, elbsvc services.ELBInterface, instance *infrav1.Instance, lb *infrav1.AWSLoadBalancerSpec) error {
+	state, err := elbsvc.GetAPIServerLBTargetHealth(instance, lb)
+	if err != nil {
+		return errors.Wrapf(err, "could not determine health of control plane instance %q in load balancer", instance.ID)
+	}
+
+	if state == elbv2.TargetHealthStateEnumHealthy {
+		conditions.MarkTrue(machineScope.AWSMachine, infrav1.ELBAttachedCondition)
+		return nil
+	}
+
+	// The transition time is kept while the condition stays false, so it tells how long the target has been waiting.
+	reason, severity := infrav1.ELBTargetUnhealthyReason, clusterv1.ConditionSeverityInfo
+	if lastTransition := conditions.GetLastTransitionTime(machineScope.AWSMachine, infrav1.ELBAttachedCondition); conditions.IsFalse(machineScope.AWSMachine, infrav1.ELBAttachedCondition) &&
+		lastTransition != nil && time.Since(lastTransition.Time) > ELBTargetHealthTimeout {
+		reason, severity = infrav1.ELBTargetHealthTimeoutReason, clusterv1.ConditionSeverityError
+	}
+	conditions.MarkFalse(machineScope.AWSMachine, infrav1.ELBAttachedCondition, reason, severity, "target is %q in the API server target group", state)
+
+	return elb.NewInstanceNotHealthy(fmt.Sprintf("control plane instance %q target is %q", instance.ID, state))
 }
 
 func (r *AWSMachineReconciler) deregisterInstanceFromClassicLB(machineScope *scope.MachineScope, elbsvc services.ELBInterface, instance *infrav1.Instance) error {
@@ -1183,6 +1300,7 @@ func (r *AWSMachineReconciler) getInfraCluster(ctx context.Context, log *logger.
 			ControllerName:               "awsManagedControlPlane",
 			Endpoints:                    r.Endpoints,
 			TagUnmanagedNetworkResources: r.TagUnmanagedNetworkResources,
+			OwnershipTagPrefix:           r.OwnershipTagPrefix,
 		})
 		if err != nil {
 			return nil, err
@@ -1211,6 +1329,7 @@ func (r *AWSMachineReconciler) getInfraCluster(ctx context.Context, log *logger.
 		AWSCluster:                   awsCluster,
 		ControllerName:               "awsmachine",
 		TagUnmanagedNetworkResources: r.TagUnmanagedNetworkResources,
+		OwnershipTagPrefix:           r.OwnershipTagPrefix,
 	})
 	if err != nil {
 		return nil, err
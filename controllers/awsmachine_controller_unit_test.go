@@ -26,6 +26,7 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -55,6 +56,7 @@ import (
 	kubeadmv1beta1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
 	capierrors "sigs.k8s.io/cluster-api/errors"
 	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/conditions"
 )
 
 const providerID = "aws:////myMachine"
@@ -224,6 +226,8 @@ func TestAWSMachineReconciler(t *testing.T) {
 
 				_, _ = reconciler.reconcileNormal(context.Background(), ms, cs, cs, cs, cs)
 				g.Expect(buf).To(ContainSubstring("Error state detected, skipping reconciliation"))
+				expectConditions(g, ms.AWSMachine, []conditionAssertion{{infrav1.ReconciliationSkippedCondition, corev1.ConditionTrue, clusterv1.ConditionSeverityWarning, infrav1.FailedStateReason}})
+				g.Expect(recorder.Events).To(Receive(ContainSubstring("ReconciliationSkipped")))
 			})
 
 			t.Run("should exit immediately if cluster infra isn't ready", func(t *testing.T) {
@@ -240,7 +244,10 @@ func TestAWSMachineReconciler(t *testing.T) {
 				_, err := reconciler.reconcileNormal(context.Background(), ms, cs, cs, cs, cs)
 				g.Expect(err).To(BeNil())
 				g.Expect(buf.String()).To(ContainSubstring("Cluster infrastructure is not ready yet"))
-				expectConditions(g, ms.AWSMachine, []conditionAssertion{{infrav1.InstanceReadyCondition, corev1.ConditionFalse, clusterv1.ConditionSeverityInfo, infrav1.WaitingForClusterInfrastructureReason}})
+				expectConditions(g, ms.AWSMachine, []conditionAssertion{
+					{infrav1.InstanceReadyCondition, corev1.ConditionFalse, clusterv1.ConditionSeverityInfo, infrav1.WaitingForClusterInfrastructureReason},
+					{infrav1.ReconciliationSkippedCondition, corev1.ConditionTrue, clusterv1.ConditionSeverityInfo, infrav1.WaitingForClusterInfrastructureReason},
+				})
 			})
 
 			t.Run("should exit immediately if bootstrap data secret reference isn't available", func(t *testing.T) {
@@ -258,7 +265,23 @@ func TestAWSMachineReconciler(t *testing.T) {
 
 				g.Expect(err).To(BeNil())
 				g.Expect(buf.String()).To(ContainSubstring("Bootstrap data secret reference is not yet available"))
-				expectConditions(g, ms.AWSMachine, []conditionAssertion{{infrav1.InstanceReadyCondition, corev1.ConditionFalse, clusterv1.ConditionSeverityInfo, infrav1.WaitingForBootstrapDataReason}})
+				expectConditions(g, ms.AWSMachine, []conditionAssertion{
+					{infrav1.InstanceReadyCondition, corev1.ConditionFalse, clusterv1.ConditionSeverityInfo, infrav1.WaitingForBootstrapDataReason},
+					{infrav1.ReconciliationSkippedCondition, corev1.ConditionTrue, clusterv1.ConditionSeverityInfo, infrav1.WaitingForBootstrapDataReason},
+				})
+			})
+
+			t.Run("should clear the skipped condition once reconciliation resumes", func(t *testing.T) {
+				g := NewWithT(t)
+				awsMachine := getAWSMachine()
+				setup(t, g, awsMachine)
+				defer teardown(t, g)
+				runningInstance(t, g)
+				conditions.MarkTrueWithNegativePolarity(ms.AWSMachine, infrav1.ReconciliationSkippedCondition, infrav1.WaitingForBootstrapDataReason, clusterv1.ConditionSeverityInfo, "")
+
+				_, _ = reconciler.reconcileNormal(context.Background(), ms, cs, cs, cs, cs)
+				g.Expect(conditions.Has(ms.AWSMachine, infrav1.ReconciliationSkippedCondition)).To(BeFalse())
+				g.Expect(recorder.Events).To(Receive(ContainSubstring("ReconciliationResumed")))
 			})
 
 			t.Run("should return an error when we can't list instances by tags", func(t *testing.T) {
@@ -703,6 +726,81 @@ func TestAWSMachineReconciler(t *testing.T) {
 				expectConditions(g, ms.AWSMachine, []conditionAssertion{{infrav1.ELBAttachedCondition, corev1.ConditionTrue, "", ""}})
 				expectConditions(g, ms.AWSMachine, []conditionAssertion{{infrav1.InstanceReadyCondition, corev1.ConditionFalse, clusterv1.ConditionSeverityWarning, infrav1.InstanceNotReadyReason}})
 			})
+			t.Run("should wait for the control plane NLB target to be healthy", func(t *testing.T) {
+				g := NewWithT(t)
+				awsMachine := getAWSMachine()
+				setup(t, g, awsMachine)
+				defer teardown(t, g)
+
+				ms.Machine.Labels = map[string]string{clusterv1.MachineControlPlaneLabel: ""}
+				ms.SetInstanceState(infrav1.InstanceStateRunning)
+				instance := &infrav1.Instance{ID: "myMachine", State: infrav1.InstanceStateRunning}
+				lb := &infrav1.AWSLoadBalancerSpec{LoadBalancerType: infrav1.LoadBalancerTypeNLB}
+
+				elbSvc.EXPECT().IsInstanceRegisteredWithAPIServerLB(instance, lb).Return(nil, false, nil)
+				elbSvc.EXPECT().RegisterInstanceWithAPIServerLB(instance, lb).Return(nil)
+				elbSvc.EXPECT().GetAPIServerLBTargetHealth(instance, lb).Return(elbv2.TargetHealthStateEnumInitial, nil)
+
+				err := reconciler.registerInstanceToV2LB(ms, elbSvc, instance, lb)
+				g.Expect(elbService.IsInstanceNotHealthy(err)).To(BeTrue())
+				g.Eventually(recorder.Events).Should(Receive(ContainSubstring("SuccessfulAttachControlPlaneELB")))
+				expectConditions(g, ms.AWSMachine, []conditionAssertion{{infrav1.ELBAttachedCondition, corev1.ConditionFalse, clusterv1.ConditionSeverityInfo, infrav1.ELBTargetUnhealthyReason}})
+
+				elbSvc.EXPECT().IsInstanceRegisteredWithAPIServerLB(instance, lb).Return([]string{"tg-arn"}, true, nil)
+				elbSvc.EXPECT().GetAPIServerLBTargetHealth(instance, lb).Return(elbv2.TargetHealthStateEnumHealthy, nil)
+
+				err = reconciler.registerInstanceToV2LB(ms, elbSvc, instance, lb)
+				g.Expect(err).To(BeNil())
+				expectConditions(g, ms.AWSMachine, []conditionAssertion{{infrav1.ELBAttachedCondition, corev1.ConditionTrue, "", ""}})
+
+				// Once attached, the target health is no longer checked.
+				elbSvc.EXPECT().IsInstanceRegisteredWithAPIServerLB(instance, lb).Return([]string{"tg-arn"}, true, nil)
+
+				err = reconciler.registerInstanceToV2LB(ms, elbSvc, instance, lb)
+				g.Expect(err).To(BeNil())
+			})
+			t.Run("should report a timeout when the control plane NLB target stays unhealthy", func(t *testing.T) {
+				g := NewWithT(t)
+				awsMachine := getAWSMachine()
+				setup(t, g, awsMachine)
+				defer teardown(t, g)
+
+				ms.Machine.Labels = map[string]string{clusterv1.MachineControlPlaneLabel: ""}
+				ms.SetInstanceState(infrav1.InstanceStateRunning)
+				instance := &infrav1.Instance{ID: "myMachine", State: infrav1.InstanceStateRunning}
+				lb := &infrav1.AWSLoadBalancerSpec{LoadBalancerType: infrav1.LoadBalancerTypeNLB}
+				conditions.Set(ms.AWSMachine, &clusterv1.Condition{
+					Type:               infrav1.ELBAttachedCondition,
+					Status:             corev1.ConditionFalse,
+					Severity:           clusterv1.ConditionSeverityInfo,
+					Reason:             infrav1.ELBTargetUnhealthyReason,
+					LastTransitionTime: metav1.NewTime(time.Now().Add(-2 * ELBTargetHealthTimeout)),
+				})
+
+				elbSvc.EXPECT().IsInstanceRegisteredWithAPIServerLB(instance, lb).Return([]string{"tg-arn"}, true, nil)
+				elbSvc.EXPECT().GetAPIServerLBTargetHealth(instance, lb).Return(elbv2.TargetHealthStateEnumUnhealthy, nil)
+
+				err := reconciler.registerInstanceToV2LB(ms, elbSvc, instance, lb)
+				g.Expect(elbService.IsInstanceNotHealthy(err)).To(BeTrue())
+				expectConditions(g, ms.AWSMachine, []conditionAssertion{{infrav1.ELBAttachedCondition, corev1.ConditionFalse, clusterv1.ConditionSeverityError, infrav1.ELBTargetHealthTimeoutReason}})
+			})
+			t.Run("should not register control plane instances with a load balancer managed outside of CAPA", func(t *testing.T) {
+				g := NewWithT(t)
+				awsMachine := getAWSMachine()
+				setup(t, g, awsMachine)
+				defer teardown(t, g)
+
+				ms.Machine.Labels = map[string]string{clusterv1.MachineControlPlaneLabel: ""}
+				ms.SetInstanceState(infrav1.InstanceStateRunning)
+				cs.AWSCluster.Spec.ControlPlaneLoadBalancer = &infrav1.AWSLoadBalancerSpec{LoadBalancerType: infrav1.LoadBalancerTypeDisabled}
+				reconciler.elbServiceFactory = func(elbScope scope.ELBScope) services.ELBInterface {
+					return elbSvc
+				}
+				instance := &infrav1.Instance{ID: "myMachine", State: infrav1.InstanceStateRunning}
+
+				// The ELB service isn't called.
+				g.Expect(reconciler.reconcileLBAttachment(ms, cs, instance)).To(Succeed())
+			})
 			t.Run("should store userdata for CloudInit using AWS Secrets Manager only when not skipped", func(t *testing.T) {
 				g := NewWithT(t)
 				awsMachine := getAWSMachine()
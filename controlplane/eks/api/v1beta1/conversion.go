@@ -40,7 +40,30 @@ func (r *AWSManagedControlPlane) ConvertTo(dstRaw conversion.Hub) error {
 	}
 	dst.Spec.VpcCni.Disable = r.Spec.DisableVPCCNI
 	dst.Spec.Partition = restored.Spec.Partition
+	dst.Spec.OwnershipTagPrefix = restored.Spec.OwnershipTagPrefix
 	dst.Spec.RestrictPrivateSubnets = restored.Spec.RestrictPrivateSubnets
+	dst.Spec.RoleManagement = restored.Spec.RoleManagement
+	dst.Spec.NetworkSpec.ApplyRulesToUnmanagedGroups = restored.Spec.NetworkSpec.ApplyRulesToUnmanagedGroups
+	dst.Spec.NetworkSpec.PublishEgressPrefixList = restored.Spec.NetworkSpec.PublishEgressPrefixList
+	dst.Spec.NetworkSpec.EgressPrefixListIncludeAPIServerLB = restored.Spec.NetworkSpec.EgressPrefixListIncludeAPIServerLB
+	dst.Spec.NetworkSpec.LegacyClusterTag = restored.Spec.NetworkSpec.LegacyClusterTag
+	if restored.Spec.NetworkSpec.CNI != nil && dst.Spec.NetworkSpec.CNI != nil {
+		dst.Spec.NetworkSpec.CNI.Preset = restored.Spec.NetworkSpec.CNI.Preset
+	}
+	if restored.Spec.IAMAuthenticatorConfig != nil && dst.Spec.IAMAuthenticatorConfig != nil {
+		dst.Spec.IAMAuthenticatorConfig.ManagementMode = restored.Spec.IAMAuthenticatorConfig.ManagementMode
+		dst.Spec.IAMAuthenticatorConfig.AcknowledgeExternalManagement = restored.Spec.IAMAuthenticatorConfig.AcknowledgeExternalManagement
+	}
+	dst.Status.Network.EgressPrefixListID = restored.Status.Network.EgressPrefixListID
+	dst.Status.NamespaceIdentityRoleARN = restored.Status.NamespaceIdentityRoleARN
+	dst.Status.IdentityCredentialsExpiration = restored.Status.IdentityCredentialsExpiration
+	dst.Status.UpgradeInsights = restored.Status.UpgradeInsights
+	dst.Spec.NodeTerminationHandling = restored.Spec.NodeTerminationHandling
+	dst.Status.NodeTerminationHandling = restored.Status.NodeTerminationHandling
+	if restored.Spec.Addons != nil && dst.Spec.Addons != nil {
+		restoreAddonVersionConstraints(*restored.Spec.Addons, *dst.Spec.Addons)
+	}
+	restoreAddonStateVersions(restored.Status.Addons, dst.Status.Addons)
 
 	return nil
 }
@@ -109,6 +132,11 @@ func Convert_v1beta1_AWSManagedControlPlaneSpec_To_v1beta2_AWSManagedControlPlan
 	return autoConvert_v1beta1_AWSManagedControlPlaneSpec_To_v1beta2_AWSManagedControlPlaneSpec(in, out, s)
 }
 
+// Convert_v1beta2_IAMAuthenticatorConfig_To_v1beta1_IAMAuthenticatorConfig is a generated conversion function.
+func Convert_v1beta2_IAMAuthenticatorConfig_To_v1beta1_IAMAuthenticatorConfig(in *ekscontrolplanev1.IAMAuthenticatorConfig, out *IAMAuthenticatorConfig, s apiconversion.Scope) error {
+	return autoConvert_v1beta2_IAMAuthenticatorConfig_To_v1beta1_IAMAuthenticatorConfig(in, out, s)
+}
+
 func Convert_v1beta2_VpcCni_To_v1beta1_VpcCni(in *ekscontrolplanev1.VpcCni, out *VpcCni, s apiconversion.Scope) error {
 	return autoConvert_v1beta2_VpcCni_To_v1beta1_VpcCni(in, out, s)
 }
@@ -117,3 +145,44 @@ func Convert_v1beta2_VpcCni_To_v1beta1_VpcCni(in *ekscontrolplanev1.VpcCni, out
 func Convert_v1beta2_AWSManagedControlPlaneSpec_To_v1beta1_AWSManagedControlPlaneSpec(in *ekscontrolplanev1.AWSManagedControlPlaneSpec, out *AWSManagedControlPlaneSpec, scope apiconversion.Scope) error {
 	return autoConvert_v1beta2_AWSManagedControlPlaneSpec_To_v1beta1_AWSManagedControlPlaneSpec(in, out, scope)
 }
+
+// Convert_v1beta2_AWSManagedControlPlaneStatus_To_v1beta1_AWSManagedControlPlaneStatus is a generated conversion function
+func Convert_v1beta2_AWSManagedControlPlaneStatus_To_v1beta1_AWSManagedControlPlaneStatus(in *ekscontrolplanev1.AWSManagedControlPlaneStatus, out *AWSManagedControlPlaneStatus, scope apiconversion.Scope) error {
+	return autoConvert_v1beta2_AWSManagedControlPlaneStatus_To_v1beta1_AWSManagedControlPlaneStatus(in, out, scope)
+}
+
+// restoreAddonVersionConstraints restores the version constraints of the addons, which are matched by name as
+// addons may have been added or removed from the v1beta1 object since it was converted.
+func restoreAddonVersionConstraints(restored, dst []ekscontrolplanev1.Addon) {
+	for i := range dst {
+		for _, addon := range restored {
+			if addon.Name == dst[i].Name {
+				dst[i].VersionConstraint = addon.VersionConstraint
+				break
+			}
+		}
+	}
+}
+
+// restoreAddonStateVersions restores the version constraints and resolved versions of the addons in the status.
+func restoreAddonStateVersions(restored, dst []ekscontrolplanev1.AddonState) {
+	for i := range dst {
+		for _, addon := range restored {
+			if addon.Name == dst[i].Name {
+				dst[i].VersionConstraint = addon.VersionConstraint
+				dst[i].ResolvedVersion = addon.ResolvedVersion
+				break
+			}
+		}
+	}
+}
+
+// Convert_v1beta2_Addon_To_v1beta1_Addon is a conversion function.
+func Convert_v1beta2_Addon_To_v1beta1_Addon(in *ekscontrolplanev1.Addon, out *Addon, s apiconversion.Scope) error {
+	return autoConvert_v1beta2_Addon_To_v1beta1_Addon(in, out, s)
+}
+
+// Convert_v1beta2_AddonState_To_v1beta1_AddonState is a conversion function.
+func Convert_v1beta2_AddonState_To_v1beta1_AddonState(in *ekscontrolplanev1.AddonState, out *AddonState, s apiconversion.Scope) error {
+	return autoConvert_v1beta2_AddonState_To_v1beta1_AddonState(in, out, s)
+}
@@ -30,6 +30,10 @@ const (
 
 	// AWSManagedControlPlaneKind is the Kind of AWSManagedControlPlane.
 	AWSManagedControlPlaneKind = "AWSManagedControlPlane"
+
+	// AcknowledgeUpgradeRisksAnnotation acknowledges the risks reported by the upgrade insights in the ERROR status
+	// for the Kubernetes version of its value, e.g. "1.30", so that the control plane is updated to that version.
+	AcknowledgeUpgradeRisksAnnotation = "aws.cluster.x-k8s.io/acknowledge-upgrade-risks"
 )
 
 // AWSManagedControlPlaneSpec defines the desired state of an Amazon EKS Cluster.
@@ -88,6 +92,11 @@ type AWSManagedControlPlaneSpec struct { //nolint: maligned
 	// +optional
 	RoleAdditionalPolicies *[]string `json:"roleAdditionalPolicies,omitempty"`
 
+	// RoleManagement configures the control plane role when it is created by CAPA. Roles which
+	// are not created by CAPA are left untouched.
+	// +optional
+	RoleManagement *infrav1.IAMRoleManagement `json:"roleManagement,omitempty"`
+
 	// Logging specifies which EKS Cluster logs should be enabled. Entries for
 	// each of the enabled logs will be sent to CloudWatch
 	// +optional
@@ -102,6 +111,13 @@ type AWSManagedControlPlaneSpec struct { //nolint: maligned
 	// +optional
 	AdditionalTags infrav1.Tags `json:"additionalTags,omitempty"`
 
+	// OwnershipTagPrefix overrides the prefix of the tag key used to mark AWS resources as owned by
+	// this cluster. The cluster name is appended to the prefix. Defaults to the controller's
+	// --ownership-tag-prefix flag, or "sigs.k8s.io/cluster-api-provider-aws/cluster/" if unset.
+	// Changing the prefix of an existing cluster migrates the tags of its resources to the new key.
+	// +optional
+	OwnershipTagPrefix string `json:"ownershipTagPrefix,omitempty"`
+
 	// IAMAuthenticatorConfig allows the specification of any additional user or role mappings
 	// for use when generating the aws-iam-authenticator configuration. If this is nil the
 	// default configuration is still generated for the cluster.
@@ -179,6 +195,12 @@ type AWSManagedControlPlaneSpec struct { //nolint: maligned
 
 	// KubeProxy defines managed attributes of the kube-proxy daemonset
 	KubeProxy KubeProxy `json:"kubeProxy,omitempty"`
+
+	// NodeTerminationHandling configures the AWS resources used by aws-node-termination-handler
+	// in queue-processor mode. Only the SQS queue, the EventBridge rules and the Auto Scaling group
+	// lifecycle hooks of the AWSMachinePools are managed; the handler itself must be installed separately.
+	// +optional
+	NodeTerminationHandling *infrav1.NodeTerminationHandling `json:"nodeTerminationHandling,omitempty"`
 }
 
 // KubeProxy specifies how the kube-proxy daemonset is managed.
@@ -283,6 +305,25 @@ type AWSManagedControlPlaneStatus struct {
 	// associated identity provider
 	// +optional
 	IdentityProviderStatus IdentityProviderStatus `json:"identityProviderStatus,omitempty"`
+
+	// NamespaceIdentityRoleARN is the ARN of the role the controller assumes for the control plane, configured for
+	// its namespace, as it doesn't set an identityRef other than the controller identity.
+	// +optional
+	NamespaceIdentityRoleARN string `json:"namespaceIdentityRoleARN,omitempty"`
+
+	// IdentityCredentialsExpiration is when the credentials of the role assumed for the control plane expire, and
+	// the role is assumed again. It is not set when the control plane uses credentials which don't expire.
+	// +optional
+	IdentityCredentialsExpiration *metav1.Time `json:"identityCredentialsExpiration,omitempty"`
+
+	// UpgradeInsights summarizes the EKS upgrade readiness insights of the cluster, which are refreshed
+	// periodically and before updating its Kubernetes version.
+	// +optional
+	UpgradeInsights *UpgradeInsights `json:"upgradeInsights,omitempty"`
+
+	// NodeTerminationHandling is the observed state of the resources managed for the node termination handler.
+	// +optional
+	NodeTerminationHandling *infrav1.NodeTerminationHandlingStatus `json:"nodeTerminationHandling,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -17,10 +17,12 @@ limitations under the License.
 package v1beta2
 
 import (
+	"context"
 	"fmt"
 	"net"
 
 	"github.com/apparentlymart/go-cidr/cidr"
+	"github.com/blang/semver"
 	"github.com/pkg/errors"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -28,11 +30,14 @@ import (
 	"k8s.io/apimachinery/pkg/util/version"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/eks"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	expclusterv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
 )
 
 const (
@@ -57,9 +62,120 @@ const (
 func (r *AWSManagedControlPlane) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
+		WithValidator(&awsManagedControlPlaneValidator{client: mgr.GetClient()}).
 		Complete()
 }
 
+// awsManagedControlPlaneValidator validates an AWSManagedControlPlane, and checks the nodes of its cluster when
+// the aws-iam-authenticator configuration is managed externally, which requires a client.
+// +kubebuilder:object:generate=false
+type awsManagedControlPlaneValidator struct {
+	client client.Reader
+}
+
+var _ webhook.CustomValidator = &awsManagedControlPlaneValidator{}
+
+// ValidateCreate will do any extra validation when creating a AWSManagedControlPlane.
+func (v *awsManagedControlPlaneValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	r, ok := obj.(*AWSManagedControlPlane)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected an AWSManagedControlPlane but got a %T", obj))
+	}
+
+	warnings, err := r.ValidateCreate()
+	if err != nil {
+		return warnings, err
+	}
+	return warnings, v.validateExternalManagement(ctx, r)
+}
+
+// ValidateUpdate will do any extra validation when updating a AWSManagedControlPlane. The nodes of the cluster are
+// only checked when the external management of the aws-iam-authenticator configuration is newly unacknowledged, so
+// that other updates, such as the removal of finalizers, aren't blocked.
+func (v *awsManagedControlPlaneValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	r, ok := newObj.(*AWSManagedControlPlane)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected an AWSManagedControlPlane but got a %T", newObj))
+	}
+	old, ok := oldObj.(*AWSManagedControlPlane)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected an AWSManagedControlPlane but got a %T", oldObj))
+	}
+
+	warnings, err := r.ValidateUpdate(old)
+	if err != nil {
+		return warnings, err
+	}
+	if oldCfg := old.Spec.IAMAuthenticatorConfig; oldCfg.IsManagedExternally() && !oldCfg.AcknowledgeExternalManagement {
+		return warnings, nil
+	}
+	return warnings, v.validateExternalManagement(ctx, r)
+}
+
+// ValidateDelete allows you to add any extra validation when deleting.
+func (v *awsManagedControlPlaneValidator) ValidateDelete(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	r, ok := obj.(*AWSManagedControlPlane)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected an AWSManagedControlPlane but got a %T", obj))
+	}
+	return r.ValidateDelete()
+}
+
+// validateExternalManagement rejects an aws-iam-authenticator configuration managed externally without
+// acknowledgeExternalManagement when the cluster has self-managed nodes, which can't join the cluster until the
+// external tool maps their roles.
+func (v *awsManagedControlPlaneValidator) validateExternalManagement(ctx context.Context, r *AWSManagedControlPlane) error {
+	cfg := r.Spec.IAMAuthenticatorConfig
+	clusterName := r.Labels[clusterv1.ClusterNameLabel]
+	if !cfg.IsManagedExternally() || cfg.AcknowledgeExternalManagement || clusterName == "" {
+		return nil
+	}
+
+	selfManaged, err := HasSelfManagedNodes(ctx, v.client, r.Namespace, clusterName)
+	if err != nil {
+		return apierrors.NewInternalError(err)
+	}
+	if !selfManaged {
+		return nil
+	}
+	return apierrors.NewInvalid(GroupVersion.WithKind("AWSManagedControlPlane").GroupKind(), r.Name, field.ErrorList{
+		field.Invalid(field.NewPath("spec", "iamAuthenticatorConfig", "acknowledgeExternalManagement"), cfg.AcknowledgeExternalManagement,
+			"must be set once the roles of the self-managed nodes of the cluster are mapped by the tool managing aws-auth"),
+	})
+}
+
+// HasSelfManagedNodes returns whether the cluster has MachineDeployments of AWSMachines or MachinePools of
+// AWSMachinePools, whose nodes can only join the cluster when their role is mapped in aws-auth.
+func HasSelfManagedNodes(ctx context.Context, c client.Reader, namespace, clusterName string) (bool, error) {
+	selectors := []client.ListOption{
+		client.InNamespace(namespace),
+		client.MatchingLabels{
+			clusterv1.ClusterNameLabel: clusterName,
+		},
+	}
+
+	deploymentList := &clusterv1.MachineDeploymentList{}
+	if err := c.List(ctx, deploymentList, selectors...); err != nil {
+		return false, fmt.Errorf("failed to list machine deployments for cluster %s/%s: %w", namespace, clusterName, err)
+	}
+	for _, deployment := range deploymentList.Items {
+		if deployment.Spec.Template.Spec.InfrastructureRef.Kind == "AWSMachineTemplate" {
+			return true, nil
+		}
+	}
+
+	machinePoolList := &expclusterv1.MachinePoolList{}
+	if err := c.List(ctx, machinePoolList, selectors...); err != nil {
+		return false, fmt.Errorf("failed to list machine pools for cluster %s/%s: %w", namespace, clusterName, err)
+	}
+	for _, pool := range machinePoolList.Items {
+		if pool.Spec.Template.Spec.InfrastructureRef.Kind == "AWSMachinePool" {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // +kubebuilder:webhook:verbs=create;update,path=/validate-controlplane-cluster-x-k8s-io-v1beta2-awsmanagedcontrolplane,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,groups=controlplane.cluster.x-k8s.io,resources=awsmanagedcontrolplanes,versions=v1beta2,name=validation.awsmanagedcontrolplanes.controlplane.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1;v1beta1
 // +kubebuilder:webhook:verbs=create;update,path=/mutate-controlplane-cluster-x-k8s-io-v1beta2-awsmanagedcontrolplane,mutating=true,failurePolicy=fail,matchPolicy=Equivalent,groups=controlplane.cluster.x-k8s.io,resources=awsmanagedcontrolplanes,versions=v1beta2,name=default.awsmanagedcontrolplanes.controlplane.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1;v1beta1
 
@@ -90,12 +206,17 @@ func (r *AWSManagedControlPlane) ValidateCreate() (admission.Warnings, error) {
 	allErrs = append(allErrs, r.validateIAMAuthConfig()...)
 	allErrs = append(allErrs, r.validateSecondaryCIDR()...)
 	allErrs = append(allErrs, r.validateEKSAddons()...)
+	allErrs = append(allErrs, r.validateAddonVersions()...)
 	allErrs = append(allErrs, r.validateDisableVPCCNI()...)
 	allErrs = append(allErrs, r.validateRestrictPrivateSubnets()...)
 	allErrs = append(allErrs, r.validateKubeProxy()...)
 	allErrs = append(allErrs, r.Spec.AdditionalTags.Validate()...)
+	allErrs = append(allErrs, r.Spec.RoleManagement.Validate(field.NewPath("spec", "roleManagement"))...)
+	allErrs = append(allErrs, infrav1.ValidateOwnershipTagPrefix(r.Spec.OwnershipTagPrefix, r.Labels[clusterv1.ClusterNameLabel], field.NewPath("spec", "ownershipTagPrefix"))...)
 	allErrs = append(allErrs, r.validateNetwork()...)
+	allErrs = append(allErrs, r.validateSecurityGroupOverrides()...)
 	allErrs = append(allErrs, r.validatePrivateDNSHostnameTypeOnLaunch()...)
+	allErrs = append(allErrs, r.validateAcknowledgeUpgradeRisks()...)
 
 	if len(allErrs) == 0 {
 		return nil, nil
@@ -126,11 +247,17 @@ func (r *AWSManagedControlPlane) ValidateUpdate(old runtime.Object) (admission.W
 	allErrs = append(allErrs, r.validateIAMAuthConfig()...)
 	allErrs = append(allErrs, r.validateSecondaryCIDR()...)
 	allErrs = append(allErrs, r.validateEKSAddons()...)
+	allErrs = append(allErrs, r.validateAddonVersions()...)
 	allErrs = append(allErrs, r.validateDisableVPCCNI()...)
 	allErrs = append(allErrs, r.validateRestrictPrivateSubnets()...)
 	allErrs = append(allErrs, r.validateKubeProxy()...)
 	allErrs = append(allErrs, r.Spec.AdditionalTags.Validate()...)
+	allErrs = append(allErrs, r.Spec.RoleManagement.Validate(field.NewPath("spec", "roleManagement"))...)
+	allErrs = append(allErrs, infrav1.ValidateOwnershipTagPrefix(r.Spec.OwnershipTagPrefix, r.Labels[clusterv1.ClusterNameLabel], field.NewPath("spec", "ownershipTagPrefix"))...)
+	allErrs = append(allErrs, r.validateSecurityGroupOverrides()...)
 	allErrs = append(allErrs, r.validatePrivateDNSHostnameTypeOnLaunch()...)
+	allErrs = append(allErrs, r.validateAcknowledgeUpgradeRisks()...)
+	allErrs = append(allErrs, r.Spec.NodeTerminationHandling.ValidateUpdate(oldAWSManagedControlplane.Spec.NodeTerminationHandling, field.NewPath("spec", "nodeTerminationHandling"))...)
 
 	if r.Spec.Region != oldAWSManagedControlplane.Spec.Region {
 		allErrs = append(allErrs,
@@ -235,6 +362,17 @@ func (r *AWSManagedControlPlane) validateEKSVersion(old *AWSManagedControlPlane)
 	return allErrs
 }
 
+func (r *AWSManagedControlPlane) validateAcknowledgeUpgradeRisks() field.ErrorList {
+	value, ok := r.Annotations[AcknowledgeUpgradeRisksAnnotation]
+	if !ok {
+		return nil
+	}
+	if _, err := parseEKSVersion(value); err != nil {
+		return field.ErrorList{field.Invalid(field.NewPath("metadata", "annotations", AcknowledgeUpgradeRisksAnnotation), value, "must be the Kubernetes version whose upgrade risks are acknowledged, e.g. 1.30")}
+	}
+	return nil
+}
+
 func (r *AWSManagedControlPlane) validateEKSAddons() field.ErrorList {
 	var allErrs field.ErrorList
 
@@ -271,7 +409,8 @@ func (r *AWSManagedControlPlane) validateEKSAddons() field.ErrorList {
 		}
 
 		for _, addon := range *r.Spec.Addons {
-			if addon.Name == vpcCniAddon {
+			// The version resolved from a version constraint is only known once the cluster exists.
+			if addon.Name == vpcCniAddon && addon.Version != "" {
 				v, err := version.ParseGeneric(addon.Version)
 				if err != nil {
 					allErrs = append(allErrs, field.Invalid(addonsPath, addon.Version, err.Error()))
@@ -289,6 +428,35 @@ func (r *AWSManagedControlPlane) validateEKSAddons() field.ErrorList {
 	return allErrs
 }
 
+// validateAddonVersions checks that the addons set either a version or a version constraint, and that the version
+// constraints are valid.
+func (r *AWSManagedControlPlane) validateAddonVersions() field.ErrorList {
+	var allErrs field.ErrorList
+	if r.Spec.Addons == nil {
+		return allErrs
+	}
+
+	for i, addon := range *r.Spec.Addons {
+		addonPath := field.NewPath("spec", "addons").Index(i)
+		switch {
+		case addon.Version != "" && addon.VersionConstraint != "":
+			allErrs = append(allErrs, field.Forbidden(addonPath.Child("versionConstraint"), "version and versionConstraint are mutually exclusive"))
+		case addon.Version == "" && addon.VersionConstraint == "":
+			allErrs = append(allErrs, field.Required(addonPath.Child("version"), "either version or versionConstraint must be set"))
+		}
+
+		switch addon.VersionConstraint {
+		case "", AddonVersionConstraintDefault, AddonVersionConstraintLatest:
+		default:
+			if _, err := semver.ParseRange(addon.VersionConstraint); err != nil {
+				allErrs = append(allErrs, field.Invalid(addonPath.Child("versionConstraint"), addon.VersionConstraint,
+					fmt.Sprintf("must be %q, %q or a semantic version range: %v", AddonVersionConstraintDefault, AddonVersionConstraintLatest, err)))
+			}
+		}
+	}
+	return allErrs
+}
+
 func (r *AWSManagedControlPlane) validateIAMAuthConfig() field.ErrorList {
 	var allErrs field.ErrorList
 
@@ -317,6 +485,11 @@ func (r *AWSManagedControlPlane) validateIAMAuthConfig() field.ErrorList {
 		}
 	}
 
+	if cfg.AcknowledgeExternalManagement && !cfg.IsManagedExternally() {
+		allErrs = append(allErrs, field.Invalid(parentPath.Child("acknowledgeExternalManagement"), cfg.AcknowledgeExternalManagement,
+			fmt.Sprintf("can only be set with managementMode %s", IAMAuthenticatorManagementModeNone)))
+	}
+
 	return allErrs
 }
 
@@ -394,10 +567,16 @@ func (r *AWSManagedControlPlane) validateDisableVPCCNI() field.ErrorList {
 	return allErrs
 }
 
+// ownershipTagPrefixes returns the prefixes of the ownership tag key the resources of the control plane are
+// recognized by: the prefix of its spec and the prefix recorded on it, an empty prefix standing for the default one.
+func (r *AWSManagedControlPlane) ownershipTagPrefixes() []string {
+	return []string{r.Spec.OwnershipTagPrefix, r.Annotations[infrav1.OwnershipTagPrefixAnnotation]}
+}
+
 func (r *AWSManagedControlPlane) validateRestrictPrivateSubnets() field.ErrorList {
 	var allErrs field.ErrorList
 
-	if r.Spec.RestrictPrivateSubnets && r.Spec.NetworkSpec.VPC.IsUnmanaged(r.Spec.EKSClusterName) {
+	if r.Spec.RestrictPrivateSubnets && r.Spec.NetworkSpec.VPC.IsUnmanaged(r.Spec.EKSClusterName, r.ownershipTagPrefixes()...) {
 		boolField := field.NewPath("spec", "restrictPrivateSubnets")
 		if len(r.Spec.NetworkSpec.Subnets.FilterPrivate()) == 0 {
 			allErrs = append(allErrs, field.Invalid(boolField, r.Spec.RestrictPrivateSubnets, "cannot enable private subnets restriction when no private subnets are specified"))
@@ -454,6 +633,11 @@ func (r *AWSManagedControlPlane) validateNetwork() field.ErrorList {
 		}
 	}
 
+	// The API server of EKS clusters is not exposed through a load balancer managed by the provider.
+	if r.Spec.NetworkSpec.EgressPrefixListIncludeAPIServerLB {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "network", "egressPrefixListIncludeAPIServerLB"), r.Spec.NetworkSpec.EgressPrefixListIncludeAPIServerLB, "cannot be enabled for EKS clusters"))
+	}
+
 	if r.Spec.NetworkSpec.VPC.IsIPv6Enabled() && r.Spec.NetworkSpec.VPC.IPv6.CidrBlock != "" && r.Spec.NetworkSpec.VPC.IPv6.PoolID == "" {
 		poolField := field.NewPath("spec", "network", "vpc", "ipv6", "poolId")
 		allErrs = append(allErrs, field.Invalid(poolField, r.Spec.NetworkSpec.VPC.IPv6.PoolID, "poolId cannot be empty if cidrBlock is set"))
@@ -477,6 +661,23 @@ func (r *AWSManagedControlPlane) validateNetwork() field.ErrorList {
 	return allErrs
 }
 
+func (r *AWSManagedControlPlane) validateSecurityGroupOverrides() field.ErrorList {
+	var allErrs field.ErrorList
+
+	// Additional control plane ingress rules are applied to the node-eks-additional security group. When that
+	// group is provided by the user, CAPA leaves its rules untouched unless explicitly told otherwise.
+	if _, ok := r.Spec.NetworkSpec.SecurityGroupOverrides[infrav1.SecurityGroupEKSNodeAdditional]; !ok {
+		return allErrs
+	}
+
+	if len(r.Spec.NetworkSpec.AdditionalControlPlaneIngressRules) > 0 && !r.Spec.NetworkSpec.ApplyRulesToUnmanagedGroups {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "network", "additionalControlPlaneIngressRules"),
+			fmt.Sprintf("additional ingress rules cannot be applied to the %q security group override unless spec.network.applyRulesToUnmanagedGroups is true", infrav1.SecurityGroupEKSNodeAdditional)))
+	}
+
+	return allErrs
+}
+
 // Default will set default values for the AWSManagedControlPlane.
 func (r *AWSManagedControlPlane) Default() {
 	mcpLog.Info("AWSManagedControlPlane setting defaults", "control-plane", klog.KObj(r))
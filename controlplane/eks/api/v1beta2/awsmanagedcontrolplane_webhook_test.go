@@ -24,10 +24,16 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	expclusterv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
 	utildefaulting "sigs.k8s.io/cluster-api/util/defaulting"
 )
 
@@ -179,6 +185,8 @@ func TestWebhookCreate(t *testing.T) {
 		secondaryCidr        *string
 		secondaryCidrBlocks  []infrav1.VpcCidrBlock
 		kubeProxy            KubeProxy
+		egressPrefixListLB   bool
+		iamAuthConfig        *IAMAuthenticatorConfig
 	}{
 		{
 			name:           "ekscluster specified",
@@ -191,6 +199,35 @@ func TestWebhookCreate(t *testing.T) {
 				"key-2": "value-2",
 			},
 		},
+		{
+			name:                 "egress prefix list with API server load balancer",
+			eksClusterName:       "default_cluster1",
+			expectError:          true,
+			expectErrorToContain: "egressPrefixListIncludeAPIServerLB",
+			vpcCNI:               VpcCni{Disable: false},
+			egressPrefixListLB:   true,
+		},
+		{
+			name:           "aws-auth managed externally with acknowledgement",
+			eksClusterName: "default_cluster1",
+			expectError:    false,
+			vpcCNI:         VpcCni{Disable: false},
+			iamAuthConfig: &IAMAuthenticatorConfig{
+				ManagementMode:                IAMAuthenticatorManagementModeNone,
+				AcknowledgeExternalManagement: true,
+			},
+		},
+		{
+			name:                 "acknowledgement of external aws-auth management without management mode none",
+			eksClusterName:       "default_cluster1",
+			expectError:          true,
+			expectErrorToContain: "acknowledgeExternalManagement",
+			vpcCNI:               VpcCni{Disable: false},
+			iamAuthConfig: &IAMAuthenticatorConfig{
+				ManagementMode:                IAMAuthenticatorManagementModeCAPA,
+				AcknowledgeExternalManagement: true,
+			},
+		},
 		{
 			name:           "ekscluster NOT specified",
 			eksClusterName: "",
@@ -335,14 +372,17 @@ func TestWebhookCreate(t *testing.T) {
 					Namespace:    "default",
 				},
 				Spec: AWSManagedControlPlaneSpec{
-					EKSClusterName: tc.eksClusterName,
-					KubeProxy:      tc.kubeProxy,
-					AdditionalTags: tc.additionalTags,
-					VpcCni:         tc.vpcCNI,
+					EKSClusterName:         tc.eksClusterName,
+					KubeProxy:              tc.kubeProxy,
+					AdditionalTags:         tc.additionalTags,
+					VpcCni:                 tc.vpcCNI,
+					IAMAuthenticatorConfig: tc.iamAuthConfig,
 					NetworkSpec: infrav1.NetworkSpec{
 						VPC: infrav1.VPCSpec{
 							SecondaryCidrBlocks: tc.secondaryCidrBlocks,
 						},
+						PublishEgressPrefixList:            tc.egressPrefixListLB,
+						EgressPrefixListIncludeAPIServerLB: tc.egressPrefixListLB,
 					},
 				},
 			}
@@ -526,6 +566,141 @@ func TestWebhookCreateIPv6Details(t *testing.T) {
 	}
 }
 
+func TestWebhookCreateAddonVersions(t *testing.T) {
+	tests := []struct {
+		name   string
+		addons *[]Addon
+		err    string
+	}{
+		{
+			name: "addon with version",
+			addons: &[]Addon{
+				{
+					Name:    vpcCniAddon,
+					Version: "v1.16.0-eksbuild.1",
+				},
+			},
+		},
+		{
+			name: "addons with version constraints",
+			addons: &[]Addon{
+				{
+					Name:              vpcCniAddon,
+					VersionConstraint: ">=1.16.0 <1.17.0",
+				},
+				{
+					Name:              kubeProxyAddon,
+					VersionConstraint: AddonVersionConstraintDefault,
+				},
+				{
+					Name:              "coredns",
+					VersionConstraint: AddonVersionConstraintLatest,
+				},
+			},
+		},
+		{
+			name: "addon with version and version constraint",
+			addons: &[]Addon{
+				{
+					Name:              vpcCniAddon,
+					Version:           "v1.16.0-eksbuild.1",
+					VersionConstraint: AddonVersionConstraintLatest,
+				},
+			},
+			err: "version and versionConstraint are mutually exclusive",
+		},
+		{
+			name: "addon without version or version constraint",
+			addons: &[]Addon{
+				{
+					Name: vpcCniAddon,
+				},
+			},
+			err: "either version or versionConstraint must be set",
+		},
+		{
+			name: "addon with invalid version constraint",
+			addons: &[]Addon{
+				{
+					Name:              vpcCniAddon,
+					VersionConstraint: "newest",
+				},
+			},
+			err: "must be \"default\", \"latest\" or a semantic version range",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.TODO()
+			g := NewWithT(t)
+
+			mcp := &AWSManagedControlPlane{
+				ObjectMeta: metav1.ObjectMeta{
+					GenerateName: "mcp-",
+					Namespace:    "default",
+				},
+				Spec: AWSManagedControlPlaneSpec{
+					EKSClusterName: "test-cluster",
+					Addons:         tc.addons,
+					Version:        aws.String("v1.29"),
+				},
+			}
+			err := testEnv.Create(ctx, mcp)
+
+			if tc.err != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tc.err)))
+			} else {
+				g.Expect(err).To(BeNil())
+			}
+		})
+	}
+}
+
+func TestWebhookCreateAcknowledgeUpgradeRisks(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		err   string
+	}{
+		{
+			name:  "kubernetes version",
+			value: "1.30",
+		},
+		{
+			name:  "invalid version",
+			value: "yes",
+			err:   "must be the Kubernetes version whose upgrade risks are acknowledged",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.TODO()
+			g := NewWithT(t)
+
+			mcp := &AWSManagedControlPlane{
+				ObjectMeta: metav1.ObjectMeta{
+					GenerateName: "mcp-",
+					Namespace:    "default",
+					Annotations:  map[string]string{AcknowledgeUpgradeRisksAnnotation: tc.value},
+				},
+				Spec: AWSManagedControlPlaneSpec{
+					EKSClusterName: "test-cluster",
+					Version:        aws.String("v1.29"),
+				},
+			}
+			err := testEnv.Create(ctx, mcp)
+
+			if tc.err != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tc.err)))
+			} else {
+				g.Expect(err).To(BeNil())
+			}
+		})
+	}
+}
+
 func TestWebhookUpdate(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -726,6 +901,30 @@ func TestWebhookUpdate(t *testing.T) {
 			},
 			expectError: true,
 		},
+		{
+			name: "nodeTerminationHandling queueName is immutable while enabled",
+			oldClusterSpec: AWSManagedControlPlaneSpec{
+				EKSClusterName:          "default_cluster1",
+				NodeTerminationHandling: &infrav1.NodeTerminationHandling{Enabled: true, QueueName: "my-queue"},
+			},
+			newClusterSpec: AWSManagedControlPlaneSpec{
+				EKSClusterName:          "default_cluster1",
+				NodeTerminationHandling: &infrav1.NodeTerminationHandling{Enabled: true, QueueName: "other-queue"},
+			},
+			expectError: true,
+		},
+		{
+			name: "nodeTerminationHandling queueName can be changed while disabled",
+			oldClusterSpec: AWSManagedControlPlaneSpec{
+				EKSClusterName:          "default_cluster1",
+				NodeTerminationHandling: &infrav1.NodeTerminationHandling{Enabled: false, QueueName: "my-queue"},
+			},
+			newClusterSpec: AWSManagedControlPlaneSpec{
+				EKSClusterName:          "default_cluster1",
+				NodeTerminationHandling: &infrav1.NodeTerminationHandling{Enabled: true, QueueName: "other-queue"},
+			},
+			expectError: false,
+		},
 	}
 
 	for _, tc := range tests {
@@ -820,6 +1019,86 @@ func TestValidatingWebhookCreateSecondaryCidr(t *testing.T) {
 	}
 }
 
+func TestValidatingWebhookCreateSecurityGroupOverrides(t *testing.T) {
+	ingressRules := []infrav1.IngressRule{
+		{
+			Description: "test",
+			Protocol:    infrav1.SecurityGroupProtocolTCP,
+			FromPort:    443,
+			ToPort:      443,
+			CidrBlocks:  []string{"10.0.0.0/16"},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		network     infrav1.NetworkSpec
+		expectError bool
+	}{
+		{
+			name: "overrides without additional ingress rules",
+			network: infrav1.NetworkSpec{
+				SecurityGroupOverrides: map[infrav1.SecurityGroupRole]string{
+					infrav1.SecurityGroupEKSNodeAdditional: "sg-1",
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "overrides with additional ingress rules",
+			network: infrav1.NetworkSpec{
+				SecurityGroupOverrides: map[infrav1.SecurityGroupRole]string{
+					infrav1.SecurityGroupEKSNodeAdditional: "sg-1",
+				},
+				AdditionalControlPlaneIngressRules: ingressRules,
+			},
+			expectError: true,
+		},
+		{
+			name: "overrides with additional ingress rules applied to unmanaged groups",
+			network: infrav1.NetworkSpec{
+				SecurityGroupOverrides: map[infrav1.SecurityGroupRole]string{
+					infrav1.SecurityGroupEKSNodeAdditional: "sg-1",
+				},
+				AdditionalControlPlaneIngressRules: ingressRules,
+				ApplyRulesToUnmanagedGroups:        true,
+			},
+			expectError: false,
+		},
+		{
+			name: "additional ingress rules with an override for another role",
+			network: infrav1.NetworkSpec{
+				SecurityGroupOverrides: map[infrav1.SecurityGroupRole]string{
+					infrav1.SecurityGroupBastion: "sg-1",
+				},
+				AdditionalControlPlaneIngressRules: ingressRules,
+			},
+			expectError: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mcp := &AWSManagedControlPlane{
+				Spec: AWSManagedControlPlaneSpec{
+					EKSClusterName: "default_cluster1",
+					NetworkSpec:    tc.network,
+				},
+			}
+			warn, err := mcp.ValidateCreate()
+
+			if tc.expectError {
+				g.Expect(err).ToNot(BeNil())
+			} else {
+				g.Expect(err).To(BeNil())
+			}
+			g.Expect(warn).To(BeEmpty())
+		})
+	}
+}
+
 func TestValidatingWebhookUpdateSecondaryCidr(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -892,3 +1171,99 @@ func TestValidatingWebhookUpdateSecondaryCidr(t *testing.T) {
 		})
 	}
 }
+
+func TestValidatingWebhookExternalManagement(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clusterv1.AddToScheme(scheme)
+	_ = expclusterv1.AddToScheme(scheme)
+
+	selfManagedPool := &expclusterv1.MachinePool{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pool",
+			Namespace: "default",
+			Labels:    map[string]string{clusterv1.ClusterNameLabel: "cluster"},
+		},
+		Spec: expclusterv1.MachinePoolSpec{
+			Template: clusterv1.MachineTemplateSpec{
+				Spec: clusterv1.MachineSpec{
+					InfrastructureRef: corev1.ObjectReference{Kind: "AWSMachinePool"},
+				},
+			},
+		},
+	}
+	controlPlane := func(cfg *IAMAuthenticatorConfig) *AWSManagedControlPlane {
+		return &AWSManagedControlPlane{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "control-plane",
+				Namespace: "default",
+				Labels:    map[string]string{clusterv1.ClusterNameLabel: "cluster"},
+			},
+			Spec: AWSManagedControlPlaneSpec{
+				EKSClusterName:         "default_cluster",
+				IAMAuthenticatorConfig: cfg,
+			},
+		}
+	}
+	external := &IAMAuthenticatorConfig{ManagementMode: IAMAuthenticatorManagementModeNone}
+	acknowledged := &IAMAuthenticatorConfig{ManagementMode: IAMAuthenticatorManagementModeNone, AcknowledgeExternalManagement: true}
+
+	tests := []struct {
+		name    string
+		old     *AWSManagedControlPlane
+		new     *AWSManagedControlPlane
+		objects []client.Object
+		wantErr bool
+	}{
+		{
+			name:    "external management without self-managed nodes is accepted",
+			new:     controlPlane(external),
+			wantErr: false,
+		},
+		{
+			name:    "unacknowledged external management with self-managed nodes is rejected",
+			new:     controlPlane(external),
+			objects: []client.Object{selfManagedPool},
+			wantErr: true,
+		},
+		{
+			name:    "acknowledged external management with self-managed nodes is accepted",
+			new:     controlPlane(acknowledged),
+			objects: []client.Object{selfManagedPool},
+			wantErr: false,
+		},
+		{
+			name:    "removing the acknowledgement with self-managed nodes is rejected",
+			old:     controlPlane(acknowledged),
+			new:     controlPlane(external),
+			objects: []client.Object{selfManagedPool},
+			wantErr: true,
+		},
+		{
+			name:    "updating an unacknowledged control plane with self-managed nodes is accepted",
+			old:     controlPlane(external),
+			new:     controlPlane(external),
+			objects: []client.Object{selfManagedPool},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			v := &awsManagedControlPlaneValidator{
+				client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.objects...).Build(),
+			}
+
+			var err error
+			if tt.old == nil {
+				_, err = v.ValidateCreate(context.Background(), tt.new)
+			} else {
+				_, err = v.ValidateUpdate(context.Background(), tt.old, tt.new)
+			}
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+		})
+	}
+}
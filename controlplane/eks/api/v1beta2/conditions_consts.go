@@ -43,6 +43,11 @@ const (
 	IAMAuthenticatorConfiguredCondition clusterv1.ConditionType = "IAMAuthenticatorConfigured"
 	// IAMAuthenticatorConfigurationFailedReason used to report failures while reconciling the aws-iam-authenticator config.
 	IAMAuthenticatorConfigurationFailedReason = "IAMAuthenticatorConfigurationFailed"
+	// IAMAuthenticatorManagedExternallyReason used to report that the aws-auth ConfigMap is left to a tool outside of the controller.
+	IAMAuthenticatorManagedExternallyReason = "IAMAuthenticatorManagedExternally"
+	// IAMAuthenticatorExternalManagementNotAcknowledgedReason used to report that self-managed nodes rely on an aws-auth
+	// ConfigMap managed outside of the controller without spec.iamAuthenticatorConfig.acknowledgeExternalManagement.
+	IAMAuthenticatorExternalManagementNotAcknowledgedReason = "IAMAuthenticatorExternalManagementNotAcknowledged"
 )
 
 const (
@@ -50,6 +55,21 @@ const (
 	EKSAddonsConfiguredCondition clusterv1.ConditionType = "EKSAddonsConfigured"
 	// EKSAddonsConfiguredFailedReason used to report failures while reconciling the EKS addons.
 	EKSAddonsConfiguredFailedReason = "EKSAddonsConfiguredFailed"
+	// AddonDegradedCondition is set while EKS addons of the cluster are in the DEGRADED status. The message lists the
+	// name and the health issues of each degraded addon. It is removed once no addon is degraded.
+	AddonDegradedCondition clusterv1.ConditionType = "AddonDegraded"
+	// AddonHealthIssuesReason used when EKS addons of the cluster report health issues.
+	AddonHealthIssuesReason = "AddonHealthIssues"
+)
+
+const (
+	// UpgradeBlockedByInsightsCondition is set while the update of the Kubernetes version of the cluster is held,
+	// as upgrade insights for the version are in the ERROR status and their risks weren't acknowledged with the
+	// AcknowledgeUpgradeRisksAnnotation. It is removed once the update starts or isn't pending anymore.
+	UpgradeBlockedByInsightsCondition clusterv1.ConditionType = "UpgradeBlockedByInsights"
+	// UpgradeInsightsErrorReason used when upgrade insights of the version the cluster is updated to are in the
+	// ERROR status.
+	UpgradeInsightsErrorReason = "UpgradeInsightsError"
 )
 
 const (
@@ -58,3 +78,23 @@ const (
 	// EKSIdentityProviderConfiguredFailedReason used to report failures while reconciling the identity provider config association.
 	EKSIdentityProviderConfiguredFailedReason = "EKSIdentityProviderConfiguredFailed"
 )
+
+const (
+	// WaitingForDependentsCondition reports that the deletion of the EKS cluster waits for the machines, machine pools
+	// and fargate profiles of the cluster to be deleted, as EKS refuses to delete a cluster which still has any.
+	WaitingForDependentsCondition clusterv1.ConditionType = "WaitingForDependents"
+	// DependentsExistReason used when dependent resources of the control plane are still being deleted.
+	DependentsExistReason = "DependentsExist"
+)
+
+const (
+	// WaitingForControlPlaneENIsCondition reports that the deletion of the managed VPC of the cluster waits for the
+	// network interfaces EKS created for the control plane to be released, as they linger for several minutes after
+	// the deletion of the EKS cluster and prevent the deletion of the subnets. The message lists the remaining ones.
+	WaitingForControlPlaneENIsCondition clusterv1.ConditionType = "WaitingForControlPlaneENIs"
+	// ControlPlaneENIsExistReason used while network interfaces of the control plane remain in the VPC.
+	ControlPlaneENIsExistReason = "ControlPlaneENIsExist"
+	// ControlPlaneENIsWaitTimedOutReason used when network interfaces of the control plane still remain in the VPC
+	// once the wait timed out, and the network is deleted anyway.
+	ControlPlaneENIsWaitTimedOutReason = "ControlPlaneENIsWaitTimedOut"
+)
@@ -86,6 +86,17 @@ var (
 	DefaultEKSControlPlaneRole = fmt.Sprintf("eks-controlplane%s", iamv1.DefaultNameSuffix)
 )
 
+// IAMAuthenticatorManagementMode sets which tool manages the aws-auth ConfigMap of a cluster.
+type IAMAuthenticatorManagementMode string
+
+var (
+	// IAMAuthenticatorManagementModeCAPA makes the controller create and patch the aws-auth ConfigMap.
+	IAMAuthenticatorManagementModeCAPA = IAMAuthenticatorManagementMode("capa")
+
+	// IAMAuthenticatorManagementModeNone leaves the aws-auth ConfigMap to a tool outside of the controller.
+	IAMAuthenticatorManagementModeNone = IAMAuthenticatorManagementMode("none")
+)
+
 // IAMAuthenticatorConfig represents an aws-iam-authenticator configuration.
 type IAMAuthenticatorConfig struct {
 	// RoleMappings is a list of role mappings
@@ -94,6 +105,22 @@ type IAMAuthenticatorConfig struct {
 	// UserMappings is a list of user mappings
 	// +optional
 	UserMappings []UserMapping `json:"mapUsers,omitempty"`
+	// ManagementMode sets whether the controller manages the aws-auth ConfigMap. With none, the ConfigMap
+	// is neither created nor patched, including the mappings of the node roles, and mapRoles and mapUsers
+	// are ignored. Defaults to capa.
+	// +kubebuilder:validation:Enum=capa;none
+	// +optional
+	ManagementMode IAMAuthenticatorManagementMode `json:"managementMode,omitempty"`
+	// AcknowledgeExternalManagement acknowledges that the node roles of the self-managed machine pools and
+	// machine deployments of the cluster are mapped by the tool managing the aws-auth ConfigMap. It is
+	// required with managementMode none when the cluster has self-managed nodes.
+	// +optional
+	AcknowledgeExternalManagement bool `json:"acknowledgeExternalManagement,omitempty"`
+}
+
+// IsManagedExternally returns whether the aws-auth ConfigMap is managed outside of the controller.
+func (c *IAMAuthenticatorConfig) IsManagedExternally() bool {
+	return c != nil && c.ManagementMode == IAMAuthenticatorManagementModeNone
 }
 
 // KubernetesMapping represents the kubernetes RBAC mapping.
@@ -128,8 +155,16 @@ type Addon struct {
 	// +kubebuilder:validation:MinLength:=2
 	// +kubebuilder:validation:Required
 	Name string `json:"name"`
-	// Version is the version of the addon to use
-	Version string `json:"version"`
+	// Version is the version of the addon to use. Either Version or VersionConstraint must be set.
+	// +optional
+	Version string `json:"version,omitempty"`
+	// VersionConstraint resolves the version of the addon from the versions compatible with the Kubernetes
+	// version of the cluster, so that the addon follows the upgrades of the control plane: a semantic version
+	// range such as ">=1.18.0 <1.19.0" or "1.18.x", which selects the highest matching version, or "default"
+	// for the default version of the addon, or "latest" for the highest version. The eksbuild suffix of the
+	// versions is ignored when matching a range.
+	// +optional
+	VersionConstraint string `json:"versionConstraint,omitempty"`
 	// Configuration of the EKS addon
 	// +optional
 	Configuration string `json:"configuration,omitempty"`
@@ -143,6 +178,14 @@ type Addon struct {
 	ServiceAccountRoleArn *string `json:"serviceAccountRoleARN,omitempty"`
 }
 
+const (
+	// AddonVersionConstraintDefault resolves the default version of an addon for the Kubernetes version of the cluster.
+	AddonVersionConstraintDefault = "default"
+
+	// AddonVersionConstraintLatest resolves the highest version of an addon for the Kubernetes version of the cluster.
+	AddonVersionConstraintLatest = "latest"
+)
+
 // AddonResolution defines the method for resolving parameter conflicts.
 type AddonResolution string
 
@@ -200,6 +243,13 @@ type AddonState struct {
 	Status *string `json:"status,omitempty"`
 	// Issues is a list of issue associated with the addon
 	Issues []AddonIssue `json:"issues,omitempty"`
+	// VersionConstraint is the version constraint of the addon in the spec, if any.
+	// +optional
+	VersionConstraint string `json:"versionConstraint,omitempty"`
+	// ResolvedVersion is the version the version constraint of the addon resolved to for the Kubernetes
+	// version of the cluster, which the addon is updated to.
+	// +optional
+	ResolvedVersion string `json:"resolvedVersion,omitempty"`
 }
 
 // AddonIssue represents an issue with an addon.
@@ -212,6 +262,67 @@ type AddonIssue struct {
 	ResourceIDs []string `json:"resourceIds,omitempty"`
 }
 
+// UpgradeInsights summarizes the upgrade readiness insights EKS reports for a cluster.
+type UpgradeInsights struct {
+	// RefreshedAt is when the insights were last fetched.
+	RefreshedAt metav1.Time `json:"refreshedAt"`
+
+	// Insights are the upgrade readiness insights of the cluster.
+	// +optional
+	Insights []UpgradeInsight `json:"insights,omitempty"`
+}
+
+// UpgradeInsight summarizes an upgrade readiness insight.
+type UpgradeInsight struct {
+	// ID is the ID of the insight.
+	ID string `json:"id"`
+
+	// Name is the name of the insight.
+	Name string `json:"name"`
+
+	// Category is the category of the insight.
+	// +optional
+	Category string `json:"category,omitempty"`
+
+	// KubernetesVersion is the Kubernetes version the insight assesses the upgrade to.
+	// +optional
+	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
+
+	// Status is the status of the insight: PASSING, WARNING, ERROR or UNKNOWN.
+	Status string `json:"status"`
+
+	// Reason explains the status of the insight.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// DeprecatedAPIs are the deprecated APIs which are still in use and stop being served, reported by the
+	// insights of the status other than PASSING.
+	// +optional
+	DeprecatedAPIs []DeprecatedAPIUsage `json:"deprecatedAPIs,omitempty"`
+}
+
+// DeprecatedAPIUsage is a deprecated API still in use in the cluster.
+type DeprecatedAPIUsage struct {
+	// Usage is the deprecated API.
+	Usage string `json:"usage"`
+
+	// ReplacedWith is the API replacing the deprecated one.
+	// +optional
+	ReplacedWith string `json:"replacedWith,omitempty"`
+
+	// StopServingVersion is the Kubernetes version from which the deprecated API isn't served anymore.
+	// +optional
+	StopServingVersion string `json:"stopServingVersion,omitempty"`
+
+	// RequestsLast30Days is the number of requests to the deprecated API within the last 30 days.
+	// +optional
+	RequestsLast30Days int64 `json:"requestsLast30Days,omitempty"`
+
+	// Clients are the user agents of the clients which requested the deprecated API.
+	// +optional
+	Clients []string `json:"clients,omitempty"`
+}
+
 const (
 	// SecurityGroupCluster is the security group for communication between EKS
 	// control plane and managed node groups.
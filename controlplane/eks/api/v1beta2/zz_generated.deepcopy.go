@@ -124,6 +124,11 @@ func (in *AWSManagedControlPlaneSpec) DeepCopyInto(out *AWSManagedControlPlaneSp
 			copy(*out, *in)
 		}
 	}
+	if in.RoleManagement != nil {
+		in, out := &in.RoleManagement, &out.RoleManagement
+		*out = new(apiv1beta2.IAMRoleManagement)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Logging != nil {
 		in, out := &in.Logging, &out.Logging
 		*out = new(ControlPlaneLoggingSpec)
@@ -172,6 +177,11 @@ func (in *AWSManagedControlPlaneSpec) DeepCopyInto(out *AWSManagedControlPlaneSp
 	}
 	in.VpcCni.DeepCopyInto(&out.VpcCni)
 	out.KubeProxy = in.KubeProxy
+	if in.NodeTerminationHandling != nil {
+		in, out := &in.NodeTerminationHandling, &out.NodeTerminationHandling
+		*out = new(apiv1beta2.NodeTerminationHandling)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSManagedControlPlaneSpec.
@@ -226,6 +236,20 @@ func (in *AWSManagedControlPlaneStatus) DeepCopyInto(out *AWSManagedControlPlane
 		}
 	}
 	out.IdentityProviderStatus = in.IdentityProviderStatus
+	if in.IdentityCredentialsExpiration != nil {
+		in, out := &in.IdentityCredentialsExpiration, &out.IdentityCredentialsExpiration
+		*out = (*in).DeepCopy()
+	}
+	if in.UpgradeInsights != nil {
+		in, out := &in.UpgradeInsights, &out.UpgradeInsights
+		*out = new(UpgradeInsights)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeTerminationHandling != nil {
+		in, out := &in.NodeTerminationHandling, &out.NodeTerminationHandling
+		*out = new(apiv1beta2.NodeTerminationHandlingStatus)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSManagedControlPlaneStatus.
@@ -342,6 +366,26 @@ func (in *ControlPlaneLoggingSpec) DeepCopy() *ControlPlaneLoggingSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeprecatedAPIUsage) DeepCopyInto(out *DeprecatedAPIUsage) {
+	*out = *in
+	if in.Clients != nil {
+		in, out := &in.Clients, &out.Clients
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeprecatedAPIUsage.
+func (in *DeprecatedAPIUsage) DeepCopy() *DeprecatedAPIUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(DeprecatedAPIUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EncryptionConfig) DeepCopyInto(out *EncryptionConfig) {
 	*out = *in
@@ -568,6 +612,51 @@ func (in *RoleMapping) DeepCopy() *RoleMapping {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeInsight) DeepCopyInto(out *UpgradeInsight) {
+	*out = *in
+	if in.DeprecatedAPIs != nil {
+		in, out := &in.DeprecatedAPIs, &out.DeprecatedAPIs
+		*out = make([]DeprecatedAPIUsage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeInsight.
+func (in *UpgradeInsight) DeepCopy() *UpgradeInsight {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeInsight)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeInsights) DeepCopyInto(out *UpgradeInsights) {
+	*out = *in
+	in.RefreshedAt.DeepCopyInto(&out.RefreshedAt)
+	if in.Insights != nil {
+		in, out := &in.Insights, &out.Insights
+		*out = make([]UpgradeInsight, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeInsights.
+func (in *UpgradeInsights) DeepCopy() *UpgradeInsights {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeInsights)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UserMapping) DeepCopyInto(out *UserMapping) {
 	*out = *in
@@ -49,6 +49,8 @@ import (
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/instancestate"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/kubeproxy"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/network"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/nodetermination"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/ownershiptags"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/securitygroup"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -103,6 +105,7 @@ type AWSManagedControlPlaneReconciler struct {
 	AlternativeGCStrategy        bool
 	WaitInfraPeriod              time.Duration
 	TagUnmanagedNetworkResources bool
+	OwnershipTagPrefix           string
 }
 
 // getAWSNodeService factory func is added for testing purpose so that we can inject mocked AWSNodeInterface to the AWSManagedControlPlaneReconciler.
@@ -204,6 +207,7 @@ func (r *AWSManagedControlPlaneReconciler) SetupWithManager(ctx context.Context,
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsmachinetemplates,verbs=get;list;watch
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsmanagedmachinepools;awsmanagedmachinepools/status,verbs=get;list;watch
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsmachinepools;awsmachinepools/status,verbs=get;list;watch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsfargateprofiles,verbs=get;list;watch;delete
 // +kubebuilder:rbac:groups=controlplane.cluster.x-k8s.io,resources=awsmanagedcontrolplanes,verbs=get;list;watch;update;patch;delete
 // +kubebuilder:rbac:groups=controlplane.cluster.x-k8s.io,resources=awsmanagedcontrolplanes/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsclusterroleidentities;awsclusterstaticidentities;awsclustercontrolleridentities,verbs=get;list;watch
@@ -251,6 +255,7 @@ func (r *AWSManagedControlPlaneReconciler) Reconcile(ctx context.Context, req ct
 		AllowAdditionalRoles:         r.AllowAdditionalRoles,
 		Endpoints:                    r.Endpoints,
 		TagUnmanagedNetworkResources: r.TagUnmanagedNetworkResources,
+		OwnershipTagPrefix:           r.OwnershipTagPrefix,
 		Logger:                       log,
 	})
 	if err != nil {
@@ -262,14 +267,18 @@ func (r *AWSManagedControlPlaneReconciler) Reconcile(ctx context.Context, req ct
 		applicableConditions := []clusterv1.ConditionType{
 			ekscontrolplanev1.EKSControlPlaneReadyCondition,
 			ekscontrolplanev1.IAMControlPlaneRolesReadyCondition,
-			ekscontrolplanev1.IAMAuthenticatorConfiguredCondition,
 			ekscontrolplanev1.EKSAddonsConfiguredCondition,
 			infrav1.VpcReadyCondition,
 			infrav1.SubnetsReadyCondition,
 			infrav1.ClusterSecurityGroupsReadyCondition,
 		}
 
-		if managedScope.VPC().IsManaged(managedScope.Name()) {
+		// The aws-auth ConfigMap being managed externally is reported without affecting the readiness.
+		if conditions.GetReason(managedScope.ControlPlane, ekscontrolplanev1.IAMAuthenticatorConfiguredCondition) != ekscontrolplanev1.IAMAuthenticatorManagedExternallyReason {
+			applicableConditions = append(applicableConditions, ekscontrolplanev1.IAMAuthenticatorConfiguredCondition)
+		}
+
+		if managedScope.VPC().IsManaged(managedScope.Name(), managedScope.OwnershipTagPrefixes()...) {
 			applicableConditions = append(applicableConditions,
 				infrav1.InternetGatewayReadyCondition,
 				infrav1.NatGatewaysReadyCondition,
@@ -293,6 +302,9 @@ func (r *AWSManagedControlPlaneReconciler) Reconcile(ctx context.Context, req ct
 
 	if !awsManagedControlPlane.ObjectMeta.DeletionTimestamp.IsZero() {
 		// Handle deletion reconciliation loop.
+		if scope.DeferDeletion(managedScope, managedScope) {
+			return ctrl.Result{RequeueAfter: scope.ReadOnlyRequeueAfter}, nil
+		}
 		return r.reconcileDelete(ctx, managedScope)
 	}
 
@@ -335,6 +347,16 @@ func (r *AWSManagedControlPlaneReconciler) reconcileNormal(ctx context.Context,
 	awsnodeService := r.getAWSNodeService(managedScope)
 	kubeproxyService := r.getKubeProxyService(managedScope)
 
+	// Resources must carry the ownership tag with the current prefix before they're looked up by it. Otherwise, the
+	// prefix is recorded so that a later change of the default prefix migrates them as well.
+	if managedScope.PreviousOwnershipTagPrefix() != "" {
+		if err := ownershiptags.NewService(managedScope).ReconcileOwnershipTags(); err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to migrate ownership tags for AWSManagedControlPlane %s/%s: %w", awsManagedControlPlane.Namespace, awsManagedControlPlane.Name, err)
+		}
+	} else {
+		managedScope.CompleteOwnershipTagMigration()
+	}
+
 	if err := networkSvc.ReconcileNetwork(); err != nil {
 		return reconcile.Result{}, fmt.Errorf("failed to reconcile network for AWSManagedControlPlane %s/%s: %w", awsManagedControlPlane.Namespace, awsManagedControlPlane.Name, err)
 	}
@@ -369,11 +391,25 @@ func (r *AWSManagedControlPlaneReconciler) reconcileNormal(ctx context.Context,
 			managedScope.Error(err, "non-fatal: failed to set up EventBridge")
 		}
 	}
-	if err := authService.ReconcileIAMAuthenticator(ctx); err != nil {
+
+	if managedScope.NodeTerminationHandling() != nil || managedScope.NodeTerminationHandlingStatus() != nil {
+		if err := nodetermination.NewService(managedScope).ReconcileNodeTerminationHandling(); err != nil {
+			return reconcile.Result{}, errors.Wrapf(err, "failed to reconcile node termination handler resources for AWSManagedControlPlane %s/%s", awsManagedControlPlane.Namespace, awsManagedControlPlane.Name)
+		}
+	}
+
+	switch err := authService.ReconcileIAMAuthenticator(ctx); {
+	case errors.Is(err, iamauth.ErrExternalManagementNotAcknowledged):
+		// The nodes won't join until their roles are mapped externally, which doesn't block the control plane.
+		conditions.MarkFalse(awsManagedControlPlane, ekscontrolplanev1.IAMAuthenticatorConfiguredCondition, ekscontrolplanev1.IAMAuthenticatorExternalManagementNotAcknowledgedReason, clusterv1.ConditionSeverityWarning, err.Error())
+	case err != nil:
 		conditions.MarkFalse(awsManagedControlPlane, ekscontrolplanev1.IAMAuthenticatorConfiguredCondition, ekscontrolplanev1.IAMAuthenticatorConfigurationFailedReason, clusterv1.ConditionSeverityError, err.Error())
 		return reconcile.Result{}, errors.Wrapf(err, "failed to reconcile aws-iam-authenticator config for AWSManagedControlPlane %s/%s", awsManagedControlPlane.Namespace, awsManagedControlPlane.Name)
+	case managedScope.IAMAuthConfig().IsManagedExternally():
+		conditions.MarkFalse(awsManagedControlPlane, ekscontrolplanev1.IAMAuthenticatorConfiguredCondition, ekscontrolplanev1.IAMAuthenticatorManagedExternallyReason, clusterv1.ConditionSeverityInfo, "aws-auth is managed externally")
+	default:
+		conditions.MarkTrue(awsManagedControlPlane, ekscontrolplanev1.IAMAuthenticatorConfiguredCondition)
 	}
-	conditions.MarkTrue(awsManagedControlPlane, ekscontrolplanev1.IAMAuthenticatorConfiguredCondition)
 
 	for _, subnet := range managedScope.Subnets().FilterPrivate() {
 		managedScope.SetFailureDomain(subnet.AvailabilityZone, clusterv1.FailureDomainSpec{
@@ -396,11 +432,20 @@ func (r *AWSManagedControlPlaneReconciler) reconcileDelete(ctx context.Context,
 		log.Error(err, "error getting controlplane dependencies", "namespace", controlPlane.Namespace, "name", controlPlane.Name)
 		return reconcile.Result{}, err
 	}
+	numFargateProfiles, err := r.deleteFargateProfiles(ctx, managedScope)
+	if err != nil {
+		log.Error(err, "error deleting fargate profiles of controlplane", "namespace", controlPlane.Namespace, "name", controlPlane.Name)
+		return reconcile.Result{}, err
+	}
+	numDependencies += numFargateProfiles
 	if numDependencies > 0 {
 		log.Info("EKS cluster still has dependencies - requeue needed", "dependencyCount", numDependencies)
+		conditions.MarkTrueWithNegativePolarity(controlPlane, ekscontrolplanev1.WaitingForDependentsCondition, ekscontrolplanev1.DependentsExistReason, clusterv1.ConditionSeverityInfo,
+			"%d dependent resources are still being deleted", numDependencies)
 		return reconcile.Result{RequeueAfter: deleteRequeueAfter}, nil
 	}
 	log.Info("EKS cluster has no dependencies")
+	conditions.Delete(controlPlane, ekscontrolplanev1.WaitingForDependentsCondition)
 
 	ekssvc := eks.NewService(managedScope)
 	ec2svc := ec2.NewService(managedScope)
@@ -412,6 +457,16 @@ func (r *AWSManagedControlPlaneReconciler) reconcileDelete(ctx context.Context,
 		return reconcile.Result{}, err
 	}
 
+	released, err := ekssvc.ControlPlaneENIsReleased()
+	if err != nil {
+		log.Error(err, "error looking for network interfaces of EKS control plane", "namespace", controlPlane.Namespace, "name", controlPlane.Name)
+		return reconcile.Result{}, err
+	}
+	if !released {
+		log.Info("EKS control plane network interfaces still exist - requeue needed")
+		return reconcile.Result{RequeueAfter: deleteRequeueAfter}, nil
+	}
+
 	if err := ec2svc.DeleteBastion(); err != nil {
 		log.Error(err, "error deleting bastion for AWSManagedControlPlane", "namespace", controlPlane.Namespace, "name", controlPlane.Name)
 		return reconcile.Result{}, err
@@ -422,6 +477,13 @@ func (r *AWSManagedControlPlaneReconciler) reconcileDelete(ctx context.Context,
 		return reconcile.Result{}, err
 	}
 
+	if nth := managedScope.NodeTerminationHandling(); (nth != nil && nth.Enabled) || managedScope.NodeTerminationHandlingStatus() != nil {
+		if err := nodetermination.NewService(managedScope).DeleteNodeTerminationHandling(); err != nil {
+			log.Error(err, "error deleting node termination handler resources for AWSManagedControlPlane", "namespace", controlPlane.Namespace, "name", controlPlane.Name)
+			return reconcile.Result{}, err
+		}
+	}
+
 	if r.ExternalResourceGC {
 		gcSvc := gc.NewService(managedScope, gc.WithGCStrategy(r.AlternativeGCStrategy))
 		if gcErr := gcSvc.ReconcileDelete(ctx); gcErr != nil {
@@ -499,6 +561,44 @@ func (r *AWSManagedControlPlaneReconciler) dependencyCount(ctx context.Context,
 	return dependencies, nil
 }
 
+// deleteFargateProfiles deletes the AWSFargateProfiles of the cluster, which are not deleted by Cluster API before the
+// control plane, and returns the number of profiles left. The EKS cluster can only be deleted once they are gone.
+func (r *AWSManagedControlPlaneReconciler) deleteFargateProfiles(ctx context.Context, managedScope *scope.ManagedControlPlaneScope) (int, error) {
+	if !feature.Gates.Enabled(feature.EKSFargate) {
+		return 0, nil
+	}
+
+	log := logger.FromContext(ctx)
+
+	clusterName := managedScope.Name()
+	namespace := managedScope.Namespace()
+
+	fargateProfiles := &expinfrav1.AWSFargateProfileList{}
+	if err := r.Client.List(ctx, fargateProfiles, client.InNamespace(namespace)); err != nil {
+		return 0, fmt.Errorf("failed to list fargate profiles for cluster %s/%s: %w", namespace, clusterName, err)
+	}
+
+	remaining := 0
+	for i := range fargateProfiles.Items {
+		fargateProfile := &fargateProfiles.Items[i]
+		if fargateProfile.Spec.ClusterName != clusterName {
+			continue
+		}
+		remaining++
+		if !fargateProfile.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		log.Info("Deleting AWSFargateProfile before the EKS cluster", "fargateProfile", klog.KObj(fargateProfile))
+		if err := r.Client.Delete(ctx, fargateProfile); client.IgnoreNotFound(err) != nil {
+			return 0, fmt.Errorf("failed to delete fargate profile %s/%s: %w", namespace, fargateProfile.Name, err)
+		}
+	}
+	log.Debug("tested for AWSFargateProfile dependencies", "count", remaining)
+
+	return remaining, nil
+}
+
 func (r *AWSManagedControlPlaneReconciler) managedClusterToManagedControlPlane(_ context.Context, log *logger.Logger) handler.MapFunc {
 	return func(ctx context.Context, o client.Object) []ctrl.Request {
 		awsManagedCluster, ok := o.(*infrav1.AWSManagedCluster)
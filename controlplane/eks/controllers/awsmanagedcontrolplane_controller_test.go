@@ -920,6 +920,13 @@ func mockedEKSCluster(g *WithT, eksRec *mock_eksiface.MockEKSAPIMockRecorder, ia
 		}),
 	}).Return(&eks.TagResourceOutput{}, nil)
 
+	eksRec.ListInsightsPages(&eks.ListInsightsInput{
+		ClusterName: aws.String("test-cluster"),
+		Filter: &eks.InsightsFilter{
+			Categories: aws.StringSlice([]string{eks.CategoryUpgradeReadiness}),
+		},
+	}, gomock.Any()).Return(nil)
+
 	eksRec.ListAddons(&eks.ListAddonsInput{
 		ClusterName: aws.String("test-cluster"),
 	}).Return(&eks.ListAddonsOutput{}, nil)
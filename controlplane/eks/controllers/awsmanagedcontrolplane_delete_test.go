@@ -0,0 +1,116 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	utilfeature "k8s.io/component-base/featuregate/testing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/feature"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// TestAWSManagedControlPlaneReconcileDeleteWithFargateProfiles reproduces the deletion of a control plane whose cluster
+// still has fargate profiles: EKS refuses to delete such a cluster, so the profiles have to be deleted first.
+func TestAWSManagedControlPlaneReconcileDeleteWithFargateProfiles(t *testing.T) {
+	g := NewWithT(t)
+	defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.EKSFargate, true)()
+
+	controllerIdentity := createControllerIdentity(g)
+	ns, err := testEnv.CreateNamespace(ctx, fmt.Sprintf("integ-test-%s", util.RandomString(5)))
+	g.Expect(err).To(BeNil())
+
+	cluster, awsManagedCluster, awsManagedControlPlane := getManagedClusterObjects("test-cluster", ns.Name)
+	awsManagedControlPlane.Finalizers = []string{ekscontrolplanev1.ManagedControlPlaneFinalizer}
+	fargateProfile := &expinfrav1.AWSFargateProfile{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "profile",
+			Namespace: ns.Name,
+			// Set by the fargate profile controller, which isn't running.
+			Finalizers: []string{expinfrav1.FargateProfileFinalizer},
+		},
+		Spec: expinfrav1.FargateProfileSpec{
+			ClusterName: cluster.Name,
+			ProfileName: "profile",
+		},
+	}
+
+	g.Expect(testEnv.Create(ctx, &cluster)).To(Succeed())
+	g.Expect(testEnv.Create(ctx, &awsManagedCluster)).To(Succeed())
+	g.Expect(testEnv.Create(ctx, &awsManagedControlPlane)).To(Succeed())
+	g.Expect(testEnv.Create(ctx, fargateProfile)).To(Succeed())
+	defer t.Cleanup(func() {
+		g.Expect(testEnv.Cleanup(ctx, &cluster, &awsManagedCluster, controllerIdentity, ns)).To(Succeed())
+	})
+
+	g.Expect(testEnv.Delete(ctx, &awsManagedControlPlane)).To(Succeed())
+
+	// No AWS client is mocked: the EKS cluster must not be deleted while the fargate profile exists.
+	reconciler := AWSManagedControlPlaneReconciler{
+		Client:    testEnv.Client,
+		Recorder:  record.NewFakeRecorder(10),
+		EnableIAM: true,
+	}
+	request := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&awsManagedControlPlane)}
+
+	g.Eventually(func(g Gomega) {
+		result, err := reconciler.Reconcile(ctx, request)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(result.RequeueAfter).To(Equal(deleteRequeueAfter))
+
+		profile := &expinfrav1.AWSFargateProfile{}
+		g.Expect(testEnv.Get(ctx, client.ObjectKeyFromObject(fargateProfile), profile)).To(Succeed())
+		g.Expect(profile.DeletionTimestamp.IsZero()).To(BeFalse())
+	}, 10*time.Second).Should(Succeed())
+
+	controlPlane := &ekscontrolplanev1.AWSManagedControlPlane{}
+	g.Expect(testEnv.Get(ctx, client.ObjectKeyFromObject(&awsManagedControlPlane), controlPlane)).To(Succeed())
+	g.Expect(controlPlane.Finalizers).To(ContainElement(ekscontrolplanev1.ManagedControlPlaneFinalizer))
+	g.Expect(conditions.IsTrue(controlPlane, ekscontrolplanev1.WaitingForDependentsCondition)).To(BeTrue())
+	g.Expect(conditions.GetReason(controlPlane, ekscontrolplanev1.WaitingForDependentsCondition)).To(Equal(ekscontrolplanev1.DependentsExistReason))
+
+	// Once the fargate profile controller deleted the profile, the control plane stops waiting for it.
+	profile := &expinfrav1.AWSFargateProfile{}
+	g.Expect(testEnv.Get(ctx, client.ObjectKeyFromObject(fargateProfile), profile)).To(Succeed())
+	profile.Finalizers = nil
+	g.Expect(testEnv.Update(ctx, profile)).To(Succeed())
+
+	managedScope := getAWSManagedControlPlaneScope(&cluster, controlPlane)
+	g.Eventually(func(g Gomega) {
+		g.Expect(apierrors.IsNotFound(testEnv.Get(ctx, client.ObjectKeyFromObject(fargateProfile), profile))).To(BeTrue())
+		remaining, err := reconciler.deleteFargateProfiles(ctx, managedScope)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(remaining).To(BeZero())
+	}, 10*time.Second).Should(Succeed())
+
+	// The AWS API isn't mocked for the deletion of the EKS cluster itself.
+	g.Expect(testEnv.Get(ctx, client.ObjectKeyFromObject(&awsManagedControlPlane), controlPlane)).To(Succeed())
+	controlPlane.Finalizers = nil
+	g.Expect(testEnv.Update(ctx, controlPlane)).To(Succeed())
+}
@@ -42,9 +42,19 @@ func (src *AWSMachinePool) ConvertTo(dstRaw conversion.Hub) error {
 	if restored.Spec.SuspendProcesses != nil {
 		dst.Spec.SuspendProcesses = restored.Spec.SuspendProcesses
 	}
-	if restored.Spec.RefreshPreferences != nil {
+	// The v1beta1 object may have been edited since it was converted, only restore the fields of the
+	// structs which are still set.
+	if restored.Spec.RefreshPreferences != nil && dst.Spec.RefreshPreferences != nil {
 		dst.Spec.RefreshPreferences.Disable = restored.Spec.RefreshPreferences.Disable
 		dst.Spec.RefreshPreferences.MaxHealthyPercentage = restored.Spec.RefreshPreferences.MaxHealthyPercentage
+		dst.Spec.RefreshPreferences.CheckpointPercentages = restored.Spec.RefreshPreferences.CheckpointPercentages
+		dst.Spec.RefreshPreferences.CheckpointDelay = restored.Spec.RefreshPreferences.CheckpointDelay
+		dst.Spec.RefreshPreferences.SkipMatching = restored.Spec.RefreshPreferences.SkipMatching
+		dst.Spec.RefreshPreferences.ScaleInProtectedInstances = restored.Spec.RefreshPreferences.ScaleInProtectedInstances
+		dst.Spec.RefreshPreferences.StandbyInstances = restored.Spec.RefreshPreferences.StandbyInstances
+		dst.Spec.RefreshPreferences.CancelOutdatedRefresh = restored.Spec.RefreshPreferences.CancelOutdatedRefresh
+		dst.Spec.RefreshPreferences.MaxSurge = restored.Spec.RefreshPreferences.MaxSurge
+		dst.Spec.RefreshPreferences.TriggerOnUserDataChange = restored.Spec.RefreshPreferences.TriggerOnUserDataChange
 	}
 	if restored.Spec.AWSLaunchTemplate.InstanceMetadataOptions != nil {
 		dst.Spec.AWSLaunchTemplate.InstanceMetadataOptions = restored.Spec.AWSLaunchTemplate.InstanceMetadataOptions
@@ -56,9 +66,84 @@ func (src *AWSMachinePool) ConvertTo(dstRaw conversion.Hub) error {
 	if restored.Spec.AWSLaunchTemplate.PrivateDNSName != nil {
 		dst.Spec.AWSLaunchTemplate.PrivateDNSName = restored.Spec.AWSLaunchTemplate.PrivateDNSName
 	}
+	dst.Spec.AWSLaunchTemplate.ValidateBeforeUse = restored.Spec.AWSLaunchTemplate.ValidateBeforeUse
+	dst.Spec.AWSLaunchTemplate.LaunchTemplateVersionRetention = restored.Spec.AWSLaunchTemplate.LaunchTemplateVersionRetention
+	dst.Spec.AWSLaunchTemplate.AMI.SourceRegion = restored.Spec.AWSLaunchTemplate.AMI.SourceRegion
+	dst.Spec.AWSLaunchTemplate.AMI.CopyEncryptionKey = restored.Spec.AWSLaunchTemplate.AMI.CopyEncryptionKey
+	dst.Spec.AWSLaunchTemplate.AMI.GPUCompatible = restored.Spec.AWSLaunchTemplate.AMI.GPUCompatible
+	dst.Spec.AWSLaunchTemplate.AMIType = restored.Spec.AWSLaunchTemplate.AMIType
+	dst.Spec.AWSLaunchTemplate.OSFamily = restored.Spec.AWSLaunchTemplate.OSFamily
+	dst.Spec.AWSLaunchTemplate.Bottlerocket = restored.Spec.AWSLaunchTemplate.Bottlerocket
+	dst.Spec.AWSLaunchTemplate.Ref = restored.Spec.AWSLaunchTemplate.Ref
+	dst.Spec.AWSLaunchTemplate.MarketType = restored.Spec.AWSLaunchTemplate.MarketType
+	dst.Spec.AWSLaunchTemplate.CapacityReservationID = restored.Spec.AWSLaunchTemplate.CapacityReservationID
+	dst.Spec.AWSLaunchTemplate.CapacityReservationResourceGroupARN = restored.Spec.AWSLaunchTemplate.CapacityReservationResourceGroupARN
+	dst.Spec.AWSLaunchTemplate.PlacementGroupName = restored.Spec.AWSLaunchTemplate.PlacementGroupName
+	dst.Spec.AWSLaunchTemplate.PlacementGroupPartition = restored.Spec.AWSLaunchTemplate.PlacementGroupPartition
+	dst.Spec.AWSLaunchTemplate.EnclaveOptions = restored.Spec.AWSLaunchTemplate.EnclaveOptions
+	dst.Spec.AWSLaunchTemplate.ElasticInferenceAccelerators = restored.Spec.AWSLaunchTemplate.ElasticInferenceAccelerators
+	dst.Spec.AWSLaunchTemplate.NetworkInterfaces = restored.Spec.AWSLaunchTemplate.NetworkInterfaces
+	dst.Spec.AWSLaunchTemplate.PerOverrideUserData = restored.Spec.AWSLaunchTemplate.PerOverrideUserData
 
 	dst.Spec.DefaultInstanceWarmup = restored.Spec.DefaultInstanceWarmup
+	dst.Spec.MaxInstanceLifetime = restored.Spec.MaxInstanceLifetime
 	dst.Spec.AWSLaunchTemplate.NonRootVolumes = restored.Spec.AWSLaunchTemplate.NonRootVolumes
+	dst.Spec.UnmanagedFields = restored.Spec.UnmanagedFields
+	dst.Spec.DedicatedSecurityGroup = restored.Spec.DedicatedSecurityGroup
+	dst.Spec.LifecycleHooks = restored.Spec.LifecycleHooks
+	dst.Spec.ScalingPolicies = restored.Spec.ScalingPolicies
+	dst.Spec.AZFailureHandling = restored.Spec.AZFailureHandling
+	dst.Spec.TerminationPolicies = restored.Spec.TerminationPolicies
+	dst.Spec.HealthCheckType = restored.Spec.HealthCheckType
+	dst.Spec.HealthCheckGracePeriod = restored.Spec.HealthCheckGracePeriod
+	dst.Spec.Metrics = restored.Spec.Metrics
+	dst.Spec.NodeLabels = restored.Spec.NodeLabels
+	dst.Spec.NodeTaints = restored.Spec.NodeTaints
+	dst.Spec.WarmPool = restored.Spec.WarmPool
+	dst.Spec.TargetGroupARNs = restored.Spec.TargetGroupARNs
+	dst.Spec.ScaleInDrainPolicy = restored.Spec.ScaleInDrainPolicy
+	dst.Spec.PreserveOnDelete = restored.Spec.PreserveOnDelete
+	if restored.Spec.MixedInstancesPolicy != nil && dst.Spec.MixedInstancesPolicy != nil {
+		for i := range dst.Spec.MixedInstancesPolicy.Overrides {
+			if i < len(restored.Spec.MixedInstancesPolicy.Overrides) &&
+				restored.Spec.MixedInstancesPolicy.Overrides[i].InstanceType == dst.Spec.MixedInstancesPolicy.Overrides[i].InstanceType {
+				dst.Spec.MixedInstancesPolicy.Overrides[i].InstanceRequirements = restored.Spec.MixedInstancesPolicy.Overrides[i].InstanceRequirements
+				dst.Spec.MixedInstancesPolicy.Overrides[i].RootVolume = restored.Spec.MixedInstancesPolicy.Overrides[i].RootVolume
+			}
+		}
+	}
+	dst.Status.InfrastructureMachineKind = restored.Status.InfrastructureMachineKind
+	dst.Status.AdditionalSecurityGroupIDs = restored.Status.AdditionalSecurityGroupIDs
+	dst.Status.OverrideLaunchTemplates = restored.Status.OverrideLaunchTemplates
+	dst.Status.DedicatedSecurityGroupID = restored.Status.DedicatedSecurityGroupID
+	dst.Status.LifecycleActions = restored.Status.LifecycleActions
+	dst.Status.ScalingPolicies = restored.Status.ScalingPolicies
+	dst.Status.ExcludedAvailabilityZones = restored.Status.ExcludedAvailabilityZones
+	dst.Status.CapacityBlock = restored.Status.CapacityBlock
+	dst.Status.CopiedAMI = restored.Status.CopiedAMI
+	dst.Status.CapacityMix = restored.Status.CapacityMix
+	dst.Status.SpotPrice = restored.Status.SpotPrice
+	dst.Status.CapacityProbe = restored.Status.CapacityProbe
+	dst.Status.SpotPlacementScores = restored.Status.SpotPlacementScores
+	dst.Status.LastScaleEvent = restored.Status.LastScaleEvent
+	dst.Status.ASG = restored.Status.ASG
+	dst.Status.InstanceRefreshStatus = restored.Status.InstanceRefreshStatus
+	dst.Status.AZSequentialRefresh = restored.Status.AZSequentialRefresh
+	dst.Status.InstanceRefreshLaunchTemplateVersion = restored.Status.InstanceRefreshLaunchTemplateVersion
+	dst.Status.PreviousLaunchTemplateVersion = restored.Status.PreviousLaunchTemplateVersion
+	dst.Status.LaunchTemplateRollback = restored.Status.LaunchTemplateRollback
+	dst.Status.LaunchTemplateChange = restored.Status.LaunchTemplateChange
+	dst.Status.TargetGroupARNs = restored.Status.TargetGroupARNs
+	dst.Status.ReadyReplicas = restored.Status.ReadyReplicas
+	for i := range dst.Status.Instances {
+		if i < len(restored.Status.Instances) && restored.Status.Instances[i].InstanceID == dst.Status.Instances[i].InstanceID {
+			dst.Status.Instances[i].Lifecycle = restored.Status.Instances[i].Lifecycle
+			dst.Status.Instances[i].LifecycleState = restored.Status.Instances[i].LifecycleState
+			dst.Status.Instances[i].HealthStatus = restored.Status.Instances[i].HealthStatus
+			dst.Status.Instances[i].LaunchTemplateVersion = restored.Status.Instances[i].LaunchTemplateVersion
+			dst.Status.Instances[i].AvailabilityZone = restored.Status.Instances[i].AvailabilityZone
+		}
+	}
 
 	return nil
 }
@@ -99,20 +184,45 @@ func (src *AWSManagedMachinePool) ConvertTo(dstRaw conversion.Hub) error {
 		return err
 	}
 
-	if restored.Spec.AWSLaunchTemplate != nil {
-		if dst.Spec.AWSLaunchTemplate == nil {
-			dst.Spec.AWSLaunchTemplate = restored.Spec.AWSLaunchTemplate
-		}
+	// The launch template may have been removed from the v1beta1 object since it was converted, in which
+	// case it must not be restored.
+	if restored.Spec.AWSLaunchTemplate != nil && dst.Spec.AWSLaunchTemplate != nil {
 		dst.Spec.AWSLaunchTemplate.InstanceMetadataOptions = restored.Spec.AWSLaunchTemplate.InstanceMetadataOptions
 		dst.Spec.AWSLaunchTemplate.NonRootVolumes = restored.Spec.AWSLaunchTemplate.NonRootVolumes
 
 		if restored.Spec.AWSLaunchTemplate.PrivateDNSName != nil {
 			dst.Spec.AWSLaunchTemplate.PrivateDNSName = restored.Spec.AWSLaunchTemplate.PrivateDNSName
 		}
+		dst.Spec.AWSLaunchTemplate.ValidateBeforeUse = restored.Spec.AWSLaunchTemplate.ValidateBeforeUse
+		dst.Spec.AWSLaunchTemplate.LaunchTemplateVersionRetention = restored.Spec.AWSLaunchTemplate.LaunchTemplateVersionRetention
+		dst.Spec.AWSLaunchTemplate.AMI.SourceRegion = restored.Spec.AWSLaunchTemplate.AMI.SourceRegion
+		dst.Spec.AWSLaunchTemplate.AMI.CopyEncryptionKey = restored.Spec.AWSLaunchTemplate.AMI.CopyEncryptionKey
+		dst.Spec.AWSLaunchTemplate.AMI.GPUCompatible = restored.Spec.AWSLaunchTemplate.AMI.GPUCompatible
+		dst.Spec.AWSLaunchTemplate.AMIType = restored.Spec.AWSLaunchTemplate.AMIType
+		dst.Spec.AWSLaunchTemplate.OSFamily = restored.Spec.AWSLaunchTemplate.OSFamily
+		dst.Spec.AWSLaunchTemplate.Bottlerocket = restored.Spec.AWSLaunchTemplate.Bottlerocket
+		dst.Spec.AWSLaunchTemplate.Ref = restored.Spec.AWSLaunchTemplate.Ref
+		dst.Spec.AWSLaunchTemplate.MarketType = restored.Spec.AWSLaunchTemplate.MarketType
+		dst.Spec.AWSLaunchTemplate.CapacityReservationID = restored.Spec.AWSLaunchTemplate.CapacityReservationID
+		dst.Spec.AWSLaunchTemplate.CapacityReservationResourceGroupARN = restored.Spec.AWSLaunchTemplate.CapacityReservationResourceGroupARN
+		dst.Spec.AWSLaunchTemplate.PlacementGroupName = restored.Spec.AWSLaunchTemplate.PlacementGroupName
+		dst.Spec.AWSLaunchTemplate.PlacementGroupPartition = restored.Spec.AWSLaunchTemplate.PlacementGroupPartition
+		dst.Spec.AWSLaunchTemplate.EnclaveOptions = restored.Spec.AWSLaunchTemplate.EnclaveOptions
+		dst.Spec.AWSLaunchTemplate.ElasticInferenceAccelerators = restored.Spec.AWSLaunchTemplate.ElasticInferenceAccelerators
+		dst.Spec.AWSLaunchTemplate.NetworkInterfaces = restored.Spec.AWSLaunchTemplate.NetworkInterfaces
+		dst.Spec.AWSLaunchTemplate.PerOverrideUserData = restored.Spec.AWSLaunchTemplate.PerOverrideUserData
 	}
 	if restored.Spec.AvailabilityZoneSubnetType != nil {
 		dst.Spec.AvailabilityZoneSubnetType = restored.Spec.AvailabilityZoneSubnetType
 	}
+	dst.Spec.DedicatedSecurityGroup = restored.Spec.DedicatedSecurityGroup
+	dst.Spec.RoleManagement = restored.Spec.RoleManagement
+	dst.Spec.DeletePolicy = restored.Spec.DeletePolicy
+	dst.Status.AdditionalSecurityGroupIDs = restored.Status.AdditionalSecurityGroupIDs
+	dst.Status.DedicatedSecurityGroupID = restored.Status.DedicatedSecurityGroupID
+	dst.Status.CopiedAMI = restored.Status.CopiedAMI
+	dst.Status.ActiveUpdate = restored.Status.ActiveUpdate
+	dst.Status.InfrastructureMachineKind = restored.Status.InfrastructureMachineKind
 
 	return nil
 }
@@ -149,14 +259,34 @@ func (r *AWSManagedMachinePoolList) ConvertFrom(srcRaw conversion.Hub) error {
 // ConvertTo converts the v1beta1 AWSFargateProfile receiver to a v1beta2 AWSFargateProfile.
 func (src *AWSFargateProfile) ConvertTo(dstRaw conversion.Hub) error {
 	dst := dstRaw.(*infrav1exp.AWSFargateProfile)
-	return Convert_v1beta1_AWSFargateProfile_To_v1beta2_AWSFargateProfile(src, dst, nil)
+	if err := Convert_v1beta1_AWSFargateProfile_To_v1beta2_AWSFargateProfile(src, dst, nil); err != nil {
+		return err
+	}
+	// Manually restore data.
+	restored := &infrav1exp.AWSFargateProfile{}
+	if ok, err := utilconversion.UnmarshalData(src, restored); err != nil || !ok {
+		return err
+	}
+
+	dst.Spec.RoleManagement = restored.Spec.RoleManagement
+
+	return nil
 }
 
 // ConvertFrom converts the v1beta2 AWSFargateProfile receiver to v1beta1 AWSFargateProfile.
 func (r *AWSFargateProfile) ConvertFrom(srcRaw conversion.Hub) error {
 	src := srcRaw.(*infrav1exp.AWSFargateProfile)
 
-	return Convert_v1beta2_AWSFargateProfile_To_v1beta1_AWSFargateProfile(src, r, nil)
+	if err := Convert_v1beta2_AWSFargateProfile_To_v1beta1_AWSFargateProfile(src, r, nil); err != nil {
+		return err
+	}
+
+	return utilconversion.MarshalData(src, r)
+}
+
+// Convert_v1beta2_FargateProfileSpec_To_v1beta1_FargateProfileSpec is a conversion function.
+func Convert_v1beta2_FargateProfileSpec_To_v1beta1_FargateProfileSpec(in *infrav1exp.FargateProfileSpec, out *FargateProfileSpec, s apiconversion.Scope) error {
+	return autoConvert_v1beta2_FargateProfileSpec_To_v1beta1_FargateProfileSpec(in, out, s)
 }
 
 // ConvertTo converts the v1beta1 AWSFargateProfileList receiver to a v1beta2 AWSFargateProfileList.
@@ -219,3 +349,25 @@ func Convert_v1beta2_RefreshPreferences_To_v1beta1_RefreshPreferences(in *infrav
 	// spec.refreshPreferences.disable has been added to v1beta2.
 	return autoConvert_v1beta2_RefreshPreferences_To_v1beta1_RefreshPreferences(in, out, s)
 }
+
+// Convert_v1beta2_Overrides_To_v1beta1_Overrides is a conversion function.
+func Convert_v1beta2_Overrides_To_v1beta1_Overrides(in *infrav1exp.Overrides, out *Overrides, s apiconversion.Scope) error {
+	// spec.mixedInstancesPolicy.overrides.rootVolume has been added to v1beta2.
+	return autoConvert_v1beta2_Overrides_To_v1beta1_Overrides(in, out, s)
+}
+
+// Convert_v1beta2_AWSMachinePoolStatus_To_v1beta1_AWSMachinePoolStatus is a conversion function.
+func Convert_v1beta2_AWSMachinePoolStatus_To_v1beta1_AWSMachinePoolStatus(in *infrav1exp.AWSMachinePoolStatus, out *AWSMachinePoolStatus, s apiconversion.Scope) error {
+	return autoConvert_v1beta2_AWSMachinePoolStatus_To_v1beta1_AWSMachinePoolStatus(in, out, s)
+}
+
+// Convert_v1beta2_AWSMachinePoolInstanceStatus_To_v1beta1_AWSMachinePoolInstanceStatus is a conversion function.
+func Convert_v1beta2_AWSMachinePoolInstanceStatus_To_v1beta1_AWSMachinePoolInstanceStatus(in *infrav1exp.AWSMachinePoolInstanceStatus, out *AWSMachinePoolInstanceStatus, s apiconversion.Scope) error {
+	// status.instances.lifecycle has been added to v1beta2.
+	return autoConvert_v1beta2_AWSMachinePoolInstanceStatus_To_v1beta1_AWSMachinePoolInstanceStatus(in, out, s)
+}
+
+// Convert_v1beta2_AWSManagedMachinePoolStatus_To_v1beta1_AWSManagedMachinePoolStatus is a conversion function.
+func Convert_v1beta2_AWSManagedMachinePoolStatus_To_v1beta1_AWSManagedMachinePoolStatus(in *infrav1exp.AWSManagedMachinePoolStatus, out *AWSManagedMachinePoolStatus, s apiconversion.Scope) error {
+	return autoConvert_v1beta2_AWSManagedMachinePoolStatus_To_v1beta1_AWSManagedMachinePoolStatus(in, out, s)
+}
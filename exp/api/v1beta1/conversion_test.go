@@ -21,6 +21,8 @@ import (
 
 	. "github.com/onsi/gomega"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+
 	"sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
 	utilconversion "sigs.k8s.io/cluster-api/util/conversion"
 )
@@ -49,3 +51,62 @@ func TestFuzzyConversion(t *testing.T) {
 		Spoke:  &AWSFargateProfile{},
 	}))
 }
+
+func TestAWSMachinePoolConvertToEditedSpoke(t *testing.T) {
+	g := NewWithT(t)
+
+	hub := &v1beta2.AWSMachinePool{
+		Spec: v1beta2.AWSMachinePoolSpec{
+			RefreshPreferences: &v1beta2.RefreshPreferences{
+				Disable:              true,
+				MaxHealthyPercentage: ptr.To[int64](120),
+			},
+			SuspendProcesses:       &v1beta2.SuspendProcessesTypes{All: true},
+			DedicatedSecurityGroup: &v1beta2.DedicatedSecurityGroup{Enabled: true},
+			UnmanagedFields:        []v1beta2.UnmanagedField{v1beta2.UnmanagedFieldDesiredCapacity},
+		},
+		Status: v1beta2.AWSMachinePoolStatus{
+			DedicatedSecurityGroupID: "sg-pool",
+		},
+	}
+
+	spoke := &AWSMachinePool{}
+	g.Expect(spoke.ConvertFrom(hub)).To(Succeed())
+
+	// The refresh preferences are removed from the v1beta1 manifest, while the annotation holding the
+	// v1beta2 fields is kept.
+	spoke.Spec.RefreshPreferences = nil
+
+	restored := &v1beta2.AWSMachinePool{}
+	g.Expect(spoke.ConvertTo(restored)).To(Succeed())
+	g.Expect(restored.Spec.RefreshPreferences).To(BeNil())
+	g.Expect(restored.Spec.SuspendProcesses).To(Equal(hub.Spec.SuspendProcesses))
+	g.Expect(restored.Spec.DedicatedSecurityGroup).To(Equal(hub.Spec.DedicatedSecurityGroup))
+	g.Expect(restored.Spec.UnmanagedFields).To(Equal(hub.Spec.UnmanagedFields))
+	g.Expect(restored.Status.DedicatedSecurityGroupID).To(Equal("sg-pool"))
+}
+
+func TestAWSManagedMachinePoolConvertToEditedSpoke(t *testing.T) {
+	g := NewWithT(t)
+
+	hub := &v1beta2.AWSManagedMachinePool{
+		Spec: v1beta2.AWSManagedMachinePoolSpec{
+			AWSLaunchTemplate: &v1beta2.AWSLaunchTemplate{
+				Name:              "pool",
+				ValidateBeforeUse: true,
+			},
+			DedicatedSecurityGroup: &v1beta2.DedicatedSecurityGroup{Enabled: true},
+		},
+	}
+
+	spoke := &AWSManagedMachinePool{}
+	g.Expect(spoke.ConvertFrom(hub)).To(Succeed())
+
+	// The launch template is removed from the v1beta1 manifest, it must not be restored.
+	spoke.Spec.AWSLaunchTemplate = nil
+
+	restored := &v1beta2.AWSManagedMachinePool{}
+	g.Expect(spoke.ConvertTo(restored)).To(Succeed())
+	g.Expect(restored.Spec.AWSLaunchTemplate).To(BeNil())
+	g.Expect(restored.Spec.DedicatedSecurityGroup).To(Equal(hub.Spec.DedicatedSecurityGroup))
+}
@@ -60,6 +60,11 @@ type FargateProfileSpec struct {
 	// +optional
 	RoleName string `json:"roleName,omitempty"`
 
+	// RoleManagement configures the pod execution role when it is created by CAPA. Roles which
+	// are not created by CAPA are left untouched.
+	// +optional
+	RoleManagement *infrav1.IAMRoleManagement `json:"roleManagement,omitempty"`
+
 	// Selectors specify fargate pod selectors.
 	Selectors []FargateSelector `json:"selectors,omitempty"`
 }
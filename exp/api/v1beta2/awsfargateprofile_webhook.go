@@ -110,9 +110,12 @@ func (r *AWSFargateProfile) ValidateUpdate(oldObj runtime.Object) (admission.War
 	}
 
 	allErrs = append(allErrs, r.Spec.AdditionalTags.Validate()...)
-	// remove additionalTags from equal check since they are mutable
+	allErrs = append(allErrs, r.Spec.RoleManagement.Validate(field.NewPath("spec", "roleManagement"))...)
+	// remove additionalTags and roleManagement from equal check since they are mutable
 	old.Spec.AdditionalTags = nil
 	r.Spec.AdditionalTags = nil
+	old.Spec.RoleManagement = nil
+	r.Spec.RoleManagement = nil
 
 	if !cmp.Equal(old.Spec, r.Spec) {
 		allErrs = append(
@@ -137,6 +140,7 @@ func (r *AWSFargateProfile) ValidateCreate() (admission.Warnings, error) {
 	var allErrs field.ErrorList
 
 	allErrs = append(allErrs, r.Spec.AdditionalTags.Validate()...)
+	allErrs = append(allErrs, r.Spec.RoleManagement.Validate(field.NewPath("spec", "roleManagement"))...)
 
 	if len(allErrs) == 0 {
 		return nil, nil
@@ -78,6 +78,11 @@ func TestAWSFargateProfileValidateRoleNameUpdate(t *testing.T) {
 	beforeWithDifferentRoleName := before.DeepCopy()
 	beforeWithDifferentRoleName.Spec.RoleName = "different-role-name"
 
+	roleManagementUpdate := before.DeepCopy()
+	roleManagementUpdate.Spec.RoleManagement = &infrav1.IAMRoleManagement{
+		PermissionsBoundary: "arn:aws:iam::123456789012:policy/boundary",
+	}
+
 	tests := []struct {
 		name           string
 		expectErr      bool
@@ -108,6 +113,12 @@ func TestAWSFargateProfileValidateRoleNameUpdate(t *testing.T) {
 			before:         beforeWithDifferentRoleName,
 			fargateProfile: validRoleNameUpdate,
 		},
+		{
+			name:           "update roleManagement should succeed",
+			expectErr:      false,
+			before:         before,
+			fargateProfile: roleManagementUpdate,
+		},
 		{
 			name:           "update tags should fail when invalid tags are present",
 			expectErr:      true,
@@ -177,6 +188,31 @@ func TestAWSFargateProfileValidateCreate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "role management with a policy as permissions boundary is accepted",
+			profile: &AWSFargateProfile{
+				Spec: FargateProfileSpec{
+					ClusterName: "cluster-1",
+					RoleManagement: &infrav1.IAMRoleManagement{
+						PermissionsBoundary: "arn:aws:iam::123456789012:policy/boundary",
+						Path:                "/capa/",
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "role management with a role as permissions boundary is rejected",
+			profile: &AWSFargateProfile{
+				Spec: FargateProfileSpec{
+					ClusterName: "cluster-1",
+					RoleManagement: &infrav1.IAMRoleManagement{
+						PermissionsBoundary: "arn:aws:iam::123456789012:role/boundary",
+					},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
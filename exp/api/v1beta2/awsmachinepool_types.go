@@ -17,7 +17,11 @@ limitations under the License.
 package v1beta2
 
 import (
+	"fmt"
 	"reflect"
+	"strconv"
+	"strings"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -31,6 +35,65 @@ import (
 const (
 	// LaunchTemplateLatestVersion defines the launching of the latest version of the template.
 	LaunchTemplateLatestVersion = "$Latest"
+
+	// DefaultLifecycleHookHeartbeatTimeout is the heartbeat timeout of the lifecycle hooks not setting one.
+	DefaultLifecycleHookHeartbeatTimeout = 10 * time.Minute
+
+	// DefaultAZFailureCooldown is how long an availability zone lacking capacity is excluded from the ASG when
+	// the AZ failure handling doesn't set a cooldown.
+	DefaultAZFailureCooldown = 30 * time.Minute
+
+	// PredictiveScalingForecastAnnotation requests the forecast of the predictive scaling policies of an
+	// AWSMachinePool to be fetched into its status. The annotation is removed once the forecast is fetched.
+	PredictiveScalingForecastAnnotation = "aws.cluster.x-k8s.io/fetch-predictive-scaling-forecast"
+
+	// ASGInstanceStateAnnotation moves instances of an AWSMachinePool in and out of standby, e.g. to investigate
+	// an instance without terminating it. Its value is a comma-separated list of <instance-id>=<state> entries,
+	// the state being ASGInstanceStateStandby or ASGInstanceStateInService.
+	ASGInstanceStateAnnotation = "aws.cluster.x-k8s.io/asg-instance-state"
+
+	// RefreshSurgeAnnotation is set by the controller while the maximum size of the ASG is raised by
+	// spec.refreshPreferences.maxSurge for an instance refresh. Its value is the maximum size of the spec when the
+	// surge was added, and the maximum size of the spec is restored once the annotation is removed.
+	RefreshSurgeAnnotation = "aws.cluster.x-k8s.io/refresh-surge-original-max-size"
+
+	// CapacityProbeAnnotation requests a probe of the capacity available to the instance types and availability
+	// zones of an AWSMachinePool, e.g. before a large planned scale-up. Its value is the number of instances to
+	// probe for. The probe is a dry run which never launches instances, its result is recorded in
+	// status.capacityProbe and the annotation is removed.
+	CapacityProbeAnnotation = "aws.cluster.x-k8s.io/capacity-probe"
+
+	// SpotPlacementScoresAnnotation requests the spot placement scores of the instance types, or instance
+	// requirements, of an AWSMachinePool in the availability zones of its region, e.g. before moving the pool to
+	// spot. Its value is the number of instances to score for. The scores are recorded in
+	// status.spotPlacementScores and the annotation is removed.
+	SpotPlacementScoresAnnotation = "aws.cluster.x-k8s.io/spot-placement-scores"
+
+	// RollbackLaunchTemplateAnnotation rolls the launch template of an AWSMachinePool back to
+	// status.previousLaunchTemplateVersion when set to "true", e.g. after an AMI change broke the new instances.
+	// The previous version is restored as the latest version of the launch template and rolled out by an instance
+	// refresh. The launch template isn't updated from the spec again until the spec changes, and the annotation
+	// is removed.
+	RollbackLaunchTemplateAnnotation = "aws.cluster.x-k8s.io/rollback-launch-template"
+
+	// AdoptASGAnnotation allows the controller to adopt an existing ASG named after an AWSMachinePool when set to
+	// "true". An ASG lacking the ownership tags of the cluster is otherwise left untouched. The adopted ASG is
+	// tagged as owned by the cluster, its sizes are recorded in the AdoptedASGOriginalSizeAnnotation, and the
+	// annotation is removed.
+	AdoptASGAnnotation = "aws.cluster.x-k8s.io/adopt-asg"
+
+	// AdoptedASGOriginalSizeAnnotation is set by the controller when it adopts an ASG. Its value is the minimum
+	// size, maximum size and desired capacity of the ASG before its adoption, in the format <min>/<max>/<desired>.
+	// They are restored when the ASG is preserved on deletion.
+	AdoptedASGOriginalSizeAnnotation = "aws.cluster.x-k8s.io/adopted-asg-original-size"
+
+	// ASGInstanceStateStandby requests an instance to enter standby. The desired capacity of the ASG is
+	// decremented, so that no instance is launched to replace it.
+	ASGInstanceStateStandby = "standby"
+
+	// ASGInstanceStateInService requests an instance in standby to return to service. The desired capacity of
+	// the ASG is incremented back.
+	ASGInstanceStateInService = "inservice"
 )
 
 // AWSMachinePoolSpec defines the desired state of AWSMachinePool.
@@ -73,6 +136,10 @@ type AWSMachinePoolSpec struct {
 	// MixedInstancesPolicy describes how multiple instance types will be used by the ASG.
 	MixedInstancesPolicy *MixedInstancesPolicy `json:"mixedInstancesPolicy,omitempty"`
 
+	// DedicatedSecurityGroup configures a security group owned by the machine pool and attached to its instances.
+	// +optional
+	DedicatedSecurityGroup *DedicatedSecurityGroup `json:"dedicatedSecurityGroup,omitempty"`
+
 	// ProviderIDList are the identification IDs of machine instances provided by the provider.
 	// This field must match the provider IDs as seen on the node objects corresponding to a machine pool's machine instances.
 	// +optional
@@ -85,10 +152,18 @@ type AWSMachinePoolSpec struct {
 
 	// The amount of time, in seconds, until a new instance is considered to
 	// have finished initializing and resource consumption to become stable
-	// after it enters the InService state.
+	// after it enters the InService state. Until then, its metrics aren't
+	// taken into account by the scaling policies of the ASG.
+	// It must be a whole number of seconds.
 	// If no value is supplied by user a default value of 300 seconds is set
 	// +optional
-	DefaultInstanceWarmup metav1.Duration `json:"defaultInstanceWarmup,omitempty"`
+	DefaultInstanceWarmup *metav1.Duration `json:"defaultInstanceWarmup,omitempty"`
+
+	// MaxInstanceLifetime is the maximum amount of time an instance can be in service. The ASG replaces
+	// the instances reaching it, a few at a time. It must be a whole number of seconds between one day
+	// and one year, or zero. The instances have no maximum lifetime when it's not set or zero.
+	// +optional
+	MaxInstanceLifetime *metav1.Duration `json:"maxInstanceLifetime,omitempty"`
 
 	// RefreshPreferences describes set of preferences associated with the instance refresh request.
 	// +optional
@@ -98,14 +173,476 @@ type AWSMachinePoolSpec struct {
 	// +optional
 	CapacityRebalance bool `json:"capacityRebalance,omitempty"`
 
+	// TerminationPolicies are the policies the ASG selects the instances to terminate on scale in with, in order.
+	// Each is either one of Default, AllocationStrategy, OldestLaunchTemplate, OldestLaunchConfiguration,
+	// ClosestToNextInstanceHour, NewestInstance and OldestInstance, or the ARN of a Lambda function.
+	// Defaults to Default.
+	// +optional
+	TerminationPolicies []string `json:"terminationPolicies,omitempty"`
+
+	// HealthCheckType is the type of the health checks of the instances of the ASG, either EC2 or ELB. With ELB
+	// health checks, the instances failing the health checks of the target groups or load balancers of the ASG are
+	// replaced as well. Defaults to EC2.
+	// +kubebuilder:validation:Enum=EC2;ELB
+	// +optional
+	HealthCheckType HealthCheckType `json:"healthCheckType,omitempty"`
+
+	// HealthCheckGracePeriod is the time the ASG waits after an instance comes into service before checking its
+	// health. Requires healthCheckType to be set.
+	// +optional
+	HealthCheckGracePeriod *metav1.Duration `json:"healthCheckGracePeriod,omitempty"`
+
+	// Metrics configures the collection of the group metrics of the ASG in CloudWatch. The collection is disabled
+	// when not set.
+	// +optional
+	Metrics *MetricsCollection `json:"metrics,omitempty"`
+
 	// SuspendProcesses defines a list of processes to suspend for the given ASG. This is constantly reconciled.
 	// If a process is removed from this list it will automatically be resumed.
 	SuspendProcesses *SuspendProcessesTypes `json:"suspendProcesses,omitempty"`
+
+	// LifecycleHooks lists the lifecycle hooks added to the ASG.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	LifecycleHooks []AWSLifecycleHook `json:"lifecycleHooks,omitempty"`
+
+	// ScalingPolicies lists the scaling policies of the ASG. Policies removed from the list are deleted.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	ScalingPolicies []ScalingPolicy `json:"scalingPolicies,omitempty"`
+
+	// AZFailureHandling temporarily removes the subnets of an availability zone from the ASG when launches
+	// repeatedly fail there for lack of capacity.
+	// +optional
+	AZFailureHandling *AZFailureHandling `json:"azFailureHandling,omitempty"`
+
+	// UnmanagedFields lists the aspects of the ASG that are owned by other tooling once the ASG exists.
+	// CAPA sets them when creating the ASG, but doesn't revert changes made to them afterwards.
+	// The status keeps reflecting the actual state of the ASG.
+	// +optional
+	// +listType=set
+	UnmanagedFields []UnmanagedField `json:"unmanagedFields,omitempty"`
+
+	// NodeLabels are the labels the nodes of the pool register with. They are passed to the kubelet with
+	// --node-labels by the user data of the launch template, so only the labels a kubelet is allowed to set
+	// can be used.
+	// +optional
+	NodeLabels map[string]string `json:"nodeLabels,omitempty"`
+
+	// NodeTaints are the taints the nodes of the pool register with. They are passed to the kubelet with
+	// --register-with-taints by the user data of the launch template.
+	// +optional
+	NodeTaints Taints `json:"nodeTaints,omitempty"`
+
+	// WarmPool configures a warm pool of pre-initialized instances the ASG scales out from. Removing it deletes
+	// the warm pool of the ASG.
+	// +optional
+	WarmPool *WarmPool `json:"warmPool,omitempty"`
+
+	// TargetGroupARNs lists the ARNs of the load balancer target groups the ASG registers its instances with.
+	// Target groups removed from the list are detached from the ASG, while the target groups attached to the ASG
+	// by other tooling are kept.
+	// +optional
+	// +listType=set
+	TargetGroupARNs []string `json:"targetGroupARNs,omitempty"`
+
+	// ScaleInDrainPolicy deletes the Machines of the instances removed when the replicas of the MachinePool are
+	// decreased, so that Cluster API drains their nodes before the instances are terminated, instead of letting
+	// the ASG terminate instances with their pods. It requires the MachinePoolMachines feature gate, and is
+	// ignored while the replicas are managed by an external autoscaler.
+	// +optional
+	ScaleInDrainPolicy *ScaleInDrainPolicy `json:"scaleInDrainPolicy,omitempty"`
+
+	// PreserveOnDelete keeps an ASG adopted with the AdoptASGAnnotation when the AWSMachinePool is deleted,
+	// instead of deleting it. Its ownership tags are removed and its original sizes restored. It's ignored for the
+	// ASGs created by the controller.
+	// +optional
+	PreserveOnDelete bool `json:"preserveOnDelete,omitempty"`
+}
+
+// IsUnmanaged returns true if the given aspect of the ASG is owned by other tooling.
+func (s *AWSMachinePoolSpec) IsUnmanaged(field UnmanagedField) bool {
+	for _, f := range s.UnmanagedFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// LifecycleHookRole defines how the controller completes the lifecycle actions of a lifecycle hook.
+type LifecycleHookRole string
+
+const (
+	// LifecycleHookRoleNodeJoin is a launch lifecycle hook holding new instances in the Pending:Wait state
+	// until their node is Ready in the workload cluster.
+	LifecycleHookRoleNodeJoin = LifecycleHookRole("capa-node-join")
+)
+
+// LifecycleTransition is the instance state transition a lifecycle hook is attached to.
+type LifecycleTransition string
+
+const (
+	// LifecycleTransitionLaunching holds the instances being launched.
+	LifecycleTransitionLaunching = LifecycleTransition("autoscaling:EC2_INSTANCE_LAUNCHING")
+	// LifecycleTransitionTerminating holds the instances being terminated.
+	LifecycleTransitionTerminating = LifecycleTransition("autoscaling:EC2_INSTANCE_TERMINATING")
+)
+
+// AWSLifecycleHook describes a lifecycle hook of the ASG. The lifecycle actions of the hooks with a role are
+// completed by the controller, the other ones by the tooling receiving their notifications.
+type AWSLifecycleHook struct {
+	// Name is the name of the lifecycle hook.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=255
+	Name string `json:"name"`
+
+	// Role defines how the lifecycle actions of the hook are completed by the controller.
+	// capa-node-join holds new instances until their node is Ready in the workload cluster.
+	// The lifecycle actions of a hook without role are left to other tooling.
+	// +kubebuilder:validation:Enum=capa-node-join
+	// +optional
+	Role LifecycleHookRole `json:"role,omitempty"`
+
+	// LifecycleTransition is the instance state transition the hook is attached to. Defaults to
+	// autoscaling:EC2_INSTANCE_LAUNCHING.
+	// +kubebuilder:validation:Enum=autoscaling:EC2_INSTANCE_LAUNCHING;autoscaling:EC2_INSTANCE_TERMINATING
+	// +optional
+	LifecycleTransition LifecycleTransition `json:"lifecycleTransition,omitempty"`
+
+	// DefaultResult is the result the lifecycle action is completed with once the heartbeat timeout elapsed.
+	// Defaults to ABANDON.
+	// +kubebuilder:validation:Enum=CONTINUE;ABANDON
+	// +optional
+	DefaultResult LifecycleActionResult `json:"defaultResult,omitempty"`
+
+	// HeartbeatTimeout is the maximum time an instance is held by the hook, after which its lifecycle
+	// action is completed with the default result. Defaults to 10 minutes.
+	// +optional
+	HeartbeatTimeout *metav1.Duration `json:"heartbeatTimeout,omitempty"`
+
+	// NotificationTargetARN is the ARN of the SQS queue or SNS topic notified when an instance is held by the hook.
+	// +optional
+	NotificationTargetARN string `json:"notificationTargetARN,omitempty"`
+
+	// RoleARN is the ARN of the IAM role allowing Auto Scaling to publish to the notification target. It is
+	// required with a notification target.
+	// +optional
+	RoleARN string `json:"roleARN,omitempty"`
+}
+
+// GetLifecycleTransition returns the lifecycle transition of the hook, or the default one if not set.
+func (h *AWSLifecycleHook) GetLifecycleTransition() LifecycleTransition {
+	if h.LifecycleTransition == "" {
+		return LifecycleTransitionLaunching
+	}
+	return h.LifecycleTransition
+}
+
+// GetDefaultResult returns the default result of the hook, or ABANDON if not set.
+func (h *AWSLifecycleHook) GetDefaultResult() LifecycleActionResult {
+	if h.DefaultResult == "" {
+		return LifecycleActionResultAbandon
+	}
+	return h.DefaultResult
+}
+
+// GetHeartbeatTimeout returns the heartbeat timeout of the hook, or the default one if not set.
+func (h *AWSLifecycleHook) GetHeartbeatTimeout() time.Duration {
+	if h.HeartbeatTimeout == nil {
+		return DefaultLifecycleHookHeartbeatTimeout
+	}
+	return h.HeartbeatTimeout.Duration
+}
+
+// LifecycleActionResult is the result a lifecycle action was completed with.
+type LifecycleActionResult string
+
+const (
+	// LifecycleActionResultContinue lets the instance proceed to the next state.
+	LifecycleActionResultContinue = LifecycleActionResult("CONTINUE")
+	// LifecycleActionResultAbandon terminates the instance.
+	LifecycleActionResultAbandon = LifecycleActionResult("ABANDON")
+)
+
+// LifecycleAction is the lifecycle action of an instance held by a lifecycle hook of the ASG.
+type LifecycleAction struct {
+	// InstanceID is the ID of the instance held by the hook.
+	InstanceID string `json:"instanceID"`
+
+	// HookName is the name of the lifecycle hook.
+	HookName string `json:"hookName"`
+
+	// StartTime is when the instance was first observed waiting for the hook.
+	StartTime metav1.Time `json:"startTime"`
+
+	// Result is the result the lifecycle action was completed with. It is empty while the action is pending.
+	// +optional
+	Result LifecycleActionResult `json:"result,omitempty"`
+}
+
+// ScaleInDrainPolicy configures the drain of the nodes of the instances removed by a scale-in. The nodes are
+// drained by Cluster API as for any Machine, bounded by the nodeDrainTimeout of the MachinePool.
+type ScaleInDrainPolicy struct{}
+
+// AZFailureHandling configures the exclusion of the availability zones lacking capacity from the ASG.
+type AZFailureHandling struct {
+	// Enabled excludes an availability zone from the ASG once several launches failed there with
+	// InsufficientInstanceCapacity. The last availability zone of the ASG is never excluded.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Cooldown is how long an availability zone is excluded before its subnets are restored to the ASG.
+	// Defaults to 30 minutes.
+	// +optional
+	Cooldown *metav1.Duration `json:"cooldown,omitempty"`
+}
+
+// GetCooldown returns the cooldown of the excluded availability zones, or the default one if not set.
+func (h *AZFailureHandling) GetCooldown() time.Duration {
+	if h.Cooldown == nil {
+		return DefaultAZFailureCooldown
+	}
+	return h.Cooldown.Duration
+}
+
+// WarmPoolState is the state of the instances of a warm pool.
+type WarmPoolState string
+
+const (
+	// WarmPoolStateStopped keeps the instances of the warm pool stopped.
+	WarmPoolStateStopped = WarmPoolState("Stopped")
+	// WarmPoolStateRunning keeps the instances of the warm pool running.
+	WarmPoolStateRunning = WarmPoolState("Running")
+	// WarmPoolStateHibernated keeps the instances of the warm pool hibernated.
+	WarmPoolStateHibernated = WarmPoolState("Hibernated")
+)
+
+// WarmPool configures the warm pool of the ASG. The instances of the warm pool aren't nodes of the cluster, they
+// are neither counted in the replicas nor listed in the provider IDs of the AWSMachinePool.
+type WarmPool struct {
+	// MinSize is the minimum number of instances kept in the warm pool.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MinSize int32 `json:"minSize,omitempty"`
+
+	// MaxGroupPreparedCapacity is the maximum number of instances in the ASG and its warm pool together.
+	// Defaults to the max size of the ASG.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxGroupPreparedCapacity *int32 `json:"maxGroupPreparedCapacity,omitempty"`
+
+	// PoolState is the state of the instances of the warm pool. Defaults to Stopped.
+	// +kubebuilder:validation:Enum=Stopped;Running;Hibernated
+	// +optional
+	PoolState WarmPoolState `json:"poolState,omitempty"`
+
+	// ReuseOnScaleIn returns the instances of the ASG to the warm pool on scale in instead of terminating them.
+	// +optional
+	ReuseOnScaleIn bool `json:"reuseOnScaleIn,omitempty"`
+}
+
+// GetPoolState returns the state of the instances of the warm pool, or Stopped if not set.
+func (w *WarmPool) GetPoolState() WarmPoolState {
+	if w.PoolState == "" {
+		return WarmPoolStateStopped
+	}
+	return w.PoolState
+}
+
+const (
+	// TerminationPolicyDefault terminates instances following the default termination policy of the ASG.
+	TerminationPolicyDefault = "Default"
+	// TerminationPolicyAllocationStrategy terminates instances to keep the allocation strategy of the ASG.
+	TerminationPolicyAllocationStrategy = "AllocationStrategy"
+	// TerminationPolicyOldestLaunchTemplate terminates instances using the oldest launch template first.
+	TerminationPolicyOldestLaunchTemplate = "OldestLaunchTemplate"
+	// TerminationPolicyOldestLaunchConfiguration terminates instances using the oldest launch configuration first.
+	TerminationPolicyOldestLaunchConfiguration = "OldestLaunchConfiguration"
+	// TerminationPolicyClosestToNextInstanceHour terminates instances closest to their next billing hour first.
+	TerminationPolicyClosestToNextInstanceHour = "ClosestToNextInstanceHour"
+	// TerminationPolicyNewestInstance terminates the newest instances first.
+	TerminationPolicyNewestInstance = "NewestInstance"
+	// TerminationPolicyOldestInstance terminates the oldest instances first.
+	TerminationPolicyOldestInstance = "OldestInstance"
+)
+
+// TerminationPolicies lists the predefined termination policies of an ASG.
+var TerminationPolicies = []string{
+	TerminationPolicyDefault,
+	TerminationPolicyAllocationStrategy,
+	TerminationPolicyOldestLaunchTemplate,
+	TerminationPolicyOldestLaunchConfiguration,
+	TerminationPolicyClosestToNextInstanceHour,
+	TerminationPolicyNewestInstance,
+	TerminationPolicyOldestInstance,
+}
+
+// GetTerminationPolicies returns the termination policies of the spec, or Default if none is set.
+func (s *AWSMachinePoolSpec) GetTerminationPolicies() []string {
+	if len(s.TerminationPolicies) == 0 {
+		return []string{TerminationPolicyDefault}
+	}
+	return s.TerminationPolicies
+}
+
+// HealthCheckType is the type of the health checks of the instances of an ASG.
+type HealthCheckType string
+
+const (
+	// HealthCheckTypeEC2 replaces the instances EC2 reports unhealthy.
+	HealthCheckTypeEC2 = HealthCheckType("EC2")
+	// HealthCheckTypeELB replaces as well the instances failing the health checks of the load balancers of the ASG.
+	HealthCheckTypeELB = HealthCheckType("ELB")
+)
+
+// GetHealthCheckType returns the type of the health checks of the ASG, or EC2 if not set.
+func (s *AWSMachinePoolSpec) GetHealthCheckType() HealthCheckType {
+	if s.HealthCheckType == "" {
+		return HealthCheckTypeEC2
+	}
+	return s.HealthCheckType
+}
+
+// GetMaxInstanceLifetime returns the maximum lifetime of the instances, or zero if they have none.
+func (s *AWSMachinePoolSpec) GetMaxInstanceLifetime() time.Duration {
+	if s.MaxInstanceLifetime == nil {
+		return 0
+	}
+	return s.MaxInstanceLifetime.Duration
+}
+
+// MetricsGranularity1Minute is the only granularity of the group metrics of an ASG.
+const MetricsGranularity1Minute = "1Minute"
+
+// ASGMetrics lists the group metrics of an ASG.
+var ASGMetrics = []string{
+	"GroupMinSize",
+	"GroupMaxSize",
+	"GroupDesiredCapacity",
+	"GroupInServiceInstances",
+	"GroupPendingInstances",
+	"GroupStandbyInstances",
+	"GroupTerminatingInstances",
+	"GroupTotalInstances",
+	"GroupInServiceCapacity",
+	"GroupPendingCapacity",
+	"GroupStandbyCapacity",
+	"GroupTerminatingCapacity",
+	"GroupTotalCapacity",
+	"WarmPoolDesiredCapacity",
+	"WarmPoolWarmedCapacity",
+	"WarmPoolPendingCapacity",
+	"WarmPoolTerminatingCapacity",
+	"WarmPoolTotalCapacity",
+	"GroupAndWarmPoolDesiredCapacity",
+	"GroupAndWarmPoolTotalCapacity",
+}
+
+// MetricsCollection configures the collection of the group metrics of an ASG.
+type MetricsCollection struct {
+	// Granularity is the granularity of the metrics. Defaults to 1Minute, the only granularity supported.
+	// +kubebuilder:validation:Enum="1Minute"
+	// +optional
+	Granularity string `json:"granularity,omitempty"`
+
+	// Metrics are the group metrics to collect, such as GroupInServiceInstances or GroupDesiredCapacity. All the
+	// group metrics are collected when empty.
+	// +optional
+	Metrics []string `json:"metrics,omitempty"`
+}
+
+// GetGranularity returns the granularity of the metrics, or 1Minute if not set.
+func (m *MetricsCollection) GetGranularity() string {
+	if m.Granularity == "" {
+		return MetricsGranularity1Minute
+	}
+	return m.Granularity
+}
+
+// GetMetrics returns the group metrics to collect, or all of them if none is set.
+func (m *MetricsCollection) GetMetrics() []string {
+	if len(m.Metrics) == 0 {
+		return ASGMetrics
+	}
+	return m.Metrics
+}
+
+// ExcludedAvailabilityZone is an availability zone whose subnets are temporarily removed from the ASG.
+type ExcludedAvailabilityZone struct {
+	// Name is the name of the availability zone.
+	Name string `json:"name"`
+
+	// Failures is the number of launches which failed for lack of capacity in the availability zone.
+	Failures int32 `json:"failures"`
+
+	// ExcludedAt is when the subnets of the availability zone were removed from the ASG.
+	ExcludedAt metav1.Time `json:"excludedAt"`
+
+	// ExpiresAt is when the subnets of the availability zone are restored to the ASG.
+	ExpiresAt metav1.Time `json:"expiresAt"`
+}
+
+// ASGReference identifies the ASG of an AWSMachinePool.
+type ASGReference struct {
+	// Name is the name of the ASG.
+	Name string `json:"name"`
+
+	// ARN is the Amazon Resource Name of the ASG. It is only known once the ASG is described.
+	// +optional
+	ARN string `json:"arn,omitempty"`
+
+	// CreatedAt is when the ASG was created by the controller. It is cleared once the ASG is described.
+	// +optional
+	CreatedAt *metav1.Time `json:"createdAt,omitempty"`
+}
+
+// CreationPending returns whether the ASG was created by the controller less than the given window before the
+// given time and hasn't been described since.
+func (r *ASGReference) CreationPending(now time.Time, window time.Duration) bool {
+	return r != nil && r.ARN == "" && r.CreatedAt != nil && now.Before(r.CreatedAt.Add(window))
+}
+
+// CapacityBlockStatus is the state of a Capacity Block for ML, as reported by DescribeCapacityReservations.
+type CapacityBlockStatus struct {
+	// CapacityReservationID is the ID of the capacity reservation of the capacity block.
+	CapacityReservationID string `json:"capacityReservationId"`
+
+	// State is the state of the capacity reservation, e.g. scheduled, active or expired.
+	// +optional
+	State string `json:"state,omitempty"`
+
+	// StartTime is when the instances can start running in the capacity block.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// EndTime is when the capacity block expires and its instances are terminated.
+	// +optional
+	EndTime *metav1.Time `json:"endTime,omitempty"`
+}
+
+// InWindow returns whether the given time is within the window of the capacity block.
+func (c *CapacityBlockStatus) InWindow(t time.Time) bool {
+	if c.StartTime != nil && t.Before(c.StartTime.Time) {
+		return false
+	}
+	if c.EndTime != nil && !t.Before(c.EndTime.Time) {
+		return false
+	}
+	return true
 }
 
 // SuspendProcessesTypes contains user friendly auto-completable values for suspended process names.
 type SuspendProcessesTypes struct {
-	All       bool       `json:"all,omitempty"`
+	// All suspends every process of the ASG, e.g. to hand the scaling of the ASG over to an external system.
+	// The processes set to false in Processes are left running.
+	// +optional
+	All bool `json:"all,omitempty"`
+
+	// Processes lists the processes to suspend individually. It can't set processes to true when All is set.
+	// +optional
 	Processes *Processes `json:"processes,omitempty"`
 }
 
@@ -123,16 +660,18 @@ type Processes struct {
 }
 
 // ConvertSetValuesToStringSlice converts all the values that are set into a string slice for further processing.
+// All is expanded to every process which isn't explicitly set to false.
 func (s *SuspendProcessesTypes) ConvertSetValuesToStringSlice() []string {
 	if s == nil {
 		return nil
 	}
 
-	if s.Processes == nil {
-		s.Processes = &Processes{}
+	processes := s.Processes
+	if processes == nil {
+		processes = &Processes{}
 	}
 
-	e := reflect.ValueOf(s.Processes).Elem()
+	e := reflect.ValueOf(processes).Elem()
 	var result []string
 	for i := 0; i < e.NumField(); i++ {
 		if s.All {
@@ -149,6 +688,92 @@ func (s *SuspendProcessesTypes) ConvertSetValuesToStringSlice() []string {
 	return result
 }
 
+// LaunchTemplateRollback describes a rollback of the launch template of an AWSMachinePool.
+type LaunchTemplateRollback struct {
+	// FromVersion is the version of the launch template which was rolled back.
+	FromVersion string `json:"fromVersion"`
+
+	// ToVersion is the previous version of the launch template which was restored.
+	ToVersion string `json:"toVersion"`
+
+	// Version is the version of the launch template created from ToVersion.
+	Version string `json:"version"`
+
+	// ObservedGeneration is the generation of the AWSMachinePool when it was rolled back. The launch template
+	// is updated from the spec again once the generation changes.
+	ObservedGeneration int64 `json:"observedGeneration"`
+}
+
+// LaunchTemplateChange classifies the last change of the launch template of an AWSMachinePool.
+type LaunchTemplateChange struct {
+	// Version is the version of the launch template created for the change.
+	Version string `json:"version"`
+
+	// AMIChanged is whether the AMI of the launch template changed.
+	// +optional
+	AMIChanged bool `json:"amiChanged,omitempty"`
+
+	// UserDataOnly is whether the userdata is the only thing which changed. Such a change isn't rolled out to
+	// the instances unless spec.refreshPreferences.triggerOnUserDataChange is set.
+	// +optional
+	UserDataOnly bool `json:"userdataOnly,omitempty"`
+
+	// HardwareConfigChanged is whether the configuration of the instances beyond their AMI and userdata changed,
+	// e.g. their instance type or security groups.
+	// +optional
+	HardwareConfigChanged bool `json:"hardwareConfigChanged,omitempty"`
+
+	// ChangedFields are the names of the fields of the launch template which changed.
+	// +optional
+	ChangedFields []string `json:"changedFields,omitempty"`
+
+	// RolledOut is whether the change was rolled out to the instances, with an instance refresh unless
+	// spec.refreshPreferences.disable is set.
+	// +optional
+	RolledOut bool `json:"rolledOut,omitempty"`
+
+	// Time is when the launch template changed.
+	Time metav1.Time `json:"time"`
+}
+
+// AZSequentialRefresh is the progress of an instance refresh with the AZSequential strategy.
+type AZSequentialRefresh struct {
+	// LaunchTemplateVersion is the version of the launch template rolled out.
+	LaunchTemplateVersion string `json:"launchTemplateVersion"`
+
+	// AvailabilityZones are the availability zones of the instances, in the order they are refreshed in. They
+	// are listed when the instance refresh of the first zone starts.
+	// +optional
+	AvailabilityZones []string `json:"availabilityZones,omitempty"`
+
+	// Current is the index in AvailabilityZones of the zone being refreshed.
+	// +optional
+	Current int32 `json:"current,omitempty"`
+
+	// InstanceRefreshID is the ID of the instance refresh of the current zone. Once it succeeded, the next zone
+	// is refreshed when the nodes of the instances of the current zone are Ready.
+	// +optional
+	InstanceRefreshID string `json:"instanceRefreshID,omitempty"`
+
+	// ProtectedInstanceIDs are the instances of the other zones the controller protected from scale in, so that
+	// the instance refresh of the current zone skips them. Their protection is removed once the zone is
+	// refreshed, or when the refresh is cancelled.
+	// +optional
+	ProtectedInstanceIDs []string `json:"protectedInstanceIDs,omitempty"`
+
+	// StartTime is when the refresh of the first zone was requested.
+	StartTime metav1.Time `json:"startTime"`
+}
+
+// CurrentAvailabilityZone returns the availability zone being refreshed, or an empty string when the availability
+// zones aren't listed yet.
+func (r *AZSequentialRefresh) CurrentAvailabilityZone() string {
+	if int(r.Current) >= len(r.AvailabilityZones) {
+		return ""
+	}
+	return r.AvailabilityZones[r.Current]
+}
+
 // RefreshPreferences defines the specs for instance refreshing.
 type RefreshPreferences struct {
 	// Disable, if true, disables instance refresh from triggering when new launch templates are detected.
@@ -156,9 +781,12 @@ type RefreshPreferences struct {
 	// +optional
 	Disable bool `json:"disable,omitempty"`
 
-	// The strategy to use for the instance refresh. The only valid value is Rolling.
+	// The strategy to use for the instance refresh, Rolling or AZSequential.
 	// A rolling update is an update that is applied to all instances in an Auto
 	// Scaling group until all instances have been updated.
+	// AZSequential replaces the instances of one availability zone at a time, and waits for the nodes of the
+	// zone to be Ready in the workload cluster before refreshing the next zone. The instances of the other zones
+	// are protected from scale in meanwhile, unless they already were.
 	// +optional
 	Strategy *string `json:"strategy,omitempty"`
 
@@ -183,8 +811,87 @@ type RefreshPreferences struct {
 	// +kubebuilder:validation:Minimum=100
 	// +kubebuilder:validation:Maximum=200
 	MaxHealthyPercentage *int64 `json:"maxHealthyPercentage,omitempty"`
+
+	// CheckpointPercentages are the percentages of the instances replaced at which the instance refresh pauses
+	// for CheckpointDelay. The percentages must be increasing, and the last one must be 100.
+	// +optional
+	CheckpointPercentages []int64 `json:"checkpointPercentages,omitempty"`
+
+	// CheckpointDelay is the time the instance refresh pauses at each checkpoint. It requires
+	// CheckpointPercentages, and defaults to 1 hour.
+	// +optional
+	CheckpointDelay *metav1.Duration `json:"checkpointDelay,omitempty"`
+
+	// SkipMatching, if true, skips replacing the instances which already run the launch template version
+	// the instance refresh rolls out.
+	// +optional
+	SkipMatching *bool `json:"skipMatching,omitempty"`
+
+	// ScaleInProtectedInstances is what the instance refresh does with the instances protected from scale in:
+	// Refresh replaces them, Ignore skips them and Wait waits for their protection to be removed.
+	// The default is Ignore.
+	// +kubebuilder:validation:Enum=Refresh;Ignore;Wait
+	// +optional
+	ScaleInProtectedInstances ScaleInProtectedInstancesStrategy `json:"scaleInProtectedInstances,omitempty"`
+
+	// StandbyInstances is what the instance refresh does with the instances in standby: Terminate replaces them,
+	// Ignore skips them and Wait waits for them to leave standby. The default is Ignore.
+	// +kubebuilder:validation:Enum=Terminate;Ignore;Wait
+	// +optional
+	StandbyInstances StandbyInstancesStrategy `json:"standbyInstances,omitempty"`
+
+	// CancelOutdatedRefresh, if true, cancels an instance refresh which is still running when the launch template
+	// changes again, and starts a new instance refresh rolling out the latest launch template version once the
+	// cancellation completed. By default, the launch template isn't changed until the running instance refresh ended.
+	// +optional
+	CancelOutdatedRefresh bool `json:"cancelOutdatedRefresh,omitempty"`
+
+	// MaxSurge is how many instances the maximum size of the ASG is raised by while an instance refresh runs, so
+	// that replacement instances can be launched before the instances they replace are terminated, even when the
+	// ASG is at its maximum size. The maximum size is restored once the instance refresh ended.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxSurge *int32 `json:"maxSurge,omitempty"`
+
+	// TriggerOnUserDataChange, if true, rolls out a change of the userdata of the launch template to the
+	// instances. By default, a launch template version is created for a change of the userdata alone, but the
+	// instances only run it once they are replaced for another reason.
+	// +optional
+	TriggerOnUserDataChange bool `json:"triggerOnUserDataChange,omitempty"`
 }
 
+const (
+	// RefreshStrategyRolling replaces all the instances of the ASG with a single instance refresh.
+	RefreshStrategyRolling = "Rolling"
+	// RefreshStrategyAZSequential replaces the instances of one availability zone at a time, with an instance
+	// refresh per zone.
+	RefreshStrategyAZSequential = "AZSequential"
+)
+
+// ScaleInProtectedInstancesStrategy is what an instance refresh does with the instances protected from scale in.
+type ScaleInProtectedInstancesStrategy string
+
+const (
+	// ScaleInProtectedInstancesRefresh replaces the instances protected from scale in.
+	ScaleInProtectedInstancesRefresh = ScaleInProtectedInstancesStrategy("Refresh")
+	// ScaleInProtectedInstancesIgnore skips the instances protected from scale in.
+	ScaleInProtectedInstancesIgnore = ScaleInProtectedInstancesStrategy("Ignore")
+	// ScaleInProtectedInstancesWait waits for the protection of the instances to be removed.
+	ScaleInProtectedInstancesWait = ScaleInProtectedInstancesStrategy("Wait")
+)
+
+// StandbyInstancesStrategy is what an instance refresh does with the instances in standby.
+type StandbyInstancesStrategy string
+
+const (
+	// StandbyInstancesTerminate replaces the instances in standby.
+	StandbyInstancesTerminate = StandbyInstancesStrategy("Terminate")
+	// StandbyInstancesIgnore skips the instances in standby.
+	StandbyInstancesIgnore = StandbyInstancesStrategy("Ignore")
+	// StandbyInstancesWait waits for the instances to leave standby.
+	StandbyInstancesWait = StandbyInstancesStrategy("Wait")
+)
+
 // AWSMachinePoolStatus defines the observed state of AWSMachinePool.
 type AWSMachinePoolStatus struct {
 	// Ready is true when the provider resource is ready.
@@ -195,6 +902,10 @@ type AWSMachinePoolStatus struct {
 	// +optional
 	Replicas int32 `json:"replicas"`
 
+	// ReadyReplicas is the number of instances of the ASG which are InService and Healthy.
+	// +optional
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+
 	// Conditions defines current service state of the AWSMachinePool.
 	// +optional
 	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
@@ -203,6 +914,11 @@ type AWSMachinePoolStatus struct {
 	// +optional
 	Instances []AWSMachinePoolInstanceStatus `json:"instances,omitempty"`
 
+	// InfrastructureMachineKind is the kind of the infrastructure resources behind MachinePool Machines, set when
+	// the MachinePoolMachines feature gate is enabled.
+	// +optional
+	InfrastructureMachineKind string `json:"infrastructureMachineKind,omitempty"`
+
 	// The ID of the launch template
 	LaunchTemplateID string `json:"launchTemplateID,omitempty"`
 
@@ -210,6 +926,108 @@ type AWSMachinePoolStatus struct {
 	// +optional
 	LaunchTemplateVersion *string `json:"launchTemplateVersion,omitempty"`
 
+	// OverrideLaunchTemplates lists the launch templates managed for instance type overrides
+	// of the mixed instances policy that set their own root volume.
+	// +optional
+	OverrideLaunchTemplates []OverrideLaunchTemplate `json:"overrideLaunchTemplates,omitempty"`
+
+	// AdditionalSecurityGroupIDs is the list of security group IDs the additional security groups of the
+	// launch template were last resolved to. The filters matching no group are reported by the
+	// AdditionalSecurityGroupsReady condition.
+	// +optional
+	AdditionalSecurityGroupIDs []string `json:"additionalSecurityGroupIDs,omitempty"`
+
+	// DedicatedSecurityGroupID is the ID of the security group owned by the machine pool.
+	// +optional
+	DedicatedSecurityGroupID string `json:"dedicatedSecurityGroupID,omitempty"`
+
+	// LifecycleActions lists the lifecycle actions of the instances waiting for the lifecycle hooks of the spec.
+	// +optional
+	LifecycleActions []LifecycleAction `json:"lifecycleActions,omitempty"`
+
+	// ScalingPolicies is the observed state of the scaling policies of the spec.
+	// +optional
+	ScalingPolicies []ScalingPolicyStatus `json:"scalingPolicies,omitempty"`
+
+	// ExcludedAvailabilityZones lists the availability zones temporarily removed from the ASG by the
+	// AZ failure handling.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	ExcludedAvailabilityZones []ExcludedAvailabilityZone `json:"excludedAvailabilityZones,omitempty"`
+
+	// CapacityBlock is the state and the window of the Capacity Block for ML the instances of the pool are
+	// launched in, when spec.awsLaunchTemplate.marketType is capacity-block.
+	// +optional
+	CapacityBlock *CapacityBlockStatus `json:"capacityBlock,omitempty"`
+
+	// CopiedAMI is the copy of the AMI of the launch template made from spec.awsLaunchTemplate.ami.sourceRegion.
+	// +optional
+	CopiedAMI *CopiedAMI `json:"copiedAMI,omitempty"`
+
+	// CapacityMix is the number of spot and on-demand instances of the pool.
+	// +optional
+	CapacityMix *CapacityMix `json:"capacityMix,omitempty"`
+
+	// SpotPrice is the current spot price of the spot instances of the pool. It is only reported when the
+	// controller is started with --enable-spot-price-status.
+	// +optional
+	SpotPrice *SpotPriceStatus `json:"spotPrice,omitempty"`
+
+	// CapacityProbe is the result of the last capacity probe requested by the CapacityProbeAnnotation.
+	// +optional
+	CapacityProbe *CapacityProbe `json:"capacityProbe,omitempty"`
+
+	// SpotPlacementScores are the last spot placement scores requested by the SpotPlacementScoresAnnotation.
+	// +optional
+	SpotPlacementScores *SpotPlacementScores `json:"spotPlacementScores,omitempty"`
+
+	// LastScaleEvent is the last scaling activity of the ASG which changed its capacity. The scaling activities
+	// since this one are reported as events on the AWSMachinePool and its MachinePool.
+	// +optional
+	LastScaleEvent *ScaleEvent `json:"lastScaleEvent,omitempty"`
+
+	// ASG identifies the ASG of the pool. It is recorded as soon as the ASG is created, the ASG isn't created
+	// again while the AutoScaling API doesn't describe it yet.
+	// +optional
+	ASG *ASGReference `json:"asg,omitempty"`
+
+	// InstanceRefreshStatus is the progress of the latest instance refresh of the ASG.
+	// +optional
+	InstanceRefreshStatus *InstanceRefreshStatus `json:"instanceRefreshStatus,omitempty"`
+
+	// AZSequentialRefresh is the progress of the instance refresh with the AZSequential strategy, which is
+	// removed once the instances of all the availability zones were refreshed.
+	// +optional
+	AZSequentialRefresh *AZSequentialRefresh `json:"azSequentialRefresh,omitempty"`
+
+	// InstanceRefreshLaunchTemplateVersion is the version of the launch template the instances of the ASG are
+	// expected to run, which is the version the last instance refresh was started for. An instance refresh is
+	// started when it differs from LaunchTemplateVersion, e.g. after the controller restarted before starting it.
+	// +optional
+	InstanceRefreshLaunchTemplateVersion *string `json:"instanceRefreshLaunchTemplateVersion,omitempty"`
+
+	// PreviousLaunchTemplateVersion is the version of the launch template before its last change rolled out by an
+	// instance refresh. It isn't pruned, and the RollbackLaunchTemplateAnnotation rolls the launch template back
+	// to it.
+	// +optional
+	PreviousLaunchTemplateVersion *string `json:"previousLaunchTemplateVersion,omitempty"`
+
+	// LaunchTemplateRollback is the last rollback of the launch template, which holds the launch template at the
+	// restored version until the spec changes.
+	// +optional
+	LaunchTemplateRollback *LaunchTemplateRollback `json:"launchTemplateRollback,omitempty"`
+
+	// LaunchTemplateChange is the last change of the launch template, and whether it was rolled out to the
+	// instances.
+	// +optional
+	LaunchTemplateChange *LaunchTemplateChange `json:"launchTemplateChange,omitempty"`
+
+	// TargetGroupARNs lists the ARNs of the load balancer target groups attached to the ASG by the controller,
+	// which are the ones detached when they are removed from the spec.
+	// +optional
+	TargetGroupARNs []string `json:"targetGroupARNs,omitempty"`
+
 	// FailureReason will be set in the event that there is a terminal problem
 	// reconciling the Machine and will contain a succinct value suitable
 	// for machine interpretation.
@@ -260,6 +1078,27 @@ type AWSMachinePoolInstanceStatus struct {
 	// Version defines the Kubernetes version for the Machine Instance
 	// +optional
 	Version *string `json:"version,omitempty"`
+
+	// Lifecycle is the purchasing option of the instance.
+	// +kubebuilder:validation:Enum=spot;on-demand
+	// +optional
+	Lifecycle InstanceLifecycle `json:"lifecycle,omitempty"`
+
+	// LifecycleState is the lifecycle state of the instance in the Auto Scaling group, e.g. InService or Standby.
+	// +optional
+	LifecycleState string `json:"lifecycleState,omitempty"`
+
+	// HealthStatus is the health status of the instance reported by the Auto Scaling group, Healthy or Unhealthy.
+	// +optional
+	HealthStatus string `json:"healthStatus,omitempty"`
+
+	// LaunchTemplateVersion is the version of the launch template the instance was launched with.
+	// +optional
+	LaunchTemplateVersion string `json:"launchTemplateVersion,omitempty"`
+
+	// AvailabilityZone is the availability zone of the instance.
+	// +optional
+	AvailabilityZone string `json:"availabilityZone,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -304,6 +1143,69 @@ func (r *AWSMachinePool) SetConditions(conditions clusterv1.Conditions) {
 	r.Status.Conditions = conditions
 }
 
+// ASGInstanceStates returns the state requested for instances of the AWSMachinePool by its
+// ASGInstanceStateAnnotation, keyed by instance ID.
+func (r *AWSMachinePool) ASGInstanceStates() (map[string]string, error) {
+	value, ok := r.Annotations[ASGInstanceStateAnnotation]
+	if !ok {
+		return nil, nil
+	}
+
+	states := map[string]string{}
+	for _, entry := range strings.Split(value, ",") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		instanceID, state, found := strings.Cut(entry, "=")
+		instanceID, state = strings.TrimSpace(instanceID), strings.TrimSpace(state)
+		if !found || instanceID == "" {
+			return nil, fmt.Errorf("invalid entry %q, expected <instance-id>=<state>", entry)
+		}
+		if state != ASGInstanceStateStandby && state != ASGInstanceStateInService {
+			return nil, fmt.Errorf("invalid state %q of instance %q, expected %q or %q", state, instanceID, ASGInstanceStateStandby, ASGInstanceStateInService)
+		}
+		states[instanceID] = state
+	}
+	return states, nil
+}
+
+// CapacityProbeInstances returns the number of instances requested by the CapacityProbeAnnotation of the
+// AWSMachinePool, and whether the annotation is set.
+func (r *AWSMachinePool) CapacityProbeInstances() (int32, bool, error) {
+	return r.instancesAnnotation(CapacityProbeAnnotation)
+}
+
+// SpotPlacementScoresTargetCapacity returns the number of instances requested by the SpotPlacementScoresAnnotation
+// of the AWSMachinePool, and whether the annotation is set.
+func (r *AWSMachinePool) SpotPlacementScoresTargetCapacity() (int32, bool, error) {
+	return r.instancesAnnotation(SpotPlacementScoresAnnotation)
+}
+
+// instancesAnnotation returns the number of instances set by an annotation, and whether it is set.
+func (r *AWSMachinePool) instancesAnnotation(annotation string) (int32, bool, error) {
+	value, ok := r.Annotations[annotation]
+	if !ok {
+		return 0, false, nil
+	}
+	instances, err := strconv.ParseInt(strings.TrimSpace(value), 10, 32)
+	if err != nil || instances <= 0 {
+		return 0, true, fmt.Errorf("invalid number of instances %q, expected a positive integer", value)
+	}
+	return int32(instances), true, nil
+}
+
+// ASGMaxSize returns the maximum size of the ASG, which is the one of the spec raised by
+// spec.refreshPreferences.maxSurge while the RefreshSurgeAnnotation is set.
+func (r *AWSMachinePool) ASGMaxSize() int32 {
+	if _, ok := r.Annotations[RefreshSurgeAnnotation]; !ok {
+		return r.Spec.MaxSize
+	}
+	if r.Spec.RefreshPreferences == nil || r.Spec.RefreshPreferences.MaxSurge == nil {
+		return r.Spec.MaxSize
+	}
+	return r.Spec.MaxSize + *r.Spec.RefreshPreferences.MaxSurge
+}
+
 // GetObjectKind will return the ObjectKind of an AWSMachinePool.
 func (r *AWSMachinePool) GetObjectKind() schema.ObjectKind {
 	return &r.TypeMeta
@@ -17,12 +17,22 @@ limitations under the License.
 package v1beta2
 
 import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
 	"time"
 
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -79,17 +89,21 @@ func (r *AWSMachinePool) validateRootVolume() field.ErrorList {
 		log.Info("root volume shouldn't have a device name (this can be ignored if performing a `clusterctl move`)")
 	}
 
+	allErrs = append(allErrs, r.Spec.AWSLaunchTemplate.RootVolume.ValidateSnapshot(field.NewPath("spec", "awsLaunchTemplate", "rootVolume"), true)...)
+
 	return allErrs
 }
 
 func (r *AWSMachinePool) validateNonRootVolumes() field.ErrorList {
 	var allErrs field.ErrorList
 
-	for _, volume := range r.Spec.AWSLaunchTemplate.NonRootVolumes {
+	for i, volume := range r.Spec.AWSLaunchTemplate.NonRootVolumes {
 		if v1beta2.VolumeTypesProvisioned.Has(string(volume.Type)) && volume.IOPS == 0 {
 			allErrs = append(allErrs, field.Required(field.NewPath("spec.template.spec.nonRootVolumes.iops"), "iops required if type is 'io1' or 'io2'"))
 		}
 
+		allErrs = append(allErrs, volume.ValidateSnapshot(field.NewPath("spec", "awsLaunchTemplate", "nonRootVolumes").Index(i), false)...)
+
 		if volume.Throughput != nil {
 			if volume.Type != v1beta2.VolumeTypeGP3 {
 				allErrs = append(allErrs, field.Required(field.NewPath("spec.template.spec.nonRootVolumes.throughput"), "throughput is valid only for type 'gp3'"))
@@ -134,6 +148,222 @@ func (r *AWSMachinePool) validateAdditionalSecurityGroups() field.ErrorList {
 	return allErrs
 }
 
+// validateDedicatedSecurityGroup validates the dedicated security group of a machine pool. The machine pools
+// a rule refers to are sources of the rule, like security groups, so they can't be combined with CIDR blocks.
+func validateDedicatedSecurityGroup(dedicatedSG *DedicatedSecurityGroup, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if dedicatedSG == nil {
+		return allErrs
+	}
+
+	for i, rule := range dedicatedSG.IngressRules {
+		rulePath := fldPath.Child("ingressRules").Index(i)
+		if len(rule.SourceMachinePools) == 0 {
+			continue
+		}
+		if len(rule.CidrBlocks) > 0 || len(rule.IPv6CidrBlocks) > 0 || rule.NatGatewaysIPsSource {
+			allErrs = append(allErrs, field.Forbidden(rulePath.Child("sourceMachinePools"), "sourceMachinePools can't be combined with cidrBlocks, ipv6CidrBlocks or natGatewaysIPsSource"))
+		}
+		for j, poolName := range rule.SourceMachinePools {
+			if poolName == "" {
+				allErrs = append(allErrs, field.Invalid(rulePath.Child("sourceMachinePools").Index(j), poolName, "machine pool name must not be empty"))
+			}
+		}
+	}
+	return allErrs
+}
+
+// validateAMISourceRegion validates the AMI of a launch template copied from another region: the AMI
+// to copy must be referenced by ID.
+func validateAMISourceRegion(ami v1beta2.AMIReference, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if ami.SourceRegion == "" {
+		if ami.CopyEncryptionKey != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("copyEncryptionKey"), "copyEncryptionKey can only be set with sourceRegion"))
+		}
+		return allErrs
+	}
+
+	if ami.ID == nil || *ami.ID == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("id"), "id is required when sourceRegion is set"))
+	}
+	if ami.EKSOptimizedLookupType != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("eksLookupType"), "eksLookupType can't be combined with sourceRegion"))
+	}
+	return allErrs
+}
+
+// validateLaunchTemplateMarketType checks the market type and the capacity reservation target of a launch template,
+// and that its instance type can be launched in a capacity block.
+func validateLaunchTemplateMarketType(lt *AWSLaunchTemplate, fldPath *field.Path) field.ErrorList {
+	allErrs := v1beta2.ValidateMarketType(lt.MarketType, lt.SpotMarketOptions, lt.CapacityReservationID, fldPath)
+	allErrs = append(allErrs, v1beta2.ValidateCapacityReservationTarget(lt.CapacityReservationID, lt.CapacityReservationResourceGroupARN, fldPath)...)
+	if lt.MarketType == v1beta2.MarketTypeCapacityBlock && lt.InstanceType != "" {
+		allErrs = append(allErrs, v1beta2.ValidateCapacityBlockInstanceType(lt.InstanceType, fldPath.Child("instanceType"))...)
+	}
+	return allErrs
+}
+
+// validateLaunchTemplatePlacement checks that a partition of the placement group of a launch template is only set
+// along with the placement group. The strategy of the placement group is checked by EC2 when the launch template
+// version is created, as a partition is rejected for cluster and spread placement groups.
+func validateLaunchTemplatePlacement(lt *AWSLaunchTemplate, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if lt.PlacementGroupPartition != 0 && lt.PlacementGroupName == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("placementGroupName"), "placementGroupName is required when placementGroupPartition is set"))
+	}
+	if lt.PlacementGroupPartition < 0 || lt.PlacementGroupPartition > 7 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("placementGroupPartition"), lt.PlacementGroupPartition, "placementGroupPartition must be between 1 and 7"))
+	}
+	return allErrs
+}
+
+// validateLaunchTemplateNetworkInterfaces checks the network interfaces of a launch template. The primary network
+// interface is placed by the Auto Scaling group and uses the security groups of the launch template, and Elastic
+// Fabric Adapters only reach their low latency within a placement group.
+func validateLaunchTemplateNetworkInterfaces(lt *AWSLaunchTemplate, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, networkInterface := range lt.NetworkInterfaces {
+		interfacePath := fldPath.Child("networkInterfaces").Index(i)
+		if networkInterface.DeviceIndex == 0 {
+			if networkInterface.SubnetID != "" {
+				allErrs = append(allErrs, field.Forbidden(interfacePath.Child("subnetId"), "subnetId can't be set on the primary network interface"))
+			}
+			if len(networkInterface.SecurityGroups) > 0 {
+				allErrs = append(allErrs, field.Forbidden(interfacePath.Child("securityGroups"), "the primary network interface uses additionalSecurityGroups"))
+			}
+		}
+		for _, sg := range networkInterface.SecurityGroups {
+			if sg.ID != nil && sg.Filters != nil {
+				allErrs = append(allErrs, field.Forbidden(interfacePath.Child("securityGroups"), "either ID or filters should be used"))
+			}
+		}
+		if networkInterface.GetInterfaceType() == NetworkInterfaceTypeEFA && lt.PlacementGroupName == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("placementGroupName"), "placementGroupName is required for efa network interfaces"))
+		}
+	}
+	return allErrs
+}
+
+// enclaveUnsupportedInstanceFamilies are the instance families which can't run Nitro Enclaves, as they are burstable
+// or not built on the Nitro System.
+var enclaveUnsupportedInstanceFamilies = []string{
+	"a1", "c1", "c3", "c4", "d2", "f1", "g2", "g3", "g3s", "h1", "i2", "i3", "m1", "m2", "m3", "m4",
+	"mac1", "mac2", "p2", "p3", "r3", "r4", "t1", "t2", "t3", "t3a", "t4g", "x1", "x1e",
+}
+
+// enclaveUnsupportedInstanceSizes are the instance sizes with too few vCPUs to be split between an instance and
+// its enclave.
+var enclaveUnsupportedInstanceSizes = []string{"nano", "micro", "small", "medium"}
+
+// validateEnclaveInstanceType checks that Nitro Enclaves can run on an instance type, as far as it can be known
+// without querying EC2.
+func validateEnclaveInstanceType(instanceType string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	family, size, _ := strings.Cut(instanceType, ".")
+	if slices.Contains(enclaveUnsupportedInstanceFamilies, family) || slices.Contains(enclaveUnsupportedInstanceSizes, size) {
+		allErrs = append(allErrs, field.Invalid(fldPath, instanceType, "doesn't support Nitro Enclaves"))
+	}
+	return allErrs
+}
+
+// validateLaunchTemplateEnclave checks that the instance type of a launch template supports Nitro Enclaves when
+// they are enabled.
+func validateLaunchTemplateEnclave(lt *AWSLaunchTemplate, fldPath *field.Path) field.ErrorList {
+	if lt.EnclaveOptions == nil || !lt.EnclaveOptions.Enabled || lt.InstanceType == "" {
+		return nil
+	}
+	return validateEnclaveInstanceType(lt.InstanceType, fldPath.Child("instanceType"))
+}
+
+// validateEnclaveOptions checks that the instance types of the ASG support Nitro Enclaves when they are enabled,
+// and that the instances of its warm pool aren't hibernated, as enclaves don't support hibernation.
+func (r *AWSMachinePool) validateEnclaveOptions() field.ErrorList {
+	allErrs := validateLaunchTemplateEnclave(&r.Spec.AWSLaunchTemplate, field.NewPath("spec", "awsLaunchTemplate"))
+	if r.Spec.AWSLaunchTemplate.EnclaveOptions == nil || !r.Spec.AWSLaunchTemplate.EnclaveOptions.Enabled {
+		return allErrs
+	}
+
+	if r.Spec.MixedInstancesPolicy != nil {
+		for i, override := range r.Spec.MixedInstancesPolicy.Overrides {
+			allErrs = append(allErrs, validateEnclaveInstanceType(override.InstanceType, field.NewPath("spec", "mixedInstancesPolicy", "overrides").Index(i).Child("instanceType"))...)
+		}
+	}
+	if r.Spec.WarmPool != nil && r.Spec.WarmPool.GetPoolState() == WarmPoolStateHibernated {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "warmPool", "poolState"), "instances with Nitro Enclaves enabled can't be hibernated"))
+	}
+	return allErrs
+}
+
+// validateMarketType checks the market type of the launch template. The instances of a capacity block all have the
+// instance type it was reserved for, and the ASG rejects launch templates requesting spot instances within a mixed
+// instances policy, so neither can be combined with one.
+func (r *AWSMachinePool) validateMarketType() field.ErrorList {
+	allErrs := validateLaunchTemplateMarketType(&r.Spec.AWSLaunchTemplate, field.NewPath("spec", "awsLaunchTemplate"))
+	if r.Spec.MixedInstancesPolicy == nil {
+		return allErrs
+	}
+
+	switch r.Spec.AWSLaunchTemplate.MarketType {
+	case v1beta2.MarketTypeCapacityBlock, v1beta2.MarketTypeSpot:
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "mixedInstancesPolicy"),
+			fmt.Sprintf("mixedInstancesPolicy can't be set when marketType is %s", r.Spec.AWSLaunchTemplate.MarketType)))
+	}
+	return allErrs
+}
+
+// validateLaunchTemplateRef validates a launch template managed outside of the controller. It replaces the inline
+// launch template definition, as well as the features relying on launch templates created by the controller.
+func (r *AWSMachinePool) validateLaunchTemplateRef() field.ErrorList {
+	var allErrs field.ErrorList
+	ref := r.Spec.AWSLaunchTemplate.Ref
+	if ref == nil {
+		return allErrs
+	}
+
+	refPath := field.NewPath("spec", "awsLaunchTemplate", "ref")
+	if (ref.ID == nil) == (ref.Name == nil) {
+		allErrs = append(allErrs, field.Invalid(refPath, ref, "exactly one of id or name must be set"))
+	}
+	if ref.Version == "" {
+		allErrs = append(allErrs, field.Required(refPath.Child("version"), "version is required"))
+	}
+
+	inline := r.Spec.AWSLaunchTemplate.DeepCopy()
+	inline.Ref = nil
+	inline.VersionNumber = nil
+	if !cmp.Equal(*inline, AWSLaunchTemplate{}) {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "awsLaunchTemplate"), "the inline launch template fields can't be combined with ref"))
+	}
+
+	if r.Spec.DedicatedSecurityGroup != nil && r.Spec.DedicatedSecurityGroup.Enabled {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "dedicatedSecurityGroup"), "dedicatedSecurityGroup can't be enabled with awsLaunchTemplate.ref"))
+	}
+	if len(r.Spec.NodeLabels) > 0 || len(r.Spec.NodeTaints) > 0 {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec"), "nodeLabels and nodeTaints can't be set with awsLaunchTemplate.ref"))
+	}
+	if r.Spec.MixedInstancesPolicy != nil {
+		for i, override := range r.Spec.MixedInstancesPolicy.Overrides {
+			if override.RootVolume != nil {
+				allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "mixedInstancesPolicy", "overrides").Index(i).Child("rootVolume"), "rootVolume can't be set with awsLaunchTemplate.ref"))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// validateLaunchTemplateRefSwitch forbids switching between a referenced and an inline launch template, as the
+// launch template created by the controller for the inline definition would no longer be tracked, nor deleted.
+func (r *AWSMachinePool) validateLaunchTemplateRefSwitch(old *AWSMachinePool) field.ErrorList {
+	var allErrs field.ErrorList
+	if (old.Spec.AWSLaunchTemplate.Ref == nil) != (r.Spec.AWSLaunchTemplate.Ref == nil) {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "awsLaunchTemplate", "ref"), "can't switch between an inline and a referenced launch template, create a new AWSMachinePool instead"))
+	}
+	return allErrs
+}
+
 func (r *AWSMachinePool) validateSpotInstances() field.ErrorList {
 	var allErrs field.ErrorList
 	if r.Spec.AWSLaunchTemplate.SpotMarketOptions != nil && r.Spec.MixedInstancesPolicy != nil {
@@ -142,6 +372,139 @@ func (r *AWSMachinePool) validateSpotInstances() field.ErrorList {
 	return allErrs
 }
 
+func (r *AWSMachinePool) validateOverrides() field.ErrorList {
+	var allErrs field.ErrorList
+
+	if r.Spec.MixedInstancesPolicy == nil {
+		return allErrs
+	}
+
+	instanceTypes := map[string]struct{}{}
+	for i, override := range r.Spec.MixedInstancesPolicy.Overrides {
+		fldPath := field.NewPath("spec", "mixedInstancesPolicy", "overrides").Index(i)
+		switch {
+		case override.InstanceType != "" && override.InstanceRequirements != nil:
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("instanceRequirements"), "instanceType and instanceRequirements are mutually exclusive"))
+		case override.InstanceType == "" && override.InstanceRequirements == nil:
+			allErrs = append(allErrs, field.Required(fldPath, "either instanceType or instanceRequirements must be set"))
+		}
+		if override.InstanceRequirements != nil {
+			allErrs = append(allErrs, validateInstanceRequirements(override.InstanceRequirements, fldPath.Child("instanceRequirements"))...)
+		}
+
+		if override.RootVolume == nil {
+			continue
+		}
+		if override.InstanceType == "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("rootVolume"), "rootVolume can only be set for an instanceType"))
+			continue
+		}
+
+		if _, ok := instanceTypes[override.InstanceType]; ok {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("instanceType"), override.InstanceType))
+		}
+		instanceTypes[override.InstanceType] = struct{}{}
+
+		volumePath := fldPath.Child("rootVolume")
+		if v1beta2.VolumeTypesProvisioned.Has(string(override.RootVolume.Type)) && override.RootVolume.IOPS == 0 {
+			allErrs = append(allErrs, field.Required(volumePath.Child("iops"), "iops required if type is 'io1' or 'io2'"))
+		}
+
+		if override.RootVolume.Throughput != nil {
+			if override.RootVolume.Type != v1beta2.VolumeTypeGP3 {
+				allErrs = append(allErrs, field.Required(volumePath.Child("throughput"), "throughput is valid only for type 'gp3'"))
+			}
+			if *override.RootVolume.Throughput < 0 {
+				allErrs = append(allErrs, field.Required(volumePath.Child("throughput"), "throughput must be nonnegative"))
+			}
+		}
+
+		if override.RootVolume.DeviceName != "" {
+			allErrs = append(allErrs, field.Forbidden(volumePath.Child("deviceName"), "root volume shouldn't have a device name"))
+		}
+
+		allErrs = append(allErrs, override.RootVolume.ValidateSnapshot(volumePath, true)...)
+	}
+
+	return allErrs
+}
+
+// validatePerOverrideUserData checks that the user data variants are keyed by the instance type of an override.
+// validateBottlerocket checks that the Bottlerocket settings are only set with the bottlerocket OS family.
+func (r *AWSMachinePool) validateBottlerocket() field.ErrorList {
+	var allErrs field.ErrorList
+
+	fldPath := field.NewPath("spec", "awsLaunchTemplate", "bottlerocket")
+	bottlerocket := r.Spec.AWSLaunchTemplate.Bottlerocket
+	if bottlerocket == nil {
+		return allErrs
+	}
+	if r.Spec.AWSLaunchTemplate.OSFamily != OSFamilyBottlerocket {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "bottlerocket can only be set with the bottlerocket osFamily"))
+	}
+	if len(bottlerocket.NoProxy) > 0 && bottlerocket.HTTPSProxy == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("httpsProxy"), "httpsProxy is required with noProxy"))
+	}
+	return allErrs
+}
+
+func (r *AWSMachinePool) validatePerOverrideUserData() field.ErrorList {
+	var allErrs field.ErrorList
+
+	fldPath := field.NewPath("spec", "awsLaunchTemplate", "perOverrideUserData")
+	variants := r.Spec.AWSLaunchTemplate.PerOverrideUserData
+	if len(variants) == 0 {
+		return allErrs
+	}
+	if r.Spec.MixedInstancesPolicy == nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "perOverrideUserData can only be set with spec.mixedInstancesPolicy"))
+		return allErrs
+	}
+
+	instanceTypes := map[string]struct{}{}
+	for _, override := range r.Spec.MixedInstancesPolicy.Overrides {
+		if override.InstanceType != "" {
+			instanceTypes[override.InstanceType] = struct{}{}
+		}
+	}
+	seen := map[string]struct{}{}
+	for i, variant := range variants {
+		variantPath := fldPath.Index(i)
+		if _, ok := seen[variant.InstanceType]; ok {
+			allErrs = append(allErrs, field.Duplicate(variantPath.Child("instanceType"), variant.InstanceType))
+		}
+		seen[variant.InstanceType] = struct{}{}
+		if _, ok := instanceTypes[variant.InstanceType]; !ok {
+			allErrs = append(allErrs, field.Invalid(variantPath.Child("instanceType"), variant.InstanceType, "must be the instanceType of an override of spec.mixedInstancesPolicy"))
+		}
+		if variant.DataSecretName == "" {
+			allErrs = append(allErrs, field.Required(variantPath.Child("dataSecretName"), "dataSecretName is required"))
+		}
+	}
+
+	return allErrs
+}
+
+// validateInstanceRequirements checks that the ranges of the instance requirements of an override are valid, and that
+// the instance types aren't both allowed and excluded.
+func validateInstanceRequirements(requirements *InstanceRequirements, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if requirements.VCPUCount.Max != nil && *requirements.VCPUCount.Max < requirements.VCPUCount.Min {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("vCpuCount", "max"), *requirements.VCPUCount.Max, "must be greater than or equal to min"))
+	}
+	if requirements.MemoryMiB.Max != nil && *requirements.MemoryMiB.Max < requirements.MemoryMiB.Min {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("memoryMiB", "max"), *requirements.MemoryMiB.Max, "must be greater than or equal to min"))
+	}
+	if count := requirements.AcceleratorCount; count != nil && count.Min != nil && count.Max != nil && *count.Max < *count.Min {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("acceleratorCount", "max"), *count.Max, "must be greater than or equal to min"))
+	}
+	if len(requirements.AllowedInstanceTypes) > 0 && len(requirements.ExcludedInstanceTypes) > 0 {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("excludedInstanceTypes"), "allowedInstanceTypes and excludedInstanceTypes are mutually exclusive"))
+	}
+	return allErrs
+}
+
 func (r *AWSMachinePool) validateRefreshPreferences() field.ErrorList {
 	var allErrs field.ErrorList
 
@@ -159,6 +522,426 @@ func (r *AWSMachinePool) validateRefreshPreferences() field.ErrorList {
 		}
 	}
 
+	checkpointsPath := field.NewPath("spec", "refreshPreferences", "checkpointPercentages")
+	checkpoints := r.Spec.RefreshPreferences.CheckpointPercentages
+	for i, percentage := range checkpoints {
+		if percentage < 1 || percentage > 100 {
+			allErrs = append(allErrs, field.Invalid(checkpointsPath.Index(i), percentage, "must be between 1 and 100"))
+		} else if i > 0 && percentage <= checkpoints[i-1] {
+			allErrs = append(allErrs, field.Invalid(checkpointsPath.Index(i), percentage, "checkpoint percentages must be increasing"))
+		}
+	}
+	if len(checkpoints) > 0 && checkpoints[len(checkpoints)-1] != 100 {
+		allErrs = append(allErrs, field.Invalid(checkpointsPath, checkpoints, "the last checkpoint percentage must be 100"))
+	}
+
+	if delay := r.Spec.RefreshPreferences.CheckpointDelay; delay != nil {
+		delayPath := field.NewPath("spec", "refreshPreferences", "checkpointDelay")
+		if len(checkpoints) == 0 {
+			allErrs = append(allErrs, field.Forbidden(delayPath, "requires spec.refreshPreferences.checkpointPercentages"))
+		}
+		if delay.Duration < 0 {
+			allErrs = append(allErrs, field.Invalid(delayPath, delay.Duration.String(), "must not be negative"))
+		}
+	}
+
+	return allErrs
+}
+
+func (r *AWSMachinePool) validateUnmanagedFields() field.ErrorList {
+	var allErrs field.ErrorList
+	fldPath := field.NewPath("spec", "unmanagedFields")
+
+	valid := make(map[UnmanagedField]bool, len(UnmanagedFields))
+	for _, f := range UnmanagedFields {
+		valid[f] = true
+	}
+
+	unmanaged := map[UnmanagedField]bool{}
+	for i, f := range r.Spec.UnmanagedFields {
+		if !valid[f] {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Index(i), f, UnmanagedFields))
+			continue
+		}
+		unmanaged[f] = true
+	}
+
+	if len(unmanaged) == len(UnmanagedFields) {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "at least one field must be left managed by CAPA"))
+	}
+
+	return allErrs
+}
+
+func (r *AWSMachinePool) validateLifecycleHooks() field.ErrorList {
+	var allErrs field.ErrorList
+	fldPath := field.NewPath("spec", "lifecycleHooks")
+
+	names := map[string]bool{}
+	for i, hook := range r.Spec.LifecycleHooks {
+		if names[hook.Name] {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i).Child("name"), hook.Name))
+		}
+		names[hook.Name] = true
+
+		// The hook used by the node termination handler is managed from the AWSCluster.
+		if hook.Name == "capa-node-termination-handler" {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("name"), hook.Name, "name is reserved"))
+		}
+
+		// Auto Scaling only accepts heartbeat timeouts between 30 seconds and 2 hours.
+		if timeout := hook.GetHeartbeatTimeout(); timeout < 30*time.Second || timeout > 2*time.Hour {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("heartbeatTimeout"), timeout.String(), "must be between 30s and 2h"))
+		}
+
+		// The node joins the cluster after the instance is launched.
+		if hook.Role == LifecycleHookRoleNodeJoin && hook.GetLifecycleTransition() != LifecycleTransitionLaunching {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("lifecycleTransition"), hook.LifecycleTransition, "must be autoscaling:EC2_INSTANCE_LAUNCHING for the capa-node-join role"))
+		}
+
+		if hook.NotificationTargetARN != "" && hook.RoleARN == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Index(i).Child("roleARN"), "required with a notification target"))
+		}
+		if hook.RoleARN != "" && hook.NotificationTargetARN == "" {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("roleARN"), hook.RoleARN, "can only be set with a notification target"))
+		}
+	}
+
+	return allErrs
+}
+
+// kubeletLabels are the labels of the kubernetes.io and k8s.io namespaces a kubelet is allowed to set.
+var kubeletLabels = map[string]bool{
+	corev1.LabelHostname:                true,
+	corev1.LabelTopologyZone:            true,
+	corev1.LabelTopologyRegion:          true,
+	corev1.LabelFailureDomainBetaZone:   true,
+	corev1.LabelFailureDomainBetaRegion: true,
+	corev1.LabelInstanceType:            true,
+	corev1.LabelInstanceTypeStable:      true,
+	corev1.LabelOSStable:                true,
+	corev1.LabelArchStable:              true,
+	"beta.kubernetes.io/os":             true,
+	"beta.kubernetes.io/arch":           true,
+}
+
+// isKubeletLabel returns whether the kubelet accepts the label with --node-labels: the labels of the
+// kubernetes.io and k8s.io namespaces are restricted to the well-known ones and the kubelet.kubernetes.io and
+// node.kubernetes.io namespaces.
+func isKubeletLabel(key string) bool {
+	namespace, _, found := strings.Cut(key, "/")
+	if !found {
+		return true
+	}
+	inNamespace := func(ns string) bool {
+		return namespace == ns || strings.HasSuffix(namespace, "."+ns)
+	}
+	if !inNamespace("kubernetes.io") && !inNamespace("k8s.io") {
+		return true
+	}
+	return kubeletLabels[key] || inNamespace(corev1.LabelNamespaceSuffixKubelet) || inNamespace(corev1.LabelNamespaceSuffixNode)
+}
+
+func (r *AWSMachinePool) validateNodeLabelsAndTaints() field.ErrorList {
+	var allErrs field.ErrorList
+
+	labelsPath := field.NewPath("spec", "nodeLabels")
+	for key, value := range r.Spec.NodeLabels {
+		for _, msg := range validation.IsQualifiedName(key) {
+			allErrs = append(allErrs, field.Invalid(labelsPath.Key(key), key, msg))
+		}
+		for _, msg := range validation.IsValidLabelValue(value) {
+			allErrs = append(allErrs, field.Invalid(labelsPath.Key(key), value, msg))
+		}
+		if !isKubeletLabel(key) {
+			allErrs = append(allErrs, field.Invalid(labelsPath.Key(key), key, "the kubelet can only set the labels of the kubernetes.io and k8s.io namespaces in the kubelet.kubernetes.io and node.kubernetes.io namespaces or well-known ones"))
+		}
+	}
+
+	taintsPath := field.NewPath("spec", "nodeTaints")
+	for i, taint := range r.Spec.NodeTaints {
+		for _, msg := range validation.IsQualifiedName(taint.Key) {
+			allErrs = append(allErrs, field.Invalid(taintsPath.Index(i).Child("key"), taint.Key, msg))
+		}
+		for _, msg := range validation.IsValidLabelValue(taint.Value) {
+			allErrs = append(allErrs, field.Invalid(taintsPath.Index(i).Child("value"), taint.Value, msg))
+		}
+		switch taint.Effect {
+		case TaintEffectNoSchedule, TaintEffectNoExecute, TaintEffectPreferNoSchedule:
+		default:
+			allErrs = append(allErrs, field.NotSupported(taintsPath.Index(i).Child("effect"), taint.Effect, []TaintEffect{TaintEffectNoSchedule, TaintEffectNoExecute, TaintEffectPreferNoSchedule}))
+		}
+	}
+
+	return allErrs
+}
+
+func (r *AWSMachinePool) validateScalingPolicies() field.ErrorList {
+	var allErrs field.ErrorList
+	fldPath := field.NewPath("spec", "scalingPolicies")
+
+	names := map[string]bool{}
+	for i, policy := range r.Spec.ScalingPolicies {
+		policyPath := fldPath.Index(i)
+		if names[policy.Name] {
+			allErrs = append(allErrs, field.Duplicate(policyPath.Child("name"), policy.Name))
+		}
+		names[policy.Name] = true
+
+		if policy.PredictiveScaling == nil {
+			allErrs = append(allErrs, field.Required(policyPath.Child("predictiveScaling"), "a kind of scaling policy must be set"))
+			continue
+		}
+		allErrs = append(allErrs, validatePredictiveScaling(policy.PredictiveScaling, policyPath.Child("predictiveScaling"))...)
+	}
+
+	return allErrs
+}
+
+func (r *AWSMachinePool) validateAZFailureHandling() field.ErrorList {
+	var allErrs field.ErrorList
+
+	if r.Spec.AZFailureHandling == nil {
+		return allErrs
+	}
+	if cooldown := r.Spec.AZFailureHandling.GetCooldown(); cooldown < time.Minute || cooldown > 24*time.Hour {
+		fldPath := field.NewPath("spec", "azFailureHandling", "cooldown")
+		allErrs = append(allErrs, field.Invalid(fldPath, cooldown.String(), "must be between 1m and 24h"))
+	}
+
+	return allErrs
+}
+
+func (r *AWSMachinePool) validateWarmPool() field.ErrorList {
+	var allErrs field.ErrorList
+
+	warmPool := r.Spec.WarmPool
+	if warmPool == nil {
+		return allErrs
+	}
+	fldPath := field.NewPath("spec", "warmPool")
+	// The Auto Scaling API doesn't support warm pools on ASGs with a mixed instances policy.
+	if r.Spec.MixedInstancesPolicy != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "can't be used with spec.mixedInstancesPolicy"))
+	}
+	if warmPool.MaxGroupPreparedCapacity != nil && *warmPool.MaxGroupPreparedCapacity < r.Spec.MinSize {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxGroupPreparedCapacity"), *warmPool.MaxGroupPreparedCapacity, "must not be lower than spec.minSize"))
+	}
+
+	return allErrs
+}
+
+func (r *AWSMachinePool) validateTerminationPolicies() field.ErrorList {
+	var allErrs field.ErrorList
+	fldPath := field.NewPath("spec", "terminationPolicies")
+
+	seen := make(map[string]bool, len(r.Spec.TerminationPolicies))
+	for i, policy := range r.Spec.TerminationPolicies {
+		if seen[policy] {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i), policy))
+			continue
+		}
+		seen[policy] = true
+
+		if slices.Contains(TerminationPolicies, policy) {
+			continue
+		}
+		// Custom termination policies are Lambda functions.
+		if parsed, err := arn.Parse(policy); err == nil && parsed.Service == "lambda" {
+			continue
+		}
+		allErrs = append(allErrs, field.Invalid(fldPath.Index(i), policy,
+			"must be one of "+strings.Join(TerminationPolicies, ", ")+", or the ARN of a Lambda function"))
+	}
+
+	return allErrs
+}
+
+func (r *AWSMachinePool) validateHealthCheck() field.ErrorList {
+	var allErrs field.ErrorList
+	if r.Spec.HealthCheckGracePeriod == nil {
+		return allErrs
+	}
+	fldPath := field.NewPath("spec", "healthCheckGracePeriod")
+
+	if r.Spec.HealthCheckType == "" {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "requires spec.healthCheckType to be set"))
+	}
+	if r.Spec.HealthCheckGracePeriod.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath, r.Spec.HealthCheckGracePeriod.Duration.String(), "must not be negative"))
+	}
+
+	return allErrs
+}
+
+func (r *AWSMachinePool) validateDefaultInstanceWarmup() field.ErrorList {
+	var allErrs field.ErrorList
+	if r.Spec.DefaultInstanceWarmup == nil {
+		return allErrs
+	}
+	fldPath := field.NewPath("spec", "defaultInstanceWarmup")
+
+	if r.Spec.DefaultInstanceWarmup.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath, r.Spec.DefaultInstanceWarmup.Duration.String(), "must not be negative"))
+	}
+	if r.Spec.DefaultInstanceWarmup.Duration%time.Second != 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath, r.Spec.DefaultInstanceWarmup.Duration.String(), "must be a whole number of seconds"))
+	}
+
+	return allErrs
+}
+
+// The maximum instance lifetime of an ASG is between one day and one year, or zero.
+const (
+	minMaxInstanceLifetime = 24 * time.Hour
+	maxMaxInstanceLifetime = 365 * 24 * time.Hour
+)
+
+func (r *AWSMachinePool) validateMaxInstanceLifetime() field.ErrorList {
+	var allErrs field.ErrorList
+	if r.Spec.MaxInstanceLifetime == nil {
+		return allErrs
+	}
+	fldPath := field.NewPath("spec", "maxInstanceLifetime")
+
+	lifetime := r.Spec.MaxInstanceLifetime.Duration
+	if lifetime != 0 && (lifetime < minMaxInstanceLifetime || lifetime > maxMaxInstanceLifetime) {
+		allErrs = append(allErrs, field.Invalid(fldPath, lifetime.String(), "must be zero or between 24h and 8760h"))
+	}
+	if lifetime%time.Second != 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath, lifetime.String(), "must be a whole number of seconds"))
+	}
+
+	return allErrs
+}
+
+func (r *AWSMachinePool) validateMetrics() field.ErrorList {
+	if r.Spec.Metrics == nil {
+		return nil
+	}
+	var allErrs field.ErrorList
+	fldPath := field.NewPath("spec", "metrics", "metrics")
+
+	seen := make(map[string]bool, len(r.Spec.Metrics.Metrics))
+	for i, metric := range r.Spec.Metrics.Metrics {
+		if seen[metric] {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i), metric))
+			continue
+		}
+		seen[metric] = true
+
+		if !slices.Contains(ASGMetrics, metric) {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Index(i), metric, ASGMetrics))
+		}
+	}
+
+	return allErrs
+}
+
+func (r *AWSMachinePool) validateSuspendProcesses() field.ErrorList {
+	suspendProcesses := r.Spec.SuspendProcesses
+	if suspendProcesses == nil || !suspendProcesses.All || suspendProcesses.Processes == nil {
+		return nil
+	}
+	var allErrs field.ErrorList
+	fldPath := field.NewPath("spec", "suspendProcesses", "processes")
+
+	// All suspends every process already, the processes can only be excluded from it.
+	e := reflect.ValueOf(suspendProcesses.Processes).Elem()
+	for i := 0; i < e.NumField(); i++ {
+		if value := e.Field(i); !value.IsNil() && *value.Interface().(*bool) {
+			name, _, _ := strings.Cut(e.Type().Field(i).Tag.Get("json"), ",")
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child(name), "can't be set to true with spec.suspendProcesses.all, only to false to leave the process running"))
+		}
+	}
+
+	return allErrs
+}
+
+func (r *AWSMachinePool) validateASGInstanceStates() field.ErrorList {
+	var allErrs field.ErrorList
+
+	if _, err := r.ASGInstanceStates(); err != nil {
+		fldPath := field.NewPath("metadata", "annotations").Key(ASGInstanceStateAnnotation)
+		allErrs = append(allErrs, field.Invalid(fldPath, r.Annotations[ASGInstanceStateAnnotation], err.Error()))
+	}
+
+	return allErrs
+}
+
+func (r *AWSMachinePool) validateCapacityProbe() field.ErrorList {
+	var allErrs field.ErrorList
+
+	if _, _, err := r.CapacityProbeInstances(); err != nil {
+		fldPath := field.NewPath("metadata", "annotations").Key(CapacityProbeAnnotation)
+		allErrs = append(allErrs, field.Invalid(fldPath, r.Annotations[CapacityProbeAnnotation], err.Error()))
+	}
+
+	return allErrs
+}
+
+func (r *AWSMachinePool) validateSpotPlacementScores() field.ErrorList {
+	var allErrs field.ErrorList
+
+	if _, _, err := r.SpotPlacementScoresTargetCapacity(); err != nil {
+		fldPath := field.NewPath("metadata", "annotations").Key(SpotPlacementScoresAnnotation)
+		allErrs = append(allErrs, field.Invalid(fldPath, r.Annotations[SpotPlacementScoresAnnotation], err.Error()))
+	}
+
+	return allErrs
+}
+
+func (r *AWSMachinePool) validateLaunchTemplateRollback() field.ErrorList {
+	var allErrs field.ErrorList
+
+	if r.Annotations[RollbackLaunchTemplateAnnotation] == "true" && ptr.Deref(r.Status.PreviousLaunchTemplateVersion, "") == "" {
+		fldPath := field.NewPath("metadata", "annotations").Key(RollbackLaunchTemplateAnnotation)
+		allErrs = append(allErrs, field.Forbidden(fldPath, "no previous launch template version is recorded to roll back to"))
+	}
+
+	return allErrs
+}
+
+func validatePredictiveScaling(config *PredictiveScalingConfiguration, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if len(config.MetricSpecifications) != 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("metricSpecifications"), len(config.MetricSpecifications), "exactly one metric specification must be set"))
+	}
+
+	for i, spec := range config.MetricSpecifications {
+		specPath := fldPath.Child("metricSpecifications").Index(i)
+		if spec.TargetValue <= 0 {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("targetValue"), spec.TargetValue, "must be greater than zero"))
+		}
+
+		pair := spec.PredefinedMetricPair
+		pairPath := specPath.Child("predefinedMetricPair")
+		switch pair.PredefinedMetricType {
+		case PredefinedMetricPairTypeALBRequestCount:
+			if pair.ResourceLabel == "" {
+				allErrs = append(allErrs, field.Required(pairPath.Child("resourceLabel"), "the target group must be set for ALBRequestCount"))
+			}
+		case PredefinedMetricPairTypeASGCPUUtilization, PredefinedMetricPairTypeASGNetworkIn, PredefinedMetricPairTypeASGNetworkOut:
+			if pair.ResourceLabel != "" {
+				allErrs = append(allErrs, field.Forbidden(pairPath.Child("resourceLabel"), "can only be set for ALBRequestCount"))
+			}
+		default:
+			allErrs = append(allErrs, field.NotSupported(pairPath.Child("predefinedMetricType"), pair.PredefinedMetricType, PredefinedMetricPairTypes))
+		}
+	}
+
+	switch config.Mode {
+	case "", PredictiveScalingModeForecastOnly, PredictiveScalingModeForecastAndScale:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("mode"), config.Mode,
+			[]PredictiveScalingMode{PredictiveScalingModeForecastOnly, PredictiveScalingModeForecastAndScale}))
+	}
+
+	if config.SchedulingBufferTime != nil && (config.SchedulingBufferTime.Duration < 0 || config.SchedulingBufferTime.Duration > time.Hour) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("schedulingBufferTime"), config.SchedulingBufferTime.Duration.String(), "must be between 0s and 1h"))
+	}
+
 	return allErrs
 }
 
@@ -175,7 +958,33 @@ func (r *AWSMachinePool) ValidateCreate() (admission.Warnings, error) {
 	allErrs = append(allErrs, r.validateSubnets()...)
 	allErrs = append(allErrs, r.validateAdditionalSecurityGroups()...)
 	allErrs = append(allErrs, r.validateSpotInstances()...)
+	allErrs = append(allErrs, r.validateMarketType()...)
+	allErrs = append(allErrs, validateLaunchTemplatePlacement(&r.Spec.AWSLaunchTemplate, field.NewPath("spec", "awsLaunchTemplate"))...)
+	allErrs = append(allErrs, r.validateEnclaveOptions()...)
+	allErrs = append(allErrs, validateLaunchTemplateNetworkInterfaces(&r.Spec.AWSLaunchTemplate, field.NewPath("spec", "awsLaunchTemplate"))...)
+	allErrs = append(allErrs, r.validateLaunchTemplateRef()...)
+	allErrs = append(allErrs, r.validateOverrides()...)
+	allErrs = append(allErrs, r.validatePerOverrideUserData()...)
+	allErrs = append(allErrs, r.validateBottlerocket()...)
 	allErrs = append(allErrs, r.validateRefreshPreferences()...)
+	allErrs = append(allErrs, r.validateUnmanagedFields()...)
+	allErrs = append(allErrs, r.validateLifecycleHooks()...)
+	allErrs = append(allErrs, r.validateNodeLabelsAndTaints()...)
+	allErrs = append(allErrs, r.validateScalingPolicies()...)
+	allErrs = append(allErrs, r.validateAZFailureHandling()...)
+	allErrs = append(allErrs, r.validateWarmPool()...)
+	allErrs = append(allErrs, r.validateTerminationPolicies()...)
+	allErrs = append(allErrs, r.validateMetrics()...)
+	allErrs = append(allErrs, r.validateSuspendProcesses()...)
+	allErrs = append(allErrs, r.validateHealthCheck()...)
+	allErrs = append(allErrs, r.validateDefaultInstanceWarmup()...)
+	allErrs = append(allErrs, r.validateMaxInstanceLifetime()...)
+	allErrs = append(allErrs, r.validateASGInstanceStates()...)
+	allErrs = append(allErrs, r.validateCapacityProbe()...)
+	allErrs = append(allErrs, r.validateSpotPlacementScores()...)
+	allErrs = append(allErrs, r.validateLaunchTemplateRollback()...)
+	allErrs = append(allErrs, validateAMISourceRegion(r.Spec.AWSLaunchTemplate.AMI, field.NewPath("spec", "awsLaunchTemplate", "ami"))...)
+	allErrs = append(allErrs, validateDedicatedSecurityGroup(r.Spec.DedicatedSecurityGroup, field.NewPath("spec", "dedicatedSecurityGroup"))...)
 
 	if len(allErrs) == 0 {
 		return nil, nil
@@ -189,15 +998,45 @@ func (r *AWSMachinePool) ValidateCreate() (admission.Warnings, error) {
 }
 
 // ValidateUpdate will do any extra validation when updating a AWSMachinePool.
-func (r *AWSMachinePool) ValidateUpdate(_ runtime.Object) (admission.Warnings, error) {
+func (r *AWSMachinePool) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
 	var allErrs field.ErrorList
 
+	if oldPool, ok := old.(*AWSMachinePool); ok && oldPool != nil {
+		allErrs = append(allErrs, r.validateLaunchTemplateRefSwitch(oldPool)...)
+	}
+
 	allErrs = append(allErrs, r.validateDefaultCoolDown()...)
 	allErrs = append(allErrs, r.Spec.AdditionalTags.Validate()...)
 	allErrs = append(allErrs, r.validateSubnets()...)
 	allErrs = append(allErrs, r.validateAdditionalSecurityGroups()...)
 	allErrs = append(allErrs, r.validateSpotInstances()...)
+	allErrs = append(allErrs, r.validateMarketType()...)
+	allErrs = append(allErrs, validateLaunchTemplatePlacement(&r.Spec.AWSLaunchTemplate, field.NewPath("spec", "awsLaunchTemplate"))...)
+	allErrs = append(allErrs, r.validateEnclaveOptions()...)
+	allErrs = append(allErrs, validateLaunchTemplateNetworkInterfaces(&r.Spec.AWSLaunchTemplate, field.NewPath("spec", "awsLaunchTemplate"))...)
+	allErrs = append(allErrs, r.validateLaunchTemplateRef()...)
+	allErrs = append(allErrs, r.validateOverrides()...)
+	allErrs = append(allErrs, r.validatePerOverrideUserData()...)
+	allErrs = append(allErrs, r.validateBottlerocket()...)
 	allErrs = append(allErrs, r.validateRefreshPreferences()...)
+	allErrs = append(allErrs, r.validateUnmanagedFields()...)
+	allErrs = append(allErrs, r.validateLifecycleHooks()...)
+	allErrs = append(allErrs, r.validateNodeLabelsAndTaints()...)
+	allErrs = append(allErrs, r.validateScalingPolicies()...)
+	allErrs = append(allErrs, r.validateAZFailureHandling()...)
+	allErrs = append(allErrs, r.validateWarmPool()...)
+	allErrs = append(allErrs, r.validateTerminationPolicies()...)
+	allErrs = append(allErrs, r.validateMetrics()...)
+	allErrs = append(allErrs, r.validateSuspendProcesses()...)
+	allErrs = append(allErrs, r.validateHealthCheck()...)
+	allErrs = append(allErrs, r.validateDefaultInstanceWarmup()...)
+	allErrs = append(allErrs, r.validateMaxInstanceLifetime()...)
+	allErrs = append(allErrs, r.validateASGInstanceStates()...)
+	allErrs = append(allErrs, r.validateCapacityProbe()...)
+	allErrs = append(allErrs, r.validateSpotPlacementScores()...)
+	allErrs = append(allErrs, r.validateLaunchTemplateRollback()...)
+	allErrs = append(allErrs, validateAMISourceRegion(r.Spec.AWSLaunchTemplate.AMI, field.NewPath("spec", "awsLaunchTemplate", "ami"))...)
+	allErrs = append(allErrs, validateDedicatedSecurityGroup(r.Spec.DedicatedSecurityGroup, field.NewPath("spec", "dedicatedSecurityGroup"))...)
 
 	if len(allErrs) == 0 {
 		return nil, nil
@@ -222,8 +1061,14 @@ func (r *AWSMachinePool) Default() {
 		r.Spec.DefaultCoolDown.Duration = 300 * time.Second
 	}
 
-	if int(r.Spec.DefaultInstanceWarmup.Duration.Seconds()) == 0 {
-		log.Info("DefaultInstanceWarmup is zero, setting 300 seconds as default")
-		r.Spec.DefaultInstanceWarmup.Duration = 300 * time.Second
+	if r.Spec.DefaultInstanceWarmup == nil {
+		log.Info("DefaultInstanceWarmup is not set, setting 300 seconds as default")
+		r.Spec.DefaultInstanceWarmup = &metav1.Duration{Duration: 300 * time.Second}
+	}
+
+	for i := range r.Spec.ScalingPolicies {
+		if predictiveScaling := r.Spec.ScalingPolicies[i].PredictiveScaling; predictiveScaling != nil && predictiveScaling.Mode == "" {
+			predictiveScaling.Mode = PredictiveScalingModeForecastOnly
+		}
 	}
 }
@@ -19,6 +19,7 @@ package v1beta2
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	. "github.com/onsi/gomega"
@@ -35,6 +36,7 @@ func TestAWSMachinePoolDefault(t *testing.T) {
 	m.Default()
 	g := NewWithT(t)
 	g.Expect(m.Spec.DefaultCoolDown.Duration).To(BeNumerically(">=", 0))
+	g.Expect(m.Spec.DefaultInstanceWarmup).To(Equal(&metav1.Duration{Duration: 300 * time.Second}))
 }
 
 func TestAWSMachinePoolValidateCreate(t *testing.T) {
@@ -153,22 +155,1195 @@ func TestAWSMachinePoolValidateCreate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Should pass if overrides set their own root volume",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					MixedInstancesPolicy: &MixedInstancesPolicy{
+						Overrides: []Overrides{
+							{InstanceType: "t3.medium"},
+							{InstanceType: "m6i.large", RootVolume: &infrav1.Volume{Size: 50, Type: infrav1.VolumeTypeGP3, Throughput: aws.Int64(250)}},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail if an override root volume sets throughput for a non gp3 volume",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					MixedInstancesPolicy: &MixedInstancesPolicy{
+						Overrides: []Overrides{
+							{InstanceType: "m6i.large", RootVolume: &infrav1.Volume{Size: 50, Type: infrav1.VolumeTypeGP2, Throughput: aws.Int64(250)}},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if an override root volume has a device name",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					MixedInstancesPolicy: &MixedInstancesPolicy{
+						Overrides: []Overrides{
+							{InstanceType: "m6i.large", RootVolume: &infrav1.Volume{Size: 50, DeviceName: "/dev/sda1"}},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if the same instance type is overridden with a root volume twice",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					MixedInstancesPolicy: &MixedInstancesPolicy{
+						Overrides: []Overrides{
+							{InstanceType: "m6i.large", RootVolume: &infrav1.Volume{Size: 50}},
+							{InstanceType: "m6i.large", RootVolume: &infrav1.Volume{Size: 100}},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should pass with instance requirements",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					MixedInstancesPolicy: &MixedInstancesPolicy{
+						Overrides: []Overrides{
+							{InstanceType: "m6i.large"},
+							{InstanceRequirements: &InstanceRequirements{VCPUCount: VCPUCountRequest{Min: 2}, MemoryMiB: MemoryMiBRequest{Min: 4096}}},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail if an override sets both an instance type and instance requirements",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					MixedInstancesPolicy: &MixedInstancesPolicy{
+						Overrides: []Overrides{
+							{InstanceType: "m6i.large", InstanceRequirements: &InstanceRequirements{VCPUCount: VCPUCountRequest{Min: 2}, MemoryMiB: MemoryMiBRequest{Min: 4096}}},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if an override sets neither an instance type nor instance requirements",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					MixedInstancesPolicy: &MixedInstancesPolicy{
+						Overrides: []Overrides{
+							{},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if an override with instance requirements sets a root volume",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					MixedInstancesPolicy: &MixedInstancesPolicy{
+						Overrides: []Overrides{
+							{InstanceRequirements: &InstanceRequirements{VCPUCount: VCPUCountRequest{Min: 2}, MemoryMiB: MemoryMiBRequest{Min: 4096}}, RootVolume: &infrav1.Volume{Size: 100}},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if the maximum of the vCPUs of instance requirements is below their minimum",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					MixedInstancesPolicy: &MixedInstancesPolicy{
+						Overrides: []Overrides{
+							{InstanceRequirements: &InstanceRequirements{VCPUCount: VCPUCountRequest{Min: 4, Max: aws.Int64(2)}, MemoryMiB: MemoryMiBRequest{Min: 4096}}},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if instance requirements both allow and exclude instance types",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					MixedInstancesPolicy: &MixedInstancesPolicy{
+						Overrides: []Overrides{
+							{InstanceRequirements: &InstanceRequirements{VCPUCount: VCPUCountRequest{Min: 2}, MemoryMiB: MemoryMiBRequest{Min: 4096}, AllowedInstanceTypes: []string{"m5.*"}, ExcludedInstanceTypes: []string{"m5.large"}}},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should pass with user data for an instance type override",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						PerOverrideUserData: []OverrideUserData{{InstanceType: "m6i.large", DataSecretName: "m6i-bootstrap-data"}},
+					},
+					MixedInstancesPolicy: &MixedInstancesPolicy{
+						Overrides: []Overrides{{InstanceType: "t3.large"}, {InstanceType: "m6i.large"}},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail if user data is set for an instance type which isn't overridden",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						PerOverrideUserData: []OverrideUserData{{InstanceType: "c6i.large", DataSecretName: "c6i-bootstrap-data"}},
+					},
+					MixedInstancesPolicy: &MixedInstancesPolicy{
+						Overrides: []Overrides{{InstanceType: "t3.large"}, {InstanceType: "m6i.large"}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if user data is set twice for an instance type override",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						PerOverrideUserData: []OverrideUserData{{InstanceType: "m6i.large", DataSecretName: "m6i-bootstrap-data"}, {InstanceType: "m6i.large", DataSecretName: "m6i-bootstrap-data"}},
+					},
+					MixedInstancesPolicy: &MixedInstancesPolicy{
+						Overrides: []Overrides{{InstanceType: "t3.large"}, {InstanceType: "m6i.large"}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should pass if Bottlerocket settings are set with the bottlerocket OS family",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						OSFamily: OSFamilyBottlerocket,
+						Bottlerocket: &BottlerocketSettings{
+							AdminContainer: ptr.To(true),
+							HTTPSProxy:     "http://proxy.example.com:3128",
+							NoProxy:        []string{"localhost"},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail if Bottlerocket settings are set without the bottlerocket OS family",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						Bottlerocket: &BottlerocketSettings{AdminContainer: ptr.To(true)},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if Bottlerocket noProxy is set without httpsProxy",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						OSFamily:     OSFamilyBottlerocket,
+						Bottlerocket: &BottlerocketSettings{NoProxy: []string{"localhost"}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if user data per override is set without a mixed instances policy",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						PerOverrideUserData: []OverrideUserData{{InstanceType: "m6i.large", DataSecretName: "m6i-bootstrap-data"}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if user data for an override has no secret name",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						PerOverrideUserData: []OverrideUserData{{InstanceType: "m6i.large"}},
+					},
+					MixedInstancesPolicy: &MixedInstancesPolicy{
+						Overrides: []Overrides{{InstanceType: "t3.large"}, {InstanceType: "m6i.large"}},
+					},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "Should fail if MaxHealthyPercentage is set, but MinHealthyPercentage is not set",
 			pool: &AWSMachinePool{
 				Spec: AWSMachinePoolSpec{
-					RefreshPreferences: &RefreshPreferences{MaxHealthyPercentage: aws.Int64(100)},
+					RefreshPreferences: &RefreshPreferences{MaxHealthyPercentage: aws.Int64(100)},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if the difference between MaxHealthyPercentage and MinHealthyPercentage is greater than 100",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					RefreshPreferences: &RefreshPreferences{
+						MaxHealthyPercentage: aws.Int64(150),
+						MinHealthyPercentage: aws.Int64(25),
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should pass if the checkpoint percentages are increasing and end at 100",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					RefreshPreferences: &RefreshPreferences{
+						CheckpointPercentages: []int64{20, 50, 100},
+						CheckpointDelay:       &metav1.Duration{Duration: 10 * time.Minute},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail if the checkpoint percentages aren't increasing",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					RefreshPreferences: &RefreshPreferences{CheckpointPercentages: []int64{50, 20, 100}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if the last checkpoint percentage isn't 100",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					RefreshPreferences: &RefreshPreferences{CheckpointPercentages: []int64{20, 50}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if a checkpoint delay is set without checkpoint percentages",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					RefreshPreferences: &RefreshPreferences{CheckpointDelay: &metav1.Duration{Duration: 10 * time.Minute}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should pass if some fields are unmanaged",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					UnmanagedFields: []UnmanagedField{UnmanagedFieldMixedInstancesPolicy, UnmanagedFieldDesiredCapacity},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail if an unmanaged field is not supported",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					UnmanagedFields: []UnmanagedField{"launchTemplate"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if all fields are unmanaged",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					UnmanagedFields: UnmanagedFields,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should pass with a node join lifecycle hook",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					LifecycleHooks: []AWSLifecycleHook{{
+						Name:             "node-join",
+						Role:             LifecycleHookRoleNodeJoin,
+						HeartbeatTimeout: &metav1.Duration{Duration: 15 * time.Minute},
+					}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should pass with a termination lifecycle hook notifying a queue",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					LifecycleHooks: []AWSLifecycleHook{{
+						Name:                  "drain",
+						LifecycleTransition:   LifecycleTransitionTerminating,
+						DefaultResult:         LifecycleActionResultContinue,
+						NotificationTargetARN: "arn:aws:sqs:us-east-1:123456789012:drain",
+						RoleARN:               "arn:aws:iam::123456789012:role/asg-notifications",
+					}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail if a lifecycle hook notification target has no role",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					LifecycleHooks: []AWSLifecycleHook{{
+						Name:                  "drain",
+						LifecycleTransition:   LifecycleTransitionTerminating,
+						NotificationTargetARN: "arn:aws:sqs:us-east-1:123456789012:drain",
+					}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if a node join lifecycle hook isn't a launch hook",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					LifecycleHooks: []AWSLifecycleHook{{
+						Name:                "node-join",
+						Role:                LifecycleHookRoleNodeJoin,
+						LifecycleTransition: LifecycleTransitionTerminating,
+					}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should pass with node labels and taints the kubelet can set",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					NodeLabels: map[string]string{
+						"team":                           "ml",
+						"node.kubernetes.io/pool":        "gpu",
+						"topology.kubernetes.io/zone":    "us-east-1a",
+						"example.com/accelerator-vendor": "nvidia",
+					},
+					NodeTaints: Taints{{Key: "nvidia.com/gpu", Value: "true", Effect: TaintEffectNoSchedule}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail if a node label is in a namespace restricted for the kubelet",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					NodeLabels: map[string]string{"node-role.kubernetes.io/worker": ""},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if a node taint key is invalid",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					NodeTaints: Taints{{Key: "gpu vendor", Value: "nvidia", Effect: TaintEffectNoSchedule}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if a node taint effect is invalid",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					NodeTaints: Taints{{Key: "gpu", Value: "true", Effect: "NoSchedule"}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if lifecycle hook names are duplicated",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					LifecycleHooks: []AWSLifecycleHook{
+						{Name: "node-join", Role: LifecycleHookRoleNodeJoin},
+						{Name: "node-join", Role: LifecycleHookRoleNodeJoin},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if a lifecycle hook uses the node termination handler hook name",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					LifecycleHooks: []AWSLifecycleHook{{Name: "capa-node-termination-handler", Role: LifecycleHookRoleNodeJoin}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if a lifecycle hook heartbeat timeout is too long",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					LifecycleHooks: []AWSLifecycleHook{{
+						Name:             "node-join",
+						Role:             LifecycleHookRoleNodeJoin,
+						HeartbeatTimeout: &metav1.Duration{Duration: 3 * time.Hour},
+					}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should pass with a predictive scaling policy",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					ScalingPolicies: []ScalingPolicy{{
+						Name: "predictive",
+						PredictiveScaling: &PredictiveScalingConfiguration{
+							MetricSpecifications: []PredictiveScalingMetricSpecification{{
+								TargetValue:          40,
+								PredefinedMetricPair: PredefinedMetricPair{PredefinedMetricType: PredefinedMetricPairTypeASGCPUUtilization},
+							}},
+							Mode:                 PredictiveScalingModeForecastAndScale,
+							SchedulingBufferTime: &metav1.Duration{Duration: 5 * time.Minute},
+						},
+					}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail if a scaling policy doesn't set a kind of policy",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					ScalingPolicies: []ScalingPolicy{{Name: "empty"}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if the metric pair of a predictive scaling policy is not supported",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					ScalingPolicies: []ScalingPolicy{{
+						Name: "predictive",
+						PredictiveScaling: &PredictiveScalingConfiguration{
+							MetricSpecifications: []PredictiveScalingMetricSpecification{{
+								TargetValue:          40,
+								PredefinedMetricPair: PredefinedMetricPair{PredefinedMetricType: "SQSQueueDepth"},
+							}},
+						},
+					}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if an ALBRequestCount predictive scaling policy doesn't set the target group",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					ScalingPolicies: []ScalingPolicy{{
+						Name: "predictive",
+						PredictiveScaling: &PredictiveScalingConfiguration{
+							MetricSpecifications: []PredictiveScalingMetricSpecification{{
+								TargetValue:          1000,
+								PredefinedMetricPair: PredefinedMetricPair{PredefinedMetricType: PredefinedMetricPairTypeALBRequestCount},
+							}},
+						},
+					}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if the scheduling buffer time of a predictive scaling policy is too long",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					ScalingPolicies: []ScalingPolicy{{
+						Name: "predictive",
+						PredictiveScaling: &PredictiveScalingConfiguration{
+							MetricSpecifications: []PredictiveScalingMetricSpecification{{
+								TargetValue:          40,
+								PredefinedMetricPair: PredefinedMetricPair{PredefinedMetricType: PredefinedMetricPairTypeASGCPUUtilization},
+							}},
+							SchedulingBufferTime: &metav1.Duration{Duration: 2 * time.Hour},
+						},
+					}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should pass if dedicated security group rules refer to machine pools",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					DedicatedSecurityGroup: &DedicatedSecurityGroup{
+						Enabled: true,
+						IngressRules: []DedicatedSecurityGroupIngressRule{{
+							IngressRule: infrav1.IngressRule{
+								Description: "ingress",
+								Protocol:    infrav1.SecurityGroupProtocolTCP,
+								FromPort:    443,
+								ToPort:      443,
+							},
+							SourceMachinePools: []string{"ingress-pool"},
+						}},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail if dedicated security group rules combine machine pools and cidr blocks",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					DedicatedSecurityGroup: &DedicatedSecurityGroup{
+						Enabled: true,
+						IngressRules: []DedicatedSecurityGroupIngressRule{{
+							IngressRule: infrav1.IngressRule{
+								Description: "ingress",
+								Protocol:    infrav1.SecurityGroupProtocolTCP,
+								FromPort:    443,
+								ToPort:      443,
+								CidrBlocks:  []string{"10.0.0.0/16"},
+							},
+							SourceMachinePools: []string{"ingress-pool"},
+						}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should pass if an AMI with a source region is referenced by ID",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						AMI: infrav1.AMIReference{
+							ID:                aws.String("ami-source"),
+							SourceRegion:      "eu-west-1",
+							CopyEncryptionKey: "alias/copy",
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail if an AMI with a source region is not referenced by ID",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						AMI: infrav1.AMIReference{
+							SourceRegion: "eu-west-1",
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if the copy encryption key of an AMI is set without source region",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						AMI: infrav1.AMIReference{
+							ID:                aws.String("ami-source"),
+							CopyEncryptionKey: "alias/copy",
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should pass with a launch template reference",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						Ref: &LaunchTemplateReference{Name: aws.String("byo"), Version: "3"},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail if a launch template reference sets both id and name",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						Ref: &LaunchTemplateReference{ID: aws.String("lt-byo"), Name: aws.String("byo"), Version: "3"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if a launch template reference is combined with inline fields",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						InstanceType: "m5.large",
+						Ref:          &LaunchTemplateReference{ID: aws.String("lt-byo"), Version: "$Latest"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if a launch template reference is combined with root volume overrides",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						Ref: &LaunchTemplateReference{ID: aws.String("lt-byo"), Version: "3"},
+					},
+					MixedInstancesPolicy: &MixedInstancesPolicy{
+						Overrides: []Overrides{{InstanceType: "m6i.large", RootVolume: &infrav1.Volume{Size: 100}}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should accept valid instance states",
+			pool: &AWSMachinePool{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{ASGInstanceStateAnnotation: "i-1=standby,i-2=inservice"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail if an instance state is invalid",
+			pool: &AWSMachinePool{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{ASGInstanceStateAnnotation: "i-1=detached"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should accept a capacity probe",
+			pool: &AWSMachinePool{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{CapacityProbeAnnotation: "450"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail if the capacity probe isn't a positive number of instances",
+			pool: &AWSMachinePool{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{CapacityProbeAnnotation: "0"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if the spot placement scores aren't requested for a positive number of instances",
+			pool: &AWSMachinePool{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{SpotPlacementScoresAnnotation: "many"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if the launch template is rolled back on creation",
+			pool: &AWSMachinePool{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{RollbackLaunchTemplateAnnotation: "true"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should accept AZ failure handling with the default cooldown",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AZFailureHandling: &AZFailureHandling{Enabled: true},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail if the AZ failure handling cooldown is too short",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AZFailureHandling: &AZFailureHandling{Enabled: true, Cooldown: &metav1.Duration{Duration: 10 * time.Second}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should accept termination policies",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					TerminationPolicies: []string{
+						TerminationPolicyOldestLaunchTemplate,
+						"arn:aws:lambda:us-west-2:123456789012:function:pick-instances",
+						TerminationPolicyDefault,
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail if a termination policy is unknown",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					TerminationPolicies: []string{"OldestLaunchTemplates"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if a termination policy is the ARN of another resource than a Lambda function",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					TerminationPolicies: []string{"arn:aws:sns:us-west-2:123456789012:topic"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if a termination policy is listed twice",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					TerminationPolicies: []string{TerminationPolicyNewestInstance, TerminationPolicyNewestInstance},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should accept the collection of all the group metrics",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					Metrics: &MetricsCollection{},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should accept the collection of some group metrics",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					Metrics: &MetricsCollection{
+						Granularity: MetricsGranularity1Minute,
+						Metrics:     []string{"GroupInServiceInstances", "GroupDesiredCapacity"},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail if a group metric is unknown",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					Metrics: &MetricsCollection{Metrics: []string{"GroupInServiceInstance"}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if a group metric is listed twice",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					Metrics: &MetricsCollection{Metrics: []string{"GroupTotalInstances", "GroupTotalInstances"}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should accept suspending all processes but the ones set to false",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					SuspendProcesses: &SuspendProcessesTypes{
+						All:       true,
+						Processes: &Processes{HealthCheck: ptr.To[bool](false)},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail if a process is suspended individually while all processes are suspended",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					SuspendProcesses: &SuspendProcessesTypes{
+						All:       true,
+						Processes: &Processes{Launch: ptr.To[bool](true)},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should accept ELB health checks with a grace period",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					HealthCheckType:        HealthCheckTypeELB,
+					HealthCheckGracePeriod: &metav1.Duration{Duration: 5 * time.Minute},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail if the health check grace period is set without the health check type",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					HealthCheckGracePeriod: &metav1.Duration{Duration: 5 * time.Minute},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if the health check grace period is negative",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					HealthCheckType:        HealthCheckTypeEC2,
+					HealthCheckGracePeriod: &metav1.Duration{Duration: -time.Minute},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should accept a default instance warmup of zero",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					DefaultInstanceWarmup: &metav1.Duration{},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail if the default instance warmup is negative",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					DefaultInstanceWarmup: &metav1.Duration{Duration: -time.Minute},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if the default instance warmup isn't a whole number of seconds",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					DefaultInstanceWarmup: &metav1.Duration{Duration: 1500 * time.Millisecond},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should accept a max instance lifetime of 14 days",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					MaxInstanceLifetime: &metav1.Duration{Duration: 14 * 24 * time.Hour},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should accept a max instance lifetime of zero",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					MaxInstanceLifetime: &metav1.Duration{},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail if the max instance lifetime is below one day",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					MaxInstanceLifetime: &metav1.Duration{Duration: time.Hour},
 				},
 			},
 			wantErr: true,
 		},
 		{
-			name: "Should fail if the difference between MaxHealthyPercentage and MinHealthyPercentage is greater than 100",
+			name: "Should fail if the max instance lifetime is above one year",
 			pool: &AWSMachinePool{
 				Spec: AWSMachinePoolSpec{
-					RefreshPreferences: &RefreshPreferences{
-						MaxHealthyPercentage: aws.Int64(150),
-						MinHealthyPercentage: aws.Int64(25),
+					MaxInstanceLifetime: &metav1.Duration{Duration: 366 * 24 * time.Hour},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if the max instance lifetime isn't a whole number of seconds",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					MaxInstanceLifetime: &metav1.Duration{Duration: 24*time.Hour + 1500*time.Millisecond},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should accept a warm pool",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					MinSize:  1,
+					WarmPool: &WarmPool{MinSize: 2, MaxGroupPreparedCapacity: ptr.To[int32](4), PoolState: WarmPoolStateRunning},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail if a warm pool is used with a mixed instances policy",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					MixedInstancesPolicy: &MixedInstancesPolicy{},
+					WarmPool:             &WarmPool{MinSize: 2},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if the warm pool max group prepared capacity is lower than the min size",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					MinSize:  3,
+					WarmPool: &WarmPool{MaxGroupPreparedCapacity: ptr.To[int32](2)},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should accept a capacity block with a supported instance type",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						InstanceType:          "trn1.32xlarge",
+						MarketType:            infrav1.MarketTypeCapacityBlock,
+						CapacityReservationID: aws.String("cr-0123456789abcdef0"),
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail if a capacity block override has an unsupported instance type",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						InstanceType:          "p5.48xlarge",
+						MarketType:            infrav1.MarketTypeCapacityBlock,
+						CapacityReservationID: aws.String("cr-0123456789abcdef0"),
+					},
+					MixedInstancesPolicy: &MixedInstancesPolicy{
+						Overrides: []Overrides{{InstanceType: "p5.48xlarge"}, {InstanceType: "c5.large"}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if a capacity block is combined with a mixed instances policy",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						InstanceType:          "p5.48xlarge",
+						MarketType:            infrav1.MarketTypeCapacityBlock,
+						CapacityReservationID: aws.String("cr-0123456789abcdef0"),
+					},
+					MixedInstancesPolicy: &MixedInstancesPolicy{
+						Overrides: []Overrides{{InstanceType: "p5.48xlarge"}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if the spot market type is combined with a mixed instances policy",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						MarketType: infrav1.MarketTypeSpot,
+					},
+					MixedInstancesPolicy: &MixedInstancesPolicy{
+						Overrides: []Overrides{{InstanceType: "m5.large"}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should accept a capacity reservation resource group",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						CapacityReservationResourceGroupARN: aws.String("arn:aws:resource-groups:us-east-1:123456789012:group/reservations"),
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail if both a capacity reservation and a capacity reservation resource group are set",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						CapacityReservationID:               aws.String("cr-0123456789abcdef0"),
+						CapacityReservationResourceGroupARN: aws.String("arn:aws:resource-groups:us-east-1:123456789012:group/reservations"),
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if the capacity reservation resource group isn't a resource group ARN",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						CapacityReservationResourceGroupARN: aws.String("arn:aws:ec2:us-east-1:123456789012:capacity-reservation/cr-0123456789abcdef0"),
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should accept a partition of a placement group",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						PlacementGroupName:      "hpc",
+						PlacementGroupPartition: 2,
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail if a placement group partition is set without a placement group",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						PlacementGroupPartition: 2,
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should accept Nitro Enclaves on a supported instance type",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						InstanceType:   "m5.xlarge",
+						EnclaveOptions: &EnclaveOptions{Enabled: true},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail if Nitro Enclaves are enabled on a burstable instance type",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						InstanceType:   "t3.xlarge",
+						EnclaveOptions: &EnclaveOptions{Enabled: true},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if Nitro Enclaves are enabled on an override instance type with too few vCPUs",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						EnclaveOptions: &EnclaveOptions{Enabled: true},
+					},
+					MixedInstancesPolicy: &MixedInstancesPolicy{
+						Overrides: []Overrides{{InstanceType: "m6g.medium"}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if Nitro Enclaves are enabled with a hibernated warm pool",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						InstanceType:   "m5.xlarge",
+						EnclaveOptions: &EnclaveOptions{Enabled: true},
+					},
+					WarmPool: &WarmPool{PoolState: WarmPoolStateHibernated},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should accept an efa network interface in a placement group",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						PlacementGroupName: "hpc",
+						NetworkInterfaces: []LaunchTemplateNetworkInterface{
+							{DeviceIndex: 0, InterfaceType: NetworkInterfaceTypeEFA},
+							{DeviceIndex: 1, SubnetID: "subnet-1", SecurityGroups: []infrav1.AWSResourceReference{{ID: aws.String("sg-1")}}},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail if an efa network interface isn't in a placement group",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						NetworkInterfaces: []LaunchTemplateNetworkInterface{
+							{DeviceIndex: 1, InterfaceType: NetworkInterfaceTypeEFA},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if the primary network interface sets security groups",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						NetworkInterfaces: []LaunchTemplateNetworkInterface{
+							{DeviceIndex: 0, SecurityGroups: []infrav1.AWSResourceReference{{ID: aws.String("sg-1")}}},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if the primary network interface sets a subnet",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						NetworkInterfaces: []LaunchTemplateNetworkInterface{
+							{DeviceIndex: 0, SubnetID: "subnet-1"},
+						},
 					},
 				},
 			},
@@ -198,6 +1373,29 @@ func TestAWSMachinePoolValidateUpdate(t *testing.T) {
 		old     *AWSMachinePool
 		wantErr bool
 	}{
+		{
+			name: "rolling back the launch template to the previous version is accepted",
+			old: &AWSMachinePool{
+				Status: AWSMachinePoolStatus{PreviousLaunchTemplateVersion: ptr.To[string]("4")},
+			},
+			new: &AWSMachinePool{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{RollbackLaunchTemplateAnnotation: "true"},
+				},
+				Status: AWSMachinePoolStatus{PreviousLaunchTemplateVersion: ptr.To[string]("4")},
+			},
+			wantErr: false,
+		},
+		{
+			name: "rolling back the launch template without a previous version is rejected",
+			old:  &AWSMachinePool{},
+			new: &AWSMachinePool{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{RollbackLaunchTemplateAnnotation: "true"},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "adding tags is accepted",
 			old: &AWSMachinePool{
@@ -308,6 +1506,48 @@ func TestAWSMachinePoolValidateUpdate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Should fail if the inline launch template is replaced with a reference",
+			old:  &AWSMachinePool{},
+			new: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						Ref: &LaunchTemplateReference{ID: aws.String("lt-byo"), Version: "3"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if the referenced launch template is replaced with an inline one",
+			old: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						Ref: &LaunchTemplateReference{ID: aws.String("lt-byo"), Version: "3"},
+					},
+				},
+			},
+			new:     &AWSMachinePool{},
+			wantErr: true,
+		},
+		{
+			name: "Should pass if the version of the referenced launch template changes",
+			old: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						Ref: &LaunchTemplateReference{ID: aws.String("lt-byo"), Version: "3"},
+					},
+				},
+			},
+			new: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						Ref: &LaunchTemplateReference{ID: aws.String("lt-byo"), Version: "4"},
+					},
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "Should fail if MaxHealthyPercentage is set, but MinHealthyPercentage is not set",
 			new: &AWSMachinePool{
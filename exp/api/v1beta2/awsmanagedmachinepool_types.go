@@ -100,6 +100,11 @@ type AWSManagedMachinePoolSpec struct {
 	// +optional
 	RoleName string `json:"roleName,omitempty"`
 
+	// RoleManagement configures the node group role when it is created by CAPA. Roles which
+	// are not created by CAPA are left untouched.
+	// +optional
+	RoleManagement *infrav1.IAMRoleManagement `json:"roleManagement,omitempty"`
+
 	// AMIVersion defines the desired AMI release version. If no version number
 	// is supplied then the latest version for the Kubernetes version
 	// will be used
@@ -159,6 +164,32 @@ type AWSManagedMachinePoolSpec struct {
 	// are prohibited (https://docs.aws.amazon.com/eks/latest/userguide/launch-templates.html).
 	// +optional
 	AWSLaunchTemplate *AWSLaunchTemplate `json:"awsLaunchTemplate,omitempty"`
+
+	// DedicatedSecurityGroup configures a security group owned by the machine pool and attached to its instances.
+	// It requires AWSLaunchTemplate to be set.
+	// +optional
+	DedicatedSecurityGroup *DedicatedSecurityGroup `json:"dedicatedSecurityGroup,omitempty"`
+
+	// DeletePolicy configures how the nodes of the node group are drained before it is deleted.
+	// When unset, the node group is deleted right away and EKS drains its nodes.
+	// +optional
+	DeletePolicy *ManagedMachinePoolDeletePolicy `json:"deletePolicy,omitempty"`
+}
+
+// ManagedMachinePoolDeletePolicy configures the deletion of a node group.
+type ManagedMachinePoolDeletePolicy struct {
+	// DrainTimeout is how long the nodes of the node group are cordoned and drained, respecting the
+	// pod disruption budgets of their pods, before the node group is deleted. It is counted from the
+	// deletion of the AWSManagedMachinePool. The drain is skipped when the AWSManagedMachinePool has
+	// the machine.cluster.x-k8s.io/exclude-node-draining annotation, e.g. when the workload cluster
+	// API is unreachable.
+	DrainTimeout metav1.Duration `json:"drainTimeout"`
+
+	// Force deletes the pods which couldn't be evicted within DrainTimeout, bypassing their pod
+	// disruption budgets, before the node group is deleted. Otherwise the node group is deleted
+	// with these pods, which EKS evicts with its own timeout.
+	// +optional
+	Force bool `json:"force,omitempty"`
 }
 
 // ManagedMachinePoolScaling specifies scaling options.
@@ -199,6 +230,30 @@ type AWSManagedMachinePoolStatus struct {
 	// +optional
 	LaunchTemplateVersion *string `json:"launchTemplateVersion,omitempty"`
 
+	// AdditionalSecurityGroupIDs is the list of security group IDs the additional security groups of the
+	// launch template were last resolved to. The filters matching no group are reported by the
+	// AdditionalSecurityGroupsReady condition.
+	// +optional
+	AdditionalSecurityGroupIDs []string `json:"additionalSecurityGroupIDs,omitempty"`
+
+	// DedicatedSecurityGroupID is the ID of the security group owned by the machine pool.
+	// +optional
+	DedicatedSecurityGroupID string `json:"dedicatedSecurityGroupID,omitempty"`
+
+	// CopiedAMI is the copy of the AMI of the launch template made from spec.awsLaunchTemplate.ami.sourceRegion.
+	// +optional
+	CopiedAMI *CopiedAMI `json:"copiedAMI,omitempty"`
+
+	// ActiveUpdate is the last update of the configuration of the node group started by the controller. It is
+	// kept until the update succeeds, and polled on every reconcile while it is in progress.
+	// +optional
+	ActiveUpdate *NodegroupUpdate `json:"activeUpdate,omitempty"`
+
+	// InfrastructureMachineKind is the kind of the infrastructure resources behind MachinePool Machines, set when
+	// the MachinePoolMachines feature gate is enabled.
+	// +optional
+	InfrastructureMachineKind string `json:"infrastructureMachineKind,omitempty"`
+
 	// FailureReason will be set in the event that there is a terminal problem
 	// reconciling the MachinePool and will contain a succinct value suitable
 	// for machine interpretation.
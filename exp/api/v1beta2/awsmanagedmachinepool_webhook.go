@@ -121,9 +121,25 @@ func (r *AWSManagedMachinePool) validateRemoteAccess() field.ErrorList {
 func (r *AWSManagedMachinePool) validateLaunchTemplate() field.ErrorList {
 	var allErrs field.ErrorList
 	if r.Spec.AWSLaunchTemplate == nil {
+		if r.Spec.DedicatedSecurityGroup != nil && r.Spec.DedicatedSecurityGroup.Enabled {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "dedicatedSecurityGroup"), "dedicatedSecurityGroup can only be enabled when awsLaunchTemplate is specified"))
+		}
 		return allErrs
 	}
 
+	if r.Spec.AWSLaunchTemplate.Ref != nil {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "awsLaunchTemplate", "ref"), "referencing a launch template is only supported by AWSMachinePool"))
+	}
+	if len(r.Spec.AWSLaunchTemplate.PerOverrideUserData) > 0 {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "awsLaunchTemplate", "perOverrideUserData"), "user data per instance type override is only supported by AWSMachinePool"))
+	}
+	if r.Spec.AWSLaunchTemplate.OSFamily != "" {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "awsLaunchTemplate", "osFamily"), "osFamily is only supported by AWSMachinePool"))
+	}
+	if r.Spec.AWSLaunchTemplate.Bottlerocket != nil {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "awsLaunchTemplate", "bottlerocket"), "bottlerocket is only supported by AWSMachinePool"))
+	}
+
 	if r.Spec.InstanceType != nil {
 		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "InstanceType"), r.Spec.InstanceType, "InstanceType cannot be specified when LaunchTemplate is specified"))
 	}
@@ -135,6 +151,31 @@ func (r *AWSManagedMachinePool) validateLaunchTemplate() field.ErrorList {
 		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "AWSLaunchTemplate", "IamInstanceProfile"), r.Spec.AWSLaunchTemplate.IamInstanceProfile, "IAM instance profile in launch template is prohibited in EKS managed node group"))
 	}
 
+	for _, sg := range r.Spec.AWSLaunchTemplate.AdditionalSecurityGroups {
+		if sg.ID != nil && sg.Filters != nil {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "AWSLaunchTemplate", "AdditionalSecurityGroups"), "either ID or filters should be used"))
+		}
+	}
+
+	allErrs = append(allErrs, validateAMISourceRegion(r.Spec.AWSLaunchTemplate.AMI, field.NewPath("spec", "awsLaunchTemplate", "ami"))...)
+	allErrs = append(allErrs, validateLaunchTemplateMarketType(r.Spec.AWSLaunchTemplate, field.NewPath("spec", "awsLaunchTemplate"))...)
+	allErrs = append(allErrs, validateLaunchTemplatePlacement(r.Spec.AWSLaunchTemplate, field.NewPath("spec", "awsLaunchTemplate"))...)
+	allErrs = append(allErrs, validateLaunchTemplateEnclave(r.Spec.AWSLaunchTemplate, field.NewPath("spec", "awsLaunchTemplate"))...)
+	allErrs = append(allErrs, validateLaunchTemplateNetworkInterfaces(r.Spec.AWSLaunchTemplate, field.NewPath("spec", "awsLaunchTemplate"))...)
+	allErrs = append(allErrs, validateDedicatedSecurityGroup(r.Spec.DedicatedSecurityGroup, field.NewPath("spec", "dedicatedSecurityGroup"))...)
+
+	return allErrs
+}
+
+func (r *AWSManagedMachinePool) validateDeletePolicy() field.ErrorList {
+	var allErrs field.ErrorList
+	if r.Spec.DeletePolicy == nil {
+		return allErrs
+	}
+
+	if r.Spec.DeletePolicy.DrainTimeout.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "deletePolicy", "drainTimeout"), r.Spec.DeletePolicy.DrainTimeout.Duration.String(), "drainTimeout must be positive"))
+	}
 	return allErrs
 }
 
@@ -159,8 +200,10 @@ func (r *AWSManagedMachinePool) ValidateCreate() (admission.Warnings, error) {
 	if errs := r.validateLaunchTemplate(); len(errs) > 0 {
 		allErrs = append(allErrs, errs...)
 	}
+	allErrs = append(allErrs, r.validateDeletePolicy()...)
 
 	allErrs = append(allErrs, r.Spec.AdditionalTags.Validate()...)
+	allErrs = append(allErrs, r.Spec.RoleManagement.Validate(field.NewPath("spec", "roleManagement"))...)
 
 	if len(allErrs) == 0 {
 		return nil, nil
@@ -186,6 +229,7 @@ func (r *AWSManagedMachinePool) ValidateUpdate(old runtime.Object) (admission.Wa
 	var allErrs field.ErrorList
 	allErrs = append(allErrs, r.validateImmutable(oldPool)...)
 	allErrs = append(allErrs, r.Spec.AdditionalTags.Validate()...)
+	allErrs = append(allErrs, r.Spec.RoleManagement.Validate(field.NewPath("spec", "roleManagement"))...)
 
 	if errs := r.validateScaling(); errs != nil || len(errs) == 0 {
 		allErrs = append(allErrs, errs...)
@@ -196,6 +240,7 @@ func (r *AWSManagedMachinePool) ValidateUpdate(old runtime.Object) (admission.Wa
 	if errs := r.validateLaunchTemplate(); len(errs) > 0 {
 		allErrs = append(allErrs, errs...)
 	}
+	allErrs = append(allErrs, r.validateDeletePolicy()...)
 
 	if len(allErrs) == 0 {
 		return nil, nil
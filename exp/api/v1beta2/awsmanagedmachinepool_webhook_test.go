@@ -19,6 +19,7 @@ package v1beta2
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	. "github.com/onsi/gomega"
@@ -150,6 +151,147 @@ func TestAWSManagedMachinePoolValidateCreate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "additional security groups with filters are accepted",
+			pool: &AWSManagedMachinePool{
+				Spec: AWSManagedMachinePoolSpec{
+					EKSNodegroupName: "eks-node-group-3",
+					AWSLaunchTemplate: &AWSLaunchTemplate{
+						AdditionalSecurityGroups: []infrav1.AWSResourceReference{{
+							Filters: []infrav1.Filter{{Name: "tag:team", Values: []string{"payments"}}},
+						}},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "additional security groups with both ID and filters are rejected",
+			pool: &AWSManagedMachinePool{
+				Spec: AWSManagedMachinePoolSpec{
+					EKSNodegroupName: "eks-node-group-3",
+					AWSLaunchTemplate: &AWSLaunchTemplate{
+						AdditionalSecurityGroups: []infrav1.AWSResourceReference{{
+							ID:      aws.String("sg-1"),
+							Filters: []infrav1.Filter{{Name: "tag:team", Values: []string{"payments"}}},
+						}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "launch template reference is rejected",
+			pool: &AWSManagedMachinePool{
+				Spec: AWSManagedMachinePoolSpec{
+					EKSNodegroupName: "eks-node-group-3",
+					AWSLaunchTemplate: &AWSLaunchTemplate{
+						Ref: &LaunchTemplateReference{Name: aws.String("byo"), Version: "3"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "user data per instance type override is rejected",
+			pool: &AWSManagedMachinePool{
+				Spec: AWSManagedMachinePoolSpec{
+					EKSNodegroupName: "eks-node-group-3",
+					AWSLaunchTemplate: &AWSLaunchTemplate{
+						PerOverrideUserData: []OverrideUserData{{InstanceType: "m6i.large", DataSecretName: "m6i-bootstrap-data"}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "bottlerocket OS family is rejected",
+			pool: &AWSManagedMachinePool{
+				Spec: AWSManagedMachinePoolSpec{
+					EKSNodegroupName: "eks-node-group-3",
+					AWSLaunchTemplate: &AWSLaunchTemplate{
+						OSFamily: OSFamilyBottlerocket,
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "dedicated security group with a launch template is accepted",
+			pool: &AWSManagedMachinePool{
+				Spec: AWSManagedMachinePoolSpec{
+					EKSNodegroupName:       "eks-node-group-3",
+					AWSLaunchTemplate:      &AWSLaunchTemplate{},
+					DedicatedSecurityGroup: &DedicatedSecurityGroup{Enabled: true},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "dedicated security group without a launch template is rejected",
+			pool: &AWSManagedMachinePool{
+				Spec: AWSManagedMachinePoolSpec{
+					EKSNodegroupName:       "eks-node-group-3",
+					DedicatedSecurityGroup: &DedicatedSecurityGroup{Enabled: true},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "role management with additional trust statements is accepted",
+			pool: &AWSManagedMachinePool{
+				Spec: AWSManagedMachinePoolSpec{
+					EKSNodegroupName: "eks-node-group-3",
+					RoleManagement: &infrav1.IAMRoleManagement{
+						AdditionalTrustStatements: []infrav1.IAMTrustStatement{{
+							PrincipalType: "AWS",
+							Principals:    []string{"arn:aws:iam::123456789012:role/break-glass"},
+							Actions:       []string{"sts:AssumeRole", "sts:TagSession"},
+						}},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "role management with non sts trust actions is rejected",
+			pool: &AWSManagedMachinePool{
+				Spec: AWSManagedMachinePoolSpec{
+					EKSNodegroupName: "eks-node-group-3",
+					RoleManagement: &infrav1.IAMRoleManagement{
+						AdditionalTrustStatements: []infrav1.IAMTrustStatement{{
+							PrincipalType: "AWS",
+							Principals:    []string{"arn:aws:iam::123456789012:role/break-glass"},
+							Actions:       []string{"iam:PassRole"},
+						}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "delete policy with a drain timeout is accepted",
+			pool: &AWSManagedMachinePool{
+				Spec: AWSManagedMachinePoolSpec{
+					EKSNodegroupName: "eks-node-group-3",
+					DeletePolicy: &ManagedMachinePoolDeletePolicy{
+						DrainTimeout: metav1.Duration{Duration: 10 * time.Minute},
+						Force:        true,
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "delete policy without drain timeout is rejected",
+			pool: &AWSManagedMachinePool{
+				Spec: AWSManagedMachinePoolSpec{
+					EKSNodegroupName: "eks-node-group-3",
+					DeletePolicy:     &ManagedMachinePoolDeletePolicy{},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
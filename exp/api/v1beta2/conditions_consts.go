@@ -36,6 +36,14 @@ const (
 	LaunchTemplateCreateFailedReason = "LaunchTemplateCreateFailed"
 	// LaunchTemplateReconcileFailedReason used for failures during Launch Template reconciliation.
 	LaunchTemplateReconcileFailedReason = "LaunchTemplateReconcileFailed"
+	// LaunchTemplateVersionLimitExceededReason used when the launch template version quota is exceeded and no
+	// version of the launch template can be pruned to make room for a new one.
+	LaunchTemplateVersionLimitExceededReason = "LaunchTemplateVersionLimitExceeded"
+	// AMICopyInProgressReason used while the AMI of the launch template is copied from its source region.
+	AMICopyInProgressReason = "AMICopyInProgress"
+	// AMICopyFailedReason used when the copy of the AMI of the launch template from its source region failed.
+	// The copy isn't retried until the AMI of the spec changes.
+	AMICopyFailedReason = "AMICopyFailed"
 
 	// PreLaunchTemplateUpdateCheckCondition reports if all prerequisite are met for launch template update.
 	PreLaunchTemplateUpdateCheckCondition clusterv1.ConditionType = "PreLaunchTemplateUpdateCheckSuccess"
@@ -52,8 +60,89 @@ const (
 	// InstanceRefreshNotReadyReason used to report instance refresh is not initiated.
 	// If there are instance refreshes that are in progress, then a new instance refresh request will fail.
 	InstanceRefreshNotReadyReason = "InstanceRefreshNotReady"
-	// InstanceRefreshFailedReason used to report when there instance refresh is not initiated.
+	// InstanceRefreshFailedReason used to report when there instance refresh is not initiated, or when the latest
+	// instance refresh of the ASG failed.
 	InstanceRefreshFailedReason = "InstanceRefreshFailed"
+
+	// AdditionalSecurityGroupsReadyCondition reports on the resolution of the additional security groups of a launch template.
+	AdditionalSecurityGroupsReadyCondition clusterv1.ConditionType = "AdditionalSecurityGroupsReady"
+	// AdditionalSecurityGroupsResolutionFailedReason used when the additional security groups could not be resolved.
+	AdditionalSecurityGroupsResolutionFailedReason = "AdditionalSecurityGroupsResolutionFailed"
+	// AdditionalSecurityGroupsNotFoundReason used when a filter of the additional security groups matched no security group.
+	AdditionalSecurityGroupsNotFoundReason = "AdditionalSecurityGroupsNotFound"
+
+	// LaunchTemplateValidationFailedCondition reports that the latest launch template version failed the dry run
+	// enabled by spec.awsLaunchTemplate.validateBeforeUse. The previous version is kept in use while it is set.
+	LaunchTemplateValidationFailedCondition clusterv1.ConditionType = "LaunchTemplateValidationFailed"
+	// LaunchTemplateDryRunFailedReason used when RunInstances rejected a dry run with the new launch template version.
+	LaunchTemplateDryRunFailedReason = "LaunchTemplateDryRunFailed"
+
+	// LifecycleHooksReadyCondition reports on the reconciliation of the lifecycle hooks of the ASG.
+	LifecycleHooksReadyCondition clusterv1.ConditionType = "LifecycleHooksReady"
+	// LifecycleHooksReconciliationFailedReason used when the lifecycle hooks of the ASG could not be reconciled.
+	LifecycleHooksReconciliationFailedReason = "LifecycleHooksReconciliationFailed"
+
+	// WarmPoolReadyCondition reports on the reconciliation of the warm pool of the ASG.
+	WarmPoolReadyCondition clusterv1.ConditionType = "WarmPoolReady"
+	// WarmPoolReconciliationFailedReason used when the warm pool of the ASG could not be reconciled.
+	WarmPoolReconciliationFailedReason = "WarmPoolReconciliationFailed"
+
+	// ASGSuspendedProcessesCondition is set while processes of the ASG are suspended, whether by the spec or by other
+	// tooling. The message lists the suspended processes. It is removed once no process is suspended.
+	ASGSuspendedProcessesCondition clusterv1.ConditionType = "ASGSuspendedProcesses"
+	// SuspendedProcessesPresentReason used when processes of the ASG are suspended.
+	SuspendedProcessesPresentReason = "SuspendedProcessesPresent"
+
+	// InstanceRefreshInProgressCondition reports on the latest instance refresh of the ASG. It is true while the
+	// instance refresh runs, and false with the outcome of the instance refresh once it ended.
+	InstanceRefreshInProgressCondition clusterv1.ConditionType = "InstanceRefreshInProgress"
+	// InstanceRefreshSuccessfulReason used when the latest instance refresh of the ASG succeeded.
+	InstanceRefreshSuccessfulReason = "InstanceRefreshSuccessful"
+	// InstanceRefreshCancelledReason used when the latest instance refresh of the ASG was cancelled or rolled back.
+	InstanceRefreshCancelledReason = "InstanceRefreshCancelled"
+
+	// ASGExternallyManagedCondition is set while the AWSMachinePool is annotated with cluster.x-k8s.io/managed-by.
+	// Its ASG and launch template are then managed by other tooling: CAPA reports their state, but never creates,
+	// updates or deletes them. It is removed once the annotation is removed.
+	ASGExternallyManagedCondition clusterv1.ConditionType = "ASGExternallyManaged"
+	// ExternallyManagedReason used when the ASG of the AWSMachinePool is managed by other tooling.
+	ExternallyManagedReason = "ExternallyManaged"
+
+	// ASGAdoptionPendingCondition is set while an ASG named after the AWSMachinePool exists without the ownership
+	// tags of the cluster, e.g. as it was created by other tooling. CAPA leaves it untouched until the AWSMachinePool
+	// is annotated with aws.cluster.x-k8s.io/adopt-asg. It is removed once the ASG is adopted.
+	ASGAdoptionPendingCondition clusterv1.ConditionType = "ASGAdoptionPending"
+	// ASGNotOwnedReason used when the ASG of the AWSMachinePool isn't tagged as owned by the cluster.
+	ASGNotOwnedReason = "ASGNotOwned"
+
+	// CapacityBlockOutsideWindowCondition is set while the current time is outside of the window of the capacity
+	// block the AWSMachinePool launches in. Instance refreshes are deferred until the window starts, as the new
+	// instances couldn't be launched.
+	CapacityBlockOutsideWindowCondition clusterv1.ConditionType = "CapacityBlockOutsideWindow"
+	// CapacityBlockOutsideWindowReason used when the current time is outside of the window of the capacity block.
+	CapacityBlockOutsideWindowReason = "OutsideWindow"
+
+	// ASGStructureDriftedCondition is set while the ASG uses a launch template where the spec sets a mixed instances
+	// policy, or the other way around, after it was switched outside of CAPA. The message tells what CAPA replaces.
+	// It is removed once the ASG is back to the structure of the spec.
+	ASGStructureDriftedCondition clusterv1.ConditionType = "ASGStructureDrifted"
+	// LaunchTemplateStructureDriftedReason used when the ASG was switched between a launch template and a mixed
+	// instances policy outside of CAPA.
+	LaunchTemplateStructureDriftedReason = "LaunchTemplateStructureDrifted"
+
+	// AMILacksGPUDriversCondition is set while the launch template launches instance types with NVIDIA GPUs from an
+	// AMI known to lack the GPU drivers, the nodes joining the cluster without their GPUs. The message lists the
+	// instance types and why the AMI is known to lack the drivers. It is removed once the AMI ships them.
+	AMILacksGPUDriversCondition clusterv1.ConditionType = "AMILacksGPUDrivers"
+	// GPUDriversMissingReason used when the AMI of instance types with NVIDIA GPUs lacks the GPU drivers.
+	GPUDriversMissingReason = "GPUDriversMissing"
+
+	// SpotPlacementScoresUnavailableCondition is set when the spot placement scores requested by the
+	// SpotPlacementScoresAnnotation couldn't be fetched because the API isn't available in the partition of the
+	// region. It is removed once scores are fetched.
+	SpotPlacementScoresUnavailableCondition clusterv1.ConditionType = "SpotPlacementScoresUnavailable"
+	// SpotPlacementScoresUnsupportedReason used when the spot placement scores API isn't supported in the region.
+	SpotPlacementScoresUnsupportedReason = "SpotPlacementScoresUnsupported"
 )
 
 const (
@@ -64,6 +153,26 @@ const (
 	// WaitingForEKSControlPlaneReason used when the machine pool is waiting for
 	// EKS control plane infrastructure to be ready before proceeding.
 	WaitingForEKSControlPlaneReason = "WaitingForEKSControlPlane"
+	// EKSNodegroupDrainedCondition reports on the drain of the nodes of the node group before it is deleted.
+	EKSNodegroupDrainedCondition clusterv1.ConditionType = "EKSNodegroupDrained"
+	// EKSNodegroupDrainingReason used while the nodes of the node group are drained.
+	EKSNodegroupDrainingReason = "Draining"
+	// EKSNodegroupDrainTimeoutReason used when the nodes of the node group couldn't be drained within the drain timeout.
+	EKSNodegroupDrainTimeoutReason = "DrainTimeout"
+	// EKSNodegroupDrainSkippedReason used when the drain of the nodes of the node group is skipped.
+	EKSNodegroupDrainSkippedReason = "DrainSkipped"
+	// NodegroupDegradedCondition is set while the EKS node group is in the DEGRADED status. The message lists the
+	// health issues of the node group. It is removed once the node group is no longer degraded.
+	NodegroupDegradedCondition clusterv1.ConditionType = "NodegroupDegraded"
+	// NodegroupHealthIssuesReason used when the EKS node group reports health issues.
+	NodegroupHealthIssuesReason = "NodegroupHealthIssues"
+	// NodegroupUpdateInProgressCondition is set while an update of the configuration of the EKS node group started by
+	// the controller is in progress, and when it failed. The message of a failed update lists the errors reported by EKS.
+	NodegroupUpdateInProgressCondition clusterv1.ConditionType = "NodegroupUpdateInProgress"
+	// NodegroupUpdatingReason used while the EKS node group update is in progress.
+	NodegroupUpdatingReason = "NodegroupUpdating"
+	// NodegroupUpdateFailedReason used when the EKS node group update failed or was cancelled.
+	NodegroupUpdateFailedReason = "NodegroupUpdateFailed"
 )
 
 const (
@@ -54,6 +54,22 @@ type BlockDeviceMapping struct {
 	Ebs EBS `json:"ebs,omitempty"`
 }
 
+// AMIType is the variant of the image looked up for a launch template.
+type AMIType string
+
+const (
+	// AMITypeGPU looks up the variant of the image which ships the NVIDIA drivers.
+	AMITypeGPU AMIType = "gpu"
+)
+
+// OSFamily is the family of the operating system of the instances of a launch template.
+type OSFamily string
+
+const (
+	// OSFamilyBottlerocket is Bottlerocket, which is configured with TOML settings instead of cloud-init or Ignition.
+	OSFamilyBottlerocket OSFamily = "bottlerocket"
+)
+
 // AWSLaunchTemplate defines the desired state of AWSLaunchTemplate.
 type AWSLaunchTemplate struct {
 	// The name of the launch template.
@@ -89,6 +105,26 @@ type AWSLaunchTemplate struct {
 	// image lookup the AMI is not set.
 	ImageLookupBaseOS string `json:"imageLookupBaseOS,omitempty"`
 
+	// AMIType is the variant of the image looked up when the AMI is not set. With gpu, the EKS optimized AMI with
+	// the NVIDIA drivers is looked up for EKS, and the image of the ImageLookupBaseOS suffixed with -gpu
+	// otherwise, e.g. capa-ami-ubuntu-24.04-gpu-?1.30.0-*.
+	// +kubebuilder:validation:Enum=gpu
+	// +optional
+	AMIType AMIType `json:"amiType,omitempty"`
+
+	// OSFamily is the family of the operating system of the instances. With bottlerocket, the bootstrap data
+	// must be Bottlerocket TOML settings, which the settings of Bottlerocket and the node labels and taints of the
+	// pool are merged into, and the latest Bottlerocket AMI for the Kubernetes version is looked up when neither the
+	// AMI nor an image lookup field is set. Only supported by AWSMachinePool.
+	// +kubebuilder:validation:Enum=bottlerocket
+	// +optional
+	OSFamily OSFamily `json:"osFamily,omitempty"`
+
+	// Bottlerocket are settings merged into the user data of Bottlerocket instances. Requires the bottlerocket
+	// osFamily.
+	// +optional
+	Bottlerocket *BottlerocketSettings `json:"bottlerocket,omitempty"`
+
 	// InstanceType is the type of instance to create. Example: m4.xlarge
 	InstanceType string `json:"instanceType,omitempty"`
 
@@ -121,6 +157,21 @@ type AWSLaunchTemplate struct {
 	// SpotMarketOptions are options for configuring AWSMachinePool instances to be run using AWS Spot instances.
 	SpotMarketOptions *infrav1.SpotMarketOptions `json:"spotMarketOptions,omitempty"`
 
+	// MarketType is the purchasing option of the instances. capacity-block launches the instances in the
+	// Capacity Block for ML set with capacityReservationId, and forbids spotMarketOptions.
+	// +kubebuilder:validation:Enum:=on-demand;spot;capacity-block
+	// +optional
+	MarketType infrav1.MarketType `json:"marketType,omitempty"`
+
+	// CapacityReservationID specifies the target Capacity Reservation into which the instances should be launched.
+	// +optional
+	CapacityReservationID *string `json:"capacityReservationId,omitempty"`
+
+	// CapacityReservationResourceGroupARN is the ARN of the Capacity Reservation resource group into which the
+	// instances should be launched. It can't be combined with CapacityReservationID.
+	// +optional
+	CapacityReservationResourceGroupARN *string `json:"capacityReservationResourceGroupArn,omitempty"`
+
 	// InstanceMetadataOptions defines the behavior for applying metadata to instances.
 	// +optional
 	InstanceMetadataOptions *infrav1.InstanceMetadataOptions `json:"instanceMetadataOptions,omitempty"`
@@ -128,12 +179,594 @@ type AWSLaunchTemplate struct {
 	// PrivateDNSName is the options for the instance hostname.
 	// +optional
 	PrivateDNSName *infrav1.PrivateDNSName `json:"privateDnsName,omitempty"`
+
+	// PlacementGroupName is the name of the placement group the instances are launched in.
+	// Changing it creates a new launch template version, which starts an instance refresh.
+	// +optional
+	PlacementGroupName string `json:"placementGroupName,omitempty"`
+
+	// PlacementGroupPartition is the partition number within the placement group the instances are launched in.
+	// It is only valid if the placement group, referred in `PlacementGroupName`, was created with strategy
+	// set to partition.
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=7
+	// +optional
+	PlacementGroupPartition int64 `json:"placementGroupPartition,omitempty"`
+
+	// EnclaveOptions enables AWS Nitro Enclaves on the instances.
+	// +optional
+	EnclaveOptions *EnclaveOptions `json:"enclaveOptions,omitempty"`
+
+	// ElasticInferenceAccelerators are the Elastic Inference accelerators attached to the instances.
+	// +optional
+	ElasticInferenceAccelerators []ElasticInferenceAccelerator `json:"elasticInferenceAccelerators,omitempty"`
+
+	// NetworkInterfaces are the network interfaces attached to the instances. The primary network interface, with
+	// device index 0, is added when it isn't listed, and is always in the subnet chosen for the instance with the
+	// core and additional security groups.
+	// +listType=map
+	// +listMapKey=deviceIndex
+	// +optional
+	NetworkInterfaces []LaunchTemplateNetworkInterface `json:"networkInterfaces,omitempty"`
+
+	// PerOverrideUserData replaces the bootstrap data of the MachinePool for the instances of some instance type
+	// overrides of the mixed instances policy. Those instances are launched from a separate launch template
+	// managed alongside the primary one, the other instances keep using the bootstrap data of the MachinePool.
+	// Only supported by AWSMachinePool.
+	// +listType=map
+	// +listMapKey=instanceType
+	// +optional
+	PerOverrideUserData []OverrideUserData `json:"perOverrideUserData,omitempty"`
+
+	// ValidateBeforeUse enables a dry run of RunInstances against every new launch template version.
+	// A version that fails the dry run is deleted again so that the previous version stays in use,
+	// and the failure is reported in the LaunchTemplateValidationFailed condition.
+	// Dry runs require the ec2:RunInstances permission for the controller.
+	// +optional
+	ValidateBeforeUse bool `json:"validateBeforeUse,omitempty"`
+
+	// LaunchTemplateVersionRetention is the number of the most recent launch template versions to retain. The
+	// older versions are deleted after each new version, except the default and latest versions, the versions the
+	// instances were launched from, the version the launch template may be rolled back to, and the versions not
+	// created by the controller. All the versions are retained when it is not set, besides one old version
+	// deleted before creating each new one.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	LaunchTemplateVersionRetention *int32 `json:"launchTemplateVersionRetention,omitempty"`
+
+	// Ref references a launch template managed outside of the controller. When set, the launch template
+	// is neither created nor updated nor deleted by the controller, the Auto Scaling group launches the
+	// referenced version of it, and none of the other fields may be set.
+	// Only supported by AWSMachinePool.
+	// +optional
+	Ref *LaunchTemplateReference `json:"ref,omitempty"`
+}
+
+// BottlerocketSettings are settings merged into the TOML user data of Bottlerocket instances. They take precedence
+// over the same settings of the bootstrap data.
+type BottlerocketSettings struct {
+	// AdminContainer enables or disables the admin host container, which gives root access to the host through
+	// SSH. The setting of the bootstrap data, or the Bottlerocket default of disabled, is kept when unset.
+	// +optional
+	AdminContainer *bool `json:"adminContainer,omitempty"`
+
+	// HTTPSProxy is the proxy the container runtime and the kubelet reach the network through, e.g.
+	// http://proxy.example.com:3128.
+	// +optional
+	HTTPSProxy string `json:"httpsProxy,omitempty"`
+
+	// NoProxy are the hosts reached without the proxy. Requires httpsProxy.
+	// +optional
+	NoProxy []string `json:"noProxy,omitempty"`
+}
+
+// OverrideUserData is the bootstrap data of the instances of an instance type override.
+type OverrideUserData struct {
+	// InstanceType of the override of the mixed instances policy.
+	InstanceType string `json:"instanceType"`
+
+	// DataSecretName is the name of the secret holding the bootstrap data, in the namespace of the machine pool.
+	// Like the bootstrap data secret of the MachinePool, it holds the data in its value key and may tell its
+	// format in its format key.
+	// +kubebuilder:validation:MinLength=1
+	DataSecretName string `json:"dataSecretName"`
+}
+
+// EnclaveOptions are the AWS Nitro Enclaves options of the instances.
+type EnclaveOptions struct {
+	// Enabled enables AWS Nitro Enclaves on the instances. Enclaves can't be used with hibernation, nor on
+	// the instance types not built on the Nitro System or with too few vCPUs.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+// ElasticInferenceAccelerator is an Elastic Inference accelerator attached to the instances.
+type ElasticInferenceAccelerator struct {
+	// Type is the type of the accelerator.
+	// +kubebuilder:validation:Enum:=eia1.medium;eia1.large;eia1.xlarge;eia2.medium;eia2.large;eia2.xlarge
+	Type string `json:"type"`
+
+	// Count is the number of accelerators of this type attached to each instance. Defaults to 1.
+	// +kubebuilder:validation:Minimum:=1
+	// +optional
+	Count int64 `json:"count,omitempty"`
+}
+
+// GetCount returns the number of accelerators attached to each instance, or 1 if not set.
+func (a ElasticInferenceAccelerator) GetCount() int64 {
+	if a.Count == 0 {
+		return 1
+	}
+	return a.Count
+}
+
+// NetworkInterfaceType is the type of a network interface.
+type NetworkInterfaceType string
+
+const (
+	// NetworkInterfaceTypeInterface is a standard network interface.
+	NetworkInterfaceTypeInterface NetworkInterfaceType = "interface"
+	// NetworkInterfaceTypeEFA is an Elastic Fabric Adapter.
+	NetworkInterfaceTypeEFA NetworkInterfaceType = "efa"
+)
+
+// LaunchTemplateNetworkInterface is a network interface attached to the instances launched from a launch template.
+type LaunchTemplateNetworkInterface struct {
+	// DeviceIndex is the position of the network interface in the attachment order of the instance. The network
+	// interface with device index 0 is the primary network interface.
+	// +kubebuilder:validation:Minimum:=0
+	DeviceIndex int64 `json:"deviceIndex"`
+
+	// InterfaceType is the type of the network interface. Elastic Fabric Adapters require a placement group.
+	// Defaults to interface.
+	// +kubebuilder:validation:Enum:=interface;efa
+	// +optional
+	InterfaceType NetworkInterfaceType `json:"interfaceType,omitempty"`
+
+	// SubnetID is the subnet of a secondary network interface. Defaults to the subnet of the instance.
+	// It can't be set on the primary network interface.
+	// +optional
+	SubnetID string `json:"subnetId,omitempty"`
+
+	// SecurityGroups are the security groups of a secondary network interface. When empty, the default security
+	// group of the VPC is used. They can't be set on the primary network interface, which uses the core and
+	// additional security groups of the launch template.
+	// +optional
+	SecurityGroups []infrav1.AWSResourceReference `json:"securityGroups,omitempty"`
+
+	// IPv6AddressCount is the number of IPv6 addresses assigned to the network interface.
+	// +kubebuilder:validation:Minimum:=0
+	// +optional
+	IPv6AddressCount *int64 `json:"ipv6AddressCount,omitempty"`
+}
+
+// GetInterfaceType returns the type of the network interface, or interface if not set.
+func (n LaunchTemplateNetworkInterface) GetInterfaceType() NetworkInterfaceType {
+	if n.InterfaceType == "" {
+		return NetworkInterfaceTypeInterface
+	}
+	return n.InterfaceType
+}
+
+// LaunchTemplateReference references a launch template managed outside of the controller.
+type LaunchTemplateReference struct {
+	// ID of the launch template. Either ID or Name must be set.
+	// +optional
+	ID *string `json:"id,omitempty"`
+
+	// Name of the launch template. Either ID or Name must be set.
+	// +optional
+	Name *string `json:"name,omitempty"`
+
+	// Version of the launch template the Auto Scaling group launches: a version number, $Latest or $Default.
+	// Changing it starts an instance refresh, unless instance refreshes are disabled.
+	// +kubebuilder:validation:MinLength=1
+	Version string `json:"version"`
 }
 
 // Overrides are used to override the instance type specified by the launch template with multiple
 // instance types that can be used to launch On-Demand Instances and Spot Instances.
 type Overrides struct {
+	// InstanceType launched by the Auto Scaling group. Either InstanceType or InstanceRequirements must be set.
+	// +optional
+	InstanceType string `json:"instanceType,omitempty"`
+
+	// InstanceRequirements selects the instance types launched by the Auto Scaling group from their attributes
+	// instead of naming them. Either InstanceType or InstanceRequirements must be set.
+	// +optional
+	InstanceRequirements *InstanceRequirements `json:"instanceRequirements,omitempty"`
+
+	// RootVolume overrides the root volume of the launch template for this instance type,
+	// e.g. to use a different size or KMS key with an AMI that needs it.
+	// Instances of this type are launched from a separate launch template managed alongside the primary one.
+	// +optional
+	RootVolume *infrav1.Volume `json:"rootVolume,omitempty"`
+}
+
+// CPUManufacturer is the manufacturer of the CPUs of an instance type.
+// +kubebuilder:validation:Enum=intel;amd;amazon-web-services
+type CPUManufacturer string
+
+var (
+	// CPUManufacturerIntel is the manufacturer of Intel CPUs.
+	CPUManufacturerIntel = CPUManufacturer("intel")
+
+	// CPUManufacturerAMD is the manufacturer of AMD CPUs.
+	CPUManufacturerAMD = CPUManufacturer("amd")
+
+	// CPUManufacturerAWS is the manufacturer of AWS Graviton CPUs.
+	CPUManufacturerAWS = CPUManufacturer("amazon-web-services")
+)
+
+// InstanceGeneration is the generation of an instance type.
+// +kubebuilder:validation:Enum=current;previous
+type InstanceGeneration string
+
+var (
+	// InstanceGenerationCurrent selects the current generation instance types.
+	InstanceGenerationCurrent = InstanceGeneration("current")
+
+	// InstanceGenerationPrevious selects the previous generation instance types.
+	InstanceGenerationPrevious = InstanceGeneration("previous")
+)
+
+// InstanceTypeFeature tells whether the instance types with a feature, e.g. burstable performance, are selected.
+type InstanceTypeFeature string
+
+var (
+	// InstanceTypeFeatureIncluded selects the instance types with and without the feature.
+	InstanceTypeFeatureIncluded = InstanceTypeFeature("included")
+
+	// InstanceTypeFeatureExcluded only selects the instance types without the feature.
+	InstanceTypeFeatureExcluded = InstanceTypeFeature("excluded")
+
+	// InstanceTypeFeatureRequired only selects the instance types with the feature.
+	InstanceTypeFeatureRequired = InstanceTypeFeature("required")
+)
+
+// AcceleratorType is the type of the accelerators of an instance type.
+// +kubebuilder:validation:Enum=gpu;fpga;inference
+type AcceleratorType string
+
+var (
+	// AcceleratorTypeGPU is a GPU accelerator.
+	AcceleratorTypeGPU = AcceleratorType("gpu")
+
+	// AcceleratorTypeFPGA is an FPGA accelerator.
+	AcceleratorTypeFPGA = AcceleratorType("fpga")
+
+	// AcceleratorTypeInference is an inference accelerator.
+	AcceleratorTypeInference = AcceleratorType("inference")
+)
+
+// InstanceRequirements are the attributes of the instance types selected by the Auto Scaling group.
+// Instance types are only selected when they have all the attributes.
+type InstanceRequirements struct {
+	// VCPUCount is the range of vCPUs of the instance types.
+	VCPUCount VCPUCountRequest `json:"vCpuCount"`
+
+	// MemoryMiB is the range of memory of the instance types, in MiB.
+	MemoryMiB MemoryMiBRequest `json:"memoryMiB"`
+
+	// CPUManufacturers are the manufacturers of the CPUs of the instance types. All of them are selected when empty.
+	// +optional
+	CPUManufacturers []CPUManufacturer `json:"cpuManufacturers,omitempty"`
+
+	// InstanceGenerations are the generations of the instance types. All of them are selected when empty.
+	// +optional
+	InstanceGenerations []InstanceGeneration `json:"instanceGenerations,omitempty"`
+
+	// BurstablePerformance tells whether burstable performance instance types, e.g. T instance types, are selected.
+	// AWS excludes them when it's not set.
+	// +kubebuilder:validation:Enum=included;excluded;required
+	// +optional
+	BurstablePerformance InstanceTypeFeature `json:"burstablePerformance,omitempty"`
+
+	// BareMetal tells whether bare metal instance types are selected. AWS excludes them when it's not set.
+	// +kubebuilder:validation:Enum=included;excluded;required
+	// +optional
+	BareMetal InstanceTypeFeature `json:"bareMetal,omitempty"`
+
+	// AcceleratorCount is the range of accelerators, e.g. GPUs, of the instance types. Setting its maximum to 0
+	// excludes the instance types with accelerators.
+	// +optional
+	AcceleratorCount *AcceleratorCountRequest `json:"acceleratorCount,omitempty"`
+
+	// AcceleratorTypes are the types of the accelerators of the instance types. All of them are selected when empty.
+	// +optional
+	AcceleratorTypes []AcceleratorType `json:"acceleratorTypes,omitempty"`
+
+	// AllowedInstanceTypes restricts the selected instance types to the ones matching these names, which accept
+	// the * wildcard, e.g. m5.*. It can't be set with ExcludedInstanceTypes.
+	// +optional
+	AllowedInstanceTypes []string `json:"allowedInstanceTypes,omitempty"`
+
+	// ExcludedInstanceTypes are the instance types never selected, their names accept the * wildcard, e.g. m5.*.
+	// It can't be set with AllowedInstanceTypes.
+	// +optional
+	ExcludedInstanceTypes []string `json:"excludedInstanceTypes,omitempty"`
+
+	// SpotMaxPricePercentageOverLowestPrice is the price protection threshold of the Spot Instances, as a percentage
+	// above the price of the cheapest selected instance type. AWS defaults it to 100.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	SpotMaxPricePercentageOverLowestPrice *int64 `json:"spotMaxPricePercentageOverLowestPrice,omitempty"`
+
+	// OnDemandMaxPricePercentageOverLowestPrice is the price protection threshold of the On-Demand Instances, as a
+	// percentage above the price of the cheapest selected instance type. AWS defaults it to 20.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	OnDemandMaxPricePercentageOverLowestPrice *int64 `json:"onDemandMaxPricePercentageOverLowestPrice,omitempty"`
+}
+
+// VCPUCountRequest is a range of vCPUs.
+type VCPUCountRequest struct {
+	// Min is the minimum number of vCPUs.
+	// +kubebuilder:validation:Minimum=0
+	Min int64 `json:"min"`
+
+	// Max is the maximum number of vCPUs. There is no maximum when it's not set.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Max *int64 `json:"max,omitempty"`
+}
+
+// MemoryMiBRequest is a range of memory, in MiB.
+type MemoryMiBRequest struct {
+	// Min is the minimum amount of memory.
+	// +kubebuilder:validation:Minimum=0
+	Min int64 `json:"min"`
+
+	// Max is the maximum amount of memory. There is no maximum when it's not set.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Max *int64 `json:"max,omitempty"`
+}
+
+// AcceleratorCountRequest is a range of accelerators.
+type AcceleratorCountRequest struct {
+	// Min is the minimum number of accelerators. There is no minimum when it's not set.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Min *int64 `json:"min,omitempty"`
+
+	// Max is the maximum number of accelerators. There is no maximum when it's not set.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Max *int64 `json:"max,omitempty"`
+}
+
+// OverrideLaunchTemplate describes the launch template managed for an instance type override
+// with its own root volume.
+type OverrideLaunchTemplate struct {
+	// InstanceType of the override the launch template is used for.
 	InstanceType string `json:"instanceType"`
+
+	// ID of the launch template.
+	ID string `json:"id"`
+
+	// Version is the latest version of the launch template.
+	// +optional
+	Version *string `json:"version,omitempty"`
+}
+
+// CopiedAMI describes the copy of an AMI made from another region for a launch template.
+type CopiedAMI struct {
+	// SourceRegion is the region the AMI was copied from.
+	SourceRegion string `json:"sourceRegion"`
+
+	// SourceID is the ID of the AMI in the source region.
+	SourceID string `json:"sourceID"`
+
+	// EncryptionKey is the KMS key the snapshots of the copy are encrypted with, if any.
+	// +optional
+	EncryptionKey string `json:"encryptionKey,omitempty"`
+
+	// ID is the ID of the copy in the region of the cluster.
+	ID string `json:"id"`
+}
+
+// InstanceLifecycle is the purchasing option of an instance.
+type InstanceLifecycle string
+
+const (
+	// InstanceLifecycleSpot is the lifecycle of spot instances.
+	InstanceLifecycleSpot InstanceLifecycle = "spot"
+	// InstanceLifecycleOnDemand is the lifecycle of on-demand instances.
+	InstanceLifecycleOnDemand InstanceLifecycle = "on-demand"
+)
+
+// CapacityMix is the number of spot and on-demand instances of a machine pool.
+type CapacityMix struct {
+	// Spot is the number of spot instances.
+	Spot int32 `json:"spot"`
+
+	// OnDemand is the number of on-demand instances.
+	OnDemand int32 `json:"onDemand"`
+
+	// ExpectedSpot is the number of spot instances expected from the spot market options of the launch
+	// template, or from the instances distribution of the mixed instances policy.
+	ExpectedSpot int32 `json:"expectedSpot"`
+}
+
+// CapacityProbe is the result of the capacity probe requested by the CapacityProbeAnnotation of a machine pool.
+type CapacityProbe struct {
+	// Instances is the number of instances the capacity was probed for.
+	Instances int32 `json:"instances"`
+
+	// ProbedAt is when the capacity was probed.
+	ProbedAt metav1.Time `json:"probedAt"`
+
+	// Results lists the result of the probe of each instance type in each availability zone of the pool.
+	// +optional
+	Results []CapacityProbeResult `json:"results,omitempty"`
+}
+
+// CapacityProbeResult is the result of the probe of an instance type in an availability zone.
+type CapacityProbeResult struct {
+	// AvailabilityZone is the availability zone of the subnet probed.
+	AvailabilityZone string `json:"availabilityZone"`
+
+	// SubnetID is the ID of the subnet probed.
+	SubnetID string `json:"subnetID"`
+
+	// InstanceType is the instance type probed. It is empty when the instance type of the launch template was
+	// probed.
+	// +optional
+	InstanceType string `json:"instanceType,omitempty"`
+
+	// Feasible is whether the dry run of the launch of the instances succeeded.
+	Feasible bool `json:"feasible"`
+
+	// Reason is the error code and message the dry run failed with.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+}
+
+// SpotPlacementScores are the spot placement scores requested by the SpotPlacementScoresAnnotation of a machine pool.
+type SpotPlacementScores struct {
+	// TargetCapacity is the number of instances the scores were requested for.
+	TargetCapacity int32 `json:"targetCapacity"`
+
+	// InstanceTypes are the instance types scored. They are empty when the instance requirements of the pool were
+	// scored.
+	// +optional
+	InstanceTypes []string `json:"instanceTypes,omitempty"`
+
+	// ScoredAt is when the scores were fetched from AWS. Scores requested again shortly after are reused.
+	ScoredAt metav1.Time `json:"scoredAt"`
+
+	// Scores lists the score of each availability zone of the region, from the highest one.
+	// +optional
+	Scores []SpotPlacementScore `json:"scores,omitempty"`
+}
+
+// SpotPlacementScore is the likelihood of a spot request for the target capacity succeeding in an availability zone.
+type SpotPlacementScore struct {
+	// AvailabilityZoneID is the ID of the availability zone, e.g. use1-az1, which is the same in all the accounts.
+	AvailabilityZoneID string `json:"availabilityZoneID"`
+
+	// AvailabilityZone is the name of the availability zone in the account of the cluster, e.g. us-east-1a.
+	// +optional
+	AvailabilityZone string `json:"availabilityZone,omitempty"`
+
+	// Score is from 1 to 10, a score of 10 meaning that the spot request is highly likely to succeed.
+	Score int32 `json:"score"`
+}
+
+// SpotPriceStatus is the current spot price of the spot instances of a machine pool.
+type SpotPriceStatus struct {
+	// WeightedHourlyPrice is the average hourly price of the spot instances in USD, weighted by the
+	// number of instances of each instance type and availability zone.
+	// +optional
+	WeightedHourlyPrice string `json:"weightedHourlyPrice,omitempty"`
+
+	// Prices lists the hourly price of the instance types and availability zones of the spot instances.
+	// +optional
+	Prices []SpotPrice `json:"prices,omitempty"`
+}
+
+// SpotPrice is the hourly spot price of an instance type in an availability zone.
+type SpotPrice struct {
+	// InstanceType is the instance type.
+	InstanceType string `json:"instanceType"`
+
+	// AvailabilityZone is the availability zone.
+	AvailabilityZone string `json:"availabilityZone"`
+
+	// HourlyPrice is the current hourly spot price in USD.
+	HourlyPrice string `json:"hourlyPrice"`
+
+	// Instances is the number of spot instances of the pool of this instance type in this availability zone.
+	Instances int32 `json:"instances"`
+}
+
+// ScaleDirection is the direction of a change of the capacity of a machine pool.
+type ScaleDirection string
+
+const (
+	// ScaleDirectionOut is the direction of the scaling activities launching instances.
+	ScaleDirectionOut ScaleDirection = "ScaleOut"
+	// ScaleDirectionIn is the direction of the scaling activities terminating instances.
+	ScaleDirectionIn ScaleDirection = "ScaleIn"
+)
+
+// ScaleCause is what triggered a scaling activity of an ASG, as parsed from the cause of the activity.
+type ScaleCause string
+
+const (
+	// ScaleCauseScalingPolicy is the cause of the scaling activities triggered by a scaling policy.
+	ScaleCauseScalingPolicy ScaleCause = "ScalingPolicy"
+	// ScaleCauseScheduledAction is the cause of the scaling activities triggered by a scheduled action.
+	ScaleCauseScheduledAction ScaleCause = "ScheduledAction"
+	// ScaleCauseInstanceRefresh is the cause of the scaling activities replacing instances during an instance refresh.
+	ScaleCauseInstanceRefresh ScaleCause = "InstanceRefresh"
+	// ScaleCauseHealthReplacement is the cause of the scaling activities replacing unhealthy instances.
+	ScaleCauseHealthReplacement ScaleCause = "HealthReplacement"
+	// ScaleCauseDesiredCapacity is the cause of the scaling activities following a change of the desired capacity
+	// of the ASG, e.g. by the replicas of the MachinePool or an external autoscaler.
+	ScaleCauseDesiredCapacity ScaleCause = "DesiredCapacity"
+	// ScaleCauseUnknown is the cause of the scaling activities whose cause couldn't be parsed.
+	ScaleCauseUnknown ScaleCause = "Unknown"
+)
+
+// ScaleEvent is a scaling activity of the ASG of a machine pool which changed its capacity.
+type ScaleEvent struct {
+	// ActivityID is the ID of the scaling activity.
+	ActivityID string `json:"activityID"`
+
+	// Time is when the scaling activity started.
+	Time metav1.Time `json:"time"`
+
+	// Direction is whether the scaling activity launched or terminated instances.
+	Direction ScaleDirection `json:"direction"`
+
+	// Delta is the change of the capacity of the ASG made by the scaling activity, negative when scaling in.
+	Delta int32 `json:"delta"`
+
+	// Cause is what triggered the scaling activity.
+	Cause ScaleCause `json:"cause"`
+}
+
+// InstanceRefreshStatus is the progress of the latest instance refresh of the ASG of a machine pool.
+type InstanceRefreshStatus struct {
+	// ID is the ID of the instance refresh.
+	ID string `json:"id"`
+
+	// State is the status of the instance refresh as reported by AWS, e.g. InProgress, Successful or Failed.
+	State string `json:"state"`
+
+	// PercentageComplete is the percentage of the instance refresh which is complete.
+	// +optional
+	PercentageComplete *int64 `json:"percentageComplete,omitempty"`
+
+	// InstancesToUpdate is the number of instances which are still to be replaced.
+	// +optional
+	InstancesToUpdate *int64 `json:"instancesToUpdate,omitempty"`
+
+	// StatusReason is the explanation of the state of the instance refresh given by AWS.
+	// +optional
+	StatusReason string `json:"statusReason,omitempty"`
+
+	// StartTime is when the instance refresh started.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// EndTime is when the instance refresh ended.
+	// +optional
+	EndTime *metav1.Time `json:"endTime,omitempty"`
+}
+
+// InProgress returns whether the instance refresh is still running.
+func (s *InstanceRefreshStatus) InProgress() bool {
+	if s == nil {
+		return false
+	}
+	switch s.State {
+	case "Pending", "InProgress", "Cancelling", "RollbackInProgress":
+		return true
+	}
+	return false
 }
 
 // OnDemandAllocationStrategy indicates how to allocate instance types to fulfill On-Demand capacity.
@@ -203,22 +836,53 @@ type Tags map[string]string
 // AutoScalingGroup describes an AWS autoscaling group.
 type AutoScalingGroup struct {
 	// The tags associated with the instance.
-	ID                    string          `json:"id,omitempty"`
-	Tags                  infrav1.Tags    `json:"tags,omitempty"`
-	Name                  string          `json:"name,omitempty"`
-	DesiredCapacity       *int32          `json:"desiredCapacity,omitempty"`
-	MaxSize               int32           `json:"maxSize,omitempty"`
-	MinSize               int32           `json:"minSize,omitempty"`
-	PlacementGroup        string          `json:"placementGroup,omitempty"`
-	Subnets               []string        `json:"subnets,omitempty"`
-	DefaultCoolDown       metav1.Duration `json:"defaultCoolDown,omitempty"`
-	DefaultInstanceWarmup metav1.Duration `json:"defaultInstanceWarmup,omitempty"`
-	CapacityRebalance     bool            `json:"capacityRebalance,omitempty"`
+	ID                     string           `json:"id,omitempty"`
+	Tags                   infrav1.Tags     `json:"tags,omitempty"`
+	Name                   string           `json:"name,omitempty"`
+	DesiredCapacity        *int32           `json:"desiredCapacity,omitempty"`
+	MaxSize                int32            `json:"maxSize,omitempty"`
+	MinSize                int32            `json:"minSize,omitempty"`
+	PlacementGroup         string           `json:"placementGroup,omitempty"`
+	Subnets                []string         `json:"subnets,omitempty"`
+	DefaultCoolDown        metav1.Duration  `json:"defaultCoolDown,omitempty"`
+	DefaultInstanceWarmup  *metav1.Duration `json:"defaultInstanceWarmup,omitempty"`
+	MaxInstanceLifetime    *metav1.Duration `json:"maxInstanceLifetime,omitempty"`
+	CapacityRebalance      bool             `json:"capacityRebalance,omitempty"`
+	TerminationPolicies    []string         `json:"terminationPolicies,omitempty"`
+	EnabledMetrics         []string         `json:"enabledMetrics,omitempty"`
+	HealthCheckType        HealthCheckType  `json:"healthCheckType,omitempty"`
+	HealthCheckGracePeriod metav1.Duration  `json:"healthCheckGracePeriod,omitempty"`
+	TargetGroupARNs        []string         `json:"targetGroupARNs,omitempty"`
 
 	MixedInstancesPolicy      *MixedInstancesPolicy `json:"mixedInstancesPolicy,omitempty"`
 	Status                    ASGStatus
 	Instances                 []infrav1.Instance `json:"instances,omitempty"`
 	CurrentlySuspendProcesses []string           `json:"currentlySuspendProcesses,omitempty"`
+
+	// LaunchTemplateVersion is the version of its primary launch template the ASG launches instances with,
+	// $Latest or a version number.
+	LaunchTemplateVersion string `json:"launchTemplateVersion,omitempty"`
+
+	// InstanceDetails holds the details reported by the ASG of each of its instances, by instance ID.
+	InstanceDetails map[string]ASGInstanceDetails `json:"instanceDetails,omitempty"`
+}
+
+// ASGInstanceDetails are the details reported by an ASG about one of its instances.
+type ASGInstanceDetails struct {
+	// HealthStatus is the health status of the instance, Healthy or Unhealthy.
+	HealthStatus string `json:"healthStatus,omitempty"`
+
+	// InstanceType is the instance type of the instance.
+	InstanceType string `json:"instanceType,omitempty"`
+
+	// LaunchTemplateID is the ID of the launch template the instance was launched with.
+	LaunchTemplateID string `json:"launchTemplateID,omitempty"`
+
+	// LaunchTemplateVersion is the version of the launch template the instance was launched with.
+	LaunchTemplateVersion string `json:"launchTemplateVersion,omitempty"`
+
+	// ProtectedFromScaleIn is whether the instance is protected from scale in.
+	ProtectedFromScaleIn bool `json:"protectedFromScaleIn,omitempty"`
 }
 
 // ASGStatus is a status string returned by the autoscaling API.
@@ -300,6 +964,56 @@ type UpdateConfig struct {
 	MaxUnavailablePercentage *int `json:"maxUnavailablePercentage,omitempty"`
 }
 
+// NodegroupUpdate describes an update of an EKS node group started by the controller.
+type NodegroupUpdate struct {
+	// ID is the ID of the EKS update.
+	ID string `json:"id"`
+
+	// Type is the type of the EKS update, e.g. ConfigUpdate.
+	// +optional
+	Type string `json:"type,omitempty"`
+
+	// Status is the last observed status of the EKS update: InProgress, Failed, Cancelled or Successful.
+	Status string `json:"status"`
+
+	// Errors are the errors EKS reported for a failed update.
+	// +optional
+	Errors []string `json:"errors,omitempty"`
+}
+
+// InProgress returns whether the node group update is still running.
+func (u *NodegroupUpdate) InProgress() bool {
+	return u != nil && u.Status == "InProgress"
+}
+
+// DedicatedSecurityGroup configures a security group owned by a machine pool, to segment the network
+// of its instances from the rest of the cluster.
+type DedicatedSecurityGroup struct {
+	// Enabled creates a security group for the machine pool and attaches it to its instances.
+	// Once created, the security group is kept until the machine pool is deleted.
+	Enabled bool `json:"enabled"`
+
+	// ReplaceNodeSG attaches the dedicated security group instead of the node security group
+	// shared by the machines of the cluster.
+	// +optional
+	ReplaceNodeSG bool `json:"replaceNodeSG,omitempty"`
+
+	// IngressRules are the rules applied to the dedicated security group, on top of the ones
+	// allowing the traffic from the control plane, the nodes and the instances of the machine pool.
+	// +optional
+	IngressRules []DedicatedSecurityGroupIngressRule `json:"ingressRules,omitempty"`
+}
+
+// DedicatedSecurityGroupIngressRule is an ingress rule of a dedicated security group.
+type DedicatedSecurityGroupIngressRule struct {
+	infrav1.IngressRule `json:",inline"`
+
+	// SourceMachinePools are the names of the machine pools of the cluster whose dedicated security
+	// group to allow access from. The field will be combined with source security group IDs and roles if specified.
+	// +optional
+	SourceMachinePools []string `json:"sourceMachinePools,omitempty"`
+}
+
 // AZSubnetType is the type of subnet to use when an availability zone is specified.
 type AZSubnetType string
 
@@ -316,3 +1030,162 @@ const (
 func NewAZSubnetType(t AZSubnetType) *AZSubnetType {
 	return &t
 }
+
+// UnmanagedField is an aspect of an Auto Scaling group that CAPA leaves to other tooling once the group exists.
+// +kubebuilder:validation:Enum=mixedInstancesPolicy;suspendProcesses;tags;desiredCapacity;minSize;maxSize
+type UnmanagedField string
+
+const (
+	// UnmanagedFieldMixedInstancesPolicy leaves the mixed instances policy, or the launch template if the
+	// pool doesn't use one, to other tooling.
+	UnmanagedFieldMixedInstancesPolicy UnmanagedField = "mixedInstancesPolicy"
+	// UnmanagedFieldSuspendProcesses leaves the suspended processes to other tooling.
+	UnmanagedFieldSuspendProcesses UnmanagedField = "suspendProcesses"
+	// UnmanagedFieldTags leaves the tags of the Auto Scaling group to other tooling.
+	UnmanagedFieldTags UnmanagedField = "tags"
+	// UnmanagedFieldDesiredCapacity leaves the desired capacity to other tooling.
+	UnmanagedFieldDesiredCapacity UnmanagedField = "desiredCapacity"
+	// UnmanagedFieldMinSize leaves the minimum size to other tooling.
+	UnmanagedFieldMinSize UnmanagedField = "minSize"
+	// UnmanagedFieldMaxSize leaves the maximum size to other tooling.
+	UnmanagedFieldMaxSize UnmanagedField = "maxSize"
+)
+
+// UnmanagedFields lists all the values an UnmanagedField can take.
+var UnmanagedFields = []UnmanagedField{
+	UnmanagedFieldMixedInstancesPolicy,
+	UnmanagedFieldSuspendProcesses,
+	UnmanagedFieldTags,
+	UnmanagedFieldDesiredCapacity,
+	UnmanagedFieldMinSize,
+	UnmanagedFieldMaxSize,
+}
+
+// ScalingPolicy is a scaling policy of an Auto Scaling group. Exactly one kind of policy must be set.
+type ScalingPolicy struct {
+	// Name is the name of the scaling policy.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=255
+	Name string `json:"name"`
+
+	// PredictiveScaling configures a predictive scaling policy, which scales the group ahead of the load
+	// forecasted from its history.
+	// +optional
+	PredictiveScaling *PredictiveScalingConfiguration `json:"predictiveScaling,omitempty"`
+}
+
+// PredictiveScalingMode defines whether a predictive scaling policy scales the group, or only forecasts its load.
+// +kubebuilder:validation:Enum=ForecastOnly;ForecastAndScale
+type PredictiveScalingMode string
+
+const (
+	// PredictiveScalingModeForecastOnly only forecasts the capacity of the group.
+	PredictiveScalingModeForecastOnly PredictiveScalingMode = "ForecastOnly"
+	// PredictiveScalingModeForecastAndScale scales the group according to the forecast.
+	PredictiveScalingModeForecastAndScale PredictiveScalingMode = "ForecastAndScale"
+)
+
+// PredictiveScalingConfiguration defines a predictive scaling policy.
+type PredictiveScalingConfiguration struct {
+	// MetricSpecifications are the metrics the load is forecasted from, with their target utilization.
+	// Auto Scaling only supports a single metric specification.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=1
+	MetricSpecifications []PredictiveScalingMetricSpecification `json:"metricSpecifications"`
+
+	// Mode defines whether the policy scales the group, or only forecasts its load. Defaults to ForecastOnly,
+	// so that the forecast can be evaluated before it is used.
+	// +kubebuilder:default=ForecastOnly
+	// +optional
+	Mode PredictiveScalingMode `json:"mode,omitempty"`
+
+	// SchedulingBufferTime is how long before the forecasted time the instances are launched, so that they are
+	// ready when the load increases. It can't be longer than an hour.
+	// +optional
+	SchedulingBufferTime *metav1.Duration `json:"schedulingBufferTime,omitempty"`
+}
+
+// PredefinedMetricPairType is a pair of load and scaling metrics supported by predictive scaling.
+// +kubebuilder:validation:Enum=ASGCPUUtilization;ASGNetworkIn;ASGNetworkOut;ALBRequestCount
+type PredefinedMetricPairType string
+
+const (
+	// PredefinedMetricPairTypeASGCPUUtilization is the average CPU utilization of the group.
+	PredefinedMetricPairTypeASGCPUUtilization PredefinedMetricPairType = "ASGCPUUtilization"
+	// PredefinedMetricPairTypeASGNetworkIn is the average number of bytes received by the instances of the group.
+	PredefinedMetricPairTypeASGNetworkIn PredefinedMetricPairType = "ASGNetworkIn"
+	// PredefinedMetricPairTypeASGNetworkOut is the average number of bytes sent by the instances of the group.
+	PredefinedMetricPairTypeASGNetworkOut PredefinedMetricPairType = "ASGNetworkOut"
+	// PredefinedMetricPairTypeALBRequestCount is the number of requests to an Application Load Balancer target group.
+	PredefinedMetricPairTypeALBRequestCount PredefinedMetricPairType = "ALBRequestCount"
+)
+
+// PredefinedMetricPairTypes lists all the values a PredefinedMetricPairType can take.
+var PredefinedMetricPairTypes = []PredefinedMetricPairType{
+	PredefinedMetricPairTypeASGCPUUtilization,
+	PredefinedMetricPairTypeASGNetworkIn,
+	PredefinedMetricPairTypeASGNetworkOut,
+	PredefinedMetricPairTypeALBRequestCount,
+}
+
+// PredictiveScalingMetricSpecification defines a metric of a predictive scaling policy.
+type PredictiveScalingMetricSpecification struct {
+	// TargetValue is the target utilization of the metric, for example 40 for an average CPU utilization of 40%.
+	// +kubebuilder:validation:Minimum=1
+	TargetValue int64 `json:"targetValue"`
+
+	// PredefinedMetricPair is the pair of load and scaling metrics the forecast is based on.
+	PredefinedMetricPair PredefinedMetricPair `json:"predefinedMetricPair"`
+}
+
+// PredefinedMetricPair is a pair of load and scaling metrics of a predictive scaling policy.
+type PredefinedMetricPair struct {
+	// PredefinedMetricType is the type of the metric pair.
+	PredefinedMetricType PredefinedMetricPairType `json:"predefinedMetricType"`
+
+	// ResourceLabel identifies the target group of an ALBRequestCount metric pair, in the format
+	// app/<load-balancer-name>/<load-balancer-id>/targetgroup/<target-group-name>/<target-group-id>.
+	// It must only be set for ALBRequestCount.
+	// +optional
+	ResourceLabel string `json:"resourceLabel,omitempty"`
+}
+
+// ScalingPolicyStatus is the observed state of a scaling policy managed for an Auto Scaling group.
+type ScalingPolicyStatus struct {
+	// Name is the name of the scaling policy.
+	Name string `json:"name"`
+
+	// ARN is the Amazon Resource Name of the scaling policy.
+	// +optional
+	ARN string `json:"arn,omitempty"`
+
+	// Forecast summarizes the latest capacity forecast of a predictive scaling policy. It is only fetched
+	// when the AWSMachinePool is annotated with PredictiveScalingForecastAnnotation.
+	// +optional
+	Forecast *PredictiveScalingForecast `json:"forecast,omitempty"`
+
+	// ForecastError is why the latest forecast requested by PredictiveScalingForecastAnnotation couldn't be
+	// fetched. It is cleared once a forecast is fetched.
+	// +optional
+	ForecastError string `json:"forecastError,omitempty"`
+}
+
+// PredictiveScalingForecast summarizes the capacity forecast of a predictive scaling policy.
+type PredictiveScalingForecast struct {
+	// FetchTime is when the forecast was fetched.
+	FetchTime metav1.Time `json:"fetchTime"`
+
+	// UpdateTime is when the forecast was last updated by Auto Scaling.
+	// +optional
+	UpdateTime *metav1.Time `json:"updateTime,omitempty"`
+
+	// MinCapacity is the lowest capacity forecasted over the forecast window.
+	MinCapacity int32 `json:"minCapacity"`
+
+	// MaxCapacity is the highest capacity forecasted over the forecast window.
+	MaxCapacity int32 `json:"maxCapacity"`
+
+	// MaxCapacityTime is when the highest capacity is forecasted.
+	// +optional
+	MaxCapacityTime *metav1.Time `json:"maxCapacityTime,omitempty"`
+}
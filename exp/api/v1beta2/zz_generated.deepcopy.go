@@ -29,6 +29,40 @@ import (
 	"sigs.k8s.io/cluster-api/errors"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ASGInstanceDetails) DeepCopyInto(out *ASGInstanceDetails) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ASGInstanceDetails.
+func (in *ASGInstanceDetails) DeepCopy() *ASGInstanceDetails {
+	if in == nil {
+		return nil
+	}
+	out := new(ASGInstanceDetails)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ASGReference) DeepCopyInto(out *ASGReference) {
+	*out = *in
+	if in.CreatedAt != nil {
+		in, out := &in.CreatedAt, &out.CreatedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ASGReference.
+func (in *ASGReference) DeepCopy() *ASGReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ASGReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AWSFargateProfile) DeepCopyInto(out *AWSFargateProfile) {
 	*out = *in
@@ -92,6 +126,11 @@ func (in *AWSFargateProfileList) DeepCopyObject() runtime.Object {
 func (in *AWSLaunchTemplate) DeepCopyInto(out *AWSLaunchTemplate) {
 	*out = *in
 	in.AMI.DeepCopyInto(&out.AMI)
+	if in.Bottlerocket != nil {
+		in, out := &in.Bottlerocket, &out.Bottlerocket
+		*out = new(BottlerocketSettings)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.RootVolume != nil {
 		in, out := &in.RootVolume, &out.RootVolume
 		*out = new(apiv1beta2.Volume)
@@ -126,6 +165,16 @@ func (in *AWSLaunchTemplate) DeepCopyInto(out *AWSLaunchTemplate) {
 		*out = new(apiv1beta2.SpotMarketOptions)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.CapacityReservationID != nil {
+		in, out := &in.CapacityReservationID, &out.CapacityReservationID
+		*out = new(string)
+		**out = **in
+	}
+	if in.CapacityReservationResourceGroupARN != nil {
+		in, out := &in.CapacityReservationResourceGroupARN, &out.CapacityReservationResourceGroupARN
+		*out = new(string)
+		**out = **in
+	}
 	if in.InstanceMetadataOptions != nil {
 		in, out := &in.InstanceMetadataOptions, &out.InstanceMetadataOptions
 		*out = new(apiv1beta2.InstanceMetadataOptions)
@@ -136,6 +185,38 @@ func (in *AWSLaunchTemplate) DeepCopyInto(out *AWSLaunchTemplate) {
 		*out = new(apiv1beta2.PrivateDNSName)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.EnclaveOptions != nil {
+		in, out := &in.EnclaveOptions, &out.EnclaveOptions
+		*out = new(EnclaveOptions)
+		**out = **in
+	}
+	if in.ElasticInferenceAccelerators != nil {
+		in, out := &in.ElasticInferenceAccelerators, &out.ElasticInferenceAccelerators
+		*out = make([]ElasticInferenceAccelerator, len(*in))
+		copy(*out, *in)
+	}
+	if in.NetworkInterfaces != nil {
+		in, out := &in.NetworkInterfaces, &out.NetworkInterfaces
+		*out = make([]LaunchTemplateNetworkInterface, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PerOverrideUserData != nil {
+		in, out := &in.PerOverrideUserData, &out.PerOverrideUserData
+		*out = make([]OverrideUserData, len(*in))
+		copy(*out, *in)
+	}
+	if in.LaunchTemplateVersionRetention != nil {
+		in, out := &in.LaunchTemplateVersionRetention, &out.LaunchTemplateVersionRetention
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Ref != nil {
+		in, out := &in.Ref, &out.Ref
+		*out = new(LaunchTemplateReference)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSLaunchTemplate.
@@ -148,6 +229,26 @@ func (in *AWSLaunchTemplate) DeepCopy() *AWSLaunchTemplate {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSLifecycleHook) DeepCopyInto(out *AWSLifecycleHook) {
+	*out = *in
+	if in.HeartbeatTimeout != nil {
+		in, out := &in.HeartbeatTimeout, &out.HeartbeatTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSLifecycleHook.
+func (in *AWSLifecycleHook) DeepCopy() *AWSLifecycleHook {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSLifecycleHook)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AWSMachinePool) DeepCopyInto(out *AWSMachinePool) {
 	*out = *in
@@ -260,23 +361,103 @@ func (in *AWSMachinePoolSpec) DeepCopyInto(out *AWSMachinePoolSpec) {
 		*out = new(MixedInstancesPolicy)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DedicatedSecurityGroup != nil {
+		in, out := &in.DedicatedSecurityGroup, &out.DedicatedSecurityGroup
+		*out = new(DedicatedSecurityGroup)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ProviderIDList != nil {
 		in, out := &in.ProviderIDList, &out.ProviderIDList
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 	out.DefaultCoolDown = in.DefaultCoolDown
-	out.DefaultInstanceWarmup = in.DefaultInstanceWarmup
+	if in.DefaultInstanceWarmup != nil {
+		in, out := &in.DefaultInstanceWarmup, &out.DefaultInstanceWarmup
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxInstanceLifetime != nil {
+		in, out := &in.MaxInstanceLifetime, &out.MaxInstanceLifetime
+		*out = new(v1.Duration)
+		**out = **in
+	}
 	if in.RefreshPreferences != nil {
 		in, out := &in.RefreshPreferences, &out.RefreshPreferences
 		*out = new(RefreshPreferences)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.TerminationPolicies != nil {
+		in, out := &in.TerminationPolicies, &out.TerminationPolicies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.HealthCheckGracePeriod != nil {
+		in, out := &in.HealthCheckGracePeriod, &out.HealthCheckGracePeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = new(MetricsCollection)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.SuspendProcesses != nil {
 		in, out := &in.SuspendProcesses, &out.SuspendProcesses
 		*out = new(SuspendProcessesTypes)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.LifecycleHooks != nil {
+		in, out := &in.LifecycleHooks, &out.LifecycleHooks
+		*out = make([]AWSLifecycleHook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ScalingPolicies != nil {
+		in, out := &in.ScalingPolicies, &out.ScalingPolicies
+		*out = make([]ScalingPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AZFailureHandling != nil {
+		in, out := &in.AZFailureHandling, &out.AZFailureHandling
+		*out = new(AZFailureHandling)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UnmanagedFields != nil {
+		in, out := &in.UnmanagedFields, &out.UnmanagedFields
+		*out = make([]UnmanagedField, len(*in))
+		copy(*out, *in)
+	}
+	if in.NodeLabels != nil {
+		in, out := &in.NodeLabels, &out.NodeLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.NodeTaints != nil {
+		in, out := &in.NodeTaints, &out.NodeTaints
+		*out = make(Taints, len(*in))
+		copy(*out, *in)
+	}
+	if in.WarmPool != nil {
+		in, out := &in.WarmPool, &out.WarmPool
+		*out = new(WarmPool)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TargetGroupARNs != nil {
+		in, out := &in.TargetGroupARNs, &out.TargetGroupARNs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ScaleInDrainPolicy != nil {
+		in, out := &in.ScaleInDrainPolicy, &out.ScaleInDrainPolicy
+		*out = new(ScaleInDrainPolicy)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSMachinePoolSpec.
@@ -311,6 +492,114 @@ func (in *AWSMachinePoolStatus) DeepCopyInto(out *AWSMachinePoolStatus) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.OverrideLaunchTemplates != nil {
+		in, out := &in.OverrideLaunchTemplates, &out.OverrideLaunchTemplates
+		*out = make([]OverrideLaunchTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AdditionalSecurityGroupIDs != nil {
+		in, out := &in.AdditionalSecurityGroupIDs, &out.AdditionalSecurityGroupIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LifecycleActions != nil {
+		in, out := &in.LifecycleActions, &out.LifecycleActions
+		*out = make([]LifecycleAction, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ScalingPolicies != nil {
+		in, out := &in.ScalingPolicies, &out.ScalingPolicies
+		*out = make([]ScalingPolicyStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExcludedAvailabilityZones != nil {
+		in, out := &in.ExcludedAvailabilityZones, &out.ExcludedAvailabilityZones
+		*out = make([]ExcludedAvailabilityZone, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CapacityBlock != nil {
+		in, out := &in.CapacityBlock, &out.CapacityBlock
+		*out = new(CapacityBlockStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CopiedAMI != nil {
+		in, out := &in.CopiedAMI, &out.CopiedAMI
+		*out = new(CopiedAMI)
+		**out = **in
+	}
+	if in.CapacityMix != nil {
+		in, out := &in.CapacityMix, &out.CapacityMix
+		*out = new(CapacityMix)
+		**out = **in
+	}
+	if in.SpotPrice != nil {
+		in, out := &in.SpotPrice, &out.SpotPrice
+		*out = new(SpotPriceStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CapacityProbe != nil {
+		in, out := &in.CapacityProbe, &out.CapacityProbe
+		*out = new(CapacityProbe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SpotPlacementScores != nil {
+		in, out := &in.SpotPlacementScores, &out.SpotPlacementScores
+		*out = new(SpotPlacementScores)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastScaleEvent != nil {
+		in, out := &in.LastScaleEvent, &out.LastScaleEvent
+		*out = new(ScaleEvent)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ASG != nil {
+		in, out := &in.ASG, &out.ASG
+		*out = new(ASGReference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InstanceRefreshStatus != nil {
+		in, out := &in.InstanceRefreshStatus, &out.InstanceRefreshStatus
+		*out = new(InstanceRefreshStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AZSequentialRefresh != nil {
+		in, out := &in.AZSequentialRefresh, &out.AZSequentialRefresh
+		*out = new(AZSequentialRefresh)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InstanceRefreshLaunchTemplateVersion != nil {
+		in, out := &in.InstanceRefreshLaunchTemplateVersion, &out.InstanceRefreshLaunchTemplateVersion
+		*out = new(string)
+		**out = **in
+	}
+	if in.PreviousLaunchTemplateVersion != nil {
+		in, out := &in.PreviousLaunchTemplateVersion, &out.PreviousLaunchTemplateVersion
+		*out = new(string)
+		**out = **in
+	}
+	if in.LaunchTemplateRollback != nil {
+		in, out := &in.LaunchTemplateRollback, &out.LaunchTemplateRollback
+		*out = new(LaunchTemplateRollback)
+		**out = **in
+	}
+	if in.LaunchTemplateChange != nil {
+		in, out := &in.LaunchTemplateChange, &out.LaunchTemplateChange
+		*out = new(LaunchTemplateChange)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TargetGroupARNs != nil {
+		in, out := &in.TargetGroupARNs, &out.TargetGroupARNs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.FailureReason != nil {
 		in, out := &in.FailureReason, &out.FailureReason
 		*out = new(errors.MachineStatusError)
@@ -427,6 +716,11 @@ func (in *AWSManagedMachinePoolSpec) DeepCopyInto(out *AWSManagedMachinePoolSpec
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.RoleManagement != nil {
+		in, out := &in.RoleManagement, &out.RoleManagement
+		*out = new(apiv1beta2.IAMRoleManagement)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.AMIVersion != nil {
 		in, out := &in.AMIVersion, &out.AMIVersion
 		*out = new(string)
@@ -489,6 +783,16 @@ func (in *AWSManagedMachinePoolSpec) DeepCopyInto(out *AWSManagedMachinePoolSpec
 		*out = new(AWSLaunchTemplate)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DedicatedSecurityGroup != nil {
+		in, out := &in.DedicatedSecurityGroup, &out.DedicatedSecurityGroup
+		*out = new(DedicatedSecurityGroup)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DeletePolicy != nil {
+		in, out := &in.DeletePolicy, &out.DeletePolicy
+		*out = new(ManagedMachinePoolDeletePolicy)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSManagedMachinePoolSpec.
@@ -514,6 +818,21 @@ func (in *AWSManagedMachinePoolStatus) DeepCopyInto(out *AWSManagedMachinePoolSt
 		*out = new(string)
 		**out = **in
 	}
+	if in.AdditionalSecurityGroupIDs != nil {
+		in, out := &in.AdditionalSecurityGroupIDs, &out.AdditionalSecurityGroupIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CopiedAMI != nil {
+		in, out := &in.CopiedAMI, &out.CopiedAMI
+		*out = new(CopiedAMI)
+		**out = **in
+	}
+	if in.ActiveUpdate != nil {
+		in, out := &in.ActiveUpdate, &out.ActiveUpdate
+		*out = new(NodegroupUpdate)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.FailureReason != nil {
 		in, out := &in.FailureReason, &out.FailureReason
 		*out = new(errors.MachineStatusError)
@@ -543,6 +862,77 @@ func (in *AWSManagedMachinePoolStatus) DeepCopy() *AWSManagedMachinePoolStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AZFailureHandling) DeepCopyInto(out *AZFailureHandling) {
+	*out = *in
+	if in.Cooldown != nil {
+		in, out := &in.Cooldown, &out.Cooldown
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AZFailureHandling.
+func (in *AZFailureHandling) DeepCopy() *AZFailureHandling {
+	if in == nil {
+		return nil
+	}
+	out := new(AZFailureHandling)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AZSequentialRefresh) DeepCopyInto(out *AZSequentialRefresh) {
+	*out = *in
+	if in.AvailabilityZones != nil {
+		in, out := &in.AvailabilityZones, &out.AvailabilityZones
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ProtectedInstanceIDs != nil {
+		in, out := &in.ProtectedInstanceIDs, &out.ProtectedInstanceIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.StartTime.DeepCopyInto(&out.StartTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AZSequentialRefresh.
+func (in *AZSequentialRefresh) DeepCopy() *AZSequentialRefresh {
+	if in == nil {
+		return nil
+	}
+	out := new(AZSequentialRefresh)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AcceleratorCountRequest) DeepCopyInto(out *AcceleratorCountRequest) {
+	*out = *in
+	if in.Min != nil {
+		in, out := &in.Min, &out.Min
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Max != nil {
+		in, out := &in.Max, &out.Max
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AcceleratorCountRequest.
+func (in *AcceleratorCountRequest) DeepCopy() *AcceleratorCountRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(AcceleratorCountRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AutoScalingGroup) DeepCopyInto(out *AutoScalingGroup) {
 	*out = *in
@@ -564,7 +954,32 @@ func (in *AutoScalingGroup) DeepCopyInto(out *AutoScalingGroup) {
 		copy(*out, *in)
 	}
 	out.DefaultCoolDown = in.DefaultCoolDown
-	out.DefaultInstanceWarmup = in.DefaultInstanceWarmup
+	if in.DefaultInstanceWarmup != nil {
+		in, out := &in.DefaultInstanceWarmup, &out.DefaultInstanceWarmup
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxInstanceLifetime != nil {
+		in, out := &in.MaxInstanceLifetime, &out.MaxInstanceLifetime
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.TerminationPolicies != nil {
+		in, out := &in.TerminationPolicies, &out.TerminationPolicies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.EnabledMetrics != nil {
+		in, out := &in.EnabledMetrics, &out.EnabledMetrics
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.HealthCheckGracePeriod = in.HealthCheckGracePeriod
+	if in.TargetGroupARNs != nil {
+		in, out := &in.TargetGroupARNs, &out.TargetGroupARNs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.MixedInstancesPolicy != nil {
 		in, out := &in.MixedInstancesPolicy, &out.MixedInstancesPolicy
 		*out = new(MixedInstancesPolicy)
@@ -582,6 +997,13 @@ func (in *AutoScalingGroup) DeepCopyInto(out *AutoScalingGroup) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.InstanceDetails != nil {
+		in, out := &in.InstanceDetails, &out.InstanceDetails
+		*out = make(map[string]ASGInstanceDetails, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoScalingGroup.
@@ -611,35 +1033,244 @@ func (in *BlockDeviceMapping) DeepCopy() *BlockDeviceMapping {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EBS) DeepCopyInto(out *EBS) {
+func (in *BottlerocketSettings) DeepCopyInto(out *BottlerocketSettings) {
 	*out = *in
+	if in.AdminContainer != nil {
+		in, out := &in.AdminContainer, &out.AdminContainer
+		*out = new(bool)
+		**out = **in
+	}
+	if in.NoProxy != nil {
+		in, out := &in.NoProxy, &out.NoProxy
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EBS.
-func (in *EBS) DeepCopy() *EBS {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BottlerocketSettings.
+func (in *BottlerocketSettings) DeepCopy() *BottlerocketSettings {
 	if in == nil {
 		return nil
 	}
-	out := new(EBS)
+	out := new(BottlerocketSettings)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FargateProfileSpec) DeepCopyInto(out *FargateProfileSpec) {
+func (in *CapacityBlockStatus) DeepCopyInto(out *CapacityBlockStatus) {
 	*out = *in
-	if in.SubnetIDs != nil {
-		in, out := &in.SubnetIDs, &out.SubnetIDs
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
 	}
-	if in.AdditionalTags != nil {
-		in, out := &in.AdditionalTags, &out.AdditionalTags
-		*out = make(apiv1beta2.Tags, len(*in))
-		for key, val := range *in {
+	if in.EndTime != nil {
+		in, out := &in.EndTime, &out.EndTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CapacityBlockStatus.
+func (in *CapacityBlockStatus) DeepCopy() *CapacityBlockStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CapacityBlockStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CapacityMix) DeepCopyInto(out *CapacityMix) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CapacityMix.
+func (in *CapacityMix) DeepCopy() *CapacityMix {
+	if in == nil {
+		return nil
+	}
+	out := new(CapacityMix)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CapacityProbe) DeepCopyInto(out *CapacityProbe) {
+	*out = *in
+	in.ProbedAt.DeepCopyInto(&out.ProbedAt)
+	if in.Results != nil {
+		in, out := &in.Results, &out.Results
+		*out = make([]CapacityProbeResult, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CapacityProbe.
+func (in *CapacityProbe) DeepCopy() *CapacityProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(CapacityProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CapacityProbeResult) DeepCopyInto(out *CapacityProbeResult) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CapacityProbeResult.
+func (in *CapacityProbeResult) DeepCopy() *CapacityProbeResult {
+	if in == nil {
+		return nil
+	}
+	out := new(CapacityProbeResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CopiedAMI) DeepCopyInto(out *CopiedAMI) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CopiedAMI.
+func (in *CopiedAMI) DeepCopy() *CopiedAMI {
+	if in == nil {
+		return nil
+	}
+	out := new(CopiedAMI)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DedicatedSecurityGroup) DeepCopyInto(out *DedicatedSecurityGroup) {
+	*out = *in
+	if in.IngressRules != nil {
+		in, out := &in.IngressRules, &out.IngressRules
+		*out = make([]DedicatedSecurityGroupIngressRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DedicatedSecurityGroup.
+func (in *DedicatedSecurityGroup) DeepCopy() *DedicatedSecurityGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(DedicatedSecurityGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DedicatedSecurityGroupIngressRule) DeepCopyInto(out *DedicatedSecurityGroupIngressRule) {
+	*out = *in
+	in.IngressRule.DeepCopyInto(&out.IngressRule)
+	if in.SourceMachinePools != nil {
+		in, out := &in.SourceMachinePools, &out.SourceMachinePools
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DedicatedSecurityGroupIngressRule.
+func (in *DedicatedSecurityGroupIngressRule) DeepCopy() *DedicatedSecurityGroupIngressRule {
+	if in == nil {
+		return nil
+	}
+	out := new(DedicatedSecurityGroupIngressRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EBS) DeepCopyInto(out *EBS) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EBS.
+func (in *EBS) DeepCopy() *EBS {
+	if in == nil {
+		return nil
+	}
+	out := new(EBS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticInferenceAccelerator) DeepCopyInto(out *ElasticInferenceAccelerator) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticInferenceAccelerator.
+func (in *ElasticInferenceAccelerator) DeepCopy() *ElasticInferenceAccelerator {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticInferenceAccelerator)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnclaveOptions) DeepCopyInto(out *EnclaveOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnclaveOptions.
+func (in *EnclaveOptions) DeepCopy() *EnclaveOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(EnclaveOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExcludedAvailabilityZone) DeepCopyInto(out *ExcludedAvailabilityZone) {
+	*out = *in
+	in.ExcludedAt.DeepCopyInto(&out.ExcludedAt)
+	in.ExpiresAt.DeepCopyInto(&out.ExpiresAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExcludedAvailabilityZone.
+func (in *ExcludedAvailabilityZone) DeepCopy() *ExcludedAvailabilityZone {
+	if in == nil {
+		return nil
+	}
+	out := new(ExcludedAvailabilityZone)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FargateProfileSpec) DeepCopyInto(out *FargateProfileSpec) {
+	*out = *in
+	if in.SubnetIDs != nil {
+		in, out := &in.SubnetIDs, &out.SubnetIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AdditionalTags != nil {
+		in, out := &in.AdditionalTags, &out.AdditionalTags
+		*out = make(apiv1beta2.Tags, len(*in))
+		for key, val := range *in {
 			(*out)[key] = val
 		}
 	}
+	if in.RoleManagement != nil {
+		in, out := &in.RoleManagement, &out.RoleManagement
+		*out = new(apiv1beta2.IAMRoleManagement)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Selectors != nil {
 		in, out := &in.Selectors, &out.Selectors
 		*out = make([]FargateSelector, len(*in))
@@ -649,181 +1280,578 @@ func (in *FargateProfileSpec) DeepCopyInto(out *FargateProfileSpec) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FargateProfileSpec.
-func (in *FargateProfileSpec) DeepCopy() *FargateProfileSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FargateProfileSpec.
+func (in *FargateProfileSpec) DeepCopy() *FargateProfileSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FargateProfileSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FargateProfileStatus) DeepCopyInto(out *FargateProfileStatus) {
+	*out = *in
+	if in.FailureReason != nil {
+		in, out := &in.FailureReason, &out.FailureReason
+		*out = new(errors.MachineStatusError)
+		**out = **in
+	}
+	if in.FailureMessage != nil {
+		in, out := &in.FailureMessage, &out.FailureMessage
+		*out = new(string)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(v1beta1.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FargateProfileStatus.
+func (in *FargateProfileStatus) DeepCopy() *FargateProfileStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FargateProfileStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FargateSelector) DeepCopyInto(out *FargateSelector) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FargateSelector.
+func (in *FargateSelector) DeepCopy() *FargateSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(FargateSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceRefreshStatus) DeepCopyInto(out *InstanceRefreshStatus) {
+	*out = *in
+	if in.PercentageComplete != nil {
+		in, out := &in.PercentageComplete, &out.PercentageComplete
+		*out = new(int64)
+		**out = **in
+	}
+	if in.InstancesToUpdate != nil {
+		in, out := &in.InstancesToUpdate, &out.InstancesToUpdate
+		*out = new(int64)
+		**out = **in
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.EndTime != nil {
+		in, out := &in.EndTime, &out.EndTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceRefreshStatus.
+func (in *InstanceRefreshStatus) DeepCopy() *InstanceRefreshStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceRefreshStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceRequirements) DeepCopyInto(out *InstanceRequirements) {
+	*out = *in
+	in.VCPUCount.DeepCopyInto(&out.VCPUCount)
+	in.MemoryMiB.DeepCopyInto(&out.MemoryMiB)
+	if in.CPUManufacturers != nil {
+		in, out := &in.CPUManufacturers, &out.CPUManufacturers
+		*out = make([]CPUManufacturer, len(*in))
+		copy(*out, *in)
+	}
+	if in.InstanceGenerations != nil {
+		in, out := &in.InstanceGenerations, &out.InstanceGenerations
+		*out = make([]InstanceGeneration, len(*in))
+		copy(*out, *in)
+	}
+	if in.AcceleratorCount != nil {
+		in, out := &in.AcceleratorCount, &out.AcceleratorCount
+		*out = new(AcceleratorCountRequest)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AcceleratorTypes != nil {
+		in, out := &in.AcceleratorTypes, &out.AcceleratorTypes
+		*out = make([]AcceleratorType, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedInstanceTypes != nil {
+		in, out := &in.AllowedInstanceTypes, &out.AllowedInstanceTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludedInstanceTypes != nil {
+		in, out := &in.ExcludedInstanceTypes, &out.ExcludedInstanceTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SpotMaxPricePercentageOverLowestPrice != nil {
+		in, out := &in.SpotMaxPricePercentageOverLowestPrice, &out.SpotMaxPricePercentageOverLowestPrice
+		*out = new(int64)
+		**out = **in
+	}
+	if in.OnDemandMaxPricePercentageOverLowestPrice != nil {
+		in, out := &in.OnDemandMaxPricePercentageOverLowestPrice, &out.OnDemandMaxPricePercentageOverLowestPrice
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceRequirements.
+func (in *InstanceRequirements) DeepCopy() *InstanceRequirements {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceRequirements)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstancesDistribution) DeepCopyInto(out *InstancesDistribution) {
+	*out = *in
+	if in.OnDemandBaseCapacity != nil {
+		in, out := &in.OnDemandBaseCapacity, &out.OnDemandBaseCapacity
+		*out = new(int64)
+		**out = **in
+	}
+	if in.OnDemandPercentageAboveBaseCapacity != nil {
+		in, out := &in.OnDemandPercentageAboveBaseCapacity, &out.OnDemandPercentageAboveBaseCapacity
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstancesDistribution.
+func (in *InstancesDistribution) DeepCopy() *InstancesDistribution {
+	if in == nil {
+		return nil
+	}
+	out := new(InstancesDistribution)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LaunchTemplateChange) DeepCopyInto(out *LaunchTemplateChange) {
+	*out = *in
+	if in.ChangedFields != nil {
+		in, out := &in.ChangedFields, &out.ChangedFields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LaunchTemplateChange.
+func (in *LaunchTemplateChange) DeepCopy() *LaunchTemplateChange {
+	if in == nil {
+		return nil
+	}
+	out := new(LaunchTemplateChange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LaunchTemplateNetworkInterface) DeepCopyInto(out *LaunchTemplateNetworkInterface) {
+	*out = *in
+	if in.SecurityGroups != nil {
+		in, out := &in.SecurityGroups, &out.SecurityGroups
+		*out = make([]apiv1beta2.AWSResourceReference, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.IPv6AddressCount != nil {
+		in, out := &in.IPv6AddressCount, &out.IPv6AddressCount
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LaunchTemplateNetworkInterface.
+func (in *LaunchTemplateNetworkInterface) DeepCopy() *LaunchTemplateNetworkInterface {
+	if in == nil {
+		return nil
+	}
+	out := new(LaunchTemplateNetworkInterface)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LaunchTemplateReference) DeepCopyInto(out *LaunchTemplateReference) {
+	*out = *in
+	if in.ID != nil {
+		in, out := &in.ID, &out.ID
+		*out = new(string)
+		**out = **in
+	}
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LaunchTemplateReference.
+func (in *LaunchTemplateReference) DeepCopy() *LaunchTemplateReference {
+	if in == nil {
+		return nil
+	}
+	out := new(LaunchTemplateReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LaunchTemplateRollback) DeepCopyInto(out *LaunchTemplateRollback) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LaunchTemplateRollback.
+func (in *LaunchTemplateRollback) DeepCopy() *LaunchTemplateRollback {
+	if in == nil {
+		return nil
+	}
+	out := new(LaunchTemplateRollback)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LifecycleAction) DeepCopyInto(out *LifecycleAction) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LifecycleAction.
+func (in *LifecycleAction) DeepCopy() *LifecycleAction {
+	if in == nil {
+		return nil
+	}
+	out := new(LifecycleAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedMachinePoolDeletePolicy) DeepCopyInto(out *ManagedMachinePoolDeletePolicy) {
+	*out = *in
+	out.DrainTimeout = in.DrainTimeout
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedMachinePoolDeletePolicy.
+func (in *ManagedMachinePoolDeletePolicy) DeepCopy() *ManagedMachinePoolDeletePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedMachinePoolDeletePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedMachinePoolScaling) DeepCopyInto(out *ManagedMachinePoolScaling) {
+	*out = *in
+	if in.MinSize != nil {
+		in, out := &in.MinSize, &out.MinSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxSize != nil {
+		in, out := &in.MaxSize, &out.MaxSize
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedMachinePoolScaling.
+func (in *ManagedMachinePoolScaling) DeepCopy() *ManagedMachinePoolScaling {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedMachinePoolScaling)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedRemoteAccess) DeepCopyInto(out *ManagedRemoteAccess) {
+	*out = *in
+	if in.SSHKeyName != nil {
+		in, out := &in.SSHKeyName, &out.SSHKeyName
+		*out = new(string)
+		**out = **in
+	}
+	if in.SourceSecurityGroups != nil {
+		in, out := &in.SourceSecurityGroups, &out.SourceSecurityGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedRemoteAccess.
+func (in *ManagedRemoteAccess) DeepCopy() *ManagedRemoteAccess {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedRemoteAccess)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemoryMiBRequest) DeepCopyInto(out *MemoryMiBRequest) {
+	*out = *in
+	if in.Max != nil {
+		in, out := &in.Max, &out.Max
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemoryMiBRequest.
+func (in *MemoryMiBRequest) DeepCopy() *MemoryMiBRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(MemoryMiBRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricsCollection) DeepCopyInto(out *MetricsCollection) {
+	*out = *in
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricsCollection.
+func (in *MetricsCollection) DeepCopy() *MetricsCollection {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricsCollection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MixedInstancesPolicy) DeepCopyInto(out *MixedInstancesPolicy) {
+	*out = *in
+	if in.InstancesDistribution != nil {
+		in, out := &in.InstancesDistribution, &out.InstancesDistribution
+		*out = new(InstancesDistribution)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Overrides != nil {
+		in, out := &in.Overrides, &out.Overrides
+		*out = make([]Overrides, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MixedInstancesPolicy.
+func (in *MixedInstancesPolicy) DeepCopy() *MixedInstancesPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(FargateProfileSpec)
+	out := new(MixedInstancesPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FargateProfileStatus) DeepCopyInto(out *FargateProfileStatus) {
+func (in *NodegroupUpdate) DeepCopyInto(out *NodegroupUpdate) {
 	*out = *in
-	if in.FailureReason != nil {
-		in, out := &in.FailureReason, &out.FailureReason
-		*out = new(errors.MachineStatusError)
-		**out = **in
+	if in.Errors != nil {
+		in, out := &in.Errors, &out.Errors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.FailureMessage != nil {
-		in, out := &in.FailureMessage, &out.FailureMessage
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodegroupUpdate.
+func (in *NodegroupUpdate) DeepCopy() *NodegroupUpdate {
+	if in == nil {
+		return nil
+	}
+	out := new(NodegroupUpdate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OverrideLaunchTemplate) DeepCopyInto(out *OverrideLaunchTemplate) {
+	*out = *in
+	if in.Version != nil {
+		in, out := &in.Version, &out.Version
 		*out = new(string)
 		**out = **in
 	}
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make(v1beta1.Conditions, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FargateProfileStatus.
-func (in *FargateProfileStatus) DeepCopy() *FargateProfileStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OverrideLaunchTemplate.
+func (in *OverrideLaunchTemplate) DeepCopy() *OverrideLaunchTemplate {
 	if in == nil {
 		return nil
 	}
-	out := new(FargateProfileStatus)
+	out := new(OverrideLaunchTemplate)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FargateSelector) DeepCopyInto(out *FargateSelector) {
+func (in *OverrideUserData) DeepCopyInto(out *OverrideUserData) {
 	*out = *in
-	if in.Labels != nil {
-		in, out := &in.Labels, &out.Labels
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FargateSelector.
-func (in *FargateSelector) DeepCopy() *FargateSelector {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OverrideUserData.
+func (in *OverrideUserData) DeepCopy() *OverrideUserData {
 	if in == nil {
 		return nil
 	}
-	out := new(FargateSelector)
+	out := new(OverrideUserData)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InstancesDistribution) DeepCopyInto(out *InstancesDistribution) {
+func (in *Overrides) DeepCopyInto(out *Overrides) {
 	*out = *in
-	if in.OnDemandBaseCapacity != nil {
-		in, out := &in.OnDemandBaseCapacity, &out.OnDemandBaseCapacity
-		*out = new(int64)
-		**out = **in
+	if in.InstanceRequirements != nil {
+		in, out := &in.InstanceRequirements, &out.InstanceRequirements
+		*out = new(InstanceRequirements)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.OnDemandPercentageAboveBaseCapacity != nil {
-		in, out := &in.OnDemandPercentageAboveBaseCapacity, &out.OnDemandPercentageAboveBaseCapacity
-		*out = new(int64)
-		**out = **in
+	if in.RootVolume != nil {
+		in, out := &in.RootVolume, &out.RootVolume
+		*out = new(apiv1beta2.Volume)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstancesDistribution.
-func (in *InstancesDistribution) DeepCopy() *InstancesDistribution {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Overrides.
+func (in *Overrides) DeepCopy() *Overrides {
 	if in == nil {
 		return nil
 	}
-	out := new(InstancesDistribution)
+	out := new(Overrides)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ManagedMachinePoolScaling) DeepCopyInto(out *ManagedMachinePoolScaling) {
+func (in *PredefinedMetricPair) DeepCopyInto(out *PredefinedMetricPair) {
 	*out = *in
-	if in.MinSize != nil {
-		in, out := &in.MinSize, &out.MinSize
-		*out = new(int32)
-		**out = **in
-	}
-	if in.MaxSize != nil {
-		in, out := &in.MaxSize, &out.MaxSize
-		*out = new(int32)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedMachinePoolScaling.
-func (in *ManagedMachinePoolScaling) DeepCopy() *ManagedMachinePoolScaling {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PredefinedMetricPair.
+func (in *PredefinedMetricPair) DeepCopy() *PredefinedMetricPair {
 	if in == nil {
 		return nil
 	}
-	out := new(ManagedMachinePoolScaling)
+	out := new(PredefinedMetricPair)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ManagedRemoteAccess) DeepCopyInto(out *ManagedRemoteAccess) {
+func (in *PredictiveScalingConfiguration) DeepCopyInto(out *PredictiveScalingConfiguration) {
 	*out = *in
-	if in.SSHKeyName != nil {
-		in, out := &in.SSHKeyName, &out.SSHKeyName
-		*out = new(string)
-		**out = **in
-	}
-	if in.SourceSecurityGroups != nil {
-		in, out := &in.SourceSecurityGroups, &out.SourceSecurityGroups
-		*out = make([]string, len(*in))
+	if in.MetricSpecifications != nil {
+		in, out := &in.MetricSpecifications, &out.MetricSpecifications
+		*out = make([]PredictiveScalingMetricSpecification, len(*in))
 		copy(*out, *in)
 	}
+	if in.SchedulingBufferTime != nil {
+		in, out := &in.SchedulingBufferTime, &out.SchedulingBufferTime
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedRemoteAccess.
-func (in *ManagedRemoteAccess) DeepCopy() *ManagedRemoteAccess {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PredictiveScalingConfiguration.
+func (in *PredictiveScalingConfiguration) DeepCopy() *PredictiveScalingConfiguration {
 	if in == nil {
 		return nil
 	}
-	out := new(ManagedRemoteAccess)
+	out := new(PredictiveScalingConfiguration)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MixedInstancesPolicy) DeepCopyInto(out *MixedInstancesPolicy) {
+func (in *PredictiveScalingForecast) DeepCopyInto(out *PredictiveScalingForecast) {
 	*out = *in
-	if in.InstancesDistribution != nil {
-		in, out := &in.InstancesDistribution, &out.InstancesDistribution
-		*out = new(InstancesDistribution)
-		(*in).DeepCopyInto(*out)
+	in.FetchTime.DeepCopyInto(&out.FetchTime)
+	if in.UpdateTime != nil {
+		in, out := &in.UpdateTime, &out.UpdateTime
+		*out = (*in).DeepCopy()
 	}
-	if in.Overrides != nil {
-		in, out := &in.Overrides, &out.Overrides
-		*out = make([]Overrides, len(*in))
-		copy(*out, *in)
+	if in.MaxCapacityTime != nil {
+		in, out := &in.MaxCapacityTime, &out.MaxCapacityTime
+		*out = (*in).DeepCopy()
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MixedInstancesPolicy.
-func (in *MixedInstancesPolicy) DeepCopy() *MixedInstancesPolicy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PredictiveScalingForecast.
+func (in *PredictiveScalingForecast) DeepCopy() *PredictiveScalingForecast {
 	if in == nil {
 		return nil
 	}
-	out := new(MixedInstancesPolicy)
+	out := new(PredictiveScalingForecast)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Overrides) DeepCopyInto(out *Overrides) {
+func (in *PredictiveScalingMetricSpecification) DeepCopyInto(out *PredictiveScalingMetricSpecification) {
 	*out = *in
+	out.PredefinedMetricPair = in.PredefinedMetricPair
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Overrides.
-func (in *Overrides) DeepCopy() *Overrides {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PredictiveScalingMetricSpecification.
+func (in *PredictiveScalingMetricSpecification) DeepCopy() *PredictiveScalingMetricSpecification {
 	if in == nil {
 		return nil
 	}
-	out := new(Overrides)
+	out := new(PredictiveScalingMetricSpecification)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1067,6 +2095,26 @@ func (in *RefreshPreferences) DeepCopyInto(out *RefreshPreferences) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.CheckpointPercentages != nil {
+		in, out := &in.CheckpointPercentages, &out.CheckpointPercentages
+		*out = make([]int64, len(*in))
+		copy(*out, *in)
+	}
+	if in.CheckpointDelay != nil {
+		in, out := &in.CheckpointDelay, &out.CheckpointDelay
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.SkipMatching != nil {
+		in, out := &in.SkipMatching, &out.SkipMatching
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MaxSurge != nil {
+		in, out := &in.MaxSurge, &out.MaxSurge
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RefreshPreferences.
@@ -1245,6 +2293,153 @@ func (in *RosaUpdateConfig) DeepCopy() *RosaUpdateConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaleEvent) DeepCopyInto(out *ScaleEvent) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleEvent.
+func (in *ScaleEvent) DeepCopy() *ScaleEvent {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaleEvent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaleInDrainPolicy) DeepCopyInto(out *ScaleInDrainPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleInDrainPolicy.
+func (in *ScaleInDrainPolicy) DeepCopy() *ScaleInDrainPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaleInDrainPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScalingPolicy) DeepCopyInto(out *ScalingPolicy) {
+	*out = *in
+	if in.PredictiveScaling != nil {
+		in, out := &in.PredictiveScaling, &out.PredictiveScaling
+		*out = new(PredictiveScalingConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalingPolicy.
+func (in *ScalingPolicy) DeepCopy() *ScalingPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ScalingPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScalingPolicyStatus) DeepCopyInto(out *ScalingPolicyStatus) {
+	*out = *in
+	if in.Forecast != nil {
+		in, out := &in.Forecast, &out.Forecast
+		*out = new(PredictiveScalingForecast)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalingPolicyStatus.
+func (in *ScalingPolicyStatus) DeepCopy() *ScalingPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ScalingPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpotPlacementScore) DeepCopyInto(out *SpotPlacementScore) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpotPlacementScore.
+func (in *SpotPlacementScore) DeepCopy() *SpotPlacementScore {
+	if in == nil {
+		return nil
+	}
+	out := new(SpotPlacementScore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpotPlacementScores) DeepCopyInto(out *SpotPlacementScores) {
+	*out = *in
+	if in.InstanceTypes != nil {
+		in, out := &in.InstanceTypes, &out.InstanceTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.ScoredAt.DeepCopyInto(&out.ScoredAt)
+	if in.Scores != nil {
+		in, out := &in.Scores, &out.Scores
+		*out = make([]SpotPlacementScore, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpotPlacementScores.
+func (in *SpotPlacementScores) DeepCopy() *SpotPlacementScores {
+	if in == nil {
+		return nil
+	}
+	out := new(SpotPlacementScores)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpotPrice) DeepCopyInto(out *SpotPrice) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpotPrice.
+func (in *SpotPrice) DeepCopy() *SpotPrice {
+	if in == nil {
+		return nil
+	}
+	out := new(SpotPrice)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpotPriceStatus) DeepCopyInto(out *SpotPriceStatus) {
+	*out = *in
+	if in.Prices != nil {
+		in, out := &in.Prices, &out.Prices
+		*out = make([]SpotPrice, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpotPriceStatus.
+func (in *SpotPriceStatus) DeepCopy() *SpotPriceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SpotPriceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SuspendProcessesTypes) DeepCopyInto(out *SuspendProcessesTypes) {
 	*out = *in
@@ -1344,3 +2539,43 @@ func (in *UpdateConfig) DeepCopy() *UpdateConfig {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VCPUCountRequest) DeepCopyInto(out *VCPUCountRequest) {
+	*out = *in
+	if in.Max != nil {
+		in, out := &in.Max, &out.Max
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VCPUCountRequest.
+func (in *VCPUCountRequest) DeepCopy() *VCPUCountRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(VCPUCountRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WarmPool) DeepCopyInto(out *WarmPool) {
+	*out = *in
+	if in.MaxGroupPreparedCapacity != nil {
+		in, out := &in.MaxGroupPreparedCapacity, &out.MaxGroupPreparedCapacity
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WarmPool.
+func (in *WarmPool) DeepCopy() *WarmPool {
+	if in == nil {
+		return nil
+	}
+	out := new(WarmPool)
+	in.DeepCopyInto(out)
+	return out
+}
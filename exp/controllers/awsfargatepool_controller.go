@@ -44,10 +44,11 @@ import (
 // AWSFargateProfileReconciler reconciles a AWSFargateProfile object.
 type AWSFargateProfileReconciler struct {
 	client.Client
-	Recorder         record.EventRecorder
-	Endpoints        []scope.ServiceEndpoint
-	EnableIAM        bool
-	WatchFilterValue string
+	Recorder           record.EventRecorder
+	Endpoints          []scope.ServiceEndpoint
+	EnableIAM          bool
+	OwnershipTagPrefix string
+	WatchFilterValue   string
 }
 
 // SetupWithManager is used to setup the controller.
@@ -101,13 +102,14 @@ func (r *AWSFargateProfileReconciler) Reconcile(ctx context.Context, req ctrl.Re
 	}
 
 	fargateProfileScope, err := scope.NewFargateProfileScope(scope.FargateProfileScopeParams{
-		Client:         r.Client,
-		ControllerName: "awsfargateprofile",
-		Cluster:        cluster,
-		ControlPlane:   controlPlane,
-		FargateProfile: fargateProfile,
-		EnableIAM:      r.EnableIAM,
-		Endpoints:      r.Endpoints,
+		Client:             r.Client,
+		ControllerName:     "awsfargateprofile",
+		Cluster:            cluster,
+		ControlPlane:       controlPlane,
+		FargateProfile:     fargateProfile,
+		EnableIAM:          r.EnableIAM,
+		OwnershipTagPrefix: r.OwnershipTagPrefix,
+		Endpoints:          r.Endpoints,
 	})
 	if err != nil {
 		return ctrl.Result{}, errors.Wrap(err, "failed to create scope")
@@ -126,16 +128,21 @@ func (r *AWSFargateProfileReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		}
 	}()
 
+	// The profile is deleted regardless of the readiness of the control plane, as the control plane waits for
+	// its fargate profiles to be deleted before deleting the EKS cluster.
+	if !fargateProfile.ObjectMeta.DeletionTimestamp.IsZero() {
+		if scope.DeferDeletion(fargateProfileScope, fargateProfileScope) {
+			return ctrl.Result{RequeueAfter: scope.ReadOnlyRequeueAfter}, nil
+		}
+		return r.reconcileDelete(ctx, fargateProfileScope)
+	}
+
 	if !controlPlane.Status.Ready {
 		log.Info("Control plane is not ready yet")
 		conditions.MarkFalse(fargateProfile, clusterv1.ReadyCondition, expinfrav1.WaitingForEKSControlPlaneReason, clusterv1.ConditionSeverityInfo, "")
 		return ctrl.Result{}, nil
 	}
 
-	if !fargateProfile.ObjectMeta.DeletionTimestamp.IsZero() {
-		return r.reconcileDelete(ctx, fargateProfileScope)
-	}
-
 	return r.reconcileNormal(ctx, fargateProfileScope)
 }
 
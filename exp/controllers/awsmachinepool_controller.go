@@ -20,7 +20,12 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/aws/aws-sdk-go/service/autoscaling"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/pkg/errors"
@@ -28,8 +33,11 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -41,12 +49,19 @@ import (
 	"sigs.k8s.io/cluster-api-provider-aws/v2/controllers"
 	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
 	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/feature"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
 	asg "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/autoscaling"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/capacityprobe"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/drift"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/ec2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/gpu"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/securitygroup"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/spot"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
+	infrautilconditions "sigs.k8s.io/cluster-api-provider-aws/v2/util/conditions"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	expclusterv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util"
@@ -55,6 +70,23 @@ import (
 	"sigs.k8s.io/cluster-api/util/predicates"
 )
 
+// lifecycleActionsRequeueAfter is how often the nodes of the instances held by lifecycle hooks are checked.
+const lifecycleActionsRequeueAfter = 20 * time.Second
+
+// instanceRefreshRequeueAfter is how often the progress of a running instance refresh of the ASG is reported.
+const instanceRefreshRequeueAfter = 30 * time.Second
+
+const (
+	// asgCreationWindow is how long an ASG created by the controller is expected to be described by the
+	// eventually consistent AutoScaling API. It isn't created again in the meantime.
+	asgCreationWindow = 2 * time.Minute
+	// asgCreationRequeueAfter is how often an ASG created by the controller is looked up until it is described.
+	asgCreationRequeueAfter = 10 * time.Second
+)
+
+// errASGCreationPending is returned when the ASG created by the controller isn't described yet.
+var errASGCreationPending = errors.New("ASG was created but isn't described by the AutoScaling API yet")
+
 // AWSMachinePoolReconciler reconciles a AWSMachinePool object.
 type AWSMachinePoolReconciler struct {
 	client.Client
@@ -63,7 +95,20 @@ type AWSMachinePoolReconciler struct {
 	asgServiceFactory            func(cloud.ClusterScoper) services.ASGInterface
 	ec2ServiceFactory            func(scope.EC2Scope) services.EC2Interface
 	reconcileServiceFactory      func(scope.EC2Scope) services.MachinePoolReconcileInterface
+	securityGroupServiceFactory  func(scope.SGScope) services.SecurityGroupInterface
 	TagUnmanagedNetworkResources bool
+	OwnershipTagPrefix           string
+	// DriftAuditor reports the attributes of the instances changed outside of the controller when set.
+	DriftAuditor *drift.Auditor
+	// SpotPriceCache holds the spot prices reported in the status of the pools when set.
+	SpotPriceCache *spot.PriceCache
+	// SpotPlacementScoreCache holds the spot placement scores requested by the pools when set.
+	SpotPlacementScoreCache *spot.PlacementScoreCache
+	// GPUCache holds the instance types and images described to check the AMIs of the pools launching instance
+	// types with GPUs.
+	GPUCache *gpu.Cache
+
+	securityGroupFilterCache *scope.SecurityGroupFilterCache
 }
 
 func (r *AWSMachinePoolReconciler) getASGService(scope cloud.ClusterScoper) services.ASGInterface {
@@ -89,9 +134,19 @@ func (r *AWSMachinePoolReconciler) getReconcileService(scope scope.EC2Scope) ser
 	return ec2.NewService(scope)
 }
 
+func (r *AWSMachinePoolReconciler) getSecurityGroupService(scope scope.SGScope) services.SecurityGroupInterface {
+	if r.securityGroupServiceFactory != nil {
+		return r.securityGroupServiceFactory(scope)
+	}
+
+	return securitygroup.NewService(scope, nil)
+}
+
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsmachinepools,verbs=get;list;watch;update;patch;delete
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsmachinepools/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machinepools;machinepools/status,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machines,verbs=get;list;watch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsmachines,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=events,verbs=get;list;watch;create;update;patch
 // +kubebuilder:rbac:groups="",resources=secrets;,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
@@ -128,9 +183,15 @@ func (r *AWSMachinePoolReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		return reconcile.Result{}, nil
 	}
 
+	if annotations.IsPaused(cluster, awsMachinePool) {
+		log.Info("AWSMachinePool or linked Cluster is marked as paused. Won't reconcile")
+		return ctrl.Result{}, infrautilconditions.PatchReconciliationSkipped(ctx, r.Client, r.Recorder, awsMachinePool,
+			infrav1.ReconciliationPausedReason, clusterv1.ConditionSeverityInfo, "AWSMachinePool or linked Cluster is marked as paused")
+	}
+
 	log = log.WithValues("cluster", klog.KObj(cluster))
 
-	infraCluster, err := r.getInfraCluster(ctx, log, cluster, awsMachinePool)
+	infraCluster, err := getInfraCluster(ctx, r.Client, log, cluster, awsMachinePool, r.TagUnmanagedNetworkResources, r.OwnershipTagPrefix)
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("getting infra provider cluster or control plane object: %w", err)
 	}
@@ -147,6 +208,8 @@ func (r *AWSMachinePoolReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		MachinePool:    machinePool,
 		InfraCluster:   infraCluster,
 		AWSMachinePool: awsMachinePool,
+
+		SecurityGroupFilterCache: r.securityGroupFilterCache,
 	})
 	if err != nil {
 		log.Error(err, "failed to create scope")
@@ -175,22 +238,36 @@ func (r *AWSMachinePoolReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	switch infraScope := infraCluster.(type) {
 	case *scope.ManagedControlPlaneScope:
 		if !awsMachinePool.ObjectMeta.DeletionTimestamp.IsZero() {
+			if scope.DeferDeletion(machinePoolScope, infraScope) {
+				return ctrl.Result{RequeueAfter: scope.ReadOnlyRequeueAfter}, nil
+			}
 			return ctrl.Result{}, r.reconcileDelete(machinePoolScope, infraScope, infraScope)
 		}
 
-		return ctrl.Result{}, r.reconcileNormal(ctx, machinePoolScope, infraScope, infraScope)
+		if err := r.reconcileNormal(ctx, machinePoolScope, infraScope, infraScope); err != nil {
+			return ctrl.Result{}, err
+		}
+		return reconcileNormalResult(machinePoolScope), nil
 	case *scope.ClusterScope:
 		if !awsMachinePool.ObjectMeta.DeletionTimestamp.IsZero() {
+			if scope.DeferDeletion(machinePoolScope, infraScope) {
+				return ctrl.Result{RequeueAfter: scope.ReadOnlyRequeueAfter}, nil
+			}
 			return ctrl.Result{}, r.reconcileDelete(machinePoolScope, infraScope, infraScope)
 		}
 
-		return ctrl.Result{}, r.reconcileNormal(ctx, machinePoolScope, infraScope, infraScope)
+		if err := r.reconcileNormal(ctx, machinePoolScope, infraScope, infraScope); err != nil {
+			return ctrl.Result{}, err
+		}
+		return reconcileNormalResult(machinePoolScope), nil
 	default:
 		return ctrl.Result{}, errors.New("infraCluster has unknown type")
 	}
 }
 
 func (r *AWSMachinePoolReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
+	r.securityGroupFilterCache = scope.NewSecurityGroupFilterCache(scope.DefaultSecurityGroupFilterCacheTTL)
+
 	return ctrl.NewControllerManagedBy(mgr).
 		WithOptions(options).
 		For(&expinfrav1.AWSMachinePool{}).
@@ -208,6 +285,8 @@ func (r *AWSMachinePoolReconciler) reconcileNormal(ctx context.Context, machineP
 	// If the AWSMachine is in an error state, return early.
 	if machinePoolScope.HasFailed() {
 		machinePoolScope.Info("Error state detected, skipping reconciliation")
+		infrautilconditions.MarkReconciliationSkipped(r.Recorder, machinePoolScope.AWSMachinePool, infrav1.FailedStateReason, clusterv1.ConditionSeverityWarning,
+			"AWSMachinePool is in a failed state: %s", ptr.Deref(machinePoolScope.AWSMachinePool.Status.FailureMessage, ""))
 
 		// TODO: If we are in a failed state, delete the secret regardless of instance state
 
@@ -225,27 +304,50 @@ func (r *AWSMachinePoolReconciler) reconcileNormal(ctx context.Context, machineP
 	if !machinePoolScope.Cluster.Status.InfrastructureReady {
 		machinePoolScope.Info("Cluster infrastructure is not ready yet")
 		conditions.MarkFalse(machinePoolScope.AWSMachinePool, expinfrav1.ASGReadyCondition, infrav1.WaitingForClusterInfrastructureReason, clusterv1.ConditionSeverityInfo, "")
+		infrautilconditions.MarkReconciliationSkipped(r.Recorder, machinePoolScope.AWSMachinePool, infrav1.WaitingForClusterInfrastructureReason, clusterv1.ConditionSeverityInfo,
+			"Cluster infrastructure is not ready yet")
 		return nil
 	}
 
+	// The ASG of an externally managed pool launches its instances from its own launch template, without the
+	// bootstrap data of the MachinePool.
+	if machinePoolScope.IsExternallyManaged() {
+		return r.reconcileExternallyManaged(ctx, machinePoolScope, clusterScope)
+	}
+	conditions.Delete(machinePoolScope.AWSMachinePool, expinfrav1.ASGExternallyManagedCondition)
+
 	// Make sure bootstrap data is available and populated
 	if machinePoolScope.MachinePool.Spec.Template.Spec.Bootstrap.DataSecretName == nil {
 		machinePoolScope.Info("Bootstrap data secret reference is not yet available")
 		conditions.MarkFalse(machinePoolScope.AWSMachinePool, expinfrav1.ASGReadyCondition, infrav1.WaitingForBootstrapDataReason, clusterv1.ConditionSeverityInfo, "")
+		infrautilconditions.MarkReconciliationSkipped(r.Recorder, machinePoolScope.AWSMachinePool, infrav1.WaitingForBootstrapDataReason, clusterv1.ConditionSeverityInfo,
+			"Bootstrap data secret reference is not yet available")
 		return nil
 	}
 
+	infrautilconditions.ClearReconciliationSkipped(r.Recorder, machinePoolScope.AWSMachinePool)
+
 	ec2Svc := r.getEC2Service(ec2Scope)
 	asgsvc := r.getASGService(clusterScope)
 	reconSvc := r.getReconcileService(ec2Scope)
 
 	// Find existing ASG
 	asg, err := r.findASG(machinePoolScope, asgsvc)
+	if errors.Is(err, errASGCreationPending) {
+		machinePoolScope.Info("Waiting for the ASG to be described", "name", machinePoolScope.Name())
+		conditions.MarkFalse(machinePoolScope.AWSMachinePool, expinfrav1.ASGReadyCondition, expinfrav1.ASGNotFoundReason, clusterv1.ConditionSeverityInfo, err.Error())
+		return nil
+	}
 	if err != nil {
 		conditions.MarkUnknown(machinePoolScope.AWSMachinePool, expinfrav1.ASGReadyCondition, expinfrav1.ASGNotFoundReason, err.Error())
 		return err
 	}
 
+	// An ASG created by other tooling is only changed once it's adopted.
+	if adopted, err := r.reconcileASGAdoption(machinePoolScope, clusterScope, asgsvc, asg); err != nil || !adopted {
+		return err
+	}
+
 	canUpdateLaunchTemplate := func() (bool, error) {
 		// If there is a change: before changing the template, check if there exist an ongoing instance refresh,
 		// because only 1 instance refresh can be "InProgress". If template is updated when refresh cannot be started,
@@ -255,14 +357,46 @@ func (r *AWSMachinePoolReconciler) reconcileNormal(ctx context.Context, machineP
 			// But we want to update the LaunchTemplate because an error in the LaunchTemplate may be blocking the ASG creation.
 			return true, nil
 		}
-		return asgsvc.CanStartASGInstanceRefresh(machinePoolScope)
+		// The instances of the remaining availability zones are refreshed before a newer version is rolled out.
+		if machinePoolScope.AWSMachinePool.Status.AZSequentialRefresh != nil {
+			if refreshPreferences := machinePoolScope.AWSMachinePool.Spec.RefreshPreferences; refreshPreferences != nil && refreshPreferences.CancelOutdatedRefresh {
+				return false, r.cancelAZSequentialRefresh(machinePoolScope, asgsvc, "a newer launch template version is rolled out")
+			}
+			return false, nil
+		}
+		canStart, err := asgsvc.CanStartASGInstanceRefresh(machinePoolScope)
+		if err != nil || canStart {
+			return canStart, err
+		}
+		// The running instance refresh rolls out a launch template version which is about to be outdated.
+		if refreshPreferences := machinePoolScope.AWSMachinePool.Spec.RefreshPreferences; refreshPreferences != nil && refreshPreferences.CancelOutdatedRefresh {
+			return false, r.cancelOutdatedInstanceRefresh(machinePoolScope, asgsvc)
+		}
+		return false, nil
+	}
+	// The launch template is rolled back first, so that its restored version is rolled out like a new one.
+	if err := r.reconcileLaunchTemplateRollback(machinePoolScope, ec2Svc, asgsvc); err != nil {
+		return err
 	}
+
+	previousLaunchTemplateID := machinePoolScope.AWSMachinePool.Status.LaunchTemplateID
+	previousLaunchTemplateVersion := ptr.Deref(machinePoolScope.AWSMachinePool.Status.LaunchTemplateVersion, "")
+	postLaunchTemplateUpdateOperationRan := false
 	runPostLaunchTemplateUpdateOperation := func() error {
 		// skip instance refresh if ASG is not created yet
 		if asg == nil {
 			machinePoolScope.Debug("ASG does not exist yet, skipping instance refresh")
 			return nil
 		}
+		postLaunchTemplateUpdateOperationRan = true
+		// The ASG launches a fixed version of a referenced launch template or of a launch template validated
+		// before use, and references a recreated launch template by its previous ID, so it has to be updated first.
+		if machinePoolScope.AWSMachinePool.Spec.AWSLaunchTemplate.Ref != nil || machinePoolScope.AWSMachinePool.Spec.AWSLaunchTemplate.ValidateBeforeUse ||
+			machinePoolScope.AWSMachinePool.Status.LaunchTemplateID != previousLaunchTemplateID {
+			if err := asgsvc.UpdateASG(machinePoolScope); err != nil {
+				return errors.Wrap(err, "unable to update ASG with the launch template")
+			}
+		}
 		// skip instance refresh if explicitly disabled
 		if machinePoolScope.AWSMachinePool.Spec.RefreshPreferences != nil && machinePoolScope.AWSMachinePool.Spec.RefreshPreferences.Disable {
 			machinePoolScope.Debug("instance refresh disabled, skipping instance refresh")
@@ -270,25 +404,40 @@ func (r *AWSMachinePoolReconciler) reconcileNormal(ctx context.Context, machineP
 		}
 		// After creating a new version of launch template, instance refresh is required
 		// to trigger a rolling replacement of all previously launched instances.
-		// If ONLY the userdata changed, previously launched instances continue to use the old launch
-		// template.
+		// If ONLY the userdata changed, this isn't called unless refreshPreferences.triggerOnUserDataChange
+		// is set, and previously launched instances continue to use the old launch template.
 		//
-		// FIXME(dlipovetsky,sedefsavas): If the controller terminates, or the StartASGInstanceRefresh returns an error,
-		// this conditional will not evaluate to true the next reconcile. If any machines use an older
-		// Launch Template version, and the difference between the older and current versions is _more_
-		// than userdata, we should start an Instance Refresh.
-		machinePoolScope.Info("starting instance refresh", "number of instances", machinePoolScope.MachinePool.Spec.Replicas)
-		return asgsvc.StartASGInstanceRefresh(machinePoolScope)
+		// If the controller terminates, or the StartASGInstanceRefresh returns an error, the launch template version
+		// the last instance refresh was started for is outdated, and reconcileOutdatedInstanceRefresh starts it.
+		return r.startInstanceRefresh(machinePoolScope, asgsvc)
 	}
-	if err := reconSvc.ReconcileLaunchTemplate(machinePoolScope, ec2Svc, canUpdateLaunchTemplate, runPostLaunchTemplateUpdateOperation); err != nil {
-		r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeWarning, "FailedLaunchTemplateReconcile", "Failed to reconcile launch template: %v", err)
-		machinePoolScope.Error(err, "failed to reconcile launch template")
+
+	// The dedicated security group is referenced by the launch template, so it has to exist first.
+	if err := r.reconcileDedicatedSecurityGroup(machinePoolScope, ec2Scope); err != nil {
 		return err
 	}
 
+	r.reconcileCapacityBlock(machinePoolScope, ec2Svc)
+
+	// A rolled back launch template is held at the restored version until the spec changes, as updating it from
+	// the spec would roll it forward again.
+	if machinePoolScope.AWSMachinePool.Status.LaunchTemplateRollback == nil {
+		if err := reconSvc.ReconcileLaunchTemplate(machinePoolScope, ec2Svc, canUpdateLaunchTemplate, runPostLaunchTemplateUpdateOperation); err != nil {
+			r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeWarning, "FailedLaunchTemplateReconcile", "Failed to reconcile launch template: %v", err)
+			machinePoolScope.Error(err, "failed to reconcile launch template")
+			return err
+		}
+		recordPreviousLaunchTemplateVersion(machinePoolScope, previousLaunchTemplateID, previousLaunchTemplateVersion, postLaunchTemplateUpdateOperationRan)
+	}
+
 	// set the LaunchTemplateReady condition
 	conditions.MarkTrue(machinePoolScope.AWSMachinePool, expinfrav1.LaunchTemplateReadyCondition)
 
+	if err := gpu.NewService(ec2Scope, r.GPUCache).ReconcileGPUCompatibility(machinePoolScope); err != nil {
+		// non fatal error, so we continue
+		machinePoolScope.Error(err, "non-fatal: failed to check the GPU compatibility of the AMI")
+	}
+
 	if asg == nil {
 		// Create new ASG
 		if err := r.createPool(machinePoolScope, clusterScope); err != nil {
@@ -298,35 +447,117 @@ func (r *AWSMachinePoolReconciler) reconcileNormal(ctx context.Context, machineP
 		return nil
 	}
 
+	reportSuspendedProcesses(machinePoolScope, asg)
+
+	// Instances are moved in and out of standby first, so that the desired capacity of the ASG isn't reverted.
+	r.reconcileStandby(machinePoolScope, asgsvc, asg)
+
 	if annotations.ReplicasManagedByExternalAutoscaler(machinePoolScope.MachinePool) {
-		// Set MachinePool replicas to the ASG DesiredCapacity
-		if *machinePoolScope.MachinePool.Spec.Replicas != *asg.DesiredCapacity {
+		// Set MachinePool replicas to the ASG DesiredCapacity, which the instances put in standby were left out of.
+		desiredCapacity := *asg.DesiredCapacity + machinePoolScope.StandbyInstanceCount()
+		if *machinePoolScope.MachinePool.Spec.Replicas != desiredCapacity {
 			machinePoolScope.Info("Setting MachinePool replicas to ASG DesiredCapacity",
 				"local", machinePoolScope.MachinePool.Spec.Replicas,
-				"external", asg.DesiredCapacity)
-			machinePoolScope.MachinePool.Spec.Replicas = asg.DesiredCapacity
+				"external", desiredCapacity)
+			machinePoolScope.MachinePool.Spec.Replicas = &desiredCapacity
 			if err := machinePoolScope.PatchCAPIMachinePoolObject(ctx); err != nil {
 				return err
 			}
 		}
 	}
 
+	// The instances of the Machines marked for deletion are removed first by a scale-in.
+	markedInstances, err := r.reconcileDeleteMachineAnnotation(ctx, machinePoolScope, asgsvc, asg)
+	if err != nil {
+		machinePoolScope.Error(err, "error terminating the instances of the Machines marked for deletion")
+		return err
+	}
+
+	// The instances removed by a scale-in are drained and terminated before the desired capacity of the ASG is updated.
+	if err := r.reconcileScaleInDrain(ctx, machinePoolScope, asgsvc, asg, markedInstances); err != nil {
+		machinePoolScope.Error(err, "error terminating the instances removed by the scale-in")
+		return err
+	}
+
+	// The availability zones lacking capacity are excluded first, so that the subnets of the ASG are updated.
+	if machinePoolScope.AWSMachinePool.Spec.AZFailureHandling != nil || len(machinePoolScope.AWSMachinePool.Status.ExcludedAvailabilityZones) > 0 {
+		if err := asgsvc.ReconcileAZFailures(machinePoolScope); err != nil {
+			// non fatal error, so we continue
+			machinePoolScope.Error(err, "non-fatal: failed to reconcile the availability zones lacking capacity")
+		}
+	}
+
 	if err := r.updatePool(machinePoolScope, clusterScope, asg); err != nil {
 		machinePoolScope.Error(err, "error updating AWSMachinePool")
 		return err
 	}
 
+	// Launch templates of removed instance type overrides can only be deleted once the ASG no longer references them.
+	if err := r.deleteOverrideLaunchTemplates(machinePoolScope, ec2Svc, false); err != nil {
+		machinePoolScope.Error(err, "error deleting launch templates of removed instance type overrides")
+		return err
+	}
+
+	// Only a change of the launch template beyond its userdata rolls out its new version to the instances.
+	keepInstances := ptr.Deref(machinePoolScope.AWSMachinePool.Status.LaunchTemplateVersion, "") != previousLaunchTemplateVersion && !postLaunchTemplateUpdateOperationRan
+	if err := r.reconcileOutdatedInstanceRefresh(machinePoolScope, asgsvc, keepInstances); err != nil {
+		machinePoolScope.Error(err, "error starting instance refresh of the latest launch template version")
+		return err
+	}
+
+	// Instances left on an older launch template version are replaced, unless it only differs in the userdata.
+	if !postLaunchTemplateUpdateOperationRan {
+		if err := r.reconcileOutdatedInstances(machinePoolScope, ec2Svc, asgsvc, asg); err != nil {
+			machinePoolScope.Error(err, "error replacing instances running an outdated launch template version")
+			return err
+		}
+	}
+
+	if err := r.reconcileAZSequentialRefresh(ctx, machinePoolScope, asgsvc, asg); err != nil {
+		machinePoolScope.Error(err, "error refreshing the instances of an availability zone")
+		return err
+	}
+
+	// The lifecycle hook is only reconciled once node termination handling is configured on the AWSCluster or
+	// the AWSManagedControlPlane, so that Auto Scaling groups of clusters not using it are left untouched.
+	if nthScope, ok := clusterScope.(scope.NodeTerminationHandlingScope); ok && nthScope.NodeTerminationHandling() != nil {
+		hookEnabled := nthScope.NodeTerminationHandling().HasRule(infrav1.NodeTerminationHandlingRuleASGLifecycle)
+		if err := asgsvc.ReconcileNodeTerminationLifecycleHook(machinePoolScope.Name(), hookEnabled); err != nil {
+			machinePoolScope.Error(err, "error reconciling node termination lifecycle hook")
+			return err
+		}
+	}
+
+	if err := r.reconcileLifecycleHooks(machinePoolScope, asgsvc); err != nil {
+		machinePoolScope.Error(err, "error reconciling lifecycle hooks")
+		return err
+	}
+
+	if err := r.reconcileScalingPolicies(machinePoolScope, asgsvc); err != nil {
+		machinePoolScope.Error(err, "error reconciling scaling policies")
+		return err
+	}
+
+	if err := r.reconcileWarmPool(machinePoolScope, asgsvc); err != nil {
+		machinePoolScope.Error(err, "error reconciling warm pool")
+		return err
+	}
+
 	launchTemplateID := machinePoolScope.GetLaunchTemplateIDStatus()
 	asgName := machinePoolScope.Name()
-	resourceServiceToUpdate := []scope.ResourceServiceToUpdate{
-		{
+	resourceServiceToUpdate := []scope.ResourceServiceToUpdate{}
+	// A referenced launch template is managed outside of the controller, its tags are left untouched.
+	if machinePoolScope.AWSMachinePool.Spec.AWSLaunchTemplate.Ref == nil {
+		resourceServiceToUpdate = append(resourceServiceToUpdate, scope.ResourceServiceToUpdate{
 			ResourceID:      &launchTemplateID,
 			ResourceService: ec2Svc,
-		},
-		{
+		})
+	}
+	if !machinePoolScope.AWSMachinePool.Spec.IsUnmanaged(expinfrav1.UnmanagedFieldTags) {
+		resourceServiceToUpdate = append(resourceServiceToUpdate, scope.ResourceServiceToUpdate{
 			ResourceID:      &asgName,
 			ResourceService: asgsvc,
-		},
+		})
 	}
 	err = reconSvc.ReconcileTags(machinePoolScope, resourceServiceToUpdate)
 	if err != nil {
@@ -335,10 +566,18 @@ func (r *AWSMachinePoolReconciler) reconcileNormal(ctx context.Context, machineP
 
 	// Make sure Spec.ProviderID is always set.
 	machinePoolScope.AWSMachinePool.Spec.ProviderID = asg.ID
-	providerIDList := make([]string, len(asg.Instances))
+	providerIDList := make([]string, 0, len(asg.Instances))
 
-	for i, ec2 := range asg.Instances {
-		providerIDList[i] = fmt.Sprintf("aws:///%s/%s", ec2.AvailabilityZone, ec2.ID)
+	for _, ec2 := range asg.Instances {
+		// Instances in standby are out of service, they are listed again once they return to service.
+		if isStandby(ec2) {
+			continue
+		}
+		// Instances of the warm pool aren't nodes of the cluster until they leave it.
+		if isWarmed(ec2) {
+			continue
+		}
+		providerIDList = append(providerIDList, fmt.Sprintf("aws:///%s/%s", ec2.AvailabilityZone, ec2.ID))
 	}
 
 	machinePoolScope.SetAnnotation("cluster-api-provider-aws", "true")
@@ -348,17 +587,51 @@ func (r *AWSMachinePoolReconciler) reconcileNormal(ctx context.Context, machineP
 	machinePoolScope.AWSMachinePool.Status.Ready = true
 	conditions.MarkTrue(machinePoolScope.AWSMachinePool, expinfrav1.ASGReadyCondition)
 
-	err = machinePoolScope.UpdateInstanceStatuses(ctx, asg.Instances)
+	err = machinePoolScope.UpdateInstanceStatuses(ctx, asg)
 	if err != nil {
 		machinePoolScope.Error(err, "failed updating instances", "instances", asg.Instances)
 	}
 
-	return nil
+	if err := spot.NewService(ec2Scope, r.SpotPriceCache).ReconcileCapacityMix(machinePoolScope.AWSMachinePool, asg); err != nil {
+		// non fatal error, so we continue
+		machinePoolScope.Error(err, "non-fatal: failed to report the capacity mix")
+	}
+
+	if err := r.reconcileCapacityProbe(machinePoolScope, ec2Scope, asgsvc); err != nil {
+		// non fatal error, so we continue
+		machinePoolScope.Error(err, "non-fatal: failed to probe the capacity")
+	}
+
+	if err := r.reconcileSpotPlacementScores(machinePoolScope, ec2Scope); err != nil {
+		// non fatal error, so we continue
+		machinePoolScope.Error(err, "non-fatal: failed to fetch the spot placement scores")
+	}
+
+	if err := asgsvc.ReconcileScaleEvents(machinePoolScope); err != nil {
+		// non fatal error, so we continue
+		machinePoolScope.Error(err, "non-fatal: failed to report the scaling activities")
+	}
+
+	if err := r.reconcileInstanceRefreshStatus(machinePoolScope, asgsvc); err != nil {
+		// non fatal error, so we continue
+		machinePoolScope.Error(err, "non-fatal: failed to report the progress of the instance refresh")
+	}
+
+	r.reconcileInstanceDrift(machinePoolScope, ec2Scope, ec2Svc, asg.Instances)
+
+	return r.reconcileLifecycleActions(ctx, machinePoolScope, asgsvc, asg.Instances)
 }
 
 func (r *AWSMachinePoolReconciler) reconcileDelete(machinePoolScope *scope.MachinePoolScope, clusterScope cloud.ClusterScoper, ec2Scope scope.EC2Scope) error {
 	clusterScope.Info("Handling deleted AWSMachinePool")
 
+	// The ASG and the launch template of an externally managed pool are left to the tooling managing them.
+	if machinePoolScope.IsExternallyManaged() {
+		machinePoolScope.Info("AWSMachinePool is externally managed, keeping the ASG and the launch template")
+		controllerutil.RemoveFinalizer(machinePoolScope.AWSMachinePool, expinfrav1.MachinePoolFinalizer)
+		return nil
+	}
+
 	ec2Svc := r.getEC2Service(ec2Scope)
 	asgSvc := r.getASGService(clusterScope)
 
@@ -372,13 +645,25 @@ func (r *AWSMachinePoolReconciler) reconcileDelete(machinePoolScope *scope.Machi
 		r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeNormal, expinfrav1.ASGNotFoundReason, "Unable to find matching ASG")
 	} else {
 		machinePoolScope.SetASGStatus(asg.Status)
-		switch asg.Status {
-		case expinfrav1.ASGStatusDeleteInProgress:
+		switch {
+		case asg.Status == expinfrav1.ASGStatusDeleteInProgress:
 			// ASG is already deleting
 			machinePoolScope.SetNotReady()
 			conditions.MarkFalse(machinePoolScope.AWSMachinePool, expinfrav1.ASGReadyCondition, expinfrav1.ASGDeletionInProgress, clusterv1.ConditionSeverityWarning, "")
 			r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeWarning, "DeletionInProgress", "ASG deletion in progress: %q", asg.Name)
 			machinePoolScope.Info("ASG is already deleting", "name", asg.Name)
+		case !asg.Tags.HasOwned(clusterScope.KubernetesClusterName(), clusterScope.OwnershipTagPrefixes()...):
+			// An ASG which was never adopted is left to the tooling which created it.
+			machinePoolScope.Info("ASG isn't owned by the cluster, keeping it", "name", asg.Name)
+			r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeNormal, expinfrav1.ASGNotOwnedReason, "Keeping ASG %q which isn't owned by the cluster", asg.Name)
+		case machinePoolScope.AWSMachinePool.Spec.PreserveOnDelete && machinePoolScope.AWSMachinePool.Annotations[expinfrav1.AdoptedASGOriginalSizeAnnotation] != "":
+			// The preserved ASG keeps launching its instances from the launch template of the pool, so neither
+			// the launch templates nor the security group they reference are deleted.
+			if err := r.releaseAdoptedASG(machinePoolScope, asgSvc, asg); err != nil {
+				return err
+			}
+			controllerutil.RemoveFinalizer(machinePoolScope.AWSMachinePool, expinfrav1.MachinePoolFinalizer)
+			return nil
 		default:
 			machinePoolScope.Info("Deleting ASG", "id", asg.Name, "status", asg.Status)
 			if err := asgSvc.DeleteASGAndWait(asg.Name); err != nil {
@@ -388,7 +673,21 @@ func (r *AWSMachinePoolReconciler) reconcileDelete(machinePoolScope *scope.Machi
 		}
 	}
 
-	launchTemplateID := machinePoolScope.AWSMachinePool.Status.LaunchTemplateID
+	if err := r.deleteOverrideLaunchTemplates(machinePoolScope, ec2Svc, true); err != nil {
+		return err
+	}
+
+	if err := r.deleteDedicatedSecurityGroup(machinePoolScope, ec2Scope); err != nil {
+		return err
+	}
+
+	// A referenced launch template is managed outside of the controller and is never deleted.
+	if machinePoolScope.AWSMachinePool.Spec.AWSLaunchTemplate.Ref != nil {
+		machinePoolScope.Info("successfully deleted AutoScalingGroup, keeping the referenced launch template")
+		controllerutil.RemoveFinalizer(machinePoolScope.AWSMachinePool, expinfrav1.MachinePoolFinalizer)
+		return nil
+	}
+
 	launchTemplate, _, _, err := ec2Svc.GetLaunchTemplate(machinePoolScope.LaunchTemplateName())
 	if err != nil {
 		return err
@@ -402,9 +701,8 @@ func (r *AWSMachinePoolReconciler) reconcileDelete(machinePoolScope *scope.Machi
 	}
 
 	machinePoolScope.Info("deleting launch template", "name", launchTemplate.Name)
-	if err := ec2Svc.DeleteLaunchTemplate(launchTemplateID); err != nil {
-		r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeWarning, "FailedDelete", "Failed to delete launch template %q: %v", launchTemplate.Name, err)
-		return errors.Wrap(err, "failed to delete ASG")
+	if err := deleteLaunchTemplate(ec2Svc, r.Recorder, machinePoolScope.AWSMachinePool, machinePoolScope.AWSMachinePool.Status.LaunchTemplateID, launchTemplate.Name); err != nil {
+		return err
 	}
 
 	machinePoolScope.Info("successfully deleted AutoScalingGroup and Launch Template")
@@ -415,137 +713,1709 @@ func (r *AWSMachinePoolReconciler) reconcileDelete(machinePoolScope *scope.Machi
 	return nil
 }
 
-func (r *AWSMachinePoolReconciler) updatePool(machinePoolScope *scope.MachinePoolScope, clusterScope cloud.ClusterScoper, existingASG *expinfrav1.AutoScalingGroup) error {
-	asgSvc := r.getASGService(clusterScope)
+// reconcileDedicatedSecurityGroup reconciles the security group owned by the machine pool, when enabled.
+func (r *AWSMachinePoolReconciler) reconcileDedicatedSecurityGroup(machinePoolScope *scope.MachinePoolScope, ec2Scope scope.EC2Scope) error {
+	dedicatedSG := machinePoolScope.GetDedicatedSecurityGroup()
+	if dedicatedSG == nil || !dedicatedSG.Enabled {
+		return nil
+	}
 
-	subnetIDs, err := asgSvc.SubnetIDs(machinePoolScope)
-	if err != nil {
-		return errors.Wrapf(err, "fail to get subnets for ASG")
+	sgScope, ok := ec2Scope.(scope.SGScope)
+	if !ok {
+		return errors.New("infraCluster does not manage security groups")
 	}
-	machinePoolScope.Debug("determining if subnets change in machinePoolScope",
-		"subnets of machinePoolScope", subnetIDs,
-		"subnets of existing asg", existingASG.Subnets)
-	less := func(a, b string) bool { return a < b }
-	subnetDiff := cmp.Diff(subnetIDs, existingASG.Subnets, cmpopts.SortSlices(less))
-	if subnetDiff != "" {
-		machinePoolScope.Debug("asg subnet diff detected", "diff", subnetDiff)
+
+	if err := r.getSecurityGroupService(sgScope).ReconcileDedicatedSecurityGroup(machinePoolScope); err != nil {
+		r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeWarning, "FailedDedicatedSecurityGroupReconcile", "Failed to reconcile dedicated security group: %v", err)
+		return errors.Wrap(err, "failed to reconcile dedicated security group")
 	}
+	return nil
+}
 
-	asgDiff := diffASG(machinePoolScope, existingASG)
-	if asgDiff != "" {
-		machinePoolScope.Debug("asg diff detected", "asgDiff", asgDiff, "subnetDiff", subnetDiff)
+// reconcileCapacityBlock reports the state and the window of the capacity block of the launch template in the
+// status of the machine pool, and whether the current time is outside of its window. The previous status is kept
+// when the capacity block can't be described.
+func (r *AWSMachinePoolReconciler) reconcileCapacityBlock(machinePoolScope *scope.MachinePoolScope, ec2Svc services.EC2Interface) {
+	defer reportCapacityBlockWindow(r.Recorder, machinePoolScope.AWSMachinePool)
+
+	lt := machinePoolScope.AWSMachinePool.Spec.AWSLaunchTemplate
+	if lt.MarketType != infrav1.MarketTypeCapacityBlock || ptr.Deref(lt.CapacityReservationID, "") == "" {
+		machinePoolScope.AWSMachinePool.Status.CapacityBlock = nil
+		return
 	}
-	if asgDiff != "" || subnetDiff != "" {
-		machinePoolScope.Info("updating AutoScalingGroup")
 
-		if err := asgSvc.UpdateASG(machinePoolScope); err != nil {
-			r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeWarning, "FailedUpdate", "Failed to update ASG: %v", err)
-			return errors.Wrap(err, "unable to update ASG")
+	capacityBlock, err := ec2Svc.DescribeCapacityBlock(*lt.CapacityReservationID)
+	if err != nil {
+		// non fatal error, so we continue
+		machinePoolScope.Error(err, "non-fatal: failed to describe capacity block")
+		if previous := machinePoolScope.AWSMachinePool.Status.CapacityBlock; previous != nil && previous.CapacityReservationID != *lt.CapacityReservationID {
+			machinePoolScope.AWSMachinePool.Status.CapacityBlock = nil
 		}
+		return
 	}
+	machinePoolScope.AWSMachinePool.Status.CapacityBlock = capacityBlock
+}
 
-	suspendedProcessesSlice := machinePoolScope.AWSMachinePool.Spec.SuspendProcesses.ConvertSetValuesToStringSlice()
-	if !cmp.Equal(existingASG.CurrentlySuspendProcesses, suspendedProcessesSlice) {
-		clusterScope.Info("reconciling processes", "suspend-processes", suspendedProcessesSlice)
-		var (
-			toBeSuspended []string
-			toBeResumed   []string
+// reportCapacityBlockWindow sets the CapacityBlockOutsideWindowCondition while the current time is outside of the
+// window of the capacity block of the pool, and emits an event when the pool leaves or enters the window.
+func reportCapacityBlockWindow(recorder record.EventRecorder, pool *expinfrav1.AWSMachinePool) {
+	capacityBlock := pool.Status.CapacityBlock
+	outside := conditions.Has(pool, expinfrav1.CapacityBlockOutsideWindowCondition)
+	if capacityBlock == nil || capacityBlock.InWindow(time.Now()) {
+		if outside && capacityBlock != nil {
+			recorder.Eventf(pool, corev1.EventTypeNormal, "CapacityBlockInWindow",
+				"Capacity block %q is in its window (%s), resuming instance refreshes", capacityBlock.CapacityReservationID, capacityBlockWindow(capacityBlock))
+		}
+		conditions.Delete(pool, expinfrav1.CapacityBlockOutsideWindowCondition)
+		return
+	}
 
-			currentlySuspended = make(map[string]struct{})
-			desiredSuspended   = make(map[string]struct{})
-		)
+	if !outside {
+		recorder.Eventf(pool, corev1.EventTypeWarning, "CapacityBlockOutsideWindow",
+			"Deferring instance refreshes outside of the window of capacity block %q (%s)", capacityBlock.CapacityReservationID, capacityBlockWindow(capacityBlock))
+	}
+	conditions.MarkTrueWithNegativePolarity(pool, expinfrav1.CapacityBlockOutsideWindowCondition, expinfrav1.CapacityBlockOutsideWindowReason, clusterv1.ConditionSeverityWarning,
+		"Instance refreshes are deferred until the window of capacity block %q (%s)", capacityBlock.CapacityReservationID, capacityBlockWindow(capacityBlock))
+}
 
-		// Convert the items to a map, so it's easy to create an effective diff from these two slices.
-		for _, p := range existingASG.CurrentlySuspendProcesses {
-			currentlySuspended[p] = struct{}{}
+// capacityBlockWindow formats the window of a capacity block.
+func capacityBlockWindow(capacityBlock *expinfrav1.CapacityBlockStatus) string {
+	window := func(t *metav1.Time) string {
+		if t == nil {
+			return "unknown"
 		}
+		return t.UTC().Format(time.RFC3339)
+	}
+	return fmt.Sprintf("from %s to %s", window(capacityBlock.StartTime), window(capacityBlock.EndTime))
+}
 
-		for _, p := range suspendedProcessesSlice {
-			desiredSuspended[p] = struct{}{}
-		}
+// reconcileASGAdoption returns whether the ASG of the machine pool can be changed. An existing ASG named after the
+// pool which isn't tagged as owned by the cluster was created by other tooling: it's left untouched, and the
+// ASGAdoptionPendingCondition is set, until the pool is annotated with the AdoptASGAnnotation. The ASG is then tagged
+// as owned by the cluster, and its sizes are recorded in the AdoptedASGOriginalSizeAnnotation so that they can be
+// restored if the ASG is preserved on deletion.
+func (r *AWSMachinePoolReconciler) reconcileASGAdoption(machinePoolScope *scope.MachinePoolScope, clusterScope cloud.ClusterScoper, asgsvc services.ASGInterface, asg *expinfrav1.AutoScalingGroup) (bool, error) {
+	pool := machinePoolScope.AWSMachinePool
+	if asg == nil || asg.Tags.HasOwned(clusterScope.KubernetesClusterName(), clusterScope.OwnershipTagPrefixes()...) {
+		conditions.Delete(pool, expinfrav1.ASGAdoptionPendingCondition)
+		return true, nil
+	}
 
-		// Anything that remains in the desired items is not currently suspended so must be suspended.
-		// Anything that remains in the currentlySuspended list must be resumed since they were not part of
-		// desiredSuspended.
-		for k := range desiredSuspended {
-			if _, ok := currentlySuspended[k]; ok {
-				delete(desiredSuspended, k)
-			}
-			delete(currentlySuspended, k)
+	if pool.Annotations[expinfrav1.AdoptASGAnnotation] != "true" {
+		if !conditions.Has(pool, expinfrav1.ASGAdoptionPendingCondition) {
+			r.Recorder.Eventf(pool, corev1.EventTypeWarning, expinfrav1.ASGNotOwnedReason,
+				"ASG %q isn't owned by the cluster, annotate the AWSMachinePool with %s=true to adopt it", asg.Name, expinfrav1.AdoptASGAnnotation)
 		}
+		machinePoolScope.Info("ASG isn't owned by the cluster, waiting for its adoption", "name", asg.Name)
+		machinePoolScope.SetNotReady()
+		conditions.MarkTrueWithNegativePolarity(pool, expinfrav1.ASGAdoptionPendingCondition, expinfrav1.ASGNotOwnedReason, clusterv1.ConditionSeverityWarning,
+			"ASG %q isn't owned by the cluster, annotate the AWSMachinePool with %s=true to adopt it", asg.Name, expinfrav1.AdoptASGAnnotation)
+		conditions.MarkFalse(pool, expinfrav1.ASGReadyCondition, expinfrav1.ASGNotOwnedReason, clusterv1.ConditionSeverityWarning,
+			"ASG %q isn't owned by the cluster", asg.Name)
+		return false, nil
+	}
 
-		// Convert them back into lists to pass them to resume/suspend.
-		for k := range desiredSuspended {
-			toBeSuspended = append(toBeSuspended, k)
-		}
+	// The original sizes are kept when a previous adoption failed after recording them.
+	if _, ok := pool.Annotations[expinfrav1.AdoptedASGOriginalSizeAnnotation]; !ok {
+		pool.Annotations[expinfrav1.AdoptedASGOriginalSizeAnnotation] = fmt.Sprintf("%d/%d/%d", asg.MinSize, asg.MaxSize, ptr.Deref(asg.DesiredCapacity, 0))
+	}
+	if err := asgsvc.AdoptASG(machinePoolScope); err != nil {
+		r.Recorder.Eventf(pool, corev1.EventTypeWarning, "FailedAdoptASG", "Failed to adopt ASG %q: %v", asg.Name, err)
+		return false, errors.Wrapf(err, "failed to adopt ASG %q", asg.Name)
+	}
+	delete(pool.Annotations, expinfrav1.AdoptASGAnnotation)
+	conditions.Delete(pool, expinfrav1.ASGAdoptionPendingCondition)
+	r.Recorder.Eventf(pool, corev1.EventTypeNormal, "ASGAdopted", "Adopted ASG %q with sizes %s", asg.Name, pool.Annotations[expinfrav1.AdoptedASGOriginalSizeAnnotation])
+	return true, nil
+}
 
-		for k := range currentlySuspended {
-			toBeResumed = append(toBeResumed, k)
-		}
+// releaseAdoptedASG restores the sizes an adopted ASG had before its adoption and removes its ownership tags, so
+// that it's left to other tooling when the machine pool is deleted.
+func (r *AWSMachinePoolReconciler) releaseAdoptedASG(machinePoolScope *scope.MachinePoolScope, asgsvc services.ASGInterface, asg *expinfrav1.AutoScalingGroup) error {
+	originalSize := machinePoolScope.AWSMachinePool.Annotations[expinfrav1.AdoptedASGOriginalSizeAnnotation]
+	var minSize, maxSize, desiredCapacity int32
+	if _, err := fmt.Sscanf(originalSize, "%d/%d/%d", &minSize, &maxSize, &desiredCapacity); err != nil {
+		return errors.Wrapf(err, "invalid value %q of annotation %s", originalSize, expinfrav1.AdoptedASGOriginalSizeAnnotation)
+	}
 
-		if len(toBeSuspended) > 0 {
-			clusterScope.Info("suspending processes", "processes", toBeSuspended)
-			if err := asgSvc.SuspendProcesses(existingASG.Name, toBeSuspended); err != nil {
-				return errors.Wrapf(err, "failed to suspend processes while trying update pool")
-			}
-		}
-		if len(toBeResumed) > 0 {
-			clusterScope.Info("resuming processes", "processes", toBeResumed)
-			if err := asgSvc.ResumeProcesses(existingASG.Name, toBeResumed); err != nil {
-				return errors.Wrapf(err, "failed to resume processes while trying update pool")
-			}
-		}
+	machinePoolScope.Info("Preserving adopted ASG", "name", asg.Name, "minSize", minSize, "maxSize", maxSize, "desiredCapacity", desiredCapacity)
+	if err := asgsvc.ReleaseASG(asg, minSize, maxSize, desiredCapacity); err != nil {
+		r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeWarning, "FailedPreserveASG", "Failed to preserve ASG %q: %v", asg.Name, err)
+		return errors.Wrap(err, "failed to preserve ASG")
 	}
+	r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeNormal, "ASGPreserved", "Preserved ASG %q with sizes %s", asg.Name, originalSize)
 	return nil
 }
 
-func (r *AWSMachinePoolReconciler) createPool(machinePoolScope *scope.MachinePoolScope, clusterScope cloud.ClusterScoper) error {
-	clusterScope.Info("Initializing ASG client")
+// reconcileExternallyManaged reports the state of the ASG of an externally managed pool without changing it: the
+// replicas of the MachinePool follow the desired capacity of the ASG, and the status lists its instances, but the
+// ASG and its launch template are never created, updated or deleted.
+func (r *AWSMachinePoolReconciler) reconcileExternallyManaged(ctx context.Context, machinePoolScope *scope.MachinePoolScope, clusterScope cloud.ClusterScoper) error {
+	infrautilconditions.ClearReconciliationSkipped(r.Recorder, machinePoolScope.AWSMachinePool)
+	conditions.MarkTrueWithNegativePolarity(machinePoolScope.AWSMachinePool, expinfrav1.ASGExternallyManagedCondition, expinfrav1.ExternallyManagedReason, clusterv1.ConditionSeverityInfo,
+		"The ASG %q is externally managed, CAPA only reports its state", machinePoolScope.Name())
+	// The launch template isn't reconciled, its readiness is only reported by the ASG.
+	conditions.Delete(machinePoolScope.AWSMachinePool, expinfrav1.LaunchTemplateReadyCondition)
 
 	asgsvc := r.getASGService(clusterScope)
+	asg, err := asgsvc.GetASGByName(machinePoolScope)
+	if err != nil {
+		conditions.MarkUnknown(machinePoolScope.AWSMachinePool, expinfrav1.ASGReadyCondition, expinfrav1.ASGNotFoundReason, err.Error())
+		return errors.Wrapf(err, "failed to query AWSMachinePool by name")
+	}
+	if asg == nil {
+		machinePoolScope.Info("Waiting for the externally managed ASG to exist", "name", machinePoolScope.Name())
+		machinePoolScope.SetNotReady()
+		conditions.MarkFalse(machinePoolScope.AWSMachinePool, expinfrav1.ASGReadyCondition, expinfrav1.ASGNotFoundReason, clusterv1.ConditionSeverityWarning,
+			"The externally managed ASG %q doesn't exist", machinePoolScope.Name())
+		return nil
+	}
+	machinePoolScope.SetASGDescribed(asg)
 
-	machinePoolScope.Info("Creating Autoscaling Group")
-	if _, err := asgsvc.CreateASG(machinePoolScope); err != nil {
-		return errors.Wrapf(err, "failed to create AWSMachinePool")
+	reportSuspendedProcesses(machinePoolScope, asg)
+
+	if asg.DesiredCapacity != nil && ptr.Deref(machinePoolScope.MachinePool.Spec.Replicas, -1) != *asg.DesiredCapacity {
+		machinePoolScope.Info("Setting MachinePool replicas to the desired capacity of the externally managed ASG",
+			"local", machinePoolScope.MachinePool.Spec.Replicas,
+			"external", *asg.DesiredCapacity)
+		machinePoolScope.MachinePool.Spec.Replicas = ptr.To(*asg.DesiredCapacity)
+		if err := machinePoolScope.PatchCAPIMachinePoolObject(ctx); err != nil {
+			return err
+		}
+	}
+
+	machinePoolScope.AWSMachinePool.Spec.ProviderID = asg.ID
+	providerIDList := make([]string, 0, len(asg.Instances))
+	for _, instance := range asg.Instances {
+		if isStandby(instance) || isWarmed(instance) {
+			continue
+		}
+		providerIDList = append(providerIDList, fmt.Sprintf("aws:///%s/%s", instance.AvailabilityZone, instance.ID))
 	}
+	machinePoolScope.AWSMachinePool.Spec.ProviderIDList = providerIDList
+	machinePoolScope.AWSMachinePool.Status.Replicas = int32(len(providerIDList))
+	machinePoolScope.AWSMachinePool.Status.Ready = true
+	conditions.MarkTrue(machinePoolScope.AWSMachinePool, expinfrav1.ASGReadyCondition)
 
+	if err := machinePoolScope.UpdateInstanceStatuses(ctx, asg); err != nil {
+		machinePoolScope.Error(err, "failed updating instances", "instances", asg.Instances)
+	}
 	return nil
 }
 
-func (r *AWSMachinePoolReconciler) findASG(machinePoolScope *scope.MachinePoolScope, asgsvc services.ASGInterface) (*expinfrav1.AutoScalingGroup, error) {
-	// Query the instance using tags.
-	asg, err := asgsvc.GetASGByName(machinePoolScope)
+// deleteLaunchTemplate deletes a launch template by the ID recorded in the status, or by name when no ID is recorded,
+// as the status may be lost, e.g. when the object is restored from a backup. When the deletion fails, an event warns
+// that the launch template may have to be cleaned up manually.
+func deleteLaunchTemplate(ec2Svc services.EC2Interface, recorder record.EventRecorder, obj client.Object, id, name string) error {
+	var err error
+	if id != "" {
+		err = ec2Svc.DeleteLaunchTemplate(id)
+	} else {
+		err = ec2Svc.DeleteLaunchTemplateByName(name)
+	}
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to query AWSMachinePool by name")
+		recorder.Eventf(obj, corev1.EventTypeWarning, "LaunchTemplateLeaked", "Failed to delete launch template %q, manual cleanup may be required: %v", name, err)
+		return errors.Wrapf(err, "failed to delete launch template %q", name)
+	}
+	return nil
+}
+
+// deleteDedicatedSecurityGroup deletes the security group owned by the machine pool, once its instances are gone.
+func (r *AWSMachinePoolReconciler) deleteDedicatedSecurityGroup(machinePoolScope *scope.MachinePoolScope, ec2Scope scope.EC2Scope) error {
+	if machinePoolScope.GetDedicatedSecurityGroup() == nil && machinePoolScope.GetDedicatedSecurityGroupIDStatus() == "" {
+		return nil
 	}
 
-	return asg, nil
+	sgScope, ok := ec2Scope.(scope.SGScope)
+	if !ok {
+		return errors.New("infraCluster does not manage security groups")
+	}
+
+	if err := r.getSecurityGroupService(sgScope).DeleteDedicatedSecurityGroup(machinePoolScope); err != nil {
+		r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeWarning, "FailedDelete", "Failed to delete dedicated security group: %v", err)
+		return errors.Wrap(err, "failed to delete dedicated security group")
+	}
+	return nil
 }
 
-// diffASG compares incoming AWSMachinePool and compares against existing ASG.
-func diffASG(machinePoolScope *scope.MachinePoolScope, existingASG *expinfrav1.AutoScalingGroup) string {
-	detectedMachinePoolSpec := machinePoolScope.MachinePool.Spec.DeepCopy()
+// reconcileLifecycleHooks reconciles the lifecycle hooks of the spec on the ASG. The ASGs of machine pools which
+// never configured lifecycle hooks are left untouched.
+func (r *AWSMachinePoolReconciler) reconcileLifecycleHooks(machinePoolScope *scope.MachinePoolScope, asgsvc services.ASGInterface) error {
+	hooks := machinePoolScope.AWSMachinePool.Spec.LifecycleHooks
+	if len(hooks) == 0 && !conditions.Has(machinePoolScope.AWSMachinePool, expinfrav1.LifecycleHooksReadyCondition) {
+		return nil
+	}
 
-	if !annotations.ReplicasManagedByExternalAutoscaler(machinePoolScope.MachinePool) {
-		detectedMachinePoolSpec.Replicas = existingASG.DesiredCapacity
+	if err := asgsvc.ReconcileLifecycleHooks(machinePoolScope.Name(), hooks); err != nil {
+		r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeWarning, "FailedLifecycleHooksReconcile", "Failed to reconcile lifecycle hooks: %v", err)
+		conditions.MarkFalse(machinePoolScope.AWSMachinePool, expinfrav1.LifecycleHooksReadyCondition, expinfrav1.LifecycleHooksReconciliationFailedReason, clusterv1.ConditionSeverityError, err.Error())
+		return err
 	}
-	if diff := cmp.Diff(machinePoolScope.MachinePool.Spec, *detectedMachinePoolSpec); diff != "" {
-		return diff
+
+	if len(hooks) == 0 {
+		conditions.Delete(machinePoolScope.AWSMachinePool, expinfrav1.LifecycleHooksReadyCondition)
+		return nil
 	}
+	conditions.MarkTrue(machinePoolScope.AWSMachinePool, expinfrav1.LifecycleHooksReadyCondition)
+	return nil
+}
 
-	detectedAWSMachinePoolSpec := machinePoolScope.AWSMachinePool.Spec.DeepCopy()
-	detectedAWSMachinePoolSpec.MaxSize = existingASG.MaxSize
-	detectedAWSMachinePoolSpec.MinSize = existingASG.MinSize
-	detectedAWSMachinePoolSpec.CapacityRebalance = existingASG.CapacityRebalance
-	{
-		mixedInstancesPolicy := machinePoolScope.AWSMachinePool.Spec.MixedInstancesPolicy
-		// InstancesDistribution is optional, and the default values come from AWS, so
+// reconcileWarmPool reconciles the warm pool of the spec on the ASG. The ASGs of machine pools which never
+// configured a warm pool are left untouched.
+func (r *AWSMachinePoolReconciler) reconcileWarmPool(machinePoolScope *scope.MachinePoolScope, asgsvc services.ASGInterface) error {
+	warmPool := machinePoolScope.AWSMachinePool.Spec.WarmPool
+	if warmPool == nil && !conditions.Has(machinePoolScope.AWSMachinePool, expinfrav1.WarmPoolReadyCondition) {
+		return nil
+	}
+
+	if err := asgsvc.ReconcileWarmPool(machinePoolScope.Name(), warmPool); err != nil {
+		r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeWarning, "FailedWarmPoolReconcile", "Failed to reconcile warm pool: %v", err)
+		conditions.MarkFalse(machinePoolScope.AWSMachinePool, expinfrav1.WarmPoolReadyCondition, expinfrav1.WarmPoolReconciliationFailedReason, clusterv1.ConditionSeverityError, err.Error())
+		return err
+	}
+
+	if warmPool == nil {
+		conditions.Delete(machinePoolScope.AWSMachinePool, expinfrav1.WarmPoolReadyCondition)
+		return nil
+	}
+	conditions.MarkTrue(machinePoolScope.AWSMachinePool, expinfrav1.WarmPoolReadyCondition)
+	return nil
+}
+
+// reconcileMetricsCollection converges the group metrics collected for the ASG on the metrics of the spec, the
+// collection of all of them being disabled when the spec doesn't set any.
+func (r *AWSMachinePoolReconciler) reconcileMetricsCollection(machinePoolScope *scope.MachinePoolScope, asgsvc services.ASGInterface, existingASG *expinfrav1.AutoScalingGroup) error {
+	enabled := sets.New[string](existingASG.EnabledMetrics...)
+	desired := sets.New[string]()
+	granularity := expinfrav1.MetricsGranularity1Minute
+	if metrics := machinePoolScope.AWSMachinePool.Spec.Metrics; metrics != nil {
+		desired.Insert(metrics.GetMetrics()...)
+		granularity = metrics.GetGranularity()
+	}
+
+	if toEnable := sets.List(desired.Difference(enabled)); len(toEnable) > 0 {
+		machinePoolScope.Info("enabling metrics collection", "metrics", toEnable)
+		if err := asgsvc.EnableMetricsCollection(existingASG.Name, granularity, toEnable); err != nil {
+			r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeWarning, "FailedEnableMetricsCollection", "Failed to enable metrics collection: %v", err)
+			return errors.Wrap(err, "unable to enable metrics collection")
+		}
+	}
+	if toDisable := sets.List(enabled.Difference(desired)); len(toDisable) > 0 {
+		machinePoolScope.Info("disabling metrics collection", "metrics", toDisable)
+		if err := asgsvc.DisableMetricsCollection(existingASG.Name, toDisable); err != nil {
+			r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeWarning, "FailedDisableMetricsCollection", "Failed to disable metrics collection: %v", err)
+			return errors.Wrap(err, "unable to disable metrics collection")
+		}
+	}
+	return nil
+}
+
+// reconcileScalingPolicies reconciles the scaling policies of the spec on the ASG. When the forecast annotation is
+// set, the forecast of the predictive scaling policies is fetched into the status and the annotation removed. A
+// forecast which can't be fetched is reported in the status instead of failing the reconciliation, since the forecast
+// isn't needed to run the machine pool and would otherwise be retried in a loop.
+func (r *AWSMachinePoolReconciler) reconcileScalingPolicies(machinePoolScope *scope.MachinePoolScope, asgsvc services.ASGInterface) error {
+	awsMachinePool := machinePoolScope.AWSMachinePool
+	if len(awsMachinePool.Spec.ScalingPolicies) == 0 && len(awsMachinePool.Status.ScalingPolicies) == 0 {
+		return nil
+	}
+
+	statuses, err := asgsvc.ReconcileScalingPolicies(machinePoolScope.Name(), awsMachinePool.Spec.ScalingPolicies, awsMachinePool.Status.ScalingPolicies)
+	if err != nil {
+		return err
+	}
+	awsMachinePool.Status.ScalingPolicies = statuses
+
+	if _, ok := awsMachinePool.Annotations[expinfrav1.PredictiveScalingForecastAnnotation]; !ok {
+		return nil
+	}
+	for i := range statuses {
+		forecast, err := asgsvc.GetPredictiveScalingForecast(machinePoolScope.Name(), statuses[i].Name)
+		if err != nil {
+			r.Recorder.Eventf(awsMachinePool, corev1.EventTypeWarning, "FailedPredictiveScalingForecast", "Failed to fetch the forecast of scaling policy %s: %v", statuses[i].Name, err)
+			statuses[i].ForecastError = err.Error()
+			continue
+		}
+		statuses[i].Forecast = forecast
+		statuses[i].ForecastError = ""
+	}
+	delete(awsMachinePool.Annotations, expinfrav1.PredictiveScalingForecastAnnotation)
+	return nil
+}
+
+// reconcileCapacityProbe probes the capacity available to the machine pool when requested by the
+// CapacityProbeAnnotation, and removes the annotation once the result is recorded in the status.
+func (r *AWSMachinePoolReconciler) reconcileCapacityProbe(machinePoolScope *scope.MachinePoolScope, ec2Scope scope.EC2Scope, asgsvc services.ASGInterface) error {
+	awsMachinePool := machinePoolScope.AWSMachinePool
+	instances, ok, err := awsMachinePool.CapacityProbeInstances()
+	if !ok {
+		return nil
+	}
+	if err != nil {
+		r.Recorder.Eventf(awsMachinePool, corev1.EventTypeWarning, "InvalidCapacityProbe", "Ignoring the capacity probe: %v", err)
+		delete(awsMachinePool.Annotations, expinfrav1.CapacityProbeAnnotation)
+		return nil
+	}
+
+	subnetIDs, err := asgsvc.SubnetIDs(machinePoolScope)
+	if err != nil {
+		return err
+	}
+	if err := capacityprobe.NewService(ec2Scope).ProbeCapacity(awsMachinePool, subnetIDs, instances); err != nil {
+		r.Recorder.Eventf(awsMachinePool, corev1.EventTypeWarning, "FailedCapacityProbe", "Failed to probe the capacity: %v", err)
+		return err
+	}
+	delete(awsMachinePool.Annotations, expinfrav1.CapacityProbeAnnotation)
+	return nil
+}
+
+// reconcileSpotPlacementScores fetches the spot placement scores of the machine pool when requested by the
+// SpotPlacementScoresAnnotation, and removes the annotation once the scores are recorded in the status, or once the
+// SpotPlacementScoresUnavailableCondition reports that they can't be fetched in the region.
+func (r *AWSMachinePoolReconciler) reconcileSpotPlacementScores(machinePoolScope *scope.MachinePoolScope, ec2Scope scope.EC2Scope) error {
+	awsMachinePool := machinePoolScope.AWSMachinePool
+	targetCapacity, ok, err := awsMachinePool.SpotPlacementScoresTargetCapacity()
+	if !ok {
+		return nil
+	}
+	if err != nil {
+		r.Recorder.Eventf(awsMachinePool, corev1.EventTypeWarning, "InvalidSpotPlacementScores", "Ignoring the spot placement scores request: %v", err)
+		delete(awsMachinePool.Annotations, expinfrav1.SpotPlacementScoresAnnotation)
+		return nil
+	}
+
+	err = spot.NewService(ec2Scope, r.SpotPriceCache).ReportPlacementScores(awsMachinePool, targetCapacity, r.SpotPlacementScoreCache)
+	if errors.Is(err, spot.ErrNoPlacementScoreConfiguration) {
+		r.Recorder.Eventf(awsMachinePool, corev1.EventTypeWarning, "InvalidSpotPlacementScores", "Ignoring the spot placement scores request: %v", err)
+		delete(awsMachinePool.Annotations, expinfrav1.SpotPlacementScoresAnnotation)
+		return nil
+	}
+	if err != nil {
+		r.Recorder.Eventf(awsMachinePool, corev1.EventTypeWarning, "FailedSpotPlacementScores", "Failed to fetch the spot placement scores: %v", err)
+		return err
+	}
+	delete(awsMachinePool.Annotations, expinfrav1.SpotPlacementScoresAnnotation)
+	return nil
+}
+
+// reconcileLaunchTemplateRollback rolls the launch template back to its previous version when requested by the
+// RollbackLaunchTemplateAnnotation. The previous version is copied as the latest version of the launch template,
+// which reconcileOutdatedInstanceRefresh rolls out once the running instance refresh is cancelled. The rollback is
+// forgotten once the spec changes, so that the launch template is updated from it again.
+func (r *AWSMachinePoolReconciler) reconcileLaunchTemplateRollback(machinePoolScope *scope.MachinePoolScope, ec2Svc services.EC2Interface, asgsvc services.ASGInterface) error {
+	awsMachinePool := machinePoolScope.AWSMachinePool
+	status := &awsMachinePool.Status
+	if status.LaunchTemplateRollback != nil && status.LaunchTemplateRollback.ObservedGeneration != awsMachinePool.Generation {
+		status.LaunchTemplateRollback = nil
+	}
+	if awsMachinePool.Annotations[expinfrav1.RollbackLaunchTemplateAnnotation] != "true" {
+		return nil
+	}
+
+	previousVersion := ptr.Deref(status.PreviousLaunchTemplateVersion, "")
+	if previousVersion == "" || status.LaunchTemplateID == "" {
+		r.Recorder.Eventf(awsMachinePool, corev1.EventTypeWarning, "InvalidLaunchTemplateRollback", "Ignoring the launch template rollback: no previous launch template version is recorded")
+		delete(awsMachinePool.Annotations, expinfrav1.RollbackLaunchTemplateAnnotation)
+		return nil
+	}
+
+	fromVersion := ptr.Deref(status.LaunchTemplateVersion, "")
+	machinePoolScope.Info("rolling back launch template", "id", status.LaunchTemplateID, "from", fromVersion, "to", previousVersion)
+	version, err := ec2Svc.CreateLaunchTemplateVersionFromVersion(status.LaunchTemplateID, previousVersion)
+	if err != nil {
+		r.Recorder.Eventf(awsMachinePool, corev1.EventTypeWarning, "FailedLaunchTemplateRollback", "Failed to roll back launch template %s to version %s: %v", status.LaunchTemplateID, previousVersion, err)
+		return err
+	}
+	status.LaunchTemplateVersion = ptr.To[string](version)
+	status.PreviousLaunchTemplateVersion = nil
+	status.LaunchTemplateRollback = &expinfrav1.LaunchTemplateRollback{
+		FromVersion:        fromVersion,
+		ToVersion:          previousVersion,
+		Version:            version,
+		ObservedGeneration: awsMachinePool.Generation,
+	}
+	delete(awsMachinePool.Annotations, expinfrav1.RollbackLaunchTemplateAnnotation)
+	r.Recorder.Eventf(awsMachinePool, corev1.EventTypeNormal, "LaunchTemplateRolledBack",
+		"Rolled back launch template %s from version %s to version %s, restored as version %s", status.LaunchTemplateID, fromVersion, previousVersion, version)
+
+	// The running instance refresh rolls out the version which was rolled back.
+	return r.cancelOutdatedInstanceRefresh(machinePoolScope, asgsvc)
+}
+
+// recordPreviousLaunchTemplateVersion records the version of the launch template before a change rolled out by an
+// instance refresh, so that the launch template can be rolled back to it. The version of a launch template which
+// was recreated or is referenced is irrelevant, and isn't recorded.
+func recordPreviousLaunchTemplateVersion(machinePoolScope *scope.MachinePoolScope, previousID, previousVersion string, rolledOut bool) {
+	status := &machinePoolScope.AWSMachinePool.Status
+	if machinePoolScope.AWSMachinePool.Spec.AWSLaunchTemplate.Ref != nil || status.LaunchTemplateID != previousID {
+		status.PreviousLaunchTemplateVersion = nil
+		return
+	}
+	if !rolledOut || previousVersion == "" || ptr.Deref(status.LaunchTemplateVersion, "") == previousVersion {
+		return
+	}
+	status.PreviousLaunchTemplateVersion = ptr.To[string](previousVersion)
+}
+
+// startInstanceRefresh starts an instance refresh of the ASG, and records the launch template version it rolls out.
+// With the AZSequential strategy, the instance refreshes of the availability zones are started by
+// reconcileAZSequentialRefresh instead.
+func (r *AWSMachinePoolReconciler) startInstanceRefresh(machinePoolScope *scope.MachinePoolScope, asgsvc services.ASGInterface) error {
+	// The instances of a capacity block can only be launched within its window, an instance refresh started outside
+	// of it would leave the pool without instances. The refresh is started by reconcileOutdatedInstanceRefresh once
+	// the window starts.
+	if conditions.Has(machinePoolScope.AWSMachinePool, expinfrav1.CapacityBlockOutsideWindowCondition) {
+		machinePoolScope.Info("deferring instance refresh until the window of the capacity block starts")
+		return nil
+	}
+
+	if azSequentialRefreshEnabled(machinePoolScope) {
+		return r.startAZSequentialRefresh(machinePoolScope, asgsvc)
+	}
+
+	if err := r.addRefreshSurge(machinePoolScope, asgsvc); err != nil {
+		return err
+	}
+
+	machinePoolScope.Info("starting instance refresh", "number of instances", machinePoolScope.MachinePool.Spec.Replicas)
+	instanceRefreshID, err := asgsvc.StartASGInstanceRefresh(machinePoolScope)
+	if err != nil {
+		return err
+	}
+	r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeNormal, "InstanceRefreshStarted", "Started instance refresh %s", instanceRefreshID)
+	machinePoolScope.AWSMachinePool.Status.InstanceRefreshLaunchTemplateVersion = machinePoolScope.AWSMachinePool.Status.LaunchTemplateVersion
+	return nil
+}
+
+// addRefreshSurge raises the maximum size of the ASG by spec.refreshPreferences.maxSurge before an instance refresh
+// starts. The RefreshSurgeAnnotation is persisted before the ASG is updated, so that the maximum size of the spec is
+// restored by removeRefreshSurge even when the controller restarts while the instance refresh runs.
+func (r *AWSMachinePoolReconciler) addRefreshSurge(machinePoolScope *scope.MachinePoolScope, asgsvc services.ASGInterface) error {
+	awsMachinePool := machinePoolScope.AWSMachinePool
+	refreshPreferences := awsMachinePool.Spec.RefreshPreferences
+	if refreshPreferences == nil || refreshPreferences.MaxSurge == nil || awsMachinePool.Spec.IsUnmanaged(expinfrav1.UnmanagedFieldMaxSize) {
+		return nil
+	}
+
+	if _, ok := awsMachinePool.Annotations[expinfrav1.RefreshSurgeAnnotation]; !ok {
+		machinePoolScope.SetAnnotation(expinfrav1.RefreshSurgeAnnotation, strconv.Itoa(int(awsMachinePool.Spec.MaxSize)))
+		if err := machinePoolScope.PatchObject(); err != nil {
+			return err
+		}
+	}
+
+	machinePoolScope.Info("raising maximum size of the ASG for the instance refresh", "maxSize", awsMachinePool.ASGMaxSize())
+	if err := asgsvc.UpdateASG(machinePoolScope); err != nil {
+		r.Recorder.Eventf(awsMachinePool, corev1.EventTypeWarning, "FailedUpdate", "Failed to raise the maximum size of the ASG for the instance refresh: %v", err)
+		return err
+	}
+	r.Recorder.Eventf(awsMachinePool, corev1.EventTypeNormal, "RefreshSurgeAdded",
+		"Raised the maximum size of the ASG from %d to %d for the instance refresh", awsMachinePool.Spec.MaxSize, awsMachinePool.ASGMaxSize())
+	return nil
+}
+
+// removeRefreshSurge restores the maximum size of the spec on the ASG once the instance refresh the surge was added
+// for isn't in progress anymore. The maximum size of the spec wins over the one recorded when the surge was added,
+// in case the spec changed while the instance refresh ran.
+func (r *AWSMachinePoolReconciler) removeRefreshSurge(machinePoolScope *scope.MachinePoolScope, asgsvc services.ASGInterface) error {
+	awsMachinePool := machinePoolScope.AWSMachinePool
+	originalMaxSize, ok := awsMachinePool.Annotations[expinfrav1.RefreshSurgeAnnotation]
+	if !ok || awsMachinePool.Status.InstanceRefreshStatus.InProgress() || awsMachinePool.Status.AZSequentialRefresh != nil {
+		return nil
+	}
+
+	delete(awsMachinePool.Annotations, expinfrav1.RefreshSurgeAnnotation)
+	if awsMachinePool.Spec.IsUnmanaged(expinfrav1.UnmanagedFieldMaxSize) {
+		return nil
+	}
+
+	machinePoolScope.Info("restoring maximum size of the ASG after the instance refresh", "maxSize", awsMachinePool.Spec.MaxSize)
+	if err := asgsvc.UpdateASG(machinePoolScope); err != nil {
+		machinePoolScope.SetAnnotation(expinfrav1.RefreshSurgeAnnotation, originalMaxSize)
+		r.Recorder.Eventf(awsMachinePool, corev1.EventTypeWarning, "FailedUpdate", "Failed to restore the maximum size of the ASG after the instance refresh: %v", err)
+		return err
+	}
+	if originalMaxSize != strconv.Itoa(int(awsMachinePool.Spec.MaxSize)) {
+		r.Recorder.Eventf(awsMachinePool, corev1.EventTypeNormal, "RefreshSurgeRemoved",
+			"Restored the maximum size %d of the spec on the ASG after the instance refresh, the maximum size changed from %s meanwhile", awsMachinePool.Spec.MaxSize, originalMaxSize)
+		return nil
+	}
+	r.Recorder.Eventf(awsMachinePool, corev1.EventTypeNormal, "RefreshSurgeRemoved",
+		"Restored the maximum size of the ASG to %d after the instance refresh", awsMachinePool.Spec.MaxSize)
+	return nil
+}
+
+// reconcileOutdatedInstanceRefresh starts an instance refresh when the launch template version the last instance
+// refresh was started for is outdated, which happens when the controller terminated or failed to start it after
+// creating the latest version. The instances are kept when keepInstances is set, when the instance refresh is
+// disabled, or when no version was recorded yet, the latest version being recorded instead.
+func (r *AWSMachinePoolReconciler) reconcileOutdatedInstanceRefresh(machinePoolScope *scope.MachinePoolScope, asgsvc services.ASGInterface, keepInstances bool) error {
+	status := &machinePoolScope.AWSMachinePool.Status
+	if status.LaunchTemplateVersion == nil || ptr.Equal(status.InstanceRefreshLaunchTemplateVersion, status.LaunchTemplateVersion) {
+		return nil
+	}
+
+	refreshPreferences := machinePoolScope.AWSMachinePool.Spec.RefreshPreferences
+	if keepInstances || status.InstanceRefreshLaunchTemplateVersion == nil || (refreshPreferences != nil && refreshPreferences.Disable) {
+		status.InstanceRefreshLaunchTemplateVersion = status.LaunchTemplateVersion
+		return nil
+	}
+
+	canStart, err := asgsvc.CanStartASGInstanceRefresh(machinePoolScope)
+	if err != nil {
+		return err
+	}
+	if !canStart {
+		if refreshPreferences != nil && refreshPreferences.CancelOutdatedRefresh {
+			return r.cancelOutdatedInstanceRefresh(machinePoolScope, asgsvc)
+		}
+		machinePoolScope.Info("waiting for the running instance refresh to end before rolling out the latest launch template version",
+			"version", *status.LaunchTemplateVersion)
+		return nil
+	}
+	return r.startInstanceRefresh(machinePoolScope, asgsvc)
+}
+
+// reconcileOutdatedInstances starts an instance refresh when instances of the ASG run a version of the launch
+// template older than the latest one, which differs from it in more than the userdata. This catches the instances
+// no recorded instance refresh accounts for, e.g. those of pools which recorded their latest version without
+// refreshing them. Instances in standby, and instances protected from scale in unless the instance refresh replaces
+// them, are skipped by an instance refresh, so they don't count; a failed or cancelled instance refresh of the latest
+// version isn't retried.
+func (r *AWSMachinePoolReconciler) reconcileOutdatedInstances(machinePoolScope *scope.MachinePoolScope, ec2Svc services.EC2Interface, asgsvc services.ASGInterface, existingASG *expinfrav1.AutoScalingGroup) error {
+	awsMachinePool := machinePoolScope.AWSMachinePool
+	status := &awsMachinePool.Status
+	refreshPreferences := awsMachinePool.Spec.RefreshPreferences
+	// A referenced launch template is rolled out by updating the version launched by the ASG.
+	if status.LaunchTemplateVersion == nil || awsMachinePool.Spec.AWSLaunchTemplate.Ref != nil || (refreshPreferences != nil && refreshPreferences.Disable) {
+		return nil
+	}
+	if refresh := status.InstanceRefreshStatus; refresh != nil && !refresh.InProgress() && refresh.State != autoscaling.InstanceRefreshStatusSuccessful &&
+		ptr.Equal(status.InstanceRefreshLaunchTemplateVersion, status.LaunchTemplateVersion) {
+		return nil
+	}
+	// The instances of the availability zones not refreshed yet are outdated until their turn.
+	if status.AZSequentialRefresh != nil {
+		return nil
+	}
+	latest, err := strconv.ParseInt(*status.LaunchTemplateVersion, 10, 64)
+	if err != nil {
+		return nil
+	}
+
+	skipsProtected := instanceRefreshSkipsProtectedInstances(awsMachinePool)
+	outdatedVersions := sets.New[string]()
+	for _, instance := range existingASG.Instances {
+		details := existingASG.InstanceDetails[instance.ID]
+		if details.LaunchTemplateID != status.LaunchTemplateID || isStandby(instance) || isTerminating(instance) ||
+			(skipsProtected && details.ProtectedFromScaleIn) {
+			continue
+		}
+		if version, err := strconv.ParseInt(details.LaunchTemplateVersion, 10, 64); err == nil && version < latest {
+			outdatedVersions.Insert(details.LaunchTemplateVersion)
+		}
+	}
+
+	for _, version := range sets.List(outdatedVersions) {
+		changed, err := ec2Svc.LaunchTemplateVersionChangedBeyondUserData(status.LaunchTemplateID, version, *status.LaunchTemplateVersion)
+		if err != nil {
+			return err
+		}
+		if !changed {
+			continue
+		}
+
+		canStart, err := asgsvc.CanStartASGInstanceRefresh(machinePoolScope)
+		if err != nil {
+			return err
+		}
+		if !canStart {
+			machinePoolScope.Info("waiting for the running instance refresh to end before replacing the instances of an outdated launch template version",
+				"version", version)
+			return nil
+		}
+		machinePoolScope.Info("replacing the instances of an outdated launch template version", "version", version, "latest", *status.LaunchTemplateVersion)
+		return r.startInstanceRefresh(machinePoolScope, asgsvc)
+	}
+	return nil
+}
+
+// instanceRefreshSkipsProtectedInstances returns whether the instance refreshes of the pool skip the instances
+// protected from scale in, which they do by default, and always with the AZSequential strategy.
+func instanceRefreshSkipsProtectedInstances(awsMachinePool *expinfrav1.AWSMachinePool) bool {
+	refreshPreferences := awsMachinePool.Spec.RefreshPreferences
+	if refreshPreferences == nil || ptr.Deref(refreshPreferences.Strategy, "") == expinfrav1.RefreshStrategyAZSequential {
+		return true
+	}
+	return refreshPreferences.ScaleInProtectedInstances == "" || refreshPreferences.ScaleInProtectedInstances == expinfrav1.ScaleInProtectedInstancesIgnore
+}
+
+// cancelOutdatedInstanceRefresh cancels the running instance refresh of the ASG, which rolls out an outdated launch
+// template version. An instance refresh of the latest version is started once the cancellation completed.
+func (r *AWSMachinePoolReconciler) cancelOutdatedInstanceRefresh(machinePoolScope *scope.MachinePoolScope, asgsvc services.ASGInterface) error {
+	refresh, err := asgsvc.DescribeLatestInstanceRefresh(machinePoolScope.Name())
+	if err != nil {
+		return err
+	}
+	// An instance refresh which is already cancelled or rolled back is waited for.
+	if refresh == nil || (refresh.State != autoscaling.InstanceRefreshStatusPending && refresh.State != autoscaling.InstanceRefreshStatusInProgress) {
+		return nil
+	}
+
+	machinePoolScope.Info("cancelling outdated instance refresh", "id", refresh.ID)
+	if err := asgsvc.CancelASGInstanceRefresh(machinePoolScope.Name()); err != nil {
+		r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeWarning, "FailedCancelInstanceRefresh", "Failed to cancel outdated instance refresh %s: %v", refresh.ID, err)
+		return err
+	}
+	r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeNormal, "InstanceRefreshCancelled",
+		"Cancelled instance refresh %s to roll out the latest launch template version", refresh.ID)
+	return nil
+}
+
+// azSequentialRefreshEnabled returns whether the instances of the pool are refreshed one availability zone at a time.
+func azSequentialRefreshEnabled(machinePoolScope *scope.MachinePoolScope) bool {
+	refreshPreferences := machinePoolScope.AWSMachinePool.Spec.RefreshPreferences
+	return refreshPreferences != nil && !refreshPreferences.Disable && ptr.Deref(refreshPreferences.Strategy, "") == expinfrav1.RefreshStrategyAZSequential
+}
+
+// startAZSequentialRefresh records the launch template version rolled out one availability zone at a time, the
+// instance refreshes of the zones being started by reconcileAZSequentialRefresh. A refresh of an older version is
+// cancelled first, so that the latest version is rolled out from the first zone on.
+func (r *AWSMachinePoolReconciler) startAZSequentialRefresh(machinePoolScope *scope.MachinePoolScope, asgsvc services.ASGInterface) error {
+	status := &machinePoolScope.AWSMachinePool.Status
+	if status.AZSequentialRefresh != nil {
+		if err := r.cancelAZSequentialRefresh(machinePoolScope, asgsvc, "a newer launch template version is rolled out"); err != nil {
+			return err
+		}
+	}
+	if err := r.addRefreshSurge(machinePoolScope, asgsvc); err != nil {
+		return err
+	}
+
+	version := ptr.Deref(status.LaunchTemplateVersion, "")
+	machinePoolScope.Info("starting instance refresh one availability zone at a time", "version", version)
+	status.AZSequentialRefresh = &expinfrav1.AZSequentialRefresh{
+		LaunchTemplateVersion: version,
+		StartTime:             metav1.Now(),
+	}
+	status.InstanceRefreshLaunchTemplateVersion = status.LaunchTemplateVersion
+	r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeNormal, "AZSequentialRefreshStarted",
+		"Started refreshing the instances one availability zone at a time for launch template version %s", version)
+	return nil
+}
+
+// reconcileAZSequentialRefresh drives the instance refresh with the AZSequential strategy. The instances of the
+// other availability zones are protected from scale in, and an instance refresh replaces those of the current zone.
+// Once it succeeded and the nodes of the instances of the zone are Ready, their protection is removed and the next
+// zone is refreshed. The progress is kept in the status, so that the refresh resumes after a controller restart.
+func (r *AWSMachinePoolReconciler) reconcileAZSequentialRefresh(ctx context.Context, machinePoolScope *scope.MachinePoolScope, asgsvc services.ASGInterface, existingASG *expinfrav1.AutoScalingGroup) error {
+	return r.driveAZSequentialRefresh(machinePoolScope, asgsvc, existingASG, func(instanceIDs []string) (map[string]bool, error) {
+		return machinePoolScope.GetNodeReadyByInstanceID(ctx, instanceIDs)
+	})
+}
+
+// driveAZSequentialRefresh implements reconcileAZSequentialRefresh, nodeReady returning whether the node of each
+// of the given instances is Ready in the workload cluster.
+func (r *AWSMachinePoolReconciler) driveAZSequentialRefresh(machinePoolScope *scope.MachinePoolScope, asgsvc services.ASGInterface, existingASG *expinfrav1.AutoScalingGroup, nodeReady func(instanceIDs []string) (map[string]bool, error)) error {
+	awsMachinePool := machinePoolScope.AWSMachinePool
+	azRefresh := awsMachinePool.Status.AZSequentialRefresh
+	if azRefresh == nil {
+		return nil
+	}
+	if !azSequentialRefreshEnabled(machinePoolScope) {
+		return r.cancelAZSequentialRefresh(machinePoolScope, asgsvc, "the AZSequential strategy isn't configured anymore")
+	}
+
+	if azRefresh.AvailabilityZones == nil {
+		azRefresh.AvailabilityZones = sets.List(refreshableInstanceZones(existingASG))
+	}
+
+	if azRefresh.InstanceRefreshID != "" {
+		refreshed, err := r.reconcileAZRefreshed(machinePoolScope, asgsvc, existingASG, nodeReady)
+		if err != nil || !refreshed {
+			return err
+		}
+		azRefresh = awsMachinePool.Status.AZSequentialRefresh
+		if azRefresh == nil {
+			return nil
+		}
+	}
+
+	if int(azRefresh.Current) >= len(azRefresh.AvailabilityZones) {
+		machinePoolScope.Info("refreshed the instances of all the availability zones", "version", azRefresh.LaunchTemplateVersion)
+		r.Recorder.Eventf(awsMachinePool, corev1.EventTypeNormal, "AZSequentialRefreshCompleted",
+			"Refreshed the instances of availability zones %s for launch template version %s", strings.Join(azRefresh.AvailabilityZones, ", "), azRefresh.LaunchTemplateVersion)
+		awsMachinePool.Status.AZSequentialRefresh = nil
+		return nil
+	}
+	return r.startAZRefresh(machinePoolScope, asgsvc, existingASG)
+}
+
+// reconcileAZRefreshed returns whether the instances of the current availability zone were refreshed, in which case
+// the protection of the instances of the other zones is removed and the next zone becomes the current one. The
+// refresh is cancelled when the instance refresh of the zone didn't succeed.
+func (r *AWSMachinePoolReconciler) reconcileAZRefreshed(machinePoolScope *scope.MachinePoolScope, asgsvc services.ASGInterface, existingASG *expinfrav1.AutoScalingGroup, nodeReady func(instanceIDs []string) (map[string]bool, error)) (bool, error) {
+	azRefresh := machinePoolScope.AWSMachinePool.Status.AZSequentialRefresh
+	zone := azRefresh.CurrentAvailabilityZone()
+
+	refresh, err := asgsvc.DescribeLatestInstanceRefresh(machinePoolScope.Name())
+	if err != nil {
+		return false, err
+	}
+	if refresh != nil && refresh.ID == azRefresh.InstanceRefreshID && refresh.InProgress() {
+		return false, nil
+	}
+	if refresh == nil || refresh.ID != azRefresh.InstanceRefreshID || refresh.State != autoscaling.InstanceRefreshStatusSuccessful {
+		reason := fmt.Sprintf("instance refresh %s of availability zone %s isn't the latest one anymore", azRefresh.InstanceRefreshID, zone)
+		if refresh != nil && refresh.ID == azRefresh.InstanceRefreshID {
+			reason = fmt.Sprintf("instance refresh %s of availability zone %s %s: %s", refresh.ID, zone, refresh.State, refresh.StatusReason)
+		}
+		// The instance refresh of the zone isn't running anymore, there's nothing to cancel.
+		azRefresh.InstanceRefreshID = ""
+		return false, r.cancelAZSequentialRefresh(machinePoolScope, asgsvc, reason)
+	}
+
+	var instanceIDs []string
+	for _, instance := range existingASG.Instances {
+		if instance.AvailabilityZone == zone && !isStandby(instance) && !isTerminating(instance) && !isWarmed(instance) {
+			instanceIDs = append(instanceIDs, instance.ID)
+		}
+	}
+	if len(instanceIDs) > 0 {
+		ready, err := nodeReady(instanceIDs)
+		if err != nil {
+			// The next availability zone is only refreshed once the nodes are known to be Ready.
+			machinePoolScope.Error(err, "failed to get the nodes of the refreshed instances", "availabilityZone", zone)
+			return false, nil
+		}
+		for _, id := range instanceIDs {
+			if !ready[id] {
+				machinePoolScope.Info("waiting for the nodes of the refreshed instances to be Ready", "availabilityZone", zone, "instance", id)
+				return false, nil
+			}
+		}
+	}
+
+	if err := r.unprotectAZRefreshInstances(machinePoolScope, asgsvc); err != nil {
+		return false, err
+	}
+	r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeNormal, "AZRefreshed",
+		"Refreshed the instances of availability zone %s (%d/%d)", zone, azRefresh.Current+1, len(azRefresh.AvailabilityZones))
+	azRefresh.Current++
+	azRefresh.InstanceRefreshID = ""
+	return true, nil
+}
+
+// startAZRefresh protects the instances of the other availability zones from scale in, and starts the instance
+// refresh of the current zone. The protected instances are persisted in the status first, so that their protection
+// is removed even when the controller restarts meanwhile. An instance refresh started before a restart is adopted.
+func (r *AWSMachinePoolReconciler) startAZRefresh(machinePoolScope *scope.MachinePoolScope, asgsvc services.ASGInterface, existingASG *expinfrav1.AutoScalingGroup) error {
+	awsMachinePool := machinePoolScope.AWSMachinePool
+	azRefresh := awsMachinePool.Status.AZSequentialRefresh
+	zone := azRefresh.CurrentAvailabilityZone()
+
+	if conditions.Has(awsMachinePool, expinfrav1.CapacityBlockOutsideWindowCondition) {
+		machinePoolScope.Info("deferring instance refresh of an availability zone until the window of the capacity block starts", "availabilityZone", zone)
+		return nil
+	}
+
+	canStart, err := asgsvc.CanStartASGInstanceRefresh(machinePoolScope)
+	if err != nil {
+		return err
+	}
+	if !canStart {
+		refresh, err := asgsvc.DescribeLatestInstanceRefresh(machinePoolScope.Name())
+		if err != nil {
+			return err
+		}
+		if refresh != nil && refresh.InProgress() && len(azRefresh.ProtectedInstanceIDs) > 0 {
+			azRefresh.InstanceRefreshID = refresh.ID
+			return nil
+		}
+		machinePoolScope.Info("waiting for the running instance refresh to end before refreshing an availability zone", "availabilityZone", zone)
+		return nil
+	}
+
+	protected := sets.New(azRefresh.ProtectedInstanceIDs...)
+	var toProtect []string
+	for _, instance := range existingASG.Instances {
+		if instance.AvailabilityZone == zone || isStandby(instance) || isTerminating(instance) || isWarmed(instance) ||
+			existingASG.InstanceDetails[instance.ID].ProtectedFromScaleIn {
+			continue
+		}
+		toProtect = append(toProtect, instance.ID)
+	}
+	if len(toProtect) > 0 {
+		azRefresh.ProtectedInstanceIDs = sets.List(protected.Insert(toProtect...))
+		if err := machinePoolScope.PatchObject(); err != nil {
+			return err
+		}
+		if err := asgsvc.SetInstanceProtection(machinePoolScope.Name(), toProtect, true); err != nil {
+			r.Recorder.Eventf(awsMachinePool, corev1.EventTypeWarning, "FailedSetInstanceProtection",
+				"Failed to protect the instances outside of availability zone %s from scale in: %v", zone, err)
+			return err
+		}
+	}
+
+	machinePoolScope.Info("starting instance refresh of an availability zone", "availabilityZone", zone, "protectedInstances", azRefresh.ProtectedInstanceIDs)
+	instanceRefreshID, err := asgsvc.StartASGInstanceRefresh(machinePoolScope)
+	if err != nil {
+		return err
+	}
+	azRefresh.InstanceRefreshID = instanceRefreshID
+	r.Recorder.Eventf(awsMachinePool, corev1.EventTypeNormal, "InstanceRefreshStarted",
+		"Started instance refresh %s of availability zone %s (%d/%d)", instanceRefreshID, zone, azRefresh.Current+1, len(azRefresh.AvailabilityZones))
+	return nil
+}
+
+// cancelAZSequentialRefresh cancels the instance refresh of the current availability zone when it's running,
+// removes the protection the controller added to the instances of the other zones, and forgets the refresh.
+func (r *AWSMachinePoolReconciler) cancelAZSequentialRefresh(machinePoolScope *scope.MachinePoolScope, asgsvc services.ASGInterface, reason string) error {
+	awsMachinePool := machinePoolScope.AWSMachinePool
+	azRefresh := awsMachinePool.Status.AZSequentialRefresh
+
+	if azRefresh.InstanceRefreshID != "" {
+		refresh, err := asgsvc.DescribeLatestInstanceRefresh(machinePoolScope.Name())
+		if err != nil {
+			return err
+		}
+		if refresh != nil && refresh.ID == azRefresh.InstanceRefreshID &&
+			(refresh.State == autoscaling.InstanceRefreshStatusPending || refresh.State == autoscaling.InstanceRefreshStatusInProgress) {
+			if err := asgsvc.CancelASGInstanceRefresh(machinePoolScope.Name()); err != nil {
+				r.Recorder.Eventf(awsMachinePool, corev1.EventTypeWarning, "FailedCancelInstanceRefresh", "Failed to cancel instance refresh %s: %v", refresh.ID, err)
+				return err
+			}
+		}
+	}
+	if err := r.unprotectAZRefreshInstances(machinePoolScope, asgsvc); err != nil {
+		return err
+	}
+
+	machinePoolScope.Info("cancelled instance refresh one availability zone at a time", "reason", reason)
+	r.Recorder.Eventf(awsMachinePool, corev1.EventTypeWarning, "AZSequentialRefreshCancelled",
+		"Cancelled refreshing the instances of launch template version %s one availability zone at a time: %s", azRefresh.LaunchTemplateVersion, reason)
+	awsMachinePool.Status.AZSequentialRefresh = nil
+	return nil
+}
+
+// unprotectAZRefreshInstances removes the protection from scale in the controller added to the instances outside
+// of the availability zone being refreshed.
+func (r *AWSMachinePoolReconciler) unprotectAZRefreshInstances(machinePoolScope *scope.MachinePoolScope, asgsvc services.ASGInterface) error {
+	azRefresh := machinePoolScope.AWSMachinePool.Status.AZSequentialRefresh
+	if len(azRefresh.ProtectedInstanceIDs) == 0 {
+		return nil
+	}
+	if err := asgsvc.SetInstanceProtection(machinePoolScope.Name(), azRefresh.ProtectedInstanceIDs, false); err != nil {
+		r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeWarning, "FailedSetInstanceProtection",
+			"Failed to remove the scale in protection of instances %v: %v", azRefresh.ProtectedInstanceIDs, err)
+		return err
+	}
+	azRefresh.ProtectedInstanceIDs = nil
+	return nil
+}
+
+// refreshableInstanceZones returns the availability zones of the instances of the ASG an instance refresh replaces.
+func refreshableInstanceZones(existingASG *expinfrav1.AutoScalingGroup) sets.Set[string] {
+	zones := sets.New[string]()
+	for _, instance := range existingASG.Instances {
+		if !isStandby(instance) && !isTerminating(instance) && !isWarmed(instance) {
+			zones.Insert(instance.AvailabilityZone)
+		}
+	}
+	return zones
+}
+
+// reconcileInstanceRefreshStatus reports the progress of the latest instance refresh of the ASG in the status and
+// the InstanceRefreshInProgressCondition, the condition being removed when the ASG never had an instance refresh.
+// The surge added to the maximum size of the ASG is removed once the instance refresh ended.
+func (r *AWSMachinePoolReconciler) reconcileInstanceRefreshStatus(machinePoolScope *scope.MachinePoolScope, asgsvc services.ASGInterface) error {
+	awsMachinePool := machinePoolScope.AWSMachinePool
+	refresh, err := asgsvc.DescribeLatestInstanceRefresh(machinePoolScope.Name())
+	if err != nil {
+		return err
+	}
+	awsMachinePool.Status.InstanceRefreshStatus = refresh
+
+	switch {
+	case refresh == nil:
+		conditions.Delete(awsMachinePool, expinfrav1.InstanceRefreshInProgressCondition)
+	case refresh.InProgress():
+		conditions.MarkTrue(awsMachinePool, expinfrav1.InstanceRefreshInProgressCondition)
+	case refresh.State == autoscaling.InstanceRefreshStatusFailed || refresh.State == autoscaling.InstanceRefreshStatusRollbackFailed:
+		conditions.MarkFalse(awsMachinePool, expinfrav1.InstanceRefreshInProgressCondition, expinfrav1.InstanceRefreshFailedReason, clusterv1.ConditionSeverityError,
+			"Instance refresh %s %s: %s", refresh.ID, refresh.State, refresh.StatusReason)
+	case refresh.State == autoscaling.InstanceRefreshStatusCancelled || refresh.State == autoscaling.InstanceRefreshStatusRollbackSuccessful:
+		conditions.MarkFalse(awsMachinePool, expinfrav1.InstanceRefreshInProgressCondition, expinfrav1.InstanceRefreshCancelledReason, clusterv1.ConditionSeverityWarning,
+			"Instance refresh %s %s: %s", refresh.ID, refresh.State, refresh.StatusReason)
+	default:
+		conditions.MarkFalse(awsMachinePool, expinfrav1.InstanceRefreshInProgressCondition, expinfrav1.InstanceRefreshSuccessfulReason, clusterv1.ConditionSeverityInfo,
+			"Instance refresh %s %s", refresh.ID, refresh.State)
+	}
+	return r.removeRefreshSurge(machinePoolScope, asgsvc)
+}
+
+// reconcileInstanceDrift compares the in service instances of the ASG with the latest version of the launch
+// template when the drift audit is enabled. Launch templates managed outside of the controller aren't audited.
+func (r *AWSMachinePoolReconciler) reconcileInstanceDrift(machinePoolScope *scope.MachinePoolScope, ec2Scope scope.EC2Scope, ec2Svc services.EC2Interface, instances []infrav1.Instance) {
+	if r.DriftAuditor == nil || machinePoolScope.AWSMachinePool.Spec.AWSLaunchTemplate.Ref != nil {
+		return
+	}
+
+	instanceIDs := []string{}
+	for _, instance := range instances {
+		if string(instance.State) == autoscaling.LifecycleStateInService {
+			instanceIDs = append(instanceIDs, instance.ID)
+		}
+	}
+
+	expected := func() (*drift.Expected, error) {
+		launchTemplate, _, _, err := ec2Svc.GetLaunchTemplate(machinePoolScope.LaunchTemplateName())
+		if err != nil {
+			return nil, err
+		}
+		if launchTemplate == nil {
+			return nil, errors.Errorf("launch template %s not found", machinePoolScope.LaunchTemplateName())
+		}
+
+		want := &drift.Expected{
+			IAMInstanceProfile: launchTemplate.IamInstanceProfile,
+		}
+		// The instance types of a mixed instances policy override the one of the launch template.
+		if machinePoolScope.GetMixedInstancesPolicy() == nil {
+			want.InstanceType = launchTemplate.InstanceType
+		}
+		for _, group := range launchTemplate.AdditionalSecurityGroups {
+			want.SecurityGroupIDs = append(want.SecurityGroupIDs, ptr.Deref(group.ID, ""))
+		}
+		return want, nil
+	}
+
+	if err := drift.NewService(ec2Scope, r.DriftAuditor).ReconcileInstanceDrift(machinePoolScope.AWSMachinePool, instanceIDs, expected); err != nil {
+		// non fatal error, so we continue
+		machinePoolScope.Error(err, "non-fatal: failed to audit instance drift")
+	}
+}
+
+// reconcileLifecycleActions tracks the instances held in the Pending:Wait state by the capa-node-join lifecycle
+// hooks. Their lifecycle action is completed once their node is Ready in the workload cluster, or with the default
+// result of the hook once its heartbeat timeout elapsed.
+func (r *AWSMachinePoolReconciler) reconcileLifecycleActions(ctx context.Context, machinePoolScope *scope.MachinePoolScope, asgsvc services.ASGInterface, instances []infrav1.Instance) error {
+	var waiting []string
+	for _, instance := range instances {
+		if instance.State == infrav1.InstanceState(autoscaling.LifecycleStatePendingWait) {
+			waiting = append(waiting, instance.ID)
+		}
+	}
+
+	var nodeReady map[string]bool
+	if len(waiting) > 0 && len(nodeJoinLifecycleHooks(machinePoolScope)) > 0 {
+		var err error
+		// The lifecycle actions still have to be abandoned when the workload cluster can't be reached.
+		if nodeReady, err = machinePoolScope.GetNodeReadyByInstanceID(ctx, waiting); err != nil {
+			machinePoolScope.Error(err, "failed to get the nodes of the instances waiting for lifecycle hooks")
+		}
+	}
+
+	return r.completeLifecycleActions(machinePoolScope, asgsvc, waiting, nodeReady)
+}
+
+// completeLifecycleActions completes the lifecycle actions of the given waiting instances according to the
+// readiness of their node, and records them in the status. Lifecycle actions already completed are never
+// completed again.
+func (r *AWSMachinePoolReconciler) completeLifecycleActions(machinePoolScope *scope.MachinePoolScope, asgsvc services.ASGInterface, waiting []string, nodeReady map[string]bool) error {
+	hooks := nodeJoinLifecycleHooks(machinePoolScope)
+
+	type actionKey struct{ instanceID, hookName string }
+	previous := map[actionKey]expinfrav1.LifecycleAction{}
+	for _, action := range machinePoolScope.AWSMachinePool.Status.LifecycleActions {
+		previous[actionKey{action.InstanceID, action.HookName}] = action
+	}
+
+	now := metav1.Now()
+	var actions []expinfrav1.LifecycleAction
+	var errs []error
+	for _, instanceID := range waiting {
+		for _, hook := range hooks {
+			action, ok := previous[actionKey{instanceID, hook.Name}]
+			if !ok {
+				action = expinfrav1.LifecycleAction{InstanceID: instanceID, HookName: hook.Name, StartTime: now}
+			}
+
+			if action.Result == "" {
+				var result expinfrav1.LifecycleActionResult
+				switch {
+				case nodeReady[instanceID]:
+					result = expinfrav1.LifecycleActionResultContinue
+				case now.Sub(action.StartTime.Time) >= hook.GetHeartbeatTimeout():
+					result = hook.GetDefaultResult()
+				}
+
+				if result != "" {
+					if err := asgsvc.CompleteLifecycleAction(machinePoolScope.Name(), hook.Name, instanceID, result); err != nil {
+						errs = append(errs, err)
+					} else {
+						action.Result = result
+						machinePoolScope.Info("Completed lifecycle action", "instance", instanceID, "hook", hook.Name, "result", result)
+						if result == expinfrav1.LifecycleActionResultAbandon {
+							r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeWarning, "LifecycleActionAbandoned",
+								"Abandoned lifecycle action of instance %s for hook %s: node not ready after %s", instanceID, hook.Name, hook.GetHeartbeatTimeout())
+						}
+					}
+				}
+			}
+
+			actions = append(actions, action)
+		}
+	}
+
+	// The instances which left the Pending:Wait state are no longer tracked.
+	machinePoolScope.AWSMachinePool.Status.LifecycleActions = actions
+	return kerrors.NewAggregate(errs)
+}
+
+// nodeJoinLifecycleHooks returns the lifecycle hooks of the spec completed once the node of the instance joined.
+func nodeJoinLifecycleHooks(machinePoolScope *scope.MachinePoolScope) []expinfrav1.AWSLifecycleHook {
+	var hooks []expinfrav1.AWSLifecycleHook
+	for _, hook := range machinePoolScope.AWSMachinePool.Spec.LifecycleHooks {
+		if hook.Role == expinfrav1.LifecycleHookRoleNodeJoin {
+			hooks = append(hooks, hook)
+		}
+	}
+	return hooks
+}
+
+// reconcileNormalResult requeues the AWSMachinePool while the ASG it created isn't described yet, while
+// lifecycle actions are pending, or while an instance refresh of the ASG is running.
+func reconcileNormalResult(machinePoolScope *scope.MachinePoolScope) ctrl.Result {
+	if machinePoolScope.AWSMachinePool.Status.ASG.CreationPending(time.Now(), asgCreationWindow) {
+		return ctrl.Result{RequeueAfter: asgCreationRequeueAfter}
+	}
+	if result := lifecycleActionsResult(machinePoolScope); !result.IsZero() {
+		return result
+	}
+	if machinePoolScope.AWSMachinePool.Status.InstanceRefreshStatus.InProgress() || machinePoolScope.AWSMachinePool.Status.AZSequentialRefresh != nil {
+		return ctrl.Result{RequeueAfter: instanceRefreshRequeueAfter}
+	}
+	return ctrl.Result{}
+}
+
+// lifecycleActionsResult requeues the AWSMachinePool while lifecycle actions are pending, as the nodes of the
+// workload cluster are not watched.
+func lifecycleActionsResult(machinePoolScope *scope.MachinePoolScope) ctrl.Result {
+	for _, action := range machinePoolScope.AWSMachinePool.Status.LifecycleActions {
+		if action.Result == "" {
+			return ctrl.Result{RequeueAfter: lifecycleActionsRequeueAfter}
+		}
+	}
+	return ctrl.Result{}
+}
+
+// reconcileStandby moves the instances of the pool in and out of standby as requested by the ASGInstanceStateAnnotation.
+// The state of the instances and the desired capacity of the ASG are updated to reflect the change. Failures are
+// reported as events, they don't block the reconciliation of the pool.
+func (r *AWSMachinePoolReconciler) reconcileStandby(machinePoolScope *scope.MachinePoolScope, asgsvc services.ASGInterface, existingASG *expinfrav1.AutoScalingGroup) {
+	states, err := machinePoolScope.AWSMachinePool.ASGInstanceStates()
+	if err != nil {
+		r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeWarning, "InvalidASGInstanceState", "Invalid %s annotation: %v", expinfrav1.ASGInstanceStateAnnotation, err)
+		return
+	}
+
+	var enter, exit []string
+	for _, instance := range existingASG.Instances {
+		switch {
+		case states[instance.ID] == expinfrav1.ASGInstanceStateStandby && string(instance.State) == autoscaling.LifecycleStateInService:
+			enter = append(enter, instance.ID)
+		case states[instance.ID] == expinfrav1.ASGInstanceStateInService && string(instance.State) == autoscaling.LifecycleStateStandby:
+			exit = append(exit, instance.ID)
+		}
+	}
+
+	if len(enter) > 0 {
+		if err := asgsvc.EnterStandby(existingASG.Name, enter); err != nil {
+			// non fatal error, so we continue
+			machinePoolScope.Error(err, "non-fatal: failed to move instances into standby", "instances", enter)
+			r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeWarning, "FailedEnterStandby", "Failed to move instances %v into standby: %v", enter, err)
+		} else {
+			r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeNormal, "SuccessfulEnterStandby", "Moved instances %v into standby", enter)
+			setInstanceLifecycleState(machinePoolScope, existingASG, enter, autoscaling.LifecycleStateStandby)
+			existingASG.DesiredCapacity = ptr.To(ptr.Deref(existingASG.DesiredCapacity, 0) - int32(len(enter)))
+		}
+	}
+
+	if len(exit) > 0 {
+		if err := asgsvc.ExitStandby(existingASG.Name, exit); err != nil {
+			// non fatal error, so we continue
+			machinePoolScope.Error(err, "non-fatal: failed to move instances out of standby", "instances", exit)
+			r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeWarning, "FailedExitStandby", "Failed to move instances %v out of standby: %v", exit, err)
+		} else {
+			r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeNormal, "SuccessfulExitStandby", "Moved instances %v out of standby", exit)
+			setInstanceLifecycleState(machinePoolScope, existingASG, exit, autoscaling.LifecycleStatePending)
+			existingASG.DesiredCapacity = ptr.To(ptr.Deref(existingASG.DesiredCapacity, 0) + int32(len(exit)))
+		}
+	}
+}
+
+// scaleInDrainEnabled returns whether the nodes of the instances removed when the replicas of the MachinePool are
+// decreased are drained before the instances are terminated. The nodes are drained by Cluster API, through the
+// Machines of the instances, which only exist with the MachinePoolMachines feature gate enabled.
+func scaleInDrainEnabled(machinePoolScope *scope.MachinePoolScope) bool {
+	return feature.Gates.Enabled(feature.MachinePoolMachines) &&
+		machinePoolScope.AWSMachinePool.Spec.ScaleInDrainPolicy != nil && machinePoolScope.MachinePool.Spec.Replicas != nil &&
+		!annotations.ReplicasManagedByExternalAutoscaler(machinePoolScope.MachinePool) &&
+		!machinePoolScope.AWSMachinePool.Spec.IsUnmanaged(expinfrav1.UnmanagedFieldDesiredCapacity)
+}
+
+// reconcileScaleInDrain removes the instances exceeding the replicas of the MachinePool when they are decreased, as
+// configured by the ScaleInDrainPolicy. The owner Machines of the instances are deleted, so that Cluster API drains
+// their nodes before their AWSMachines terminate them, and the instances are detached from the ASG, decrementing its
+// desired capacity, so that the ASG neither replaces them nor terminates other instances with their pods. The marked
+// instances are removed first. The instances without a Machine are left to the scale-in of the ASG.
+func (r *AWSMachinePoolReconciler) reconcileScaleInDrain(ctx context.Context, machinePoolScope *scope.MachinePoolScope, asgsvc services.ASGInterface, existingASG *expinfrav1.AutoScalingGroup, marked []string) error {
+	if !scaleInDrainEnabled(machinePoolScope) {
+		return nil
+	}
+
+	machines, err := instanceMachines(ctx, r.Client, machinePoolScope.MachinePool)
+	if err != nil {
+		return err
+	}
+	removed := pickScaleInInstances(machinePoolScope, existingASG, machines, marked)
+	if len(removed) == 0 {
+		return nil
+	}
+
+	pool := machinePoolScope.AWSMachinePool
+	for _, id := range removed {
+		machine := machines[id]
+		if !machine.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if err := r.Client.Delete(ctx, machine); client.IgnoreNotFound(err) != nil {
+			r.Recorder.Eventf(pool, corev1.EventTypeWarning, "FailedScaleInDrain", "Failed to delete Machine %s of instance %s removed by the scale-in: %v", machine.Name, id, err)
+			return errors.Wrapf(err, "failed to delete Machine %s of instance %s removed by the scale-in", machine.Name, id)
+		}
+	}
+
+	if err := asgsvc.DetachInstances(existingASG.Name, removed); err != nil {
+		r.Recorder.Eventf(pool, corev1.EventTypeWarning, "FailedScaleInDetach", "Failed to detach instances %v removed by the scale-in: %v", removed, err)
+		return errors.Wrap(err, "failed to detach the instances removed by the scale-in")
+	}
+	r.Recorder.Eventf(pool, corev1.EventTypeNormal, "SuccessfulScaleInDrain", "Detached instances %v removed by the scale-in, which are terminated once their nodes are drained", removed)
+	existingASG.DesiredCapacity = ptr.To(ptr.Deref(existingASG.DesiredCapacity, 0) - int32(len(removed)))
+	setInstanceLifecycleState(machinePoolScope, existingASG, removed, autoscaling.LifecycleStateDetaching)
+	return nil
+}
+
+// pickScaleInInstances returns the instances of the ASG exceeding the replicas of the MachinePool which have a
+// Machine. The instances whose Machine is already deleted are picked first, so that a failed detachment is retried,
+// then the marked instances.
+func pickScaleInInstances(machinePoolScope *scope.MachinePoolScope, existingASG *expinfrav1.AutoScalingGroup, machines map[string]*clusterv1.Machine, marked []string) []string {
+	// Only the instances launching or in service are removed, the ones in standby are left out of the replicas.
+	var launched int32
+	candidates := map[string]infrav1.Instance{}
+	for _, instance := range existingASG.Instances {
+		if instance.State != autoscaling.LifecycleStateInService && !strings.HasPrefix(string(instance.State), autoscaling.LifecycleStatePending) {
+			continue
+		}
+		launched++
+		if _, ok := machines[instance.ID]; ok {
+			candidates[instance.ID] = instance
+		}
+	}
+
+	// The desired capacity isn't decreased beyond the launched instances, the ones not launched yet are just left out
+	// of it.
+	current := ptr.Deref(existingASG.DesiredCapacity, 0)
+	if launched < current {
+		current = launched
+	}
+	excess := int(current - (*machinePoolScope.MachinePool.Spec.Replicas - machinePoolScope.StandbyInstanceCount()))
+
+	deleting := []string{}
+	for id := range candidates {
+		if !machines[id].DeletionTimestamp.IsZero() {
+			deleting = append(deleting, id)
+		}
+	}
+	sort.Strings(deleting)
+
+	removed := []string{}
+	for _, id := range append(deleting, marked...) {
+		if len(removed) >= excess {
+			break
+		}
+		if _, ok := candidates[id]; ok {
+			removed = append(removed, id)
+			delete(candidates, id)
+		}
+	}
+	for len(removed) < excess && len(candidates) > 0 {
+		id := nextScaleInInstance(candidates)
+		removed = append(removed, id)
+		delete(candidates, id)
+	}
+	return removed
+}
+
+// nextScaleInInstance picks the instance removed next by a scale-in, like the default termination policy of the ASG:
+// from the availability zone with the most instances, preferring the instances which aren't in service yet.
+func nextScaleInInstance(candidates map[string]infrav1.Instance) string {
+	perZone := map[string]int{}
+	instances := make([]infrav1.Instance, 0, len(candidates))
+	for _, instance := range candidates {
+		perZone[instance.AvailabilityZone]++
+		instances = append(instances, instance)
+	}
+
+	sort.Slice(instances, func(i, j int) bool {
+		a, b := instances[i], instances[j]
+		if perZone[a.AvailabilityZone] != perZone[b.AvailabilityZone] {
+			return perZone[a.AvailabilityZone] > perZone[b.AvailabilityZone]
+		}
+		if inServiceA, inServiceB := a.State == autoscaling.LifecycleStateInService, b.State == autoscaling.LifecycleStateInService; inServiceA != inServiceB {
+			return inServiceB
+		}
+		return a.ID < b.ID
+	})
+	return instances[0].ID
+}
+
+// reconcileDeleteMachineAnnotation removes the instances whose Machines carry the delete-machine annotation of Cluster
+// API when the replicas of the MachinePool are decreased, oldest Machine first and at most as many as the instances
+// exceeding the replicas. Without a ScaleInDrainPolicy the instances are terminated right away, decrementing the
+// desired capacity of the ASG, otherwise they are returned to be drained first. Nothing is done while the replicas
+// are managed by an external autoscaler, as the capacity of the ASG isn't touched then.
+func (r *AWSMachinePoolReconciler) reconcileDeleteMachineAnnotation(ctx context.Context, machinePoolScope *scope.MachinePoolScope, asgsvc services.ASGInterface, existingASG *expinfrav1.AutoScalingGroup) ([]string, error) {
+	if machinePoolScope.MachinePool.Spec.Replicas == nil || annotations.ReplicasManagedByExternalAutoscaler(machinePoolScope.MachinePool) ||
+		machinePoolScope.AWSMachinePool.Spec.IsUnmanaged(expinfrav1.UnmanagedFieldDesiredCapacity) {
+		return nil, nil
+	}
+
+	marked, err := markedForDeletionInstances(ctx, r.Client, machinePoolScope.MachinePool, existingASG)
+	if err != nil || len(marked) == 0 {
+		return nil, err
+	}
+	if scaleInDrainEnabled(machinePoolScope) {
+		return marked, nil
+	}
+
+	desired := *machinePoolScope.MachinePool.Spec.Replicas - machinePoolScope.StandbyInstanceCount()
+	excess := int(ptr.Deref(existingASG.DesiredCapacity, 0) - desired)
+	if excess <= 0 {
+		return nil, nil
+	}
+	if len(marked) > excess {
+		marked = marked[:excess]
+	}
+
+	for _, id := range marked {
+		if err := asgsvc.TerminateInstanceInASG(id, true); err != nil {
+			r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeWarning, "FailedScaleInTerminate", "Failed to terminate instance %s marked for deletion: %v", id, err)
+			return nil, errors.Wrap(err, "failed to terminate the instances marked for deletion")
+		}
+		r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeNormal, "SuccessfulScaleInTerminate", "Terminated instance %s marked for deletion", id)
+		existingASG.DesiredCapacity = ptr.To(ptr.Deref(existingASG.DesiredCapacity, 0) - 1)
+		setInstanceLifecycleState(machinePoolScope, existingASG, []string{id}, autoscaling.LifecycleStateTerminating)
+	}
+	return nil, nil
+}
+
+// markedForDeletionInstances returns the instances of the ASG whose Machines carry the delete-machine annotation,
+// ordered by the creation of their Machines, oldest first. The Machines whose instance already left the ASG are
+// skipped.
+func markedForDeletionInstances(ctx context.Context, kubeClient client.Client, mp *expclusterv1.MachinePool, existingASG *expinfrav1.AutoScalingGroup) ([]string, error) {
+	machines, err := instanceMachines(ctx, kubeClient, mp)
+	if err != nil {
+		return nil, err
+	}
+
+	inASG := sets.New[string]()
+	for _, instance := range existingASG.Instances {
+		if instance.State == autoscaling.LifecycleStateInService || strings.HasPrefix(string(instance.State), autoscaling.LifecycleStatePending) {
+			inASG.Insert(instance.ID)
+		}
+	}
+
+	marked := []string{}
+	for id, machine := range machines {
+		if _, ok := machine.Annotations[clusterv1.DeleteMachineAnnotation]; ok && inASG.Has(id) {
+			marked = append(marked, id)
+		}
+	}
+	sort.Slice(marked, func(i, j int) bool {
+		a, b := machines[marked[i]], machines[marked[j]]
+		if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+			return a.CreationTimestamp.Before(&b.CreationTimestamp)
+		}
+		return a.Name < b.Name
+	})
+	return marked, nil
+}
+
+// instanceMachines returns the owner Machines of the AWSMachines of the instances of a MachinePool, by instance ID.
+func instanceMachines(ctx context.Context, kubeClient client.Client, mp *expclusterv1.MachinePool) (map[string]*clusterv1.Machine, error) {
+	awsMachineList, err := getAWSMachines(ctx, mp, kubeClient)
+	if err != nil {
+		return nil, err
+	}
+
+	machines := map[string]*clusterv1.Machine{}
+	for i := range awsMachineList.Items {
+		awsMachine := &awsMachineList.Items[i]
+		machine, err := util.GetOwnerMachine(ctx, kubeClient, awsMachine.ObjectMeta)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get the owner Machine of AWSMachine %s", klog.KObj(awsMachine))
+		}
+		if machine != nil {
+			machines[ptr.Deref(awsMachine.Spec.InstanceID, "")] = machine
+		}
+	}
+	return machines, nil
+}
+
+// reportSuspendedProcesses reports the processes suspended on the ASG with the ASGSuspendedProcessesCondition.
+func reportSuspendedProcesses(machinePoolScope *scope.MachinePoolScope, existingASG *expinfrav1.AutoScalingGroup) {
+	if len(existingASG.CurrentlySuspendProcesses) == 0 {
+		infrautilconditions.ClearAWSState(machinePoolScope.AWSMachinePool, expinfrav1.ASGSuspendedProcessesCondition)
+		return
+	}
+
+	processes := append([]string{}, existingASG.CurrentlySuspendProcesses...)
+	sort.Strings(processes)
+	infrautilconditions.MarkAWSState(machinePoolScope.AWSMachinePool, expinfrav1.ASGSuspendedProcessesCondition, expinfrav1.SuspendedProcessesPresentReason,
+		fmt.Sprintf("Processes of the ASG are suspended: %s", strings.Join(processes, ", ")))
+}
+
+// reportStructureDrift reports with the ASGStructureDriftedCondition an ASG which was switched between a launch
+// template and a mixed instances policy outside of CAPA, before UpdateASG switches it back to the one of the spec.
+func reportStructureDrift(machinePoolScope *scope.MachinePoolScope, existingASG *expinfrav1.AutoScalingGroup) {
+	spec := &machinePoolScope.AWSMachinePool.Spec
+
+	var message string
+	switch {
+	case spec.IsUnmanaged(expinfrav1.UnmanagedFieldMixedInstancesPolicy):
+	case machinePoolScope.GetMixedInstancesPolicy() != nil && existingASG.MixedInstancesPolicy == nil:
+		message = "The ASG uses a launch template while spec.mixedInstancesPolicy is set, it is replaced by the mixed instances policy"
+	case machinePoolScope.GetMixedInstancesPolicy() == nil && existingASG.MixedInstancesPolicy != nil:
+		message = "The ASG uses a mixed instances policy while spec.mixedInstancesPolicy is not set, it is replaced by the launch template"
+	}
+
+	if message == "" {
+		infrautilconditions.ClearAWSState(machinePoolScope.AWSMachinePool, expinfrav1.ASGStructureDriftedCondition)
+		return
+	}
+	infrautilconditions.MarkAWSState(machinePoolScope.AWSMachinePool, expinfrav1.ASGStructureDriftedCondition, expinfrav1.LaunchTemplateStructureDriftedReason, message)
+}
+
+// setInstanceLifecycleState sets the lifecycle state of instances of the pool, both in the ASG and in the status
+// of the AWSMachinePool.
+func setInstanceLifecycleState(machinePoolScope *scope.MachinePoolScope, existingASG *expinfrav1.AutoScalingGroup, instanceIDs []string, state string) {
+	ids := sets.New[string](instanceIDs...)
+	for i := range existingASG.Instances {
+		if ids.Has(existingASG.Instances[i].ID) {
+			existingASG.Instances[i].State = infrav1.InstanceState(state)
+		}
+	}
+	for i := range machinePoolScope.AWSMachinePool.Status.Instances {
+		if ids.Has(machinePoolScope.AWSMachinePool.Status.Instances[i].InstanceID) {
+			machinePoolScope.AWSMachinePool.Status.Instances[i].LifecycleState = state
+		}
+	}
+}
+
+// isStandby returns whether an instance of an ASG is in standby or entering it.
+func isStandby(instance infrav1.Instance) bool {
+	return string(instance.State) == autoscaling.LifecycleStateStandby || string(instance.State) == autoscaling.LifecycleStateEnteringStandby
+}
+
+// isTerminating returns whether an instance of an ASG is being terminated or was terminated.
+func isTerminating(instance infrav1.Instance) bool {
+	return strings.HasPrefix(string(instance.State), "Terminat")
+}
+
+// isWarmed returns whether an instance is in the warm pool of the ASG, in one of the Warmed:* lifecycle states.
+func isWarmed(instance infrav1.Instance) bool {
+	return strings.HasPrefix(string(instance.State), "Warmed:")
+}
+
+// deleteOverrideLaunchTemplates deletes the launch templates of instance type overrides which no longer set their
+// own root volume, or all of them, and drops them from the status.
+func (r *AWSMachinePoolReconciler) deleteOverrideLaunchTemplates(machinePoolScope *scope.MachinePoolScope, ec2Svc services.EC2Interface, all bool) error {
+	existing := machinePoolScope.GetOverrideLaunchTemplatesStatus()
+	if len(existing) == 0 {
+		return nil
+	}
+
+	desired := map[string]struct{}{}
+	if !all {
+		for _, override := range machinePoolScope.GetLaunchTemplateOverrides() {
+			desired[override.InstanceType] = struct{}{}
+		}
+	}
+
+	remaining := make([]expinfrav1.OverrideLaunchTemplate, 0, len(existing))
+	for _, overrideLaunchTemplate := range existing {
+		if _, ok := desired[overrideLaunchTemplate.InstanceType]; ok {
+			remaining = append(remaining, overrideLaunchTemplate)
+			continue
+		}
+
+		name := scope.OverrideLaunchTemplateName(machinePoolScope.LaunchTemplateName(), overrideLaunchTemplate.InstanceType)
+		launchTemplate, _, _, err := ec2Svc.GetLaunchTemplate(name)
+		if err != nil {
+			return err
+		}
+		if launchTemplate == nil {
+			machinePoolScope.Debug("Unable to locate launch template of instance type override", "name", name)
+			continue
+		}
+
+		machinePoolScope.Info("deleting launch template of instance type override", "name", name)
+		if err := deleteLaunchTemplate(ec2Svc, r.Recorder, machinePoolScope.AWSMachinePool, overrideLaunchTemplate.ID, name); err != nil {
+			return err
+		}
+	}
+
+	if len(remaining) == len(existing) {
+		return nil
+	}
+	machinePoolScope.SetOverrideLaunchTemplatesStatus(remaining)
+	return machinePoolScope.PatchObject()
+}
+
+func (r *AWSMachinePoolReconciler) updatePool(machinePoolScope *scope.MachinePoolScope, clusterScope cloud.ClusterScoper, existingASG *expinfrav1.AutoScalingGroup) error {
+	asgSvc := r.getASGService(clusterScope)
+
+	subnetIDs, err := asgSvc.SubnetIDs(machinePoolScope)
+	if err != nil {
+		return errors.Wrapf(err, "fail to get subnets for ASG")
+	}
+	machinePoolScope.Debug("determining if subnets change in machinePoolScope",
+		"subnets of machinePoolScope", subnetIDs,
+		"subnets of existing asg", existingASG.Subnets)
+	less := func(a, b string) bool { return a < b }
+	subnetDiff := cmp.Diff(subnetIDs, existingASG.Subnets, cmpopts.SortSlices(less))
+	if subnetDiff != "" {
+		machinePoolScope.Debug("asg subnet diff detected", "diff", subnetDiff)
+	}
+
+	reportStructureDrift(machinePoolScope, existingASG)
+	asgDiff := diffASG(machinePoolScope, existingASG)
+	if asgDiff != "" {
+		machinePoolScope.Debug("asg diff detected", "asgDiff", asgDiff, "subnetDiff", subnetDiff)
+	}
+	if asgDiff != "" || subnetDiff != "" {
+		machinePoolScope.Info("updating AutoScalingGroup")
+
+		if err := asgSvc.UpdateASG(machinePoolScope); err != nil {
+			r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeWarning, "FailedUpdate", "Failed to update ASG: %v", err)
+			return errors.Wrap(err, "unable to update ASG")
+		}
+		if lifetime := machinePoolScope.AWSMachinePool.Spec.GetMaxInstanceLifetime(); lifetime != maxInstanceLifetime(existingASG) {
+			if lifetime == 0 {
+				r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeNormal, "MaxInstanceLifetimeChanged",
+					"Cleared the maximum instance lifetime of ASG %q, its instances are no longer replaced for their age", existingASG.Name)
+			} else {
+				r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeNormal, "MaxInstanceLifetimeChanged",
+					"Set the maximum instance lifetime of ASG %q to %s, its instances older than that are replaced", existingASG.Name, lifetime)
+			}
+		}
+	}
+
+	if err := r.reconcileMetricsCollection(machinePoolScope, asgSvc, existingASG); err != nil {
+		return err
+	}
+
+	// Only the target groups attached by the controller are detached, so pools which never had any are skipped.
+	if len(machinePoolScope.AWSMachinePool.Spec.TargetGroupARNs) > 0 || len(machinePoolScope.AWSMachinePool.Status.TargetGroupARNs) > 0 {
+		if err := asgSvc.ReconcileTargetGroups(machinePoolScope); err != nil {
+			return errors.Wrap(err, "unable to reconcile the target groups of the ASG")
+		}
+	}
+
+	if machinePoolScope.AWSMachinePool.Spec.IsUnmanaged(expinfrav1.UnmanagedFieldSuspendProcesses) {
+		return nil
+	}
+
+	suspendedProcessesSlice := machinePoolScope.AWSMachinePool.Spec.SuspendProcesses.ConvertSetValuesToStringSlice()
+	// The ASG lists its suspended processes in any order.
+	if !sets.New(existingASG.CurrentlySuspendProcesses...).Equal(sets.New(suspendedProcessesSlice...)) {
+		clusterScope.Info("reconciling processes", "suspend-processes", suspendedProcessesSlice)
+		var (
+			toBeSuspended []string
+			toBeResumed   []string
+
+			currentlySuspended = make(map[string]struct{})
+			desiredSuspended   = make(map[string]struct{})
+		)
+
+		// Convert the items to a map, so it's easy to create an effective diff from these two slices.
+		for _, p := range existingASG.CurrentlySuspendProcesses {
+			currentlySuspended[p] = struct{}{}
+		}
+
+		for _, p := range suspendedProcessesSlice {
+			desiredSuspended[p] = struct{}{}
+		}
+
+		// Anything that remains in the desired items is not currently suspended so must be suspended.
+		// Anything that remains in the currentlySuspended list must be resumed since they were not part of
+		// desiredSuspended.
+		for k := range desiredSuspended {
+			if _, ok := currentlySuspended[k]; ok {
+				delete(desiredSuspended, k)
+			}
+			delete(currentlySuspended, k)
+		}
+
+		// Convert them back into lists to pass them to resume/suspend.
+		for k := range desiredSuspended {
+			toBeSuspended = append(toBeSuspended, k)
+		}
+
+		for k := range currentlySuspended {
+			toBeResumed = append(toBeResumed, k)
+		}
+
+		if len(toBeSuspended) > 0 {
+			clusterScope.Info("suspending processes", "processes", toBeSuspended)
+			if err := asgSvc.SuspendProcesses(existingASG.Name, toBeSuspended); err != nil {
+				return errors.Wrapf(err, "failed to suspend processes while trying update pool")
+			}
+		}
+		if len(toBeResumed) > 0 {
+			clusterScope.Info("resuming processes", "processes", toBeResumed)
+			if err := asgSvc.ResumeProcesses(existingASG.Name, toBeResumed); err != nil {
+				return errors.Wrapf(err, "failed to resume processes while trying update pool")
+			}
+		}
+	}
+	return nil
+}
+
+func (r *AWSMachinePoolReconciler) createPool(machinePoolScope *scope.MachinePoolScope, clusterScope cloud.ClusterScoper) error {
+	clusterScope.Info("Initializing ASG client")
+
+	asgsvc := r.getASGService(clusterScope)
+
+	machinePoolScope.Info("Creating Autoscaling Group")
+	if _, err := asgsvc.CreateASG(machinePoolScope); err != nil {
+		return errors.Wrapf(err, "failed to create AWSMachinePool")
+	}
+
+	return nil
+}
+
+func (r *AWSMachinePoolReconciler) findASG(machinePoolScope *scope.MachinePoolScope, asgsvc services.ASGInterface) (*expinfrav1.AutoScalingGroup, error) {
+	// Query the instance using tags.
+	asg, err := asgsvc.GetASGByName(machinePoolScope)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to query AWSMachinePool by name")
+	}
+	if asg == nil {
+		// The AutoScaling API is eventually consistent, the ASG created by a previous reconciliation may not be
+		// described yet and mustn't be created again.
+		if machinePoolScope.AWSMachinePool.Status.ASG.CreationPending(time.Now(), asgCreationWindow) {
+			return nil, errASGCreationPending
+		}
+		return nil, nil
+	}
+	machinePoolScope.SetASGDescribed(asg)
+
+	return asg, nil
+}
+
+// maxInstanceLifetime returns the maximum lifetime of the instances of an ASG, or zero if they have none.
+func maxInstanceLifetime(asg *expinfrav1.AutoScalingGroup) time.Duration {
+	if asg.MaxInstanceLifetime == nil {
+		return 0
+	}
+	return asg.MaxInstanceLifetime.Duration
+}
+
+// diffASG compares incoming AWSMachinePool and compares against existing ASG.
+// Fields listed in spec.unmanagedFields are not compared.
+func diffASG(machinePoolScope *scope.MachinePoolScope, existingASG *expinfrav1.AutoScalingGroup) string {
+	detectedMachinePoolSpec := machinePoolScope.MachinePool.Spec.DeepCopy()
+
+	desiredCapacityUnmanaged := machinePoolScope.AWSMachinePool != nil && machinePoolScope.AWSMachinePool.Spec.IsUnmanaged(expinfrav1.UnmanagedFieldDesiredCapacity)
+	if !annotations.ReplicasManagedByExternalAutoscaler(machinePoolScope.MachinePool) && !desiredCapacityUnmanaged {
+		detectedMachinePoolSpec.Replicas = existingASG.DesiredCapacity
+		// The instances put in standby were left out of the desired capacity.
+		if machinePoolScope.AWSMachinePool != nil && existingASG.DesiredCapacity != nil {
+			if standby := machinePoolScope.StandbyInstanceCount(); standby > 0 {
+				detectedMachinePoolSpec.Replicas = ptr.To(*existingASG.DesiredCapacity + standby)
+			}
+		}
+	}
+	if diff := cmp.Diff(machinePoolScope.MachinePool.Spec, *detectedMachinePoolSpec); diff != "" {
+		return diff
+	}
+
+	// A new version which isn't rolled out, e.g. a userdata change, is launched once it passed the validation, and
+	// the latest version again once the validation is turned off.
+	if existingASG.LaunchTemplateVersion != "" && machinePoolScope.AWSMachinePool != nil {
+		if version := machinePoolScope.ASGLaunchTemplateVersion(); existingASG.LaunchTemplateVersion != version {
+			return fmt.Sprintf("launch template version: %s -> %s", existingASG.LaunchTemplateVersion, version)
+		}
+	}
+
+	spec := &machinePoolScope.AWSMachinePool.Spec
+	detectedAWSMachinePoolSpec := spec.DeepCopy()
+	if !spec.IsUnmanaged(expinfrav1.UnmanagedFieldMaxSize) {
+		// The surge added to the maximum size of the ASG for an instance refresh isn't part of the spec.
+		detectedAWSMachinePoolSpec.MaxSize = existingASG.MaxSize - (machinePoolScope.AWSMachinePool.ASGMaxSize() - spec.MaxSize)
+	}
+	if !spec.IsUnmanaged(expinfrav1.UnmanagedFieldMinSize) {
+		detectedAWSMachinePoolSpec.MinSize = existingASG.MinSize
+	}
+	detectedAWSMachinePoolSpec.CapacityRebalance = existingASG.CapacityRebalance
+	// An ASG without termination policies reports the Default one, which is the same as none in the spec.
+	existingTerminationPolicies := existingASG.TerminationPolicies
+	if len(existingTerminationPolicies) == 0 {
+		existingTerminationPolicies = []string{expinfrav1.TerminationPolicyDefault}
+	}
+	if !cmp.Equal(spec.GetTerminationPolicies(), existingTerminationPolicies) {
+		detectedAWSMachinePoolSpec.TerminationPolicies = existingASG.TerminationPolicies
+	}
+	// An ASG without a health check type uses EC2 health checks, which is the same as none in the spec.
+	existingHealthCheckType := existingASG.HealthCheckType
+	if existingHealthCheckType == "" {
+		existingHealthCheckType = expinfrav1.HealthCheckTypeEC2
+	}
+	if spec.GetHealthCheckType() != existingHealthCheckType {
+		detectedAWSMachinePoolSpec.HealthCheckType = existingHealthCheckType
+	}
+	if spec.HealthCheckGracePeriod != nil && spec.HealthCheckGracePeriod.Duration != existingASG.HealthCheckGracePeriod.Duration {
+		detectedAWSMachinePoolSpec.HealthCheckGracePeriod = existingASG.HealthCheckGracePeriod.DeepCopy()
+	}
+	// The default instance warmup of the ASG is left alone when it's not set in the spec.
+	if spec.DefaultInstanceWarmup != nil && (existingASG.DefaultInstanceWarmup == nil || spec.DefaultInstanceWarmup.Duration != existingASG.DefaultInstanceWarmup.Duration) {
+		detectedAWSMachinePoolSpec.DefaultInstanceWarmup = existingASG.DefaultInstanceWarmup.DeepCopy()
+	}
+	// An ASG without a maximum instance lifetime is the same as none or zero in the spec.
+	if spec.GetMaxInstanceLifetime() != maxInstanceLifetime(existingASG) {
+		detectedAWSMachinePoolSpec.MaxInstanceLifetime = existingASG.MaxInstanceLifetime.DeepCopy()
+	}
+	if !spec.IsUnmanaged(expinfrav1.UnmanagedFieldMixedInstancesPolicy) {
+		mixedInstancesPolicy := machinePoolScope.GetMixedInstancesPolicy()
+		// InstancesDistribution is optional, and the default values come from AWS, so
 		// they are not set by the AWSMachinePool defaulting webhook. If InstancesDistribution is
 		// not set, we use the AWS values for the purpose of comparison.
-		if mixedInstancesPolicy != nil && mixedInstancesPolicy.InstancesDistribution == nil {
-			mixedInstancesPolicy = machinePoolScope.AWSMachinePool.Spec.MixedInstancesPolicy.DeepCopy()
+		if mixedInstancesPolicy != nil && mixedInstancesPolicy.InstancesDistribution == nil && existingASG.MixedInstancesPolicy != nil {
+			mixedInstancesPolicy = mixedInstancesPolicy.DeepCopy()
 			mixedInstancesPolicy.InstancesDistribution = existingASG.MixedInstancesPolicy.InstancesDistribution
 		}
+		// The ASG only tells whether an override uses a launch template of its own, the root volume
+		// and bootstrap data themselves are reconciled as part of that launch template.
+		if mixedInstancesPolicy != nil {
+			mixedInstancesPolicy = mixedInstancesPolicy.DeepCopy()
+			for i := range mixedInstancesPolicy.Overrides {
+				if scope.HasOverrideLaunchTemplate(&spec.AWSLaunchTemplate, mixedInstancesPolicy.Overrides[i]) {
+					mixedInstancesPolicy.Overrides[i].RootVolume = &infrav1.Volume{}
+				}
+			}
+		}
 
 		if !cmp.Equal(mixedInstancesPolicy, existingASG.MixedInstancesPolicy) {
 			detectedAWSMachinePoolSpec.MixedInstancesPolicy = existingASG.MixedInstancesPolicy
@@ -607,7 +2477,9 @@ func machinePoolToInfrastructureMapFunc(gvk schema.GroupVersionKind) handler.Map
 	}
 }
 
-func (r *AWSMachinePoolReconciler) getInfraCluster(ctx context.Context, log *logger.Logger, cluster *clusterv1.Cluster, awsMachinePool *expinfrav1.AWSMachinePool) (scope.EC2Scope, error) {
+// getInfraCluster returns the scope of the AWSCluster or AWSManagedControlPlane of the cluster of an AWSMachinePool, or
+// nil if it doesn't exist yet.
+func getInfraCluster(ctx context.Context, kubeClient client.Client, log *logger.Logger, cluster *clusterv1.Cluster, awsMachinePool *expinfrav1.AWSMachinePool, tagUnmanagedNetworkResources bool, ownershipTagPrefix string) (scope.EC2Scope, error) {
 	var clusterScope *scope.ClusterScope
 	var managedControlPlaneScope *scope.ManagedControlPlaneScope
 	var err error
@@ -619,18 +2491,19 @@ func (r *AWSMachinePoolReconciler) getInfraCluster(ctx context.Context, log *log
 			Name:      cluster.Spec.ControlPlaneRef.Name,
 		}
 
-		if err := r.Get(ctx, controlPlaneName, controlPlane); err != nil {
+		if err := kubeClient.Get(ctx, controlPlaneName, controlPlane); err != nil {
 			// AWSManagedControlPlane is not ready
 			return nil, nil //nolint:nilerr
 		}
 
 		managedControlPlaneScope, err = scope.NewManagedControlPlaneScope(scope.ManagedControlPlaneScopeParams{
-			Client:                       r.Client,
+			Client:                       kubeClient,
 			Logger:                       log,
 			Cluster:                      cluster,
 			ControlPlane:                 controlPlane,
 			ControllerName:               "awsManagedControlPlane",
-			TagUnmanagedNetworkResources: r.TagUnmanagedNetworkResources,
+			TagUnmanagedNetworkResources: tagUnmanagedNetworkResources,
+			OwnershipTagPrefix:           ownershipTagPrefix,
 		})
 		if err != nil {
 			return nil, err
@@ -646,19 +2519,20 @@ func (r *AWSMachinePoolReconciler) getInfraCluster(ctx context.Context, log *log
 		Name:      cluster.Spec.InfrastructureRef.Name,
 	}
 
-	if err := r.Client.Get(ctx, infraClusterName, awsCluster); err != nil {
+	if err := kubeClient.Get(ctx, infraClusterName, awsCluster); err != nil {
 		// AWSCluster is not ready
 		return nil, nil //nolint:nilerr
 	}
 
 	// Create the cluster scope
 	clusterScope, err = scope.NewClusterScope(scope.ClusterScopeParams{
-		Client:                       r.Client,
+		Client:                       kubeClient,
 		Logger:                       log,
 		Cluster:                      cluster,
 		AWSCluster:                   awsCluster,
 		ControllerName:               "awsmachine",
-		TagUnmanagedNetworkResources: r.TagUnmanagedNetworkResources,
+		TagUnmanagedNetworkResources: tagUnmanagedNetworkResources,
+		OwnershipTagPrefix:           ownershipTagPrefix,
 	})
 	if err != nil {
 		return nil, err
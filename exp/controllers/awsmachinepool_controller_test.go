@@ -22,8 +22,10 @@ import (
 	"flag"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
 	"github.com/go-logr/logr"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
@@ -33,12 +35,15 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	apimachinerytypes "k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
+	utilfeature "k8s.io/component-base/featuregate/testing"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/feature"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
@@ -157,6 +162,8 @@ func TestAWSMachinePoolReconciler(t *testing.T) {
 		mockCtrl = gomock.NewController(t)
 		ec2Svc = mock_services.NewMockEC2Interface(mockCtrl)
 		asgSvc = mock_services.NewMockASGInterface(mockCtrl)
+		asgSvc.EXPECT().ReconcileScaleEvents(gomock.Any()).Return(nil).AnyTimes()
+		asgSvc.EXPECT().DescribeLatestInstanceRefresh(gomock.Any()).Return(nil, nil).AnyTimes()
 		reconSvc = mock_services.NewMockMachinePoolReconcileInterface(mockCtrl)
 
 		// If the test hangs for 9 minutes, increase the value here to the number of events during a reconciliation loop
@@ -213,6 +220,8 @@ func TestAWSMachinePoolReconciler(t *testing.T) {
 
 				_ = reconciler.reconcileNormal(context.Background(), ms, cs, cs)
 				g.Expect(buf).To(ContainSubstring("Error state detected, skipping reconciliation"))
+				expectConditions(g, ms.AWSMachinePool, []conditionAssertion{{infrav1.ReconciliationSkippedCondition, corev1.ConditionTrue, clusterv1.ConditionSeverityWarning, infrav1.FailedStateReason}})
+				g.Expect(recorder.Events).To(Receive(ContainSubstring("ReconciliationSkipped")))
 			})
 			t.Run("should add our finalizer to the machinepool", func(t *testing.T) {
 				g := NewWithT(t)
@@ -238,7 +247,10 @@ func TestAWSMachinePoolReconciler(t *testing.T) {
 				err := reconciler.reconcileNormal(context.Background(), ms, cs, cs)
 				g.Expect(err).To(BeNil())
 				g.Expect(buf.String()).To(ContainSubstring("Cluster infrastructure is not ready yet"))
-				expectConditions(g, ms.AWSMachinePool, []conditionAssertion{{expinfrav1.ASGReadyCondition, corev1.ConditionFalse, clusterv1.ConditionSeverityInfo, infrav1.WaitingForClusterInfrastructureReason}})
+				expectConditions(g, ms.AWSMachinePool, []conditionAssertion{
+					{expinfrav1.ASGReadyCondition, corev1.ConditionFalse, clusterv1.ConditionSeverityInfo, infrav1.WaitingForClusterInfrastructureReason},
+					{infrav1.ReconciliationSkippedCondition, corev1.ConditionTrue, clusterv1.ConditionSeverityInfo, infrav1.WaitingForClusterInfrastructureReason},
+				})
 			})
 			t.Run("should exit immediately if bootstrap data secret reference isn't available", func(t *testing.T) {
 				g := NewWithT(t)
@@ -254,7 +266,10 @@ func TestAWSMachinePoolReconciler(t *testing.T) {
 
 				g.Expect(err).To(BeNil())
 				g.Expect(buf.String()).To(ContainSubstring("Bootstrap data secret reference is not yet available"))
-				expectConditions(g, ms.AWSMachinePool, []conditionAssertion{{expinfrav1.ASGReadyCondition, corev1.ConditionFalse, clusterv1.ConditionSeverityInfo, infrav1.WaitingForBootstrapDataReason}})
+				expectConditions(g, ms.AWSMachinePool, []conditionAssertion{
+					{expinfrav1.ASGReadyCondition, corev1.ConditionFalse, clusterv1.ConditionSeverityInfo, infrav1.WaitingForBootstrapDataReason},
+					{infrav1.ReconciliationSkippedCondition, corev1.ConditionTrue, clusterv1.ConditionSeverityInfo, infrav1.WaitingForBootstrapDataReason},
+				})
 			})
 		})
 		t.Run("there's a provider ID", func(t *testing.T) {
@@ -309,6 +324,30 @@ func TestAWSMachinePoolReconciler(t *testing.T) {
 				g.Expect(err).To(Succeed())
 			})
 		})
+		t.Run("ASG created by a previous reconciliation isn't described yet", func(t *testing.T) {
+			g := NewWithT(t)
+			setup(t, g)
+			defer teardown(t, g)
+
+			createdAt := metav1.NewTime(time.Now().Add(-30 * time.Second))
+			ms.AWSMachinePool.Status.ASG = &expinfrav1.ASGReference{Name: "name", CreatedAt: &createdAt}
+			asgSvc.EXPECT().GetASGByName(gomock.Any()).Return(nil, nil)
+			asgSvc.EXPECT().CreateASG(gomock.Any()).Times(0)
+
+			err := reconciler.reconcileNormal(context.Background(), ms, cs, cs)
+			g.Expect(err).To(Succeed())
+			g.Expect(conditions.GetReason(ms.AWSMachinePool, expinfrav1.ASGReadyCondition)).To(Equal(expinfrav1.ASGNotFoundReason))
+			g.Expect(reconcileNormalResult(ms).RequeueAfter).To(Equal(asgCreationRequeueAfter))
+
+			// The ASG is created again once it isn't described within the window.
+			createdAt = metav1.NewTime(time.Now().Add(-asgCreationWindow))
+			reconSvc.EXPECT().ReconcileLaunchTemplate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			asgSvc.EXPECT().GetASGByName(gomock.Any()).Return(nil, nil)
+			asgSvc.EXPECT().CreateASG(gomock.Any()).Return(nil, nil)
+
+			err = reconciler.reconcileNormal(context.Background(), ms, cs, cs)
+			g.Expect(err).To(Succeed())
+		})
 		t.Run("all processes are suspended", func(t *testing.T) {
 			setSuspendedProcesses := func(t *testing.T, g *WithT) {
 				t.Helper()
@@ -326,6 +365,7 @@ func TestAWSMachinePoolReconciler(t *testing.T) {
 				reconSvc.EXPECT().ReconcileTags(gomock.Any(), gomock.Any()).Return(nil)
 				asgSvc.EXPECT().GetASGByName(gomock.Any()).Return(&expinfrav1.AutoScalingGroup{
 					Name: "name",
+					Tags: ownedASGTags,
 				}, nil)
 				asgSvc.EXPECT().SubnetIDs(gomock.Any()).Return([]string{}, nil).Times(1)
 				asgSvc.EXPECT().UpdateASG(gomock.Any()).Return(nil).AnyTimes()
@@ -344,6 +384,31 @@ func TestAWSMachinePoolReconciler(t *testing.T) {
 				err := reconciler.reconcileNormal(context.Background(), ms, cs, cs)
 				g.Expect(err).To(Succeed())
 			})
+			t.Run("processes set to false should be resumed when switching to all processes", func(t *testing.T) {
+				g := NewWithT(t)
+				setup(t, g)
+				defer teardown(t, g)
+				setSuspendedProcesses(t, g)
+				ms.AWSMachinePool.Spec.SuspendProcesses.Processes = &expinfrav1.Processes{HealthCheck: ptr.To[bool](false)}
+				reconSvc.EXPECT().ReconcileLaunchTemplate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				reconSvc.EXPECT().ReconcileTags(gomock.Any(), gomock.Any()).Return(nil)
+				asgSvc.EXPECT().GetASGByName(gomock.Any()).Return(&expinfrav1.AutoScalingGroup{
+					Name: "name",
+					Tags: ownedASGTags,
+					CurrentlySuspendProcesses: []string{
+						"ReplaceUnhealthy", "HealthCheck", "InstanceRefresh", "AZRebalance", "AlarmNotification",
+						"AddToLoadBalancer", "Terminate", "Launch", "ScheduledActions",
+					},
+				}, nil)
+				asgSvc.EXPECT().SubnetIDs(gomock.Any()).Return([]string{}, nil).Times(1)
+				asgSvc.EXPECT().UpdateASG(gomock.Any()).Return(nil).AnyTimes()
+				asgSvc.EXPECT().SuspendProcesses(gomock.Any(), gomock.Any()).Times(0)
+				asgSvc.EXPECT().ResumeProcesses("name", []string{"HealthCheck"}).Return(nil).Times(1)
+
+				err := reconciler.reconcileNormal(context.Background(), ms, cs, cs)
+				g.Expect(err).To(Succeed())
+				g.Expect(ms.AWSMachinePool.Spec.SuspendProcesses.Processes).To(Equal(&expinfrav1.Processes{HealthCheck: ptr.To[bool](false)}))
+			})
 		})
 		t.Run("there are existing processes already suspended", func(t *testing.T) {
 			setSuspendedProcesses := func(t *testing.T, g *WithT) {
@@ -366,6 +431,7 @@ func TestAWSMachinePoolReconciler(t *testing.T) {
 				reconSvc.EXPECT().ReconcileTags(gomock.Any(), gomock.Any()).Return(nil)
 				asgSvc.EXPECT().GetASGByName(gomock.Any()).Return(&expinfrav1.AutoScalingGroup{
 					Name:                      "name",
+					Tags:                      ownedASGTags,
 					CurrentlySuspendProcesses: []string{"Launch", "process3"},
 				}, nil)
 				asgSvc.EXPECT().SubnetIDs(gomock.Any()).Return([]string{}, nil).Times(1)
@@ -403,12 +469,103 @@ func TestAWSMachinePoolReconciler(t *testing.T) {
 			_ = reconciler.reconcileNormal(context.Background(), ms, cs, cs)
 			g.Expect(*ms.MachinePool.Spec.Replicas).To(Equal(int32(1)))
 		})
+		t.Run("externally managed ASG", func(t *testing.T) {
+			g := NewWithT(t)
+			setup(t, g)
+			defer teardown(t, g)
+
+			// The mocks fail on any other call, so that neither the ASG nor the launch template are changed.
+			asg := expinfrav1.AutoScalingGroup{
+				Name:                      "an-asg",
+				ID:                        "aws:///us-east-1a/an-asg",
+				DesiredCapacity:           ptr.To[int32](2),
+				CurrentlySuspendProcesses: []string{"Launch"},
+				Instances: []infrav1.Instance{
+					{ID: "i-1", AvailabilityZone: "us-east-1a", State: infrav1.InstanceStateRunning},
+					{ID: "i-2", AvailabilityZone: "us-east-1b", State: infrav1.InstanceStateRunning},
+				},
+			}
+			asgSvc.EXPECT().GetASGByName(gomock.Any()).Return(&asg, nil)
+
+			ms.AWSMachinePool.Annotations = map[string]string{clusterv1.ManagedByAnnotation: "terraform"}
+			ms.MachinePool.Spec.Replicas = ptr.To[int32](2)
+
+			g.Expect(reconciler.reconcileNormal(context.Background(), ms, cs, cs)).To(Succeed())
+			g.Expect(ms.AWSMachinePool.Spec.ProviderIDList).To(ConsistOf("aws:///us-east-1a/i-1", "aws:///us-east-1b/i-2"))
+			g.Expect(ms.AWSMachinePool.Status.Replicas).To(Equal(int32(2)))
+			g.Expect(ms.AWSMachinePool.Status.Ready).To(BeTrue())
+			g.Expect(conditions.IsTrue(ms.AWSMachinePool, expinfrav1.ASGReadyCondition)).To(BeTrue())
+			g.Expect(conditions.GetReason(ms.AWSMachinePool, expinfrav1.ASGExternallyManagedCondition)).To(Equal(expinfrav1.ExternallyManagedReason))
+			g.Expect(conditions.Has(ms.AWSMachinePool, expinfrav1.ASGSuspendedProcessesCondition)).To(BeTrue())
+		})
+		t.Run("ASG not owned by the cluster", func(t *testing.T) {
+			unownedASG := func() *expinfrav1.AutoScalingGroup {
+				return &expinfrav1.AutoScalingGroup{
+					Name:            "test",
+					MinSize:         1,
+					MaxSize:         5,
+					DesiredCapacity: ptr.To[int32](3),
+					Tags:            infrav1.Tags{"team": "platform"},
+				}
+			}
+			t.Run("should not change the ASG until it's adopted", func(t *testing.T) {
+				g := NewWithT(t)
+				setup(t, g)
+				defer teardown(t, g)
+
+				// The mocks fail on any other call, so that neither the ASG nor the launch template are changed.
+				asgSvc.EXPECT().GetASGByName(gomock.Any()).Return(unownedASG(), nil)
+
+				g.Expect(reconciler.reconcileNormal(context.Background(), ms, cs, cs)).To(Succeed())
+				g.Expect(conditions.IsTrue(ms.AWSMachinePool, expinfrav1.ASGAdoptionPendingCondition)).To(BeTrue())
+				g.Expect(conditions.GetReason(ms.AWSMachinePool, expinfrav1.ASGReadyCondition)).To(Equal(expinfrav1.ASGNotOwnedReason))
+				g.Expect(ms.AWSMachinePool.Status.Ready).To(BeFalse())
+				g.Eventually(recorder.Events).Should(Receive(ContainSubstring(expinfrav1.AdoptASGAnnotation)))
+			})
+			t.Run("should tag the ASG and record its sizes when it's adopted", func(t *testing.T) {
+				g := NewWithT(t)
+				setup(t, g)
+				defer teardown(t, g)
+
+				ms.AWSMachinePool.Annotations = map[string]string{expinfrav1.AdoptASGAnnotation: "true"}
+				conditions.MarkTrueWithNegativePolarity(ms.AWSMachinePool, expinfrav1.ASGAdoptionPendingCondition, expinfrav1.ASGNotOwnedReason, clusterv1.ConditionSeverityWarning, "")
+				asgSvc.EXPECT().GetASGByName(gomock.Any()).Return(unownedASG(), nil)
+				asgSvc.EXPECT().AdoptASG(gomock.Any()).Return(nil)
+				reconSvc.EXPECT().ReconcileLaunchTemplate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				reconSvc.EXPECT().ReconcileTags(gomock.Any(), gomock.Any()).Return(nil)
+				asgSvc.EXPECT().SubnetIDs(gomock.Any()).Return([]string{}, nil)
+				asgSvc.EXPECT().UpdateASG(gomock.Any()).Return(nil).AnyTimes()
+
+				g.Expect(reconciler.reconcileNormal(context.Background(), ms, cs, cs)).To(Succeed())
+				g.Expect(ms.AWSMachinePool.Annotations).NotTo(HaveKey(expinfrav1.AdoptASGAnnotation))
+				g.Expect(ms.AWSMachinePool.Annotations).To(HaveKeyWithValue(expinfrav1.AdoptedASGOriginalSizeAnnotation, "1/5/3"))
+				g.Expect(conditions.Has(ms.AWSMachinePool, expinfrav1.ASGAdoptionPendingCondition)).To(BeFalse())
+				g.Eventually(recorder.Events).Should(Receive(ContainSubstring("ASGAdopted")))
+			})
+			t.Run("should keep the recorded sizes and the annotation when the adoption failed", func(t *testing.T) {
+				g := NewWithT(t)
+				setup(t, g)
+				defer teardown(t, g)
+
+				ms.AWSMachinePool.Annotations = map[string]string{
+					expinfrav1.AdoptASGAnnotation:               "true",
+					expinfrav1.AdoptedASGOriginalSizeAnnotation: "0/2/1",
+				}
+				asgSvc.EXPECT().GetASGByName(gomock.Any()).Return(unownedASG(), nil)
+				asgSvc.EXPECT().AdoptASG(gomock.Any()).Return(errors.New("access denied"))
+
+				g.Expect(reconciler.reconcileNormal(context.Background(), ms, cs, cs)).NotTo(Succeed())
+				g.Expect(ms.AWSMachinePool.Annotations).To(HaveKeyWithValue(expinfrav1.AdoptASGAnnotation, "true"))
+				g.Expect(ms.AWSMachinePool.Annotations).To(HaveKeyWithValue(expinfrav1.AdoptedASGOriginalSizeAnnotation, "0/2/1"))
+			})
+		})
 		t.Run("No need to update Asg because asgNeedsUpdates is false and no subnets change", func(t *testing.T) {
 			g := NewWithT(t)
 			setup(t, g)
 			defer teardown(t, g)
 
 			asg := expinfrav1.AutoScalingGroup{
+				Tags:    ownedASGTags,
 				MinSize: int32(0),
 				MaxSize: int32(100),
 				MixedInstancesPolicy: &expinfrav1.MixedInstancesPolicy{
@@ -440,6 +597,7 @@ func TestAWSMachinePoolReconciler(t *testing.T) {
 			defer teardown(t, g)
 
 			asg := expinfrav1.AutoScalingGroup{
+				Tags:    ownedASGTags,
 				MinSize: int32(0),
 				MaxSize: int32(100),
 				Subnets: []string{"subnet1", "subnet2"}}
@@ -458,6 +616,7 @@ func TestAWSMachinePoolReconciler(t *testing.T) {
 			defer teardown(t, g)
 
 			asg := expinfrav1.AutoScalingGroup{
+				Tags:    ownedASGTags,
 				MinSize: int32(0),
 				MaxSize: int32(2),
 				Subnets: []string{}}
@@ -519,7 +678,7 @@ func TestAWSMachinePoolReconciler(t *testing.T) {
 					&userDataSecretKey,
 					nil)
 				ec2Svc.EXPECT().DiscoverLaunchTemplateAMI(gomock.Any()).Return(ptr.To[string]("ami-existing"), nil) // no change
-				ec2Svc.EXPECT().LaunchTemplateNeedsUpdate(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+				ec2Svc.EXPECT().LaunchTemplateChangedFields(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
 
 				asgSvc.EXPECT().GetASGByName(gomock.Any()).DoAndReturn(func(scope *scope.MachinePoolScope) (*expinfrav1.AutoScalingGroup, error) {
 					g.Expect(scope.Name()).To(Equal("test"))
@@ -527,6 +686,7 @@ func TestAWSMachinePoolReconciler(t *testing.T) {
 					// No difference to `AWSMachinePool.spec`
 					return &expinfrav1.AutoScalingGroup{
 						Name: scope.Name(),
+						Tags: ownedASGTags,
 						Subnets: []string{
 							"subnet-1",
 						},
@@ -566,13 +726,13 @@ func TestAWSMachinePoolReconciler(t *testing.T) {
 					&userDataSecretKey,
 					nil)
 				ec2Svc.EXPECT().DiscoverLaunchTemplateAMI(gomock.Any()).Return(ptr.To[string]("ami-different"), nil)
-				ec2Svc.EXPECT().LaunchTemplateNeedsUpdate(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+				ec2Svc.EXPECT().LaunchTemplateChangedFields(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
 				asgSvc.EXPECT().CanStartASGInstanceRefresh(gomock.Any()).Return(true, nil)
-				ec2Svc.EXPECT().PruneLaunchTemplateVersions(gomock.Any()).Return(nil)
+				ec2Svc.EXPECT().PruneLaunchTemplateVersions(gomock.Any(), gomock.Any()).Return(nil)
 				ec2Svc.EXPECT().CreateLaunchTemplateVersion(gomock.Any(), gomock.Any(), gomock.Eq(ptr.To[string]("ami-different")), gomock.Eq(apimachinerytypes.NamespacedName{Namespace: "default", Name: "bootstrap-data"}), gomock.Any()).Return(nil)
 				ec2Svc.EXPECT().GetLaunchTemplateLatestVersion(gomock.Any()).Return("2", nil)
 				// AMI change should trigger rolling out new nodes
-				asgSvc.EXPECT().StartASGInstanceRefresh(gomock.Any())
+				asgSvc.EXPECT().StartASGInstanceRefresh(gomock.Any()).Return("instance-refresh-1", nil)
 
 				asgSvc.EXPECT().GetASGByName(gomock.Any()).DoAndReturn(func(scope *scope.MachinePoolScope) (*expinfrav1.AutoScalingGroup, error) {
 					g.Expect(scope.Name()).To(Equal("test"))
@@ -580,6 +740,7 @@ func TestAWSMachinePoolReconciler(t *testing.T) {
 					// No difference to `AWSMachinePool.spec`
 					return &expinfrav1.AutoScalingGroup{
 						Name: scope.Name(),
+						Tags: ownedASGTags,
 						Subnets: []string{
 							"subnet-1",
 						},
@@ -620,15 +781,15 @@ func TestAWSMachinePoolReconciler(t *testing.T) {
 					&apimachinerytypes.NamespacedName{Namespace: "default", Name: "previous-secret-name"},
 					nil)
 				ec2Svc.EXPECT().DiscoverLaunchTemplateAMI(gomock.Any()).Return(ptr.To[string]("ami-existing"), nil)
-				ec2Svc.EXPECT().LaunchTemplateNeedsUpdate(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+				ec2Svc.EXPECT().LaunchTemplateChangedFields(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
 				asgSvc.EXPECT().CanStartASGInstanceRefresh(gomock.Any()).Return(true, nil)
-				ec2Svc.EXPECT().PruneLaunchTemplateVersions(gomock.Any()).Return(nil)
+				ec2Svc.EXPECT().PruneLaunchTemplateVersions(gomock.Any(), gomock.Any()).Return(nil)
 				ec2Svc.EXPECT().CreateLaunchTemplateVersion(gomock.Any(), gomock.Any(), gomock.Eq(ptr.To[string]("ami-existing")), gomock.Eq(apimachinerytypes.NamespacedName{Namespace: "default", Name: "bootstrap-data"}), gomock.Any()).Return(nil)
 				ec2Svc.EXPECT().GetLaunchTemplateLatestVersion(gomock.Any()).Return("2", nil)
 				// Changing the bootstrap data secret name should trigger rolling out new nodes, no matter what the
 				// content (user data) is. This way, users can enforce a rollout by changing the bootstrap config
 				// reference (`MachinePool.spec.template.spec.bootstrap`).
-				asgSvc.EXPECT().StartASGInstanceRefresh(gomock.Any())
+				asgSvc.EXPECT().StartASGInstanceRefresh(gomock.Any()).Return("instance-refresh-1", nil)
 
 				asgSvc.EXPECT().GetASGByName(gomock.Any()).DoAndReturn(func(scope *scope.MachinePoolScope) (*expinfrav1.AutoScalingGroup, error) {
 					g.Expect(scope.Name()).To(Equal("test"))
@@ -636,6 +797,7 @@ func TestAWSMachinePoolReconciler(t *testing.T) {
 					// No difference to `AWSMachinePool.spec`
 					return &expinfrav1.AutoScalingGroup{
 						Name: scope.Name(),
+						Tags: ownedASGTags,
 						Subnets: []string{
 							"subnet-1",
 						},
@@ -704,15 +866,15 @@ func TestAWSMachinePoolReconciler(t *testing.T) {
 					&apimachinerytypes.NamespacedName{Namespace: "default", Name: "bootstrap-data"},
 					nil)
 				ec2Svc.EXPECT().DiscoverLaunchTemplateAMI(gomock.Any()).Return(ptr.To[string]("ami-existing"), nil)
-				ec2Svc.EXPECT().LaunchTemplateNeedsUpdate(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+				ec2Svc.EXPECT().LaunchTemplateChangedFields(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
 				asgSvc.EXPECT().CanStartASGInstanceRefresh(gomock.Any()).Return(true, nil)
-				ec2Svc.EXPECT().PruneLaunchTemplateVersions(gomock.Any()).Return(nil)
+				ec2Svc.EXPECT().PruneLaunchTemplateVersions(gomock.Any(), gomock.Any()).Return(nil)
 				ec2Svc.EXPECT().CreateLaunchTemplateVersion(gomock.Any(), gomock.Any(), gomock.Eq(ptr.To[string]("ami-existing")), gomock.Eq(apimachinerytypes.NamespacedName{Namespace: "default", Name: "bootstrap-data-new"}), gomock.Any()).Return(nil)
 				ec2Svc.EXPECT().GetLaunchTemplateLatestVersion(gomock.Any()).Return("2", nil)
 				// Changing the bootstrap data secret name should trigger rolling out new nodes, no matter what the
 				// content (user data) is. This way, users can enforce a rollout by changing the bootstrap config
 				// reference (`MachinePool.spec.template.spec.bootstrap.configRef`).
-				asgSvc.EXPECT().StartASGInstanceRefresh(gomock.Any())
+				asgSvc.EXPECT().StartASGInstanceRefresh(gomock.Any()).Return("instance-refresh-1", nil)
 
 				asgSvc.EXPECT().GetASGByName(gomock.Any()).DoAndReturn(func(scope *scope.MachinePoolScope) (*expinfrav1.AutoScalingGroup, error) {
 					g.Expect(scope.Name()).To(Equal("test"))
@@ -720,6 +882,7 @@ func TestAWSMachinePoolReconciler(t *testing.T) {
 					// No difference to `AWSMachinePool.spec`
 					return &expinfrav1.AutoScalingGroup{
 						Name: scope.Name(),
+						Tags: ownedASGTags,
 						Subnets: []string{
 							"subnet-1",
 						},
@@ -736,499 +899,2671 @@ func TestAWSMachinePoolReconciler(t *testing.T) {
 				g.Expect(err).To(Succeed())
 			})
 		})
-	})
 
-	t.Run("Deleting an AWSMachinePool", func(t *testing.T) {
-		finalizer := func(t *testing.T, g *WithT) {
-			t.Helper()
+		t.Run("node join lifecycle hooks", func(t *testing.T) {
+			setupHooks := func(t *testing.T, g *WithT) {
+				t.Helper()
 
-			ms.AWSMachinePool.Finalizers = []string{
-				expinfrav1.MachinePoolFinalizer,
-				metav1.FinalizerDeleteDependents,
+				ms.AWSMachinePool.Spec.LifecycleHooks = []expinfrav1.AWSLifecycleHook{{
+					Name:             "node-join",
+					Role:             expinfrav1.LifecycleHookRoleNodeJoin,
+					HeartbeatTimeout: &metav1.Duration{Duration: 5 * time.Minute},
+				}}
 			}
-		}
-		t.Run("should exit immediately on an error state", func(t *testing.T) {
-			g := NewWithT(t)
-			setup(t, g)
-			defer teardown(t, g)
-			finalizer(t, g)
 
-			expectedErr := errors.New("no connection available ")
-			asgSvc.EXPECT().GetASGByName(gomock.Any()).Return(nil, expectedErr).AnyTimes()
+			t.Run("should complete the lifecycle action once the node is ready", func(t *testing.T) {
+				g := NewWithT(t)
+				setup(t, g)
+				defer teardown(t, g)
+				setupHooks(t, g)
 
-			err := reconciler.reconcileDelete(ms, cs, cs)
-			g.Expect(errors.Cause(err)).To(MatchError(expectedErr))
-		})
-		t.Run("should log and remove finalizer when no machinepool exists", func(t *testing.T) {
-			g := NewWithT(t)
-			setup(t, g)
-			defer teardown(t, g)
-			finalizer(t, g)
+				asgSvc.EXPECT().CompleteLifecycleAction("test", "node-join", "i-ready", expinfrav1.LifecycleActionResultContinue).Return(nil)
 
-			asgSvc.EXPECT().GetASGByName(gomock.Any()).Return(nil, nil)
-			ec2Svc.EXPECT().GetLaunchTemplate(gomock.Any()).Return(nil, "", nil, nil).AnyTimes()
+				err := reconciler.completeLifecycleActions(ms, asgSvc, []string{"i-ready", "i-joining"}, map[string]bool{"i-ready": true})
+				g.Expect(err).To(Succeed())
+				g.Expect(ms.AWSMachinePool.Status.LifecycleActions).To(HaveLen(2))
+				g.Expect(ms.AWSMachinePool.Status.LifecycleActions[0].Result).To(Equal(expinfrav1.LifecycleActionResultContinue))
+				g.Expect(ms.AWSMachinePool.Status.LifecycleActions[1].Result).To(BeEmpty())
+				g.Expect(lifecycleActionsResult(ms).RequeueAfter).To(Equal(lifecycleActionsRequeueAfter))
+			})
 
-			buf := new(bytes.Buffer)
-			klog.SetOutput(buf)
+			t.Run("should abandon the lifecycle action once the heartbeat timeout elapsed", func(t *testing.T) {
+				g := NewWithT(t)
+				setup(t, g)
+				defer teardown(t, g)
+				setupHooks(t, g)
 
-			err := reconciler.reconcileDelete(ms, cs, cs)
-			g.Expect(err).To(BeNil())
-			g.Expect(buf.String()).To(ContainSubstring("Unable to locate ASG"))
-			g.Expect(ms.AWSMachinePool.Finalizers).To(ConsistOf(metav1.FinalizerDeleteDependents))
-			g.Eventually(recorder.Events).Should(Receive(ContainSubstring(expinfrav1.ASGNotFoundReason)))
+				ms.AWSMachinePool.Status.LifecycleActions = []expinfrav1.LifecycleAction{{
+					InstanceID: "i-stuck",
+					HookName:   "node-join",
+					StartTime:  metav1.NewTime(time.Now().Add(-10 * time.Minute)),
+				}}
+				asgSvc.EXPECT().CompleteLifecycleAction("test", "node-join", "i-stuck", expinfrav1.LifecycleActionResultAbandon).Return(nil)
+
+				err := reconciler.completeLifecycleActions(ms, asgSvc, []string{"i-stuck"}, nil)
+				g.Expect(err).To(Succeed())
+				g.Expect(ms.AWSMachinePool.Status.LifecycleActions).To(ConsistOf(HaveField("Result", expinfrav1.LifecycleActionResultAbandon)))
+				g.Expect(lifecycleActionsResult(ms).RequeueAfter).To(BeZero())
+				g.Eventually(recorder.Events).Should(Receive(ContainSubstring("LifecycleActionAbandoned")))
+			})
+
+			t.Run("should not complete a lifecycle action twice and forget instances no longer waiting", func(t *testing.T) {
+				g := NewWithT(t)
+				setup(t, g)
+				defer teardown(t, g)
+				setupHooks(t, g)
+
+				ms.AWSMachinePool.Status.LifecycleActions = []expinfrav1.LifecycleAction{
+					{InstanceID: "i-completed", HookName: "node-join", StartTime: metav1.Now(), Result: expinfrav1.LifecycleActionResultContinue},
+					{InstanceID: "i-in-service", HookName: "node-join", StartTime: metav1.Now()},
+				}
+				asgSvc.EXPECT().CompleteLifecycleAction(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+				err := reconciler.completeLifecycleActions(ms, asgSvc, []string{"i-completed"}, map[string]bool{"i-completed": true})
+				g.Expect(err).To(Succeed())
+				g.Expect(ms.AWSMachinePool.Status.LifecycleActions).To(ConsistOf(HaveField("InstanceID", "i-completed")))
+			})
+
+			t.Run("should retry the lifecycle action when it could not be completed", func(t *testing.T) {
+				g := NewWithT(t)
+				setup(t, g)
+				defer teardown(t, g)
+				setupHooks(t, g)
+
+				asgSvc.EXPECT().CompleteLifecycleAction("test", "node-join", "i-ready", expinfrav1.LifecycleActionResultContinue).Return(errors.New("throttled"))
+
+				err := reconciler.completeLifecycleActions(ms, asgSvc, []string{"i-ready"}, map[string]bool{"i-ready": true})
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(ms.AWSMachinePool.Status.LifecycleActions).To(ConsistOf(HaveField("Result", BeEmpty())))
+			})
 		})
-		t.Run("should cause AWSMachinePool to go into NotReady", func(t *testing.T) {
-			g := NewWithT(t)
-			setup(t, g)
-			defer teardown(t, g)
-			finalizer(t, g)
 
-			inProgressASG := expinfrav1.AutoScalingGroup{
-				Name:   "an-asg-that-is-currently-being-deleted",
-				Status: expinfrav1.ASGStatusDeleteInProgress,
+		t.Run("predictive scaling policies", func(t *testing.T) {
+			setupPolicies := func(t *testing.T, g *WithT) {
+				t.Helper()
+
+				ms.AWSMachinePool.Spec.ScalingPolicies = []expinfrav1.ScalingPolicy{{
+					Name: "cpu",
+					PredictiveScaling: &expinfrav1.PredictiveScalingConfiguration{
+						MetricSpecifications: []expinfrav1.PredictiveScalingMetricSpecification{{
+							TargetValue:          50,
+							PredefinedMetricPair: expinfrav1.PredefinedMetricPair{PredefinedMetricType: expinfrav1.PredefinedMetricPairTypeASGCPUUtilization},
+						}},
+						Mode: expinfrav1.PredictiveScalingModeForecastOnly,
+					},
+				}}
 			}
-			asgSvc.EXPECT().GetASGByName(gomock.Any()).Return(&inProgressASG, nil)
-			ec2Svc.EXPECT().GetLaunchTemplate(gomock.Any()).Return(nil, "", nil, nil).AnyTimes()
 
-			buf := new(bytes.Buffer)
-			klog.SetOutput(buf)
-			err := reconciler.reconcileDelete(ms, cs, cs)
-			g.Expect(err).To(BeNil())
-			g.Expect(ms.AWSMachinePool.Status.Ready).To(BeFalse())
-			g.Eventually(recorder.Events).Should(Receive(ContainSubstring("DeletionInProgress")))
+			t.Run("should record the reconciled policies in the status", func(t *testing.T) {
+				g := NewWithT(t)
+				setup(t, g)
+				defer teardown(t, g)
+				setupPolicies(t, g)
+
+				statuses := []expinfrav1.ScalingPolicyStatus{{Name: "cpu", ARN: "arn:cpu"}}
+				asgSvc.EXPECT().ReconcileScalingPolicies("test", ms.AWSMachinePool.Spec.ScalingPolicies, gomock.Len(0)).Return(statuses, nil)
+				asgSvc.EXPECT().GetPredictiveScalingForecast(gomock.Any(), gomock.Any()).Times(0)
+
+				err := reconciler.reconcileScalingPolicies(ms, asgSvc)
+				g.Expect(err).To(Succeed())
+				g.Expect(ms.AWSMachinePool.Status.ScalingPolicies).To(Equal(statuses))
+			})
+
+			t.Run("should fetch the forecast when requested and remove the annotation", func(t *testing.T) {
+				g := NewWithT(t)
+				setup(t, g)
+				defer teardown(t, g)
+				setupPolicies(t, g)
+
+				ms.AWSMachinePool.Annotations = map[string]string{expinfrav1.PredictiveScalingForecastAnnotation: ""}
+				forecast := &expinfrav1.PredictiveScalingForecast{FetchTime: metav1.Now(), MinCapacity: 1, MaxCapacity: 4}
+				asgSvc.EXPECT().ReconcileScalingPolicies("test", gomock.Any(), gomock.Any()).Return([]expinfrav1.ScalingPolicyStatus{{Name: "cpu", ARN: "arn:cpu"}}, nil)
+				asgSvc.EXPECT().GetPredictiveScalingForecast("test", "cpu").Return(forecast, nil)
+
+				err := reconciler.reconcileScalingPolicies(ms, asgSvc)
+				g.Expect(err).To(Succeed())
+				g.Expect(ms.AWSMachinePool.Status.ScalingPolicies).To(ConsistOf(HaveField("Forecast", forecast)))
+				g.Expect(ms.AWSMachinePool.Annotations).ToNot(HaveKey(expinfrav1.PredictiveScalingForecastAnnotation))
+			})
+
+			t.Run("should record a forecast which can't be fetched in the status and remove the annotation", func(t *testing.T) {
+				g := NewWithT(t)
+				setup(t, g)
+				defer teardown(t, g)
+				setupPolicies(t, g)
+
+				ms.AWSMachinePool.Annotations = map[string]string{expinfrav1.PredictiveScalingForecastAnnotation: ""}
+				asgSvc.EXPECT().ReconcileScalingPolicies("test", gomock.Any(), gomock.Any()).Return([]expinfrav1.ScalingPolicyStatus{{Name: "cpu", ARN: "arn:cpu"}}, nil)
+				asgSvc.EXPECT().GetPredictiveScalingForecast("test", "cpu").Return(nil, errors.New("no forecast yet"))
+
+				err := reconciler.reconcileScalingPolicies(ms, asgSvc)
+				g.Expect(err).To(Succeed())
+				g.Expect(ms.AWSMachinePool.Status.ScalingPolicies).To(ConsistOf(HaveField("ForecastError", "no forecast yet")))
+				g.Expect(ms.AWSMachinePool.Annotations).ToNot(HaveKey(expinfrav1.PredictiveScalingForecastAnnotation))
+				g.Expect(recorder.Events).To(Receive(ContainSubstring("FailedPredictiveScalingForecast")))
+			})
+
+			t.Run("should not touch the ASG of machine pools without scaling policies", func(t *testing.T) {
+				g := NewWithT(t)
+				setup(t, g)
+				defer teardown(t, g)
+
+				asgSvc.EXPECT().ReconcileScalingPolicies(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+				err := reconciler.reconcileScalingPolicies(ms, asgSvc)
+				g.Expect(err).To(Succeed())
+			})
 		})
-	})
-}
 
-//TODO: This was taken from awsmachine_controller_test, i think it should be moved to elsewhere in both locations like test/helpers.
+		t.Run("warm pool", func(t *testing.T) {
+			t.Run("should put the warm pool of the spec", func(t *testing.T) {
+				g := NewWithT(t)
+				setup(t, g)
+				defer teardown(t, g)
 
-type conditionAssertion struct {
-	conditionType clusterv1.ConditionType
-	status        corev1.ConditionStatus
-	severity      clusterv1.ConditionSeverity
-	reason        string
-}
+				ms.AWSMachinePool.Spec.WarmPool = &expinfrav1.WarmPool{MinSize: 2, PoolState: expinfrav1.WarmPoolStateRunning}
+				asgSvc.EXPECT().ReconcileWarmPool("test", ms.AWSMachinePool.Spec.WarmPool).Return(nil)
 
-func expectConditions(g *WithT, m *expinfrav1.AWSMachinePool, expected []conditionAssertion) {
-	g.Expect(len(m.Status.Conditions)).To(BeNumerically(">=", len(expected)), "number of conditions")
-	for _, c := range expected {
-		actual := conditions.Get(m, c.conditionType)
-		g.Expect(actual).To(Not(BeNil()))
-		g.Expect(actual.Type).To(Equal(c.conditionType))
-		g.Expect(actual.Status).To(Equal(c.status))
-		g.Expect(actual.Severity).To(Equal(c.severity))
-		g.Expect(actual.Reason).To(Equal(c.reason))
-	}
-}
+				err := reconciler.reconcileWarmPool(ms, asgSvc)
+				g.Expect(err).To(Succeed())
+				g.Expect(conditions.IsTrue(ms.AWSMachinePool, expinfrav1.WarmPoolReadyCondition)).To(BeTrue())
+			})
 
-func setupCluster(clusterName string) (*scope.ClusterScope, error) {
-	scheme := runtime.NewScheme()
-	_ = infrav1.AddToScheme(scheme)
-	awsCluster := &infrav1.AWSCluster{
-		ObjectMeta: metav1.ObjectMeta{Name: "test"},
-		Spec:       infrav1.AWSClusterSpec{},
-	}
-	client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(awsCluster).Build()
-	return scope.NewClusterScope(scope.ClusterScopeParams{
-		Cluster: &clusterv1.Cluster{
-			ObjectMeta: metav1.ObjectMeta{Name: clusterName},
-		},
-		AWSCluster: awsCluster,
-		Client:     client,
-	})
-}
+			t.Run("should delete the warm pool once removed from the spec", func(t *testing.T) {
+				g := NewWithT(t)
+				setup(t, g)
+				defer teardown(t, g)
 
-func TestDiffASG(t *testing.T) {
-	type args struct {
-		machinePoolScope *scope.MachinePoolScope
-		existingASG      *expinfrav1.AutoScalingGroup
-	}
-	tests := []struct {
-		name string
-		args args
-		want bool
-	}{
-		{
-			name: "replicas != asg.desiredCapacity",
-			args: args{
-				machinePoolScope: &scope.MachinePoolScope{
-					MachinePool: &expclusterv1.MachinePool{
-						Spec: expclusterv1.MachinePoolSpec{
-							Replicas: ptr.To[int32](0),
-						},
-					},
-				},
-				existingASG: &expinfrav1.AutoScalingGroup{
-					DesiredCapacity: ptr.To[int32](1),
-				},
-			},
-			want: true,
-		},
-		{
-			name: "replicas (nil) != asg.desiredCapacity",
-			args: args{
-				machinePoolScope: &scope.MachinePoolScope{
-					MachinePool: &expclusterv1.MachinePool{
-						Spec: expclusterv1.MachinePoolSpec{
-							Replicas: nil,
+				conditions.MarkTrue(ms.AWSMachinePool, expinfrav1.WarmPoolReadyCondition)
+				asgSvc.EXPECT().ReconcileWarmPool("test", gomock.Nil()).Return(nil)
+
+				err := reconciler.reconcileWarmPool(ms, asgSvc)
+				g.Expect(err).To(Succeed())
+				g.Expect(conditions.Has(ms.AWSMachinePool, expinfrav1.WarmPoolReadyCondition)).To(BeFalse())
+			})
+
+			t.Run("should not touch the ASG of machine pools which never had a warm pool", func(t *testing.T) {
+				g := NewWithT(t)
+				setup(t, g)
+				defer teardown(t, g)
+
+				asgSvc.EXPECT().ReconcileWarmPool(gomock.Any(), gomock.Any()).Times(0)
+
+				err := reconciler.reconcileWarmPool(ms, asgSvc)
+				g.Expect(err).To(Succeed())
+			})
+
+			t.Run("should not count the instances of the warm pool as replicas", func(t *testing.T) {
+				g := NewWithT(t)
+
+				g.Expect(isWarmed(infrav1.Instance{State: "Warmed:Stopped"})).To(BeTrue())
+				g.Expect(isWarmed(infrav1.Instance{State: "InService"})).To(BeFalse())
+			})
+		})
+		t.Run("metrics collection", func(t *testing.T) {
+			t.Run("should enable the collection of the metrics of the spec", func(t *testing.T) {
+				g := NewWithT(t)
+				setup(t, g)
+				defer teardown(t, g)
+
+				ms.AWSMachinePool.Spec.Metrics = &expinfrav1.MetricsCollection{Metrics: []string{"GroupInServiceInstances", "GroupDesiredCapacity"}}
+				asgSvc.EXPECT().EnableMetricsCollection("test", "1Minute", []string{"GroupDesiredCapacity", "GroupInServiceInstances"}).Return(nil)
+
+				err := reconciler.reconcileMetricsCollection(ms, asgSvc, &expinfrav1.AutoScalingGroup{Name: "test"})
+				g.Expect(err).To(Succeed())
+			})
+
+			t.Run("should enable the collection of all the metrics when the spec lists none", func(t *testing.T) {
+				g := NewWithT(t)
+				setup(t, g)
+				defer teardown(t, g)
+
+				ms.AWSMachinePool.Spec.Metrics = &expinfrav1.MetricsCollection{}
+				existingASG := &expinfrav1.AutoScalingGroup{Name: "test", EnabledMetrics: []string{"GroupMinSize"}}
+				asgSvc.EXPECT().EnableMetricsCollection("test", "1Minute", gomock.Len(len(expinfrav1.ASGMetrics)-1)).Return(nil)
+
+				err := reconciler.reconcileMetricsCollection(ms, asgSvc, existingASG)
+				g.Expect(err).To(Succeed())
+			})
+
+			t.Run("should enable the missing metrics and disable the ones removed from the spec", func(t *testing.T) {
+				g := NewWithT(t)
+				setup(t, g)
+				defer teardown(t, g)
+
+				ms.AWSMachinePool.Spec.Metrics = &expinfrav1.MetricsCollection{Metrics: []string{"GroupInServiceInstances", "GroupDesiredCapacity"}}
+				existingASG := &expinfrav1.AutoScalingGroup{Name: "test", EnabledMetrics: []string{"GroupInServiceInstances", "GroupMaxSize"}}
+				asgSvc.EXPECT().EnableMetricsCollection("test", "1Minute", []string{"GroupDesiredCapacity"}).Return(nil)
+				asgSvc.EXPECT().DisableMetricsCollection("test", []string{"GroupMaxSize"}).Return(nil)
+
+				err := reconciler.reconcileMetricsCollection(ms, asgSvc, existingASG)
+				g.Expect(err).To(Succeed())
+			})
+
+			t.Run("should disable the collection once removed from the spec", func(t *testing.T) {
+				g := NewWithT(t)
+				setup(t, g)
+				defer teardown(t, g)
+
+				existingASG := &expinfrav1.AutoScalingGroup{Name: "test", EnabledMetrics: []string{"GroupMaxSize", "GroupMinSize"}}
+				asgSvc.EXPECT().DisableMetricsCollection("test", []string{"GroupMaxSize", "GroupMinSize"}).Return(nil)
+
+				err := reconciler.reconcileMetricsCollection(ms, asgSvc, existingASG)
+				g.Expect(err).To(Succeed())
+			})
+
+			t.Run("should not touch the ASG when the collected metrics match the spec", func(t *testing.T) {
+				g := NewWithT(t)
+				setup(t, g)
+				defer teardown(t, g)
+
+				ms.AWSMachinePool.Spec.Metrics = &expinfrav1.MetricsCollection{Metrics: []string{"GroupMaxSize"}}
+				existingASG := &expinfrav1.AutoScalingGroup{Name: "test", EnabledMetrics: []string{"GroupMaxSize"}}
+
+				err := reconciler.reconcileMetricsCollection(ms, asgSvc, existingASG)
+				g.Expect(err).To(Succeed())
+			})
+
+			t.Run("should return an error when the metrics collection could not be enabled", func(t *testing.T) {
+				g := NewWithT(t)
+				setup(t, g)
+				defer teardown(t, g)
+
+				ms.AWSMachinePool.Spec.Metrics = &expinfrav1.MetricsCollection{Metrics: []string{"GroupMaxSize"}}
+				asgSvc.EXPECT().EnableMetricsCollection("test", "1Minute", []string{"GroupMaxSize"}).Return(errors.New("an error"))
+
+				err := reconciler.reconcileMetricsCollection(ms, asgSvc, &expinfrav1.AutoScalingGroup{Name: "test"})
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(recorder.Events).To(Receive(ContainSubstring("FailedEnableMetricsCollection")))
+			})
+		})
+
+		t.Run("instance refresh progress", func(t *testing.T) {
+			t.Run("should report a running instance refresh and requeue", func(t *testing.T) {
+				g := NewWithT(t)
+				setup(t, g)
+				defer teardown(t, g)
+
+				refreshSvc := mock_services.NewMockASGInterface(mockCtrl)
+				refreshSvc.EXPECT().DescribeLatestInstanceRefresh("test").Return(&expinfrav1.InstanceRefreshStatus{
+					ID:                 "refresh-1",
+					State:              autoscaling.InstanceRefreshStatusInProgress,
+					PercentageComplete: ptr.To[int64](40),
+					InstancesToUpdate:  ptr.To[int64](3),
+				}, nil)
+
+				err := reconciler.reconcileInstanceRefreshStatus(ms, refreshSvc)
+				g.Expect(err).To(Succeed())
+				g.Expect(ms.AWSMachinePool.Status.InstanceRefreshStatus.PercentageComplete).To(Equal(ptr.To[int64](40)))
+				g.Expect(conditions.IsTrue(ms.AWSMachinePool, expinfrav1.InstanceRefreshInProgressCondition)).To(BeTrue())
+				g.Expect(reconcileNormalResult(ms).RequeueAfter).To(Equal(instanceRefreshRequeueAfter))
+			})
+
+			t.Run("should report the reason of a failed instance refresh", func(t *testing.T) {
+				g := NewWithT(t)
+				setup(t, g)
+				defer teardown(t, g)
+
+				refreshSvc := mock_services.NewMockASGInterface(mockCtrl)
+				refreshSvc.EXPECT().DescribeLatestInstanceRefresh("test").Return(&expinfrav1.InstanceRefreshStatus{
+					ID:           "refresh-1",
+					State:        autoscaling.InstanceRefreshStatusFailed,
+					StatusReason: "Instances failed to launch",
+				}, nil)
+
+				err := reconciler.reconcileInstanceRefreshStatus(ms, refreshSvc)
+				g.Expect(err).To(Succeed())
+				condition := conditions.Get(ms.AWSMachinePool, expinfrav1.InstanceRefreshInProgressCondition)
+				g.Expect(condition).ToNot(BeNil())
+				g.Expect(condition.Status).To(Equal(corev1.ConditionFalse))
+				g.Expect(condition.Reason).To(Equal(expinfrav1.InstanceRefreshFailedReason))
+				g.Expect(condition.Message).To(ContainSubstring("Instances failed to launch"))
+				g.Expect(reconcileNormalResult(ms).RequeueAfter).To(BeZero())
+			})
+
+			t.Run("should remove the condition when the ASG never had an instance refresh", func(t *testing.T) {
+				g := NewWithT(t)
+				setup(t, g)
+				defer teardown(t, g)
+
+				conditions.MarkTrue(ms.AWSMachinePool, expinfrav1.InstanceRefreshInProgressCondition)
+				refreshSvc := mock_services.NewMockASGInterface(mockCtrl)
+				refreshSvc.EXPECT().DescribeLatestInstanceRefresh("test").Return(nil, nil)
+
+				err := reconciler.reconcileInstanceRefreshStatus(ms, refreshSvc)
+				g.Expect(err).To(Succeed())
+				g.Expect(ms.AWSMachinePool.Status.InstanceRefreshStatus).To(BeNil())
+				g.Expect(conditions.Has(ms.AWSMachinePool, expinfrav1.InstanceRefreshInProgressCondition)).To(BeFalse())
+			})
+		})
+
+		t.Run("outdated instance refresh", func(t *testing.T) {
+			setupVersions := func() {
+				// The controller restarted after creating version 2 of the launch template, before starting its instance refresh.
+				ms.AWSMachinePool.Status.LaunchTemplateVersion = ptr.To[string]("2")
+				ms.AWSMachinePool.Status.InstanceRefreshLaunchTemplateVersion = ptr.To[string]("1")
+			}
+
+			t.Run("should start the instance refresh of the latest version after a controller restart", func(t *testing.T) {
+				g := NewWithT(t)
+				setup(t, g)
+				defer teardown(t, g)
+				setupVersions()
+
+				asgSvc.EXPECT().CanStartASGInstanceRefresh(gomock.Any()).Return(true, nil)
+				asgSvc.EXPECT().StartASGInstanceRefresh(gomock.Any()).Return("refresh-2", nil)
+
+				err := reconciler.reconcileOutdatedInstanceRefresh(ms, asgSvc, false)
+				g.Expect(err).To(Succeed())
+				g.Expect(ms.AWSMachinePool.Status.InstanceRefreshLaunchTemplateVersion).To(Equal(ptr.To[string]("2")))
+				g.Eventually(recorder.Events).Should(Receive(ContainSubstring("InstanceRefreshStarted")))
+			})
+
+			t.Run("should wait for the running instance refresh after a controller restart by default", func(t *testing.T) {
+				g := NewWithT(t)
+				setup(t, g)
+				defer teardown(t, g)
+				setupVersions()
+
+				asgSvc.EXPECT().CanStartASGInstanceRefresh(gomock.Any()).Return(false, nil)
+				asgSvc.EXPECT().CancelASGInstanceRefresh(gomock.Any()).Times(0)
+
+				err := reconciler.reconcileOutdatedInstanceRefresh(ms, asgSvc, false)
+				g.Expect(err).To(Succeed())
+				g.Expect(ms.AWSMachinePool.Status.InstanceRefreshLaunchTemplateVersion).To(Equal(ptr.To[string]("1")))
+			})
+
+			t.Run("should cancel the running instance refresh after a controller restart when configured", func(t *testing.T) {
+				g := NewWithT(t)
+				setup(t, g)
+				defer teardown(t, g)
+				setupVersions()
+
+				ms.AWSMachinePool.Spec.RefreshPreferences = &expinfrav1.RefreshPreferences{CancelOutdatedRefresh: true}
+				refreshSvc := mock_services.NewMockASGInterface(mockCtrl)
+				refreshSvc.EXPECT().CanStartASGInstanceRefresh(gomock.Any()).Return(false, nil)
+				refreshSvc.EXPECT().DescribeLatestInstanceRefresh("test").Return(&expinfrav1.InstanceRefreshStatus{
+					ID:    "refresh-1",
+					State: autoscaling.InstanceRefreshStatusInProgress,
+				}, nil)
+				refreshSvc.EXPECT().CancelASGInstanceRefresh("test").Return(nil)
+
+				err := reconciler.reconcileOutdatedInstanceRefresh(ms, refreshSvc, false)
+				g.Expect(err).To(Succeed())
+				g.Expect(ms.AWSMachinePool.Status.InstanceRefreshLaunchTemplateVersion).To(Equal(ptr.To[string]("1")))
+				g.Eventually(recorder.Events).Should(Receive(ContainSubstring("InstanceRefreshCancelled")))
+			})
+
+			t.Run("should not cancel an instance refresh which is already being cancelled", func(t *testing.T) {
+				g := NewWithT(t)
+				setup(t, g)
+				defer teardown(t, g)
+				setupVersions()
+
+				ms.AWSMachinePool.Spec.RefreshPreferences = &expinfrav1.RefreshPreferences{CancelOutdatedRefresh: true}
+				refreshSvc := mock_services.NewMockASGInterface(mockCtrl)
+				refreshSvc.EXPECT().CanStartASGInstanceRefresh(gomock.Any()).Return(false, nil)
+				refreshSvc.EXPECT().DescribeLatestInstanceRefresh("test").Return(&expinfrav1.InstanceRefreshStatus{
+					ID:    "refresh-1",
+					State: autoscaling.InstanceRefreshStatusCancelling,
+				}, nil)
+				refreshSvc.EXPECT().CancelASGInstanceRefresh(gomock.Any()).Times(0)
+
+				err := reconciler.reconcileOutdatedInstanceRefresh(ms, refreshSvc, false)
+				g.Expect(err).To(Succeed())
+			})
+
+			t.Run("should record the latest version without refreshing the instances when only the userdata changed", func(t *testing.T) {
+				g := NewWithT(t)
+				setup(t, g)
+				defer teardown(t, g)
+				setupVersions()
+
+				asgSvc.EXPECT().StartASGInstanceRefresh(gomock.Any()).Times(0)
+
+				err := reconciler.reconcileOutdatedInstanceRefresh(ms, asgSvc, true)
+				g.Expect(err).To(Succeed())
+				g.Expect(ms.AWSMachinePool.Status.InstanceRefreshLaunchTemplateVersion).To(Equal(ptr.To[string]("2")))
+			})
+
+			t.Run("should record the version of pools which never recorded one without refreshing the instances", func(t *testing.T) {
+				g := NewWithT(t)
+				setup(t, g)
+				defer teardown(t, g)
+
+				ms.AWSMachinePool.Status.LaunchTemplateVersion = ptr.To[string]("3")
+				asgSvc.EXPECT().StartASGInstanceRefresh(gomock.Any()).Times(0)
+
+				err := reconciler.reconcileOutdatedInstanceRefresh(ms, asgSvc, false)
+				g.Expect(err).To(Succeed())
+				g.Expect(ms.AWSMachinePool.Status.InstanceRefreshLaunchTemplateVersion).To(Equal(ptr.To[string]("3")))
+			})
+		})
+
+		t.Run("instance refresh surge", func(t *testing.T) {
+			setupSurge := func(surged bool) {
+				ms.AWSMachinePool.Spec.MaxSize = 5
+				ms.AWSMachinePool.Spec.RefreshPreferences = &expinfrav1.RefreshPreferences{MaxSurge: ptr.To[int32](2)}
+				if surged {
+					ms.AWSMachinePool.SetAnnotations(map[string]string{expinfrav1.RefreshSurgeAnnotation: "5"})
+				}
+			}
+
+			t.Run("should raise the maximum size of the ASG before starting the instance refresh", func(t *testing.T) {
+				g := NewWithT(t)
+				setup(t, g)
+				defer teardown(t, g)
+				setupSurge(false)
+
+				refreshSvc := mock_services.NewMockASGInterface(mockCtrl)
+				gomock.InOrder(
+					refreshSvc.EXPECT().UpdateASG(gomock.Any()).DoAndReturn(func(mps *scope.MachinePoolScope) error {
+						g.Expect(mps.AWSMachinePool.ASGMaxSize()).To(Equal(int32(7)))
+						return nil
+					}),
+					refreshSvc.EXPECT().StartASGInstanceRefresh(gomock.Any()).Return("refresh-1", nil),
+				)
+
+				err := reconciler.startInstanceRefresh(ms, refreshSvc)
+				g.Expect(err).To(Succeed())
+				g.Expect(ms.AWSMachinePool.Annotations).To(HaveKeyWithValue(expinfrav1.RefreshSurgeAnnotation, "5"))
+				g.Eventually(recorder.Events).Should(Receive(ContainSubstring("RefreshSurgeAdded")))
+			})
+
+			t.Run("should keep the surge while the instance refresh is in progress", func(t *testing.T) {
+				g := NewWithT(t)
+				setup(t, g)
+				defer teardown(t, g)
+				setupSurge(true)
+
+				refreshSvc := mock_services.NewMockASGInterface(mockCtrl)
+				refreshSvc.EXPECT().DescribeLatestInstanceRefresh("test").Return(&expinfrav1.InstanceRefreshStatus{
+					ID:    "refresh-1",
+					State: autoscaling.InstanceRefreshStatusInProgress,
+				}, nil)
+				refreshSvc.EXPECT().UpdateASG(gomock.Any()).Times(0)
+
+				err := reconciler.reconcileInstanceRefreshStatus(ms, refreshSvc)
+				g.Expect(err).To(Succeed())
+				g.Expect(ms.AWSMachinePool.ASGMaxSize()).To(Equal(int32(7)))
+			})
+
+			t.Run("should restore the maximum size of the ASG once the instance refresh ended", func(t *testing.T) {
+				g := NewWithT(t)
+				setup(t, g)
+				defer teardown(t, g)
+				setupSurge(true)
+
+				refreshSvc := mock_services.NewMockASGInterface(mockCtrl)
+				refreshSvc.EXPECT().DescribeLatestInstanceRefresh("test").Return(&expinfrav1.InstanceRefreshStatus{
+					ID:    "refresh-1",
+					State: autoscaling.InstanceRefreshStatusSuccessful,
+				}, nil)
+				refreshSvc.EXPECT().UpdateASG(gomock.Any()).DoAndReturn(func(mps *scope.MachinePoolScope) error {
+					g.Expect(mps.AWSMachinePool.ASGMaxSize()).To(Equal(int32(5)))
+					return nil
+				})
+
+				err := reconciler.reconcileInstanceRefreshStatus(ms, refreshSvc)
+				g.Expect(err).To(Succeed())
+				g.Expect(ms.AWSMachinePool.Annotations).ToNot(HaveKey(expinfrav1.RefreshSurgeAnnotation))
+				g.Eventually(recorder.Events).Should(Receive(ContainSubstring("RefreshSurgeRemoved")))
+			})
+
+			t.Run("should restore the maximum size of the spec when it changed during the instance refresh", func(t *testing.T) {
+				g := NewWithT(t)
+				setup(t, g)
+				defer teardown(t, g)
+				setupSurge(true)
+				ms.AWSMachinePool.Spec.MaxSize = 6
+
+				refreshSvc := mock_services.NewMockASGInterface(mockCtrl)
+				refreshSvc.EXPECT().DescribeLatestInstanceRefresh("test").Return(&expinfrav1.InstanceRefreshStatus{
+					ID:    "refresh-1",
+					State: autoscaling.InstanceRefreshStatusFailed,
+				}, nil)
+				refreshSvc.EXPECT().UpdateASG(gomock.Any()).DoAndReturn(func(mps *scope.MachinePoolScope) error {
+					g.Expect(mps.AWSMachinePool.ASGMaxSize()).To(Equal(int32(6)))
+					return nil
+				})
+
+				err := reconciler.reconcileInstanceRefreshStatus(ms, refreshSvc)
+				g.Expect(err).To(Succeed())
+				g.Eventually(recorder.Events).Should(Receive(ContainSubstring("changed from 5")))
+			})
+
+			t.Run("should keep the surge annotation when restoring the maximum size fails", func(t *testing.T) {
+				g := NewWithT(t)
+				setup(t, g)
+				defer teardown(t, g)
+				setupSurge(true)
+
+				refreshSvc := mock_services.NewMockASGInterface(mockCtrl)
+				refreshSvc.EXPECT().DescribeLatestInstanceRefresh("test").Return(nil, nil)
+				refreshSvc.EXPECT().UpdateASG(gomock.Any()).Return(errors.New("an error"))
+
+				err := reconciler.reconcileInstanceRefreshStatus(ms, refreshSvc)
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(ms.AWSMachinePool.Annotations).To(HaveKeyWithValue(expinfrav1.RefreshSurgeAnnotation, "5"))
+			})
+		})
+	})
+
+	t.Run("Deleting an AWSMachinePool", func(t *testing.T) {
+		finalizer := func(t *testing.T, g *WithT) {
+			t.Helper()
+
+			ms.AWSMachinePool.Finalizers = []string{
+				expinfrav1.MachinePoolFinalizer,
+				metav1.FinalizerDeleteDependents,
+			}
+		}
+		t.Run("should exit immediately on an error state", func(t *testing.T) {
+			g := NewWithT(t)
+			setup(t, g)
+			defer teardown(t, g)
+			finalizer(t, g)
+
+			expectedErr := errors.New("no connection available ")
+			asgSvc.EXPECT().GetASGByName(gomock.Any()).Return(nil, expectedErr).AnyTimes()
+
+			err := reconciler.reconcileDelete(ms, cs, cs)
+			g.Expect(errors.Cause(err)).To(MatchError(expectedErr))
+		})
+		t.Run("should log and remove finalizer when no machinepool exists", func(t *testing.T) {
+			g := NewWithT(t)
+			setup(t, g)
+			defer teardown(t, g)
+			finalizer(t, g)
+
+			asgSvc.EXPECT().GetASGByName(gomock.Any()).Return(nil, nil)
+			ec2Svc.EXPECT().GetLaunchTemplate(gomock.Any()).Return(nil, "", nil, nil).AnyTimes()
+
+			buf := new(bytes.Buffer)
+			klog.SetOutput(buf)
+
+			err := reconciler.reconcileDelete(ms, cs, cs)
+			g.Expect(err).To(BeNil())
+			g.Expect(buf.String()).To(ContainSubstring("Unable to locate ASG"))
+			g.Expect(ms.AWSMachinePool.Finalizers).To(ConsistOf(metav1.FinalizerDeleteDependents))
+			g.Eventually(recorder.Events).Should(Receive(ContainSubstring(expinfrav1.ASGNotFoundReason)))
+		})
+		t.Run("should keep the finalizer while the ASG created recently isn't described", func(t *testing.T) {
+			g := NewWithT(t)
+			setup(t, g)
+			defer teardown(t, g)
+			finalizer(t, g)
+
+			ms.AWSMachinePool.Status.ASG = &expinfrav1.ASGReference{Name: "name", CreatedAt: ptr.To(metav1.Now())}
+			asgSvc.EXPECT().GetASGByName(gomock.Any()).Return(nil, nil)
+
+			err := reconciler.reconcileDelete(ms, cs, cs)
+			g.Expect(err).To(MatchError(errASGCreationPending))
+			g.Expect(ms.AWSMachinePool.Finalizers).To(ContainElement(expinfrav1.MachinePoolFinalizer))
+		})
+		t.Run("should cause AWSMachinePool to go into NotReady", func(t *testing.T) {
+			g := NewWithT(t)
+			setup(t, g)
+			defer teardown(t, g)
+			finalizer(t, g)
+
+			inProgressASG := expinfrav1.AutoScalingGroup{
+				Name:   "an-asg-that-is-currently-being-deleted",
+				Status: expinfrav1.ASGStatusDeleteInProgress,
+			}
+			asgSvc.EXPECT().GetASGByName(gomock.Any()).Return(&inProgressASG, nil)
+			ec2Svc.EXPECT().GetLaunchTemplate(gomock.Any()).Return(nil, "", nil, nil).AnyTimes()
+
+			buf := new(bytes.Buffer)
+			klog.SetOutput(buf)
+			err := reconciler.reconcileDelete(ms, cs, cs)
+			g.Expect(err).To(BeNil())
+			g.Expect(ms.AWSMachinePool.Status.Ready).To(BeFalse())
+			g.Eventually(recorder.Events).Should(Receive(ContainSubstring("DeletionInProgress")))
+		})
+		t.Run("should keep an ASG which isn't owned by the cluster", func(t *testing.T) {
+			g := NewWithT(t)
+			setup(t, g)
+			defer teardown(t, g)
+			finalizer(t, g)
+
+			asgSvc.EXPECT().GetASGByName(gomock.Any()).Return(&expinfrav1.AutoScalingGroup{Name: "test"}, nil)
+			asgSvc.EXPECT().DeleteASGAndWait(gomock.Any()).Times(0)
+			ec2Svc.EXPECT().GetLaunchTemplate(gomock.Any()).Return(nil, "", nil, nil).AnyTimes()
+
+			g.Expect(reconciler.reconcileDelete(ms, cs, cs)).To(Succeed())
+			g.Expect(ms.AWSMachinePool.Finalizers).NotTo(ContainElement(expinfrav1.MachinePoolFinalizer))
+			g.Eventually(recorder.Events).Should(Receive(ContainSubstring(expinfrav1.ASGNotOwnedReason)))
+		})
+		t.Run("should restore the sizes of an adopted ASG and keep it when it's preserved on delete", func(t *testing.T) {
+			g := NewWithT(t)
+			setup(t, g)
+			defer teardown(t, g)
+			finalizer(t, g)
+
+			// The mocks fail on any other call, so that neither the launch template nor the security group are deleted.
+			ms.AWSMachinePool.Spec.PreserveOnDelete = true
+			ms.AWSMachinePool.Annotations = map[string]string{expinfrav1.AdoptedASGOriginalSizeAnnotation: "1/5/3"}
+			asg := &expinfrav1.AutoScalingGroup{Name: "test", Tags: ownedASGTags}
+			asgSvc.EXPECT().GetASGByName(gomock.Any()).Return(asg, nil)
+			asgSvc.EXPECT().ReleaseASG(asg, int32(1), int32(5), int32(3)).Return(nil)
+
+			g.Expect(reconciler.reconcileDelete(ms, cs, cs)).To(Succeed())
+			g.Expect(ms.AWSMachinePool.Finalizers).NotTo(ContainElement(expinfrav1.MachinePoolFinalizer))
+			g.Eventually(recorder.Events).Should(Receive(ContainSubstring("ASGPreserved")))
+		})
+		t.Run("should keep the ASG and the launch template of an externally managed pool", func(t *testing.T) {
+			g := NewWithT(t)
+			setup(t, g)
+			defer teardown(t, g)
+			finalizer(t, g)
+
+			// The mocks fail on any call, so that neither the ASG nor the launch template are deleted.
+			ms.AWSMachinePool.Annotations = map[string]string{clusterv1.ManagedByAnnotation: "terraform"}
+
+			err := reconciler.reconcileDelete(ms, cs, cs)
+			g.Expect(err).To(BeNil())
+			g.Expect(ms.AWSMachinePool.Finalizers).To(ConsistOf(metav1.FinalizerDeleteDependents))
+		})
+		t.Run("should delete the launch template by name when the status lost its ID", func(t *testing.T) {
+			g := NewWithT(t)
+			setup(t, g)
+			defer teardown(t, g)
+			finalizer(t, g)
+
+			ms.AWSMachinePool.Status.LaunchTemplateID = ""
+			asgSvc.EXPECT().GetASGByName(gomock.Any()).Return(nil, nil)
+			ec2Svc.EXPECT().GetLaunchTemplate(ms.LaunchTemplateName()).Return(&expinfrav1.AWSLaunchTemplate{Name: ms.LaunchTemplateName()}, "", nil, nil)
+			ec2Svc.EXPECT().DeleteLaunchTemplate(gomock.Any()).Times(0)
+			ec2Svc.EXPECT().DeleteLaunchTemplateByName(ms.LaunchTemplateName()).Return(nil)
+
+			err := reconciler.reconcileDelete(ms, cs, cs)
+			g.Expect(err).To(BeNil())
+			g.Expect(ms.AWSMachinePool.Finalizers).To(ConsistOf(metav1.FinalizerDeleteDependents))
+		})
+		t.Run("should keep the finalizer and warn about the leaked launch template when it could not be deleted", func(t *testing.T) {
+			g := NewWithT(t)
+			setup(t, g)
+			defer teardown(t, g)
+			finalizer(t, g)
+
+			ms.AWSMachinePool.Status.LaunchTemplateID = "lt-1"
+			asgSvc.EXPECT().GetASGByName(gomock.Any()).Return(nil, nil)
+			ec2Svc.EXPECT().GetLaunchTemplate(ms.LaunchTemplateName()).Return(&expinfrav1.AWSLaunchTemplate{Name: ms.LaunchTemplateName()}, "", nil, nil)
+			ec2Svc.EXPECT().DeleteLaunchTemplate("lt-1").Return(errors.New("access denied"))
+
+			err := reconciler.reconcileDelete(ms, cs, cs)
+			g.Expect(err).To(MatchError(ContainSubstring("access denied")))
+			g.Expect(ms.AWSMachinePool.Finalizers).To(ContainElement(expinfrav1.MachinePoolFinalizer))
+			g.Eventually(recorder.Events).Should(Receive(ContainSubstring(expinfrav1.ASGNotFoundReason)))
+			g.Eventually(recorder.Events).Should(Receive(ContainSubstring("LaunchTemplateLeaked")))
+		})
+	})
+}
+
+//TODO: This was taken from awsmachine_controller_test, i think it should be moved to elsewhere in both locations like test/helpers.
+
+type conditionAssertion struct {
+	conditionType clusterv1.ConditionType
+	status        corev1.ConditionStatus
+	severity      clusterv1.ConditionSeverity
+	reason        string
+}
+
+func expectConditions(g *WithT, m *expinfrav1.AWSMachinePool, expected []conditionAssertion) {
+	g.Expect(len(m.Status.Conditions)).To(BeNumerically(">=", len(expected)), "number of conditions")
+	for _, c := range expected {
+		actual := conditions.Get(m, c.conditionType)
+		g.Expect(actual).To(Not(BeNil()))
+		g.Expect(actual.Type).To(Equal(c.conditionType))
+		g.Expect(actual.Status).To(Equal(c.status))
+		g.Expect(actual.Severity).To(Equal(c.severity))
+		g.Expect(actual.Reason).To(Equal(c.reason))
+	}
+}
+
+// ownedASGTags are the tags of an ASG created or adopted for the cluster set up by setupCluster("test-cluster").
+var ownedASGTags = infrav1.Tags{infrav1.ClusterTagKey("test-cluster"): string(infrav1.ResourceLifecycleOwned)}
+
+func setupCluster(clusterName string) (*scope.ClusterScope, error) {
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+	awsCluster := &infrav1.AWSCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec:       infrav1.AWSClusterSpec{},
+	}
+	client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(awsCluster).Build()
+	return scope.NewClusterScope(scope.ClusterScopeParams{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: clusterName},
+		},
+		AWSCluster: awsCluster,
+		Client:     client,
+	})
+}
+
+func TestDiffASG(t *testing.T) {
+	type args struct {
+		machinePoolScope *scope.MachinePoolScope
+		existingASG      *expinfrav1.AutoScalingGroup
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{
+			name: "replicas != asg.desiredCapacity",
+			args: args{
+				machinePoolScope: &scope.MachinePoolScope{
+					MachinePool: &expclusterv1.MachinePool{
+						Spec: expclusterv1.MachinePoolSpec{
+							Replicas: ptr.To[int32](0),
+						},
+					},
+				},
+				existingASG: &expinfrav1.AutoScalingGroup{
+					DesiredCapacity: ptr.To[int32](1),
+				},
+			},
+			want: true,
+		},
+		{
+			name: "replicas (nil) != asg.desiredCapacity",
+			args: args{
+				machinePoolScope: &scope.MachinePoolScope{
+					MachinePool: &expclusterv1.MachinePool{
+						Spec: expclusterv1.MachinePoolSpec{
+							Replicas: nil,
+						},
+					},
+				},
+				existingASG: &expinfrav1.AutoScalingGroup{
+					DesiredCapacity: ptr.To[int32](1),
+				},
+			},
+			want: true,
+		},
+		{
+			name: "replicas != asg.desiredCapacity (nil)",
+			args: args{
+				machinePoolScope: &scope.MachinePoolScope{
+					MachinePool: &expclusterv1.MachinePool{
+						Spec: expclusterv1.MachinePoolSpec{
+							Replicas: ptr.To[int32](0),
+						},
+					},
+				},
+				existingASG: &expinfrav1.AutoScalingGroup{
+					DesiredCapacity: nil,
+				},
+			},
+			want: true,
+		},
+		{
+			name: "maxSize != asg.maxSize",
+			args: args{
+				machinePoolScope: &scope.MachinePoolScope{
+					MachinePool: &expclusterv1.MachinePool{
+						Spec: expclusterv1.MachinePoolSpec{
+							Replicas: ptr.To[int32](1),
+						},
+					},
+					AWSMachinePool: &expinfrav1.AWSMachinePool{
+						Spec: expinfrav1.AWSMachinePoolSpec{
+							MaxSize: 1,
+						},
+					},
+				},
+				existingASG: &expinfrav1.AutoScalingGroup{
+					DesiredCapacity: ptr.To[int32](1),
+					MaxSize:         2,
+				},
+			},
+			want: true,
+		},
+		{
+			name: "minSize != asg.minSize",
+			args: args{
+				machinePoolScope: &scope.MachinePoolScope{
+					MachinePool: &expclusterv1.MachinePool{
+						Spec: expclusterv1.MachinePoolSpec{
+							Replicas: ptr.To[int32](1),
+						},
+					},
+					AWSMachinePool: &expinfrav1.AWSMachinePool{
+						Spec: expinfrav1.AWSMachinePoolSpec{
+							MaxSize: 2,
+							MinSize: 0,
+						},
+					},
+				},
+				existingASG: &expinfrav1.AutoScalingGroup{
+					DesiredCapacity: ptr.To[int32](1),
+					MaxSize:         2,
+					MinSize:         1,
+				},
+			},
+			want: true,
+		},
+		{
+			name: "capacityRebalance != asg.capacityRebalance",
+			args: args{
+				machinePoolScope: &scope.MachinePoolScope{
+					MachinePool: &expclusterv1.MachinePool{
+						Spec: expclusterv1.MachinePoolSpec{
+							Replicas: ptr.To[int32](1),
+						},
+					},
+					AWSMachinePool: &expinfrav1.AWSMachinePool{
+						Spec: expinfrav1.AWSMachinePoolSpec{
+							MaxSize:           2,
+							MinSize:           0,
+							CapacityRebalance: true,
+						},
+					},
+				},
+				existingASG: &expinfrav1.AutoScalingGroup{
+					DesiredCapacity:   ptr.To[int32](1),
+					MaxSize:           2,
+					MinSize:           0,
+					CapacityRebalance: false,
+				},
+			},
+			want: true,
+		},
+		{
+			name: "MixedInstancesPolicy != asg.MixedInstancesPolicy",
+			args: args{
+				machinePoolScope: &scope.MachinePoolScope{
+					MachinePool: &expclusterv1.MachinePool{
+						Spec: expclusterv1.MachinePoolSpec{
+							Replicas: ptr.To[int32](1),
+						},
+					},
+					AWSMachinePool: &expinfrav1.AWSMachinePool{
+						Spec: expinfrav1.AWSMachinePoolSpec{
+							MaxSize:           2,
+							MinSize:           0,
+							CapacityRebalance: true,
+							MixedInstancesPolicy: &expinfrav1.MixedInstancesPolicy{
+								InstancesDistribution: &expinfrav1.InstancesDistribution{
+									OnDemandAllocationStrategy: expinfrav1.OnDemandAllocationStrategyPrioritized,
+								},
+								Overrides: nil,
+							},
+						},
+					},
+					Logger: *logger.NewLogger(logr.Discard()),
+				},
+				existingASG: &expinfrav1.AutoScalingGroup{
+					DesiredCapacity:      ptr.To[int32](1),
+					MaxSize:              2,
+					MinSize:              0,
+					CapacityRebalance:    true,
+					MixedInstancesPolicy: &expinfrav1.MixedInstancesPolicy{},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "MixedInstancesPolicy.InstancesDistribution != asg.MixedInstancesPolicy.InstancesDistribution",
+			args: args{
+				machinePoolScope: &scope.MachinePoolScope{
+					MachinePool: &expclusterv1.MachinePool{
+						Spec: expclusterv1.MachinePoolSpec{
+							Replicas: ptr.To[int32](1),
+						},
+					},
+					AWSMachinePool: &expinfrav1.AWSMachinePool{
+						Spec: expinfrav1.AWSMachinePoolSpec{
+							MaxSize:           2,
+							MinSize:           0,
+							CapacityRebalance: true,
+							MixedInstancesPolicy: &expinfrav1.MixedInstancesPolicy{
+								InstancesDistribution: &expinfrav1.InstancesDistribution{
+									OnDemandAllocationStrategy:          expinfrav1.OnDemandAllocationStrategyPrioritized,
+									SpotAllocationStrategy:              expinfrav1.SpotAllocationStrategyCapacityOptimized,
+									OnDemandBaseCapacity:                aws.Int64(0),
+									OnDemandPercentageAboveBaseCapacity: aws.Int64(100),
+								},
+								Overrides: []expinfrav1.Overrides{
+									{
+										InstanceType: "m6a.32xlarge",
+									},
+								},
+							},
+						},
+					},
+					Logger: *logger.NewLogger(logr.Discard()),
+				},
+				existingASG: &expinfrav1.AutoScalingGroup{
+					DesiredCapacity:   ptr.To[int32](1),
+					MaxSize:           2,
+					MinSize:           0,
+					CapacityRebalance: true,
+					MixedInstancesPolicy: &expinfrav1.MixedInstancesPolicy{
+						InstancesDistribution: &expinfrav1.InstancesDistribution{
+							OnDemandAllocationStrategy:          expinfrav1.OnDemandAllocationStrategyPrioritized,
+							SpotAllocationStrategy:              expinfrav1.SpotAllocationStrategyLowestPrice,
+							OnDemandBaseCapacity:                aws.Int64(0),
+							OnDemandPercentageAboveBaseCapacity: aws.Int64(100),
+						},
+						Overrides: []expinfrav1.Overrides{
+							{
+								InstanceType: "m6a.32xlarge",
+							},
+						},
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "MixedInstancesPolicy.InstancesDistribution unset",
+			args: args{
+				machinePoolScope: &scope.MachinePoolScope{
+					MachinePool: &expclusterv1.MachinePool{
+						Spec: expclusterv1.MachinePoolSpec{
+							Replicas: ptr.To[int32](1),
+						},
+					},
+					AWSMachinePool: &expinfrav1.AWSMachinePool{
+						Spec: expinfrav1.AWSMachinePoolSpec{
+							MaxSize:           2,
+							MinSize:           0,
+							CapacityRebalance: true,
+							MixedInstancesPolicy: &expinfrav1.MixedInstancesPolicy{
+								Overrides: []expinfrav1.Overrides{
+									{
+										InstanceType: "m6a.32xlarge",
+									},
+								},
+							},
+						},
+					},
+					Logger: *logger.NewLogger(logr.Discard()),
+				},
+				existingASG: &expinfrav1.AutoScalingGroup{
+					DesiredCapacity:   ptr.To[int32](1),
+					MaxSize:           2,
+					MinSize:           0,
+					CapacityRebalance: true,
+					MixedInstancesPolicy: &expinfrav1.MixedInstancesPolicy{
+						InstancesDistribution: &expinfrav1.InstancesDistribution{
+							OnDemandAllocationStrategy:          expinfrav1.OnDemandAllocationStrategyPrioritized,
+							SpotAllocationStrategy:              expinfrav1.SpotAllocationStrategyLowestPrice,
+							OnDemandBaseCapacity:                aws.Int64(0),
+							OnDemandPercentageAboveBaseCapacity: aws.Int64(100),
+						},
+						Overrides: []expinfrav1.Overrides{
+							{
+								InstanceType: "m6a.32xlarge",
+							},
+						},
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "asg launches the last validated launch template version",
+			args: args{
+				machinePoolScope: &scope.MachinePoolScope{
+					MachinePool: &expclusterv1.MachinePool{
+						Spec: expclusterv1.MachinePoolSpec{
+							Replicas: ptr.To[int32](1),
+						},
+					},
+					AWSMachinePool: &expinfrav1.AWSMachinePool{
+						Spec: expinfrav1.AWSMachinePoolSpec{
+							MaxSize:           1,
+							AWSLaunchTemplate: expinfrav1.AWSLaunchTemplate{ValidateBeforeUse: true},
+						},
+						Status: expinfrav1.AWSMachinePoolStatus{LaunchTemplateVersion: ptr.To("3")},
+					},
+					Logger: *logger.NewLogger(logr.Discard()),
+				},
+				existingASG: &expinfrav1.AutoScalingGroup{
+					DesiredCapacity:       ptr.To[int32](1),
+					MaxSize:               1,
+					LaunchTemplateVersion: "3",
+				},
+			},
+			want: false,
+		},
+		{
+			name: "asg still launches the last validated launch template version after the validation was turned off",
+			args: args{
+				machinePoolScope: &scope.MachinePoolScope{
+					MachinePool: &expclusterv1.MachinePool{
+						Spec: expclusterv1.MachinePoolSpec{
+							Replicas: ptr.To[int32](1),
+						},
+					},
+					AWSMachinePool: &expinfrav1.AWSMachinePool{
+						Spec: expinfrav1.AWSMachinePoolSpec{
+							MaxSize: 1,
+						},
+						Status: expinfrav1.AWSMachinePoolStatus{LaunchTemplateVersion: ptr.To("3")},
+					},
+					Logger: *logger.NewLogger(logr.Discard()),
+				},
+				existingASG: &expinfrav1.AutoScalingGroup{
+					DesiredCapacity:       ptr.To[int32](1),
+					MaxSize:               1,
+					LaunchTemplateVersion: "3",
+				},
+			},
+			want: true,
+		},
+		{
+			name: "asg.maxSize raised by the surge of an instance refresh",
+			args: args{
+				machinePoolScope: &scope.MachinePoolScope{
+					MachinePool: &expclusterv1.MachinePool{
+						Spec: expclusterv1.MachinePoolSpec{
+							Replicas: ptr.To[int32](1),
+						},
+					},
+					AWSMachinePool: &expinfrav1.AWSMachinePool{
+						ObjectMeta: metav1.ObjectMeta{
+							Annotations: map[string]string{expinfrav1.RefreshSurgeAnnotation: "2"},
+						},
+						Spec: expinfrav1.AWSMachinePoolSpec{
+							MaxSize:            2,
+							RefreshPreferences: &expinfrav1.RefreshPreferences{MaxSurge: ptr.To[int32](1)},
+						},
+					},
+					Logger: *logger.NewLogger(logr.Discard()),
+				},
+				existingASG: &expinfrav1.AutoScalingGroup{
+					DesiredCapacity: ptr.To[int32](1),
+					MaxSize:         3,
+				},
+			},
+			want: false,
+		},
+		{
+			name: "asg.maxSize not raised by the surge of an instance refresh yet",
+			args: args{
+				machinePoolScope: &scope.MachinePoolScope{
+					MachinePool: &expclusterv1.MachinePool{
+						Spec: expclusterv1.MachinePoolSpec{
+							Replicas: ptr.To[int32](1),
+						},
+					},
+					AWSMachinePool: &expinfrav1.AWSMachinePool{
+						ObjectMeta: metav1.ObjectMeta{
+							Annotations: map[string]string{expinfrav1.RefreshSurgeAnnotation: "2"},
+						},
+						Spec: expinfrav1.AWSMachinePoolSpec{
+							MaxSize:            2,
+							RefreshPreferences: &expinfrav1.RefreshPreferences{MaxSurge: ptr.To[int32](1)},
+						},
+					},
+					Logger: *logger.NewLogger(logr.Discard()),
+				},
+				existingASG: &expinfrav1.AutoScalingGroup{
+					DesiredCapacity: ptr.To[int32](1),
+					MaxSize:         2,
+				},
+			},
+			want: true,
+		},
+		{
+			name: "TerminationPolicies unset while the ASG uses the default termination policy",
+			args: args{
+				machinePoolScope: &scope.MachinePoolScope{
+					MachinePool: &expclusterv1.MachinePool{
+						Spec: expclusterv1.MachinePoolSpec{
+							Replicas: ptr.To[int32](1),
+						},
+					},
+					AWSMachinePool: &expinfrav1.AWSMachinePool{
+						Spec: expinfrav1.AWSMachinePoolSpec{
+							MaxSize: 2,
+						},
+					},
+					Logger: *logger.NewLogger(logr.Discard()),
+				},
+				existingASG: &expinfrav1.AutoScalingGroup{
+					DesiredCapacity:     ptr.To[int32](1),
+					MaxSize:             2,
+					TerminationPolicies: []string{expinfrav1.TerminationPolicyDefault},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "TerminationPolicies unset while the ASG uses another termination policy",
+			args: args{
+				machinePoolScope: &scope.MachinePoolScope{
+					MachinePool: &expclusterv1.MachinePool{
+						Spec: expclusterv1.MachinePoolSpec{
+							Replicas: ptr.To[int32](1),
+						},
+					},
+					AWSMachinePool: &expinfrav1.AWSMachinePool{
+						Spec: expinfrav1.AWSMachinePoolSpec{
+							MaxSize: 2,
+						},
+					},
+					Logger: *logger.NewLogger(logr.Discard()),
+				},
+				existingASG: &expinfrav1.AutoScalingGroup{
+					DesiredCapacity:     ptr.To[int32](1),
+					MaxSize:             2,
+					TerminationPolicies: []string{expinfrav1.TerminationPolicyOldestInstance},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "TerminationPolicies != asg.TerminationPolicies",
+			args: args{
+				machinePoolScope: &scope.MachinePoolScope{
+					MachinePool: &expclusterv1.MachinePool{
+						Spec: expclusterv1.MachinePoolSpec{
+							Replicas: ptr.To[int32](1),
+						},
+					},
+					AWSMachinePool: &expinfrav1.AWSMachinePool{
+						Spec: expinfrav1.AWSMachinePoolSpec{
+							MaxSize:             2,
+							TerminationPolicies: []string{expinfrav1.TerminationPolicyOldestLaunchTemplate, expinfrav1.TerminationPolicyClosestToNextInstanceHour},
+						},
+					},
+					Logger: *logger.NewLogger(logr.Discard()),
+				},
+				existingASG: &expinfrav1.AutoScalingGroup{
+					DesiredCapacity:     ptr.To[int32](1),
+					MaxSize:             2,
+					TerminationPolicies: []string{expinfrav1.TerminationPolicyDefault},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "HealthCheckType unset while the ASG uses EC2 health checks",
+			args: args{
+				machinePoolScope: &scope.MachinePoolScope{
+					MachinePool: &expclusterv1.MachinePool{
+						Spec: expclusterv1.MachinePoolSpec{
+							Replicas: ptr.To[int32](1),
+						},
+					},
+					AWSMachinePool: &expinfrav1.AWSMachinePool{
+						Spec: expinfrav1.AWSMachinePoolSpec{
+							MaxSize: 2,
+						},
+					},
+					Logger: *logger.NewLogger(logr.Discard()),
+				},
+				existingASG: &expinfrav1.AutoScalingGroup{
+					DesiredCapacity: ptr.To[int32](1),
+					MaxSize:         2,
+					HealthCheckType: expinfrav1.HealthCheckTypeEC2,
+				},
+			},
+			want: false,
+		},
+		{
+			name: "HealthCheckType unset while the ASG uses ELB health checks",
+			args: args{
+				machinePoolScope: &scope.MachinePoolScope{
+					MachinePool: &expclusterv1.MachinePool{
+						Spec: expclusterv1.MachinePoolSpec{
+							Replicas: ptr.To[int32](1),
+						},
+					},
+					AWSMachinePool: &expinfrav1.AWSMachinePool{
+						Spec: expinfrav1.AWSMachinePoolSpec{
+							MaxSize: 2,
+						},
+					},
+					Logger: *logger.NewLogger(logr.Discard()),
+				},
+				existingASG: &expinfrav1.AutoScalingGroup{
+					DesiredCapacity: ptr.To[int32](1),
+					MaxSize:         2,
+					HealthCheckType: expinfrav1.HealthCheckTypeELB,
+				},
+			},
+			want: true,
+		},
+		{
+			name: "HealthCheckType != asg.HealthCheckType",
+			args: args{
+				machinePoolScope: &scope.MachinePoolScope{
+					MachinePool: &expclusterv1.MachinePool{
+						Spec: expclusterv1.MachinePoolSpec{
+							Replicas: ptr.To[int32](1),
+						},
+					},
+					AWSMachinePool: &expinfrav1.AWSMachinePool{
+						Spec: expinfrav1.AWSMachinePoolSpec{
+							MaxSize:         2,
+							HealthCheckType: expinfrav1.HealthCheckTypeELB,
+						},
+					},
+					Logger: *logger.NewLogger(logr.Discard()),
+				},
+				existingASG: &expinfrav1.AutoScalingGroup{
+					DesiredCapacity: ptr.To[int32](1),
+					MaxSize:         2,
+					HealthCheckType: expinfrav1.HealthCheckTypeEC2,
+				},
+			},
+			want: true,
+		},
+		{
+			name: "HealthCheckGracePeriod != asg.HealthCheckGracePeriod",
+			args: args{
+				machinePoolScope: &scope.MachinePoolScope{
+					MachinePool: &expclusterv1.MachinePool{
+						Spec: expclusterv1.MachinePoolSpec{
+							Replicas: ptr.To[int32](1),
+						},
+					},
+					AWSMachinePool: &expinfrav1.AWSMachinePool{
+						Spec: expinfrav1.AWSMachinePoolSpec{
+							MaxSize:                2,
+							HealthCheckType:        expinfrav1.HealthCheckTypeELB,
+							HealthCheckGracePeriod: &metav1.Duration{Duration: 5 * time.Minute},
+						},
+					},
+					Logger: *logger.NewLogger(logr.Discard()),
+				},
+				existingASG: &expinfrav1.AutoScalingGroup{
+					DesiredCapacity:        ptr.To[int32](1),
+					MaxSize:                2,
+					HealthCheckType:        expinfrav1.HealthCheckTypeELB,
+					HealthCheckGracePeriod: metav1.Duration{Duration: time.Minute},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "HealthCheckGracePeriod unset while the ASG has one",
+			args: args{
+				machinePoolScope: &scope.MachinePoolScope{
+					MachinePool: &expclusterv1.MachinePool{
+						Spec: expclusterv1.MachinePoolSpec{
+							Replicas: ptr.To[int32](1),
+						},
+					},
+					AWSMachinePool: &expinfrav1.AWSMachinePool{
+						Spec: expinfrav1.AWSMachinePoolSpec{
+							MaxSize:         2,
+							HealthCheckType: expinfrav1.HealthCheckTypeELB,
+						},
+					},
+					Logger: *logger.NewLogger(logr.Discard()),
+				},
+				existingASG: &expinfrav1.AutoScalingGroup{
+					DesiredCapacity:        ptr.To[int32](1),
+					MaxSize:                2,
+					HealthCheckType:        expinfrav1.HealthCheckTypeELB,
+					HealthCheckGracePeriod: metav1.Duration{Duration: time.Minute},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "DefaultInstanceWarmup != asg.DefaultInstanceWarmup",
+			args: args{
+				machinePoolScope: &scope.MachinePoolScope{
+					MachinePool: &expclusterv1.MachinePool{
+						Spec: expclusterv1.MachinePoolSpec{
+							Replicas: ptr.To[int32](1),
+						},
+					},
+					AWSMachinePool: &expinfrav1.AWSMachinePool{
+						Spec: expinfrav1.AWSMachinePoolSpec{
+							MaxSize:               2,
+							DefaultInstanceWarmup: &metav1.Duration{Duration: 2 * time.Minute},
+						},
+					},
+					Logger: *logger.NewLogger(logr.Discard()),
+				},
+				existingASG: &expinfrav1.AutoScalingGroup{
+					DesiredCapacity:       ptr.To[int32](1),
+					MaxSize:               2,
+					DefaultInstanceWarmup: &metav1.Duration{Duration: 5 * time.Minute},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "DefaultInstanceWarmup set while the ASG has none",
+			args: args{
+				machinePoolScope: &scope.MachinePoolScope{
+					MachinePool: &expclusterv1.MachinePool{
+						Spec: expclusterv1.MachinePoolSpec{
+							Replicas: ptr.To[int32](1),
+						},
+					},
+					AWSMachinePool: &expinfrav1.AWSMachinePool{
+						Spec: expinfrav1.AWSMachinePoolSpec{
+							MaxSize:               2,
+							DefaultInstanceWarmup: &metav1.Duration{Duration: 2 * time.Minute},
+						},
+					},
+					Logger: *logger.NewLogger(logr.Discard()),
+				},
+				existingASG: &expinfrav1.AutoScalingGroup{
+					DesiredCapacity: ptr.To[int32](1),
+					MaxSize:         2,
+				},
+			},
+			want: true,
+		},
+		{
+			name: "DefaultInstanceWarmup unset while the ASG has one",
+			args: args{
+				machinePoolScope: &scope.MachinePoolScope{
+					MachinePool: &expclusterv1.MachinePool{
+						Spec: expclusterv1.MachinePoolSpec{
+							Replicas: ptr.To[int32](1),
+						},
+					},
+					AWSMachinePool: &expinfrav1.AWSMachinePool{
+						Spec: expinfrav1.AWSMachinePoolSpec{
+							MaxSize: 2,
+						},
+					},
+					Logger: *logger.NewLogger(logr.Discard()),
+				},
+				existingASG: &expinfrav1.AutoScalingGroup{
+					DesiredCapacity:       ptr.To[int32](1),
+					MaxSize:               2,
+					DefaultInstanceWarmup: &metav1.Duration{Duration: 5 * time.Minute},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "MaxInstanceLifetime != asg.MaxInstanceLifetime",
+			args: args{
+				machinePoolScope: &scope.MachinePoolScope{
+					MachinePool: &expclusterv1.MachinePool{
+						Spec: expclusterv1.MachinePoolSpec{
+							Replicas: ptr.To[int32](1),
+						},
+					},
+					AWSMachinePool: &expinfrav1.AWSMachinePool{
+						Spec: expinfrav1.AWSMachinePoolSpec{
+							MaxSize:             2,
+							MaxInstanceLifetime: &metav1.Duration{Duration: 14 * 24 * time.Hour},
+						},
+					},
+					Logger: *logger.NewLogger(logr.Discard()),
+				},
+				existingASG: &expinfrav1.AutoScalingGroup{
+					DesiredCapacity:     ptr.To[int32](1),
+					MaxSize:             2,
+					MaxInstanceLifetime: &metav1.Duration{Duration: 7 * 24 * time.Hour},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "MaxInstanceLifetime unset while the ASG has one",
+			args: args{
+				machinePoolScope: &scope.MachinePoolScope{
+					MachinePool: &expclusterv1.MachinePool{
+						Spec: expclusterv1.MachinePoolSpec{
+							Replicas: ptr.To[int32](1),
+						},
+					},
+					AWSMachinePool: &expinfrav1.AWSMachinePool{
+						Spec: expinfrav1.AWSMachinePoolSpec{
+							MaxSize: 2,
+						},
+					},
+					Logger: *logger.NewLogger(logr.Discard()),
+				},
+				existingASG: &expinfrav1.AutoScalingGroup{
+					DesiredCapacity:     ptr.To[int32](1),
+					MaxSize:             2,
+					MaxInstanceLifetime: &metav1.Duration{Duration: 7 * 24 * time.Hour},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "MaxInstanceLifetime zero while the ASG has none",
+			args: args{
+				machinePoolScope: &scope.MachinePoolScope{
+					MachinePool: &expclusterv1.MachinePool{
+						Spec: expclusterv1.MachinePoolSpec{
+							Replicas: ptr.To[int32](1),
+						},
+					},
+					AWSMachinePool: &expinfrav1.AWSMachinePool{
+						Spec: expinfrav1.AWSMachinePoolSpec{
+							MaxSize:             2,
+							MaxInstanceLifetime: &metav1.Duration{},
+						},
+					},
+					Logger: *logger.NewLogger(logr.Discard()),
+				},
+				existingASG: &expinfrav1.AutoScalingGroup{
+					DesiredCapacity: ptr.To[int32](1),
+					MaxSize:         2,
+				},
+			},
+			want: false,
+		},
+		{
+			name: "MixedInstancesPolicy set while the ASG uses a launch template",
+			args: args{
+				machinePoolScope: &scope.MachinePoolScope{
+					MachinePool: &expclusterv1.MachinePool{
+						Spec: expclusterv1.MachinePoolSpec{
+							Replicas: ptr.To[int32](1),
+						},
+					},
+					AWSMachinePool: &expinfrav1.AWSMachinePool{
+						Spec: expinfrav1.AWSMachinePoolSpec{
+							MaxSize: 2,
+							MixedInstancesPolicy: &expinfrav1.MixedInstancesPolicy{
+								Overrides: []expinfrav1.Overrides{{InstanceType: "m6a.32xlarge"}},
+							},
+						},
+					},
+					Logger: *logger.NewLogger(logr.Discard()),
+				},
+				existingASG: &expinfrav1.AutoScalingGroup{
+					DesiredCapacity: ptr.To[int32](1),
+					MaxSize:         2,
+				},
+			},
+			want: true,
+		},
+		{
+			name: "MixedInstancesPolicy unset while the ASG uses a mixed instances policy",
+			args: args{
+				machinePoolScope: &scope.MachinePoolScope{
+					MachinePool: &expclusterv1.MachinePool{
+						Spec: expclusterv1.MachinePoolSpec{
+							Replicas: ptr.To[int32](1),
+						},
+					},
+					AWSMachinePool: &expinfrav1.AWSMachinePool{
+						Spec: expinfrav1.AWSMachinePoolSpec{
+							MaxSize: 2,
+						},
+					},
+					Logger: *logger.NewLogger(logr.Discard()),
+				},
+				existingASG: &expinfrav1.AutoScalingGroup{
+					DesiredCapacity: ptr.To[int32](1),
+					MaxSize:         2,
+					MixedInstancesPolicy: &expinfrav1.MixedInstancesPolicy{
+						Overrides: []expinfrav1.Overrides{{InstanceType: "m6a.32xlarge"}},
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "MixedInstancesPolicy override root volume launch template referenced by the ASG",
+			args: args{
+				machinePoolScope: &scope.MachinePoolScope{
+					MachinePool: &expclusterv1.MachinePool{
+						Spec: expclusterv1.MachinePoolSpec{
+							Replicas: ptr.To[int32](1),
+						},
+					},
+					AWSMachinePool: &expinfrav1.AWSMachinePool{
+						Spec: expinfrav1.AWSMachinePoolSpec{
+							MaxSize:           2,
+							MinSize:           0,
+							CapacityRebalance: true,
+							MixedInstancesPolicy: &expinfrav1.MixedInstancesPolicy{
+								InstancesDistribution: &expinfrav1.InstancesDistribution{
+									OnDemandAllocationStrategy: expinfrav1.OnDemandAllocationStrategyPrioritized,
+								},
+								Overrides: []expinfrav1.Overrides{
+									{
+										InstanceType: "m6a.32xlarge",
+										RootVolume:   &infrav1.Volume{Size: 100},
+									},
+								},
+							},
+						},
+					},
+					Logger: *logger.NewLogger(logr.Discard()),
+				},
+				existingASG: &expinfrav1.AutoScalingGroup{
+					DesiredCapacity:   ptr.To[int32](1),
+					MaxSize:           2,
+					MinSize:           0,
+					CapacityRebalance: true,
+					MixedInstancesPolicy: &expinfrav1.MixedInstancesPolicy{
+						InstancesDistribution: &expinfrav1.InstancesDistribution{
+							OnDemandAllocationStrategy: expinfrav1.OnDemandAllocationStrategyPrioritized,
+						},
+						Overrides: []expinfrav1.Overrides{
+							{
+								InstanceType: "m6a.32xlarge",
+								RootVolume:   &infrav1.Volume{},
+							},
+						},
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "MixedInstancesPolicy override root volume launch template not yet referenced by the ASG",
+			args: args{
+				machinePoolScope: &scope.MachinePoolScope{
+					MachinePool: &expclusterv1.MachinePool{
+						Spec: expclusterv1.MachinePoolSpec{
+							Replicas: ptr.To[int32](1),
+						},
+					},
+					AWSMachinePool: &expinfrav1.AWSMachinePool{
+						Spec: expinfrav1.AWSMachinePoolSpec{
+							MaxSize:           2,
+							MinSize:           0,
+							CapacityRebalance: true,
+							MixedInstancesPolicy: &expinfrav1.MixedInstancesPolicy{
+								InstancesDistribution: &expinfrav1.InstancesDistribution{
+									OnDemandAllocationStrategy: expinfrav1.OnDemandAllocationStrategyPrioritized,
+								},
+								Overrides: []expinfrav1.Overrides{
+									{
+										InstanceType: "m6a.32xlarge",
+										RootVolume:   &infrav1.Volume{Size: 100},
+									},
+								},
+							},
+						},
+					},
+					Logger: *logger.NewLogger(logr.Discard()),
+				},
+				existingASG: &expinfrav1.AutoScalingGroup{
+					DesiredCapacity:   ptr.To[int32](1),
+					MaxSize:           2,
+					MinSize:           0,
+					CapacityRebalance: true,
+					MixedInstancesPolicy: &expinfrav1.MixedInstancesPolicy{
+						InstancesDistribution: &expinfrav1.InstancesDistribution{
+							OnDemandAllocationStrategy: expinfrav1.OnDemandAllocationStrategyPrioritized,
+						},
+						Overrides: []expinfrav1.Overrides{
+							{
+								InstanceType: "m6a.32xlarge",
+							},
+						},
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "SuspendProcesses != asg.SuspendProcesses",
+			args: args{
+				machinePoolScope: &scope.MachinePoolScope{
+					MachinePool: &expclusterv1.MachinePool{
+						Spec: expclusterv1.MachinePoolSpec{
+							Replicas: ptr.To[int32](1),
+						},
+					},
+					AWSMachinePool: &expinfrav1.AWSMachinePool{
+						Spec: expinfrav1.AWSMachinePoolSpec{
+							MaxSize:           2,
+							MinSize:           0,
+							CapacityRebalance: true,
+							MixedInstancesPolicy: &expinfrav1.MixedInstancesPolicy{
+								InstancesDistribution: &expinfrav1.InstancesDistribution{
+									OnDemandAllocationStrategy: expinfrav1.OnDemandAllocationStrategyPrioritized,
+								},
+								Overrides: nil,
+							},
+							SuspendProcesses: &expinfrav1.SuspendProcessesTypes{
+								Processes: &expinfrav1.Processes{
+									Launch:    ptr.To[bool](true),
+									Terminate: ptr.To[bool](true),
+								},
+							},
+						},
+					},
+					Logger: *logger.NewLogger(logr.Discard()),
+				},
+				existingASG: &expinfrav1.AutoScalingGroup{
+					DesiredCapacity:           ptr.To[int32](1),
+					MaxSize:                   2,
+					MinSize:                   0,
+					CapacityRebalance:         true,
+					MixedInstancesPolicy:      &expinfrav1.MixedInstancesPolicy{},
+					CurrentlySuspendProcesses: []string{"Launch", "Terminate"},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "all matches",
+			args: args{
+				machinePoolScope: &scope.MachinePoolScope{
+					MachinePool: &expclusterv1.MachinePool{
+						Spec: expclusterv1.MachinePoolSpec{
+							Replicas: ptr.To[int32](1),
+						},
+					},
+					AWSMachinePool: &expinfrav1.AWSMachinePool{
+						Spec: expinfrav1.AWSMachinePoolSpec{
+							MaxSize:           2,
+							MinSize:           0,
+							CapacityRebalance: true,
+							MixedInstancesPolicy: &expinfrav1.MixedInstancesPolicy{
+								InstancesDistribution: &expinfrav1.InstancesDistribution{
+									OnDemandAllocationStrategy: expinfrav1.OnDemandAllocationStrategyPrioritized,
+								},
+								Overrides: nil,
+							},
+						},
+					},
+				},
+				existingASG: &expinfrav1.AutoScalingGroup{
+					DesiredCapacity:   ptr.To[int32](1),
+					MaxSize:           2,
+					MinSize:           0,
+					CapacityRebalance: true,
+					MixedInstancesPolicy: &expinfrav1.MixedInstancesPolicy{
+						InstancesDistribution: &expinfrav1.InstancesDistribution{
+							OnDemandAllocationStrategy: expinfrav1.OnDemandAllocationStrategyPrioritized,
+						},
+						Overrides: nil,
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "externally managed annotation ignores difference between desiredCapacity and replicas",
+			args: args{
+				machinePoolScope: &scope.MachinePoolScope{
+					MachinePool: &expclusterv1.MachinePool{
+						ObjectMeta: metav1.ObjectMeta{
+							Annotations: map[string]string{
+								clusterv1.ReplicasManagedByAnnotation: "", // empty value counts as true (= externally managed)
+							},
+						},
+						Spec: expclusterv1.MachinePoolSpec{
+							Replicas: ptr.To[int32](0),
+						},
+					},
+					AWSMachinePool: &expinfrav1.AWSMachinePool{
+						Spec: expinfrav1.AWSMachinePoolSpec{},
+					},
+				},
+				existingASG: &expinfrav1.AutoScalingGroup{
+					DesiredCapacity: ptr.To[int32](1),
+				},
+			},
+			want: false,
+		},
+		{
+			name: "without externally managed annotation ignores difference between desiredCapacity and replicas",
+			args: args{
+				machinePoolScope: &scope.MachinePoolScope{
+					MachinePool: &expclusterv1.MachinePool{
+						Spec: expclusterv1.MachinePoolSpec{
+							Replicas: ptr.To[int32](0),
+						},
+					},
+					AWSMachinePool: &expinfrav1.AWSMachinePool{
+						Spec: expinfrav1.AWSMachinePoolSpec{},
+					},
+				},
+				existingASG: &expinfrav1.AutoScalingGroup{
+					DesiredCapacity: ptr.To[int32](1),
+				},
+			},
+			want: true,
+		},
+		{
+			name: "unmanaged fields ignore differences with the ASG",
+			args: args{
+				machinePoolScope: &scope.MachinePoolScope{
+					MachinePool: &expclusterv1.MachinePool{
+						Spec: expclusterv1.MachinePoolSpec{
+							Replicas: ptr.To[int32](0),
+						},
+					},
+					AWSMachinePool: &expinfrav1.AWSMachinePool{
+						Spec: expinfrav1.AWSMachinePoolSpec{
+							MaxSize:              2,
+							MinSize:              0,
+							MixedInstancesPolicy: &expinfrav1.MixedInstancesPolicy{},
+							UnmanagedFields: []expinfrav1.UnmanagedField{
+								expinfrav1.UnmanagedFieldDesiredCapacity,
+								expinfrav1.UnmanagedFieldMinSize,
+								expinfrav1.UnmanagedFieldMaxSize,
+								expinfrav1.UnmanagedFieldMixedInstancesPolicy,
+							},
+						},
+					},
+				},
+				existingASG: &expinfrav1.AutoScalingGroup{
+					DesiredCapacity: ptr.To[int32](3),
+					MaxSize:         5,
+					MinSize:         1,
+					MixedInstancesPolicy: &expinfrav1.MixedInstancesPolicy{
+						Overrides: []expinfrav1.Overrides{{InstanceType: "m5.large"}},
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "managed fields are still compared when others are unmanaged",
+			args: args{
+				machinePoolScope: &scope.MachinePoolScope{
+					MachinePool: &expclusterv1.MachinePool{
+						Spec: expclusterv1.MachinePoolSpec{
+							Replicas: ptr.To[int32](1),
 						},
 					},
+					AWSMachinePool: &expinfrav1.AWSMachinePool{
+						Spec: expinfrav1.AWSMachinePoolSpec{
+							MaxSize:         2,
+							MinSize:         0,
+							UnmanagedFields: []expinfrav1.UnmanagedField{expinfrav1.UnmanagedFieldMinSize},
+						},
+					},
+				},
+				existingASG: &expinfrav1.AutoScalingGroup{
+					DesiredCapacity: ptr.To[int32](1),
+					MaxSize:         5,
+					MinSize:         1,
+				},
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(diffASG(tt.args.machinePoolScope, tt.args.existingASG) != "").To(Equal(tt.want))
+		})
+	}
+}
+
+func TestReportStructureDrift(t *testing.T) {
+	mixedInstancesPolicy := &expinfrav1.MixedInstancesPolicy{
+		Overrides: []expinfrav1.Overrides{{InstanceType: "m6a.32xlarge"}},
+	}
+	tests := []struct {
+		name        string
+		spec        expinfrav1.AWSMachinePoolSpec
+		existingASG *expinfrav1.AutoScalingGroup
+		wantMessage string
+	}{
+		{
+			name:        "should report an ASG switched to a launch template",
+			spec:        expinfrav1.AWSMachinePoolSpec{MixedInstancesPolicy: mixedInstancesPolicy},
+			existingASG: &expinfrav1.AutoScalingGroup{},
+			wantMessage: "The ASG uses a launch template while spec.mixedInstancesPolicy is set, it is replaced by the mixed instances policy",
+		},
+		{
+			name:        "should report an ASG switched to a mixed instances policy",
+			existingASG: &expinfrav1.AutoScalingGroup{MixedInstancesPolicy: mixedInstancesPolicy},
+			wantMessage: "The ASG uses a mixed instances policy while spec.mixedInstancesPolicy is not set, it is replaced by the launch template",
+		},
+		{
+			name:        "should not report an ASG matching the spec",
+			spec:        expinfrav1.AWSMachinePoolSpec{MixedInstancesPolicy: mixedInstancesPolicy},
+			existingASG: &expinfrav1.AutoScalingGroup{MixedInstancesPolicy: mixedInstancesPolicy},
+		},
+		{
+			name: "should not report an ASG whose mixed instances policy is unmanaged",
+			spec: expinfrav1.AWSMachinePoolSpec{
+				UnmanagedFields: []expinfrav1.UnmanagedField{expinfrav1.UnmanagedFieldMixedInstancesPolicy},
+			},
+			existingASG: &expinfrav1.AutoScalingGroup{MixedInstancesPolicy: mixedInstancesPolicy},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			machinePoolScope := &scope.MachinePoolScope{
+				AWSMachinePool: &expinfrav1.AWSMachinePool{Spec: tt.spec},
+			}
+
+			reportStructureDrift(machinePoolScope, tt.existingASG)
+			if tt.wantMessage == "" {
+				g.Expect(conditions.Has(machinePoolScope.AWSMachinePool, expinfrav1.ASGStructureDriftedCondition)).To(BeFalse())
+				return
+			}
+			g.Expect(conditions.GetMessage(machinePoolScope.AWSMachinePool, expinfrav1.ASGStructureDriftedCondition)).To(Equal(tt.wantMessage))
+		})
+	}
+}
+
+func TestReportCapacityBlockWindow(t *testing.T) {
+	g := NewWithT(t)
+	recorder := record.NewFakeRecorder(10)
+	pool := &expinfrav1.AWSMachinePool{
+		Status: expinfrav1.AWSMachinePoolStatus{
+			CapacityBlock: &expinfrav1.CapacityBlockStatus{
+				CapacityReservationID: "cr-1",
+				StartTime:             &metav1.Time{Time: time.Now().Add(time.Hour)},
+			},
+		},
+	}
+
+	// The event is only emitted when the pool leaves or enters the window.
+	reportCapacityBlockWindow(recorder, pool)
+	reportCapacityBlockWindow(recorder, pool)
+	g.Expect(conditions.IsTrue(pool, expinfrav1.CapacityBlockOutsideWindowCondition)).To(BeTrue())
+	g.Expect(recorder.Events).To(Receive(ContainSubstring("CapacityBlockOutsideWindow")))
+	g.Expect(recorder.Events).NotTo(Receive())
+
+	pool.Status.CapacityBlock.StartTime = &metav1.Time{Time: time.Now().Add(-time.Hour)}
+	reportCapacityBlockWindow(recorder, pool)
+	reportCapacityBlockWindow(recorder, pool)
+	g.Expect(conditions.Has(pool, expinfrav1.CapacityBlockOutsideWindowCondition)).To(BeFalse())
+	g.Expect(recorder.Events).To(Receive(ContainSubstring("CapacityBlockInWindow")))
+	g.Expect(recorder.Events).NotTo(Receive())
+}
+
+func TestStartInstanceRefreshOutsideCapacityBlockWindow(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	asgSvc := mock_services.NewMockASGInterface(mockCtrl)
+	asgSvc.EXPECT().StartASGInstanceRefresh(gomock.Any()).Times(0)
+
+	machinePoolScope := &scope.MachinePoolScope{
+		Logger: *logger.NewLogger(klog.Background()),
+		AWSMachinePool: &expinfrav1.AWSMachinePool{
+			Status: expinfrav1.AWSMachinePoolStatus{LaunchTemplateVersion: ptr.To[string]("2")},
+		},
+	}
+	conditions.MarkTrueWithNegativePolarity(machinePoolScope.AWSMachinePool, expinfrav1.CapacityBlockOutsideWindowCondition,
+		expinfrav1.CapacityBlockOutsideWindowReason, clusterv1.ConditionSeverityWarning, "")
+	reconciler := &AWSMachinePoolReconciler{Recorder: record.NewFakeRecorder(10)}
+
+	// The refresh isn't recorded as started, so that it's started once the window starts.
+	g.Expect(reconciler.startInstanceRefresh(machinePoolScope, asgSvc)).To(Succeed())
+	g.Expect(machinePoolScope.AWSMachinePool.Status.InstanceRefreshLaunchTemplateVersion).To(BeNil())
+}
+
+func TestReconcileLaunchTemplateRollback(t *testing.T) {
+	tests := []struct {
+		name         string
+		pool         *expinfrav1.AWSMachinePool
+		expect       func(ec2Svc *mock_services.MockEC2InterfaceMockRecorder, asgSvc *mock_services.MockASGInterfaceMockRecorder)
+		wantVersion  string
+		wantRollback *expinfrav1.LaunchTemplateRollback
+	}{
+		{
+			name: "should restore the previous version and cancel the running instance refresh",
+			pool: &expinfrav1.AWSMachinePool{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "pool",
+					Generation:  3,
+					Annotations: map[string]string{expinfrav1.RollbackLaunchTemplateAnnotation: "true"},
 				},
-				existingASG: &expinfrav1.AutoScalingGroup{
-					DesiredCapacity: ptr.To[int32](1),
+				Status: expinfrav1.AWSMachinePoolStatus{
+					LaunchTemplateID:              "lt-1",
+					LaunchTemplateVersion:         ptr.To[string]("5"),
+					PreviousLaunchTemplateVersion: ptr.To[string]("4"),
 				},
 			},
-			want: true,
+			expect: func(ec2Svc *mock_services.MockEC2InterfaceMockRecorder, asgSvc *mock_services.MockASGInterfaceMockRecorder) {
+				ec2Svc.CreateLaunchTemplateVersionFromVersion("lt-1", "4").Return("6", nil)
+				asgSvc.DescribeLatestInstanceRefresh("pool").Return(&expinfrav1.InstanceRefreshStatus{ID: "refresh-1", State: autoscaling.InstanceRefreshStatusInProgress}, nil)
+				asgSvc.CancelASGInstanceRefresh("pool").Return(nil)
+			},
+			wantVersion:  "6",
+			wantRollback: &expinfrav1.LaunchTemplateRollback{FromVersion: "5", ToVersion: "4", Version: "6", ObservedGeneration: 3},
 		},
 		{
-			name: "replicas != asg.desiredCapacity (nil)",
-			args: args{
-				machinePoolScope: &scope.MachinePoolScope{
-					MachinePool: &expclusterv1.MachinePool{
-						Spec: expclusterv1.MachinePoolSpec{
-							Replicas: ptr.To[int32](0),
-						},
-					},
+			name: "should ignore the annotation without a previous version",
+			pool: &expinfrav1.AWSMachinePool{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "pool",
+					Annotations: map[string]string{expinfrav1.RollbackLaunchTemplateAnnotation: "true"},
 				},
-				existingASG: &expinfrav1.AutoScalingGroup{
-					DesiredCapacity: nil,
+				Status: expinfrav1.AWSMachinePoolStatus{
+					LaunchTemplateID:      "lt-1",
+					LaunchTemplateVersion: ptr.To[string]("5"),
 				},
 			},
-			want: true,
+			wantVersion: "5",
 		},
 		{
-			name: "maxSize != asg.maxSize",
-			args: args{
-				machinePoolScope: &scope.MachinePoolScope{
-					MachinePool: &expclusterv1.MachinePool{
-						Spec: expclusterv1.MachinePoolSpec{
-							Replicas: ptr.To[int32](1),
-						},
-					},
-					AWSMachinePool: &expinfrav1.AWSMachinePool{
-						Spec: expinfrav1.AWSMachinePoolSpec{
-							MaxSize: 1,
-						},
-					},
-				},
-				existingASG: &expinfrav1.AutoScalingGroup{
-					DesiredCapacity: ptr.To[int32](1),
-					MaxSize:         2,
+			name: "should hold the rollback while the spec is unchanged",
+			pool: &expinfrav1.AWSMachinePool{
+				ObjectMeta: metav1.ObjectMeta{Name: "pool", Generation: 3},
+				Status: expinfrav1.AWSMachinePoolStatus{
+					LaunchTemplateID:       "lt-1",
+					LaunchTemplateVersion:  ptr.To[string]("6"),
+					LaunchTemplateRollback: &expinfrav1.LaunchTemplateRollback{FromVersion: "5", ToVersion: "4", Version: "6", ObservedGeneration: 3},
 				},
 			},
-			want: true,
+			wantVersion:  "6",
+			wantRollback: &expinfrav1.LaunchTemplateRollback{FromVersion: "5", ToVersion: "4", Version: "6", ObservedGeneration: 3},
 		},
 		{
-			name: "minSize != asg.minSize",
-			args: args{
-				machinePoolScope: &scope.MachinePoolScope{
-					MachinePool: &expclusterv1.MachinePool{
-						Spec: expclusterv1.MachinePoolSpec{
-							Replicas: ptr.To[int32](1),
-						},
-					},
-					AWSMachinePool: &expinfrav1.AWSMachinePool{
-						Spec: expinfrav1.AWSMachinePoolSpec{
-							MaxSize: 2,
-							MinSize: 0,
-						},
-					},
-				},
-				existingASG: &expinfrav1.AutoScalingGroup{
-					DesiredCapacity: ptr.To[int32](1),
-					MaxSize:         2,
-					MinSize:         1,
+			name: "should forget the rollback once the spec changed",
+			pool: &expinfrav1.AWSMachinePool{
+				ObjectMeta: metav1.ObjectMeta{Name: "pool", Generation: 4},
+				Status: expinfrav1.AWSMachinePoolStatus{
+					LaunchTemplateID:       "lt-1",
+					LaunchTemplateVersion:  ptr.To[string]("6"),
+					LaunchTemplateRollback: &expinfrav1.LaunchTemplateRollback{FromVersion: "5", ToVersion: "4", Version: "6", ObservedGeneration: 3},
 				},
 			},
-			want: true,
+			wantVersion: "6",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			ec2Svc := mock_services.NewMockEC2Interface(mockCtrl)
+			asgSvc := mock_services.NewMockASGInterface(mockCtrl)
+			if tt.expect != nil {
+				tt.expect(ec2Svc.EXPECT(), asgSvc.EXPECT())
+			}
+			reconciler := AWSMachinePoolReconciler{Recorder: record.NewFakeRecorder(2)}
+			machinePoolScope := &scope.MachinePoolScope{
+				Logger:         *logger.NewLogger(logr.Discard()),
+				AWSMachinePool: tt.pool,
+			}
+
+			g.Expect(reconciler.reconcileLaunchTemplateRollback(machinePoolScope, ec2Svc, asgSvc)).To(Succeed())
+			g.Expect(tt.pool.Annotations).NotTo(HaveKey(expinfrav1.RollbackLaunchTemplateAnnotation))
+			g.Expect(tt.pool.Status.LaunchTemplateVersion).To(Equal(ptr.To[string](tt.wantVersion)))
+			g.Expect(tt.pool.Status.LaunchTemplateRollback).To(Equal(tt.wantRollback))
+			if tt.wantRollback != nil {
+				g.Expect(tt.pool.Status.PreviousLaunchTemplateVersion).To(BeNil())
+			}
+		})
+	}
+}
+
+func TestReconcileOutdatedInstances(t *testing.T) {
+	asg := &expinfrav1.AutoScalingGroup{
+		Instances: []infrav1.Instance{
+			{ID: "i-1", State: autoscaling.LifecycleStateInService},
+			{ID: "i-2", State: autoscaling.LifecycleStateInService},
+		},
+		InstanceDetails: map[string]expinfrav1.ASGInstanceDetails{
+			"i-1": {LaunchTemplateID: "lt-1", LaunchTemplateVersion: "2"},
+			"i-2": {LaunchTemplateID: "lt-1", LaunchTemplateVersion: "3"},
 		},
+	}
+	protectedASG := asg.DeepCopy()
+	protectedASG.InstanceDetails["i-1"] = expinfrav1.ASGInstanceDetails{LaunchTemplateID: "lt-1", LaunchTemplateVersion: "2", ProtectedFromScaleIn: true}
+
+	tests := []struct {
+		name   string
+		spec   expinfrav1.AWSMachinePoolSpec
+		status expinfrav1.AWSMachinePoolStatus
+		// protected protects the outdated instance from scale in.
+		protected   bool
+		expect      func(ec2Svc *mock_services.MockEC2InterfaceMockRecorder, asgSvc *mock_services.MockASGInterfaceMockRecorder)
+		wantRefresh bool
+	}{
 		{
-			name: "capacityRebalance != asg.capacityRebalance",
-			args: args{
-				machinePoolScope: &scope.MachinePoolScope{
-					MachinePool: &expclusterv1.MachinePool{
-						Spec: expclusterv1.MachinePoolSpec{
-							Replicas: ptr.To[int32](1),
-						},
-					},
-					AWSMachinePool: &expinfrav1.AWSMachinePool{
-						Spec: expinfrav1.AWSMachinePoolSpec{
-							MaxSize:           2,
-							MinSize:           0,
-							CapacityRebalance: true,
-						},
-					},
+			name:   "should replace the instances of a version which changed beyond the userdata",
+			status: expinfrav1.AWSMachinePoolStatus{LaunchTemplateID: "lt-1", LaunchTemplateVersion: ptr.To[string]("3")},
+			expect: func(ec2Svc *mock_services.MockEC2InterfaceMockRecorder, asgSvc *mock_services.MockASGInterfaceMockRecorder) {
+				ec2Svc.LaunchTemplateVersionChangedBeyondUserData("lt-1", "2", "3").Return(true, nil)
+				asgSvc.CanStartASGInstanceRefresh(gomock.Any()).Return(true, nil)
+				asgSvc.StartASGInstanceRefresh(gomock.Any()).Return("refresh-1", nil)
+			},
+			wantRefresh: true,
+		},
+		{
+			name:   "should keep the instances of a version which only changed the userdata",
+			status: expinfrav1.AWSMachinePoolStatus{LaunchTemplateID: "lt-1", LaunchTemplateVersion: ptr.To[string]("3")},
+			expect: func(ec2Svc *mock_services.MockEC2InterfaceMockRecorder, asgSvc *mock_services.MockASGInterfaceMockRecorder) {
+				ec2Svc.LaunchTemplateVersionChangedBeyondUserData("lt-1", "2", "3").Return(false, nil)
+			},
+		},
+		{
+			name:   "should wait for the running instance refresh",
+			status: expinfrav1.AWSMachinePoolStatus{LaunchTemplateID: "lt-1", LaunchTemplateVersion: ptr.To[string]("3")},
+			expect: func(ec2Svc *mock_services.MockEC2InterfaceMockRecorder, asgSvc *mock_services.MockASGInterfaceMockRecorder) {
+				ec2Svc.LaunchTemplateVersionChangedBeyondUserData("lt-1", "2", "3").Return(true, nil)
+				asgSvc.CanStartASGInstanceRefresh(gomock.Any()).Return(false, nil)
+			},
+		},
+		{
+			name:   "should not compare instances of the latest version",
+			status: expinfrav1.AWSMachinePoolStatus{LaunchTemplateID: "lt-1", LaunchTemplateVersion: ptr.To[string]("2")},
+		},
+		{
+			name:   "should not compare instances of another launch template",
+			status: expinfrav1.AWSMachinePoolStatus{LaunchTemplateID: "lt-2", LaunchTemplateVersion: ptr.To[string]("3")},
+		},
+		{
+			name:   "should not replace instances when the instance refresh is disabled",
+			spec:   expinfrav1.AWSMachinePoolSpec{RefreshPreferences: &expinfrav1.RefreshPreferences{Disable: true}},
+			status: expinfrav1.AWSMachinePoolStatus{LaunchTemplateID: "lt-1", LaunchTemplateVersion: ptr.To[string]("3")},
+		},
+		{
+			name: "should not retry a failed instance refresh of the latest version",
+			status: expinfrav1.AWSMachinePoolStatus{
+				LaunchTemplateID:                     "lt-1",
+				LaunchTemplateVersion:                ptr.To[string]("3"),
+				InstanceRefreshLaunchTemplateVersion: ptr.To[string]("3"),
+				InstanceRefreshStatus:                &expinfrav1.InstanceRefreshStatus{ID: "refresh-1", State: autoscaling.InstanceRefreshStatusFailed},
+			},
+		},
+		{
+			name:      "should keep the instances protected from scale in skipped by the instance refresh",
+			status:    expinfrav1.AWSMachinePoolStatus{LaunchTemplateID: "lt-1", LaunchTemplateVersion: ptr.To[string]("3")},
+			protected: true,
+		},
+		{
+			name: "should keep the instances protected from scale in with the AZSequential strategy",
+			spec: expinfrav1.AWSMachinePoolSpec{RefreshPreferences: &expinfrav1.RefreshPreferences{
+				Strategy:                  ptr.To(expinfrav1.RefreshStrategyAZSequential),
+				ScaleInProtectedInstances: expinfrav1.ScaleInProtectedInstancesRefresh,
+			}},
+			status:    expinfrav1.AWSMachinePoolStatus{LaunchTemplateID: "lt-1", LaunchTemplateVersion: ptr.To[string]("3")},
+			protected: true,
+		},
+		{
+			name: "should replace the instances protected from scale in refreshed by the instance refresh",
+			spec: expinfrav1.AWSMachinePoolSpec{RefreshPreferences: &expinfrav1.RefreshPreferences{
+				ScaleInProtectedInstances: expinfrav1.ScaleInProtectedInstancesRefresh,
+			}},
+			status:    expinfrav1.AWSMachinePoolStatus{LaunchTemplateID: "lt-1", LaunchTemplateVersion: ptr.To[string]("3")},
+			protected: true,
+			expect: func(ec2Svc *mock_services.MockEC2InterfaceMockRecorder, asgSvc *mock_services.MockASGInterfaceMockRecorder) {
+				ec2Svc.LaunchTemplateVersionChangedBeyondUserData("lt-1", "2", "3").Return(true, nil)
+				asgSvc.CanStartASGInstanceRefresh(gomock.Any()).Return(true, nil)
+				asgSvc.StartASGInstanceRefresh(gomock.Any()).Return("refresh-1", nil)
+			},
+			wantRefresh: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			ec2Svc := mock_services.NewMockEC2Interface(mockCtrl)
+			asgSvc := mock_services.NewMockASGInterface(mockCtrl)
+			if tt.expect != nil {
+				tt.expect(ec2Svc.EXPECT(), asgSvc.EXPECT())
+			}
+			recorder := record.NewFakeRecorder(2)
+			reconciler := AWSMachinePoolReconciler{Recorder: recorder}
+			machinePoolScope := &scope.MachinePoolScope{
+				Logger:         *logger.NewLogger(logr.Discard()),
+				MachinePool:    &expclusterv1.MachinePool{},
+				AWSMachinePool: &expinfrav1.AWSMachinePool{Spec: tt.spec, Status: tt.status},
+			}
+
+			existingASG := asg
+			if tt.protected {
+				existingASG = protectedASG
+			}
+			g.Expect(reconciler.reconcileOutdatedInstances(machinePoolScope, ec2Svc, asgSvc, existingASG)).To(Succeed())
+			if tt.wantRefresh {
+				g.Expect(recorder.Events).To(Receive(ContainSubstring("InstanceRefreshStarted")))
+				g.Expect(machinePoolScope.AWSMachinePool.Status.InstanceRefreshLaunchTemplateVersion).To(Equal(tt.status.LaunchTemplateVersion))
+			} else {
+				g.Expect(recorder.Events).NotTo(Receive())
+			}
+		})
+	}
+}
+
+func TestRecordPreviousLaunchTemplateVersion(t *testing.T) {
+	tests := []struct {
+		name         string
+		spec         expinfrav1.AWSMachinePoolSpec
+		status       expinfrav1.AWSMachinePoolStatus
+		rolledOut    bool
+		wantPrevious *string
+	}{
+		{
+			name:         "should record the version replaced by a change rolled out to the instances",
+			status:       expinfrav1.AWSMachinePoolStatus{LaunchTemplateID: "lt-1", LaunchTemplateVersion: ptr.To[string]("5")},
+			rolledOut:    true,
+			wantPrevious: ptr.To[string]("4"),
+		},
+		{
+			name:         "should keep the recorded version after a change of the user data only",
+			status:       expinfrav1.AWSMachinePoolStatus{LaunchTemplateID: "lt-1", LaunchTemplateVersion: ptr.To[string]("5"), PreviousLaunchTemplateVersion: ptr.To[string]("3")},
+			wantPrevious: ptr.To[string]("3"),
+		},
+		{
+			name:      "should forget the recorded version of a recreated launch template",
+			status:    expinfrav1.AWSMachinePoolStatus{LaunchTemplateID: "lt-2", LaunchTemplateVersion: ptr.To[string]("1"), PreviousLaunchTemplateVersion: ptr.To[string]("3")},
+			rolledOut: true,
+		},
+		{
+			name:      "should not record the version of a referenced launch template",
+			spec:      expinfrav1.AWSMachinePoolSpec{AWSLaunchTemplate: expinfrav1.AWSLaunchTemplate{Ref: &expinfrav1.LaunchTemplateReference{ID: ptr.To[string]("lt-1")}}},
+			status:    expinfrav1.AWSMachinePoolStatus{LaunchTemplateID: "lt-1", LaunchTemplateVersion: ptr.To[string]("5")},
+			rolledOut: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			machinePoolScope := &scope.MachinePoolScope{
+				AWSMachinePool: &expinfrav1.AWSMachinePool{Spec: tt.spec, Status: tt.status},
+			}
+
+			recordPreviousLaunchTemplateVersion(machinePoolScope, "lt-1", "4", tt.rolledOut)
+			g.Expect(machinePoolScope.AWSMachinePool.Status.PreviousLaunchTemplateVersion).To(Equal(tt.wantPrevious))
+		})
+	}
+}
+
+func TestPickScaleInInstances(t *testing.T) {
+	instances := []infrav1.Instance{
+		{ID: "i-1", State: autoscaling.LifecycleStateInService, AvailabilityZone: "us-east-1a"},
+		{ID: "i-2", State: autoscaling.LifecycleStateInService, AvailabilityZone: "us-east-1a"},
+		{ID: "i-3", State: autoscaling.LifecycleStateInService, AvailabilityZone: "us-east-1b"},
+		{ID: "i-4", State: autoscaling.LifecycleStatePending, AvailabilityZone: "us-east-1a"},
+		{ID: "i-5", State: autoscaling.LifecycleStateStandby, AvailabilityZone: "us-east-1b"},
+	}
+	machinesOf := func(deleting string, ids ...string) map[string]*clusterv1.Machine {
+		machines := map[string]*clusterv1.Machine{}
+		for _, id := range ids {
+			machines[id] = &clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{Name: "machine-" + id}}
+			if id == deleting {
+				machines[id].DeletionTimestamp = ptr.To(metav1.Now())
+			}
+		}
+		return machines
+	}
+
+	tests := []struct {
+		name            string
+		replicas        int32
+		desiredCapacity int32
+		machines        map[string]*clusterv1.Machine
+		marked          []string
+		want            []string
+	}{
+		{
+			name:            "should not pick instances without scale-in",
+			replicas:        4,
+			desiredCapacity: 4,
+			machines:        machinesOf("", "i-1", "i-2", "i-3", "i-4"),
+			want:            []string{},
+		},
+		{
+			name:            "should pick the instances from the largest availability zone, the ones not in service first",
+			replicas:        2,
+			desiredCapacity: 4,
+			machines:        machinesOf("", "i-1", "i-2", "i-3", "i-4"),
+			want:            []string{"i-4", "i-1"},
+		},
+		{
+			name:            "should pick the marked instances first",
+			replicas:        2,
+			desiredCapacity: 4,
+			machines:        machinesOf("", "i-1", "i-2", "i-3", "i-4"),
+			marked:          []string{"i-9", "i-3"},
+			want:            []string{"i-3", "i-4"},
+		},
+		{
+			name:            "should pick the instances whose Machine is already deleted first",
+			replicas:        3,
+			desiredCapacity: 4,
+			machines:        machinesOf("i-2", "i-1", "i-2", "i-3", "i-4"),
+			marked:          []string{"i-3"},
+			want:            []string{"i-2"},
+		},
+		{
+			name:            "should not decrease the desired capacity beyond the launched instances",
+			replicas:        3,
+			desiredCapacity: 6,
+			machines:        machinesOf("", "i-1", "i-2", "i-3", "i-4"),
+			want:            []string{"i-4"},
+		},
+		{
+			name:            "should skip the instances without a Machine",
+			replicas:        2,
+			desiredCapacity: 4,
+			machines:        machinesOf("", "i-1", "i-3"),
+			want:            []string{"i-1", "i-3"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			machinePoolScope := &scope.MachinePoolScope{
+				MachinePool:    &expclusterv1.MachinePool{Spec: expclusterv1.MachinePoolSpec{Replicas: ptr.To(tt.replicas)}},
+				AWSMachinePool: &expinfrav1.AWSMachinePool{},
+			}
+			existingASG := &expinfrav1.AutoScalingGroup{DesiredCapacity: ptr.To(tt.desiredCapacity), Instances: instances}
+
+			g.Expect(pickScaleInInstances(machinePoolScope, existingASG, tt.machines, tt.marked)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestReconcileScaleInDrain(t *testing.T) {
+	instances := []infrav1.Instance{
+		{ID: "i-1", State: autoscaling.LifecycleStateInService, AvailabilityZone: "us-east-1a"},
+		{ID: "i-2", State: autoscaling.LifecycleStateInService, AvailabilityZone: "us-east-1b"},
+	}
+	mp := &expclusterv1.MachinePool{
+		ObjectMeta: metav1.ObjectMeta{Name: "mp", Namespace: "default"},
+		Spec:       expclusterv1.MachinePoolSpec{ClusterName: "test"},
+	}
+	machineObjects := func(name, instanceID string, deleting bool) []client.Object {
+		machine := &clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}
+		if deleting {
+			machine.Finalizers = []string{clusterv1.MachineFinalizer}
+			machine.DeletionTimestamp = ptr.To(metav1.Now())
+		}
+		awsMachine := &infrav1.AWSMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Labels:    machinePoolMachineLabels(mp),
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: clusterv1.GroupVersion.String(),
+					Kind:       "Machine",
+					Name:       name,
+				}},
+			},
+			Spec: infrav1.AWSMachineSpec{InstanceID: ptr.To(instanceID)},
+		}
+		return []client.Object{machine, awsMachine}
+	}
+
+	tests := []struct {
+		name         string
+		disabled     bool
+		replicas     int32
+		deleting     bool
+		expect       func(m *mock_services.MockASGInterfaceMockRecorder)
+		wantErr      bool
+		wantMachines []string
+		wantDesired  int32
+	}{
+		{
+			name:     "should delete the Machines and detach the instances removed by the scale-in",
+			replicas: 1,
+			expect: func(m *mock_services.MockASGInterfaceMockRecorder) {
+				m.DetachInstances("test", []string{"i-1"}).Return(nil)
+			},
+			wantMachines: []string{"machine-2"},
+			wantDesired:  1,
+		},
+		{
+			name:         "should not remove instances without scale-in",
+			replicas:     2,
+			wantMachines: []string{"machine-1", "machine-2"},
+			wantDesired:  2,
+		},
+		{
+			name:         "should not remove instances with the MachinePoolMachines feature gate disabled",
+			disabled:     true,
+			replicas:     1,
+			wantMachines: []string{"machine-1", "machine-2"},
+			wantDesired:  2,
+		},
+		{
+			name:     "should retry the detachment of the instances whose Machines are already deleted",
+			replicas: 1,
+			deleting: true,
+			expect: func(m *mock_services.MockASGInterfaceMockRecorder) {
+				m.DetachInstances("test", []string{"i-2"}).Return(errors.New("ValidationError"))
+			},
+			wantErr:      true,
+			wantMachines: []string{"machine-1", "machine-2"},
+			wantDesired:  2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.MachinePoolMachines, !tt.disabled)()
+			mockCtrl := gomock.NewController(t)
+			asgSvc := mock_services.NewMockASGInterface(mockCtrl)
+			if tt.expect != nil {
+				tt.expect(asgSvc.EXPECT())
+			}
+
+			scheme := runtime.NewScheme()
+			g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+			g.Expect(clusterv1.AddToScheme(scheme)).To(Succeed())
+			objects := append(machineObjects("machine-1", "i-1", false), machineObjects("machine-2", "i-2", tt.deleting)...)
+			kubeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+			machinePool := mp.DeepCopy()
+			machinePool.Spec.Replicas = ptr.To(tt.replicas)
+			machinePoolScope := &scope.MachinePoolScope{
+				MachinePool: machinePool,
+				AWSMachinePool: &expinfrav1.AWSMachinePool{
+					Spec: expinfrav1.AWSMachinePoolSpec{ScaleInDrainPolicy: &expinfrav1.ScaleInDrainPolicy{}},
 				},
-				existingASG: &expinfrav1.AutoScalingGroup{
-					DesiredCapacity:   ptr.To[int32](1),
-					MaxSize:           2,
-					MinSize:           0,
-					CapacityRebalance: false,
+			}
+			existingASG := &expinfrav1.AutoScalingGroup{Name: "test", DesiredCapacity: ptr.To[int32](2), Instances: append([]infrav1.Instance{}, instances...)}
+			reconciler := &AWSMachinePoolReconciler{Client: kubeClient, Recorder: record.NewFakeRecorder(10)}
+
+			err := reconciler.reconcileScaleInDrain(context.TODO(), machinePoolScope, asgSvc, existingASG, nil)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+			machineList := &clusterv1.MachineList{}
+			g.Expect(kubeClient.List(context.TODO(), machineList)).To(Succeed())
+			machines := []string{}
+			for _, machine := range machineList.Items {
+				machines = append(machines, machine.Name)
+			}
+			g.Expect(machines).To(ConsistOf(tt.wantMachines))
+			g.Expect(existingASG.DesiredCapacity).To(Equal(ptr.To(tt.wantDesired)))
+		})
+	}
+}
+
+func TestReconcileDeleteMachineAnnotation(t *testing.T) {
+	instances := []infrav1.Instance{
+		{ID: "i-1", State: autoscaling.LifecycleStateInService},
+		{ID: "i-2", State: autoscaling.LifecycleStateInService},
+		{ID: "i-3", State: autoscaling.LifecycleStateInService},
+	}
+	mp := &expclusterv1.MachinePool{
+		ObjectMeta: metav1.ObjectMeta{Name: "mp", Namespace: "default"},
+		Spec:       expclusterv1.MachinePoolSpec{ClusterName: "test"},
+	}
+	now := time.Now()
+	machineObjects := func(name, instanceID string, created time.Time, marked bool) []client.Object {
+		machine := &clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", CreationTimestamp: metav1.NewTime(created)}}
+		if marked {
+			machine.Annotations = map[string]string{clusterv1.DeleteMachineAnnotation: ""}
+		}
+		awsMachine := &infrav1.AWSMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Labels:    machinePoolMachineLabels(mp),
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: clusterv1.GroupVersion.String(),
+					Kind:       "Machine",
+					Name:       name,
+				}},
+			},
+			Spec: infrav1.AWSMachineSpec{InstanceID: ptr.To(instanceID)},
+		}
+		return []client.Object{machine, awsMachine}
+	}
+	objects := append(machineObjects("new", "i-2", now, true), machineObjects("old", "i-1", now.Add(-time.Hour), true)...)
+	objects = append(objects, machineObjects("kept", "i-3", now, false)...)
+	objects = append(objects, machineObjects("gone", "i-9", now.Add(-2*time.Hour), true)...)
+
+	tests := []struct {
+		name        string
+		replicas    int32
+		annotations map[string]string
+		policy      *expinfrav1.ScaleInDrainPolicy
+		expect      func(m *mock_services.MockASGInterfaceMockRecorder)
+		wantMarked  []string
+		wantDesired int32
+	}{
+		{
+			name:     "should terminate the marked instances of the oldest Machines up to the scale-in",
+			replicas: 2,
+			expect: func(m *mock_services.MockASGInterfaceMockRecorder) {
+				m.TerminateInstanceInASG("i-1", true).Return(nil)
+			},
+			wantDesired: 2,
+		},
+		{
+			name:     "should terminate all the marked instances",
+			replicas: 1,
+			expect: func(m *mock_services.MockASGInterfaceMockRecorder) {
+				m.TerminateInstanceInASG("i-1", true).Return(nil)
+				m.TerminateInstanceInASG("i-2", true).Return(nil)
+			},
+			wantDesired: 1,
+		},
+		{
+			name:        "should not terminate instances without scale-in",
+			replicas:    3,
+			wantDesired: 3,
+		},
+		{
+			name:        "should not touch the capacity managed by an external autoscaler",
+			replicas:    1,
+			annotations: map[string]string{clusterv1.ReplicasManagedByAnnotation: ""},
+			wantDesired: 3,
+		},
+		{
+			name:        "should leave the marked instances to the scale-in drain",
+			replicas:    2,
+			policy:      &expinfrav1.ScaleInDrainPolicy{},
+			wantMarked:  []string{"i-1", "i-2"},
+			wantDesired: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.MachinePoolMachines, true)()
+			mockCtrl := gomock.NewController(t)
+			asgSvc := mock_services.NewMockASGInterface(mockCtrl)
+			if tt.expect != nil {
+				tt.expect(asgSvc.EXPECT())
+			}
+
+			scheme := runtime.NewScheme()
+			g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+			g.Expect(clusterv1.AddToScheme(scheme)).To(Succeed())
+			machinePool := mp.DeepCopy()
+			machinePool.Annotations = tt.annotations
+			machinePool.Spec.Replicas = ptr.To(tt.replicas)
+			machinePoolScope := &scope.MachinePoolScope{
+				MachinePool: machinePool,
+				AWSMachinePool: &expinfrav1.AWSMachinePool{
+					Spec: expinfrav1.AWSMachinePoolSpec{ScaleInDrainPolicy: tt.policy},
 				},
+			}
+			existingASG := &expinfrav1.AutoScalingGroup{Name: "test", DesiredCapacity: ptr.To[int32](3), Instances: append([]infrav1.Instance{}, instances...)}
+			reconciler := &AWSMachinePoolReconciler{
+				Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build(),
+				Recorder: record.NewFakeRecorder(10),
+			}
+
+			marked, err := reconciler.reconcileDeleteMachineAnnotation(context.TODO(), machinePoolScope, asgSvc, existingASG)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(marked).To(Equal(tt.wantMarked))
+			g.Expect(existingASG.DesiredCapacity).To(Equal(ptr.To(tt.wantDesired)))
+		})
+	}
+}
+
+func TestDriveAZSequentialRefresh(t *testing.T) {
+	existingASG := &expinfrav1.AutoScalingGroup{
+		Name: "pool",
+		Instances: []infrav1.Instance{
+			{ID: "i-a1", AvailabilityZone: "us-east-1a", State: autoscaling.LifecycleStateInService},
+			{ID: "i-b1", AvailabilityZone: "us-east-1b", State: autoscaling.LifecycleStateInService},
+			{ID: "i-c1", AvailabilityZone: "us-east-1c", State: autoscaling.LifecycleStateInService},
+			{ID: "i-d1", AvailabilityZone: "us-east-1d", State: autoscaling.LifecycleStateTerminating},
+		},
+		InstanceDetails: map[string]expinfrav1.ASGInstanceDetails{
+			"i-c1": {ProtectedFromScaleIn: true},
+		},
+	}
+	zones := []string{"us-east-1a", "us-east-1b", "us-east-1c"}
+	azSequential := &expinfrav1.RefreshPreferences{Strategy: ptr.To[string](expinfrav1.RefreshStrategyAZSequential)}
+	startTime := metav1.NewTime(time.Now().Truncate(time.Second))
+
+	tests := []struct {
+		name          string
+		refresh       *expinfrav1.RefreshPreferences
+		azRefresh     expinfrav1.AZSequentialRefresh
+		nodeReady     map[string]bool
+		expect        func(m *mock_services.MockASGInterfaceMockRecorder)
+		wantAZRefresh *expinfrav1.AZSequentialRefresh
+		wantEvents    []string
+	}{
+		{
+			name:      "should protect the instances of the other zones and refresh the first zone",
+			refresh:   azSequential,
+			azRefresh: expinfrav1.AZSequentialRefresh{LaunchTemplateVersion: "3", StartTime: startTime},
+			expect: func(m *mock_services.MockASGInterfaceMockRecorder) {
+				m.CanStartASGInstanceRefresh(gomock.Any()).Return(true, nil)
+				m.SetInstanceProtection("pool", []string{"i-b1"}, true).Return(nil)
+				m.StartASGInstanceRefresh(gomock.Any()).Return("refresh-a", nil)
 			},
-			want: true,
+			wantAZRefresh: &expinfrav1.AZSequentialRefresh{
+				LaunchTemplateVersion: "3", AvailabilityZones: zones, InstanceRefreshID: "refresh-a", ProtectedInstanceIDs: []string{"i-b1"}, StartTime: startTime,
+			},
+			wantEvents: []string{"InstanceRefreshStarted"},
 		},
 		{
-			name: "MixedInstancesPolicy != asg.MixedInstancesPolicy",
-			args: args{
-				machinePoolScope: &scope.MachinePoolScope{
-					MachinePool: &expclusterv1.MachinePool{
-						Spec: expclusterv1.MachinePoolSpec{
-							Replicas: ptr.To[int32](1),
-						},
-					},
-					AWSMachinePool: &expinfrav1.AWSMachinePool{
-						Spec: expinfrav1.AWSMachinePoolSpec{
-							MaxSize:           2,
-							MinSize:           0,
-							CapacityRebalance: true,
-							MixedInstancesPolicy: &expinfrav1.MixedInstancesPolicy{
-								InstancesDistribution: &expinfrav1.InstancesDistribution{
-									OnDemandAllocationStrategy: expinfrav1.OnDemandAllocationStrategyPrioritized,
-								},
-								Overrides: nil,
-							},
-						},
-					},
-					Logger: *logger.NewLogger(logr.Discard()),
-				},
-				existingASG: &expinfrav1.AutoScalingGroup{
-					DesiredCapacity:      ptr.To[int32](1),
-					MaxSize:              2,
-					MinSize:              0,
-					CapacityRebalance:    true,
-					MixedInstancesPolicy: &expinfrav1.MixedInstancesPolicy{},
-				},
+			name:    "should wait for the instance refresh of the zone",
+			refresh: azSequential,
+			azRefresh: expinfrav1.AZSequentialRefresh{
+				LaunchTemplateVersion: "3", AvailabilityZones: zones, InstanceRefreshID: "refresh-a", ProtectedInstanceIDs: []string{"i-b1"}, StartTime: startTime,
+			},
+			expect: func(m *mock_services.MockASGInterfaceMockRecorder) {
+				m.DescribeLatestInstanceRefresh("pool").Return(&expinfrav1.InstanceRefreshStatus{ID: "refresh-a", State: autoscaling.InstanceRefreshStatusInProgress}, nil)
+			},
+			wantAZRefresh: &expinfrav1.AZSequentialRefresh{
+				LaunchTemplateVersion: "3", AvailabilityZones: zones, InstanceRefreshID: "refresh-a", ProtectedInstanceIDs: []string{"i-b1"}, StartTime: startTime,
 			},
-			want: true,
 		},
 		{
-			name: "MixedInstancesPolicy.InstancesDistribution != asg.MixedInstancesPolicy.InstancesDistribution",
-			args: args{
-				machinePoolScope: &scope.MachinePoolScope{
-					MachinePool: &expclusterv1.MachinePool{
-						Spec: expclusterv1.MachinePoolSpec{
-							Replicas: ptr.To[int32](1),
-						},
-					},
-					AWSMachinePool: &expinfrav1.AWSMachinePool{
-						Spec: expinfrav1.AWSMachinePoolSpec{
-							MaxSize:           2,
-							MinSize:           0,
-							CapacityRebalance: true,
-							MixedInstancesPolicy: &expinfrav1.MixedInstancesPolicy{
-								InstancesDistribution: &expinfrav1.InstancesDistribution{
-									OnDemandAllocationStrategy:          expinfrav1.OnDemandAllocationStrategyPrioritized,
-									SpotAllocationStrategy:              expinfrav1.SpotAllocationStrategyCapacityOptimized,
-									OnDemandBaseCapacity:                aws.Int64(0),
-									OnDemandPercentageAboveBaseCapacity: aws.Int64(100),
-								},
-								Overrides: []expinfrav1.Overrides{
-									{
-										InstanceType: "m6a.32xlarge",
-									},
-								},
-							},
-						},
-					},
-					Logger: *logger.NewLogger(logr.Discard()),
-				},
-				existingASG: &expinfrav1.AutoScalingGroup{
-					DesiredCapacity:   ptr.To[int32](1),
-					MaxSize:           2,
-					MinSize:           0,
-					CapacityRebalance: true,
-					MixedInstancesPolicy: &expinfrav1.MixedInstancesPolicy{
-						InstancesDistribution: &expinfrav1.InstancesDistribution{
-							OnDemandAllocationStrategy:          expinfrav1.OnDemandAllocationStrategyPrioritized,
-							SpotAllocationStrategy:              expinfrav1.SpotAllocationStrategyLowestPrice,
-							OnDemandBaseCapacity:                aws.Int64(0),
-							OnDemandPercentageAboveBaseCapacity: aws.Int64(100),
-						},
-						Overrides: []expinfrav1.Overrides{
-							{
-								InstanceType: "m6a.32xlarge",
-							},
-						},
-					},
-				},
+			name:    "should wait for the nodes of the refreshed zone to be Ready",
+			refresh: azSequential,
+			azRefresh: expinfrav1.AZSequentialRefresh{
+				LaunchTemplateVersion: "3", AvailabilityZones: zones, InstanceRefreshID: "refresh-a", ProtectedInstanceIDs: []string{"i-b1"}, StartTime: startTime,
+			},
+			nodeReady: map[string]bool{"i-a1": false},
+			expect: func(m *mock_services.MockASGInterfaceMockRecorder) {
+				m.DescribeLatestInstanceRefresh("pool").Return(&expinfrav1.InstanceRefreshStatus{ID: "refresh-a", State: autoscaling.InstanceRefreshStatusSuccessful}, nil)
+			},
+			wantAZRefresh: &expinfrav1.AZSequentialRefresh{
+				LaunchTemplateVersion: "3", AvailabilityZones: zones, InstanceRefreshID: "refresh-a", ProtectedInstanceIDs: []string{"i-b1"}, StartTime: startTime,
 			},
-			want: true,
 		},
 		{
-			name: "MixedInstancesPolicy.InstancesDistribution unset",
-			args: args{
-				machinePoolScope: &scope.MachinePoolScope{
-					MachinePool: &expclusterv1.MachinePool{
-						Spec: expclusterv1.MachinePoolSpec{
-							Replicas: ptr.To[int32](1),
-						},
-					},
-					AWSMachinePool: &expinfrav1.AWSMachinePool{
-						Spec: expinfrav1.AWSMachinePoolSpec{
-							MaxSize:           2,
-							MinSize:           0,
-							CapacityRebalance: true,
-							MixedInstancesPolicy: &expinfrav1.MixedInstancesPolicy{
-								Overrides: []expinfrav1.Overrides{
-									{
-										InstanceType: "m6a.32xlarge",
-									},
-								},
-							},
-						},
-					},
-					Logger: *logger.NewLogger(logr.Discard()),
-				},
-				existingASG: &expinfrav1.AutoScalingGroup{
-					DesiredCapacity:   ptr.To[int32](1),
-					MaxSize:           2,
-					MinSize:           0,
-					CapacityRebalance: true,
-					MixedInstancesPolicy: &expinfrav1.MixedInstancesPolicy{
-						InstancesDistribution: &expinfrav1.InstancesDistribution{
-							OnDemandAllocationStrategy:          expinfrav1.OnDemandAllocationStrategyPrioritized,
-							SpotAllocationStrategy:              expinfrav1.SpotAllocationStrategyLowestPrice,
-							OnDemandBaseCapacity:                aws.Int64(0),
-							OnDemandPercentageAboveBaseCapacity: aws.Int64(100),
-						},
-						Overrides: []expinfrav1.Overrides{
-							{
-								InstanceType: "m6a.32xlarge",
-							},
-						},
-					},
-				},
+			name:    "should remove the protection and refresh the next zone once the nodes are Ready",
+			refresh: azSequential,
+			azRefresh: expinfrav1.AZSequentialRefresh{
+				LaunchTemplateVersion: "3", AvailabilityZones: zones, InstanceRefreshID: "refresh-a", ProtectedInstanceIDs: []string{"i-b1"}, StartTime: startTime,
 			},
-			want: false,
+			nodeReady: map[string]bool{"i-a1": true},
+			expect: func(m *mock_services.MockASGInterfaceMockRecorder) {
+				m.DescribeLatestInstanceRefresh("pool").Return(&expinfrav1.InstanceRefreshStatus{ID: "refresh-a", State: autoscaling.InstanceRefreshStatusSuccessful}, nil)
+				m.SetInstanceProtection("pool", []string{"i-b1"}, false).Return(nil)
+				m.CanStartASGInstanceRefresh(gomock.Any()).Return(true, nil)
+				m.SetInstanceProtection("pool", []string{"i-a1"}, true).Return(nil)
+				m.StartASGInstanceRefresh(gomock.Any()).Return("refresh-b", nil)
+			},
+			wantAZRefresh: &expinfrav1.AZSequentialRefresh{
+				LaunchTemplateVersion: "3", AvailabilityZones: zones, Current: 1, InstanceRefreshID: "refresh-b", ProtectedInstanceIDs: []string{"i-a1"}, StartTime: startTime,
+			},
+			wantEvents: []string{"AZRefreshed", "InstanceRefreshStarted"},
 		},
 		{
-			name: "SuspendProcesses != asg.SuspendProcesses",
-			args: args{
-				machinePoolScope: &scope.MachinePoolScope{
-					MachinePool: &expclusterv1.MachinePool{
-						Spec: expclusterv1.MachinePoolSpec{
-							Replicas: ptr.To[int32](1),
-						},
-					},
-					AWSMachinePool: &expinfrav1.AWSMachinePool{
-						Spec: expinfrav1.AWSMachinePoolSpec{
-							MaxSize:           2,
-							MinSize:           0,
-							CapacityRebalance: true,
-							MixedInstancesPolicy: &expinfrav1.MixedInstancesPolicy{
-								InstancesDistribution: &expinfrav1.InstancesDistribution{
-									OnDemandAllocationStrategy: expinfrav1.OnDemandAllocationStrategyPrioritized,
-								},
-								Overrides: nil,
-							},
-							SuspendProcesses: &expinfrav1.SuspendProcessesTypes{
-								Processes: &expinfrav1.Processes{
-									Launch:    ptr.To[bool](true),
-									Terminate: ptr.To[bool](true),
-								},
-							},
-						},
-					},
-					Logger: *logger.NewLogger(logr.Discard()),
-				},
-				existingASG: &expinfrav1.AutoScalingGroup{
-					DesiredCapacity:           ptr.To[int32](1),
-					MaxSize:                   2,
-					MinSize:                   0,
-					CapacityRebalance:         true,
-					MixedInstancesPolicy:      &expinfrav1.MixedInstancesPolicy{},
-					CurrentlySuspendProcesses: []string{"Launch", "Terminate"},
-				},
+			name:    "should forget the refresh once the last zone is refreshed",
+			refresh: azSequential,
+			azRefresh: expinfrav1.AZSequentialRefresh{
+				LaunchTemplateVersion: "3", AvailabilityZones: zones, Current: 2, InstanceRefreshID: "refresh-c", ProtectedInstanceIDs: []string{"i-a1", "i-b1"}, StartTime: startTime,
 			},
-			want: true,
+			nodeReady: map[string]bool{"i-c1": true},
+			expect: func(m *mock_services.MockASGInterfaceMockRecorder) {
+				m.DescribeLatestInstanceRefresh("pool").Return(&expinfrav1.InstanceRefreshStatus{ID: "refresh-c", State: autoscaling.InstanceRefreshStatusSuccessful}, nil)
+				m.SetInstanceProtection("pool", []string{"i-a1", "i-b1"}, false).Return(nil)
+			},
+			wantEvents: []string{"AZRefreshed", "AZSequentialRefreshCompleted"},
 		},
 		{
-			name: "all matches",
-			args: args{
-				machinePoolScope: &scope.MachinePoolScope{
-					MachinePool: &expclusterv1.MachinePool{
-						Spec: expclusterv1.MachinePoolSpec{
-							Replicas: ptr.To[int32](1),
-						},
-					},
-					AWSMachinePool: &expinfrav1.AWSMachinePool{
-						Spec: expinfrav1.AWSMachinePoolSpec{
-							MaxSize:           2,
-							MinSize:           0,
-							CapacityRebalance: true,
-							MixedInstancesPolicy: &expinfrav1.MixedInstancesPolicy{
-								InstancesDistribution: &expinfrav1.InstancesDistribution{
-									OnDemandAllocationStrategy: expinfrav1.OnDemandAllocationStrategyPrioritized,
-								},
-								Overrides: nil,
-							},
-						},
-					},
-				},
-				existingASG: &expinfrav1.AutoScalingGroup{
-					DesiredCapacity:   ptr.To[int32](1),
-					MaxSize:           2,
-					MinSize:           0,
-					CapacityRebalance: true,
-					MixedInstancesPolicy: &expinfrav1.MixedInstancesPolicy{
-						InstancesDistribution: &expinfrav1.InstancesDistribution{
-							OnDemandAllocationStrategy: expinfrav1.OnDemandAllocationStrategyPrioritized,
-						},
-						Overrides: nil,
-					},
-				},
+			name:    "should restore the protection and forget the refresh when the instance refresh of the zone failed",
+			refresh: azSequential,
+			azRefresh: expinfrav1.AZSequentialRefresh{
+				LaunchTemplateVersion: "3", AvailabilityZones: zones, InstanceRefreshID: "refresh-a", ProtectedInstanceIDs: []string{"i-b1"}, StartTime: startTime,
 			},
-			want: false,
+			expect: func(m *mock_services.MockASGInterfaceMockRecorder) {
+				m.DescribeLatestInstanceRefresh("pool").Return(&expinfrav1.InstanceRefreshStatus{ID: "refresh-a", State: autoscaling.InstanceRefreshStatusFailed}, nil)
+				m.SetInstanceProtection("pool", []string{"i-b1"}, false).Return(nil)
+			},
+			wantEvents: []string{"AZSequentialRefreshCancelled"},
 		},
 		{
-			name: "externally managed annotation ignores difference between desiredCapacity and replicas",
-			args: args{
-				machinePoolScope: &scope.MachinePoolScope{
-					MachinePool: &expclusterv1.MachinePool{
-						ObjectMeta: metav1.ObjectMeta{
-							Annotations: map[string]string{
-								clusterv1.ReplicasManagedByAnnotation: "", // empty value counts as true (= externally managed)
-							},
-						},
-						Spec: expclusterv1.MachinePoolSpec{
-							Replicas: ptr.To[int32](0),
-						},
-					},
-					AWSMachinePool: &expinfrav1.AWSMachinePool{
-						Spec: expinfrav1.AWSMachinePoolSpec{},
-					},
-				},
-				existingASG: &expinfrav1.AutoScalingGroup{
-					DesiredCapacity: ptr.To[int32](1),
-				},
+			name:    "should cancel the instance refresh of the zone and restore the protection when the strategy changed",
+			refresh: &expinfrav1.RefreshPreferences{Strategy: ptr.To[string](expinfrav1.RefreshStrategyRolling)},
+			azRefresh: expinfrav1.AZSequentialRefresh{
+				LaunchTemplateVersion: "3", AvailabilityZones: zones, InstanceRefreshID: "refresh-a", ProtectedInstanceIDs: []string{"i-b1"}, StartTime: startTime,
 			},
-			want: false,
+			expect: func(m *mock_services.MockASGInterfaceMockRecorder) {
+				m.DescribeLatestInstanceRefresh("pool").Return(&expinfrav1.InstanceRefreshStatus{ID: "refresh-a", State: autoscaling.InstanceRefreshStatusInProgress}, nil)
+				m.CancelASGInstanceRefresh("pool").Return(nil)
+				m.SetInstanceProtection("pool", []string{"i-b1"}, false).Return(nil)
+			},
+			wantEvents: []string{"AZSequentialRefreshCancelled"},
 		},
 		{
-			name: "without externally managed annotation ignores difference between desiredCapacity and replicas",
-			args: args{
-				machinePoolScope: &scope.MachinePoolScope{
-					MachinePool: &expclusterv1.MachinePool{
-						Spec: expclusterv1.MachinePoolSpec{
-							Replicas: ptr.To[int32](0),
-						},
-					},
-					AWSMachinePool: &expinfrav1.AWSMachinePool{
-						Spec: expinfrav1.AWSMachinePoolSpec{},
-					},
-				},
-				existingASG: &expinfrav1.AutoScalingGroup{
-					DesiredCapacity: ptr.To[int32](1),
-				},
+			name:    "should resume the instance refresh of the zone started before a restart",
+			refresh: azSequential,
+			azRefresh: expinfrav1.AZSequentialRefresh{
+				LaunchTemplateVersion: "3", AvailabilityZones: zones, ProtectedInstanceIDs: []string{"i-b1"}, StartTime: startTime,
+			},
+			expect: func(m *mock_services.MockASGInterfaceMockRecorder) {
+				m.CanStartASGInstanceRefresh(gomock.Any()).Return(false, nil)
+				m.DescribeLatestInstanceRefresh("pool").Return(&expinfrav1.InstanceRefreshStatus{ID: "refresh-a", State: autoscaling.InstanceRefreshStatusInProgress}, nil)
+			},
+			wantAZRefresh: &expinfrav1.AZSequentialRefresh{
+				LaunchTemplateVersion: "3", AvailabilityZones: zones, InstanceRefreshID: "refresh-a", ProtectedInstanceIDs: []string{"i-b1"}, StartTime: startTime,
 			},
-			want: true,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			g := NewWithT(t)
-			g.Expect(diffASG(tt.args.machinePoolScope, tt.args.existingASG) != "").To(Equal(tt.want))
+			mockCtrl := gomock.NewController(t)
+			asgSvc := mock_services.NewMockASGInterface(mockCtrl)
+			tt.expect(asgSvc.EXPECT())
+
+			scheme := runtime.NewScheme()
+			g.Expect(expinfrav1.AddToScheme(scheme)).To(Succeed())
+			g.Expect(expclusterv1.AddToScheme(scheme)).To(Succeed())
+			awsMachinePool := &expinfrav1.AWSMachinePool{
+				ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default"},
+				Spec:       expinfrav1.AWSMachinePoolSpec{RefreshPreferences: tt.refresh},
+			}
+			machinePool := &expclusterv1.MachinePool{ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default"}}
+			cs, err := setupCluster("test-cluster")
+			g.Expect(err).ToNot(HaveOccurred())
+			machinePoolScope, err := scope.NewMachinePoolScope(scope.MachinePoolScopeParams{
+				Client:         fake.NewClientBuilder().WithScheme(scheme).WithObjects(awsMachinePool, machinePool).WithStatusSubresource(awsMachinePool).Build(),
+				Cluster:        &clusterv1.Cluster{},
+				MachinePool:    machinePool,
+				InfraCluster:   cs,
+				AWSMachinePool: awsMachinePool,
+			})
+			g.Expect(err).ToNot(HaveOccurred())
+			azRefresh := tt.azRefresh
+			awsMachinePool.Status.AZSequentialRefresh = &azRefresh
+			recorder := record.NewFakeRecorder(10)
+			reconciler := &AWSMachinePoolReconciler{Recorder: recorder}
+
+			err = reconciler.driveAZSequentialRefresh(machinePoolScope, asgSvc, existingASG, func(instanceIDs []string) (map[string]bool, error) {
+				return tt.nodeReady, nil
+			})
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(awsMachinePool.Status.AZSequentialRefresh).To(Equal(tt.wantAZRefresh))
+			for _, event := range tt.wantEvents {
+				g.Expect(recorder.Events).To(Receive(ContainSubstring(event)))
+			}
+			g.Expect(recorder.Events).NotTo(Receive())
 		})
 	}
 }
@@ -0,0 +1,210 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/feature"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/ec2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/predicates"
+)
+
+// AWSMachinePoolMachinesReconciler maintains the AWSMachines of the instances of AWSMachinePools. It is separate from
+// the AWSMachinePoolReconciler, which maintains the provider ID list and the instance statuses it reacts to, so that
+// the changes of the AWSMachines don't requeue the reconciliation of the ASGs.
+type AWSMachinePoolMachinesReconciler struct {
+	client.Client
+	Recorder                     record.EventRecorder
+	WatchFilterValue             string
+	ec2ServiceFactory            func(scope.EC2Scope) services.EC2Interface
+	TagUnmanagedNetworkResources bool
+	OwnershipTagPrefix           string
+}
+
+func (r *AWSMachinePoolMachinesReconciler) getEC2Service(scope scope.EC2Scope) services.EC2Interface {
+	if r.ec2ServiceFactory != nil {
+		return r.ec2ServiceFactory(scope)
+	}
+
+	return ec2.NewService(scope)
+}
+
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsmachinepools,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsmachinepools/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machinepools,verbs=get;list;watch
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machines,verbs=get;list;watch;patch;delete
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsmachines,verbs=get;list;watch;create;patch;delete
+// +kubebuilder:rbac:groups="",resources=events,verbs=get;list;watch;create;update;patch
+
+// Reconcile creates and deletes the AWSMachines of the instances of an AWSMachinePool.
+func (r *AWSMachinePoolMachinesReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	log := logger.FromContext(ctx)
+
+	awsMachinePool := &expinfrav1.AWSMachinePool{}
+	if err := r.Get(ctx, req.NamespacedName, awsMachinePool); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	// The AWSMachines are owned by the AWSMachinePool, and garbage collected with it.
+	if !awsMachinePool.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	machinePool, err := getOwnerMachinePool(ctx, r.Client, awsMachinePool.ObjectMeta)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if machinePool == nil {
+		log.Info("MachinePool Controller has not yet set OwnerRef")
+		return ctrl.Result{}, nil
+	}
+	log = log.WithValues("machinePool", klog.KObj(machinePool))
+
+	cluster, err := util.GetClusterFromMetadata(ctx, r.Client, machinePool.ObjectMeta)
+	if err != nil {
+		log.Info("MachinePool is missing cluster label or cluster does not exist")
+		return ctrl.Result{}, nil
+	}
+
+	if annotations.IsPaused(cluster, awsMachinePool) {
+		log.Info("AWSMachinePool or linked Cluster is marked as paused. Won't reconcile")
+		return ctrl.Result{}, nil
+	}
+
+	log = log.WithValues("cluster", klog.KObj(cluster))
+
+	infraCluster, err := getInfraCluster(ctx, r.Client, log, cluster, awsMachinePool, r.TagUnmanagedNetworkResources, r.OwnershipTagPrefix)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("getting infra provider cluster or control plane object: %w", err)
+	}
+	if infraCluster == nil {
+		log.Info("AWSCluster or AWSManagedControlPlane is not ready yet")
+		return ctrl.Result{}, nil
+	}
+
+	machinePoolScope, err := scope.NewMachinePoolScope(scope.MachinePoolScopeParams{
+		Client:         r.Client,
+		Logger:         log,
+		Cluster:        cluster,
+		MachinePool:    machinePool,
+		InfraCluster:   infraCluster,
+		AWSMachinePool: awsMachinePool,
+	})
+	if err != nil {
+		log.Error(err, "failed to create scope")
+		return ctrl.Result{}, err
+	}
+
+	defer func() {
+		if err := machinePoolScope.Close(); err != nil && reterr == nil {
+			reterr = err
+		}
+	}()
+
+	return ctrl.Result{}, r.reconcileMachinePoolMachines(ctx, machinePoolScope, r.getEC2Service(infraCluster))
+}
+
+// SetupWithManager is used to setup the controller.
+func (r *AWSMachinePoolMachinesReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("awsmachinepoolmachines").
+		WithOptions(options).
+		For(&expinfrav1.AWSMachinePool{}, builder.WithPredicates(
+			predicate.Funcs{
+				// Only the instances of the ASG matter to the AWSMachines, the AWSMachinePoolReconciler reports them
+				// in the provider ID list and the status.
+				UpdateFunc: func(e event.UpdateEvent) bool {
+					oldPool, okOld := e.ObjectOld.(*expinfrav1.AWSMachinePool)
+					newPool, okNew := e.ObjectNew.(*expinfrav1.AWSMachinePool)
+					if !okOld || !okNew {
+						return true
+					}
+
+					return oldPool.Status.Ready != newPool.Status.Ready ||
+						!apiequality.Semantic.DeepEqual(oldPool.Spec.ProviderIDList, newPool.Spec.ProviderIDList) ||
+						!apiequality.Semantic.DeepEqual(oldPool.Status.Instances, newPool.Status.Instances)
+				},
+			},
+		)).
+		Watches(
+			&infrav1.AWSMachine{},
+			handler.EnqueueRequestForOwner(mgr.GetScheme(), mgr.GetRESTMapper(), &expinfrav1.AWSMachinePool{}),
+		).
+		WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(logger.FromContext(ctx).GetLogger(), r.WatchFilterValue)).
+		Complete(r)
+}
+
+// reconcileMachinePoolMachines maintains an AWSMachine for each instance of the ASG when the MachinePoolMachines
+// feature gate is enabled, so that Cluster API creates their Machines.
+func (r *AWSMachinePoolMachinesReconciler) reconcileMachinePoolMachines(ctx context.Context, machinePoolScope *scope.MachinePoolScope, ec2Svc services.EC2Interface) error {
+	awsMachinePool := machinePoolScope.AWSMachinePool
+	if !feature.Gates.Enabled(feature.MachinePoolMachines) {
+		awsMachinePool.Status.InfrastructureMachineKind = ""
+		return nil
+	}
+	// The provider ID list is only maintained while the ASG is ready.
+	if !awsMachinePool.Status.Ready {
+		return nil
+	}
+	awsMachinePool.Status.InfrastructureMachineKind = "AWSMachine"
+
+	awsMachineList, err := getAWSMachines(ctx, machinePoolScope.MachinePool, r.Client)
+	if err != nil {
+		return err
+	}
+
+	gvk := expinfrav1.GroupVersion.WithKind("AWSMachinePool")
+	if err := createAWSMachinesIfNotExists(ctx, awsMachineList, machinePoolScope.MachinePool, awsMachinePool, gvk, awsMachinePool.Spec.ProviderIDList, machinePoolScope.InfraCluster.Region(), machinePoolScope, r.Client, ec2Svc); err != nil {
+		r.Recorder.Eventf(awsMachinePool, corev1.EventTypeWarning, "FailedCreateAWSMachines", "Failed to create AWSMachines of the ASG instances: %v", err)
+		return errors.Wrap(err, "failed to create AWSMachines of the ASG instances")
+	}
+	if err := reconcileTopologyLabels(ctx, awsMachineList, machinePoolScope.InfraCluster.Region(), machinePoolScope, r.Client); err != nil {
+		return errors.Wrap(err, "failed to reconcile the topology labels of the AWSMachines of the ASG instances")
+	}
+	if err := deleteOrphanedAWSMachines(ctx, awsMachineList, awsMachinePool.Spec.ProviderIDList, machinePoolScope, r.Client, ec2Svc); err != nil {
+		r.Recorder.Eventf(awsMachinePool, corev1.EventTypeWarning, "FailedDeleteAWSMachines", "Failed to delete AWSMachines of the instances which left the ASG: %v", err)
+		return errors.Wrap(err, "failed to delete AWSMachines of the instances which left the ASG")
+	}
+
+	return nil
+}
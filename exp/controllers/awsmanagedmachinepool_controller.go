@@ -18,6 +18,7 @@ package controllers
 
 import (
 	"context"
+	"time"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -26,6 +27,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
@@ -34,13 +36,17 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
 	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/feature"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/ec2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/eks"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/securitygroup"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
+	infrautilconditions "sigs.k8s.io/cluster-api-provider-aws/v2/util/conditions"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	expclusterv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util"
@@ -49,6 +55,13 @@ import (
 	"sigs.k8s.io/cluster-api/util/predicates"
 )
 
+// nodegroupDrainRequeueAfter is how often the drain of the nodes of a deleted AWSManagedMachinePool is checked.
+const nodegroupDrainRequeueAfter = 20 * time.Second
+
+// nodegroupUpdateRequeueAfter is how often an update of the node group started by the controller is polled, and how
+// long the controller waits before retrying an update rejected because of another update in progress.
+const nodegroupUpdateRequeueAfter = 30 * time.Second
+
 // AWSManagedMachinePoolReconciler reconciles a AWSManagedMachinePool object.
 type AWSManagedMachinePoolReconciler struct {
 	client.Client
@@ -58,6 +71,9 @@ type AWSManagedMachinePoolReconciler struct {
 	AllowAdditionalRoles         bool
 	WatchFilterValue             string
 	TagUnmanagedNetworkResources bool
+	OwnershipTagPrefix           string
+
+	securityGroupFilterCache *scope.SecurityGroupFilterCache
 }
 
 // SetupWithManager is used to setup the controller.
@@ -69,6 +85,8 @@ func (r *AWSManagedMachinePoolReconciler) SetupWithManager(ctx context.Context,
 		return errors.Wrapf(err, "failed to find GVK for AWSManagedMachinePool")
 	}
 	managedControlPlaneToManagedMachinePoolMap := managedControlPlaneToManagedMachinePoolMapFunc(r.Client, gvk, log)
+	r.securityGroupFilterCache = scope.NewSecurityGroupFilterCache(scope.DefaultSecurityGroupFilterCacheTTL)
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&expinfrav1.AWSManagedMachinePool{}).
 		WithOptions(options).
@@ -89,6 +107,8 @@ func (r *AWSManagedMachinePoolReconciler) SetupWithManager(ctx context.Context,
 // +kubebuilder:rbac:groups=controlplane.cluster.x-k8s.io,resources=awsmanagedcontrolplanes;awsmanagedcontrolplanes/status,verbs=get;list;watch
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsmanagedmachinepools,verbs=get;list;watch;update;patch;delete
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsmanagedmachinepools/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsmachines,verbs=get;list;watch;create;patch;delete
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machines,verbs=get;list;watch;patch;delete
 
 // Reconcile reconciles AWSManagedMachinePools.
 func (r *AWSManagedMachinePoolReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
@@ -122,7 +142,8 @@ func (r *AWSManagedMachinePoolReconciler) Reconcile(ctx context.Context, req ctr
 
 	if annotations.IsPaused(cluster, awsPool) {
 		log.Info("Reconciliation is paused for this object")
-		return ctrl.Result{}, nil
+		return ctrl.Result{}, infrautilconditions.PatchReconciliationSkipped(ctx, r.Client, r.Recorder, awsPool,
+			infrav1.ReconciliationPausedReason, clusterv1.ConditionSeverityInfo, "AWSManagedMachinePool or linked Cluster is marked as paused")
 	}
 
 	log = log.WithValues("cluster", klog.KObj(cluster))
@@ -144,6 +165,7 @@ func (r *AWSManagedMachinePoolReconciler) Reconcile(ctx context.Context, req ctr
 		ControlPlane:                 controlPlane,
 		ControllerName:               "awsManagedControlPlane",
 		TagUnmanagedNetworkResources: r.TagUnmanagedNetworkResources,
+		OwnershipTagPrefix:           r.OwnershipTagPrefix,
 	})
 	if err != nil {
 		return ctrl.Result{}, errors.New("error getting managed control plane scope")
@@ -152,7 +174,8 @@ func (r *AWSManagedMachinePoolReconciler) Reconcile(ctx context.Context, req ctr
 	if !controlPlane.Status.Ready {
 		log.Info("Control plane is not ready yet")
 		conditions.MarkFalse(awsPool, expinfrav1.EKSNodegroupReadyCondition, expinfrav1.WaitingForEKSControlPlaneReason, clusterv1.ConditionSeverityInfo, "")
-		return ctrl.Result{}, nil
+		return ctrl.Result{}, infrautilconditions.PatchReconciliationSkipped(ctx, r.Client, r.Recorder, awsPool,
+			expinfrav1.WaitingForEKSControlPlaneReason, clusterv1.ConditionSeverityInfo, "Control plane is not ready yet")
 	}
 
 	machinePoolScope, err := scope.NewManagedMachinePoolScope(scope.ManagedMachinePoolScopeParams{
@@ -164,8 +187,11 @@ func (r *AWSManagedMachinePoolReconciler) Reconcile(ctx context.Context, req ctr
 		ManagedMachinePool:   awsPool,
 		EnableIAM:            r.EnableIAM,
 		AllowAdditionalRoles: r.AllowAdditionalRoles,
+		OwnershipTagPrefix:   r.OwnershipTagPrefix,
 		Endpoints:            r.Endpoints,
 		InfraCluster:         managedControlPlaneScope,
+
+		SecurityGroupFilterCache: r.securityGroupFilterCache,
 	})
 	if err != nil {
 		return ctrl.Result{}, errors.Wrap(err, "failed to create scope")
@@ -186,10 +212,23 @@ func (r *AWSManagedMachinePoolReconciler) Reconcile(ctx context.Context, req ctr
 	}()
 
 	if !awsPool.ObjectMeta.DeletionTimestamp.IsZero() {
-		return ctrl.Result{}, r.reconcileDelete(ctx, machinePoolScope, managedControlPlaneScope)
+		if scope.DeferDeletion(machinePoolScope, machinePoolScope) {
+			return ctrl.Result{RequeueAfter: scope.ReadOnlyRequeueAfter}, nil
+		}
+		return r.reconcileDelete(ctx, machinePoolScope, managedControlPlaneScope)
 	}
 
-	return ctrl.Result{}, r.reconcileNormal(ctx, machinePoolScope, managedControlPlaneScope)
+	if err := r.reconcileNormal(ctx, machinePoolScope, managedControlPlaneScope); err != nil {
+		if errors.Is(err, eks.ErrNodegroupUpdateInProgress) {
+			machinePoolScope.Info("Nodegroup update in progress, requeueing", "reason", err.Error())
+			return ctrl.Result{RequeueAfter: nodegroupUpdateRequeueAfter}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	if awsPool.Status.ActiveUpdate.InProgress() {
+		return ctrl.Result{RequeueAfter: nodegroupUpdateRequeueAfter}, nil
+	}
+	return ctrl.Result{}, nil
 }
 
 func (r *AWSManagedMachinePoolReconciler) reconcileNormal(
@@ -199,6 +238,8 @@ func (r *AWSManagedMachinePoolReconciler) reconcileNormal(
 ) error {
 	machinePoolScope.Info("Reconciling AWSManagedMachinePool")
 
+	infrautilconditions.ClearReconciliationSkipped(r.Recorder, machinePoolScope.ManagedMachinePool)
+
 	if controllerutil.AddFinalizer(machinePoolScope.ManagedMachinePool, expinfrav1.ManagedMachinePoolFinalizer) {
 		if err := machinePoolScope.PatchObject(); err != nil {
 			return err
@@ -216,6 +257,12 @@ func (r *AWSManagedMachinePoolReconciler) reconcileNormal(
 		runPostLaunchTemplateUpdateOperation := func() error {
 			return nil
 		}
+
+		// The dedicated security group is referenced by the launch template, so it has to exist first.
+		if err := r.reconcileDedicatedSecurityGroup(machinePoolScope, ec2Scope); err != nil {
+			return err
+		}
+
 		if err := reconSvc.ReconcileLaunchTemplate(machinePoolScope, ec2svc, canUpdateLaunchTemplate, runPostLaunchTemplateUpdateOperation); err != nil {
 			r.Recorder.Eventf(machinePoolScope.ManagedMachinePool, corev1.EventTypeWarning, "FailedLaunchTemplateReconcile", "Failed to reconcile launch template: %v", err)
 			machinePoolScope.Error(err, "failed to reconcile launch template")
@@ -240,41 +287,95 @@ func (r *AWSManagedMachinePoolReconciler) reconcileNormal(
 		return errors.Wrapf(err, "failed to reconcile machine pool for AWSManagedMachinePool %s/%s", machinePoolScope.ManagedMachinePool.Namespace, machinePoolScope.ManagedMachinePool.Name)
 	}
 
+	if err := r.reconcileMachinePoolMachines(ctx, machinePoolScope, ec2svc); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// reconcileMachinePoolMachines maintains an AWSMachine for each instance of the node group when the
+// MachinePoolMachines feature gate is enabled, so that Cluster API creates their Machines.
+func (r *AWSManagedMachinePoolReconciler) reconcileMachinePoolMachines(ctx context.Context, machinePoolScope *scope.ManagedMachinePoolScope, ec2svc services.EC2Interface) error {
+	managedPool := machinePoolScope.ManagedMachinePool
+	if !feature.Gates.Enabled(feature.MachinePoolMachines) {
+		managedPool.Status.InfrastructureMachineKind = ""
+		return nil
+	}
+	managedPool.Status.InfrastructureMachineKind = "AWSMachine"
+
+	// The provider ID list is only maintained while the node group is ready.
+	if !managedPool.Status.Ready {
+		return nil
+	}
+
+	awsMachineList, err := getAWSMachines(ctx, machinePoolScope.MachinePool, r.Client)
+	if err != nil {
+		return err
+	}
+
+	gvk, err := apiutil.GVKForObject(managedPool, r.Client.Scheme())
+	if err != nil {
+		return errors.Wrap(err, "failed to find GVK for AWSManagedMachinePool")
+	}
+	if err := createAWSMachinesIfNotExists(ctx, awsMachineList, machinePoolScope.MachinePool, managedPool, gvk, managedPool.Spec.ProviderIDList, machinePoolScope.ControlPlane.Spec.Region, machinePoolScope, r.Client, ec2svc); err != nil {
+		r.Recorder.Eventf(managedPool, corev1.EventTypeWarning, "FailedCreateAWSMachines", "Failed to create AWSMachines of the node group instances: %v", err)
+		return errors.Wrap(err, "failed to create AWSMachines of the node group instances")
+	}
+	if err := reconcileTopologyLabels(ctx, awsMachineList, machinePoolScope.ControlPlane.Spec.Region, machinePoolScope, r.Client); err != nil {
+		return errors.Wrap(err, "failed to reconcile the topology labels of the AWSMachines of the node group instances")
+	}
+	if err := deleteOrphanedAWSMachines(ctx, awsMachineList, managedPool.Spec.ProviderIDList, machinePoolScope, r.Client, ec2svc); err != nil {
+		r.Recorder.Eventf(managedPool, corev1.EventTypeWarning, "FailedDeleteAWSMachines", "Failed to delete AWSMachines of the instances which left the node group: %v", err)
+		return errors.Wrap(err, "failed to delete AWSMachines of the instances which left the node group")
+	}
+
 	return nil
 }
 
 func (r *AWSManagedMachinePoolReconciler) reconcileDelete(
-	_ context.Context,
+	ctx context.Context,
 	machinePoolScope *scope.ManagedMachinePoolScope,
 	ec2Scope scope.EC2Scope,
-) error {
+) (ctrl.Result, error) {
 	machinePoolScope.Info("Reconciling deletion of AWSManagedMachinePool")
 
 	ekssvc := eks.NewNodegroupService(machinePoolScope)
 	ec2Svc := ec2.NewService(ec2Scope)
 
+	drained, err := ekssvc.ReconcilePoolDrain(ctx)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to drain the nodes of AWSManagedMachinePool %s/%s", machinePoolScope.ManagedMachinePool.Namespace, machinePoolScope.ManagedMachinePool.Name)
+	}
+	if !drained {
+		machinePoolScope.Info("Waiting for the nodes of AWSManagedMachinePool to be drained")
+		return ctrl.Result{RequeueAfter: nodegroupDrainRequeueAfter}, nil
+	}
+
 	if err := ekssvc.ReconcilePoolDelete(); err != nil {
-		return errors.Wrapf(err, "failed to reconcile machine pool deletion for AWSManagedMachinePool %s/%s", machinePoolScope.ManagedMachinePool.Namespace, machinePoolScope.ManagedMachinePool.Name)
+		return ctrl.Result{}, errors.Wrapf(err, "failed to reconcile machine pool deletion for AWSManagedMachinePool %s/%s", machinePoolScope.ManagedMachinePool.Namespace, machinePoolScope.ManagedMachinePool.Name)
+	}
+
+	if err := r.deleteDedicatedSecurityGroup(machinePoolScope, ec2Scope); err != nil {
+		return ctrl.Result{}, err
 	}
 
 	if machinePoolScope.ManagedMachinePool.Spec.AWSLaunchTemplate != nil {
-		launchTemplateID := machinePoolScope.ManagedMachinePool.Status.LaunchTemplateID
 		launchTemplate, _, _, err := ec2Svc.GetLaunchTemplate(machinePoolScope.LaunchTemplateName())
 		if err != nil {
-			return err
+			return ctrl.Result{}, err
 		}
 
 		if launchTemplate == nil {
 			machinePoolScope.Debug("Unable to find matching launch template")
 			r.Recorder.Eventf(machinePoolScope.ManagedMachinePool, corev1.EventTypeNormal, "NoLaunchTemplateFound", "Unable to find matching launch template")
 			controllerutil.RemoveFinalizer(machinePoolScope.ManagedMachinePool, expinfrav1.ManagedMachinePoolFinalizer)
-			return nil
+			return ctrl.Result{}, nil
 		}
 
 		machinePoolScope.Info("deleting launch template", "name", launchTemplate.Name)
-		if err := ec2Svc.DeleteLaunchTemplate(*launchTemplateID); err != nil {
-			r.Recorder.Eventf(machinePoolScope.ManagedMachinePool, corev1.EventTypeWarning, "FailedDelete", "Failed to delete launch template %q: %v", launchTemplate.Name, err)
-			return errors.Wrap(err, "failed to delete launch template")
+		if err := deleteLaunchTemplate(ec2Svc, r.Recorder, machinePoolScope.ManagedMachinePool, ptr.Deref(machinePoolScope.ManagedMachinePool.Status.LaunchTemplateID, ""), launchTemplate.Name); err != nil {
+			return ctrl.Result{}, err
 		}
 
 		machinePoolScope.Info("successfully deleted launch template")
@@ -282,7 +383,7 @@ func (r *AWSManagedMachinePoolReconciler) reconcileDelete(
 
 	controllerutil.RemoveFinalizer(machinePoolScope.ManagedMachinePool, expinfrav1.ManagedMachinePoolFinalizer)
 
-	return nil
+	return ctrl.Result{}, nil
 }
 
 // GetOwnerClusterKey returns only the Cluster name and namespace.
@@ -352,3 +453,44 @@ func (r *AWSManagedMachinePoolReconciler) getEC2Service(scope scope.EC2Scope) se
 func (r *AWSManagedMachinePoolReconciler) getReconcileService(scope scope.EC2Scope) services.MachinePoolReconcileInterface {
 	return ec2.NewService(scope)
 }
+
+func (r *AWSManagedMachinePoolReconciler) getSecurityGroupService(scope scope.SGScope) services.SecurityGroupInterface {
+	return securitygroup.NewService(scope, nil)
+}
+
+// reconcileDedicatedSecurityGroup reconciles the security group owned by the machine pool, when enabled.
+func (r *AWSManagedMachinePoolReconciler) reconcileDedicatedSecurityGroup(machinePoolScope *scope.ManagedMachinePoolScope, ec2Scope scope.EC2Scope) error {
+	dedicatedSG := machinePoolScope.GetDedicatedSecurityGroup()
+	if dedicatedSG == nil || !dedicatedSG.Enabled {
+		return nil
+	}
+
+	sgScope, ok := ec2Scope.(scope.SGScope)
+	if !ok {
+		return errors.New("control plane does not manage security groups")
+	}
+
+	if err := r.getSecurityGroupService(sgScope).ReconcileDedicatedSecurityGroup(machinePoolScope); err != nil {
+		r.Recorder.Eventf(machinePoolScope.ManagedMachinePool, corev1.EventTypeWarning, "FailedDedicatedSecurityGroupReconcile", "Failed to reconcile dedicated security group: %v", err)
+		return errors.Wrap(err, "failed to reconcile dedicated security group")
+	}
+	return nil
+}
+
+// deleteDedicatedSecurityGroup deletes the security group owned by the machine pool, once its nodegroup is gone.
+func (r *AWSManagedMachinePoolReconciler) deleteDedicatedSecurityGroup(machinePoolScope *scope.ManagedMachinePoolScope, ec2Scope scope.EC2Scope) error {
+	if machinePoolScope.GetDedicatedSecurityGroup() == nil && machinePoolScope.GetDedicatedSecurityGroupIDStatus() == "" {
+		return nil
+	}
+
+	sgScope, ok := ec2Scope.(scope.SGScope)
+	if !ok {
+		return errors.New("control plane does not manage security groups")
+	}
+
+	if err := r.getSecurityGroupService(sgScope).DeleteDedicatedSecurityGroup(machinePoolScope); err != nil {
+		r.Recorder.Eventf(machinePoolScope.ManagedMachinePool, corev1.EventTypeWarning, "FailedDelete", "Failed to delete dedicated security group: %v", err)
+		return errors.Wrap(err, "failed to delete dedicated security group")
+	}
+	return nil
+}
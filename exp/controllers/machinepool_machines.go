@@ -0,0 +1,329 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/ec2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/hash"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	expclusterv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/labels/format"
+	"sigs.k8s.io/cluster-api/util/patch"
+)
+
+// machinePoolMachineLabels returns the labels of the AWSMachines of the instances of a machine pool, which Cluster
+// API selects them by.
+func machinePoolMachineLabels(mp *expclusterv1.MachinePool) map[string]string {
+	return map[string]string{
+		clusterv1.MachinePoolNameLabel: format.MustFormatValue(mp.Name),
+		clusterv1.ClusterNameLabel:     mp.Spec.ClusterName,
+	}
+}
+
+// machinePoolMachineTopologyLabels returns the well-known topology labels of the instance of an AWSMachine of a machine
+// pool, so that the AWSMachines and Machines of a pool can be selected by zone, region or instance type. The zone is
+// taken from the provider ID, which the machine pool sets from the ASG instance list.
+func machinePoolMachineTopologyLabels(awsMachine *infrav1.AWSMachine, region string) map[string]string {
+	labels := map[string]string{}
+	if providerID := ptr.Deref(awsMachine.Spec.ProviderID, ""); providerID != "" {
+		// aws:///<zone>/<instance ID>
+		if segments := strings.Split(providerID, "/"); len(segments) > 1 && segments[len(segments)-2] != "" {
+			labels[corev1.LabelTopologyZone] = segments[len(segments)-2]
+		}
+	}
+	if region != "" {
+		labels[corev1.LabelTopologyRegion] = region
+	}
+	if awsMachine.Spec.InstanceType != "" {
+		labels[corev1.LabelInstanceTypeStable] = awsMachine.Spec.InstanceType
+	}
+	return labels
+}
+
+// asgNameTag is the tag EC2 Auto Scaling sets on the instances of an ASG to the name of the ASG.
+const asgNameTag = "aws:autoscaling:groupName"
+
+// machinePoolMachineName returns the name of the AWSMachine of an instance of a machine pool, <asgName>-<instanceID>.
+// The ASG name is sanitized, and truncated with a hash of the full name appended when the name would exceed 63
+// characters, so that the instance ID always stays readable at its end.
+func machinePoolMachineName(asgName, instanceID string) (string, error) {
+	sanitize := func(s string) string {
+		return strings.Trim(strings.Map(func(r rune) rune {
+			if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-' {
+				return r
+			}
+			return '-'
+		}, strings.ToLower(s)), "-")
+	}
+
+	instanceID = sanitize(instanceID)
+	prefix := sanitize(asgName)
+	if prefix == "" {
+		return instanceID, nil
+	}
+	name := fmt.Sprintf("%s-%s", prefix, instanceID)
+	if len(name) <= validation.DNS1123LabelMaxLength {
+		return name, nil
+	}
+
+	const hashLength = 5
+	suffix, err := hash.Base36TruncatedHash(name, hashLength)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to hash AWSMachine name")
+	}
+	// EC2 instance IDs are at most 19 characters long, leaving room for a part of the ASG name.
+	prefix = strings.TrimRight(prefix[:validation.DNS1123LabelMaxLength-len(instanceID)-hashLength-2], "-")
+	return fmt.Sprintf("%s-%s-%s", prefix, suffix, instanceID), nil
+}
+
+// getAWSMachines returns the AWSMachines of the instances of a machine pool.
+func getAWSMachines(ctx context.Context, mp *expclusterv1.MachinePool, kubeClient client.Client) (*infrav1.AWSMachineList, error) {
+	awsMachineList := &infrav1.AWSMachineList{}
+	if err := kubeClient.List(ctx, awsMachineList, client.InNamespace(mp.Namespace), client.MatchingLabels(machinePoolMachineLabels(mp))); err != nil {
+		return nil, errors.Wrap(err, "failed to list AWSMachines")
+	}
+	return awsMachineList, nil
+}
+
+// instanceIsGone returns true if the instance is terminated, or terminating.
+func instanceIsGone(instance *infrav1.Instance) bool {
+	return instance.State == infrav1.InstanceStateShuttingDown || instance.State == infrav1.InstanceStateTerminated
+}
+
+// createAWSMachinesIfNotExists creates an AWSMachine, owned by the infrastructure machine pool, for each instance
+// of the machine pool which doesn't have one yet, labelled with the topology of the instance. Instances which are
+// already terminating are skipped. The AWSMachines are named after the ASG and the instance, see
+// machinePoolMachineName; the AWSMachines created before, with a generated name, are matched by their provider ID
+// and kept.
+func createAWSMachinesIfNotExists(ctx context.Context, awsMachineList *infrav1.AWSMachineList, mp *expclusterv1.MachinePool, infraMachinePool client.Object, gvk schema.GroupVersionKind, providerIDList []string, region string, log logger.Wrapper, kubeClient client.Client, ec2Svc services.EC2Interface) error {
+	providerIDs := make(map[string]struct{}, len(awsMachineList.Items))
+	for _, awsMachine := range awsMachineList.Items {
+		if awsMachine.Spec.ProviderID != nil {
+			providerIDs[*awsMachine.Spec.ProviderID] = struct{}{}
+		}
+	}
+
+	for _, providerID := range providerIDList {
+		if _, ok := providerIDs[providerID]; ok {
+			continue
+		}
+		pid, err := scope.NewProviderID(providerID)
+		if err != nil {
+			return errors.Wrapf(err, "failed to parse provider ID %q", providerID)
+		}
+		instanceID := pid.ID()
+
+		instance, err := ec2Svc.InstanceIfExists(&instanceID)
+		if errors.Is(err, ec2.ErrInstanceNotFoundByID) {
+			log.Debug("Instance of the machine pool not found, it may have been terminated", "instance-id", instanceID)
+			continue
+		}
+		if err != nil {
+			return errors.Wrapf(err, "failed to look up instance %q", instanceID)
+		}
+		if instanceIsGone(instance) {
+			log.Debug("Instance of the machine pool is terminating", "instance-id", instanceID, "state", instance.State)
+			continue
+		}
+
+		asgName, ok := instance.Tags[asgNameTag]
+		if !ok {
+			asgName = infraMachinePool.GetName()
+		}
+		name, err := machinePoolMachineName(asgName, instanceID)
+		if err != nil {
+			return err
+		}
+
+		awsMachine := &infrav1.AWSMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: mp.Namespace,
+				Name:      name,
+				Labels:    machinePoolMachineLabels(mp),
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion:         gvk.GroupVersion().String(),
+					Kind:               gvk.Kind,
+					Name:               infraMachinePool.GetName(),
+					UID:                infraMachinePool.GetUID(),
+					BlockOwnerDeletion: ptr.To(true),
+				}},
+			},
+			Spec: infrav1.AWSMachineSpec{
+				ProviderID:         ptr.To(providerID),
+				InstanceID:         ptr.To(instanceID),
+				InstanceType:       instance.Type,
+				AMI:                infrav1.AMIReference{ID: ptr.To(instance.ImageID)},
+				SSHKeyName:         instance.SSHKeyName,
+				IAMInstanceProfile: instance.IAMProfile,
+				Subnet:             &infrav1.AWSResourceReference{ID: ptr.To(instance.SubnetID)},
+				// The placement is informational, the instance is launched by the machine pool.
+				PlacementGroupName:      instance.PlacementGroupName,
+				PlacementGroupPartition: instance.PlacementGroupPartition,
+			},
+		}
+		for k, v := range machinePoolMachineTopologyLabels(awsMachine, region) {
+			awsMachine.Labels[k] = v
+		}
+
+		log.Info("Creating AWSMachine for machine pool instance", "instance-id", instanceID, "awsmachine", klog.KObj(awsMachine))
+		if err := kubeClient.Create(ctx, awsMachine); apierrors.IsAlreadyExists(err) {
+			// Created by a previous reconcile whose AWSMachine list was stale.
+			log.Debug("AWSMachine of machine pool instance already exists", "instance-id", instanceID, "awsmachine", klog.KObj(awsMachine))
+			continue
+		} else if err != nil {
+			return errors.Wrapf(err, "failed to create AWSMachine for instance %q", instanceID)
+		}
+	}
+
+	return nil
+}
+
+// reconcileTopologyLabels keeps the topology labels of the AWSMachines of a machine pool, and of their Machines once
+// Cluster API created them, in line with the instances of the AWSMachines.
+func reconcileTopologyLabels(ctx context.Context, awsMachineList *infrav1.AWSMachineList, region string, log logger.Wrapper, kubeClient client.Client) error {
+	for i := range awsMachineList.Items {
+		awsMachine := &awsMachineList.Items[i]
+		if !awsMachine.DeletionTimestamp.IsZero() {
+			continue
+		}
+		labels := machinePoolMachineTopologyLabels(awsMachine, region)
+
+		if !hasLabels(awsMachine, labels) {
+			patchHelper, err := patch.NewHelper(awsMachine, kubeClient)
+			if err != nil {
+				return errors.Wrapf(err, "failed to init patch helper for AWSMachine %s", klog.KObj(awsMachine))
+			}
+			setLabels(awsMachine, labels)
+			log.Debug("Updating topology labels of AWSMachine", "awsmachine", klog.KObj(awsMachine), "labels", labels)
+			if err := patchHelper.Patch(ctx, awsMachine); err != nil {
+				return errors.Wrapf(err, "failed to patch AWSMachine %s", klog.KObj(awsMachine))
+			}
+		}
+
+		machine, err := util.GetOwnerMachine(ctx, kubeClient, awsMachine.ObjectMeta)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get the owner Machine of AWSMachine %s", klog.KObj(awsMachine))
+		}
+		if machine == nil || !machine.DeletionTimestamp.IsZero() || hasLabels(machine, labels) {
+			continue
+		}
+		patchHelper, err := patch.NewHelper(machine, kubeClient)
+		if err != nil {
+			return errors.Wrapf(err, "failed to init patch helper for Machine %s", klog.KObj(machine))
+		}
+		setLabels(machine, labels)
+		log.Debug("Updating topology labels of Machine", "machine", klog.KObj(machine), "labels", labels)
+		if err := patchHelper.Patch(ctx, machine); err != nil {
+			return errors.Wrapf(err, "failed to patch Machine %s", klog.KObj(machine))
+		}
+	}
+
+	return nil
+}
+
+// hasLabels returns true if the object carries all the labels.
+func hasLabels(obj metav1.Object, labels map[string]string) bool {
+	for k, v := range labels {
+		if value, ok := obj.GetLabels()[k]; !ok || value != v {
+			return false
+		}
+	}
+	return true
+}
+
+// setLabels sets the labels on the object, keeping its other labels.
+func setLabels(obj metav1.Object, labels map[string]string) {
+	objLabels := obj.GetLabels()
+	if objLabels == nil {
+		objLabels = map[string]string{}
+	}
+	for k, v := range labels {
+		objLabels[k] = v
+	}
+	obj.SetLabels(objLabels)
+}
+
+// deleteOrphanedAWSMachines deletes the Machines of the AWSMachines whose instances left the machine pool, or the
+// AWSMachines themselves while Cluster API hasn't created their Machines yet. An instance which left the pool but
+// is still running is kept, as a node group may detach instances it is replacing before terminating them.
+func deleteOrphanedAWSMachines(ctx context.Context, awsMachineList *infrav1.AWSMachineList, providerIDList []string, log logger.Wrapper, kubeClient client.Client, ec2Svc services.EC2Interface) error {
+	providerIDs := make(map[string]struct{}, len(providerIDList))
+	for _, providerID := range providerIDList {
+		providerIDs[providerID] = struct{}{}
+	}
+
+	for i := range awsMachineList.Items {
+		awsMachine := &awsMachineList.Items[i]
+		if awsMachine.Spec.ProviderID == nil {
+			continue
+		}
+		if _, ok := providerIDs[*awsMachine.Spec.ProviderID]; ok {
+			continue
+		}
+		if !awsMachine.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		if awsMachine.Spec.InstanceID != nil {
+			instance, err := ec2Svc.InstanceIfExists(awsMachine.Spec.InstanceID)
+			if err != nil && !errors.Is(err, ec2.ErrInstanceNotFoundByID) {
+				return errors.Wrapf(err, "failed to look up instance %q", *awsMachine.Spec.InstanceID)
+			}
+			if err == nil && !instanceIsGone(instance) {
+				log.Debug("Instance left the machine pool but is still running", "instance-id", instance.ID, "state", instance.State)
+				continue
+			}
+		}
+
+		machine, err := util.GetOwnerMachine(ctx, kubeClient, awsMachine.ObjectMeta)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get the owner Machine of AWSMachine %s", klog.KObj(awsMachine))
+		}
+		if machine == nil {
+			log.Info("Deleting AWSMachine of instance which left the machine pool", "awsmachine", klog.KObj(awsMachine))
+			if err := kubeClient.Delete(ctx, awsMachine); client.IgnoreNotFound(err) != nil {
+				return errors.Wrapf(err, "failed to delete AWSMachine %s", klog.KObj(awsMachine))
+			}
+			continue
+		}
+		log.Info("Deleting Machine of instance which left the machine pool", "machine", klog.KObj(machine))
+		if err := kubeClient.Delete(ctx, machine); client.IgnoreNotFound(err) != nil {
+			return errors.Wrapf(err, "failed to delete Machine %s", klog.KObj(machine))
+		}
+	}
+
+	return nil
+}
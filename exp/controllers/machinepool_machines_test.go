@@ -0,0 +1,306 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	apimachinerytypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/tools/record"
+	utilfeature "k8s.io/component-base/featuregate/testing"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/feature"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/ec2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/mock_services"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	expclusterv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+)
+
+func TestMachinePoolMachines(t *testing.T) {
+	log := logger.NewLogger(klog.Background())
+
+	machinePool := &expclusterv1.MachinePool{
+		ObjectMeta: metav1.ObjectMeta{Name: "mp", Namespace: "default"},
+		Spec:       expclusterv1.MachinePoolSpec{ClusterName: "cluster"},
+	}
+	awsMachinePool := &expinfrav1.AWSMachinePool{
+		ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default", UID: "pool-uid"},
+	}
+	gvk := expinfrav1.GroupVersion.WithKind("AWSMachinePool")
+
+	awsMachine := func(name, instanceID string) *infrav1.AWSMachine {
+		return &infrav1.AWSMachine{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: machinePoolMachineLabels(machinePool)},
+			Spec: infrav1.AWSMachineSpec{
+				ProviderID: ptr.To("aws:///us-east-1a/" + instanceID),
+				InstanceID: ptr.To(instanceID),
+			},
+		}
+	}
+
+	setup := func(t *testing.T, objects ...client.Object) (*WithT, client.Client, *mock_services.MockEC2InterfaceMockRecorder, *mock_services.MockEC2Interface) {
+		t.Helper()
+		g := NewWithT(t)
+		scheme := runtime.NewScheme()
+		g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+		g.Expect(clusterv1.AddToScheme(scheme)).To(Succeed())
+		mockCtrl := gomock.NewController(t)
+		ec2Svc := mock_services.NewMockEC2Interface(mockCtrl)
+		return g, fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build(), ec2Svc.EXPECT(), ec2Svc
+	}
+
+	t.Run("should create AWSMachines for new running instances only", func(t *testing.T) {
+		g, kubeClient, ec2Mock, ec2Svc := setup(t, awsMachine("existing", "i-existing"))
+		ec2Mock.InstanceIfExists(ptr.To("i-new")).Return(&infrav1.Instance{
+			ID: "i-new", State: infrav1.InstanceStatePending, Type: "m5.large", ImageID: "ami-1", SubnetID: "subnet-1",
+			PlacementGroupName: "hpc", PlacementGroupPartition: 2,
+			Tags: map[string]string{asgNameTag: "my-cluster-pool"},
+		}, nil)
+		ec2Mock.InstanceIfExists(ptr.To("i-recycled")).Return(&infrav1.Instance{ID: "i-recycled", State: infrav1.InstanceStateShuttingDown}, nil)
+		ec2Mock.InstanceIfExists(ptr.To("i-gone")).Return(nil, ec2.ErrInstanceNotFoundByID)
+
+		awsMachineList, err := getAWSMachines(context.Background(), machinePool, kubeClient)
+		g.Expect(err).ToNot(HaveOccurred())
+		providerIDList := []string{"aws:///us-east-1a/i-existing", "aws:///us-east-1a/i-new", "aws:///us-east-1b/i-recycled", "aws:///us-east-1b/i-gone"}
+		g.Expect(createAWSMachinesIfNotExists(context.Background(), awsMachineList, machinePool, awsMachinePool, gvk, providerIDList, "us-east-1", log, kubeClient, ec2Svc)).To(Succeed())
+
+		awsMachineList, err = getAWSMachines(context.Background(), machinePool, kubeClient)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(awsMachineList.Items).To(HaveLen(2))
+		var created *infrav1.AWSMachine
+		for i := range awsMachineList.Items {
+			if awsMachineList.Items[i].Name != "existing" {
+				created = &awsMachineList.Items[i]
+			}
+		}
+		g.Expect(created).ToNot(BeNil())
+		g.Expect(created.Name).To(Equal("my-cluster-pool-i-new"))
+		g.Expect(created.Spec.ProviderID).To(Equal(ptr.To("aws:///us-east-1a/i-new")))
+		g.Expect(created.Spec.InstanceType).To(Equal("m5.large"))
+		g.Expect(created.Spec.PlacementGroupName).To(Equal("hpc"))
+		g.Expect(created.Spec.PlacementGroupPartition).To(Equal(int64(2)))
+		g.Expect(created.Labels).To(HaveKeyWithValue(clusterv1.MachinePoolNameLabel, "mp"))
+		g.Expect(created.Labels).To(HaveKeyWithValue(corev1.LabelTopologyZone, "us-east-1a"))
+		g.Expect(created.Labels).To(HaveKeyWithValue(corev1.LabelTopologyRegion, "us-east-1"))
+		g.Expect(created.Labels).To(HaveKeyWithValue(corev1.LabelInstanceTypeStable, "m5.large"))
+		g.Expect(created.OwnerReferences).To(ConsistOf(HaveField("UID", awsMachinePool.UID)))
+	})
+
+	t.Run("should treat an AWSMachine created by a previous reconcile as created", func(t *testing.T) {
+		// Missing from the stale list the reconcile works on.
+		g, kubeClient, ec2Mock, ec2Svc := setup(t, awsMachine("pool-i-new", "i-new"))
+		ec2Mock.InstanceIfExists(ptr.To("i-new")).Return(&infrav1.Instance{ID: "i-new", State: infrav1.InstanceStateRunning}, nil)
+
+		providerIDList := []string{"aws:///us-east-1a/i-new"}
+		g.Expect(createAWSMachinesIfNotExists(context.Background(), &infrav1.AWSMachineList{}, machinePool, awsMachinePool, gvk, providerIDList, "us-east-1", log, kubeClient, ec2Svc)).To(Succeed())
+
+		awsMachineList, err := getAWSMachines(context.Background(), machinePool, kubeClient)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(awsMachineList.Items).To(ConsistOf(HaveField("Name", "pool-i-new")))
+	})
+
+	t.Run("should keep the topology labels of the AWSMachines and their Machines up to date", func(t *testing.T) {
+		// Moved to another zone, e.g. an AWSMachine whose labels were edited.
+		moved := awsMachine("moved", "i-moved")
+		moved.Spec.ProviderID = ptr.To("aws:///us-east-1b/i-moved")
+		moved.Spec.InstanceType = "m5.large"
+		moved.Labels[corev1.LabelTopologyZone] = "us-east-1a"
+		moved.OwnerReferences = []metav1.OwnerReference{{APIVersion: clusterv1.GroupVersion.String(), Kind: "Machine", Name: "moved"}}
+		machine := &clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{
+			Name: "moved", Namespace: "default", Labels: map[string]string{corev1.LabelTopologyZone: "us-east-1a", "team": "a"},
+		}}
+		g, kubeClient, _, _ := setup(t, moved, machine)
+
+		awsMachineList, err := getAWSMachines(context.Background(), machinePool, kubeClient)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(reconcileTopologyLabels(context.Background(), awsMachineList, "us-east-1", log, kubeClient)).To(Succeed())
+
+		want := map[string]string{
+			corev1.LabelTopologyZone:       "us-east-1b",
+			corev1.LabelTopologyRegion:     "us-east-1",
+			corev1.LabelInstanceTypeStable: "m5.large",
+		}
+		updated := &infrav1.AWSMachine{}
+		g.Expect(kubeClient.Get(context.Background(), client.ObjectKeyFromObject(moved), updated)).To(Succeed())
+		g.Expect(hasLabels(updated, want)).To(BeTrue())
+		g.Expect(updated.Labels).To(HaveKeyWithValue(clusterv1.MachinePoolNameLabel, "mp"))
+		updatedMachine := &clusterv1.Machine{}
+		g.Expect(kubeClient.Get(context.Background(), client.ObjectKeyFromObject(machine), updatedMachine)).To(Succeed())
+		g.Expect(hasLabels(updatedMachine, want)).To(BeTrue())
+		g.Expect(updatedMachine.Labels).To(HaveKeyWithValue("team", "a"))
+	})
+
+	t.Run("should delete the Machines of instances which left the pool once terminated", func(t *testing.T) {
+		withMachine := awsMachine("terminated", "i-terminated")
+		withMachine.OwnerReferences = []metav1.OwnerReference{{APIVersion: clusterv1.GroupVersion.String(), Kind: "Machine", Name: "terminated"}}
+		machine := &clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{Name: "terminated", Namespace: "default"}}
+		g, kubeClient, ec2Mock, ec2Svc := setup(t, awsMachine("in-pool", "i-in-pool"), awsMachine("detached", "i-detached"), awsMachine("gone", "i-gone"), withMachine, machine)
+		ec2Mock.InstanceIfExists(ptr.To("i-detached")).Return(&infrav1.Instance{ID: "i-detached", State: infrav1.InstanceStateRunning}, nil)
+		ec2Mock.InstanceIfExists(ptr.To("i-gone")).Return(nil, ec2.ErrInstanceNotFoundByID)
+		ec2Mock.InstanceIfExists(ptr.To("i-terminated")).Return(&infrav1.Instance{ID: "i-terminated", State: infrav1.InstanceStateTerminated}, nil)
+
+		awsMachineList, err := getAWSMachines(context.Background(), machinePool, kubeClient)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(deleteOrphanedAWSMachines(context.Background(), awsMachineList, []string{"aws:///us-east-1a/i-in-pool"}, log, kubeClient, ec2Svc)).To(Succeed())
+
+		awsMachineList, err = getAWSMachines(context.Background(), machinePool, kubeClient)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(awsMachineList.Items).To(ConsistOf(HaveField("Name", "in-pool"), HaveField("Name", "detached"), HaveField("Name", "terminated")))
+		machines := &clusterv1.MachineList{}
+		g.Expect(kubeClient.List(context.Background(), machines)).To(Succeed())
+		g.Expect(machines.Items).To(BeEmpty())
+	})
+}
+
+func TestMachinePoolMachineName(t *testing.T) {
+	longASGName := "eks-" + strings.Repeat("nodegroup", 8) + "-4ec6f1a2"
+
+	tests := []struct {
+		name       string
+		asgName    string
+		instanceID string
+		want       string
+	}{
+		{
+			name:       "should join the ASG name and the instance ID",
+			asgName:    "my-cluster-pool",
+			instanceID: "i-0123456789abcdef0",
+			want:       "my-cluster-pool-i-0123456789abcdef0",
+		},
+		{
+			name:       "should sanitize the ASG name",
+			asgName:    "Pool_A.workers",
+			instanceID: "i-0123456789abcdef0",
+			want:       "pool-a-workers-i-0123456789abcdef0",
+		},
+		{
+			name:       "should only use the instance ID without an ASG name",
+			instanceID: "i-0123456789abcdef0",
+			want:       "i-0123456789abcdef0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			name, err := machinePoolMachineName(tt.asgName, tt.instanceID)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(name).To(Equal(tt.want))
+		})
+	}
+
+	t.Run("should truncate a long ASG name and append a hash", func(t *testing.T) {
+		g := NewWithT(t)
+		name, err := machinePoolMachineName(longASGName, "i-0123456789abcdef0")
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(name).To(HaveLen(validation.DNS1123LabelMaxLength))
+		g.Expect(validation.IsDNS1123Subdomain(name)).To(BeEmpty())
+		g.Expect(name).To(HavePrefix("eks-nodegroup"))
+		g.Expect(name).To(HaveSuffix("-i-0123456789abcdef0"))
+
+		other, err := machinePoolMachineName(longASGName+"x", "i-0123456789abcdef0")
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(other).ToNot(Equal(name), "ASG names sharing the truncated prefix must not collide")
+	})
+}
+
+func TestAWSMachinePoolMachinesReconcileMachinePoolMachines(t *testing.T) {
+	mp := &expclusterv1.MachinePool{
+		ObjectMeta: metav1.ObjectMeta{Name: "mp", Namespace: "default"},
+		Spec:       expclusterv1.MachinePoolSpec{ClusterName: "test"},
+	}
+	setup := func(t *testing.T) (*WithT, *AWSMachinePoolMachinesReconciler, *scope.MachinePoolScope, *mock_services.MockEC2Interface) {
+		t.Helper()
+		g := NewWithT(t)
+		scheme := runtime.NewScheme()
+		g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+		g.Expect(clusterv1.AddToScheme(scheme)).To(Succeed())
+		machinePoolScope := &scope.MachinePoolScope{
+			MachinePool: mp.DeepCopy(),
+			AWSMachinePool: &expinfrav1.AWSMachinePool{
+				ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default", UID: "pool-uid"},
+				Spec:       expinfrav1.AWSMachinePoolSpec{ProviderIDList: []string{"aws:///us-east-1a/i-1"}},
+				Status:     expinfrav1.AWSMachinePoolStatus{Ready: true},
+			},
+			InfraCluster: &scope.ClusterScope{
+				AWSCluster: &infrav1.AWSCluster{Spec: infrav1.AWSClusterSpec{Region: "us-east-1"}},
+			},
+			Logger: *logger.NewLogger(klog.Background()),
+		}
+		reconciler := &AWSMachinePoolMachinesReconciler{
+			Client:   fake.NewClientBuilder().WithScheme(scheme).Build(),
+			Recorder: record.NewFakeRecorder(10),
+		}
+		return g, reconciler, machinePoolScope, mock_services.NewMockEC2Interface(gomock.NewController(t))
+	}
+
+	t.Run("should create the AWSMachines of the instances of the ASG", func(t *testing.T) {
+		defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.MachinePoolMachines, true)()
+		g, reconciler, machinePoolScope, ec2Svc := setup(t)
+		ec2Svc.EXPECT().InstanceIfExists(ptr.To("i-1")).Return(&infrav1.Instance{ID: "i-1", State: infrav1.InstanceStateRunning, Type: "m5.large", Tags: map[string]string{asgNameTag: "pool"}}, nil)
+
+		g.Expect(reconciler.reconcileMachinePoolMachines(context.TODO(), machinePoolScope, ec2Svc)).To(Succeed())
+		g.Expect(machinePoolScope.AWSMachinePool.Status.InfrastructureMachineKind).To(Equal("AWSMachine"))
+
+		awsMachineList, err := getAWSMachines(context.TODO(), mp, reconciler.Client)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(awsMachineList.Items).To(HaveLen(1))
+		awsMachine := awsMachineList.Items[0]
+		g.Expect(awsMachine.Name).To(Equal("pool-i-1"))
+		g.Expect(awsMachine.Labels).To(HaveKeyWithValue(corev1.LabelTopologyRegion, "us-east-1"))
+		g.Expect(awsMachine.OwnerReferences).To(ConsistOf(And(HaveField("Kind", "AWSMachinePool"), HaveField("UID", apimachinerytypes.UID("pool-uid")))))
+	})
+
+	t.Run("should not create AWSMachines before the ASG is ready", func(t *testing.T) {
+		defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.MachinePoolMachines, true)()
+		g, reconciler, machinePoolScope, ec2Svc := setup(t)
+		machinePoolScope.AWSMachinePool.Status.Ready = false
+
+		g.Expect(reconciler.reconcileMachinePoolMachines(context.TODO(), machinePoolScope, ec2Svc)).To(Succeed())
+
+		awsMachineList, err := getAWSMachines(context.TODO(), mp, reconciler.Client)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(awsMachineList.Items).To(BeEmpty())
+	})
+
+	t.Run("should not create AWSMachines with the feature gate disabled", func(t *testing.T) {
+		g, reconciler, machinePoolScope, ec2Svc := setup(t)
+		machinePoolScope.AWSMachinePool.Status.InfrastructureMachineKind = "AWSMachine"
+
+		g.Expect(reconciler.reconcileMachinePoolMachines(context.TODO(), machinePoolScope, ec2Svc)).To(Succeed())
+		g.Expect(machinePoolScope.AWSMachinePool.Status.InfrastructureMachineKind).To(BeEmpty())
+
+		awsMachineList, err := getAWSMachines(context.TODO(), mp, reconciler.Client)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(awsMachineList.Items).To(BeEmpty())
+	})
+}
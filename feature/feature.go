@@ -86,6 +86,11 @@ const (
 	// owner: @enxebre
 	// alpha: v2.2
 	ROSA featuregate.Feature = "ROSA"
+
+	// MachinePoolMachines is used to create an AWSMachine for each instance of a machine pool, for which Cluster API
+	// creates a Machine.
+	// alpha: v2.6
+	MachinePoolMachines featuregate.Feature = "MachinePoolMachines"
 )
 
 func init() {
@@ -108,4 +113,5 @@ var defaultCAPAFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
 	AlternativeGCStrategy:         {Default: false, PreRelease: featuregate.Alpha},
 	TagUnmanagedNetworkResources:  {Default: true, PreRelease: featuregate.Alpha},
 	ROSA:                          {Default: false, PreRelease: featuregate.Alpha},
+	MachinePoolMachines:           {Default: false, PreRelease: featuregate.Alpha},
 }
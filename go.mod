@@ -34,6 +34,7 @@ require (
 	github.com/openshift-online/ocm-common v0.0.11
 	github.com/openshift-online/ocm-sdk-go v0.1.440
 	github.com/openshift/rosa v1.2.46-rc1.0.20241003145806-a4af6ae81a7c
+	github.com/pelletier/go-toml/v2 v2.2.2
 	github.com/pkg/errors v0.9.1
 	github.com/prometheus/client_golang v1.19.0
 	github.com/sergi/go-diff v1.3.1
@@ -194,7 +195,6 @@ require (
 	github.com/opencontainers/go-digest v1.0.0 // indirect
 	github.com/opencontainers/image-spec v1.1.0-rc5 // indirect
 	github.com/pelletier/go-toml v1.9.5 // indirect
-	github.com/pelletier/go-toml/v2 v2.2.2 // indirect
 	github.com/peterbourgon/diskv v2.0.1+incompatible // indirect
 	github.com/prometheus/client_model v0.6.1 // indirect
 	github.com/prometheus/common v0.52.2 // indirect
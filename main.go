@@ -25,10 +25,13 @@ import (
 	"net/http"
 	_ "net/http/pprof"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/spf13/pflag"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	cgscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/leaderelection/resourcelock"
 	cgrecord "k8s.io/client-go/tools/record"
@@ -39,6 +42,7 @@ import (
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
@@ -62,8 +66,17 @@ import (
 	"sigs.k8s.io/cluster-api-provider-aws/v2/feature"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/endpoints"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/credentialcheck"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/drift"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/endpointprobe"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/gpu"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/permissions"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/quotas"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/spot"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/volumeencryption"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/util/system"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/version"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	expclusterv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
@@ -92,23 +105,47 @@ func init() {
 }
 
 var (
-	enableLeaderElection        bool
-	leaderElectionLeaseDuration time.Duration
-	leaderElectionRenewDeadline time.Duration
-	leaderElectionRetryPeriod   time.Duration
-	leaderElectionNamespace     string
-	watchNamespace              string
-	watchFilterValue            string
-	profilerAddress             string
-	awsClusterConcurrency       int
-	instanceStateConcurrency    int
-	awsMachineConcurrency       int
-	waitInfraPeriod             time.Duration
-	syncPeriod                  time.Duration
-	webhookPort                 int
-	webhookCertDir              string
-	healthAddr                  string
-	serviceEndpoints            string
+	enableLeaderElection           bool
+	leaderElectionLeaseDuration    time.Duration
+	leaderElectionRenewDeadline    time.Duration
+	leaderElectionRetryPeriod      time.Duration
+	leaderElectionNamespace        string
+	watchNamespace                 string
+	watchNamespaceList             []string
+	namespaceIdentityRoles         string
+	namespaceIdentityRolesConfig   string
+	watchFilterValue               string
+	profilerAddress                string
+	awsClusterConcurrency          int
+	instanceStateConcurrency       int
+	awsMachineConcurrency          int
+	waitInfraPeriod                time.Duration
+	syncPeriod                     time.Duration
+	webhookPort                    int
+	webhookCertDir                 string
+	healthAddr                     string
+	serviceEndpoints               string
+	ownershipTagPrefix             string
+	enableCostAllocationTags       bool
+	enableOwnerNamespaceTag        bool
+	clusterUIDTagKey               string
+	ownerNamespaceTagKey           string
+	enablePermissionPrecheck       bool
+	enableAPIEndpointProbe         bool
+	enableInstanceDriftAudit       bool
+	instanceDriftAttributes        []string
+	instanceDriftAuditInterval     time.Duration
+	enableSpotPriceStatus          bool
+	spotPriceCacheTTL              time.Duration
+	enableVolumeEncryptionReport   bool
+	volumeEncryptionReportInterval time.Duration
+	enableNetworkSummary           bool
+	enableAccountQuotaCheck        bool
+	accountQuotaCheckInterval      time.Duration
+	enableCredentialsCheck         bool
+	credentialsCheckInterval       time.Duration
+	credentialsCheckTimeout        time.Duration
+	credentialsCheckThreshold      int
 
 	// maxEKSSyncPeriod is the maximum allowed duration for the sync-period flag when using EKS. It is set to 10 minutes
 	// because during resync it will create a new AWS auth token which can a maximum life of 15 minutes and this ensures
@@ -116,6 +153,7 @@ var (
 	maxEKSSyncPeriod         = time.Minute * 10
 	errMaxSyncPeriodExceeded = errors.New("sync period greater than maximum allowed")
 	errEKSInvalidFlags       = errors.New("invalid EKS flag combination")
+	errCredentialsCheckFlags = errors.New("invalid credentials check flag")
 
 	logOptions     = logs.NewOptions()
 	managerOptions = flags.ManagerOptions{}
@@ -143,9 +181,13 @@ func main() {
 
 	var watchNamespaces map[string]cache.Config
 	if watchNamespace != "" {
-		setupLog.Info("Watching cluster-api objects only in namespace for reconciliation", "namespace", watchNamespace)
-		watchNamespaces = map[string]cache.Config{
-			watchNamespace: {},
+		watchNamespaceList = append(watchNamespaceList, watchNamespace)
+	}
+	if len(watchNamespaceList) > 0 {
+		setupLog.Info("Watching cluster-api objects only in namespaces for reconciliation", "namespaces", watchNamespaceList)
+		watchNamespaces = map[string]cache.Config{}
+		for _, namespace := range watchNamespaceList {
+			watchNamespaces[namespace] = cache.Config{}
 		}
 	}
 
@@ -219,6 +261,42 @@ func main() {
 		setupLog.Info("Enabling Ignition support for machine bootstrap data")
 	}
 
+	if errs := infrav1.ValidateOwnershipTagPrefix(ownershipTagPrefix, "", field.NewPath("ownership-tag-prefix")); len(errs) > 0 {
+		setupLog.Error(errs.ToAggregate(), "invalid ownership tag prefix")
+		os.Exit(1)
+	}
+
+	if enableCredentialsCheck {
+		if credentialsCheckInterval <= 0 || credentialsCheckTimeout <= 0 {
+			setupLog.Error(errCredentialsCheckFlags, "credentials check interval and timeout must be greater than 0",
+				"credentials-check-interval", credentialsCheckInterval, "credentials-check-timeout", credentialsCheckTimeout)
+			os.Exit(1)
+		}
+		if credentialsCheckThreshold < 0 {
+			setupLog.Error(errCredentialsCheckFlags, "credentials check failure threshold must not be negative",
+				"credentials-check-failure-threshold", credentialsCheckThreshold)
+			os.Exit(1)
+		}
+	}
+
+	if enableCostAllocationTags {
+		costAllocationTags := scope.CostAllocationTagOptions{ClusterUIDKey: clusterUIDTagKey}
+		if enableOwnerNamespaceTag {
+			costAllocationTags.OwnerNamespaceKey = ownerNamespaceTagKey
+		}
+		if err := costAllocationTags.Validate(); err != nil {
+			setupLog.Error(err, "invalid cost allocation tags")
+			os.Exit(1)
+		}
+		setupLog.Info("Tagging the resources of clusters with cost allocation tags", "cluster-uid-key", costAllocationTags.ClusterUIDKey, "owner-namespace-key", costAllocationTags.OwnerNamespaceKey)
+		scope.SetCostAllocationTagOptions(costAllocationTags)
+	}
+
+	if err := setupNamespaceIdentityRoles(ctx, mgr.GetAPIReader(), watchNamespaces); err != nil {
+		setupLog.Error(err, "invalid namespace identity roles")
+		os.Exit(1)
+	}
+
 	// Parse service endpoints.
 	awsServiceEndpoints, err := endpoints.ParseFlag(serviceEndpoints)
 	if err != nil {
@@ -288,6 +366,13 @@ func main() {
 		os.Exit(1)
 	}
 
+	if enableCredentialsCheck {
+		if err := setupCredentialsCheck(mgr); err != nil {
+			setupLog.Error(err, "unable to create credentials check")
+			os.Exit(1)
+		}
+	}
+
 	setupLog.Info("starting manager", "version", version.Get().String())
 	if err := mgr.Start(ctx); err != nil {
 		setupLog.Error(err, "problem running manager")
@@ -298,6 +383,21 @@ func main() {
 func setupReconcilersAndWebhooks(ctx context.Context, mgr ctrl.Manager, awsServiceEndpoints []scope.ServiceEndpoint,
 	externalResourceGC, alternativeGCStrategy bool,
 ) {
+	var driftAuditor *drift.Auditor
+	if enableInstanceDriftAudit {
+		var err error
+		driftAuditor, err = drift.NewAuditor(instanceDriftAttributes, instanceDriftAuditInterval)
+		if err != nil {
+			setupLog.Error(err, "unable to configure the instance drift audit")
+			os.Exit(1)
+		}
+	}
+
+	var spotPriceCache *spot.PriceCache
+	if enableSpotPriceStatus {
+		spotPriceCache = spot.NewPriceCache(spotPriceCacheTTL)
+	}
+
 	if err := (&controllers.AWSMachineReconciler{
 		Client:                       mgr.GetClient(),
 		Log:                          ctrl.Log.WithName("controllers").WithName("AWSMachine"),
@@ -305,11 +405,38 @@ func setupReconcilersAndWebhooks(ctx context.Context, mgr ctrl.Manager, awsServi
 		Endpoints:                    awsServiceEndpoints,
 		WatchFilterValue:             watchFilterValue,
 		TagUnmanagedNetworkResources: feature.Gates.Enabled(feature.TagUnmanagedNetworkResources),
+		OwnershipTagPrefix:           ownershipTagPrefix,
+		DriftAuditor:                 driftAuditor,
 	}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: awsMachineConcurrency, RecoverPanic: ptr.To[bool](true)}); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "AWSMachine")
 		os.Exit(1)
 	}
 
+	var permissionsChecker *permissions.Checker
+	if enablePermissionPrecheck {
+		permissionsChecker = permissions.NewChecker(permissions.Features{
+			EKS:                feature.Gates.Enabled(feature.EKS),
+			MachinePool:        feature.Gates.Enabled(feature.MachinePool),
+			ExternalResourceGC: externalResourceGC,
+			AccountQuotaCheck:  enableAccountQuotaCheck,
+		}, permissions.DefaultCheckInterval)
+	}
+
+	var quotaChecker *quotas.Checker
+	if enableAccountQuotaCheck {
+		quotaChecker = quotas.NewChecker(accountQuotaCheckInterval)
+	}
+
+	var endpointProber *endpointprobe.Prober
+	if enableAPIEndpointProbe {
+		endpointProber = endpointprobe.NewProber(endpointprobe.DefaultProbeInterval, endpointprobe.DefaultProbeTimeout)
+	}
+
+	var volumeEncryptionReporter *volumeencryption.Reporter
+	if enableVolumeEncryptionReport {
+		volumeEncryptionReporter = volumeencryption.NewReporter(volumeEncryptionReportInterval)
+	}
+
 	if err := (&controllers.AWSClusterReconciler{
 		Client:                       mgr.GetClient(),
 		Recorder:                     mgr.GetEventRecorderFor("awscluster-controller"),
@@ -318,6 +445,12 @@ func setupReconcilersAndWebhooks(ctx context.Context, mgr ctrl.Manager, awsServi
 		ExternalResourceGC:           externalResourceGC,
 		AlternativeGCStrategy:        alternativeGCStrategy,
 		TagUnmanagedNetworkResources: feature.Gates.Enabled(feature.TagUnmanagedNetworkResources),
+		OwnershipTagPrefix:           ownershipTagPrefix,
+		PermissionsChecker:           permissionsChecker,
+		EndpointProber:               endpointProber,
+		VolumeEncryptionReporter:     volumeEncryptionReporter,
+		NetworkSummary:               enableNetworkSummary,
+		QuotaChecker:                 quotaChecker,
 	}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: awsClusterConcurrency, RecoverPanic: ptr.To[bool](true)}); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "AWSCluster")
 		os.Exit(1)
@@ -330,11 +463,27 @@ func setupReconcilersAndWebhooks(ctx context.Context, mgr ctrl.Manager, awsServi
 			Recorder:                     mgr.GetEventRecorderFor("awsmachinepool-controller"),
 			WatchFilterValue:             watchFilterValue,
 			TagUnmanagedNetworkResources: feature.Gates.Enabled(feature.TagUnmanagedNetworkResources),
+			OwnershipTagPrefix:           ownershipTagPrefix,
+			DriftAuditor:                 driftAuditor,
+			SpotPriceCache:               spotPriceCache,
+			SpotPlacementScoreCache:      spot.NewPlacementScoreCache(spot.DefaultPlacementScoreCacheTTL),
+			GPUCache:                     gpu.NewCache(),
 		}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: instanceStateConcurrency, RecoverPanic: ptr.To[bool](true)}); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "AWSMachinePool")
 			os.Exit(1)
 		}
 
+		if err := (&expcontrollers.AWSMachinePoolMachinesReconciler{
+			Client:                       mgr.GetClient(),
+			Recorder:                     mgr.GetEventRecorderFor("awsmachinepoolmachines-controller"),
+			WatchFilterValue:             watchFilterValue,
+			TagUnmanagedNetworkResources: feature.Gates.Enabled(feature.TagUnmanagedNetworkResources),
+			OwnershipTagPrefix:           ownershipTagPrefix,
+		}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: instanceStateConcurrency, RecoverPanic: ptr.To[bool](true)}); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "AWSMachinePoolMachines")
+			os.Exit(1)
+		}
+
 		if err := (&expinfrav1.AWSMachinePool{}).SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "AWSMachinePool")
 			os.Exit(1)
@@ -427,6 +576,7 @@ func setupEKSReconcilersAndWebhooks(ctx context.Context, mgr ctrl.Manager, awsSe
 		AlternativeGCStrategy:        alternativeGCStrategy,
 		WaitInfraPeriod:              waitInfraPeriod,
 		TagUnmanagedNetworkResources: feature.Gates.Enabled(feature.TagUnmanagedNetworkResources),
+		OwnershipTagPrefix:           ownershipTagPrefix,
 	}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: awsClusterConcurrency, RecoverPanic: ptr.To[bool](true)}); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "AWSManagedControlPlane")
 		os.Exit(1)
@@ -454,11 +604,12 @@ func setupEKSReconcilersAndWebhooks(ctx context.Context, mgr ctrl.Manager, awsSe
 	if feature.Gates.Enabled(feature.EKSFargate) {
 		setupLog.Debug("enabling EKS fargate profile controller")
 		if err := (&expcontrollers.AWSFargateProfileReconciler{
-			Client:           mgr.GetClient(),
-			Recorder:         mgr.GetEventRecorderFor("awsfargateprofile-reconciler"),
-			EnableIAM:        enableIAM,
-			Endpoints:        awsServiceEndpoints,
-			WatchFilterValue: watchFilterValue,
+			Client:             mgr.GetClient(),
+			Recorder:           mgr.GetEventRecorderFor("awsfargateprofile-reconciler"),
+			EnableIAM:          enableIAM,
+			Endpoints:          awsServiceEndpoints,
+			OwnershipTagPrefix: ownershipTagPrefix,
+			WatchFilterValue:   watchFilterValue,
 		}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: awsClusterConcurrency, RecoverPanic: ptr.To[bool](true)}); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "AWSFargateProfile")
 		}
@@ -479,6 +630,7 @@ func setupEKSReconcilersAndWebhooks(ctx context.Context, mgr ctrl.Manager, awsSe
 			Recorder:                     mgr.GetEventRecorderFor("awsmanagedmachinepool-reconciler"),
 			WatchFilterValue:             watchFilterValue,
 			TagUnmanagedNetworkResources: feature.Gates.Enabled(feature.TagUnmanagedNetworkResources),
+			OwnershipTagPrefix:           ownershipTagPrefix,
 		}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: instanceStateConcurrency, RecoverPanic: ptr.To[bool](true)}); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "AWSManagedMachinePool")
 			os.Exit(1)
@@ -496,6 +648,54 @@ func setupEKSReconcilersAndWebhooks(ctx context.Context, mgr ctrl.Manager, awsSe
 	}
 }
 
+// setupCredentialsCheck registers the runnable validating the credentials of the controller, and its readiness check.
+// The namespace identity roles must be set before, as they're validated along the default credential chain.
+func setupCredentialsCheck(mgr ctrl.Manager) error {
+	checker, err := credentialcheck.NewChecker(scope.NamespaceIdentityRoleARNs(), credentialsCheckInterval, credentialsCheckTimeout,
+		credentialsCheckThreshold, logger.NewLogger(ctrl.Log.WithName("credentials-check")))
+	if err != nil {
+		return err
+	}
+	if err := mgr.Add(checker); err != nil {
+		return err
+	}
+	return mgr.AddReadyzCheck("aws-credentials", checker.Check)
+}
+
+// setupNamespaceIdentityRoles sets the namespace identity roles of the --namespace-identity-roles flag and of the
+// --namespace-identity-roles-configmap ConfigMap. The namespaces must be watched by the controller.
+func setupNamespaceIdentityRoles(ctx context.Context, reader client.Reader, watchNamespaces map[string]cache.Config) error {
+	roles, err := scope.ParseNamespaceIdentityRoles(namespaceIdentityRoles)
+	if err != nil {
+		return err
+	}
+	if namespaceIdentityRolesConfig != "" {
+		configMap := &corev1.ConfigMap{}
+		key := client.ObjectKey{Namespace: system.GetManagerNamespace(), Name: namespaceIdentityRolesConfig}
+		if err := reader.Get(ctx, key, configMap); err != nil {
+			return fmt.Errorf("failed to get ConfigMap %s: %w", key, err)
+		}
+		configRoles, err := scope.NamespaceIdentityRolesFromData(configMap.Data)
+		if err != nil {
+			return fmt.Errorf("invalid ConfigMap %s: %w", key, err)
+		}
+		for namespace, roleARN := range configRoles {
+			if _, ok := roles[namespace]; ok {
+				return fmt.Errorf("identity role for namespace %q set both by flag and by ConfigMap %s", namespace, key)
+			}
+			roles[namespace] = roleARN
+		}
+	}
+	for namespace := range roles {
+		if _, ok := watchNamespaces[namespace]; len(watchNamespaces) > 0 && !ok {
+			return fmt.Errorf("identity role set for namespace %q, which isn't watched", namespace)
+		}
+		setupLog.Info("Clusters in namespace assume its identity role by default", "namespace", namespace, "role", roles[namespace])
+	}
+	scope.SetNamespaceIdentityRoles(roles)
+	return nil
+}
+
 func initFlags(fs *pflag.FlagSet) {
 	fs.BoolVar(
 		&enableLeaderElection,
@@ -532,6 +732,27 @@ func initFlags(fs *pflag.FlagSet) {
 		"Namespace that the controller watches to reconcile cluster-api objects. If unspecified, the controller watches for cluster-api objects across all namespaces.",
 	)
 
+	fs.StringSliceVar(
+		&watchNamespaceList,
+		"watch-namespace-list",
+		nil,
+		"Comma-separated list of namespaces that the controller watches to reconcile cluster-api objects, in addition to the namespace set with --namespace. Objects in other namespaces are ignored.",
+	)
+
+	fs.StringVar(
+		&namespaceIdentityRoles,
+		"namespace-identity-roles",
+		"",
+		"Comma-separated list of namespace=roleARN pairs. The clusters of a namespace which don't set an identityRef, or use the controller identity, assume the role of their namespace with the credentials of the controller (e.g. team-a=arn:aws:iam::111122223333:role/capa).",
+	)
+
+	fs.StringVar(
+		&namespaceIdentityRolesConfig,
+		"namespace-identity-roles-configmap",
+		"",
+		"Name of a ConfigMap in the namespace of the controller whose data maps namespaces to the ARNs of the roles assumed by their clusters, as --namespace-identity-roles. The ConfigMap is read on start.",
+	)
+
 	fs.StringVar(
 		&leaderElectionNamespace,
 		"leader-elect-namespace",
@@ -597,6 +818,132 @@ func initFlags(fs *pflag.FlagSet) {
 		"Set custom AWS service endpoins in semi-colon separated format: ${SigningRegion1}:${ServiceID1}=${URL},${ServiceID2}=${URL};${SigningRegion2}...",
 	)
 
+	fs.StringVar(&ownershipTagPrefix,
+		"ownership-tag-prefix",
+		"",
+		fmt.Sprintf("Prefix of the tag key used to mark AWS resources as owned by a cluster, followed by the cluster name. Clusters can override it in their spec. Defaults to %s.", infrav1.NameAWSProviderOwned),
+	)
+
+	fs.BoolVar(&enableCostAllocationTags,
+		"enable-cost-allocation-tags",
+		false,
+		"Tag the AWS resources of each cluster with the UID of its Cluster, so that Cost Explorer can group their costs once the tag is activated as a cost allocation tag. The resources of existing clusters are tagged as well, and the launch templates of AWSMachinePools get a new version.",
+	)
+
+	fs.BoolVar(&enableOwnerNamespaceTag,
+		"enable-owner-namespace-tag",
+		false,
+		"Also tag the AWS resources of each cluster with the namespace of its Cluster. Requires --enable-cost-allocation-tags.",
+	)
+
+	fs.StringVar(&clusterUIDTagKey,
+		"cluster-uid-tag-key",
+		scope.DefaultClusterUIDTagKey,
+		"Key of the cost allocation tag set to the UID of the Cluster.",
+	)
+
+	fs.StringVar(&ownerNamespaceTagKey,
+		"owner-namespace-tag-key",
+		scope.DefaultOwnerNamespaceTagKey,
+		"Key of the cost allocation tag set to the namespace of the Cluster.",
+	)
+
+	fs.BoolVar(&enablePermissionPrecheck,
+		"enable-permission-precheck",
+		false,
+		fmt.Sprintf("Simulate the IAM policy of the controller principal of each AWSCluster against the actions required by the enabled features, and report missing actions with the %s condition.", infrav1.ControllerPermissionsCondition),
+	)
+
+	fs.BoolVar(&enableAPIEndpointProbe,
+		"enable-api-endpoint-probe",
+		false,
+		fmt.Sprintf("Open a TLS connection from the management cluster to the API server endpoint of each AWSCluster, and report the result with the %s condition. Only enable it when the management cluster can reach the workload cluster endpoints.", infrav1.APIEndpointReachableCondition),
+	)
+
+	fs.BoolVar(&enableInstanceDriftAudit,
+		"enable-instance-drift-audit",
+		false,
+		fmt.Sprintf("Compare the live attributes of the instances of AWSMachines and AWSMachinePools with the values applied by the controllers, and report the differences with the %s condition. Nothing is corrected by the audit.", infrav1.DriftDetectedCondition),
+	)
+
+	fs.StringSliceVar(&instanceDriftAttributes,
+		"instance-drift-audit-attributes",
+		drift.Attributes,
+		fmt.Sprintf("Instance attributes compared by the instance drift audit. Supported values: %s.", strings.Join(drift.Attributes, ", ")),
+	)
+
+	fs.DurationVar(&instanceDriftAuditInterval,
+		"instance-drift-audit-interval",
+		drift.DefaultAuditInterval,
+		"The minimum interval at which the instances of an AWSMachine or AWSMachinePool are audited for drift. Each audit describes the instances of the object.",
+	)
+
+	fs.BoolVar(&enableSpotPriceStatus,
+		"enable-spot-price-status",
+		false,
+		"Report the current spot price of the spot instances of each AWSMachinePool in its status.",
+	)
+
+	fs.DurationVar(&spotPriceCacheTTL,
+		"spot-price-cache-ttl",
+		spot.DefaultPriceCacheTTL,
+		"The duration for which the spot price of an instance type in an availability zone is reused by all the AWSMachinePools before it is fetched again.",
+	)
+
+	fs.BoolVar(&enableVolumeEncryptionReport,
+		"enable-volume-encryption-report",
+		false,
+		fmt.Sprintf("Check whether the root volumes of the instances of each AWSCluster are encrypted, and report the unencrypted ones in its status and with the %s condition. Nothing is encrypted by the report.", infrav1.VolumeEncryptionCompliantCondition),
+	)
+
+	fs.DurationVar(&volumeEncryptionReportInterval,
+		"volume-encryption-report-interval",
+		volumeencryption.DefaultReportInterval,
+		"The minimum interval at which the root volumes of an AWSCluster are checked for encryption. Each check describes the instances and the root volumes of the cluster.",
+	)
+
+	fs.BoolVar(&enableNetworkSummary,
+		"enable-network-summary",
+		false,
+		"Publish the VPC, subnets, NAT gateways and security groups of each AWSCluster with their ARNs in its status.networkSummary, for the automation deploying add-ons. Each reconciliation describes the VPC and the NAT gateways of the cluster.",
+	)
+
+	fs.BoolVar(&enableAccountQuotaCheck,
+		"enable-account-quota-check",
+		false,
+		fmt.Sprintf("Export the quotas and the usage of the Auto Scaling groups and launch templates of the account and region of each AWSCluster as metrics, and report with the %s condition when the pending AWSMachinePools of the cluster would exceed them.", infrav1.AccountQuotasCondition),
+	)
+
+	fs.DurationVar(&accountQuotaCheckInterval,
+		"account-quota-check-interval",
+		quotas.DefaultCheckInterval,
+		"Interval after which the quotas and the usage of an account and region are fetched again. The fetched values are shared by all the AWSClusters of the account and region.",
+	)
+
+	fs.BoolVar(&enableCredentialsCheck,
+		"enable-credentials-check",
+		false,
+		"Periodically validate the credentials of the default credential chain of the controller, and the namespace identity roles assumed with them, with STS GetCallerIdentity, and report the controller as not ready while they fail. Identities of clusters are not validated.",
+	)
+
+	fs.DurationVar(&credentialsCheckInterval,
+		"credentials-check-interval",
+		credentialcheck.DefaultCheckInterval,
+		"Interval after which the credentials of the controller are validated again.",
+	)
+
+	fs.DurationVar(&credentialsCheckTimeout,
+		"credentials-check-timeout",
+		credentialcheck.DefaultCheckTimeout,
+		"Time a validation of the credentials of the controller waits for STS before failing.",
+	)
+
+	fs.IntVar(&credentialsCheckThreshold,
+		"credentials-check-failure-threshold",
+		credentialcheck.DefaultFailureThreshold,
+		"Number of consecutive failed validations of the credentials of the controller tolerated before it's reported as not ready.",
+	)
+
 	fs.StringVar(
 		&watchFilterValue,
 		"watch-filter",
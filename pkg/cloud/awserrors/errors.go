@@ -26,23 +26,29 @@ import (
 
 // Error singletons for AWS errors.
 const (
-	AssociationIDNotFound             = "InvalidAssociationID.NotFound"
-	AuthFailure                       = "AuthFailure"
-	BucketAlreadyOwnedByYou           = "BucketAlreadyOwnedByYou"
-	EIPNotFound                       = "InvalidElasticIpID.NotFound"
-	GatewayNotFound                   = "InvalidGatewayID.NotFound"
-	GroupNotFound                     = "InvalidGroup.NotFound"
-	InternetGatewayNotFound           = "InvalidInternetGatewayID.NotFound"
-	InvalidCarrierGatewayNotFound     = "InvalidCarrierGatewayID.NotFound"
-	EgressOnlyInternetGatewayNotFound = "InvalidEgressOnlyInternetGatewayID.NotFound"
-	InUseIPAddress                    = "InvalidIPAddress.InUse"
-	InvalidAccessKeyID                = "InvalidAccessKeyId"
-	InvalidClientTokenID              = "InvalidClientTokenId"
-	InvalidInstanceID                 = "InvalidInstanceID.NotFound"
-	InvalidSubnet                     = "InvalidSubnet"
-	LaunchTemplateNameNotFound        = "InvalidLaunchTemplateName.NotFoundException"
-	LoadBalancerNotFound              = "LoadBalancerNotFound"
-	NATGatewayNotFound                = "InvalidNatGatewayID.NotFound"
+	AssociationIDNotFound              = "InvalidAssociationID.NotFound"
+	AuthFailure                        = "AuthFailure"
+	BucketAlreadyOwnedByYou            = "BucketAlreadyOwnedByYou"
+	DryRunOperation                    = "DryRunOperation"
+	EIPNotFound                        = "InvalidElasticIpID.NotFound"
+	GatewayNotFound                    = "InvalidGatewayID.NotFound"
+	GroupNotFound                      = "InvalidGroup.NotFound"
+	ImageNotFound                      = "InvalidAMIID.NotFound"
+	InternetGatewayNotFound            = "InvalidInternetGatewayID.NotFound"
+	InvalidCarrierGatewayNotFound      = "InvalidCarrierGatewayID.NotFound"
+	EgressOnlyInternetGatewayNotFound  = "InvalidEgressOnlyInternetGatewayID.NotFound"
+	InUseIPAddress                     = "InvalidIPAddress.InUse"
+	InvalidAccessKeyID                 = "InvalidAccessKeyId"
+	InvalidClientTokenID               = "InvalidClientTokenId"
+	InvalidInstanceID                  = "InvalidInstanceID.NotFound"
+	InvalidSubnet                      = "InvalidSubnet"
+	KeyPairNotFound                    = "InvalidKeyPair.NotFound"
+	LaunchTemplateIDNotFound           = "InvalidLaunchTemplateId.NotFound"
+	LaunchTemplateVersionLimitExceeded = "LaunchTemplateVersionLimitExceeded"
+	LaunchTemplateNameNotFound         = "InvalidLaunchTemplateName.NotFoundException"
+	LimitExceeded                      = "LimitExceeded"
+	LoadBalancerNotFound               = "LoadBalancerNotFound"
+	NATGatewayNotFound                 = "InvalidNatGatewayID.NotFound"
 	//nolint:gosec
 	NoCredentialProviders                   = "NoCredentialProviders"
 	NoSuchKey                               = "NoSuchKey"
@@ -112,6 +118,15 @@ func IsBucketAlreadyOwnedByYou(err error) bool {
 	return false
 }
 
+// IsLimitExceeded checks if the request was rejected because a quota of the account, such as the number of
+// versions of a launch template, was exceeded.
+func IsLimitExceeded(err error) bool {
+	if code, ok := Code(err); ok {
+		return code == LimitExceeded || code == LaunchTemplateVersionLimitExceeded
+	}
+	return false
+}
+
 // IsResourceExists checks the state of the resource.
 func IsResourceExists(err error) bool {
 	if code, ok := Code(err); ok {
@@ -172,12 +187,24 @@ func IsInvalidNotFoundError(err error) bool {
 			return true
 		case LaunchTemplateNameNotFound:
 			return true
+		case LaunchTemplateIDNotFound:
+			return true
+		case KeyPairNotFound:
+			return true
 		}
 	}
 
 	return false
 }
 
+// IsDryRunOperation returns whether the error reports that a dry run request would have succeeded.
+func IsDryRunOperation(err error) bool {
+	if code, ok := Code(err); ok {
+		return code == DryRunOperation
+	}
+	return false
+}
+
 // IsPermissionsError tests for common aws permission errors.
 func IsPermissionsError(err error) bool {
 	if code, ok := Code(err); ok {
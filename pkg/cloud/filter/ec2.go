@@ -39,11 +39,12 @@ var EC2 = new(ec2Filters)
 
 type ec2Filters struct{}
 
-// Cluster returns a filter based on the cluster name.
-func (ec2Filters) Cluster(clusterName string) *ec2.Filter {
+// Cluster returns a filter based on the cluster name, matching the ownership tag keys built from
+// any of the given prefixes.
+func (ec2Filters) Cluster(clusterName string, ownershipTagPrefixes ...string) *ec2.Filter {
 	return &ec2.Filter{
 		Name:   aws.String(filterNameTagKey),
-		Values: aws.StringSlice([]string{infrav1.ClusterTagKey(clusterName)}),
+		Values: aws.StringSlice(infrav1.OwnershipTagKeys(clusterName, ownershipTagPrefixes...)),
 	}
 }
 
@@ -55,20 +56,20 @@ func (ec2Filters) Name(name string) *ec2.Filter {
 	}
 }
 
-// ClusterOwned returns a filter using the Cluster API per-cluster tag where
-// the resource is owned.
-func (ec2Filters) ClusterOwned(clusterName string) *ec2.Filter {
+// ClusterOwned returns a filter using the Cluster API per-cluster tag, built from the
+// ownership tag prefix, where the resource is owned.
+func (ec2Filters) ClusterOwned(clusterName, ownershipTagPrefix string) *ec2.Filter {
 	return &ec2.Filter{
-		Name:   aws.String(fmt.Sprintf("tag:%s", infrav1.ClusterTagKey(clusterName))),
+		Name:   aws.String(fmt.Sprintf("tag:%s", infrav1.OwnershipTagKey(ownershipTagPrefix, clusterName))),
 		Values: aws.StringSlice([]string{string(infrav1.ResourceLifecycleOwned)}),
 	}
 }
 
-// ClusterShared returns a filter using the Cluster API per-cluster tag where
-// the resource is shared.
-func (ec2Filters) ClusterShared(clusterName string) *ec2.Filter {
+// ClusterShared returns a filter using the Cluster API per-cluster tag, built from the
+// ownership tag prefix, where the resource is shared.
+func (ec2Filters) ClusterShared(clusterName, ownershipTagPrefix string) *ec2.Filter {
 	return &ec2.Filter{
-		Name:   aws.String(fmt.Sprintf("tag:%s", infrav1.ClusterTagKey(clusterName))),
+		Name:   aws.String(fmt.Sprintf("tag:%s", infrav1.OwnershipTagKey(ownershipTagPrefix, clusterName))),
 		Values: aws.StringSlice([]string{string(infrav1.ResourceLifecycleShared)}),
 	}
 }
@@ -81,6 +82,14 @@ func (ec2Filters) ProviderRole(role string) *ec2.Filter {
 	}
 }
 
+// MachinePool returns a filter using cluster-api-provider-aws machine pool tag.
+func (ec2Filters) MachinePool(name string) *ec2.Filter {
+	return &ec2.Filter{
+		Name:   aws.String(fmt.Sprintf("tag:%s", infrav1.NameAWSMachinePool)),
+		Values: aws.StringSlice([]string{name}),
+	}
+}
+
 // ProviderOwned returns a filter using the cloud provider tag where the resource is owned.
 func (ec2Filters) ProviderOwned(clusterName string) *ec2.Filter {
 	return &ec2.Filter{
@@ -153,6 +162,14 @@ func (ec2Filters) SubnetStates(states ...string) *ec2.Filter {
 	}
 }
 
+// VolumeStates returns a filter based on the list of states passed in.
+func (ec2Filters) VolumeStates(states ...string) *ec2.Filter {
+	return &ec2.Filter{
+		Name:   aws.String("status"),
+		Values: aws.StringSlice(states),
+	}
+}
+
 func (ec2Filters) AvailabilityZone(zone string) *ec2.Filter {
 	return &ec2.Filter{
 		Name:   aws.String(filterAvailabilityZone),
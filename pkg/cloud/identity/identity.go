@@ -21,6 +21,7 @@ import (
 	"bytes"
 	"crypto/sha256"
 	"encoding/gob"
+	"fmt"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -28,6 +29,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/sts/stsiface"
+	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
@@ -144,6 +146,11 @@ func (p *AWSRolePrincipalTypeProvider) Hash() (string, error) {
 	if err != nil {
 		return "", err
 	}
+	// The region is unexported, so it is not encoded along with the provider. Providers of clusters in
+	// different regions must not be shared, as the credentials are retrieved from the STS endpoint of the region.
+	if err := gob.NewEncoder(&roleIdentityValue).Encode(p.region); err != nil {
+		return "", err
+	}
 	hash := sha256.New()
 	return string(hash.Sum(roleIdentityValue.Bytes())), nil
 }
@@ -169,10 +176,37 @@ func (p *AWSRolePrincipalTypeProvider) Retrieve() (credentials.Value, error) {
 		// Update credentials
 		p.credentials = creds
 	}
-	return p.credentials.Get()
+	value, err := p.credentials.Get()
+	if err != nil {
+		return credentials.Value{}, &AssumeRoleError{RoleARN: p.Principal.Spec.RoleArn, Err: err}
+	}
+	return value, nil
+}
+
+// ExpiresAt returns when the current credentials of the assumed role expire, and the role is assumed again.
+func (p *AWSRolePrincipalTypeProvider) ExpiresAt() (time.Time, error) {
+	if p.credentials == nil {
+		return time.Time{}, errors.New("the role wasn't assumed yet")
+	}
+	return p.credentials.ExpiresAt()
 }
 
 // IsExpired checks the expiration state of the AWSRolePrincipalTypeProvider.
 func (p *AWSRolePrincipalTypeProvider) IsExpired() bool {
 	return p.credentials.IsExpired()
 }
+
+// AssumeRoleError is returned when a role can't be assumed. In a chain of roles, it tells the role failing to be
+// assumed, as the errors of the source roles are returned as is.
+type AssumeRoleError struct {
+	RoleARN string
+	Err     error
+}
+
+func (e *AssumeRoleError) Error() string {
+	return fmt.Sprintf("failed to assume role %s: %v", e.RoleARN, e.Err)
+}
+
+func (e *AssumeRoleError) Unwrap() error {
+	return e.Err
+}
@@ -21,6 +21,7 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/service/sts"
 	"github.com/golang/mock/gomock"
@@ -85,11 +86,13 @@ func TestAWSStaticPrincipalTypeProvider(t *testing.T) {
 	}
 
 	testCases := []struct {
-		name      string
-		provider  AWSPrincipalTypeProvider
-		expect    func(m *mock_stsiface.MockSTSAPIMockRecorder)
-		expectErr bool
-		value     credentials.Value
+		name             string
+		provider         AWSPrincipalTypeProvider
+		expect           func(m *mock_stsiface.MockSTSAPIMockRecorder)
+		expectErr        bool
+		expectErrRoleARN string
+		expectErrCode    string
+		value            credentials.Value
 	}{
 		{
 			name:      "Static provider successfully retrieves",
@@ -178,7 +181,35 @@ func TestAWSStaticPrincipalTypeProvider(t *testing.T) {
 					DurationSeconds: ptr.To[int64](int64(roleIdentity.Spec.DurationSeconds)),
 				}).Return(&sts.AssumeRoleOutput{}, errors.New("Not authorized to assume role"))
 			},
-			expectErr: true,
+			expectErr:        true,
+			expectErrRoleARN: roleIdentity.Spec.RoleArn,
+		},
+		{
+			name:     "Role provider reports the AWS error code of the role it cannot assume",
+			provider: roleProvider2,
+			expect: func(m *mock_stsiface.MockSTSAPIMockRecorder) {
+				roleProvider2.credentials.Expire()
+				m.AssumeRoleWithContext(gomock.Any(), &sts.AssumeRoleInput{
+					RoleArn:         aws.String(roleIdentity.Spec.RoleArn),
+					RoleSessionName: aws.String(roleIdentity.Spec.SessionName),
+					DurationSeconds: ptr.To[int64](int64(roleIdentity.Spec.DurationSeconds)),
+				}).Return(&sts.AssumeRoleOutput{
+					Credentials: &sts.Credentials{
+						AccessKeyId:     aws.String("assumedAccessKeyId"),
+						SecretAccessKey: aws.String("assumedSecretAccessKey"),
+						SessionToken:    aws.String("assumedSessionToken"),
+						Expiration:      aws.Time(time.Now().Add(time.Hour)),
+					},
+				}, nil)
+				m.AssumeRoleWithContext(gomock.Any(), &sts.AssumeRoleInput{
+					RoleArn:         aws.String(roleIdentity2.Spec.RoleArn),
+					RoleSessionName: aws.String(roleIdentity2.Spec.SessionName),
+					DurationSeconds: ptr.To[int64](int64(roleIdentity2.Spec.DurationSeconds)),
+				}).Return(nil, awserr.New("AccessDenied", "User is not authorized to perform: sts:AssumeRole", nil))
+			},
+			expectErr:        true,
+			expectErrRoleARN: roleIdentity2.Spec.RoleArn,
+			expectErrCode:    "AccessDenied",
 		},
 	}
 
@@ -190,6 +221,14 @@ func TestAWSStaticPrincipalTypeProvider(t *testing.T) {
 			value, err := tc.provider.Retrieve()
 			if tc.expectErr {
 				g.Expect(err).ToNot(BeNil())
+				var assumeRoleErr *AssumeRoleError
+				g.Expect(errors.As(err, &assumeRoleErr)).To(BeTrue())
+				g.Expect(assumeRoleErr.RoleARN).To(Equal(tc.expectErrRoleARN))
+				if tc.expectErrCode != "" {
+					var awsErr awserr.Error
+					g.Expect(errors.As(err, &awsErr)).To(BeTrue())
+					g.Expect(awsErr.Code()).To(Equal(tc.expectErrCode))
+				}
 				return
 			}
 
@@ -41,6 +41,13 @@ type ScopeUsage interface {
 	ControllerName() string
 }
 
+// ReadOnlyScoper is implemented by the sessions of clusters which can be put in read-only mode, in which the AWS
+// clients created for the session refuse to send mutating requests.
+type ReadOnlyScoper interface {
+	// ReadOnly returns true when the cluster is annotated with the read-only annotation.
+	ReadOnly() bool
+}
+
 // ClusterObject represents a AWS cluster object.
 type ClusterObject interface {
 	conditions.Setter
@@ -81,6 +88,11 @@ type ClusterScoper interface {
 	APIServerPort() int32
 	// AdditionalTags returns any tags that you would like to attach to AWS resources. The returned value will never be nil.
 	AdditionalTags() infrav1.Tags
+	// OwnershipTagPrefix returns the prefix of the ownership tag key the cluster's resources are tagged with.
+	OwnershipTagPrefix() string
+	// OwnershipTagPrefixes returns the prefixes of the ownership tag key the cluster's resources are recognized by,
+	// the current one first.
+	OwnershipTagPrefixes() []string
 	// SetFailureDomain sets the infrastructure provider failure domain key to the spec given as input.
 	SetFailureDomain(id string, spec clusterv1.FailureDomainSpec)
 	// PatchObject persists the cluster configuration and status.
@@ -46,6 +46,9 @@ type ClusterScopeParams struct {
 	Endpoints                    []ServiceEndpoint
 	Session                      awsclient.ConfigProvider
 	TagUnmanagedNetworkResources bool
+	// OwnershipTagPrefix is the prefix of the ownership tag key of the clusters which don't override it.
+	// Defaults to NameAWSProviderOwned.
+	OwnershipTagPrefix string
 }
 
 // NewClusterScope creates a new Scope from the supplied parameters.
@@ -70,16 +73,20 @@ func NewClusterScope(params ClusterScopeParams) (*ClusterScope, error) {
 		AWSCluster:                   params.AWSCluster,
 		controllerName:               params.ControllerName,
 		tagUnmanagedNetworkResources: params.TagUnmanagedNetworkResources,
+		ownershipTagPrefix:           params.OwnershipTagPrefix,
 	}
-
-	session, serviceLimiters, err := sessionForClusterWithRegion(params.Client, clusterScope, params.AWSCluster.Spec.Region, params.Endpoints, params.Logger)
+	helper, err := patch.NewHelper(params.AWSCluster, params.Client)
 	if err != nil {
-		return nil, errors.Errorf("failed to create aws session: %v", err)
+		return nil, errors.Wrap(err, "failed to init patch helper")
 	}
+	resetPendingChanges(params.AWSCluster)
 
-	helper, err := patch.NewHelper(params.AWSCluster, params.Client)
+	session, serviceLimiters, err := sessionForClusterWithRegion(params.Client, clusterScope, params.AWSCluster.Spec.Region, params.Endpoints, params.Logger)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to init patch helper")
+		if patchErr := patchIdentityConditions(helper, params.AWSCluster); patchErr != nil {
+			params.Logger.Error(patchErr, "failed to persist the identity conditions")
+		}
+		return nil, errors.Errorf("failed to create aws session: %v", err)
 	}
 
 	clusterScope.patchHelper = helper
@@ -103,6 +110,7 @@ type ClusterScope struct {
 	controllerName  string
 
 	tagUnmanagedNetworkResources bool
+	ownershipTagPrefix           string
 }
 
 // Network returns the cluster network object.
@@ -138,11 +146,34 @@ func (s *ClusterScope) CNIIngressRules() infrav1.CNIIngressRules {
 	return infrav1.CNIIngressRules{}
 }
 
+// CNIPreset returns the preset of CNI ingress rules of the spec.
+func (s *ClusterScope) CNIPreset() infrav1.CNIPreset {
+	if s.AWSCluster.Spec.NetworkSpec.CNI != nil {
+		return s.AWSCluster.Spec.NetworkSpec.CNI.Preset
+	}
+	return ""
+}
+
+// LegacyClusterTag returns the configuration of the kubernetes.io/cluster/<name> tag.
+func (s *ClusterScope) LegacyClusterTag() *infrav1.LegacyClusterTag {
+	return s.AWSCluster.Spec.NetworkSpec.LegacyClusterTag
+}
+
+// ResourceNaming returns the configuration of the Name tag of the resources created for the cluster.
+func (s *ClusterScope) ResourceNaming() *infrav1.ResourceNaming {
+	return s.AWSCluster.Spec.ResourceNaming
+}
+
 // SecurityGroupOverrides returns the cluster security group overrides.
 func (s *ClusterScope) SecurityGroupOverrides() map[infrav1.SecurityGroupRole]string {
 	return s.AWSCluster.Spec.NetworkSpec.SecurityGroupOverrides
 }
 
+// ApplyRulesToUnmanagedGroups returns whether ingress rules should be reconciled on security group overrides.
+func (s *ClusterScope) ApplyRulesToUnmanagedGroups() bool {
+	return s.AWSCluster.Spec.NetworkSpec.ApplyRulesToUnmanagedGroups
+}
+
 // SecurityGroups returns the cluster security groups as a map, it creates the map if empty.
 func (s *ClusterScope) SecurityGroups() map[infrav1.SecurityGroupRole]infrav1.SecurityGroup {
 	return s.AWSCluster.Status.Network.SecurityGroups
@@ -164,6 +195,11 @@ func (s *ClusterScope) AllSecondaryCidrBlocks() []infrav1.VpcCidrBlock {
 	return s.SecondaryCidrBlocks()
 }
 
+// LocalGatewayRouteTables returns the local gateways used to route traffic of AWS Outpost subnets.
+func (s *ClusterScope) LocalGatewayRouteTables() []infrav1.LocalGatewayRouteTable {
+	return s.AWSCluster.Spec.NetworkSpec.LocalGatewayRouteTables
+}
+
 // Name returns the CAPI cluster name.
 func (s *ClusterScope) Name() string {
 	return s.Cluster.Name
@@ -203,6 +239,11 @@ func (s *ClusterScope) ControlPlaneLoadBalancers() []*infrav1.AWSLoadBalancerSpe
 	}
 }
 
+// ControlPlaneZones returns the availability zones of the failure domains used by control plane machines.
+func (s *ClusterScope) ControlPlaneZones() []string {
+	return s.AWSCluster.Status.ControlPlaneZones()
+}
+
 // ControlPlaneLoadBalancerScheme returns the Classic ELB scheme (public or internal facing).
 // Deprecated: This method is going to be removed in a future release. Use LoadBalancer.Scheme.
 func (s *ClusterScope) ControlPlaneLoadBalancerScheme() infrav1.ELBScheme {
@@ -254,15 +295,23 @@ func (s *ClusterScope) PatchObject() error {
 		infrav1.LoadBalancerReadyCondition,
 	}
 
-	if s.VPC().IsManaged(s.Name()) {
+	if s.VPC().IsManaged(s.Name(), s.OwnershipTagPrefixes()...) {
 		applicableConditions = append(applicableConditions,
-			infrav1.InternetGatewayReadyCondition,
-			infrav1.NatGatewaysReadyCondition,
 			infrav1.RouteTablesReadyCondition,
 			infrav1.VpcEndpointsReadyCondition,
 		)
 
-		if s.AWSCluster.Spec.Bastion.Enabled {
+		// A private only VPC has no internet gateway nor NAT gateways.
+		if !s.VPC().PrivateOnly {
+			applicableConditions = append(applicableConditions, infrav1.InternetGatewayReadyCondition)
+		}
+
+		// Suspended resources don't make the cluster unready.
+		if !s.NatGatewaysSuspended() && !s.VPC().PrivateOnly {
+			applicableConditions = append(applicableConditions, infrav1.NatGatewaysReadyCondition)
+		}
+
+		if s.AWSCluster.Spec.Bastion.Enabled && !s.BastionSuspended() {
 			applicableConditions = append(applicableConditions, infrav1.BastionHostReadyCondition)
 		}
 		if s.VPC().IsIPv6Enabled() {
@@ -293,6 +342,9 @@ func (s *ClusterScope) PatchObject() error {
 			infrav1.LoadBalancerReadyCondition,
 			infrav1.PrincipalUsageAllowedCondition,
 			infrav1.PrincipalCredentialRetrievedCondition,
+			infrav1.IdentityResolvedCondition,
+			infrav1.ResourcesSuspendedCondition,
+			infrav1.PendingChangesCondition,
 		}})
 }
 
@@ -307,7 +359,33 @@ func (s *ClusterScope) AdditionalTags() infrav1.Tags {
 		s.AWSCluster.Spec.AdditionalTags = infrav1.Tags{}
 	}
 
-	return s.AWSCluster.Spec.AdditionalTags.DeepCopy()
+	tags := s.AWSCluster.Spec.AdditionalTags.DeepCopy()
+	// The cost allocation tags are computed, so that they can't be overridden.
+	tags.Merge(costAllocationTags(s.Cluster))
+	return tags
+}
+
+// OwnershipTagPrefix returns the prefix of the ownership tag key the cluster's resources are tagged with.
+func (s *ClusterScope) OwnershipTagPrefix() string {
+	current, _ := ownershipTagPrefixes(s.AWSCluster, s.AWSCluster.Spec.OwnershipTagPrefix, s.ownershipTagPrefix)
+	return current
+}
+
+// OwnershipTagPrefixes returns the prefixes of the ownership tag key the cluster's resources are recognized by,
+// the current one first, followed by the one they're being migrated from, if any.
+func (s *ClusterScope) OwnershipTagPrefixes() []string {
+	return ownershipTagPrefixList(s.AWSCluster, s.AWSCluster.Spec.OwnershipTagPrefix, s.ownershipTagPrefix)
+}
+
+// PreviousOwnershipTagPrefix returns the prefix the cluster's resources are being migrated from, if any.
+func (s *ClusterScope) PreviousOwnershipTagPrefix() string {
+	_, previous := ownershipTagPrefixes(s.AWSCluster, s.AWSCluster.Spec.OwnershipTagPrefix, s.ownershipTagPrefix)
+	return previous
+}
+
+// CompleteOwnershipTagMigration records that the cluster's resources are tagged with the current prefix.
+func (s *ClusterScope) CompleteOwnershipTagMigration() {
+	completeOwnershipTagMigration(s.AWSCluster, s.AWSCluster.Spec.OwnershipTagPrefix, s.ownershipTagPrefix)
 }
 
 // APIServerPort returns the APIServerPort to use when creating the load balancer.
@@ -346,6 +424,11 @@ func (s *ClusterScope) ClusterObj() cloud.ClusterObject {
 	return s.Cluster
 }
 
+// ReadOnly returns true when the AWSCluster is annotated with the read-only annotation.
+func (s *ClusterScope) ReadOnly() bool {
+	return IsReadOnly(s.AWSCluster)
+}
+
 // Session returns the AWS SDK session. Used for creating clients.
 func (s *ClusterScope) Session() awsclient.ConfigProvider {
 	return s.session
@@ -369,16 +452,127 @@ func (s *ClusterScope) TagUnmanagedNetworkResources() bool {
 	return s.tagUnmanagedNetworkResources
 }
 
+// PublishEgressPrefixList returns whether the egress IPs of the cluster are published in a managed prefix list.
+func (s *ClusterScope) PublishEgressPrefixList() bool {
+	return s.AWSCluster.Spec.NetworkSpec.PublishEgressPrefixList
+}
+
+// EgressPrefixListIncludeAPIServerLB returns whether the IPs of the API server load balancer are added to the egress prefix list.
+func (s *ClusterScope) EgressPrefixListIncludeAPIServerLB() bool {
+	return s.AWSCluster.Spec.NetworkSpec.EgressPrefixListIncludeAPIServerLB
+}
+
 // SetBastionInstance sets the bastion instance in the status of the cluster.
 func (s *ClusterScope) SetBastionInstance(instance *infrav1.Instance) {
 	s.AWSCluster.Status.Bastion = instance
 }
 
+// BastionSuspended returns whether the bastion host is suspended by the cost savings schedule.
+func (s *ClusterScope) BastionSuspended() bool {
+	return s.AWSCluster.Status.CostSavings.Suspended(infrav1.CostSavingsResourceBastion)
+}
+
+// NatGatewaysSuspended returns whether the NAT gateways are suspended by the cost savings schedule.
+func (s *ClusterScope) NatGatewaysSuspended() bool {
+	return s.AWSCluster.Status.CostSavings.Suspended(infrav1.CostSavingsResourceNatGateways)
+}
+
+// SuspendedNatGatewayAllocations returns the allocation IDs of the Elastic IPs of the suspended NAT gateways
+// by ID of their public subnet.
+func (s *ClusterScope) SuspendedNatGatewayAllocations() map[string]string {
+	if s.AWSCluster.Status.CostSavings == nil {
+		return nil
+	}
+	return s.AWSCluster.Status.CostSavings.NatGatewayAllocations
+}
+
+// SetSuspendedNatGatewayAllocations sets the allocation IDs of the Elastic IPs of the suspended NAT gateways.
+func (s *ClusterScope) SetSuspendedNatGatewayAllocations(allocations map[string]string) {
+	if s.AWSCluster.Status.CostSavings == nil {
+		if len(allocations) == 0 {
+			return
+		}
+		s.AWSCluster.Status.CostSavings = &infrav1.CostSavingsStatus{}
+	}
+	s.AWSCluster.Status.CostSavings.NatGatewayAllocations = nil
+	if len(allocations) > 0 {
+		s.AWSCluster.Status.CostSavings.NatGatewayAllocations = allocations
+	}
+}
+
 // SSHKeyName returns the SSH key name to use for instances.
 func (s *ClusterScope) SSHKeyName() *string {
+	if s.AWSCluster.Spec.SSHKey != nil {
+		return &s.AWSCluster.Spec.SSHKey.Name
+	}
 	return s.AWSCluster.Spec.SSHKeyName
 }
 
+// SSHKey returns the SSH key pair managed for the cluster, if any.
+func (s *ClusterScope) SSHKey() *infrav1.SSHKey {
+	return s.AWSCluster.Spec.SSHKey
+}
+
+// SSHKeyPair returns the observed state of the SSH key pair managed for the cluster.
+func (s *ClusterScope) SSHKeyPair() *infrav1.SSHKeyPairStatus {
+	return s.AWSCluster.Status.SSHKeyPair
+}
+
+// SetSSHKeyPair sets the observed state of the SSH key pair managed for the cluster.
+func (s *ClusterScope) SetSSHKeyPair(status *infrav1.SSHKeyPairStatus) {
+	s.AWSCluster.Status.SSHKeyPair = status
+}
+
+// NodeTerminationHandling returns the node termination handling configuration of the cluster.
+func (s *ClusterScope) NodeTerminationHandling() *infrav1.NodeTerminationHandling {
+	return s.AWSCluster.Spec.NodeTerminationHandling
+}
+
+// NodeTerminationHandlingQueueName returns the name of the node termination handler queue.
+func (s *ClusterScope) NodeTerminationHandlingQueueName() string {
+	if nth := s.AWSCluster.Spec.NodeTerminationHandling; nth != nil && nth.QueueName != "" {
+		return nth.QueueName
+	}
+	return defaultNodeTerminationHandlingQueueName(s.Name())
+}
+
+// NodeTerminationHandlingStatus returns the observed state of the node termination handler resources.
+func (s *ClusterScope) NodeTerminationHandlingStatus() *infrav1.NodeTerminationHandlingStatus {
+	return s.AWSCluster.Status.NodeTerminationHandling
+}
+
+// SetNodeTerminationHandlingStatus sets the observed state of the node termination handler resources.
+func (s *ClusterScope) SetNodeTerminationHandlingStatus(status *infrav1.NodeTerminationHandlingStatus) {
+	s.AWSCluster.Status.NodeTerminationHandling = status
+}
+
+// NodeRoleManagement returns the node role configuration of the cluster.
+func (s *ClusterScope) NodeRoleManagement() *infrav1.NodeRoleManagement {
+	return s.AWSCluster.Spec.NodeRoleManagement
+}
+
+// NodeRoleName returns the name of the node role and of its instance profile.
+func (s *ClusterScope) NodeRoleName() string {
+	if m := s.AWSCluster.Spec.NodeRoleManagement; m != nil && m.NamePrefix != "" {
+		return fmt.Sprintf("%s-nodes", m.NamePrefix)
+	}
+	// Roles created before the namespace was part of the default name keep their name.
+	if status := s.AWSCluster.Status.NodeRole; status != nil && status.RoleName != "" {
+		return status.RoleName
+	}
+	return defaultNodeRoleName(s.Namespace(), s.Name())
+}
+
+// NodeRoleStatus returns the observed state of the node role and of its instance profile.
+func (s *ClusterScope) NodeRoleStatus() *infrav1.NodeRoleStatus {
+	return s.AWSCluster.Status.NodeRole
+}
+
+// SetNodeRoleStatus sets the observed state of the node role and of its instance profile.
+func (s *ClusterScope) SetNodeRoleStatus(status *infrav1.NodeRoleStatus) {
+	s.AWSCluster.Status.NodeRole = status
+}
+
 // ControllerName returns the name of the controller that
 // created the ClusterScope.
 func (s *ClusterScope) ControllerName() string {
@@ -0,0 +1,83 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+const (
+	// DefaultClusterUIDTagKey is the default key of the cost allocation tag set to the UID of the Cluster.
+	DefaultClusterUIDTagKey = "capa:cluster-uid"
+	// DefaultOwnerNamespaceTagKey is the default key of the cost allocation tag set to the namespace of the Cluster.
+	DefaultOwnerNamespaceTagKey = "capa:owner-namespace"
+)
+
+// CostAllocationTagOptions configures the cost allocation tags added to the resources of every cluster, which
+// Cost Explorer can group costs by once they are activated as cost allocation tags.
+type CostAllocationTagOptions struct {
+	// ClusterUIDKey is the key of the tag set to the UID of the Cluster. No tag is set when empty.
+	ClusterUIDKey string
+	// OwnerNamespaceKey is the key of the tag set to the namespace of the Cluster. No tag is set when empty.
+	OwnerNamespaceKey string
+}
+
+// Validate checks that the keys of the cost allocation tags are valid AWS tag keys.
+func (o CostAllocationTagOptions) Validate() error {
+	tags := infrav1.Tags{}
+	if o.ClusterUIDKey != "" {
+		tags[o.ClusterUIDKey] = ""
+	}
+	if o.OwnerNamespaceKey != "" {
+		if _, ok := tags[o.OwnerNamespaceKey]; ok {
+			return errors.Errorf("the cluster UID and owner namespace cost allocation tags have the same key %q", o.OwnerNamespaceKey)
+		}
+		tags[o.OwnerNamespaceKey] = ""
+	}
+	if errs := tags.Validate(); len(errs) > 0 {
+		return errors.Wrap(field.ErrorList(errs).ToAggregate(), "invalid cost allocation tag key")
+	}
+	return nil
+}
+
+// costAllocationTagOptions are the cost allocation tags added to the resources of every cluster.
+var costAllocationTagOptions CostAllocationTagOptions
+
+// SetCostAllocationTagOptions sets the cost allocation tags added to the resources of every cluster. The tags are
+// added to the additional tags of the clusters, so that the resources of existing clusters are tagged as well.
+func SetCostAllocationTagOptions(options CostAllocationTagOptions) {
+	costAllocationTagOptions = options
+}
+
+// costAllocationTags returns the cost allocation tags of the resources of a cluster.
+func costAllocationTags(cluster *clusterv1.Cluster) infrav1.Tags {
+	tags := infrav1.Tags{}
+	if cluster == nil {
+		return tags
+	}
+	if key := costAllocationTagOptions.ClusterUIDKey; key != "" && cluster.UID != "" {
+		tags[key] = string(cluster.UID)
+	}
+	if key := costAllocationTagOptions.OwnerNamespaceKey; key != "" {
+		tags[key] = cluster.Namespace
+	}
+	return tags
+}
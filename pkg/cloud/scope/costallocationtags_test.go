@@ -0,0 +1,111 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestCostAllocationTagOptionsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		options CostAllocationTagOptions
+		wantErr bool
+	}{
+		{
+			name:    "default keys",
+			options: CostAllocationTagOptions{ClusterUIDKey: DefaultClusterUIDTagKey, OwnerNamespaceKey: DefaultOwnerNamespaceTagKey},
+		},
+		{
+			name:    "custom keys",
+			options: CostAllocationTagOptions{ClusterUIDKey: "example.com/k8s-cluster-id", OwnerNamespaceKey: "example.com/team"},
+		},
+		{
+			name:    "reserved prefix",
+			options: CostAllocationTagOptions{ClusterUIDKey: "aws:cluster-uid"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid characters",
+			options: CostAllocationTagOptions{ClusterUIDKey: "cluster#uid"},
+			wantErr: true,
+		},
+		{
+			name:    "same key for both tags",
+			options: CostAllocationTagOptions{ClusterUIDKey: "capa:cluster", OwnerNamespaceKey: "capa:cluster"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := tt.options.Validate()
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+		})
+	}
+}
+
+func TestCostAllocationTags(t *testing.T) {
+	defer SetCostAllocationTagOptions(CostAllocationTagOptions{})
+
+	newScope := func() *ClusterScope {
+		return &ClusterScope{
+			Cluster: &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "team-a", UID: "1234-abcd"}},
+			AWSCluster: &infrav1.AWSCluster{
+				Spec: infrav1.AWSClusterSpec{AdditionalTags: infrav1.Tags{"env": "dev", DefaultClusterUIDTagKey: "overridden"}},
+			},
+		}
+	}
+
+	t.Run("should not add tags unless enabled", func(t *testing.T) {
+		g := NewWithT(t)
+		SetCostAllocationTagOptions(CostAllocationTagOptions{})
+
+		g.Expect(newScope().AdditionalTags()).To(Equal(infrav1.Tags{"env": "dev", DefaultClusterUIDTagKey: "overridden"}))
+	})
+
+	t.Run("should add the cluster UID tag over the additional tags", func(t *testing.T) {
+		g := NewWithT(t)
+		SetCostAllocationTagOptions(CostAllocationTagOptions{ClusterUIDKey: DefaultClusterUIDTagKey})
+
+		scope := newScope()
+		g.Expect(scope.AdditionalTags()).To(Equal(infrav1.Tags{"env": "dev", DefaultClusterUIDTagKey: "1234-abcd"}))
+		g.Expect(scope.AWSCluster.Spec.AdditionalTags).To(HaveKeyWithValue(DefaultClusterUIDTagKey, "overridden"))
+	})
+
+	t.Run("should add the owner namespace tag with a custom key", func(t *testing.T) {
+		g := NewWithT(t)
+		SetCostAllocationTagOptions(CostAllocationTagOptions{ClusterUIDKey: "example.com/cluster-id", OwnerNamespaceKey: "example.com/team"})
+
+		g.Expect(newScope().AdditionalTags()).To(Equal(infrav1.Tags{
+			"env":                    "dev",
+			DefaultClusterUIDTagKey:  "overridden",
+			"example.com/cluster-id": "1234-abcd",
+			"example.com/team":       "team-a",
+		}))
+	})
+}
@@ -43,9 +43,15 @@ type EC2Scope interface {
 	// SetBastionInstance sets the bastion instance in the status of the cluster.
 	SetBastionInstance(instance *infrav1.Instance)
 
+	// BastionSuspended returns whether the bastion host is suspended by the cost savings schedule.
+	BastionSuspended() bool
+
 	// SSHKeyName returns the SSH key name to use for instances.
 	SSHKeyName() *string
 
+	// LegacyClusterTag returns the configuration of the kubernetes.io/cluster/<name> tag.
+	LegacyClusterTag() *infrav1.LegacyClusterTag
+
 	// ImageLookupFormat returns the format string to use when looking up AMIs
 	ImageLookupFormat() string
 
@@ -55,3 +61,18 @@ type EC2Scope interface {
 	// ImageLookupBaseOS returns the base operating system name to use when looking up AMIs
 	ImageLookupBaseOS() string
 }
+
+// SSHKeyPairScope is the interface for the scope of a cluster whose SSH key pair is imported and
+// managed by the ec2 service.
+type SSHKeyPairScope interface {
+	EC2Scope
+
+	// SSHKey returns the SSH key pair to import, or nil if the cluster doesn't manage one.
+	SSHKey() *infrav1.SSHKey
+
+	// SSHKeyPair returns the observed state of the imported key pair.
+	SSHKeyPair() *infrav1.SSHKeyPairStatus
+
+	// SetSSHKeyPair sets the observed state of the imported key pair in the status of the cluster.
+	SetSSHKeyPair(status *infrav1.SSHKeyPairStatus)
+}
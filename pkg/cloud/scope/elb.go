@@ -55,4 +55,10 @@ type ELBScope interface {
 	// ControlPlaneLoadBalancers returns both the ControlPlaneLoadBalancer and SecondaryControlPlaneLoadBalancer AWSLoadBalancerSpecs.
 	// The control plane load balancers should always be returned in the above order.
 	ControlPlaneLoadBalancers() []*infrav1.AWSLoadBalancerSpec
+
+	// ControlPlaneZones returns the availability zones of the failure domains used by control plane machines.
+	ControlPlaneZones() []string
+
+	// ResourceNaming returns the configuration of the Name tag of the resources created for the cluster.
+	ResourceNaming() *infrav1.ResourceNaming
 }
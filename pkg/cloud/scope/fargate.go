@@ -48,6 +48,9 @@ type FargateProfileScopeParams struct {
 	Session        awsclient.ConfigProvider
 
 	EnableIAM bool
+	// OwnershipTagPrefix is the prefix of the ownership tag key of the clusters which don't override it.
+	// Defaults to NameAWSProviderOwned.
+	OwnershipTagPrefix string
 }
 
 // NewFargateProfileScope creates a new Scope from the supplied parameters.
@@ -78,18 +81,20 @@ func NewFargateProfileScope(params FargateProfileScopeParams) (*FargateProfileSc
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to init patch helper")
 	}
+	resetPendingChanges(params.FargateProfile)
 
 	return &FargateProfileScope{
-		Logger:          *params.Logger,
-		Client:          params.Client,
-		Cluster:         params.Cluster,
-		ControlPlane:    params.ControlPlane,
-		FargateProfile:  params.FargateProfile,
-		patchHelper:     helper,
-		session:         session,
-		serviceLimiters: serviceLimiters,
-		controllerName:  params.ControllerName,
-		enableIAM:       params.EnableIAM,
+		Logger:             *params.Logger,
+		Client:             params.Client,
+		Cluster:            params.Cluster,
+		ControlPlane:       params.ControlPlane,
+		FargateProfile:     params.FargateProfile,
+		patchHelper:        helper,
+		session:            session,
+		serviceLimiters:    serviceLimiters,
+		controllerName:     params.ControllerName,
+		enableIAM:          params.EnableIAM,
+		ownershipTagPrefix: params.OwnershipTagPrefix,
 	}, nil
 }
 
@@ -107,7 +112,8 @@ type FargateProfileScope struct {
 	serviceLimiters throttle.ServiceLimiters
 	controllerName  string
 
-	enableIAM bool
+	enableIAM          bool
+	ownershipTagPrefix string
 }
 
 // ManagedPoolName returns the managed machine pool name.
@@ -140,7 +146,10 @@ func (s *FargateProfileScope) AdditionalTags() infrav1.Tags {
 		s.FargateProfile.Spec.AdditionalTags = infrav1.Tags{}
 	}
 
-	return s.FargateProfile.Spec.AdditionalTags.DeepCopy()
+	tags := s.FargateProfile.Spec.AdditionalTags.DeepCopy()
+	// The cost allocation tags are computed, so that they can't be overridden.
+	tags.Merge(costAllocationTags(s.Cluster))
+	return tags
 }
 
 // RoleName returns the node group role name.
@@ -196,6 +205,7 @@ func (s *FargateProfileScope) PatchObject() error {
 			expinfrav1.EKSFargateCreatingCondition,
 			expinfrav1.EKSFargateDeletingCondition,
 			expinfrav1.IAMFargateRolesReadyCondition,
+			infrav1.PendingChangesCondition,
 		}})
 }
 
@@ -214,6 +224,12 @@ func (s *FargateProfileScope) ClusterObj() cloud.ClusterObject {
 	return s.Cluster
 }
 
+// ReadOnly returns true when the AWSManagedControlPlane of the Fargate profile is annotated with the read-only
+// annotation.
+func (s *FargateProfileScope) ReadOnly() bool {
+	return IsReadOnly(s.ControlPlane)
+}
+
 // Session returns the AWS SDK session. Used for creating clients.
 func (s *FargateProfileScope) Session() awsclient.ConfigProvider {
 	return s.session
@@ -225,6 +241,18 @@ func (s *FargateProfileScope) ControllerName() string {
 	return s.controllerName
 }
 
+// OwnershipTagPrefix returns the prefix of the ownership tag key the cluster's resources are tagged with.
+func (s *FargateProfileScope) OwnershipTagPrefix() string {
+	current, _ := ownershipTagPrefixes(s.ControlPlane, s.ControlPlane.Spec.OwnershipTagPrefix, s.ownershipTagPrefix)
+	return current
+}
+
+// OwnershipTagPrefixes returns the prefixes of the ownership tag key the cluster's resources are recognized by,
+// the current one first.
+func (s *FargateProfileScope) OwnershipTagPrefixes() []string {
+	return ownershipTagPrefixList(s.ControlPlane, s.ControlPlane.Spec.OwnershipTagPrefix, s.ownershipTagPrefix)
+}
+
 // KubernetesClusterName is the name of the EKS cluster name.
 func (s *FargateProfileScope) KubernetesClusterName() string {
 	return s.ControlPlane.Spec.EKSClusterName
@@ -37,6 +37,9 @@ type LaunchTemplateScope interface {
 	SetLaunchTemplateIDStatus(id string)
 	GetLaunchTemplateLatestVersionStatus() string
 	SetLaunchTemplateLatestVersionStatus(version string)
+	GetAdditionalSecurityGroupIDsStatus() []string
+	SetAdditionalSecurityGroupIDsStatus(ids []string)
+	GetSecurityGroupFilterCache() *SecurityGroupFilterCache
 	GetRawBootstrapData() ([]byte, *types.NamespacedName, error)
 
 	IsEKSManaged() bool
@@ -51,6 +54,76 @@ type LaunchTemplateScope interface {
 	logger.Wrapper
 }
 
+// LaunchTemplateOverridesScope is implemented by launch template scopes which also manage a launch template
+// for each instance type override that sets its own root volume or bootstrap data.
+type LaunchTemplateOverridesScope interface {
+	GetMixedInstancesPolicy() *expinfrav1.MixedInstancesPolicy
+	GetLaunchTemplateOverrides() []expinfrav1.Overrides
+	GetOverrideRawBootstrapData(instanceType string) ([]byte, *types.NamespacedName, error)
+	GetOverrideLaunchTemplatesStatus() []expinfrav1.OverrideLaunchTemplate
+	SetOverrideLaunchTemplatesStatus(templates []expinfrav1.OverrideLaunchTemplate)
+}
+
+// LaunchTemplateChangeScope is implemented by launch template scopes which report the last change of their
+// launch template, and which can roll out a change of its userdata alone.
+type LaunchTemplateChangeScope interface {
+	SetLaunchTemplateChangeStatus(change *expinfrav1.LaunchTemplateChange)
+	TriggerRefreshOnUserDataChange() bool
+}
+
+// LaunchTemplateSubnetsScope is implemented by launch template scopes whose instances can be placed
+// in subnets referenced by ID.
+type LaunchTemplateSubnetsScope interface {
+	GetSubnetIDs() []string
+}
+
+// DedicatedSecurityGroupScope is implemented by launch template scopes whose instances can be attached to
+// a security group owned by the machine pool.
+type DedicatedSecurityGroupScope interface {
+	Name() string
+	GetDedicatedSecurityGroup() *expinfrav1.DedicatedSecurityGroup
+	GetDedicatedSecurityGroupIDStatus() string
+	SetDedicatedSecurityGroupIDStatus(id string)
+}
+
+// CopiedAMIScope is implemented by launch template scopes which track the copy of the AMI made from
+// the source region of the launch template AMI.
+type CopiedAMIScope interface {
+	GetCopiedAMIStatus() *expinfrav1.CopiedAMI
+	SetCopiedAMIStatus(ami *expinfrav1.CopiedAMI)
+}
+
+// LaunchTemplateRollbackScope is implemented by launch template scopes which record the version of the launch
+// template before its last change, so that it can be rolled back to it.
+type LaunchTemplateRollbackScope interface {
+	GetPreviousLaunchTemplateVersionStatus() string
+}
+
+// OverrideLaunchTemplateName returns the name of the launch template managed for an instance type override.
+func OverrideLaunchTemplateName(launchTemplateName, instanceType string) string {
+	return launchTemplateName + "-" + instanceType
+}
+
+// GetOverrideUserData returns the bootstrap data set for the instances of an instance type override, or nil when
+// they use the bootstrap data of the MachinePool.
+func GetOverrideUserData(launchTemplate *expinfrav1.AWSLaunchTemplate, instanceType string) *expinfrav1.OverrideUserData {
+	if instanceType == "" {
+		return nil
+	}
+	for i := range launchTemplate.PerOverrideUserData {
+		if launchTemplate.PerOverrideUserData[i].InstanceType == instanceType {
+			return &launchTemplate.PerOverrideUserData[i]
+		}
+	}
+	return nil
+}
+
+// HasOverrideLaunchTemplate tells whether the instances of an instance type override are launched from a launch
+// template of their own, which is the case when the override sets its own root volume or bootstrap data.
+func HasOverrideLaunchTemplate(launchTemplate *expinfrav1.AWSLaunchTemplate, override expinfrav1.Overrides) bool {
+	return override.RootVolume != nil || GetOverrideUserData(launchTemplate, override.InstanceType) != nil
+}
+
 // ResourceServiceToUpdate is a struct that contains the resource ID and the resource service to update.
 type ResourceServiceToUpdate struct {
 	ResourceID      *string
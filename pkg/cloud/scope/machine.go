@@ -114,6 +114,13 @@ func (m *MachineScope) IsControlPlane() bool {
 	return util.IsControlPlaneMachine(m.Machine)
 }
 
+// IsMachinePoolMachine returns true if the machine is an instance of a machine pool, which the machine pool
+// launches and terminates.
+func (m *MachineScope) IsMachinePoolMachine() bool {
+	_, ok := m.AWSMachine.Labels[clusterv1.MachinePoolNameLabel]
+	return ok
+}
+
 // Role returns the machine role from the labels.
 func (m *MachineScope) Role() string {
 	if util.IsControlPlaneMachine(m.Machine) {
@@ -312,6 +319,8 @@ func (m *MachineScope) PatchObject() error {
 			infrav1.InstanceReadyCondition,
 			infrav1.SecurityGroupsReadyCondition,
 			infrav1.ELBAttachedCondition,
+			infrav1.ReconciliationSkippedCondition,
+			infrav1.DriftDetectedCondition,
 		}})
 }
 
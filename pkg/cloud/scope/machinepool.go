@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/aws/aws-sdk-go/service/autoscaling"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -33,16 +34,21 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
 	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/userdata"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controllers/remote"
 	capierrors "sigs.k8s.io/cluster-api/errors"
 	expclusterv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/annotations"
 	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/patch"
 )
 
+// asgInstanceHealthy is the health status of the instances of an ASG which passed their health checks.
+const asgInstanceHealthy = "Healthy"
+
 // MachinePoolScope defines a scope defined around a machine and its cluster.
 type MachinePoolScope struct {
 	logger.Logger
@@ -54,6 +60,8 @@ type MachinePoolScope struct {
 	MachinePool    *expclusterv1.MachinePool
 	InfraCluster   EC2Scope
 	AWSMachinePool *expinfrav1.AWSMachinePool
+
+	SecurityGroupFilterCache *SecurityGroupFilterCache
 }
 
 // MachinePoolScopeParams defines a scope defined around a machine and its cluster.
@@ -65,6 +73,10 @@ type MachinePoolScopeParams struct {
 	MachinePool    *expclusterv1.MachinePool
 	InfraCluster   EC2Scope
 	AWSMachinePool *expinfrav1.AWSMachinePool
+
+	// SecurityGroupFilterCache is an optional cache for security group IDs resolved from filters,
+	// shared across reconciles.
+	SecurityGroupFilterCache *SecurityGroupFilterCache
 }
 
 // GetProviderID returns the AWSMachine providerID from the spec.
@@ -118,6 +130,8 @@ func NewMachinePoolScope(params MachinePoolScopeParams) (*MachinePoolScope, erro
 		MachinePool:    params.MachinePool,
 		InfraCluster:   params.InfraCluster,
 		AWSMachinePool: params.AWSMachinePool,
+
+		SecurityGroupFilterCache: params.SecurityGroupFilterCache,
 	}, nil
 }
 
@@ -132,20 +146,83 @@ func (m *MachinePoolScope) Namespace() string {
 }
 
 // GetRawBootstrapData returns the bootstrap data from the secret in the Machine's bootstrap.dataSecretName,
-// including the secret's namespaced name.
+// including the secret's namespaced name. The node labels and taints of the AWSMachinePool are added to the
+// arguments of the kubelet.
 func (m *MachinePoolScope) GetRawBootstrapData() ([]byte, *types.NamespacedName, error) {
-	data, _, bootstrapDataSecretKey, err := m.getBootstrapData()
+	if m.MachinePool.Spec.Template.Spec.Bootstrap.DataSecretName == nil {
+		return nil, nil, errors.New("error retrieving bootstrap data: linked Machine's bootstrap.dataSecretName is nil")
+	}
+	return m.getRawBootstrapData(*m.MachinePool.Spec.Template.Spec.Bootstrap.DataSecretName)
+}
 
-	return data, bootstrapDataSecretKey, err
+// GetOverrideRawBootstrapData returns the bootstrap data of the instances of an instance type override, from the
+// secret set for it in spec.awsLaunchTemplate.perOverrideUserData, or else from the secret of the MachinePool.
+func (m *MachinePoolScope) GetOverrideRawBootstrapData(instanceType string) ([]byte, *types.NamespacedName, error) {
+	if userData := GetOverrideUserData(&m.AWSMachinePool.Spec.AWSLaunchTemplate, instanceType); userData != nil {
+		return m.getRawBootstrapData(userData.DataSecretName)
+	}
+	return m.GetRawBootstrapData()
 }
 
-func (m *MachinePoolScope) getBootstrapData() ([]byte, string, *types.NamespacedName, error) {
-	if m.MachinePool.Spec.Template.Spec.Bootstrap.DataSecretName == nil {
-		return nil, "", nil, errors.New("error retrieving bootstrap data: linked Machine's bootstrap.dataSecretName is nil")
+func (m *MachinePoolScope) getRawBootstrapData(secretName string) ([]byte, *types.NamespacedName, error) {
+	data, format, bootstrapDataSecretKey, err := m.getBootstrapData(secretName)
+	if err != nil {
+		return data, bootstrapDataSecretKey, err
+	}
+
+	if m.AWSMachinePool.Spec.AWSLaunchTemplate.OSFamily == expinfrav1.OSFamilyBottlerocket {
+		data, err = userdata.MergeBottlerocketSettings(data, m.bottlerocketSettings())
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to merge the Bottlerocket settings into the bootstrap data of AWSMachinePool %s/%s", m.Namespace(), m.Name())
+		}
+		return data, bootstrapDataSecretKey, nil
+	}
+
+	args := userdata.KubeletNodeArgs(m.AWSMachinePool.Spec.NodeLabels, m.kubeletNodeTaints())
+	data, err = userdata.AddKubeletExtraArgs(data, format, args)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to add the node labels and taints to the bootstrap data of AWSMachinePool %s/%s", m.Namespace(), m.Name())
+	}
+
+	return data, bootstrapDataSecretKey, nil
+}
+
+// bottlerocketSettings returns the settings of the AWSMachinePool merged into the user data of Bottlerocket instances.
+func (m *MachinePoolScope) bottlerocketSettings() userdata.BottlerocketSettings {
+	settings := userdata.BottlerocketSettings{NodeLabels: m.AWSMachinePool.Spec.NodeLabels}
+	if len(m.AWSMachinePool.Spec.NodeTaints) > 0 {
+		settings.NodeTaints = map[string][]string{}
+		for _, taint := range m.AWSMachinePool.Spec.NodeTaints {
+			settings.NodeTaints[taint.Key] = append(settings.NodeTaints[taint.Key], fmt.Sprintf("%s:%s", taint.Value, kubeletTaintEffects[taint.Effect]))
+		}
+	}
+	if bottlerocket := m.AWSMachinePool.Spec.AWSLaunchTemplate.Bottlerocket; bottlerocket != nil {
+		settings.AdminContainer = bottlerocket.AdminContainer
+		settings.HTTPSProxy = bottlerocket.HTTPSProxy
+		settings.NoProxy = bottlerocket.NoProxy
+	}
+	return settings
+}
+
+// kubeletTaintEffects maps the effects of the taints of the AWSMachinePool spec to the ones of the kubelet.
+var kubeletTaintEffects = map[expinfrav1.TaintEffect]corev1.TaintEffect{
+	expinfrav1.TaintEffectNoSchedule:       corev1.TaintEffectNoSchedule,
+	expinfrav1.TaintEffectNoExecute:        corev1.TaintEffectNoExecute,
+	expinfrav1.TaintEffectPreferNoSchedule: corev1.TaintEffectPreferNoSchedule,
+}
+
+// kubeletNodeTaints returns the node taints of the AWSMachinePool formatted for --register-with-taints.
+func (m *MachinePoolScope) kubeletNodeTaints() []string {
+	taints := make([]string, 0, len(m.AWSMachinePool.Spec.NodeTaints))
+	for _, taint := range m.AWSMachinePool.Spec.NodeTaints {
+		taints = append(taints, fmt.Sprintf("%s=%s:%s", taint.Key, taint.Value, kubeletTaintEffects[taint.Effect]))
 	}
+	return taints
+}
 
+func (m *MachinePoolScope) getBootstrapData(secretName string) ([]byte, string, *types.NamespacedName, error) {
 	secret := &corev1.Secret{}
-	key := types.NamespacedName{Namespace: m.Namespace(), Name: *m.MachinePool.Spec.Template.Spec.Bootstrap.DataSecretName}
+	key := types.NamespacedName{Namespace: m.Namespace(), Name: secretName}
 
 	if err := m.Client.Get(context.TODO(), key, secret); err != nil {
 		return nil, "", nil, errors.Wrapf(err, "failed to retrieve bootstrap data secret %s for AWSMachinePool %s/%s", key.Name, m.Namespace(), m.Name())
@@ -180,6 +257,11 @@ func (m *MachinePoolScope) PatchObject() error {
 		patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{
 			expinfrav1.ASGReadyCondition,
 			expinfrav1.LaunchTemplateReadyCondition,
+			infrav1.ReconciliationSkippedCondition,
+			infrav1.DriftDetectedCondition,
+			expinfrav1.ASGSuspendedProcessesCondition,
+			expinfrav1.InstanceRefreshInProgressCondition,
+			expinfrav1.ASGExternallyManagedCondition,
 		}})
 }
 
@@ -214,6 +296,12 @@ func (m *MachinePoolScope) SetFailureReason(v capierrors.MachineStatusError) {
 	m.AWSMachinePool.Status.FailureReason = &v
 }
 
+// IsExternallyManaged returns true when the AWSMachinePool is annotated with cluster.x-k8s.io/managed-by, its ASG
+// and launch template being managed by other tooling.
+func (m *MachinePoolScope) IsExternallyManaged() bool {
+	return annotations.IsExternallyManaged(m.AWSMachinePool)
+}
+
 // HasFailed returns true when the AWSMachinePool's Failure reason or Failure message is populated.
 func (m *MachinePoolScope) HasFailed() bool {
 	return m.AWSMachinePool.Status.FailureReason != nil || m.AWSMachinePool.Status.FailureMessage != nil
@@ -234,6 +322,17 @@ func (m *MachinePoolScope) SetASGStatus(v expinfrav1.ASGStatus) {
 	m.AWSMachinePool.Status.ASGStatus = &v
 }
 
+// SetASGCreated records in the AWSMachinePool status that the ASG was just created.
+func (m *MachinePoolScope) SetASGCreated(name string) {
+	now := metav1.Now()
+	m.AWSMachinePool.Status.ASG = &expinfrav1.ASGReference{Name: name, CreatedAt: &now}
+}
+
+// SetASGDescribed records in the AWSMachinePool status the ASG described by the AutoScaling API.
+func (m *MachinePoolScope) SetASGDescribed(asg *expinfrav1.AutoScalingGroup) {
+	m.AWSMachinePool.Status.ASG = &expinfrav1.ASGReference{Name: asg.Name, ARN: asg.ID}
+}
+
 // GetObjectMeta returns the AWSMachinePool ObjectMeta.
 func (m *MachinePoolScope) GetObjectMeta() *metav1.ObjectMeta {
 	return &m.AWSMachinePool.ObjectMeta
@@ -267,11 +366,73 @@ func (m *MachinePoolScope) GetLaunchTemplateLatestVersionStatus() string {
 	return ""
 }
 
+// ASGLaunchTemplateVersion returns the version of its primary launch template the ASG launches: the version of a
+// referenced launch template, the last version which passed the validation of a launch template validated before
+// use, or else the latest version.
+func (m *MachinePoolScope) ASGLaunchTemplateVersion() string {
+	lt := m.AWSMachinePool.Spec.AWSLaunchTemplate
+	if lt.Ref != nil {
+		return lt.Ref.Version
+	}
+	if lt.ValidateBeforeUse && m.GetLaunchTemplateLatestVersionStatus() != "" {
+		return m.GetLaunchTemplateLatestVersionStatus()
+	}
+	return expinfrav1.LaunchTemplateLatestVersion
+}
+
+// GetPreviousLaunchTemplateVersionStatus returns the version of the launch template before its last change.
+func (m *MachinePoolScope) GetPreviousLaunchTemplateVersionStatus() string {
+	return ptr.Deref(m.AWSMachinePool.Status.PreviousLaunchTemplateVersion, "")
+}
+
 // SetLaunchTemplateLatestVersionStatus sets the launch template latest version status.
 func (m *MachinePoolScope) SetLaunchTemplateLatestVersionStatus(version string) {
 	m.AWSMachinePool.Status.LaunchTemplateVersion = &version
 }
 
+// GetAdditionalSecurityGroupIDsStatus returns the additional security group IDs last resolved.
+func (m *MachinePoolScope) GetAdditionalSecurityGroupIDsStatus() []string {
+	return m.AWSMachinePool.Status.AdditionalSecurityGroupIDs
+}
+
+// SetAdditionalSecurityGroupIDsStatus sets the resolved additional security group IDs status.
+func (m *MachinePoolScope) SetAdditionalSecurityGroupIDsStatus(ids []string) {
+	m.AWSMachinePool.Status.AdditionalSecurityGroupIDs = ids
+}
+
+// GetDedicatedSecurityGroup returns the dedicated security group configuration of the AWSMachinePool.
+func (m *MachinePoolScope) GetDedicatedSecurityGroup() *expinfrav1.DedicatedSecurityGroup {
+	if m.AWSMachinePool == nil {
+		return nil
+	}
+	return m.AWSMachinePool.Spec.DedicatedSecurityGroup
+}
+
+// GetDedicatedSecurityGroupIDStatus returns the ID of the security group owned by the AWSMachinePool.
+func (m *MachinePoolScope) GetDedicatedSecurityGroupIDStatus() string {
+	return m.AWSMachinePool.Status.DedicatedSecurityGroupID
+}
+
+// SetDedicatedSecurityGroupIDStatus sets the ID of the security group owned by the AWSMachinePool.
+func (m *MachinePoolScope) SetDedicatedSecurityGroupIDStatus(id string) {
+	m.AWSMachinePool.Status.DedicatedSecurityGroupID = id
+}
+
+// GetCopiedAMIStatus returns the copy of the launch template AMI made from its source region.
+func (m *MachinePoolScope) GetCopiedAMIStatus() *expinfrav1.CopiedAMI {
+	return m.AWSMachinePool.Status.CopiedAMI
+}
+
+// SetCopiedAMIStatus sets the copy of the launch template AMI made from its source region.
+func (m *MachinePoolScope) SetCopiedAMIStatus(ami *expinfrav1.CopiedAMI) {
+	m.AWSMachinePool.Status.CopiedAMI = ami
+}
+
+// GetSecurityGroupFilterCache returns the cache for security group IDs resolved from filters.
+func (m *MachinePoolScope) GetSecurityGroupFilterCache() *SecurityGroupFilterCache {
+	return m.SecurityGroupFilterCache
+}
+
 // IsEKSManaged checks if the AWSMachinePool is EKS managed.
 func (m *MachinePoolScope) IsEKSManaged() bool {
 	return m.InfraCluster.InfraCluster().GetObjectKind().GroupVersionKind().Kind == ekscontrolplanev1.AWSManagedControlPlaneKind
@@ -299,40 +460,87 @@ type NodeStatus struct {
 	Version string
 }
 
-// UpdateInstanceStatuses ties ASG instances and Node status data together and updates AWSMachinePool
-// This updates if ASG instances ready and kubelet version running on the node..
-func (m *MachinePoolScope) UpdateInstanceStatuses(ctx context.Context, instances []infrav1.Instance) error {
-	providerIDs := make([]string, len(instances))
-	for i, instance := range instances {
+// UpdateInstanceStatuses ties the instances of the ASG and the status of their nodes together and updates the
+// instances of the AWSMachinePool with their lifecycle and health state, launch template version, availability
+// zone and kubelet version. ReadyReplicas counts the instances which are InService and Healthy.
+// The instances are updated even when the nodes can't be listed, without their kubelet version.
+func (m *MachinePoolScope) UpdateInstanceStatuses(ctx context.Context, asg *expinfrav1.AutoScalingGroup) error {
+	providerIDs := make([]string, len(asg.Instances))
+	for i, instance := range asg.Instances {
 		providerIDs[i] = fmt.Sprintf("aws:////%s", instance.ID)
 	}
 
 	nodeStatusByProviderID, err := m.getNodeStatusByProviderID(ctx, providerIDs)
-	if err != nil {
-		return errors.Wrap(err, "failed to get node status by provider id")
+
+	// The purchasing option of the instances is reported separately, it is kept until then.
+	lifecycles := make(map[string]expinfrav1.InstanceLifecycle, len(m.AWSMachinePool.Status.Instances))
+	for _, instance := range m.AWSMachinePool.Status.Instances {
+		lifecycles[instance.InstanceID] = instance.Lifecycle
 	}
 
 	var readyReplicas int32
-	instanceStatuses := make([]expinfrav1.AWSMachinePoolInstanceStatus, len(instances))
-	for i, instance := range instances {
+	instanceStatuses := make([]expinfrav1.AWSMachinePoolInstanceStatus, len(asg.Instances))
+	for i, instance := range asg.Instances {
+		details := asg.InstanceDetails[instance.ID]
 		instanceStatuses[i] = expinfrav1.AWSMachinePoolInstanceStatus{
-			InstanceID: instance.ID,
+			InstanceID:            instance.ID,
+			Lifecycle:             lifecycles[instance.ID],
+			LifecycleState:        string(instance.State),
+			HealthStatus:          details.HealthStatus,
+			LaunchTemplateVersion: details.LaunchTemplateVersion,
+			AvailabilityZone:      instance.AvailabilityZone,
 		}
 
-		instanceStatus := instanceStatuses[i]
-		if nodeStatus, ok := nodeStatusByProviderID[fmt.Sprintf("aws:////%s", instanceStatus.InstanceID)]; ok {
-			instanceStatus.Version = &nodeStatus.Version
-			if nodeStatus.Ready {
-				readyReplicas++
-			}
+		if nodeStatus, ok := nodeStatusByProviderID[providerIDs[i]]; ok && nodeStatus.Version != "" {
+			instanceStatuses[i].Version = ptr.To(nodeStatus.Version)
+		}
+
+		if instance.State == autoscaling.LifecycleStateInService && details.HealthStatus == asgInstanceHealthy {
+			readyReplicas++
 		}
 	}
 
-	// TODO: readyReplicas can be used as status.replicas but this will delay machinepool to become ready. next reconcile updates this.
 	m.AWSMachinePool.Status.Instances = instanceStatuses
+	m.AWSMachinePool.Status.ReadyReplicas = readyReplicas
+	if err != nil {
+		return errors.Wrap(err, "failed to get node status by provider id")
+	}
 	return nil
 }
 
+// StandbyInstanceCount returns the number of instances of the pool which the ASG reports in standby, whether they
+// were put there by the ASGInstanceStateAnnotation or by other tooling. Entering standby decrements the desired
+// capacity of the ASG, so they are left out of it.
+func (m *MachinePoolScope) StandbyInstanceCount() int32 {
+	var count int32
+	for _, instance := range m.AWSMachinePool.Status.Instances {
+		switch instance.LifecycleState {
+		case autoscaling.LifecycleStateStandby, autoscaling.LifecycleStateEnteringStandby:
+			count++
+		}
+	}
+	return count
+}
+
+// GetNodeReadyByInstanceID returns whether the node of each of the given instances is Ready in the workload cluster.
+func (m *MachinePoolScope) GetNodeReadyByInstanceID(ctx context.Context, instanceIDs []string) (map[string]bool, error) {
+	providerIDs := make([]string, len(instanceIDs))
+	for i, id := range instanceIDs {
+		providerIDs[i] = fmt.Sprintf("aws:////%s", id)
+	}
+
+	nodeStatusByProviderID, err := m.getNodeStatusByProviderID(ctx, providerIDs)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get node status by provider id")
+	}
+
+	nodeReady := make(map[string]bool, len(instanceIDs))
+	for _, id := range instanceIDs {
+		nodeReady[id] = nodeStatusByProviderID[fmt.Sprintf("aws:////%s", id)].Ready
+	}
+	return nodeReady, nil
+}
+
 func (m *MachinePoolScope) getNodeStatusByProviderID(ctx context.Context, providerIDList []string) (map[string]*NodeStatus, error) {
 	nodeStatusMap := map[string]*NodeStatus{}
 	for _, id := range providerIDList {
@@ -391,6 +599,62 @@ func (m *MachinePoolScope) LaunchTemplateName() string {
 	return m.Name()
 }
 
+// GetMixedInstancesPolicy returns the mixed instances policy of the ASG. The instances of a capacity block all have
+// the instance type it was reserved for, so the ASG launches them from the launch template without any policy.
+func (m *MachinePoolScope) GetMixedInstancesPolicy() *expinfrav1.MixedInstancesPolicy {
+	if m.AWSMachinePool.Spec.AWSLaunchTemplate.MarketType == infrav1.MarketTypeCapacityBlock {
+		return nil
+	}
+	return m.AWSMachinePool.Spec.MixedInstancesPolicy
+}
+
+// GetSubnetIDs returns the IDs of the subnets referenced by ID in the AWSMachinePool spec.
+func (m *MachinePoolScope) GetSubnetIDs() []string {
+	var subnetIDs []string
+	for _, subnet := range m.AWSMachinePool.Spec.Subnets {
+		if subnet.ID != nil {
+			subnetIDs = append(subnetIDs, *subnet.ID)
+		}
+	}
+	return subnetIDs
+}
+
+// GetLaunchTemplateOverrides returns the instance type overrides which set their own root volume or bootstrap data.
+func (m *MachinePoolScope) GetLaunchTemplateOverrides() []expinfrav1.Overrides {
+	mixedInstancesPolicy := m.GetMixedInstancesPolicy()
+	if mixedInstancesPolicy == nil {
+		return nil
+	}
+
+	var overrides []expinfrav1.Overrides
+	for _, override := range mixedInstancesPolicy.Overrides {
+		if HasOverrideLaunchTemplate(&m.AWSMachinePool.Spec.AWSLaunchTemplate, override) {
+			overrides = append(overrides, override)
+		}
+	}
+	return overrides
+}
+
+// GetOverrideLaunchTemplatesStatus returns the launch templates managed for instance type overrides.
+func (m *MachinePoolScope) GetOverrideLaunchTemplatesStatus() []expinfrav1.OverrideLaunchTemplate {
+	return m.AWSMachinePool.Status.OverrideLaunchTemplates
+}
+
+// SetOverrideLaunchTemplatesStatus sets the launch templates managed for instance type overrides.
+func (m *MachinePoolScope) SetOverrideLaunchTemplatesStatus(templates []expinfrav1.OverrideLaunchTemplate) {
+	m.AWSMachinePool.Status.OverrideLaunchTemplates = templates
+}
+
+// SetLaunchTemplateChangeStatus sets the last change of the launch template.
+func (m *MachinePoolScope) SetLaunchTemplateChangeStatus(change *expinfrav1.LaunchTemplateChange) {
+	m.AWSMachinePool.Status.LaunchTemplateChange = change
+}
+
+// TriggerRefreshOnUserDataChange returns whether a change of the userdata alone is rolled out to the instances.
+func (m *MachinePoolScope) TriggerRefreshOnUserDataChange() bool {
+	return m.AWSMachinePool.Spec.RefreshPreferences != nil && m.AWSMachinePool.Spec.RefreshPreferences.TriggerOnUserDataChange
+}
+
 // GetRuntimeObject returns the AWSMachinePool object, in runtime.Object form.
 func (m *MachinePoolScope) GetRuntimeObject() runtime.Object {
 	return m.AWSMachinePool
@@ -26,6 +26,7 @@ import (
 	"github.com/pkg/errors"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -51,6 +52,7 @@ func init() {
 	_ = amazoncni.AddToScheme(scheme)
 	_ = appsv1.AddToScheme(scheme)
 	_ = corev1.AddToScheme(scheme)
+	_ = policyv1.AddToScheme(scheme)
 	_ = rbacv1.AddToScheme(scheme)
 }
 
@@ -67,6 +69,9 @@ type ManagedControlPlaneScopeParams struct {
 	EnableIAM                    bool
 	AllowAdditionalRoles         bool
 	TagUnmanagedNetworkResources bool
+	// OwnershipTagPrefix is the prefix of the ownership tag key of the clusters which don't override it.
+	// Defaults to NameAWSProviderOwned.
+	OwnershipTagPrefix string
 }
 
 // NewManagedControlPlaneScope creates a new Scope from the supplied parameters.
@@ -95,20 +100,24 @@ func NewManagedControlPlaneScope(params ManagedControlPlaneScopeParams) (*Manage
 		allowAdditionalRoles:         params.AllowAdditionalRoles,
 		enableIAM:                    params.EnableIAM,
 		tagUnmanagedNetworkResources: params.TagUnmanagedNetworkResources,
+		ownershipTagPrefix:           params.OwnershipTagPrefix,
 	}
+	helper, err := patch.NewHelper(params.ControlPlane, params.Client)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to init patch helper")
+	}
+	resetPendingChanges(params.ControlPlane)
+
 	session, serviceLimiters, err := sessionForClusterWithRegion(params.Client, managedScope, params.ControlPlane.Spec.Region, params.Endpoints, params.Logger)
 	if err != nil {
+		if patchErr := patchIdentityConditions(helper, params.ControlPlane); patchErr != nil {
+			params.Logger.Error(patchErr, "failed to persist the identity conditions")
+		}
 		return nil, errors.Errorf("failed to create aws session: %v", err)
 	}
 
 	managedScope.session = session
 	managedScope.serviceLimiters = serviceLimiters
-
-	helper, err := patch.NewHelper(params.ControlPlane, params.Client)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to init patch helper")
-	}
-
 	managedScope.patchHelper = helper
 	return managedScope, nil
 }
@@ -129,6 +138,7 @@ type ManagedControlPlaneScope struct {
 	enableIAM                    bool
 	allowAdditionalRoles         bool
 	tagUnmanagedNetworkResources bool
+	ownershipTagPrefix           string
 }
 
 // RemoteClient returns the Kubernetes client for connecting to the workload cluster.
@@ -198,6 +208,24 @@ func (s *ManagedControlPlaneScope) CNIIngressRules() infrav1.CNIIngressRules {
 	return infrav1.CNIIngressRules{}
 }
 
+// CNIPreset returns the preset of CNI ingress rules of the spec.
+func (s *ManagedControlPlaneScope) CNIPreset() infrav1.CNIPreset {
+	if s.ControlPlane.Spec.NetworkSpec.CNI != nil {
+		return s.ControlPlane.Spec.NetworkSpec.CNI.Preset
+	}
+	return ""
+}
+
+// LegacyClusterTag returns the configuration of the kubernetes.io/cluster/<name> tag.
+func (s *ManagedControlPlaneScope) LegacyClusterTag() *infrav1.LegacyClusterTag {
+	return s.ControlPlane.Spec.NetworkSpec.LegacyClusterTag
+}
+
+// ResourceNaming returns nil, as the resources of managed control planes are named after the cluster.
+func (s *ManagedControlPlaneScope) ResourceNaming() *infrav1.ResourceNaming {
+	return nil
+}
+
 // SecurityGroups returns the control plane security groups as a map, it creates the map if empty.
 func (s *ManagedControlPlaneScope) SecurityGroups() map[infrav1.SecurityGroupRole]infrav1.SecurityGroup {
 	return s.ControlPlane.Status.Network.SecurityGroups
@@ -213,6 +241,11 @@ func (s *ManagedControlPlaneScope) SecondaryCidrBlocks() []infrav1.VpcCidrBlock
 	return s.ControlPlane.Spec.NetworkSpec.VPC.SecondaryCidrBlocks
 }
 
+// LocalGatewayRouteTables returns the local gateways used to route traffic of AWS Outpost subnets.
+func (s *ManagedControlPlaneScope) LocalGatewayRouteTables() []infrav1.LocalGatewayRouteTable {
+	return s.ControlPlane.Spec.NetworkSpec.LocalGatewayRouteTables
+}
+
 // AllSecondaryCidrBlocks returns all secondary CIDR blocks (combining `SecondaryCidrBlock` and `SecondaryCidrBlocks`).
 func (s *ManagedControlPlaneScope) AllSecondaryCidrBlocks() []infrav1.VpcCidrBlock {
 	secondaryCidrBlocks := s.ControlPlane.Spec.NetworkSpec.VPC.SecondaryCidrBlocks
@@ -235,6 +268,11 @@ func (s *ManagedControlPlaneScope) SecurityGroupOverrides() map[infrav1.Security
 	return s.ControlPlane.Spec.NetworkSpec.SecurityGroupOverrides
 }
 
+// ApplyRulesToUnmanagedGroups returns whether ingress rules should be reconciled on security group overrides.
+func (s *ManagedControlPlaneScope) ApplyRulesToUnmanagedGroups() bool {
+	return s.ControlPlane.Spec.NetworkSpec.ApplyRulesToUnmanagedGroups
+}
+
 // Name returns the CAPI cluster name.
 func (s *ManagedControlPlaneScope) Name() string {
 	return s.Cluster.Name
@@ -277,10 +315,18 @@ func (s *ManagedControlPlaneScope) PatchObject() error {
 			infrav1.VpcEndpointsReadyCondition,
 			infrav1.BastionHostReadyCondition,
 			infrav1.EgressOnlyInternetGatewayReadyCondition,
+			infrav1.PrincipalUsageAllowedCondition,
+			infrav1.PrincipalCredentialRetrievedCondition,
+			infrav1.IdentityResolvedCondition,
 			ekscontrolplanev1.EKSControlPlaneCreatingCondition,
 			ekscontrolplanev1.EKSControlPlaneReadyCondition,
 			ekscontrolplanev1.EKSControlPlaneUpdatingCondition,
 			ekscontrolplanev1.IAMControlPlaneRolesReadyCondition,
+			ekscontrolplanev1.WaitingForDependentsCondition,
+			ekscontrolplanev1.WaitingForControlPlaneENIsCondition,
+			ekscontrolplanev1.AddonDegradedCondition,
+			ekscontrolplanev1.UpgradeBlockedByInsightsCondition,
+			infrav1.PendingChangesCondition,
 		}})
 }
 
@@ -295,7 +341,33 @@ func (s *ManagedControlPlaneScope) AdditionalTags() infrav1.Tags {
 		s.ControlPlane.Spec.AdditionalTags = infrav1.Tags{}
 	}
 
-	return s.ControlPlane.Spec.AdditionalTags.DeepCopy()
+	tags := s.ControlPlane.Spec.AdditionalTags.DeepCopy()
+	// The cost allocation tags are computed, so that they can't be overridden.
+	tags.Merge(costAllocationTags(s.Cluster))
+	return tags
+}
+
+// OwnershipTagPrefix returns the prefix of the ownership tag key the cluster's resources are tagged with.
+func (s *ManagedControlPlaneScope) OwnershipTagPrefix() string {
+	current, _ := ownershipTagPrefixes(s.ControlPlane, s.ControlPlane.Spec.OwnershipTagPrefix, s.ownershipTagPrefix)
+	return current
+}
+
+// OwnershipTagPrefixes returns the prefixes of the ownership tag key the cluster's resources are recognized by,
+// the current one first, followed by the one they're being migrated from, if any.
+func (s *ManagedControlPlaneScope) OwnershipTagPrefixes() []string {
+	return ownershipTagPrefixList(s.ControlPlane, s.ControlPlane.Spec.OwnershipTagPrefix, s.ownershipTagPrefix)
+}
+
+// PreviousOwnershipTagPrefix returns the prefix the cluster's resources are being migrated from, if any.
+func (s *ManagedControlPlaneScope) PreviousOwnershipTagPrefix() string {
+	_, previous := ownershipTagPrefixes(s.ControlPlane, s.ControlPlane.Spec.OwnershipTagPrefix, s.ownershipTagPrefix)
+	return previous
+}
+
+// CompleteOwnershipTagMigration records that the cluster's resources are tagged with the current prefix.
+func (s *ManagedControlPlaneScope) CompleteOwnershipTagMigration() {
+	completeOwnershipTagMigration(s.ControlPlane, s.ControlPlane.Spec.OwnershipTagPrefix, s.ownershipTagPrefix)
 }
 
 // APIServerPort returns the port to use when communicating with the API server.
@@ -321,6 +393,11 @@ func (s *ManagedControlPlaneScope) ClusterObj() cloud.ClusterObject {
 	return s.Cluster
 }
 
+// ReadOnly returns true when the AWSManagedControlPlane is annotated with the read-only annotation.
+func (s *ManagedControlPlaneScope) ReadOnly() bool {
+	return IsReadOnly(s.ControlPlane)
+}
+
 // Session returns the AWS SDK session. Used for creating clients.
 func (s *ManagedControlPlaneScope) Session() awsclient.ConfigProvider {
 	return s.session
@@ -342,11 +419,41 @@ func (s *ManagedControlPlaneScope) TagUnmanagedNetworkResources() bool {
 	return s.tagUnmanagedNetworkResources
 }
 
+// PublishEgressPrefixList returns whether the egress IPs of the cluster are published in a managed prefix list.
+func (s *ManagedControlPlaneScope) PublishEgressPrefixList() bool {
+	return s.ControlPlane.Spec.NetworkSpec.PublishEgressPrefixList
+}
+
+// EgressPrefixListIncludeAPIServerLB returns whether the IPs of the API server load balancer are added to the egress prefix list.
+func (s *ManagedControlPlaneScope) EgressPrefixListIncludeAPIServerLB() bool {
+	return s.ControlPlane.Spec.NetworkSpec.EgressPrefixListIncludeAPIServerLB
+}
+
 // SetBastionInstance sets the bastion instance in the status of the cluster.
 func (s *ManagedControlPlaneScope) SetBastionInstance(instance *infrav1.Instance) {
 	s.ControlPlane.Status.Bastion = instance
 }
 
+// BastionSuspended returns whether the bastion host is suspended, which is only supported for AWSClusters.
+func (s *ManagedControlPlaneScope) BastionSuspended() bool {
+	return false
+}
+
+// NatGatewaysSuspended returns whether the NAT gateways are suspended, which is only supported for AWSClusters.
+func (s *ManagedControlPlaneScope) NatGatewaysSuspended() bool {
+	return false
+}
+
+// SuspendedNatGatewayAllocations returns the allocation IDs of the Elastic IPs of the suspended NAT gateways,
+// which are never suspended for managed control planes.
+func (s *ManagedControlPlaneScope) SuspendedNatGatewayAllocations() map[string]string {
+	return nil
+}
+
+// SetSuspendedNatGatewayAllocations does nothing, as NAT gateways are never suspended for managed control planes.
+func (s *ManagedControlPlaneScope) SetSuspendedNatGatewayAllocations(_ map[string]string) {
+}
+
 // SSHKeyName returns the SSH key name to use for instances.
 func (s *ManagedControlPlaneScope) SSHKeyName() *string {
 	return s.ControlPlane.Spec.SSHKeyName
@@ -462,6 +569,12 @@ func (s *ManagedControlPlaneScope) ControlPlaneLoadBalancers() []*infrav1.AWSLoa
 	return nil
 }
 
+// ControlPlaneZones returns the availability zones used by control plane machines, there are none for
+// a managed control plane.
+func (s *ManagedControlPlaneScope) ControlPlaneZones() []string {
+	return nil
+}
+
 // Partition returns the cluster partition.
 func (s *ManagedControlPlaneScope) Partition() string {
 	if s.ControlPlane.Spec.Partition == "" {
@@ -485,3 +598,26 @@ func (s *ManagedControlPlaneScope) UnstructuredControlPlane() (*unstructured.Uns
 func (s *ManagedControlPlaneScope) NodePortIngressRuleCidrBlocks() []string {
 	return nil
 }
+
+// NodeTerminationHandling returns the node termination handling configuration of the cluster.
+func (s *ManagedControlPlaneScope) NodeTerminationHandling() *infrav1.NodeTerminationHandling {
+	return s.ControlPlane.Spec.NodeTerminationHandling
+}
+
+// NodeTerminationHandlingQueueName returns the name of the node termination handler queue.
+func (s *ManagedControlPlaneScope) NodeTerminationHandlingQueueName() string {
+	if nth := s.ControlPlane.Spec.NodeTerminationHandling; nth != nil && nth.QueueName != "" {
+		return nth.QueueName
+	}
+	return defaultNodeTerminationHandlingQueueName(s.Name())
+}
+
+// NodeTerminationHandlingStatus returns the observed state of the node termination handler resources.
+func (s *ManagedControlPlaneScope) NodeTerminationHandlingStatus() *infrav1.NodeTerminationHandlingStatus {
+	return s.ControlPlane.Status.NodeTerminationHandling
+}
+
+// SetNodeTerminationHandlingStatus sets the observed state of the node termination handler resources.
+func (s *ManagedControlPlaneScope) SetNodeTerminationHandlingStatus(status *infrav1.NodeTerminationHandlingStatus) {
+	s.ControlPlane.Status.NodeTerminationHandling = status
+}
@@ -19,6 +19,7 @@ package scope
 import (
 	"context"
 	"fmt"
+	"time"
 
 	awsclient "github.com/aws/aws-sdk-go/aws/client"
 	"github.com/pkg/errors"
@@ -37,7 +38,9 @@ import (
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/util/system"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controllers/remote"
 	expclusterv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/patch"
 )
@@ -56,8 +59,15 @@ type ManagedMachinePoolScopeParams struct {
 
 	EnableIAM            bool
 	AllowAdditionalRoles bool
+	// OwnershipTagPrefix is the prefix of the ownership tag key of the clusters which don't override it.
+	// Defaults to NameAWSProviderOwned.
+	OwnershipTagPrefix string
 
 	InfraCluster EC2Scope
+
+	// SecurityGroupFilterCache is an optional cache for security group IDs resolved from filters,
+	// shared across reconciles.
+	SecurityGroupFilterCache *SecurityGroupFilterCache
 }
 
 // NewManagedMachinePoolScope creates a new Scope from the supplied parameters.
@@ -97,6 +107,7 @@ func NewManagedMachinePoolScope(params ManagedMachinePoolScopeParams) (*ManagedM
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to init MachinePool patch helper")
 	}
+	resetPendingChanges(params.ManagedMachinePool)
 
 	return &ManagedMachinePoolScope{
 		Logger:                     *params.Logger,
@@ -114,6 +125,9 @@ func NewManagedMachinePoolScope(params ManagedMachinePoolScopeParams) (*ManagedM
 		controllerName:       params.ControllerName,
 		enableIAM:            params.EnableIAM,
 		allowAdditionalRoles: params.AllowAdditionalRoles,
+		ownershipTagPrefix:   params.OwnershipTagPrefix,
+
+		SecurityGroupFilterCache: params.SecurityGroupFilterCache,
 	}, nil
 }
 
@@ -130,12 +144,15 @@ type ManagedMachinePoolScope struct {
 	MachinePool        *expclusterv1.MachinePool
 	EC2Scope           EC2Scope
 
+	SecurityGroupFilterCache *SecurityGroupFilterCache
+
 	session         awsclient.ConfigProvider
 	serviceLimiters throttle.ServiceLimiters
 	controllerName  string
 
 	enableIAM            bool
 	allowAdditionalRoles bool
+	ownershipTagPrefix   string
 }
 
 // ManagedPoolName returns the managed machine pool name.
@@ -220,6 +237,11 @@ func (s *ManagedMachinePoolScope) SubnetIDs() ([]string, error) {
 	})
 }
 
+// GetSubnetIDs returns the IDs of the subnets set in the AWSManagedMachinePool spec.
+func (s *ManagedMachinePoolScope) GetSubnetIDs() []string {
+	return s.ManagedMachinePool.Spec.SubnetIDs
+}
+
 // NodegroupReadyFalse marks the ready condition false using warning if error isn't
 // empty.
 func (s *ManagedMachinePoolScope) NodegroupReadyFalse(reason string, err string) error {
@@ -267,7 +289,12 @@ func (s *ManagedMachinePoolScope) PatchObject() error {
 		s.ManagedMachinePool,
 		patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{
 			expinfrav1.EKSNodegroupReadyCondition,
+			expinfrav1.EKSNodegroupDrainedCondition,
+			expinfrav1.NodegroupDegradedCondition,
+			expinfrav1.NodegroupUpdateInProgressCondition,
 			expinfrav1.IAMNodegroupRolesReadyCondition,
+			infrav1.ReconciliationSkippedCondition,
+			infrav1.PendingChangesCondition,
 		}})
 }
 
@@ -279,6 +306,17 @@ func (s *ManagedMachinePoolScope) PatchCAPIMachinePoolObject(ctx context.Context
 	)
 }
 
+// RemoteClient returns the Kubernetes client for connecting to the workload cluster.
+func (s *ManagedMachinePoolScope) RemoteClient() (client.Client, error) {
+	restConfig, err := remote.RESTConfig(context.Background(), s.controllerName, s.Client, util.ObjectKey(s.Cluster))
+	if err != nil {
+		return nil, fmt.Errorf("getting remote rest config for %s/%s: %w", s.Cluster.Namespace, s.Cluster.Name, err)
+	}
+	restConfig.Timeout = 1 * time.Minute
+
+	return client.New(restConfig, client.Options{Scheme: scheme})
+}
+
 // Close closes the current scope persisting the control plane configuration and status.
 func (s *ManagedMachinePoolScope) Close() error {
 	return s.PatchObject()
@@ -294,6 +332,12 @@ func (s *ManagedMachinePoolScope) ClusterObj() cloud.ClusterObject {
 	return s.Cluster
 }
 
+// ReadOnly returns true when the AWSManagedControlPlane of the machine pool is annotated with the read-only
+// annotation.
+func (s *ManagedMachinePoolScope) ReadOnly() bool {
+	return IsReadOnly(s.ControlPlane)
+}
+
 // Session returns the AWS SDK session. Used for creating clients.
 func (s *ManagedMachinePoolScope) Session() awsclient.ConfigProvider {
 	return s.session
@@ -305,6 +349,18 @@ func (s *ManagedMachinePoolScope) ControllerName() string {
 	return s.controllerName
 }
 
+// OwnershipTagPrefix returns the prefix of the ownership tag key the cluster's resources are tagged with.
+func (s *ManagedMachinePoolScope) OwnershipTagPrefix() string {
+	current, _ := ownershipTagPrefixes(s.ControlPlane, s.ControlPlane.Spec.OwnershipTagPrefix, s.ownershipTagPrefix)
+	return current
+}
+
+// OwnershipTagPrefixes returns the prefixes of the ownership tag key the cluster's resources are recognized by,
+// the current one first.
+func (s *ManagedMachinePoolScope) OwnershipTagPrefixes() []string {
+	return ownershipTagPrefixList(s.ControlPlane, s.ControlPlane.Spec.OwnershipTagPrefix, s.ownershipTagPrefix)
+}
+
 // KubernetesClusterName is the name of the EKS cluster name.
 func (s *ManagedMachinePoolScope) KubernetesClusterName() string {
 	return s.ControlPlane.Spec.EKSClusterName
@@ -392,6 +448,46 @@ func (s *ManagedMachinePoolScope) SetLaunchTemplateLatestVersionStatus(version s
 	s.ManagedMachinePool.Status.LaunchTemplateVersion = &version
 }
 
+// GetAdditionalSecurityGroupIDsStatus returns the additional security group IDs last resolved.
+func (s *ManagedMachinePoolScope) GetAdditionalSecurityGroupIDsStatus() []string {
+	return s.ManagedMachinePool.Status.AdditionalSecurityGroupIDs
+}
+
+// SetAdditionalSecurityGroupIDsStatus sets the resolved additional security group IDs status.
+func (s *ManagedMachinePoolScope) SetAdditionalSecurityGroupIDsStatus(ids []string) {
+	s.ManagedMachinePool.Status.AdditionalSecurityGroupIDs = ids
+}
+
+// GetDedicatedSecurityGroup returns the dedicated security group configuration of the AWSManagedMachinePool.
+func (s *ManagedMachinePoolScope) GetDedicatedSecurityGroup() *expinfrav1.DedicatedSecurityGroup {
+	return s.ManagedMachinePool.Spec.DedicatedSecurityGroup
+}
+
+// GetDedicatedSecurityGroupIDStatus returns the ID of the security group owned by the AWSManagedMachinePool.
+func (s *ManagedMachinePoolScope) GetDedicatedSecurityGroupIDStatus() string {
+	return s.ManagedMachinePool.Status.DedicatedSecurityGroupID
+}
+
+// SetDedicatedSecurityGroupIDStatus sets the ID of the security group owned by the AWSManagedMachinePool.
+func (s *ManagedMachinePoolScope) SetDedicatedSecurityGroupIDStatus(id string) {
+	s.ManagedMachinePool.Status.DedicatedSecurityGroupID = id
+}
+
+// GetCopiedAMIStatus returns the copy of the launch template AMI made from its source region.
+func (s *ManagedMachinePoolScope) GetCopiedAMIStatus() *expinfrav1.CopiedAMI {
+	return s.ManagedMachinePool.Status.CopiedAMI
+}
+
+// SetCopiedAMIStatus sets the copy of the launch template AMI made from its source region.
+func (s *ManagedMachinePoolScope) SetCopiedAMIStatus(ami *expinfrav1.CopiedAMI) {
+	s.ManagedMachinePool.Status.CopiedAMI = ami
+}
+
+// GetSecurityGroupFilterCache returns the cache for security group IDs resolved from filters.
+func (s *ManagedMachinePoolScope) GetSecurityGroupFilterCache() *SecurityGroupFilterCache {
+	return s.SecurityGroupFilterCache
+}
+
 // GetLaunchTemplate returns the launch template.
 func (s *ManagedMachinePoolScope) GetLaunchTemplate() *expinfrav1.AWSLaunchTemplate {
 	return s.ManagedMachinePool.Spec.AWSLaunchTemplate
@@ -0,0 +1,132 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/identity"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
+)
+
+// namespaceIdentityRoles are the ARNs of the roles assumed by default by the clusters of a namespace, by namespace.
+var namespaceIdentityRoles map[string]string
+
+// SetNamespaceIdentityRoles sets the ARNs of the roles assumed by default by the clusters of a namespace, by
+// namespace. The roles are assumed with the credentials of the controller by the clusters which don't set an
+// identityRef, or use the controller identity, in place of the controller identity.
+func SetNamespaceIdentityRoles(roles map[string]string) {
+	namespaceIdentityRoles = roles
+}
+
+// NamespaceIdentityRoleARNs returns the sorted ARNs of the namespace identity roles, without duplicates.
+func NamespaceIdentityRoleARNs() []string {
+	seen := map[string]bool{}
+	roleARNs := []string{}
+	for _, roleARN := range namespaceIdentityRoles {
+		if !seen[roleARN] {
+			seen[roleARN] = true
+			roleARNs = append(roleARNs, roleARN)
+		}
+	}
+	sort.Strings(roleARNs)
+	return roleARNs
+}
+
+// ParseNamespaceIdentityRoles parses a comma-separated list of namespace=roleARN pairs.
+func ParseNamespaceIdentityRoles(value string) (map[string]string, error) {
+	roles := map[string]string{}
+	if value == "" {
+		return roles, nil
+	}
+	for _, pair := range strings.Split(value, ",") {
+		namespace, roleARN, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok {
+			return nil, errors.Errorf("invalid namespace identity role %q, expected namespace=roleARN", pair)
+		}
+		if err := addNamespaceIdentityRole(roles, namespace, roleARN); err != nil {
+			return nil, err
+		}
+	}
+	return roles, nil
+}
+
+// NamespaceIdentityRolesFromData returns the namespace identity roles of the data of a ConfigMap, whose keys are
+// namespaces and values role ARNs.
+func NamespaceIdentityRolesFromData(data map[string]string) (map[string]string, error) {
+	roles := make(map[string]string, len(data))
+	for namespace, roleARN := range data {
+		if err := addNamespaceIdentityRole(roles, namespace, strings.TrimSpace(roleARN)); err != nil {
+			return nil, err
+		}
+	}
+	return roles, nil
+}
+
+func addNamespaceIdentityRole(roles map[string]string, namespace, roleARN string) error {
+	if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+		return errors.Errorf("invalid namespace %q: %s", namespace, strings.Join(errs, ", "))
+	}
+	if _, ok := roles[namespace]; ok {
+		return errors.Errorf("duplicate identity role for namespace %q", namespace)
+	}
+	parsed, err := arn.Parse(roleARN)
+	if err != nil || parsed.Service != "iam" || !strings.HasPrefix(parsed.Resource, "role/") {
+		return errors.Errorf("invalid identity role %q for namespace %q, expected the ARN of an IAM role", roleARN, namespace)
+	}
+	roles[namespace] = roleARN
+	return nil
+}
+
+// namespaceIdentityRole returns the role assumed by the cluster in place of the controller identity, if any.
+func namespaceIdentityRole(clusterScoper cloud.SessionMetadata) string {
+	if ref := clusterScoper.IdentityRef(); ref != nil && ref.Kind != infrav1.ControllerIdentityKind {
+		return ""
+	}
+	return namespaceIdentityRoles[clusterScoper.Namespace()]
+}
+
+// namespaceIdentityProvider returns the provider assuming the role of the namespace of the cluster with the
+// credentials of the controller.
+func namespaceIdentityProvider(clusterScoper cloud.SessionMetadata, roleARN, region string, log logger.Wrapper) identity.AWSPrincipalTypeProvider {
+	roleIdentity := &infrav1.AWSClusterRoleIdentity{
+		ObjectMeta: metav1.ObjectMeta{Name: "namespace-" + clusterScoper.Namespace()},
+		Spec: infrav1.AWSClusterRoleIdentitySpec{
+			AWSRoleSpec: infrav1.AWSRoleSpec{RoleArn: roleARN},
+		},
+	}
+	return identity.NewAWSRolePrincipalTypeProvider(roleIdentity, nil, region, log)
+}
+
+// setNamespaceIdentityRoleStatus records in the status of the cluster the role of its namespace it assumes.
+func setNamespaceIdentityRoleStatus(clusterScoper cloud.SessionMetadata, roleARN string) {
+	switch obj := clusterScoper.InfraCluster().(type) {
+	case *infrav1.AWSCluster:
+		obj.Status.NamespaceIdentityRoleARN = roleARN
+	case *ekscontrolplanev1.AWSManagedControlPlane:
+		obj.Status.NamespaceIdentityRoleARN = roleARN
+	}
+}
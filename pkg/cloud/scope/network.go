@@ -45,6 +45,8 @@ type NetworkScope interface {
 	// AllSecondaryCidrBlocks returns a unique list of all secondary CIDR blocks (combining `SecondaryCidrBlock` and
 	// `SecondaryCidrBlocks`).
 	AllSecondaryCidrBlocks() []infrav1.VpcCidrBlock
+	// LocalGatewayRouteTables returns the local gateways used to route traffic of AWS Outpost subnets.
+	LocalGatewayRouteTables() []infrav1.LocalGatewayRouteTable
 
 	// Bastion returns the bastion details for the cluster.
 	Bastion() *infrav1.Bastion
@@ -55,8 +57,27 @@ type NetworkScope interface {
 	// TagUnmanagedNetworkResources returns is tagging unmanaged network resources is set.
 	TagUnmanagedNetworkResources() bool
 
+	// LegacyClusterTag returns the configuration of the kubernetes.io/cluster/<name> tag.
+	LegacyClusterTag() *infrav1.LegacyClusterTag
+
+	// ResourceNaming returns the configuration of the Name tag of the resources created for the cluster.
+	ResourceNaming() *infrav1.ResourceNaming
+
+	// PublishEgressPrefixList returns whether the egress IPs of the cluster are published in a managed prefix list.
+	PublishEgressPrefixList() bool
+	// EgressPrefixListIncludeAPIServerLB returns whether the IPs of the API server load balancer are added to the egress prefix list.
+	EgressPrefixListIncludeAPIServerLB() bool
+
 	// SetNatGatewaysIPs sets the Nat Gateways Public IPs.
 	SetNatGatewaysIPs(ips []string)
 	// GetNatGatewaysIPs gets the Nat Gateways Public IPs.
 	GetNatGatewaysIPs() []string
+
+	// NatGatewaysSuspended returns whether the NAT gateways are suspended by the cost savings schedule.
+	NatGatewaysSuspended() bool
+	// SuspendedNatGatewayAllocations returns the allocation IDs of the Elastic IPs of the suspended NAT gateways
+	// by ID of their public subnet.
+	SuspendedNatGatewayAllocations() map[string]string
+	// SetSuspendedNatGatewayAllocations sets the allocation IDs of the Elastic IPs of the suspended NAT gateways.
+	SetSuspendedNatGatewayAllocations(allocations map[string]string)
 }
@@ -0,0 +1,62 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"fmt"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/hash"
+)
+
+const (
+	// maxNodeRoleNamePrefixLength is the maximum length of the prefix of the name of the node role, as role
+	// names are limited to 64 characters.
+	maxNodeRoleNamePrefixLength = 58
+	// nodeRoleNameHashLength is the length of the hash ending a default name prefix which would be too long.
+	nodeRoleNameHashLength = 8
+)
+
+// NodeRoleScope is a scope for managing the IAM role and instance profile created for the nodes of a cluster.
+type NodeRoleScope interface {
+	cloud.ClusterScoper
+
+	// NodeRoleManagement returns the node role configuration of the cluster.
+	NodeRoleManagement() *infrav1.NodeRoleManagement
+	// NodeRoleName returns the name of the node role and of its instance profile.
+	NodeRoleName() string
+	// NodeRoleStatus returns the observed state of the node role and of its instance profile.
+	NodeRoleStatus() *infrav1.NodeRoleStatus
+	// SetNodeRoleStatus sets the observed state of the node role and of its instance profile.
+	SetNodeRoleStatus(status *infrav1.NodeRoleStatus)
+	// Partition returns the partition of the cluster, used to build the ARNs of the AWS managed policies.
+	Partition() string
+}
+
+// defaultNodeRoleName returns the name of the node role of a cluster which doesn't set a name prefix,
+// <namespace>-<cluster name>-nodes, so that clusters with the same name in different namespaces don't share it.
+// When the prefix would be too long, it is truncated and followed by a hash of it.
+func defaultNodeRoleName(namespace, clusterName string) string {
+	prefix := fmt.Sprintf("%s-%s", namespace, clusterName)
+	if len(prefix) > maxNodeRoleNamePrefixLength {
+		// The hash length is valid, so hashing can't fail.
+		hashed, _ := hash.Base36TruncatedHash(prefix, nodeRoleNameHashLength)
+		prefix = fmt.Sprintf("%s-%s", prefix[:maxNodeRoleNamePrefixLength-nodeRoleNameHashLength-1], hashed)
+	}
+	return fmt.Sprintf("%s-nodes", prefix)
+}
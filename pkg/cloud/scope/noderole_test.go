@@ -0,0 +1,88 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+)
+
+func TestNodeRoleName(t *testing.T) {
+	longClusterName := strings.Repeat("workload-", 6) + "cluster"
+
+	tests := []struct {
+		name        string
+		namespace   string
+		clusterName string
+		management  *infrav1.NodeRoleManagement
+		status      *infrav1.NodeRoleStatus
+		want        string
+	}{
+		{
+			name:        "default name",
+			namespace:   "team-a",
+			clusterName: "my-cluster",
+			want:        "team-a-my-cluster-nodes",
+		},
+		{
+			name:        "name prefix",
+			namespace:   "team-a",
+			clusterName: "my-cluster",
+			management:  &infrav1.NodeRoleManagement{Create: true, NamePrefix: "custom"},
+			status:      &infrav1.NodeRoleStatus{RoleName: "my-cluster-nodes"},
+			want:        "custom-nodes",
+		},
+		{
+			name:        "existing role without the namespace",
+			namespace:   "team-a",
+			clusterName: "my-cluster",
+			management:  &infrav1.NodeRoleManagement{Create: true},
+			status:      &infrav1.NodeRoleStatus{RoleName: "my-cluster-nodes"},
+			want:        "my-cluster-nodes",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			s := &ClusterScope{
+				Cluster: &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: tt.clusterName, Namespace: tt.namespace}},
+				AWSCluster: &infrav1.AWSCluster{
+					Spec:   infrav1.AWSClusterSpec{NodeRoleManagement: tt.management},
+					Status: infrav1.AWSClusterStatus{NodeRole: tt.status},
+				},
+			}
+			g.Expect(s.NodeRoleName()).To(Equal(tt.want))
+		})
+	}
+
+	t.Run("long default name", func(t *testing.T) {
+		g := NewWithT(t)
+		name := defaultNodeRoleName("team-a", longClusterName)
+		g.Expect(name).To(HaveLen(64))
+		g.Expect(name).To(HavePrefix("team-a-workload-"))
+		g.Expect(name).To(HaveSuffix("-nodes"))
+
+		other := defaultNodeRoleName("team-a", longClusterName+"2")
+		g.Expect(other).ToNot(Equal(name), "clusters sharing the truncated prefix must not collide")
+	})
+}
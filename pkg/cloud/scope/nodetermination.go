@@ -0,0 +1,75 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"fmt"
+	"strings"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/hash"
+)
+
+const (
+	// maxQueueNameLength is the maximum length of the name of an SQS queue.
+	maxQueueNameLength = 80
+
+	// nodeTerminationHandlingNameHashLength is the length of the hash replacing the end of a cluster name
+	// too long to fit in the name of a node termination handler resource.
+	nodeTerminationHandlingNameHashLength = 8
+)
+
+// NodeTerminationHandlingScope is a scope for managing the AWS resources used by the node termination handler.
+type NodeTerminationHandlingScope interface {
+	cloud.ClusterScoper
+
+	// NodeTerminationHandling returns the node termination handling configuration of the cluster.
+	NodeTerminationHandling() *infrav1.NodeTerminationHandling
+	// NodeTerminationHandlingQueueName returns the name of the node termination handler queue.
+	NodeTerminationHandlingQueueName() string
+	// NodeTerminationHandlingStatus returns the observed state of the node termination handler resources.
+	NodeTerminationHandlingStatus() *infrav1.NodeTerminationHandlingStatus
+	// SetNodeTerminationHandlingStatus sets the observed state of the node termination handler resources.
+	SetNodeTerminationHandlingStatus(status *infrav1.NodeTerminationHandlingStatus)
+}
+
+// NodeTerminationHandlingName returns the name of a resource managed for the node termination handler of a cluster,
+// <cluster name><suffix>, with the characters which aren't allowed in the names of SQS queues and EventBridge rules
+// replaced by dashes. When the name would exceed maxLength characters, the cluster name is truncated and followed by
+// a hash of it.
+func NodeTerminationHandlingName(clusterName, suffix string, maxLength int) string {
+	name := strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '-' || r == '_' {
+			return r
+		}
+		return '-'
+	}, clusterName)
+	if len(name)+len(suffix) <= maxLength {
+		return name + suffix
+	}
+
+	// The hash length is valid, so hashing can't fail.
+	hashed, _ := hash.Base36TruncatedHash(clusterName, nodeTerminationHandlingNameHashLength)
+	return fmt.Sprintf("%s-%s%s", name[:maxLength-len(suffix)-nodeTerminationHandlingNameHashLength-1], hashed, suffix)
+}
+
+// defaultNodeTerminationHandlingQueueName returns the name of the node termination handler queue of a cluster
+// which doesn't set one.
+func defaultNodeTerminationHandlingQueueName(clusterName string) string {
+	return NodeTerminationHandlingName(clusterName, "-nth-queue", maxQueueNameLength)
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestNodeTerminationHandlingName(t *testing.T) {
+	longClusterName := strings.Repeat("workload.", 10) + "cluster"
+
+	tests := []struct {
+		name        string
+		clusterName string
+		want        string
+	}{
+		{
+			name:        "short cluster name",
+			clusterName: "my-cluster",
+			want:        "my-cluster-nth-queue",
+		},
+		{
+			name:        "cluster name with dots",
+			clusterName: "my.cluster",
+			want:        "my-cluster-nth-queue",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(NodeTerminationHandlingName(tt.clusterName, "-nth-queue", maxQueueNameLength)).To(Equal(tt.want))
+		})
+	}
+
+	t.Run("long cluster name", func(t *testing.T) {
+		g := NewWithT(t)
+		name := NodeTerminationHandlingName(longClusterName, "-nth-", 48)
+		g.Expect(name).To(HaveLen(48))
+		g.Expect(name).To(MatchRegexp(`^[A-Za-z0-9_-]+$`))
+		g.Expect(name).To(HavePrefix("workload-workload-"))
+		g.Expect(name).To(HaveSuffix("-nth-"))
+
+		other := NodeTerminationHandlingName(longClusterName+"2", "-nth-", 48)
+		g.Expect(other).ToNot(Equal(name), "cluster names sharing the truncated prefix must not collide")
+	})
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/annotations"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud"
+)
+
+// OwnershipTagScope is a scope for migrating the ownership tags of a cluster's resources
+// to a new prefix.
+type OwnershipTagScope interface {
+	cloud.ClusterScoper
+
+	// PreviousOwnershipTagPrefix returns the prefix the cluster's resources are being migrated from,
+	// or an empty string if no migration is in progress.
+	PreviousOwnershipTagPrefix() string
+	// CompleteOwnershipTagMigration records that the cluster's resources are tagged with the current prefix.
+	CompleteOwnershipTagMigration()
+}
+
+// ownershipTagPrefixes returns the current ownership tag prefix of the cluster object and, while its
+// resources are being migrated, the prefix they're migrated from. The current prefix is the override of
+// the cluster, or else the default prefix of the controller, or else NameAWSProviderOwned. The prefix
+// the resources are tagged with is recorded on the cluster object, so that changing the default prefix
+// migrates the resources of the existing clusters rather than making them look unowned.
+func ownershipTagPrefixes(obj metav1.Object, override, defaultPrefix string) (current, previous string) {
+	current = override
+	if current == "" {
+		current = defaultPrefix
+	}
+	if current == "" {
+		current = infrav1.NameAWSProviderOwned
+	}
+
+	previous, ok := annotations.Get(obj, infrav1.OwnershipTagPrefixAnnotation)
+	if !ok {
+		previous = infrav1.NameAWSProviderOwned
+	}
+	if previous == current {
+		previous = ""
+	}
+	return current, previous
+}
+
+// ownershipTagPrefixList returns the prefixes the cluster's resources are recognized by, the current one first.
+func ownershipTagPrefixList(obj metav1.Object, override, defaultPrefix string) []string {
+	current, previous := ownershipTagPrefixes(obj, override, defaultPrefix)
+	if previous == "" {
+		return []string{current}
+	}
+	return []string{current, previous}
+}
+
+// completeOwnershipTagMigration records the current prefix on the cluster object, which stops
+// the previous one from being recognized.
+func completeOwnershipTagMigration(obj metav1.Object, override, defaultPrefix string) {
+	current, _ := ownershipTagPrefixes(obj, override, defaultPrefix)
+	annotations.Set(obj, infrav1.OwnershipTagPrefixAnnotation, current)
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+)
+
+func TestOwnershipTagPrefixes(t *testing.T) {
+	tests := []struct {
+		name          string
+		annotations   map[string]string
+		override      string
+		defaultPrefix string
+		wantCurrent   string
+		wantPrevious  string
+	}{
+		{
+			name:        "should use NameAWSProviderOwned without override nor default prefix",
+			wantCurrent: infrav1.NameAWSProviderOwned,
+		},
+		{
+			name:          "should migrate from NameAWSProviderOwned to the default prefix of the controller",
+			defaultPrefix: "example.com/cluster/",
+			wantCurrent:   "example.com/cluster/",
+			wantPrevious:  infrav1.NameAWSProviderOwned,
+		},
+		{
+			name:          "should prefer the override of the cluster to the default prefix",
+			override:      "team.example.com/cluster/",
+			defaultPrefix: "example.com/cluster/",
+			wantCurrent:   "team.example.com/cluster/",
+			wantPrevious:  infrav1.NameAWSProviderOwned,
+		},
+		{
+			name:          "should not migrate once the current prefix is recorded",
+			annotations:   map[string]string{infrav1.OwnershipTagPrefixAnnotation: "example.com/cluster/"},
+			defaultPrefix: "example.com/cluster/",
+			wantCurrent:   "example.com/cluster/",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			obj := &infrav1.AWSCluster{ObjectMeta: metav1.ObjectMeta{Annotations: tt.annotations}}
+
+			current, previous := ownershipTagPrefixes(obj, tt.override, tt.defaultPrefix)
+			g.Expect(current).To(Equal(tt.wantCurrent))
+			g.Expect(previous).To(Equal(tt.wantPrevious))
+		})
+	}
+}
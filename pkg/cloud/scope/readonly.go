@@ -0,0 +1,186 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// ReadOnlyRequeueAfter is the duration after which an object whose deletion is deferred in read-only mode is
+// reconciled again.
+const ReadOnlyRequeueAfter = 5 * time.Minute
+
+// pendingChangesMessagePrefix prefixes the list of refused requests in the message of the PendingChangesCondition.
+const pendingChangesMessagePrefix = "Requests not sent in read-only mode: "
+
+// readOnlyOperationPrefixes are the prefixes of the names of the AWS API operations which don't mutate resources.
+var readOnlyOperationPrefixes = []string{"Describe", "List", "Get", "Head", "Simulate"}
+
+// pendingChangesMu serializes the updates of the PendingChangesCondition by the clients sharing a target.
+var pendingChangesMu sync.Mutex
+
+// IsReadOnly returns true when the object is annotated with the read-only annotation.
+func IsReadOnly(obj metav1.Object) bool {
+	return obj.GetAnnotations()[infrav1.ReadOnlyAnnotation] == "true"
+}
+
+// IsMutatingOperation returns true when the AWS API operation may create, update or delete resources.
+func IsMutatingOperation(name string) bool {
+	for _, prefix := range readOnlyOperationPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+// DeferDeletion returns true when the deletion of an object must wait for its cluster to leave read-only mode. The
+// refused requests deleting its AWS resources succeed without being sent, so reconciling the deletion would remove
+// the finalizer of the object while its resources are left behind.
+func DeferDeletion(log logger.Wrapper, session cloud.ReadOnlyScoper) bool {
+	if !session.ReadOnly() {
+		return false
+	}
+	log.Info("Deletion deferred until the cluster leaves read-only mode")
+	return true
+}
+
+// resetPendingChanges removes the PendingChangesCondition of the target, so that it only lists the requests refused
+// during the current reconciliation. It must be called after the patch helper of the target is created.
+func resetPendingChanges(target conditions.Setter) {
+	conditions.Delete(target, infrav1.PendingChangesCondition)
+}
+
+// readOnlyGuard returns a handler which refuses the mutating requests of a client while the cluster of the session
+// is in read-only mode. The operation of a refused request is recorded in the PendingChangesCondition of the target,
+// and the request succeeds without being sent, so that the reconciliation carries on describing the resources.
+// Requests setting their DryRun parameter are sent, as they only check the permissions of the caller.
+func readOnlyGuard(session cloud.Session, target runtime.Object) request.NamedHandler {
+	return request.NamedHandler{
+		Name: "capa/read-only-guard",
+		Fn: func(r *request.Request) {
+			readOnly, ok := session.(cloud.ReadOnlyScoper)
+			if !ok || !readOnly.ReadOnly() || r.Operation == nil || !IsMutatingOperation(r.Operation.Name) || isDryRun(r.Params) {
+				return
+			}
+			recordPendingChange(target, fmt.Sprintf("%s:%s", r.ClientInfo.ServiceID, r.Operation.Name))
+			skipSend(r)
+		},
+	}
+}
+
+// isDryRun returns true when the input of a request sets its DryRun parameter.
+func isDryRun(params interface{}) bool {
+	input := reflect.Indirect(reflect.ValueOf(params))
+	if input.Kind() != reflect.Struct {
+		return false
+	}
+	field := input.FieldByName("DryRun")
+	if !field.IsValid() {
+		return false
+	}
+	dryRun, ok := field.Interface().(*bool)
+	return ok && aws.BoolValue(dryRun)
+}
+
+// skipSend makes a request succeed without sending it. The handlers building, signing, sending and unmarshaling the
+// request are removed from its own copy of the handlers of the client, and the nil pointers of its output are
+// allocated so that the callers dereferencing its fields don't panic.
+func skipSend(r *request.Request) {
+	r.Handlers.Build.Clear()
+	r.Handlers.Sign.Clear()
+	r.Handlers.Send.Clear()
+	r.Handlers.ValidateResponse.Clear()
+	r.Handlers.UnmarshalMeta.Clear()
+	r.Handlers.Unmarshal.Clear()
+	r.Handlers.UnmarshalError.Clear()
+	r.Handlers.CompleteAttempt.Clear()
+	r.Handlers.Retry.Clear()
+	r.Handlers.AfterRetry.Clear()
+	allocateNilPointers(reflect.ValueOf(r.Data), map[reflect.Type]bool{})
+}
+
+// allocateNilPointers sets the nil pointer fields of the struct pointed to by v, and of its nested structs, to
+// pointers to zero values. The fields pointing to a struct which contains them are left nil.
+func allocateNilPointers(v reflect.Value, parents map[reflect.Type]bool) {
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return
+	}
+	elem := v.Elem()
+	parents[elem.Type()] = true
+	defer delete(parents, elem.Type())
+
+	for i := 0; i < elem.NumField(); i++ {
+		field := elem.Field(i)
+		if field.Kind() != reflect.Ptr || !field.CanSet() || parents[field.Type().Elem()] {
+			continue
+		}
+		if field.IsNil() {
+			field.Set(reflect.New(field.Type().Elem()))
+		}
+		allocateNilPointers(field, parents)
+	}
+}
+
+// recordPendingChange adds the operation to the requests listed by the PendingChangesCondition of the target, and
+// emits an event the first time the operation is refused in the reconciliation.
+func recordPendingChange(target runtime.Object, operation string) {
+	setter, ok := target.(conditions.Setter)
+	if !ok {
+		return
+	}
+
+	pendingChangesMu.Lock()
+	defer pendingChangesMu.Unlock()
+
+	operations := PendingChanges(setter)
+	for _, existing := range operations {
+		if existing == operation {
+			return
+		}
+	}
+	operations = append(operations, operation)
+	sort.Strings(operations)
+
+	conditions.MarkTrueWithNegativePolarity(setter, infrav1.PendingChangesCondition, infrav1.ReadOnlyModeReason, "", "%s%s", pendingChangesMessagePrefix, strings.Join(operations, ", "))
+	record.Eventf(target, "ReadOnlyMode", "Request %s wasn't sent as the cluster is in read-only mode", operation)
+}
+
+// PendingChanges returns the operations of the requests listed by the PendingChangesCondition of the object.
+func PendingChanges(obj conditions.Getter) []string {
+	message := strings.TrimPrefix(conditions.GetMessage(obj, infrav1.PendingChangesCondition), pendingChangesMessagePrefix)
+	if message == "" {
+		return nil
+	}
+	return strings.Split(message, ", ")
+}
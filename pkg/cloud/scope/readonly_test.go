@@ -0,0 +1,183 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/aws/aws-sdk-go/service/elb"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestReadOnlyGuard(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv("AWS_ACCESS_KEY_ID", "test")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "test")
+
+	var (
+		mu      sync.Mutex
+		actions []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		mu.Lock()
+		actions = append(actions, r.Form.Get("Action"))
+		mu.Unlock()
+		fmt.Fprintf(w, `<%[1]sResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/"></%[1]sResponse>`, r.Form.Get("Action"))
+	}))
+	defer server.Close()
+
+	scheme, err := setupScheme()
+	g.Expect(err).NotTo(HaveOccurred())
+	awsCluster := &infrav1.AWSCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "read-only",
+			Namespace:   "default",
+			Annotations: map[string]string{infrav1.ReadOnlyAnnotation: "true"},
+		},
+		Spec: infrav1.AWSClusterSpec{Region: "us-east-1"},
+	}
+	conditions.MarkTrueWithNegativePolarity(awsCluster, infrav1.PendingChangesCondition, infrav1.ReadOnlyModeReason, "", "%sEC2:DeleteVpc", pendingChangesMessagePrefix)
+	clusterScope, err := NewClusterScope(ClusterScopeParams{
+		Client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "read-only", Namespace: "default"},
+		},
+		AWSCluster: awsCluster,
+		Endpoints: []ServiceEndpoint{
+			{ServiceID: "ec2", URL: server.URL, SigningRegion: "us-east-1"},
+			{ServiceID: "elasticloadbalancing", URL: server.URL, SigningRegion: "us-east-1"},
+			{ServiceID: "autoscaling", URL: server.URL, SigningRegion: "us-east-1"},
+			{ServiceID: "eks", URL: server.URL, SigningRegion: "us-east-1"},
+		},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	// The requests refused by a previous reconciliation aren't listed anymore.
+	g.Expect(PendingChanges(awsCluster)).To(BeEmpty())
+
+	ec2Client := NewEC2Client(clusterScope, clusterScope, clusterScope, awsCluster)
+	elbClient := NewELBClient(clusterScope, clusterScope, clusterScope, awsCluster)
+	asgClient := NewASGClient(clusterScope, clusterScope, clusterScope, awsCluster)
+	eksClient := NewEKSClient(clusterScope, clusterScope, clusterScope, awsCluster)
+
+	mutations := []func() error{
+		func() error {
+			_, err := ec2Client.CreateVpc(&ec2.CreateVpcInput{CidrBlock: aws.String("10.0.0.0/16")})
+			return err
+		},
+		func() error {
+			_, err := ec2Client.CreateTags(&ec2.CreateTagsInput{Resources: aws.StringSlice([]string{"vpc-1"}), Tags: []*ec2.Tag{{Key: aws.String("k"), Value: aws.String("v")}}})
+			return err
+		},
+		func() error {
+			_, err := elbClient.DeleteLoadBalancer(&elb.DeleteLoadBalancerInput{LoadBalancerName: aws.String("lb")})
+			return err
+		},
+		func() error {
+			_, err := asgClient.UpdateAutoScalingGroup(&autoscaling.UpdateAutoScalingGroupInput{AutoScalingGroupName: aws.String("asg"), DesiredCapacity: aws.Int64(3)})
+			return err
+		},
+		func() error {
+			_, err := eksClient.UpdateClusterVersion(&eks.UpdateClusterVersionInput{Name: aws.String("cluster"), Version: aws.String("1.29")})
+			return err
+		},
+		// A mutating request is refused again once it's already pending.
+		func() error {
+			_, err := ec2Client.CreateVpc(&ec2.CreateVpcInput{CidrBlock: aws.String("10.0.0.0/16")})
+			return err
+		},
+	}
+	for _, mutate := range mutations {
+		g.Expect(mutate()).To(Succeed())
+	}
+	g.Expect(actions).To(BeEmpty())
+
+	// The output of a refused request is filled with zero values.
+	out, err := ec2Client.CreateVpc(&ec2.CreateVpcInput{CidrBlock: aws.String("10.0.0.0/16")})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(out.Vpc).NotTo(BeNil())
+	g.Expect(aws.StringValue(out.Vpc.VpcId)).To(BeEmpty())
+	g.Expect(out.Vpc.VpcId).NotTo(BeNil())
+
+	condition := conditions.Get(awsCluster, infrav1.PendingChangesCondition)
+	g.Expect(condition).NotTo(BeNil())
+	g.Expect(condition.Status).To(Equal(corev1.ConditionTrue))
+	g.Expect(condition.Reason).To(Equal(infrav1.ReadOnlyModeReason))
+	g.Expect(PendingChanges(awsCluster)).To(Equal([]string{
+		"Auto Scaling:UpdateAutoScalingGroup",
+		"EC2:CreateTags",
+		"EC2:CreateVpc",
+		"EKS:UpdateClusterVersion",
+		"Elastic Load Balancing:DeleteLoadBalancer",
+	}))
+
+	// Describe and dry-run requests are still sent.
+	_, err = ec2Client.DescribeVpcs(&ec2.DescribeVpcsInput{})
+	g.Expect(err).NotTo(HaveOccurred())
+	_, err = ec2Client.RunInstances(&ec2.RunInstancesInput{ImageId: aws.String("ami-1"), MinCount: aws.Int64(1), MaxCount: aws.Int64(1), DryRun: aws.Bool(true)})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(actions).To(Equal([]string{"DescribeVpcs", "RunInstances"}))
+	g.Expect(PendingChanges(awsCluster)).NotTo(ContainElement("EC2:RunInstances"))
+
+	// Mutating requests are sent again once the annotation is removed.
+	delete(awsCluster.Annotations, infrav1.ReadOnlyAnnotation)
+	_, err = ec2Client.CreateTags(&ec2.CreateTagsInput{Resources: aws.StringSlice([]string{"vpc-1"}), Tags: []*ec2.Tag{{Key: aws.String("k"), Value: aws.String("v")}}})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(actions).To(Equal([]string{"DescribeVpcs", "RunInstances", "CreateTags"}))
+}
+
+func TestIsMutatingOperation(t *testing.T) {
+	g := NewWithT(t)
+
+	for _, name := range []string{"DescribeInstances", "DescribeVpcsPages", "ListTagsForResource", "GetCallerIdentity", "HeadObject", "SimulatePrincipalPolicy"} {
+		g.Expect(IsMutatingOperation(name)).To(BeFalse(), name)
+	}
+	for _, name := range []string{"RunInstances", "CreateTags", "DeleteLaunchTemplate", "ModifyInstanceAttribute", "UpdateClusterConfig", "PutObject"} {
+		g.Expect(IsMutatingOperation(name)).To(BeTrue(), name)
+	}
+}
+
+func TestDeferDeletion(t *testing.T) {
+	g := NewWithT(t)
+	log := logger.NewLogger(klog.Background())
+
+	controlPlane := &ekscontrolplanev1.AWSManagedControlPlane{}
+	managedScope := &ManagedControlPlaneScope{ControlPlane: controlPlane}
+	g.Expect(DeferDeletion(log, managedScope)).To(BeFalse())
+
+	controlPlane.Annotations = map[string]string{infrav1.ReadOnlyAnnotation: "true"}
+	g.Expect(DeferDeletion(log, managedScope)).To(BeTrue())
+}
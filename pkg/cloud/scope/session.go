@@ -19,9 +19,12 @@ package scope
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sync"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/endpoints"
 	"github.com/aws/aws-sdk-go/aws/session"
@@ -37,6 +40,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/identity"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/throttle"
@@ -49,7 +53,8 @@ import (
 )
 
 const (
-	notPermittedError = "Namespace is not permitted to use %s: %s"
+	notPermittedError       = "Namespace is not permitted to use %s: %s"
+	regionNotPermittedError = "Region %q is not permitted to use %s: %s"
 )
 
 // ServiceEndpoint defines a tuple containing AWS Service resolution information.
@@ -77,20 +82,10 @@ func sessionForRegion(region string, endpoint []ServiceEndpoint) (*session.Sessi
 		return entry.session, entry.serviceLimiters, nil
 	}
 
-	resolver := func(service, region string, optFns ...func(*endpoints.Options)) (endpoints.ResolvedEndpoint, error) {
-		for _, s := range endpoint {
-			if service == s.ServiceID {
-				return endpoints.ResolvedEndpoint{
-					URL:           s.URL,
-					SigningRegion: s.SigningRegion,
-				}, nil
-			}
-		}
-		return endpoints.DefaultResolver().EndpointFor(service, region, optFns...)
-	}
-	ns, err := session.NewSession(&aws.Config{
+	resolver := endpointResolver(endpoint)
+	ns, err := newSession(&aws.Config{
 		Region:           aws.String(region),
-		EndpointResolver: endpoints.ResolverFunc(resolver),
+		EndpointResolver: resolver,
 	})
 	if err != nil {
 		return nil, nil, err
@@ -108,22 +103,14 @@ func sessionForClusterWithRegion(k8sClient client.Client, clusterScoper cloud.Se
 	log = log.WithName("identity")
 	log.Trace("Creating an AWS Session")
 
-	resolver := func(service, region string, optFns ...func(*endpoints.Options)) (endpoints.ResolvedEndpoint, error) {
-		for _, s := range endpoint {
-			if service == s.ServiceID {
-				return endpoints.ResolvedEndpoint{
-					URL:           s.URL,
-					SigningRegion: s.SigningRegion,
-				}, nil
-			}
-		}
-		return endpoints.DefaultResolver().EndpointFor(service, region, optFns...)
-	}
+	resolver := endpointResolver(endpoint)
 
 	providers, err := getProvidersForCluster(context.Background(), k8sClient, clusterScoper, region, log)
 	if err != nil {
 		// could not get providers and retrieve the credentials
 		conditions.MarkFalse(clusterScoper.InfraCluster(), infrav1.PrincipalCredentialRetrievedCondition, infrav1.PrincipalCredentialRetrievalFailedReason, clusterv1.ConditionSeverityError, err.Error())
+		conditions.MarkFalse(clusterScoper.InfraCluster(), infrav1.IdentityResolvedCondition, infrav1.IdentityResolutionFailedReason, clusterv1.ConditionSeverityError, err.Error())
+		setIdentityCredentialsExpiration(clusterScoper, nil)
 		return nil, nil, errors.Wrap(err, "Failed to get providers for cluster")
 	}
 
@@ -146,6 +133,23 @@ func sessionForClusterWithRegion(k8sClient client.Client, clusterScoper cloud.Se
 		awsProviders[i] = provider.(credentials.Provider)
 	}
 
+	// The identity is resolved on every reconciliation, the providers only assume their roles again once
+	// their credentials expired.
+	if len(providers) > 0 {
+		if _, err := awsProviders[0].Retrieve(); err != nil {
+			// One reason this will fail is that source identity is not authorized for assume role.
+			conditions.MarkUnknown(clusterScoper.InfraCluster(), infrav1.PrincipalCredentialRetrievedCondition, infrav1.CredentialProviderBuildFailedReason, err.Error())
+			setIdentityUnresolvedCondition(clusterScoper, err)
+
+			// delete the existing session from cache. Otherwise, we give back a defective session on next method invocation with same cluster scope
+			sessionCache.Delete(getSessionName(region, clusterScoper))
+
+			return nil, nil, errors.Wrap(err, "Failed to retrieve identity credentials")
+		}
+	}
+	conditions.MarkTrue(clusterScoper.InfraCluster(), infrav1.IdentityResolvedCondition)
+	setIdentityCredentialsExpiration(clusterScoper, awsProviders)
+
 	if !isChanged {
 		if s, ok := sessionCache.Load(getSessionName(region, clusterScoper)); ok {
 			entry := s.(*sessionCacheEntry)
@@ -154,26 +158,16 @@ func sessionForClusterWithRegion(k8sClient client.Client, clusterScoper cloud.Se
 	}
 	awsConfig := &aws.Config{
 		Region:           aws.String(region),
-		EndpointResolver: endpoints.ResolverFunc(resolver),
+		EndpointResolver: resolver,
 	}
 
 	if len(providers) > 0 {
-		// Check if identity credentials can be retrieved. One reason this will fail is that source identity is not authorized for assume role.
-		_, err := providers[0].Retrieve()
-		if err != nil {
-			conditions.MarkUnknown(clusterScoper.InfraCluster(), infrav1.PrincipalCredentialRetrievedCondition, infrav1.CredentialProviderBuildFailedReason, err.Error())
-
-			// delete the existing session from cache. Otherwise, we give back a defective session on next method invocation with same cluster scope
-			sessionCache.Delete(getSessionName(region, clusterScoper))
-
-			return nil, nil, errors.Wrap(err, "Failed to retrieve identity credentials")
-		}
 		awsConfig = awsConfig.WithCredentials(credentials.NewChainCredentials(awsProviders))
 	}
 
 	conditions.MarkTrue(clusterScoper.InfraCluster(), infrav1.PrincipalCredentialRetrievedCondition)
 
-	ns, err := session.NewSession(awsConfig)
+	ns, err := newSession(awsConfig)
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "Failed to create a new AWS session")
 	}
@@ -186,6 +180,46 @@ func sessionForClusterWithRegion(k8sClient client.Client, clusterScoper cloud.Se
 	return ns, sl, nil
 }
 
+// newSession creates a session for the region of the given config. The SDK falls back to the region of the
+// environment of the controller when the region is empty, which is reverted so that the calls of a cluster
+// without region fail instead of targeting the region the controller runs in.
+func newSession(awsConfig *aws.Config) (*session.Session, error) {
+	ns, err := session.NewSession(awsConfig)
+	if err != nil {
+		return nil, err
+	}
+	ns.Config.Region = aws.String(aws.StringValue(awsConfig.Region))
+	return ns, nil
+}
+
+// endpointResolver returns a resolver using the custom service endpoints. The endpoints are configured per
+// signing region: the endpoint of the region of the cluster is preferred, and the endpoint of another region
+// is only used when the service has a single custom endpoint, which is the case of single region setups.
+func endpointResolver(serviceEndpoints []ServiceEndpoint) endpoints.ResolverFunc {
+	return func(service, region string, optFns ...func(*endpoints.Options)) (endpoints.ResolvedEndpoint, error) {
+		var candidates []ServiceEndpoint
+		for _, s := range serviceEndpoints {
+			if service != s.ServiceID {
+				continue
+			}
+			if s.SigningRegion == region {
+				return endpoints.ResolvedEndpoint{
+					URL:           s.URL,
+					SigningRegion: s.SigningRegion,
+				}, nil
+			}
+			candidates = append(candidates, s)
+		}
+		if len(candidates) == 1 {
+			return endpoints.ResolvedEndpoint{
+				URL:           candidates[0].URL,
+				SigningRegion: candidates[0].SigningRegion,
+			}, nil
+		}
+		return endpoints.DefaultResolver().EndpointFor(service, region, optFns...)
+	}
+}
+
 func getSessionName(region string, clusterScoper cloud.SessionMetadata) string {
 	return fmt.Sprintf("%s-%s-%s", region, clusterScoper.InfraClusterName(), clusterScoper.Namespace())
 }
@@ -270,14 +304,14 @@ func buildProvidersForRef(
 
 	switch ref.Kind {
 	case infrav1.ControllerIdentityKind:
-		err := buildAWSClusterControllerIdentity(ctx, identityObjectKey, k8sClient, clusterScoper)
+		err := buildAWSClusterControllerIdentity(ctx, identityObjectKey, k8sClient, clusterScoper, region)
 		if err != nil {
 			return providers, err
 		}
 		// returning empty provider list to default to Controller Principal.
 		return []identity.AWSPrincipalTypeProvider{}, nil
 	case infrav1.ClusterStaticIdentityKind:
-		provider, err := buildAWSClusterStaticIdentity(ctx, identityObjectKey, k8sClient, clusterScoper)
+		provider, err := buildAWSClusterStaticIdentity(ctx, identityObjectKey, k8sClient, clusterScoper, region)
 		if err != nil {
 			return providers, err
 		}
@@ -297,6 +331,9 @@ func buildProvidersForRef(
 			setPrincipalUsageNotAllowedCondition(infrav1.ClusterRoleIdentityKind, identityObjectKey, clusterScoper)
 			return providers, errors.Errorf(notPermittedError, infrav1.ClusterRoleIdentityKind, roleIdentity.Name)
 		}
+		if err := checkPrincipalRegion(infrav1.ClusterRoleIdentityKind, identityObjectKey, roleIdentity.Spec.AllowedRegions, region, clusterScoper); err != nil {
+			return providers, err
+		}
 		setPrincipalUsageAllowedCondition(clusterScoper)
 
 		if roleIdentity.Spec.SourceIdentityRef != nil {
@@ -323,6 +360,52 @@ func buildProvidersForRef(
 	return providers, nil
 }
 
+// patchIdentityConditions persists the conditions reporting on the identity of a cluster whose session could not
+// be created, as the controllers can't go any further and would otherwise only return the error.
+func patchIdentityConditions(helper *patch.Helper, obj client.Object) error {
+	return helper.Patch(context.TODO(), obj, patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{
+		infrav1.PrincipalUsageAllowedCondition,
+		infrav1.PrincipalCredentialRetrievedCondition,
+		infrav1.IdentityResolvedCondition,
+	}})
+}
+
+// setIdentityUnresolvedCondition reports the role which could not be assumed, with the AWS error code, so that
+// a wrong trust policy can be told apart from other failures.
+func setIdentityUnresolvedCondition(clusterScoper cloud.SessionMetadata, err error) {
+	msg := err.Error()
+	var assumeRoleErr *identity.AssumeRoleError
+	if errors.As(err, &assumeRoleErr) {
+		msg = fmt.Sprintf("Failed to assume role %s: %v", assumeRoleErr.RoleARN, assumeRoleErr.Err)
+		var awsErr awserr.Error
+		if errors.As(assumeRoleErr.Err, &awsErr) {
+			msg = fmt.Sprintf("Failed to assume role %s: %s: %s", assumeRoleErr.RoleARN, awsErr.Code(), awsErr.Message())
+		}
+	}
+	conditions.MarkFalse(clusterScoper.InfraCluster(), infrav1.IdentityResolvedCondition, infrav1.IdentityResolutionFailedReason, clusterv1.ConditionSeverityError, msg)
+	setIdentityCredentialsExpiration(clusterScoper, nil)
+}
+
+// setIdentityCredentialsExpiration records in the status of the cluster when the credentials of the role it
+// assumes expire. Nothing is recorded for the controller identity and static credentials.
+func setIdentityCredentialsExpiration(clusterScoper cloud.SessionMetadata, providers []credentials.Provider) {
+	var expiration *metav1.Time
+	if len(providers) > 0 {
+		if expirer, ok := providers[0].(interface{ ExpiresAt() (time.Time, error) }); ok {
+			if expiresAt, err := expirer.ExpiresAt(); err == nil && !expiresAt.IsZero() {
+				expiration = &metav1.Time{Time: expiresAt}
+			}
+		}
+	}
+
+	switch obj := clusterScoper.InfraCluster().(type) {
+	case *infrav1.AWSCluster:
+		obj.Status.IdentityCredentialsExpiration = expiration
+	case *ekscontrolplanev1.AWSManagedControlPlane:
+		obj.Status.IdentityCredentialsExpiration = expiration
+	}
+}
+
 func setPrincipalUsageAllowedCondition(clusterScoper cloud.SessionMetadata) {
 	conditions.MarkTrue(clusterScoper.InfraCluster(), infrav1.PrincipalUsageAllowedCondition)
 }
@@ -337,7 +420,7 @@ func setPrincipalUsageNotAllowedCondition(kind infrav1.AWSIdentityKind, identity
 	}
 }
 
-func buildAWSClusterStaticIdentity(ctx context.Context, identityObjectKey client.ObjectKey, k8sClient client.Client, clusterScoper cloud.SessionMetadata) (*identity.AWSStaticPrincipalTypeProvider, error) {
+func buildAWSClusterStaticIdentity(ctx context.Context, identityObjectKey client.ObjectKey, k8sClient client.Client, clusterScoper cloud.SessionMetadata, region string) (*identity.AWSStaticPrincipalTypeProvider, error) {
 	staticPrincipal := &infrav1.AWSClusterStaticIdentity{}
 	err := k8sClient.Get(ctx, identityObjectKey, staticPrincipal)
 	if err != nil {
@@ -374,12 +457,15 @@ func buildAWSClusterStaticIdentity(ctx context.Context, identityObjectKey client
 		setPrincipalUsageNotAllowedCondition(infrav1.ClusterStaticIdentityKind, identityObjectKey, clusterScoper)
 		return nil, errors.Errorf(notPermittedError, infrav1.ClusterStaticIdentityKind, identityObjectKey.Name)
 	}
+	if err := checkPrincipalRegion(infrav1.ClusterStaticIdentityKind, identityObjectKey, staticPrincipal.Spec.AllowedRegions, region, clusterScoper); err != nil {
+		return nil, err
+	}
 	setPrincipalUsageAllowedCondition(clusterScoper)
 
 	return identity.NewAWSStaticPrincipalTypeProvider(staticPrincipal, secret), nil
 }
 
-func buildAWSClusterControllerIdentity(ctx context.Context, identityObjectKey client.ObjectKey, k8sClient client.Client, clusterScoper cloud.SessionMetadata) error {
+func buildAWSClusterControllerIdentity(ctx context.Context, identityObjectKey client.ObjectKey, k8sClient client.Client, clusterScoper cloud.SessionMetadata, region string) error {
 	controllerIdentity := &infrav1.AWSClusterControllerIdentity{}
 	controllerIdentity.Kind = string(infrav1.ControllerIdentityKind)
 
@@ -401,11 +487,35 @@ func buildAWSClusterControllerIdentity(ctx context.Context, identityObjectKey cl
 		setPrincipalUsageNotAllowedCondition(infrav1.ControllerIdentityKind, identityObjectKey, clusterScoper)
 		return errors.Errorf(notPermittedError, infrav1.ControllerIdentityKind, controllerIdentity.Name)
 	}
+	if err := checkPrincipalRegion(infrav1.ControllerIdentityKind, identityObjectKey, controllerIdentity.Spec.AllowedRegions, region, clusterScoper); err != nil {
+		return err
+	}
 	setPrincipalUsageAllowedCondition(clusterScoper)
 	return nil
 }
 
+// checkPrincipalRegion returns an error if the region of the cluster is not one of the regions the identity
+// is allowed to be used in. An empty list of allowed regions allows any region.
+func checkPrincipalRegion(kind infrav1.AWSIdentityKind, identityObjectKey client.ObjectKey, allowedRegions []string, region string, clusterScoper cloud.SessionMetadata) error {
+	if len(allowedRegions) == 0 || slices.Contains(allowedRegions, region) {
+		return nil
+	}
+
+	errMsg := fmt.Sprintf(regionNotPermittedError, region, kind, identityObjectKey.Name)
+	conditions.MarkFalse(clusterScoper.InfraCluster(), infrav1.PrincipalUsageAllowedCondition, infrav1.PrincipalRegionNotAllowedReason, clusterv1.ConditionSeverityError, errMsg)
+	return errors.New(errMsg)
+}
+
 func getProvidersForCluster(ctx context.Context, k8sClient client.Client, clusterScoper cloud.SessionMetadata, region string, log logger.Wrapper) ([]identity.AWSPrincipalTypeProvider, error) {
+	// The clusters of a namespace with an identity role of its own assume it in place of the controller identity.
+	roleARN := namespaceIdentityRole(clusterScoper)
+	setNamespaceIdentityRoleStatus(clusterScoper, roleARN)
+	if roleARN != "" {
+		log.Trace("Using the identity role of the namespace", "roleARN", roleARN)
+		setPrincipalUsageAllowedCondition(clusterScoper)
+		return []identity.AWSPrincipalTypeProvider{namespaceIdentityProvider(clusterScoper, roleARN, region, log)}, nil
+	}
+
 	providers := make([]identity.AWSPrincipalTypeProvider, 0)
 	providers, err := buildProvidersForRef(ctx, providers, k8sClient, clusterScoper, clusterScoper.IdentityRef(), region, log)
 	if err != nil {
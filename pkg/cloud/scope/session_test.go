@@ -19,7 +19,11 @@ package scope
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -35,6 +39,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/util/system"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
 )
 
 func TestIsClusterPermittedToUsePrincipal(t *testing.T) {
@@ -423,6 +428,50 @@ func TestPrincipalParsing(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "Should not be able to get a role Principal outside of its allowed regions",
+			awsCluster: infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "cluster3",
+					Namespace: "default",
+				},
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: infrav1.GroupVersion.String(),
+					Kind:       "AWSCluster",
+				},
+				Spec: infrav1.AWSClusterSpec{
+					Region: "us-west-2",
+					IdentityRef: &infrav1.AWSIdentityReference{
+						Name: "role-identity",
+						Kind: infrav1.ClusterRoleIdentityKind,
+					},
+				},
+			},
+			setup: func(t *testing.T, c client.Client) {
+				t.Helper()
+
+				identity := &infrav1.AWSClusterRoleIdentity{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "role-identity",
+					},
+					Spec: infrav1.AWSClusterRoleIdentitySpec{
+						AWSClusterIdentitySpec: infrav1.AWSClusterIdentitySpec{
+							AllowedNamespaces: &infrav1.AllowedNamespaces{},
+							AllowedRegions:    []string{"eu-west-1"},
+						},
+						AWSRoleSpec: infrav1.AWSRoleSpec{
+							RoleArn: "role-arn",
+						},
+					},
+				}
+				identity.SetGroupVersionKind(infrav1.GroupVersion.WithKind("AWSClusterRoleIdentity"))
+				err := c.Create(context.Background(), identity)
+				if err != nil {
+					t.Fatal(err)
+				}
+			},
+			expectError: true,
+		},
 		{
 			name: "Can get a session for a role Principal",
 			awsCluster: infrav1.AWSCluster{
@@ -503,3 +552,298 @@ func TestPrincipalParsing(t *testing.T) {
 		})
 	}
 }
+
+func TestSessionsForClustersInDifferentRegions(t *testing.T) {
+	g := NewWithT(t)
+
+	// The region of the environment of the controller must not be used by the clusters.
+	t.Setenv("AWS_REGION", "ap-south-1")
+
+	scheme, err := setupScheme()
+	g.Expect(err).NotTo(HaveOccurred())
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+	roleIdentity := &infrav1.AWSClusterRoleIdentity{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "shared-role-identity",
+		},
+		Spec: infrav1.AWSClusterRoleIdentitySpec{
+			AWSClusterIdentitySpec: infrav1.AWSClusterIdentitySpec{
+				AllowedNamespaces: &infrav1.AllowedNamespaces{},
+			},
+			AWSRoleSpec: infrav1.AWSRoleSpec{
+				RoleArn: "role-arn",
+			},
+		},
+	}
+	g.Expect(k8sClient.Create(context.Background(), roleIdentity)).To(Succeed())
+
+	hashes := map[string]string{}
+	for _, region := range []string{"eu-west-1", "us-gov-west-1", ""} {
+		clusterScope, err := NewClusterScope(ClusterScopeParams{
+			Client: k8sClient,
+			Cluster: &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "regions-" + region, Namespace: "default"},
+			},
+			AWSCluster: &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "regions-" + region, Namespace: "default"},
+				Spec:       infrav1.AWSClusterSpec{Region: region},
+			},
+		})
+		g.Expect(err).NotTo(HaveOccurred())
+
+		g.Expect(clusterScope.Session().(*session.Session).Config.Region).To(Equal(aws.String(region)))
+		g.Expect(clusterScope.Partition()).To(Equal(system.GetPartitionFromRegion(region)))
+
+		clusterScope.AWSCluster.Spec.IdentityRef = &infrav1.AWSIdentityReference{
+			Name: roleIdentity.Name,
+			Kind: infrav1.ClusterRoleIdentityKind,
+		}
+		providers, err := getProvidersForCluster(context.Background(), k8sClient, clusterScope, clusterScope.Region(), logger.NewLogger(klog.Background()))
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(providers).To(HaveLen(1))
+		hash, err := providers[0].Hash()
+		g.Expect(err).NotTo(HaveOccurred())
+		hashes[hash] = region
+	}
+
+	// The providers of the shared identity are cached per region.
+	g.Expect(hashes).To(HaveLen(3))
+}
+
+func TestParseNamespaceIdentityRoles(t *testing.T) {
+	testCases := []struct {
+		name          string
+		value         string
+		expectedRoles map[string]string
+		expectErr     bool
+	}{
+		{
+			name:          "empty",
+			value:         "",
+			expectedRoles: map[string]string{},
+		},
+		{
+			name:  "roles",
+			value: "team-a=arn:aws:iam::111111111111:role/capa, team-b=arn:aws-us-gov:iam::222222222222:role/path/capa",
+			expectedRoles: map[string]string{
+				"team-a": "arn:aws:iam::111111111111:role/capa",
+				"team-b": "arn:aws-us-gov:iam::222222222222:role/path/capa",
+			},
+		},
+		{
+			name:      "missing role",
+			value:     "team-a",
+			expectErr: true,
+		},
+		{
+			name:      "invalid namespace",
+			value:     "Team_A=arn:aws:iam::111111111111:role/capa",
+			expectErr: true,
+		},
+		{
+			name:      "duplicate namespace",
+			value:     "team-a=arn:aws:iam::111111111111:role/capa,team-a=arn:aws:iam::111111111111:role/other",
+			expectErr: true,
+		},
+		{
+			name:      "not a role",
+			value:     "team-a=arn:aws:iam::111111111111:user/capa",
+			expectErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			roles, err := ParseNamespaceIdentityRoles(tc.value)
+			if tc.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(roles).To(Equal(tc.expectedRoles))
+		})
+	}
+}
+
+func TestNamespaceIdentityRoles(t *testing.T) {
+	scheme, err := setupScheme()
+	if err != nil {
+		t.Fatal(err)
+	}
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+	roleIdentity := &infrav1.AWSClusterRoleIdentity{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "explicit-role-identity",
+		},
+		Spec: infrav1.AWSClusterRoleIdentitySpec{
+			AWSClusterIdentitySpec: infrav1.AWSClusterIdentitySpec{
+				AllowedNamespaces: &infrav1.AllowedNamespaces{},
+			},
+			AWSRoleSpec: infrav1.AWSRoleSpec{
+				RoleArn: "arn:aws:iam::333333333333:role/explicit",
+			},
+		},
+	}
+	if err := k8sClient.Create(context.Background(), roleIdentity); err != nil {
+		t.Fatal(err)
+	}
+
+	SetNamespaceIdentityRoles(map[string]string{"team-a": "arn:aws:iam::111111111111:role/capa"})
+	defer SetNamespaceIdentityRoles(nil)
+
+	testCases := []struct {
+		name            string
+		namespace       string
+		identityRef     *infrav1.AWSIdentityReference
+		expectedRoleARN string
+		expectedStatus  string
+	}{
+		{
+			name:            "cluster without identityRef assumes the role of its namespace",
+			namespace:       "team-a",
+			expectedRoleARN: "arn:aws:iam::111111111111:role/capa",
+			expectedStatus:  "arn:aws:iam::111111111111:role/capa",
+		},
+		{
+			name:      "cluster using the controller identity assumes the role of its namespace",
+			namespace: "team-a",
+			identityRef: &infrav1.AWSIdentityReference{
+				Name: infrav1.AWSClusterControllerIdentityName,
+				Kind: infrav1.ControllerIdentityKind,
+			},
+			expectedRoleARN: "arn:aws:iam::111111111111:role/capa",
+			expectedStatus:  "arn:aws:iam::111111111111:role/capa",
+		},
+		{
+			name:      "cluster with an explicit identity ignores the role of its namespace",
+			namespace: "team-a",
+			identityRef: &infrav1.AWSIdentityReference{
+				Name: roleIdentity.Name,
+				Kind: infrav1.ClusterRoleIdentityKind,
+			},
+			expectedRoleARN: "arn:aws:iam::333333333333:role/explicit",
+		},
+		{
+			name:      "cluster of a namespace without identity role",
+			namespace: "team-b",
+			identityRef: &infrav1.AWSIdentityReference{
+				Name: roleIdentity.Name,
+				Kind: infrav1.ClusterRoleIdentityKind,
+			},
+			expectedRoleARN: "arn:aws:iam::333333333333:role/explicit",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			awsCluster := &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: tc.namespace},
+				Spec:       infrav1.AWSClusterSpec{Region: "eu-west-1", IdentityRef: tc.identityRef},
+			}
+			clusterScope := &ClusterScope{
+				Cluster:    &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: tc.namespace}},
+				AWSCluster: awsCluster,
+			}
+
+			providers, err := getProvidersForCluster(context.Background(), k8sClient, clusterScope, "eu-west-1", logger.NewLogger(klog.Background()))
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(providers).To(HaveLen(1))
+			roleProvider, ok := providers[0].(*identity.AWSRolePrincipalTypeProvider)
+			g.Expect(ok).To(BeTrue())
+			g.Expect(roleProvider.Principal.Spec.RoleArn).To(Equal(tc.expectedRoleARN))
+
+			g.Expect(awsCluster.Status.NamespaceIdentityRoleARN).To(Equal(tc.expectedStatus))
+		})
+	}
+}
+
+func TestSetIdentityUnresolvedCondition(t *testing.T) {
+	testCases := []struct {
+		name            string
+		err             error
+		expectedMessage string
+	}{
+		{
+			name:            "role which cannot be assumed",
+			err:             &identity.AssumeRoleError{RoleARN: "arn:aws:iam::111111111111:role/capa", Err: awserr.NewRequestFailure(awserr.New("AccessDenied", "User is not authorized to perform: sts:AssumeRole", nil), 403, "request-id")},
+			expectedMessage: "Failed to assume role arn:aws:iam::111111111111:role/capa: AccessDenied: User is not authorized to perform: sts:AssumeRole",
+		},
+		{
+			name:            "role which cannot be assumed without an AWS error",
+			err:             &identity.AssumeRoleError{RoleARN: "arn:aws:iam::111111111111:role/capa", Err: errors.New("connection refused")},
+			expectedMessage: "Failed to assume role arn:aws:iam::111111111111:role/capa: connection refused",
+		},
+		{
+			name:            "other failure",
+			err:             errors.New("secret not found"),
+			expectedMessage: "secret not found",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			awsCluster := &infrav1.AWSCluster{
+				Status: infrav1.AWSClusterStatus{IdentityCredentialsExpiration: &metav1.Time{Time: time.Now()}},
+			}
+			clusterScope := &ClusterScope{AWSCluster: awsCluster}
+
+			setIdentityUnresolvedCondition(clusterScope, tc.err)
+			g.Expect(conditions.IsFalse(awsCluster, infrav1.IdentityResolvedCondition)).To(BeTrue())
+			g.Expect(conditions.GetReason(awsCluster, infrav1.IdentityResolvedCondition)).To(Equal(infrav1.IdentityResolutionFailedReason))
+			g.Expect(conditions.GetMessage(awsCluster, infrav1.IdentityResolvedCondition)).To(Equal(tc.expectedMessage))
+			g.Expect(awsCluster.Status.IdentityCredentialsExpiration).To(BeNil())
+		})
+	}
+}
+
+func TestEndpointResolver(t *testing.T) {
+	tests := []struct {
+		name      string
+		endpoints []ServiceEndpoint
+		region    string
+		wantURL   string
+	}{
+		{
+			name:    "no custom endpoint",
+			region:  "eu-west-1",
+			wantURL: "https://ec2.eu-west-1.amazonaws.com",
+		},
+		{
+			name: "endpoint of the region",
+			endpoints: []ServiceEndpoint{
+				{ServiceID: "ec2", URL: "https://ec2.us-east-1.example.com", SigningRegion: "us-east-1"},
+				{ServiceID: "ec2", URL: "https://ec2.eu-west-1.example.com", SigningRegion: "eu-west-1"},
+			},
+			region:  "eu-west-1",
+			wantURL: "https://ec2.eu-west-1.example.com",
+		},
+		{
+			name: "single endpoint of another region",
+			endpoints: []ServiceEndpoint{
+				{ServiceID: "ec2", URL: "https://ec2.example.com", SigningRegion: "us-east-1"},
+			},
+			region:  "eu-west-1",
+			wantURL: "https://ec2.example.com",
+		},
+		{
+			name: "endpoints of other regions",
+			endpoints: []ServiceEndpoint{
+				{ServiceID: "ec2", URL: "https://ec2.us-east-1.example.com", SigningRegion: "us-east-1"},
+				{ServiceID: "ec2", URL: "https://ec2.us-west-2.example.com", SigningRegion: "us-west-2"},
+			},
+			region:  "eu-west-1",
+			wantURL: "https://ec2.eu-west-1.amazonaws.com",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			resolved, err := endpointResolver(tt.endpoints).EndpointFor("ec2", tt.region)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(resolved.URL).To(Equal(tt.wantURL))
+		})
+	}
+}
@@ -34,12 +34,24 @@ type SGScope interface {
 	// SecurityGroupOverrides returns the security groups that are used as overrides in the cluster spec
 	SecurityGroupOverrides() map[infrav1.SecurityGroupRole]string
 
+	// ApplyRulesToUnmanagedGroups returns whether ingress rules should be reconciled on security group overrides.
+	ApplyRulesToUnmanagedGroups() bool
+
 	// VPC returns the cluster VPC.
 	VPC() *infrav1.VPCSpec
 
 	// CNIIngressRules returns the CNI spec ingress rules.
 	CNIIngressRules() infrav1.CNIIngressRules
 
+	// CNIPreset returns the preset of CNI ingress rules of the spec.
+	CNIPreset() infrav1.CNIPreset
+
+	// LegacyClusterTag returns the configuration of the kubernetes.io/cluster/<name> tag.
+	LegacyClusterTag() *infrav1.LegacyClusterTag
+
+	// ResourceNaming returns the configuration of the Name tag of the resources created for the cluster.
+	ResourceNaming() *infrav1.ResourceNaming
+
 	// Bastion returns the bastion details for the cluster.
 	Bastion() *infrav1.Bastion
 
@@ -0,0 +1,102 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+)
+
+// DefaultSecurityGroupFilterCacheTTL is the default duration for which security group IDs
+// resolved from filters are reused.
+const DefaultSecurityGroupFilterCacheTTL = 5 * time.Minute
+
+// SecurityGroupFilterCache caches the security group IDs resolved from AWSResourceReference filters.
+// Entries are keyed per cluster, so machine pools of the same cluster sharing a filter share a single
+// DescribeSecurityGroups call per TTL period. A nil cache is valid and caches nothing.
+type SecurityGroupFilterCache struct {
+	ttl     time.Duration
+	now     func() time.Time
+	mu      sync.Mutex
+	entries map[string]securityGroupFilterCacheEntry
+}
+
+type securityGroupFilterCacheEntry struct {
+	ids     []string
+	expires time.Time
+}
+
+// NewSecurityGroupFilterCache creates a new SecurityGroupFilterCache with the given TTL.
+func NewSecurityGroupFilterCache(ttl time.Duration) *SecurityGroupFilterCache {
+	return &SecurityGroupFilterCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: map[string]securityGroupFilterCacheEntry{},
+	}
+}
+
+// Get returns the cached security group IDs for the filters of the given cluster, if present and not expired.
+func (c *SecurityGroupFilterCache) Get(cluster string, filters []infrav1.Filter) ([]string, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := securityGroupFilterCacheKey(cluster, filters)
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if c.now().After(entry.expires) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return append([]string(nil), entry.ids...), true
+}
+
+// Set stores the security group IDs resolved for the filters of the given cluster.
+func (c *SecurityGroupFilterCache) Set(cluster string, filters []infrav1.Filter, ids []string) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[securityGroupFilterCacheKey(cluster, filters)] = securityGroupFilterCacheEntry{
+		ids:     append([]string(nil), ids...),
+		expires: c.now().Add(c.ttl),
+	}
+}
+
+// securityGroupFilterCacheKey builds a key that does not depend on the order of the filters or their values.
+func securityGroupFilterCacheKey(cluster string, filters []infrav1.Filter) string {
+	parts := make([]string, 0, len(filters))
+	for _, f := range filters {
+		values := append([]string(nil), f.Values...)
+		sort.Strings(values)
+		parts = append(parts, f.Name+"="+strings.Join(values, ","))
+	}
+	sort.Strings(parts)
+	return cluster + "/" + strings.Join(parts, ";")
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+)
+
+func TestSecurityGroupFilterCache(t *testing.T) {
+	g := NewWithT(t)
+
+	now := time.Now()
+	cache := NewSecurityGroupFilterCache(time.Minute)
+	cache.now = func() time.Time { return now }
+
+	filters := []infrav1.Filter{
+		{Name: "tag:team", Values: []string{"a", "b"}},
+		{Name: "vpc-id", Values: []string{"vpc-1"}},
+	}
+	reordered := []infrav1.Filter{
+		{Name: "vpc-id", Values: []string{"vpc-1"}},
+		{Name: "tag:team", Values: []string{"b", "a"}},
+	}
+
+	_, ok := cache.Get("ns/cluster", filters)
+	g.Expect(ok).To(BeFalse())
+
+	cache.Set("ns/cluster", filters, []string{"sg-1", "sg-2"})
+
+	ids, ok := cache.Get("ns/cluster", reordered)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(ids).To(Equal([]string{"sg-1", "sg-2"}))
+
+	_, ok = cache.Get("ns/other-cluster", filters)
+	g.Expect(ok).To(BeFalse(), "entries must not be shared between clusters")
+
+	now = now.Add(2 * time.Minute)
+	_, ok = cache.Get("ns/cluster", filters)
+	g.Expect(ok).To(BeFalse(), "expired entries must not be returned")
+
+	var nilCache *SecurityGroupFilterCache
+	nilCache.Set("ns/cluster", filters, []string{"sg-1"})
+	_, ok = nilCache.Get("ns/cluster", filters)
+	g.Expect(ok).To(BeFalse())
+}
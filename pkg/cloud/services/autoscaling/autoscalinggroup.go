@@ -20,14 +20,18 @@ package asg
 import (
 	"context"
 	"fmt"
+	"math"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/autoscaling"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/utils/ptr"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
@@ -39,6 +43,28 @@ import (
 	"sigs.k8s.io/cluster-api/util/annotations"
 )
 
+const (
+	// NodeTerminationLifecycleHookName is the name of the termination lifecycle hook added to the
+	// autoscaling groups for the node termination handler.
+	NodeTerminationLifecycleHookName = "capa-node-termination-handler"
+
+	// nodeTerminationLifecycleHookHeartbeatTimeout is how long, in seconds, an instance waits for the
+	// node termination handler to drain its node before it is terminated.
+	nodeTerminationLifecycleHookHeartbeatTimeout = 300
+
+	// lifecycleHookNotificationMetadata is set on the lifecycle hooks of the AWSMachinePool spec, so that the
+	// ones removed from the spec can be told apart from the hooks added by other tooling.
+	lifecycleHookNotificationMetadata = "sigs.k8s.io/cluster-api-provider-aws"
+
+	// noActiveLifecycleActionMessage is the message of the error returned when completing a lifecycle action
+	// which was already completed, or timed out.
+	noActiveLifecycleActionMessage = "No active Lifecycle Action found"
+
+	// predictiveScalingForecastWindow is how far ahead the forecast of a predictive scaling policy is fetched.
+	// Auto Scaling forecasts the next 48 hours.
+	predictiveScalingForecastWindow = 48 * time.Hour
+)
+
 // SDKToAutoScalingGroup converts an AWS EC2 SDK AutoScalingGroup to the CAPA AutoScalingGroup type.
 func (s *Service) SDKToAutoScalingGroup(v *autoscaling.Group) (*expinfrav1.AutoScalingGroup, error) {
 	i := &expinfrav1.AutoScalingGroup{
@@ -56,41 +82,41 @@ func (s *Service) SDKToAutoScalingGroup(v *autoscaling.Group) (*expinfrav1.AutoS
 		i.Subnets = strings.Split(*v.VPCZoneIdentifier, ",")
 	}
 
-	if v.MixedInstancesPolicy != nil {
-		i.MixedInstancesPolicy = &expinfrav1.MixedInstancesPolicy{
-			InstancesDistribution: &expinfrav1.InstancesDistribution{
-				OnDemandBaseCapacity:                v.MixedInstancesPolicy.InstancesDistribution.OnDemandBaseCapacity,
-				OnDemandPercentageAboveBaseCapacity: v.MixedInstancesPolicy.InstancesDistribution.OnDemandPercentageAboveBaseCapacity,
-			},
-		}
+	if len(v.TerminationPolicies) > 0 {
+		i.TerminationPolicies = aws.StringValueSlice(v.TerminationPolicies)
+	}
 
-		for _, override := range v.MixedInstancesPolicy.LaunchTemplate.Overrides {
-			i.MixedInstancesPolicy.Overrides = append(i.MixedInstancesPolicy.Overrides, expinfrav1.Overrides{InstanceType: aws.StringValue(override.InstanceType)})
-		}
+	for _, metric := range v.EnabledMetrics {
+		i.EnabledMetrics = append(i.EnabledMetrics, aws.StringValue(metric.Metric))
+	}
 
-		onDemandAllocationStrategy := aws.StringValue(v.MixedInstancesPolicy.InstancesDistribution.OnDemandAllocationStrategy)
-		switch onDemandAllocationStrategy {
-		case string(expinfrav1.OnDemandAllocationStrategyPrioritized):
-			i.MixedInstancesPolicy.InstancesDistribution.OnDemandAllocationStrategy = expinfrav1.OnDemandAllocationStrategyPrioritized
-		case string(expinfrav1.OnDemandAllocationStrategyLowestPrice):
-			i.MixedInstancesPolicy.InstancesDistribution.OnDemandAllocationStrategy = expinfrav1.OnDemandAllocationStrategyLowestPrice
-		default:
-			return nil, fmt.Errorf("unsupported on-demand allocation strategy: %s", onDemandAllocationStrategy)
-		}
+	i.HealthCheckType = expinfrav1.HealthCheckType(aws.StringValue(v.HealthCheckType))
+	if v.HealthCheckGracePeriod != nil {
+		i.HealthCheckGracePeriod = metav1.Duration{Duration: time.Duration(*v.HealthCheckGracePeriod) * time.Second}
+	}
+	// An ASG without a default instance warmup reports none or -1.
+	if v.DefaultInstanceWarmup != nil && *v.DefaultInstanceWarmup >= 0 {
+		i.DefaultInstanceWarmup = &metav1.Duration{Duration: time.Duration(*v.DefaultInstanceWarmup) * time.Second}
+	}
+	// An ASG without a maximum instance lifetime reports none or 0.
+	if aws.Int64Value(v.MaxInstanceLifetime) > 0 {
+		i.MaxInstanceLifetime = &metav1.Duration{Duration: time.Duration(*v.MaxInstanceLifetime) * time.Second}
+	}
 
-		spotAllocationStrategy := aws.StringValue(v.MixedInstancesPolicy.InstancesDistribution.SpotAllocationStrategy)
-		switch spotAllocationStrategy {
-		case string(expinfrav1.SpotAllocationStrategyLowestPrice):
-			i.MixedInstancesPolicy.InstancesDistribution.SpotAllocationStrategy = expinfrav1.SpotAllocationStrategyLowestPrice
-		case string(expinfrav1.SpotAllocationStrategyCapacityOptimized):
-			i.MixedInstancesPolicy.InstancesDistribution.SpotAllocationStrategy = expinfrav1.SpotAllocationStrategyCapacityOptimized
-		case string(expinfrav1.SpotAllocationStrategyCapacityOptimizedPrioritized):
-			i.MixedInstancesPolicy.InstancesDistribution.SpotAllocationStrategy = expinfrav1.SpotAllocationStrategyCapacityOptimizedPrioritized
-		case string(expinfrav1.SpotAllocationStrategyPriceCapacityOptimized):
-			i.MixedInstancesPolicy.InstancesDistribution.SpotAllocationStrategy = expinfrav1.SpotAllocationStrategyPriceCapacityOptimized
-		default:
-			return nil, fmt.Errorf("unsupported spot allocation strategy: %s", spotAllocationStrategy)
+	// An ASG either uses a launch template or a mixed instances policy. Either may have been switched to the other
+	// outside of CAPA, and a policy set up by other tooling may leave out any of its parts.
+	if v.MixedInstancesPolicy != nil {
+		mixedInstancesPolicy, err := sdkToMixedInstancesPolicy(v.MixedInstancesPolicy)
+		if err != nil {
+			return nil, err
 		}
+		i.MixedInstancesPolicy = mixedInstancesPolicy
+		if lt := v.MixedInstancesPolicy.LaunchTemplate; lt != nil && lt.LaunchTemplateSpecification != nil {
+			i.LaunchTemplateVersion = aws.StringValue(lt.LaunchTemplateSpecification.Version)
+		}
+	}
+	if v.LaunchTemplate != nil {
+		i.LaunchTemplateVersion = aws.StringValue(v.LaunchTemplate.Version)
 	}
 
 	if v.Status != nil {
@@ -102,13 +128,26 @@ func (s *Service) SDKToAutoScalingGroup(v *autoscaling.Group) (*expinfrav1.AutoS
 	}
 
 	if len(v.Instances) > 0 {
+		i.InstanceDetails = make(map[string]expinfrav1.ASGInstanceDetails, len(v.Instances))
 		for _, autoscalingInstance := range v.Instances {
 			tmp := &infrav1.Instance{
 				ID:               aws.StringValue(autoscalingInstance.InstanceId),
-				State:            infrav1.InstanceState(*autoscalingInstance.LifecycleState),
-				AvailabilityZone: *autoscalingInstance.AvailabilityZone,
+				State:            infrav1.InstanceState(aws.StringValue(autoscalingInstance.LifecycleState)),
+				AvailabilityZone: aws.StringValue(autoscalingInstance.AvailabilityZone),
 			}
 			i.Instances = append(i.Instances, *tmp)
+
+			details := expinfrav1.ASGInstanceDetails{
+				HealthStatus:         aws.StringValue(autoscalingInstance.HealthStatus),
+				InstanceType:         aws.StringValue(autoscalingInstance.InstanceType),
+				ProtectedFromScaleIn: aws.BoolValue(autoscalingInstance.ProtectedFromScaleIn),
+			}
+			// The instances launched with a mixed instances policy report the launch template of the policy.
+			if autoscalingInstance.LaunchTemplate != nil {
+				details.LaunchTemplateID = aws.StringValue(autoscalingInstance.LaunchTemplate.LaunchTemplateId)
+				details.LaunchTemplateVersion = aws.StringValue(autoscalingInstance.LaunchTemplate.Version)
+			}
+			i.InstanceDetails[tmp.ID] = details
 		}
 	}
 
@@ -123,6 +162,61 @@ func (s *Service) SDKToAutoScalingGroup(v *autoscaling.Group) (*expinfrav1.AutoS
 	return i, nil
 }
 
+func sdkToMixedInstancesPolicy(v *autoscaling.MixedInstancesPolicy) (*expinfrav1.MixedInstancesPolicy, error) {
+	mixedInstancesPolicy := &expinfrav1.MixedInstancesPolicy{}
+
+	if v.LaunchTemplate != nil {
+		for _, override := range v.LaunchTemplate.Overrides {
+			o := expinfrav1.Overrides{
+				InstanceType:         aws.StringValue(override.InstanceType),
+				InstanceRequirements: sdkToInstanceRequirements(override.InstanceRequirements),
+			}
+			// The root volume or bootstrap data live in the launch template of the override, the root volume only
+			// records that there is one.
+			if override.LaunchTemplateSpecification != nil {
+				o.RootVolume = &infrav1.Volume{}
+			}
+			mixedInstancesPolicy.Overrides = append(mixedInstancesPolicy.Overrides, o)
+		}
+	}
+
+	if v.InstancesDistribution == nil {
+		return mixedInstancesPolicy, nil
+	}
+	mixedInstancesPolicy.InstancesDistribution = &expinfrav1.InstancesDistribution{
+		OnDemandBaseCapacity:                v.InstancesDistribution.OnDemandBaseCapacity,
+		OnDemandPercentageAboveBaseCapacity: v.InstancesDistribution.OnDemandPercentageAboveBaseCapacity,
+	}
+
+	onDemandAllocationStrategy := aws.StringValue(v.InstancesDistribution.OnDemandAllocationStrategy)
+	switch onDemandAllocationStrategy {
+	case "":
+	case string(expinfrav1.OnDemandAllocationStrategyPrioritized):
+		mixedInstancesPolicy.InstancesDistribution.OnDemandAllocationStrategy = expinfrav1.OnDemandAllocationStrategyPrioritized
+	case string(expinfrav1.OnDemandAllocationStrategyLowestPrice):
+		mixedInstancesPolicy.InstancesDistribution.OnDemandAllocationStrategy = expinfrav1.OnDemandAllocationStrategyLowestPrice
+	default:
+		return nil, fmt.Errorf("unsupported on-demand allocation strategy: %s", onDemandAllocationStrategy)
+	}
+
+	spotAllocationStrategy := aws.StringValue(v.InstancesDistribution.SpotAllocationStrategy)
+	switch spotAllocationStrategy {
+	case "":
+	case string(expinfrav1.SpotAllocationStrategyLowestPrice):
+		mixedInstancesPolicy.InstancesDistribution.SpotAllocationStrategy = expinfrav1.SpotAllocationStrategyLowestPrice
+	case string(expinfrav1.SpotAllocationStrategyCapacityOptimized):
+		mixedInstancesPolicy.InstancesDistribution.SpotAllocationStrategy = expinfrav1.SpotAllocationStrategyCapacityOptimized
+	case string(expinfrav1.SpotAllocationStrategyCapacityOptimizedPrioritized):
+		mixedInstancesPolicy.InstancesDistribution.SpotAllocationStrategy = expinfrav1.SpotAllocationStrategyCapacityOptimizedPrioritized
+	case string(expinfrav1.SpotAllocationStrategyPriceCapacityOptimized):
+		mixedInstancesPolicy.InstancesDistribution.SpotAllocationStrategy = expinfrav1.SpotAllocationStrategyPriceCapacityOptimized
+	default:
+		return nil, fmt.Errorf("unsupported spot allocation strategy: %s", spotAllocationStrategy)
+	}
+
+	return mixedInstancesPolicy, nil
+}
+
 // ASGIfExists returns the existing autoscaling group or nothing if it doesn't exist.
 func (s *Service) ASGIfExists(name *string) (*expinfrav1.AutoScalingGroup, error) {
 	if name == nil {
@@ -170,8 +264,15 @@ func (s *Service) CreateASG(machinePoolScope *scope.MachinePoolScope) (*expinfra
 		Subnets:               subnets,
 		DefaultCoolDown:       machinePoolScope.AWSMachinePool.Spec.DefaultCoolDown,
 		DefaultInstanceWarmup: machinePoolScope.AWSMachinePool.Spec.DefaultInstanceWarmup,
+		MaxInstanceLifetime:   machinePoolScope.AWSMachinePool.Spec.MaxInstanceLifetime,
 		CapacityRebalance:     machinePoolScope.AWSMachinePool.Spec.CapacityRebalance,
-		MixedInstancesPolicy:  machinePoolScope.AWSMachinePool.Spec.MixedInstancesPolicy,
+		MixedInstancesPolicy:  machinePoolScope.GetMixedInstancesPolicy(),
+		TerminationPolicies:   machinePoolScope.AWSMachinePool.Spec.TerminationPolicies,
+		HealthCheckType:       machinePoolScope.AWSMachinePool.Spec.HealthCheckType,
+		TargetGroupARNs:       machinePoolScope.AWSMachinePool.Spec.TargetGroupARNs,
+	}
+	if gracePeriod := machinePoolScope.AWSMachinePool.Spec.HealthCheckGracePeriod; gracePeriod != nil {
+		input.HealthCheckGracePeriod = *gracePeriod
 	}
 
 	// Default value of MachinePool replicas set by CAPI is 1.
@@ -189,21 +290,16 @@ func (s *Service) CreateASG(machinePoolScope *scope.MachinePoolScope) (*expinfra
 		return nil, errors.New("AWSMachinePool has no LaunchTemplateID for some reason")
 	}
 
-	// Make sure to use the MachinePoolScope here to get the merger of AWSCluster and AWSMachinePool tags
-	additionalTags := machinePoolScope.AdditionalTags()
-	// Set the cloud provider tag
-	additionalTags[infrav1.ClusterAWSCloudProviderTagKey(s.scope.KubernetesClusterName())] = string(infrav1.ResourceLifecycleOwned)
-
-	input.Tags = infrav1.Build(infrav1.BuildParams{
-		ClusterName: s.scope.KubernetesClusterName(),
-		Lifecycle:   infrav1.ResourceLifecycleOwned,
-		Name:        aws.String(machinePoolScope.Name()),
-		Role:        aws.String("node"),
-		Additional:  additionalTags,
-	})
+	input.Tags = s.asgTags(machinePoolScope)
 
 	s.scope.Info("Running instance")
-	if err := s.runPool(input, machinePoolScope.AWSMachinePool.Status.LaunchTemplateID); err != nil {
+	launchTemplate := launchTemplateSpecification(machinePoolScope, input.MixedInstancesPolicy != nil)
+	if err := s.runPool(input, launchTemplate, &machinePoolScope.AWSMachinePool.Spec.AWSLaunchTemplate); err != nil {
+		// The ASG was created by a previous reconciliation which didn't find it, as the AutoScaling API is
+		// eventually consistent.
+		if code, _ := awserrors.Code(errors.Cause(err)); code == autoscaling.ErrCodeAlreadyExistsFault {
+			return s.existingASG(machinePoolScope)
+		}
 		// Only record the failure event if the error is not related to failed dependencies.
 		// This is to avoid spamming failure events since the machine will be requeued by the actuator.
 		// if !awserrors.IsFailedDependency(errors.Cause(err)) {
@@ -212,33 +308,81 @@ func (s *Service) CreateASG(machinePoolScope *scope.MachinePoolScope) (*expinfra
 		s.scope.Error(err, "unable to create AutoScalingGroup")
 		return nil, err
 	}
+	machinePoolScope.SetASGCreated(machinePoolScope.Name())
+	if len(input.TargetGroupARNs) > 0 {
+		machinePoolScope.AWSMachinePool.Status.TargetGroupARNs = sets.List(sets.New[string](input.TargetGroupARNs...))
+	}
+	if warmPool := machinePoolScope.AWSMachinePool.Spec.WarmPool; warmPool != nil {
+		if _, err := s.ASGClient.PutWarmPoolWithContext(context.TODO(), warmPoolInput(machinePoolScope.Name(), warmPool)); err != nil {
+			// non fatal error, the warm pool is put again by the next reconciliation
+			s.scope.Error(err, "non-fatal: failed to put warm pool for AutoScalingGroup", "name", machinePoolScope.Name())
+		}
+	}
+	if metrics := machinePoolScope.AWSMachinePool.Spec.Metrics; metrics != nil {
+		if err := s.EnableMetricsCollection(machinePoolScope.Name(), metrics.GetGranularity(), metrics.GetMetrics()); err != nil {
+			// non fatal error, the metrics collection is enabled again by the next reconciliation
+			s.scope.Error(err, "non-fatal: failed to enable metrics collection for AutoScalingGroup", "name", machinePoolScope.Name())
+		}
+	}
 	record.Eventf(machinePoolScope.AWSMachinePool, "SuccessfulCreate", "Created new ASG: %s", machinePoolScope.Name())
 
 	return nil, nil
 }
 
-func (s *Service) runPool(i *expinfrav1.AutoScalingGroup, launchTemplateID string) error {
+// existingASG returns the ASG of the pool which already exists, or nothing while it isn't described yet.
+func (s *Service) existingASG(machinePoolScope *scope.MachinePoolScope) (*expinfrav1.AutoScalingGroup, error) {
+	s.scope.Info("AutoScalingGroup already exists", "name", machinePoolScope.Name())
+	asg, err := s.GetASGByName(machinePoolScope)
+	if err != nil {
+		return nil, err
+	}
+	if asg == nil {
+		machinePoolScope.SetASGCreated(machinePoolScope.Name())
+		return nil, nil
+	}
+	machinePoolScope.SetASGDescribed(asg)
+	return asg, nil
+}
+
+func (s *Service) runPool(i *expinfrav1.AutoScalingGroup, launchTemplate *autoscaling.LaunchTemplateSpecification, awsLaunchTemplate *expinfrav1.AWSLaunchTemplate) error {
 	input := &autoscaling.CreateAutoScalingGroupInput{
-		AutoScalingGroupName:  aws.String(i.Name),
-		MaxSize:               aws.Int64(int64(i.MaxSize)),
-		MinSize:               aws.Int64(int64(i.MinSize)),
-		VPCZoneIdentifier:     aws.String(strings.Join(i.Subnets, ", ")),
-		DefaultCooldown:       aws.Int64(int64(i.DefaultCoolDown.Duration.Seconds())),
-		DefaultInstanceWarmup: aws.Int64(int64(i.DefaultInstanceWarmup.Duration.Seconds())),
-		CapacityRebalance:     aws.Bool(i.CapacityRebalance),
+		AutoScalingGroupName: aws.String(i.Name),
+		MaxSize:              aws.Int64(int64(i.MaxSize)),
+		MinSize:              aws.Int64(int64(i.MinSize)),
+		VPCZoneIdentifier:    aws.String(strings.Join(i.Subnets, ", ")),
+		DefaultCooldown:      aws.Int64(int64(i.DefaultCoolDown.Duration.Seconds())),
+		CapacityRebalance:    aws.Bool(i.CapacityRebalance),
+	}
+
+	if i.DefaultInstanceWarmup != nil {
+		input.DefaultInstanceWarmup = aws.Int64(int64(i.DefaultInstanceWarmup.Duration.Seconds()))
+	}
+
+	if i.MaxInstanceLifetime != nil && i.MaxInstanceLifetime.Duration > 0 {
+		input.MaxInstanceLifetime = aws.Int64(int64(i.MaxInstanceLifetime.Duration.Seconds()))
 	}
 
 	if i.DesiredCapacity != nil {
 		input.DesiredCapacity = aws.Int64(int64(aws.Int32Value(i.DesiredCapacity)))
 	}
 
+	if len(i.TerminationPolicies) > 0 {
+		input.TerminationPolicies = aws.StringSlice(i.TerminationPolicies)
+	}
+
+	if len(i.TargetGroupARNs) > 0 {
+		input.TargetGroupARNs = aws.StringSlice(i.TargetGroupARNs)
+	}
+
+	if i.HealthCheckType != "" {
+		input.HealthCheckType = aws.String(string(i.HealthCheckType))
+		input.HealthCheckGracePeriod = aws.Int64(int64(i.HealthCheckGracePeriod.Duration.Seconds()))
+	}
+
 	if i.MixedInstancesPolicy != nil {
-		input.MixedInstancesPolicy = createSDKMixedInstancesPolicy(i.Name, i.MixedInstancesPolicy)
+		input.MixedInstancesPolicy = createSDKMixedInstancesPolicy(i.Name, launchTemplate, awsLaunchTemplate, i.MixedInstancesPolicy)
 	} else {
-		input.LaunchTemplate = &autoscaling.LaunchTemplateSpecification{
-			LaunchTemplateId: aws.String(launchTemplateID),
-			Version:          aws.String(expinfrav1.LaunchTemplateLatestVersion),
-		}
+		input.LaunchTemplate = launchTemplate
 	}
 
 	if i.Tags != nil {
@@ -295,25 +439,47 @@ func (s *Service) UpdateASG(machinePoolScope *scope.MachinePoolScope) error {
 		return fmt.Errorf("getting subnets for ASG: %w", err)
 	}
 
+	spec := &machinePoolScope.AWSMachinePool.Spec
 	input := &autoscaling.UpdateAutoScalingGroupInput{
 		AutoScalingGroupName: aws.String(machinePoolScope.Name()), // TODO: define dynamically - borrow logic from ec2
-		MaxSize:              aws.Int64(int64(machinePoolScope.AWSMachinePool.Spec.MaxSize)),
-		MinSize:              aws.Int64(int64(machinePoolScope.AWSMachinePool.Spec.MinSize)),
 		VPCZoneIdentifier:    aws.String(strings.Join(subnetIDs, ",")),
-		CapacityRebalance:    aws.Bool(machinePoolScope.AWSMachinePool.Spec.CapacityRebalance),
+		CapacityRebalance:    aws.Bool(spec.CapacityRebalance),
+		// Without termination policies the ASG keeps its current ones, so Default is sent to revert other policies.
+		TerminationPolicies: aws.StringSlice(spec.GetTerminationPolicies()),
+		// Without a health check type the ASG keeps its current one, so EC2 is sent to revert ELB health checks.
+		HealthCheckType: aws.String(string(spec.GetHealthCheckType())),
+	}
+	if spec.HealthCheckGracePeriod != nil {
+		input.HealthCheckGracePeriod = aws.Int64(int64(spec.HealthCheckGracePeriod.Duration.Seconds()))
 	}
+	// Without a default instance warmup the ASG keeps its current one.
+	if spec.DefaultInstanceWarmup != nil {
+		input.DefaultInstanceWarmup = aws.Int64(int64(spec.DefaultInstanceWarmup.Duration.Seconds()))
+	}
+	// Without a maximum instance lifetime the ASG keeps its current one, so 0 is sent to clear it.
+	input.MaxInstanceLifetime = aws.Int64(int64(spec.GetMaxInstanceLifetime().Seconds()))
 
-	if machinePoolScope.MachinePool.Spec.Replicas != nil && !annotations.ReplicasManagedByExternalAutoscaler(machinePoolScope.MachinePool) {
-		input.DesiredCapacity = aws.Int64(int64(*machinePoolScope.MachinePool.Spec.Replicas))
+	// Fields owned by other tooling are left out of the request, so that their current values are kept.
+	if !spec.IsUnmanaged(expinfrav1.UnmanagedFieldMaxSize) {
+		input.MaxSize = aws.Int64(int64(machinePoolScope.AWSMachinePool.ASGMaxSize()))
+	}
+	if !spec.IsUnmanaged(expinfrav1.UnmanagedFieldMinSize) {
+		input.MinSize = aws.Int64(int64(spec.MinSize))
 	}
 
-	if machinePoolScope.AWSMachinePool.Spec.MixedInstancesPolicy != nil {
-		input.MixedInstancesPolicy = createSDKMixedInstancesPolicy(machinePoolScope.Name(), machinePoolScope.AWSMachinePool.Spec.MixedInstancesPolicy)
-	} else {
-		input.LaunchTemplate = &autoscaling.LaunchTemplateSpecification{
-			LaunchTemplateId: aws.String(machinePoolScope.AWSMachinePool.Status.LaunchTemplateID),
-			Version:          aws.String(expinfrav1.LaunchTemplateLatestVersion),
-		}
+	if machinePoolScope.MachinePool.Spec.Replicas != nil && !annotations.ReplicasManagedByExternalAutoscaler(machinePoolScope.MachinePool) &&
+		!spec.IsUnmanaged(expinfrav1.UnmanagedFieldDesiredCapacity) {
+		// The instances put in standby were left out of the desired capacity.
+		input.DesiredCapacity = aws.Int64(int64(*machinePoolScope.MachinePool.Spec.Replicas - machinePoolScope.StandbyInstanceCount()))
+	}
+
+	switch {
+	case spec.IsUnmanaged(expinfrav1.UnmanagedFieldMixedInstancesPolicy):
+		// The ASG keeps using the latest version of the launch template from within whichever policy it has.
+	case machinePoolScope.GetMixedInstancesPolicy() != nil:
+		input.MixedInstancesPolicy = createSDKMixedInstancesPolicy(machinePoolScope.Name(), launchTemplateSpecification(machinePoolScope, true), &machinePoolScope.AWSMachinePool.Spec.AWSLaunchTemplate, machinePoolScope.GetMixedInstancesPolicy())
+	default:
+		input.LaunchTemplate = launchTemplateSpecification(machinePoolScope, false)
 	}
 
 	if _, err := s.ASGClient.UpdateAutoScalingGroupWithContext(context.TODO(), input); err != nil {
@@ -346,70 +512,263 @@ func (s *Service) CanStartASGInstanceRefresh(scope *scope.MachinePoolScope) (boo
 	return true, nil
 }
 
-// StartASGInstanceRefresh will start an ASG instance with refresh.
-func (s *Service) StartASGInstanceRefresh(scope *scope.MachinePoolScope) error {
+// CancelASGInstanceRefresh cancels the running instance refresh of an autoscaling group. The cancellation completes
+// asynchronously, and an autoscaling group without a running instance refresh is left untouched.
+func (s *Service) CancelASGInstanceRefresh(name string) error {
+	_, err := s.ASGClient.CancelInstanceRefreshWithContext(context.TODO(), &autoscaling.CancelInstanceRefreshInput{
+		AutoScalingGroupName: aws.String(name),
+	})
+	if err != nil {
+		if code, _ := awserrors.Code(err); code == autoscaling.ErrCodeActiveInstanceRefreshNotFoundFault {
+			return nil
+		}
+		return errors.Wrapf(err, "failed to cancel instance refresh of AutoScalingGroup: %q", name)
+	}
+	return nil
+}
+
+// DescribeLatestInstanceRefresh returns the progress of the most recent instance refresh of an autoscaling group,
+// or nil when the autoscaling group never had an instance refresh.
+func (s *Service) DescribeLatestInstanceRefresh(name string) (*expinfrav1.InstanceRefreshStatus, error) {
+	// Instance refreshes are described from the most recent one.
+	out, err := s.ASGClient.DescribeInstanceRefreshesWithContext(context.TODO(), &autoscaling.DescribeInstanceRefreshesInput{
+		AutoScalingGroupName: aws.String(name),
+		MaxRecords:           aws.Int64(1),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to describe instance refreshes of AutoScalingGroup: %q", name)
+	}
+	if len(out.InstanceRefreshes) == 0 {
+		return nil, nil
+	}
+
+	refresh := out.InstanceRefreshes[0]
+	status := &expinfrav1.InstanceRefreshStatus{
+		ID:                 aws.StringValue(refresh.InstanceRefreshId),
+		State:              aws.StringValue(refresh.Status),
+		PercentageComplete: refresh.PercentageComplete,
+		InstancesToUpdate:  refresh.InstancesToUpdate,
+		StatusReason:       aws.StringValue(refresh.StatusReason),
+	}
+	if refresh.StartTime != nil {
+		status.StartTime = ptr.To(metav1.NewTime(*refresh.StartTime))
+	}
+	if refresh.EndTime != nil {
+		status.EndTime = ptr.To(metav1.NewTime(*refresh.EndTime))
+	}
+	return status, nil
+}
+
+// StartASGInstanceRefresh will start an ASG instance with refresh, and returns the ID of the instance refresh.
+func (s *Service) StartASGInstanceRefresh(scope *scope.MachinePoolScope) (string, error) {
 	strategy := ptr.To[string](autoscaling.RefreshStrategyRolling)
-	var minHealthyPercentage, maxHealthyPercentage, instanceWarmup *int64
-	if scope.AWSMachinePool.Spec.RefreshPreferences != nil {
-		if scope.AWSMachinePool.Spec.RefreshPreferences.Strategy != nil {
-			strategy = scope.AWSMachinePool.Spec.RefreshPreferences.Strategy
+	preferences := &autoscaling.RefreshPreferences{}
+	if refreshPreferences := scope.AWSMachinePool.Spec.RefreshPreferences; refreshPreferences != nil {
+		if refreshPreferences.Strategy != nil {
+			strategy = refreshPreferences.Strategy
+		}
+		preferences.InstanceWarmup = refreshPreferences.InstanceWarmup
+		preferences.MinHealthyPercentage = refreshPreferences.MinHealthyPercentage
+		preferences.MaxHealthyPercentage = refreshPreferences.MaxHealthyPercentage
+		if len(refreshPreferences.CheckpointPercentages) > 0 {
+			preferences.CheckpointPercentages = aws.Int64Slice(refreshPreferences.CheckpointPercentages)
+		}
+		if refreshPreferences.CheckpointDelay != nil {
+			preferences.CheckpointDelay = aws.Int64(int64(refreshPreferences.CheckpointDelay.Seconds()))
 		}
-		if scope.AWSMachinePool.Spec.RefreshPreferences.InstanceWarmup != nil {
-			instanceWarmup = scope.AWSMachinePool.Spec.RefreshPreferences.InstanceWarmup
+		preferences.SkipMatching = refreshPreferences.SkipMatching
+		if refreshPreferences.ScaleInProtectedInstances != "" {
+			preferences.ScaleInProtectedInstances = aws.String(string(refreshPreferences.ScaleInProtectedInstances))
 		}
-		if scope.AWSMachinePool.Spec.RefreshPreferences.MinHealthyPercentage != nil {
-			minHealthyPercentage = scope.AWSMachinePool.Spec.RefreshPreferences.MinHealthyPercentage
+		if refreshPreferences.StandbyInstances != "" {
+			preferences.StandbyInstances = aws.String(string(refreshPreferences.StandbyInstances))
 		}
-		if scope.AWSMachinePool.Spec.RefreshPreferences.MaxHealthyPercentage != nil {
-			maxHealthyPercentage = scope.AWSMachinePool.Spec.RefreshPreferences.MaxHealthyPercentage
+		// The instance refresh of an availability zone replaces the instances of the zone only, those of the
+		// other zones being protected from scale in, and the replacements launched in other zones being skipped.
+		if aws.StringValue(refreshPreferences.Strategy) == expinfrav1.RefreshStrategyAZSequential {
+			strategy = ptr.To[string](autoscaling.RefreshStrategyRolling)
+			preferences.SkipMatching = aws.Bool(true)
+			preferences.ScaleInProtectedInstances = aws.String(string(expinfrav1.ScaleInProtectedInstancesIgnore))
 		}
 	}
 
 	input := &autoscaling.StartInstanceRefreshInput{
 		AutoScalingGroupName: aws.String(scope.Name()),
 		Strategy:             strategy,
-		Preferences: &autoscaling.RefreshPreferences{
-			InstanceWarmup:       instanceWarmup,
-			MinHealthyPercentage: minHealthyPercentage,
-			MaxHealthyPercentage: maxHealthyPercentage,
-		},
+		Preferences:          preferences,
 	}
 
-	if _, err := s.ASGClient.StartInstanceRefreshWithContext(context.TODO(), input); err != nil {
-		return errors.Wrapf(err, "failed to start ASG instance refresh %q", scope.Name())
+	out, err := s.ASGClient.StartInstanceRefreshWithContext(context.TODO(), input)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to start ASG instance refresh %q", scope.Name())
 	}
 
-	return nil
+	return aws.StringValue(out.InstanceRefreshId), nil
+}
+
+// launchTemplateSpecification returns the primary launch template of the ASG. A referenced launch template is
+// launched at the version of the reference, the launch template managed by the controller at its latest version,
+// identified by name within a mixed instances policy. A launch template validated before use is launched at the
+// last version which passed the validation, recorded in the status, so that a new version isn't launched before.
+func launchTemplateSpecification(machinePoolScope *scope.MachinePoolScope, byName bool) *autoscaling.LaunchTemplateSpecification {
+	if ref := machinePoolScope.AWSMachinePool.Spec.AWSLaunchTemplate.Ref; ref != nil {
+		return &autoscaling.LaunchTemplateSpecification{
+			LaunchTemplateId: aws.String(machinePoolScope.AWSMachinePool.Status.LaunchTemplateID),
+			Version:          aws.String(ref.Version),
+		}
+	}
+	version := expinfrav1.LaunchTemplateLatestVersion
+	if machinePoolScope.AWSMachinePool.Spec.AWSLaunchTemplate.ValidateBeforeUse && machinePoolScope.GetLaunchTemplateLatestVersionStatus() != "" {
+		version = machinePoolScope.GetLaunchTemplateLatestVersionStatus()
+	}
+	if byName {
+		return &autoscaling.LaunchTemplateSpecification{
+			LaunchTemplateName: aws.String(machinePoolScope.Name()),
+			Version:            aws.String(version),
+		}
+	}
+	return &autoscaling.LaunchTemplateSpecification{
+		LaunchTemplateId: aws.String(machinePoolScope.AWSMachinePool.Status.LaunchTemplateID),
+		Version:          aws.String(version),
+	}
 }
 
-func createSDKMixedInstancesPolicy(name string, i *expinfrav1.MixedInstancesPolicy) *autoscaling.MixedInstancesPolicy {
+func createSDKMixedInstancesPolicy(name string, launchTemplate *autoscaling.LaunchTemplateSpecification, awsLaunchTemplate *expinfrav1.AWSLaunchTemplate, i *expinfrav1.MixedInstancesPolicy) *autoscaling.MixedInstancesPolicy {
 	mixedInstancesPolicy := &autoscaling.MixedInstancesPolicy{
 		LaunchTemplate: &autoscaling.LaunchTemplate{
-			LaunchTemplateSpecification: &autoscaling.LaunchTemplateSpecification{
-				LaunchTemplateName: aws.String(name),
-				Version:            aws.String(expinfrav1.LaunchTemplateLatestVersion),
-			},
+			LaunchTemplateSpecification: launchTemplate,
 		},
 	}
 
 	if i.InstancesDistribution != nil {
 		mixedInstancesPolicy.InstancesDistribution = &autoscaling.InstancesDistribution{
-			OnDemandAllocationStrategy:          aws.String(string(i.InstancesDistribution.OnDemandAllocationStrategy)),
 			OnDemandBaseCapacity:                i.InstancesDistribution.OnDemandBaseCapacity,
 			OnDemandPercentageAboveBaseCapacity: i.InstancesDistribution.OnDemandPercentageAboveBaseCapacity,
-			SpotAllocationStrategy:              aws.String(string(i.InstancesDistribution.SpotAllocationStrategy)),
+		}
+		// Empty strategies are rejected by the Auto Scaling API, which defaults them when left out.
+		if i.InstancesDistribution.OnDemandAllocationStrategy != "" {
+			mixedInstancesPolicy.InstancesDistribution.OnDemandAllocationStrategy = aws.String(string(i.InstancesDistribution.OnDemandAllocationStrategy))
+		}
+		if i.InstancesDistribution.SpotAllocationStrategy != "" {
+			mixedInstancesPolicy.InstancesDistribution.SpotAllocationStrategy = aws.String(string(i.InstancesDistribution.SpotAllocationStrategy))
 		}
 	}
 
 	for _, override := range i.Overrides {
-		mixedInstancesPolicy.LaunchTemplate.Overrides = append(mixedInstancesPolicy.LaunchTemplate.Overrides, &autoscaling.LaunchTemplateOverrides{
-			InstanceType: aws.String(override.InstanceType),
-		})
+		o := &autoscaling.LaunchTemplateOverrides{
+			InstanceRequirements: createSDKInstanceRequirements(override.InstanceRequirements),
+		}
+		if override.InstanceType != "" {
+			o.InstanceType = aws.String(override.InstanceType)
+		}
+		if scope.HasOverrideLaunchTemplate(awsLaunchTemplate, override) {
+			o.LaunchTemplateSpecification = &autoscaling.LaunchTemplateSpecification{
+				LaunchTemplateName: aws.String(scope.OverrideLaunchTemplateName(name, override.InstanceType)),
+				Version:            aws.String(expinfrav1.LaunchTemplateLatestVersion),
+			}
+		}
+		mixedInstancesPolicy.LaunchTemplate.Overrides = append(mixedInstancesPolicy.LaunchTemplate.Overrides, o)
 	}
 
 	return mixedInstancesPolicy
 }
 
+func createSDKInstanceRequirements(r *expinfrav1.InstanceRequirements) *autoscaling.InstanceRequirements {
+	if r == nil {
+		return nil
+	}
+
+	requirements := &autoscaling.InstanceRequirements{
+		VCpuCount: &autoscaling.VCpuCountRequest{
+			Min: aws.Int64(r.VCPUCount.Min),
+			Max: r.VCPUCount.Max,
+		},
+		MemoryMiB: &autoscaling.MemoryMiBRequest{
+			Min: aws.Int64(r.MemoryMiB.Min),
+			Max: r.MemoryMiB.Max,
+		},
+		SpotMaxPricePercentageOverLowestPrice:     r.SpotMaxPricePercentageOverLowestPrice,
+		OnDemandMaxPricePercentageOverLowestPrice: r.OnDemandMaxPricePercentageOverLowestPrice,
+	}
+	if len(r.AllowedInstanceTypes) > 0 {
+		requirements.AllowedInstanceTypes = aws.StringSlice(r.AllowedInstanceTypes)
+	}
+	if len(r.ExcludedInstanceTypes) > 0 {
+		requirements.ExcludedInstanceTypes = aws.StringSlice(r.ExcludedInstanceTypes)
+	}
+	for _, manufacturer := range r.CPUManufacturers {
+		requirements.CpuManufacturers = append(requirements.CpuManufacturers, aws.String(string(manufacturer)))
+	}
+	for _, generation := range r.InstanceGenerations {
+		requirements.InstanceGenerations = append(requirements.InstanceGenerations, aws.String(string(generation)))
+	}
+	for _, acceleratorType := range r.AcceleratorTypes {
+		requirements.AcceleratorTypes = append(requirements.AcceleratorTypes, aws.String(string(acceleratorType)))
+	}
+	if r.BurstablePerformance != "" {
+		requirements.BurstablePerformance = aws.String(string(r.BurstablePerformance))
+	}
+	if r.BareMetal != "" {
+		requirements.BareMetal = aws.String(string(r.BareMetal))
+	}
+	if r.AcceleratorCount != nil {
+		requirements.AcceleratorCount = &autoscaling.AcceleratorCountRequest{
+			Min: r.AcceleratorCount.Min,
+			Max: r.AcceleratorCount.Max,
+		}
+	}
+	return requirements
+}
+
+// sdkToInstanceRequirements converts the instance requirements of an override back to the ones of the spec, so
+// that the mixed instances policy of the ASG doesn't drift from it.
+func sdkToInstanceRequirements(r *autoscaling.InstanceRequirements) *expinfrav1.InstanceRequirements {
+	if r == nil {
+		return nil
+	}
+
+	requirements := &expinfrav1.InstanceRequirements{
+		BurstablePerformance:                      expinfrav1.InstanceTypeFeature(aws.StringValue(r.BurstablePerformance)),
+		BareMetal:                                 expinfrav1.InstanceTypeFeature(aws.StringValue(r.BareMetal)),
+		SpotMaxPricePercentageOverLowestPrice:     r.SpotMaxPricePercentageOverLowestPrice,
+		OnDemandMaxPricePercentageOverLowestPrice: r.OnDemandMaxPricePercentageOverLowestPrice,
+	}
+	if r.VCpuCount != nil {
+		requirements.VCPUCount = expinfrav1.VCPUCountRequest{
+			Min: aws.Int64Value(r.VCpuCount.Min),
+			Max: r.VCpuCount.Max,
+		}
+	}
+	if r.MemoryMiB != nil {
+		requirements.MemoryMiB = expinfrav1.MemoryMiBRequest{
+			Min: aws.Int64Value(r.MemoryMiB.Min),
+			Max: r.MemoryMiB.Max,
+		}
+	}
+	if len(r.AllowedInstanceTypes) > 0 {
+		requirements.AllowedInstanceTypes = aws.StringValueSlice(r.AllowedInstanceTypes)
+	}
+	if len(r.ExcludedInstanceTypes) > 0 {
+		requirements.ExcludedInstanceTypes = aws.StringValueSlice(r.ExcludedInstanceTypes)
+	}
+	for _, manufacturer := range r.CpuManufacturers {
+		requirements.CPUManufacturers = append(requirements.CPUManufacturers, expinfrav1.CPUManufacturer(aws.StringValue(manufacturer)))
+	}
+	for _, generation := range r.InstanceGenerations {
+		requirements.InstanceGenerations = append(requirements.InstanceGenerations, expinfrav1.InstanceGeneration(aws.StringValue(generation)))
+	}
+	for _, acceleratorType := range r.AcceleratorTypes {
+		requirements.AcceleratorTypes = append(requirements.AcceleratorTypes, expinfrav1.AcceleratorType(aws.StringValue(acceleratorType)))
+	}
+	if r.AcceleratorCount != nil {
+		requirements.AcceleratorCount = &expinfrav1.AcceleratorCountRequest{
+			Min: r.AcceleratorCount.Min,
+			Max: r.AcceleratorCount.Max,
+		}
+	}
+	return requirements
+}
+
 // BuildTagsFromMap takes a map of keys and values and returns them as autoscaling group tags.
 func BuildTagsFromMap(asgName string, inTags map[string]string) []*autoscaling.Tag {
 	if inTags == nil {
@@ -434,6 +793,51 @@ func BuildTagsFromMap(asgName string, inTags map[string]string) []*autoscaling.T
 	return tags
 }
 
+// asgTags returns the tags the ASG of the machine pool is created with, which mark it as owned by the cluster.
+func (s *Service) asgTags(machinePoolScope *scope.MachinePoolScope) infrav1.Tags {
+	// Make sure to use the MachinePoolScope here to get the merger of AWSCluster and AWSMachinePool tags
+	additionalTags := machinePoolScope.AdditionalTags()
+	// Set the cloud provider tag
+	additionalTags[infrav1.ClusterAWSCloudProviderTagKey(s.scope.KubernetesClusterName())] = string(infrav1.ResourceLifecycleOwned)
+
+	return infrav1.Build(infrav1.BuildParams{
+		ClusterName:        s.scope.KubernetesClusterName(),
+		OwnershipTagPrefix: s.scope.OwnershipTagPrefix(),
+		Lifecycle:          infrav1.ResourceLifecycleOwned,
+		Name:               aws.String(machinePoolScope.Name()),
+		Role:               aws.String("node"),
+		Additional:         additionalTags,
+	})
+}
+
+// AdoptASG tags an existing ASG named after the machine pool with the tags of the ASGs created by the controller,
+// so that it's owned by the cluster from then on.
+func (s *Service) AdoptASG(machinePoolScope *scope.MachinePoolScope) error {
+	return s.UpdateResourceTags(aws.String(machinePoolScope.Name()), s.asgTags(machinePoolScope), nil)
+}
+
+// ReleaseASG restores the sizes an adopted ASG had before its adoption, and removes the tags marking it as owned
+// by the cluster, so that it's left to other tooling.
+func (s *Service) ReleaseASG(asg *expinfrav1.AutoScalingGroup, minSize, maxSize, desiredCapacity int32) error {
+	input := &autoscaling.UpdateAutoScalingGroupInput{
+		AutoScalingGroupName: aws.String(asg.Name),
+		MinSize:              aws.Int64(int64(minSize)),
+		MaxSize:              aws.Int64(int64(maxSize)),
+		DesiredCapacity:      aws.Int64(int64(desiredCapacity)),
+	}
+	if _, err := s.ASGClient.UpdateAutoScalingGroupWithContext(context.TODO(), input); err != nil {
+		return errors.Wrapf(err, "failed to restore the sizes of AutoScalingGroup %q", asg.Name)
+	}
+
+	remove := map[string]string{}
+	for _, key := range infrav1.OwnershipTagKeys(s.scope.KubernetesClusterName(), s.scope.OwnershipTagPrefixes()...) {
+		if value, ok := asg.Tags[key]; ok {
+			remove[key] = value
+		}
+	}
+	return s.UpdateResourceTags(aws.String(asg.Name), nil, remove)
+}
+
 // UpdateResourceTags updates the tags for an autoscaling group.
 // This will be called if there is anything to create (update) or delete.
 // We may not always have to perform each action, so we check what we're
@@ -500,6 +904,392 @@ func (s *Service) ResumeProcesses(name string, processes []string) error {
 	return nil
 }
 
+// EnableMetricsCollection enables the collection of the given group metrics of an autoscaling group.
+func (s *Service) EnableMetricsCollection(name, granularity string, metrics []string) error {
+	input := &autoscaling.EnableMetricsCollectionInput{
+		AutoScalingGroupName: aws.String(name),
+		Granularity:          aws.String(granularity),
+		Metrics:              aws.StringSlice(metrics),
+	}
+	if _, err := s.ASGClient.EnableMetricsCollectionWithContext(context.TODO(), input); err != nil {
+		return errors.Wrapf(err, "failed to enable metrics collection %v for AutoScalingGroup %q", metrics, name)
+	}
+	return nil
+}
+
+// DisableMetricsCollection disables the collection of the given group metrics of an autoscaling group, or of all
+// of them when none is given.
+func (s *Service) DisableMetricsCollection(name string, metrics []string) error {
+	input := &autoscaling.DisableMetricsCollectionInput{
+		AutoScalingGroupName: aws.String(name),
+	}
+	if len(metrics) > 0 {
+		input.Metrics = aws.StringSlice(metrics)
+	}
+	if _, err := s.ASGClient.DisableMetricsCollectionWithContext(context.TODO(), input); err != nil {
+		return errors.Wrapf(err, "failed to disable metrics collection %v for AutoScalingGroup %q", metrics, name)
+	}
+	return nil
+}
+
+// EnterStandby moves instances of an autoscaling group into standby. The desired capacity of the group is
+// decremented, so that no instance is launched to replace them.
+func (s *Service) EnterStandby(name string, instanceIDs []string) error {
+	input := &autoscaling.EnterStandbyInput{
+		AutoScalingGroupName:           aws.String(name),
+		InstanceIds:                    aws.StringSlice(instanceIDs),
+		ShouldDecrementDesiredCapacity: aws.Bool(true),
+	}
+	if _, err := s.ASGClient.EnterStandbyWithContext(context.TODO(), input); err != nil {
+		return errors.Wrapf(err, "failed to move instances %v of AutoScalingGroup %q into standby", instanceIDs, name)
+	}
+	return nil
+}
+
+// ExitStandby moves instances of an autoscaling group out of standby. The desired capacity of the group is
+// incremented by the number of instances.
+func (s *Service) ExitStandby(name string, instanceIDs []string) error {
+	input := &autoscaling.ExitStandbyInput{
+		AutoScalingGroupName: aws.String(name),
+		InstanceIds:          aws.StringSlice(instanceIDs),
+	}
+	if _, err := s.ASGClient.ExitStandbyWithContext(context.TODO(), input); err != nil {
+		return errors.Wrapf(err, "failed to move instances %v of AutoScalingGroup %q out of standby", instanceIDs, name)
+	}
+	return nil
+}
+
+// SetInstanceProtection protects instances of an autoscaling group from scale in, or removes their protection.
+// Instance refreshes ignore the protected instances when configured to.
+func (s *Service) SetInstanceProtection(name string, instanceIDs []string, protected bool) error {
+	input := &autoscaling.SetInstanceProtectionInput{
+		AutoScalingGroupName: aws.String(name),
+		InstanceIds:          aws.StringSlice(instanceIDs),
+		ProtectedFromScaleIn: aws.Bool(protected),
+	}
+	if _, err := s.ASGClient.SetInstanceProtectionWithContext(context.TODO(), input); err != nil {
+		return errors.Wrapf(err, "failed to set the scale in protection of instances %v of AutoScalingGroup %q to %t", instanceIDs, name, protected)
+	}
+	return nil
+}
+
+// DetachInstances removes instances from an autoscaling group without terminating them. The desired capacity of the
+// group is decremented for each instance, so that no instance is launched to replace them.
+func (s *Service) DetachInstances(name string, instanceIDs []string) error {
+	input := &autoscaling.DetachInstancesInput{
+		AutoScalingGroupName:           aws.String(name),
+		InstanceIds:                    aws.StringSlice(instanceIDs),
+		ShouldDecrementDesiredCapacity: aws.Bool(true),
+	}
+	if _, err := s.ASGClient.DetachInstancesWithContext(context.TODO(), input); err != nil {
+		return errors.Wrapf(err, "failed to detach instances %v from AutoScalingGroup %q", instanceIDs, name)
+	}
+	return nil
+}
+
+// TerminateInstanceInASG terminates an instance of an autoscaling group. The desired capacity of the group is
+// decremented when decrementCapacity is set, otherwise the group launches an instance to replace it.
+func (s *Service) TerminateInstanceInASG(instanceID string, decrementCapacity bool) error {
+	input := &autoscaling.TerminateInstanceInAutoScalingGroupInput{
+		InstanceId:                     aws.String(instanceID),
+		ShouldDecrementDesiredCapacity: aws.Bool(decrementCapacity),
+	}
+	if _, err := s.ASGClient.TerminateInstanceInAutoScalingGroupWithContext(context.TODO(), input); err != nil {
+		return errors.Wrapf(err, "failed to terminate instance %q", instanceID)
+	}
+	return nil
+}
+
+// ReconcileNodeTerminationLifecycleHook adds the termination lifecycle hook used by the node termination
+// handler to an autoscaling group when enabled is true, and removes it otherwise.
+func (s *Service) ReconcileNodeTerminationLifecycleHook(name string, enabled bool) error {
+	out, err := s.ASGClient.DescribeLifecycleHooksWithContext(context.TODO(), &autoscaling.DescribeLifecycleHooksInput{
+		AutoScalingGroupName: aws.String(name),
+		LifecycleHookNames:   aws.StringSlice([]string{NodeTerminationLifecycleHookName}),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to describe lifecycle hooks for AutoScalingGroup: %q", name)
+	}
+	exists := len(out.LifecycleHooks) > 0
+
+	switch {
+	case enabled && !exists:
+		input := &autoscaling.PutLifecycleHookInput{
+			AutoScalingGroupName: aws.String(name),
+			LifecycleHookName:    aws.String(NodeTerminationLifecycleHookName),
+			LifecycleTransition:  aws.String("autoscaling:EC2_INSTANCE_TERMINATING"),
+			DefaultResult:        aws.String("CONTINUE"),
+			HeartbeatTimeout:     aws.Int64(nodeTerminationLifecycleHookHeartbeatTimeout),
+		}
+		if _, err := s.ASGClient.PutLifecycleHookWithContext(context.TODO(), input); err != nil {
+			return errors.Wrapf(err, "failed to put lifecycle hook for AutoScalingGroup: %q", name)
+		}
+		s.scope.Debug("Added node termination lifecycle hook", "name", name)
+	case !enabled && exists:
+		input := &autoscaling.DeleteLifecycleHookInput{
+			AutoScalingGroupName: aws.String(name),
+			LifecycleHookName:    aws.String(NodeTerminationLifecycleHookName),
+		}
+		if _, err := s.ASGClient.DeleteLifecycleHookWithContext(context.TODO(), input); err != nil {
+			return errors.Wrapf(err, "failed to delete lifecycle hook for AutoScalingGroup: %q", name)
+		}
+		s.scope.Debug("Removed node termination lifecycle hook", "name", name)
+	}
+	return nil
+}
+
+// ReconcileLifecycleHooks adds the lifecycle hooks of the AWSMachinePool spec to an autoscaling group, updates
+// the ones which changed, and removes the ones previously added which are no longer in the spec. The hooks added
+// by other tooling don't carry the notification metadata of the controller and are left untouched.
+func (s *Service) ReconcileLifecycleHooks(name string, hooks []expinfrav1.AWSLifecycleHook) error {
+	out, err := s.ASGClient.DescribeLifecycleHooksWithContext(context.TODO(), &autoscaling.DescribeLifecycleHooksInput{
+		AutoScalingGroupName: aws.String(name),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to describe lifecycle hooks for AutoScalingGroup: %q", name)
+	}
+
+	existing := make(map[string]*autoscaling.LifecycleHook, len(out.LifecycleHooks))
+	for _, hook := range out.LifecycleHooks {
+		existing[aws.StringValue(hook.LifecycleHookName)] = hook
+	}
+
+	wanted := make(map[string]bool, len(hooks))
+	for _, hook := range hooks {
+		wanted[hook.Name] = true
+
+		input := lifecycleHookInput(name, hook)
+		if current, ok := existing[hook.Name]; ok && lifecycleHookMatches(current, input) {
+			continue
+		}
+		if _, err := s.ASGClient.PutLifecycleHookWithContext(context.TODO(), input); err != nil {
+			return errors.Wrapf(err, "failed to put lifecycle hook %q for AutoScalingGroup: %q", hook.Name, name)
+		}
+		s.scope.Debug("Put lifecycle hook", "name", name, "hook", hook.Name)
+	}
+
+	for hookName, hook := range existing {
+		if wanted[hookName] || aws.StringValue(hook.NotificationMetadata) != lifecycleHookNotificationMetadata {
+			continue
+		}
+		input := &autoscaling.DeleteLifecycleHookInput{
+			AutoScalingGroupName: aws.String(name),
+			LifecycleHookName:    aws.String(hookName),
+		}
+		if _, err := s.ASGClient.DeleteLifecycleHookWithContext(context.TODO(), input); err != nil {
+			return errors.Wrapf(err, "failed to delete lifecycle hook %q for AutoScalingGroup: %q", hookName, name)
+		}
+		s.scope.Debug("Removed lifecycle hook", "name", name, "hook", hookName)
+	}
+	return nil
+}
+
+// CompleteLifecycleAction completes the lifecycle action of an instance held by a lifecycle hook of an autoscaling
+// group. Completing an action which is no longer pending isn't an error.
+func (s *Service) CompleteLifecycleAction(name, hookName, instanceID string, result expinfrav1.LifecycleActionResult) error {
+	input := &autoscaling.CompleteLifecycleActionInput{
+		AutoScalingGroupName:  aws.String(name),
+		LifecycleHookName:     aws.String(hookName),
+		InstanceId:            aws.String(instanceID),
+		LifecycleActionResult: aws.String(string(result)),
+	}
+	if _, err := s.ASGClient.CompleteLifecycleActionWithContext(context.TODO(), input); err != nil {
+		if strings.Contains(awserrors.Message(err), noActiveLifecycleActionMessage) {
+			s.scope.Debug("Lifecycle action already completed", "name", name, "hook", hookName, "instance", instanceID)
+			return nil
+		}
+		return errors.Wrapf(err, "failed to complete lifecycle action of instance %q for lifecycle hook %q of AutoScalingGroup: %q", instanceID, hookName, name)
+	}
+	return nil
+}
+
+// ReconcileScalingPolicies puts the scaling policies of the AWSMachinePool spec which are missing or changed on an
+// autoscaling group, and deletes the previously managed policies which are no longer in the spec. It returns the
+// status of the policies of the spec, keeping their last fetched forecast or forecast error.
+func (s *Service) ReconcileScalingPolicies(name string, policies []expinfrav1.ScalingPolicy, current []expinfrav1.ScalingPolicyStatus) ([]expinfrav1.ScalingPolicyStatus, error) {
+	policyNames := make([]string, 0, len(policies)+len(current))
+	for _, policy := range policies {
+		policyNames = append(policyNames, policy.Name)
+	}
+	for _, status := range current {
+		policyNames = append(policyNames, status.Name)
+	}
+
+	out, err := s.ASGClient.DescribePoliciesWithContext(context.TODO(), &autoscaling.DescribePoliciesInput{
+		AutoScalingGroupName: aws.String(name),
+		PolicyNames:          aws.StringSlice(policyNames),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to describe scaling policies for AutoScalingGroup: %q", name)
+	}
+
+	existing := make(map[string]*autoscaling.ScalingPolicy, len(out.ScalingPolicies))
+	for _, policy := range out.ScalingPolicies {
+		existing[aws.StringValue(policy.PolicyName)] = policy
+	}
+	previous := make(map[string]expinfrav1.ScalingPolicyStatus, len(current))
+	for _, status := range current {
+		previous[status.Name] = status
+	}
+
+	statuses := make([]expinfrav1.ScalingPolicyStatus, 0, len(policies))
+	wanted := make(map[string]bool, len(policies))
+	for _, policy := range policies {
+		wanted[policy.Name] = true
+
+		input := scalingPolicyInput(name, policy)
+		status := expinfrav1.ScalingPolicyStatus{
+			Name:          policy.Name,
+			Forecast:      previous[policy.Name].Forecast,
+			ForecastError: previous[policy.Name].ForecastError,
+		}
+		if existingPolicy, ok := existing[policy.Name]; ok && scalingPolicyMatches(existingPolicy, input) {
+			status.ARN = aws.StringValue(existingPolicy.PolicyARN)
+		} else {
+			putOut, err := s.ASGClient.PutScalingPolicyWithContext(context.TODO(), input)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to put scaling policy %q for AutoScalingGroup: %q", policy.Name, name)
+			}
+			s.scope.Debug("Put scaling policy", "name", name, "policy", policy.Name)
+			status.ARN = aws.StringValue(putOut.PolicyARN)
+		}
+		statuses = append(statuses, status)
+	}
+
+	for _, status := range current {
+		if _, ok := existing[status.Name]; !ok || wanted[status.Name] {
+			continue
+		}
+		if _, err := s.ASGClient.DeletePolicyWithContext(context.TODO(), &autoscaling.DeletePolicyInput{
+			AutoScalingGroupName: aws.String(name),
+			PolicyName:           aws.String(status.Name),
+		}); err != nil {
+			return nil, errors.Wrapf(err, "failed to delete scaling policy %q for AutoScalingGroup: %q", status.Name, name)
+		}
+		s.scope.Debug("Deleted scaling policy", "name", name, "policy", status.Name)
+	}
+
+	return statuses, nil
+}
+
+// GetPredictiveScalingForecast returns a summary of the capacity forecast of a predictive scaling policy of an
+// autoscaling group over the forecast window.
+func (s *Service) GetPredictiveScalingForecast(name, policyName string) (*expinfrav1.PredictiveScalingForecast, error) {
+	now := time.Now()
+	out, err := s.ASGClient.GetPredictiveScalingForecastWithContext(context.TODO(), &autoscaling.GetPredictiveScalingForecastInput{
+		AutoScalingGroupName: aws.String(name),
+		PolicyName:           aws.String(policyName),
+		StartTime:            aws.Time(now),
+		EndTime:              aws.Time(now.Add(predictiveScalingForecastWindow)),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get forecast of scaling policy %q for AutoScalingGroup: %q", policyName, name)
+	}
+
+	forecast := &expinfrav1.PredictiveScalingForecast{FetchTime: metav1.NewTime(now)}
+	if out.UpdateTime != nil {
+		forecast.UpdateTime = ptr.To(metav1.NewTime(*out.UpdateTime))
+	}
+	if out.CapacityForecast == nil {
+		return forecast, nil
+	}
+
+	for i, value := range out.CapacityForecast.Values {
+		capacity := int32(math.Ceil(aws.Float64Value(value)))
+		if i == 0 || capacity < forecast.MinCapacity {
+			forecast.MinCapacity = capacity
+		}
+		if i == 0 || capacity > forecast.MaxCapacity {
+			forecast.MaxCapacity = capacity
+			if i < len(out.CapacityForecast.Timestamps) && out.CapacityForecast.Timestamps[i] != nil {
+				forecast.MaxCapacityTime = ptr.To(metav1.NewTime(*out.CapacityForecast.Timestamps[i]))
+			}
+		}
+	}
+	return forecast, nil
+}
+
+func scalingPolicyInput(name string, policy expinfrav1.ScalingPolicy) *autoscaling.PutScalingPolicyInput {
+	// Predictive scaling is the only kind of policy.
+	predictiveScaling := policy.PredictiveScaling
+	config := &autoscaling.PredictiveScalingConfiguration{
+		Mode: aws.String(string(predictiveScaling.Mode)),
+	}
+	if predictiveScaling.Mode == "" {
+		config.Mode = aws.String(string(expinfrav1.PredictiveScalingModeForecastOnly))
+	}
+	if predictiveScaling.SchedulingBufferTime != nil {
+		config.SchedulingBufferTime = aws.Int64(int64(predictiveScaling.SchedulingBufferTime.Seconds()))
+	}
+	for _, spec := range predictiveScaling.MetricSpecifications {
+		pair := &autoscaling.PredictiveScalingPredefinedMetricPair{
+			PredefinedMetricType: aws.String(string(spec.PredefinedMetricPair.PredefinedMetricType)),
+		}
+		if spec.PredefinedMetricPair.ResourceLabel != "" {
+			pair.ResourceLabel = aws.String(spec.PredefinedMetricPair.ResourceLabel)
+		}
+		config.MetricSpecifications = append(config.MetricSpecifications, &autoscaling.PredictiveScalingMetricSpecification{
+			TargetValue:                       aws.Float64(float64(spec.TargetValue)),
+			PredefinedMetricPairSpecification: pair,
+		})
+	}
+
+	return &autoscaling.PutScalingPolicyInput{
+		AutoScalingGroupName:           aws.String(name),
+		PolicyName:                     aws.String(policy.Name),
+		PolicyType:                     aws.String("PredictiveScaling"),
+		PredictiveScalingConfiguration: config,
+	}
+}
+
+func scalingPolicyMatches(policy *autoscaling.ScalingPolicy, input *autoscaling.PutScalingPolicyInput) bool {
+	if aws.StringValue(policy.PolicyType) != aws.StringValue(input.PolicyType) || policy.PredictiveScalingConfiguration == nil {
+		return false
+	}
+
+	current, desired := policy.PredictiveScalingConfiguration, input.PredictiveScalingConfiguration
+	if aws.StringValue(current.Mode) != aws.StringValue(desired.Mode) ||
+		aws.Int64Value(current.SchedulingBufferTime) != aws.Int64Value(desired.SchedulingBufferTime) ||
+		len(current.MetricSpecifications) != len(desired.MetricSpecifications) {
+		return false
+	}
+	for i := range desired.MetricSpecifications {
+		currentSpec, desiredSpec := current.MetricSpecifications[i], desired.MetricSpecifications[i]
+		if aws.Float64Value(currentSpec.TargetValue) != aws.Float64Value(desiredSpec.TargetValue) ||
+			currentSpec.PredefinedMetricPairSpecification == nil ||
+			aws.StringValue(currentSpec.PredefinedMetricPairSpecification.PredefinedMetricType) != aws.StringValue(desiredSpec.PredefinedMetricPairSpecification.PredefinedMetricType) ||
+			aws.StringValue(currentSpec.PredefinedMetricPairSpecification.ResourceLabel) != aws.StringValue(desiredSpec.PredefinedMetricPairSpecification.ResourceLabel) {
+			return false
+		}
+	}
+	return true
+}
+
+func lifecycleHookInput(name string, hook expinfrav1.AWSLifecycleHook) *autoscaling.PutLifecycleHookInput {
+	input := &autoscaling.PutLifecycleHookInput{
+		AutoScalingGroupName: aws.String(name),
+		LifecycleHookName:    aws.String(hook.Name),
+		LifecycleTransition:  aws.String(string(hook.GetLifecycleTransition())),
+		DefaultResult:        aws.String(string(hook.GetDefaultResult())),
+		HeartbeatTimeout:     aws.Int64(int64(hook.GetHeartbeatTimeout().Seconds())),
+		NotificationMetadata: aws.String(lifecycleHookNotificationMetadata),
+	}
+	if hook.NotificationTargetARN != "" {
+		input.NotificationTargetARN = aws.String(hook.NotificationTargetARN)
+		input.RoleARN = aws.String(hook.RoleARN)
+	}
+	return input
+}
+
+func lifecycleHookMatches(hook *autoscaling.LifecycleHook, input *autoscaling.PutLifecycleHookInput) bool {
+	return aws.StringValue(hook.LifecycleTransition) == aws.StringValue(input.LifecycleTransition) &&
+		aws.StringValue(hook.DefaultResult) == aws.StringValue(input.DefaultResult) &&
+		aws.Int64Value(hook.HeartbeatTimeout) == aws.Int64Value(input.HeartbeatTimeout) &&
+		aws.StringValue(hook.NotificationMetadata) == aws.StringValue(input.NotificationMetadata) &&
+		aws.StringValue(hook.NotificationTargetARN) == aws.StringValue(input.NotificationTargetARN) &&
+		aws.StringValue(hook.RoleARN) == aws.StringValue(input.RoleARN)
+}
+
 func mapToTags(input map[string]string, resourceID *string) []*autoscaling.Tag {
 	tags := make([]*autoscaling.Tag, 0)
 	for k, v := range input {
@@ -518,8 +1308,18 @@ func mapToTags(input map[string]string, resourceID *string) []*autoscaling.Tag {
 	return tags
 }
 
-// SubnetIDs return subnet IDs of a AWSMachinePool based on given subnetIDs and filters.
+// SubnetIDs return subnet IDs of a AWSMachinePool based on given subnetIDs and filters, leaving out the subnets
+// of the availability zones excluded by the AZ failure handling.
 func (s *Service) SubnetIDs(scope *scope.MachinePoolScope) ([]string, error) {
+	subnetIDs, err := s.poolSubnetIDs(scope)
+	if err != nil || len(scope.AWSMachinePool.Status.ExcludedAvailabilityZones) == 0 {
+		return subnetIDs, err
+	}
+	return s.withoutExcludedAvailabilityZones(subnetIDs, scope.AWSMachinePool.Status.ExcludedAvailabilityZones)
+}
+
+// poolSubnetIDs returns all the subnet IDs of a AWSMachinePool based on given subnetIDs and filters.
+func (s *Service) poolSubnetIDs(scope *scope.MachinePoolScope) ([]string, error) {
 	subnetIDs := make([]string, 0)
 	var inputFilters = make([]*ec2.Filter, 0)
 
@@ -20,8 +20,10 @@ import (
 	"context"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/autoscaling"
 	"github.com/aws/aws-sdk-go/service/ec2"
@@ -188,6 +190,248 @@ func TestServiceSDKToAutoScalingGroup(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "valid input - override with its own launch template",
+			input: &autoscaling.Group{
+				DesiredCapacity: aws.Int64(1234),
+				MaxSize:         aws.Int64(1234),
+				MinSize:         aws.Int64(1234),
+				MixedInstancesPolicy: &autoscaling.MixedInstancesPolicy{
+					InstancesDistribution: &autoscaling.InstancesDistribution{
+						OnDemandAllocationStrategy: aws.String("prioritized"),
+						SpotAllocationStrategy:     aws.String("lowest-price"),
+					},
+					LaunchTemplate: &autoscaling.LaunchTemplate{
+						Overrides: []*autoscaling.LaunchTemplateOverrides{
+							{
+								InstanceType: aws.String("t2.medium"),
+							},
+							{
+								InstanceType: aws.String("m6i.large"),
+								LaunchTemplateSpecification: &autoscaling.LaunchTemplateSpecification{
+									LaunchTemplateName: aws.String("test-name-m6i.large"),
+									Version:            aws.String("$Latest"),
+								},
+							},
+						},
+					},
+				},
+			},
+			want: &expinfrav1.AutoScalingGroup{
+				DesiredCapacity: aws.Int32(1234),
+				MaxSize:         int32(1234),
+				MinSize:         int32(1234),
+				MixedInstancesPolicy: &expinfrav1.MixedInstancesPolicy{
+					InstancesDistribution: &expinfrav1.InstancesDistribution{
+						OnDemandAllocationStrategy: expinfrav1.OnDemandAllocationStrategyPrioritized,
+						SpotAllocationStrategy:     expinfrav1.SpotAllocationStrategyLowestPrice,
+					},
+					Overrides: []expinfrav1.Overrides{
+						{
+							InstanceType: "t2.medium",
+						},
+						{
+							InstanceType: "m6i.large",
+							RootVolume:   &infrav1.Volume{},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid input - default instance warmup",
+			input: &autoscaling.Group{
+				DesiredCapacity:       aws.Int64(1234),
+				MaxSize:               aws.Int64(1234),
+				MinSize:               aws.Int64(1234),
+				DefaultInstanceWarmup: aws.Int64(120),
+			},
+			want: &expinfrav1.AutoScalingGroup{
+				DesiredCapacity:       aws.Int32(1234),
+				MaxSize:               int32(1234),
+				MinSize:               int32(1234),
+				DefaultInstanceWarmup: &metav1.Duration{Duration: 2 * time.Minute},
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid input - default instance warmup unset",
+			input: &autoscaling.Group{
+				DesiredCapacity:       aws.Int64(1234),
+				MaxSize:               aws.Int64(1234),
+				MinSize:               aws.Int64(1234),
+				DefaultInstanceWarmup: aws.Int64(-1),
+			},
+			want: &expinfrav1.AutoScalingGroup{
+				DesiredCapacity: aws.Int32(1234),
+				MaxSize:         int32(1234),
+				MinSize:         int32(1234),
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid input - max instance lifetime",
+			input: &autoscaling.Group{
+				DesiredCapacity:     aws.Int64(1234),
+				MaxSize:             aws.Int64(1234),
+				MinSize:             aws.Int64(1234),
+				MaxInstanceLifetime: aws.Int64(1209600),
+			},
+			want: &expinfrav1.AutoScalingGroup{
+				DesiredCapacity:     aws.Int32(1234),
+				MaxSize:             int32(1234),
+				MinSize:             int32(1234),
+				MaxInstanceLifetime: &metav1.Duration{Duration: 14 * 24 * time.Hour},
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid input - max instance lifetime unset",
+			input: &autoscaling.Group{
+				DesiredCapacity:     aws.Int64(1234),
+				MaxSize:             aws.Int64(1234),
+				MinSize:             aws.Int64(1234),
+				MaxInstanceLifetime: aws.Int64(0),
+			},
+			want: &expinfrav1.AutoScalingGroup{
+				DesiredCapacity: aws.Int32(1234),
+				MaxSize:         int32(1234),
+				MinSize:         int32(1234),
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid input - launch template",
+			input: &autoscaling.Group{
+				DesiredCapacity: aws.Int64(1234),
+				MaxSize:         aws.Int64(1234),
+				MinSize:         aws.Int64(1234),
+				LaunchTemplate: &autoscaling.LaunchTemplateSpecification{
+					LaunchTemplateName: aws.String("test-name"),
+					Version:            aws.String("3"),
+				},
+			},
+			want: &expinfrav1.AutoScalingGroup{
+				DesiredCapacity:       aws.Int32(1234),
+				MaxSize:               int32(1234),
+				MinSize:               int32(1234),
+				LaunchTemplateVersion: "3",
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid input - mixed instances policy set up outside of CAPA without instances distribution",
+			input: &autoscaling.Group{
+				DesiredCapacity: aws.Int64(1234),
+				MaxSize:         aws.Int64(1234),
+				MinSize:         aws.Int64(1234),
+				MixedInstancesPolicy: &autoscaling.MixedInstancesPolicy{
+					LaunchTemplate: &autoscaling.LaunchTemplate{
+						LaunchTemplateSpecification: &autoscaling.LaunchTemplateSpecification{
+							LaunchTemplateName: aws.String("test-name"),
+							Version:            aws.String("$Latest"),
+						},
+						Overrides: []*autoscaling.LaunchTemplateOverrides{
+							{
+								InstanceType: aws.String("t2.medium"),
+							},
+						},
+					},
+				},
+			},
+			want: &expinfrav1.AutoScalingGroup{
+				DesiredCapacity: aws.Int32(1234),
+				MaxSize:         int32(1234),
+				MinSize:         int32(1234),
+				MixedInstancesPolicy: &expinfrav1.MixedInstancesPolicy{
+					Overrides: []expinfrav1.Overrides{
+						{
+							InstanceType: "t2.medium",
+						},
+					},
+				},
+				LaunchTemplateVersion: "$Latest",
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid input - mixed instances policy without launch template nor allocation strategies",
+			input: &autoscaling.Group{
+				DesiredCapacity: aws.Int64(1234),
+				MaxSize:         aws.Int64(1234),
+				MinSize:         aws.Int64(1234),
+				MixedInstancesPolicy: &autoscaling.MixedInstancesPolicy{
+					InstancesDistribution: &autoscaling.InstancesDistribution{
+						OnDemandBaseCapacity: aws.Int64(1),
+					},
+				},
+			},
+			want: &expinfrav1.AutoScalingGroup{
+				DesiredCapacity: aws.Int32(1234),
+				MaxSize:         int32(1234),
+				MinSize:         int32(1234),
+				MixedInstancesPolicy: &expinfrav1.MixedInstancesPolicy{
+					InstancesDistribution: &expinfrav1.InstancesDistribution{
+						OnDemandBaseCapacity: aws.Int64(1),
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid input - termination policies",
+			input: &autoscaling.Group{
+				DesiredCapacity:     aws.Int64(1234),
+				MaxSize:             aws.Int64(1234),
+				MinSize:             aws.Int64(1234),
+				TerminationPolicies: aws.StringSlice([]string{"OldestLaunchTemplate", "Default"}),
+			},
+			want: &expinfrav1.AutoScalingGroup{
+				DesiredCapacity:     aws.Int32(1234),
+				MaxSize:             int32(1234),
+				MinSize:             int32(1234),
+				TerminationPolicies: []string{"OldestLaunchTemplate", "Default"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid input - enabled metrics",
+			input: &autoscaling.Group{
+				DesiredCapacity: aws.Int64(1234),
+				MaxSize:         aws.Int64(1234),
+				MinSize:         aws.Int64(1234),
+				EnabledMetrics: []*autoscaling.EnabledMetric{
+					{Granularity: aws.String("1Minute"), Metric: aws.String("GroupInServiceInstances")},
+					{Granularity: aws.String("1Minute"), Metric: aws.String("GroupDesiredCapacity")},
+				},
+			},
+			want: &expinfrav1.AutoScalingGroup{
+				DesiredCapacity: aws.Int32(1234),
+				MaxSize:         int32(1234),
+				MinSize:         int32(1234),
+				EnabledMetrics:  []string{"GroupInServiceInstances", "GroupDesiredCapacity"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid input - health check",
+			input: &autoscaling.Group{
+				DesiredCapacity:        aws.Int64(1234),
+				MaxSize:                aws.Int64(1234),
+				MinSize:                aws.Int64(1234),
+				HealthCheckType:        aws.String("ELB"),
+				HealthCheckGracePeriod: aws.Int64(300),
+			},
+			want: &expinfrav1.AutoScalingGroup{
+				DesiredCapacity:        aws.Int32(1234),
+				MaxSize:                int32(1234),
+				MinSize:                int32(1234),
+				HealthCheckType:        expinfrav1.HealthCheckTypeELB,
+				HealthCheckGracePeriod: metav1.Duration{Duration: 5 * time.Minute},
+			},
+			wantErr: false,
+		},
 		{
 			name: "valid input - suspended processes",
 			input: &autoscaling.Group{
@@ -246,6 +490,11 @@ func TestServiceSDKToAutoScalingGroup(t *testing.T) {
 						InstanceId:       aws.String("instanceId"),
 						LifecycleState:   aws.String("lifecycleState"),
 						AvailabilityZone: aws.String("us-east-1a"),
+						HealthStatus:     aws.String("Healthy"),
+						LaunchTemplate: &autoscaling.LaunchTemplateSpecification{
+							LaunchTemplateId: aws.String("lt-1"),
+							Version:          aws.String("3"),
+						},
 					},
 				},
 			},
@@ -280,6 +529,13 @@ func TestServiceSDKToAutoScalingGroup(t *testing.T) {
 						AvailabilityZone: "us-east-1a",
 					},
 				},
+				InstanceDetails: map[string]expinfrav1.ASGInstanceDetails{
+					"instanceId": {
+						HealthStatus:          "Healthy",
+						LaunchTemplateID:      "lt-1",
+						LaunchTemplateVersion: "3",
+					},
+				},
 			},
 			wantErr: false,
 		},
@@ -377,6 +633,105 @@ func TestServiceSDKToAutoScalingGroup(t *testing.T) {
 	}
 }
 
+func TestCreateSDKMixedInstancesPolicy(t *testing.T) {
+	g := NewWithT(t)
+
+	launchTemplate := &autoscaling.LaunchTemplateSpecification{
+		LaunchTemplateName: aws.String("test-name"),
+		Version:            aws.String("$Latest"),
+	}
+	awsLaunchTemplate := &expinfrav1.AWSLaunchTemplate{
+		PerOverrideUserData: []expinfrav1.OverrideUserData{{InstanceType: "c6i.large", DataSecretName: "c6i-bootstrap-data"}},
+	}
+	got := createSDKMixedInstancesPolicy("test-name", launchTemplate, awsLaunchTemplate, &expinfrav1.MixedInstancesPolicy{
+		Overrides: []expinfrav1.Overrides{
+			{InstanceType: "t2.medium"},
+			{InstanceType: "m6i.large", RootVolume: &infrav1.Volume{Size: 100}},
+			{InstanceType: "c6i.large"},
+			{InstanceRequirements: &expinfrav1.InstanceRequirements{
+				VCPUCount: expinfrav1.VCPUCountRequest{Min: 2, Max: aws.Int64(8)},
+				MemoryMiB: expinfrav1.MemoryMiBRequest{Min: 4096},
+			}},
+		},
+	})
+
+	g.Expect(got.LaunchTemplate.LaunchTemplateSpecification).To(Equal(launchTemplate))
+	g.Expect(got.LaunchTemplate.Overrides).To(Equal([]*autoscaling.LaunchTemplateOverrides{
+		{
+			InstanceType: aws.String("t2.medium"),
+		},
+		{
+			InstanceType: aws.String("m6i.large"),
+			LaunchTemplateSpecification: &autoscaling.LaunchTemplateSpecification{
+				LaunchTemplateName: aws.String("test-name-m6i.large"),
+				Version:            aws.String("$Latest"),
+			},
+		},
+		{
+			InstanceType: aws.String("c6i.large"),
+			LaunchTemplateSpecification: &autoscaling.LaunchTemplateSpecification{
+				LaunchTemplateName: aws.String("test-name-c6i.large"),
+				Version:            aws.String("$Latest"),
+			},
+		},
+		{
+			InstanceRequirements: &autoscaling.InstanceRequirements{
+				VCpuCount: &autoscaling.VCpuCountRequest{Min: aws.Int64(2), Max: aws.Int64(8)},
+				MemoryMiB: &autoscaling.MemoryMiBRequest{Min: aws.Int64(4096)},
+			},
+		},
+	}))
+}
+
+func TestInstanceRequirementsRoundTrip(t *testing.T) {
+	tests := []struct {
+		name         string
+		requirements *expinfrav1.InstanceRequirements
+	}{
+		{
+			name: "only the required attributes",
+			requirements: &expinfrav1.InstanceRequirements{
+				VCPUCount: expinfrav1.VCPUCountRequest{Min: 2},
+				MemoryMiB: expinfrav1.MemoryMiBRequest{Min: 4096},
+			},
+		},
+		{
+			name: "all the attributes",
+			requirements: &expinfrav1.InstanceRequirements{
+				VCPUCount:                             expinfrav1.VCPUCountRequest{Min: 2, Max: aws.Int64(16)},
+				MemoryMiB:                             expinfrav1.MemoryMiBRequest{Min: 4096, Max: aws.Int64(65536)},
+				CPUManufacturers:                      []expinfrav1.CPUManufacturer{expinfrav1.CPUManufacturerIntel, expinfrav1.CPUManufacturerAMD},
+				InstanceGenerations:                   []expinfrav1.InstanceGeneration{expinfrav1.InstanceGenerationCurrent},
+				BurstablePerformance:                  expinfrav1.InstanceTypeFeatureExcluded,
+				BareMetal:                             expinfrav1.InstanceTypeFeatureIncluded,
+				AcceleratorCount:                      &expinfrav1.AcceleratorCountRequest{Min: aws.Int64(1), Max: aws.Int64(4)},
+				AcceleratorTypes:                      []expinfrav1.AcceleratorType{expinfrav1.AcceleratorTypeGPU},
+				ExcludedInstanceTypes:                 []string{"t2.*", "m4.large"},
+				SpotMaxPricePercentageOverLowestPrice: aws.Int64(50),
+				OnDemandMaxPricePercentageOverLowestPrice: aws.Int64(10),
+			},
+		},
+		{
+			name: "allowed instance types",
+			requirements: &expinfrav1.InstanceRequirements{
+				VCPUCount:            expinfrav1.VCPUCountRequest{Min: 4},
+				MemoryMiB:            expinfrav1.MemoryMiBRequest{Min: 8192},
+				AllowedInstanceTypes: []string{"m5.*", "m6i.*"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			got := createSDKInstanceRequirements(tt.requirements)
+			g.Expect(got.AllowedInstanceTypes == nil).To(Equal(tt.requirements.AllowedInstanceTypes == nil))
+			g.Expect(got.ExcludedInstanceTypes == nil).To(Equal(tt.requirements.ExcludedInstanceTypes == nil))
+			g.Expect(sdkToInstanceRequirements(got)).To(Equal(tt.requirements))
+		})
+	}
+}
+
 func TestServiceASGIfExists(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
@@ -481,23 +836,26 @@ func TestServiceCreateASG(t *testing.T) {
 		expect                func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder)
 	}{
 		{
-			name:                  "should return without error if create ASG is successful",
-			machinePoolName:       "create-asg-success",
-			setupMachinePoolScope: func(mps *scope.MachinePoolScope) {},
-			wantErr:               false,
-			wantASG:               false,
+			name:            "should return without error if create ASG is successful",
+			machinePoolName: "create-asg-success",
+			setupMachinePoolScope: func(mps *scope.MachinePoolScope) {
+				mps.AWSMachinePool.Spec.DefaultInstanceWarmup = &metav1.Duration{Duration: 5 * time.Minute}
+				mps.AWSMachinePool.Spec.MaxInstanceLifetime = &metav1.Duration{Duration: 14 * 24 * time.Hour}
+			},
+			wantErr: false,
+			wantASG: false,
 			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
 				expected := &autoscaling.CreateAutoScalingGroupInput{
 					AutoScalingGroupName:  aws.String("create-asg-success"),
 					CapacityRebalance:     aws.Bool(false),
 					DefaultCooldown:       aws.Int64(0),
-					DefaultInstanceWarmup: aws.Int64(0),
+					DefaultInstanceWarmup: aws.Int64(300),
+					MaxInstanceLifetime:   aws.Int64(1209600),
 					MixedInstancesPolicy: &autoscaling.MixedInstancesPolicy{
 						InstancesDistribution: &autoscaling.InstancesDistribution{
 							OnDemandAllocationStrategy:          aws.String("prioritized"),
 							OnDemandBaseCapacity:                aws.Int64(0),
 							OnDemandPercentageAboveBaseCapacity: aws.Int64(100),
-							SpotAllocationStrategy:              aws.String(""),
 						},
 						LaunchTemplate: &autoscaling.LaunchTemplate{
 							LaunchTemplateSpecification: &autoscaling.LaunchTemplateSpecification{
@@ -608,6 +966,23 @@ func TestServiceCreateASG(t *testing.T) {
 					})
 			},
 		},
+		{
+			name:            "should create the ASG with the target groups of the spec",
+			machinePoolName: "create-asg-success",
+			setupMachinePoolScope: func(mps *scope.MachinePoolScope) {
+				mps.AWSMachinePool.Spec.TargetGroupARNs = []string{"tg-1", "tg-2"}
+			},
+			wantErr: false,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.CreateAutoScalingGroupWithContext(context.TODO(), gomock.AssignableToTypeOf(&autoscaling.CreateAutoScalingGroupInput{})).Do(
+					func(ctx context.Context, actual *autoscaling.CreateAutoScalingGroupInput, requestOptions ...request.Option) (*autoscaling.CreateAutoScalingGroupOutput, error) {
+						if !cmp.Equal(aws.StringSlice([]string{"tg-1", "tg-2"}), actual.TargetGroupARNs) {
+							t.Fatalf("Actual TargetGroupARNs did not match expected, Actual: %v, Expected: [tg-1 tg-2]", aws.StringValueSlice(actual.TargetGroupARNs))
+						}
+						return &autoscaling.CreateAutoScalingGroupOutput{}, nil
+					})
+			},
+		},
 		{
 			name:            "should return error if MachinePool replicas number is less than AWSMachinePool MinSize",
 			machinePoolName: "create-asg-fail",
@@ -692,6 +1067,62 @@ func TestServiceCreateASG(t *testing.T) {
 	}
 }
 
+func TestServiceCreateASGEventualConsistency(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	fakeClient := getFakeClient()
+	clusterScope, err := getClusterScope(fakeClient)
+	g.Expect(err).ToNot(HaveOccurred())
+	asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+	s := NewService(clusterScope)
+	s.ASGClient = asgMock
+
+	mps, err := getMachinePoolScope(fakeClient, clusterScope)
+	g.Expect(err).ToNot(HaveOccurred())
+	mps.AWSMachinePool.Name = "asgName"
+	mps.AWSMachinePool.Spec.MixedInstancesPolicy = nil
+	mps.MachinePool.Spec.Replicas = aws.Int32(1)
+
+	// The ASG is recorded as soon as it is created.
+	asgMock.EXPECT().CreateAutoScalingGroupWithContext(context.TODO(), gomock.Any()).Return(&autoscaling.CreateAutoScalingGroupOutput{}, nil)
+	asg, err := s.CreateASG(mps)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(asg).To(BeNil())
+	g.Expect(mps.AWSMachinePool.Status.ASG.Name).To(Equal("asgName"))
+	g.Expect(mps.AWSMachinePool.Status.ASG.CreationPending(time.Now(), time.Minute)).To(BeTrue())
+
+	// The ASG which already exists isn't described yet.
+	asgMock.EXPECT().CreateAutoScalingGroupWithContext(context.TODO(), gomock.Any()).
+		Return(nil, awserr.New(autoscaling.ErrCodeAlreadyExistsFault, "AutoScalingGroup by this name already exists", nil))
+	asgMock.EXPECT().DescribeAutoScalingGroupsWithContext(context.TODO(), gomock.Eq(&autoscaling.DescribeAutoScalingGroupsInput{
+		AutoScalingGroupNames: aws.StringSlice([]string{"asgName"}),
+	})).Return(&autoscaling.DescribeAutoScalingGroupsOutput{}, nil)
+	asg, err = s.CreateASG(mps)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(asg).To(BeNil())
+	g.Expect(mps.AWSMachinePool.Status.ASG.CreationPending(time.Now(), time.Minute)).To(BeTrue())
+
+	// The ASG which already exists is returned once it is described.
+	asgMock.EXPECT().CreateAutoScalingGroupWithContext(context.TODO(), gomock.Any()).
+		Return(nil, awserr.New(autoscaling.ErrCodeAlreadyExistsFault, "AutoScalingGroup by this name already exists", nil))
+	asgMock.EXPECT().DescribeAutoScalingGroupsWithContext(context.TODO(), gomock.Any()).Return(&autoscaling.DescribeAutoScalingGroupsOutput{
+		AutoScalingGroups: []*autoscaling.Group{{
+			AutoScalingGroupARN:  aws.String("arn:aws:autoscaling:us-east-1:123456789012:autoScalingGroup:uuid:autoScalingGroupName/asgName"),
+			AutoScalingGroupName: aws.String("asgName"),
+		}},
+	}, nil)
+	asg, err = s.CreateASG(mps)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(asg).ToNot(BeNil())
+	g.Expect(mps.AWSMachinePool.Status.ASG).To(Equal(&expinfrav1.ASGReference{
+		Name: "asgName",
+		ARN:  "arn:aws:autoscaling:us-east-1:123456789012:autoScalingGroup:uuid:autoScalingGroupName/asgName",
+	}))
+	g.Expect(mps.AWSMachinePool.Status.ASG.CreationPending(time.Now(), time.Minute)).To(BeFalse())
+}
+
 func TestServiceUpdateASG(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
@@ -711,6 +1142,8 @@ func TestServiceUpdateASG(t *testing.T) {
 				mps.MachinePool.Spec.Replicas = ptr.To[int32](3)
 				mps.AWSMachinePool.Spec.MinSize = 2
 				mps.AWSMachinePool.Spec.MaxSize = 5
+				mps.AWSMachinePool.Spec.DefaultInstanceWarmup = &metav1.Duration{Duration: 2 * time.Minute}
+				mps.AWSMachinePool.Spec.MaxInstanceLifetime = &metav1.Duration{Duration: 14 * 24 * time.Hour}
 			},
 			expect: func(e *mocks.MockEC2APIMockRecorder, m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder, g *WithT) {
 				m.UpdateAutoScalingGroupWithContext(context.TODO(), gomock.AssignableToTypeOf(&autoscaling.UpdateAutoScalingGroupInput{})).DoAndReturn(func(ctx context.Context, input *autoscaling.UpdateAutoScalingGroupInput, options ...request.Option) (*autoscaling.UpdateAutoScalingGroupOutput, error) {
@@ -719,6 +1152,23 @@ func TestServiceUpdateASG(t *testing.T) {
 					g.Expect(input.MinSize).To(BeComparableTo(ptr.To[int64](2)))
 					g.Expect(input.MaxSize).To(BeComparableTo(ptr.To[int64](5)))
 					g.Expect(input.DesiredCapacity).To(BeComparableTo(ptr.To[int64](3)))
+					g.Expect(input.DefaultInstanceWarmup).To(BeComparableTo(ptr.To[int64](120)))
+					g.Expect(input.MaxInstanceLifetime).To(BeComparableTo(ptr.To[int64](1209600)))
+					return &autoscaling.UpdateAutoScalingGroupOutput{}, nil
+				})
+			},
+		},
+		{
+			name:            "should clear the max instance lifetime once it's no longer set",
+			machinePoolName: "update-asg-success",
+			wantErr:         false,
+			setupMachinePoolScope: func(mps *scope.MachinePoolScope) {
+				mps.AWSMachinePool.Spec.MinSize = 2
+				mps.AWSMachinePool.Spec.MaxSize = 5
+			},
+			expect: func(e *mocks.MockEC2APIMockRecorder, m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder, g *WithT) {
+				m.UpdateAutoScalingGroupWithContext(context.TODO(), gomock.AssignableToTypeOf(&autoscaling.UpdateAutoScalingGroupInput{})).DoAndReturn(func(ctx context.Context, input *autoscaling.UpdateAutoScalingGroupInput, options ...request.Option) (*autoscaling.UpdateAutoScalingGroupOutput, error) {
+					g.Expect(input.MaxInstanceLifetime).To(BeComparableTo(ptr.To[int64](0)))
 					return &autoscaling.UpdateAutoScalingGroupOutput{}, nil
 				})
 			},
@@ -755,40 +1205,189 @@ func TestServiceUpdateASG(t *testing.T) {
 				})
 			},
 		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			g := NewWithT(t)
-			fakeClient := getFakeClient()
-
-			clusterScope, err := getClusterScope(fakeClient)
-			g.Expect(err).ToNot(HaveOccurred())
-			ec2Mock := mocks.NewMockEC2API(mockCtrl)
-			asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
-			tt.expect(ec2Mock.EXPECT(), asgMock.EXPECT(), g)
-			s := NewService(clusterScope)
-			s.ASGClient = asgMock
-
-			mps, err := getMachinePoolScope(fakeClient, clusterScope)
-			g.Expect(err).ToNot(HaveOccurred())
-			mps.AWSMachinePool.Name = tt.machinePoolName
-			tt.setupMachinePoolScope(mps)
-
-			err = s.UpdateASG(mps)
-			checkErr(tt.wantErr, err, g)
-		})
-	}
-}
-
-func TestServiceUpdateASGWithSubnetFilters(t *testing.T) {
-	mockCtrl := gomock.NewController(t)
-	defer mockCtrl.Finish()
-
-	tests := []struct {
-		name                 string
-		machinePoolName      string
-		awsResourceReference []infrav1.AWSResourceReference
-		wantErr              bool
+		{
+			name:            "unmanaged fields are not sent",
+			machinePoolName: "update-asg-unmanaged-fields",
+			wantErr:         false,
+			setupMachinePoolScope: func(mps *scope.MachinePoolScope) {
+				mps.MachinePool.Spec.Replicas = ptr.To[int32](3)
+				mps.AWSMachinePool.Spec.MinSize = 2
+				mps.AWSMachinePool.Spec.MaxSize = 5
+				mps.AWSMachinePool.Spec.UnmanagedFields = []expinfrav1.UnmanagedField{
+					expinfrav1.UnmanagedFieldMinSize,
+					expinfrav1.UnmanagedFieldDesiredCapacity,
+					expinfrav1.UnmanagedFieldMixedInstancesPolicy,
+				}
+			},
+			expect: func(e *mocks.MockEC2APIMockRecorder, m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder, g *WithT) {
+				m.UpdateAutoScalingGroupWithContext(context.TODO(), gomock.AssignableToTypeOf(&autoscaling.UpdateAutoScalingGroupInput{})).DoAndReturn(func(ctx context.Context, input *autoscaling.UpdateAutoScalingGroupInput, options ...request.Option) (*autoscaling.UpdateAutoScalingGroupOutput, error) {
+					g.Expect(input.MinSize).To(BeNil())
+					g.Expect(input.MaxSize).To(BeComparableTo(ptr.To[int64](5)))
+					g.Expect(input.DesiredCapacity).To(BeNil())
+					g.Expect(input.MixedInstancesPolicy).To(BeNil())
+					g.Expect(input.LaunchTemplate).To(BeNil())
+					return &autoscaling.UpdateAutoScalingGroupOutput{}, nil
+				})
+			},
+		},
+		{
+			name:            "maximum size is raised by the surge of an instance refresh",
+			machinePoolName: "update-asg-refresh-surge",
+			wantErr:         false,
+			setupMachinePoolScope: func(mps *scope.MachinePoolScope) {
+				mps.MachinePool.Spec.Replicas = ptr.To[int32](5)
+				mps.AWSMachinePool.Spec.MinSize = 2
+				mps.AWSMachinePool.Spec.MaxSize = 5
+				mps.AWSMachinePool.Spec.RefreshPreferences = &expinfrav1.RefreshPreferences{MaxSurge: ptr.To[int32](2)}
+				mps.AWSMachinePool.SetAnnotations(map[string]string{expinfrav1.RefreshSurgeAnnotation: "5"})
+			},
+			expect: func(e *mocks.MockEC2APIMockRecorder, m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder, g *WithT) {
+				m.UpdateAutoScalingGroupWithContext(context.TODO(), gomock.AssignableToTypeOf(&autoscaling.UpdateAutoScalingGroupInput{})).DoAndReturn(func(ctx context.Context, input *autoscaling.UpdateAutoScalingGroupInput, options ...request.Option) (*autoscaling.UpdateAutoScalingGroupOutput, error) {
+					g.Expect(input.MaxSize).To(BeComparableTo(ptr.To[int64](7)))
+					g.Expect(input.DesiredCapacity).To(BeComparableTo(ptr.To[int64](5)))
+					return &autoscaling.UpdateAutoScalingGroupOutput{}, nil
+				})
+			},
+		},
+		{
+			name:            "default termination policy is sent without termination policies",
+			machinePoolName: "update-asg-termination-policies-default",
+			wantErr:         false,
+			setupMachinePoolScope: func(mps *scope.MachinePoolScope) {
+				mps.AWSMachinePool.Spec.MixedInstancesPolicy = nil
+			},
+			expect: func(e *mocks.MockEC2APIMockRecorder, m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder, g *WithT) {
+				m.UpdateAutoScalingGroupWithContext(context.TODO(), gomock.AssignableToTypeOf(&autoscaling.UpdateAutoScalingGroupInput{})).DoAndReturn(func(ctx context.Context, input *autoscaling.UpdateAutoScalingGroupInput, options ...request.Option) (*autoscaling.UpdateAutoScalingGroupOutput, error) {
+					g.Expect(input.TerminationPolicies).To(BeComparableTo(aws.StringSlice([]string{"Default"})))
+					return &autoscaling.UpdateAutoScalingGroupOutput{}, nil
+				})
+			},
+		},
+		{
+			name:            "termination policies are sent in order",
+			machinePoolName: "update-asg-termination-policies",
+			wantErr:         false,
+			setupMachinePoolScope: func(mps *scope.MachinePoolScope) {
+				mps.AWSMachinePool.Spec.MixedInstancesPolicy = nil
+				mps.AWSMachinePool.Spec.TerminationPolicies = []string{"OldestLaunchTemplate", "ClosestToNextInstanceHour"}
+			},
+			expect: func(e *mocks.MockEC2APIMockRecorder, m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder, g *WithT) {
+				m.UpdateAutoScalingGroupWithContext(context.TODO(), gomock.AssignableToTypeOf(&autoscaling.UpdateAutoScalingGroupInput{})).DoAndReturn(func(ctx context.Context, input *autoscaling.UpdateAutoScalingGroupInput, options ...request.Option) (*autoscaling.UpdateAutoScalingGroupOutput, error) {
+					g.Expect(input.TerminationPolicies).To(BeComparableTo(aws.StringSlice([]string{"OldestLaunchTemplate", "ClosestToNextInstanceHour"})))
+					return &autoscaling.UpdateAutoScalingGroupOutput{}, nil
+				})
+			},
+		},
+		{
+			name:            "EC2 health checks are sent without health check type",
+			machinePoolName: "update-asg-health-check-default",
+			wantErr:         false,
+			setupMachinePoolScope: func(mps *scope.MachinePoolScope) {
+				mps.AWSMachinePool.Spec.MixedInstancesPolicy = nil
+			},
+			expect: func(e *mocks.MockEC2APIMockRecorder, m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder, g *WithT) {
+				m.UpdateAutoScalingGroupWithContext(context.TODO(), gomock.AssignableToTypeOf(&autoscaling.UpdateAutoScalingGroupInput{})).DoAndReturn(func(ctx context.Context, input *autoscaling.UpdateAutoScalingGroupInput, options ...request.Option) (*autoscaling.UpdateAutoScalingGroupOutput, error) {
+					g.Expect(input.HealthCheckType).To(BeComparableTo(aws.String("EC2")))
+					g.Expect(input.HealthCheckGracePeriod).To(BeNil())
+					return &autoscaling.UpdateAutoScalingGroupOutput{}, nil
+				})
+			},
+		},
+		{
+			name:            "health check type and grace period are sent",
+			machinePoolName: "update-asg-health-check",
+			wantErr:         false,
+			setupMachinePoolScope: func(mps *scope.MachinePoolScope) {
+				mps.AWSMachinePool.Spec.MixedInstancesPolicy = nil
+				mps.AWSMachinePool.Spec.HealthCheckType = expinfrav1.HealthCheckTypeELB
+				mps.AWSMachinePool.Spec.HealthCheckGracePeriod = &metav1.Duration{Duration: 5 * time.Minute}
+			},
+			expect: func(e *mocks.MockEC2APIMockRecorder, m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder, g *WithT) {
+				m.UpdateAutoScalingGroupWithContext(context.TODO(), gomock.AssignableToTypeOf(&autoscaling.UpdateAutoScalingGroupInput{})).DoAndReturn(func(ctx context.Context, input *autoscaling.UpdateAutoScalingGroupInput, options ...request.Option) (*autoscaling.UpdateAutoScalingGroupOutput, error) {
+					g.Expect(input.HealthCheckType).To(BeComparableTo(aws.String("ELB")))
+					g.Expect(input.HealthCheckGracePeriod).To(BeComparableTo(aws.Int64(300)))
+					return &autoscaling.UpdateAutoScalingGroupOutput{}, nil
+				})
+			},
+		},
+		{
+			name:            "capacity block is launched from the launch template without the mixed instances policy",
+			machinePoolName: "update-asg-capacity-block",
+			wantErr:         false,
+			setupMachinePoolScope: func(mps *scope.MachinePoolScope) {
+				mps.AWSMachinePool.Spec.MixedInstancesPolicy = &expinfrav1.MixedInstancesPolicy{
+					Overrides: []expinfrav1.Overrides{{InstanceType: "p5.48xlarge"}},
+				}
+				mps.AWSMachinePool.Spec.AWSLaunchTemplate.MarketType = infrav1.MarketTypeCapacityBlock
+				mps.AWSMachinePool.Spec.AWSLaunchTemplate.CapacityReservationID = aws.String("cr-0123456789abcdef0")
+				mps.AWSMachinePool.Status.LaunchTemplateID = "lt-capacity-block"
+			},
+			expect: func(e *mocks.MockEC2APIMockRecorder, m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder, g *WithT) {
+				m.UpdateAutoScalingGroupWithContext(context.TODO(), gomock.AssignableToTypeOf(&autoscaling.UpdateAutoScalingGroupInput{})).DoAndReturn(func(ctx context.Context, input *autoscaling.UpdateAutoScalingGroupInput, options ...request.Option) (*autoscaling.UpdateAutoScalingGroupOutput, error) {
+					g.Expect(input.MixedInstancesPolicy).To(BeNil())
+					g.Expect(input.LaunchTemplate).To(BeComparableTo(&autoscaling.LaunchTemplateSpecification{
+						LaunchTemplateId: aws.String("lt-capacity-block"),
+						Version:          aws.String(expinfrav1.LaunchTemplateLatestVersion),
+					}))
+					return &autoscaling.UpdateAutoScalingGroupOutput{}, nil
+				})
+			},
+		},
+		{
+			name:            "referenced launch template is launched at the referenced version",
+			machinePoolName: "update-asg-launch-template-ref",
+			wantErr:         false,
+			setupMachinePoolScope: func(mps *scope.MachinePoolScope) {
+				mps.AWSMachinePool.Spec.MixedInstancesPolicy = nil
+				mps.AWSMachinePool.Spec.AWSLaunchTemplate = expinfrav1.AWSLaunchTemplate{
+					Ref: &expinfrav1.LaunchTemplateReference{Name: aws.String("byo"), Version: "4"},
+				}
+				mps.AWSMachinePool.Status.LaunchTemplateID = "lt-byo"
+			},
+			expect: func(e *mocks.MockEC2APIMockRecorder, m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder, g *WithT) {
+				m.UpdateAutoScalingGroupWithContext(context.TODO(), gomock.AssignableToTypeOf(&autoscaling.UpdateAutoScalingGroupInput{})).DoAndReturn(func(ctx context.Context, input *autoscaling.UpdateAutoScalingGroupInput, options ...request.Option) (*autoscaling.UpdateAutoScalingGroupOutput, error) {
+					g.Expect(input.LaunchTemplate).To(BeComparableTo(&autoscaling.LaunchTemplateSpecification{
+						LaunchTemplateId: aws.String("lt-byo"),
+						Version:          aws.String("4"),
+					}))
+					return &autoscaling.UpdateAutoScalingGroupOutput{}, nil
+				})
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			fakeClient := getFakeClient()
+
+			clusterScope, err := getClusterScope(fakeClient)
+			g.Expect(err).ToNot(HaveOccurred())
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+			asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+			tt.expect(ec2Mock.EXPECT(), asgMock.EXPECT(), g)
+			s := NewService(clusterScope)
+			s.ASGClient = asgMock
+
+			mps, err := getMachinePoolScope(fakeClient, clusterScope)
+			g.Expect(err).ToNot(HaveOccurred())
+			mps.AWSMachinePool.Name = tt.machinePoolName
+			tt.setupMachinePoolScope(mps)
+
+			err = s.UpdateASG(mps)
+			checkErr(tt.wantErr, err, g)
+		})
+	}
+}
+
+func TestServiceUpdateASGWithSubnetFilters(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	tests := []struct {
+		name                 string
+		machinePoolName      string
+		awsResourceReference []infrav1.AWSResourceReference
+		wantErr              bool
 		expect               func(e *mocks.MockEC2APIMockRecorder, m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder)
 	}{
 		{
@@ -1036,54 +1635,68 @@ func TestServiceDeleteASG(t *testing.T) {
 	}
 }
 
-func TestServiceDeleteASGAndWait(t *testing.T) {
+func TestServiceReconcileNodeTerminationLifecycleHook(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
 
+	describeInput := &autoscaling.DescribeLifecycleHooksInput{
+		AutoScalingGroupName: aws.String("asgName"),
+		LifecycleHookNames:   aws.StringSlice([]string{NodeTerminationLifecycleHookName}),
+	}
+	existingHook := &autoscaling.DescribeLifecycleHooksOutput{
+		LifecycleHooks: []*autoscaling.LifecycleHook{{LifecycleHookName: aws.String(NodeTerminationLifecycleHookName)}},
+	}
+
 	tests := []struct {
 		name    string
+		enabled bool
 		wantErr bool
 		expect  func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder)
 	}{
 		{
-			name:    "Delete ASG with wait passed",
-			wantErr: false,
+			name:    "should add the hook when enabled and missing",
+			enabled: true,
 			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
-				m.DeleteAutoScalingGroupWithContext(context.TODO(), gomock.Eq(&autoscaling.DeleteAutoScalingGroupInput{
+				m.DescribeLifecycleHooksWithContext(context.TODO(), gomock.Eq(describeInput)).
+					Return(&autoscaling.DescribeLifecycleHooksOutput{}, nil)
+				m.PutLifecycleHookWithContext(context.TODO(), gomock.Eq(&autoscaling.PutLifecycleHookInput{
 					AutoScalingGroupName: aws.String("asgName"),
-					ForceDelete:          aws.Bool(true),
-				})).
-					Return(nil, nil)
-				m.WaitUntilGroupNotExistsWithContext(context.TODO(), gomock.Eq(&autoscaling.DescribeAutoScalingGroupsInput{
-					AutoScalingGroupNames: aws.StringSlice([]string{"asgName"}),
+					LifecycleHookName:    aws.String(NodeTerminationLifecycleHookName),
+					LifecycleTransition:  aws.String("autoscaling:EC2_INSTANCE_TERMINATING"),
+					DefaultResult:        aws.String("CONTINUE"),
+					HeartbeatTimeout:     aws.Int64(300),
 				})).
-					Return(nil)
+					Return(&autoscaling.PutLifecycleHookOutput{}, nil)
 			},
 		},
 		{
-			name:    "should return error if delete ASG failed while waiting",
-			wantErr: true,
+			name:    "should do nothing when enabled and present",
+			enabled: true,
 			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
-				m.DeleteAutoScalingGroupWithContext(context.TODO(), gomock.Eq(&autoscaling.DeleteAutoScalingGroupInput{
+				m.DescribeLifecycleHooksWithContext(context.TODO(), gomock.Eq(describeInput)).
+					Return(existingHook, nil)
+			},
+		},
+		{
+			name:    "should remove the hook when disabled and present",
+			enabled: false,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.DescribeLifecycleHooksWithContext(context.TODO(), gomock.Eq(describeInput)).
+					Return(existingHook, nil)
+				m.DeleteLifecycleHookWithContext(context.TODO(), gomock.Eq(&autoscaling.DeleteLifecycleHookInput{
 					AutoScalingGroupName: aws.String("asgName"),
-					ForceDelete:          aws.Bool(true),
-				})).
-					Return(nil, nil)
-				m.WaitUntilGroupNotExistsWithContext(context.TODO(), gomock.Eq(&autoscaling.DescribeAutoScalingGroupsInput{
-					AutoScalingGroupNames: aws.StringSlice([]string{"asgName"}),
+					LifecycleHookName:    aws.String(NodeTerminationLifecycleHookName),
 				})).
-					Return(awserrors.NewFailedDependency("dependency error"))
+					Return(&autoscaling.DeleteLifecycleHookOutput{}, nil)
 			},
 		},
 		{
-			name:    "should return error if delete ASG failed during ASG deletion",
+			name:    "should return an error when the hooks can't be described",
+			enabled: true,
 			wantErr: true,
 			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
-				m.DeleteAutoScalingGroupWithContext(context.TODO(), gomock.Eq(&autoscaling.DeleteAutoScalingGroupInput{
-					AutoScalingGroupName: aws.String("asgName"),
-					ForceDelete:          aws.Bool(true),
-				})).
-					Return(nil, awserrors.NewNotFound("not found"))
+				m.DescribeLifecycleHooksWithContext(context.TODO(), gomock.Eq(describeInput)).
+					Return(nil, awserrors.NewFailedDependency("dependency failure"))
 			},
 		},
 	}
@@ -1100,59 +1713,140 @@ func TestServiceDeleteASGAndWait(t *testing.T) {
 			s := NewService(clusterScope)
 			s.ASGClient = asgMock
 
-			err = s.DeleteASGAndWait("asgName")
+			err = s.ReconcileNodeTerminationLifecycleHook("asgName", tt.enabled)
 			checkErr(tt.wantErr, err, g)
 		})
 	}
 }
 
-func TestServiceCanStartASGInstanceRefresh(t *testing.T) {
+func TestServiceReconcileLifecycleHooks(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
 
+	describeInput := &autoscaling.DescribeLifecycleHooksInput{
+		AutoScalingGroupName: aws.String("asgName"),
+	}
+	nodeJoinHook := expinfrav1.AWSLifecycleHook{
+		Name: "node-join",
+		Role: expinfrav1.LifecycleHookRoleNodeJoin,
+	}
+	putNodeJoinHook := &autoscaling.PutLifecycleHookInput{
+		AutoScalingGroupName: aws.String("asgName"),
+		LifecycleHookName:    aws.String("node-join"),
+		LifecycleTransition:  aws.String("autoscaling:EC2_INSTANCE_LAUNCHING"),
+		DefaultResult:        aws.String("ABANDON"),
+		HeartbeatTimeout:     aws.Int64(600),
+		NotificationMetadata: aws.String(lifecycleHookNotificationMetadata),
+	}
+	existingNodeJoinHook := &autoscaling.LifecycleHook{
+		AutoScalingGroupName: aws.String("asgName"),
+		LifecycleHookName:    aws.String("node-join"),
+		LifecycleTransition:  aws.String("autoscaling:EC2_INSTANCE_LAUNCHING"),
+		DefaultResult:        aws.String("ABANDON"),
+		HeartbeatTimeout:     aws.Int64(600),
+		NotificationMetadata: aws.String(lifecycleHookNotificationMetadata),
+	}
+
 	tests := []struct {
-		name     string
-		wantErr  bool
-		canStart bool
-		expect   func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder)
+		name    string
+		hooks   []expinfrav1.AWSLifecycleHook
+		wantErr bool
+		expect  func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder)
 	}{
 		{
-			name:     "should return error if describe instance refresh failed",
-			wantErr:  true,
-			canStart: false,
+			name:  "should add the missing hooks",
+			hooks: []expinfrav1.AWSLifecycleHook{nodeJoinHook},
 			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
-				m.DescribeInstanceRefreshesWithContext(context.TODO(), gomock.Eq(&autoscaling.DescribeInstanceRefreshesInput{
-					AutoScalingGroupName: aws.String("machinePoolName"),
-				})).
-					Return(nil, awserrors.NewConflict("some error"))
+				m.DescribeLifecycleHooksWithContext(context.TODO(), gomock.Eq(describeInput)).
+					Return(&autoscaling.DescribeLifecycleHooksOutput{}, nil)
+				m.PutLifecycleHookWithContext(context.TODO(), gomock.Eq(putNodeJoinHook)).
+					Return(&autoscaling.PutLifecycleHookOutput{}, nil)
 			},
 		},
 		{
-			name:     "should return true if no instance available for refresh",
-			wantErr:  false,
-			canStart: true,
+			name:  "should do nothing when the hooks are up to date",
+			hooks: []expinfrav1.AWSLifecycleHook{nodeJoinHook},
 			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
-				m.DescribeInstanceRefreshesWithContext(context.TODO(), gomock.Eq(&autoscaling.DescribeInstanceRefreshesInput{
-					AutoScalingGroupName: aws.String("machinePoolName"),
+				m.DescribeLifecycleHooksWithContext(context.TODO(), gomock.Eq(describeInput)).
+					Return(&autoscaling.DescribeLifecycleHooksOutput{LifecycleHooks: []*autoscaling.LifecycleHook{existingNodeJoinHook}}, nil)
+			},
+		},
+		{
+			name: "should update the hooks whose heartbeat timeout changed",
+			hooks: []expinfrav1.AWSLifecycleHook{{
+				Name:             "node-join",
+				Role:             expinfrav1.LifecycleHookRoleNodeJoin,
+				HeartbeatTimeout: &metav1.Duration{Duration: 15 * time.Minute},
+			}},
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.DescribeLifecycleHooksWithContext(context.TODO(), gomock.Eq(describeInput)).
+					Return(&autoscaling.DescribeLifecycleHooksOutput{LifecycleHooks: []*autoscaling.LifecycleHook{existingNodeJoinHook}}, nil)
+				updated := *putNodeJoinHook
+				updated.HeartbeatTimeout = aws.Int64(900)
+				m.PutLifecycleHookWithContext(context.TODO(), gomock.Eq(&updated)).
+					Return(&autoscaling.PutLifecycleHookOutput{}, nil)
+			},
+		},
+		{
+			name: "should add the hooks notifying a target",
+			hooks: []expinfrav1.AWSLifecycleHook{{
+				Name:                  "drain",
+				LifecycleTransition:   expinfrav1.LifecycleTransitionTerminating,
+				DefaultResult:         expinfrav1.LifecycleActionResultContinue,
+				NotificationTargetARN: "arn:aws:sqs:us-east-1:123456789012:drain",
+				RoleARN:               "arn:aws:iam::123456789012:role/asg-notifications",
+			}},
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.DescribeLifecycleHooksWithContext(context.TODO(), gomock.Eq(describeInput)).
+					Return(&autoscaling.DescribeLifecycleHooksOutput{}, nil)
+				m.PutLifecycleHookWithContext(context.TODO(), gomock.Eq(&autoscaling.PutLifecycleHookInput{
+					AutoScalingGroupName:  aws.String("asgName"),
+					LifecycleHookName:     aws.String("drain"),
+					LifecycleTransition:   aws.String("autoscaling:EC2_INSTANCE_TERMINATING"),
+					DefaultResult:         aws.String("CONTINUE"),
+					HeartbeatTimeout:      aws.Int64(600),
+					NotificationMetadata:  aws.String(lifecycleHookNotificationMetadata),
+					NotificationTargetARN: aws.String("arn:aws:sqs:us-east-1:123456789012:drain"),
+					RoleARN:               aws.String("arn:aws:iam::123456789012:role/asg-notifications"),
 				})).
-					Return(&autoscaling.DescribeInstanceRefreshesOutput{}, nil)
+					Return(&autoscaling.PutLifecycleHookOutput{}, nil)
 			},
 		},
 		{
-			name:     "should return false if some instances have unfinished refresh",
-			wantErr:  false,
-			canStart: false,
+			name:  "should update the hooks whose notification target was removed",
+			hooks: []expinfrav1.AWSLifecycleHook{nodeJoinHook},
 			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
-				m.DescribeInstanceRefreshesWithContext(context.TODO(), gomock.Eq(&autoscaling.DescribeInstanceRefreshesInput{
-					AutoScalingGroupName: aws.String("machinePoolName"),
+				drifted := *existingNodeJoinHook
+				drifted.NotificationTargetARN = aws.String("arn:aws:sqs:us-east-1:123456789012:drain")
+				drifted.RoleARN = aws.String("arn:aws:iam::123456789012:role/asg-notifications")
+				m.DescribeLifecycleHooksWithContext(context.TODO(), gomock.Eq(describeInput)).
+					Return(&autoscaling.DescribeLifecycleHooksOutput{LifecycleHooks: []*autoscaling.LifecycleHook{&drifted}}, nil)
+				m.PutLifecycleHookWithContext(context.TODO(), gomock.Eq(putNodeJoinHook)).
+					Return(&autoscaling.PutLifecycleHookOutput{}, nil)
+			},
+		},
+		{
+			name: "should remove the hooks no longer in the spec and keep the ones of other tooling",
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.DescribeLifecycleHooksWithContext(context.TODO(), gomock.Eq(describeInput)).
+					Return(&autoscaling.DescribeLifecycleHooksOutput{LifecycleHooks: []*autoscaling.LifecycleHook{
+						existingNodeJoinHook,
+						{LifecycleHookName: aws.String(NodeTerminationLifecycleHookName)},
+					}}, nil)
+				m.DeleteLifecycleHookWithContext(context.TODO(), gomock.Eq(&autoscaling.DeleteLifecycleHookInput{
+					AutoScalingGroupName: aws.String("asgName"),
+					LifecycleHookName:    aws.String("node-join"),
 				})).
-					Return(&autoscaling.DescribeInstanceRefreshesOutput{
-						InstanceRefreshes: []*autoscaling.InstanceRefresh{
-							{
-								Status: aws.String(autoscaling.InstanceRefreshStatusInProgress),
-							},
-						},
-					}, nil)
+					Return(&autoscaling.DeleteLifecycleHookOutput{}, nil)
+			},
+		},
+		{
+			name:    "should return an error when the hooks can't be described",
+			hooks:   []expinfrav1.AWSLifecycleHook{nodeJoinHook},
+			wantErr: true,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.DescribeLifecycleHooksWithContext(context.TODO(), gomock.Eq(describeInput)).
+					Return(nil, awserrors.NewFailedDependency("dependency failure"))
 			},
 		},
 	}
@@ -1169,64 +1863,944 @@ func TestServiceCanStartASGInstanceRefresh(t *testing.T) {
 			s := NewService(clusterScope)
 			s.ASGClient = asgMock
 
-			mps, err := getMachinePoolScope(fakeClient, clusterScope)
-			g.Expect(err).ToNot(HaveOccurred())
-			mps.AWSMachinePool.Name = "machinePoolName"
-
-			out, err := s.CanStartASGInstanceRefresh(mps)
+			err = s.ReconcileLifecycleHooks("asgName", tt.hooks)
 			checkErr(tt.wantErr, err, g)
-			if tt.canStart {
-				g.Expect(out).To(BeTrue())
-				return
-			}
-			g.Expect(out).To(BeFalse())
 		})
 	}
 }
 
-func TestServiceStartASGInstanceRefresh(t *testing.T) {
+func TestServiceCompleteLifecycleAction(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
 
+	completeInput := &autoscaling.CompleteLifecycleActionInput{
+		AutoScalingGroupName:  aws.String("asgName"),
+		LifecycleHookName:     aws.String("node-join"),
+		InstanceId:            aws.String("i-1"),
+		LifecycleActionResult: aws.String("CONTINUE"),
+	}
+
 	tests := []struct {
 		name    string
 		wantErr bool
 		expect  func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder)
 	}{
 		{
-			name:    "should return error if start instance refresh failed",
-			wantErr: true,
+			name: "should complete the lifecycle action",
 			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
-				m.StartInstanceRefreshWithContext(context.TODO(), gomock.Eq(&autoscaling.StartInstanceRefreshInput{
-					AutoScalingGroupName: aws.String("mpn"),
-					Strategy:             aws.String("Rolling"),
-					Preferences: &autoscaling.RefreshPreferences{
-						InstanceWarmup:       aws.Int64(100),
-						MinHealthyPercentage: aws.Int64(80),
-						MaxHealthyPercentage: aws.Int64(100),
-					},
-				})).
-					Return(nil, awserrors.NewNotFound("not found"))
+				m.CompleteLifecycleActionWithContext(context.TODO(), gomock.Eq(completeInput)).
+					Return(&autoscaling.CompleteLifecycleActionOutput{}, nil)
 			},
 		},
 		{
-			name:    "should return nil if start instance refresh is success",
-			wantErr: false,
+			name: "should succeed when the lifecycle action was already completed",
 			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
-				m.StartInstanceRefreshWithContext(context.TODO(), gomock.Eq(&autoscaling.StartInstanceRefreshInput{
-					AutoScalingGroupName: aws.String("mpn"),
-					Strategy:             aws.String("Rolling"),
-					Preferences: &autoscaling.RefreshPreferences{
-						InstanceWarmup:       aws.Int64(100),
-						MinHealthyPercentage: aws.Int64(80),
-						MaxHealthyPercentage: aws.Int64(100),
-					},
-				})).
-					Return(&autoscaling.StartInstanceRefreshOutput{}, nil)
+				m.CompleteLifecycleActionWithContext(context.TODO(), gomock.Eq(completeInput)).
+					Return(nil, awserr.New("ValidationError", "No active Lifecycle Action found with instance ID i-1", nil))
 			},
 		},
-	}
-
+		{
+			name:    "should return other errors",
+			wantErr: true,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.CompleteLifecycleActionWithContext(context.TODO(), gomock.Eq(completeInput)).
+					Return(nil, awserr.New("Throttling", "Rate exceeded", nil))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			fakeClient := getFakeClient()
+
+			clusterScope, err := getClusterScope(fakeClient)
+			g.Expect(err).ToNot(HaveOccurred())
+			asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+			tt.expect(asgMock.EXPECT())
+			s := NewService(clusterScope)
+			s.ASGClient = asgMock
+
+			err = s.CompleteLifecycleAction("asgName", "node-join", "i-1", expinfrav1.LifecycleActionResultContinue)
+			checkErr(tt.wantErr, err, g)
+		})
+	}
+}
+
+func TestServiceStandby(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	tests := []struct {
+		name    string
+		wantErr bool
+		expect  func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder)
+		call    func(s *Service) error
+	}{
+		{
+			name: "should move instances into standby and decrement the desired capacity",
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.EnterStandbyWithContext(context.TODO(), gomock.Eq(&autoscaling.EnterStandbyInput{
+					AutoScalingGroupName:           aws.String("asgName"),
+					InstanceIds:                    aws.StringSlice([]string{"i-1", "i-2"}),
+					ShouldDecrementDesiredCapacity: aws.Bool(true),
+				})).Return(&autoscaling.EnterStandbyOutput{}, nil)
+			},
+			call: func(s *Service) error {
+				return s.EnterStandby("asgName", []string{"i-1", "i-2"})
+			},
+		},
+		{
+			name:    "should return an error if moving instances into standby fails",
+			wantErr: true,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.EnterStandbyWithContext(context.TODO(), gomock.AssignableToTypeOf(&autoscaling.EnterStandbyInput{})).
+					Return(nil, awserr.New("ValidationError", "The instance i-1 is not in InService.", nil))
+			},
+			call: func(s *Service) error {
+				return s.EnterStandby("asgName", []string{"i-1"})
+			},
+		},
+		{
+			name: "should move instances out of standby",
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.ExitStandbyWithContext(context.TODO(), gomock.Eq(&autoscaling.ExitStandbyInput{
+					AutoScalingGroupName: aws.String("asgName"),
+					InstanceIds:          aws.StringSlice([]string{"i-1"}),
+				})).Return(&autoscaling.ExitStandbyOutput{}, nil)
+			},
+			call: func(s *Service) error {
+				return s.ExitStandby("asgName", []string{"i-1"})
+			},
+		},
+		{
+			name:    "should return an error if moving instances out of standby fails",
+			wantErr: true,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.ExitStandbyWithContext(context.TODO(), gomock.AssignableToTypeOf(&autoscaling.ExitStandbyInput{})).
+					Return(nil, awserr.New("ValidationError", "The instance i-1 is not in Standby.", nil))
+			},
+			call: func(s *Service) error {
+				return s.ExitStandby("asgName", []string{"i-1"})
+			},
+		},
+		{
+			name: "should protect instances from scale in",
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.SetInstanceProtectionWithContext(context.TODO(), gomock.Eq(&autoscaling.SetInstanceProtectionInput{
+					AutoScalingGroupName: aws.String("asgName"),
+					InstanceIds:          aws.StringSlice([]string{"i-1", "i-2"}),
+					ProtectedFromScaleIn: aws.Bool(true),
+				})).Return(&autoscaling.SetInstanceProtectionOutput{}, nil)
+			},
+			call: func(s *Service) error {
+				return s.SetInstanceProtection("asgName", []string{"i-1", "i-2"}, true)
+			},
+		},
+		{
+			name:    "should return an error if the protection of instances can't be removed",
+			wantErr: true,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.SetInstanceProtectionWithContext(context.TODO(), gomock.Eq(&autoscaling.SetInstanceProtectionInput{
+					AutoScalingGroupName: aws.String("asgName"),
+					InstanceIds:          aws.StringSlice([]string{"i-1"}),
+					ProtectedFromScaleIn: aws.Bool(false),
+				})).Return(nil, awserr.New("ValidationError", "The instance i-1 is not part of Auto Scaling group asgName.", nil))
+			},
+			call: func(s *Service) error {
+				return s.SetInstanceProtection("asgName", []string{"i-1"}, false)
+			},
+		},
+		{
+			name: "should detach instances and decrement the desired capacity",
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.DetachInstancesWithContext(context.TODO(), gomock.Eq(&autoscaling.DetachInstancesInput{
+					AutoScalingGroupName:           aws.String("asgName"),
+					InstanceIds:                    aws.StringSlice([]string{"i-1", "i-2"}),
+					ShouldDecrementDesiredCapacity: aws.Bool(true),
+				})).Return(&autoscaling.DetachInstancesOutput{}, nil)
+			},
+			call: func(s *Service) error {
+				return s.DetachInstances("asgName", []string{"i-1", "i-2"})
+			},
+		},
+		{
+			name:    "should return an error if detaching the instances fails",
+			wantErr: true,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.DetachInstancesWithContext(context.TODO(), gomock.AssignableToTypeOf(&autoscaling.DetachInstancesInput{})).
+					Return(nil, awserr.New("ValidationError", "The instance i-1 is not part of Auto Scaling group asgName.", nil))
+			},
+			call: func(s *Service) error {
+				return s.DetachInstances("asgName", []string{"i-1", "i-2"})
+			},
+		},
+		{
+			name: "should terminate an instance without decrementing the desired capacity",
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.TerminateInstanceInAutoScalingGroupWithContext(context.TODO(), gomock.Eq(&autoscaling.TerminateInstanceInAutoScalingGroupInput{
+					InstanceId:                     aws.String("i-1"),
+					ShouldDecrementDesiredCapacity: aws.Bool(false),
+				})).Return(&autoscaling.TerminateInstanceInAutoScalingGroupOutput{}, nil)
+			},
+			call: func(s *Service) error {
+				return s.TerminateInstanceInASG("i-1", false)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			fakeClient := getFakeClient()
+
+			clusterScope, err := getClusterScope(fakeClient)
+			g.Expect(err).ToNot(HaveOccurred())
+			asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+			tt.expect(asgMock.EXPECT())
+			s := NewService(clusterScope)
+			s.ASGClient = asgMock
+
+			checkErr(tt.wantErr, tt.call(s), g)
+		})
+	}
+}
+
+func TestServiceMetricsCollection(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	tests := []struct {
+		name    string
+		wantErr bool
+		expect  func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder)
+		call    func(s *Service) error
+	}{
+		{
+			name: "should enable the collection of the given metrics",
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.EnableMetricsCollectionWithContext(context.TODO(), gomock.Eq(&autoscaling.EnableMetricsCollectionInput{
+					AutoScalingGroupName: aws.String("asgName"),
+					Granularity:          aws.String("1Minute"),
+					Metrics:              aws.StringSlice([]string{"GroupInServiceInstances", "GroupDesiredCapacity"}),
+				})).Return(&autoscaling.EnableMetricsCollectionOutput{}, nil)
+			},
+			call: func(s *Service) error {
+				return s.EnableMetricsCollection("asgName", "1Minute", []string{"GroupInServiceInstances", "GroupDesiredCapacity"})
+			},
+		},
+		{
+			name:    "should return an error if enabling the metrics collection fails",
+			wantErr: true,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.EnableMetricsCollectionWithContext(context.TODO(), gomock.AssignableToTypeOf(&autoscaling.EnableMetricsCollectionInput{})).
+					Return(nil, awserr.New("ValidationError", "Unknown metric GroupFoo", nil))
+			},
+			call: func(s *Service) error {
+				return s.EnableMetricsCollection("asgName", "1Minute", []string{"GroupFoo"})
+			},
+		},
+		{
+			name: "should disable the collection of the given metrics",
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.DisableMetricsCollectionWithContext(context.TODO(), gomock.Eq(&autoscaling.DisableMetricsCollectionInput{
+					AutoScalingGroupName: aws.String("asgName"),
+					Metrics:              aws.StringSlice([]string{"GroupMaxSize"}),
+				})).Return(&autoscaling.DisableMetricsCollectionOutput{}, nil)
+			},
+			call: func(s *Service) error {
+				return s.DisableMetricsCollection("asgName", []string{"GroupMaxSize"})
+			},
+		},
+		{
+			name: "should disable the collection of all the metrics when none is given",
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.DisableMetricsCollectionWithContext(context.TODO(), gomock.Eq(&autoscaling.DisableMetricsCollectionInput{
+					AutoScalingGroupName: aws.String("asgName"),
+				})).Return(&autoscaling.DisableMetricsCollectionOutput{}, nil)
+			},
+			call: func(s *Service) error {
+				return s.DisableMetricsCollection("asgName", nil)
+			},
+		},
+		{
+			name:    "should return an error if disabling the metrics collection fails",
+			wantErr: true,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.DisableMetricsCollectionWithContext(context.TODO(), gomock.AssignableToTypeOf(&autoscaling.DisableMetricsCollectionInput{})).
+					Return(nil, awserr.New("ValidationError", "AutoScalingGroup name not found", nil))
+			},
+			call: func(s *Service) error {
+				return s.DisableMetricsCollection("asgName", nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			fakeClient := getFakeClient()
+
+			clusterScope, err := getClusterScope(fakeClient)
+			g.Expect(err).ToNot(HaveOccurred())
+			asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+			tt.expect(asgMock.EXPECT())
+			s := NewService(clusterScope)
+			s.ASGClient = asgMock
+
+			checkErr(tt.wantErr, tt.call(s), g)
+		})
+	}
+}
+
+func TestServiceReconcileScalingPolicies(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	cpuPolicy := expinfrav1.ScalingPolicy{
+		Name: "cpu",
+		PredictiveScaling: &expinfrav1.PredictiveScalingConfiguration{
+			MetricSpecifications: []expinfrav1.PredictiveScalingMetricSpecification{{
+				TargetValue:          50,
+				PredefinedMetricPair: expinfrav1.PredefinedMetricPair{PredefinedMetricType: expinfrav1.PredefinedMetricPairTypeASGCPUUtilization},
+			}},
+			Mode:                 expinfrav1.PredictiveScalingModeForecastAndScale,
+			SchedulingBufferTime: &metav1.Duration{Duration: 5 * time.Minute},
+		},
+	}
+	cpuConfiguration := &autoscaling.PredictiveScalingConfiguration{
+		MetricSpecifications: []*autoscaling.PredictiveScalingMetricSpecification{{
+			TargetValue: aws.Float64(50),
+			PredefinedMetricPairSpecification: &autoscaling.PredictiveScalingPredefinedMetricPair{
+				PredefinedMetricType: aws.String("ASGCPUUtilization"),
+			},
+		}},
+		Mode:                 aws.String("ForecastAndScale"),
+		SchedulingBufferTime: aws.Int64(300),
+	}
+	putCPUPolicy := &autoscaling.PutScalingPolicyInput{
+		AutoScalingGroupName:           aws.String("asgName"),
+		PolicyName:                     aws.String("cpu"),
+		PolicyType:                     aws.String("PredictiveScaling"),
+		PredictiveScalingConfiguration: cpuConfiguration,
+	}
+	existingCPUPolicy := &autoscaling.ScalingPolicy{
+		AutoScalingGroupName:           aws.String("asgName"),
+		PolicyARN:                      aws.String("arn:cpu"),
+		PolicyName:                     aws.String("cpu"),
+		PolicyType:                     aws.String("PredictiveScaling"),
+		PredictiveScalingConfiguration: cpuConfiguration,
+	}
+	forecast := &expinfrav1.PredictiveScalingForecast{MinCapacity: 1, MaxCapacity: 3}
+
+	tests := []struct {
+		name     string
+		policies []expinfrav1.ScalingPolicy
+		current  []expinfrav1.ScalingPolicyStatus
+		want     []expinfrav1.ScalingPolicyStatus
+		wantErr  bool
+		expect   func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder)
+	}{
+		{
+			name:     "should put the missing policies",
+			policies: []expinfrav1.ScalingPolicy{cpuPolicy},
+			want:     []expinfrav1.ScalingPolicyStatus{{Name: "cpu", ARN: "arn:cpu"}},
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.DescribePoliciesWithContext(context.TODO(), gomock.Eq(&autoscaling.DescribePoliciesInput{
+					AutoScalingGroupName: aws.String("asgName"),
+					PolicyNames:          aws.StringSlice([]string{"cpu"}),
+				})).
+					Return(&autoscaling.DescribePoliciesOutput{}, nil)
+				m.PutScalingPolicyWithContext(context.TODO(), gomock.Eq(putCPUPolicy)).
+					Return(&autoscaling.PutScalingPolicyOutput{PolicyARN: aws.String("arn:cpu")}, nil)
+			},
+		},
+		{
+			name:     "should keep the forecast of the policies which are up to date",
+			policies: []expinfrav1.ScalingPolicy{cpuPolicy},
+			current:  []expinfrav1.ScalingPolicyStatus{{Name: "cpu", ARN: "arn:cpu", Forecast: forecast}},
+			want:     []expinfrav1.ScalingPolicyStatus{{Name: "cpu", ARN: "arn:cpu", Forecast: forecast}},
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.DescribePoliciesWithContext(context.TODO(), gomock.Eq(&autoscaling.DescribePoliciesInput{
+					AutoScalingGroupName: aws.String("asgName"),
+					PolicyNames:          aws.StringSlice([]string{"cpu", "cpu"}),
+				})).
+					Return(&autoscaling.DescribePoliciesOutput{ScalingPolicies: []*autoscaling.ScalingPolicy{existingCPUPolicy}}, nil)
+			},
+		},
+		{
+			name: "should update the policies whose target changed",
+			policies: []expinfrav1.ScalingPolicy{func() expinfrav1.ScalingPolicy {
+				policy := *cpuPolicy.DeepCopy()
+				policy.PredictiveScaling.MetricSpecifications[0].TargetValue = 70
+				return policy
+			}()},
+			want: []expinfrav1.ScalingPolicyStatus{{Name: "cpu", ARN: "arn:cpu"}},
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.DescribePoliciesWithContext(context.TODO(), gomock.Any()).
+					Return(&autoscaling.DescribePoliciesOutput{ScalingPolicies: []*autoscaling.ScalingPolicy{existingCPUPolicy}}, nil)
+				updated := *putCPUPolicy
+				updated.PredictiveScalingConfiguration = &autoscaling.PredictiveScalingConfiguration{
+					MetricSpecifications: []*autoscaling.PredictiveScalingMetricSpecification{{
+						TargetValue:                       aws.Float64(70),
+						PredefinedMetricPairSpecification: cpuConfiguration.MetricSpecifications[0].PredefinedMetricPairSpecification,
+					}},
+					Mode:                 cpuConfiguration.Mode,
+					SchedulingBufferTime: cpuConfiguration.SchedulingBufferTime,
+				}
+				m.PutScalingPolicyWithContext(context.TODO(), gomock.Eq(&updated)).
+					Return(&autoscaling.PutScalingPolicyOutput{PolicyARN: aws.String("arn:cpu")}, nil)
+			},
+		},
+		{
+			name:    "should delete the policies no longer in the spec",
+			current: []expinfrav1.ScalingPolicyStatus{{Name: "cpu", ARN: "arn:cpu"}},
+			want:    []expinfrav1.ScalingPolicyStatus{},
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.DescribePoliciesWithContext(context.TODO(), gomock.Any()).
+					Return(&autoscaling.DescribePoliciesOutput{ScalingPolicies: []*autoscaling.ScalingPolicy{existingCPUPolicy}}, nil)
+				m.DeletePolicyWithContext(context.TODO(), gomock.Eq(&autoscaling.DeletePolicyInput{
+					AutoScalingGroupName: aws.String("asgName"),
+					PolicyName:           aws.String("cpu"),
+				})).
+					Return(&autoscaling.DeletePolicyOutput{}, nil)
+			},
+		},
+		{
+			name:     "should return an error when the policies can't be described",
+			policies: []expinfrav1.ScalingPolicy{cpuPolicy},
+			wantErr:  true,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.DescribePoliciesWithContext(context.TODO(), gomock.Any()).
+					Return(nil, awserrors.NewFailedDependency("dependency failure"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			fakeClient := getFakeClient()
+
+			clusterScope, err := getClusterScope(fakeClient)
+			g.Expect(err).ToNot(HaveOccurred())
+			asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+			tt.expect(asgMock.EXPECT())
+			s := NewService(clusterScope)
+			s.ASGClient = asgMock
+
+			statuses, err := s.ReconcileScalingPolicies("asgName", tt.policies, tt.current)
+			checkErr(tt.wantErr, err, g)
+			if !tt.wantErr {
+				g.Expect(statuses).To(Equal(tt.want))
+			}
+		})
+	}
+}
+
+func TestServiceGetPredictiveScalingForecast(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	peak := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	updated := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		wantErr bool
+		want    *expinfrav1.PredictiveScalingForecast
+		expect  func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder)
+	}{
+		{
+			name: "should summarize the capacity forecast",
+			want: &expinfrav1.PredictiveScalingForecast{
+				UpdateTime:      ptr.To(metav1.NewTime(updated)),
+				MinCapacity:     2,
+				MaxCapacity:     5,
+				MaxCapacityTime: ptr.To(metav1.NewTime(peak)),
+			},
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.GetPredictiveScalingForecastWithContext(context.TODO(), gomock.Any()).
+					Return(&autoscaling.GetPredictiveScalingForecastOutput{
+						CapacityForecast: &autoscaling.CapacityForecast{
+							Timestamps: []*time.Time{aws.Time(peak.Add(-time.Hour)), aws.Time(peak), aws.Time(peak.Add(time.Hour))},
+							Values:     aws.Float64Slice([]float64{1.5, 4.2, 3}),
+						},
+						UpdateTime: aws.Time(updated),
+					}, nil)
+			},
+		},
+		{
+			name:    "should return an error when the forecast can't be fetched",
+			wantErr: true,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.GetPredictiveScalingForecastWithContext(context.TODO(), gomock.Any()).
+					Return(nil, awserrors.NewFailedDependency("dependency failure"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			fakeClient := getFakeClient()
+
+			clusterScope, err := getClusterScope(fakeClient)
+			g.Expect(err).ToNot(HaveOccurred())
+			asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+			tt.expect(asgMock.EXPECT())
+			s := NewService(clusterScope)
+			s.ASGClient = asgMock
+
+			forecast, err := s.GetPredictiveScalingForecast("asgName", "cpu")
+			checkErr(tt.wantErr, err, g)
+			if !tt.wantErr {
+				g.Expect(forecast.FetchTime.IsZero()).To(BeFalse())
+				forecast.FetchTime = metav1.Time{}
+				g.Expect(forecast).To(Equal(tt.want))
+			}
+		})
+	}
+}
+
+func TestServiceCancelASGInstanceRefresh(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	cancelInput := &autoscaling.CancelInstanceRefreshInput{AutoScalingGroupName: aws.String("asgName")}
+
+	tests := []struct {
+		name    string
+		wantErr bool
+		expect  func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder)
+	}{
+		{
+			name: "should cancel the running instance refresh",
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.CancelInstanceRefreshWithContext(context.TODO(), gomock.Eq(cancelInput)).
+					Return(&autoscaling.CancelInstanceRefreshOutput{InstanceRefreshId: aws.String("refresh-1")}, nil)
+			},
+		},
+		{
+			name: "should succeed when no instance refresh is running",
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.CancelInstanceRefreshWithContext(context.TODO(), gomock.Eq(cancelInput)).
+					Return(nil, awserr.New(autoscaling.ErrCodeActiveInstanceRefreshNotFoundFault, "no active instance refresh", nil))
+			},
+		},
+		{
+			name:    "should return an error when the instance refresh can't be cancelled",
+			wantErr: true,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.CancelInstanceRefreshWithContext(context.TODO(), gomock.Eq(cancelInput)).
+					Return(nil, awserrors.NewFailedDependency("dependency failure"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			fakeClient := getFakeClient()
+
+			clusterScope, err := getClusterScope(fakeClient)
+			g.Expect(err).ToNot(HaveOccurred())
+			asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+			tt.expect(asgMock.EXPECT())
+			s := NewService(clusterScope)
+			s.ASGClient = asgMock
+
+			err = s.CancelASGInstanceRefresh("asgName")
+			checkErr(tt.wantErr, err, g)
+		})
+	}
+}
+
+func TestServiceReleaseASG(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	asg := &expinfrav1.AutoScalingGroup{
+		Name: "asgName",
+		Tags: infrav1.Tags{
+			infrav1.ClusterTagKey("test"): string(infrav1.ResourceLifecycleOwned),
+			"team":                        "platform",
+		},
+	}
+	updateInput := &autoscaling.UpdateAutoScalingGroupInput{
+		AutoScalingGroupName: aws.String("asgName"),
+		MinSize:              aws.Int64(1),
+		MaxSize:              aws.Int64(5),
+		DesiredCapacity:      aws.Int64(3),
+	}
+
+	tests := []struct {
+		name    string
+		wantErr bool
+		expect  func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder)
+	}{
+		{
+			name: "should restore the sizes and remove the ownership tags only",
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.UpdateAutoScalingGroupWithContext(context.TODO(), gomock.Eq(updateInput)).
+					Return(&autoscaling.UpdateAutoScalingGroupOutput{}, nil)
+				m.DeleteTagsWithContext(context.TODO(), gomock.Eq(&autoscaling.DeleteTagsInput{
+					Tags: []*autoscaling.Tag{{
+						Key:               aws.String(infrav1.ClusterTagKey("test")),
+						PropagateAtLaunch: aws.Bool(false),
+						ResourceId:        aws.String("asgName"),
+						ResourceType:      aws.String("auto-scaling-group"),
+						Value:             aws.String(string(infrav1.ResourceLifecycleOwned)),
+					}},
+				})).Return(&autoscaling.DeleteTagsOutput{}, nil)
+			},
+		},
+		{
+			name:    "should keep the ownership tags when the sizes can't be restored",
+			wantErr: true,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.UpdateAutoScalingGroupWithContext(context.TODO(), gomock.Eq(updateInput)).
+					Return(nil, awserrors.NewFailedDependency("dependency failure"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			fakeClient := getFakeClient()
+
+			clusterScope, err := getClusterScope(fakeClient)
+			g.Expect(err).ToNot(HaveOccurred())
+			asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+			tt.expect(asgMock.EXPECT())
+			s := NewService(clusterScope)
+			s.ASGClient = asgMock
+
+			err = s.ReleaseASG(asg, 1, 5, 3)
+			checkErr(tt.wantErr, err, g)
+		})
+	}
+}
+
+func TestServiceDescribeLatestInstanceRefresh(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	started := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	describeInput := &autoscaling.DescribeInstanceRefreshesInput{
+		AutoScalingGroupName: aws.String("asgName"),
+		MaxRecords:           aws.Int64(1),
+	}
+
+	tests := []struct {
+		name    string
+		wantErr bool
+		want    *expinfrav1.InstanceRefreshStatus
+		expect  func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder)
+	}{
+		{
+			name: "should report the progress of the latest instance refresh",
+			want: &expinfrav1.InstanceRefreshStatus{
+				ID:                 "refresh-1",
+				State:              autoscaling.InstanceRefreshStatusInProgress,
+				PercentageComplete: aws.Int64(40),
+				InstancesToUpdate:  aws.Int64(3),
+				StatusReason:       "Waiting for instances to warm up",
+				StartTime:          ptr.To(metav1.NewTime(started)),
+			},
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.DescribeInstanceRefreshesWithContext(context.TODO(), gomock.Eq(describeInput)).
+					Return(&autoscaling.DescribeInstanceRefreshesOutput{
+						InstanceRefreshes: []*autoscaling.InstanceRefresh{{
+							InstanceRefreshId:  aws.String("refresh-1"),
+							Status:             aws.String(autoscaling.InstanceRefreshStatusInProgress),
+							PercentageComplete: aws.Int64(40),
+							InstancesToUpdate:  aws.Int64(3),
+							StatusReason:       aws.String("Waiting for instances to warm up"),
+							StartTime:          aws.Time(started),
+						}},
+					}, nil)
+			},
+		},
+		{
+			name: "should return nil when the ASG never had an instance refresh",
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.DescribeInstanceRefreshesWithContext(context.TODO(), gomock.Eq(describeInput)).
+					Return(&autoscaling.DescribeInstanceRefreshesOutput{}, nil)
+			},
+		},
+		{
+			name:    "should return an error when the instance refreshes can't be described",
+			wantErr: true,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.DescribeInstanceRefreshesWithContext(context.TODO(), gomock.Eq(describeInput)).
+					Return(nil, awserrors.NewFailedDependency("dependency failure"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			fakeClient := getFakeClient()
+
+			clusterScope, err := getClusterScope(fakeClient)
+			g.Expect(err).ToNot(HaveOccurred())
+			asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+			tt.expect(asgMock.EXPECT())
+			s := NewService(clusterScope)
+			s.ASGClient = asgMock
+
+			refresh, err := s.DescribeLatestInstanceRefresh("asgName")
+			checkErr(tt.wantErr, err, g)
+			g.Expect(refresh).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestServiceDeleteASGAndWait(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	tests := []struct {
+		name    string
+		wantErr bool
+		expect  func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder)
+	}{
+		{
+			name:    "Delete ASG with wait passed",
+			wantErr: false,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.DeleteAutoScalingGroupWithContext(context.TODO(), gomock.Eq(&autoscaling.DeleteAutoScalingGroupInput{
+					AutoScalingGroupName: aws.String("asgName"),
+					ForceDelete:          aws.Bool(true),
+				})).
+					Return(nil, nil)
+				m.WaitUntilGroupNotExistsWithContext(context.TODO(), gomock.Eq(&autoscaling.DescribeAutoScalingGroupsInput{
+					AutoScalingGroupNames: aws.StringSlice([]string{"asgName"}),
+				})).
+					Return(nil)
+			},
+		},
+		{
+			name:    "should return error if delete ASG failed while waiting",
+			wantErr: true,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.DeleteAutoScalingGroupWithContext(context.TODO(), gomock.Eq(&autoscaling.DeleteAutoScalingGroupInput{
+					AutoScalingGroupName: aws.String("asgName"),
+					ForceDelete:          aws.Bool(true),
+				})).
+					Return(nil, nil)
+				m.WaitUntilGroupNotExistsWithContext(context.TODO(), gomock.Eq(&autoscaling.DescribeAutoScalingGroupsInput{
+					AutoScalingGroupNames: aws.StringSlice([]string{"asgName"}),
+				})).
+					Return(awserrors.NewFailedDependency("dependency error"))
+			},
+		},
+		{
+			name:    "should return error if delete ASG failed during ASG deletion",
+			wantErr: true,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.DeleteAutoScalingGroupWithContext(context.TODO(), gomock.Eq(&autoscaling.DeleteAutoScalingGroupInput{
+					AutoScalingGroupName: aws.String("asgName"),
+					ForceDelete:          aws.Bool(true),
+				})).
+					Return(nil, awserrors.NewNotFound("not found"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			fakeClient := getFakeClient()
+
+			clusterScope, err := getClusterScope(fakeClient)
+			g.Expect(err).ToNot(HaveOccurred())
+			asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+			tt.expect(asgMock.EXPECT())
+			s := NewService(clusterScope)
+			s.ASGClient = asgMock
+
+			err = s.DeleteASGAndWait("asgName")
+			checkErr(tt.wantErr, err, g)
+		})
+	}
+}
+
+func TestServiceCanStartASGInstanceRefresh(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	tests := []struct {
+		name     string
+		wantErr  bool
+		canStart bool
+		expect   func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder)
+	}{
+		{
+			name:     "should return error if describe instance refresh failed",
+			wantErr:  true,
+			canStart: false,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.DescribeInstanceRefreshesWithContext(context.TODO(), gomock.Eq(&autoscaling.DescribeInstanceRefreshesInput{
+					AutoScalingGroupName: aws.String("machinePoolName"),
+				})).
+					Return(nil, awserrors.NewConflict("some error"))
+			},
+		},
+		{
+			name:     "should return true if no instance available for refresh",
+			wantErr:  false,
+			canStart: true,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.DescribeInstanceRefreshesWithContext(context.TODO(), gomock.Eq(&autoscaling.DescribeInstanceRefreshesInput{
+					AutoScalingGroupName: aws.String("machinePoolName"),
+				})).
+					Return(&autoscaling.DescribeInstanceRefreshesOutput{}, nil)
+			},
+		},
+		{
+			name:     "should return false if some instances have unfinished refresh",
+			wantErr:  false,
+			canStart: false,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.DescribeInstanceRefreshesWithContext(context.TODO(), gomock.Eq(&autoscaling.DescribeInstanceRefreshesInput{
+					AutoScalingGroupName: aws.String("machinePoolName"),
+				})).
+					Return(&autoscaling.DescribeInstanceRefreshesOutput{
+						InstanceRefreshes: []*autoscaling.InstanceRefresh{
+							{
+								Status: aws.String(autoscaling.InstanceRefreshStatusInProgress),
+							},
+						},
+					}, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			fakeClient := getFakeClient()
+
+			clusterScope, err := getClusterScope(fakeClient)
+			g.Expect(err).ToNot(HaveOccurred())
+			asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+			tt.expect(asgMock.EXPECT())
+			s := NewService(clusterScope)
+			s.ASGClient = asgMock
+
+			mps, err := getMachinePoolScope(fakeClient, clusterScope)
+			g.Expect(err).ToNot(HaveOccurred())
+			mps.AWSMachinePool.Name = "machinePoolName"
+
+			out, err := s.CanStartASGInstanceRefresh(mps)
+			checkErr(tt.wantErr, err, g)
+			if tt.canStart {
+				g.Expect(out).To(BeTrue())
+				return
+			}
+			g.Expect(out).To(BeFalse())
+		})
+	}
+}
+
+func TestServiceStartASGInstanceRefresh(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	tests := []struct {
+		name               string
+		refreshPreferences *expinfrav1.RefreshPreferences
+		wantErr            bool
+		wantID             string
+		expect             func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder)
+	}{
+		{
+			name:    "should return error if start instance refresh failed",
+			wantErr: true,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.StartInstanceRefreshWithContext(context.TODO(), gomock.Eq(&autoscaling.StartInstanceRefreshInput{
+					AutoScalingGroupName: aws.String("mpn"),
+					Strategy:             aws.String("Rolling"),
+					Preferences: &autoscaling.RefreshPreferences{
+						InstanceWarmup:       aws.Int64(100),
+						MinHealthyPercentage: aws.Int64(80),
+						MaxHealthyPercentage: aws.Int64(100),
+					},
+				})).
+					Return(nil, awserrors.NewNotFound("not found"))
+			},
+		},
+		{
+			name:    "should return nil if start instance refresh is success",
+			wantErr: false,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.StartInstanceRefreshWithContext(context.TODO(), gomock.Eq(&autoscaling.StartInstanceRefreshInput{
+					AutoScalingGroupName: aws.String("mpn"),
+					Strategy:             aws.String("Rolling"),
+					Preferences: &autoscaling.RefreshPreferences{
+						InstanceWarmup:       aws.Int64(100),
+						MinHealthyPercentage: aws.Int64(80),
+						MaxHealthyPercentage: aws.Int64(100),
+					},
+				})).
+					Return(&autoscaling.StartInstanceRefreshOutput{InstanceRefreshId: aws.String("instance-refresh-1")}, nil)
+			},
+			wantID: "instance-refresh-1",
+		},
+		{
+			name: "should pass the checkpoints, skip matching and instance strategies",
+			refreshPreferences: &expinfrav1.RefreshPreferences{
+				MinHealthyPercentage:      aws.Int64(90),
+				CheckpointPercentages:     []int64{20, 100},
+				CheckpointDelay:           &metav1.Duration{Duration: 10 * time.Minute},
+				SkipMatching:              aws.Bool(true),
+				ScaleInProtectedInstances: expinfrav1.ScaleInProtectedInstancesWait,
+				StandbyInstances:          expinfrav1.StandbyInstancesTerminate,
+			},
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.StartInstanceRefreshWithContext(context.TODO(), gomock.Eq(&autoscaling.StartInstanceRefreshInput{
+					AutoScalingGroupName: aws.String("mpn"),
+					Strategy:             aws.String("Rolling"),
+					Preferences: &autoscaling.RefreshPreferences{
+						MinHealthyPercentage:      aws.Int64(90),
+						CheckpointPercentages:     aws.Int64Slice([]int64{20, 100}),
+						CheckpointDelay:           aws.Int64(600),
+						SkipMatching:              aws.Bool(true),
+						ScaleInProtectedInstances: aws.String("Wait"),
+						StandbyInstances:          aws.String("Terminate"),
+					},
+				})).
+					Return(&autoscaling.StartInstanceRefreshOutput{InstanceRefreshId: aws.String("instance-refresh-2")}, nil)
+			},
+			wantID: "instance-refresh-2",
+		},
+		{
+			name: "should refresh the unprotected instances not matching the launch template with the AZSequential strategy",
+			refreshPreferences: &expinfrav1.RefreshPreferences{
+				Strategy:                  aws.String(expinfrav1.RefreshStrategyAZSequential),
+				MinHealthyPercentage:      aws.Int64(90),
+				ScaleInProtectedInstances: expinfrav1.ScaleInProtectedInstancesRefresh,
+			},
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.StartInstanceRefreshWithContext(context.TODO(), gomock.Eq(&autoscaling.StartInstanceRefreshInput{
+					AutoScalingGroupName: aws.String("mpn"),
+					Strategy:             aws.String("Rolling"),
+					Preferences: &autoscaling.RefreshPreferences{
+						MinHealthyPercentage:      aws.Int64(90),
+						SkipMatching:              aws.Bool(true),
+						ScaleInProtectedInstances: aws.String("Ignore"),
+					},
+				})).
+					Return(&autoscaling.StartInstanceRefreshOutput{InstanceRefreshId: aws.String("instance-refresh-3")}, nil)
+			},
+			wantID: "instance-refresh-3",
+		},
+	}
+
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			g := NewWithT(t)
@@ -1242,9 +2816,13 @@ func TestServiceStartASGInstanceRefresh(t *testing.T) {
 			mps, err := getMachinePoolScope(fakeClient, clusterScope)
 			g.Expect(err).ToNot(HaveOccurred())
 			mps.AWSMachinePool.Name = "mpn"
+			if tt.refreshPreferences != nil {
+				mps.AWSMachinePool.Spec.RefreshPreferences = tt.refreshPreferences
+			}
 
-			err = s.StartASGInstanceRefresh(mps)
+			id, err := s.StartASGInstanceRefresh(mps)
 			checkErr(tt.wantErr, err, g)
+			g.Expect(id).To(Equal(tt.wantID))
 		})
 	}
 }
@@ -0,0 +1,203 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package asg
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+)
+
+// azCapacityFailureThreshold is the number of launches failing for lack of capacity in an availability zone within
+// the cooldown of the AZ failure handling after which the availability zone is excluded from the ASG.
+const azCapacityFailureThreshold = 3
+
+// scalingActivityDetails holds the fields of the details of a scaling activity identifying where an instance was launched.
+type scalingActivityDetails struct {
+	AvailabilityZone string `json:"Availability Zone"`
+}
+
+// ReconcileAZFailures excludes from the ASG the availability zones in which launches repeatedly failed for lack of
+// capacity, as recorded by the scaling activities of the ASG, and restores them once their cooldown expired. The
+// exclusions are recorded in the status of the AWSMachinePool and applied to the subnets of the ASG by SubnetIDs,
+// the last availability zone of the ASG is never excluded. All the exclusions are restored when the AZ failure
+// handling is disabled.
+func (s *Service) ReconcileAZFailures(machinePoolScope *scope.MachinePoolScope) error {
+	pool := machinePoolScope.AWSMachinePool
+	handling := pool.Spec.AZFailureHandling
+	enabled := handling != nil && handling.Enabled
+	now := time.Now()
+
+	var excluded []expinfrav1.ExcludedAvailabilityZone
+	for _, zone := range pool.Status.ExcludedAvailabilityZones {
+		if enabled && now.Before(zone.ExpiresAt.Time) {
+			excluded = append(excluded, zone)
+			continue
+		}
+		record.Eventf(pool, "AvailabilityZoneRestored", "Restored the subnets of availability zone %s to the ASG", zone.Name)
+	}
+	pool.Status.ExcludedAvailabilityZones = excluded
+	if !enabled {
+		return nil
+	}
+
+	failures, err := s.capacityFailuresByZone(machinePoolScope.Name(), now.Add(-handling.GetCooldown()))
+	if err != nil {
+		return err
+	}
+	var failing []string
+	for zone, count := range failures {
+		if count >= azCapacityFailureThreshold {
+			failing = append(failing, zone)
+		}
+	}
+	if len(failing) == 0 {
+		return nil
+	}
+	sort.Strings(failing)
+
+	subnetIDs, err := s.poolSubnetIDs(machinePoolScope)
+	if err != nil {
+		return errors.Wrap(err, "failed to get subnets of the ASG")
+	}
+	subnetZones, err := s.subnetAvailabilityZones(subnetIDs)
+	if err != nil {
+		return err
+	}
+	remaining := sets.New[string]()
+	for _, zone := range subnetZones {
+		remaining.Insert(zone)
+	}
+	for _, zone := range excluded {
+		remaining.Delete(zone.Name)
+	}
+
+	for _, zone := range failing {
+		if !remaining.Has(zone) {
+			// Already excluded, or not an availability zone of the ASG anymore.
+			continue
+		}
+		if remaining.Len() == 1 {
+			record.Warnf(pool, "AvailabilityZoneExclusionSkipped", "Launches failed %d times for lack of capacity in availability zone %s, which is the last availability zone of the ASG", failures[zone], zone)
+			continue
+		}
+		remaining.Delete(zone)
+		exclusion := expinfrav1.ExcludedAvailabilityZone{
+			Name:       zone,
+			Failures:   failures[zone],
+			ExcludedAt: metav1.NewTime(now),
+			ExpiresAt:  metav1.NewTime(now.Add(handling.GetCooldown())),
+		}
+		pool.Status.ExcludedAvailabilityZones = append(pool.Status.ExcludedAvailabilityZones, exclusion)
+		record.Warnf(pool, "AvailabilityZoneExcluded", "Excluded the subnets of availability zone %s from the ASG until %s, %d launches failed for lack of capacity",
+			zone, exclusion.ExpiresAt.UTC().Format(time.RFC3339), exclusion.Failures)
+	}
+
+	return nil
+}
+
+// capacityFailuresByZone counts the launches of the ASG which failed for lack of capacity since the given time,
+// by availability zone. Activities not naming a single availability zone are ignored.
+func (s *Service) capacityFailuresByZone(name string, since time.Time) (map[string]int32, error) {
+	input := &autoscaling.DescribeScalingActivitiesInput{
+		AutoScalingGroupName: aws.String(name),
+	}
+
+	failures := map[string]int32{}
+	err := s.ASGClient.DescribeScalingActivitiesPagesWithContext(context.TODO(), input, func(out *autoscaling.DescribeScalingActivitiesOutput, _ bool) bool {
+		for _, activity := range out.Activities {
+			// Activities are listed from the most recent one.
+			if aws.TimeValue(activity.StartTime).Before(since) {
+				return false
+			}
+			if aws.StringValue(activity.StatusCode) != autoscaling.ScalingActivityStatusCodeFailed || !isInsufficientCapacity(aws.StringValue(activity.StatusMessage)) {
+				continue
+			}
+			details := scalingActivityDetails{}
+			if err := json.Unmarshal([]byte(aws.StringValue(activity.Details)), &details); err != nil || details.AvailabilityZone == "" {
+				continue
+			}
+			failures[details.AvailabilityZone]++
+		}
+		return true
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to describe scaling activities of AutoScalingGroup: %q", name)
+	}
+	return failures, nil
+}
+
+// isInsufficientCapacity returns whether the status message of a failed launch reports a lack of capacity.
+func isInsufficientCapacity(message string) bool {
+	return strings.Contains(message, "InsufficientInstanceCapacity") || strings.Contains(message, "do not have sufficient")
+}
+
+// subnetAvailabilityZones returns the availability zone of the given subnets, keyed by subnet ID.
+func (s *Service) subnetAvailabilityZones(subnetIDs []string) (map[string]string, error) {
+	zones := map[string]string{}
+	if len(subnetIDs) == 0 {
+		return zones, nil
+	}
+
+	out, err := s.EC2Client.DescribeSubnetsWithContext(context.TODO(), &ec2.DescribeSubnetsInput{
+		SubnetIds: aws.StringSlice(subnetIDs),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to describe subnets of the ASG")
+	}
+	for _, subnet := range out.Subnets {
+		zones[aws.StringValue(subnet.SubnetId)] = aws.StringValue(subnet.AvailabilityZone)
+	}
+	return zones, nil
+}
+
+// withoutExcludedAvailabilityZones drops the subnets of the excluded availability zones. All the subnets are kept
+// if none would be left.
+func (s *Service) withoutExcludedAvailabilityZones(subnetIDs []string, excluded []expinfrav1.ExcludedAvailabilityZone) ([]string, error) {
+	zones, err := s.subnetAvailabilityZones(subnetIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	excludedZones := sets.New[string]()
+	for _, zone := range excluded {
+		excludedZones.Insert(zone.Name)
+	}
+	var kept []string
+	for _, subnetID := range subnetIDs {
+		if !excludedZones.Has(zones[subnetID]) {
+			kept = append(kept, subnetID)
+		}
+	}
+	if len(kept) == 0 {
+		return subnetIDs, nil
+	}
+	return kept, nil
+}
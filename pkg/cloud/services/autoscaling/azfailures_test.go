@@ -0,0 +1,227 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package asg
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/autoscaling/mock_autoscalingiface"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+)
+
+func TestServiceReconcileAZFailures(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	now := time.Now()
+	capacityFailure := func(zone string, age time.Duration) *autoscaling.Activity {
+		return &autoscaling.Activity{
+			StartTime:     aws.Time(now.Add(-age)),
+			StatusCode:    aws.String(autoscaling.ScalingActivityStatusCodeFailed),
+			StatusMessage: aws.String("We currently do not have sufficient m5.large capacity in the Availability Zone you requested (" + zone + "). Launching EC2 instance failed."),
+			Details:       aws.String(`{"Subnet ID":"subnet","Availability Zone":"` + zone + `"}`),
+		}
+	}
+	activities := func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder, activities ...*autoscaling.Activity) {
+		m.DescribeScalingActivitiesPagesWithContext(context.TODO(), gomock.Eq(&autoscaling.DescribeScalingActivitiesInput{AutoScalingGroupName: aws.String("asgName")}), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ *autoscaling.DescribeScalingActivitiesInput, fn func(*autoscaling.DescribeScalingActivitiesOutput, bool) bool, _ ...request.Option) error {
+				fn(&autoscaling.DescribeScalingActivitiesOutput{Activities: activities}, true)
+				return nil
+			})
+	}
+	subnets := func(e *mocks.MockEC2APIMockRecorder) {
+		e.DescribeSubnetsWithContext(context.TODO(), gomock.Eq(&ec2.DescribeSubnetsInput{SubnetIds: aws.StringSlice([]string{"subnet-a", "subnet-b"})})).
+			Return(&ec2.DescribeSubnetsOutput{Subnets: []*ec2.Subnet{
+				{SubnetId: aws.String("subnet-a"), AvailabilityZone: aws.String("us-east-1a")},
+				{SubnetId: aws.String("subnet-b"), AvailabilityZone: aws.String("us-east-1b")},
+			}}, nil)
+	}
+	exclusion := func(zone string, expiresIn time.Duration) expinfrav1.ExcludedAvailabilityZone {
+		return expinfrav1.ExcludedAvailabilityZone{
+			Name:       zone,
+			Failures:   3,
+			ExcludedAt: metav1.NewTime(now.Add(expiresIn - expinfrav1.DefaultAZFailureCooldown)),
+			ExpiresAt:  metav1.NewTime(now.Add(expiresIn)),
+		}
+	}
+
+	tests := []struct {
+		name         string
+		handling     *expinfrav1.AZFailureHandling
+		excluded     []expinfrav1.ExcludedAvailabilityZone
+		expect       func(e *mocks.MockEC2APIMockRecorder, m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder)
+		wantExcluded []string
+		wantErr      bool
+	}{
+		{
+			name:     "should exclude an availability zone after repeated capacity failures",
+			handling: &expinfrav1.AZFailureHandling{Enabled: true},
+			expect: func(e *mocks.MockEC2APIMockRecorder, m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				activities(m,
+					capacityFailure("us-east-1a", time.Minute),
+					capacityFailure("us-east-1b", 2*time.Minute),
+					capacityFailure("us-east-1a", 3*time.Minute),
+					&autoscaling.Activity{StartTime: aws.Time(now.Add(-4 * time.Minute)), StatusCode: aws.String(autoscaling.ScalingActivityStatusCodeSuccessful)},
+					capacityFailure("us-east-1a", 5*time.Minute),
+					// Failures older than the cooldown are not counted.
+					capacityFailure("us-east-1b", time.Hour),
+					capacityFailure("us-east-1b", time.Hour),
+				)
+				subnets(e)
+			},
+			wantExcluded: []string{"us-east-1a"},
+		},
+		{
+			name:     "should not exclude an availability zone with few capacity failures",
+			handling: &expinfrav1.AZFailureHandling{Enabled: true},
+			expect: func(e *mocks.MockEC2APIMockRecorder, m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				activities(m, capacityFailure("us-east-1a", time.Minute), capacityFailure("us-east-1a", 2*time.Minute))
+			},
+		},
+		{
+			name:     "should never exclude the last availability zone",
+			handling: &expinfrav1.AZFailureHandling{Enabled: true},
+			excluded: []expinfrav1.ExcludedAvailabilityZone{exclusion("us-east-1a", 10*time.Minute)},
+			expect: func(e *mocks.MockEC2APIMockRecorder, m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				activities(m, capacityFailure("us-east-1b", time.Minute), capacityFailure("us-east-1b", 2*time.Minute), capacityFailure("us-east-1b", 3*time.Minute))
+				subnets(e)
+			},
+			wantExcluded: []string{"us-east-1a"},
+		},
+		{
+			name:     "should restore an availability zone once its cooldown expired",
+			handling: &expinfrav1.AZFailureHandling{Enabled: true},
+			excluded: []expinfrav1.ExcludedAvailabilityZone{exclusion("us-east-1a", -time.Minute), exclusion("us-east-1b", time.Minute)},
+			expect: func(e *mocks.MockEC2APIMockRecorder, m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				activities(m)
+			},
+			wantExcluded: []string{"us-east-1b"},
+		},
+		{
+			name:     "should restore all availability zones when disabled",
+			handling: &expinfrav1.AZFailureHandling{Enabled: false},
+			excluded: []expinfrav1.ExcludedAvailabilityZone{exclusion("us-east-1a", 10*time.Minute)},
+		},
+		{
+			name:     "should return an error if the scaling activities can't be described",
+			handling: &expinfrav1.AZFailureHandling{Enabled: true},
+			expect: func(e *mocks.MockEC2APIMockRecorder, m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.DescribeScalingActivitiesPagesWithContext(context.TODO(), gomock.Any(), gomock.Any()).
+					Return(awserr.New("AccessDenied", "not authorized", nil))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			fakeClient := getFakeClient()
+
+			clusterScope, err := getClusterScope(fakeClient)
+			g.Expect(err).ToNot(HaveOccurred())
+
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+			asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+			if tt.expect != nil {
+				tt.expect(ec2Mock.EXPECT(), asgMock.EXPECT())
+			}
+			s := NewService(clusterScope)
+			s.ASGClient = asgMock
+			s.EC2Client = ec2Mock
+
+			mps, err := getMachinePoolScope(fakeClient, clusterScope)
+			g.Expect(err).ToNot(HaveOccurred())
+			mps.AWSMachinePool.Name = "asgName"
+			mps.AWSMachinePool.Spec.Subnets = []infrav1.AWSResourceReference{{ID: aws.String("subnet-a")}, {ID: aws.String("subnet-b")}}
+			mps.AWSMachinePool.Spec.AZFailureHandling = tt.handling
+			mps.AWSMachinePool.Status.ExcludedAvailabilityZones = tt.excluded
+
+			err = s.ReconcileAZFailures(mps)
+			checkErr(tt.wantErr, err, g)
+
+			var excluded []string
+			for _, zone := range mps.AWSMachinePool.Status.ExcludedAvailabilityZones {
+				excluded = append(excluded, zone.Name)
+			}
+			g.Expect(excluded).To(Equal(tt.wantExcluded))
+		})
+	}
+}
+
+func TestServiceSubnetIDsWithExcludedAvailabilityZones(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	tests := []struct {
+		name        string
+		excluded    []string
+		wantSubnets []string
+	}{
+		{
+			name:        "should leave out the subnets of the excluded availability zones",
+			excluded:    []string{"us-east-1a"},
+			wantSubnets: []string{"subnet-b"},
+		},
+		{
+			name:        "should keep all subnets if none would be left",
+			excluded:    []string{"us-east-1a", "us-east-1b"},
+			wantSubnets: []string{"subnet-a", "subnet-b"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			fakeClient := getFakeClient()
+
+			clusterScope, err := getClusterScope(fakeClient)
+			g.Expect(err).ToNot(HaveOccurred())
+
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+			ec2Mock.EXPECT().DescribeSubnetsWithContext(context.TODO(), gomock.Eq(&ec2.DescribeSubnetsInput{SubnetIds: aws.StringSlice([]string{"subnet-a", "subnet-b"})})).
+				Return(&ec2.DescribeSubnetsOutput{Subnets: []*ec2.Subnet{
+					{SubnetId: aws.String("subnet-a"), AvailabilityZone: aws.String("us-east-1a")},
+					{SubnetId: aws.String("subnet-b"), AvailabilityZone: aws.String("us-east-1b")},
+				}}, nil)
+			s := NewService(clusterScope)
+			s.EC2Client = ec2Mock
+
+			mps, err := getMachinePoolScope(fakeClient, clusterScope)
+			g.Expect(err).ToNot(HaveOccurred())
+			mps.AWSMachinePool.Spec.Subnets = []infrav1.AWSResourceReference{{ID: aws.String("subnet-a")}, {ID: aws.String("subnet-b")}}
+			for _, zone := range tt.excluded {
+				mps.AWSMachinePool.Status.ExcludedAvailabilityZones = append(mps.AWSMachinePool.Status.ExcludedAvailabilityZones, expinfrav1.ExcludedAvailabilityZone{Name: zone})
+			}
+
+			subnetIDs, err := s.SubnetIDs(mps)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(subnetIDs).To(Equal(tt.wantSubnets))
+		})
+	}
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package asg
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+)
+
+// capacityChangeRegexp matches the change of capacity reported in the cause of a scaling activity, e.g.
+// "increasing the capacity from 1 to 2" or "changing the desired capacity from 2 to 1".
+var capacityChangeRegexp = regexp.MustCompile(`capacity from (\d+) to (\d+)`)
+
+// ReconcileScaleEvents reports the successful scaling activities of the ASG since the LastScaleEvent recorded in
+// the status of the AWSMachinePool as events on the AWSMachinePool and its MachinePool, and records the last one
+// in the status. The activities are not reported the first time, only the last one is recorded.
+func (s *Service) ReconcileScaleEvents(machinePoolScope *scope.MachinePoolScope) error {
+	pool := machinePoolScope.AWSMachinePool
+	last := pool.Status.LastScaleEvent
+
+	events, err := s.scaleEventsSince(machinePoolScope.Name(), last)
+	if err != nil {
+		return err
+	}
+	if len(events) == 0 {
+		return nil
+	}
+
+	objects := []runtime.Object{pool}
+	if machinePoolScope.MachinePool != nil {
+		objects = append(objects, machinePoolScope.MachinePool)
+	}
+	if last != nil {
+		// The events are listed from the most recent one, they are reported in the order they happened.
+		for i := len(events) - 1; i >= 0; i-- {
+			event := events[i]
+			reason, verb := "ASGScaledOut", "out"
+			if event.Direction == expinfrav1.ScaleDirectionIn {
+				reason, verb = "ASGScaledIn", "in"
+			}
+			for _, obj := range objects {
+				record.Eventf(obj, reason, "Auto Scaling group %s scaled %s by %d (cause: %s, activity %s)",
+					machinePoolScope.Name(), verb, abs(event.Delta), event.Cause, event.ActivityID)
+			}
+		}
+	}
+	pool.Status.LastScaleEvent = &events[0]
+
+	return nil
+}
+
+// scaleEventsSince returns the successful scaling activities of the ASG which launched or terminated instances
+// since the given event, from the most recent one. The activities launching or terminating the instances of the
+// same change of capacity share its cause, only the most recent one of them is returned. Only the most recent
+// activity is returned without a given event.
+func (s *Service) scaleEventsSince(name string, last *expinfrav1.ScaleEvent) ([]expinfrav1.ScaleEvent, error) {
+	input := &autoscaling.DescribeScalingActivitiesInput{
+		AutoScalingGroupName: aws.String(name),
+	}
+
+	var events []expinfrav1.ScaleEvent
+	var previousCause string
+	err := s.ASGClient.DescribeScalingActivitiesPagesWithContext(context.TODO(), input, func(out *autoscaling.DescribeScalingActivitiesOutput, _ bool) bool {
+		for _, activity := range out.Activities {
+			if last != nil && (aws.StringValue(activity.ActivityId) == last.ActivityID || aws.TimeValue(activity.StartTime).Before(last.Time.Time)) {
+				return false
+			}
+			if aws.StringValue(activity.StatusCode) != autoscaling.ScalingActivityStatusCodeSuccessful {
+				continue
+			}
+			event, ok := scaleEventFromActivity(activity)
+			if !ok {
+				continue
+			}
+			cause := aws.StringValue(activity.Cause)
+			if len(events) > 0 && cause == previousCause && events[len(events)-1].Direction == event.Direction {
+				continue
+			}
+			previousCause = cause
+			events = append(events, event)
+			if last == nil {
+				return false
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to describe scaling activities of AutoScalingGroup: %q", name)
+	}
+	return events, nil
+}
+
+// scaleEventFromActivity parses the direction, the change of capacity and the cause of a scaling activity. It
+// returns false for the activities which neither launched nor terminated instances.
+func scaleEventFromActivity(activity *autoscaling.Activity) (expinfrav1.ScaleEvent, bool) {
+	event := expinfrav1.ScaleEvent{
+		ActivityID: aws.StringValue(activity.ActivityId),
+		Time:       metav1.NewTime(aws.TimeValue(activity.StartTime)),
+	}
+
+	description := aws.StringValue(activity.Description)
+	switch {
+	case strings.HasPrefix(description, "Launching"):
+		event.Direction = expinfrav1.ScaleDirectionOut
+		event.Delta = 1
+	case strings.HasPrefix(description, "Terminating"):
+		event.Direction = expinfrav1.ScaleDirectionIn
+		event.Delta = -1
+	default:
+		return event, false
+	}
+
+	cause := aws.StringValue(activity.Cause)
+	// The last change of capacity is the one made by the activities, the first one may be the change of the
+	// desired capacity which triggered them.
+	if matches := capacityChangeRegexp.FindAllStringSubmatch(cause, -1); len(matches) > 0 {
+		match := matches[len(matches)-1]
+		from, fromErr := strconv.Atoi(match[1])
+		to, toErr := strconv.Atoi(match[2])
+		if fromErr == nil && toErr == nil && from != to && (to > from) == (event.Direction == expinfrav1.ScaleDirectionOut) {
+			event.Delta = int32(to - from)
+		}
+	}
+	event.Cause = scaleCause(cause)
+
+	return event, true
+}
+
+// scaleCause returns what triggered a scaling activity from its cause.
+func scaleCause(cause string) expinfrav1.ScaleCause {
+	cause = strings.ToLower(cause)
+	switch {
+	case strings.Contains(cause, "instance refresh"):
+		return expinfrav1.ScaleCauseInstanceRefresh
+	case strings.Contains(cause, "health check"), strings.Contains(cause, "unhealthy"):
+		return expinfrav1.ScaleCauseHealthReplacement
+	case strings.Contains(cause, "scheduled action"):
+		return expinfrav1.ScaleCauseScheduledAction
+	case strings.Contains(cause, "policy"):
+		return expinfrav1.ScaleCauseScalingPolicy
+	case strings.Contains(cause, "user request"), strings.Contains(cause, "difference between desired and running capacity"):
+		return expinfrav1.ScaleCauseDesiredCapacity
+	default:
+		return expinfrav1.ScaleCauseUnknown
+	}
+}
+
+func abs(delta int32) int32 {
+	if delta < 0 {
+		return -delta
+	}
+	return delta
+}
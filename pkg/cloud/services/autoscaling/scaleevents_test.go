@@ -0,0 +1,186 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package asg
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/autoscaling/mock_autoscalingiface"
+)
+
+func TestServiceReconcileScaleEvents(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	now := time.Now().Truncate(time.Second)
+	activity := func(id, description, cause string, age time.Duration) *autoscaling.Activity {
+		return &autoscaling.Activity{
+			ActivityId:  aws.String(id),
+			Description: aws.String(description),
+			Cause:       aws.String(cause),
+			StartTime:   aws.Time(now.Add(-age)),
+			StatusCode:  aws.String(autoscaling.ScalingActivityStatusCodeSuccessful),
+		}
+	}
+	policyScaleOut := "At 2024-01-01T00:03:00Z a monitor alarm cpu-high in state ALARM triggered policy scale-out changing the desired capacity from 1 to 3. " +
+		"At 2024-01-01T00:03:10Z an instance was started in response to a difference between desired and running capacity, increasing the capacity from 1 to 3."
+	activities := []*autoscaling.Activity{
+		activity("4", "Launching a new EC2 instance: i-4", policyScaleOut, time.Minute),
+		activity("3", "Launching a new EC2 instance: i-3", policyScaleOut, time.Minute),
+		{ActivityId: aws.String("failed"), Description: aws.String("Launching a new EC2 instance.  Status Reason: no capacity"), StartTime: aws.Time(now.Add(-2 * time.Minute)), StatusCode: aws.String(autoscaling.ScalingActivityStatusCodeFailed)},
+		activity("2", "Terminating EC2 instance: i-2", "At 2024-01-01T00:01:00Z an instance was taken out of service in response to an EC2 health check indicating it has been terminated or stopped.", 3*time.Minute),
+		activity("1", "Launching a new EC2 instance: i-1", "At 2024-01-01T00:00:00Z a user request update of AutoScalingGroup constraints to min: 1, max: 3, desired: 1 changing the desired capacity from 0 to 1.", 4*time.Minute),
+	}
+	expectActivities := func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+		m.DescribeScalingActivitiesPagesWithContext(context.TODO(), gomock.Eq(&autoscaling.DescribeScalingActivitiesInput{AutoScalingGroupName: aws.String("asgName")}), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ *autoscaling.DescribeScalingActivitiesInput, fn func(*autoscaling.DescribeScalingActivitiesOutput, bool) bool, _ ...request.Option) error {
+				fn(&autoscaling.DescribeScalingActivitiesOutput{Activities: activities}, true)
+				return nil
+			})
+	}
+
+	tests := []struct {
+		name    string
+		last    *expinfrav1.ScaleEvent
+		expect  func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder)
+		want    *expinfrav1.ScaleEvent
+		wantErr bool
+	}{
+		{
+			name:   "should only record the most recent activity the first time",
+			expect: expectActivities,
+			want: &expinfrav1.ScaleEvent{
+				ActivityID: "4",
+				Time:       metav1.NewTime(now.Add(-time.Minute)),
+				Direction:  expinfrav1.ScaleDirectionOut,
+				Delta:      2,
+				Cause:      expinfrav1.ScaleCauseScalingPolicy,
+			},
+		},
+		{
+			name:   "should record the most recent activity since the last scale event",
+			last:   &expinfrav1.ScaleEvent{ActivityID: "1", Time: metav1.NewTime(now.Add(-4 * time.Minute))},
+			expect: expectActivities,
+			want: &expinfrav1.ScaleEvent{
+				ActivityID: "4",
+				Time:       metav1.NewTime(now.Add(-time.Minute)),
+				Direction:  expinfrav1.ScaleDirectionOut,
+				Delta:      2,
+				Cause:      expinfrav1.ScaleCauseScalingPolicy,
+			},
+		},
+		{
+			name:   "should keep the last scale event without new activities",
+			last:   &expinfrav1.ScaleEvent{ActivityID: "4", Time: metav1.NewTime(now.Add(-time.Minute))},
+			expect: expectActivities,
+			want:   &expinfrav1.ScaleEvent{ActivityID: "4", Time: metav1.NewTime(now.Add(-time.Minute))},
+		},
+		{
+			name: "should return an error if the scaling activities can't be described",
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.DescribeScalingActivitiesPagesWithContext(context.TODO(), gomock.Any(), gomock.Any()).
+					Return(awserr.New("AccessDenied", "not authorized", nil))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			fakeClient := getFakeClient()
+
+			clusterScope, err := getClusterScope(fakeClient)
+			g.Expect(err).ToNot(HaveOccurred())
+
+			asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+			tt.expect(asgMock.EXPECT())
+			s := NewService(clusterScope)
+			s.ASGClient = asgMock
+
+			mps, err := getMachinePoolScope(fakeClient, clusterScope)
+			g.Expect(err).ToNot(HaveOccurred())
+			mps.AWSMachinePool.Name = "asgName"
+			mps.AWSMachinePool.Status.LastScaleEvent = tt.last
+
+			err = s.ReconcileScaleEvents(mps)
+			checkErr(tt.wantErr, err, g)
+			g.Expect(mps.AWSMachinePool.Status.LastScaleEvent).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestScaleEventsSince(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	now := time.Now().Truncate(time.Second)
+	asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+	asgMock.EXPECT().DescribeScalingActivitiesPagesWithContext(context.TODO(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *autoscaling.DescribeScalingActivitiesInput, fn func(*autoscaling.DescribeScalingActivitiesOutput, bool) bool, _ ...request.Option) error {
+			fn(&autoscaling.DescribeScalingActivitiesOutput{Activities: []*autoscaling.Activity{
+				{
+					ActivityId:  aws.String("4"),
+					Description: aws.String("Terminating EC2 instance: i-1"),
+					Cause:       aws.String("At 2024-01-01T00:03:00Z an instance was taken out of service in response to an instance refresh. At 2024-01-01T00:03:10Z instance i-1 was selected for termination."),
+					StartTime:   aws.Time(now.Add(-time.Minute)),
+					StatusCode:  aws.String(autoscaling.ScalingActivityStatusCodeSuccessful),
+				},
+				{
+					ActivityId:  aws.String("3"),
+					Description: aws.String("Launching a new EC2 instance: i-3"),
+					Cause:       aws.String("At 2024-01-01T00:02:00Z a scheduled action update of AutoScalingGroup constraints to min: 1, max: 3, desired: 3 changing the desired capacity from 2 to 3."),
+					StartTime:   aws.Time(now.Add(-2 * time.Minute)),
+					StatusCode:  aws.String(autoscaling.ScalingActivityStatusCodeSuccessful),
+				},
+				{
+					ActivityId:  aws.String("2"),
+					Description: aws.String("Terminating EC2 instance: i-2"),
+					Cause:       aws.String("At 2024-01-01T00:01:00Z a user request explicitly set group desired capacity changing the desired capacity from 4 to 2. At 2024-01-01T00:01:10Z an instance was taken out of service in response to a difference between desired and running capacity, shrinking the capacity from 4 to 2."),
+					StartTime:   aws.Time(now.Add(-3 * time.Minute)),
+					StatusCode:  aws.String(autoscaling.ScalingActivityStatusCodeSuccessful),
+				},
+				{
+					ActivityId:  aws.String("1"),
+					Description: aws.String("Launching a new EC2 instance: i-0"),
+					StartTime:   aws.Time(now.Add(-time.Hour)),
+					StatusCode:  aws.String(autoscaling.ScalingActivityStatusCodeSuccessful),
+				},
+			}}, true)
+			return nil
+		})
+
+	s := &Service{ASGClient: asgMock}
+	events, err := s.scaleEventsSince("asgName", &expinfrav1.ScaleEvent{ActivityID: "0", Time: metav1.NewTime(now.Add(-10 * time.Minute))})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(events).To(Equal([]expinfrav1.ScaleEvent{
+		{ActivityID: "4", Time: metav1.NewTime(now.Add(-time.Minute)), Direction: expinfrav1.ScaleDirectionIn, Delta: -1, Cause: expinfrav1.ScaleCauseInstanceRefresh},
+		{ActivityID: "3", Time: metav1.NewTime(now.Add(-2 * time.Minute)), Direction: expinfrav1.ScaleDirectionOut, Delta: 1, Cause: expinfrav1.ScaleCauseScheduledAction},
+		{ActivityID: "2", Time: metav1.NewTime(now.Add(-3 * time.Minute)), Direction: expinfrav1.ScaleDirectionIn, Delta: -2, Cause: expinfrav1.ScaleCauseDesiredCapacity},
+	}))
+}
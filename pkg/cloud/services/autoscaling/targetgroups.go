@@ -0,0 +1,116 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package asg
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+)
+
+// targetGroupsBatchSize is the maximum number of target groups attached to or detached from an ASG by one request.
+const targetGroupsBatchSize = 10
+
+// ReconcileTargetGroups attaches the target groups of the spec of the AWSMachinePool to the ASG, and detaches the
+// target groups removed from the spec which were attached by the controller, as recorded in the status. The target
+// groups attached to the ASG by other tooling are kept.
+func (s *Service) ReconcileTargetGroups(machinePoolScope *scope.MachinePoolScope) error {
+	pool := machinePoolScope.AWSMachinePool
+	name := machinePoolScope.Name()
+
+	attached, err := s.attachedTargetGroups(name)
+	if err != nil {
+		return err
+	}
+
+	desired := sets.New[string](pool.Spec.TargetGroupARNs...)
+	// The target groups of the spec which were attached by other tooling before aren't recorded, so that they are
+	// kept when removed from the spec.
+	owned := sets.New[string](pool.Status.TargetGroupARNs...).Intersection(attached.Union(desired))
+	defer func() {
+		pool.Status.TargetGroupARNs = nil
+		if owned.Len() > 0 {
+			pool.Status.TargetGroupARNs = sets.List(owned)
+		}
+	}()
+
+	for _, batch := range targetGroupBatches(sets.List(desired.Difference(attached))) {
+		if _, err := s.ASGClient.AttachLoadBalancerTargetGroupsWithContext(context.TODO(), &autoscaling.AttachLoadBalancerTargetGroupsInput{
+			AutoScalingGroupName: aws.String(name),
+			TargetGroupARNs:      aws.StringSlice(batch),
+		}); err != nil {
+			record.Warnf(pool, "FailedAttachTargetGroups", "Failed to attach target groups %v to the ASG: %v", batch, err)
+			return errors.Wrapf(err, "failed to attach target groups to AutoScalingGroup %q", name)
+		}
+		owned.Insert(batch...)
+		record.Eventf(pool, "TargetGroupsAttached", "Attached target groups %v to the ASG", batch)
+	}
+
+	for _, batch := range targetGroupBatches(sets.List(owned.Difference(desired).Intersection(attached))) {
+		if _, err := s.ASGClient.DetachLoadBalancerTargetGroupsWithContext(context.TODO(), &autoscaling.DetachLoadBalancerTargetGroupsInput{
+			AutoScalingGroupName: aws.String(name),
+			TargetGroupARNs:      aws.StringSlice(batch),
+		}); err != nil {
+			record.Warnf(pool, "FailedDetachTargetGroups", "Failed to detach target groups %v from the ASG: %v", batch, err)
+			return errors.Wrapf(err, "failed to detach target groups from AutoScalingGroup %q", name)
+		}
+		owned.Delete(batch...)
+		record.Eventf(pool, "TargetGroupsDetached", "Detached target groups %v from the ASG", batch)
+	}
+	return nil
+}
+
+// attachedTargetGroups returns the ARNs of the target groups attached to the ASG, leaving out the ones being detached.
+func (s *Service) attachedTargetGroups(name string) (sets.Set[string], error) {
+	attached := sets.New[string]()
+	input := &autoscaling.DescribeLoadBalancerTargetGroupsInput{
+		AutoScalingGroupName: aws.String(name),
+	}
+	err := s.ASGClient.DescribeLoadBalancerTargetGroupsPagesWithContext(context.TODO(), input, func(out *autoscaling.DescribeLoadBalancerTargetGroupsOutput, _ bool) bool {
+		for _, targetGroup := range out.LoadBalancerTargetGroups {
+			switch aws.StringValue(targetGroup.State) {
+			case "Removing", "Removed":
+				continue
+			}
+			attached.Insert(aws.StringValue(targetGroup.LoadBalancerTargetGroupARN))
+		}
+		return true
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to describe target groups of AutoScalingGroup %q", name)
+	}
+	return attached, nil
+}
+
+// targetGroupBatches splits the target group ARNs into batches of at most targetGroupsBatchSize.
+func targetGroupBatches(arns []string) [][]string {
+	var batches [][]string
+	for len(arns) > targetGroupsBatchSize {
+		batches = append(batches, arns[:targetGroupsBatchSize])
+		arns = arns[targetGroupsBatchSize:]
+	}
+	if len(arns) > 0 {
+		batches = append(batches, arns)
+	}
+	return batches
+}
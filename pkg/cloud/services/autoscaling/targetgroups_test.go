@@ -0,0 +1,157 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package asg
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/autoscaling/mock_autoscalingiface"
+)
+
+func TestServiceReconcileTargetGroups(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	describe := func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder, targetGroups map[string]string) {
+		m.DescribeLoadBalancerTargetGroupsPagesWithContext(context.TODO(), gomock.Eq(&autoscaling.DescribeLoadBalancerTargetGroupsInput{AutoScalingGroupName: aws.String("asgName")}), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ *autoscaling.DescribeLoadBalancerTargetGroupsInput, fn func(*autoscaling.DescribeLoadBalancerTargetGroupsOutput, bool) bool, _ ...request.Option) error {
+				out := &autoscaling.DescribeLoadBalancerTargetGroupsOutput{}
+				for arn, state := range targetGroups {
+					out.LoadBalancerTargetGroups = append(out.LoadBalancerTargetGroups, &autoscaling.LoadBalancerTargetGroupState{
+						LoadBalancerTargetGroupARN: aws.String(arn),
+						State:                      aws.String(state),
+					})
+				}
+				fn(out, true)
+				return nil
+			})
+	}
+
+	tests := []struct {
+		name    string
+		spec    []string
+		status  []string
+		expect  func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder)
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "should attach the target groups of the spec",
+			spec: []string{"tg-2", "tg-1"},
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				describe(m, map[string]string{"tg-2": "InService"})
+				m.AttachLoadBalancerTargetGroupsWithContext(context.TODO(), gomock.Eq(&autoscaling.AttachLoadBalancerTargetGroupsInput{
+					AutoScalingGroupName: aws.String("asgName"),
+					TargetGroupARNs:      aws.StringSlice([]string{"tg-1"}),
+				})).Return(&autoscaling.AttachLoadBalancerTargetGroupsOutput{}, nil)
+			},
+			want: []string{"tg-1"},
+		},
+		{
+			name:   "should detach the target groups removed from the spec which were attached by the controller",
+			spec:   []string{"tg-1"},
+			status: []string{"tg-1", "tg-2"},
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				describe(m, map[string]string{"tg-1": "InService", "tg-2": "InService", "external": "InService"})
+				m.DetachLoadBalancerTargetGroupsWithContext(context.TODO(), gomock.Eq(&autoscaling.DetachLoadBalancerTargetGroupsInput{
+					AutoScalingGroupName: aws.String("asgName"),
+					TargetGroupARNs:      aws.StringSlice([]string{"tg-2"}),
+				})).Return(&autoscaling.DetachLoadBalancerTargetGroupsOutput{}, nil)
+			},
+			want: []string{"tg-1"},
+		},
+		{
+			name:   "should attach again a target group being detached",
+			spec:   []string{"tg-1"},
+			status: []string{"tg-1"},
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				describe(m, map[string]string{"tg-1": "Removing"})
+				m.AttachLoadBalancerTargetGroupsWithContext(context.TODO(), gomock.Any()).Return(&autoscaling.AttachLoadBalancerTargetGroupsOutput{}, nil)
+			},
+			want: []string{"tg-1"},
+		},
+		{
+			name:   "should forget the target groups detached by other tooling",
+			status: []string{"tg-1"},
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				describe(m, map[string]string{})
+			},
+		},
+		{
+			name:   "should keep recording a target group which failed to be detached",
+			status: []string{"tg-1"},
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				describe(m, map[string]string{"tg-1": "InService"})
+				m.DetachLoadBalancerTargetGroupsWithContext(context.TODO(), gomock.Any()).Return(nil, awserr.New("AccessDenied", "not authorized", nil))
+			},
+			want:    []string{"tg-1"},
+			wantErr: true,
+		},
+		{
+			name: "should attach the target groups in batches",
+			spec: []string{"tg-00", "tg-01", "tg-02", "tg-03", "tg-04", "tg-05", "tg-06", "tg-07", "tg-08", "tg-09", "tg-10"},
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				describe(m, map[string]string{})
+				m.AttachLoadBalancerTargetGroupsWithContext(context.TODO(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, input *autoscaling.AttachLoadBalancerTargetGroupsInput, _ ...request.Option) (*autoscaling.AttachLoadBalancerTargetGroupsOutput, error) {
+						if len(input.TargetGroupARNs) != 10 {
+							return nil, fmt.Errorf("unexpected batch of %d target groups", len(input.TargetGroupARNs))
+						}
+						return &autoscaling.AttachLoadBalancerTargetGroupsOutput{}, nil
+					})
+				m.AttachLoadBalancerTargetGroupsWithContext(context.TODO(), gomock.Eq(&autoscaling.AttachLoadBalancerTargetGroupsInput{
+					AutoScalingGroupName: aws.String("asgName"),
+					TargetGroupARNs:      aws.StringSlice([]string{"tg-10"}),
+				})).Return(&autoscaling.AttachLoadBalancerTargetGroupsOutput{}, nil)
+			},
+			want: []string{"tg-00", "tg-01", "tg-02", "tg-03", "tg-04", "tg-05", "tg-06", "tg-07", "tg-08", "tg-09", "tg-10"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			fakeClient := getFakeClient()
+
+			clusterScope, err := getClusterScope(fakeClient)
+			g.Expect(err).ToNot(HaveOccurred())
+
+			asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+			tt.expect(asgMock.EXPECT())
+			s := NewService(clusterScope)
+			s.ASGClient = asgMock
+
+			mps, err := getMachinePoolScope(fakeClient, clusterScope)
+			g.Expect(err).ToNot(HaveOccurred())
+			mps.AWSMachinePool.Name = "asgName"
+			mps.AWSMachinePool.Spec.TargetGroupARNs = tt.spec
+			mps.AWSMachinePool.Status.TargetGroupARNs = tt.status
+
+			err = s.ReconcileTargetGroups(mps)
+			checkErr(tt.wantErr, err, g)
+			g.Expect(mps.AWSMachinePool.Status.TargetGroupARNs).To(Equal(tt.want))
+		})
+	}
+}
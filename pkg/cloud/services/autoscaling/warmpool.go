@@ -0,0 +1,103 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package asg
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/pkg/errors"
+
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+)
+
+// ReconcileWarmPool puts the warm pool of the AWSMachinePool spec on an autoscaling group when it is missing or
+// changed, and deletes the warm pool of the autoscaling group once it is removed from the spec.
+func (s *Service) ReconcileWarmPool(name string, warmPool *expinfrav1.WarmPool) error {
+	out, err := s.ASGClient.DescribeWarmPoolWithContext(context.TODO(), &autoscaling.DescribeWarmPoolInput{
+		AutoScalingGroupName: aws.String(name),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to describe warm pool for AutoScalingGroup: %q", name)
+	}
+	current := out.WarmPoolConfiguration
+
+	// A warm pool being deleted can't be updated, it is put again once the deletion completes.
+	if current != nil && aws.StringValue(current.Status) == autoscaling.WarmPoolStatusPendingDelete {
+		if warmPool == nil {
+			return nil
+		}
+		return errors.Errorf("warm pool for AutoScalingGroup %q is being deleted", name)
+	}
+
+	if warmPool == nil {
+		if current == nil {
+			return nil
+		}
+		input := &autoscaling.DeleteWarmPoolInput{AutoScalingGroupName: aws.String(name)}
+		if _, err := s.ASGClient.DeleteWarmPoolWithContext(context.TODO(), input); err != nil {
+			return errors.Wrapf(err, "failed to delete warm pool for AutoScalingGroup: %q", name)
+		}
+		s.scope.Debug("Deleted warm pool", "name", name)
+		return nil
+	}
+
+	input := warmPoolInput(name, warmPool)
+	if current != nil && warmPoolMatches(current, input) {
+		return nil
+	}
+	if _, err := s.ASGClient.PutWarmPoolWithContext(context.TODO(), input); err != nil {
+		return errors.Wrapf(err, "failed to put warm pool for AutoScalingGroup: %q", name)
+	}
+	s.scope.Debug("Put warm pool", "name", name)
+	return nil
+}
+
+func warmPoolInput(name string, warmPool *expinfrav1.WarmPool) *autoscaling.PutWarmPoolInput {
+	input := &autoscaling.PutWarmPoolInput{
+		AutoScalingGroupName: aws.String(name),
+		MinSize:              aws.Int64(int64(warmPool.MinSize)),
+		PoolState:            aws.String(string(warmPool.GetPoolState())),
+		InstanceReusePolicy: &autoscaling.InstanceReusePolicy{
+			ReuseOnScaleIn: aws.Bool(warmPool.ReuseOnScaleIn),
+		},
+	}
+	if warmPool.MaxGroupPreparedCapacity != nil {
+		input.MaxGroupPreparedCapacity = aws.Int64(int64(*warmPool.MaxGroupPreparedCapacity))
+	}
+	return input
+}
+
+// warmPoolMatches returns whether the warm pool of an autoscaling group is the one the input would put.
+func warmPoolMatches(current *autoscaling.WarmPoolConfiguration, input *autoscaling.PutWarmPoolInput) bool {
+	// The Auto Scaling API reports a warm pool sized by the maximum size of the group with -1, or no value.
+	maxPrepared := func(v *int64) int64 {
+		if v == nil {
+			return -1
+		}
+		return *v
+	}
+	reuseOnScaleIn := func(p *autoscaling.InstanceReusePolicy) bool {
+		return p != nil && aws.BoolValue(p.ReuseOnScaleIn)
+	}
+
+	return aws.Int64Value(current.MinSize) == aws.Int64Value(input.MinSize) &&
+		maxPrepared(current.MaxGroupPreparedCapacity) == maxPrepared(input.MaxGroupPreparedCapacity) &&
+		aws.StringValue(current.PoolState) == aws.StringValue(input.PoolState) &&
+		reuseOnScaleIn(current.InstanceReusePolicy) == reuseOnScaleIn(input.InstanceReusePolicy)
+}
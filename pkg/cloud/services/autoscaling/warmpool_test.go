@@ -0,0 +1,148 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package asg
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/autoscaling/mock_autoscalingiface"
+)
+
+func TestServiceReconcileWarmPool(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	describeInput := &autoscaling.DescribeWarmPoolInput{AutoScalingGroupName: aws.String("asgName")}
+	describe := func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder, current *autoscaling.WarmPoolConfiguration) {
+		m.DescribeWarmPoolWithContext(context.TODO(), gomock.Eq(describeInput)).
+			Return(&autoscaling.DescribeWarmPoolOutput{WarmPoolConfiguration: current}, nil)
+	}
+
+	tests := []struct {
+		name     string
+		warmPool *expinfrav1.WarmPool
+		expect   func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder)
+		wantErr  bool
+	}{
+		{
+			name:     "should put a missing warm pool",
+			warmPool: &expinfrav1.WarmPool{MinSize: 2},
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				describe(m, nil)
+				m.PutWarmPoolWithContext(context.TODO(), gomock.Eq(&autoscaling.PutWarmPoolInput{
+					AutoScalingGroupName: aws.String("asgName"),
+					MinSize:              aws.Int64(2),
+					PoolState:            aws.String(autoscaling.WarmPoolStateStopped),
+					InstanceReusePolicy:  &autoscaling.InstanceReusePolicy{ReuseOnScaleIn: aws.Bool(false)},
+				})).Return(&autoscaling.PutWarmPoolOutput{}, nil)
+			},
+		},
+		{
+			name:     "should not put an unchanged warm pool",
+			warmPool: &expinfrav1.WarmPool{MinSize: 2, PoolState: expinfrav1.WarmPoolStateRunning, ReuseOnScaleIn: true},
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				describe(m, &autoscaling.WarmPoolConfiguration{
+					MinSize:                  aws.Int64(2),
+					MaxGroupPreparedCapacity: aws.Int64(-1),
+					PoolState:                aws.String(autoscaling.WarmPoolStateRunning),
+					InstanceReusePolicy:      &autoscaling.InstanceReusePolicy{ReuseOnScaleIn: aws.Bool(true)},
+				})
+			},
+		},
+		{
+			name:     "should put a changed warm pool",
+			warmPool: &expinfrav1.WarmPool{MinSize: 2, MaxGroupPreparedCapacity: ptr.To[int32](5), PoolState: expinfrav1.WarmPoolStateHibernated},
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				describe(m, &autoscaling.WarmPoolConfiguration{
+					MinSize:   aws.Int64(2),
+					PoolState: aws.String(autoscaling.WarmPoolStateStopped),
+				})
+				m.PutWarmPoolWithContext(context.TODO(), gomock.Eq(&autoscaling.PutWarmPoolInput{
+					AutoScalingGroupName:     aws.String("asgName"),
+					MinSize:                  aws.Int64(2),
+					MaxGroupPreparedCapacity: aws.Int64(5),
+					PoolState:                aws.String(autoscaling.WarmPoolStateHibernated),
+					InstanceReusePolicy:      &autoscaling.InstanceReusePolicy{ReuseOnScaleIn: aws.Bool(false)},
+				})).Return(&autoscaling.PutWarmPoolOutput{}, nil)
+			},
+		},
+		{
+			name: "should delete the warm pool once removed from the spec",
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				describe(m, &autoscaling.WarmPoolConfiguration{MinSize: aws.Int64(2)})
+				m.DeleteWarmPoolWithContext(context.TODO(), gomock.Eq(&autoscaling.DeleteWarmPoolInput{
+					AutoScalingGroupName: aws.String("asgName"),
+				})).Return(&autoscaling.DeleteWarmPoolOutput{}, nil)
+			},
+		},
+		{
+			name: "should not delete a warm pool being deleted",
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				describe(m, &autoscaling.WarmPoolConfiguration{Status: aws.String(autoscaling.WarmPoolStatusPendingDelete)})
+			},
+		},
+		{
+			name:     "should return an error while the warm pool is being deleted",
+			warmPool: &expinfrav1.WarmPool{MinSize: 2},
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				describe(m, &autoscaling.WarmPoolConfiguration{Status: aws.String(autoscaling.WarmPoolStatusPendingDelete)})
+			},
+			wantErr: true,
+		},
+		{
+			name: "should do nothing without a warm pool",
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				describe(m, nil)
+			},
+		},
+		{
+			name:     "should return an error if the warm pool can't be put",
+			warmPool: &expinfrav1.WarmPool{MinSize: 2},
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				describe(m, nil)
+				m.PutWarmPoolWithContext(context.TODO(), gomock.Any()).Return(nil, awserr.New("AccessDenied", "not authorized", nil))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			fakeClient := getFakeClient()
+
+			clusterScope, err := getClusterScope(fakeClient)
+			g.Expect(err).ToNot(HaveOccurred())
+
+			asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+			tt.expect(asgMock.EXPECT())
+			s := NewService(clusterScope)
+			s.ASGClient = asgMock
+
+			err = s.ReconcileWarmPool("asgName", tt.warmPool)
+			checkErr(tt.wantErr, err, g)
+		})
+	}
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capacityprobe
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+)
+
+// ProbeCapacity probes whether the given number of on-demand instances can be launched with the launch template of
+// the machine pool, for each of its instance types in each of the given subnets, and records the result in the
+// status. Each probe is a dry run of an instant EC2 Fleet, so that no instance is ever launched: AWS validates the
+// request and may report a lack of capacity, but reserves nothing.
+func (s *Service) ProbeCapacity(pool *expinfrav1.AWSMachinePool, subnetIDs []string, instances int32) error {
+	launchTemplate, err := fleetLaunchTemplate(pool)
+	if err != nil {
+		return err
+	}
+
+	zones, err := s.subnetAvailabilityZones(subnetIDs)
+	if err != nil {
+		return err
+	}
+	subnets := append([]string(nil), subnetIDs...)
+	sort.Slice(subnets, func(i, j int) bool {
+		if zones[subnets[i]] != zones[subnets[j]] {
+			return zones[subnets[i]] < zones[subnets[j]]
+		}
+		return subnets[i] < subnets[j]
+	})
+
+	probe := &expinfrav1.CapacityProbe{
+		Instances: instances,
+		ProbedAt:  metav1.NewTime(s.now()),
+	}
+	feasible := 0
+	for _, subnetID := range subnets {
+		for _, instanceType := range instanceTypes(pool) {
+			result := expinfrav1.CapacityProbeResult{
+				AvailabilityZone: zones[subnetID],
+				SubnetID:         subnetID,
+				InstanceType:     instanceType,
+			}
+			result.Feasible, result.Reason, err = s.dryRun(launchTemplate, subnetID, instanceType, instances)
+			if err != nil {
+				return err
+			}
+			if result.Feasible {
+				feasible++
+			}
+			probe.Results = append(probe.Results, result)
+		}
+	}
+	pool.Status.CapacityProbe = probe
+
+	if feasible == 0 {
+		record.Warnf(pool, "CapacityProbeInfeasible", "None of the %d instance types and availability zones probed can launch %d instances",
+			len(probe.Results), instances)
+		return nil
+	}
+	record.Eventf(pool, "CapacityProbed", "%d of the %d instance types and availability zones probed can launch %d instances",
+		feasible, len(probe.Results), instances)
+	return nil
+}
+
+// dryRun runs a dry run of an instant EC2 Fleet launching the instances in the subnet. It returns whether the dry
+// run succeeded, or the error code and message it failed with. Errors which aren't returned by AWS are returned.
+func (s *Service) dryRun(launchTemplate *ec2.FleetLaunchTemplateSpecificationRequest, subnetID, instanceType string, instances int32) (bool, string, error) {
+	override := &ec2.FleetLaunchTemplateOverridesRequest{
+		SubnetId: aws.String(subnetID),
+	}
+	if instanceType != "" {
+		override.InstanceType = aws.String(instanceType)
+	}
+	input := &ec2.CreateFleetInput{
+		DryRun: aws.Bool(true),
+		Type:   aws.String(ec2.FleetTypeInstant),
+		LaunchTemplateConfigs: []*ec2.FleetLaunchTemplateConfigRequest{{
+			LaunchTemplateSpecification: launchTemplate,
+			Overrides:                   []*ec2.FleetLaunchTemplateOverridesRequest{override},
+		}},
+		TargetCapacitySpecification: &ec2.TargetCapacitySpecificationRequest{
+			TotalTargetCapacity:       aws.Int64(int64(instances)),
+			DefaultTargetCapacityType: aws.String(ec2.DefaultTargetCapacityTypeOnDemand),
+		},
+	}
+
+	_, err := s.EC2Client.CreateFleetWithContext(context.TODO(), input)
+	if err == nil {
+		// A dry run always fails, don't trust a request which may have launched instances.
+		return false, "", errors.New("the dry run of the EC2 Fleet unexpectedly succeeded")
+	}
+	if awserrors.IsDryRunOperation(err) {
+		return true, "", nil
+	}
+	code, ok := awserrors.Code(err)
+	if !ok {
+		return false, "", errors.Wrap(err, "failed to probe capacity")
+	}
+	return false, fmt.Sprintf("%s: %s", code, awserrors.Message(err)), nil
+}
+
+// subnetAvailabilityZones returns the availability zone of each of the subnets.
+func (s *Service) subnetAvailabilityZones(subnetIDs []string) (map[string]string, error) {
+	out, err := s.EC2Client.DescribeSubnetsWithContext(context.TODO(), &ec2.DescribeSubnetsInput{
+		SubnetIds: aws.StringSlice(subnetIDs),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to describe subnets")
+	}
+	zones := make(map[string]string, len(out.Subnets))
+	for _, subnet := range out.Subnets {
+		zones[aws.StringValue(subnet.SubnetId)] = aws.StringValue(subnet.AvailabilityZone)
+	}
+	return zones, nil
+}
+
+// fleetLaunchTemplate returns the launch template the machine pool launches its instances with.
+func fleetLaunchTemplate(pool *expinfrav1.AWSMachinePool) (*ec2.FleetLaunchTemplateSpecificationRequest, error) {
+	if pool.Status.LaunchTemplateID == "" {
+		return nil, errors.New("the launch template of the machine pool doesn't exist yet")
+	}
+	version := ptr.Deref(pool.Status.LaunchTemplateVersion, expinfrav1.LaunchTemplateLatestVersion)
+	if ref := pool.Spec.AWSLaunchTemplate.Ref; ref != nil {
+		version = ref.Version
+	}
+	return &ec2.FleetLaunchTemplateSpecificationRequest{
+		LaunchTemplateId: aws.String(pool.Status.LaunchTemplateID),
+		Version:          aws.String(version),
+	}, nil
+}
+
+// instanceTypes returns the instance types of the machine pool, the instance types of the overrides of its mixed
+// instances policy replacing the one of its launch template. An empty instance type stands for the one of a launch
+// template managed outside of the controller, or of the launch template of overrides with instance requirements.
+func instanceTypes(pool *expinfrav1.AWSMachinePool) []string {
+	if policy := pool.Spec.MixedInstancesPolicy; policy != nil && len(policy.Overrides) > 0 {
+		var types []string
+		seen := map[string]bool{}
+		for _, override := range policy.Overrides {
+			if !seen[override.InstanceType] {
+				seen[override.InstanceType] = true
+				types = append(types, override.InstanceType)
+			}
+		}
+		return types
+	}
+	return []string{pool.Spec.AWSLaunchTemplate.InstanceType}
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capacityprobe
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloudtest"
+)
+
+// recordingEC2Client records the fleets requested through it. Embedding the interface makes any other call, which
+// could launch instances, panic.
+type recordingEC2Client struct {
+	ec2iface.EC2API
+
+	fleetErr func(*ec2.CreateFleetInput) error
+	fleets   []*ec2.CreateFleetInput
+}
+
+func (c *recordingEC2Client) CreateFleetWithContext(_ context.Context, input *ec2.CreateFleetInput, _ ...request.Option) (*ec2.CreateFleetOutput, error) {
+	c.fleets = append(c.fleets, input)
+	return &ec2.CreateFleetOutput{}, c.fleetErr(input)
+}
+
+func (c *recordingEC2Client) DescribeSubnetsWithContext(_ context.Context, input *ec2.DescribeSubnetsInput, _ ...request.Option) (*ec2.DescribeSubnetsOutput, error) {
+	zones := map[string]string{"subnet-a": "us-east-1a", "subnet-b": "us-east-1b"}
+	out := &ec2.DescribeSubnetsOutput{}
+	for _, id := range input.SubnetIds {
+		out.Subnets = append(out.Subnets, &ec2.Subnet{SubnetId: id, AvailabilityZone: aws.String(zones[aws.StringValue(id)])})
+	}
+	return out, nil
+}
+
+func TestProbeCapacity(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	dryRunSucceeded := awserr.New("DryRunOperation", "Request would have succeeded, but DryRun flag is set.", nil)
+	insufficientCapacity := awserr.New("InsufficientInstanceCapacity", "We currently do not have sufficient capacity.", nil)
+
+	newPool := func() *expinfrav1.AWSMachinePool {
+		return &expinfrav1.AWSMachinePool{
+			ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default"},
+			Spec: expinfrav1.AWSMachinePoolSpec{
+				AWSLaunchTemplate: expinfrav1.AWSLaunchTemplate{InstanceType: "m5.large"},
+				MixedInstancesPolicy: &expinfrav1.MixedInstancesPolicy{
+					Overrides: []expinfrav1.Overrides{{InstanceType: "m5.large"}, {InstanceType: "c5.large"}, {InstanceType: "m5.large"}},
+				},
+			},
+			Status: expinfrav1.AWSMachinePoolStatus{
+				LaunchTemplateID:      "lt-1",
+				LaunchTemplateVersion: aws.String("3"),
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		pool     func() *expinfrav1.AWSMachinePool
+		fleetErr func(*ec2.CreateFleetInput) error
+		want     []expinfrav1.CapacityProbeResult
+		wantErr  bool
+	}{
+		{
+			name: "records the feasibility of each instance type in each availability zone",
+			pool: newPool,
+			fleetErr: func(input *ec2.CreateFleetInput) error {
+				override := input.LaunchTemplateConfigs[0].Overrides[0]
+				if aws.StringValue(override.InstanceType) == "c5.large" && aws.StringValue(override.SubnetId) == "subnet-b" {
+					return insufficientCapacity
+				}
+				return dryRunSucceeded
+			},
+			want: []expinfrav1.CapacityProbeResult{
+				{AvailabilityZone: "us-east-1a", SubnetID: "subnet-a", InstanceType: "m5.large", Feasible: true},
+				{AvailabilityZone: "us-east-1a", SubnetID: "subnet-a", InstanceType: "c5.large", Feasible: true},
+				{AvailabilityZone: "us-east-1b", SubnetID: "subnet-b", InstanceType: "m5.large", Feasible: true},
+				{
+					AvailabilityZone: "us-east-1b", SubnetID: "subnet-b", InstanceType: "c5.large",
+					Reason: "InsufficientInstanceCapacity: We currently do not have sufficient capacity.",
+				},
+			},
+		},
+		{
+			name: "probes the instance type of the launch template without a mixed instances policy",
+			pool: func() *expinfrav1.AWSMachinePool {
+				pool := newPool()
+				pool.Spec.MixedInstancesPolicy = nil
+				return pool
+			},
+			fleetErr: func(*ec2.CreateFleetInput) error { return insufficientCapacity },
+			want: []expinfrav1.CapacityProbeResult{
+				{
+					AvailabilityZone: "us-east-1a", SubnetID: "subnet-a", InstanceType: "m5.large",
+					Reason: "InsufficientInstanceCapacity: We currently do not have sufficient capacity.",
+				},
+				{
+					AvailabilityZone: "us-east-1b", SubnetID: "subnet-b", InstanceType: "m5.large",
+					Reason: "InsufficientInstanceCapacity: We currently do not have sufficient capacity.",
+				},
+			},
+		},
+		{
+			name:     "fails on errors not returned by AWS",
+			pool:     newPool,
+			fleetErr: func(*ec2.CreateFleetInput) error { return errors.New("connection reset") },
+			wantErr:  true,
+		},
+		{
+			name:     "fails when the dry run unexpectedly succeeds",
+			pool:     newPool,
+			fleetErr: func(*ec2.CreateFleetInput) error { return nil },
+			wantErr:  true,
+		},
+		{
+			name: "fails without a launch template",
+			pool: func() *expinfrav1.AWSMachinePool {
+				pool := newPool()
+				pool.Status.LaunchTemplateID = ""
+				return pool
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			client := &recordingEC2Client{fleetErr: tt.fleetErr}
+			s := NewService(cloudtest.NewClusterScope(t))
+			s.EC2Client = client
+			s.now = func() time.Time { return now }
+
+			pool := tt.pool()
+			err := s.ProbeCapacity(pool, []string{"subnet-b", "subnet-a"}, 450)
+
+			// No instance may ever be launched by the probe.
+			for _, fleet := range client.fleets {
+				g.Expect(aws.BoolValue(fleet.DryRun)).To(BeTrue())
+				g.Expect(aws.StringValue(fleet.Type)).To(Equal(ec2.FleetTypeInstant))
+				g.Expect(aws.Int64Value(fleet.TargetCapacitySpecification.TotalTargetCapacity)).To(Equal(int64(450)))
+				g.Expect(fleet.LaunchTemplateConfigs[0].LaunchTemplateSpecification).To(Equal(&ec2.FleetLaunchTemplateSpecificationRequest{
+					LaunchTemplateId: aws.String("lt-1"),
+					Version:          aws.String("3"),
+				}))
+			}
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(pool.Status.CapacityProbe).To(BeNil())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(client.fleets).To(HaveLen(len(tt.want)))
+			g.Expect(pool.Status.CapacityProbe).To(Equal(&expinfrav1.CapacityProbe{
+				Instances: 450,
+				ProbedAt:  metav1.NewTime(now),
+				Results:   tt.want,
+			}))
+		})
+	}
+}
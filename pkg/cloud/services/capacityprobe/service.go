@@ -0,0 +1,43 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package capacityprobe probes the capacity available to the instance types and availability zones of a machine
+// pool with dry runs, which never launch instances.
+package capacityprobe
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+)
+
+// Service probes the capacity available to a machine pool.
+type Service struct {
+	scope     scope.EC2Scope
+	EC2Client ec2iface.EC2API
+	now       func() time.Time
+}
+
+// NewService returns a new service given the EC2 scope.
+func NewService(ec2Scope scope.EC2Scope) *Service {
+	return &Service{
+		scope:     ec2Scope,
+		EC2Client: scope.NewEC2Client(ec2Scope, ec2Scope, ec2Scope, ec2Scope.InfraCluster()),
+		now:       time.Now,
+	}
+}
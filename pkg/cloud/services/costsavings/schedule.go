@@ -0,0 +1,193 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package costsavings
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cron"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	expclusterv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// deferredSuspensionRequeue is how long a deferred suspension waits for the machines to be provisioned.
+const deferredSuspensionRequeue = time.Minute
+
+// ReconcileSchedule updates the resources suspended in the status of the cluster from its cost savings schedule,
+// which the network and bastion reconciliations act on. Resources are only suspended while no machine of the
+// cluster is provisioning, as bootstrapping machines may need the NAT gateways. It returns when the schedule must
+// be evaluated again, or zero.
+func (s *Service) ReconcileSchedule(ctx context.Context) (time.Duration, error) {
+	awsCluster := s.scope.AWSCluster
+	now := s.now().UTC()
+
+	scheduled := awsCluster.Spec.CostSavings != nil && awsCluster.Spec.CostSavings.Schedule != nil
+	var desired []infrav1.CostSavingsResource
+	var next time.Time
+	if scheduled {
+		schedule := awsCluster.Spec.CostSavings.Schedule
+		suspend, err := cron.Parse(schedule.Suspend)
+		if err != nil {
+			err = errors.Wrapf(err, "invalid suspend schedule %q", schedule.Suspend)
+			conditions.MarkFalse(awsCluster, infrav1.ResourcesSuspendedCondition, infrav1.CostSavingsScheduleFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+			return 0, err
+		}
+		resume, err := cron.Parse(schedule.Resume)
+		if err != nil {
+			err = errors.Wrapf(err, "invalid resume schedule %q", schedule.Resume)
+			conditions.MarkFalse(awsCluster, infrav1.ResourcesSuspendedCondition, infrav1.CostSavingsScheduleFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+			return 0, err
+		}
+
+		// The resources are suspended when the suspend schedule activated after the resume schedule.
+		if suspend.Prev(now).After(resume.Prev(now)) {
+			desired = schedule.Resources
+		}
+		next = earliest(suspend.Next(now), resume.Next(now))
+	}
+
+	status := awsCluster.Status.CostSavings
+	if status == nil {
+		status = &infrav1.CostSavingsStatus{}
+	}
+	var suspended, resumed, toSuspend []infrav1.CostSavingsResource
+	for _, resource := range status.SuspendedResources {
+		if contains(desired, resource) {
+			suspended = append(suspended, resource)
+		} else {
+			resumed = append(resumed, resource)
+		}
+	}
+	for _, resource := range desired {
+		if !status.Suspended(resource) {
+			toSuspend = append(toSuspend, resource)
+		}
+	}
+
+	if len(resumed) > 0 {
+		status.LastTransitionTime = &metav1.Time{Time: now}
+		record.Eventf(awsCluster, "ResourcesResumed", "Resumed %v suspended by the cost savings schedule", resumed)
+	}
+
+	var provisioning []string
+	if len(toSuspend) > 0 {
+		var err error
+		if provisioning, err = s.provisioningMachines(ctx); err != nil {
+			return 0, err
+		}
+		if len(provisioning) == 0 {
+			suspended = append(suspended, toSuspend...)
+			status.LastTransitionTime = &metav1.Time{Time: now}
+			record.Eventf(awsCluster, "ResourcesSuspended", "Suspended %v on the cost savings schedule", toSuspend)
+		}
+	}
+	sort.Slice(suspended, func(i, j int) bool { return suspended[i] < suspended[j] })
+	status.SuspendedResources = suspended
+
+	status.NextTransitionTime = nil
+	if !next.IsZero() {
+		status.NextTransitionTime = &metav1.Time{Time: next}
+	}
+
+	if !scheduled {
+		// The Elastic IPs of suspended NAT gateways are kept until the NAT gateways are recreated.
+		if len(status.SuspendedResources) == 0 && len(status.NatGatewayAllocations) == 0 {
+			status = nil
+		}
+		awsCluster.Status.CostSavings = status
+		conditions.Delete(awsCluster, infrav1.ResourcesSuspendedCondition)
+		return 0, nil
+	}
+	awsCluster.Status.CostSavings = status
+
+	switch {
+	case len(provisioning) > 0:
+		conditions.MarkFalse(awsCluster, infrav1.ResourcesSuspendedCondition, infrav1.SuspensionDeferredReason, clusterv1.ConditionSeverityInfo,
+			"Suspension of %v deferred while machines %v are provisioning", toSuspend, provisioning)
+		record.Warnf(awsCluster, "SuspensionDeferred", "Deferred the suspension of %v while machines %v are provisioning", toSuspend, provisioning)
+		return deferredSuspensionRequeue, nil
+	case len(suspended) > 0:
+		conditions.MarkTrue(awsCluster, infrav1.ResourcesSuspendedCondition)
+	default:
+		conditions.MarkFalse(awsCluster, infrav1.ResourcesSuspendedCondition, infrav1.ResourcesResumedReason, clusterv1.ConditionSeverityInfo, "")
+	}
+
+	if next.IsZero() {
+		return 0, nil
+	}
+	return next.Sub(now), nil
+}
+
+// provisioningMachines returns the names of the machines and machine pools of the cluster which are provisioning.
+func (s *Service) provisioningMachines(ctx context.Context) ([]string, error) {
+	listOptions := []client.ListOption{
+		client.InNamespace(s.scope.Namespace()),
+		client.MatchingLabels{clusterv1.ClusterNameLabel: s.scope.Cluster.Name},
+	}
+
+	var names []string
+	machines := &clusterv1.MachineList{}
+	if err := s.client.List(ctx, machines, listOptions...); err != nil {
+		return nil, errors.Wrap(err, "failed to list machines")
+	}
+	for _, machine := range machines.Items {
+		switch machine.Status.GetTypedPhase() {
+		case clusterv1.MachinePhasePending, clusterv1.MachinePhaseProvisioning, clusterv1.MachinePhaseProvisioned:
+			names = append(names, machine.Name)
+		}
+	}
+
+	machinePools := &expclusterv1.MachinePoolList{}
+	if err := s.client.List(ctx, machinePools, listOptions...); err != nil {
+		return nil, errors.Wrap(err, "failed to list machine pools")
+	}
+	for _, machinePool := range machinePools.Items {
+		switch machinePool.Status.GetTypedPhase() {
+		case expclusterv1.MachinePoolPhasePending, expclusterv1.MachinePoolPhaseProvisioning, expclusterv1.MachinePoolPhaseProvisioned,
+			expclusterv1.MachinePoolPhaseScalingUp:
+			names = append(names, machinePool.Name)
+		}
+	}
+
+	sort.Strings(names)
+	return names, nil
+}
+
+func earliest(a, b time.Time) time.Time {
+	if a.IsZero() || !b.IsZero() && b.Before(a) {
+		return b
+	}
+	return a
+}
+
+func contains(resources []infrav1.CostSavingsResource, resource infrav1.CostSavingsResource) bool {
+	for _, r := range resources {
+		if r == resource {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package costsavings
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloudtest"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestReconcileSchedule(t *testing.T) {
+	// Wednesday.
+	day := time.Date(2024, 1, 3, 12, 0, 0, 0, time.UTC)
+	night := time.Date(2024, 1, 3, 21, 0, 0, 0, time.UTC)
+	schedule := &infrav1.CostSavings{
+		Schedule: &infrav1.CostSavingsSchedule{
+			Suspend:   "0 20 * * 1-5",
+			Resume:    "0 7 * * 1-5",
+			Resources: []infrav1.CostSavingsResource{infrav1.CostSavingsResourceNatGateways, infrav1.CostSavingsResourceBastion},
+		},
+	}
+	provisioningMachine := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "machine-1",
+			Namespace: "default",
+			Labels:    map[string]string{clusterv1.ClusterNameLabel: "test-cluster"},
+		},
+		Status: clusterv1.MachineStatus{Phase: string(clusterv1.MachinePhaseProvisioning)},
+	}
+	runningMachine := provisioningMachine.DeepCopy()
+	runningMachine.Status.Phase = string(clusterv1.MachinePhaseRunning)
+
+	tests := []struct {
+		name          string
+		now           time.Time
+		spec          *infrav1.CostSavings
+		status        *infrav1.CostSavingsStatus
+		objects       []client.Object
+		wantStatus    *infrav1.CostSavingsStatus
+		wantCondition *clusterv1.Condition
+		wantRequeue   time.Duration
+	}{
+		{
+			name:    "suspends the resources at night",
+			now:     night,
+			spec:    schedule,
+			objects: []client.Object{runningMachine},
+			wantStatus: &infrav1.CostSavingsStatus{
+				SuspendedResources: []infrav1.CostSavingsResource{infrav1.CostSavingsResourceBastion, infrav1.CostSavingsResourceNatGateways},
+				LastTransitionTime: &metav1.Time{Time: night},
+				NextTransitionTime: &metav1.Time{Time: time.Date(2024, 1, 4, 7, 0, 0, 0, time.UTC)},
+			},
+			wantCondition: conditions.TrueCondition(infrav1.ResourcesSuspendedCondition),
+			wantRequeue:   10 * time.Hour,
+		},
+		{
+			name:    "defers the suspension while machines are provisioning",
+			now:     night,
+			spec:    schedule,
+			objects: []client.Object{provisioningMachine},
+			wantStatus: &infrav1.CostSavingsStatus{
+				NextTransitionTime: &metav1.Time{Time: time.Date(2024, 1, 4, 7, 0, 0, 0, time.UTC)},
+			},
+			wantCondition: conditions.FalseCondition(infrav1.ResourcesSuspendedCondition, infrav1.SuspensionDeferredReason, clusterv1.ConditionSeverityInfo,
+				"Suspension of [natGateways bastion] deferred while machines [machine-1] are provisioning"),
+			wantRequeue: time.Minute,
+		},
+		{
+			name: "resumes the resources during the day, even while machines are provisioning",
+			now:  day,
+			spec: schedule,
+			status: &infrav1.CostSavingsStatus{
+				SuspendedResources: []infrav1.CostSavingsResource{infrav1.CostSavingsResourceBastion, infrav1.CostSavingsResourceNatGateways},
+			},
+			objects: []client.Object{provisioningMachine},
+			wantStatus: &infrav1.CostSavingsStatus{
+				LastTransitionTime: &metav1.Time{Time: day},
+				NextTransitionTime: &metav1.Time{Time: time.Date(2024, 1, 3, 20, 0, 0, 0, time.UTC)},
+			},
+			wantCondition: conditions.FalseCondition(infrav1.ResourcesSuspendedCondition, infrav1.ResourcesResumedReason, clusterv1.ConditionSeverityInfo, ""),
+			wantRequeue:   8 * time.Hour,
+		},
+		{
+			name: "resumes the resources when the schedule is removed, keeping the Elastic IPs of the NAT gateways",
+			now:  night,
+			status: &infrav1.CostSavingsStatus{
+				SuspendedResources:    []infrav1.CostSavingsResource{infrav1.CostSavingsResourceNatGateways},
+				NatGatewayAllocations: map[string]string{"subnet-1": "eipalloc-1"},
+			},
+			wantStatus: &infrav1.CostSavingsStatus{
+				LastTransitionTime:    &metav1.Time{Time: night},
+				NatGatewayAllocations: map[string]string{"subnet-1": "eipalloc-1"},
+			},
+		},
+		{
+			name: "does nothing without a schedule",
+			now:  night,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			clusterScope, c := cloudtest.NewClusterScopeWithClient(t, tt.objects...)
+			clusterScope.AWSCluster.Spec.CostSavings = tt.spec
+			clusterScope.AWSCluster.Status.CostSavings = tt.status
+			s := NewService(clusterScope, c)
+			s.now = func() time.Time { return tt.now }
+
+			requeue, err := s.ReconcileSchedule(context.TODO())
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(requeue).To(Equal(tt.wantRequeue))
+			g.Expect(clusterScope.AWSCluster.Status.CostSavings).To(Equal(tt.wantStatus))
+
+			condition := conditions.Get(clusterScope.AWSCluster, infrav1.ResourcesSuspendedCondition)
+			if tt.wantCondition == nil {
+				g.Expect(condition).To(BeNil())
+				return
+			}
+			g.Expect(condition).NotTo(BeNil())
+			g.Expect(condition.Status).To(Equal(tt.wantCondition.Status))
+			g.Expect(condition.Reason).To(Equal(tt.wantCondition.Reason))
+			g.Expect(condition.Message).To(Equal(tt.wantCondition.Message))
+		})
+	}
+}
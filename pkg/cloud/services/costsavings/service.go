@@ -0,0 +1,42 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package costsavings suspends and resumes resources of a cluster on the schedule of its cost savings configuration.
+package costsavings
+
+import (
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+)
+
+// Service decides which resources of a cluster are suspended.
+type Service struct {
+	scope  *scope.ClusterScope
+	client client.Reader
+	now    func() time.Time
+}
+
+// NewService returns a new service given the cluster scope, and a client to list the machines of the cluster.
+func NewService(clusterScope *scope.ClusterScope, client client.Reader) *Service {
+	return &Service{
+		scope:  clusterScope,
+		client: client,
+		now:    time.Now,
+	}
+}
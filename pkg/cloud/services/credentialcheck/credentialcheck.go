@@ -0,0 +1,196 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package credentialcheck validates the credentials of the controller itself, and reports
+// them through a readiness check of the manager.
+package credentialcheck
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
+)
+
+const (
+	// DefaultCheckInterval is the interval after which the credentials of the controller are validated again.
+	DefaultCheckInterval = time.Minute
+
+	// DefaultCheckTimeout is the time a validation of the credentials of a principal waits for STS.
+	DefaultCheckTimeout = 5 * time.Second
+
+	// DefaultFailureThreshold is the number of consecutive failed validations after which the
+	// controller is reported as not ready.
+	DefaultFailureThreshold = 3
+
+	// DefaultPrincipal identifies the credentials of the default credential chain in the metrics.
+	DefaultPrincipal = "default"
+
+	// defaultRegion is the region of the STS endpoint when none is configured for the controller.
+	defaultRegion = "us-east-1"
+)
+
+var (
+	credentialsValid = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: "aws",
+		Name:      "controller_credentials_valid",
+		Help:      "Whether the last validation of the credentials of the controller for a principal succeeded (1) or failed (0)",
+	}, []string{"principal"})
+	credentialsCheckFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: "aws",
+		Name:      "controller_credentials_check_failures_total",
+		Help:      "Total number of failed validations of the credentials of the controller for a principal",
+	}, []string{"principal"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(credentialsValid, credentialsCheckFailures)
+}
+
+// principal is a set of credentials of the controller, validated with STS GetCallerIdentity.
+type principal struct {
+	name   string
+	client stsiface.STSAPI
+}
+
+// Checker periodically validates the credentials of the default credential chain of the
+// controller and of the roles it assumes on its own behalf. It implements a manager runnable
+// running the validations and a healthz checker failing once a principal failed more than
+// the failure threshold of consecutive validations.
+type Checker struct {
+	principals       []principal
+	interval         time.Duration
+	timeout          time.Duration
+	failureThreshold int
+	log              logger.Wrapper
+
+	mu       sync.Mutex
+	failures map[string]int
+	lastErr  map[string]error
+}
+
+// NewChecker returns a checker validating the default credential chain, and the given roles
+// assumed with it, once per interval.
+func NewChecker(roleARNs []string, interval, timeout time.Duration, failureThreshold int, log logger.Wrapper) (*Checker, error) {
+	sess, err := session.NewSession()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create session for the default credential chain")
+	}
+	if aws.StringValue(sess.Config.Region) == "" {
+		sess.Config.Region = aws.String(defaultRegion)
+	}
+
+	principals := []principal{{name: DefaultPrincipal, client: sts.New(sess)}}
+	for _, roleARN := range roleARNs {
+		principals = append(principals, principal{
+			name:   roleARN,
+			client: sts.New(sess, &aws.Config{Credentials: stscreds.NewCredentials(sess, roleARN)}),
+		})
+	}
+	return newChecker(principals, interval, timeout, failureThreshold, log), nil
+}
+
+func newChecker(principals []principal, interval, timeout time.Duration, failureThreshold int, log logger.Wrapper) *Checker {
+	return &Checker{
+		principals:       principals,
+		interval:         interval,
+		timeout:          timeout,
+		failureThreshold: failureThreshold,
+		log:              log,
+		failures:         map[string]int{},
+		lastErr:          map[string]error{},
+	}
+}
+
+// Start validates the credentials once per interval until the context is done.
+func (c *Checker) Start(ctx context.Context) error {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		c.checkAll(ctx)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// NeedLeaderElection returns false, as each replica of the controller validates its own credentials.
+func (c *Checker) NeedLeaderElection() bool {
+	return false
+}
+
+// Check fails once the credentials of a principal failed more than the failure threshold of
+// consecutive validations.
+func (c *Checker) Check(_ *http.Request) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, p := range c.principals {
+		if c.failures[p.name] > c.failureThreshold {
+			return errors.Wrapf(c.lastErr[p.name], "credentials of principal %q failed %d consecutive validations", p.name, c.failures[p.name])
+		}
+	}
+	return nil
+}
+
+func (c *Checker) checkAll(ctx context.Context) {
+	for _, p := range c.principals {
+		c.record(p.name, c.check(ctx, p))
+	}
+}
+
+func (c *Checker) check(ctx context.Context, p principal) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	_, err := p.client.GetCallerIdentityWithContext(ctx, &sts.GetCallerIdentityInput{})
+	return err
+}
+
+func (c *Checker) record(name string, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err == nil {
+		if c.failures[name] > 0 {
+			c.log.Info("Credentials of the controller are valid again", "principal", name)
+		}
+		c.failures[name] = 0
+		delete(c.lastErr, name)
+		credentialsValid.WithLabelValues(name).Set(1)
+		return
+	}
+
+	c.failures[name]++
+	c.lastErr[name] = err
+	c.log.Error(err, "Failed to validate the credentials of the controller", "principal", name, "consecutiveFailures", c.failures[name])
+	credentialsValid.WithLabelValues(name).Set(0)
+	credentialsCheckFailures.WithLabelValues(name).Inc()
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentialcheck
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/sts/mock_stsiface"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
+)
+
+const roleARN = "arn:aws:iam::111122223333:role/capa"
+
+func TestCheckerCheck(t *testing.T) {
+	expiredErr := awserr.New("ExpiredToken", "The security token included in the request is expired", nil)
+
+	tests := []struct {
+		name         string
+		defaultErrs  []error
+		roleErrs     []error
+		expectReady  bool
+		expectValid  float64
+		expectFailed float64
+	}{
+		{
+			name:        "should be ready when the credentials are valid",
+			defaultErrs: []error{nil, nil, nil},
+			roleErrs:    []error{nil, nil, nil},
+			expectReady: true,
+			expectValid: 1,
+		},
+		{
+			name:         "should stay ready while the failures don't exceed the threshold",
+			defaultErrs:  []error{expiredErr, expiredErr},
+			roleErrs:     []error{nil, nil},
+			expectReady:  true,
+			expectValid:  0,
+			expectFailed: 2,
+		},
+		{
+			name:         "should not be ready once the failures of the default credentials exceed the threshold",
+			defaultErrs:  []error{expiredErr, expiredErr, expiredErr},
+			roleErrs:     []error{nil, nil, nil},
+			expectReady:  false,
+			expectValid:  0,
+			expectFailed: 3,
+		},
+		{
+			name:        "should not be ready once the failures of a role exceed the threshold",
+			defaultErrs: []error{nil, nil, nil},
+			roleErrs:    []error{expiredErr, expiredErr, expiredErr},
+			expectReady: false,
+			expectValid: 1,
+		},
+		{
+			name:         "should be ready again after a successful validation",
+			defaultErrs:  []error{expiredErr, expiredErr, expiredErr, nil},
+			roleErrs:     []error{nil, nil, nil, nil},
+			expectReady:  true,
+			expectValid:  1,
+			expectFailed: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defaultClient := mock_stsiface.NewMockSTSAPI(mockCtrl)
+			roleClient := mock_stsiface.NewMockSTSAPI(mockCtrl)
+
+			for _, err := range tt.defaultErrs {
+				defaultClient.EXPECT().GetCallerIdentityWithContext(gomock.Any(), gomock.Any()).Return(&sts.GetCallerIdentityOutput{}, err)
+			}
+			for _, err := range tt.roleErrs {
+				roleClient.EXPECT().GetCallerIdentityWithContext(gomock.Any(), gomock.Any()).Return(&sts.GetCallerIdentityOutput{}, err)
+			}
+
+			credentialsValid.Reset()
+			credentialsCheckFailures.Reset()
+			c := newChecker([]principal{
+				{name: DefaultPrincipal, client: defaultClient},
+				{name: roleARN, client: roleClient},
+			}, time.Minute, time.Second, 2, logger.NewLogger(ctrl.Log))
+
+			for range tt.defaultErrs {
+				c.checkAll(context.Background())
+			}
+
+			if tt.expectReady {
+				g.Expect(c.Check(nil)).To(Succeed())
+			} else {
+				g.Expect(c.Check(nil)).To(MatchError(ContainSubstring("consecutive validations")))
+			}
+			g.Expect(testutil.ToFloat64(credentialsValid.WithLabelValues(DefaultPrincipal))).To(Equal(tt.expectValid))
+			g.Expect(testutil.ToFloat64(credentialsCheckFailures.WithLabelValues(DefaultPrincipal))).To(Equal(tt.expectFailed))
+		})
+	}
+}
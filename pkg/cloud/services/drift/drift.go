@@ -0,0 +1,268 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package drift
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+const (
+	// AttributeInstanceType compares the instance type, which can be changed while the instance is stopped.
+	AttributeInstanceType = "instanceType"
+	// AttributeIAMInstanceProfile compares the name of the IAM instance profile associated with the instance.
+	AttributeIAMInstanceProfile = "iamInstanceProfile"
+	// AttributeSecurityGroups compares the security groups attached to the primary network interface.
+	AttributeSecurityGroups = "securityGroups"
+
+	// DefaultAuditInterval is the interval after which the instances of an object are audited again.
+	DefaultAuditInterval = 30 * time.Minute
+
+	// maxReportedDifferences caps the differences listed in the condition message, so that the
+	// message stays readable for large machine pools.
+	maxReportedDifferences = 10
+)
+
+// Attributes lists the instance attributes the auditor can compare.
+var Attributes = []string{AttributeInstanceType, AttributeIAMInstanceProfile, AttributeSecurityGroups}
+
+var driftDetectedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Subsystem: "aws",
+	Name:      "instance_drift_detected_total",
+	Help:      "Total number of instance attributes found by the drift audit to differ from the values applied by the controller",
+}, []string{"kind", "attribute"})
+
+func init() {
+	metrics.Registry.MustRegister(driftDetectedTotal)
+}
+
+// Auditor holds the attributes to compare and the time of the last audit of each object, so the
+// instances of an object are only described once per interval.
+type Auditor struct {
+	attributes map[string]bool
+	interval   time.Duration
+	now        func() time.Time
+
+	mu        sync.Mutex
+	auditedAt map[string]time.Time
+}
+
+// NewAuditor returns an auditor comparing the given attributes once per interval.
+func NewAuditor(attributes []string, interval time.Duration) (*Auditor, error) {
+	a := &Auditor{
+		attributes: map[string]bool{},
+		interval:   interval,
+		now:        time.Now,
+		auditedAt:  map[string]time.Time{},
+	}
+	for _, attribute := range attributes {
+		if !isKnownAttribute(attribute) {
+			return nil, errors.Errorf("unknown instance drift attribute %q, must be one of %s", attribute, strings.Join(Attributes, ", "))
+		}
+		a.attributes[attribute] = true
+	}
+	return a, nil
+}
+
+func isKnownAttribute(attribute string) bool {
+	for _, known := range Attributes {
+		if attribute == known {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *Auditor) due(key string) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	auditedAt, ok := a.auditedAt[key]
+	return !ok || a.now().Sub(auditedAt) >= a.interval
+}
+
+func (a *Auditor) store(key string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.auditedAt[key] = a.now()
+}
+
+// Expected holds the attribute values the controller applied to the instances. Empty values are not compared.
+type Expected struct {
+	InstanceType       string
+	IAMInstanceProfile string
+	SecurityGroupIDs   []string
+}
+
+// Difference is an attribute of an instance whose live value differs from the expected one.
+type Difference struct {
+	InstanceID string
+	Attribute  string
+	Expected   string
+	Actual     string
+}
+
+func (d Difference) String() string {
+	return fmt.Sprintf("%s %s: expected %q, found %q", d.InstanceID, d.Attribute, d.Expected, d.Actual)
+}
+
+// ReconcileInstanceDrift compares the live attributes of the instances of the object with the expected values
+// once per interval of the auditor, and publishes the differences with the DriftDetectedCondition. Nothing is
+// corrected. The expected values are only computed when an audit is due, as they may require API calls.
+func (s *Service) ReconcileInstanceDrift(obj conditions.Setter, instanceIDs []string, expected func() (*Expected, error)) error {
+	kind := reflect.Indirect(reflect.ValueOf(obj)).Type().Name()
+	key := kind + "/" + obj.GetNamespace() + "/" + obj.GetName()
+	if len(instanceIDs) == 0 || !s.auditor.due(key) {
+		return nil
+	}
+
+	want, err := expected()
+	if err != nil {
+		return errors.Wrap(err, "failed to get the expected instance attributes")
+	}
+
+	instances, err := s.describeInstances(instanceIDs)
+	if err != nil {
+		if awserrors.IsNotFound(err) {
+			// An instance was terminated since it was listed, it is audited again on the next reconcile.
+			s.scope.Debug("Skipping instance drift audit as an instance wasn't found", "error", err)
+			return nil
+		}
+		return errors.Wrap(err, "failed to describe instances")
+	}
+
+	var diffs []Difference
+	for _, instance := range instances {
+		diffs = append(diffs, s.auditor.compare(want, instance)...)
+	}
+	s.auditor.store(key)
+
+	if len(diffs) == 0 {
+		if conditions.Has(obj, infrav1.DriftDetectedCondition) {
+			conditions.Delete(obj, infrav1.DriftDetectedCondition)
+			record.Eventf(obj, "InstanceDriftResolved", "No instance drift detected anymore")
+		}
+		return nil
+	}
+
+	for _, diff := range diffs {
+		driftDetectedTotal.WithLabelValues(kind, diff.Attribute).Inc()
+	}
+
+	messages := []string{}
+	for i, diff := range diffs {
+		if i == maxReportedDifferences {
+			messages = append(messages, fmt.Sprintf("and %d more", len(diffs)-maxReportedDifferences))
+			break
+		}
+		messages = append(messages, diff.String())
+	}
+	message := strings.Join(messages, "; ")
+
+	if conditions.GetMessage(obj, infrav1.DriftDetectedCondition) != message {
+		record.Warnf(obj, "InstanceDriftDetected", "Instance attributes changed outside of the controller: %s", message)
+	}
+	conditions.MarkTrueWithNegativePolarity(obj, infrav1.DriftDetectedCondition, infrav1.InstanceDriftDetectedReason, clusterv1.ConditionSeverityWarning, "%s", message)
+	return nil
+}
+
+func (s *Service) describeInstances(instanceIDs []string) ([]*ec2.Instance, error) {
+	input := &ec2.DescribeInstancesInput{
+		InstanceIds: aws.StringSlice(instanceIDs),
+	}
+
+	var instances []*ec2.Instance
+	err := s.EC2Client.DescribeInstancesPagesWithContext(context.TODO(), input, func(out *ec2.DescribeInstancesOutput, _ bool) bool {
+		for _, reservation := range out.Reservations {
+			instances = append(instances, reservation.Instances...)
+		}
+		return true
+	})
+	return instances, err
+}
+
+// compare returns the enabled attributes of the instance which differ from the expected values.
+// Instances which are shutting down or terminated are not compared.
+func (a *Auditor) compare(want *Expected, instance *ec2.Instance) []Difference {
+	if instance.State != nil {
+		switch aws.StringValue(instance.State.Name) {
+		case ec2.InstanceStateNameShuttingDown, ec2.InstanceStateNameTerminated:
+			return nil
+		}
+	}
+
+	id := aws.StringValue(instance.InstanceId)
+	var diffs []Difference
+
+	if a.attributes[AttributeInstanceType] && want.InstanceType != "" {
+		if actual := aws.StringValue(instance.InstanceType); actual != want.InstanceType {
+			diffs = append(diffs, Difference{InstanceID: id, Attribute: AttributeInstanceType, Expected: want.InstanceType, Actual: actual})
+		}
+	}
+
+	if a.attributes[AttributeIAMInstanceProfile] && want.IAMInstanceProfile != "" {
+		actual := ""
+		if instance.IamInstanceProfile != nil {
+			actual = instanceProfileName(aws.StringValue(instance.IamInstanceProfile.Arn))
+		}
+		if actual != want.IAMInstanceProfile {
+			diffs = append(diffs, Difference{InstanceID: id, Attribute: AttributeIAMInstanceProfile, Expected: want.IAMInstanceProfile, Actual: actual})
+		}
+	}
+
+	if a.attributes[AttributeSecurityGroups] && len(want.SecurityGroupIDs) > 0 {
+		actual := []string{}
+		for _, group := range instance.SecurityGroups {
+			actual = append(actual, aws.StringValue(group.GroupId))
+		}
+		expected := append([]string{}, want.SecurityGroupIDs...)
+		sort.Strings(actual)
+		sort.Strings(expected)
+		if strings.Join(actual, ",") != strings.Join(expected, ",") {
+			diffs = append(diffs, Difference{InstanceID: id, Attribute: AttributeSecurityGroups, Expected: strings.Join(expected, ","), Actual: strings.Join(actual, ",")})
+		}
+	}
+
+	return diffs
+}
+
+// instanceProfileName extracts the name of an instance profile from its ARN.
+func instanceProfileName(arn string) string {
+	split := strings.Split(arn, "instance-profile/")
+	if len(split) > 1 {
+		return split[1]
+	}
+	return arn
+}
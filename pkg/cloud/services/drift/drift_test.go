@@ -0,0 +1,130 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package drift
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloudtest"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestNewAuditor(t *testing.T) {
+	g := NewWithT(t)
+
+	_, err := NewAuditor(Attributes, DefaultAuditInterval)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	_, err = NewAuditor([]string{AttributeInstanceType, "userData"}, DefaultAuditInterval)
+	g.Expect(err).To(MatchError(ContainSubstring(`unknown instance drift attribute "userData"`)))
+}
+
+func TestReconcileInstanceDrift(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+	live := &ec2.Instance{
+		InstanceId:         aws.String("i-1"),
+		InstanceType:       aws.String("m5.large"),
+		IamInstanceProfile: &ec2.IamInstanceProfile{Arn: aws.String("arn:aws:iam::123456789012:instance-profile/nodes")},
+		SecurityGroups:     []*ec2.GroupIdentifier{{GroupId: aws.String("sg-node")}, {GroupId: aws.String("sg-extra")}},
+		State:              &ec2.InstanceState{Name: aws.String(ec2.InstanceStateNameRunning)},
+	}
+	describes := 0
+	ec2Mock.EXPECT().DescribeInstancesPagesWithContext(context.TODO(), &ec2.DescribeInstancesInput{InstanceIds: aws.StringSlice([]string{"i-1"})}, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *ec2.DescribeInstancesInput, fn func(*ec2.DescribeInstancesOutput, bool) bool, _ ...request.Option) error {
+			describes++
+			fn(&ec2.DescribeInstancesOutput{Reservations: []*ec2.Reservation{{Instances: []*ec2.Instance{live}}}}, true)
+			return nil
+		}).AnyTimes()
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	auditor, err := NewAuditor([]string{AttributeInstanceType, AttributeSecurityGroups}, DefaultAuditInterval)
+	g.Expect(err).NotTo(HaveOccurred())
+	auditor.now = func() time.Time { return now }
+
+	s := NewService(cloudtest.NewClusterScope(t), auditor)
+	s.EC2Client = ec2Mock
+
+	machine := &infrav1.AWSMachine{ObjectMeta: metav1.ObjectMeta{Name: "machine", Namespace: "default"}}
+	expected := func() (*Expected, error) {
+		return &Expected{
+			InstanceType:       "m5.large",
+			IAMInstanceProfile: "control-plane",
+			SecurityGroupIDs:   []string{"sg-extra", "sg-node"},
+		}, nil
+	}
+
+	// The instance profile isn't audited, and the other attributes match.
+	g.Expect(s.ReconcileInstanceDrift(machine, []string{"i-1"}, expected)).To(Succeed())
+	g.Expect(describes).To(Equal(1))
+	g.Expect(conditions.Has(machine, infrav1.DriftDetectedCondition)).To(BeFalse())
+
+	// Changes aren't detected before the interval elapsed.
+	live.InstanceType = aws.String("m5.xlarge")
+	live.SecurityGroups = []*ec2.GroupIdentifier{{GroupId: aws.String("sg-open")}}
+	g.Expect(s.ReconcileInstanceDrift(machine, []string{"i-1"}, expected)).To(Succeed())
+	g.Expect(describes).To(Equal(1))
+
+	now = now.Add(DefaultAuditInterval)
+	g.Expect(s.ReconcileInstanceDrift(machine, []string{"i-1"}, expected)).To(Succeed())
+	g.Expect(describes).To(Equal(2))
+	g.Expect(conditions.IsTrue(machine, infrav1.DriftDetectedCondition)).To(BeTrue())
+	g.Expect(conditions.GetReason(machine, infrav1.DriftDetectedCondition)).To(Equal(infrav1.InstanceDriftDetectedReason))
+	g.Expect(conditions.GetMessage(machine, infrav1.DriftDetectedCondition)).To(Equal(
+		`i-1 instanceType: expected "m5.large", found "m5.xlarge"; i-1 securityGroups: expected "sg-extra,sg-node", found "sg-open"`))
+
+	// The condition is removed once the instance matches again.
+	live.InstanceType = aws.String("m5.large")
+	live.SecurityGroups = []*ec2.GroupIdentifier{{GroupId: aws.String("sg-node")}, {GroupId: aws.String("sg-extra")}}
+	now = now.Add(DefaultAuditInterval)
+	g.Expect(s.ReconcileInstanceDrift(machine, []string{"i-1"}, expected)).To(Succeed())
+	g.Expect(describes).To(Equal(3))
+	g.Expect(conditions.Has(machine, infrav1.DriftDetectedCondition)).To(BeFalse())
+}
+
+func TestCompareSkipsTerminatedInstances(t *testing.T) {
+	g := NewWithT(t)
+
+	auditor, err := NewAuditor(Attributes, DefaultAuditInterval)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	instance := &ec2.Instance{
+		InstanceId:   aws.String("i-1"),
+		InstanceType: aws.String("m5.xlarge"),
+		State:        &ec2.InstanceState{Name: aws.String(ec2.InstanceStateNameTerminated)},
+	}
+	g.Expect(auditor.compare(&Expected{InstanceType: "m5.large"}, instance)).To(BeEmpty())
+
+	instance.State.Name = aws.String(ec2.InstanceStateNameStopped)
+	g.Expect(auditor.compare(&Expected{InstanceType: "m5.large", IAMInstanceProfile: "nodes"}, instance)).To(ConsistOf(
+		Difference{InstanceID: "i-1", Attribute: AttributeInstanceType, Expected: "m5.large", Actual: "m5.xlarge"},
+		Difference{InstanceID: "i-1", Attribute: AttributeIAMInstanceProfile, Expected: "nodes", Actual: ""},
+	))
+}
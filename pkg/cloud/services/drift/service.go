@@ -0,0 +1,42 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package drift provides a way to detect changes made outside of the controllers to the
+// instances of machines and machine pools.
+package drift
+
+import (
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+)
+
+// Service audits the instances of a machine or machine pool.
+type Service struct {
+	scope     scope.EC2Scope
+	auditor   *Auditor
+	EC2Client ec2iface.EC2API
+}
+
+// NewService returns a new service given the EC2 scope and the auditor holding the configuration
+// and the time of previous audits.
+func NewService(ec2Scope scope.EC2Scope, auditor *Auditor) *Service {
+	return &Service{
+		scope:     ec2Scope,
+		auditor:   auditor,
+		EC2Client: scope.NewEC2Client(ec2Scope, ec2Scope, ec2Scope, ec2Scope.InfraCluster()),
+	}
+}
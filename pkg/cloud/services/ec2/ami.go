@@ -19,6 +19,8 @@ package ec2
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"sort"
 	"strings"
@@ -34,11 +36,16 @@ import (
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/api/bootstrap/v1beta1"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/util/system"
 )
 
+// ErrAMICopyFailed is returned when the copy of the AMI of a launch template from another region failed.
+var ErrAMICopyFailed = errors.New("AMI copy failed")
+
 const (
 	// DefaultArchitectureTag is the default architecture used when the architcture can't be determined from instance type.
 	DefaultArchitectureTag = Amd64ArchitectureTag
@@ -66,6 +73,10 @@ const (
 	// when looking up machine AMIs.
 	defaultMachineAMILookupBaseOS = "ubuntu-24.04"
 
+	// gpuImageLookupBaseOSSuffix is appended to the base OS to look up the variant of an image which ships the
+	// NVIDIA drivers.
+	gpuImageLookupBaseOSSuffix = "-gpu"
+
 	// DefaultAmiNameFormat is defined in the build/ directory of this project.
 	// The pattern is:
 	// 1. the string value `capa-ami-`
@@ -85,6 +96,16 @@ const (
 
 	// EKS GPU AMI ID SSM Parameter name.
 	eksGPUAmiSSMParameterFormat = "/aws/service/eks/optimized-ami/%s/amazon-linux-2-gpu/recommended/image_id"
+
+	// bottlerocketAMIOwner is the owner alias of the Bottlerocket AMIs published by AWS.
+	bottlerocketAMIOwner = "amazon"
+
+	// bottlerocketAMINameFormat is the name of the Bottlerocket AMIs for a Kubernetes minor version and an
+	// architecture, e.g. bottlerocket-aws-k8s-1.30-x86_64-v1.20.3-5d9ac849.
+	bottlerocketAMINameFormat = "bottlerocket-aws-k8s-%s-%s-v*"
+
+	// bottlerocketGPUAMINameFormat is the name of the Bottlerocket AMIs which ship the NVIDIA drivers.
+	bottlerocketGPUAMINameFormat = "bottlerocket-aws-k8s-%s-nvidia-%s-v*"
 )
 
 // AMILookup contains the parameters used to template AMI names used for lookup.
@@ -160,6 +181,14 @@ archCheck:
 	return architecture, nil
 }
 
+// gpuImageLookupBaseOS returns the base OS of the variant of an image which ships the NVIDIA drivers.
+func gpuImageLookupBaseOS(baseOS string) string {
+	if baseOS == "" {
+		baseOS = defaultMachineAMILookupBaseOS
+	}
+	return baseOS + gpuImageLookupBaseOSSuffix
+}
+
 // DefaultAMILookup will do a default AMI lookup.
 func DefaultAMILookup(ec2Client ec2iface.EC2API, ownerID, baseOS, kubernetesVersion, architecture, amiNameFormat string) (*ec2.Image, error) {
 	if amiNameFormat == "" {
@@ -357,6 +386,57 @@ func (s *Service) eksAMILookup(kubernetesVersion string, architecture string, am
 	return id, nil
 }
 
+// bottlerocketAMILookup returns the latest Bottlerocket AMI for the Kubernetes minor version and the architecture.
+func (s *Service) bottlerocketAMILookup(kubernetesVersion, architecture string, amiType expinfrav1.AMIType) (string, error) {
+	formattedVersion, err := formatVersionForEKS(kubernetesVersion)
+	if err != nil {
+		return "", err
+	}
+
+	// The Bottlerocket AMI names use aarch64 for the arm64 architecture.
+	nameArchitecture := architecture
+	if architecture == Arm64ArchitectureTag {
+		nameArchitecture = "aarch64"
+	}
+	nameFormat := bottlerocketAMINameFormat
+	if amiType == expinfrav1.AMITypeGPU {
+		nameFormat = bottlerocketGPUAMINameFormat
+	}
+	amiName := fmt.Sprintf(nameFormat, formattedVersion, nameArchitecture)
+
+	out, err := s.EC2Client.DescribeImagesWithContext(context.TODO(), &ec2.DescribeImagesInput{
+		Owners: []*string{aws.String(bottlerocketAMIOwner)},
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("name"),
+				Values: []*string{aws.String(amiName)},
+			},
+			{
+				Name:   aws.String("architecture"),
+				Values: []*string{aws.String(architecture)},
+			},
+			{
+				Name:   aws.String("state"),
+				Values: []*string{aws.String("available")},
+			},
+		},
+	})
+	if err != nil {
+		record.Eventf(s.scope.InfraCluster(), "FailedDescribeImages", "Failed to find Bottlerocket ami %q: %v", amiName, err)
+		return "", errors.Wrapf(err, "failed to find ami: %q", amiName)
+	}
+	if len(out.Images) == 0 {
+		return "", errors.Errorf("found no Bottlerocket AMIs with the name: %q", amiName)
+	}
+	latestImage, err := GetLatestImage(out.Images)
+	if err != nil {
+		return "", err
+	}
+
+	s.scope.Debug("Found and using a Bottlerocket AMI", "ami-id", aws.StringValue(latestImage.ImageId))
+	return aws.StringValue(latestImage.ImageId), nil
+}
+
 func formatVersionForEKS(version string) (string, error) {
 	parsed, err := semver.ParseTolerant(version)
 	if err != nil {
@@ -365,3 +445,142 @@ func formatVersionForEKS(version string) (string, error) {
 
 	return fmt.Sprintf("%d.%d", parsed.Major, parsed.Minor), nil
 }
+
+// reconcileCopiedAMI returns the AMI of a launch template which can be copied from another region. The AMI
+// is used as is when it exists in the region of the cluster, otherwise it is copied from its source region
+// and a failed dependency error is returned until the copy is available.
+func (s *Service) reconcileCopiedAMI(ltScope scope.LaunchTemplateScope, ami infrav1.AMIReference) (*string, error) {
+	copiedScope, ok := ltScope.(scope.CopiedAMIScope)
+	if !ok {
+		return nil, errors.Errorf("copying AMIs from another region is not supported for %q", ltScope.LaunchTemplateName())
+	}
+	sourceID := aws.StringValue(ami.ID)
+
+	copied := copiedScope.GetCopiedAMIStatus()
+	if copied != nil && copied.SourceID == sourceID && copied.SourceRegion == ami.SourceRegion && copied.EncryptionKey == ami.CopyEncryptionKey {
+		return s.checkCopiedAMI(copied)
+	}
+
+	image, err := s.describeImage(sourceID)
+	if err != nil {
+		return nil, err
+	}
+	if image != nil {
+		copiedScope.SetCopiedAMIStatus(nil)
+		return ami.ID, nil
+	}
+
+	// A copy made for another machine pool, or whose status was lost, is reused.
+	copyName := copiedAMIName(sourceID, ami.SourceRegion, ami.CopyEncryptionKey)
+	copyID, err := s.findCopiedAMI(copyName)
+	if err != nil {
+		return nil, err
+	}
+
+	if copyID == "" {
+		input := &ec2.CopyImageInput{
+			Name:          aws.String(copyName),
+			Description:   aws.String(fmt.Sprintf("Copy of %s from %s", sourceID, ami.SourceRegion)),
+			SourceImageId: aws.String(sourceID),
+			SourceRegion:  aws.String(ami.SourceRegion),
+		}
+		if ami.CopyEncryptionKey != "" {
+			input.Encrypted = aws.Bool(true)
+			input.KmsKeyId = aws.String(ami.CopyEncryptionKey)
+		}
+
+		out, err := s.EC2Client.CopyImageWithContext(context.TODO(), input)
+		if err != nil {
+			record.Warnf(ltScope.GetMachinePool(), "FailedCopyImage", "Failed to copy AMI %q from region %q: %v", sourceID, ami.SourceRegion, err)
+			return nil, errors.Wrapf(err, "failed to copy AMI %q from region %q", sourceID, ami.SourceRegion)
+		}
+		copyID = aws.StringValue(out.ImageId)
+		record.Eventf(ltScope.GetMachinePool(), "SuccessfulCopyImage", "Started copy %q of AMI %q from region %q", copyID, sourceID, ami.SourceRegion)
+	}
+
+	copied = &expinfrav1.CopiedAMI{
+		SourceRegion:  ami.SourceRegion,
+		SourceID:      sourceID,
+		EncryptionKey: ami.CopyEncryptionKey,
+		ID:            copyID,
+	}
+	copiedScope.SetCopiedAMIStatus(copied)
+	if err := ltScope.PatchObject(); err != nil {
+		return nil, err
+	}
+
+	return s.checkCopiedAMI(copied)
+}
+
+// checkCopiedAMI returns the ID of a copied AMI once it is available.
+func (s *Service) checkCopiedAMI(copied *expinfrav1.CopiedAMI) (*string, error) {
+	image, err := s.describeImage(copied.ID)
+	if err != nil {
+		return nil, err
+	}
+	if image == nil {
+		return nil, errors.Wrapf(ErrAMICopyFailed, "copy %q of AMI %q from region %q no longer exists", copied.ID, copied.SourceID, copied.SourceRegion)
+	}
+
+	switch state := aws.StringValue(image.State); state {
+	case ec2.ImageStateAvailable:
+		return image.ImageId, nil
+	case ec2.ImageStatePending:
+		return nil, awserrors.NewFailedDependency(fmt.Sprintf("copy %q of AMI %q from region %q is pending", copied.ID, copied.SourceID, copied.SourceRegion))
+	default:
+		reason := ""
+		if image.StateReason != nil {
+			reason = aws.StringValue(image.StateReason.Message)
+		}
+		return nil, errors.Wrapf(ErrAMICopyFailed, "copy %q of AMI %q from region %q is %s: %s", copied.ID, copied.SourceID, copied.SourceRegion, state, reason)
+	}
+}
+
+// describeImage returns the AMI with the given ID in the region of the cluster, or nil if it doesn't exist.
+func (s *Service) describeImage(id string) (*ec2.Image, error) {
+	out, err := s.EC2Client.DescribeImagesWithContext(context.TODO(), &ec2.DescribeImagesInput{
+		ImageIds: []*string{aws.String(id)},
+	})
+	if err != nil {
+		if code, _ := awserrors.Code(err); code == awserrors.ImageNotFound {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to describe AMI %q", id)
+	}
+	if len(out.Images) == 0 {
+		return nil, nil
+	}
+	return out.Images[0], nil
+}
+
+// findCopiedAMI returns the ID of the AMI with the given name owned by the account, or an empty string
+// if there is none.
+func (s *Service) findCopiedAMI(name string) (string, error) {
+	out, err := s.EC2Client.DescribeImagesWithContext(context.TODO(), &ec2.DescribeImagesInput{
+		Owners: []*string{aws.String("self")},
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("name"),
+				Values: []*string{aws.String(name)},
+			},
+		},
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to find copied AMI %q", name)
+	}
+	if len(out.Images) == 0 {
+		return "", nil
+	}
+	return aws.StringValue(out.Images[0].ImageId), nil
+}
+
+// copiedAMIName returns the name of the copy of an AMI made from another region. Copies encrypted with a key are
+// named after a hash of the key, as key ARNs contain characters AMI names don't allow, so that they aren't reused
+// for copies with another key or without encryption.
+func copiedAMIName(sourceID, sourceRegion, encryptionKey string) string {
+	if encryptionKey == "" {
+		return fmt.Sprintf("%s-%s", sourceID, sourceRegion)
+	}
+	hash := sha256.Sum256([]byte(encryptionKey))
+	return fmt.Sprintf("%s-%s-%s", sourceID, sourceRegion, hex.EncodeToString(hash[:])[:16])
+}
@@ -58,6 +58,10 @@ func (s *Service) ReconcileBastion() error {
 		return s.DeleteBastion()
 	}
 
+	if s.scope.BastionSuspended() {
+		return s.suspendBastion()
+	}
+
 	s.scope.Debug("Reconciling bastion host")
 
 	subnets := s.scope.Subnets()
@@ -134,11 +138,29 @@ func (s *Service) DeleteBastion() error {
 	return nil
 }
 
+// suspendBastion terminates the bastion instance while it is suspended by the cost savings schedule. A new instance
+// is created on resume.
+func (s *Service) suspendBastion() error {
+	s.scope.Debug("Bastion host is suspended")
+
+	if _, err := s.describeBastionInstance(); err == nil {
+		if err := s.DeleteBastion(); err != nil {
+			return err
+		}
+	} else if !awserrors.IsNotFound(err) {
+		return err
+	}
+
+	conditions.MarkFalse(s.scope.InfraCluster(), infrav1.BastionHostReadyCondition, infrav1.BastionHostSuspendedReason, clusterv1.ConditionSeverityInfo,
+		"Bastion host is suspended by the cost savings schedule")
+	return nil
+}
+
 func (s *Service) describeBastionInstance() (*infrav1.Instance, error) {
 	input := &ec2.DescribeInstancesInput{
 		Filters: []*ec2.Filter{
 			filter.EC2.ProviderRole(infrav1.BastionRoleTagValue),
-			filter.EC2.Cluster(s.scope.Name()),
+			filter.EC2.Cluster(s.scope.Name(), s.scope.OwnershipTagPrefixes()...),
 			filter.EC2.InstanceStates(
 				ec2.InstanceStateNamePending,
 				ec2.InstanceStateNameRunning,
@@ -205,11 +227,12 @@ func (s *Service) getDefaultBastion(instanceType, ami string) (*infrav1.Instance
 			s.scope.Network().SecurityGroups[infrav1.SecurityGroupBastion].ID,
 		},
 		Tags: infrav1.Build(infrav1.BuildParams{
-			ClusterName: s.scope.Name(),
-			Lifecycle:   infrav1.ResourceLifecycleOwned,
-			Name:        aws.String(name),
-			Role:        aws.String(infrav1.BastionRoleTagValue),
-			Additional:  s.scope.AdditionalTags(),
+			ClusterName:        s.scope.Name(),
+			OwnershipTagPrefix: s.scope.OwnershipTagPrefix(),
+			Lifecycle:          infrav1.ResourceLifecycleOwned,
+			Name:               aws.String(name),
+			Role:               aws.String(infrav1.BastionRoleTagValue),
+			Additional:         s.scope.AdditionalTags(),
 		}),
 	}
 
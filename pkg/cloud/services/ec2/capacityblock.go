@@ -0,0 +1,61 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+)
+
+// DescribeCapacityBlock returns the state and the window of the Capacity Block for ML with the given capacity
+// reservation ID.
+func (s *Service) DescribeCapacityBlock(capacityReservationID string) (*expinfrav1.CapacityBlockStatus, error) {
+	out, err := s.EC2Client.DescribeCapacityReservationsWithContext(context.TODO(), &ec2.DescribeCapacityReservationsInput{
+		CapacityReservationIds: aws.StringSlice([]string{capacityReservationID}),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to describe capacity reservation %q", capacityReservationID)
+	}
+	if len(out.CapacityReservations) == 0 {
+		return nil, awserrors.NewNotFound(fmt.Sprintf("capacity reservation %q not found", capacityReservationID))
+	}
+
+	reservation := out.CapacityReservations[0]
+	if reservationType := aws.StringValue(reservation.ReservationType); reservationType != "" && reservationType != ec2.CapacityReservationTypeCapacityBlock {
+		return nil, errors.Errorf("capacity reservation %q isn't a capacity block but a %s reservation", capacityReservationID, reservationType)
+	}
+
+	status := &expinfrav1.CapacityBlockStatus{
+		CapacityReservationID: capacityReservationID,
+		State:                 aws.StringValue(reservation.State),
+	}
+	if reservation.StartDate != nil {
+		status.StartTime = &metav1.Time{Time: aws.TimeValue(reservation.StartDate)}
+	}
+	if reservation.EndDate != nil {
+		status.EndTime = &metav1.Time{Time: aws.TimeValue(reservation.EndDate)}
+	}
+	return status, nil
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+)
+
+func TestServiceDescribeCapacityBlock(t *testing.T) {
+	start := time.Date(2024, 6, 1, 11, 30, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+	input := &ec2.DescribeCapacityReservationsInput{CapacityReservationIds: aws.StringSlice([]string{"cr-1"})}
+
+	tests := []struct {
+		name         string
+		reservations []*ec2.CapacityReservation
+		expectStatus *expinfrav1.CapacityBlockStatus
+		expectError  func(error) bool
+	}{
+		{
+			name: "reports the state and the window of the capacity block",
+			reservations: []*ec2.CapacityReservation{{
+				CapacityReservationId: aws.String("cr-1"),
+				ReservationType:       aws.String(ec2.CapacityReservationTypeCapacityBlock),
+				State:                 aws.String(ec2.CapacityReservationStateScheduled),
+				StartDate:             aws.Time(start),
+				EndDate:               aws.Time(end),
+			}},
+			expectStatus: &expinfrav1.CapacityBlockStatus{
+				CapacityReservationID: "cr-1",
+				State:                 ec2.CapacityReservationStateScheduled,
+				StartTime:             &metav1.Time{Time: start},
+				EndTime:               &metav1.Time{Time: end},
+			},
+		},
+		{
+			name:         "capacity reservation not found",
+			reservations: []*ec2.CapacityReservation{},
+			expectError:  awserrors.IsNotFound,
+		},
+		{
+			name: "capacity reservation which isn't a capacity block",
+			reservations: []*ec2.CapacityReservation{{
+				CapacityReservationId: aws.String("cr-1"),
+				ReservationType:       aws.String(ec2.CapacityReservationTypeDefault),
+				State:                 aws.String(ec2.CapacityReservationStateActive),
+			}},
+			expectError: func(err error) bool { return err != nil },
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+			ec2Mock.EXPECT().DescribeCapacityReservationsWithContext(context.TODO(), gomock.Eq(input)).
+				Return(&ec2.DescribeCapacityReservationsOutput{CapacityReservations: tc.reservations}, nil)
+
+			s := NewService(newSSHKeyPairClusterScope(g, "cluster", nil, nil))
+			s.EC2Client = ec2Mock
+
+			status, err := s.DescribeCapacityBlock("cr-1")
+			if tc.expectError != nil {
+				g.Expect(tc.expectError(err)).To(BeTrue(), "unexpected error: %v", err)
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(status).To(Equal(tc.expectStatus))
+		})
+	}
+}
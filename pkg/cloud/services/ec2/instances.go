@@ -46,7 +46,7 @@ func (s *Service) GetRunningInstanceByTags(scope *scope.MachineScope) (*infrav1.
 
 	input := &ec2.DescribeInstancesInput{
 		Filters: []*ec2.Filter{
-			filter.EC2.ClusterOwned(s.scope.Name()),
+			filter.EC2.ClusterOwned(s.scope.Name(), s.scope.OwnershipTagPrefix()),
 			filter.EC2.Name(scope.Name()),
 			filter.EC2.InstanceStates(ec2.InstanceStateNamePending, ec2.InstanceStateNameRunning),
 		},
@@ -119,16 +119,23 @@ func (s *Service) CreateInstance(scope *scope.MachineScope, userData []byte, use
 		NonRootVolumes:    scope.AWSMachine.Spec.NonRootVolumes,
 		NetworkInterfaces: scope.AWSMachine.Spec.NetworkInterfaces,
 	}
+	// Worker machines without an instance profile use the one created for the nodes of the cluster, if any.
+	if input.IAMProfile == "" && !scope.IsControlPlane() {
+		input.IAMProfile = managedNodeInstanceProfile(scope.InfraCluster)
+	}
 
 	// Make sure to use the MachineScope here to get the merger of AWSCluster and AWSMachine tags
 	additionalTags := scope.AdditionalTags()
 	input.Tags = infrav1.Build(infrav1.BuildParams{
-		ClusterName: s.scope.KubernetesClusterName(),
-		Lifecycle:   infrav1.ResourceLifecycleOwned,
-		Name:        aws.String(scope.Name()),
-		Role:        aws.String(scope.Role()),
-		Additional:  additionalTags,
+		ClusterName:        s.scope.KubernetesClusterName(),
+		OwnershipTagPrefix: s.scope.OwnershipTagPrefix(),
+		Lifecycle:          infrav1.ResourceLifecycleOwned,
+		Name:               aws.String(scope.Name()),
+		Role:               aws.String(scope.Role()),
+		Additional:         additionalTags,
 	}.WithCloudProvider(s.scope.KubernetesClusterName()).WithMachineName(scope.Machine))
+	// The network interfaces of the instance are tagged like the instance, for the cloud controller manager.
+	s.scope.LegacyClusterTag().Apply(input.Tags, s.scope.KubernetesClusterName(), infrav1.ResourceLifecycleOwned)
 
 	var err error
 
@@ -182,6 +189,15 @@ func (s *Service) CreateInstance(scope *scope.MachineScope, userData []byte, use
 	}
 	input.SubnetID = subnetID
 
+	// Instances on an AWS Outpost are limited to the instance and volume types the Outpost supports.
+	if outpostARN := s.subnetOutpostARN(subnetID); outpostARN != "" {
+		if err := s.validateOutpostInstanceType(input.Type, outpostARN); err != nil {
+			record.Warnf(scope.AWSMachine, "FailedCreate", "Failed to create instance: %v", err)
+			return nil, err
+		}
+		input.RootVolume, input.NonRootVolumes = defaultOutpostVolumeTypes(input.RootVolume, input.NonRootVolumes)
+	}
+
 	// Preserve user-defined PublicIp option.
 	input.PublicIPOnLaunch = scope.AWSMachine.Spec.PublicIP
 
@@ -241,6 +257,8 @@ func (s *Service) CreateInstance(scope *scope.MachineScope, userData []byte, use
 
 	input.SpotMarketOptions = scope.AWSMachine.Spec.SpotMarketOptions
 
+	input.MarketType = scope.AWSMachine.Spec.MarketType
+
 	input.InstanceMetadataOptions = scope.AWSMachine.Spec.InstanceMetadataOptions
 
 	input.Tenancy = scope.AWSMachine.Spec.Tenancy
@@ -253,6 +271,8 @@ func (s *Service) CreateInstance(scope *scope.MachineScope, userData []byte, use
 
 	input.CapacityReservationID = scope.AWSMachine.Spec.CapacityReservationID
 
+	input.CapacityReservationResourceGroupARN = scope.AWSMachine.Spec.CapacityReservationResourceGroupARN
+
 	s.scope.Debug("Running instance", "machine-role", scope.Role())
 	s.scope.Debug("Running instance with instance metadata options", "metadata options", input.InstanceMetadataOptions)
 	out, err := s.runInstance(scope.Role(), input)
@@ -312,6 +332,14 @@ func (s *Service) findSubnet(scope *scope.MachineScope) (string, error) {
 	// Check Machine.Spec.FailureDomain first as it's used by KubeadmControlPlane to spread machines across failure domains.
 	failureDomain := scope.Machine.Spec.FailureDomain
 
+	// A private only VPC has no public subnet, nor an internet gateway to reach a public IP.
+	if ptr.Deref(scope.AWSMachine.Spec.PublicIP, false) && s.scope.VPC().PrivateOnly {
+		errMessage := fmt.Sprintf("failed to run machine %q with public IP, the VPC is private only: unset spec.publicIP of the AWSMachine, or spec.network.vpc.privateOnly of the cluster",
+			scope.Name())
+		record.Warnf(scope.AWSMachine, "FailedCreate", errMessage)
+		return "", awserrors.NewFailedDependency(errMessage)
+	}
+
 	// We basically have 2 sources for subnets:
 	//   1. If subnet.id or subnet.filters are specified, we directly query AWS
 	//   2. All other cases use the subnets provided in the cluster network spec without ever calling AWS
@@ -425,6 +453,60 @@ func (s *Service) findSubnet(scope *scope.MachineScope) (string, error) {
 	}
 }
 
+// subnetOutpostARN returns the ARN of the AWS Outpost of the given cluster subnet,
+// or an empty string if the subnet is not on an Outpost.
+func (s *Service) subnetOutpostARN(subnetID string) string {
+	sn := s.scope.Subnets().FindByID(subnetID)
+	if sn == nil || !sn.IsOutpost() {
+		return ""
+	}
+	return *sn.OutpostARN
+}
+
+// validateOutpostInstanceType checks that the instance type is offered on the AWS Outpost.
+func (s *Service) validateOutpostInstanceType(instanceType, outpostARN string) error {
+	out, err := s.EC2Client.DescribeInstanceTypeOfferingsWithContext(context.TODO(), &ec2.DescribeInstanceTypeOfferingsInput{
+		LocationType: aws.String(ec2.LocationTypeOutpost),
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("location"),
+				Values: aws.StringSlice([]string{outpostARN}),
+			},
+			{
+				Name:   aws.String("instance-type"),
+				Values: aws.StringSlice([]string{instanceType}),
+			},
+		},
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to describe instance type offerings for outpost %q", outpostARN)
+	}
+	if len(out.InstanceTypeOfferings) == 0 {
+		return errors.Errorf("instance type %q is not supported on outpost %q", instanceType, outpostARN)
+	}
+	return nil
+}
+
+// defaultOutpostVolumeTypes returns copies of the volumes where the volumes without
+// a type are set to gp2, as the gp3 default of some AMIs is not available on AWS Outposts.
+func defaultOutpostVolumeTypes(rootVolume *infrav1.Volume, nonRootVolumes []infrav1.Volume) (*infrav1.Volume, []infrav1.Volume) {
+	if rootVolume != nil && rootVolume.Type == "" {
+		rootVolume = rootVolume.DeepCopy()
+		rootVolume.Type = infrav1.VolumeTypeGP2
+	}
+	if len(nonRootVolumes) == 0 {
+		return rootVolume, nonRootVolumes
+	}
+	volumes := make([]infrav1.Volume, len(nonRootVolumes))
+	for i := range nonRootVolumes {
+		nonRootVolumes[i].DeepCopyInto(&volumes[i])
+		if volumes[i].Type == "" {
+			volumes[i].Type = infrav1.VolumeTypeGP2
+		}
+	}
+	return rootVolume, volumes
+}
+
 // getFilteredSubnets fetches subnets filtered based on the criteria passed.
 func (s *Service) getFilteredSubnets(criteria ...*ec2.Filter) ([]*ec2.Subnet, error) {
 	out, err := s.EC2Client.DescribeSubnetsWithContext(context.TODO(), &ec2.DescribeSubnetsInput{Filters: criteria})
@@ -485,10 +567,15 @@ func (s *Service) GetCoreSecurityGroups(scope *scope.MachineScope) ([]string, er
 
 // GetCoreNodeSecurityGroups looks up the security group IDs managed by this actuator
 // They are considered "core" to its proper functioning.
+// The security group dedicated to the machine pool is included when enabled, replacing the node
+// security group if requested.
 func (s *Service) GetCoreNodeSecurityGroups(scope scope.LaunchTemplateScope) ([]string, error) {
+	dedicatedSG, dedicatedSGScope := launchTemplateDedicatedSecurityGroup(scope)
+
 	// These are common across both controlplane and node machines
-	sgRoles := []infrav1.SecurityGroupRole{
-		infrav1.SecurityGroupNode,
+	sgRoles := []infrav1.SecurityGroupRole{}
+	if dedicatedSG == nil || !dedicatedSG.ReplaceNodeSG {
+		sgRoles = append(sgRoles, infrav1.SecurityGroupNode)
 	}
 
 	if !scope.IsEKSManaged() {
@@ -506,6 +593,14 @@ func (s *Service) GetCoreNodeSecurityGroups(scope scope.LaunchTemplateScope) ([]
 		}
 		ids = append(ids, s.scope.SecurityGroups()[sg].ID)
 	}
+
+	if dedicatedSG != nil {
+		id := dedicatedSGScope.GetDedicatedSecurityGroupIDStatus()
+		if id == "" {
+			return nil, awserrors.NewFailedDependency("dedicated security group not available")
+		}
+		ids = append(ids, id)
+	}
 	return ids, nil
 }
 
@@ -638,10 +733,10 @@ func (s *Service) runInstance(role string, i *infrav1.Instance) (*infrav1.Instan
 		}
 	}
 
-	input.InstanceMarketOptions = getInstanceMarketOptionsRequest(i.SpotMarketOptions)
+	input.InstanceMarketOptions = getInstanceMarketOptionsRequest(i.MarketType, i.SpotMarketOptions)
 	input.MetadataOptions = getInstanceMetadataOptionsRequest(i.InstanceMetadataOptions)
 	input.PrivateDnsNameOptions = getPrivateDNSNameOptionsRequest(i.PrivateDNSName)
-	input.CapacityReservationSpecification = getCapacityReservationSpecification(i.CapacityReservationID)
+	input.CapacityReservationSpecification = getCapacityReservationSpecification(i.CapacityReservationID, i.CapacityReservationResourceGroupARN)
 
 	if i.Tenancy != "" {
 		input.Placement = &ec2.Placement{
@@ -682,6 +777,14 @@ func volumeToBlockDeviceMapping(v *infrav1.Volume) *ec2.BlockDeviceMapping {
 		Encrypted:           v.Encrypted,
 	}
 
+	if v.DeleteOnTermination != nil {
+		ebsDevice.DeleteOnTermination = v.DeleteOnTermination
+	}
+
+	if v.SnapshotID != nil {
+		ebsDevice.SnapshotId = v.SnapshotID
+	}
+
 	if v.Throughput != nil {
 		ebsDevice.Throughput = v.Throughput
 	}
@@ -793,6 +896,59 @@ func (s *Service) UpdateResourceTags(resourceID *string, create, remove map[stri
 	return nil
 }
 
+// ReconcileLegacyClusterTag updates the kubernetes.io/cluster/<name> tag of an instance and of its network interfaces
+// to match its configuration in the network spec of the cluster. The network interfaces are tagged along with the
+// instance, so they are only described when the tag of the instance changes.
+func (s *Service) ReconcileLegacyClusterTag(instance *infrav1.Instance) error {
+	config := s.scope.LegacyClusterTag()
+	if config == nil {
+		return nil
+	}
+
+	create, remove := legacyClusterTagChanges(config, s.scope.KubernetesClusterName(), instance.Tags)
+	if len(create) == 0 && len(remove) == 0 {
+		return nil
+	}
+
+	s.scope.Debug("Updating the legacy cluster tag of the instance", "instance-id", instance.ID)
+	if err := s.UpdateResourceTags(aws.String(instance.ID), create, remove); err != nil {
+		return err
+	}
+
+	networkInterfaces, err := s.getInstanceENIs(instance.ID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get ENIs for instance %q", instance.ID)
+	}
+	for _, networkInterface := range networkInterfaces {
+		create, remove := legacyClusterTagChanges(config, s.scope.KubernetesClusterName(), converters.TagsToMap(networkInterface.TagSet))
+		if err := s.UpdateResourceTags(networkInterface.NetworkInterfaceId, create, remove); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// legacyClusterTagChanges returns the kubernetes.io/cluster/<name> tag to create or to remove on a resource having
+// the current tags.
+func legacyClusterTagChanges(config *infrav1.LegacyClusterTag, clusterName string, current infrav1.Tags) (create, remove map[string]string) {
+	key := infrav1.ClusterAWSCloudProviderTagKey(clusterName)
+	desired := infrav1.Tags{}
+	if value, ok := current[key]; ok {
+		desired[key] = value
+	}
+	config.Apply(desired, clusterName, infrav1.ResourceLifecycleOwned)
+
+	if value, ok := desired[key]; ok {
+		if current[key] != value {
+			create = map[string]string{key: value}
+		}
+	} else if value, ok := current[key]; ok {
+		remove = map[string]string{key: value}
+	}
+	return create, remove
+}
+
 func (s *Service) getInstanceENIs(instanceID string) ([]*ec2.NetworkInterface, error) {
 	input := &ec2.DescribeNetworkInterfacesInput{
 		Filters: []*ec2.Filter{
@@ -896,6 +1052,8 @@ func (s *Service) SDKToInstance(v *ec2.Instance) (*infrav1.Instance, error) {
 	i.Addresses = s.getInstanceAddresses(v)
 
 	i.AvailabilityZone = aws.StringValue(v.Placement.AvailabilityZone)
+	i.PlacementGroupName = aws.StringValue(v.Placement.GroupName)
+	i.PlacementGroupPartition = aws.Int64Value(v.Placement.PartitionNumber)
 
 	for _, volume := range v.BlockDeviceMappings {
 		i.VolumeIDs = append(i.VolumeIDs, *volume.Ebs.VolumeId)
@@ -1125,21 +1283,32 @@ func filterGroups(list []string, strToFilter string) (newList []string) {
 	return
 }
 
-func getCapacityReservationSpecification(capacityReservationID *string) *ec2.CapacityReservationSpecification {
-	if capacityReservationID == nil {
+func getCapacityReservationSpecification(capacityReservationID, resourceGroupARN *string) *ec2.CapacityReservationSpecification {
+	if capacityReservationID == nil && resourceGroupARN == nil {
 		//  Not targeting any specific Capacity Reservation
 		return nil
 	}
 
 	return &ec2.CapacityReservationSpecification{
 		CapacityReservationTarget: &ec2.CapacityReservationTarget{
-			CapacityReservationId: capacityReservationID,
+			CapacityReservationId:               capacityReservationID,
+			CapacityReservationResourceGroupArn: resourceGroupARN,
 		},
 	}
 }
 
-func getInstanceMarketOptionsRequest(spotMarketOptions *infrav1.SpotMarketOptions) *ec2.InstanceMarketOptionsRequest {
-	if spotMarketOptions == nil {
+func getInstanceMarketOptionsRequest(marketType infrav1.MarketType, spotMarketOptions *infrav1.SpotMarketOptions) *ec2.InstanceMarketOptionsRequest {
+	switch {
+	case marketType == infrav1.MarketTypeCapacityBlock:
+		// The instance is launched in the capacity block targeted by the capacity reservation specification.
+		return &ec2.InstanceMarketOptionsRequest{
+			MarketType: aws.String(ec2.MarketTypeCapacityBlock),
+		}
+	case marketType == infrav1.MarketTypeOnDemand:
+		return nil
+	case marketType == infrav1.MarketTypeSpot && spotMarketOptions == nil:
+		spotMarketOptions = &infrav1.SpotMarketOptions{}
+	case spotMarketOptions == nil:
 		// Instance is not a Spot instance
 		return nil
 	}
@@ -1201,3 +1370,17 @@ func getPrivateDNSNameOptionsRequest(privateDNSName *infrav1.PrivateDNSName) *ec
 		HostnameType:                    privateDNSName.HostnameType,
 	}
 }
+
+// managedNodeInstanceProfile returns the name of the instance profile created for the nodes of the cluster, or
+// an empty string if the cluster doesn't create one. Once the node role isn't created anymore, its instance profile
+// is only kept until the instances using it are gone, so it isn't used for new instances.
+func managedNodeInstanceProfile(ec2Scope scope.EC2Scope) string {
+	nodeRoleScope, ok := ec2Scope.(scope.NodeRoleScope)
+	if !ok || nodeRoleScope.NodeRoleStatus() == nil {
+		return ""
+	}
+	if management := nodeRoleScope.NodeRoleManagement(); management == nil || !management.Create {
+		return ""
+	}
+	return nodeRoleScope.NodeRoleStatus().InstanceProfileName
+}
@@ -1781,6 +1781,265 @@ func TestCreateInstance(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "with an outpost subnet, volumes without a type default to gp2",
+			machine: &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"set": "node"},
+				},
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{
+						DataSecretName: ptr.To[string]("bootstrap-data"),
+					},
+				},
+			},
+			machineConfig: &infrav1.AWSMachineSpec{
+				AMI: infrav1.AMIReference{
+					ID: aws.String("abc"),
+				},
+				InstanceType: "m5.large",
+				Subnet: &infrav1.AWSResourceReference{
+					ID: aws.String("outpost-subnet"),
+				},
+				NonRootVolumes: []infrav1.Volume{{
+					DeviceName: "device-2",
+					Size:       8,
+				}},
+			},
+			awsCluster: &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: infrav1.AWSClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						VPC: infrav1.VPCSpec{
+							ID: "vpc-id",
+						},
+						Subnets: infrav1.Subnets{{
+							ID:         "outpost-subnet",
+							OutpostARN: aws.String("arn:aws:outposts:us-east-1:123456789012:outpost/op-0123456789abcdef0"),
+						}},
+					},
+				},
+				Status: infrav1.AWSClusterStatus{
+					Network: infrav1.NetworkStatus{
+						SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+							infrav1.SecurityGroupControlPlane: {
+								ID: "1",
+							},
+							infrav1.SecurityGroupNode: {
+								ID: "2",
+							},
+							infrav1.SecurityGroupLB: {
+								ID: "3",
+							},
+						},
+						APIServerELB: infrav1.LoadBalancer{
+							DNSName: "test-apiserver.us-east-1.aws",
+						},
+					},
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.
+					DescribeSubnetsWithContext(context.TODO(), &ec2.DescribeSubnetsInput{
+						Filters: []*ec2.Filter{
+							filter.EC2.SubnetStates(ec2.SubnetStatePending, ec2.SubnetStateAvailable),
+							{Name: aws.String("subnet-id"), Values: aws.StringSlice([]string{"outpost-subnet"})},
+						},
+					}).
+					Return(&ec2.DescribeSubnetsOutput{
+						Subnets: []*ec2.Subnet{{
+							SubnetId:         aws.String("outpost-subnet"),
+							AvailabilityZone: aws.String("us-east-1b"),
+							OutpostArn:       aws.String("arn:aws:outposts:us-east-1:123456789012:outpost/op-0123456789abcdef0"),
+						}},
+					}, nil)
+				m.
+					DescribeInstanceTypesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInstanceTypesInput{
+						InstanceTypes: []*string{
+							aws.String("m5.large"),
+						},
+					})).
+					Return(&ec2.DescribeInstanceTypesOutput{
+						InstanceTypes: []*ec2.InstanceTypeInfo{
+							{
+								ProcessorInfo: &ec2.ProcessorInfo{
+									SupportedArchitectures: []*string{
+										aws.String("x86_64"),
+									},
+								},
+							},
+						},
+					}, nil)
+				m.
+					DescribeInstanceTypeOfferingsWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInstanceTypeOfferingsInput{
+						LocationType: aws.String(ec2.LocationTypeOutpost),
+						Filters: []*ec2.Filter{
+							{
+								Name:   aws.String("location"),
+								Values: aws.StringSlice([]string{"arn:aws:outposts:us-east-1:123456789012:outpost/op-0123456789abcdef0"}),
+							},
+							{
+								Name:   aws.String("instance-type"),
+								Values: aws.StringSlice([]string{"m5.large"}),
+							},
+						},
+					})).
+					Return(&ec2.DescribeInstanceTypeOfferingsOutput{
+						InstanceTypeOfferings: []*ec2.InstanceTypeOffering{
+							{
+								InstanceType: aws.String("m5.large"),
+								LocationType: aws.String(ec2.LocationTypeOutpost),
+								Location:     aws.String("arn:aws:outposts:us-east-1:123456789012:outpost/op-0123456789abcdef0"),
+							},
+						},
+					}, nil)
+				m.
+					RunInstancesWithContext(context.TODO(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, input *ec2.RunInstancesInput, _ ...request.Option) (*ec2.Reservation, error) {
+						if len(input.BlockDeviceMappings) != 1 || aws.StringValue(input.BlockDeviceMappings[0].Ebs.VolumeType) != ec2.VolumeTypeGp2 {
+							t.Fatalf("expected a single gp2 block device mapping, got %v", input.BlockDeviceMappings)
+						}
+						return &ec2.Reservation{
+							Instances: []*ec2.Instance{
+								{
+									State: &ec2.InstanceState{
+										Name: aws.String(ec2.InstanceStateNamePending),
+									},
+									InstanceId:   aws.String("two"),
+									InstanceType: aws.String("m5.large"),
+									SubnetId:     aws.String("outpost-subnet"),
+									ImageId:      aws.String("ami-1"),
+									Placement: &ec2.Placement{
+										AvailabilityZone: &az,
+									},
+								},
+							},
+						}, nil
+					})
+				m.
+					DescribeNetworkInterfacesWithContext(context.TODO(), gomock.Any()).
+					Return(&ec2.DescribeNetworkInterfacesOutput{
+						NetworkInterfaces: []*ec2.NetworkInterface{},
+						NextToken:         nil,
+					}, nil)
+			},
+			check: func(instance *infrav1.Instance, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+			},
+		},
+		{
+			name: "with an outpost subnet and an instance type not supported on the outpost",
+			machine: &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"set": "node"},
+				},
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{
+						DataSecretName: ptr.To[string]("bootstrap-data"),
+					},
+				},
+			},
+			machineConfig: &infrav1.AWSMachineSpec{
+				AMI: infrav1.AMIReference{
+					ID: aws.String("abc"),
+				},
+				InstanceType: "m5.large",
+				Subnet: &infrav1.AWSResourceReference{
+					ID: aws.String("outpost-subnet"),
+				},
+				NonRootVolumes: []infrav1.Volume{{
+					DeviceName: "device-2",
+					Size:       8,
+				}},
+			},
+			awsCluster: &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: infrav1.AWSClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						VPC: infrav1.VPCSpec{
+							ID: "vpc-id",
+						},
+						Subnets: infrav1.Subnets{{
+							ID:         "outpost-subnet",
+							OutpostARN: aws.String("arn:aws:outposts:us-east-1:123456789012:outpost/op-0123456789abcdef0"),
+						}},
+					},
+				},
+				Status: infrav1.AWSClusterStatus{
+					Network: infrav1.NetworkStatus{
+						SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+							infrav1.SecurityGroupControlPlane: {
+								ID: "1",
+							},
+							infrav1.SecurityGroupNode: {
+								ID: "2",
+							},
+							infrav1.SecurityGroupLB: {
+								ID: "3",
+							},
+						},
+						APIServerELB: infrav1.LoadBalancer{
+							DNSName: "test-apiserver.us-east-1.aws",
+						},
+					},
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.
+					DescribeSubnetsWithContext(context.TODO(), &ec2.DescribeSubnetsInput{
+						Filters: []*ec2.Filter{
+							filter.EC2.SubnetStates(ec2.SubnetStatePending, ec2.SubnetStateAvailable),
+							{Name: aws.String("subnet-id"), Values: aws.StringSlice([]string{"outpost-subnet"})},
+						},
+					}).
+					Return(&ec2.DescribeSubnetsOutput{
+						Subnets: []*ec2.Subnet{{
+							SubnetId:         aws.String("outpost-subnet"),
+							AvailabilityZone: aws.String("us-east-1b"),
+							OutpostArn:       aws.String("arn:aws:outposts:us-east-1:123456789012:outpost/op-0123456789abcdef0"),
+						}},
+					}, nil)
+				m.
+					DescribeInstanceTypesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInstanceTypesInput{
+						InstanceTypes: []*string{
+							aws.String("m5.large"),
+						},
+					})).
+					Return(&ec2.DescribeInstanceTypesOutput{
+						InstanceTypes: []*ec2.InstanceTypeInfo{
+							{
+								ProcessorInfo: &ec2.ProcessorInfo{
+									SupportedArchitectures: []*string{
+										aws.String("x86_64"),
+									},
+								},
+							},
+						},
+					}, nil)
+				m.
+					DescribeInstanceTypeOfferingsWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInstanceTypeOfferingsInput{
+						LocationType: aws.String(ec2.LocationTypeOutpost),
+						Filters: []*ec2.Filter{
+							{
+								Name:   aws.String("location"),
+								Values: aws.StringSlice([]string{"arn:aws:outposts:us-east-1:123456789012:outpost/op-0123456789abcdef0"}),
+							},
+							{
+								Name:   aws.String("instance-type"),
+								Values: aws.StringSlice([]string{"m5.large"}),
+							},
+						},
+					})).
+					Return(&ec2.DescribeInstanceTypeOfferingsOutput{}, nil)
+			},
+			check: func(instance *infrav1.Instance, err error) {
+				if err == nil {
+					t.Fatalf("expected an error for an instance type not supported on the outpost")
+				}
+			},
+		},
 		{
 			name: "with subnet ID that does not exist",
 			machine: &clusterv1.Machine{
@@ -3169,6 +3428,90 @@ func TestCreateInstance(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "public IP true and private only VPC",
+			machine: &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"set": "node"},
+				},
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{
+						DataSecretName: ptr.To[string]("bootstrap-data"),
+					},
+				},
+			},
+			machineConfig: &infrav1.AWSMachineSpec{
+				AMI: infrav1.AMIReference{
+					ID: aws.String("abc"),
+				},
+				InstanceType: "m5.large",
+				PublicIP:     aws.Bool(true),
+			},
+			awsCluster: &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: infrav1.AWSClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						VPC: infrav1.VPCSpec{
+							ID:          "vpc-id",
+							PrivateOnly: true,
+						},
+						Subnets: infrav1.Subnets{
+							infrav1.SubnetSpec{
+								ID:       "private-subnet-1",
+								IsPublic: false,
+							},
+						},
+					},
+				},
+				Status: infrav1.AWSClusterStatus{
+					Network: infrav1.NetworkStatus{
+						SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+							infrav1.SecurityGroupControlPlane: {
+								ID: "1",
+							},
+							infrav1.SecurityGroupNode: {
+								ID: "2",
+							},
+							infrav1.SecurityGroupLB: {
+								ID: "3",
+							},
+						},
+						APIServerELB: infrav1.LoadBalancer{
+							DNSName: "test-apiserver.us-east-1.aws",
+						},
+					},
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.
+					DescribeInstanceTypesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInstanceTypesInput{
+						InstanceTypes: []*string{
+							aws.String("m5.large"),
+						},
+					})).
+					Return(&ec2.DescribeInstanceTypesOutput{
+						InstanceTypes: []*ec2.InstanceTypeInfo{
+							{
+								ProcessorInfo: &ec2.ProcessorInfo{
+									SupportedArchitectures: []*string{
+										aws.String("x86_64"),
+									},
+								},
+							},
+						},
+					}, nil)
+			},
+			check: func(instance *infrav1.Instance, err error) {
+				expectedErrMsg := "failed to run machine \"aws-test1\" with public IP, the VPC is private only"
+				if err == nil {
+					t.Fatalf("Expected error, but got nil")
+				}
+
+				if !strings.Contains(err.Error(), expectedErrMsg) {
+					t.Fatalf("Expected error: %s\nInstead got: %s", expectedErrMsg, err.Error())
+				}
+			},
+		},
 		{
 			name: "with multiple block device mappings",
 			machine: &clusterv1.Machine{
@@ -5272,9 +5615,97 @@ func TestCreateInstance(t *testing.T) {
 	}
 }
 
+func TestReconcileLegacyClusterTag(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	tagKey := infrav1.ClusterAWSCloudProviderTagKey("test-cluster")
+	describeENIs := func(m *mocks.MockEC2APIMockRecorder, value string) {
+		m.DescribeNetworkInterfacesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeNetworkInterfacesInput{
+			Filters: []*ec2.Filter{{Name: aws.String("attachment.instance-id"), Values: aws.StringSlice([]string{"i-1"})}},
+		})).Return(&ec2.DescribeNetworkInterfacesOutput{NetworkInterfaces: []*ec2.NetworkInterface{
+			{NetworkInterfaceId: aws.String("eni-1"), TagSet: []*ec2.Tag{{Key: aws.String(tagKey), Value: aws.String(value)}}},
+		}}, nil)
+	}
+
+	testCases := []struct {
+		name   string
+		config *infrav1.LegacyClusterTag
+		tags   map[string]string
+		expect func(m *mocks.MockEC2APIMockRecorder)
+	}{
+		{
+			name: "not configured",
+			tags: map[string]string{tagKey: "owned"},
+		},
+		{
+			name:   "tag up to date",
+			config: &infrav1.LegacyClusterTag{Enabled: true},
+			tags:   map[string]string{tagKey: "owned"},
+		},
+		{
+			name:   "value switched",
+			config: &infrav1.LegacyClusterTag{Enabled: true, Value: infrav1.ResourceLifecycleShared},
+			tags:   map[string]string{tagKey: "owned"},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				for _, id := range []string{"i-1", "eni-1"} {
+					m.CreateTagsWithContext(context.TODO(), gomock.Eq(&ec2.CreateTagsInput{
+						Resources: aws.StringSlice([]string{id}),
+						Tags:      []*ec2.Tag{{Key: aws.String(tagKey), Value: aws.String("shared")}},
+					})).Return(nil, nil)
+				}
+				describeENIs(m, "owned")
+			},
+		},
+		{
+			name:   "tag disabled",
+			config: &infrav1.LegacyClusterTag{},
+			tags:   map[string]string{tagKey: "owned"},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				for _, id := range []string{"i-1", "eni-1"} {
+					m.DeleteTagsWithContext(context.TODO(), gomock.Eq(&ec2.DeleteTagsInput{
+						Resources: aws.StringSlice([]string{id}),
+						Tags:      []*ec2.Tag{{Key: aws.String(tagKey), Value: aws.String("owned")}},
+					})).Return(nil, nil)
+				}
+				describeENIs(m, "owned")
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Client:  client,
+				Cluster: &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"}},
+				AWSCluster: &infrav1.AWSCluster{Spec: infrav1.AWSClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{LegacyClusterTag: tc.config},
+				}},
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			if tc.expect != nil {
+				tc.expect(ec2Mock.EXPECT())
+			}
+
+			s := NewService(scope)
+			s.EC2Client = ec2Mock
+
+			g.Expect(s.ReconcileLegacyClusterTag(&infrav1.Instance{ID: "i-1", Tags: tc.tags})).To(Succeed())
+		})
+	}
+}
+
 func TestGetInstanceMarketOptionsRequest(t *testing.T) {
 	testCases := []struct {
 		name              string
+		marketType        infrav1.MarketType
 		spotMarketOptions *infrav1.SpotMarketOptions
 		expectedRequest   *ec2.InstanceMarketOptionsRequest
 	}{
@@ -5321,11 +5752,34 @@ func TestGetInstanceMarketOptionsRequest(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:       "with the spot market type and no Spot options specified",
+			marketType: infrav1.MarketTypeSpot,
+			expectedRequest: &ec2.InstanceMarketOptionsRequest{
+				MarketType: aws.String(ec2.MarketTypeSpot),
+				SpotOptions: &ec2.SpotMarketOptions{
+					InstanceInterruptionBehavior: aws.String(ec2.InstanceInterruptionBehaviorTerminate),
+					SpotInstanceType:             aws.String(ec2.SpotInstanceTypeOneTime),
+				},
+			},
+		},
+		{
+			name:            "with the on-demand market type",
+			marketType:      infrav1.MarketTypeOnDemand,
+			expectedRequest: nil,
+		},
+		{
+			name:       "with the capacity-block market type",
+			marketType: infrav1.MarketTypeCapacityBlock,
+			expectedRequest: &ec2.InstanceMarketOptionsRequest{
+				MarketType: aws.String(ec2.MarketTypeCapacityBlock),
+			},
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			request := getInstanceMarketOptionsRequest(tc.spotMarketOptions)
+			request := getInstanceMarketOptionsRequest(tc.marketType, tc.spotMarketOptions)
 			if !cmp.Equal(request, tc.expectedRequest) {
 				t.Errorf("Case: %s. Got: %v, expected: %v", tc.name, request, tc.expectedRequest)
 			}
@@ -5665,6 +6119,7 @@ func TestGetCapacityReservationSpecification(t *testing.T) {
 	testCases := []struct {
 		name                  string
 		capacityReservationID *string
+		resourceGroupARN      *string
 		expectedRequest       *ec2.CapacityReservationSpecification
 	}{
 		{
@@ -5681,10 +6136,67 @@ func TestGetCapacityReservationSpecification(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:             "with a capacity reservation resource group specified",
+			resourceGroupARN: aws.String("arn:aws:resource-groups:us-east-1:123456789012:group/reservations"),
+			expectedRequest: &ec2.CapacityReservationSpecification{
+				CapacityReservationTarget: &ec2.CapacityReservationTarget{
+					CapacityReservationResourceGroupArn: aws.String("arn:aws:resource-groups:us-east-1:123456789012:group/reservations"),
+				},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			request := getCapacityReservationSpecification(tc.capacityReservationID, tc.resourceGroupARN)
+			if !cmp.Equal(request, tc.expectedRequest) {
+				t.Errorf("Case: %s. Got: %v, expected: %v", tc.name, request, tc.expectedRequest)
+			}
+		})
+	}
+}
+
+func TestVolumeToBlockDeviceMapping(t *testing.T) {
+	testCases := []struct {
+		name            string
+		volume          *infrav1.Volume
+		expectedRequest *ec2.BlockDeviceMapping
+	}{
+		{
+			name: "with DeleteOnTermination and SnapshotID not specified",
+			volume: &infrav1.Volume{
+				DeviceName: "/dev/sdb",
+				Size:       10,
+			},
+			expectedRequest: &ec2.BlockDeviceMapping{
+				DeviceName: aws.String("/dev/sdb"),
+				Ebs: &ec2.EbsBlockDevice{
+					DeleteOnTermination: aws.Bool(true),
+					VolumeSize:          aws.Int64(10),
+				},
+			},
+		},
+		{
+			name: "with a volume kept on termination and created from a snapshot",
+			volume: &infrav1.Volume{
+				DeviceName:          "/dev/sdb",
+				Size:                10,
+				DeleteOnTermination: aws.Bool(false),
+				SnapshotID:          aws.String("snap-123"),
+			},
+			expectedRequest: &ec2.BlockDeviceMapping{
+				DeviceName: aws.String("/dev/sdb"),
+				Ebs: &ec2.EbsBlockDevice{
+					DeleteOnTermination: aws.Bool(false),
+					SnapshotId:          aws.String("snap-123"),
+					VolumeSize:          aws.Int64(10),
+				},
+			},
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			request := getCapacityReservationSpecification(tc.capacityReservationID)
+			request := volumeToBlockDeviceMapping(tc.volume)
 			if !cmp.Equal(request, tc.expectedRequest) {
 				t.Errorf("Case: %s. Got: %v, expected: %v", tc.name, request, tc.expectedRequest)
 			}
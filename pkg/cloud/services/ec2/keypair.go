@@ -0,0 +1,179 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/converters"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/tags"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// ReconcileSSHKeyPair imports the cluster's SSH key pair if it doesn't exist yet, and reports
+// whether the key pair found in EC2 is still the one that was imported.
+func (s *Service) ReconcileSSHKeyPair(scope scope.SSHKeyPairScope) error {
+	key := scope.SSHKey()
+	if key == nil {
+		return nil
+	}
+
+	existing, err := s.describeKeyPair(key.Name)
+	if err != nil && !awserrors.IsNotFound(err) {
+		conditions.MarkFalse(scope.InfraCluster(), infrav1.SSHKeyPairReadyCondition, infrav1.SSHKeyPairFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return err
+	}
+
+	if existing == nil {
+		status, err := s.importKeyPair(scope, key)
+		if err != nil {
+			conditions.MarkFalse(scope.InfraCluster(), infrav1.SSHKeyPairReadyCondition, infrav1.SSHKeyPairFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+			return err
+		}
+		scope.SetSSHKeyPair(status)
+		conditions.MarkTrue(scope.InfraCluster(), infrav1.SSHKeyPairReadyCondition)
+		return nil
+	}
+
+	if !converters.TagsToMap(existing.Tags).HasOwned(scope.Name(), scope.OwnershipTagPrefixes()...) {
+		conditions.MarkFalse(scope.InfraCluster(), infrav1.SSHKeyPairReadyCondition, infrav1.SSHKeyPairNotOwnedReason, clusterv1.ConditionSeverityError,
+			"key pair %q already exists and is not owned by the cluster", key.Name)
+		return errors.Errorf("key pair %q already exists and is not owned by the cluster", key.Name)
+	}
+
+	current := &infrav1.SSHKeyPairStatus{
+		KeyPairID:   aws.StringValue(existing.KeyPairId),
+		Fingerprint: aws.StringValue(existing.KeyFingerprint),
+	}
+
+	recorded := scope.SSHKeyPair()
+	if recorded == nil {
+		// The status was lost, e.g. after a move; take the key pair we own as the reference.
+		scope.SetSSHKeyPair(current)
+		conditions.MarkTrue(scope.InfraCluster(), infrav1.SSHKeyPairReadyCondition)
+		return nil
+	}
+
+	if *recorded != *current {
+		conditions.MarkFalse(scope.InfraCluster(), infrav1.SSHKeyPairReadyCondition, infrav1.SSHKeyPairDriftedReason, clusterv1.ConditionSeverityWarning,
+			"key pair %q has fingerprint %q, expected %q", key.Name, current.Fingerprint, recorded.Fingerprint)
+		record.Warnf(scope.InfraCluster(), "DriftedSSHKeyPair", "Key pair %q was replaced outside of the cluster's management", key.Name)
+		return nil
+	}
+
+	conditions.MarkTrue(scope.InfraCluster(), infrav1.SSHKeyPairReadyCondition)
+	return nil
+}
+
+// DeleteSSHKeyPair deletes the cluster's SSH key pair, provided it's owned by the cluster.
+func (s *Service) DeleteSSHKeyPair(scope scope.SSHKeyPairScope) error {
+	key := scope.SSHKey()
+	if key == nil {
+		return nil
+	}
+
+	existing, err := s.describeKeyPair(key.Name)
+	if err != nil {
+		if awserrors.IsNotFound(err) {
+			s.scope.Trace("key pair does not exist", "name", key.Name)
+			scope.SetSSHKeyPair(nil)
+			return nil
+		}
+		return err
+	}
+
+	if !converters.TagsToMap(existing.Tags).HasOwned(scope.Name(), scope.OwnershipTagPrefixes()...) {
+		s.scope.Info("Skipping deletion of key pair not owned by the cluster", "name", key.Name)
+		return nil
+	}
+
+	conditions.MarkFalse(scope.InfraCluster(), infrav1.SSHKeyPairReadyCondition, clusterv1.DeletingReason, clusterv1.ConditionSeverityInfo, "")
+
+	if _, err := s.EC2Client.DeleteKeyPairWithContext(context.TODO(), &ec2.DeleteKeyPairInput{KeyPairId: existing.KeyPairId}); err != nil {
+		conditions.MarkFalse(scope.InfraCluster(), infrav1.SSHKeyPairReadyCondition, "DeletingFailed", clusterv1.ConditionSeverityWarning, err.Error())
+		record.Warnf(scope.InfraCluster(), "FailedDeleteSSHKeyPair", "Failed to delete key pair %q: %v", key.Name, err)
+		return errors.Wrapf(err, "failed to delete key pair %q", key.Name)
+	}
+
+	scope.SetSSHKeyPair(nil)
+	conditions.MarkFalse(scope.InfraCluster(), infrav1.SSHKeyPairReadyCondition, clusterv1.DeletedReason, clusterv1.ConditionSeverityInfo, "")
+	record.Eventf(scope.InfraCluster(), "SuccessfulDeleteSSHKeyPair", "Deleted key pair %q", key.Name)
+	s.scope.Info("Deleted key pair", "name", key.Name)
+	return nil
+}
+
+// managedSSHKeyName returns the name of the key pair managed for the cluster, or nil if the cluster
+// doesn't manage one.
+func managedSSHKeyName(ec2Scope scope.EC2Scope) *string {
+	keyPairScope, ok := ec2Scope.(scope.SSHKeyPairScope)
+	if !ok || keyPairScope.SSHKey() == nil {
+		return nil
+	}
+	return aws.String(keyPairScope.SSHKey().Name)
+}
+
+func (s *Service) describeKeyPair(name string) (*ec2.KeyPairInfo, error) {
+	out, err := s.EC2Client.DescribeKeyPairsWithContext(context.TODO(), &ec2.DescribeKeyPairsInput{
+		KeyNames: []*string{aws.String(name)},
+	})
+	if err != nil {
+		if awserrors.IsNotFound(err) {
+			return nil, err
+		}
+		return nil, errors.Wrapf(err, "failed to describe key pair %q", name)
+	}
+	if len(out.KeyPairs) == 0 {
+		return nil, awserrors.NewNotFound("key pair not found")
+	}
+	return out.KeyPairs[0], nil
+}
+
+func (s *Service) importKeyPair(scope scope.SSHKeyPairScope, key *infrav1.SSHKey) (*infrav1.SSHKeyPairStatus, error) {
+	out, err := s.EC2Client.ImportKeyPairWithContext(context.TODO(), &ec2.ImportKeyPairInput{
+		KeyName:           aws.String(key.Name),
+		PublicKeyMaterial: []byte(key.PublicKeyMaterial),
+		TagSpecifications: []*ec2.TagSpecification{
+			tags.BuildParamsToTagSpecification(ec2.ResourceTypeKeyPair, infrav1.BuildParams{
+				ClusterName:        scope.Name(),
+				OwnershipTagPrefix: scope.OwnershipTagPrefix(),
+				Lifecycle:          infrav1.ResourceLifecycleOwned,
+				Name:               aws.String(key.Name),
+				Additional:         scope.AdditionalTags(),
+			}),
+		},
+	})
+	if err != nil {
+		record.Warnf(scope.InfraCluster(), "FailedImportSSHKeyPair", "Failed to import key pair %q: %v", key.Name, err)
+		return nil, errors.Wrapf(err, "failed to import key pair %q", key.Name)
+	}
+
+	record.Eventf(scope.InfraCluster(), "SuccessfulImportSSHKeyPair", "Imported key pair %q", key.Name)
+	s.scope.Info("Imported key pair", "name", key.Name, "id", aws.StringValue(out.KeyPairId))
+	return &infrav1.SSHKeyPairStatus{
+		KeyPairID:   aws.StringValue(out.KeyPairId),
+		Fingerprint: aws.StringValue(out.KeyFingerprint),
+	}, nil
+}
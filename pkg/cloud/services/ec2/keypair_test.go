@@ -0,0 +1,278 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestServiceReconcileSSHKeyPair(t *testing.T) {
+	clusterName := "cluster"
+	sshKey := &infrav1.SSHKey{Name: "cluster-key", PublicKeyMaterial: "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIC9z"}
+
+	describeInput := &ec2.DescribeKeyPairsInput{KeyNames: aws.StringSlice([]string{"cluster-key"})}
+	ownedTags := []*ec2.Tag{
+		{Key: aws.String(infrav1.ClusterTagKey(clusterName)), Value: aws.String(string(infrav1.ResourceLifecycleOwned))},
+	}
+
+	tests := []struct {
+		name            string
+		recorded        *infrav1.SSHKeyPairStatus
+		expect          func(m *mocks.MockEC2APIMockRecorder)
+		expectError     bool
+		expectStatus    *infrav1.SSHKeyPairStatus
+		expectCondition *clusterv1.Condition
+	}{
+		{
+			name: "imports the key pair when it doesn't exist",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeKeyPairsWithContext(context.TODO(), gomock.Eq(describeInput)).
+					Return(nil, awserr.New(awserrors.KeyPairNotFound, "not found", nil))
+				m.ImportKeyPairWithContext(context.TODO(), gomock.Eq(&ec2.ImportKeyPairInput{
+					KeyName:           aws.String("cluster-key"),
+					PublicKeyMaterial: []byte(sshKey.PublicKeyMaterial),
+					TagSpecifications: []*ec2.TagSpecification{
+						{
+							ResourceType: aws.String(ec2.ResourceTypeKeyPair),
+							Tags: []*ec2.Tag{
+								{Key: aws.String("Name"), Value: aws.String("cluster-key")},
+								{Key: aws.String(infrav1.ClusterTagKey(clusterName)), Value: aws.String(string(infrav1.ResourceLifecycleOwned))},
+							},
+						},
+					},
+				})).Return(&ec2.ImportKeyPairOutput{KeyPairId: aws.String("key-1"), KeyFingerprint: aws.String("fp-1")}, nil)
+			},
+			expectStatus:    &infrav1.SSHKeyPairStatus{KeyPairID: "key-1", Fingerprint: "fp-1"},
+			expectCondition: conditions.TrueCondition(infrav1.SSHKeyPairReadyCondition),
+		},
+		{
+			name:     "reports no drift when the fingerprint is unchanged",
+			recorded: &infrav1.SSHKeyPairStatus{KeyPairID: "key-1", Fingerprint: "fp-1"},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeKeyPairsWithContext(context.TODO(), gomock.Eq(describeInput)).
+					Return(&ec2.DescribeKeyPairsOutput{KeyPairs: []*ec2.KeyPairInfo{
+						{KeyName: aws.String("cluster-key"), KeyPairId: aws.String("key-1"), KeyFingerprint: aws.String("fp-1"), Tags: ownedTags},
+					}}, nil)
+			},
+			expectStatus:    &infrav1.SSHKeyPairStatus{KeyPairID: "key-1", Fingerprint: "fp-1"},
+			expectCondition: conditions.TrueCondition(infrav1.SSHKeyPairReadyCondition),
+		},
+		{
+			name:     "reports drift when the fingerprint changed",
+			recorded: &infrav1.SSHKeyPairStatus{KeyPairID: "key-1", Fingerprint: "fp-1"},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeKeyPairsWithContext(context.TODO(), gomock.Eq(describeInput)).
+					Return(&ec2.DescribeKeyPairsOutput{KeyPairs: []*ec2.KeyPairInfo{
+						{KeyName: aws.String("cluster-key"), KeyPairId: aws.String("key-1"), KeyFingerprint: aws.String("fp-2"), Tags: ownedTags},
+					}}, nil)
+			},
+			expectStatus: &infrav1.SSHKeyPairStatus{KeyPairID: "key-1", Fingerprint: "fp-1"},
+			expectCondition: conditions.FalseCondition(infrav1.SSHKeyPairReadyCondition, infrav1.SSHKeyPairDriftedReason, clusterv1.ConditionSeverityWarning,
+				"key pair %q has fingerprint %q, expected %q", "cluster-key", "fp-2", "fp-1"),
+		},
+		{
+			name: "records the owned key pair when the status was lost",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeKeyPairsWithContext(context.TODO(), gomock.Eq(describeInput)).
+					Return(&ec2.DescribeKeyPairsOutput{KeyPairs: []*ec2.KeyPairInfo{
+						{KeyName: aws.String("cluster-key"), KeyPairId: aws.String("key-1"), KeyFingerprint: aws.String("fp-1"), Tags: ownedTags},
+					}}, nil)
+			},
+			expectStatus:    &infrav1.SSHKeyPairStatus{KeyPairID: "key-1", Fingerprint: "fp-1"},
+			expectCondition: conditions.TrueCondition(infrav1.SSHKeyPairReadyCondition),
+		},
+		{
+			name: "refuses to take over a key pair it doesn't own",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeKeyPairsWithContext(context.TODO(), gomock.Eq(describeInput)).
+					Return(&ec2.DescribeKeyPairsOutput{KeyPairs: []*ec2.KeyPairInfo{
+						{KeyName: aws.String("cluster-key"), KeyPairId: aws.String("key-9"), KeyFingerprint: aws.String("fp-9")},
+					}}, nil)
+			},
+			expectError: true,
+			expectCondition: conditions.FalseCondition(infrav1.SSHKeyPairReadyCondition, infrav1.SSHKeyPairNotOwnedReason, clusterv1.ConditionSeverityError,
+				"key pair %q already exists and is not owned by the cluster", "cluster-key"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockControl := gomock.NewController(t)
+			defer mockControl.Finish()
+
+			ec2Mock := mocks.NewMockEC2API(mockControl)
+			clusterScope := newSSHKeyPairClusterScope(g, clusterName, sshKey, tc.recorded)
+
+			tc.expect(ec2Mock.EXPECT())
+			s := NewService(clusterScope)
+			s.EC2Client = ec2Mock
+
+			err := s.ReconcileSSHKeyPair(clusterScope)
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+
+			g.Expect(clusterScope.SSHKeyPair()).To(Equal(tc.expectStatus))
+			condition := conditions.Get(clusterScope.AWSCluster, infrav1.SSHKeyPairReadyCondition)
+			g.Expect(condition).NotTo(BeNil())
+			g.Expect(condition.Status).To(Equal(tc.expectCondition.Status))
+			g.Expect(condition.Reason).To(Equal(tc.expectCondition.Reason))
+			g.Expect(condition.Message).To(Equal(tc.expectCondition.Message))
+		})
+	}
+}
+
+func TestServiceDeleteSSHKeyPair(t *testing.T) {
+	clusterName := "cluster"
+	sshKey := &infrav1.SSHKey{Name: "cluster-key", PublicKeyMaterial: "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIC9z"}
+
+	describeInput := &ec2.DescribeKeyPairsInput{KeyNames: aws.StringSlice([]string{"cluster-key"})}
+
+	tests := []struct {
+		name         string
+		expect       func(m *mocks.MockEC2APIMockRecorder)
+		expectError  bool
+		expectStatus *infrav1.SSHKeyPairStatus
+	}{
+		{
+			name: "deletes the key pair owned by the cluster",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeKeyPairsWithContext(context.TODO(), gomock.Eq(describeInput)).
+					Return(&ec2.DescribeKeyPairsOutput{KeyPairs: []*ec2.KeyPairInfo{
+						{
+							KeyName:   aws.String("cluster-key"),
+							KeyPairId: aws.String("key-1"),
+							Tags: []*ec2.Tag{
+								{Key: aws.String(infrav1.ClusterTagKey(clusterName)), Value: aws.String(string(infrav1.ResourceLifecycleOwned))},
+							},
+						},
+					}}, nil)
+				m.DeleteKeyPairWithContext(context.TODO(), gomock.Eq(&ec2.DeleteKeyPairInput{KeyPairId: aws.String("key-1")})).
+					Return(&ec2.DeleteKeyPairOutput{}, nil)
+			},
+		},
+		{
+			name: "leaves a key pair the cluster doesn't own",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeKeyPairsWithContext(context.TODO(), gomock.Eq(describeInput)).
+					Return(&ec2.DescribeKeyPairsOutput{KeyPairs: []*ec2.KeyPairInfo{
+						{KeyName: aws.String("cluster-key"), KeyPairId: aws.String("key-1")},
+					}}, nil)
+			},
+			expectStatus: &infrav1.SSHKeyPairStatus{KeyPairID: "key-1", Fingerprint: "fp-1"},
+		},
+		{
+			name: "succeeds when the key pair is already gone",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeKeyPairsWithContext(context.TODO(), gomock.Eq(describeInput)).
+					Return(nil, awserr.New(awserrors.KeyPairNotFound, "not found", nil))
+			},
+		},
+		{
+			name: "returns an error when the key pair can't be deleted",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeKeyPairsWithContext(context.TODO(), gomock.Eq(describeInput)).
+					Return(&ec2.DescribeKeyPairsOutput{KeyPairs: []*ec2.KeyPairInfo{
+						{
+							KeyName:   aws.String("cluster-key"),
+							KeyPairId: aws.String("key-1"),
+							Tags: []*ec2.Tag{
+								{Key: aws.String(infrav1.ClusterTagKey(clusterName)), Value: aws.String(string(infrav1.ResourceLifecycleOwned))},
+							},
+						},
+					}}, nil)
+				m.DeleteKeyPairWithContext(context.TODO(), gomock.Eq(&ec2.DeleteKeyPairInput{KeyPairId: aws.String("key-1")})).
+					Return(nil, awserr.New("UnauthorizedOperation", "denied", nil))
+			},
+			expectError:  true,
+			expectStatus: &infrav1.SSHKeyPairStatus{KeyPairID: "key-1", Fingerprint: "fp-1"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockControl := gomock.NewController(t)
+			defer mockControl.Finish()
+
+			ec2Mock := mocks.NewMockEC2API(mockControl)
+			clusterScope := newSSHKeyPairClusterScope(g, clusterName, sshKey, &infrav1.SSHKeyPairStatus{KeyPairID: "key-1", Fingerprint: "fp-1"})
+
+			tc.expect(ec2Mock.EXPECT())
+			s := NewService(clusterScope)
+			s.EC2Client = ec2Mock
+
+			err := s.DeleteSSHKeyPair(clusterScope)
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			g.Expect(clusterScope.SSHKeyPair()).To(Equal(tc.expectStatus))
+		})
+	}
+}
+
+func newSSHKeyPairClusterScope(g *WithT, clusterName string, sshKey *infrav1.SSHKey, recorded *infrav1.SSHKeyPairStatus) *scope.ClusterScope {
+	scheme, err := setupScheme()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	awsCluster := &infrav1.AWSCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec: infrav1.AWSClusterSpec{
+			SSHKey: sshKey,
+		},
+		Status: infrav1.AWSClusterStatus{
+			SSHKeyPair: recorded,
+		},
+	}
+	client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(awsCluster).WithStatusSubresource(awsCluster).Build()
+
+	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns",
+				Name:      clusterName,
+			},
+		},
+		AWSCluster: awsCluster,
+		Client:     client,
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	return clusterScope
+}
@@ -20,6 +20,8 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"reflect"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -29,12 +31,14 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	apimachinerytypes "k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/filter"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/userdata"
@@ -51,6 +55,16 @@ const (
 	// See https://kubernetes.io/docs/concepts/overview/working-with-objects/annotations/
 	// for annotation formatting rules.
 	TagsLastAppliedAnnotation = "sigs.k8s.io/cluster-api-provider-aws-last-applied-tags"
+
+	// OverrideRootVolumesLastAppliedAnnotation is the key for the AWSMachinePool object annotation
+	// which tracks the root volumes last applied to the launch templates of instance type overrides,
+	// keyed by instance type.
+	OverrideRootVolumesLastAppliedAnnotation = "sigs.k8s.io/cluster-api-provider-aws-last-applied-override-root-volumes"
+
+	// LaunchTemplateValidationFailedAnnotation is the key for the AWSMachinePool object annotation which tracks
+	// a hash of the launch template, AMI, userdata and tags a new launch template version failed the validation
+	// with, so that the failed version isn't created again until one of them changes.
+	LaunchTemplateValidationFailedAnnotation = "sigs.k8s.io/cluster-api-provider-aws-launch-template-validation-failed"
 )
 
 // ReconcileLaunchTemplate reconciles a launch template and triggers instance refresh conditionally, depending on
@@ -63,6 +77,10 @@ func (s *Service) ReconcileLaunchTemplate(
 	canUpdateLaunchTemplate func() (bool, error),
 	runPostLaunchTemplateUpdateOperation func() error,
 ) error {
+	if ref := scope.GetLaunchTemplate().Ref; ref != nil {
+		return s.reconcileLaunchTemplateRef(scope, ref, canUpdateLaunchTemplate, runPostLaunchTemplateUpdateOperation)
+	}
+
 	bootstrapData, bootstrapDataSecretKey, err := scope.GetRawBootstrapData()
 	if err != nil {
 		record.Eventf(scope.GetMachinePool(), corev1.EventTypeWarning, "FailedGetBootstrapData", err.Error())
@@ -79,12 +97,37 @@ func (s *Service) ReconcileLaunchTemplate(
 
 	imageID, err := ec2svc.DiscoverLaunchTemplateAMI(scope)
 	if err != nil {
-		conditions.MarkFalse(scope.GetSetter(), expinfrav1.LaunchTemplateReadyCondition, expinfrav1.LaunchTemplateCreateFailedReason, clusterv1.ConditionSeverityError, err.Error())
+		switch {
+		case awserrors.IsFailedDependency(errors.Cause(err)):
+			conditions.MarkFalse(scope.GetSetter(), expinfrav1.LaunchTemplateReadyCondition, expinfrav1.AMICopyInProgressReason, clusterv1.ConditionSeverityInfo, err.Error())
+		case errors.Is(err, ErrAMICopyFailed):
+			conditions.MarkFalse(scope.GetSetter(), expinfrav1.LaunchTemplateReadyCondition, expinfrav1.AMICopyFailedReason, clusterv1.ConditionSeverityError, err.Error())
+		default:
+			conditions.MarkFalse(scope.GetSetter(), expinfrav1.LaunchTemplateReadyCondition, expinfrav1.LaunchTemplateCreateFailedReason, clusterv1.ConditionSeverityError, err.Error())
+		}
 		return err
 	}
 
+	if err := s.reconcileAdditionalSecurityGroups(scope); err != nil {
+		if launchTemplate == nil {
+			return err
+		}
+		// The security groups last resolved are kept in the launch template rather than failing the reconcile, the
+		// condition reports the problem until they can be resolved again.
+		scope.Info("failed to resolve additional security groups, keeping the security groups last resolved", "error", err.Error())
+	}
+
 	if launchTemplate == nil {
-		scope.Info("no existing launch template found, creating")
+		// A launch template ID in the status means the launch template was deleted outside of the controller,
+		// the IDs and version recorded for it are stale.
+		deletedLaunchTemplateID := scope.GetLaunchTemplateIDStatus()
+		if deletedLaunchTemplateID != "" {
+			scope.Info("launch template was deleted outside of the controller, recreating", "id", deletedLaunchTemplateID)
+			scope.SetLaunchTemplateIDStatus("")
+			scope.SetLaunchTemplateLatestVersionStatus("")
+		} else {
+			scope.Info("no existing launch template found, creating")
+		}
 		launchTemplateID, err := ec2svc.CreateLaunchTemplate(scope, imageID, *bootstrapDataSecretKey, bootstrapData)
 		if err != nil {
 			conditions.MarkFalse(scope.GetSetter(), expinfrav1.LaunchTemplateReadyCondition, expinfrav1.LaunchTemplateCreateFailedReason, clusterv1.ConditionSeverityError, err.Error())
@@ -92,7 +135,31 @@ func (s *Service) ReconcileLaunchTemplate(
 		}
 
 		scope.SetLaunchTemplateIDStatus(launchTemplateID)
-		return scope.PatchObject()
+
+		if deletedLaunchTemplateID == "" {
+			// The autoscaling group does not exist yet, so there is nothing to roll out.
+			if _, err := s.reconcileOverrideLaunchTemplates(scope, ec2svc, imageID, *bootstrapDataSecretKey, bootstrapData, false, canUpdateLaunchTemplate); err != nil {
+				return err
+			}
+			return scope.PatchObject()
+		}
+
+		record.Warnf(scope.GetMachinePool(), "LaunchTemplateRecreated", "Launch template %s was deleted outside of the controller, replaced by %s", deletedLaunchTemplateID, launchTemplateID)
+		if _, err := s.reconcileOverrideLaunchTemplates(scope, ec2svc, imageID, *bootstrapDataSecretKey, bootstrapData, false, canUpdateLaunchTemplate); err != nil {
+			return err
+		}
+		if err := scope.PatchObject(); err != nil {
+			return err
+		}
+
+		// The autoscaling group still references the deleted launch template, point it to the new one and
+		// refresh its instances.
+		if err := runPostLaunchTemplateUpdateOperation(); err != nil {
+			conditions.MarkFalse(scope.GetSetter(), expinfrav1.PostLaunchTemplateUpdateOperationCondition, expinfrav1.PostLaunchTemplateUpdateOperationFailedReason, clusterv1.ConditionSeverityError, err.Error())
+			return err
+		}
+		conditions.MarkTrue(scope.GetSetter(), expinfrav1.PostLaunchTemplateUpdateOperationCondition)
+		return nil
 	}
 
 	// LaunchTemplateID is set during LaunchTemplate creation, but for a scenario such as `clusterctl move`, status fields become blank.
@@ -127,22 +194,50 @@ func (s *Service) ReconcileLaunchTemplate(
 	// Check if the instance tags were changed. If they were, create a new LaunchTemplate.
 	tagsChanged, _, _, _ := tagsChanged(annotation, scope.AdditionalTags()) //nolint:dogsled
 
-	needsUpdate, err := ec2svc.LaunchTemplateNeedsUpdate(scope, scope.GetLaunchTemplate(), launchTemplate)
+	changedFields, err := ec2svc.LaunchTemplateChangedFields(scope, scope.GetLaunchTemplate(), launchTemplate)
 	if err != nil {
 		return err
 	}
 
-	amiChanged := *imageID != *launchTemplate.AMI.ID
+	if *imageID != *launchTemplate.AMI.ID {
+		changedFields = append(changedFields, launchTemplateFieldAMI)
+	}
+	if tagsChanged {
+		changedFields = append(changedFields, launchTemplateFieldTags)
+	}
+	if launchTemplateUserDataHash != bootstrapDataHash {
+		changedFields = append(changedFields, launchTemplateFieldUserData)
+	}
 
 	// `launchTemplateUserDataSecretKey` can be nil since it comes from a tag on the launch template
 	// which may not exist in older launch templates created by older CAPA versions.
 	// On change, we trigger instance refresh (rollout of new nodes). Therefore, do not consider it a change if the
 	// launch template does not have the respective tag yet, as it could be surprising to users. Instead, ensure the
 	// tag is stored on the newly-generated launch template version, without rolling out nodes.
-	userDataSecretKeyChanged := launchTemplateUserDataSecretKey != nil && bootstrapDataSecretKey.String() != launchTemplateUserDataSecretKey.String()
+	if launchTemplateUserDataSecretKey != nil && bootstrapDataSecretKey.String() != launchTemplateUserDataSecretKey.String() {
+		changedFields = append(changedFields, launchTemplateFieldUserDataSecret)
+	}
 	launchTemplateNeedsUserDataSecretKeyTag := launchTemplateUserDataSecretKey == nil
 
-	if needsUpdate || tagsChanged || amiChanged || userDataSecretKeyChanged {
+	changeScope, reportsChange := launchTemplateChangeScope(scope)
+	change := classifyLaunchTemplateChange(changedFields, reportsChange && changeScope.TriggerRefreshOnUserDataChange())
+
+	// A version which failed the validation would fail it again, it isn't created until the inputs change.
+	var validationHash string
+	if scope.GetLaunchTemplate().ValidateBeforeUse && (change != nil || launchTemplateNeedsUserDataSecretKeyTag) {
+		validationHash, err = launchTemplateValidationHash(scope, *imageID, bootstrapDataHash)
+		if err != nil {
+			return err
+		}
+		if machinePoolAnnotation(scope, LaunchTemplateValidationFailedAnnotation) == validationHash {
+			scope.Debug("launch template version failed validation before, waiting for a change", "changedFields", changedFields)
+			change = nil
+			launchTemplateNeedsUserDataSecretKeyTag = false
+		}
+	}
+	rollOut := change != nil && change.RolledOut
+
+	if rollOut {
 		canUpdate, err := canUpdateLaunchTemplate()
 		if err != nil {
 			return err
@@ -153,18 +248,16 @@ func (s *Service) ReconcileLaunchTemplate(
 		}
 	}
 
-	userDataHashChanged := launchTemplateUserDataHash != bootstrapDataHash
-
 	// Create a new launch template version if there's a difference in configuration, tags,
 	// userdata, OR we've discovered a new AMI ID.
-	if needsUpdate || tagsChanged || amiChanged || userDataHashChanged || userDataSecretKeyChanged || launchTemplateNeedsUserDataSecretKeyTag {
-		scope.Info("creating new version for launch template", "existing", launchTemplate, "incoming", scope.GetLaunchTemplate(), "needsUpdate", needsUpdate, "tagsChanged", tagsChanged, "amiChanged", amiChanged, "userDataHashChanged", userDataHashChanged, "userDataSecretKeyChanged", userDataSecretKeyChanged)
+	if change != nil || launchTemplateNeedsUserDataSecretKeyTag {
+		scope.Info("creating new version for launch template", "existing", launchTemplate, "incoming", scope.GetLaunchTemplate(), "changedFields", changedFields)
 		// There is a limit to the number of Launch Template Versions.
 		// We ensure that the number of versions does not grow without bound by following a simple rule: Before we create a new version, we delete one old version, if there is at least one old version that is not in use.
-		if err := ec2svc.PruneLaunchTemplateVersions(scope.GetLaunchTemplateIDStatus()); err != nil {
+		if err := ec2svc.PruneLaunchTemplateVersions(scope.GetLaunchTemplateIDStatus(), previousLaunchTemplateVersion(scope)); err != nil {
 			return err
 		}
-		if err := ec2svc.CreateLaunchTemplateVersion(scope.GetLaunchTemplateIDStatus(), scope, imageID, *bootstrapDataSecretKey, bootstrapData); err != nil {
+		if err := s.createLaunchTemplateVersion(scope, ec2svc, imageID, *bootstrapDataSecretKey, bootstrapData, canUpdateLaunchTemplate); err != nil {
 			return err
 		}
 		version, err := ec2svc.GetLaunchTemplateLatestVersion(scope.GetLaunchTemplateIDStatus())
@@ -172,13 +265,202 @@ func (s *Service) ReconcileLaunchTemplate(
 			return err
 		}
 
+		if scope.GetLaunchTemplate().ValidateBeforeUse {
+			// The ASG launches the version recorded in the status rather than the latest one, so the new version
+			// isn't launched before it passes the validation.
+			if err := ec2svc.ValidateLaunchTemplateVersion(scope, scope.GetLaunchTemplateIDStatus(), version); err != nil {
+				record.Warnf(scope.GetMachinePool(), "FailedLaunchTemplateValidation", "Launch template version %s failed validation: %v", version, err)
+				conditions.MarkTrueWithNegativePolarity(scope.GetSetter(), expinfrav1.LaunchTemplateValidationFailedCondition, expinfrav1.LaunchTemplateDryRunFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+				if err := ec2svc.DeleteLaunchTemplateVersion(scope.GetLaunchTemplateIDStatus(), version); err != nil {
+					return err
+				}
+				updateMachinePoolAnnotation(scope, LaunchTemplateValidationFailedAnnotation, validationHash)
+				return scope.PatchObject()
+			}
+			conditions.Delete(scope.GetSetter(), expinfrav1.LaunchTemplateValidationFailedCondition)
+			deleteMachinePoolAnnotation(scope, LaunchTemplateValidationFailedAnnotation)
+		}
+
 		scope.SetLaunchTemplateLatestVersionStatus(version)
+		if change != nil && reportsChange {
+			change.Version = version
+			changeScope.SetLaunchTemplateChangeStatus(change)
+		}
 		if err := scope.PatchObject(); err != nil {
 			return err
 		}
+
+		if retention := scope.GetLaunchTemplate().LaunchTemplateVersionRetention; retention != nil {
+			s.pruneLaunchTemplateVersionsForRetention(scope, ec2svc, int(*retention), canUpdateLaunchTemplate)
+		}
+	}
+
+	overridesChanged, err := s.reconcileOverrideLaunchTemplates(scope, ec2svc, imageID, *bootstrapDataSecretKey, bootstrapData, tagsChanged, canUpdateLaunchTemplate)
+	if err != nil {
+		return err
+	}
+
+	if rollOut || overridesChanged {
+		if err := runPostLaunchTemplateUpdateOperation(); err != nil {
+			conditions.MarkFalse(scope.GetSetter(), expinfrav1.PostLaunchTemplateUpdateOperationCondition, expinfrav1.PostLaunchTemplateUpdateOperationFailedReason, clusterv1.ConditionSeverityError, err.Error())
+			return err
+		}
+		conditions.MarkTrue(scope.GetSetter(), expinfrav1.PostLaunchTemplateUpdateOperationCondition)
+	}
+
+	switch {
+	case change == nil, !reportsChange:
+	case change.RolledOut:
+		record.Eventf(scope.GetMachinePool(), "LaunchTemplateChangeRolledOut", "Launch template version %s changed %s, rolling it out to the instances",
+			change.Version, strings.Join(change.ChangedFields, ", "))
+	default:
+		record.Eventf(scope.GetMachinePool(), "LaunchTemplateChangeNotRolledOut", "Launch template version %s only changed the userdata, the instances run it once they are replaced. "+
+			"Set spec.refreshPreferences.triggerOnUserDataChange to roll out such changes", change.Version)
+	}
+
+	return nil
+}
+
+const (
+	// launchTemplateFieldAMI is the name reported for a change of the AMI of a launch template.
+	launchTemplateFieldAMI = "ami"
+	// launchTemplateFieldTags is the name reported for a change of the tags of a launch template.
+	launchTemplateFieldTags = "additionalTags"
+	// launchTemplateFieldUserData is the name reported for a change of the userdata of a launch template.
+	launchTemplateFieldUserData = "userData"
+	// launchTemplateFieldUserDataSecret is the name reported for a change of the secret holding the bootstrap
+	// data of a launch template, which comes with a new bootstrap configuration.
+	launchTemplateFieldUserDataSecret = "userDataSecret"
+)
+
+// classifyLaunchTemplateChange classifies a change of a launch template from the names of the fields which changed.
+// It returns nil without any change. The change is rolled out to the instances unless only the userdata changed,
+// in which case it is only rolled out when triggerOnUserDataChange is set.
+func classifyLaunchTemplateChange(changedFields []string, triggerOnUserDataChange bool) *expinfrav1.LaunchTemplateChange {
+	if len(changedFields) == 0 {
+		return nil
+	}
+
+	change := &expinfrav1.LaunchTemplateChange{
+		ChangedFields: changedFields,
+		UserDataOnly:  true,
+		Time:          metav1.Now(),
+	}
+	for _, field := range changedFields {
+		switch field {
+		case launchTemplateFieldAMI:
+			change.AMIChanged = true
+		case launchTemplateFieldTags, launchTemplateFieldUserDataSecret:
+		case launchTemplateFieldUserData:
+			continue
+		default:
+			change.HardwareConfigChanged = true
+		}
+		change.UserDataOnly = false
+	}
+	change.RolledOut = !change.UserDataOnly || triggerOnUserDataChange
+	return change
+}
+
+// launchTemplateChangeScope returns the scope as a LaunchTemplateChangeScope if it reports the last change of its
+// launch template.
+func launchTemplateChangeScope(lts scope.LaunchTemplateScope) (scope.LaunchTemplateChangeScope, bool) {
+	changeScope, ok := lts.(scope.LaunchTemplateChangeScope)
+	return changeScope, ok
+}
+
+// createLaunchTemplateVersion creates a new version of the launch template of the machine pool. When the launch
+// template version quota is exceeded, old versions are pruned to make room for the new version and the creation is
+// retried once. The launch template is reported as not ready when no version can be pruned.
+func (s *Service) createLaunchTemplateVersion(
+	scope scope.LaunchTemplateScope,
+	ec2svc services.EC2Interface,
+	imageID *string,
+	userDataSecretKey apimachinerytypes.NamespacedName,
+	userData []byte,
+	canUpdateLaunchTemplate func() (bool, error),
+) error {
+	id := scope.GetLaunchTemplateIDStatus()
+	err := ec2svc.CreateLaunchTemplateVersion(id, scope, imageID, userDataSecretKey, userData)
+	if err == nil || !awserrors.IsLimitExceeded(errors.Cause(err)) {
+		return err
+	}
+
+	// The versions an in-flight instance refresh rolls out must not be deleted from under it.
+	canUpdate, checkErr := canUpdateLaunchTemplate()
+	if checkErr != nil {
+		return checkErr
+	}
+	pruned, pruneErr := ec2svc.PruneLaunchTemplateVersionsForQuota(id, []string{scope.GetLaunchTemplateLatestVersionStatus(), previousLaunchTemplateVersion(scope)}, !canUpdate)
+	if pruneErr != nil {
+		return pruneErr
+	}
+	if pruned == 0 {
+		conditions.MarkFalse(scope.GetSetter(), expinfrav1.LaunchTemplateReadyCondition, expinfrav1.LaunchTemplateVersionLimitExceededReason, clusterv1.ConditionSeverityError,
+			"launch template version quota exceeded and no version of launch template %s can be pruned", id)
+		record.Warnf(scope.GetMachinePool(), "LaunchTemplateVersionLimitExceeded", "Launch template version quota exceeded and no version of launch template %s can be pruned", id)
+		return errors.Wrapf(err, "no version of launch template %q can be pruned", id)
+	}
+
+	record.Eventf(scope.GetMachinePool(), "PrunedLaunchTemplateVersions", "Deleted %d versions of launch template %s to stay within the launch template version quota", pruned, id)
+	return ec2svc.CreateLaunchTemplateVersion(id, scope, imageID, userDataSecretKey, userData)
+}
+
+// pruneLaunchTemplateVersionsForRetention deletes the versions of the launch template of the machine pool beyond
+// the retained ones after a new version was created. Failures are only reported, as the new version is in place.
+func (s *Service) pruneLaunchTemplateVersionsForRetention(scope scope.LaunchTemplateScope, ec2svc services.EC2Interface, retain int, canUpdateLaunchTemplate func() (bool, error)) {
+	id := scope.GetLaunchTemplateIDStatus()
+	// The versions an in-flight instance refresh rolls out must not be deleted from under it.
+	canUpdate, err := canUpdateLaunchTemplate()
+	if err == nil {
+		var pruned int
+		pruned, err = ec2svc.PruneLaunchTemplateVersionsForRetention(id, retain, []string{previousLaunchTemplateVersion(scope)}, !canUpdate)
+		if pruned > 0 {
+			record.Eventf(scope.GetMachinePool(), "PrunedLaunchTemplateVersions", "Deleted %d versions of launch template %s beyond the %d retained versions", pruned, id, retain)
+		}
+	}
+	if err != nil {
+		scope.Error(err, "failed to prune launch template versions beyond the retained versions", "id", id)
+		record.Warnf(scope.GetMachinePool(), "FailedPruneLaunchTemplateVersions", "Failed to prune the versions of launch template %s: %v", id, err)
 	}
+}
+
+// reconcileLaunchTemplateRef resolves a launch template managed outside of the controller. The template is never
+// created or modified, a change of the referenced version triggers the post update operation instead, so that the
+// autoscaling group is updated and its instances are refreshed.
+func (s *Service) reconcileLaunchTemplateRef(
+	scope scope.LaunchTemplateScope,
+	ref *expinfrav1.LaunchTemplateReference,
+	canUpdateLaunchTemplate func() (bool, error),
+	runPostLaunchTemplateUpdateOperation func() error,
+) error {
+	launchTemplateID, err := s.getLaunchTemplateRefID(ref)
+	if err != nil {
+		conditions.MarkUnknown(scope.GetSetter(), expinfrav1.LaunchTemplateReadyCondition, expinfrav1.LaunchTemplateNotFoundReason, err.Error())
+		return err
+	}
+	scope.SetLaunchTemplateIDStatus(launchTemplateID)
+
+	previousVersion := scope.GetLaunchTemplateLatestVersionStatus()
+	if previousVersion == ref.Version {
+		conditions.MarkTrue(scope.GetSetter(), expinfrav1.LaunchTemplateReadyCondition)
+		return nil
+	}
+
+	// Without a previous version, the autoscaling group doesn't exist yet or the status was lost, and there is
+	// nothing to roll out.
+	if previousVersion != "" {
+		canUpdate, err := canUpdateLaunchTemplate()
+		if err != nil {
+			return err
+		}
+		if !canUpdate {
+			conditions.MarkFalse(scope.GetSetter(), expinfrav1.PreLaunchTemplateUpdateCheckCondition, expinfrav1.PreLaunchTemplateUpdateCheckFailedReason, clusterv1.ConditionSeverityWarning, "")
+			return errors.New("Cannot update the launch template, prerequisite not met")
+		}
 
-	if needsUpdate || tagsChanged || amiChanged || userDataSecretKeyChanged {
+		// The version is only recorded once the rollout started, so that a failed rollout is retried.
+		scope.Info("referenced launch template version changed", "id", launchTemplateID, "previous", previousVersion, "version", ref.Version)
 		if err := runPostLaunchTemplateUpdateOperation(); err != nil {
 			conditions.MarkFalse(scope.GetSetter(), expinfrav1.PostLaunchTemplateUpdateOperationCondition, expinfrav1.PostLaunchTemplateUpdateOperationFailedReason, clusterv1.ConditionSeverityError, err.Error())
 			return err
@@ -186,6 +468,233 @@ func (s *Service) ReconcileLaunchTemplate(
 		conditions.MarkTrue(scope.GetSetter(), expinfrav1.PostLaunchTemplateUpdateOperationCondition)
 	}
 
+	scope.SetLaunchTemplateLatestVersionStatus(ref.Version)
+	conditions.MarkTrue(scope.GetSetter(), expinfrav1.LaunchTemplateReadyCondition)
+	return scope.PatchObject()
+}
+
+// getLaunchTemplateRefID returns the ID of the referenced launch template, failing if it doesn't exist.
+func (s *Service) getLaunchTemplateRefID(ref *expinfrav1.LaunchTemplateReference) (string, error) {
+	input := &ec2.DescribeLaunchTemplatesInput{}
+	if ref.ID != nil {
+		input.LaunchTemplateIds = aws.StringSlice([]string{*ref.ID})
+	} else {
+		input.LaunchTemplateNames = aws.StringSlice([]string{aws.StringValue(ref.Name)})
+	}
+
+	out, err := s.EC2Client.DescribeLaunchTemplatesWithContext(context.TODO(), input)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to describe the referenced launch template")
+	}
+	if len(out.LaunchTemplates) == 0 {
+		return "", errors.New("referenced launch template not found")
+	}
+
+	return aws.StringValue(out.LaunchTemplates[0].LaunchTemplateId), nil
+}
+
+// overrideLaunchTemplateScope is the scope of the launch template of an instance type override. The launch
+// template is derived from the primary one and only differs in name, instance type, root volume and user data.
+type overrideLaunchTemplateScope struct {
+	scope.LaunchTemplateScope
+
+	name           string
+	launchTemplate *expinfrav1.AWSLaunchTemplate
+}
+
+func newOverrideLaunchTemplateScope(lts scope.LaunchTemplateScope, override expinfrav1.Overrides) *overrideLaunchTemplateScope {
+	launchTemplate := lts.GetLaunchTemplate().DeepCopy()
+	launchTemplate.InstanceType = override.InstanceType
+	if override.RootVolume != nil {
+		launchTemplate.RootVolume = override.RootVolume.DeepCopy()
+	}
+
+	return &overrideLaunchTemplateScope{
+		LaunchTemplateScope: lts,
+		name:                scope.OverrideLaunchTemplateName(lts.LaunchTemplateName(), override.InstanceType),
+		launchTemplate:      launchTemplate,
+	}
+}
+
+// LaunchTemplateName returns the name of the launch template of the instance type override.
+func (o *overrideLaunchTemplateScope) LaunchTemplateName() string {
+	return o.name
+}
+
+// GetLaunchTemplate returns the launch template of the instance type override.
+func (o *overrideLaunchTemplateScope) GetLaunchTemplate() *expinfrav1.AWSLaunchTemplate {
+	return o.launchTemplate
+}
+
+// reconcileOverrideLaunchTemplates reconciles the launch templates of the instance type overrides which set
+// their own root volume or bootstrap data. They follow the primary launch template: a new version is created
+// whenever the primary launch template would get one, or when the root volume or the bootstrap data of the override
+// changed. The bootstrap data of every override is compared on its own, so that only the launch templates of the
+// overrides whose bootstrap data changed get a new version. Launch templates of removed overrides are kept in the
+// status, they can only be deleted once the autoscaling group no longer references them.
+// It returns whether an existing launch template changed in a way that requires a rollout.
+//
+//nolint:gocyclo
+func (s *Service) reconcileOverrideLaunchTemplates(
+	lts scope.LaunchTemplateScope,
+	ec2svc services.EC2Interface,
+	imageID *string,
+	bootstrapDataSecretKey apimachinerytypes.NamespacedName,
+	bootstrapData []byte,
+	tagsChanged bool,
+	canUpdateLaunchTemplate func() (bool, error),
+) (bool, error) {
+	overridesScope, ok := lts.(scope.LaunchTemplateOverridesScope)
+	if !ok {
+		return false, nil
+	}
+
+	overrides := overridesScope.GetLaunchTemplateOverrides()
+	existingStatus := overridesScope.GetOverrideLaunchTemplatesStatus()
+	if len(overrides) == 0 && len(existingStatus) == 0 {
+		return false, nil
+	}
+
+	lastApplied := map[string]infrav1.Volume{}
+	if annotation := machinePoolAnnotation(lts, OverrideRootVolumesLastAppliedAnnotation); annotation != "" {
+		if err := json.Unmarshal([]byte(annotation), &lastApplied); err != nil {
+			return false, err
+		}
+	}
+
+	applied := map[string]infrav1.Volume{}
+	status := make([]expinfrav1.OverrideLaunchTemplate, 0, len(existingStatus))
+	needsRollout := false
+	checkedCanUpdate := false
+
+	for _, override := range overrides {
+		ots := newOverrideLaunchTemplateScope(lts, override)
+
+		overrideBootstrapDataSecretKey, overrideBootstrapData := bootstrapDataSecretKey, bootstrapData
+		ownUserData := scope.GetOverrideUserData(lts.GetLaunchTemplate(), override.InstanceType) != nil
+		if ownUserData {
+			data, key, err := overridesScope.GetOverrideRawBootstrapData(override.InstanceType)
+			if err != nil {
+				record.Eventf(lts.GetMachinePool(), corev1.EventTypeWarning, "FailedGetBootstrapData", err.Error())
+				return false, err
+			}
+			overrideBootstrapDataSecretKey, overrideBootstrapData = *key, data
+		}
+
+		entry := expinfrav1.OverrideLaunchTemplate{InstanceType: override.InstanceType}
+		if existing := findOverrideLaunchTemplate(existingStatus, override.InstanceType); existing != nil {
+			entry = *existing.DeepCopy()
+		}
+
+		launchTemplate, launchTemplateUserDataHash, launchTemplateUserDataSecretKey, err := ec2svc.GetLaunchTemplate(ots.LaunchTemplateName())
+		if err != nil {
+			conditions.MarkUnknown(lts.GetSetter(), expinfrav1.LaunchTemplateReadyCondition, expinfrav1.LaunchTemplateNotFoundReason, err.Error())
+			return false, err
+		}
+
+		if launchTemplate == nil {
+			// Instances of this type are launched from the new launch template once the autoscaling group
+			// references it, existing instances are not replaced.
+			ots.Info("no existing launch template found for instance type override, creating", "instanceType", override.InstanceType)
+			launchTemplateID, err := ec2svc.CreateLaunchTemplate(ots, imageID, overrideBootstrapDataSecretKey, overrideBootstrapData)
+			if err != nil {
+				conditions.MarkFalse(lts.GetSetter(), expinfrav1.LaunchTemplateReadyCondition, expinfrav1.LaunchTemplateCreateFailedReason, clusterv1.ConditionSeverityError, err.Error())
+				return false, err
+			}
+			entry.ID = launchTemplateID
+			entry.Version = nil
+		} else {
+			if entry.ID == "" {
+				launchTemplateID, err := ec2svc.GetLaunchTemplateID(ots.LaunchTemplateName())
+				if err != nil {
+					conditions.MarkUnknown(lts.GetSetter(), expinfrav1.LaunchTemplateReadyCondition, expinfrav1.LaunchTemplateNotFoundReason, err.Error())
+					return false, err
+				}
+				entry.ID = launchTemplateID
+			}
+
+			changedFields, err := ec2svc.LaunchTemplateChangedFields(ots, ots.GetLaunchTemplate(), launchTemplate)
+			if err != nil {
+				return false, err
+			}
+			needsUpdate := len(changedFields) > 0
+
+			lastAppliedRootVolume, ok := lastApplied[override.InstanceType]
+			rootVolumeChanged := ok != (override.RootVolume != nil) || (ok && !cmp.Equal(lastAppliedRootVolume, *override.RootVolume))
+			amiChanged := *imageID != *launchTemplate.AMI.ID
+			userDataSecretKeyChanged := launchTemplateUserDataSecretKey != nil && overrideBootstrapDataSecretKey.String() != launchTemplateUserDataSecretKey.String()
+			userDataHashChanged := launchTemplateUserDataHash != userdata.ComputeHash(overrideBootstrapData)
+			userDataChanged := userDataHashChanged || launchTemplateUserDataSecretKey == nil
+			// The bootstrap data of the MachinePool changes along with its secret, while the one of an override
+			// is changed in place.
+			ownUserDataChanged := ownUserData && userDataHashChanged && launchTemplateUserDataSecretKey != nil
+			changed := needsUpdate || tagsChanged || amiChanged || userDataSecretKeyChanged || rootVolumeChanged || ownUserDataChanged
+
+			if changed && !checkedCanUpdate {
+				canUpdate, err := canUpdateLaunchTemplate()
+				if err != nil {
+					return false, err
+				}
+				if !canUpdate {
+					conditions.MarkFalse(lts.GetSetter(), expinfrav1.PreLaunchTemplateUpdateCheckCondition, expinfrav1.PreLaunchTemplateUpdateCheckFailedReason, clusterv1.ConditionSeverityWarning, "")
+					return false, errors.New("Cannot update the launch template, prerequisite not met")
+				}
+				checkedCanUpdate = true
+			}
+
+			if changed || userDataChanged {
+				ots.Info("creating new version for launch template of instance type override", "instanceType", override.InstanceType, "needsUpdate", needsUpdate, "tagsChanged", tagsChanged, "amiChanged", amiChanged, "rootVolumeChanged", rootVolumeChanged, "userDataChanged", userDataChanged)
+				if err := ec2svc.PruneLaunchTemplateVersions(entry.ID, ""); err != nil {
+					return false, err
+				}
+				if err := ec2svc.CreateLaunchTemplateVersion(entry.ID, ots, imageID, overrideBootstrapDataSecretKey, overrideBootstrapData); err != nil {
+					return false, err
+				}
+				entry.Version = nil
+			}
+			needsRollout = needsRollout || changed
+		}
+
+		if entry.Version == nil {
+			version, err := ec2svc.GetLaunchTemplateLatestVersion(entry.ID)
+			if err != nil {
+				conditions.MarkUnknown(lts.GetSetter(), expinfrav1.LaunchTemplateReadyCondition, expinfrav1.LaunchTemplateNotFoundReason, err.Error())
+				return false, err
+			}
+			entry.Version = &version
+		}
+
+		if override.RootVolume != nil {
+			applied[override.InstanceType] = *override.RootVolume
+		}
+		status = append(status, entry)
+	}
+
+	for i := range existingStatus {
+		if findOverrideLaunchTemplate(status, existingStatus[i].InstanceType) == nil {
+			status = append(status, existingStatus[i])
+		}
+	}
+
+	appliedJSON, err := json.Marshal(applied)
+	if err != nil {
+		return false, err
+	}
+	if cmp.Equal(status, existingStatus) && string(appliedJSON) == machinePoolAnnotation(lts, OverrideRootVolumesLastAppliedAnnotation) {
+		return needsRollout, nil
+	}
+
+	overridesScope.SetOverrideLaunchTemplatesStatus(status)
+	updateMachinePoolAnnotation(lts, OverrideRootVolumesLastAppliedAnnotation, string(appliedJSON))
+	return needsRollout, lts.PatchObject()
+}
+
+func findOverrideLaunchTemplate(templates []expinfrav1.OverrideLaunchTemplate, instanceType string) *expinfrav1.OverrideLaunchTemplate {
+	for i := range templates {
+		if templates[i].InstanceType == instanceType {
+			return &templates[i]
+		}
+	}
 	return nil
 }
 
@@ -276,6 +785,30 @@ func updateMachinePoolAnnotation(lts scope.LaunchTemplateScope, annotation, cont
 	lts.GetObjectMeta().SetAnnotations(annotations)
 }
 
+func deleteMachinePoolAnnotation(lts scope.LaunchTemplateScope, annotation string) {
+	annotations := lts.GetObjectMeta().GetAnnotations()
+	if _, ok := annotations[annotation]; !ok {
+		return
+	}
+	delete(annotations, annotation)
+	lts.GetObjectMeta().SetAnnotations(annotations)
+}
+
+// launchTemplateValidationHash returns a hash of the inputs of a new launch template version: the launch template
+// of the spec, the AMI, the userdata and the additional tags.
+func launchTemplateValidationHash(lts scope.LaunchTemplateScope, imageID, bootstrapDataHash string) (string, error) {
+	b, err := json.Marshal(struct {
+		LaunchTemplate *expinfrav1.AWSLaunchTemplate `json:"launchTemplate"`
+		ImageID        string                        `json:"imageID"`
+		UserDataHash   string                        `json:"userDataHash"`
+		Tags           infrav1.Tags                  `json:"tags"`
+	}{lts.GetLaunchTemplate(), imageID, bootstrapDataHash, lts.AdditionalTags()})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal the launch template inputs")
+	}
+	return userdata.ComputeHash(b), nil
+}
+
 // tagsChanged determines which tags to delete and which to add.
 func tagsChanged(annotation map[string]interface{}, src map[string]string) (bool, map[string]string, map[string]string, map[string]interface{}) {
 	// Bool tracking if we found any changed state.
@@ -416,13 +949,15 @@ func (s *Service) CreateLaunchTemplate(scope scope.LaunchTemplateScope, imageID
 	additionalTags := scope.AdditionalTags()
 	// Set the cloud provider tag
 	additionalTags[infrav1.ClusterAWSCloudProviderTagKey(s.scope.KubernetesClusterName())] = string(infrav1.ResourceLifecycleOwned)
+	s.scope.LegacyClusterTag().Apply(additionalTags, s.scope.KubernetesClusterName(), infrav1.ResourceLifecycleOwned)
 
 	tags := infrav1.Build(infrav1.BuildParams{
-		ClusterName: s.scope.KubernetesClusterName(),
-		Lifecycle:   infrav1.ResourceLifecycleOwned,
-		Name:        aws.String(scope.LaunchTemplateName()),
-		Role:        aws.String("node"),
-		Additional:  additionalTags,
+		ClusterName:        s.scope.KubernetesClusterName(),
+		OwnershipTagPrefix: s.scope.OwnershipTagPrefix(),
+		Lifecycle:          infrav1.ResourceLifecycleOwned,
+		Name:               aws.String(scope.LaunchTemplateName()),
+		Role:               aws.String("node"),
+		Additional:         additionalTags,
 	})
 
 	if len(tags) > 0 {
@@ -473,6 +1008,10 @@ func (s *Service) createLaunchTemplateData(scope scope.LaunchTemplateScope, imag
 	if lt.SSHKeyName != nil && *lt.SSHKeyName != "" {
 		sshKeyNamePtr = lt.SSHKeyName
 	}
+	// Without a key name of its own, the launch template uses the key pair managed for the cluster, if any.
+	if lt.SSHKeyName == nil {
+		sshKeyNamePtr = managedSSHKeyName(scope.GetEC2Scope())
+	}
 
 	data := &ec2.RequestLaunchTemplateData{
 		InstanceType: aws.String(lt.InstanceType),
@@ -494,9 +1033,9 @@ func (s *Service) createLaunchTemplateData(scope scope.LaunchTemplateScope, imag
 		}
 	}
 
-	if len(lt.IamInstanceProfile) > 0 {
+	if instanceProfile := s.launchTemplateInstanceProfile(lt); len(instanceProfile) > 0 {
 		data.IamInstanceProfile = &ec2.LaunchTemplateIamInstanceProfileSpecificationRequest{
-			Name: aws.String(lt.IamInstanceProfile),
+			Name: aws.String(instanceProfile),
 		}
 	}
 
@@ -510,35 +1049,59 @@ func (s *Service) createLaunchTemplateData(scope scope.LaunchTemplateScope, imag
 	}
 
 	// add additional security groups as well
-	securityGroupIDs, err := s.GetAdditionalSecurityGroupsIDs(scope.GetLaunchTemplate().AdditionalSecurityGroups)
+	securityGroupIDs, err := s.getAdditionalSecurityGroupsIDsCached(scope, scope.GetLaunchTemplate().AdditionalSecurityGroups)
 	if err != nil {
 		return nil, err
 	}
 	data.SecurityGroupIds = append(data.SecurityGroupIds, aws.StringSlice(securityGroupIDs)...)
 
+	// With network interfaces, the security groups of the instance are set on its primary network interface.
+	networkInterfaces, err := s.getLaunchTemplateNetworkInterfacesRequest(scope, lt, data.SecurityGroupIds)
+	if err != nil {
+		return nil, err
+	}
+	if len(networkInterfaces) > 0 {
+		data.NetworkInterfaces = networkInterfaces
+		data.SecurityGroupIds = nil
+	}
+
 	// set the AMI ID
 	data.ImageId = imageID
 
-	data.InstanceMarketOptions = getLaunchTemplateInstanceMarketOptionsRequest(scope.GetLaunchTemplate().SpotMarketOptions)
+	data.InstanceMarketOptions = getLaunchTemplateInstanceMarketOptionsRequest(scope.GetLaunchTemplate().MarketType, scope.GetLaunchTemplate().SpotMarketOptions)
+	data.CapacityReservationSpecification = getLaunchTemplateCapacityReservationSpecificationRequest(lt.CapacityReservationID, lt.CapacityReservationResourceGroupARN)
 	data.PrivateDnsNameOptions = getLaunchTemplatePrivateDNSNameOptionsRequest(scope.GetLaunchTemplate().PrivateDNSName)
+	data.Placement = getLaunchTemplatePlacementRequest(lt.PlacementGroupName, lt.PlacementGroupPartition)
+	data.EnclaveOptions = getLaunchTemplateEnclaveOptionsRequest(lt.EnclaveOptions)
+	data.ElasticInferenceAccelerators = getLaunchTemplateElasticInferenceAcceleratorsRequest(lt.ElasticInferenceAccelerators)
+
+	rootVolume, nonRootVolumes := lt.RootVolume, lt.NonRootVolumes
+
+	// Instances on an AWS Outpost are limited to the instance and volume types the Outpost supports.
+	if outpostARN := s.launchTemplateOutpostARN(scope); outpostARN != "" {
+		if err := s.validateOutpostInstanceType(lt.InstanceType, outpostARN); err != nil {
+			return nil, err
+		}
+		rootVolume, nonRootVolumes = defaultOutpostVolumeTypes(rootVolume, nonRootVolumes)
+	}
 
 	blockDeviceMappings := []*ec2.LaunchTemplateBlockDeviceMappingRequest{}
 
 	// Set up root volume
-	if lt.RootVolume != nil {
-		rootDeviceName, err := s.checkRootVolume(lt.RootVolume, *data.ImageId)
+	if rootVolume != nil {
+		rootDeviceName, err := s.checkRootVolume(rootVolume, *data.ImageId)
 		if err != nil {
 			return nil, err
 		}
 
-		lt.RootVolume.DeviceName = aws.StringValue(rootDeviceName)
+		rootVolume.DeviceName = aws.StringValue(rootDeviceName)
 
-		req := volumeToLaunchTemplateBlockDeviceMappingRequest(lt.RootVolume)
+		req := volumeToLaunchTemplateBlockDeviceMappingRequest(rootVolume)
 		blockDeviceMappings = append(blockDeviceMappings, req)
 	}
 
-	for vi := range lt.NonRootVolumes {
-		nonRootVolume := lt.NonRootVolumes[vi]
+	for vi := range nonRootVolumes {
+		nonRootVolume := nonRootVolumes[vi]
 
 		blockDeviceMapping := volumeToLaunchTemplateBlockDeviceMappingRequest(&nonRootVolume)
 		blockDeviceMappings = append(blockDeviceMappings, blockDeviceMapping)
@@ -553,6 +1116,41 @@ func (s *Service) createLaunchTemplateData(scope scope.LaunchTemplateScope, imag
 	return data, nil
 }
 
+// launchTemplateOutpostARN returns the ARN of the AWS Outpost the launch template's instances
+// are placed on, or an empty string if none of its subnets is on an Outpost.
+func (s *Service) launchTemplateOutpostARN(lts scope.LaunchTemplateScope) string {
+	if overrideScope, ok := lts.(*overrideLaunchTemplateScope); ok {
+		lts = overrideScope.LaunchTemplateScope
+	}
+	subnetsScope, ok := lts.(scope.LaunchTemplateSubnetsScope)
+	if !ok {
+		return ""
+	}
+	for _, subnetID := range subnetsScope.GetSubnetIDs() {
+		if outpostARN := s.subnetOutpostARN(subnetID); outpostARN != "" {
+			return outpostARN
+		}
+	}
+	return ""
+}
+
+// launchTemplateDedicatedSecurityGroup returns the dedicated security group of the machine pool using the
+// launch template, with the scope holding its ID, when it is enabled.
+func launchTemplateDedicatedSecurityGroup(lts scope.LaunchTemplateScope) (*expinfrav1.DedicatedSecurityGroup, scope.DedicatedSecurityGroupScope) {
+	if overrideScope, ok := lts.(*overrideLaunchTemplateScope); ok {
+		lts = overrideScope.LaunchTemplateScope
+	}
+	poolScope, ok := lts.(scope.DedicatedSecurityGroupScope)
+	if !ok {
+		return nil, nil
+	}
+	dedicatedSG := poolScope.GetDedicatedSecurityGroup()
+	if dedicatedSG == nil || !dedicatedSG.Enabled {
+		return nil, nil
+	}
+	return dedicatedSG, poolScope
+}
+
 func volumeToLaunchTemplateBlockDeviceMappingRequest(v *infrav1.Volume) *ec2.LaunchTemplateBlockDeviceMappingRequest {
 	ltEbsDevice := &ec2.LaunchTemplateEbsBlockDeviceRequest{
 		DeleteOnTermination: aws.Bool(true),
@@ -560,6 +1158,14 @@ func volumeToLaunchTemplateBlockDeviceMappingRequest(v *infrav1.Volume) *ec2.Lau
 		Encrypted:           v.Encrypted,
 	}
 
+	if v.DeleteOnTermination != nil {
+		ltEbsDevice.DeleteOnTermination = v.DeleteOnTermination
+	}
+
+	if v.SnapshotID != nil {
+		ltEbsDevice.SnapshotId = v.SnapshotID
+	}
+
 	if v.Throughput != nil {
 		ltEbsDevice.Throughput = v.Throughput
 	}
@@ -584,6 +1190,7 @@ func volumeToLaunchTemplateBlockDeviceMappingRequest(v *infrav1.Volume) *ec2.Lau
 }
 
 // DeleteLaunchTemplate delete a launch template.
+// A launch template which doesn't exist anymore is considered deleted.
 func (s *Service) DeleteLaunchTemplate(id string) error {
 	s.scope.Debug("Deleting launch template", "id", id)
 
@@ -592,6 +1199,10 @@ func (s *Service) DeleteLaunchTemplate(id string) error {
 	}
 
 	if _, err := s.EC2Client.DeleteLaunchTemplateWithContext(context.TODO(), input); err != nil {
+		if awserrors.IsNotFound(err) {
+			s.scope.Debug("Launch template already deleted", "id", id)
+			return nil
+		}
 		return errors.Wrapf(err, "failed to delete launch template %q", id)
 	}
 
@@ -599,11 +1210,33 @@ func (s *Service) DeleteLaunchTemplate(id string) error {
 	return nil
 }
 
+// DeleteLaunchTemplateByName deletes a launch template by name, when its ID isn't known.
+// A launch template which doesn't exist anymore is considered deleted.
+func (s *Service) DeleteLaunchTemplateByName(name string) error {
+	s.scope.Debug("Deleting launch template", "name", name)
+
+	input := &ec2.DeleteLaunchTemplateInput{
+		LaunchTemplateName: aws.String(name),
+	}
+
+	if _, err := s.EC2Client.DeleteLaunchTemplateWithContext(context.TODO(), input); err != nil {
+		if awserrors.IsNotFound(err) {
+			s.scope.Debug("Launch template already deleted", "name", name)
+			return nil
+		}
+		return errors.Wrapf(err, "failed to delete launch template %q", name)
+	}
+
+	s.scope.Debug("Deleted launch template", "name", name)
+	return nil
+}
+
 // PruneLaunchTemplateVersions deletes one old launch template version.
 // It does not delete the "latest" version, because that version may still be in use.
 // It does not delete the "default" version, because that version cannot be deleted.
 // It does not assume that versions are sequential. Versions may be deleted out of band.
-func (s *Service) PruneLaunchTemplateVersions(id string) error {
+// It does not delete the version to keep, which a launch template may be rolled back to.
+func (s *Service) PruneLaunchTemplateVersions(id string, keepVersion string) error {
 	// When there is one version available, it is the default and the latest.
 	// When there are two versions available, one the is the default, the other is the latest.
 	// Therefore we only prune when there are at least 3 versions available.
@@ -613,7 +1246,8 @@ func (s *Service) PruneLaunchTemplateVersions(id string) error {
 		LaunchTemplateId: aws.String(id),
 		MinVersion:       aws.String("0"),
 		MaxVersion:       aws.String(expinfrav1.LaunchTemplateLatestVersion),
-		MaxResults:       aws.Int64(minCountToAllowPrune),
+		// One more version is described than needed, in case the version to prune is the version to keep.
+		MaxResults: aws.Int64(minCountToAllowPrune + 1),
 	}
 
 	out, err := s.EC2Client.DescribeLaunchTemplateVersionsWithContext(context.TODO(), input)
@@ -627,14 +1261,298 @@ func (s *Service) PruneLaunchTemplateVersions(id string) error {
 	// 								1	|	[default/latest]
 	// 								2	|	[default, latest]
 	// 								3	| 	[default, versionToPrune, latest]
+	// 								4	| 	[default, versionToPrune, versionToPrune, latest or newer]
 	if len(out.LaunchTemplateVersions) < minCountToAllowPrune {
 		return nil
 	}
-	versionToPrune := out.LaunchTemplateVersions[1].VersionNumber
-	return s.deleteLaunchTemplateVersion(id, versionToPrune)
-}
-
-// GetLaunchTemplateLatestVersion returns the latest version of a launch template.
+	for _, v := range out.LaunchTemplateVersions[1 : len(out.LaunchTemplateVersions)-1] {
+		if strconv.FormatInt(aws.Int64Value(v.VersionNumber), 10) == keepVersion {
+			continue
+		}
+		return s.deleteLaunchTemplateVersion(id, v.VersionNumber)
+	}
+	return nil
+}
+
+const (
+	// maxLaunchTemplateVersionsPrunedForQuota is the maximum number of versions of a launch template deleted per
+	// reconciliation once the launch template version quota is exceeded.
+	maxLaunchTemplateVersionsPrunedForQuota = 10
+
+	// maxLaunchTemplateVersionsPerDelete is the maximum number of versions DeleteLaunchTemplateVersions accepts.
+	maxLaunchTemplateVersionsPerDelete = 200
+
+	// launchTemplateIDInstanceTag and launchTemplateVersionInstanceTag are set by EC2 on the instances launched
+	// from a launch template.
+	launchTemplateIDInstanceTag      = "aws:ec2launchtemplate:id"
+	launchTemplateVersionInstanceTag = "aws:ec2launchtemplate:version"
+)
+
+// PruneLaunchTemplateVersionsForQuota deletes the oldest versions of a launch template to make room for a new one
+// once the launch template version quota is exceeded, and returns the number of deleted versions.
+// Only the versions created by the controller are deleted. The default and latest versions, the versions to keep and
+// the versions existing instances were launched from are kept. While an instance refresh is in flight, the versions
+// newer than the oldest version of the existing instances are kept as well, as the refresh may be rolling them out.
+func (s *Service) PruneLaunchTemplateVersionsForQuota(id string, keepVersions []string, instanceRefreshInFlight bool) (int, error) {
+	prunable, _, err := s.prunableLaunchTemplateVersions(id, keepVersions, instanceRefreshInFlight)
+	if err != nil {
+		return 0, err
+	}
+	if len(prunable) > maxLaunchTemplateVersionsPrunedForQuota {
+		prunable = prunable[:maxLaunchTemplateVersionsPrunedForQuota]
+	}
+	if len(prunable) == 0 {
+		return 0, nil
+	}
+
+	s.scope.Info("Pruning launch template versions to stay within the launch template version quota", "id", id, "versions", prunable)
+	return s.deleteLaunchTemplateVersions(id, prunable)
+}
+
+// PruneLaunchTemplateVersionsForRetention deletes the versions of a launch template older than the number of most
+// recent versions to retain, and returns the number of deleted versions. The same versions as for
+// PruneLaunchTemplateVersionsForQuota are kept, whatever their age.
+func (s *Service) PruneLaunchTemplateVersionsForRetention(id string, retain int, keepVersions []string, instanceRefreshInFlight bool) (int, error) {
+	prunable, versions, err := s.prunableLaunchTemplateVersions(id, keepVersions, instanceRefreshInFlight)
+	if err != nil {
+		return 0, err
+	}
+	if retain < 1 || len(versions) <= retain {
+		return 0, nil
+	}
+	oldestRetained := versions[len(versions)-retain]
+
+	toPrune := []string{}
+	for _, version := range prunable {
+		if number, _ := strconv.ParseInt(version, 10, 64); number < oldestRetained {
+			toPrune = append(toPrune, version)
+		}
+	}
+	if len(toPrune) == 0 {
+		return 0, nil
+	}
+
+	s.scope.Info("Pruning launch template versions beyond the retained versions", "id", id, "retain", retain, "versions", toPrune)
+	pruned := 0
+	for len(toPrune) > 0 {
+		batch := toPrune[:min(len(toPrune), maxLaunchTemplateVersionsPerDelete)]
+		toPrune = toPrune[len(batch):]
+		deleted, err := s.deleteLaunchTemplateVersions(id, batch)
+		pruned += deleted
+		if err != nil {
+			return pruned, err
+		}
+	}
+	return pruned, nil
+}
+
+// prunableLaunchTemplateVersions returns the versions of a launch template which can be deleted, from the oldest,
+// and the numbers of all its versions in ascending order.
+func (s *Service) prunableLaunchTemplateVersions(id string, keepVersions []string, instanceRefreshInFlight bool) ([]string, []int64, error) {
+	versions, err := s.describeLaunchTemplateVersions(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	inUse, err := s.launchTemplateVersionsInUse(id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sort.Slice(versions, func(i, j int) bool {
+		return aws.Int64Value(versions[i].VersionNumber) < aws.Int64Value(versions[j].VersionNumber)
+	})
+	numbers := make([]int64, 0, len(versions))
+	for _, v := range versions {
+		numbers = append(numbers, aws.Int64Value(v.VersionNumber))
+	}
+	var latest int64
+	if len(numbers) > 0 {
+		latest = numbers[len(numbers)-1]
+	}
+	oldestInUse := latest
+	for version := range inUse {
+		if version < oldestInUse {
+			oldestInUse = version
+		}
+	}
+
+	prunable := []string{}
+	for _, v := range versions {
+		version := aws.Int64Value(v.VersionNumber)
+		if aws.BoolValue(v.DefaultVersion) || version == latest || slices.Contains(keepVersions, strconv.FormatInt(version, 10)) {
+			continue
+		}
+		if _, ok := inUse[version]; ok {
+			continue
+		}
+		if instanceRefreshInFlight && version > oldestInUse {
+			continue
+		}
+		if !s.launchTemplateVersionOwned(v) {
+			continue
+		}
+		prunable = append(prunable, strconv.FormatInt(version, 10))
+	}
+	return prunable, numbers, nil
+}
+
+// deleteLaunchTemplateVersions deletes versions of a launch template and returns the number of deleted versions.
+// The versions which fail to be deleted are logged.
+func (s *Service) deleteLaunchTemplateVersions(id string, versions []string) (int, error) {
+	out, err := s.EC2Client.DeleteLaunchTemplateVersionsWithContext(context.TODO(), &ec2.DeleteLaunchTemplateVersionsInput{
+		LaunchTemplateId: aws.String(id),
+		Versions:         aws.StringSlice(versions),
+	})
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to delete versions of launch template %q", id)
+	}
+	for _, failed := range out.UnsuccessfullyDeletedLaunchTemplateVersions {
+		if failed.ResponseError != nil {
+			s.scope.Info("Failed to delete launch template version", "id", id, "version", aws.Int64Value(failed.VersionNumber), "error", aws.StringValue(failed.ResponseError.Message))
+		}
+	}
+	return len(out.SuccessfullyDeletedLaunchTemplateVersions), nil
+}
+
+// describeLaunchTemplateVersions returns all the versions of a launch template.
+func (s *Service) describeLaunchTemplateVersions(id string) ([]*ec2.LaunchTemplateVersion, error) {
+	input := &ec2.DescribeLaunchTemplateVersionsInput{
+		LaunchTemplateId: aws.String(id),
+		MaxResults:       aws.Int64(200),
+	}
+
+	var versions []*ec2.LaunchTemplateVersion
+	for {
+		out, err := s.EC2Client.DescribeLaunchTemplateVersionsWithContext(context.TODO(), input)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to describe versions of launch template %q", id)
+		}
+		versions = append(versions, out.LaunchTemplateVersions...)
+		if aws.StringValue(out.NextToken) == "" {
+			return versions, nil
+		}
+		input.NextToken = out.NextToken
+	}
+}
+
+// launchTemplateVersionsInUse returns the versions of a launch template the existing instances were launched from.
+func (s *Service) launchTemplateVersionsInUse(id string) (map[int64]struct{}, error) {
+	input := &ec2.DescribeInstancesInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("tag:" + launchTemplateIDInstanceTag),
+				Values: aws.StringSlice([]string{id}),
+			},
+			filter.EC2.InstanceStates(ec2.InstanceStateNamePending, ec2.InstanceStateNameRunning, ec2.InstanceStateNameStopping, ec2.InstanceStateNameStopped),
+		},
+	}
+
+	inUse := map[int64]struct{}{}
+	for {
+		out, err := s.EC2Client.DescribeInstancesWithContext(context.TODO(), input)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to describe the instances of launch template %q", id)
+		}
+		for _, reservation := range out.Reservations {
+			for _, instance := range reservation.Instances {
+				for _, tag := range instance.Tags {
+					if aws.StringValue(tag.Key) != launchTemplateVersionInstanceTag {
+						continue
+					}
+					if version, err := strconv.ParseInt(aws.StringValue(tag.Value), 10, 64); err == nil {
+						inUse[version] = struct{}{}
+					}
+				}
+			}
+		}
+		if aws.StringValue(out.NextToken) == "" {
+			return inUse, nil
+		}
+		input.NextToken = out.NextToken
+	}
+}
+
+// launchTemplateVersionOwned returns true if the version of the launch template was created by the controller,
+// which tags the instances launched from it as owned by the cluster.
+func (s *Service) launchTemplateVersionOwned(v *ec2.LaunchTemplateVersion) bool {
+	if v.LaunchTemplateData == nil {
+		return false
+	}
+	for _, tagSpecification := range v.LaunchTemplateData.TagSpecifications {
+		if aws.StringValue(tagSpecification.ResourceType) != ec2.ResourceTypeInstance {
+			continue
+		}
+		tags := make(infrav1.Tags, len(tagSpecification.Tags))
+		for _, tag := range tagSpecification.Tags {
+			tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+		}
+		if tags.HasOwned(s.scope.KubernetesClusterName(), s.scope.OwnershipTagPrefixes()...) {
+			return true
+		}
+	}
+	return false
+}
+
+// CreateLaunchTemplateVersionFromVersion creates a version of a launch template copied from one of its versions, and
+// returns the created version, which becomes the latest version.
+func (s *Service) CreateLaunchTemplateVersionFromVersion(id string, sourceVersion string) (string, error) {
+	input := &ec2.CreateLaunchTemplateVersionInput{
+		LaunchTemplateId: aws.String(id),
+		SourceVersion:    aws.String(sourceVersion),
+		// The launch template data is copied from the source version as a whole.
+		LaunchTemplateData: &ec2.RequestLaunchTemplateData{},
+	}
+
+	out, err := s.EC2Client.CreateLaunchTemplateVersionWithContext(context.TODO(), input)
+	if err != nil {
+		return "", errors.Wrapf(err, "unable to create launch template version from version %s", sourceVersion)
+	}
+
+	return strconv.FormatInt(aws.Int64Value(out.LaunchTemplateVersion.VersionNumber), 10), nil
+}
+
+// LaunchTemplateVersionChangedBeyondUserData returns whether the data of two versions of a launch template differs
+// in more than the userdata, i.e. whether the instances launched from one version have to be replaced to match the
+// other beyond the bootstrap data they only run once.
+func (s *Service) LaunchTemplateVersionChangedBeyondUserData(id string, version string, otherVersion string) (bool, error) {
+	input := &ec2.DescribeLaunchTemplateVersionsInput{
+		LaunchTemplateId: aws.String(id),
+		Versions:         aws.StringSlice([]string{version, otherVersion}),
+	}
+
+	out, err := s.EC2Client.DescribeLaunchTemplateVersionsWithContext(context.TODO(), input)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to describe versions %s and %s of launch template %q", version, otherVersion, id)
+	}
+
+	dataByVersion := make(map[string]ec2.ResponseLaunchTemplateData, len(out.LaunchTemplateVersions))
+	for _, v := range out.LaunchTemplateVersions {
+		if v.LaunchTemplateData == nil {
+			continue
+		}
+		data := *v.LaunchTemplateData
+		data.UserData = nil
+		dataByVersion[strconv.FormatInt(aws.Int64Value(v.VersionNumber), 10)] = data
+	}
+
+	data, ok := dataByVersion[version]
+	otherData, otherOk := dataByVersion[otherVersion]
+	if !ok || !otherOk {
+		return false, errors.Errorf("launch template %q is missing version %s or %s", id, version, otherVersion)
+	}
+	return !reflect.DeepEqual(data, otherData), nil
+}
+
+// previousLaunchTemplateVersion returns the version of the launch template before its last change, which is kept
+// so that the launch template can be rolled back to it.
+func previousLaunchTemplateVersion(lts scope.LaunchTemplateScope) string {
+	if rollbackScope, ok := lts.(scope.LaunchTemplateRollbackScope); ok {
+		return rollbackScope.GetPreviousLaunchTemplateVersionStatus()
+	}
+	return ""
+}
+
+// GetLaunchTemplateLatestVersion returns the latest version of a launch template.
 func (s *Service) GetLaunchTemplateLatestVersion(id string) (string, error) {
 	input := &ec2.DescribeLaunchTemplateVersionsInput{
 		LaunchTemplateId: aws.String(id),
@@ -654,6 +1572,72 @@ func (s *Service) GetLaunchTemplateLatestVersion(id string) (string, error) {
 	return strconv.Itoa(int(*out.LaunchTemplateVersions[0].VersionNumber)), nil
 }
 
+// ValidateLaunchTemplateVersion validates a launch template version with a dry run of RunInstances.
+// With a mixed instances policy, the dry run is repeated for every instance type launched from the launch template.
+func (s *Service) ValidateLaunchTemplateVersion(lts scope.LaunchTemplateScope, id string, version string) error {
+	instanceTypes := []*string{nil}
+	if overridesScope, ok := lts.(scope.LaunchTemplateOverridesScope); ok && overridesScope.GetMixedInstancesPolicy() != nil {
+		instanceTypes = nil
+		for _, override := range overridesScope.GetMixedInstancesPolicy().Overrides {
+			switch {
+			// Overrides with a root volume or bootstrap data of their own are launched from a separate launch template.
+			case scope.HasOverrideLaunchTemplate(lts.GetLaunchTemplate(), override):
+			// The instance types selected from instance requirements aren't known, the dry run uses the one of
+			// the launch template.
+			case override.InstanceType == "":
+				if !slices.Contains(instanceTypes, nil) {
+					instanceTypes = append(instanceTypes, nil)
+				}
+			default:
+				instanceTypes = append(instanceTypes, aws.String(override.InstanceType))
+			}
+		}
+	}
+
+	// The launch template does not carry a subnet, without one the dry run would target the default VPC.
+	var subnetID *string
+	if subnets := s.scope.Subnets().FilterPrivate(); len(subnets) > 0 {
+		subnetID = aws.String(subnets[0].GetResourceID())
+	}
+
+	for _, instanceType := range instanceTypes {
+		input := &ec2.RunInstancesInput{
+			DryRun: aws.Bool(true),
+			LaunchTemplate: &ec2.LaunchTemplateSpecification{
+				LaunchTemplateId: aws.String(id),
+				Version:          aws.String(version),
+			},
+			InstanceType: instanceType,
+			SubnetId:     subnetID,
+			MinCount:     aws.Int64(1),
+			MaxCount:     aws.Int64(1),
+		}
+
+		_, err := s.EC2Client.RunInstancesWithContext(context.TODO(), input)
+		if awserrors.IsDryRunOperation(err) {
+			continue
+		}
+		if err == nil {
+			err = errors.New("dry run did not report its outcome")
+		}
+		if instanceType != nil {
+			return errors.Wrapf(err, "launch template %q version %s failed validation for instance type %q", id, version, *instanceType)
+		}
+		return errors.Wrapf(err, "launch template %q version %s failed validation", id, version)
+	}
+
+	return nil
+}
+
+// DeleteLaunchTemplateVersion deletes a single version of a launch template.
+func (s *Service) DeleteLaunchTemplateVersion(id string, version string) error {
+	versionNumber, err := strconv.ParseInt(version, 10, 64)
+	if err != nil {
+		return errors.Wrapf(err, "invalid launch template version %q", version)
+	}
+	return s.deleteLaunchTemplateVersion(id, &versionNumber)
+}
+
 func (s *Service) deleteLaunchTemplateVersion(id string, version *int64) error {
 	s.scope.Debug("Deleting launch template version", "id", id)
 
@@ -712,6 +1696,36 @@ func (s *Service) SDKToLaunchTemplate(d *ec2.LaunchTemplateVersion) (*expinfrav1
 		}
 	}
 
+	// Only the market type is read back, the spot market options of a launch template aren't compared to the spec.
+	if v.InstanceMarketOptions != nil {
+		switch aws.StringValue(v.InstanceMarketOptions.MarketType) {
+		case ec2.MarketTypeCapacityBlock:
+			i.MarketType = infrav1.MarketTypeCapacityBlock
+		case ec2.MarketTypeSpot:
+			i.MarketType = infrav1.MarketTypeSpot
+		}
+	}
+	if v.CapacityReservationSpecification != nil && v.CapacityReservationSpecification.CapacityReservationTarget != nil {
+		i.CapacityReservationID = v.CapacityReservationSpecification.CapacityReservationTarget.CapacityReservationId
+		i.CapacityReservationResourceGroupARN = v.CapacityReservationSpecification.CapacityReservationTarget.CapacityReservationResourceGroupArn
+	}
+
+	if v.Placement != nil {
+		i.PlacementGroupName = aws.StringValue(v.Placement.GroupName)
+		i.PlacementGroupPartition = aws.Int64Value(v.Placement.PartitionNumber)
+	}
+
+	// Disabled enclave options are read back as unset, the same as they are omitted from the request.
+	if v.EnclaveOptions != nil && aws.BoolValue(v.EnclaveOptions.Enabled) {
+		i.EnclaveOptions = &expinfrav1.EnclaveOptions{Enabled: true}
+	}
+	for _, accelerator := range v.ElasticInferenceAccelerators {
+		i.ElasticInferenceAccelerators = append(i.ElasticInferenceAccelerators, expinfrav1.ElasticInferenceAccelerator{
+			Type:  aws.StringValue(accelerator.Type),
+			Count: aws.Int64Value(accelerator.Count),
+		})
+	}
+
 	if v.IamInstanceProfile != nil {
 		i.IamInstanceProfile = aws.StringValue(v.IamInstanceProfile.Name)
 	}
@@ -724,6 +1738,24 @@ func (s *Service) SDKToLaunchTemplate(d *ec2.LaunchTemplateVersion) (*expinfrav1
 		}
 	}
 
+	for _, ni := range v.NetworkInterfaces {
+		networkInterface := expinfrav1.LaunchTemplateNetworkInterface{
+			DeviceIndex:      aws.Int64Value(ni.DeviceIndex),
+			InterfaceType:    expinfrav1.NetworkInterfaceType(aws.StringValue(ni.InterfaceType)),
+			SubnetID:         aws.StringValue(ni.SubnetId),
+			IPv6AddressCount: ni.Ipv6AddressCount,
+		}
+		for _, id := range ni.Groups {
+			// The security groups of the primary network interface are those of the instance.
+			if networkInterface.DeviceIndex == 0 {
+				i.AdditionalSecurityGroups = append(i.AdditionalSecurityGroups, infrav1.AWSResourceReference{ID: id})
+				continue
+			}
+			networkInterface.SecurityGroups = append(networkInterface.SecurityGroups, infrav1.AWSResourceReference{ID: id})
+		}
+		i.NetworkInterfaces = append(i.NetworkInterfaces, networkInterface)
+	}
+
 	for _, id := range v.SecurityGroupIds {
 		// FIXME(dlipovetsky): This will include the core security groups as well, making the
 		// "Additional" a bit dishonest. However, including the core groups drastically simplifies
@@ -758,45 +1790,83 @@ func (s *Service) SDKToLaunchTemplate(d *ec2.LaunchTemplateVersion) (*expinfrav1
 	return i, decodedUserDataHash, nil, nil
 }
 
-// LaunchTemplateNeedsUpdate checks if a new launch template version is needed.
+// launchTemplateInstanceProfile returns the instance profile of a launch template, defaulting to the instance
+// profile created for the nodes of the cluster.
+func (s *Service) launchTemplateInstanceProfile(lt *expinfrav1.AWSLaunchTemplate) string {
+	if lt.IamInstanceProfile != "" {
+		return lt.IamInstanceProfile
+	}
+	return managedNodeInstanceProfile(s.scope)
+}
+
+// LaunchTemplateChangedFields returns the names of the fields of the launch template which differ from the ones
+// of the existing launch template, a new launch template version is needed when any does.
 //
 // FIXME(dlipovetsky): This check should account for changed userdata, but does not yet do so.
 // Although userdata is stored in an EC2 Launch Template, it is not a field of AWSLaunchTemplate.
-func (s *Service) LaunchTemplateNeedsUpdate(scope scope.LaunchTemplateScope, incoming *expinfrav1.AWSLaunchTemplate, existing *expinfrav1.AWSLaunchTemplate) (bool, error) {
-	if incoming.IamInstanceProfile != existing.IamInstanceProfile {
-		return true, nil
+func (s *Service) LaunchTemplateChangedFields(scope scope.LaunchTemplateScope, incoming *expinfrav1.AWSLaunchTemplate, existing *expinfrav1.AWSLaunchTemplate) ([]string, error) {
+	var changed []string
+	if s.launchTemplateInstanceProfile(incoming) != existing.IamInstanceProfile {
+		changed = append(changed, "iamInstanceProfile")
 	}
-
 	if incoming.InstanceType != existing.InstanceType {
-		return true, nil
+		changed = append(changed, "instanceType")
 	}
 	if !cmp.Equal(incoming.InstanceMetadataOptions, existing.InstanceMetadataOptions) {
-		return true, nil
+		changed = append(changed, "instanceMetadataOptions")
+	}
+	if launchTemplateMarketType(incoming) != launchTemplateMarketType(existing) {
+		changed = append(changed, "marketType")
+	}
+	if aws.StringValue(incoming.CapacityReservationID) != aws.StringValue(existing.CapacityReservationID) {
+		changed = append(changed, "capacityReservationId")
+	}
+	if aws.StringValue(incoming.CapacityReservationResourceGroupARN) != aws.StringValue(existing.CapacityReservationResourceGroupARN) {
+		changed = append(changed, "capacityReservationResourceGroupArn")
+	}
+	if incoming.PlacementGroupName != existing.PlacementGroupName {
+		changed = append(changed, "placementGroupName")
+	}
+	if incoming.PlacementGroupPartition != existing.PlacementGroupPartition {
+		changed = append(changed, "placementGroupPartition")
+	}
+	if launchTemplateEnclaveEnabled(incoming) != launchTemplateEnclaveEnabled(existing) {
+		changed = append(changed, "enclaveOptions")
+	}
+	if !elasticInferenceAcceleratorsEqual(incoming.ElasticInferenceAccelerators, existing.ElasticInferenceAccelerators) {
+		changed = append(changed, "elasticInferenceAccelerators")
+	}
+	networkInterfacesChanged, err := s.networkInterfacesChanged(scope, incoming, existing)
+	if err != nil {
+		return nil, err
+	}
+	if networkInterfacesChanged {
+		changed = append(changed, "networkInterfaces")
 	}
 
-	incomingIDs, err := s.GetAdditionalSecurityGroupsIDs(incoming.AdditionalSecurityGroups)
+	incomingIDs, err := s.getAdditionalSecurityGroupsIDsCached(scope, incoming.AdditionalSecurityGroups)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
 	coreIDs, err := s.GetCoreNodeSecurityGroups(scope)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
 	incomingIDs = append(incomingIDs, coreIDs...)
 	existingIDs, err := s.GetAdditionalSecurityGroupsIDs(existing.AdditionalSecurityGroups)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 	sort.Strings(incomingIDs)
 	sort.Strings(existingIDs)
 
 	if !cmp.Equal(incomingIDs, existingIDs) {
-		return true, nil
+		changed = append(changed, "additionalSecurityGroups")
 	}
 
-	return false, nil
+	return changed, nil
 }
 
 // DiscoverLaunchTemplateAMI will discover the AMI launch template.
@@ -804,6 +1874,9 @@ func (s *Service) DiscoverLaunchTemplateAMI(scope scope.LaunchTemplateScope) (*s
 	lt := scope.GetLaunchTemplate()
 
 	if lt.AMI.ID != nil {
+		if lt.AMI.SourceRegion != "" {
+			return s.reconcileCopiedAMI(scope, lt.AMI)
+		}
 		return lt.AMI.ID, nil
 	}
 
@@ -846,16 +1919,29 @@ func (s *Service) DiscoverLaunchTemplateAMI(scope scope.LaunchTemplateScope) (*s
 		}
 	}
 
-	if scope.IsEKSManaged() && imageLookupFormat == "" && imageLookupOrg == "" && imageLookupBaseOS == "" {
+	if lt.OSFamily == expinfrav1.OSFamilyBottlerocket && lt.ImageLookupFormat == "" && lt.ImageLookupOrg == "" && lt.ImageLookupBaseOS == "" {
+		// The image lookup fields of the cluster describe the images of the other machines, so they are ignored.
+		lookupAMI, err = s.bottlerocketAMILookup(*templateVersion, imageArchitecture, lt.AMIType)
+		if err != nil {
+			return nil, err
+		}
+	} else if scope.IsEKSManaged() && imageLookupFormat == "" && imageLookupOrg == "" && imageLookupBaseOS == "" {
+		eksLookupType := lt.AMI.EKSOptimizedLookupType
+		if eksLookupType == nil && lt.AMIType == expinfrav1.AMITypeGPU {
+			eksLookupType = ptr.To(infrav1.AmazonLinuxGPU)
+		}
 		lookupAMI, err = s.eksAMILookup(
 			*templateVersion,
 			imageArchitecture,
-			scope.GetLaunchTemplate().AMI.EKSOptimizedLookupType,
+			eksLookupType,
 		)
 		if err != nil {
 			return nil, err
 		}
 	} else {
+		if lt.AMIType == expinfrav1.AMITypeGPU {
+			imageLookupBaseOS = gpuImageLookupBaseOS(imageLookupBaseOS)
+		}
 		lookupAMI, err = s.defaultAMIIDLookup(
 			imageLookupFormat,
 			imageLookupOrg,
@@ -891,18 +1977,98 @@ func (s *Service) GetAdditionalSecurityGroupsIDs(securityGroups []infrav1.AWSRes
 	return additionalSecurityGroupsIDs, nil
 }
 
+// reconcileAdditionalSecurityGroups resolves the additional security groups of the launch template,
+// records the resolved IDs in the status and reports the result on the AdditionalSecurityGroupsReady condition.
+func (s *Service) reconcileAdditionalSecurityGroups(scope scope.LaunchTemplateScope) error {
+	securityGroups := scope.GetLaunchTemplate().AdditionalSecurityGroups
+	if len(securityGroups) == 0 {
+		scope.SetAdditionalSecurityGroupIDsStatus(nil)
+		conditions.Delete(scope.GetSetter(), expinfrav1.AdditionalSecurityGroupsReadyCondition)
+		return nil
+	}
+
+	ids, unmatched, err := s.resolveAdditionalSecurityGroupsIDs(scope, securityGroups)
+	if err != nil {
+		record.Warnf(scope.GetMachinePool(), "FailedResolveAdditionalSecurityGroups", "Failed to resolve additional security groups: %v", err)
+		conditions.MarkFalse(scope.GetSetter(), expinfrav1.AdditionalSecurityGroupsReadyCondition, expinfrav1.AdditionalSecurityGroupsResolutionFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return errors.Wrap(err, "failed to resolve additional security groups")
+	}
+
+	scope.SetAdditionalSecurityGroupIDsStatus(ids)
+
+	if unmatched > 0 {
+		conditions.MarkFalse(scope.GetSetter(), expinfrav1.AdditionalSecurityGroupsReadyCondition, expinfrav1.AdditionalSecurityGroupsNotFoundReason, clusterv1.ConditionSeverityWarning,
+			"%d of the filters of the additional security groups matched no security group", unmatched)
+		return nil
+	}
+	conditions.MarkTrue(scope.GetSetter(), expinfrav1.AdditionalSecurityGroupsReadyCondition)
+	return nil
+}
+
+// getAdditionalSecurityGroupsIDsCached returns the security group IDs for the additional security groups,
+// reusing the IDs resolved from filters by other machine pools of the cluster while they are cached. When the
+// filters can't be resolved, the IDs last resolved for the launch template are returned, so that the other
+// changes of the launch template are still applied.
+func (s *Service) getAdditionalSecurityGroupsIDsCached(scope scope.LaunchTemplateScope, securityGroups []infrav1.AWSResourceReference) ([]string, error) {
+	ids, _, err := s.resolveAdditionalSecurityGroupsIDs(scope, securityGroups)
+	if err != nil {
+		if previous := scope.GetAdditionalSecurityGroupIDsStatus(); previous != nil {
+			return previous, nil
+		}
+		return nil, err
+	}
+	return ids, nil
+}
+
+// resolveAdditionalSecurityGroupsIDs returns the security group IDs for the additional security groups, and the
+// number of filters which matched no security group.
+func (s *Service) resolveAdditionalSecurityGroupsIDs(scope scope.LaunchTemplateScope, securityGroups []infrav1.AWSResourceReference) ([]string, int, error) {
+	cache := scope.GetSecurityGroupFilterCache()
+	var clusterKey string
+	if cache != nil {
+		clusterKey = s.scope.Namespace() + "/" + s.scope.Name()
+	}
+
+	var additionalSecurityGroupsIDs []string
+	unmatched := 0
+	for _, sg := range securityGroups {
+		if sg.ID != nil {
+			additionalSecurityGroupsIDs = append(additionalSecurityGroupsIDs, *sg.ID)
+		} else if sg.Filters != nil {
+			ids, ok := cache.Get(clusterKey, sg.Filters)
+			if !ok {
+				var err error
+				ids, err = s.getFilteredSecurityGroupIDs(sg)
+				if err != nil {
+					return nil, 0, err
+				}
+				cache.Set(clusterKey, sg.Filters, ids)
+			}
+
+			if len(ids) == 0 {
+				unmatched++
+			}
+			additionalSecurityGroupsIDs = append(additionalSecurityGroupsIDs, ids...)
+		}
+	}
+
+	return additionalSecurityGroupsIDs, unmatched, nil
+}
+
 func (s *Service) buildLaunchTemplateTagSpecificationRequest(scope scope.LaunchTemplateScope, userDataSecretKey apimachinerytypes.NamespacedName) []*ec2.LaunchTemplateTagSpecificationRequest {
 	tagSpecifications := make([]*ec2.LaunchTemplateTagSpecificationRequest, 0)
 	additionalTags := scope.AdditionalTags()
 	// Set the cloud provider tag
 	additionalTags[infrav1.ClusterAWSCloudProviderTagKey(s.scope.KubernetesClusterName())] = string(infrav1.ResourceLifecycleOwned)
+	s.scope.LegacyClusterTag().Apply(additionalTags, s.scope.KubernetesClusterName(), infrav1.ResourceLifecycleOwned)
 
 	tags := infrav1.Build(infrav1.BuildParams{
-		ClusterName: s.scope.KubernetesClusterName(),
-		Lifecycle:   infrav1.ResourceLifecycleOwned,
-		Name:        aws.String(scope.LaunchTemplateName()),
-		Role:        aws.String("node"),
-		Additional:  additionalTags,
+		ClusterName:        s.scope.KubernetesClusterName(),
+		OwnershipTagPrefix: s.scope.OwnershipTagPrefix(),
+		Lifecycle:          infrav1.ResourceLifecycleOwned,
+		Name:               aws.String(scope.LaunchTemplateName()),
+		Role:               aws.String("node"),
+		Additional:         additionalTags,
 	})
 
 	// tag instances
@@ -962,8 +2128,18 @@ func (s *Service) getFilteredSecurityGroupIDs(securityGroup infrav1.AWSResourceR
 	return ids, nil
 }
 
-func getLaunchTemplateInstanceMarketOptionsRequest(spotMarketOptions *infrav1.SpotMarketOptions) *ec2.LaunchTemplateInstanceMarketOptionsRequest {
-	if spotMarketOptions == nil {
+func getLaunchTemplateInstanceMarketOptionsRequest(marketType infrav1.MarketType, spotMarketOptions *infrav1.SpotMarketOptions) *ec2.LaunchTemplateInstanceMarketOptionsRequest {
+	switch {
+	case marketType == infrav1.MarketTypeCapacityBlock:
+		// The instances are launched in the capacity block targeted by the capacity reservation specification.
+		return &ec2.LaunchTemplateInstanceMarketOptionsRequest{
+			MarketType: aws.String(ec2.MarketTypeCapacityBlock),
+		}
+	case marketType == infrav1.MarketTypeOnDemand:
+		return nil
+	case marketType == infrav1.MarketTypeSpot && spotMarketOptions == nil:
+		spotMarketOptions = &infrav1.SpotMarketOptions{}
+	case spotMarketOptions == nil:
 		// Instance is not a Spot instance
 		return nil
 	}
@@ -985,6 +2161,173 @@ func getLaunchTemplateInstanceMarketOptionsRequest(spotMarketOptions *infrav1.Sp
 	return launchTemplateInstanceMarketOptionsRequest
 }
 
+// launchTemplateMarketType returns the market type the instances of a launch template are launched with, spot
+// market options without a market type launching spot instances.
+func launchTemplateMarketType(lt *expinfrav1.AWSLaunchTemplate) infrav1.MarketType {
+	switch {
+	case lt.MarketType == infrav1.MarketTypeCapacityBlock, lt.MarketType == infrav1.MarketTypeSpot:
+		return lt.MarketType
+	case lt.MarketType == "" && lt.SpotMarketOptions != nil:
+		return infrav1.MarketTypeSpot
+	default:
+		return infrav1.MarketTypeOnDemand
+	}
+}
+
+func getLaunchTemplateCapacityReservationSpecificationRequest(capacityReservationID, resourceGroupARN *string) *ec2.LaunchTemplateCapacityReservationSpecificationRequest {
+	if capacityReservationID == nil && resourceGroupARN == nil {
+		return nil
+	}
+
+	return &ec2.LaunchTemplateCapacityReservationSpecificationRequest{
+		CapacityReservationTarget: &ec2.CapacityReservationTarget{
+			CapacityReservationId:               capacityReservationID,
+			CapacityReservationResourceGroupArn: resourceGroupARN,
+		},
+	}
+}
+
+// getLaunchTemplatePlacementRequest returns the placement of the instances in a placement group, or nil
+// without placement group.
+func getLaunchTemplatePlacementRequest(groupName string, partition int64) *ec2.LaunchTemplatePlacementRequest {
+	if groupName == "" {
+		return nil
+	}
+	placement := &ec2.LaunchTemplatePlacementRequest{
+		GroupName: aws.String(groupName),
+	}
+	if partition != 0 {
+		placement.PartitionNumber = aws.Int64(partition)
+	}
+	return placement
+}
+
+// getLaunchTemplateEnclaveOptionsRequest only sets the enclave options when enclaves are enabled, so that the launch
+// templates of the machine pools without them don't change.
+func getLaunchTemplateEnclaveOptionsRequest(enclaveOptions *expinfrav1.EnclaveOptions) *ec2.LaunchTemplateEnclaveOptionsRequest {
+	if enclaveOptions == nil || !enclaveOptions.Enabled {
+		return nil
+	}
+	return &ec2.LaunchTemplateEnclaveOptionsRequest{
+		Enabled: aws.Bool(true),
+	}
+}
+
+func getLaunchTemplateElasticInferenceAcceleratorsRequest(accelerators []expinfrav1.ElasticInferenceAccelerator) []*ec2.LaunchTemplateElasticInferenceAccelerator {
+	if len(accelerators) == 0 {
+		return nil
+	}
+	request := make([]*ec2.LaunchTemplateElasticInferenceAccelerator, 0, len(accelerators))
+	for _, accelerator := range accelerators {
+		request = append(request, &ec2.LaunchTemplateElasticInferenceAccelerator{
+			Type:  aws.String(accelerator.Type),
+			Count: aws.Int64(accelerator.GetCount()),
+		})
+	}
+	return request
+}
+
+func launchTemplateEnclaveEnabled(lt *expinfrav1.AWSLaunchTemplate) bool {
+	return lt.EnclaveOptions != nil && lt.EnclaveOptions.Enabled
+}
+
+// elasticInferenceAcceleratorsEqual compares Elastic Inference accelerators in order, with their default count.
+func elasticInferenceAcceleratorsEqual(a, b []expinfrav1.ElasticInferenceAccelerator) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Type != b[i].Type || a[i].GetCount() != b[i].GetCount() {
+			return false
+		}
+	}
+	return true
+}
+
+// launchTemplateNetworkInterfaces returns the network interfaces of a launch template sorted by device index, with
+// the primary network interface added when it isn't listed.
+func launchTemplateNetworkInterfaces(lt *expinfrav1.AWSLaunchTemplate) []expinfrav1.LaunchTemplateNetworkInterface {
+	if len(lt.NetworkInterfaces) == 0 {
+		return nil
+	}
+
+	networkInterfaces := make([]expinfrav1.LaunchTemplateNetworkInterface, 0, len(lt.NetworkInterfaces)+1)
+	hasPrimary := false
+	for _, networkInterface := range lt.NetworkInterfaces {
+		hasPrimary = hasPrimary || networkInterface.DeviceIndex == 0
+		networkInterfaces = append(networkInterfaces, networkInterface)
+	}
+	if !hasPrimary {
+		networkInterfaces = append(networkInterfaces, expinfrav1.LaunchTemplateNetworkInterface{DeviceIndex: 0})
+	}
+	sort.Slice(networkInterfaces, func(i, j int) bool {
+		return networkInterfaces[i].DeviceIndex < networkInterfaces[j].DeviceIndex
+	})
+	return networkInterfaces
+}
+
+// getLaunchTemplateNetworkInterfacesRequest returns the network interfaces of a launch template, the primary network
+// interface using the given security groups of the instance.
+func (s *Service) getLaunchTemplateNetworkInterfacesRequest(scope scope.LaunchTemplateScope, lt *expinfrav1.AWSLaunchTemplate, instanceSecurityGroupIDs []*string) ([]*ec2.LaunchTemplateInstanceNetworkInterfaceSpecificationRequest, error) {
+	var request []*ec2.LaunchTemplateInstanceNetworkInterfaceSpecificationRequest
+	for _, networkInterface := range launchTemplateNetworkInterfaces(lt) {
+		spec := &ec2.LaunchTemplateInstanceNetworkInterfaceSpecificationRequest{
+			DeviceIndex:         aws.Int64(networkInterface.DeviceIndex),
+			InterfaceType:       aws.String(string(networkInterface.GetInterfaceType())),
+			Ipv6AddressCount:    networkInterface.IPv6AddressCount,
+			DeleteOnTermination: aws.Bool(true),
+		}
+		if networkInterface.DeviceIndex == 0 {
+			spec.Groups = instanceSecurityGroupIDs
+		} else {
+			ids, err := s.getAdditionalSecurityGroupsIDsCached(scope, networkInterface.SecurityGroups)
+			if err != nil {
+				return nil, err
+			}
+			if len(ids) > 0 {
+				spec.Groups = aws.StringSlice(ids)
+			}
+			if networkInterface.SubnetID != "" {
+				spec.SubnetId = aws.String(networkInterface.SubnetID)
+			}
+		}
+		request = append(request, spec)
+	}
+	return request, nil
+}
+
+// networkInterfacesChanged compares the network interfaces of launch templates with their defaults. The security
+// groups of the primary network interface are compared with the security groups of the instance.
+func (s *Service) networkInterfacesChanged(scope scope.LaunchTemplateScope, incoming *expinfrav1.AWSLaunchTemplate, existing *expinfrav1.AWSLaunchTemplate) (bool, error) {
+	incomingInterfaces, existingInterfaces := launchTemplateNetworkInterfaces(incoming), launchTemplateNetworkInterfaces(existing)
+	if len(incomingInterfaces) != len(existingInterfaces) {
+		return true, nil
+	}
+
+	for i := range incomingInterfaces {
+		in, ex := incomingInterfaces[i], existingInterfaces[i]
+		if in.DeviceIndex != ex.DeviceIndex || in.GetInterfaceType() != ex.GetInterfaceType() || in.SubnetID != ex.SubnetID ||
+			aws.Int64Value(in.IPv6AddressCount) != aws.Int64Value(ex.IPv6AddressCount) {
+			return true, nil
+		}
+
+		incomingIDs, err := s.getAdditionalSecurityGroupsIDsCached(scope, in.SecurityGroups)
+		if err != nil {
+			return false, err
+		}
+		existingIDs, err := s.GetAdditionalSecurityGroupsIDs(ex.SecurityGroups)
+		if err != nil {
+			return false, err
+		}
+		sort.Strings(incomingIDs)
+		sort.Strings(existingIDs)
+		if !cmp.Equal(incomingIDs, existingIDs) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 func getLaunchTemplatePrivateDNSNameOptionsRequest(privateDNSName *infrav1.PrivateDNSName) *ec2.LaunchTemplatePrivateDnsNameOptionsRequest {
 	if privateDNSName == nil {
 		return nil
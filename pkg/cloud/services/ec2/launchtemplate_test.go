@@ -19,6 +19,7 @@ package ec2
 import (
 	"context"
 	"encoding/base64"
+	"slices"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -29,6 +30,8 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/google/go-cmp/cmp"
 	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
@@ -38,10 +41,12 @@ import (
 	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/mock_services"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/ssm/mock_ssmiface"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/userdata"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
 )
 
 const (
@@ -210,6 +215,9 @@ func TestGetLaunchTemplate(t *testing.T) {
 					SSHKeyName:               aws.String("foo-keyname"),
 					VersionNumber:            aws.Int64(1),
 					AdditionalSecurityGroups: []infrav1.AWSResourceReference{{ID: aws.String("sg-id")}},
+					NetworkInterfaces: []expinfrav1.LaunchTemplateNetworkInterface{
+						{DeviceIndex: 1, SecurityGroups: []infrav1.AWSResourceReference{{ID: aws.String("foo-group")}}},
+					},
 				}
 
 				g.Expect(err).NotTo(HaveOccurred())
@@ -268,6 +276,9 @@ func TestGetLaunchTemplate(t *testing.T) {
 					SSHKeyName:               aws.String("foo-keyname"),
 					VersionNumber:            aws.Int64(1),
 					AdditionalSecurityGroups: []infrav1.AWSResourceReference{{ID: aws.String("sg-id")}},
+					NetworkInterfaces: []expinfrav1.LaunchTemplateNetworkInterface{
+						{DeviceIndex: 1, SecurityGroups: []infrav1.AWSResourceReference{{ID: aws.String("foo-group")}}},
+					},
 				}
 
 				g.Expect(err).NotTo(HaveOccurred())
@@ -337,6 +348,16 @@ func TestServiceSDKToLaunchTemplate(t *testing.T) {
 							Groups:      []*string{aws.String("foo-group")},
 						},
 					},
+					Placement: &ec2.LaunchTemplatePlacement{
+						GroupName:       aws.String("foo-placement-group"),
+						PartitionNumber: aws.Int64(2),
+					},
+					EnclaveOptions: &ec2.LaunchTemplateEnclaveOptions{
+						Enabled: aws.Bool(true),
+					},
+					ElasticInferenceAccelerators: []*ec2.LaunchTemplateElasticInferenceAcceleratorResponse{
+						{Type: aws.String("eia2.medium"), Count: aws.Int64(1)},
+					},
 					UserData: aws.String(base64.StdEncoding.EncodeToString([]byte(testUserData))),
 				},
 				VersionNumber: aws.Int64(1),
@@ -346,13 +367,64 @@ func TestServiceSDKToLaunchTemplate(t *testing.T) {
 				AMI: infrav1.AMIReference{
 					ID: aws.String("foo-image"),
 				},
-				IamInstanceProfile: "foo-profile",
-				SSHKeyName:         aws.String("foo-keyname"),
-				VersionNumber:      aws.Int64(1),
+				IamInstanceProfile:      "foo-profile",
+				SSHKeyName:              aws.String("foo-keyname"),
+				VersionNumber:           aws.Int64(1),
+				PlacementGroupName:      "foo-placement-group",
+				PlacementGroupPartition: 2,
+				EnclaveOptions:          &expinfrav1.EnclaveOptions{Enabled: true},
+				ElasticInferenceAccelerators: []expinfrav1.ElasticInferenceAccelerator{
+					{Type: "eia2.medium", Count: 1},
+				},
+				NetworkInterfaces: []expinfrav1.LaunchTemplateNetworkInterface{
+					{DeviceIndex: 1, SecurityGroups: []infrav1.AWSResourceReference{{ID: aws.String("foo-group")}}},
+				},
 			},
 			wantHash:          testUserDataHash,
 			wantDataSecretKey: nil, // respective tag is not given
 		},
+		{
+			name: "primary network interface",
+			input: &ec2.LaunchTemplateVersion{
+				LaunchTemplateId:   aws.String("lt-12345"),
+				LaunchTemplateName: aws.String("foo"),
+				LaunchTemplateData: &ec2.ResponseLaunchTemplateData{
+					ImageId: aws.String("foo-image"),
+					NetworkInterfaces: []*ec2.LaunchTemplateInstanceNetworkInterfaceSpecification{
+						{
+							DeviceIndex:   aws.Int64(0),
+							InterfaceType: aws.String("efa"),
+							Groups:        []*string{aws.String("sg-1"), aws.String("sg-2")},
+						},
+						{
+							DeviceIndex:      aws.Int64(1),
+							InterfaceType:    aws.String("efa"),
+							SubnetId:         aws.String("subnet-1"),
+							Ipv6AddressCount: aws.Int64(1),
+						},
+					},
+					UserData: aws.String(base64.StdEncoding.EncodeToString([]byte(testUserData))),
+				},
+				VersionNumber: aws.Int64(1),
+			},
+			wantLT: &expinfrav1.AWSLaunchTemplate{
+				Name: "foo",
+				AMI: infrav1.AMIReference{
+					ID: aws.String("foo-image"),
+				},
+				VersionNumber: aws.Int64(1),
+				AdditionalSecurityGroups: []infrav1.AWSResourceReference{
+					{ID: aws.String("sg-1")},
+					{ID: aws.String("sg-2")},
+				},
+				NetworkInterfaces: []expinfrav1.LaunchTemplateNetworkInterface{
+					{DeviceIndex: 0, InterfaceType: expinfrav1.NetworkInterfaceTypeEFA},
+					{DeviceIndex: 1, InterfaceType: expinfrav1.NetworkInterfaceTypeEFA, SubnetID: "subnet-1", IPv6AddressCount: aws.Int64(1)},
+				},
+			},
+			wantHash:          testUserDataHash,
+			wantDataSecretKey: nil,
+		},
 		{
 			name: "tag of bootstrap secret",
 			input: &ec2.LaunchTemplateVersion{
@@ -403,6 +475,9 @@ func TestServiceSDKToLaunchTemplate(t *testing.T) {
 				IamInstanceProfile: "foo-profile",
 				SSHKeyName:         aws.String("foo-keyname"),
 				VersionNumber:      aws.Int64(1),
+				NetworkInterfaces: []expinfrav1.LaunchTemplateNetworkInterface{
+					{DeviceIndex: 1, SecurityGroups: []infrav1.AWSResourceReference{{ID: aws.String("foo-group")}}},
+				},
 			},
 			wantHash:          testUserDataHash,
 			wantDataSecretKey: &types.NamespacedName{Namespace: "bootstrap-secret-ns", Name: "bootstrap-secret"},
@@ -428,7 +503,7 @@ func TestServiceSDKToLaunchTemplate(t *testing.T) {
 	}
 }
 
-func TestServiceLaunchTemplateNeedsUpdate(t *testing.T) {
+func TestServiceLaunchTemplateChangedFields(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
 
@@ -436,10 +511,51 @@ func TestServiceLaunchTemplateNeedsUpdate(t *testing.T) {
 		name     string
 		incoming *expinfrav1.AWSLaunchTemplate
 		existing *expinfrav1.AWSLaunchTemplate
-		expect   func(m *mocks.MockEC2APIMockRecorder)
-		want     bool
-		wantErr  bool
+		nodeRole *infrav1.NodeRoleStatus
+		// nodeRoleRemoved unsets the creation of the node role while its status is still set.
+		nodeRoleRemoved bool
+		expect          func(m *mocks.MockEC2APIMockRecorder)
+		want            bool
+		// wantFields are the changed fields, only checked when set.
+		wantFields []string
+		wantErr    bool
 	}{
+		{
+			name:     "instance profile defaulted to the node instance profile of the cluster",
+			incoming: &expinfrav1.AWSLaunchTemplate{},
+			existing: &expinfrav1.AWSLaunchTemplate{
+				IamInstanceProfile: "test-cluster-nodes",
+				AdditionalSecurityGroups: []infrav1.AWSResourceReference{
+					{ID: aws.String("sg-111")},
+					{ID: aws.String("sg-222")},
+				},
+			},
+			nodeRole: &infrav1.NodeRoleStatus{RoleName: "test-cluster-nodes", InstanceProfileName: "test-cluster-nodes"},
+			want:     false,
+		},
+		{
+			name:       "node instance profile of the cluster created after the launch template",
+			incoming:   &expinfrav1.AWSLaunchTemplate{},
+			existing:   &expinfrav1.AWSLaunchTemplate{},
+			nodeRole:   &infrav1.NodeRoleStatus{RoleName: "test-cluster-nodes", InstanceProfileName: "test-cluster-nodes"},
+			want:       true,
+			wantFields: []string{"iamInstanceProfile", "additionalSecurityGroups"},
+		},
+		{
+			name:     "node instance profile of the cluster no longer used once the node role is removed",
+			incoming: &expinfrav1.AWSLaunchTemplate{},
+			existing: &expinfrav1.AWSLaunchTemplate{
+				IamInstanceProfile: "test-cluster-nodes",
+				AdditionalSecurityGroups: []infrav1.AWSResourceReference{
+					{ID: aws.String("sg-111")},
+					{ID: aws.String("sg-222")},
+				},
+			},
+			nodeRole:        &infrav1.NodeRoleStatus{RoleName: "test-cluster-nodes", InstanceProfileName: "test-cluster-nodes"},
+			nodeRoleRemoved: true,
+			want:            true,
+			wantFields:      []string{"iamInstanceProfile"},
+		},
 		{
 			name: "the same security groups",
 			incoming: &expinfrav1.AWSLaunchTemplate{
@@ -511,6 +627,192 @@ func TestServiceLaunchTemplateNeedsUpdate(t *testing.T) {
 			},
 			want: true,
 		},
+		{
+			name: "Should return true if the launch template is moved to a capacity block",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				MarketType:            infrav1.MarketTypeCapacityBlock,
+				CapacityReservationID: aws.String("cr-0123456789abcdef0"),
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{},
+			want:     true,
+		},
+		{
+			name: "Should return true if the launch template is moved from spot instances to a capacity block",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				MarketType:            infrav1.MarketTypeCapacityBlock,
+				CapacityReservationID: aws.String("cr-0123456789abcdef0"),
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{
+				MarketType: infrav1.MarketTypeSpot,
+			},
+			want: true,
+		},
+		{
+			name: "Should return true if the launch template is moved from a capacity block to spot instances",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				SpotMarketOptions: &infrav1.SpotMarketOptions{},
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{
+				MarketType:            infrav1.MarketTypeCapacityBlock,
+				CapacityReservationID: aws.String("cr-0123456789abcdef0"),
+			},
+			want: true,
+		},
+		{
+			name: "Should return true if the launch template is moved from on-demand to spot instances",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				MarketType: infrav1.MarketTypeSpot,
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{},
+			want:     true,
+		},
+		{
+			name: "Should return false if spot market options match the spot market type of the launch template",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				SpotMarketOptions: &infrav1.SpotMarketOptions{MaxPrice: aws.String("0.5")},
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{
+				IamInstanceProfile: "test-cluster-nodes",
+				MarketType:         infrav1.MarketTypeSpot,
+				AdditionalSecurityGroups: []infrav1.AWSResourceReference{
+					{ID: aws.String("sg-111")},
+					{ID: aws.String("sg-222")},
+				},
+			},
+			nodeRole: &infrav1.NodeRoleStatus{RoleName: "test-cluster-nodes", InstanceProfileName: "test-cluster-nodes"},
+			want:     false,
+		},
+		{
+			name: "Should return true if incoming CapacityReservationID is not same as existing CapacityReservationID",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				MarketType:            infrav1.MarketTypeCapacityBlock,
+				CapacityReservationID: aws.String("cr-0123456789abcdef1"),
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{
+				MarketType:            infrav1.MarketTypeCapacityBlock,
+				CapacityReservationID: aws.String("cr-0123456789abcdef0"),
+			},
+			want: true,
+		},
+		{
+			name: "Should return true if the instances target another capacity reservation resource group",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				CapacityReservationResourceGroupARN: aws.String("arn:aws:resource-groups:us-east-1:123456789012:group/reservations"),
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{},
+			want:     true,
+		},
+		{
+			name: "Should return true if the instances are moved to another partition of the placement group",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				PlacementGroupName:      "hpc",
+				PlacementGroupPartition: 2,
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{
+				PlacementGroupName:      "hpc",
+				PlacementGroupPartition: 1,
+			},
+			want: true,
+		},
+		{
+			name: "Should return true if Nitro Enclaves are enabled",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				EnclaveOptions: &expinfrav1.EnclaveOptions{Enabled: true},
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{},
+			want:     true,
+		},
+		{
+			name: "Should return false if Nitro Enclaves are disabled explicitly",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				EnclaveOptions: &expinfrav1.EnclaveOptions{Enabled: false},
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{
+				IamInstanceProfile: "test-cluster-nodes",
+				AdditionalSecurityGroups: []infrav1.AWSResourceReference{
+					{ID: aws.String("sg-111")},
+					{ID: aws.String("sg-222")},
+				},
+			},
+			nodeRole: &infrav1.NodeRoleStatus{RoleName: "test-cluster-nodes", InstanceProfileName: "test-cluster-nodes"},
+			want:     false,
+		},
+		{
+			name: "Should return false if Elastic Inference accelerators only differ by their default count",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				ElasticInferenceAccelerators: []expinfrav1.ElasticInferenceAccelerator{{Type: "eia2.medium"}},
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{
+				IamInstanceProfile:           "test-cluster-nodes",
+				ElasticInferenceAccelerators: []expinfrav1.ElasticInferenceAccelerator{{Type: "eia2.medium", Count: 1}},
+				AdditionalSecurityGroups: []infrav1.AWSResourceReference{
+					{ID: aws.String("sg-111")},
+					{ID: aws.String("sg-222")},
+				},
+			},
+			nodeRole: &infrav1.NodeRoleStatus{RoleName: "test-cluster-nodes", InstanceProfileName: "test-cluster-nodes"},
+			want:     false,
+		},
+		{
+			name: "Should return true if Elastic Inference accelerators are added",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				ElasticInferenceAccelerators: []expinfrav1.ElasticInferenceAccelerator{{Type: "eia2.medium", Count: 2}},
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{},
+			want:     true,
+		},
+		{
+			name: "Should return false if network interfaces only differ by the primary network interface and their default type",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				PlacementGroupName: "hpc",
+				NetworkInterfaces: []expinfrav1.LaunchTemplateNetworkInterface{
+					{DeviceIndex: 1, InterfaceType: expinfrav1.NetworkInterfaceTypeEFA, SubnetID: "subnet-1"},
+				},
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{
+				IamInstanceProfile: "test-cluster-nodes",
+				PlacementGroupName: "hpc",
+				AdditionalSecurityGroups: []infrav1.AWSResourceReference{
+					{ID: aws.String("sg-111")},
+					{ID: aws.String("sg-222")},
+				},
+				NetworkInterfaces: []expinfrav1.LaunchTemplateNetworkInterface{
+					{DeviceIndex: 0, InterfaceType: expinfrav1.NetworkInterfaceTypeInterface},
+					{DeviceIndex: 1, InterfaceType: expinfrav1.NetworkInterfaceTypeEFA, SubnetID: "subnet-1"},
+				},
+			},
+			nodeRole: &infrav1.NodeRoleStatus{RoleName: "test-cluster-nodes", InstanceProfileName: "test-cluster-nodes"},
+			want:     false,
+		},
+		{
+			name: "Should return true if a network interface is added",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				NetworkInterfaces: []expinfrav1.LaunchTemplateNetworkInterface{{DeviceIndex: 1}},
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{},
+			want:     true,
+		},
+		{
+			name: "Should return true if the security groups of a network interface change",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				NetworkInterfaces: []expinfrav1.LaunchTemplateNetworkInterface{
+					{DeviceIndex: 1, SecurityGroups: []infrav1.AWSResourceReference{{ID: aws.String("sg-333")}}},
+				},
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{
+				IamInstanceProfile: "test-cluster-nodes",
+				AdditionalSecurityGroups: []infrav1.AWSResourceReference{
+					{ID: aws.String("sg-111")},
+					{ID: aws.String("sg-222")},
+				},
+				NetworkInterfaces: []expinfrav1.LaunchTemplateNetworkInterface{
+					{DeviceIndex: 0},
+					{DeviceIndex: 1, SecurityGroups: []infrav1.AWSResourceReference{{ID: aws.String("sg-444")}}},
+				},
+			},
+			nodeRole: &infrav1.NodeRoleStatus{RoleName: "test-cluster-nodes", InstanceProfileName: "test-cluster-nodes"},
+			want:     true,
+		},
 		{
 			name: "new additional security group with filters",
 			incoming: &expinfrav1.AWSLaunchTemplate{
@@ -573,8 +875,12 @@ func TestServiceLaunchTemplateNeedsUpdate(t *testing.T) {
 							},
 						},
 					},
+					NodeRole: tt.nodeRole,
 				},
 			}
+			if tt.nodeRole != nil {
+				ac.Spec.NodeRoleManagement = &infrav1.NodeRoleManagement{Create: !tt.nodeRoleRemoved}
+			}
 			s := &Service{
 				scope: &scope.ClusterScope{
 					AWSCluster: ac,
@@ -592,102 +898,278 @@ func TestServiceLaunchTemplateNeedsUpdate(t *testing.T) {
 				tt.expect(mockEC2Client.EXPECT())
 			}
 
-			got, err := s.LaunchTemplateNeedsUpdate(machinePoolScope, tt.incoming, tt.existing)
+			got, err := s.LaunchTemplateChangedFields(machinePoolScope, tt.incoming, tt.existing)
 			if tt.wantErr {
 				g.Expect(err).To(HaveOccurred())
 				return
 			}
 			g.Expect(err).NotTo(HaveOccurred())
-			g.Expect(got).Should(Equal(tt.want))
+			g.Expect(len(got) > 0).Should(Equal(tt.want))
+			if tt.wantFields != nil {
+				g.Expect(got).Should(Equal(tt.wantFields))
+			}
 		})
 	}
 }
 
-func TestGetLaunchTemplateID(t *testing.T) {
-	mockCtrl := gomock.NewController(t)
-	defer mockCtrl.Finish()
-
-	testCases := []struct {
-		name               string
-		launchTemplateName string
-		expect             func(m *mocks.MockEC2APIMockRecorder)
-		check              func(g *WithT, launchTemplateID string, err error)
+func TestClassifyLaunchTemplateChange(t *testing.T) {
+	tests := []struct {
+		name                    string
+		changedFields           []string
+		triggerOnUserDataChange bool
+		want                    *expinfrav1.LaunchTemplateChange
 	}{
 		{
-			name:   "Should return with no error if empty launch template name passed",
-			expect: func(m *mocks.MockEC2APIMockRecorder) {},
-			check: func(g *WithT, launchTemplateID string, err error) {
-				g.Expect(err).NotTo(HaveOccurred())
-				g.Expect(launchTemplateID).Should(BeEmpty())
-			},
+			name: "nothing changed",
 		},
 		{
-			name:               "Should not return error if launch template does not exist",
-			launchTemplateName: "foo",
-			expect: func(m *mocks.MockEC2APIMockRecorder) {
-				m.DescribeLaunchTemplateVersionsWithContext(context.TODO(), gomock.Eq(&ec2.DescribeLaunchTemplateVersionsInput{
-					LaunchTemplateName: aws.String("foo"),
-					Versions:           []*string{aws.String("$Latest")},
-				})).Return(nil, awserr.New(
-					awserrors.LaunchTemplateNameNotFound,
-					"The specified launch template, with template name foo, does not exist.",
-					nil,
-				))
-			},
-			check: func(g *WithT, launchTemplateID string, err error) {
-				g.Expect(err).NotTo(HaveOccurred())
-				g.Expect(launchTemplateID).Should(BeEmpty())
-			},
+			name:          "only the userdata changed",
+			changedFields: []string{"userData"},
+			want:          &expinfrav1.LaunchTemplateChange{ChangedFields: []string{"userData"}, UserDataOnly: true},
 		},
 		{
-			name:               "Should return with error if AWS failed to fetch launch template",
-			launchTemplateName: "foo",
-			expect: func(m *mocks.MockEC2APIMockRecorder) {
-				m.DescribeLaunchTemplateVersionsWithContext(context.TODO(), gomock.Eq(&ec2.DescribeLaunchTemplateVersionsInput{
-					LaunchTemplateName: aws.String("foo"),
-					Versions:           []*string{aws.String("$Latest")},
-				})).Return(nil, awserrors.NewFailedDependency("Dependency issue from AWS"))
-			},
-			check: func(g *WithT, launchTemplateID string, err error) {
-				g.Expect(err).To(HaveOccurred())
-				g.Expect(launchTemplateID).Should(BeEmpty())
-			},
+			name:                    "only the userdata changed with the refresh triggered on userdata changes",
+			changedFields:           []string{"userData"},
+			triggerOnUserDataChange: true,
+			want:                    &expinfrav1.LaunchTemplateChange{ChangedFields: []string{"userData"}, UserDataOnly: true, RolledOut: true},
 		},
 		{
-			name:               "Should not return error if AWS returns no launch template versions info in output",
-			launchTemplateName: "foo",
-			expect: func(m *mocks.MockEC2APIMockRecorder) {
-				m.DescribeLaunchTemplateVersionsWithContext(context.TODO(), gomock.Eq(&ec2.DescribeLaunchTemplateVersionsInput{
-					LaunchTemplateName: aws.String("foo"),
-					Versions:           []*string{aws.String("$Latest")},
-				})).Return(nil, nil)
-			},
-			check: func(g *WithT, launchTemplateID string, err error) {
-				g.Expect(err).NotTo(HaveOccurred())
-				g.Expect(launchTemplateID).Should(BeEmpty())
-			},
+			name:          "the AMI and the userdata changed",
+			changedFields: []string{"ami", "userData"},
+			want:          &expinfrav1.LaunchTemplateChange{ChangedFields: []string{"ami", "userData"}, AMIChanged: true, RolledOut: true},
 		},
 		{
-			name:               "Should successfully return launch template ID for given name if exists",
-			launchTemplateName: "foo",
-			expect: func(m *mocks.MockEC2APIMockRecorder) {
-				m.DescribeLaunchTemplateVersionsWithContext(context.TODO(), gomock.Eq(&ec2.DescribeLaunchTemplateVersionsInput{
-					LaunchTemplateName: aws.String("foo"),
-					Versions:           []*string{aws.String("$Latest")},
-				})).Return(&ec2.DescribeLaunchTemplateVersionsOutput{
-					LaunchTemplateVersions: []*ec2.LaunchTemplateVersion{
-						{
-							LaunchTemplateId:   aws.String("lt-12345"),
-							LaunchTemplateName: aws.String("foo"),
-							LaunchTemplateData: &ec2.ResponseLaunchTemplateData{
-								ImageId: aws.String("foo-image"),
-								IamInstanceProfile: &ec2.LaunchTemplateIamInstanceProfileSpecification{
-									Arn: aws.String("instance-profile/foo-profile"),
-								},
-								KeyName: aws.String("foo-keyname"),
-								BlockDeviceMappings: []*ec2.LaunchTemplateBlockDeviceMapping{
-									{
-										DeviceName: aws.String("foo-device"),
+			name:          "the instance type changed",
+			changedFields: []string{"instanceType"},
+			want:          &expinfrav1.LaunchTemplateChange{ChangedFields: []string{"instanceType"}, HardwareConfigChanged: true, RolledOut: true},
+		},
+		{
+			name:          "the userdata changed with its secret",
+			changedFields: []string{"userData", "userDataSecret"},
+			want:          &expinfrav1.LaunchTemplateChange{ChangedFields: []string{"userData", "userDataSecret"}, RolledOut: true},
+		},
+		{
+			name:          "the tags changed",
+			changedFields: []string{"additionalTags"},
+			want:          &expinfrav1.LaunchTemplateChange{ChangedFields: []string{"additionalTags"}, RolledOut: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			got := classifyLaunchTemplateChange(tt.changedFields, tt.triggerOnUserDataChange)
+			if tt.want == nil {
+				g.Expect(got).To(BeNil())
+				return
+			}
+			g.Expect(got).NotTo(BeNil())
+			g.Expect(got.Time.IsZero()).To(BeFalse())
+			got.Time = metav1.Time{}
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestReconcileAdditionalSecurityGroups(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	filters := []infrav1.Filter{{Name: "tag:team", Values: []string{"payments"}}}
+	describeInput := &ec2.DescribeSecurityGroupsInput{Filters: []*ec2.Filter{{Name: aws.String("tag:team"), Values: aws.StringSlice([]string{"payments"})}}}
+
+	t.Run("Should resolve filters once and record the resolved IDs for every pool sharing the cache", func(t *testing.T) {
+		g := NewWithT(t)
+		scheme, err := setupScheme()
+		g.Expect(err).NotTo(HaveOccurred())
+		client := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+		cs, err := setupClusterScope(client)
+		g.Expect(err).NotTo(HaveOccurred())
+
+		mockEC2Client := mocks.NewMockEC2API(mockCtrl)
+		mockEC2Client.EXPECT().DescribeSecurityGroupsWithContext(context.TODO(), gomock.Eq(describeInput)).
+			Return(&ec2.DescribeSecurityGroupsOutput{SecurityGroups: []*ec2.SecurityGroup{{GroupId: aws.String("sg-payments")}}}, nil).Times(1)
+
+		s := NewService(cs)
+		s.EC2Client = mockEC2Client
+
+		cache := scope.NewSecurityGroupFilterCache(scope.DefaultSecurityGroupFilterCacheTTL)
+		for i := 0; i < 2; i++ {
+			ms, err := setupMachinePoolScope(client, cs)
+			g.Expect(err).NotTo(HaveOccurred())
+			ms.SecurityGroupFilterCache = cache
+			ms.AWSMachinePool.Spec.AWSLaunchTemplate.AdditionalSecurityGroups = []infrav1.AWSResourceReference{
+				{ID: aws.String("sg-static")},
+				{Filters: filters},
+			}
+
+			g.Expect(s.reconcileAdditionalSecurityGroups(ms)).To(Succeed())
+			g.Expect(ms.AWSMachinePool.Status.AdditionalSecurityGroupIDs).To(Equal([]string{"sg-static", "sg-payments"}))
+			g.Expect(conditions.IsTrue(ms.AWSMachinePool, expinfrav1.AdditionalSecurityGroupsReadyCondition)).To(BeTrue())
+		}
+	})
+
+	t.Run("Should set the condition to false if filters cannot be resolved", func(t *testing.T) {
+		g := NewWithT(t)
+		scheme, err := setupScheme()
+		g.Expect(err).NotTo(HaveOccurred())
+		client := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+		cs, err := setupClusterScope(client)
+		g.Expect(err).NotTo(HaveOccurred())
+		ms, err := setupMachinePoolScope(client, cs)
+		g.Expect(err).NotTo(HaveOccurred())
+		ms.AWSMachinePool.Spec.AWSLaunchTemplate.AdditionalSecurityGroups = []infrav1.AWSResourceReference{{Filters: filters}}
+
+		mockEC2Client := mocks.NewMockEC2API(mockCtrl)
+		mockEC2Client.EXPECT().DescribeSecurityGroupsWithContext(context.TODO(), gomock.Eq(describeInput)).
+			Return(nil, awserrors.NewFailedDependency("dependency failure"))
+
+		s := NewService(cs)
+		s.EC2Client = mockEC2Client
+
+		g.Expect(s.reconcileAdditionalSecurityGroups(ms)).NotTo(Succeed())
+		g.Expect(conditions.IsFalse(ms.AWSMachinePool, expinfrav1.AdditionalSecurityGroupsReadyCondition)).To(BeTrue())
+		g.Expect(conditions.GetReason(ms.AWSMachinePool, expinfrav1.AdditionalSecurityGroupsReadyCondition)).To(Equal(expinfrav1.AdditionalSecurityGroupsResolutionFailedReason))
+	})
+
+	t.Run("Should keep the security groups last resolved if filters cannot be resolved", func(t *testing.T) {
+		g := NewWithT(t)
+		scheme, err := setupScheme()
+		g.Expect(err).NotTo(HaveOccurred())
+		client := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+		cs, err := setupClusterScope(client)
+		g.Expect(err).NotTo(HaveOccurred())
+		ms, err := setupMachinePoolScope(client, cs)
+		g.Expect(err).NotTo(HaveOccurred())
+		ms.AWSMachinePool.Spec.AWSLaunchTemplate.AdditionalSecurityGroups = []infrav1.AWSResourceReference{{Filters: filters}}
+		ms.AWSMachinePool.Status.AdditionalSecurityGroupIDs = []string{"sg-payments"}
+
+		mockEC2Client := mocks.NewMockEC2API(mockCtrl)
+		mockEC2Client.EXPECT().DescribeSecurityGroupsWithContext(context.TODO(), gomock.Eq(describeInput)).
+			Return(nil, awserrors.NewFailedDependency("dependency failure"))
+
+		s := NewService(cs)
+		s.EC2Client = mockEC2Client
+
+		ids, err := s.getAdditionalSecurityGroupsIDsCached(ms, ms.AWSMachinePool.Spec.AWSLaunchTemplate.AdditionalSecurityGroups)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(ids).To(Equal([]string{"sg-payments"}))
+	})
+
+	t.Run("Should set the condition to false if a filter matches no security group", func(t *testing.T) {
+		g := NewWithT(t)
+		scheme, err := setupScheme()
+		g.Expect(err).NotTo(HaveOccurred())
+		client := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+		cs, err := setupClusterScope(client)
+		g.Expect(err).NotTo(HaveOccurred())
+		ms, err := setupMachinePoolScope(client, cs)
+		g.Expect(err).NotTo(HaveOccurred())
+		ms.AWSMachinePool.Spec.AWSLaunchTemplate.AdditionalSecurityGroups = []infrav1.AWSResourceReference{{Filters: filters}}
+
+		mockEC2Client := mocks.NewMockEC2API(mockCtrl)
+		mockEC2Client.EXPECT().DescribeSecurityGroupsWithContext(context.TODO(), gomock.Eq(describeInput)).
+			Return(&ec2.DescribeSecurityGroupsOutput{}, nil)
+
+		s := NewService(cs)
+		s.EC2Client = mockEC2Client
+
+		g.Expect(s.reconcileAdditionalSecurityGroups(ms)).To(Succeed())
+		g.Expect(ms.AWSMachinePool.Status.AdditionalSecurityGroupIDs).To(BeEmpty())
+		g.Expect(conditions.IsFalse(ms.AWSMachinePool, expinfrav1.AdditionalSecurityGroupsReadyCondition)).To(BeTrue())
+		g.Expect(conditions.GetReason(ms.AWSMachinePool, expinfrav1.AdditionalSecurityGroupsReadyCondition)).To(Equal(expinfrav1.AdditionalSecurityGroupsNotFoundReason))
+	})
+}
+
+func TestGetLaunchTemplateID(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	testCases := []struct {
+		name               string
+		launchTemplateName string
+		expect             func(m *mocks.MockEC2APIMockRecorder)
+		check              func(g *WithT, launchTemplateID string, err error)
+	}{
+		{
+			name:   "Should return with no error if empty launch template name passed",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {},
+			check: func(g *WithT, launchTemplateID string, err error) {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(launchTemplateID).Should(BeEmpty())
+			},
+		},
+		{
+			name:               "Should not return error if launch template does not exist",
+			launchTemplateName: "foo",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeLaunchTemplateVersionsWithContext(context.TODO(), gomock.Eq(&ec2.DescribeLaunchTemplateVersionsInput{
+					LaunchTemplateName: aws.String("foo"),
+					Versions:           []*string{aws.String("$Latest")},
+				})).Return(nil, awserr.New(
+					awserrors.LaunchTemplateNameNotFound,
+					"The specified launch template, with template name foo, does not exist.",
+					nil,
+				))
+			},
+			check: func(g *WithT, launchTemplateID string, err error) {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(launchTemplateID).Should(BeEmpty())
+			},
+		},
+		{
+			name:               "Should return with error if AWS failed to fetch launch template",
+			launchTemplateName: "foo",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeLaunchTemplateVersionsWithContext(context.TODO(), gomock.Eq(&ec2.DescribeLaunchTemplateVersionsInput{
+					LaunchTemplateName: aws.String("foo"),
+					Versions:           []*string{aws.String("$Latest")},
+				})).Return(nil, awserrors.NewFailedDependency("Dependency issue from AWS"))
+			},
+			check: func(g *WithT, launchTemplateID string, err error) {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(launchTemplateID).Should(BeEmpty())
+			},
+		},
+		{
+			name:               "Should not return error if AWS returns no launch template versions info in output",
+			launchTemplateName: "foo",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeLaunchTemplateVersionsWithContext(context.TODO(), gomock.Eq(&ec2.DescribeLaunchTemplateVersionsInput{
+					LaunchTemplateName: aws.String("foo"),
+					Versions:           []*string{aws.String("$Latest")},
+				})).Return(nil, nil)
+			},
+			check: func(g *WithT, launchTemplateID string, err error) {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(launchTemplateID).Should(BeEmpty())
+			},
+		},
+		{
+			name:               "Should successfully return launch template ID for given name if exists",
+			launchTemplateName: "foo",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeLaunchTemplateVersionsWithContext(context.TODO(), gomock.Eq(&ec2.DescribeLaunchTemplateVersionsInput{
+					LaunchTemplateName: aws.String("foo"),
+					Versions:           []*string{aws.String("$Latest")},
+				})).Return(&ec2.DescribeLaunchTemplateVersionsOutput{
+					LaunchTemplateVersions: []*ec2.LaunchTemplateVersion{
+						{
+							LaunchTemplateId:   aws.String("lt-12345"),
+							LaunchTemplateName: aws.String("foo"),
+							LaunchTemplateData: &ec2.ResponseLaunchTemplateData{
+								ImageId: aws.String("foo-image"),
+								IamInstanceProfile: &ec2.LaunchTemplateIamInstanceProfileSpecification{
+									Arn: aws.String("instance-profile/foo-profile"),
+								},
+								KeyName: aws.String("foo-keyname"),
+								BlockDeviceMappings: []*ec2.LaunchTemplateBlockDeviceMapping{
+									{
+										DeviceName: aws.String("foo-device"),
 										Ebs: &ec2.LaunchTemplateEbsBlockDevice{
 											Encrypted:  aws.Bool(true),
 											VolumeSize: aws.Int64(16),
@@ -768,6 +1250,15 @@ func TestDeleteLaunchTemplate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name:      "Should not return error if the given launch template ID doesn't exist anymore",
+			versionID: "1",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DeleteLaunchTemplateWithContext(context.TODO(), gomock.Eq(&ec2.DeleteLaunchTemplateInput{
+					LaunchTemplateId: aws.String("1"),
+				})).Return(nil, awserr.New(awserrors.LaunchTemplateIDNotFound, "not found", nil))
+			},
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -795,6 +1286,54 @@ func TestDeleteLaunchTemplate(t *testing.T) {
 	}
 }
 
+func TestDeleteLaunchTemplateByName(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	testCases := []struct {
+		name    string
+		err     error
+		wantErr bool
+	}{
+		{
+			name: "Should not return error if successfully deletes given launch template name",
+		},
+		{
+			name: "Should not return error if the given launch template name doesn't exist anymore",
+			err:  awserr.New(awserrors.LaunchTemplateNameNotFound, "not found", nil),
+		},
+		{
+			name:    "Should return error if failed to delete given launch template name",
+			err:     awserrors.NewFailedDependency("dependency failure"),
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+			cs, err := setupClusterScope(fake.NewClientBuilder().WithScheme(scheme).Build())
+			g.Expect(err).NotTo(HaveOccurred())
+			mockEC2Client := mocks.NewMockEC2API(mockCtrl)
+
+			s := NewService(cs)
+			s.EC2Client = mockEC2Client
+			mockEC2Client.EXPECT().DeleteLaunchTemplateWithContext(context.TODO(), gomock.Eq(&ec2.DeleteLaunchTemplateInput{
+				LaunchTemplateName: aws.String("test-lt"),
+			})).Return(&ec2.DeleteLaunchTemplateOutput{}, tc.err)
+
+			err = s.DeleteLaunchTemplateByName("test-lt")
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+		})
+	}
+}
+
 func TestCreateLaunchTemplate(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
@@ -1056,6 +1595,53 @@ func TestLaunchTemplateDataCreation(t *testing.T) {
 	})
 }
 
+func TestGetLaunchTemplateNetworkInterfacesRequest(t *testing.T) {
+	g := NewWithT(t)
+	scheme, err := setupScheme()
+	g.Expect(err).NotTo(HaveOccurred())
+	client := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	cs, err := setupClusterScope(client)
+	g.Expect(err).NotTo(HaveOccurred())
+	ms, err := setupMachinePoolScope(client, cs)
+	g.Expect(err).NotTo(HaveOccurred())
+	s := NewService(cs)
+
+	request, err := s.getLaunchTemplateNetworkInterfacesRequest(ms, &expinfrav1.AWSLaunchTemplate{}, aws.StringSlice([]string{"sg-node"}))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(request).To(BeNil())
+
+	lt := &expinfrav1.AWSLaunchTemplate{
+		NetworkInterfaces: []expinfrav1.LaunchTemplateNetworkInterface{
+			{
+				DeviceIndex:      1,
+				InterfaceType:    expinfrav1.NetworkInterfaceTypeEFA,
+				SubnetID:         "subnet-1",
+				SecurityGroups:   []infrav1.AWSResourceReference{{ID: aws.String("sg-efa")}},
+				IPv6AddressCount: aws.Int64(1),
+			},
+		},
+	}
+	request, err = s.getLaunchTemplateNetworkInterfacesRequest(ms, lt, aws.StringSlice([]string{"sg-node", "sg-additional"}))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(request).To(Equal([]*ec2.LaunchTemplateInstanceNetworkInterfaceSpecificationRequest{
+		{
+			DeviceIndex:         aws.Int64(0),
+			InterfaceType:       aws.String("interface"),
+			DeleteOnTermination: aws.Bool(true),
+			Groups:              aws.StringSlice([]string{"sg-node", "sg-additional"}),
+		},
+		{
+			DeviceIndex:         aws.Int64(1),
+			InterfaceType:       aws.String("efa"),
+			DeleteOnTermination: aws.Bool(true),
+			Groups:              aws.StringSlice([]string{"sg-efa"}),
+			SubnetId:            aws.String("subnet-1"),
+			Ipv6AddressCount:    aws.Int64(1),
+		},
+	}))
+}
+
 func TestCreateLaunchTemplateVersion(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
@@ -1329,37 +1915,34 @@ func TestDiscoverLaunchTemplateAMI(t *testing.T) {
 			},
 		},
 		{
-			name: "Should return AMI and use infra cluster image details, if not passed in aws launchtemplate",
+			name: "Should look up the GPU variant of the default image with the gpu AMI type",
 			awsLaunchTemplate: expinfrav1.AWSLaunchTemplate{
-				Name:         "aws-launch-tmpl",
-				InstanceType: "m5.large",
+				Name:              "aws-launch-tmpl",
+				InstanceType:      "g5.xlarge",
+				ImageLookupFormat: DefaultAmiNameFormat,
+				ImageLookupBaseOS: "ubuntu-24.04",
+				AMIType:           expinfrav1.AMITypeGPU,
 			},
 			machineTemplate: clusterv1.MachineTemplateSpec{
 				Spec: clusterv1.MachineSpec{
-					Version: aws.String(DefaultAmiNameFormat),
+					Version: aws.String("v1.30.0"),
 				},
 			},
 			expect: func(m *mocks.MockEC2APIMockRecorder) {
 				m.DescribeImagesWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeImagesInput{})).
-					Return(&ec2.DescribeImagesOutput{
-						Images: []*ec2.Image{
-							{
-								ImageId:      aws.String("ancient"),
-								CreationDate: aws.String("2011-02-08T17:02:31.000Z"),
-							},
-							{
-								ImageId:      aws.String("latest"),
-								CreationDate: aws.String("2019-02-08T17:02:31.000Z"),
-							},
-							{
-								ImageId:      aws.String("oldest"),
-								CreationDate: aws.String("2014-02-08T17:02:31.000Z"),
-							},
-						},
-					}, nil)
+					DoAndReturn(func(_ context.Context, input *ec2.DescribeImagesInput, _ ...request.Option) (*ec2.DescribeImagesOutput, error) {
+						if !slices.ContainsFunc(input.Filters, func(f *ec2.Filter) bool {
+							return aws.StringValue(f.Name) == "name" && aws.StringValue(f.Values[0]) == "capa-ami-ubuntu-24.04-gpu-?1.30.0-*"
+						}) {
+							return nil, errors.New("unexpected image name filter")
+						}
+						return &ec2.DescribeImagesOutput{
+							Images: []*ec2.Image{{ImageId: aws.String("gpu"), CreationDate: aws.String("2019-02-08T17:02:31.000Z")}},
+						}, nil
+					})
 				m.DescribeInstanceTypesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInstanceTypesInput{
 					InstanceTypes: []*string{
-						aws.String("m5.large"),
+						aws.String("g5.xlarge"),
 					},
 				})).
 					Return(&ec2.DescribeInstanceTypesOutput{
@@ -1375,29 +1958,132 @@ func TestDiscoverLaunchTemplateAMI(t *testing.T) {
 					}, nil)
 			},
 			check: func(g *WithT, res *string, err error) {
-				g.Expect(res).Should(Equal(aws.String("latest")))
 				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(res).Should(Equal(aws.String("gpu")))
 			},
 		},
 		{
-			name: "Should return arm64 AMI and use infra cluster image details, if not passed in aws launchtemplate",
+			name: "Should look up the latest Bottlerocket AMI with the bottlerocket OS family",
 			awsLaunchTemplate: expinfrav1.AWSLaunchTemplate{
 				Name:         "aws-launch-tmpl",
-				InstanceType: "t4g.large",
+				InstanceType: "m7g.large",
+				OSFamily:     expinfrav1.OSFamilyBottlerocket,
 			},
 			machineTemplate: clusterv1.MachineTemplateSpec{
 				Spec: clusterv1.MachineSpec{
-					Version: aws.String(DefaultAmiNameFormat),
+					Version: aws.String("v1.30.2"),
 				},
 			},
 			expect: func(m *mocks.MockEC2APIMockRecorder) {
 				m.DescribeImagesWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeImagesInput{})).
-					Return(&ec2.DescribeImagesOutput{
-						Images: []*ec2.Image{
-							{
-								ImageId:      aws.String("ancient"),
-								CreationDate: aws.String("2011-02-08T17:02:31.000Z"),
-							},
+					DoAndReturn(func(_ context.Context, input *ec2.DescribeImagesInput, _ ...request.Option) (*ec2.DescribeImagesOutput, error) {
+						if len(input.Owners) != 1 || aws.StringValue(input.Owners[0]) != "amazon" {
+							return nil, errors.New("unexpected image owner")
+						}
+						if !slices.ContainsFunc(input.Filters, func(f *ec2.Filter) bool {
+							return aws.StringValue(f.Name) == "name" && aws.StringValue(f.Values[0]) == "bottlerocket-aws-k8s-1.30-aarch64-v*"
+						}) {
+							return nil, errors.New("unexpected image name filter")
+						}
+						return &ec2.DescribeImagesOutput{
+							Images: []*ec2.Image{
+								{ImageId: aws.String("older"), CreationDate: aws.String("2024-05-08T17:02:31.000Z")},
+								{ImageId: aws.String("bottlerocket"), CreationDate: aws.String("2024-07-08T17:02:31.000Z")},
+							},
+						}, nil
+					})
+				m.DescribeInstanceTypesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInstanceTypesInput{
+					InstanceTypes: []*string{
+						aws.String("m7g.large"),
+					},
+				})).
+					Return(&ec2.DescribeInstanceTypesOutput{
+						InstanceTypes: []*ec2.InstanceTypeInfo{
+							{
+								ProcessorInfo: &ec2.ProcessorInfo{
+									SupportedArchitectures: []*string{
+										aws.String("arm64"),
+									},
+								},
+							},
+						},
+					}, nil)
+			},
+			check: func(g *WithT, res *string, err error) {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(res).Should(Equal(aws.String("bottlerocket")))
+			},
+		},
+		{
+			name: "Should return AMI and use infra cluster image details, if not passed in aws launchtemplate",
+			awsLaunchTemplate: expinfrav1.AWSLaunchTemplate{
+				Name:         "aws-launch-tmpl",
+				InstanceType: "m5.large",
+			},
+			machineTemplate: clusterv1.MachineTemplateSpec{
+				Spec: clusterv1.MachineSpec{
+					Version: aws.String(DefaultAmiNameFormat),
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeImagesWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeImagesInput{})).
+					Return(&ec2.DescribeImagesOutput{
+						Images: []*ec2.Image{
+							{
+								ImageId:      aws.String("ancient"),
+								CreationDate: aws.String("2011-02-08T17:02:31.000Z"),
+							},
+							{
+								ImageId:      aws.String("latest"),
+								CreationDate: aws.String("2019-02-08T17:02:31.000Z"),
+							},
+							{
+								ImageId:      aws.String("oldest"),
+								CreationDate: aws.String("2014-02-08T17:02:31.000Z"),
+							},
+						},
+					}, nil)
+				m.DescribeInstanceTypesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInstanceTypesInput{
+					InstanceTypes: []*string{
+						aws.String("m5.large"),
+					},
+				})).
+					Return(&ec2.DescribeInstanceTypesOutput{
+						InstanceTypes: []*ec2.InstanceTypeInfo{
+							{
+								ProcessorInfo: &ec2.ProcessorInfo{
+									SupportedArchitectures: []*string{
+										aws.String("x86_64"),
+									},
+								},
+							},
+						},
+					}, nil)
+			},
+			check: func(g *WithT, res *string, err error) {
+				g.Expect(res).Should(Equal(aws.String("latest")))
+				g.Expect(err).NotTo(HaveOccurred())
+			},
+		},
+		{
+			name: "Should return arm64 AMI and use infra cluster image details, if not passed in aws launchtemplate",
+			awsLaunchTemplate: expinfrav1.AWSLaunchTemplate{
+				Name:         "aws-launch-tmpl",
+				InstanceType: "t4g.large",
+			},
+			machineTemplate: clusterv1.MachineTemplateSpec{
+				Spec: clusterv1.MachineSpec{
+					Version: aws.String(DefaultAmiNameFormat),
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeImagesWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeImagesInput{})).
+					Return(&ec2.DescribeImagesOutput{
+						Images: []*ec2.Image{
+							{
+								ImageId:      aws.String("ancient"),
+								CreationDate: aws.String("2011-02-08T17:02:31.000Z"),
+							},
 							{
 								ImageId:      aws.String("latest"),
 								CreationDate: aws.String("2019-02-08T17:02:31.000Z"),
@@ -1597,6 +2283,198 @@ func TestDiscoverLaunchTemplateAMIForEKS(t *testing.T) {
 	}
 }
 
+func TestDiscoverLaunchTemplateAMIFromSourceRegion(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	sourceAMI := infrav1.AMIReference{
+		ID:           aws.String("ami-source"),
+		SourceRegion: "eu-west-1",
+	}
+	copied := &expinfrav1.CopiedAMI{
+		SourceRegion: "eu-west-1",
+		SourceID:     "ami-source",
+		ID:           "ami-copy",
+	}
+	describeImage := func(id string) *ec2.DescribeImagesInput {
+		return &ec2.DescribeImagesInput{ImageIds: []*string{aws.String(id)}}
+	}
+	describeCopy := func(name string) *ec2.DescribeImagesInput {
+		return &ec2.DescribeImagesInput{
+			Owners: []*string{aws.String("self")},
+			Filters: []*ec2.Filter{
+				{
+					Name:   aws.String("name"),
+					Values: []*string{aws.String(name)},
+				},
+			},
+		}
+	}
+	encryptedAMI := infrav1.AMIReference{
+		ID:                sourceAMI.ID,
+		SourceRegion:      sourceAMI.SourceRegion,
+		CopyEncryptionKey: "alias/copy",
+	}
+	encryptedCopyName := copiedAMIName("ami-source", "eu-west-1", "alias/copy")
+
+	testCases := []struct {
+		name         string
+		ami          infrav1.AMIReference
+		copiedStatus *expinfrav1.CopiedAMI
+		expect       func(m *mocks.MockEC2APIMockRecorder)
+		check        func(*WithT, *string, error)
+		wantStatus   *expinfrav1.CopiedAMI
+	}{
+		{
+			name:         "Should use the AMI when it exists in the region of the cluster",
+			ami:          sourceAMI,
+			copiedStatus: &expinfrav1.CopiedAMI{SourceRegion: "eu-west-1", SourceID: "ami-old", ID: "ami-old-copy"},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeImagesWithContext(context.TODO(), gomock.Eq(describeImage("ami-source"))).
+					Return(&ec2.DescribeImagesOutput{Images: []*ec2.Image{{ImageId: aws.String("ami-source")}}}, nil)
+			},
+			check: func(g *WithT, res *string, err error) {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(res).To(Equal(aws.String("ami-source")))
+			},
+		},
+		{
+			name: "Should copy the AMI from the source region when it doesn't exist in the region of the cluster",
+			ami:  sourceAMI,
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeImagesWithContext(context.TODO(), gomock.Eq(describeImage("ami-source"))).
+					Return(nil, awserr.New(awserrors.ImageNotFound, "not found", nil))
+				m.DescribeImagesWithContext(context.TODO(), gomock.Eq(describeCopy("ami-source-eu-west-1"))).
+					Return(&ec2.DescribeImagesOutput{}, nil)
+				m.CopyImageWithContext(context.TODO(), gomock.Eq(&ec2.CopyImageInput{
+					Name:          aws.String("ami-source-eu-west-1"),
+					Description:   aws.String("Copy of ami-source from eu-west-1"),
+					SourceImageId: aws.String("ami-source"),
+					SourceRegion:  aws.String("eu-west-1"),
+				})).Return(&ec2.CopyImageOutput{ImageId: aws.String("ami-copy")}, nil)
+				m.DescribeImagesWithContext(context.TODO(), gomock.Eq(describeImage("ami-copy"))).
+					Return(&ec2.DescribeImagesOutput{Images: []*ec2.Image{{ImageId: aws.String("ami-copy"), State: aws.String(ec2.ImageStatePending)}}}, nil)
+			},
+			check: func(g *WithT, res *string, err error) {
+				g.Expect(res).To(BeNil())
+				g.Expect(awserrors.IsFailedDependency(err)).To(BeTrue())
+			},
+			wantStatus: copied,
+		},
+		{
+			name:         "Should copy the AMI again when the encryption key of the copy changes",
+			ami:          encryptedAMI,
+			copiedStatus: copied,
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeImagesWithContext(context.TODO(), gomock.Eq(describeImage("ami-source"))).
+					Return(nil, awserr.New(awserrors.ImageNotFound, "not found", nil))
+				m.DescribeImagesWithContext(context.TODO(), gomock.Eq(describeCopy(encryptedCopyName))).
+					Return(&ec2.DescribeImagesOutput{}, nil)
+				m.CopyImageWithContext(context.TODO(), gomock.Eq(&ec2.CopyImageInput{
+					Name:          aws.String(encryptedCopyName),
+					Description:   aws.String("Copy of ami-source from eu-west-1"),
+					SourceImageId: aws.String("ami-source"),
+					SourceRegion:  aws.String("eu-west-1"),
+					Encrypted:     aws.Bool(true),
+					KmsKeyId:      aws.String("alias/copy"),
+				})).Return(&ec2.CopyImageOutput{ImageId: aws.String("ami-encrypted-copy")}, nil)
+				m.DescribeImagesWithContext(context.TODO(), gomock.Eq(describeImage("ami-encrypted-copy"))).
+					Return(&ec2.DescribeImagesOutput{Images: []*ec2.Image{{ImageId: aws.String("ami-encrypted-copy"), State: aws.String(ec2.ImageStatePending)}}}, nil)
+			},
+			check: func(g *WithT, res *string, err error) {
+				g.Expect(res).To(BeNil())
+				g.Expect(awserrors.IsFailedDependency(err)).To(BeTrue())
+			},
+			wantStatus: &expinfrav1.CopiedAMI{
+				SourceRegion:  "eu-west-1",
+				SourceID:      "ami-source",
+				EncryptionKey: "alias/copy",
+				ID:            "ami-encrypted-copy",
+			},
+		},
+		{
+			name: "Should reuse an existing copy of the AMI",
+			ami:  sourceAMI,
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeImagesWithContext(context.TODO(), gomock.Eq(describeImage("ami-source"))).
+					Return(&ec2.DescribeImagesOutput{}, nil)
+				m.DescribeImagesWithContext(context.TODO(), gomock.Eq(describeCopy("ami-source-eu-west-1"))).
+					Return(&ec2.DescribeImagesOutput{Images: []*ec2.Image{{ImageId: aws.String("ami-copy")}}}, nil)
+				m.DescribeImagesWithContext(context.TODO(), gomock.Eq(describeImage("ami-copy"))).
+					Return(&ec2.DescribeImagesOutput{Images: []*ec2.Image{{ImageId: aws.String("ami-copy"), State: aws.String(ec2.ImageStateAvailable)}}}, nil)
+			},
+			check: func(g *WithT, res *string, err error) {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(res).To(Equal(aws.String("ami-copy")))
+			},
+			wantStatus: copied,
+		},
+		{
+			name:         "Should use the copy of the AMI once it is available",
+			ami:          sourceAMI,
+			copiedStatus: copied,
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeImagesWithContext(context.TODO(), gomock.Eq(describeImage("ami-copy"))).
+					Return(&ec2.DescribeImagesOutput{Images: []*ec2.Image{{ImageId: aws.String("ami-copy"), State: aws.String(ec2.ImageStateAvailable)}}}, nil)
+			},
+			check: func(g *WithT, res *string, err error) {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(res).To(Equal(aws.String("ami-copy")))
+			},
+			wantStatus: copied,
+		},
+		{
+			name:         "Should return a terminal error when the copy of the AMI failed",
+			ami:          sourceAMI,
+			copiedStatus: copied,
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeImagesWithContext(context.TODO(), gomock.Eq(describeImage("ami-copy"))).
+					Return(&ec2.DescribeImagesOutput{Images: []*ec2.Image{{
+						ImageId:     aws.String("ami-copy"),
+						State:       aws.String(ec2.ImageStateFailed),
+						StateReason: &ec2.StateReason{Message: aws.String("snapshot key not accessible")},
+					}}}, nil)
+			},
+			check: func(g *WithT, res *string, err error) {
+				g.Expect(res).To(BeNil())
+				g.Expect(errors.Is(err, ErrAMICopyFailed)).To(BeTrue())
+				g.Expect(err.Error()).To(ContainSubstring("snapshot key not accessible"))
+			},
+			wantStatus: copied,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+			awsMachinePool := newAWSMachinePool()
+			client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(awsMachinePool).WithStatusSubresource(awsMachinePool).Build()
+
+			cs, err := setupClusterScope(client)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			ms, err := setupMachinePoolScope(client, cs)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			ms.AWSMachinePool.Spec.AWSLaunchTemplate.AMI = tc.ami
+			ms.AWSMachinePool.Status.CopiedAMI = tc.copiedStatus
+
+			tc.expect(ec2Mock.EXPECT())
+
+			s := NewService(cs)
+			s.EC2Client = ec2Mock
+
+			id, err := s.DiscoverLaunchTemplateAMI(ms)
+			tc.check(g, id, err)
+			g.Expect(ms.AWSMachinePool.Status.CopiedAMI).To(Equal(tc.wantStatus))
+		})
+	}
+}
+
 func TestDeleteLaunchTemplateVersion(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
@@ -1675,3 +2553,1089 @@ func TestDeleteLaunchTemplateVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcileOverrideLaunchTemplates(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	userDataSecretKey := types.NamespacedName{
+		Namespace: "bootstrap-secret-ns",
+		Name:      "bootstrap-secret",
+	}
+	userData := []byte{1, 0, 0}
+	userDataHash := userdata.ComputeHash(userData)
+	overrideUserDataSecretKey := types.NamespacedName{
+		Namespace: "aws-mp-ns",
+		Name:      "m6i-bootstrap-secret",
+	}
+	overrideUserData := []byte{2, 0, 0}
+	overrideUserDataHash := userdata.ComputeHash(overrideUserData)
+	imageID := aws.String("imageID")
+	overrideName := "aws-mp-name-m6i.large"
+
+	existingLaunchTemplate := &expinfrav1.AWSLaunchTemplate{
+		Name: overrideName,
+		AMI:  infrav1.AMIReference{ID: imageID},
+	}
+
+	testCases := []struct {
+		name             string
+		rootVolume       *infrav1.Volume
+		ownUserData      bool
+		lastApplied      string
+		status           []expinfrav1.OverrideLaunchTemplate
+		canUpdate        bool
+		expect           func(m *mock_services.MockEC2InterfaceMockRecorder)
+		wantRollout      bool
+		wantStatus       []expinfrav1.OverrideLaunchTemplate
+		wantLastApplied  string
+		wantCanUpdateRun bool
+		wantErr          bool
+	}{
+		{
+			name:       "Should create the launch template of a new override",
+			rootVolume: &infrav1.Volume{Size: 50},
+			expect: func(m *mock_services.MockEC2InterfaceMockRecorder) {
+				m.GetLaunchTemplate(overrideName).Return(nil, "", nil, nil)
+				m.CreateLaunchTemplate(gomock.Any(), imageID, userDataSecretKey, userData).DoAndReturn(
+					func(lts scope.LaunchTemplateScope, _ *string, _ types.NamespacedName, _ []byte) (string, error) {
+						if lts.LaunchTemplateName() != overrideName {
+							t.Fatalf("expected launch template %q, got %q", overrideName, lts.LaunchTemplateName())
+						}
+						if lts.GetLaunchTemplate().InstanceType != "m6i.large" || lts.GetLaunchTemplate().RootVolume.Size != 50 {
+							t.Fatalf("unexpected launch template for override: %+v", lts.GetLaunchTemplate())
+						}
+						return "lt-override", nil
+					})
+				m.GetLaunchTemplateLatestVersion("lt-override").Return("1", nil)
+			},
+			wantRollout:     false,
+			wantStatus:      []expinfrav1.OverrideLaunchTemplate{{InstanceType: "m6i.large", ID: "lt-override", Version: aws.String("1")}},
+			wantLastApplied: `{"m6i.large":{"size":50}}`,
+		},
+		{
+			name:        "Should not create a new version if nothing changed",
+			rootVolume:  &infrav1.Volume{Size: 50},
+			lastApplied: `{"m6i.large":{"size":50}}`,
+			status:      []expinfrav1.OverrideLaunchTemplate{{InstanceType: "m6i.large", ID: "lt-override", Version: aws.String("2")}},
+			expect: func(m *mock_services.MockEC2InterfaceMockRecorder) {
+				m.GetLaunchTemplate(overrideName).Return(existingLaunchTemplate, userDataHash, &userDataSecretKey, nil)
+				m.LaunchTemplateChangedFields(gomock.Any(), gomock.Any(), existingLaunchTemplate).Return(nil, nil)
+			},
+			wantRollout:     false,
+			wantStatus:      []expinfrav1.OverrideLaunchTemplate{{InstanceType: "m6i.large", ID: "lt-override", Version: aws.String("2")}},
+			wantLastApplied: `{"m6i.large":{"size":50}}`,
+		},
+		{
+			name:        "Should prune and create a new version if the root volume changed",
+			rootVolume:  &infrav1.Volume{Size: 100},
+			lastApplied: `{"m6i.large":{"size":50}}`,
+			status:      []expinfrav1.OverrideLaunchTemplate{{InstanceType: "m6i.large", ID: "lt-override", Version: aws.String("2")}},
+			canUpdate:   true,
+			expect: func(m *mock_services.MockEC2InterfaceMockRecorder) {
+				m.GetLaunchTemplate(overrideName).Return(existingLaunchTemplate, userDataHash, &userDataSecretKey, nil)
+				m.LaunchTemplateChangedFields(gomock.Any(), gomock.Any(), existingLaunchTemplate).Return(nil, nil)
+				gomock.InOrder(
+					m.PruneLaunchTemplateVersions("lt-override", "").Return(nil),
+					m.CreateLaunchTemplateVersion("lt-override", gomock.Any(), imageID, userDataSecretKey, userData).Return(nil),
+					m.GetLaunchTemplateLatestVersion("lt-override").Return("3", nil),
+				)
+			},
+			wantRollout:      true,
+			wantCanUpdateRun: true,
+			wantStatus:       []expinfrav1.OverrideLaunchTemplate{{InstanceType: "m6i.large", ID: "lt-override", Version: aws.String("3")}},
+			wantLastApplied:  `{"m6i.large":{"size":100}}`,
+		},
+		{
+			name:        "Should create a new version without a rollout if only the user data changed",
+			rootVolume:  &infrav1.Volume{Size: 50},
+			lastApplied: `{"m6i.large":{"size":50}}`,
+			status:      []expinfrav1.OverrideLaunchTemplate{{InstanceType: "m6i.large", ID: "lt-override", Version: aws.String("2")}},
+			expect: func(m *mock_services.MockEC2InterfaceMockRecorder) {
+				m.GetLaunchTemplate(overrideName).Return(existingLaunchTemplate, "old-hash", &userDataSecretKey, nil)
+				m.LaunchTemplateChangedFields(gomock.Any(), gomock.Any(), existingLaunchTemplate).Return(nil, nil)
+				gomock.InOrder(
+					m.PruneLaunchTemplateVersions("lt-override", "").Return(nil),
+					m.CreateLaunchTemplateVersion("lt-override", gomock.Any(), imageID, userDataSecretKey, userData).Return(nil),
+					m.GetLaunchTemplateLatestVersion("lt-override").Return("3", nil),
+				)
+			},
+			wantRollout:     false,
+			wantStatus:      []expinfrav1.OverrideLaunchTemplate{{InstanceType: "m6i.large", ID: "lt-override", Version: aws.String("3")}},
+			wantLastApplied: `{"m6i.large":{"size":50}}`,
+		},
+		{
+			name:        "Should not create a new version if the launch template cannot be updated",
+			rootVolume:  &infrav1.Volume{Size: 100},
+			lastApplied: `{"m6i.large":{"size":50}}`,
+			status:      []expinfrav1.OverrideLaunchTemplate{{InstanceType: "m6i.large", ID: "lt-override", Version: aws.String("2")}},
+			canUpdate:   false,
+			expect: func(m *mock_services.MockEC2InterfaceMockRecorder) {
+				m.GetLaunchTemplate(overrideName).Return(existingLaunchTemplate, userDataHash, &userDataSecretKey, nil)
+				m.LaunchTemplateChangedFields(gomock.Any(), gomock.Any(), existingLaunchTemplate).Return(nil, nil)
+			},
+			wantCanUpdateRun: true,
+			wantStatus:       []expinfrav1.OverrideLaunchTemplate{{InstanceType: "m6i.large", ID: "lt-override", Version: aws.String("2")}},
+			wantLastApplied:  `{"m6i.large":{"size":50}}`,
+			wantErr:          true,
+		},
+		{
+			name:        "Should recover the ID and version of an existing launch template missing from the status",
+			rootVolume:  &infrav1.Volume{Size: 50},
+			lastApplied: `{"m6i.large":{"size":50}}`,
+			expect: func(m *mock_services.MockEC2InterfaceMockRecorder) {
+				m.GetLaunchTemplate(overrideName).Return(existingLaunchTemplate, userDataHash, &userDataSecretKey, nil)
+				m.GetLaunchTemplateID(overrideName).Return("lt-override", nil)
+				m.LaunchTemplateChangedFields(gomock.Any(), gomock.Any(), existingLaunchTemplate).Return(nil, nil)
+				m.GetLaunchTemplateLatestVersion("lt-override").Return("4", nil)
+			},
+			wantRollout:     false,
+			wantStatus:      []expinfrav1.OverrideLaunchTemplate{{InstanceType: "m6i.large", ID: "lt-override", Version: aws.String("4")}},
+			wantLastApplied: `{"m6i.large":{"size":50}}`,
+		},
+		{
+			name:        "Should create the launch template of an override with its own user data",
+			ownUserData: true,
+			expect: func(m *mock_services.MockEC2InterfaceMockRecorder) {
+				m.GetLaunchTemplate(overrideName).Return(nil, "", nil, nil)
+				m.CreateLaunchTemplate(gomock.Any(), imageID, overrideUserDataSecretKey, overrideUserData).DoAndReturn(
+					func(lts scope.LaunchTemplateScope, _ *string, _ types.NamespacedName, _ []byte) (string, error) {
+						if lts.GetLaunchTemplate().InstanceType != "m6i.large" || lts.GetLaunchTemplate().RootVolume != nil {
+							t.Fatalf("unexpected launch template for override: %+v", lts.GetLaunchTemplate())
+						}
+						return "lt-override", nil
+					})
+				m.GetLaunchTemplateLatestVersion("lt-override").Return("1", nil)
+			},
+			wantRollout:     false,
+			wantStatus:      []expinfrav1.OverrideLaunchTemplate{{InstanceType: "m6i.large", ID: "lt-override", Version: aws.String("1")}},
+			wantLastApplied: `{}`,
+		},
+		{
+			name:        "Should not create a new version if the own user data of an override didn't change",
+			ownUserData: true,
+			status:      []expinfrav1.OverrideLaunchTemplate{{InstanceType: "m6i.large", ID: "lt-override", Version: aws.String("2")}},
+			expect: func(m *mock_services.MockEC2InterfaceMockRecorder) {
+				m.GetLaunchTemplate(overrideName).Return(existingLaunchTemplate, overrideUserDataHash, &overrideUserDataSecretKey, nil)
+				m.LaunchTemplateChangedFields(gomock.Any(), gomock.Any(), existingLaunchTemplate).Return(nil, nil)
+			},
+			wantRollout:     false,
+			wantStatus:      []expinfrav1.OverrideLaunchTemplate{{InstanceType: "m6i.large", ID: "lt-override", Version: aws.String("2")}},
+			wantLastApplied: `{}`,
+		},
+		{
+			name:        "Should create a new version and roll out if the own user data of an override changed",
+			ownUserData: true,
+			status:      []expinfrav1.OverrideLaunchTemplate{{InstanceType: "m6i.large", ID: "lt-override", Version: aws.String("2")}},
+			canUpdate:   true,
+			expect: func(m *mock_services.MockEC2InterfaceMockRecorder) {
+				m.GetLaunchTemplate(overrideName).Return(existingLaunchTemplate, "old-hash", &overrideUserDataSecretKey, nil)
+				m.LaunchTemplateChangedFields(gomock.Any(), gomock.Any(), existingLaunchTemplate).Return(nil, nil)
+				gomock.InOrder(
+					m.PruneLaunchTemplateVersions("lt-override", "").Return(nil),
+					m.CreateLaunchTemplateVersion("lt-override", gomock.Any(), imageID, overrideUserDataSecretKey, overrideUserData).Return(nil),
+					m.GetLaunchTemplateLatestVersion("lt-override").Return("3", nil),
+				)
+			},
+			wantRollout:      true,
+			wantCanUpdateRun: true,
+			wantStatus:       []expinfrav1.OverrideLaunchTemplate{{InstanceType: "m6i.large", ID: "lt-override", Version: aws.String("3")}},
+			wantLastApplied:  `{}`,
+		},
+		{
+			name:        "Should roll out if an override switches to its own user data",
+			rootVolume:  &infrav1.Volume{Size: 50},
+			ownUserData: true,
+			lastApplied: `{"m6i.large":{"size":50}}`,
+			status:      []expinfrav1.OverrideLaunchTemplate{{InstanceType: "m6i.large", ID: "lt-override", Version: aws.String("2")}},
+			canUpdate:   true,
+			expect: func(m *mock_services.MockEC2InterfaceMockRecorder) {
+				m.GetLaunchTemplate(overrideName).Return(existingLaunchTemplate, userDataHash, &userDataSecretKey, nil)
+				m.LaunchTemplateChangedFields(gomock.Any(), gomock.Any(), existingLaunchTemplate).Return(nil, nil)
+				gomock.InOrder(
+					m.PruneLaunchTemplateVersions("lt-override", "").Return(nil),
+					m.CreateLaunchTemplateVersion("lt-override", gomock.Any(), imageID, overrideUserDataSecretKey, overrideUserData).Return(nil),
+					m.GetLaunchTemplateLatestVersion("lt-override").Return("3", nil),
+				)
+			},
+			wantRollout:      true,
+			wantCanUpdateRun: true,
+			wantStatus:       []expinfrav1.OverrideLaunchTemplate{{InstanceType: "m6i.large", ID: "lt-override", Version: aws.String("3")}},
+			wantLastApplied:  `{"m6i.large":{"size":50}}`,
+		},
+		{
+			name:        "Should keep the launch templates of removed overrides in the status",
+			lastApplied: `{"c5.large":{"size":50}}`,
+			status:      []expinfrav1.OverrideLaunchTemplate{{InstanceType: "c5.large", ID: "lt-removed", Version: aws.String("1")}},
+			expect:      func(m *mock_services.MockEC2InterfaceMockRecorder) {},
+			wantRollout: false,
+			wantStatus:  []expinfrav1.OverrideLaunchTemplate{{InstanceType: "c5.large", ID: "lt-removed", Version: aws.String("1")}},
+			// The root volume of a removed override is no longer tracked.
+			wantLastApplied: `{}`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+			awsMachinePool := newAWSMachinePool()
+			awsMachinePool.TypeMeta = metav1.TypeMeta{}
+			overrideUserDataSecret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: overrideUserDataSecretKey.Namespace, Name: overrideUserDataSecretKey.Name},
+				Data:       map[string][]byte{"value": overrideUserData},
+			}
+			client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(awsMachinePool, overrideUserDataSecret).WithStatusSubresource(awsMachinePool).Build()
+
+			cs, err := setupClusterScope(client)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			ms, err := setupMachinePoolScope(client, cs)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			ms.AWSMachinePool.Spec.MixedInstancesPolicy = &expinfrav1.MixedInstancesPolicy{
+				Overrides: []expinfrav1.Overrides{{InstanceType: "t3.large"}},
+			}
+			if tc.rootVolume != nil || tc.ownUserData {
+				ms.AWSMachinePool.Spec.MixedInstancesPolicy.Overrides = append(ms.AWSMachinePool.Spec.MixedInstancesPolicy.Overrides,
+					expinfrav1.Overrides{InstanceType: "m6i.large", RootVolume: tc.rootVolume})
+			}
+			if tc.ownUserData {
+				ms.AWSMachinePool.Spec.AWSLaunchTemplate.PerOverrideUserData = []expinfrav1.OverrideUserData{{InstanceType: "m6i.large", DataSecretName: overrideUserDataSecretKey.Name}}
+			}
+			ms.AWSMachinePool.Status.OverrideLaunchTemplates = tc.status
+			if tc.lastApplied != "" {
+				ms.AWSMachinePool.Annotations = map[string]string{OverrideRootVolumesLastAppliedAnnotation: tc.lastApplied}
+			}
+
+			ec2Mock := mock_services.NewMockEC2Interface(mockCtrl)
+			tc.expect(ec2Mock.EXPECT())
+
+			canUpdateRun := false
+			canUpdate := func() (bool, error) {
+				canUpdateRun = true
+				return tc.canUpdate, nil
+			}
+
+			s := NewService(cs)
+			rollout, err := s.reconcileOverrideLaunchTemplates(ms, ec2Mock, imageID, userDataSecretKey, userData, false, canUpdate)
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			g.Expect(rollout).To(Equal(tc.wantRollout))
+			g.Expect(canUpdateRun).To(Equal(tc.wantCanUpdateRun))
+			g.Expect(ms.AWSMachinePool.Status.OverrideLaunchTemplates).To(Equal(tc.wantStatus))
+			g.Expect(ms.AWSMachinePool.Annotations[OverrideRootVolumesLastAppliedAnnotation]).To(Equal(tc.wantLastApplied))
+		})
+	}
+}
+
+func TestValidateLaunchTemplateVersion(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	dryRunInput := func(instanceType *string) *ec2.RunInstancesInput {
+		return &ec2.RunInstancesInput{
+			DryRun: aws.Bool(true),
+			LaunchTemplate: &ec2.LaunchTemplateSpecification{
+				LaunchTemplateId: aws.String("lt-id"),
+				Version:          aws.String("3"),
+			},
+			InstanceType: instanceType,
+			SubnetId:     aws.String("subnet-private"),
+			MinCount:     aws.Int64(1),
+			MaxCount:     aws.Int64(1),
+		}
+	}
+	dryRunSucceeded := awserr.New(awserrors.DryRunOperation, "Request would have succeeded, but DryRun flag is set.", nil)
+
+	testCases := []struct {
+		name                 string
+		mixedInstancesPolicy *expinfrav1.MixedInstancesPolicy
+		expect               func(m *mocks.MockEC2APIMockRecorder)
+		wantErr              bool
+	}{
+		{
+			name: "Should pass if the dry run would have succeeded",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.RunInstancesWithContext(context.TODO(), gomock.Eq(dryRunInput(nil))).Return(nil, dryRunSucceeded)
+			},
+		},
+		{
+			name: "Should fail if the dry run is rejected",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.RunInstancesWithContext(context.TODO(), gomock.Eq(dryRunInput(nil))).Return(nil, awserr.New("InvalidAMIID.NotFound", "The image id '[ami-123]' does not exist", nil))
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should run a dry run per instance type launched from the launch template",
+			mixedInstancesPolicy: &expinfrav1.MixedInstancesPolicy{
+				Overrides: []expinfrav1.Overrides{
+					{InstanceType: "t3.large"},
+					{InstanceType: "m6i.large", RootVolume: &infrav1.Volume{Size: 100}},
+					{InstanceType: "m5.large"},
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				gomock.InOrder(
+					m.RunInstancesWithContext(context.TODO(), gomock.Eq(dryRunInput(aws.String("t3.large")))).Return(nil, dryRunSucceeded),
+					m.RunInstancesWithContext(context.TODO(), gomock.Eq(dryRunInput(aws.String("m5.large")))).Return(nil, awserr.New("Unsupported", "The requested configuration is currently not supported.", nil)),
+				)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+			cs, err := setupClusterScope(client)
+			g.Expect(err).NotTo(HaveOccurred())
+			cs.AWSCluster.Spec.NetworkSpec.Subnets = infrav1.Subnets{
+				{ID: "subnet-public", IsPublic: true},
+				{ID: "subnet-private", IsPublic: false},
+			}
+
+			ms, err := setupMachinePoolScope(client, cs)
+			g.Expect(err).NotTo(HaveOccurred())
+			ms.AWSMachinePool.Spec.MixedInstancesPolicy = tc.mixedInstancesPolicy
+
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+			s := NewService(cs)
+			s.EC2Client = ec2Mock
+			tc.expect(ec2Mock.EXPECT())
+
+			err = s.ValidateLaunchTemplateVersion(ms, "lt-id", "3")
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+		})
+	}
+}
+
+func TestReconcileLaunchTemplateValidationFailed(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	g := NewWithT(t)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "bootstrap-data", Namespace: "aws-mp-ns"},
+		Data:       map[string][]byte{"value": []byte("user-data")},
+	}
+	scheme, err := setupScheme()
+	g.Expect(err).NotTo(HaveOccurred())
+	client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(newAWSMachinePool(), secret).WithStatusSubresource(&expinfrav1.AWSMachinePool{}).Build()
+
+	cs, err := setupClusterScope(client)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	ms, err := setupMachinePoolScope(client, cs)
+	g.Expect(err).NotTo(HaveOccurred())
+	ms.MachinePool.Spec.Template.Spec.Bootstrap.DataSecretName = aws.String("bootstrap-data")
+	ms.AWSMachinePool.Spec.AWSLaunchTemplate.ValidateBeforeUse = true
+	ms.AWSMachinePool.Status.LaunchTemplateVersion = aws.String("3")
+
+	secretKey := types.NamespacedName{Name: "bootstrap-data", Namespace: "aws-mp-ns"}
+	existing := &expinfrav1.AWSLaunchTemplate{AMI: infrav1.AMIReference{ID: aws.String("ami-old")}}
+	canUpdate := func() (bool, error) {
+		return true, nil
+	}
+	runPostLaunchTemplateUpdateOperation := func() error {
+		t.Fatal("a launch template version which failed the validation must not be rolled out")
+		return nil
+	}
+	s := NewService(cs)
+
+	// The new AMI is rejected by the validation, the new version is deleted again.
+	ec2Mock := mock_services.NewMockEC2Interface(mockCtrl)
+	ec2Mock.EXPECT().GetLaunchTemplate(ms.LaunchTemplateName()).Return(existing, userdata.ComputeHash([]byte("user-data")), &secretKey, nil)
+	ec2Mock.EXPECT().DiscoverLaunchTemplateAMI(ms).Return(aws.String("ami-new"), nil)
+	ec2Mock.EXPECT().LaunchTemplateChangedFields(ms, gomock.Any(), existing).Return(nil, nil)
+	ec2Mock.EXPECT().PruneLaunchTemplateVersions("launch-template-id", gomock.Any()).Return(nil)
+	ec2Mock.EXPECT().CreateLaunchTemplateVersion("launch-template-id", ms, aws.String("ami-new"), secretKey, []byte("user-data")).Return(nil)
+	ec2Mock.EXPECT().GetLaunchTemplateLatestVersion("launch-template-id").Return("4", nil)
+	ec2Mock.EXPECT().ValidateLaunchTemplateVersion(ms, "launch-template-id", "4").Return(errors.New("InvalidAMIID.NotFound"))
+	ec2Mock.EXPECT().DeleteLaunchTemplateVersion("launch-template-id", "4").Return(nil)
+	g.Expect(s.ReconcileLaunchTemplate(ms, ec2Mock, canUpdate, runPostLaunchTemplateUpdateOperation)).To(Succeed())
+	g.Expect(ms.GetLaunchTemplateLatestVersionStatus()).To(Equal("3"))
+	g.Expect(conditions.IsTrue(ms.AWSMachinePool, expinfrav1.LaunchTemplateValidationFailedCondition)).To(BeTrue())
+	g.Expect(ms.AWSMachinePool.Annotations).To(HaveKey(LaunchTemplateValidationFailedAnnotation))
+
+	// The same version isn't created again until its inputs change.
+	ec2Mock = mock_services.NewMockEC2Interface(mockCtrl)
+	ec2Mock.EXPECT().GetLaunchTemplate(ms.LaunchTemplateName()).Return(existing, userdata.ComputeHash([]byte("user-data")), &secretKey, nil)
+	ec2Mock.EXPECT().DiscoverLaunchTemplateAMI(ms).Return(aws.String("ami-new"), nil)
+	ec2Mock.EXPECT().LaunchTemplateChangedFields(ms, gomock.Any(), existing).Return(nil, nil)
+	g.Expect(s.ReconcileLaunchTemplate(ms, ec2Mock, canUpdate, runPostLaunchTemplateUpdateOperation)).To(Succeed())
+	g.Expect(ms.GetLaunchTemplateLatestVersionStatus()).To(Equal("3"))
+
+	// A version passing the validation is launched and clears the failure.
+	rollout := false
+	runPostLaunchTemplateUpdateOperation = func() error {
+		rollout = true
+		return nil
+	}
+	ec2Mock = mock_services.NewMockEC2Interface(mockCtrl)
+	ec2Mock.EXPECT().GetLaunchTemplate(ms.LaunchTemplateName()).Return(existing, userdata.ComputeHash([]byte("user-data")), &secretKey, nil)
+	ec2Mock.EXPECT().DiscoverLaunchTemplateAMI(ms).Return(aws.String("ami-fixed"), nil)
+	ec2Mock.EXPECT().LaunchTemplateChangedFields(ms, gomock.Any(), existing).Return(nil, nil)
+	ec2Mock.EXPECT().PruneLaunchTemplateVersions("launch-template-id", gomock.Any()).Return(nil)
+	ec2Mock.EXPECT().CreateLaunchTemplateVersion("launch-template-id", ms, aws.String("ami-fixed"), secretKey, []byte("user-data")).Return(nil)
+	ec2Mock.EXPECT().GetLaunchTemplateLatestVersion("launch-template-id").Return("4", nil)
+	ec2Mock.EXPECT().ValidateLaunchTemplateVersion(ms, "launch-template-id", "4").Return(nil)
+	g.Expect(s.ReconcileLaunchTemplate(ms, ec2Mock, canUpdate, runPostLaunchTemplateUpdateOperation)).To(Succeed())
+	g.Expect(ms.GetLaunchTemplateLatestVersionStatus()).To(Equal("4"))
+	g.Expect(rollout).To(BeTrue())
+	g.Expect(conditions.Has(ms.AWSMachinePool, expinfrav1.LaunchTemplateValidationFailedCondition)).To(BeFalse())
+	g.Expect(ms.AWSMachinePool.Annotations).NotTo(HaveKey(LaunchTemplateValidationFailedAnnotation))
+}
+
+func TestReconcileLaunchTemplateRef(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	describeByName := &ec2.DescribeLaunchTemplatesInput{LaunchTemplateNames: aws.StringSlice([]string{"byo"})}
+
+	testCases := []struct {
+		name             string
+		ref              *expinfrav1.LaunchTemplateReference
+		previousVersion  *string
+		canUpdate        bool
+		expect           func(m *mocks.MockEC2APIMockRecorder)
+		wantVersion      *string
+		wantCanUpdateRun bool
+		wantRollout      bool
+		wantErr          bool
+	}{
+		{
+			name: "Should resolve the referenced launch template without rolling out on the first reconcile",
+			ref:  &expinfrav1.LaunchTemplateReference{Name: aws.String("byo"), Version: "3"},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeLaunchTemplatesWithContext(context.TODO(), gomock.Eq(describeByName)).
+					Return(&ec2.DescribeLaunchTemplatesOutput{LaunchTemplates: []*ec2.LaunchTemplate{{LaunchTemplateId: aws.String("lt-byo")}}}, nil)
+			},
+			wantVersion: aws.String("3"),
+		},
+		{
+			name:            "Should resolve the referenced launch template by ID",
+			ref:             &expinfrav1.LaunchTemplateReference{ID: aws.String("lt-byo"), Version: "3"},
+			previousVersion: aws.String("3"),
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeLaunchTemplatesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeLaunchTemplatesInput{LaunchTemplateIds: aws.StringSlice([]string{"lt-byo"})})).
+					Return(&ec2.DescribeLaunchTemplatesOutput{LaunchTemplates: []*ec2.LaunchTemplate{{LaunchTemplateId: aws.String("lt-byo")}}}, nil)
+			},
+			wantVersion: aws.String("3"),
+		},
+		{
+			name:            "Should roll out a new referenced version",
+			ref:             &expinfrav1.LaunchTemplateReference{Name: aws.String("byo"), Version: "4"},
+			previousVersion: aws.String("3"),
+			canUpdate:       true,
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeLaunchTemplatesWithContext(context.TODO(), gomock.Eq(describeByName)).
+					Return(&ec2.DescribeLaunchTemplatesOutput{LaunchTemplates: []*ec2.LaunchTemplate{{LaunchTemplateId: aws.String("lt-byo")}}}, nil)
+			},
+			wantVersion:      aws.String("4"),
+			wantCanUpdateRun: true,
+			wantRollout:      true,
+		},
+		{
+			name:            "Should not record a new referenced version while an instance refresh is in progress",
+			ref:             &expinfrav1.LaunchTemplateReference{Name: aws.String("byo"), Version: "4"},
+			previousVersion: aws.String("3"),
+			canUpdate:       false,
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeLaunchTemplatesWithContext(context.TODO(), gomock.Eq(describeByName)).
+					Return(&ec2.DescribeLaunchTemplatesOutput{LaunchTemplates: []*ec2.LaunchTemplate{{LaunchTemplateId: aws.String("lt-byo")}}}, nil)
+			},
+			wantVersion:      aws.String("3"),
+			wantCanUpdateRun: true,
+			wantErr:          true,
+		},
+		{
+			name: "Should fail if the referenced launch template doesn't exist",
+			ref:  &expinfrav1.LaunchTemplateReference{Name: aws.String("byo"), Version: "3"},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeLaunchTemplatesWithContext(context.TODO(), gomock.Eq(describeByName)).
+					Return(nil, awserr.New("InvalidLaunchTemplateName.NotFoundException", "At least one of the launch templates specified in the request does not exist.", nil))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+			client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(newAWSMachinePool()).WithStatusSubresource(&expinfrav1.AWSMachinePool{}).Build()
+
+			cs, err := setupClusterScope(client)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			ms, err := setupMachinePoolScope(client, cs)
+			g.Expect(err).NotTo(HaveOccurred())
+			ms.AWSMachinePool.Spec.AWSLaunchTemplate = expinfrav1.AWSLaunchTemplate{Ref: tc.ref}
+			ms.AWSMachinePool.Status.LaunchTemplateVersion = tc.previousVersion
+
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+			s := NewService(cs)
+			s.EC2Client = ec2Mock
+			tc.expect(ec2Mock.EXPECT())
+
+			canUpdateRun, rollout := false, false
+			canUpdate := func() (bool, error) {
+				canUpdateRun = true
+				return tc.canUpdate, nil
+			}
+			runPostLaunchTemplateUpdateOperation := func() error {
+				rollout = true
+				return nil
+			}
+
+			// The services of the launch template managed by the controller must not be used.
+			ec2Svc := mock_services.NewMockEC2Interface(mockCtrl)
+
+			err = s.ReconcileLaunchTemplate(ms, ec2Svc, canUpdate, runPostLaunchTemplateUpdateOperation)
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(ms.GetLaunchTemplateIDStatus()).To(Equal("lt-byo"))
+			}
+			g.Expect(ms.AWSMachinePool.Status.LaunchTemplateVersion).To(Equal(tc.wantVersion))
+			g.Expect(canUpdateRun).To(Equal(tc.wantCanUpdateRun))
+			g.Expect(rollout).To(Equal(tc.wantRollout))
+		})
+	}
+}
+
+func TestReconcileLaunchTemplateDeletedOutOfBand(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	testCases := []struct {
+		name             string
+		launchTemplateID string
+		wantRollout      bool
+	}{
+		{
+			name:        "Should create the launch template of a new machine pool without rolling out",
+			wantRollout: false,
+		},
+		{
+			name:             "Should recreate a launch template deleted outside of the controller and roll out",
+			launchTemplateID: "lt-deleted",
+			wantRollout:      true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "bootstrap-data", Namespace: "aws-mp-ns"},
+				Data:       map[string][]byte{"value": []byte("user-data")},
+			}
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+			client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(newAWSMachinePool(), secret).WithStatusSubresource(&expinfrav1.AWSMachinePool{}).Build()
+
+			cs, err := setupClusterScope(client)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			ms, err := setupMachinePoolScope(client, cs)
+			g.Expect(err).NotTo(HaveOccurred())
+			ms.MachinePool.Spec.Template.Spec.Bootstrap.DataSecretName = aws.String("bootstrap-data")
+			ms.AWSMachinePool.Status.LaunchTemplateID = tc.launchTemplateID
+			ms.AWSMachinePool.Status.LaunchTemplateVersion = aws.String("3")
+
+			// The launch template isn't found by its name anymore.
+			ec2Mock := mock_services.NewMockEC2Interface(mockCtrl)
+			ec2Mock.EXPECT().GetLaunchTemplate(ms.LaunchTemplateName()).Return(nil, "", nil, nil)
+			ec2Mock.EXPECT().DiscoverLaunchTemplateAMI(ms).Return(aws.String("ami-1"), nil)
+			ec2Mock.EXPECT().CreateLaunchTemplate(ms, aws.String("ami-1"), types.NamespacedName{Name: "bootstrap-data", Namespace: "aws-mp-ns"}, []byte("user-data")).
+				DoAndReturn(func(lts scope.LaunchTemplateScope, _ *string, _ types.NamespacedName, _ []byte) (string, error) {
+					// The stale IDs of the deleted launch template are cleared first.
+					g.Expect(lts.GetLaunchTemplateIDStatus()).To(BeEmpty())
+					return "lt-new", nil
+				})
+
+			rollout := false
+			canUpdate := func() (bool, error) {
+				return true, nil
+			}
+			runPostLaunchTemplateUpdateOperation := func() error {
+				// The autoscaling group is pointed to the new launch template.
+				g.Expect(ms.GetLaunchTemplateIDStatus()).To(Equal("lt-new"))
+				rollout = true
+				return nil
+			}
+
+			s := NewService(cs)
+			g.Expect(s.ReconcileLaunchTemplate(ms, ec2Mock, canUpdate, runPostLaunchTemplateUpdateOperation)).To(Succeed())
+			g.Expect(ms.GetLaunchTemplateIDStatus()).To(Equal("lt-new"))
+			g.Expect(rollout).To(Equal(tc.wantRollout))
+			if tc.wantRollout {
+				g.Expect(ms.GetLaunchTemplateLatestVersionStatus()).To(BeEmpty())
+				g.Expect(conditions.IsTrue(ms.AWSMachinePool, expinfrav1.PostLaunchTemplateUpdateOperationCondition)).To(BeTrue())
+			}
+		})
+	}
+}
+
+func TestPruneLaunchTemplateVersions(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	versions := func(numbers ...int64) []*ec2.LaunchTemplateVersion {
+		out := []*ec2.LaunchTemplateVersion{}
+		for _, number := range numbers {
+			out = append(out, &ec2.LaunchTemplateVersion{VersionNumber: aws.Int64(number)})
+		}
+		return out
+	}
+
+	testCases := []struct {
+		name        string
+		versions    []*ec2.LaunchTemplateVersion
+		keepVersion string
+		wantPruned  string
+	}{
+		{
+			name:     "Should not prune the default and latest versions",
+			versions: versions(1, 2),
+		},
+		{
+			name:       "Should prune the oldest version which isn't the default",
+			versions:   versions(1, 2, 3, 4),
+			wantPruned: "2",
+		},
+		{
+			name:        "Should prune the next oldest version when the oldest one is kept",
+			versions:    versions(1, 2, 3, 4),
+			keepVersion: "2",
+			wantPruned:  "3",
+		},
+		{
+			name:        "Should not prune the kept version when it is the only version to prune",
+			versions:    versions(1, 2, 3),
+			keepVersion: "2",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+			cs, err := setupClusterScope(fake.NewClientBuilder().WithScheme(scheme).Build())
+			g.Expect(err).NotTo(HaveOccurred())
+
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+			s := NewService(cs)
+			s.EC2Client = ec2Mock
+
+			ec2Mock.EXPECT().DescribeLaunchTemplateVersionsWithContext(context.TODO(), gomock.Eq(&ec2.DescribeLaunchTemplateVersionsInput{
+				LaunchTemplateId: aws.String("lt-1"),
+				MinVersion:       aws.String("0"),
+				MaxVersion:       aws.String("$Latest"),
+				MaxResults:       aws.Int64(4),
+			})).Return(&ec2.DescribeLaunchTemplateVersionsOutput{LaunchTemplateVersions: tc.versions}, nil)
+			if tc.wantPruned != "" {
+				ec2Mock.EXPECT().DeleteLaunchTemplateVersionsWithContext(context.TODO(), gomock.Eq(&ec2.DeleteLaunchTemplateVersionsInput{
+					LaunchTemplateId: aws.String("lt-1"),
+					Versions:         aws.StringSlice([]string{tc.wantPruned}),
+				})).Return(&ec2.DeleteLaunchTemplateVersionsOutput{}, nil)
+			}
+
+			g.Expect(s.PruneLaunchTemplateVersions("lt-1", tc.keepVersion)).To(Succeed())
+		})
+	}
+}
+
+func TestCreateLaunchTemplateVersionFromVersion(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	g := NewWithT(t)
+
+	scheme, err := setupScheme()
+	g.Expect(err).NotTo(HaveOccurred())
+	cs, err := setupClusterScope(fake.NewClientBuilder().WithScheme(scheme).Build())
+	g.Expect(err).NotTo(HaveOccurred())
+
+	ec2Mock := mocks.NewMockEC2API(mockCtrl)
+	s := NewService(cs)
+	s.EC2Client = ec2Mock
+
+	ec2Mock.EXPECT().CreateLaunchTemplateVersionWithContext(context.TODO(), gomock.Eq(&ec2.CreateLaunchTemplateVersionInput{
+		LaunchTemplateId:   aws.String("lt-1"),
+		SourceVersion:      aws.String("4"),
+		LaunchTemplateData: &ec2.RequestLaunchTemplateData{},
+	})).Return(&ec2.CreateLaunchTemplateVersionOutput{
+		LaunchTemplateVersion: &ec2.LaunchTemplateVersion{VersionNumber: aws.Int64(7)},
+	}, nil)
+
+	version, err := s.CreateLaunchTemplateVersionFromVersion("lt-1", "4")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(version).To(Equal("7"))
+}
+
+func TestLaunchTemplateVersionChangedBeyondUserData(t *testing.T) {
+	version := func(number int64, instanceType, userData string) *ec2.LaunchTemplateVersion {
+		return &ec2.LaunchTemplateVersion{
+			VersionNumber: aws.Int64(number),
+			LaunchTemplateData: &ec2.ResponseLaunchTemplateData{
+				InstanceType: aws.String(instanceType),
+				UserData:     aws.String(userData),
+			},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		versions    []*ec2.LaunchTemplateVersion
+		wantChanged bool
+		wantErr     bool
+	}{
+		{
+			name:     "only the userdata changed",
+			versions: []*ec2.LaunchTemplateVersion{version(1, "m5.large", "old"), version(2, "m5.large", "new")},
+		},
+		{
+			name:        "the instance type changed",
+			versions:    []*ec2.LaunchTemplateVersion{version(1, "m5.large", "old"), version(2, "m5.xlarge", "old")},
+			wantChanged: true,
+		},
+		{
+			name:     "a version is missing",
+			versions: []*ec2.LaunchTemplateVersion{version(2, "m5.large", "new")},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			g := NewWithT(t)
+
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+			cs, err := setupClusterScope(fake.NewClientBuilder().WithScheme(scheme).Build())
+			g.Expect(err).NotTo(HaveOccurred())
+
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+			s := NewService(cs)
+			s.EC2Client = ec2Mock
+
+			ec2Mock.EXPECT().DescribeLaunchTemplateVersionsWithContext(context.TODO(), gomock.Eq(&ec2.DescribeLaunchTemplateVersionsInput{
+				LaunchTemplateId: aws.String("lt-1"),
+				Versions:         aws.StringSlice([]string{"1", "2"}),
+			})).Return(&ec2.DescribeLaunchTemplateVersionsOutput{LaunchTemplateVersions: tt.versions}, nil)
+
+			changed, err := s.LaunchTemplateVersionChangedBeyondUserData("lt-1", "1", "2")
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(changed).To(Equal(tt.wantChanged))
+		})
+	}
+}
+
+func TestPruneLaunchTemplateVersionsForQuota(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	version := func(number int64, owned bool, clusterName string) *ec2.LaunchTemplateVersion {
+		v := &ec2.LaunchTemplateVersion{
+			VersionNumber:      aws.Int64(number),
+			DefaultVersion:     aws.Bool(number == 1),
+			LaunchTemplateData: &ec2.ResponseLaunchTemplateData{},
+		}
+		if owned {
+			v.LaunchTemplateData.TagSpecifications = []*ec2.LaunchTemplateTagSpecification{{
+				ResourceType: aws.String(ec2.ResourceTypeInstance),
+				Tags:         []*ec2.Tag{{Key: aws.String(infrav1.ClusterTagKey(clusterName)), Value: aws.String(string(infrav1.ResourceLifecycleOwned))}},
+			}}
+		}
+		return v
+	}
+	instance := func(version string) *ec2.Instance {
+		return &ec2.Instance{Tags: []*ec2.Tag{
+			{Key: aws.String("aws:ec2launchtemplate:id"), Value: aws.String("lt-1")},
+			{Key: aws.String("aws:ec2launchtemplate:version"), Value: aws.String(version)},
+		}}
+	}
+
+	testCases := []struct {
+		name                    string
+		versions                func(clusterName string) []*ec2.LaunchTemplateVersion
+		instances               []*ec2.Instance
+		instanceRefreshInFlight bool
+		wantPruned              []string
+	}{
+		{
+			name: "Should prune the versions which aren't the default, latest, kept, in use or created outside of the controller",
+			versions: func(clusterName string) []*ec2.LaunchTemplateVersion {
+				return []*ec2.LaunchTemplateVersion{
+					version(7, true, clusterName), version(6, false, clusterName), version(5, true, clusterName), version(4, true, clusterName),
+					version(3, true, clusterName), version(2, true, clusterName), version(1, true, clusterName),
+				}
+			},
+			instances:  []*ec2.Instance{instance("3")},
+			wantPruned: []string{"2", "4"},
+		},
+		{
+			name: "Should keep the versions an in-flight instance refresh may be rolling out",
+			versions: func(clusterName string) []*ec2.LaunchTemplateVersion {
+				return []*ec2.LaunchTemplateVersion{
+					version(7, true, clusterName), version(6, false, clusterName), version(5, true, clusterName), version(4, true, clusterName),
+					version(3, true, clusterName), version(2, true, clusterName), version(1, true, clusterName),
+				}
+			},
+			instances:               []*ec2.Instance{instance("3"), instance("7")},
+			instanceRefreshInFlight: true,
+			wantPruned:              []string{"2"},
+		},
+		{
+			name: "Should prune a bounded number of the oldest versions",
+			versions: func(clusterName string) []*ec2.LaunchTemplateVersion {
+				versions := []*ec2.LaunchTemplateVersion{}
+				for i := int64(1); i <= 20; i++ {
+					versions = append(versions, version(i, true, clusterName))
+				}
+				return versions
+			},
+			wantPruned: []string{"2", "3", "4", "6", "7", "8", "9", "10", "11", "12"},
+		},
+		{
+			name: "Should not prune anything when every version is protected",
+			versions: func(clusterName string) []*ec2.LaunchTemplateVersion {
+				return []*ec2.LaunchTemplateVersion{version(3, true, clusterName), version(2, true, clusterName), version(1, true, clusterName)}
+			},
+			instances: []*ec2.Instance{instance("2")},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+			cs, err := setupClusterScope(fake.NewClientBuilder().WithScheme(scheme).Build())
+			g.Expect(err).NotTo(HaveOccurred())
+
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+			s := NewService(cs)
+			s.EC2Client = ec2Mock
+
+			ec2Mock.EXPECT().DescribeLaunchTemplateVersionsWithContext(context.TODO(), gomock.Eq(&ec2.DescribeLaunchTemplateVersionsInput{
+				LaunchTemplateId: aws.String("lt-1"),
+				MaxResults:       aws.Int64(200),
+			})).Return(&ec2.DescribeLaunchTemplateVersionsOutput{LaunchTemplateVersions: tc.versions(cs.KubernetesClusterName())}, nil)
+			ec2Mock.EXPECT().DescribeInstancesWithContext(context.TODO(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, input *ec2.DescribeInstancesInput, _ ...request.Option) (*ec2.DescribeInstancesOutput, error) {
+					g.Expect(input.Filters).To(ContainElement(&ec2.Filter{Name: aws.String("tag:aws:ec2launchtemplate:id"), Values: aws.StringSlice([]string{"lt-1"})}))
+					return &ec2.DescribeInstancesOutput{Reservations: []*ec2.Reservation{{Instances: tc.instances}}}, nil
+				})
+			if tc.wantPruned != nil {
+				ec2Mock.EXPECT().DeleteLaunchTemplateVersionsWithContext(context.TODO(), gomock.Eq(&ec2.DeleteLaunchTemplateVersionsInput{
+					LaunchTemplateId: aws.String("lt-1"),
+					Versions:         aws.StringSlice(tc.wantPruned),
+				})).DoAndReturn(func(_ context.Context, input *ec2.DeleteLaunchTemplateVersionsInput, _ ...request.Option) (*ec2.DeleteLaunchTemplateVersionsOutput, error) {
+					out := &ec2.DeleteLaunchTemplateVersionsOutput{}
+					for range input.Versions {
+						out.SuccessfullyDeletedLaunchTemplateVersions = append(out.SuccessfullyDeletedLaunchTemplateVersions, &ec2.DeleteLaunchTemplateVersionsResponseSuccessItem{})
+					}
+					return out, nil
+				})
+			}
+
+			pruned, err := s.PruneLaunchTemplateVersionsForQuota("lt-1", []string{"5"}, tc.instanceRefreshInFlight)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(pruned).To(Equal(len(tc.wantPruned)))
+		})
+	}
+}
+
+func TestPruneLaunchTemplateVersionsForRetention(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	version := func(number int64, clusterName string) *ec2.LaunchTemplateVersion {
+		return &ec2.LaunchTemplateVersion{
+			VersionNumber:  aws.Int64(number),
+			DefaultVersion: aws.Bool(number == 1),
+			LaunchTemplateData: &ec2.ResponseLaunchTemplateData{
+				TagSpecifications: []*ec2.LaunchTemplateTagSpecification{{
+					ResourceType: aws.String(ec2.ResourceTypeInstance),
+					Tags:         []*ec2.Tag{{Key: aws.String(infrav1.ClusterTagKey(clusterName)), Value: aws.String(string(infrav1.ResourceLifecycleOwned))}},
+				}},
+			},
+		}
+	}
+	instance := func(version string) *ec2.Instance {
+		return &ec2.Instance{Tags: []*ec2.Tag{
+			{Key: aws.String("aws:ec2launchtemplate:id"), Value: aws.String("lt-1")},
+			{Key: aws.String("aws:ec2launchtemplate:version"), Value: aws.String(version)},
+		}}
+	}
+
+	testCases := []struct {
+		name       string
+		retain     int
+		instances  []*ec2.Instance
+		wantPruned []string
+	}{
+		{
+			name:       "Should prune the versions older than the retained ones, except the default, kept and in use versions",
+			retain:     3,
+			instances:  []*ec2.Instance{instance("4")},
+			wantPruned: []string{"2", "3", "6"},
+		},
+		{
+			name:      "Should not prune anything when the versions fit in the retained ones",
+			retain:    10,
+			instances: []*ec2.Instance{instance("4")},
+		},
+		{
+			name:       "Should keep the versions every instance was launched from",
+			retain:     1,
+			instances:  []*ec2.Instance{instance("2"), instance("3"), instance("6"), instance("8")},
+			wantPruned: []string{"4", "7"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+			cs, err := setupClusterScope(fake.NewClientBuilder().WithScheme(scheme).Build())
+			g.Expect(err).NotTo(HaveOccurred())
+
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+			s := NewService(cs)
+			s.EC2Client = ec2Mock
+
+			// The versions are described over two pages.
+			clusterName := cs.KubernetesClusterName()
+			ec2Mock.EXPECT().DescribeLaunchTemplateVersionsWithContext(context.TODO(), gomock.Eq(&ec2.DescribeLaunchTemplateVersionsInput{
+				LaunchTemplateId: aws.String("lt-1"),
+				MaxResults:       aws.Int64(200),
+			})).Return(&ec2.DescribeLaunchTemplateVersionsOutput{
+				LaunchTemplateVersions: []*ec2.LaunchTemplateVersion{version(9, clusterName), version(8, clusterName), version(7, clusterName), version(6, clusterName)},
+				NextToken:              aws.String("page-2"),
+			}, nil)
+			ec2Mock.EXPECT().DescribeLaunchTemplateVersionsWithContext(context.TODO(), gomock.Eq(&ec2.DescribeLaunchTemplateVersionsInput{
+				LaunchTemplateId: aws.String("lt-1"),
+				MaxResults:       aws.Int64(200),
+				NextToken:        aws.String("page-2"),
+			})).Return(&ec2.DescribeLaunchTemplateVersionsOutput{
+				LaunchTemplateVersions: []*ec2.LaunchTemplateVersion{version(5, clusterName), version(4, clusterName), version(3, clusterName), version(2, clusterName), version(1, clusterName)},
+			}, nil)
+			ec2Mock.EXPECT().DescribeInstancesWithContext(context.TODO(), gomock.Any()).
+				Return(&ec2.DescribeInstancesOutput{Reservations: []*ec2.Reservation{{Instances: tc.instances}}}, nil)
+			if tc.wantPruned != nil {
+				ec2Mock.EXPECT().DeleteLaunchTemplateVersionsWithContext(context.TODO(), gomock.Eq(&ec2.DeleteLaunchTemplateVersionsInput{
+					LaunchTemplateId: aws.String("lt-1"),
+					Versions:         aws.StringSlice(tc.wantPruned),
+				})).DoAndReturn(func(_ context.Context, input *ec2.DeleteLaunchTemplateVersionsInput, _ ...request.Option) (*ec2.DeleteLaunchTemplateVersionsOutput, error) {
+					out := &ec2.DeleteLaunchTemplateVersionsOutput{}
+					for range input.Versions {
+						out.SuccessfullyDeletedLaunchTemplateVersions = append(out.SuccessfullyDeletedLaunchTemplateVersions, &ec2.DeleteLaunchTemplateVersionsResponseSuccessItem{})
+					}
+					return out, nil
+				})
+			}
+
+			pruned, err := s.PruneLaunchTemplateVersionsForRetention("lt-1", tc.retain, []string{"5"}, false)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(pruned).To(Equal(len(tc.wantPruned)))
+		})
+	}
+}
+
+func TestCreateLaunchTemplateVersionOverQuota(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	limitExceeded := errors.Wrap(awserr.New(awserrors.LaunchTemplateVersionLimitExceeded, "too many versions", nil), "unable to create launch template version")
+
+	testCases := []struct {
+		name         string
+		canUpdate    bool
+		pruned       int
+		wantErr      bool
+		wantRefresh  bool
+		wantNotReady bool
+	}{
+		{
+			name:      "Should prune old versions and retry",
+			canUpdate: true,
+			pruned:    3,
+		},
+		{
+			name:        "Should keep the versions of an in-flight instance refresh",
+			canUpdate:   false,
+			pruned:      1,
+			wantRefresh: true,
+		},
+		{
+			name:         "Should report the launch template as not ready when no version can be pruned",
+			canUpdate:    true,
+			wantErr:      true,
+			wantNotReady: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+			client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(newAWSMachinePool()).Build()
+			cs, err := setupClusterScope(client)
+			g.Expect(err).NotTo(HaveOccurred())
+			ms, err := setupMachinePoolScope(client, cs)
+			g.Expect(err).NotTo(HaveOccurred())
+			ms.AWSMachinePool.Status.LaunchTemplateID = "lt-1"
+			ms.AWSMachinePool.Status.LaunchTemplateVersion = aws.String("5")
+
+			secretKey := types.NamespacedName{Name: "bootstrap-data", Namespace: "aws-mp-ns"}
+			ec2Mock := mock_services.NewMockEC2Interface(mockCtrl)
+			ec2Mock.EXPECT().CreateLaunchTemplateVersion("lt-1", ms, aws.String("ami-1"), secretKey, []byte("user-data")).Return(limitExceeded)
+			ec2Mock.EXPECT().PruneLaunchTemplateVersionsForQuota("lt-1", []string{"5", ""}, tc.wantRefresh).Return(tc.pruned, nil)
+			if tc.pruned > 0 {
+				ec2Mock.EXPECT().CreateLaunchTemplateVersion("lt-1", ms, aws.String("ami-1"), secretKey, []byte("user-data")).Return(nil)
+			}
+
+			canUpdate := func() (bool, error) {
+				return tc.canUpdate, nil
+			}
+			s := NewService(cs)
+			err = s.createLaunchTemplateVersion(ms, ec2Mock, aws.String("ami-1"), secretKey, []byte("user-data"), canUpdate)
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			if tc.wantNotReady {
+				g.Expect(conditions.GetReason(ms.AWSMachinePool, expinfrav1.LaunchTemplateReadyCondition)).To(Equal(expinfrav1.LaunchTemplateVersionLimitExceededReason))
+			}
+		})
+	}
+}
@@ -19,15 +19,18 @@ package eks
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/blang/semver"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/converters"
 	eksaddons "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/eks/addons"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+	infrautilconditions "sigs.k8s.io/cluster-api-provider-aws/v2/util/conditions"
 )
 
 func (s *Service) reconcileAddons(ctx context.Context) error {
@@ -49,12 +52,19 @@ func (s *Service) reconcileAddons(ctx context.Context) error {
 		return fmt.Errorf("getting installed eks addons: %w", err)
 	}
 
+	// Resolve the versions of the addons with a version constraint for the Kubernetes version of the cluster
+	resolvedVersions, err := s.resolveAddonVersions(eksClusterName, s.scope.Addons())
+	if err != nil {
+		return fmt.Errorf("resolving eks addon versions: %w", err)
+	}
+
 	// Get the addons from the spec we want for the cluster
-	desiredAddons := s.translateAPIToAddon(s.scope.Addons())
+	desiredAddons := s.translateAPIToAddon(s.scope.Addons(), resolvedVersions)
 
 	// If there are no addons desired or installed then do nothing
 	if len(installed) == 0 && len(desiredAddons) == 0 {
 		s.scope.Info("no addons installed and no addons to install, no action needed")
+		infrautilconditions.ClearAWSState(s.scope.ControlPlane, ekscontrolplanev1.AddonDegradedCondition)
 		return nil
 	}
 
@@ -85,7 +95,9 @@ func (s *Service) reconcileAddons(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("getting installed state of eks addons: %w", err)
 	}
+	setAddonStateVersions(addonState, s.scope.Addons(), resolvedVersions)
 	s.scope.ControlPlane.Status.Addons = addonState
+	s.reconcileAddonHealth(addonState)
 
 	// Persist status and record event
 	if err := s.scope.PatchObject(); err != nil {
@@ -97,6 +109,29 @@ func (s *Service) reconcileAddons(ctx context.Context) error {
 	return nil
 }
 
+// reconcileAddonHealth reports the addons in the DEGRADED status, and their health issues, with the
+// AddonDegradedCondition.
+func (s *Service) reconcileAddonHealth(addons []ekscontrolplanev1.AddonState) {
+	degraded := []string{}
+	for _, addon := range addons {
+		if aws.StringValue(addon.Status) != eks.AddonStatusDegraded {
+			continue
+		}
+		issues := make([]string, 0, len(addon.Issues))
+		for _, issue := range addon.Issues {
+			issues = append(issues, fmt.Sprintf("%s: %s", aws.StringValue(issue.Code), aws.StringValue(issue.Message)))
+		}
+		degraded = append(degraded, fmt.Sprintf("%s (%s)", addon.Name, strings.Join(issues, "; ")))
+	}
+
+	if len(degraded) == 0 {
+		infrautilconditions.ClearAWSState(s.scope.ControlPlane, ekscontrolplanev1.AddonDegradedCondition)
+		return
+	}
+	infrautilconditions.MarkAWSState(s.scope.ControlPlane, ekscontrolplanev1.AddonDegradedCondition, ekscontrolplanev1.AddonHealthIssuesReason,
+		fmt.Sprintf("Degraded EKS addons: %s", strings.Join(degraded, ", ")))
+}
+
 func (s *Service) getClusterAddonsInstalled(eksClusterName string, addonNames []*string) ([]*eksaddons.EKSAddon, error) {
 	s.Debug("getting eks addons installed")
 
@@ -189,14 +224,137 @@ func (s *Service) listAddons(eksClusterName string) ([]*string, error) {
 	return addons, nil
 }
 
-func (s *Service) translateAPIToAddon(addons []ekscontrolplanev1.Addon) []*eksaddons.EKSAddon {
+// resolveAddonVersions resolves the versions of the addons with a version constraint against the versions of the
+// addons compatible with the Kubernetes version of the cluster, and returns them by addon name.
+func (s *Service) resolveAddonVersions(eksClusterName string, addons []ekscontrolplanev1.Addon) (map[string]string, error) {
+	resolved := map[string]string{}
+
+	var clusterVersion string
+	for _, addon := range addons {
+		if addon.VersionConstraint == "" {
+			continue
+		}
+
+		if clusterVersion == "" {
+			cluster, err := s.describeEKSCluster(eksClusterName)
+			if err != nil {
+				return nil, err
+			}
+			if cluster == nil || cluster.Version == nil {
+				return nil, fmt.Errorf("kubernetes version of eks cluster %s is unknown", eksClusterName)
+			}
+			clusterVersion = *cluster.Version
+		}
+
+		versions, err := s.describeAddonVersions(addon.Name, clusterVersion)
+		if err != nil {
+			return nil, err
+		}
+		version, err := resolveAddonVersion(addon.VersionConstraint, clusterVersion, versions)
+		if err != nil {
+			return nil, fmt.Errorf("resolving version of eks addon %s: %w", addon.Name, err)
+		}
+		s.scope.Debug("resolved eks addon version", "addon", addon.Name, "constraint", addon.VersionConstraint, "version", version)
+		resolved[addon.Name] = version
+	}
+
+	return resolved, nil
+}
+
+func (s *Service) describeAddonVersions(addonName, clusterVersion string) ([]*eks.AddonVersionInfo, error) {
+	input := &eks.DescribeAddonVersionsInput{
+		AddonName:         aws.String(addonName),
+		KubernetesVersion: aws.String(clusterVersion),
+	}
+
+	versions := []*eks.AddonVersionInfo{}
+	for {
+		output, err := s.EKSClient.DescribeAddonVersions(input)
+		if err != nil {
+			return nil, fmt.Errorf("describing versions of eks addon %s: %w", addonName, err)
+		}
+		for _, info := range output.Addons {
+			if aws.StringValue(info.AddonName) == addonName {
+				versions = append(versions, info.AddonVersions...)
+			}
+		}
+		if aws.StringValue(output.NextToken) == "" {
+			return versions, nil
+		}
+		input.NextToken = output.NextToken
+	}
+}
+
+// resolveAddonVersion picks the version of an addon matching the version constraint: the default version for the
+// Kubernetes version of the cluster, the highest version, or the highest version within a semantic version range.
+func resolveAddonVersion(constraint, clusterVersion string, versions []*eks.AddonVersionInfo) (string, error) {
+	if constraint == ekscontrolplanev1.AddonVersionConstraintDefault {
+		for _, info := range versions {
+			for _, compatibility := range info.Compatibilities {
+				if aws.StringValue(compatibility.ClusterVersion) == clusterVersion && aws.BoolValue(compatibility.DefaultVersion) {
+					return aws.StringValue(info.AddonVersion), nil
+				}
+			}
+		}
+		return "", fmt.Errorf("no default version for kubernetes version %s", clusterVersion)
+	}
+
+	inRange := func(semver.Version) bool { return true }
+	if constraint != ekscontrolplanev1.AddonVersionConstraintLatest {
+		r, err := semver.ParseRange(constraint)
+		if err != nil {
+			return "", fmt.Errorf("parsing version constraint %q: %w", constraint, err)
+		}
+		// Versions such as v1.16.0-eksbuild.1 are matched without their eksbuild suffix, as a pre-release version
+		// would never be in a range of releases.
+		inRange = func(v semver.Version) bool {
+			v.Pre = nil
+			return r(v)
+		}
+	}
+
+	var highest *semver.Version
+	var resolved string
+	for _, info := range versions {
+		v, err := semver.ParseTolerant(aws.StringValue(info.AddonVersion))
+		if err != nil || !inRange(v) {
+			continue
+		}
+		if highest == nil || v.GT(*highest) {
+			highest = &v
+			resolved = aws.StringValue(info.AddonVersion)
+		}
+	}
+	if highest == nil {
+		return "", fmt.Errorf("no version matching %q for kubernetes version %s", constraint, clusterVersion)
+	}
+	return resolved, nil
+}
+
+// setAddonStateVersions reports the version constraints of the addons, and the versions they resolved to, in their state.
+func setAddonStateVersions(addonState []ekscontrolplanev1.AddonState, addons []ekscontrolplanev1.Addon, resolvedVersions map[string]string) {
+	for i := range addonState {
+		for _, addon := range addons {
+			if addon.Name == addonState[i].Name {
+				addonState[i].VersionConstraint = addon.VersionConstraint
+				addonState[i].ResolvedVersion = resolvedVersions[addon.Name]
+			}
+		}
+	}
+}
+
+func (s *Service) translateAPIToAddon(addons []ekscontrolplanev1.Addon, resolvedVersions map[string]string) []*eksaddons.EKSAddon {
 	converted := []*eksaddons.EKSAddon{}
 
 	for i := range addons {
 		addon := addons[i]
+		version := addon.Version
+		if resolved, ok := resolvedVersions[addon.Name]; ok {
+			version = resolved
+		}
 		convertedAddon := &eksaddons.EKSAddon{
 			Name:                  &addon.Name,
-			Version:               &addon.Version,
+			Version:               &version,
 			Configuration:         &addon.Configuration,
 			Tags:                  ngTags(s.scope.Cluster.Name, s.scope.AdditionalTags()),
 			ResolveConflict:       convertConflictResolution(*addon.ConflictResolution),
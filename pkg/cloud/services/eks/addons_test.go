@@ -0,0 +1,130 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/eks"
+	. "github.com/onsi/gomega"
+
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+)
+
+func TestResolveAddonVersion(t *testing.T) {
+	addonVersion := func(version string, defaultFor string) *eks.AddonVersionInfo {
+		return &eks.AddonVersionInfo{
+			AddonVersion: aws.String(version),
+			Compatibilities: []*eks.Compatibility{
+				{ClusterVersion: aws.String("1.29"), DefaultVersion: aws.Bool(defaultFor == "1.29")},
+				{ClusterVersion: aws.String("1.28"), DefaultVersion: aws.Bool(defaultFor == "1.28")},
+			},
+		}
+	}
+	versions := []*eks.AddonVersionInfo{
+		addonVersion("v1.16.0-eksbuild.1", "1.28"),
+		addonVersion("v1.16.0-eksbuild.2", ""),
+		addonVersion("v1.18.1-eksbuild.1", ""),
+		addonVersion("v1.18.3-eksbuild.1", "1.29"),
+		addonVersion("v1.19.0-eksbuild.1", ""),
+	}
+
+	testCases := []struct {
+		name           string
+		constraint     string
+		clusterVersion string
+		expect         string
+		expectErr      bool
+	}{
+		{
+			name:           "default version for the cluster version",
+			constraint:     ekscontrolplanev1.AddonVersionConstraintDefault,
+			clusterVersion: "1.29",
+			expect:         "v1.18.3-eksbuild.1",
+		},
+		{
+			name:           "default version changes with the cluster version",
+			constraint:     ekscontrolplanev1.AddonVersionConstraintDefault,
+			clusterVersion: "1.28",
+			expect:         "v1.16.0-eksbuild.1",
+		},
+		{
+			name:           "no default version for the cluster version",
+			constraint:     ekscontrolplanev1.AddonVersionConstraintDefault,
+			clusterVersion: "1.30",
+			expectErr:      true,
+		},
+		{
+			name:           "latest version",
+			constraint:     ekscontrolplanev1.AddonVersionConstraintLatest,
+			clusterVersion: "1.29",
+			expect:         "v1.19.0-eksbuild.1",
+		},
+		{
+			name:           "highest version in a range",
+			constraint:     ">=1.18.0 <1.19.0",
+			clusterVersion: "1.29",
+			expect:         "v1.18.3-eksbuild.1",
+		},
+		{
+			name:           "highest eksbuild of a version in a wildcard range",
+			constraint:     "1.16.x",
+			clusterVersion: "1.29",
+			expect:         "v1.16.0-eksbuild.2",
+		},
+		{
+			name:           "no version in the range",
+			constraint:     ">=1.20.0",
+			clusterVersion: "1.29",
+			expectErr:      true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			version, err := resolveAddonVersion(tc.constraint, tc.clusterVersion, versions)
+			if tc.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(version).To(Equal(tc.expect))
+		})
+	}
+}
+
+func TestSetAddonStateVersions(t *testing.T) {
+	g := NewWithT(t)
+
+	addonState := []ekscontrolplanev1.AddonState{
+		{Name: "vpc-cni", Version: "v1.16.0-eksbuild.1"},
+		{Name: "coredns", Version: "v1.11.1-eksbuild.4"},
+	}
+	addons := []ekscontrolplanev1.Addon{
+		{Name: "vpc-cni", VersionConstraint: "1.18.x"},
+		{Name: "coredns", Version: "v1.11.1-eksbuild.4"},
+	}
+	setAddonStateVersions(addonState, addons, map[string]string{"vpc-cni": "v1.18.3-eksbuild.1"})
+
+	g.Expect(addonState[0].VersionConstraint).To(Equal("1.18.x"))
+	g.Expect(addonState[0].ResolvedVersion).To(Equal("v1.18.3-eksbuild.1"))
+	g.Expect(addonState[1].VersionConstraint).To(BeEmpty())
+	g.Expect(addonState[1].ResolvedVersion).To(BeEmpty())
+}
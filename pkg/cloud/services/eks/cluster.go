@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -40,6 +41,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/internal/cmp"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/internal/tristate"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+	infrautilconditions "sigs.k8s.io/cluster-api-provider-aws/v2/util/conditions"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util/conditions"
 )
@@ -113,6 +115,10 @@ func (s *Service) reconcileCluster(ctx context.Context) error {
 		return errors.Wrap(err, "failed reconciling additional kubeconfigs")
 	}
 
+	if err := s.reconcileUpgradeInsights(cluster); err != nil {
+		return errors.Wrap(err, "failed reconciling upgrade insights")
+	}
+
 	if err := s.reconcileClusterVersion(cluster); err != nil {
 		return errors.Wrap(err, "failed reconciling cluster version")
 	}
@@ -312,6 +318,25 @@ func makeVpcConfig(subnets infrav1.Subnets, endpointAccess ekscontrolplanev1.End
 	return vpcConfig, nil
 }
 
+// controlPlaneSecurityGroups returns the security groups used for the EKS control plane ENIs.
+// A user provided override for the node-eks-additional role takes precedence over the group created by CAPA.
+func (s *Service) controlPlaneSecurityGroups() map[infrav1.SecurityGroupRole]infrav1.SecurityGroup {
+	securityGroups := s.scope.SecurityGroups()
+
+	overrideID, ok := s.scope.SecurityGroupOverrides()[infrav1.SecurityGroupEKSNodeAdditional]
+	if !ok || securityGroups[infrav1.SecurityGroupEKSNodeAdditional].ID == overrideID {
+		return securityGroups
+	}
+
+	res := make(map[infrav1.SecurityGroupRole]infrav1.SecurityGroup, len(securityGroups)+1)
+	for role, sg := range securityGroups {
+		res[role] = sg
+	}
+	res[infrav1.SecurityGroupEKSNodeAdditional] = infrav1.SecurityGroup{ID: overrideID}
+
+	return res
+}
+
 func makeEksLogging(loggingSpec *ekscontrolplanev1.ControlPlaneLoggingSpec) *eks.Logging {
 	if loggingSpec == nil {
 		return nil
@@ -367,9 +392,9 @@ func (s *Service) createCluster(eksClusterName string) (*eks.Cluster, error) {
 	encryptionConfigs := makeEksEncryptionConfigs(s.scope.ControlPlane.Spec.EncryptionConfig)
 	if s.scope.ControlPlane.Spec.RestrictPrivateSubnets {
 		s.scope.Info("Filtering private subnets")
-		vpcConfig, err = makeVpcConfig(s.scope.Subnets().FilterPrivate(), s.scope.ControlPlane.Spec.EndpointAccess, s.scope.SecurityGroups())
+		vpcConfig, err = makeVpcConfig(s.scope.Subnets().FilterPrivate(), s.scope.ControlPlane.Spec.EndpointAccess, s.controlPlaneSecurityGroups())
 	} else {
-		vpcConfig, err = makeVpcConfig(s.scope.Subnets(), s.scope.ControlPlane.Spec.EndpointAccess, s.scope.SecurityGroups())
+		vpcConfig, err = makeVpcConfig(s.scope.Subnets(), s.scope.ControlPlane.Spec.EndpointAccess, s.controlPlaneSecurityGroups())
 	}
 	if err != nil {
 		return nil, errors.Wrap(err, "couldn't create vpc config for cluster")
@@ -557,9 +582,9 @@ func (s *Service) reconcileVpcConfig(vpcConfig *eks.VpcConfigResponse) (*eks.Vpc
 	)
 	endpointAccess := s.scope.ControlPlane.Spec.EndpointAccess
 	if s.scope.ControlPlane.Spec.RestrictPrivateSubnets {
-		updatedVpcConfig, err = makeVpcConfig(s.scope.Subnets().FilterPrivate(), endpointAccess, s.scope.SecurityGroups())
+		updatedVpcConfig, err = makeVpcConfig(s.scope.Subnets().FilterPrivate(), endpointAccess, s.controlPlaneSecurityGroups())
 	} else {
-		updatedVpcConfig, err = makeVpcConfig(s.scope.Subnets(), endpointAccess, s.scope.SecurityGroups())
+		updatedVpcConfig, err = makeVpcConfig(s.scope.Subnets(), endpointAccess, s.controlPlaneSecurityGroups())
 	}
 	if err != nil {
 		return nil, err
@@ -634,6 +659,15 @@ func (s *Service) reconcileClusterVersion(cluster *eks.Cluster) error {
 		// need to go 1.14-> 1.15 and then 1.15 -> 1.16.
 		nextVersionString := versionToEKS(clusterVersion.WithMinor(clusterVersion.Minor() + 1))
 
+		// The update is held while upgrade insights for the version report errors whose risks weren't acknowledged.
+		if blocking := s.unacknowledgedUpgradeInsights(nextVersionString); len(blocking) > 0 {
+			infrautilconditions.MarkAWSState(s.scope.ControlPlane, ekscontrolplanev1.UpgradeBlockedByInsightsCondition, ekscontrolplanev1.UpgradeInsightsErrorReason,
+				fmt.Sprintf("The update to Kubernetes %s is held as upgrade insights are in the ERROR status: %s. Annotate the control plane with %s=%q to acknowledge the risks",
+					nextVersionString, strings.Join(blocking, ", "), ekscontrolplanev1.AcknowledgeUpgradeRisksAnnotation, nextVersionString))
+			return nil
+		}
+		infrautilconditions.ClearAWSState(s.scope.ControlPlane, ekscontrolplanev1.UpgradeBlockedByInsightsCondition)
+
 		input := &eks.UpdateClusterVersionInput{
 			Name:    aws.String(s.scope.KubernetesClusterName()),
 			Version: &nextVersionString,
@@ -665,6 +699,8 @@ func (s *Service) reconcileClusterVersion(cluster *eks.Cluster) error {
 			record.Warnf(s.scope.ControlPlane, "FailedUpdateEKSControlPlane", "failed to update the EKS control plane: %v", err)
 			return errors.Wrapf(err, "failed to update EKS cluster")
 		}
+	} else {
+		infrautilconditions.ClearAWSState(s.scope.ControlPlane, ekscontrolplanev1.UpgradeBlockedByInsightsCondition)
 	}
 	return nil
 }
@@ -473,6 +473,8 @@ func TestCreateCluster(t *testing.T) {
 		role        *string
 		tags        map[string]*string
 		subnets     []infrav1.SubnetSpec
+		overrides   map[infrav1.SecurityGroupRole]string
+		sgIDs       []*string
 	}{
 		{
 			name:        "cluster create with 2 subnets",
@@ -486,6 +488,22 @@ func TestCreateCluster(t *testing.T) {
 				{ID: "1", AvailabilityZone: "us-west-2a"}, {ID: "2", AvailabilityZone: "us-west-2b"},
 			},
 		},
+		{
+			name:        "cluster create with security group override",
+			expectEKS:   func(m *mock_eksiface.MockEKSAPIMockRecorder) {},
+			expectError: false,
+			role:        aws.String("arn:role"),
+			tags: map[string]*string{
+				"kubernetes.io/cluster/" + clusterName: aws.String("owned"),
+			},
+			subnets: []infrav1.SubnetSpec{
+				{ID: "1", AvailabilityZone: "us-west-2a"}, {ID: "2", AvailabilityZone: "us-west-2b"},
+			},
+			overrides: map[infrav1.SecurityGroupRole]string{
+				infrav1.SecurityGroupEKSNodeAdditional: "sg-byo",
+			},
+			sgIDs: []*string{aws.String("sg-byo")},
+		},
 		{
 			name:        "cluster create without subnets",
 			expectEKS:   func(m *mock_eksiface.MockEKSAPIMockRecorder) {},
@@ -522,7 +540,10 @@ func TestCreateCluster(t *testing.T) {
 						EKSClusterName: clusterName,
 						Version:        version,
 						RoleName:       tc.role,
-						NetworkSpec:    infrav1.NetworkSpec{Subnets: tc.subnets},
+						NetworkSpec: infrav1.NetworkSpec{
+							Subnets:                tc.subnets,
+							SecurityGroupOverrides: tc.overrides,
+						},
 					},
 				},
 			})
@@ -539,7 +560,8 @@ func TestCreateCluster(t *testing.T) {
 					Name:             aws.String(clusterName),
 					EncryptionConfig: []*eks.EncryptionConfig{},
 					ResourcesVpcConfig: &eks.VpcConfigRequest{
-						SubnetIds: subnetIDs,
+						SubnetIds:        subnetIDs,
+						SecurityGroupIds: tc.sgIDs,
 					},
 					RoleArn: tc.role,
 					Tags:    tc.tags,
@@ -0,0 +1,161 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// nodegroupNameLabel is the label EKS sets on the nodes of a managed node group.
+const nodegroupNameLabel = "eks.amazonaws.com/nodegroup"
+
+// ReconcilePoolDrain cordons and drains the nodes of the node group before it is deleted, as configured by
+// the delete policy of the AWSManagedMachinePool. It returns true once the node group can be deleted: when
+// its nodes are drained, or when the drain timeout expired, even if the workload cluster is unreachable.
+func (s *NodegroupService) ReconcilePoolDrain(ctx context.Context) (bool, error) {
+	pool := s.scope.ManagedMachinePool
+	policy := pool.Spec.DeletePolicy
+	if policy == nil || conditions.IsTrue(pool, expinfrav1.EKSNodegroupDrainedCondition) {
+		return true, nil
+	}
+
+	if _, ok := pool.Annotations[clusterv1.ExcludeNodeDrainingAnnotation]; ok {
+		conditions.MarkFalse(pool, expinfrav1.EKSNodegroupDrainedCondition, expinfrav1.EKSNodegroupDrainSkippedReason, clusterv1.ConditionSeverityInfo,
+			"Drain skipped by the %s annotation", clusterv1.ExcludeNodeDrainingAnnotation)
+		return true, nil
+	}
+
+	timedOut := time.Now().After(pool.DeletionTimestamp.Add(policy.DrainTimeout.Duration))
+
+	remaining, err := s.drainWorkloadNodes(ctx, policy.Force && timedOut)
+	if err != nil {
+		if timedOut {
+			record.Warnf(pool, "FailedDrainEKSNodegroup", "Failed to drain EKS nodegroup %s, deleting it as the drain timed out: %v", s.scope.NodegroupName(), err)
+			conditions.MarkFalse(pool, expinfrav1.EKSNodegroupDrainedCondition, expinfrav1.EKSNodegroupDrainTimeoutReason, clusterv1.ConditionSeverityWarning, err.Error())
+			return true, nil
+		}
+		conditions.MarkFalse(pool, expinfrav1.EKSNodegroupDrainedCondition, expinfrav1.EKSNodegroupDrainingReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return false, err
+	}
+
+	switch {
+	case remaining == 0:
+		conditions.MarkTrue(pool, expinfrav1.EKSNodegroupDrainedCondition)
+		return true, nil
+	case timedOut:
+		record.Warnf(pool, "FailedDrainEKSNodegroup", "Deleting EKS nodegroup %s with %d pods left after the drain timed out", s.scope.NodegroupName(), remaining)
+		conditions.MarkFalse(pool, expinfrav1.EKSNodegroupDrainedCondition, expinfrav1.EKSNodegroupDrainTimeoutReason, clusterv1.ConditionSeverityWarning,
+			"%d pods left after the drain timed out", remaining)
+		return true, nil
+	default:
+		conditions.MarkFalse(pool, expinfrav1.EKSNodegroupDrainedCondition, expinfrav1.EKSNodegroupDrainingReason, clusterv1.ConditionSeverityInfo,
+			"%d pods left to evict", remaining)
+		return false, nil
+	}
+}
+
+func (s *NodegroupService) drainWorkloadNodes(ctx context.Context, force bool) (int, error) {
+	remoteClient, err := s.scope.RemoteClient()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to create the workload cluster client")
+	}
+	return drainNodes(ctx, remoteClient, s.scope.NodegroupName(), force)
+}
+
+// drainNodes cordons the nodes of a node group and evicts their pods, respecting their pod disruption budgets
+// unless force is set, in which case the pods are deleted. It returns the number of pods left on the nodes.
+func drainNodes(ctx context.Context, remoteClient client.Client, nodegroupName string, force bool) (int, error) {
+	nodes := &corev1.NodeList{}
+	if err := remoteClient.List(ctx, nodes, client.MatchingLabels{nodegroupNameLabel: nodegroupName}); err != nil {
+		return 0, errors.Wrapf(err, "failed to list the nodes of nodegroup %s", nodegroupName)
+	}
+
+	remaining := 0
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		if !node.Spec.Unschedulable {
+			patchHelper := client.MergeFrom(node.DeepCopy())
+			node.Spec.Unschedulable = true
+			if err := remoteClient.Patch(ctx, node, patchHelper); err != nil {
+				return 0, errors.Wrapf(err, "failed to cordon node %s", node.Name)
+			}
+		}
+
+		pods := &corev1.PodList{}
+		if err := remoteClient.List(ctx, pods, client.MatchingFields{"spec.nodeName": node.Name}); err != nil {
+			return 0, errors.Wrapf(err, "failed to list the pods of node %s", node.Name)
+		}
+
+		for j := range pods.Items {
+			pod := &pods.Items[j]
+			if !podNeedsEviction(pod) {
+				continue
+			}
+			remaining++
+			if !pod.DeletionTimestamp.IsZero() {
+				continue
+			}
+
+			if force {
+				if err := remoteClient.Delete(ctx, pod); client.IgnoreNotFound(err) != nil {
+					return 0, errors.Wrapf(err, "failed to delete pod %s/%s", pod.Namespace, pod.Name)
+				}
+				continue
+			}
+
+			err := remoteClient.SubResource("eviction").Create(ctx, pod, &policyv1.Eviction{})
+			switch {
+			case err == nil, apierrors.IsNotFound(err):
+			case apierrors.IsTooManyRequests(err):
+				// The eviction is blocked by a pod disruption budget, it is retried on the next reconcile.
+			default:
+				return 0, errors.Wrapf(err, "failed to evict pod %s/%s", pod.Namespace, pod.Name)
+			}
+		}
+	}
+
+	return remaining, nil
+}
+
+// podNeedsEviction returns whether a pod has to be evicted for its node to be drained. The pods of daemon sets
+// and the static pods would be recreated on the node, and the completed pods don't run anymore.
+func podNeedsEviction(pod *corev1.Pod) bool {
+	if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+		return false
+	}
+	if _, ok := pod.Annotations[corev1.MirrorPodAnnotationKey]; ok {
+		return false
+	}
+	for _, ref := range pod.OwnerReferences {
+		if ref.Kind == "DaemonSet" && ref.Controller != nil && *ref.Controller {
+			return false
+		}
+	}
+	return true
+}
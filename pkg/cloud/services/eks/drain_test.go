@@ -0,0 +1,155 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestDrainNodes(t *testing.T) {
+	node := func(name, nodegroup string) *corev1.Node {
+		return &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{nodegroupNameLabel: nodegroup},
+		}}
+	}
+	pod := func(name, nodeName string, mutate ...func(*corev1.Pod)) *corev1.Pod {
+		p := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       corev1.PodSpec{NodeName: nodeName},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+		for _, m := range mutate {
+			m(p)
+		}
+		return p
+	}
+	daemonSetPod := func(p *corev1.Pod) {
+		p.OwnerReferences = []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "DaemonSet", Name: "ds", UID: "ds", Controller: ptr.To(true)}}
+	}
+	completedPod := func(p *corev1.Pod) {
+		p.Status.Phase = corev1.PodSucceeded
+	}
+	blockedByPDB := interceptor.Funcs{
+		SubResourceCreate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, subResource client.Object, opts ...client.SubResourceCreateOption) error {
+			return apierrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 10)
+		},
+	}
+
+	testCases := []struct {
+		name             string
+		objects          []client.Object
+		funcs            interceptor.Funcs
+		force            bool
+		expectRemaining  int
+		expectPods       []string
+		expectUncordoned []string
+	}{
+		{
+			name: "evicts the pods of the nodegroup nodes",
+			objects: []client.Object{
+				node("node-1", "ng"),
+				node("node-2", "other"),
+				pod("app-1", "node-1"),
+				pod("app-2", "node-2"),
+			},
+			expectRemaining:  1,
+			expectPods:       []string{"app-2"},
+			expectUncordoned: []string{"node-2"},
+		},
+		{
+			name: "ignores daemon set and completed pods",
+			objects: []client.Object{
+				node("node-1", "ng"),
+				pod("ds-1", "node-1", daemonSetPod),
+				pod("job-1", "node-1", completedPod),
+			},
+			expectRemaining: 0,
+			expectPods:      []string{"ds-1", "job-1"},
+		},
+		{
+			name: "keeps the pods protected by a pod disruption budget",
+			objects: []client.Object{
+				node("node-1", "ng"),
+				pod("app-1", "node-1"),
+			},
+			funcs:           blockedByPDB,
+			expectRemaining: 1,
+			expectPods:      []string{"app-1"},
+		},
+		{
+			name: "deletes the pods protected by a pod disruption budget when forced",
+			objects: []client.Object{
+				node("node-1", "ng"),
+				pod("app-1", "node-1"),
+			},
+			funcs:           blockedByPDB,
+			force:           true,
+			expectRemaining: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			scheme := runtime.NewScheme()
+			g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+			c := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(tc.objects...).
+				WithIndex(&corev1.Pod{}, "spec.nodeName", func(o client.Object) []string {
+					return []string{o.(*corev1.Pod).Spec.NodeName}
+				}).
+				WithInterceptorFuncs(tc.funcs).
+				Build()
+
+			remaining, err := drainNodes(context.TODO(), c, "ng", tc.force)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(remaining).To(Equal(tc.expectRemaining))
+
+			pods := &corev1.PodList{}
+			g.Expect(c.List(context.TODO(), pods)).To(Succeed())
+			podNames := []string{}
+			for _, p := range pods.Items {
+				podNames = append(podNames, p.Name)
+			}
+			g.Expect(podNames).To(ConsistOf(tc.expectPods))
+
+			nodes := &corev1.NodeList{}
+			g.Expect(c.List(context.TODO(), nodes)).To(Succeed())
+			uncordoned := []string{}
+			for _, n := range nodes.Items {
+				if !n.Spec.Unschedulable {
+					uncordoned = append(uncordoned, n.Name)
+				}
+			}
+			g.Expect(uncordoned).To(ConsistOf(tc.expectUncordoned))
+		})
+	}
+}
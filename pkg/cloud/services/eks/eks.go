@@ -107,6 +107,9 @@ func (s *NodegroupService) ReconcilePool(ctx context.Context) error {
 	conditions.MarkTrue(s.scope.ManagedMachinePool, expinfrav1.IAMNodegroupRolesReadyCondition)
 
 	if err := s.reconcileNodegroup(ctx); err != nil {
+		if errors.Is(err, ErrNodegroupUpdateInProgress) {
+			return err
+		}
 		conditions.MarkFalse(
 			s.scope.ManagedMachinePool,
 			expinfrav1.EKSNodegroupReadyCondition,
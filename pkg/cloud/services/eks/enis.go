@@ -0,0 +1,102 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/filter"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// controlPlaneENIsWaitTimeout bounds the wait for the network interfaces of the control plane to be released after
+// the deletion of the EKS cluster. EKS usually releases them within a few minutes.
+const controlPlaneENIsWaitTimeout = 15 * time.Minute
+
+// ControlPlaneENIsReleased returns whether the network interfaces EKS created for the control plane in the managed
+// VPC of the cluster were released, so that its subnets can be deleted. While they remain, the
+// WaitingForControlPlaneENIsCondition lists them. Once they remained unchanged for the wait timeout, it returns true
+// so that the deletion of the network is attempted anyway, and the condition keeps reporting them.
+func (s *Service) ControlPlaneENIsReleased() (bool, error) {
+	if s.scope.VPC().ID == "" || s.scope.VPC().IsUnmanaged(s.scope.Name(), s.scope.OwnershipTagPrefixes()...) || s.scope.KubernetesClusterName() == "" {
+		return true, nil
+	}
+
+	ids, err := s.describeControlPlaneENIs()
+	if err != nil {
+		return false, err
+	}
+	if len(ids) == 0 {
+		conditions.Delete(s.scope.ControlPlane, ekscontrolplanev1.WaitingForControlPlaneENIsCondition)
+		return true, nil
+	}
+
+	// The transition time of the condition is kept while the remaining network interfaces don't change.
+	existing := conditions.Get(s.scope.ControlPlane, ekscontrolplanev1.WaitingForControlPlaneENIsCondition)
+	timedOut := existing != nil && existing.Status == corev1.ConditionTrue &&
+		(existing.Reason == ekscontrolplanev1.ControlPlaneENIsWaitTimedOutReason || time.Since(existing.LastTransitionTime.Time) >= controlPlaneENIsWaitTimeout)
+	if timedOut {
+		conditions.MarkTrueWithNegativePolarity(s.scope.ControlPlane, ekscontrolplanev1.WaitingForControlPlaneENIsCondition, ekscontrolplanev1.ControlPlaneENIsWaitTimedOutReason,
+			clusterv1.ConditionSeverityWarning, "Network interfaces of the EKS control plane still exist after %s, deleting the network anyway: %s", controlPlaneENIsWaitTimeout, strings.Join(ids, ", "))
+		return true, nil
+	}
+
+	s.scope.Info("Waiting for the network interfaces of the EKS control plane to be released", "network-interfaces", ids)
+	conditions.MarkTrueWithNegativePolarity(s.scope.ControlPlane, ekscontrolplanev1.WaitingForControlPlaneENIsCondition, ekscontrolplanev1.ControlPlaneENIsExistReason,
+		clusterv1.ConditionSeverityInfo, "Waiting for the network interfaces of the EKS control plane to be released: %s", strings.Join(ids, ", "))
+	return false, nil
+}
+
+// describeControlPlaneENIs returns the IDs of the requester-managed network interfaces EKS created for the control
+// plane in the VPC of the cluster.
+func (s *Service) describeControlPlaneENIs() ([]string, error) {
+	input := &ec2.DescribeNetworkInterfacesInput{
+		Filters: []*ec2.Filter{
+			filter.EC2.VPC(s.scope.VPC().ID),
+			{
+				Name:   aws.String("requester-managed"),
+				Values: aws.StringSlice([]string{"true"}),
+			},
+			{
+				Name:   aws.String("description"),
+				Values: aws.StringSlice([]string{"Amazon EKS " + s.scope.KubernetesClusterName()}),
+			},
+		},
+	}
+
+	var ids []string
+	if err := s.EC2Client.DescribeNetworkInterfacesPagesWithContext(context.TODO(), input, func(out *ec2.DescribeNetworkInterfacesOutput, _ bool) bool {
+		for _, eni := range out.NetworkInterfaces {
+			ids = append(ids, aws.StringValue(eni.NetworkInterfaceId))
+		}
+		return true
+	}); err != nil {
+		return nil, errors.Wrap(err, "failed to describe network interfaces of EKS control plane")
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
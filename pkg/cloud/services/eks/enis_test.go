@@ -0,0 +1,156 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestControlPlaneENIsReleased(t *testing.T) {
+	const waitingMessage = "Waiting for the network interfaces of the EKS control plane to be released: eni-1, eni-2"
+
+	tests := []struct {
+		name         string
+		unmanagedVPC bool
+		enis         []string
+		existing     *clusterv1.Condition
+		wantReleased bool
+		wantReason   string
+		wantMessage  string
+	}{
+		{
+			name:         "unmanaged VPC isn't deleted",
+			unmanagedVPC: true,
+			wantReleased: true,
+		},
+		{
+			name:         "no network interface remains",
+			enis:         nil,
+			existing:     conditions.TrueConditionWithNegativePolarity(ekscontrolplanev1.WaitingForControlPlaneENIsCondition, ekscontrolplanev1.ControlPlaneENIsExistReason, clusterv1.ConditionSeverityInfo, waitingMessage),
+			wantReleased: true,
+		},
+		{
+			name:        "network interfaces remain",
+			enis:        []string{"eni-2", "eni-1"},
+			wantReason:  ekscontrolplanev1.ControlPlaneENIsExistReason,
+			wantMessage: waitingMessage,
+		},
+		{
+			name: "network interfaces remain unchanged after the wait timeout",
+			enis: []string{"eni-2", "eni-1"},
+			existing: &clusterv1.Condition{
+				Type:               ekscontrolplanev1.WaitingForControlPlaneENIsCondition,
+				Status:             "True",
+				Severity:           clusterv1.ConditionSeverityInfo,
+				Reason:             ekscontrolplanev1.ControlPlaneENIsExistReason,
+				Message:            waitingMessage,
+				LastTransitionTime: metav1.NewTime(time.Now().Add(-controlPlaneENIsWaitTimeout - time.Minute)),
+			},
+			wantReleased: true,
+			wantReason:   ekscontrolplanev1.ControlPlaneENIsWaitTimedOutReason,
+			wantMessage:  "still exist after 15m0s, deleting the network anyway: eni-1, eni-2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+			s, controlPlane := newControlPlaneENIsService(t, tt.unmanagedVPC)
+			s.EC2Client = ec2Mock
+			if tt.existing != nil {
+				conditions.Set(controlPlane, tt.existing)
+			}
+
+			if !tt.unmanagedVPC {
+				ec2Mock.EXPECT().DescribeNetworkInterfacesPagesWithContext(context.TODO(), &ec2.DescribeNetworkInterfacesInput{
+					Filters: []*ec2.Filter{
+						{Name: aws.String("vpc-id"), Values: aws.StringSlice([]string{"vpc-1"})},
+						{Name: aws.String("requester-managed"), Values: aws.StringSlice([]string{"true"})},
+						{Name: aws.String("description"), Values: aws.StringSlice([]string{"Amazon EKS default_cp"})},
+					},
+				}, gomock.Any()).DoAndReturn(func(_ context.Context, _ *ec2.DescribeNetworkInterfacesInput, fn func(*ec2.DescribeNetworkInterfacesOutput, bool) bool, _ ...request.Option) error {
+					out := &ec2.DescribeNetworkInterfacesOutput{}
+					for _, id := range tt.enis {
+						out.NetworkInterfaces = append(out.NetworkInterfaces, &ec2.NetworkInterface{NetworkInterfaceId: aws.String(id)})
+					}
+					fn(out, true)
+					return nil
+				})
+			}
+
+			released, err := s.ControlPlaneENIsReleased()
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(released).To(Equal(tt.wantReleased))
+			if tt.wantReason == "" {
+				g.Expect(conditions.Has(controlPlane, ekscontrolplanev1.WaitingForControlPlaneENIsCondition)).To(BeFalse())
+				return
+			}
+			g.Expect(conditions.GetReason(controlPlane, ekscontrolplanev1.WaitingForControlPlaneENIsCondition)).To(Equal(tt.wantReason))
+			g.Expect(conditions.GetMessage(controlPlane, ekscontrolplanev1.WaitingForControlPlaneENIsCondition)).To(ContainSubstring(tt.wantMessage))
+		})
+	}
+}
+
+func newControlPlaneENIsService(t *testing.T, unmanagedVPC bool) (*Service, *ekscontrolplanev1.AWSManagedControlPlane) {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+	_ = ekscontrolplanev1.AddToScheme(scheme)
+	vpc := infrav1.VPCSpec{ID: "vpc-1"}
+	if !unmanagedVPC {
+		vpc.Tags = infrav1.Tags{infrav1.ClusterTagKey("cluster"): string(infrav1.ResourceLifecycleOwned)}
+	}
+	controlPlane := &ekscontrolplanev1.AWSManagedControlPlane{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "cp"},
+		Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{
+			EKSClusterName: "default_cp",
+			NetworkSpec:    infrav1.NetworkSpec{VPC: vpc},
+		},
+	}
+	managedScope, err := scope.NewManagedControlPlaneScope(scope.ManagedControlPlaneScopeParams{
+		Client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "cluster"},
+		},
+		ControlPlane: controlPlane,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test context: %v", err)
+	}
+	return NewService(managedScope), controlPlane
+}
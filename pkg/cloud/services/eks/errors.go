@@ -37,4 +37,7 @@ var (
 	ErrCannotUseAdditionalRoles = errors.New("additional rules cannot be added as this has been disabled")
 	// ErrNoSecurityGroup is an error when no security group is found for an EKS cluster.
 	ErrNoSecurityGroup = errors.New("no security group for EKS cluster")
+	// ErrNodegroupUpdateInProgress is an error if EKS rejected an update of a nodegroup because another update of
+	// the nodegroup is in progress. EKS only allows one update at a time, so the update has to be retried later.
+	ErrNodegroupUpdateInProgress = errors.New("another update of the nodegroup is in progress")
 )
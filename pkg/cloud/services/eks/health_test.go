@@ -0,0 +1,79 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/eks"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestReconcileAddonHealth(t *testing.T) {
+	g := NewWithT(t)
+	controlPlane := &ekscontrolplanev1.AWSManagedControlPlane{}
+	s := &Service{scope: &scope.ManagedControlPlaneScope{ControlPlane: controlPlane}}
+
+	addons := []ekscontrolplanev1.AddonState{
+		{Name: "vpc-cni", Status: aws.String(eks.AddonStatusActive)},
+		{
+			Name:   "coredns",
+			Status: aws.String(eks.AddonStatusDegraded),
+			Issues: []ekscontrolplanev1.AddonIssue{
+				{Code: aws.String(eks.AddonIssueCodeInsufficientNumberOfReplicas), Message: aws.String("The add-on is unhealthy because it doesn't have the desired number of replicas.")},
+			},
+		},
+	}
+	s.reconcileAddonHealth(addons)
+	condition := conditions.Get(controlPlane, ekscontrolplanev1.AddonDegradedCondition)
+	g.Expect(condition).ToNot(BeNil())
+	g.Expect(condition.Status).To(Equal(corev1.ConditionTrue))
+	g.Expect(condition.Reason).To(Equal(ekscontrolplanev1.AddonHealthIssuesReason))
+	g.Expect(condition.Message).To(Equal("Degraded EKS addons: coredns (InsufficientNumberOfReplicas: The add-on is unhealthy because it doesn't have the desired number of replicas.)"))
+
+	addons[1].Status = aws.String(eks.AddonStatusActive)
+	s.reconcileAddonHealth(addons)
+	g.Expect(conditions.Has(controlPlane, ekscontrolplanev1.AddonDegradedCondition)).To(BeFalse())
+}
+
+func TestReconcileNodegroupHealth(t *testing.T) {
+	g := NewWithT(t)
+	pool := &expinfrav1.AWSManagedMachinePool{Spec: expinfrav1.AWSManagedMachinePoolSpec{EKSNodegroupName: "ng"}}
+	s := &NodegroupService{scope: &scope.ManagedMachinePoolScope{ManagedMachinePool: pool}}
+
+	s.reconcileNodegroupHealth(&eks.Nodegroup{
+		Status: aws.String(eks.NodegroupStatusDegraded),
+		Health: &eks.NodegroupHealth{Issues: []*eks.Issue{
+			{Code: aws.String(eks.NodegroupIssueCodeAsgInstanceLaunchFailures), Message: aws.String("Could not launch On-Demand Instances.")},
+		}},
+	})
+	condition := conditions.Get(pool, expinfrav1.NodegroupDegradedCondition)
+	g.Expect(condition).ToNot(BeNil())
+	g.Expect(condition.Status).To(Equal(corev1.ConditionTrue))
+	g.Expect(condition.Reason).To(Equal(expinfrav1.NodegroupHealthIssuesReason))
+	g.Expect(condition.Message).To(Equal("EKS nodegroup ng is degraded: AsgInstanceLaunchFailures: Could not launch On-Demand Instances."))
+
+	s.reconcileNodegroupHealth(&eks.Nodegroup{Status: aws.String(eks.NodegroupStatusActive)})
+	g.Expect(conditions.Has(pool, expinfrav1.NodegroupDegradedCondition)).To(BeFalse())
+}
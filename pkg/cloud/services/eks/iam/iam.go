@@ -184,12 +184,14 @@ func RoleTags(key string, additionalTags infrav1.Tags) []*iam.Tag {
 	return tags
 }
 
-// CreateRole will create a role from the IAMService.
+// CreateRole will create a role from the IAMService. The path and permissions boundary of the role are
+// set according to the role management options, if any.
 func (s *IAMService) CreateRole(
 	roleName string,
 	key string,
 	trustRelationship *iamv1.PolicyDocument,
 	additionalTags infrav1.Tags,
+	management *infrav1.IAMRoleManagement,
 ) (*iam.Role, error) {
 	tags := RoleTags(key, additionalTags)
 
@@ -203,6 +205,14 @@ func (s *IAMService) CreateRole(
 		Tags:                     tags,
 		AssumeRolePolicyDocument: aws.String(trustRelationshipJSON),
 	}
+	if management != nil {
+		if management.Path != "" {
+			input.Path = aws.String(management.Path)
+		}
+		if management.PermissionsBoundary != "" {
+			input.PermissionsBoundary = aws.String(management.PermissionsBoundary)
+		}
+	}
 
 	out, err := s.IAMClient.CreateRole(input)
 	if err != nil {
@@ -221,31 +231,9 @@ func (s *IAMService) EnsureTagsAndPolicy(
 ) (bool, error) {
 	s.Debug("Ensuring tags and AssumeRolePolicyDocument are set on role")
 
-	rolePolicyDocumentRaw, err := url.PathUnescape(*role.AssumeRolePolicyDocument)
-	if err != nil {
-		return false, errors.Wrap(err, "couldn't decode AssumeRolePolicyDocument")
-	}
-
-	var rolePolicyDocument iamv1.PolicyDocument
-	err = json.Unmarshal([]byte(rolePolicyDocumentRaw), &rolePolicyDocument)
+	updated, err := s.EnsureTrustPolicy(role, trustRelationship)
 	if err != nil {
-		return false, errors.Wrap(err, "couldn't unmarshal AssumeRolePolicyDocument")
-	}
-
-	var updated bool
-	if !cmp.Equal(*trustRelationship, rolePolicyDocument) {
-		trustRelationshipJSON, err := converters.IAMPolicyDocumentToJSON(*trustRelationship)
-		if err != nil {
-			return false, errors.Wrap(err, "error converting trust relationship to json")
-		}
-		policyInput := &iam.UpdateAssumeRolePolicyInput{
-			RoleName:       role.RoleName,
-			PolicyDocument: aws.String(trustRelationshipJSON),
-		}
-		updated = true
-		if _, err := s.IAMClient.UpdateAssumeRolePolicy(policyInput); err != nil {
-			return updated, err
-		}
+		return updated, err
 	}
 
 	tagInput := &iam.TagRoleInput{
@@ -292,6 +280,75 @@ func (s *IAMService) EnsureTagsAndPolicy(
 	return updated, nil
 }
 
+// EnsureTrustPolicy will ensure the trust policy of a role is the given trust relationship.
+func (s *IAMService) EnsureTrustPolicy(role *iam.Role, trustRelationship *iamv1.PolicyDocument) (bool, error) {
+	rolePolicyDocumentRaw, err := url.PathUnescape(aws.StringValue(role.AssumeRolePolicyDocument))
+	if err != nil {
+		return false, errors.Wrap(err, "couldn't decode AssumeRolePolicyDocument")
+	}
+
+	var rolePolicyDocument iamv1.PolicyDocument
+	err = json.Unmarshal([]byte(rolePolicyDocumentRaw), &rolePolicyDocument)
+	if err != nil {
+		return false, errors.Wrap(err, "couldn't unmarshal AssumeRolePolicyDocument")
+	}
+
+	trustRelationshipJSON, err := converters.IAMPolicyDocumentToJSON(*trustRelationship)
+	if err != nil {
+		return false, errors.Wrap(err, "error converting trust relationship to json")
+	}
+
+	// The trust relationship is compared once decoded like the one of the role, so that the values of the
+	// conditions have the same types.
+	var trustRelationshipDocument iamv1.PolicyDocument
+	if err := json.Unmarshal([]byte(trustRelationshipJSON), &trustRelationshipDocument); err != nil {
+		return false, errors.Wrap(err, "couldn't unmarshal trust relationship")
+	}
+	if cmp.Equal(trustRelationshipDocument, rolePolicyDocument) {
+		return false, nil
+	}
+
+	policyInput := &iam.UpdateAssumeRolePolicyInput{
+		RoleName:       role.RoleName,
+		PolicyDocument: aws.String(trustRelationshipJSON),
+	}
+	if _, err := s.IAMClient.UpdateAssumeRolePolicy(policyInput); err != nil {
+		return true, err
+	}
+	return true, nil
+}
+
+// EnsurePermissionsBoundary will ensure the permissions boundary of a role is the given policy, or that the
+// role has no permissions boundary when empty.
+func (s *IAMService) EnsurePermissionsBoundary(role *iam.Role, permissionsBoundary string) (bool, error) {
+	var current string
+	if role.PermissionsBoundary != nil {
+		current = aws.StringValue(role.PermissionsBoundary.PermissionsBoundaryArn)
+	}
+	if current == permissionsBoundary {
+		return false, nil
+	}
+
+	if permissionsBoundary == "" {
+		s.Debug("Removing permissions boundary from role", "role", aws.StringValue(role.RoleName))
+		if _, err := s.IAMClient.DeleteRolePermissionsBoundary(&iam.DeleteRolePermissionsBoundaryInput{
+			RoleName: role.RoleName,
+		}); err != nil {
+			return true, errors.Wrapf(err, "error removing permissions boundary from role %s", aws.StringValue(role.RoleName))
+		}
+		return true, nil
+	}
+
+	s.Debug("Setting permissions boundary on role", "role", aws.StringValue(role.RoleName), "policy", permissionsBoundary)
+	if _, err := s.IAMClient.PutRolePermissionsBoundary(&iam.PutRolePermissionsBoundaryInput{
+		RoleName:            role.RoleName,
+		PermissionsBoundary: aws.String(permissionsBoundary),
+	}); err != nil {
+		return true, errors.Wrapf(err, "error setting permissions boundary %s on role %s", permissionsBoundary, aws.StringValue(role.RoleName))
+	}
+	return true, nil
+}
+
 func (s *IAMService) detachAllPoliciesForRole(name string) error {
 	s.Debug("Detaching all policies for role", "role", name)
 	input := &iam.ListAttachedRolePoliciesInput{
@@ -405,6 +462,52 @@ func NodegroupTrustRelationship() *iamv1.PolicyDocument {
 	return policy
 }
 
+// WithRoleManagement will add the additional trust statements of the role management options, if any, to a
+// trust relationship.
+func WithRoleManagement(trustRelationship *iamv1.PolicyDocument, management *infrav1.IAMRoleManagement) *iamv1.PolicyDocument {
+	if management == nil {
+		return trustRelationship
+	}
+
+	for _, statement := range management.AdditionalTrustStatements {
+		actions := statement.Actions
+		if len(actions) == 0 {
+			actions = []string{"sts:AssumeRole"}
+		}
+
+		entry := iamv1.StatementEntry{
+			Sid:    statement.Sid,
+			Effect: iamv1.EffectAllow,
+			Action: actions,
+			Principal: iamv1.Principals{
+				iamv1.PrincipalType(statement.PrincipalType): statement.Principals,
+			},
+		}
+
+		for _, condition := range statement.Conditions {
+			if entry.Condition == nil {
+				entry.Condition = iamv1.Conditions{}
+			}
+			operator := iamv1.ConditionOperator(condition.Operator)
+			keys, ok := entry.Condition[operator].(map[string]interface{})
+			if !ok {
+				keys = map[string]interface{}{}
+				entry.Condition[operator] = keys
+			}
+			// A single value is set as is, the way IAM returns it.
+			if len(condition.Values) == 1 {
+				keys[condition.Key] = condition.Values[0]
+			} else {
+				keys[condition.Key] = condition.Values
+			}
+		}
+
+		trustRelationship.Statement = append(trustRelationship.Statement, entry)
+	}
+
+	return trustRelationship
+}
+
 func findStringInSlice(slice []*string, toFind string) bool {
 	for _, item := range slice {
 		if *item == toFind {
@@ -0,0 +1,171 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package iam
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/go-logr/logr"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/converters"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/iamauth/mock_iamauth"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
+)
+
+var breakGlassManagement = &infrav1.IAMRoleManagement{
+	AdditionalTrustStatements: []infrav1.IAMTrustStatement{{
+		PrincipalType: "AWS",
+		Principals:    []string{"arn:aws:iam::123456789012:role/break-glass"},
+		Conditions: []infrav1.IAMTrustCondition{{
+			Operator: "Bool",
+			Key:      "aws:MultiFactorAuthPresent",
+			Values:   []string{"true"},
+		}},
+	}},
+}
+
+func TestEnsureTrustPolicy(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	trustRelationship := WithRoleManagement(NodegroupTrustRelationship(), breakGlassManagement)
+	trustRelationshipJSON, err := converters.IAMPolicyDocumentToJSON(*trustRelationship)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nodegroupJSON, err := converters.IAMPolicyDocumentToJSON(*NodegroupTrustRelationship())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		current     string
+		wantUpdated bool
+		expect      func(m *mock_iamauth.MockIAMAPIMockRecorder)
+	}{
+		{
+			name:        "should add the additional trust statements",
+			current:     nodegroupJSON,
+			wantUpdated: true,
+			expect: func(m *mock_iamauth.MockIAMAPIMockRecorder) {
+				m.UpdateAssumeRolePolicy(&iam.UpdateAssumeRolePolicyInput{
+					RoleName:       aws.String("role"),
+					PolicyDocument: aws.String(trustRelationshipJSON),
+				}).Return(&iam.UpdateAssumeRolePolicyOutput{}, nil)
+			},
+		},
+		{
+			name:    "should not update the trust policy once the statements are added",
+			current: url.PathEscape(trustRelationshipJSON),
+			expect:  func(m *mock_iamauth.MockIAMAPIMockRecorder) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			iamMock := mock_iamauth.NewMockIAMAPI(mockCtrl)
+			tt.expect(iamMock.EXPECT())
+			s := &IAMService{Wrapper: logger.NewLogger(logr.Discard()), IAMClient: iamMock}
+
+			updated, err := s.EnsureTrustPolicy(&iam.Role{
+				RoleName:                 aws.String("role"),
+				AssumeRolePolicyDocument: aws.String(tt.current),
+			}, trustRelationship)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(updated).To(Equal(tt.wantUpdated))
+		})
+	}
+}
+
+func TestEnsurePermissionsBoundary(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	boundary := "arn:aws:iam::123456789012:policy/boundary"
+
+	tests := []struct {
+		name        string
+		current     *iam.AttachedPermissionsBoundary
+		desired     string
+		wantUpdated bool
+		expect      func(m *mock_iamauth.MockIAMAPIMockRecorder)
+	}{
+		{
+			name:        "should set the permissions boundary",
+			desired:     boundary,
+			wantUpdated: true,
+			expect: func(m *mock_iamauth.MockIAMAPIMockRecorder) {
+				m.PutRolePermissionsBoundary(&iam.PutRolePermissionsBoundaryInput{
+					RoleName:            aws.String("role"),
+					PermissionsBoundary: aws.String(boundary),
+				}).Return(&iam.PutRolePermissionsBoundaryOutput{}, nil)
+			},
+		},
+		{
+			name:    "should do nothing when the permissions boundary is set",
+			current: &iam.AttachedPermissionsBoundary{PermissionsBoundaryArn: aws.String(boundary)},
+			desired: boundary,
+			expect:  func(m *mock_iamauth.MockIAMAPIMockRecorder) {},
+		},
+		{
+			name:        "should remove the permissions boundary",
+			current:     &iam.AttachedPermissionsBoundary{PermissionsBoundaryArn: aws.String(boundary)},
+			wantUpdated: true,
+			expect: func(m *mock_iamauth.MockIAMAPIMockRecorder) {
+				m.DeleteRolePermissionsBoundary(&iam.DeleteRolePermissionsBoundaryInput{
+					RoleName: aws.String("role"),
+				}).Return(&iam.DeleteRolePermissionsBoundaryOutput{}, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			iamMock := mock_iamauth.NewMockIAMAPI(mockCtrl)
+			tt.expect(iamMock.EXPECT())
+			s := &IAMService{Wrapper: logger.NewLogger(logr.Discard()), IAMClient: iamMock}
+
+			updated, err := s.EnsurePermissionsBoundary(&iam.Role{
+				RoleName:            aws.String("role"),
+				PermissionsBoundary: tt.current,
+			}, tt.desired)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(updated).To(Equal(tt.wantUpdated))
+		})
+	}
+}
+
+func TestWithRoleManagement(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(WithRoleManagement(FargateTrustRelationship(), nil)).To(Equal(FargateTrustRelationship()))
+
+	trustRelationship := WithRoleManagement(FargateTrustRelationship(), breakGlassManagement)
+	g.Expect(trustRelationship.Statement).To(HaveLen(2))
+	g.Expect(trustRelationship.Statement[1].Action).To(ConsistOf("sts:AssumeRole"))
+	g.Expect(trustRelationship.Statement[1].Principal).To(HaveKeyWithValue(BeEquivalentTo("AWS"), ConsistOf("arn:aws:iam::123456789012:role/break-glass")))
+	g.Expect(trustRelationship.Statement[1].Condition).To(HaveKeyWithValue(BeEquivalentTo("Bool"), HaveKeyWithValue("aws:MultiFactorAuthPresent", "true")))
+}
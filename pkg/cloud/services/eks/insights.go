@@ -0,0 +1,178 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/version"
+
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+)
+
+// upgradeInsightsRefreshInterval is the interval after which the upgrade insights of a cluster are fetched again
+// while no update of its Kubernetes version is pending. It matches the maximum sync period of the EKS controllers.
+const upgradeInsightsRefreshInterval = 10 * time.Minute
+
+// reconcileUpgradeInsights records the upgrade readiness insights of the cluster in the status, fetching them again
+// once they're older than the refresh interval, or on each reconciliation while an update of the Kubernetes version
+// is pending. Failing to fetch them only fails the reconciliation while an update is pending, as the update is held
+// until they're checked.
+func (s *Service) reconcileUpgradeInsights(cluster *eks.Cluster) error {
+	pending := s.pendingClusterVersion(cluster) != ""
+	current := s.scope.ControlPlane.Status.UpgradeInsights
+	if !pending && current != nil && time.Since(current.RefreshedAt.Time) < upgradeInsightsRefreshInterval {
+		return nil
+	}
+
+	insights, err := s.getUpgradeInsights(s.scope.KubernetesClusterName())
+	if err != nil {
+		if pending {
+			return err
+		}
+		s.scope.Error(err, "non-fatal: failed to refresh upgrade insights")
+		return nil
+	}
+	s.scope.ControlPlane.Status.UpgradeInsights = &ekscontrolplanev1.UpgradeInsights{
+		RefreshedAt: metav1.Now(),
+		Insights:    insights,
+	}
+	return nil
+}
+
+// pendingClusterVersion returns the Kubernetes version the cluster is updated to next, as it is updated one minor
+// version at a time, or an empty string when the cluster already runs the version of the spec.
+func (s *Service) pendingClusterVersion(cluster *eks.Cluster) string {
+	if s.scope.ControlPlane.Spec.Version == nil || cluster.Version == nil {
+		return ""
+	}
+	specVersion, err := parseEKSVersion(*s.scope.ControlPlane.Spec.Version)
+	if err != nil {
+		return ""
+	}
+	clusterVersion, err := version.ParseGeneric(*cluster.Version)
+	if err != nil || !clusterVersion.LessThan(specVersion) {
+		return ""
+	}
+	return versionToEKS(clusterVersion.WithMinor(clusterVersion.Minor() + 1))
+}
+
+// getUpgradeInsights lists the upgrade readiness insights of the cluster, and describes the ones which aren't
+// passing to report the deprecated APIs still in use.
+func (s *Service) getUpgradeInsights(eksClusterName string) ([]ekscontrolplanev1.UpgradeInsight, error) {
+	input := &eks.ListInsightsInput{
+		ClusterName: aws.String(eksClusterName),
+		Filter: &eks.InsightsFilter{
+			Categories: aws.StringSlice([]string{eks.CategoryUpgradeReadiness}),
+		},
+	}
+	var summaries []*eks.InsightSummary
+	if err := s.EKSClient.ListInsightsPages(input, func(out *eks.ListInsightsOutput, _ bool) bool {
+		summaries = append(summaries, out.Insights...)
+		return true
+	}); err != nil {
+		return nil, errors.Wrap(err, "failed to list upgrade insights")
+	}
+
+	insights := make([]ekscontrolplanev1.UpgradeInsight, 0, len(summaries))
+	for _, summary := range summaries {
+		insight := ekscontrolplanev1.UpgradeInsight{
+			ID:                aws.StringValue(summary.Id),
+			Name:              aws.StringValue(summary.Name),
+			Category:          aws.StringValue(summary.Category),
+			KubernetesVersion: aws.StringValue(summary.KubernetesVersion),
+		}
+		if summary.InsightStatus != nil {
+			insight.Status = aws.StringValue(summary.InsightStatus.Status)
+			insight.Reason = aws.StringValue(summary.InsightStatus.Reason)
+		}
+
+		if insight.Status != eks.InsightStatusValuePassing {
+			out, err := s.EKSClient.DescribeInsight(&eks.DescribeInsightInput{
+				ClusterName: aws.String(eksClusterName),
+				Id:          summary.Id,
+			})
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to describe upgrade insight %s", insight.ID)
+			}
+			if out.Insight != nil && out.Insight.CategorySpecificSummary != nil {
+				insight.DeprecatedAPIs = deprecatedAPIUsages(out.Insight.CategorySpecificSummary.DeprecationDetails)
+			}
+		}
+		insights = append(insights, insight)
+	}
+
+	sort.Slice(insights, func(i, j int) bool {
+		if insights[i].KubernetesVersion != insights[j].KubernetesVersion {
+			return insights[i].KubernetesVersion < insights[j].KubernetesVersion
+		}
+		return insights[i].Name < insights[j].Name
+	})
+	return insights, nil
+}
+
+// deprecatedAPIUsages returns the deprecated APIs of an insight which were requested by clients.
+func deprecatedAPIUsages(details []*eks.DeprecationDetail) []ekscontrolplanev1.DeprecatedAPIUsage {
+	var usages []ekscontrolplanev1.DeprecatedAPIUsage
+	for _, detail := range details {
+		if len(detail.ClientStats) == 0 {
+			continue
+		}
+		usage := ekscontrolplanev1.DeprecatedAPIUsage{
+			Usage:              aws.StringValue(detail.Usage),
+			ReplacedWith:       aws.StringValue(detail.ReplacedWith),
+			StopServingVersion: aws.StringValue(detail.StopServingVersion),
+		}
+		for _, stat := range detail.ClientStats {
+			usage.RequestsLast30Days += aws.Int64Value(stat.NumberOfRequestsLast30Days)
+			usage.Clients = append(usage.Clients, aws.StringValue(stat.UserAgent))
+		}
+		usages = append(usages, usage)
+	}
+	return usages
+}
+
+// unacknowledgedUpgradeInsights returns the names of the upgrade insights in the ERROR status for the Kubernetes
+// version the cluster is updated to, unless their risks were acknowledged for that version with the
+// AcknowledgeUpgradeRisksAnnotation.
+func (s *Service) unacknowledgedUpgradeInsights(nextVersion string) []string {
+	if acknowledged, ok := s.scope.ControlPlane.Annotations[ekscontrolplanev1.AcknowledgeUpgradeRisksAnnotation]; ok {
+		if v, err := parseEKSVersion(acknowledged); err == nil && versionToEKS(v) == nextVersion {
+			return nil
+		}
+	}
+	if s.scope.ControlPlane.Status.UpgradeInsights == nil {
+		return nil
+	}
+
+	var names []string
+	for _, insight := range s.scope.ControlPlane.Status.UpgradeInsights.Insights {
+		if insight.Status != eks.InsightStatusValueError {
+			continue
+		}
+		if insight.KubernetesVersion != "" && insight.KubernetesVersion != nextVersion {
+			continue
+		}
+		names = append(names, insight.Name)
+	}
+	return names
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/eks/mock_eksiface"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestUpgradeInsightsHoldVersionUpdate(t *testing.T) {
+	g := NewWithT(t)
+	mockControl := gomock.NewController(t)
+	eksMock := mock_eksiface.NewMockEKSAPI(mockControl)
+
+	s, controlPlane := newUpgradeInsightsService(t, "1.29")
+	s.EKSClient = eksMock
+	cluster := &eks.Cluster{Name: aws.String("default.cluster"), Version: aws.String("1.28")}
+
+	listInsights := func() {
+		eksMock.EXPECT().ListInsightsPages(&eks.ListInsightsInput{
+			ClusterName: aws.String("default.cluster"),
+			Filter:      &eks.InsightsFilter{Categories: aws.StringSlice([]string{eks.CategoryUpgradeReadiness})},
+		}, gomock.Any()).DoAndReturn(func(_ *eks.ListInsightsInput, fn func(*eks.ListInsightsOutput, bool) bool) error {
+			fn(&eks.ListInsightsOutput{Insights: []*eks.InsightSummary{
+				{
+					Id:                aws.String("insight-2"),
+					Name:              aws.String("Kubelet version skew"),
+					Category:          aws.String(eks.CategoryUpgradeReadiness),
+					KubernetesVersion: aws.String("1.29"),
+					InsightStatus:     &eks.InsightStatus{Status: aws.String(eks.InsightStatusValuePassing)},
+				},
+				{
+					Id:                aws.String("insight-1"),
+					Name:              aws.String("Deprecated APIs removed in Kubernetes v1.29"),
+					Category:          aws.String(eks.CategoryUpgradeReadiness),
+					KubernetesVersion: aws.String("1.29"),
+					InsightStatus:     &eks.InsightStatus{Status: aws.String(eks.InsightStatusValueError), Reason: aws.String("Deprecated API usage detected")},
+				},
+			}}, true)
+			return nil
+		})
+		eksMock.EXPECT().DescribeInsight(&eks.DescribeInsightInput{ClusterName: aws.String("default.cluster"), Id: aws.String("insight-1")}).
+			Return(&eks.DescribeInsightOutput{Insight: &eks.Insight{
+				CategorySpecificSummary: &eks.InsightCategorySpecificSummary{DeprecationDetails: []*eks.DeprecationDetail{
+					{
+						Usage:              aws.String("/apis/flowcontrol.apiserver.k8s.io/v1beta2/flowschemas"),
+						ReplacedWith:       aws.String("/apis/flowcontrol.apiserver.k8s.io/v1beta3/flowschemas"),
+						StopServingVersion: aws.String("1.29"),
+						ClientStats: []*eks.ClientStat{
+							{UserAgent: aws.String("kube-controller-manager"), NumberOfRequestsLast30Days: aws.Int64(20)},
+							{UserAgent: aws.String("kubectl"), NumberOfRequestsLast30Days: aws.Int64(2)},
+						},
+					},
+					{Usage: aws.String("/apis/flowcontrol.apiserver.k8s.io/v1beta2/prioritylevelconfigurations")},
+				}},
+			}}, nil)
+	}
+
+	// The update is held while the insight in the ERROR status isn't acknowledged.
+	listInsights()
+	g.Expect(s.reconcileUpgradeInsights(cluster)).To(Succeed())
+	g.Expect(controlPlane.Status.UpgradeInsights.Insights).To(Equal([]ekscontrolplanev1.UpgradeInsight{
+		{
+			ID:                "insight-1",
+			Name:              "Deprecated APIs removed in Kubernetes v1.29",
+			Category:          eks.CategoryUpgradeReadiness,
+			KubernetesVersion: "1.29",
+			Status:            eks.InsightStatusValueError,
+			Reason:            "Deprecated API usage detected",
+			DeprecatedAPIs: []ekscontrolplanev1.DeprecatedAPIUsage{{
+				Usage:              "/apis/flowcontrol.apiserver.k8s.io/v1beta2/flowschemas",
+				ReplacedWith:       "/apis/flowcontrol.apiserver.k8s.io/v1beta3/flowschemas",
+				StopServingVersion: "1.29",
+				RequestsLast30Days: 22,
+				Clients:            []string{"kube-controller-manager", "kubectl"},
+			}},
+		},
+		{
+			ID:                "insight-2",
+			Name:              "Kubelet version skew",
+			Category:          eks.CategoryUpgradeReadiness,
+			KubernetesVersion: "1.29",
+			Status:            eks.InsightStatusValuePassing,
+		},
+	}))
+	g.Expect(s.reconcileClusterVersion(cluster)).To(Succeed())
+	g.Expect(conditions.GetReason(controlPlane, ekscontrolplanev1.UpgradeBlockedByInsightsCondition)).To(Equal(ekscontrolplanev1.UpgradeInsightsErrorReason))
+	g.Expect(conditions.GetMessage(controlPlane, ekscontrolplanev1.UpgradeBlockedByInsightsCondition)).To(ContainSubstring(`Deprecated APIs removed in Kubernetes v1.29. Annotate the control plane with aws.cluster.x-k8s.io/acknowledge-upgrade-risks="1.29"`))
+
+	// An acknowledgement of another version doesn't release the update.
+	controlPlane.Annotations = map[string]string{ekscontrolplanev1.AcknowledgeUpgradeRisksAnnotation: "1.30"}
+	listInsights()
+	g.Expect(s.reconcileUpgradeInsights(cluster)).To(Succeed())
+	g.Expect(s.reconcileClusterVersion(cluster)).To(Succeed())
+	g.Expect(conditions.Has(controlPlane, ekscontrolplanev1.UpgradeBlockedByInsightsCondition)).To(BeTrue())
+
+	// The update starts once the risks are acknowledged for the version.
+	controlPlane.Annotations[ekscontrolplanev1.AcknowledgeUpgradeRisksAnnotation] = "1.29"
+	listInsights()
+	eksMock.EXPECT().UpdateClusterVersion(&eks.UpdateClusterVersionInput{Name: aws.String("default.cluster"), Version: aws.String("1.29")}).
+		Return(&eks.UpdateClusterVersionOutput{}, nil)
+	eksMock.EXPECT().WaitUntilClusterUpdating(gomock.AssignableToTypeOf(&eks.DescribeClusterInput{}), gomock.Any()).Return(nil)
+	g.Expect(s.reconcileUpgradeInsights(cluster)).To(Succeed())
+	g.Expect(s.reconcileClusterVersion(cluster)).To(Succeed())
+	g.Expect(conditions.Has(controlPlane, ekscontrolplanev1.UpgradeBlockedByInsightsCondition)).To(BeFalse())
+}
+
+func TestReconcileUpgradeInsightsRefresh(t *testing.T) {
+	g := NewWithT(t)
+	mockControl := gomock.NewController(t)
+	eksMock := mock_eksiface.NewMockEKSAPI(mockControl)
+
+	s, controlPlane := newUpgradeInsightsService(t, "1.29")
+	s.EKSClient = eksMock
+	cluster := &eks.Cluster{Name: aws.String("default.cluster"), Version: aws.String("1.29")}
+
+	// Recent insights aren't fetched again while no update is pending.
+	controlPlane.Status.UpgradeInsights = &ekscontrolplanev1.UpgradeInsights{RefreshedAt: metav1.NewTime(time.Now().Add(-time.Minute))}
+	g.Expect(s.reconcileUpgradeInsights(cluster)).To(Succeed())
+
+	// Failing to refresh them doesn't fail the reconciliation while no update is pending.
+	controlPlane.Status.UpgradeInsights.RefreshedAt = metav1.NewTime(time.Now().Add(-time.Hour))
+	eksMock.EXPECT().ListInsightsPages(gomock.Any(), gomock.Any()).Return(errors.New("access denied")).Times(2)
+	g.Expect(s.reconcileUpgradeInsights(cluster)).To(Succeed())
+
+	// An update isn't started before the insights are checked.
+	cluster.Version = aws.String("1.28")
+	g.Expect(s.reconcileUpgradeInsights(cluster)).To(MatchError(ContainSubstring("failed to list upgrade insights")))
+}
+
+func newUpgradeInsightsService(t *testing.T, specVersion string) (*Service, *ekscontrolplanev1.AWSManagedControlPlane) {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+	_ = ekscontrolplanev1.AddToScheme(scheme)
+	controlPlane := &ekscontrolplanev1.AWSManagedControlPlane{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "cp"},
+		Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{
+			EKSClusterName: "default.cluster",
+			Version:        aws.String(specVersion),
+		},
+	}
+	managedScope, err := scope.NewManagedControlPlaneScope(scope.ManagedControlPlaneScopeParams{
+		Client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "cluster"},
+		},
+		ControlPlane: controlPlane,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test context: %v", err)
+	}
+	return NewService(managedScope), controlPlane
+}
@@ -19,6 +19,7 @@ package eks
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
@@ -36,6 +37,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/converters"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/wait"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+	infrautilconditions "sigs.k8s.io/cluster-api-provider-aws/v2/util/conditions"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util/annotations"
 )
@@ -497,14 +499,84 @@ func (s *NodegroupService) reconcileNodegroupConfig(ng *eks.Nodegroup) error {
 		return errors.Wrap(err, "created invalid UpdateNodegroupConfigInput")
 	}
 
-	_, err = s.EKSClient.UpdateNodegroupConfig(input)
+	out, err := s.EKSClient.UpdateNodegroupConfig(input)
 	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == eks.ErrCodeResourceInUseException {
+			return errors.Wrap(ErrNodegroupUpdateInProgress, aerr.Message())
+		}
 		return errors.Wrap(err, "failed to update nodegroup config")
 	}
+	s.setActiveUpdate(out.Update)
 
 	return nil
 }
 
+// reconcileActiveUpdate polls the status of the in progress node group update recorded in the status of the machine
+// pool. It returns true while the update is still in progress.
+func (s *NodegroupService) reconcileActiveUpdate() (bool, error) {
+	update := s.scope.ManagedMachinePool.Status.ActiveUpdate
+	if !update.InProgress() {
+		return false, nil
+	}
+
+	out, err := s.EKSClient.DescribeUpdate(&eks.DescribeUpdateInput{
+		Name:          aws.String(s.scope.KubernetesClusterName()),
+		NodegroupName: aws.String(s.scope.NodegroupName()),
+		UpdateId:      aws.String(update.ID),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == eks.ErrCodeResourceNotFoundException {
+			s.scope.Info("EKS nodegroup update not found, forgetting it", "update-id", update.ID)
+			s.scope.ManagedMachinePool.Status.ActiveUpdate = nil
+			infrautilconditions.ClearAWSState(s.scope.ManagedMachinePool, expinfrav1.NodegroupUpdateInProgressCondition)
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "failed to describe nodegroup update %s", update.ID)
+	}
+	s.setActiveUpdate(out.Update)
+
+	return aws.StringValue(out.Update.Status) == eks.UpdateStatusInProgress, nil
+}
+
+// setActiveUpdate records an update of the node group in the status of the machine pool and reports it with the
+// NodegroupUpdateInProgressCondition. A successful update is forgotten, a failed one is kept with its errors until
+// the next update is started.
+func (s *NodegroupService) setActiveUpdate(update *eks.Update) {
+	managedPool := s.scope.ManagedMachinePool
+	if update == nil {
+		return
+	}
+	id := aws.StringValue(update.Id)
+
+	switch status := aws.StringValue(update.Status); status {
+	case eks.UpdateStatusSuccessful:
+		managedPool.Status.ActiveUpdate = nil
+		infrautilconditions.ClearAWSState(managedPool, expinfrav1.NodegroupUpdateInProgressCondition)
+		record.Eventf(managedPool, "SuccessfulUpdateEKSNodegroupConfig", "Update %s of EKS nodegroup %s succeeded", id, s.scope.NodegroupName())
+	case eks.UpdateStatusFailed, eks.UpdateStatusCancelled:
+		updateErrors := []string{}
+		for _, e := range update.Errors {
+			updateErrors = append(updateErrors, fmt.Sprintf("%s: %s", aws.StringValue(e.ErrorCode), aws.StringValue(e.ErrorMessage)))
+		}
+		managedPool.Status.ActiveUpdate = &expinfrav1.NodegroupUpdate{
+			ID:     id,
+			Type:   aws.StringValue(update.Type),
+			Status: status,
+			Errors: updateErrors,
+		}
+		infrautilconditions.MarkAWSState(managedPool, expinfrav1.NodegroupUpdateInProgressCondition, expinfrav1.NodegroupUpdateFailedReason,
+			fmt.Sprintf("Update %s of EKS nodegroup %s %s: %s", id, s.scope.NodegroupName(), strings.ToLower(status), strings.Join(updateErrors, "; ")))
+	default:
+		managedPool.Status.ActiveUpdate = &expinfrav1.NodegroupUpdate{
+			ID:     id,
+			Type:   aws.StringValue(update.Type),
+			Status: status,
+		}
+		infrautilconditions.MarkAWSState(managedPool, expinfrav1.NodegroupUpdateInProgressCondition, expinfrav1.NodegroupUpdatingReason,
+			fmt.Sprintf("Update %s of EKS nodegroup %s is in progress", id, s.scope.NodegroupName()))
+	}
+}
+
 func (s *NodegroupService) reconcileNodegroup(ctx context.Context) error {
 	ng, err := s.describeNodegroup()
 	if err != nil {
@@ -530,9 +602,17 @@ func (s *NodegroupService) reconcileNodegroup(ctx context.Context) error {
 		return errors.Wrap(err, "failed to set status")
 	}
 
+	updateInProgress, err := s.reconcileActiveUpdate()
+	if err != nil {
+		return errors.Wrap(err, "failed to reconcile nodegroup update")
+	}
+
 	switch *ng.Status {
 	case eks.NodegroupStatusCreating, eks.NodegroupStatusUpdating:
-		ng, err = s.waitForNodegroupActive()
+		// The update started by the controller is polled on the next reconciles instead.
+		if !updateInProgress {
+			ng, err = s.waitForNodegroupActive()
+		}
 	default:
 		break
 	}
@@ -555,12 +635,17 @@ func (s *NodegroupService) reconcileNodegroup(ctx context.Context) error {
 		return errors.Wrap(err, "failed to wait for nodegroup to be active")
 	}
 
-	if err := s.reconcileNodegroupVersion(ng); err != nil {
-		return errors.Wrap(err, "failed to reconcile nodegroup version")
-	}
+	// EKS only allows one update of a nodegroup at a time.
+	if updateInProgress {
+		s.scope.Debug("Nodegroup update in progress, skipping version and config reconciliation", "update-id", s.scope.ManagedMachinePool.Status.ActiveUpdate.ID)
+	} else {
+		if err := s.reconcileNodegroupVersion(ng); err != nil {
+			return errors.Wrap(err, "failed to reconcile nodegroup version")
+		}
 
-	if err := s.reconcileNodegroupConfig(ng); err != nil {
-		return errors.Wrap(err, "failed to reconcile nodegroup config")
+		if err := s.reconcileNodegroupConfig(ng); err != nil {
+			return errors.Wrap(err, "failed to reconcile nodegroup config")
+		}
 	}
 
 	if err := s.reconcileTags(ng); err != nil {
@@ -590,11 +675,12 @@ func (s *NodegroupService) setStatus(ng *eks.Nodegroup) error {
 		// TODO FailureReason
 	case eks.NodegroupStatusCreating:
 		managedPool.Status.Ready = false
-	case eks.NodegroupStatusUpdating:
+	case eks.NodegroupStatusUpdating, eks.NodegroupStatusDegraded:
 		managedPool.Status.Ready = true
 	default:
 		return errors.Errorf("unexpected EKS nodegroup status %s", *ng.Status)
 	}
+	s.reconcileNodegroupHealth(ng)
 	if managedPool.Status.Ready && ng.Resources != nil && len(ng.Resources.AutoScalingGroups) > 0 {
 		req := autoscaling.DescribeAutoScalingGroupsInput{}
 		for _, asg := range ng.Resources.AutoScalingGroups {
@@ -622,6 +708,25 @@ func (s *NodegroupService) setStatus(ng *eks.Nodegroup) error {
 	return nil
 }
 
+// reconcileNodegroupHealth reports the health issues of the node group with the NodegroupDegradedCondition while
+// the node group is in the DEGRADED status.
+func (s *NodegroupService) reconcileNodegroupHealth(ng *eks.Nodegroup) {
+	managedPool := s.scope.ManagedMachinePool
+	if aws.StringValue(ng.Status) != eks.NodegroupStatusDegraded {
+		infrautilconditions.ClearAWSState(managedPool, expinfrav1.NodegroupDegradedCondition)
+		return
+	}
+
+	issues := []string{}
+	if ng.Health != nil {
+		for _, issue := range ng.Health.Issues {
+			issues = append(issues, fmt.Sprintf("%s: %s", aws.StringValue(issue.Code), aws.StringValue(issue.Message)))
+		}
+	}
+	infrautilconditions.MarkAWSState(managedPool, expinfrav1.NodegroupDegradedCondition, expinfrav1.NodegroupHealthIssuesReason,
+		fmt.Sprintf("EKS nodegroup %s is degraded: %s", s.scope.NodegroupName(), strings.Join(issues, "; ")))
+}
+
 func (s *NodegroupService) waitForNodegroupActive() (*eks.Nodegroup, error) {
 	eksClusterName := s.scope.KubernetesClusterName()
 	eksNodegroupName := s.scope.NodegroupName()
@@ -0,0 +1,301 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/go-logr/logr"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/eks/iam"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/eks/mock_eksiface"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
+	expclusterv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func newNodegroupServiceForTest(eksMock *mock_eksiface.MockEKSAPI, pool *expinfrav1.AWSManagedMachinePool) *NodegroupService {
+	log := logger.NewLogger(logr.Discard())
+	return &NodegroupService{
+		scope: &scope.ManagedMachinePoolScope{
+			Logger:             *log,
+			ControlPlane:       &ekscontrolplanev1.AWSManagedControlPlane{Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{EKSClusterName: "cluster"}},
+			ManagedMachinePool: pool,
+			MachinePool:        &expclusterv1.MachinePool{Spec: expclusterv1.MachinePoolSpec{Replicas: aws.Int32(1)}},
+		},
+		EKSClient:  eksMock,
+		IAMService: iam.IAMService{Wrapper: log},
+	}
+}
+
+func TestReconcileNodegroupConfig(t *testing.T) {
+	testCases := []struct {
+		name            string
+		specLabels      map[string]string
+		specTaints      expinfrav1.Taints
+		nodegroup       *eks.Nodegroup
+		expect          func(m *mock_eksiface.MockEKSAPIMockRecorder)
+		expectErr       error
+		expectUpdate    *expinfrav1.NodegroupUpdate
+		expectCondition bool
+	}{
+		{
+			name:       "no update when labels and taints match",
+			specLabels: map[string]string{"role": "worker"},
+			specTaints: expinfrav1.Taints{{Key: "dedicated", Value: "gpu", Effect: expinfrav1.TaintEffectNoSchedule}},
+			nodegroup: &eks.Nodegroup{
+				Labels: map[string]*string{"role": aws.String("worker")},
+				Taints: []*eks.Taint{{Key: aws.String("dedicated"), Value: aws.String("gpu"), Effect: aws.String(eks.TaintEffectNoSchedule)}},
+			},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {},
+		},
+		{
+			name:       "adds a label in place",
+			specLabels: map[string]string{"role": "worker", "team": "a"},
+			nodegroup: &eks.Nodegroup{
+				Labels: map[string]*string{"role": aws.String("worker")},
+			},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				m.UpdateNodegroupConfig(&eks.UpdateNodegroupConfigInput{
+					ClusterName:   aws.String("cluster"),
+					NodegroupName: aws.String("ng"),
+					Labels: &eks.UpdateLabelsPayload{
+						AddOrUpdateLabels: map[string]*string{"team": aws.String("a")},
+					},
+				}).Return(&eks.UpdateNodegroupConfigOutput{Update: &eks.Update{
+					Id:     aws.String("update-1"),
+					Type:   aws.String(eks.UpdateTypeConfigUpdate),
+					Status: aws.String(eks.UpdateStatusInProgress),
+				}}, nil)
+			},
+			expectUpdate:    &expinfrav1.NodegroupUpdate{ID: "update-1", Type: eks.UpdateTypeConfigUpdate, Status: eks.UpdateStatusInProgress},
+			expectCondition: true,
+		},
+		{
+			name:       "removes a label in place",
+			specLabels: map[string]string{"role": "worker"},
+			nodegroup: &eks.Nodegroup{
+				Labels: map[string]*string{"role": aws.String("worker"), "team": aws.String("a")},
+			},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				m.UpdateNodegroupConfig(&eks.UpdateNodegroupConfigInput{
+					ClusterName:   aws.String("cluster"),
+					NodegroupName: aws.String("ng"),
+					Labels: &eks.UpdateLabelsPayload{
+						AddOrUpdateLabels: map[string]*string{},
+						RemoveLabels:      []*string{aws.String("team")},
+					},
+				}).Return(&eks.UpdateNodegroupConfigOutput{Update: &eks.Update{
+					Id:     aws.String("update-2"),
+					Type:   aws.String(eks.UpdateTypeConfigUpdate),
+					Status: aws.String(eks.UpdateStatusInProgress),
+				}}, nil)
+			},
+			expectUpdate:    &expinfrav1.NodegroupUpdate{ID: "update-2", Type: eks.UpdateTypeConfigUpdate, Status: eks.UpdateStatusInProgress},
+			expectCondition: true,
+		},
+		{
+			name:       "replaces a taint whose effect changed",
+			specTaints: expinfrav1.Taints{{Key: "dedicated", Value: "gpu", Effect: expinfrav1.TaintEffectNoExecute}},
+			nodegroup: &eks.Nodegroup{
+				Taints: []*eks.Taint{{Key: aws.String("dedicated"), Value: aws.String("gpu"), Effect: aws.String(eks.TaintEffectNoSchedule)}},
+			},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				m.UpdateNodegroupConfig(&eks.UpdateNodegroupConfigInput{
+					ClusterName:   aws.String("cluster"),
+					NodegroupName: aws.String("ng"),
+					Taints: &eks.UpdateTaintsPayload{
+						AddOrUpdateTaints: []*eks.Taint{{Key: aws.String("dedicated"), Value: aws.String("gpu"), Effect: aws.String(eks.TaintEffectNoExecute)}},
+						RemoveTaints:      []*eks.Taint{{Key: aws.String("dedicated"), Value: aws.String("gpu"), Effect: aws.String(eks.TaintEffectNoSchedule)}},
+					},
+				}).Return(&eks.UpdateNodegroupConfigOutput{Update: &eks.Update{
+					Id:     aws.String("update-3"),
+					Type:   aws.String(eks.UpdateTypeConfigUpdate),
+					Status: aws.String(eks.UpdateStatusInProgress),
+				}}, nil)
+			},
+			expectUpdate:    &expinfrav1.NodegroupUpdate{ID: "update-3", Type: eks.UpdateTypeConfigUpdate, Status: eks.UpdateStatusInProgress},
+			expectCondition: true,
+		},
+		{
+			name:       "requeues when another update is in progress",
+			specLabels: map[string]string{"team": "a"},
+			nodegroup:  &eks.Nodegroup{},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				m.UpdateNodegroupConfig(gomock.AssignableToTypeOf(&eks.UpdateNodegroupConfigInput{})).
+					Return(nil, awserr.New(eks.ErrCodeResourceInUseException, "Nodegroup cannot be updated as it is currently being updated", nil))
+			},
+			expectErr: ErrNodegroupUpdateInProgress,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			eksMock := mock_eksiface.NewMockEKSAPI(mockCtrl)
+			tc.expect(eksMock.EXPECT())
+
+			pool := &expinfrav1.AWSManagedMachinePool{Spec: expinfrav1.AWSManagedMachinePoolSpec{
+				EKSNodegroupName: "ng",
+				Labels:           tc.specLabels,
+				Taints:           tc.specTaints,
+			}}
+			s := newNodegroupServiceForTest(eksMock, pool)
+
+			tc.nodegroup.NodegroupName = aws.String("ng")
+			tc.nodegroup.ScalingConfig = &eks.NodegroupScalingConfig{DesiredSize: aws.Int64(1)}
+			err := s.reconcileNodegroupConfig(tc.nodegroup)
+			if tc.expectErr != nil {
+				g.Expect(errors.Is(err, tc.expectErr)).To(BeTrue())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+			g.Expect(pool.Status.ActiveUpdate).To(Equal(tc.expectUpdate))
+			if tc.expectCondition {
+				condition := conditions.Get(pool, expinfrav1.NodegroupUpdateInProgressCondition)
+				g.Expect(condition).ToNot(BeNil())
+				g.Expect(condition.Status).To(Equal(corev1.ConditionTrue))
+				g.Expect(condition.Reason).To(Equal(expinfrav1.NodegroupUpdatingReason))
+			} else {
+				g.Expect(conditions.Has(pool, expinfrav1.NodegroupUpdateInProgressCondition)).To(BeFalse())
+			}
+		})
+	}
+}
+
+func TestReconcileActiveUpdate(t *testing.T) {
+	describeInput := &eks.DescribeUpdateInput{
+		Name:          aws.String("cluster"),
+		NodegroupName: aws.String("ng"),
+		UpdateId:      aws.String("update-1"),
+	}
+	inProgress := &expinfrav1.NodegroupUpdate{ID: "update-1", Type: eks.UpdateTypeConfigUpdate, Status: eks.UpdateStatusInProgress}
+
+	testCases := []struct {
+		name             string
+		activeUpdate     *expinfrav1.NodegroupUpdate
+		expect           func(m *mock_eksiface.MockEKSAPIMockRecorder)
+		expectInProgress bool
+		expectUpdate     *expinfrav1.NodegroupUpdate
+		expectReason     string
+		expectMessage    string
+	}{
+		{
+			name:   "nothing to poll without an active update",
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {},
+		},
+		{
+			name:         "failed updates are not polled again",
+			activeUpdate: &expinfrav1.NodegroupUpdate{ID: "update-1", Status: eks.UpdateStatusFailed},
+			expect:       func(m *mock_eksiface.MockEKSAPIMockRecorder) {},
+			expectUpdate: &expinfrav1.NodegroupUpdate{ID: "update-1", Status: eks.UpdateStatusFailed},
+		},
+		{
+			name:         "update still in progress",
+			activeUpdate: inProgress.DeepCopy(),
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				m.DescribeUpdate(describeInput).Return(&eks.DescribeUpdateOutput{Update: &eks.Update{
+					Id:     aws.String("update-1"),
+					Type:   aws.String(eks.UpdateTypeConfigUpdate),
+					Status: aws.String(eks.UpdateStatusInProgress),
+				}}, nil)
+			},
+			expectInProgress: true,
+			expectUpdate:     inProgress,
+			expectReason:     expinfrav1.NodegroupUpdatingReason,
+			expectMessage:    "Update update-1 of EKS nodegroup ng is in progress",
+		},
+		{
+			name:         "successful update is forgotten",
+			activeUpdate: inProgress.DeepCopy(),
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				m.DescribeUpdate(describeInput).Return(&eks.DescribeUpdateOutput{Update: &eks.Update{
+					Id:     aws.String("update-1"),
+					Type:   aws.String(eks.UpdateTypeConfigUpdate),
+					Status: aws.String(eks.UpdateStatusSuccessful),
+				}}, nil)
+			},
+		},
+		{
+			name:         "failed update reports the EKS errors",
+			activeUpdate: inProgress.DeepCopy(),
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				m.DescribeUpdate(describeInput).Return(&eks.DescribeUpdateOutput{Update: &eks.Update{
+					Id:     aws.String("update-1"),
+					Type:   aws.String(eks.UpdateTypeConfigUpdate),
+					Status: aws.String(eks.UpdateStatusFailed),
+					Errors: []*eks.ErrorDetail{{
+						ErrorCode:    aws.String(eks.ErrorCodeAccessDenied),
+						ErrorMessage: aws.String("The node role is not authorized to perform the update."),
+					}},
+				}}, nil)
+			},
+			expectUpdate: &expinfrav1.NodegroupUpdate{
+				ID:     "update-1",
+				Type:   eks.UpdateTypeConfigUpdate,
+				Status: eks.UpdateStatusFailed,
+				Errors: []string{"AccessDenied: The node role is not authorized to perform the update."},
+			},
+			expectReason:  expinfrav1.NodegroupUpdateFailedReason,
+			expectMessage: "Update update-1 of EKS nodegroup ng failed: AccessDenied: The node role is not authorized to perform the update.",
+		},
+		{
+			name:         "update unknown to EKS is forgotten",
+			activeUpdate: inProgress.DeepCopy(),
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				m.DescribeUpdate(describeInput).Return(nil, awserr.New(eks.ErrCodeResourceNotFoundException, "update not found", nil))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			eksMock := mock_eksiface.NewMockEKSAPI(mockCtrl)
+			tc.expect(eksMock.EXPECT())
+
+			pool := &expinfrav1.AWSManagedMachinePool{Spec: expinfrav1.AWSManagedMachinePoolSpec{EKSNodegroupName: "ng"}}
+			pool.Status.ActiveUpdate = tc.activeUpdate
+			s := newNodegroupServiceForTest(eksMock, pool)
+
+			inProgress, err := s.reconcileActiveUpdate()
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(inProgress).To(Equal(tc.expectInProgress))
+			g.Expect(pool.Status.ActiveUpdate).To(Equal(tc.expectUpdate))
+			if tc.expectReason == "" {
+				g.Expect(conditions.Has(pool, expinfrav1.NodegroupUpdateInProgressCondition)).To(BeFalse())
+				return
+			}
+			condition := conditions.Get(pool, expinfrav1.NodegroupUpdateInProgressCondition)
+			g.Expect(condition).ToNot(BeNil())
+			g.Expect(condition.Reason).To(Equal(tc.expectReason))
+			g.Expect(condition.Message).To(Equal(tc.expectMessage))
+		})
+	}
+}
@@ -84,6 +84,9 @@ func (s *Service) reconcileControlPlaneIAMRole() error {
 	}
 	s.scope.Info("using eks control plane role", "role-name", *s.scope.ControlPlane.Spec.RoleName)
 
+	management := s.scope.ControlPlane.Spec.RoleManagement
+	trustRelationship := eksiam.WithRoleManagement(eksiam.ControlPlaneTrustRelationship(false), management)
+
 	role, err := s.GetIAMRole(*s.scope.ControlPlane.Spec.RoleName)
 	if err != nil {
 		if !isNotFound(err) {
@@ -95,7 +98,7 @@ func (s *Service) reconcileControlPlaneIAMRole() error {
 			return fmt.Errorf("getting role %s: %w", *s.scope.ControlPlane.Spec.RoleName, ErrClusterRoleNotFound)
 		}
 
-		role, err = s.CreateRole(*s.scope.ControlPlane.Spec.RoleName, s.scope.Name(), eksiam.ControlPlaneTrustRelationship(false), s.scope.AdditionalTags())
+		role, err = s.CreateRole(*s.scope.ControlPlane.Spec.RoleName, s.scope.Name(), trustRelationship, s.scope.AdditionalTags(), management)
 		if err != nil {
 			record.Warnf(s.scope.ControlPlane, "FailedIAMRoleCreation", "Failed to create control plane IAM role %q: %v", *s.scope.ControlPlane.Spec.RoleName, err)
 
@@ -109,7 +112,16 @@ func (s *Service) reconcileControlPlaneIAMRole() error {
 		return nil
 	}
 
-	//TODO: check tags and trust relationship to see if they need updating
+	//TODO: check tags to see if they need updating
+
+	// The trust relationship and permissions boundary of the role follow the role management options, so that
+	// removing the options reverts them to the defaults.
+	if _, err := s.EnsureTrustPolicy(role, trustRelationship); err != nil {
+		return errors.Wrap(err, "error ensuring trust policy of control plane role")
+	}
+	if _, err := s.EnsurePermissionsBoundary(role, management.GetPermissionsBoundary()); err != nil {
+		return err
+	}
 
 	policies := []*string{
 		aws.String(fmt.Sprintf("arn:%s:iam::aws:policy/AmazonEKSClusterPolicy", s.scope.Partition())),
@@ -193,6 +205,9 @@ func (s *NodegroupService) reconcileNodegroupIAMRole() error {
 		s.scope.ManagedMachinePool.Spec.RoleName = roleName
 	}
 
+	management := s.scope.ManagedMachinePool.Spec.RoleManagement
+	trustRelationship := eksiam.WithRoleManagement(eksiam.NodegroupTrustRelationship(), management)
+
 	role, err := s.GetIAMRole(s.scope.RoleName())
 	if err != nil {
 		if !isNotFound(err) {
@@ -204,7 +219,7 @@ func (s *NodegroupService) reconcileNodegroupIAMRole() error {
 			return ErrNodegroupRoleNotFound
 		}
 
-		role, err = s.CreateRole(s.scope.ManagedMachinePool.Spec.RoleName, s.scope.ClusterName(), eksiam.NodegroupTrustRelationship(), s.scope.AdditionalTags())
+		role, err = s.CreateRole(s.scope.ManagedMachinePool.Spec.RoleName, s.scope.ClusterName(), trustRelationship, s.scope.AdditionalTags(), management)
 		if err != nil {
 			record.Warnf(s.scope.ManagedMachinePool, "FailedIAMRoleCreation", "Failed to create nodegroup IAM role %q: %v", s.scope.RoleName(), err)
 			return err
@@ -217,11 +232,15 @@ func (s *NodegroupService) reconcileNodegroupIAMRole() error {
 		return nil
 	}
 
-	_, err = s.EnsureTagsAndPolicy(role, s.scope.ClusterName(), eksiam.NodegroupTrustRelationship(), s.scope.AdditionalTags())
+	_, err = s.EnsureTagsAndPolicy(role, s.scope.ClusterName(), trustRelationship, s.scope.AdditionalTags())
 	if err != nil {
 		return errors.Wrapf(err, "error ensuring tags and policy document are set on node role")
 	}
 
+	if _, err := s.EnsurePermissionsBoundary(role, management.GetPermissionsBoundary()); err != nil {
+		return err
+	}
+
 	policies := NodegroupRolePolicies()
 	if strings.Contains(s.scope.Partition(), v1beta1.PartitionNameUSGov) {
 		policies = NodegroupRolePoliciesUSGov()
@@ -329,7 +348,8 @@ func (s *FargateService) reconcileFargateIAMRole() (requeue bool, err error) {
 		}
 
 		createdRole = true
-		role, err = s.CreateRole(s.scope.RoleName(), s.scope.ClusterName(), eksiam.FargateTrustRelationship(), s.scope.AdditionalTags())
+		management := s.scope.FargateProfile.Spec.RoleManagement
+		role, err = s.CreateRole(s.scope.RoleName(), s.scope.ClusterName(), eksiam.WithRoleManagement(eksiam.FargateTrustRelationship(), management), s.scope.AdditionalTags(), management)
 		if err != nil {
 			record.Warnf(s.scope.FargateProfile, "FailedIAMRoleCreation", "Failed to create fargate IAM role %q: %v", s.scope.RoleName(), err)
 			return false, errors.Wrap(err, "failed to create role")
@@ -337,11 +357,25 @@ func (s *FargateService) reconcileFargateIAMRole() (requeue bool, err error) {
 		record.Eventf(s.scope.FargateProfile, "SuccessfulIAMRoleCreation", "Created fargate IAM role %q", s.scope.RoleName())
 	}
 
-	updatedRole, err := s.EnsureTagsAndPolicy(role, s.scope.ClusterName(), eksiam.FargateTrustRelationship(), s.scope.AdditionalTags())
+	management := s.scope.FargateProfile.Spec.RoleManagement
+	if s.IsUnmanaged(role, s.scope.ClusterName()) {
+		// The role management options only apply to the roles created by CAPA.
+		management = nil
+	}
+
+	updatedRole, err := s.EnsureTagsAndPolicy(role, s.scope.ClusterName(), eksiam.WithRoleManagement(eksiam.FargateTrustRelationship(), management), s.scope.AdditionalTags())
 	if err != nil {
 		return updatedRole, errors.Wrapf(err, "error ensuring tags and policy document are set on fargate role")
 	}
 
+	if !s.IsUnmanaged(role, s.scope.ClusterName()) {
+		updatedBoundary, err := s.EnsurePermissionsBoundary(role, management.GetPermissionsBoundary())
+		if err != nil {
+			return updatedRole || updatedBoundary, err
+		}
+		updatedRole = updatedRole || updatedBoundary
+	}
+
 	policies := FargateRolePolicies()
 	if strings.Contains(s.scope.Partition(), v1beta1.PartitionNameUSGov) {
 		policies = FargateRolePoliciesUSGov()
@@ -18,47 +18,233 @@ package eks
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"sort"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/eks"
 	"github.com/aws/aws-sdk-go/service/eks/eksiface"
+	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/ptr"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/converters"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/tags"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
 )
 
 const (
 	eksClusterNameTag              = "eks:cluster-name"
 	eksNodeGroupNameTag            = "eks:nodegroup-name"
 	eksClusterAutoscalerEnabledTag = "k8s.io/cluster-autoscaler/enabled"
+
+	// TagsLastAppliedAnnotation is the key for the AWSManagedControlPlane object annotation
+	// which tracks the AdditionalTags applied to the EKS cluster and the resources EKS created for it,
+	// so that tags removed from the spec are also removed from these resources.
+	TagsLastAppliedAnnotation = "sigs.k8s.io/cluster-api-provider-aws-last-applied-tags"
 )
 
+// reconcileTags reconciles the tags of the EKS cluster, and propagates the additional tags to the cluster
+// security group created by EKS and to the OIDC provider. Additional tags removed from the spec since the
+// last reconciliation, as tracked by TagsLastAppliedAnnotation, are removed from these resources, while
+// tags set by EKS or other tooling are left untouched.
 func (s *Service) reconcileTags(cluster *eks.Cluster) error {
+	additionalTags := s.validAdditionalTags()
+
+	lastApplied, err := s.lastAppliedTags()
+	if err != nil {
+		return err
+	}
+	removedKeys := []string{}
+	for key := range lastApplied {
+		if _, ok := additionalTags[key]; !ok {
+			removedKeys = append(removedKeys, key)
+		}
+	}
+	sort.Strings(removedKeys)
+
 	clusterTags := converters.MapPtrToMap(cluster.Tags)
 	buildParams := s.getEKSTagParams(*cluster.Arn)
+	buildParams.Additional = additionalTags
 	tagsBuilder := tags.New(buildParams, tags.WithEKS(s.EKSClient))
 	if err := tagsBuilder.Ensure(clusterTags); err != nil {
 		return fmt.Errorf("failed ensuring tags on cluster: %w", err)
 	}
+	if untagKeys := presentTagKeys(clusterTags, removedKeys); len(untagKeys) > 0 {
+		if _, err := s.EKSClient.UntagResource(&eks.UntagResourceInput{
+			ResourceArn: cluster.Arn,
+			TagKeys:     aws.StringSlice(untagKeys),
+		}); err != nil {
+			return fmt.Errorf("failed removing tags from cluster: %w", err)
+		}
+	}
+
+	if err := s.reconcileClusterSecurityGroupTags(additionalTags, removedKeys); err != nil {
+		return err
+	}
+
+	if err := s.reconcileOIDCProviderTags(additionalTags, removedKeys); err != nil {
+		return err
+	}
+
+	return s.setLastAppliedTags(additionalTags)
+}
+
+// reconcileClusterSecurityGroupTags propagates the additional tags to the cluster security group created by EKS.
+// The tags CAPA uses to identify the resources it owns are not propagated, as EKS deletes this security group.
+func (s *Service) reconcileClusterSecurityGroupTags(additionalTags infrav1.Tags, removedKeys []string) error {
+	sg, ok := s.scope.ControlPlane.Status.Network.SecurityGroups[ekscontrolplanev1.SecurityGroupCluster]
+	if !ok || sg.ID == "" {
+		return nil
+	}
+
+	newTags := additionalTags.Difference(sg.Tags)
+	if len(newTags) > 0 {
+		input := &ec2.CreateTagsInput{
+			Resources: aws.StringSlice([]string{sg.ID}),
+		}
+		for _, key := range sortedTagKeys(newTags) {
+			input.Tags = append(input.Tags, &ec2.Tag{Key: aws.String(key), Value: aws.String(newTags[key])})
+		}
+		if _, err := s.EC2Client.CreateTagsWithContext(context.TODO(), input); err != nil {
+			return fmt.Errorf("failed tagging cluster security group %q: %w", sg.ID, err)
+		}
+	}
+
+	if untagKeys := presentTagKeys(sg.Tags, removedKeys); len(untagKeys) > 0 {
+		input := &ec2.DeleteTagsInput{
+			Resources: aws.StringSlice([]string{sg.ID}),
+		}
+		for _, key := range untagKeys {
+			input.Tags = append(input.Tags, &ec2.Tag{Key: aws.String(key)})
+		}
+		if _, err := s.EC2Client.DeleteTagsWithContext(context.TODO(), input); err != nil {
+			return fmt.Errorf("failed removing tags from cluster security group %q: %w", sg.ID, err)
+		}
+	}
 
 	return nil
 }
 
+// reconcileOIDCProviderTags propagates the additional tags to the OIDC provider of the cluster, once it exists.
+func (s *Service) reconcileOIDCProviderTags(additionalTags infrav1.Tags, removedKeys []string) error {
+	providerARN := s.scope.ControlPlane.Status.OIDCProvider.ARN
+	if providerARN == "" {
+		return nil
+	}
+
+	out, err := s.IAMClient.ListOpenIDConnectProviderTags(&iam.ListOpenIDConnectProviderTagsInput{
+		OpenIDConnectProviderArn: aws.String(providerARN),
+	})
+	if err != nil {
+		return fmt.Errorf("failed listing tags of OIDC provider %q: %w", providerARN, err)
+	}
+	currentTags := infrav1.Tags{}
+	for _, tag := range out.Tags {
+		currentTags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+	}
+
+	if newTags := additionalTags.Difference(currentTags); len(newTags) > 0 {
+		input := &iam.TagOpenIDConnectProviderInput{
+			OpenIDConnectProviderArn: aws.String(providerARN),
+		}
+		for _, key := range sortedTagKeys(newTags) {
+			input.Tags = append(input.Tags, &iam.Tag{Key: aws.String(key), Value: aws.String(newTags[key])})
+		}
+		if _, err := s.IAMClient.TagOpenIDConnectProvider(input); err != nil {
+			return fmt.Errorf("failed tagging OIDC provider %q: %w", providerARN, err)
+		}
+	}
+
+	if untagKeys := presentTagKeys(currentTags, removedKeys); len(untagKeys) > 0 {
+		if _, err := s.IAMClient.UntagOpenIDConnectProvider(&iam.UntagOpenIDConnectProviderInput{
+			OpenIDConnectProviderArn: aws.String(providerARN),
+			TagKeys:                  aws.StringSlice(untagKeys),
+		}); err != nil {
+			return fmt.Errorf("failed removing tags from OIDC provider %q: %w", providerARN, err)
+		}
+	}
+
+	return nil
+}
+
+// validAdditionalTags returns the additional tags which EKS accepts. Other tags, which may have been created
+// before the webhook validated them, are skipped with a warning instead of failing the whole update.
+func (s *Service) validAdditionalTags() infrav1.Tags {
+	valid := infrav1.Tags{}
+	for key, value := range s.scope.AdditionalTags() {
+		if errs := (infrav1.Tags{key: value}).Validate(); len(errs) > 0 {
+			record.Warnf(s.scope.ControlPlane, "InvalidTag", "Skipping tag %q which can't be applied to the EKS cluster: %v", key, field.ErrorList(errs).ToAggregate())
+			continue
+		}
+		valid[key] = value
+	}
+	return valid
+}
+
+func (s *Service) lastAppliedTags() (infrav1.Tags, error) {
+	lastApplied := infrav1.Tags{}
+	annotation, ok := s.scope.ControlPlane.GetAnnotations()[TagsLastAppliedAnnotation]
+	if !ok || annotation == "" {
+		return lastApplied, nil
+	}
+	if err := json.Unmarshal([]byte(annotation), &lastApplied); err != nil {
+		return nil, fmt.Errorf("failed to parse annotation %q: %w", TagsLastAppliedAnnotation, err)
+	}
+	return lastApplied, nil
+}
+
+func (s *Service) setLastAppliedTags(applied infrav1.Tags) error {
+	annotation, err := json.Marshal(applied)
+	if err != nil {
+		return err
+	}
+	annotations := s.scope.ControlPlane.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[TagsLastAppliedAnnotation] = string(annotation)
+	s.scope.ControlPlane.SetAnnotations(annotations)
+	return nil
+}
+
+// presentTagKeys returns the keys which are set in the current tags.
+func presentTagKeys(current map[string]string, keys []string) []string {
+	present := []string{}
+	for _, key := range keys {
+		if _, ok := current[key]; ok {
+			present = append(present, key)
+		}
+	}
+	return present
+}
+
+func sortedTagKeys(tags infrav1.Tags) []string {
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 func (s *Service) getEKSTagParams(id string) *infrav1.BuildParams {
 	name := s.scope.KubernetesClusterName()
 
 	return &infrav1.BuildParams{
-		ClusterName: name,
-		ResourceID:  id,
-		Lifecycle:   infrav1.ResourceLifecycleOwned,
-		Name:        aws.String(name),
-		Role:        aws.String(infrav1.CommonRoleTagValue),
-		Additional:  s.scope.AdditionalTags(),
+		ClusterName:        name,
+		OwnershipTagPrefix: s.scope.OwnershipTagPrefix(),
+		ResourceID:         id,
+		Lifecycle:          infrav1.ResourceLifecycleOwned,
+		Name:               aws.String(name),
+		Role:               aws.String(infrav1.CommonRoleTagValue),
+		Additional:         s.scope.AdditionalTags(),
 	}
 }
 
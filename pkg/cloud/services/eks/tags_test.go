@@ -17,10 +17,28 @@ limitations under the License.
 package eks
 
 import (
+	"context"
+	"encoding/json"
 	"strconv"
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/eks/mock_eksiface"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/iamauth/mock_iamauth"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
 func TestGetTagUpdates(t *testing.T) {
@@ -52,3 +70,186 @@ func TestGetTagUpdates(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcileTags(t *testing.T) {
+	clusterARN := "arn:aws:eks:us-east-1:123456789012:cluster/test-cluster"
+	oidcARN := "arn:aws:iam::123456789012:oidc-provider/oidc.eks.us-east-1.amazonaws.com/id/TEST"
+
+	tests := []struct {
+		name           string
+		additionalTags infrav1.Tags
+		lastApplied    infrav1.Tags
+		// clusterTagged is whether the cluster already has the tags built from the spec.
+		clusterTagged    bool
+		extraClusterTags map[string]string
+		securityGroup    *infrav1.SecurityGroup
+		oidcProviderARN  string
+		expectEKS        func(m *mock_eksiface.MockEKSAPIMockRecorder)
+		expectEC2        func(m *mocks.MockEC2APIMockRecorder)
+		expectIAM        func(m *mock_iamauth.MockIAMAPIMockRecorder)
+		wantLastApplied  infrav1.Tags
+	}{
+		{
+			name:            "adds new tags to the cluster, its security group and the OIDC provider",
+			additionalTags:  infrav1.Tags{"team": "payments"},
+			securityGroup:   &infrav1.SecurityGroup{ID: "sg-cluster", Tags: infrav1.Tags{"aws:eks:cluster-name": "test-cluster"}},
+			oidcProviderARN: oidcARN,
+			expectEKS: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				m.TagResource(gomock.AssignableToTypeOf(&eks.TagResourceInput{})).
+					DoAndReturn(func(input *eks.TagResourceInput) (*eks.TagResourceOutput, error) {
+						g := NewWithT(t)
+						g.Expect(aws.StringValue(input.ResourceArn)).To(Equal(clusterARN))
+						g.Expect(input.Tags).To(HaveKeyWithValue("team", aws.String("payments")))
+						return &eks.TagResourceOutput{}, nil
+					})
+			},
+			expectEC2: func(m *mocks.MockEC2APIMockRecorder) {
+				m.CreateTagsWithContext(context.TODO(), gomock.Eq(&ec2.CreateTagsInput{
+					Resources: aws.StringSlice([]string{"sg-cluster"}),
+					Tags:      []*ec2.Tag{{Key: aws.String("team"), Value: aws.String("payments")}},
+				})).Return(&ec2.CreateTagsOutput{}, nil)
+			},
+			expectIAM: func(m *mock_iamauth.MockIAMAPIMockRecorder) {
+				m.ListOpenIDConnectProviderTags(gomock.Eq(&iam.ListOpenIDConnectProviderTagsInput{
+					OpenIDConnectProviderArn: aws.String(oidcARN),
+				})).Return(&iam.ListOpenIDConnectProviderTagsOutput{}, nil)
+				m.TagOpenIDConnectProvider(gomock.Eq(&iam.TagOpenIDConnectProviderInput{
+					OpenIDConnectProviderArn: aws.String(oidcARN),
+					Tags:                     []*iam.Tag{{Key: aws.String("team"), Value: aws.String("payments")}},
+				})).Return(&iam.TagOpenIDConnectProviderOutput{}, nil)
+			},
+			wantLastApplied: infrav1.Tags{"team": "payments"},
+		},
+		{
+			name:             "removes tags deleted from the spec and keeps external tags",
+			additionalTags:   infrav1.Tags{"env": "prod"},
+			lastApplied:      infrav1.Tags{"env": "prod", "team": "payments"},
+			clusterTagged:    true,
+			extraClusterTags: map[string]string{"team": "payments", "external": "value"},
+			securityGroup: &infrav1.SecurityGroup{ID: "sg-cluster", Tags: infrav1.Tags{
+				"aws:eks:cluster-name": "test-cluster",
+				"env":                  "prod",
+				"team":                 "payments",
+			}},
+			oidcProviderARN: oidcARN,
+			expectEKS: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				m.UntagResource(gomock.Eq(&eks.UntagResourceInput{
+					ResourceArn: aws.String(clusterARN),
+					TagKeys:     aws.StringSlice([]string{"team"}),
+				})).Return(&eks.UntagResourceOutput{}, nil)
+			},
+			expectEC2: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DeleteTagsWithContext(context.TODO(), gomock.Eq(&ec2.DeleteTagsInput{
+					Resources: aws.StringSlice([]string{"sg-cluster"}),
+					Tags:      []*ec2.Tag{{Key: aws.String("team")}},
+				})).Return(&ec2.DeleteTagsOutput{}, nil)
+			},
+			expectIAM: func(m *mock_iamauth.MockIAMAPIMockRecorder) {
+				m.ListOpenIDConnectProviderTags(gomock.Any()).Return(&iam.ListOpenIDConnectProviderTagsOutput{
+					Tags: []*iam.Tag{
+						{Key: aws.String("env"), Value: aws.String("prod")},
+						{Key: aws.String("team"), Value: aws.String("payments")},
+					},
+				}, nil)
+				m.UntagOpenIDConnectProvider(gomock.Eq(&iam.UntagOpenIDConnectProviderInput{
+					OpenIDConnectProviderArn: aws.String(oidcARN),
+					TagKeys:                  aws.StringSlice([]string{"team"}),
+				})).Return(&iam.UntagOpenIDConnectProviderOutput{}, nil)
+			},
+			wantLastApplied: infrav1.Tags{"env": "prod"},
+		},
+		{
+			name:            "skips tags EKS rejects",
+			additionalTags:  infrav1.Tags{"team": "payments", "cost-center": "#42", "aws:reserved": "value"},
+			lastApplied:     infrav1.Tags{"team": "payments"},
+			clusterTagged:   true,
+			wantLastApplied: infrav1.Tags{"team": "payments"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockControl := gomock.NewController(t)
+			defer mockControl.Finish()
+
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			_ = ekscontrolplanev1.AddToScheme(scheme)
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+			controlPlane := &ekscontrolplanev1.AWSManagedControlPlane{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "ns",
+					Name:      "test-cluster-control-plane",
+				},
+				Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{
+					EKSClusterName: "test-cluster",
+					AdditionalTags: tc.additionalTags,
+				},
+			}
+			if tc.lastApplied != nil {
+				annotation, err := json.Marshal(tc.lastApplied)
+				g.Expect(err).NotTo(HaveOccurred())
+				controlPlane.Annotations = map[string]string{TagsLastAppliedAnnotation: string(annotation)}
+			}
+			if tc.securityGroup != nil {
+				controlPlane.Status.Network.SecurityGroups = map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+					ekscontrolplanev1.SecurityGroupCluster: *tc.securityGroup,
+				}
+			}
+			controlPlane.Status.OIDCProvider.ARN = tc.oidcProviderARN
+
+			scope, err := scope.NewManagedControlPlaneScope(scope.ManagedControlPlaneScopeParams{
+				Client: client,
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns",
+						Name:      "test-cluster",
+					},
+				},
+				ControlPlane: controlPlane,
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			eksMock := mock_eksiface.NewMockEKSAPI(mockControl)
+			ec2Mock := mocks.NewMockEC2API(mockControl)
+			iamMock := mock_iamauth.NewMockIAMAPI(mockControl)
+			if tc.expectEKS != nil {
+				tc.expectEKS(eksMock.EXPECT())
+			}
+			if tc.expectEC2 != nil {
+				tc.expectEC2(ec2Mock.EXPECT())
+			}
+			if tc.expectIAM != nil {
+				tc.expectIAM(iamMock.EXPECT())
+			}
+
+			s := NewService(scope)
+			s.EKSClient = eksMock
+			s.EC2Client = ec2Mock
+			s.IAMClient = iamMock
+
+			clusterTags := map[string]string{}
+			if tc.clusterTagged {
+				params := s.getEKSTagParams(clusterARN)
+				params.Additional = s.validAdditionalTags()
+				clusterTags = infrav1.Build(*params)
+			}
+			for key, value := range tc.extraClusterTags {
+				clusterTags[key] = value
+			}
+			cluster := &eks.Cluster{
+				Arn:  aws.String(clusterARN),
+				Tags: aws.StringMap(clusterTags),
+			}
+
+			g.Expect(s.reconcileTags(cluster)).To(Succeed())
+
+			lastApplied, err := s.lastAppliedTags()
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(lastApplied).To(Equal(tc.wantLastApplied))
+		})
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/elb"
 	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
 
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
 )
@@ -65,6 +66,24 @@ func NewInstanceNotRunning(msg string) error {
 	}
 }
 
+// NewInstanceNotHealthy returns an error which indicates that the instance is registered with the load balancer,
+// but its target isn't healthy yet.
+func NewInstanceNotHealthy(msg string) error {
+	return &ELBError{
+		msg:  msg,
+		Code: http.StatusServiceUnavailable,
+	}
+}
+
+// NewLoadBalancerSubnetInvalid returns an error which indicates that the subnets selected for a load balancer
+// can't be attached to it.
+func NewLoadBalancerSubnetInvalid(msg string) error {
+	return &ELBError{
+		msg:  msg,
+		Code: http.StatusUnprocessableEntity,
+	}
+}
+
 // IsNotFound returns true if the error was created by NewNotFound.
 func IsNotFound(err error) bool {
 	if ReasonForError(err) == http.StatusNotFound {
@@ -104,6 +123,25 @@ func IsInstanceNotRunning(err error) (ok bool) {
 	return ReasonForError(err) == http.StatusTooEarly
 }
 
+// IsInstanceNotHealthy returns true if the error was created by NewInstanceNotHealthy.
+func IsInstanceNotHealthy(err error) bool {
+	return ReasonForError(err) == http.StatusServiceUnavailable
+}
+
+// IsLoadBalancerSubnetInvalid returns true if the error, or one of the errors it aggregates, was created by
+// NewLoadBalancerSubnetInvalid.
+func IsLoadBalancerSubnetInvalid(err error) bool {
+	if agg, ok := err.(kerrors.Aggregate); ok {
+		for _, e := range agg.Errors() {
+			if IsLoadBalancerSubnetInvalid(e) {
+				return true
+			}
+		}
+		return false
+	}
+	return ReasonForError(err) == http.StatusUnprocessableEntity
+}
+
 // ReasonForError returns the HTTP status for a particular error.
 func ReasonForError(err error) int {
 	if t, ok := errors.Cause(err).(*ELBError); ok {
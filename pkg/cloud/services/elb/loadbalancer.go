@@ -103,6 +103,9 @@ func (s *Service) reconcileV2LB(lbSpec *infrav1.AWSLoadBalancerSpec) error {
 	if err != nil {
 		return err
 	}
+	if err := s.validateLBSubnets(desiredLB); err != nil {
+		return err
+	}
 	lb, err := s.describeLB(name, lbSpec)
 	switch {
 	case IsNotFound(err) && s.scope.ControlPlaneEndpoint().IsValid():
@@ -134,7 +137,7 @@ func (s *Service) reconcileV2LB(lbSpec *infrav1.AWSLoadBalancerSpec) error {
 
 	// set up the type for later processing
 	lb.LoadBalancerType = lbSpec.LoadBalancerType
-	if lb.IsManaged(s.scope.Name()) {
+	if lb.IsManaged(s.scope.Name(), s.scope.OwnershipTagPrefixes()...) {
 		// Reconcile the target groups and listeners from the spec and the ones currently attached to the load balancer.
 		// Pass in the ARN that AWS gave us, as well as the rest of the desired specification.
 		_, _, err := s.reconcileTargetGroupsAndListeners(lb.ARN, desiredLB, lbSpec)
@@ -339,11 +342,12 @@ func (s *Service) getAPIServerLBSpec(elbName string, lbSpec *infrav1.AWSLoadBala
 	}
 
 	res.Tags = infrav1.Build(infrav1.BuildParams{
-		ClusterName: s.scope.Name(),
-		Lifecycle:   infrav1.ResourceLifecycleOwned,
-		Name:        aws.String(elbName),
-		Role:        aws.String(infrav1.APIServerRoleTagValue),
-		Additional:  s.scope.AdditionalTags(),
+		ClusterName:        s.scope.Name(),
+		OwnershipTagPrefix: s.scope.OwnershipTagPrefix(),
+		Lifecycle:          infrav1.ResourceLifecycleOwned,
+		Name:               aws.String(s.loadBalancerTagName(elbName)),
+		Role:               aws.String(infrav1.APIServerRoleTagValue),
+		Additional:         s.scope.AdditionalTags(),
 	})
 
 	// If subnet IDs have been specified for this load balancer
@@ -386,6 +390,24 @@ func (s *Service) getAPIServerLBSpec(elbName string, lbSpec *infrav1.AWSLoadBala
 	return res, nil
 }
 
+// validateLBSubnets checks that the subnets of the desired load balancer have at most one subnet per
+// availability zone and cover the availability zones of the control plane machines. The subnets may only
+// be known once discovered, so this repeats the validation done by the AWSCluster webhook.
+func (s *Service) validateLBSubnets(lb *infrav1.LoadBalancer) error {
+	subnets := make(infrav1.Subnets, 0, len(lb.SubnetIDs))
+	for i, id := range lb.SubnetIDs {
+		if i >= len(lb.AvailabilityZones) {
+			break
+		}
+		subnets = append(subnets, infrav1.SubnetSpec{ID: id, AvailabilityZone: lb.AvailabilityZones[i]})
+	}
+
+	if problems := subnets.ValidateLoadBalancerZones(s.scope.ControlPlaneZones()); len(problems) > 0 {
+		return NewLoadBalancerSubnetInvalid(fmt.Sprintf("invalid subnets for load balancer %s: %s", lb.Name, strings.Join(problems, "; ")))
+	}
+	return nil
+}
+
 func (s *Service) createLB(spec *infrav1.LoadBalancer, lbSpec *infrav1.AWSLoadBalancerSpec) (*infrav1.LoadBalancer, error) {
 	var t *string
 	switch lbSpec.LoadBalancerType {
@@ -520,6 +542,9 @@ func (s *Service) reconcileClassicLoadBalancer() error {
 	if err != nil {
 		return err
 	}
+	if err := s.validateLBSubnets(spec); err != nil {
+		return err
+	}
 
 	apiELB, err := s.describeClassicELB(spec.Name)
 	switch {
@@ -537,7 +562,7 @@ func (s *Service) reconcileClassicLoadBalancer() error {
 		return err
 	}
 
-	if apiELB.IsManaged(s.scope.Name()) {
+	if apiELB.IsManaged(s.scope.Name(), s.scope.OwnershipTagPrefixes()...) {
 		if !cmp.Equal(spec.ClassicElbAttributes, apiELB.ClassicElbAttributes) {
 			err := s.configureAttributes(apiELB.Name, spec.ClassicElbAttributes)
 			if err != nil {
@@ -588,6 +613,11 @@ func (s *Service) reconcileClassicLoadBalancer() error {
 }
 
 func (s *Service) deleteAPIServerELB() error {
+	if lb := s.scope.ControlPlaneLoadBalancer(); lb != nil && lb.LoadBalancerType == infrav1.LoadBalancerTypeDisabled {
+		s.scope.Debug("Control plane load balancer is managed outside of CAPA, skipping deletion")
+		return nil
+	}
+
 	s.scope.Debug("Deleting control plane load balancer")
 
 	elbName, err := ELBName(s.scope)
@@ -610,7 +640,7 @@ func (s *Service) deleteAPIServerELB() error {
 		return err
 	}
 
-	if apiELB.IsUnmanaged(s.scope.Name()) {
+	if apiELB.IsUnmanaged(s.scope.Name(), s.scope.OwnershipTagPrefixes()...) {
 		s.scope.Debug("Found unmanaged classic load balancer for apiserver, skipping deletion", "api-server-elb-name", apiELB.Name)
 		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.LoadBalancerReadyCondition, clusterv1.DeletedReason, clusterv1.ConditionSeverityInfo, "")
 		return nil
@@ -691,7 +721,7 @@ func (s *Service) deleteExistingNLBs() error {
 	errs := make([]error, 0)
 
 	for _, lbSpec := range s.scope.ControlPlaneLoadBalancers() {
-		if lbSpec == nil {
+		if lbSpec == nil || lbSpec.LoadBalancerType == infrav1.LoadBalancerTypeDisabled {
 			continue
 		}
 		errs = append(errs, s.deleteExistingNLB(lbSpec))
@@ -718,7 +748,7 @@ func (s *Service) deleteExistingNLB(lbSpec *infrav1.AWSLoadBalancerSpec) error {
 		return err
 	}
 
-	if lb.IsUnmanaged(s.scope.Name()) {
+	if lb.IsUnmanaged(s.scope.Name(), s.scope.OwnershipTagPrefixes()...) {
 		s.scope.Debug("Found unmanaged load balancer for apiserver, skipping deletion", "api-server-elb-name", lb.Name)
 		return nil
 	}
@@ -820,6 +850,65 @@ func (s *Service) IsInstanceRegisteredWithAPIServerLB(i *infrav1.Instance, lb *i
 	return nil, false, nil
 }
 
+// GetAPIServerLBTargetHealth returns the health state of the instance in the API server target group of a v2 load balancer.
+// An empty state is returned when the instance isn't registered with the target group.
+func (s *Service) GetAPIServerLBTargetHealth(i *infrav1.Instance, lb *infrav1.AWSLoadBalancerSpec) (string, error) {
+	name, err := LBName(s.scope, lb)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get control plane load balancer name")
+	}
+
+	input := &elbv2.DescribeLoadBalancersInput{
+		Names: []*string{aws.String(name)},
+	}
+
+	output, err := s.ELBV2Client.DescribeLoadBalancers(input)
+	if err != nil {
+		return "", errors.Wrapf(err, "error describing ELB %q", name)
+	}
+	if len(output.LoadBalancers) != 1 {
+		return "", errors.Errorf("expected 1 ELB description for %q, got %d", name, len(output.LoadBalancers))
+	}
+
+	describeTargetGroupInput := &elbv2.DescribeTargetGroupsInput{
+		LoadBalancerArn: output.LoadBalancers[0].LoadBalancerArn,
+	}
+
+	targetGroups, err := s.ELBV2Client.DescribeTargetGroups(describeTargetGroupInput)
+	if err != nil {
+		return "", errors.Wrapf(err, "error describing ELB's target groups %q", name)
+	}
+
+	for _, tg := range targetGroups.TargetGroups {
+		// Only the target group forwarding to the API server decides whether the instance can serve traffic,
+		// the target groups of additional listeners are ignored.
+		if aws.Int64Value(tg.Port) != infrav1.DefaultAPIServerPort {
+			continue
+		}
+
+		healthInput := &elbv2.DescribeTargetHealthInput{
+			TargetGroupArn: tg.TargetGroupArn,
+			Targets: []*elbv2.TargetDescription{
+				{
+					Id:   aws.String(i.ID),
+					Port: tg.Port,
+				},
+			},
+		}
+		instanceHealth, err := s.ELBV2Client.DescribeTargetHealth(healthInput)
+		if err != nil {
+			return "", errors.Wrapf(err, "error describing ELB's target groups health %q", name)
+		}
+		for _, th := range instanceHealth.TargetHealthDescriptions {
+			if aws.StringValue(th.Target.Id) == i.ID && th.TargetHealth != nil {
+				return aws.StringValue(th.TargetHealth.State), nil
+			}
+		}
+	}
+
+	return "", nil
+}
+
 // RegisterInstanceWithAPIServerELB registers an instance with a classic ELB.
 func (s *Service) RegisterInstanceWithAPIServerELB(i *infrav1.Instance) error {
 	name, err := ELBName(s.scope)
@@ -1097,11 +1186,12 @@ func (s *Service) getAPIServerClassicELBSpec(elbName string) (*infrav1.LoadBalan
 	}
 
 	res.Tags = infrav1.Build(infrav1.BuildParams{
-		ClusterName: s.scope.Name(),
-		Lifecycle:   infrav1.ResourceLifecycleOwned,
-		Name:        aws.String(elbName),
-		Role:        aws.String(infrav1.APIServerRoleTagValue),
-		Additional:  s.scope.AdditionalTags(),
+		ClusterName:        s.scope.Name(),
+		OwnershipTagPrefix: s.scope.OwnershipTagPrefix(),
+		Lifecycle:          infrav1.ResourceLifecycleOwned,
+		Name:               aws.String(s.loadBalancerTagName(elbName)),
+		Role:               aws.String(infrav1.APIServerRoleTagValue),
+		Additional:         s.scope.AdditionalTags(),
 	})
 
 	// If subnet IDs have been specified for this load balancer
@@ -1494,7 +1584,20 @@ func (s *Service) describeLBTags(arn string) ([]*elbv2.Tag, error) {
 	return output.TagDescriptions[0].Tags, nil
 }
 
+// loadBalancerTagName returns the Name tag of a new load balancer.
+func (s *Service) loadBalancerTagName(elbName string) string {
+	return s.scope.ResourceNaming().Name(elbName, infrav1.ResourceNameData{
+		ClusterName:  s.scope.Name(),
+		ResourceType: infrav1.ResourceTypeLoadBalancer,
+		Role:         infrav1.APIServerRoleTagValue,
+	})
+}
+
 func (s *Service) reconcileELBTags(lb *infrav1.LoadBalancer, desiredTags map[string]string) error {
+	if name, ok := s.scope.ResourceNaming().ExistingName(lb.Tags); ok {
+		desiredTags["Name"] = name
+	}
+
 	addTagsInput := &elb.AddTagsInput{
 		LoadBalancerNames: []*string{aws.String(lb.Name)},
 	}
@@ -1535,6 +1638,10 @@ func (s *Service) reconcileELBTags(lb *infrav1.LoadBalancer, desiredTags map[str
 }
 
 func (s *Service) reconcileV2LBTags(lb *infrav1.LoadBalancer, desiredTags map[string]string) error {
+	if name, ok := s.scope.ResourceNaming().ExistingName(lb.Tags); ok {
+		desiredTags["Name"] = name
+	}
+
 	addTagsInput := &elbv2.AddTagsInput{
 		ResourceArns: []*string{aws.String(lb.ARN)},
 	}
@@ -1576,6 +1683,8 @@ func (s *Service) reconcileV2LBTags(lb *infrav1.LoadBalancer, desiredTags map[st
 
 // reconcileTargetGroupsAndListeners reconciles a Load Balancer's defined listeners with corresponding AWS Target Groups and Listeners.
 // These are combined into a single function since they are tightly integrated.
+// Existing listeners are modified in place where possible, so that changing an additional listener never
+// recreates the API server listener. Listeners and target groups of removed additional listeners are deleted.
 func (s *Service) reconcileTargetGroupsAndListeners(lbARN string, spec *infrav1.LoadBalancer, lbSpec *infrav1.AWSLoadBalancerSpec) ([]*elbv2.TargetGroup, []*elbv2.Listener, error) {
 	existingTargetGroups, err := s.ELBV2Client.DescribeTargetGroups(
 		&elbv2.DescribeTargetGroupsInput{
@@ -1592,11 +1701,39 @@ func (s *Service) reconcileTargetGroupsAndListeners(lbARN string, spec *infrav1.
 		})
 	if err != nil {
 		s.scope.Error(err, "could not describe listeners for load balancer", "arn", lbARN)
+		return nil, nil, err
 	}
 
 	createdTargetGroups := make([]*elbv2.TargetGroup, 0, len(spec.ELBListeners))
 	createdListeners := make([]*elbv2.Listener, 0, len(spec.ELBListeners))
 
+	// A load balancer can only have a single listener per port.
+	desiredPorts := make(map[int64]bool, len(spec.ELBListeners))
+	for _, ln := range spec.ELBListeners {
+		desiredPorts[ln.Port] = true
+	}
+	listenersByPort := make(map[int64]*elbv2.Listener, len(existingListeners.Listeners))
+	// Listeners forwarding to CAPA-created target groups whose port is no longer desired, by the prefix of their
+	// target group. These are reused for new listeners of the same kind, so that changing the port of the API
+	// server listener or of an additional listener modifies it in place, and deleted if none are left to reuse them.
+	staleListeners := make(map[string][]*elbv2.Listener)
+	for _, l := range existingListeners.Listeners {
+		port := aws.Int64Value(l.Port)
+		if desiredPorts[port] {
+			listenersByPort[port] = l
+			continue
+		}
+		group := findTargetGroupByARN(existingTargetGroups.TargetGroups, listenerTargetGroupARN(l))
+		if group == nil {
+			continue
+		}
+		if prefix := capaTargetGroupPrefix(aws.StringValue(group.TargetGroupName)); prefix != "" {
+			staleListeners[prefix] = append(staleListeners[prefix], l)
+		}
+	}
+
+	usedTargetGroups := make(map[string]bool, len(spec.ELBListeners))
+
 	// TODO(Skarlso): Add options to set up SSL.
 	// https://github.com/kubernetes-sigs/cluster-api-provider-aws/issues/3899
 	for _, ln := range spec.ELBListeners {
@@ -1631,27 +1768,80 @@ func (s *Service) reconcileTargetGroupsAndListeners(lbARN string, spec *infrav1.
 				}
 			}
 		}
+		usedTargetGroups[aws.StringValue(group.TargetGroupArn)] = true
 
-		var listener *elbv2.Listener
-		for _, l := range existingListeners.Listeners {
-			if l.DefaultActions != nil && len(l.DefaultActions) > 0 && *l.DefaultActions[0].TargetGroupArn == *group.TargetGroupArn {
-				listener = l
-				break
-			}
+		listener := listenersByPort[ln.Port]
+		if prefix := capaTargetGroupPrefix(tgSpec.Name); listener == nil && prefix != "" && len(staleListeners[prefix]) > 0 {
+			listener, staleListeners[prefix] = staleListeners[prefix][0], staleListeners[prefix][1:]
 		}
 
-		if listener == nil {
+		switch {
+		case listener == nil:
 			listener, err = s.createListener(ln, group, lbARN, spec.Tags)
 			if err != nil {
 				return nil, nil, err
 			}
 			createdListeners = append(createdListeners, listener)
+		case !isSDKListenerEqualToListener(listener, ln, group):
+			if err := s.modifyListener(listener, ln, group); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	for _, prefix := range []string{apiServerTargetGroupPrefix, additionalTargetGroupPrefix} {
+		for _, l := range staleListeners[prefix] {
+			s.scope.Debug("deleting listener", "arn", aws.StringValue(l.ListenerArn))
+			if _, err := s.ELBV2Client.DeleteListener(&elbv2.DeleteListenerInput{ListenerArn: l.ListenerArn}); err != nil {
+				return nil, nil, errors.Wrapf(err, "failed to delete listener %q", aws.StringValue(l.ListenerArn))
+			}
+		}
+	}
+
+	// Target groups can only be deleted once no listener forwards to them anymore.
+	for _, g := range existingTargetGroups.TargetGroups {
+		if usedTargetGroups[aws.StringValue(g.TargetGroupArn)] || capaTargetGroupPrefix(aws.StringValue(g.TargetGroupName)) == "" {
+			continue
+		}
+		s.scope.Debug("deleting target group", "name", aws.StringValue(g.TargetGroupName))
+		if _, err := s.ELBV2Client.DeleteTargetGroup(&elbv2.DeleteTargetGroupInput{TargetGroupArn: g.TargetGroupArn}); err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to delete target group %q", aws.StringValue(g.TargetGroupName))
 		}
 	}
 
 	return createdTargetGroups, createdListeners, nil
 }
 
+// capaTargetGroupPrefix returns the prefix of the name of a target group created by CAPA, for the API server
+// listener or for an additional listener, or an empty string if the target group wasn't created by CAPA.
+func capaTargetGroupPrefix(name string) string {
+	for _, prefix := range []string{apiServerTargetGroupPrefix, additionalTargetGroupPrefix} {
+		if strings.HasPrefix(name, prefix) {
+			return prefix
+		}
+	}
+	return ""
+}
+
+// modifyListener updates an existing Listener in place to match the given spec and forward to the given Target Group.
+func (s *Service) modifyListener(listener *elbv2.Listener, ln infrav1.Listener, group *elbv2.TargetGroup) error {
+	s.scope.Debug("modifying listener", "arn", aws.StringValue(listener.ListenerArn), "listener", ln)
+	if _, err := s.ELBV2Client.ModifyListener(&elbv2.ModifyListenerInput{
+		ListenerArn: listener.ListenerArn,
+		DefaultActions: []*elbv2.Action{
+			{
+				TargetGroupArn: group.TargetGroupArn,
+				Type:           aws.String(elbv2.ActionTypeEnumForward),
+			},
+		},
+		Port:     aws.Int64(ln.Port),
+		Protocol: aws.String(string(ln.Protocol)),
+	}); err != nil {
+		return errors.Wrapf(err, "failed to modify listener %q", aws.StringValue(listener.ListenerArn))
+	}
+	return nil
+}
+
 // createListener creates a single Listener.
 func (s *Service) createListener(ln infrav1.Listener, group *elbv2.TargetGroup, lbARN string, tags map[string]string) (*elbv2.Listener, error) {
 	listenerInput := &elbv2.CreateListenerInput{
@@ -1839,3 +2029,31 @@ func isSDKTargetGroupEqualToTargetGroup(elbTG *elbv2.TargetGroup, spec *infrav1.
 	}
 	return ptr.Deref(elbTG.Port, 0) == spec.Port && strings.EqualFold(*elbTG.Protocol, spec.Protocol.String())
 }
+
+// isSDKListenerEqualToListener checks if a given AWS SDK Listener matches a Listener spec forwarding to the given Target Group.
+func isSDKListenerEqualToListener(l *elbv2.Listener, ln infrav1.Listener, group *elbv2.TargetGroup) bool {
+	return aws.Int64Value(l.Port) == ln.Port &&
+		strings.EqualFold(aws.StringValue(l.Protocol), string(ln.Protocol)) &&
+		listenerTargetGroupARN(l) == aws.StringValue(group.TargetGroupArn)
+}
+
+// listenerTargetGroupARN returns the ARN of the Target Group a Listener forwards to by default.
+func listenerTargetGroupARN(l *elbv2.Listener) string {
+	if len(l.DefaultActions) == 0 {
+		return ""
+	}
+	return aws.StringValue(l.DefaultActions[0].TargetGroupArn)
+}
+
+// findTargetGroupByARN returns the Target Group with the given ARN, or nil if there is none.
+func findTargetGroupByARN(groups []*elbv2.TargetGroup, arn string) *elbv2.TargetGroup {
+	if arn == "" {
+		return nil
+	}
+	for _, g := range groups {
+		if aws.StringValue(g.TargetGroupArn) == arn {
+			return g
+		}
+	}
+	return nil
+}
@@ -35,6 +35,7 @@ import (
 	"github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
@@ -1155,6 +1156,187 @@ func TestRegisterInstanceWithAPIServerNLB(t *testing.T) {
 	}
 }
 
+func TestGetAPIServerLBTargetHealth(t *testing.T) {
+	const (
+		namespace   = "foo"
+		clusterName = "bar"
+		elbName     = "bar-apiserver"
+		elbArn      = "arn::apiserver"
+		tgArn       = "arn::target-group"
+		otherTgArn  = "arn::other-target-group"
+		instanceID  = "test-instance"
+	)
+
+	describeLB := func(m *mocks.MockELBV2APIMockRecorder) {
+		m.DescribeLoadBalancers(gomock.Eq(&elbv2.DescribeLoadBalancersInput{
+			Names: aws.StringSlice([]string{elbName}),
+		})).Return(&elbv2.DescribeLoadBalancersOutput{
+			LoadBalancers: []*elbv2.LoadBalancer{
+				{
+					LoadBalancerArn:  aws.String(elbArn),
+					LoadBalancerName: aws.String(elbName),
+				},
+			},
+		}, nil)
+		m.DescribeTargetGroups(&elbv2.DescribeTargetGroupsInput{
+			LoadBalancerArn: aws.String(elbArn),
+		}).Return(&elbv2.DescribeTargetGroupsOutput{
+			TargetGroups: []*elbv2.TargetGroup{
+				{
+					Port:           aws.Int64(443),
+					TargetGroupArn: aws.String(otherTgArn),
+				},
+				{
+					Port:           aws.Int64(infrav1.DefaultAPIServerPort),
+					TargetGroupArn: aws.String(tgArn),
+				},
+			},
+		}, nil)
+	}
+	describeTargetHealth := func(m *mocks.MockELBV2APIMockRecorder, state string) {
+		m.DescribeTargetHealth(gomock.Eq(&elbv2.DescribeTargetHealthInput{
+			TargetGroupArn: aws.String(tgArn),
+			Targets: []*elbv2.TargetDescription{
+				{
+					Id:   aws.String(instanceID),
+					Port: aws.Int64(infrav1.DefaultAPIServerPort),
+				},
+			},
+		})).Return(&elbv2.DescribeTargetHealthOutput{
+			TargetHealthDescriptions: []*elbv2.TargetHealthDescription{
+				{
+					Target:       &elbv2.TargetDescription{Id: aws.String(instanceID)},
+					TargetHealth: &elbv2.TargetHealth{State: aws.String(state)},
+				},
+			},
+		}, nil)
+	}
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	elbV2APIMocks := mocks.NewMockELBV2API(mockCtrl)
+
+	scheme, err := setupScheme()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      clusterName,
+			},
+		},
+		AWSCluster: &infrav1.AWSCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: clusterName},
+			Spec: infrav1.AWSClusterSpec{
+				ControlPlaneLoadBalancer: &infrav1.AWSLoadBalancerSpec{
+					Name:             aws.String(elbName),
+					LoadBalancerType: infrav1.LoadBalancerTypeNLB,
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &Service{
+		scope:       clusterScope,
+		ELBV2Client: elbV2APIMocks,
+	}
+	instance := &infrav1.Instance{ID: instanceID}
+
+	// The target is reported as initial right after its registration, then turns healthy.
+	for _, state := range []string{elbv2.TargetHealthStateEnumInitial, elbv2.TargetHealthStateEnumHealthy} {
+		describeLB(elbV2APIMocks.EXPECT())
+		describeTargetHealth(elbV2APIMocks.EXPECT(), state)
+
+		got, err := s.GetAPIServerLBTargetHealth(instance, clusterScope.ControlPlaneLoadBalancer())
+		if err != nil {
+			t.Fatalf("did not expect error: %v", err)
+		}
+		if got != state {
+			t.Fatalf("expected target health %q, got %q", state, got)
+		}
+	}
+}
+
+func TestValidateLBSubnets(t *testing.T) {
+	tests := []struct {
+		name           string
+		failureDomains clusterv1.FailureDomains
+		lb             *infrav1.LoadBalancer
+		expectErr      string
+	}{
+		{
+			name: "one subnet per availability zone",
+			failureDomains: clusterv1.FailureDomains{
+				"us-east-1a": clusterv1.FailureDomainSpec{ControlPlane: true},
+				"us-east-1c": clusterv1.FailureDomainSpec{ControlPlane: false},
+			},
+			lb: &infrav1.LoadBalancer{
+				Name:              "bar-apiserver",
+				SubnetIDs:         []string{"subnet-1", "subnet-2"},
+				AvailabilityZones: []string{"us-east-1a", "us-east-1b"},
+			},
+		},
+		{
+			name: "subnets sharing an availability zone",
+			lb: &infrav1.LoadBalancer{
+				Name:              "bar-apiserver",
+				SubnetIDs:         []string{"subnet-1", "subnet-2"},
+				AvailabilityZones: []string{"us-east-1a", "us-east-1a"},
+			},
+			expectErr: "subnets subnet-1, subnet-2 are in the same availability zone us-east-1a",
+		},
+		{
+			name: "control plane availability zone not covered",
+			failureDomains: clusterv1.FailureDomains{
+				"us-east-1a": clusterv1.FailureDomainSpec{ControlPlane: true},
+				"us-east-1c": clusterv1.FailureDomainSpec{ControlPlane: true},
+			},
+			lb: &infrav1.LoadBalancer{
+				Name:              "bar-apiserver",
+				SubnetIDs:         []string{"subnet-1"},
+				AvailabilityZones: []string{"us-east-1a"},
+			},
+			expectErr: "no subnet in availability zone us-east-1c",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+
+			clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: "bar"},
+				},
+				AWSCluster: &infrav1.AWSCluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "bar"},
+					Status:     infrav1.AWSClusterStatus{FailureDomains: tc.failureDomains},
+				},
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			s := &Service{scope: clusterScope}
+			err = s.validateLBSubnets(tc.lb)
+			if tc.expectErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+				return
+			}
+			g.Expect(err).To(MatchError(ContainSubstring(tc.expectErr)))
+			g.Expect(IsLoadBalancerSubnetInvalid(kerrors.NewAggregate([]error{nil, err}))).To(BeTrue())
+		})
+	}
+}
+
 func TestCreateNLB(t *testing.T) {
 	const (
 		namespace       = "foo"
@@ -2205,6 +2387,253 @@ func TestReconcileTargetGroupsAndListeners(t *testing.T) {
 	}
 }
 
+func TestReconcileTargetGroupsAndListenersInPlace(t *testing.T) {
+	const (
+		namespace   = "foo"
+		clusterName = "bar"
+		elbArn      = "arn::apiserver"
+		vpcID       = "vpc-id"
+		apiTGArn    = "arn::target-group/api"
+		oldTGArn    = "arn::target-group/additional-old"
+		newTGArn    = "arn::target-group/additional-new"
+	)
+
+	apiListener := infrav1.Listener{
+		Protocol: infrav1.ELBProtocolTCP,
+		Port:     infrav1.DefaultAPIServerPort,
+		TargetGroup: infrav1.TargetGroupSpec{
+			Name:     apiServerTargetGroupPrefix + "abcde",
+			Port:     infrav1.DefaultAPIServerPort,
+			Protocol: infrav1.ELBProtocolTCP,
+			VpcID:    vpcID,
+		},
+	}
+	additionalListener := func(port int64, protocol infrav1.ELBProtocol) infrav1.Listener {
+		return infrav1.Listener{
+			Protocol: protocol,
+			Port:     port,
+			TargetGroup: infrav1.TargetGroupSpec{
+				Name:     additionalTargetGroupPrefix + "fghij",
+				Port:     port,
+				Protocol: protocol,
+				VpcID:    vpcID,
+			},
+		}
+	}
+	listener := func(arn string, port int64, protocol, tgArn string) *elbv2.Listener {
+		return &elbv2.Listener{
+			ListenerArn: aws.String(arn),
+			Port:        aws.Int64(port),
+			Protocol:    aws.String(protocol),
+			DefaultActions: []*elbv2.Action{{
+				TargetGroupArn: aws.String(tgArn),
+				Type:           aws.String(elbv2.ActionTypeEnumForward),
+			}},
+		}
+	}
+	targetGroup := func(arn, name string, port int64, protocol string) *elbv2.TargetGroup {
+		return &elbv2.TargetGroup{
+			TargetGroupArn:  aws.String(arn),
+			TargetGroupName: aws.String(name),
+			Port:            aws.Int64(port),
+			Protocol:        aws.String(protocol),
+		}
+	}
+	// An API server listener on 6443 and an additional listener on 8443.
+	describe := func(m *mocks.MockELBV2APIMockRecorder) {
+		m.DescribeTargetGroups(&elbv2.DescribeTargetGroupsInput{LoadBalancerArn: aws.String(elbArn)}).Return(&elbv2.DescribeTargetGroupsOutput{
+			TargetGroups: []*elbv2.TargetGroup{
+				targetGroup(apiTGArn, apiServerTargetGroupPrefix+"12345", infrav1.DefaultAPIServerPort, "TCP"),
+				targetGroup(oldTGArn, additionalTargetGroupPrefix+"67890", 8443, "TCP"),
+			},
+		}, nil)
+		m.DescribeListeners(&elbv2.DescribeListenersInput{LoadBalancerArn: aws.String(elbArn)}).Return(&elbv2.DescribeListenersOutput{
+			Listeners: []*elbv2.Listener{
+				listener("arn::listener/api", infrav1.DefaultAPIServerPort, "TCP", apiTGArn),
+				listener("arn::listener/additional", 8443, "TCP", oldTGArn),
+			},
+		}, nil)
+	}
+
+	tests := []struct {
+		name          string
+		listeners     []infrav1.Listener
+		elbV2APIMocks func(m *mocks.MockELBV2APIMockRecorder)
+	}{
+		{
+			name:      "unchanged listeners are left alone",
+			listeners: []infrav1.Listener{apiListener, additionalListener(8443, infrav1.ELBProtocolTCP)},
+			elbV2APIMocks: func(m *mocks.MockELBV2APIMockRecorder) {
+				describe(m)
+			},
+		},
+		{
+			name:      "additional listener port change modifies the listener in place",
+			listeners: []infrav1.Listener{apiListener, additionalListener(9443, infrav1.ELBProtocolTCP)},
+			elbV2APIMocks: func(m *mocks.MockELBV2APIMockRecorder) {
+				describe(m)
+				m.CreateTargetGroup(gomock.Any()).Return(&elbv2.CreateTargetGroupOutput{
+					TargetGroups: []*elbv2.TargetGroup{targetGroup(newTGArn, additionalTargetGroupPrefix+"fghij", 9443, "TCP")},
+				}, nil)
+				modify := m.ModifyListener(&elbv2.ModifyListenerInput{
+					ListenerArn: aws.String("arn::listener/additional"),
+					DefaultActions: []*elbv2.Action{{
+						TargetGroupArn: aws.String(newTGArn),
+						Type:           aws.String(elbv2.ActionTypeEnumForward),
+					}},
+					Port:     aws.Int64(9443),
+					Protocol: aws.String("TCP"),
+				}).Return(&elbv2.ModifyListenerOutput{}, nil)
+				m.DeleteTargetGroup(&elbv2.DeleteTargetGroupInput{TargetGroupArn: aws.String(oldTGArn)}).
+					Return(&elbv2.DeleteTargetGroupOutput{}, nil).After(modify)
+			},
+		},
+		{
+			name:      "additional listener protocol change modifies the listener in place",
+			listeners: []infrav1.Listener{apiListener, additionalListener(8443, infrav1.ELBProtocolUDP)},
+			elbV2APIMocks: func(m *mocks.MockELBV2APIMockRecorder) {
+				describe(m)
+				m.CreateTargetGroup(gomock.Any()).Return(&elbv2.CreateTargetGroupOutput{
+					TargetGroups: []*elbv2.TargetGroup{targetGroup(newTGArn, additionalTargetGroupPrefix+"fghij", 8443, "UDP")},
+				}, nil)
+				modify := m.ModifyListener(&elbv2.ModifyListenerInput{
+					ListenerArn: aws.String("arn::listener/additional"),
+					DefaultActions: []*elbv2.Action{{
+						TargetGroupArn: aws.String(newTGArn),
+						Type:           aws.String(elbv2.ActionTypeEnumForward),
+					}},
+					Port:     aws.Int64(8443),
+					Protocol: aws.String("UDP"),
+				}).Return(&elbv2.ModifyListenerOutput{}, nil)
+				m.DeleteTargetGroup(&elbv2.DeleteTargetGroupInput{TargetGroupArn: aws.String(oldTGArn)}).
+					Return(&elbv2.DeleteTargetGroupOutput{}, nil).After(modify)
+			},
+		},
+		{
+			name: "API server listener port change modifies the listener in place and deletes the old target group",
+			listeners: []infrav1.Listener{
+				{
+					Protocol: infrav1.ELBProtocolTCP,
+					Port:     443,
+					TargetGroup: infrav1.TargetGroupSpec{
+						Name:     apiServerTargetGroupPrefix + "abcde",
+						Port:     443,
+						Protocol: infrav1.ELBProtocolTCP,
+						VpcID:    vpcID,
+					},
+				},
+				additionalListener(8443, infrav1.ELBProtocolTCP),
+			},
+			elbV2APIMocks: func(m *mocks.MockELBV2APIMockRecorder) {
+				describe(m)
+				m.CreateTargetGroup(gomock.Any()).Return(&elbv2.CreateTargetGroupOutput{
+					TargetGroups: []*elbv2.TargetGroup{targetGroup(newTGArn, apiServerTargetGroupPrefix+"abcde", 443, "TCP")},
+				}, nil)
+				modify := m.ModifyListener(&elbv2.ModifyListenerInput{
+					ListenerArn: aws.String("arn::listener/api"),
+					DefaultActions: []*elbv2.Action{{
+						TargetGroupArn: aws.String(newTGArn),
+						Type:           aws.String(elbv2.ActionTypeEnumForward),
+					}},
+					Port:     aws.Int64(443),
+					Protocol: aws.String("TCP"),
+				}).Return(&elbv2.ModifyListenerOutput{}, nil)
+				m.DeleteTargetGroup(&elbv2.DeleteTargetGroupInput{TargetGroupArn: aws.String(apiTGArn)}).
+					Return(&elbv2.DeleteTargetGroupOutput{}, nil).After(modify)
+			},
+		},
+		{
+			name:      "removed additional listener is deleted with its target group",
+			listeners: []infrav1.Listener{apiListener},
+			elbV2APIMocks: func(m *mocks.MockELBV2APIMockRecorder) {
+				describe(m)
+				deleteListener := m.DeleteListener(&elbv2.DeleteListenerInput{ListenerArn: aws.String("arn::listener/additional")}).
+					Return(&elbv2.DeleteListenerOutput{}, nil)
+				m.DeleteTargetGroup(&elbv2.DeleteTargetGroupInput{TargetGroupArn: aws.String(oldTGArn)}).
+					Return(&elbv2.DeleteTargetGroupOutput{}, nil).After(deleteListener)
+			},
+		},
+		{
+			name: "new additional listener is created without touching existing ones",
+			listeners: []infrav1.Listener{
+				apiListener,
+				additionalListener(8443, infrav1.ELBProtocolTCP),
+				{
+					Protocol: infrav1.ELBProtocolTCP,
+					Port:     9443,
+					TargetGroup: infrav1.TargetGroupSpec{
+						Name:     additionalTargetGroupPrefix + "klmno",
+						Port:     9443,
+						Protocol: infrav1.ELBProtocolTCP,
+						VpcID:    vpcID,
+					},
+				},
+			},
+			elbV2APIMocks: func(m *mocks.MockELBV2APIMockRecorder) {
+				describe(m)
+				m.CreateTargetGroup(gomock.Any()).Return(&elbv2.CreateTargetGroupOutput{
+					TargetGroups: []*elbv2.TargetGroup{targetGroup(newTGArn, additionalTargetGroupPrefix+"klmno", 9443, "TCP")},
+				}, nil)
+				m.CreateListener(gomock.Any()).Return(&elbv2.CreateListenerOutput{
+					Listeners: []*elbv2.Listener{listener("arn::listener/new", 9443, "TCP", newTGArn)},
+				}, nil)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			elbV2APIMocks := mocks.NewMockELBV2API(mockCtrl)
+
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+			awsCluster := &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: clusterName},
+				Spec: infrav1.AWSClusterSpec{
+					ControlPlaneLoadBalancer: &infrav1.AWSLoadBalancerSpec{
+						LoadBalancerType: infrav1.LoadBalancerTypeNLB,
+						PreserveClientIP: true,
+					},
+					NetworkSpec: infrav1.NetworkSpec{
+						VPC: infrav1.VPCSpec{
+							ID: vpcID,
+						},
+					},
+				},
+			}
+			clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: namespace,
+						Name:      clusterName,
+					},
+				},
+				AWSCluster: awsCluster,
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			tc.elbV2APIMocks(elbV2APIMocks.EXPECT())
+
+			s := &Service{
+				scope:       clusterScope,
+				ELBV2Client: elbV2APIMocks,
+			}
+
+			spec := &infrav1.LoadBalancer{
+				ARN:              elbArn,
+				LoadBalancerType: infrav1.LoadBalancerTypeNLB,
+				ELBListeners:     tc.listeners,
+			}
+			_, _, err = s.reconcileTargetGroupsAndListeners(spec.ARN, spec, clusterScope.ControlPlaneLoadBalancer())
+			g.Expect(err).NotTo(HaveOccurred())
+		})
+	}
+}
+
 func TestReconcileV2LB(t *testing.T) {
 	const (
 		namespace       = "foo"
@@ -2747,9 +3176,20 @@ func TestDeleteAPIServerELB(t *testing.T) {
 	elbName := "bar-apiserver"
 	tests := []struct {
 		name             string
+		loadBalancerType infrav1.LoadBalancerType
 		elbAPIMocks      func(m *mocks.MockELBAPIMockRecorder)
 		verifyAWSCluster func(*infrav1.AWSCluster)
 	}{
+		{
+			name:             "if control plane load balancer is managed outside of CAPA, do nothing",
+			loadBalancerType: infrav1.LoadBalancerTypeDisabled,
+			elbAPIMocks:      func(m *mocks.MockELBAPIMockRecorder) {},
+			verifyAWSCluster: func(awsCluster *infrav1.AWSCluster) {
+				if conditions.Has(awsCluster, infrav1.LoadBalancerReadyCondition) {
+					t.Fatalf("Expected LoadBalancerReady condition not to be set")
+				}
+			},
+		},
 		{
 			name: "if control plane ELB is not found, do nothing",
 			elbAPIMocks: func(m *mocks.MockELBAPIMockRecorder) {
@@ -2897,7 +3337,8 @@ func TestDeleteAPIServerELB(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
 				Spec: infrav1.AWSClusterSpec{
 					ControlPlaneLoadBalancer: &infrav1.AWSLoadBalancerSpec{
-						Name: aws.String(elbName),
+						Name:             aws.String(elbName),
+						LoadBalancerType: tc.loadBalancerType,
 					},
 				},
 			}
@@ -0,0 +1,219 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpointprobe
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+const (
+	// DefaultProbeInterval is the interval after which the API server endpoint of a cluster is probed again,
+	// unless its security groups change.
+	DefaultProbeInterval = 10 * time.Minute
+
+	// DefaultProbeTimeout is the time a probe waits for the TLS handshake with the API server endpoint.
+	DefaultProbeTimeout = 10 * time.Second
+)
+
+// Prober holds the results of the probes of the API server endpoints, so endpoints are only
+// probed once per interval. The probes run in the background, so that an unreachable endpoint
+// doesn't hold up the reconciliation of its cluster until the probe times out.
+type Prober struct {
+	interval time.Duration
+	timeout  time.Duration
+	now      func() time.Time
+	probe    func(ctx context.Context, address string) error
+	run      func(f func())
+
+	mu       sync.Mutex
+	results  map[string]probeResult
+	inFlight map[string]bool
+}
+
+type probeResult struct {
+	// fingerprint identifies the endpoint and the security groups of the cluster the probe was made with.
+	fingerprint string
+	err         error
+	latency     time.Duration
+	probedAt    time.Time
+}
+
+// NewProber returns a prober keeping the results for the given interval, and waiting
+// for the given timeout for each probe.
+func NewProber(interval, timeout time.Duration) *Prober {
+	return &Prober{
+		interval: interval,
+		timeout:  timeout,
+		now:      time.Now,
+		probe:    probeTLS,
+		run:      func(f func()) { go f() },
+		results:  map[string]probeResult{},
+		inFlight: map[string]bool{},
+	}
+}
+
+func (p *Prober) cached(key, fingerprint string) (probeResult, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	res, ok := p.results[key]
+	if !ok || res.fingerprint != fingerprint || p.now().Sub(res.probedAt) >= p.interval {
+		return probeResult{}, false
+	}
+	return res, true
+}
+
+// start probes the address in the background, unless a probe of the endpoint is already running.
+func (p *Prober) start(key, fingerprint, address string) {
+	p.mu.Lock()
+	if p.inFlight[key] {
+		p.mu.Unlock()
+		return
+	}
+	p.inFlight[key] = true
+	p.mu.Unlock()
+
+	p.run(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+		defer cancel()
+
+		start := p.now()
+		err := p.probe(ctx, address)
+		p.store(key, probeResult{
+			fingerprint: fingerprint,
+			err:         err,
+			latency:     p.now().Sub(start),
+			probedAt:    p.now(),
+		})
+	})
+}
+
+// store records the result of a probe, unless the endpoint was forgotten while it was probed.
+func (p *Prober) store(key string, res probeResult) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.inFlight[key] {
+		return
+	}
+	delete(p.inFlight, key)
+	p.results[key] = res
+}
+
+// forget drops the result of the endpoint of a deleted cluster.
+func (p *Prober) forget(key string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.results, key)
+	delete(p.inFlight, key)
+}
+
+// ReconcileAPIEndpoint probes the API server endpoint of the cluster once it is set, and publishes
+// the result with the APIEndpointReachableCondition. The endpoint is probed again after the interval
+// of the prober, or as soon as the security groups of the cluster change. While a probe is running,
+// the condition keeps the previous result, and the time after which to check for the result is returned.
+func (s *Service) ReconcileAPIEndpoint() (time.Duration, error) {
+	endpoint := s.scope.AWSCluster.Spec.ControlPlaneEndpoint
+	if !endpoint.IsValid() {
+		return 0, nil
+	}
+
+	fingerprint, err := s.fingerprint(endpoint)
+	if err != nil {
+		return 0, err
+	}
+
+	res, ok := s.prober.cached(s.key(), fingerprint)
+	if !ok {
+		address := net.JoinHostPort(endpoint.Host, strconv.Itoa(int(endpoint.Port)))
+		s.scope.Debug("Probing API server endpoint", "address", address)
+		s.prober.start(s.key(), fingerprint, address)
+
+		if res, ok = s.prober.cached(s.key(), fingerprint); !ok {
+			return s.prober.timeout, nil
+		}
+	}
+
+	if res.err != nil {
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.APIEndpointReachableCondition, infrav1.APIEndpointUnreachableReason, clusterv1.ConditionSeverityWarning,
+			"API server endpoint %s is not reachable from the management cluster: %v", endpoint.Host, res.err)
+		return 0, nil
+	}
+
+	condition := conditions.TrueCondition(infrav1.APIEndpointReachableCondition)
+	condition.Message = "API server endpoint reachable in " + res.latency.Round(time.Millisecond).String()
+	conditions.Set(s.scope.InfraCluster(), condition)
+	return 0, nil
+}
+
+// DeleteAPIEndpoint drops the result of the last probe of the API server endpoint of the deleted cluster.
+func (s *Service) DeleteAPIEndpoint() {
+	s.prober.forget(s.key())
+}
+
+func (s *Service) key() string {
+	return s.scope.Namespace() + "/" + s.scope.Name()
+}
+
+// fingerprint identifies the endpoint and the security groups of the cluster, so that a change
+// of the security group rules triggers a new probe.
+func (s *Service) fingerprint(endpoint clusterv1.APIEndpoint) (string, error) {
+	data, err := json.Marshal(struct {
+		Endpoint       clusterv1.APIEndpoint
+		SecurityGroups map[infrav1.SecurityGroupRole]infrav1.SecurityGroup
+	}{
+		Endpoint:       endpoint,
+		SecurityGroups: s.scope.SecurityGroups(),
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to compute fingerprint of API server endpoint")
+	}
+
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// probeTLS opens a TLS connection to the address. The certificate of the API server is not verified,
+// as the probe only checks that the endpoint can be reached.
+func probeTLS(ctx context.Context, address string) error {
+	dialer := &tls.Dialer{
+		Config: &tls.Config{
+			InsecureSkipVerify: true, //nolint:gosec // Only the reachability of the endpoint is checked.
+		},
+	}
+
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
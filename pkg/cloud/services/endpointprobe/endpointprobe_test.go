@@ -0,0 +1,171 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpointprobe
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloudtest"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestProbeTLS(t *testing.T) {
+	g := NewWithT(t)
+
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		rw.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	g.Expect(probeTLS(context.TODO(), ts.Listener.Addr().String())).To(Succeed())
+
+	// A plain TCP listener accepts the connection, but fails the handshake.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	g.Expect(err).NotTo(HaveOccurred())
+	go func() {
+		conn, err := listener.Accept()
+		if err == nil {
+			_ = conn.Close()
+		}
+	}()
+	defer listener.Close()
+	g.Expect(probeTLS(context.TODO(), listener.Addr().String())).NotTo(Succeed())
+}
+
+func TestReconcileAPIEndpoint(t *testing.T) {
+	g := NewWithT(t)
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	probes := 0
+	var probeErr error
+	prober := NewProber(DefaultProbeInterval, DefaultProbeTimeout)
+	prober.now = func() time.Time { return now }
+	prober.probe = func(_ context.Context, address string) error {
+		probes++
+		g.Expect(address).To(Equal(net.JoinHostPort("api.example.com", strconv.Itoa(6443))))
+		now = now.Add(42 * time.Millisecond)
+		return probeErr
+	}
+	// The probes complete before the reconciliation checks for their result.
+	prober.run = func(f func()) { f() }
+
+	clusterScope := cloudtest.NewClusterScope(t)
+	s := NewService(clusterScope, prober)
+	reconcileAPIEndpoint := func() time.Duration {
+		requeueAfter, err := s.ReconcileAPIEndpoint()
+		g.Expect(err).NotTo(HaveOccurred())
+		return requeueAfter
+	}
+
+	// The endpoint isn't probed before it is set.
+	g.Expect(reconcileAPIEndpoint()).To(BeZero())
+	g.Expect(probes).To(Equal(0))
+	g.Expect(conditions.Has(clusterScope.AWSCluster, infrav1.APIEndpointReachableCondition)).To(BeFalse())
+
+	clusterScope.AWSCluster.Spec.ControlPlaneEndpoint = clusterv1.APIEndpoint{Host: "api.example.com", Port: 6443}
+	g.Expect(reconcileAPIEndpoint()).To(BeZero())
+	g.Expect(probes).To(Equal(1))
+	condition := conditions.Get(clusterScope.AWSCluster, infrav1.APIEndpointReachableCondition)
+	g.Expect(condition.Status).To(Equal(corev1.ConditionTrue))
+	g.Expect(condition.Message).To(Equal("API server endpoint reachable in 42ms"))
+
+	// The result is cached until the interval elapsed.
+	probeErr = errors.New("i/o timeout")
+	g.Expect(reconcileAPIEndpoint()).To(BeZero())
+	g.Expect(probes).To(Equal(1))
+	g.Expect(conditions.IsTrue(clusterScope.AWSCluster, infrav1.APIEndpointReachableCondition)).To(BeTrue())
+
+	// A change of the security groups triggers a new probe.
+	clusterScope.AWSCluster.Status.Network.SecurityGroups = map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+		infrav1.SecurityGroupAPIServerLB: {
+			ID: "sg-lb",
+			IngressRules: infrav1.IngressRules{{
+				Protocol:   infrav1.SecurityGroupProtocolTCP,
+				FromPort:   6443,
+				ToPort:     6443,
+				CidrBlocks: []string{"10.0.0.0/16"},
+			}},
+		},
+	}
+	g.Expect(reconcileAPIEndpoint()).To(BeZero())
+	g.Expect(probes).To(Equal(2))
+	g.Expect(conditions.GetReason(clusterScope.AWSCluster, infrav1.APIEndpointReachableCondition)).To(Equal(infrav1.APIEndpointUnreachableReason))
+	g.Expect(conditions.GetMessage(clusterScope.AWSCluster, infrav1.APIEndpointReachableCondition)).To(ContainSubstring("i/o timeout"))
+
+	// The endpoint is probed again once the interval elapsed.
+	probeErr = nil
+	now = now.Add(DefaultProbeInterval)
+	g.Expect(reconcileAPIEndpoint()).To(BeZero())
+	g.Expect(probes).To(Equal(3))
+	g.Expect(conditions.IsTrue(clusterScope.AWSCluster, infrav1.APIEndpointReachableCondition)).To(BeTrue())
+}
+
+func TestReconcileAPIEndpointInBackground(t *testing.T) {
+	g := NewWithT(t)
+
+	var probe func()
+	prober := NewProber(DefaultProbeInterval, DefaultProbeTimeout)
+	prober.probe = func(context.Context, string) error {
+		return nil
+	}
+	prober.run = func(f func()) { probe = f }
+
+	clusterScope := cloudtest.NewClusterScope(t)
+	clusterScope.AWSCluster.Spec.ControlPlaneEndpoint = clusterv1.APIEndpoint{Host: "api.example.com", Port: 6443}
+	s := NewService(clusterScope, prober)
+
+	// The reconciliation doesn't wait for the probe, it checks for its result later.
+	requeueAfter, err := s.ReconcileAPIEndpoint()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(requeueAfter).To(Equal(DefaultProbeTimeout))
+	g.Expect(conditions.Has(clusterScope.AWSCluster, infrav1.APIEndpointReachableCondition)).To(BeFalse())
+
+	// A probe already running isn't started again.
+	started := probe
+	probe = nil
+	_, err = s.ReconcileAPIEndpoint()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(probe).To(BeNil())
+
+	started()
+	requeueAfter, err = s.ReconcileAPIEndpoint()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(requeueAfter).To(BeZero())
+	g.Expect(conditions.IsTrue(clusterScope.AWSCluster, infrav1.APIEndpointReachableCondition)).To(BeTrue())
+
+	// The result is dropped once the cluster is deleted, including the one of a probe still running.
+	s.DeleteAPIEndpoint()
+	g.Expect(prober.results).To(BeEmpty())
+	_, err = s.ReconcileAPIEndpoint()
+	g.Expect(err).NotTo(HaveOccurred())
+	s.DeleteAPIEndpoint()
+	probe()
+	g.Expect(prober.results).To(BeEmpty())
+	g.Expect(prober.inFlight).To(BeEmpty())
+}
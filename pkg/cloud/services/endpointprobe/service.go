@@ -0,0 +1,38 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package endpointprobe provides a way to check that the API server endpoint of a cluster
+// can be reached from the management cluster.
+package endpointprobe
+
+import (
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+)
+
+// Service probes the API server endpoint of a cluster.
+type Service struct {
+	scope  *scope.ClusterScope
+	prober *Prober
+}
+
+// NewService returns a new service given the cluster scope and the prober holding the results
+// of previous probes.
+func NewService(clusterScope *scope.ClusterScope, prober *Prober) *Service {
+	return &Service{
+		scope:  clusterScope,
+		prober: prober,
+	}
+}
@@ -75,6 +75,7 @@ func (s *Service) deleteResources(ctx context.Context) error {
 			infrav1.GCTaskLoadBalancer:  s.deleteLoadBalancers,
 			infrav1.GCTaskTargetGroup:   s.deleteTargetGroups,
 			infrav1.GCTaskSecurityGroup: s.deleteSecurityGroups,
+			infrav1.GCTaskVolume:        s.deleteVolumes,
 		}
 
 		cleanupFuncs = ResourceCleanupFuncs{}
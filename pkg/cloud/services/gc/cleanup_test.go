@@ -795,6 +795,46 @@ func TestReconcileDelete(t *testing.T) {
 			ec2Mocks:   func(m *mocks.MockEC2APIMockRecorder) {},
 			expectErr:  false,
 		},
+		{
+			name:         "ec2 cluster with volume clean-up func enabled",
+			clusterScope: createUnManageScope(t, "", "security-group,volume"),
+			rgAPIMocks: func(m *mocks.MockResourceGroupsTaggingAPIAPIMockRecorder) {
+				m.GetResourcesWithContext(gomock.Any(), &rgapi.GetResourcesInput{
+					TagFilters: []*rgapi.TagFilter{
+						{
+							Key:    aws.String("kubernetes.io/cluster/cluster1"),
+							Values: []*string{aws.String("owned")},
+						},
+					},
+				}).DoAndReturn(func(awsCtx context.Context, input *rgapi.GetResourcesInput, opts ...request.Option) (*rgapi.GetResourcesOutput, error) {
+					return &rgapi.GetResourcesOutput{
+						ResourceTagMappingList: []*rgapi.ResourceTagMapping{
+							{
+								ResourceARN: aws.String("arn:aws:ec2:eu-west-2:1234567890:volume/vol-123456"),
+								Tags: []*rgapi.Tag{
+									{
+										Key:   aws.String("kubernetes.io/cluster/cluster1"),
+										Value: aws.String("owned"),
+									},
+									{
+										Key:   aws.String(infrav1.MachineNameTagKey),
+										Value: aws.String("default/machine1"),
+									},
+								},
+							},
+						},
+					}, nil
+				})
+			},
+			elbMocks:   func(m *mocks.MockELBAPIMockRecorder) {},
+			elbv2Mocks: func(m *mocks.MockELBV2APIMockRecorder) {},
+			ec2Mocks: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DeleteVolumeWithContext(gomock.Any(), &ec2.DeleteVolumeInput{
+					VolumeId: aws.String("vol-123456"),
+				}).Return(&ec2.DeleteVolumeOutput{}, nil)
+			},
+			expectErr: false,
+		},
 	}
 
 	for _, tc := range testCases {
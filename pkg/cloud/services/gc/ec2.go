@@ -98,3 +98,63 @@ func (s *Service) getProviderOwnedSecurityGroups(_ context.Context) ([]*AWSResou
 
 	return resources, nil
 }
+
+func (s *Service) deleteVolumes(ctx context.Context, resources []*AWSResource) error {
+	for _, resource := range resources {
+		if !s.isMatchingResource(resource, ec2.ServiceName, "volume") {
+			s.scope.Debug("Resource not a volume for deletion", "arn", resource.ARN.String())
+			continue
+		}
+
+		volumeID := strings.ReplaceAll(resource.ARN.Resource, volumeResourcePrefix, "")
+		if err := s.deleteVolume(ctx, volumeID); err != nil {
+			return fmt.Errorf("deleting volume %q with ID %s: %w", resource.ARN, volumeID, err)
+		}
+	}
+	s.scope.Debug("Finished processing resources for volume deletion")
+
+	return nil
+}
+
+func (s *Service) deleteVolume(ctx context.Context, volumeID string) error {
+	input := ec2.DeleteVolumeInput{
+		VolumeId: aws.String(volumeID),
+	}
+
+	s.scope.Debug("Deleting volume", "volume_id", volumeID)
+	if _, err := s.ec2Client.DeleteVolumeWithContext(ctx, &input); err != nil {
+		return fmt.Errorf("deleting volume: %w", err)
+	}
+
+	return nil
+}
+
+// getProviderOwnedVolumes gets the EBS volumes of this cluster that are no longer attached to an instance, filtering by tag: kubernetes.io/cluster/<cluster-name>:owned.
+// These are the volumes that were kept because DeleteOnTermination was false.
+func (s *Service) getProviderOwnedVolumes(ctx context.Context) ([]*AWSResource, error) {
+	input := &ec2.DescribeVolumesInput{
+		Filters: []*ec2.Filter{
+			filter.EC2.ProviderOwned(s.scope.KubernetesClusterName()),
+			filter.EC2.VolumeStates(ec2.VolumeStateAvailable),
+		},
+	}
+
+	var resources []*AWSResource
+	err := s.ec2Client.DescribeVolumesPagesWithContext(ctx, input, func(out *ec2.DescribeVolumesOutput, last bool) bool {
+		for _, volume := range out.Volumes {
+			arn := composeFakeArn(volumeService, volumeResourcePrefix+*volume.VolumeId)
+			resource, err := composeAWSResource(arn, converters.TagsToMap(volume.Tags))
+			if err != nil {
+				s.scope.Error(err, "error compose aws volume resource: %v", "name", arn)
+				continue
+			}
+			resources = append(resources, resource)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("describe volumes error: %w", err)
+	}
+
+	return resources, nil
+}
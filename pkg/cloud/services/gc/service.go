@@ -81,6 +81,7 @@ func addAlternativeCollectFuncs(s *Service) {
 		s.getProviderOwnedLoadBalancersV2,
 		s.getProviderOwnedTargetgroups,
 		s.getProviderOwnedSecurityGroups,
+		s.getProviderOwnedVolumes,
 	}
 }
 
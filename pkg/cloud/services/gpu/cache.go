@@ -0,0 +1,79 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gpu
+
+import (
+	"sync"
+)
+
+// Cache holds the instance types and the images described by all the machine pools. The GPUs of an instance
+// type and the name of an image never change, so they are only described once per region.
+type Cache struct {
+	mu         sync.Mutex
+	nvidiaGPUs map[string]bool
+	imageNames map[string]string
+}
+
+// NewCache returns an empty cache.
+func NewCache() *Cache {
+	return &Cache{
+		nvidiaGPUs: map[string]bool{},
+		imageNames: map[string]string{},
+	}
+}
+
+func (c *Cache) getNVIDIAGPUs(region, instanceType string) (bool, bool) {
+	if c == nil {
+		return false, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	hasGPUs, ok := c.nvidiaGPUs[region+"/"+instanceType]
+	return hasGPUs, ok
+}
+
+func (c *Cache) setNVIDIAGPUs(region, instanceType string, hasGPUs bool) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.nvidiaGPUs[region+"/"+instanceType] = hasGPUs
+}
+
+func (c *Cache) getImageName(region, imageID string) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	name, ok := c.imageNames[region+"/"+imageID]
+	return name, ok
+}
+
+func (c *Cache) setImageName(region, imageID, name string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.imageNames[region+"/"+imageID] = name
+}
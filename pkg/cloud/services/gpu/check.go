@@ -0,0 +1,231 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gpu
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	infrautilconditions "sigs.k8s.io/cluster-api-provider-aws/v2/util/conditions"
+)
+
+const nvidiaManufacturer = "NVIDIA"
+
+var (
+	// eksOptimizedImagePrefixes are the name prefixes of the EKS optimized AMIs.
+	eksOptimizedImagePrefixes = []string{"amazon-eks-node-", "amazon-eks-arm64-node-", "bottlerocket-aws-k8s-"}
+
+	// gpuImageNameMarkers are found in the names of the EKS optimized AMIs which ship the NVIDIA drivers, e.g.
+	// amazon-eks-gpu-node-1.29-v20240117 or amazon-eks-node-al2023-x86_64-nvidia-1.30-v20240625.
+	gpuImageNameMarkers = []string{"gpu", "nvidia"}
+)
+
+// ReconcileGPUCompatibility reports with the AMILacksGPUDriversCondition a launch template which launches instance
+// types with NVIDIA GPUs from an AMI known to lack the GPU drivers. The condition is removed once the AMI ships
+// them, or none of the instance types has NVIDIA GPUs.
+func (s *Service) ReconcileGPUCompatibility(lts scope.LaunchTemplateScope) error {
+	if lts.GetLaunchTemplate().Ref != nil {
+		infrautilconditions.ClearAWSState(lts.GetSetter(), expinfrav1.AMILacksGPUDriversCondition)
+		return nil
+	}
+
+	gpuInstanceTypes, err := s.nvidiaGPUInstanceTypes(launchTemplateInstanceTypes(lts))
+	if err != nil {
+		return err
+	}
+	if len(gpuInstanceTypes) == 0 {
+		infrautilconditions.ClearAWSState(lts.GetSetter(), expinfrav1.AMILacksGPUDriversCondition)
+		return nil
+	}
+
+	reason, err := s.amiLacksGPUDrivers(lts)
+	if err != nil {
+		return err
+	}
+	if reason == "" {
+		infrautilconditions.ClearAWSState(lts.GetSetter(), expinfrav1.AMILacksGPUDriversCondition)
+		return nil
+	}
+	infrautilconditions.MarkAWSState(lts.GetSetter(), expinfrav1.AMILacksGPUDriversCondition, expinfrav1.GPUDriversMissingReason,
+		fmt.Sprintf("Instance types %s have NVIDIA GPUs but %s, the nodes won't expose their GPUs", strings.Join(gpuInstanceTypes, ", "), reason))
+	return nil
+}
+
+// launchTemplateInstanceTypes returns the instance types launched from a launch template, including the ones
+// named by the instance type overrides.
+func launchTemplateInstanceTypes(lts scope.LaunchTemplateScope) []string {
+	instanceTypes := sets.New[string]()
+	if instanceType := lts.GetLaunchTemplate().InstanceType; instanceType != "" {
+		instanceTypes.Insert(instanceType)
+	}
+	if overridesScope, ok := lts.(scope.LaunchTemplateOverridesScope); ok && overridesScope.GetMixedInstancesPolicy() != nil {
+		for _, override := range overridesScope.GetMixedInstancesPolicy().Overrides {
+			// The instance types selected from instance requirements aren't known.
+			if override.InstanceType != "" {
+				instanceTypes.Insert(override.InstanceType)
+			}
+		}
+	}
+	return sets.List(instanceTypes)
+}
+
+// nvidiaGPUInstanceTypes returns the given instance types which have NVIDIA GPUs. The instance types which
+// aren't cached are described with a single call.
+func (s *Service) nvidiaGPUInstanceTypes(instanceTypes []string) ([]string, error) {
+	region := s.scope.Region()
+
+	var gpuInstanceTypes, toDescribe []string
+	for _, instanceType := range instanceTypes {
+		hasGPUs, ok := s.cache.getNVIDIAGPUs(region, instanceType)
+		switch {
+		case !ok:
+			toDescribe = append(toDescribe, instanceType)
+		case hasGPUs:
+			gpuInstanceTypes = append(gpuInstanceTypes, instanceType)
+		}
+	}
+
+	if len(toDescribe) > 0 {
+		out, err := s.EC2Client.DescribeInstanceTypesWithContext(context.TODO(), &ec2.DescribeInstanceTypesInput{
+			InstanceTypes: aws.StringSlice(toDescribe),
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to describe instance types %v", toDescribe)
+		}
+		for _, info := range out.InstanceTypes {
+			hasGPUs := hasNVIDIAGPUs(info)
+			s.cache.setNVIDIAGPUs(region, aws.StringValue(info.InstanceType), hasGPUs)
+			if hasGPUs {
+				gpuInstanceTypes = append(gpuInstanceTypes, aws.StringValue(info.InstanceType))
+			}
+		}
+	}
+
+	sort.Strings(gpuInstanceTypes)
+	return gpuInstanceTypes, nil
+}
+
+func hasNVIDIAGPUs(info *ec2.InstanceTypeInfo) bool {
+	if info.GpuInfo == nil {
+		return false
+	}
+	for _, gpu := range info.GpuInfo.Gpus {
+		if aws.StringValue(gpu.Manufacturer) == nvidiaManufacturer {
+			return true
+		}
+	}
+	return false
+}
+
+// amiLacksGPUDrivers returns why the AMI of a launch template is known to lack the NVIDIA drivers, or an empty
+// string when it ships them or isn't known to lack them.
+func (s *Service) amiLacksGPUDrivers(lts scope.LaunchTemplateScope) (string, error) {
+	lt := lts.GetLaunchTemplate()
+	ami := lt.AMI
+
+	if ami.GPUCompatible != nil {
+		if *ami.GPUCompatible {
+			return "", nil
+		}
+		return "spec.awsLaunchTemplate.ami.gpuCompatible declares that the AMI lacks the GPU drivers", nil
+	}
+
+	if ami.ID != nil {
+		// The AMI of the source region isn't described, its copy is named after it.
+		if ami.SourceRegion != "" {
+			return "", nil
+		}
+		name, err := s.imageName(*ami.ID)
+		if err != nil {
+			return "", err
+		}
+		if !isEKSOptimizedImageWithoutGPUDrivers(name) {
+			return "", nil
+		}
+		return fmt.Sprintf("AMI %s (%s) is an EKS optimized AMI without GPU drivers", *ami.ID, name), nil
+	}
+
+	if lt.AMIType == expinfrav1.AMITypeGPU {
+		return "", nil
+	}
+	// The images looked up with custom lookup parameters may ship the GPU drivers.
+	ec2Scope := lts.GetEC2Scope()
+	if lt.ImageLookupFormat != "" || lt.ImageLookupOrg != "" || lt.ImageLookupBaseOS != "" ||
+		ec2Scope.ImageLookupFormat() != "" || ec2Scope.ImageLookupOrg() != "" || ec2Scope.ImageLookupBaseOS() != "" {
+		return "", nil
+	}
+	if lts.IsEKSManaged() {
+		if ami.EKSOptimizedLookupType != nil && *ami.EKSOptimizedLookupType == infrav1.AmazonLinuxGPU {
+			return "", nil
+		}
+		return "the AMI is looked up among the EKS optimized AMIs without GPU drivers", nil
+	}
+	return "the AMI is looked up among the default images, which lack the GPU drivers", nil
+}
+
+// imageName returns the name of an image, or an empty string when it doesn't exist.
+func (s *Service) imageName(imageID string) (string, error) {
+	region := s.scope.Region()
+	if name, ok := s.cache.getImageName(region, imageID); ok {
+		return name, nil
+	}
+
+	out, err := s.EC2Client.DescribeImagesWithContext(context.TODO(), &ec2.DescribeImagesInput{
+		ImageIds: aws.StringSlice([]string{imageID}),
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to describe image %q", imageID)
+	}
+	if len(out.Images) == 0 {
+		return "", nil
+	}
+	name := aws.StringValue(out.Images[0].Name)
+	s.cache.setImageName(region, imageID, name)
+	return name, nil
+}
+
+// isEKSOptimizedImageWithoutGPUDrivers tells from its name whether an image is an EKS optimized AMI which doesn't
+// ship the NVIDIA drivers.
+func isEKSOptimizedImageWithoutGPUDrivers(name string) bool {
+	name = strings.ToLower(name)
+	eksOptimized := false
+	for _, prefix := range eksOptimizedImagePrefixes {
+		if strings.HasPrefix(name, prefix) {
+			eksOptimized = true
+			break
+		}
+	}
+	if !eksOptimized {
+		return false
+	}
+	for _, marker := range gpuImageNameMarkers {
+		if strings.Contains(name, marker) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gpu
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloudtest"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestReconcileGPUCompatibility(t *testing.T) {
+	instanceTypes := map[string]*ec2.InstanceTypeInfo{
+		"g5.xlarge": {
+			InstanceType: aws.String("g5.xlarge"),
+			GpuInfo:      &ec2.GpuInfo{Gpus: []*ec2.GpuDeviceInfo{{Manufacturer: aws.String("NVIDIA")}}},
+		},
+		"g4ad.xlarge": {
+			InstanceType: aws.String("g4ad.xlarge"),
+			GpuInfo:      &ec2.GpuInfo{Gpus: []*ec2.GpuDeviceInfo{{Manufacturer: aws.String("AMD")}}},
+		},
+		"m5.large": {InstanceType: aws.String("m5.large")},
+	}
+
+	tests := []struct {
+		name        string
+		lt          expinfrav1.AWSLaunchTemplate
+		overrides   []expinfrav1.Overrides
+		imageName   string
+		wantMessage string
+	}{
+		{
+			name:        "should report GPU instance types launched from the default images",
+			lt:          expinfrav1.AWSLaunchTemplate{InstanceType: "g5.xlarge"},
+			wantMessage: "Instance types g5.xlarge have NVIDIA GPUs but the AMI is looked up among the default images, which lack the GPU drivers, the nodes won't expose their GPUs",
+		},
+		{
+			name:        "should report GPU instance type overrides launched from an EKS optimized AMI without GPU drivers",
+			lt:          expinfrav1.AWSLaunchTemplate{InstanceType: "m5.large", AMI: infrav1.AMIReference{ID: aws.String("ami-1")}},
+			overrides:   []expinfrav1.Overrides{{InstanceType: "m5.large"}, {InstanceType: "g5.xlarge"}},
+			imageName:   "amazon-eks-node-al2023-x86_64-standard-1.30-v20240625",
+			wantMessage: "Instance types g5.xlarge have NVIDIA GPUs but AMI ami-1 (amazon-eks-node-al2023-x86_64-standard-1.30-v20240625) is an EKS optimized AMI without GPU drivers, the nodes won't expose their GPUs",
+		},
+		{
+			name:      "should not report an EKS optimized AMI with GPU drivers",
+			lt:        expinfrav1.AWSLaunchTemplate{InstanceType: "g5.xlarge", AMI: infrav1.AMIReference{ID: aws.String("ami-1")}},
+			imageName: "amazon-eks-node-al2023-x86_64-nvidia-1.30-v20240625",
+		},
+		{
+			name:      "should not report a custom AMI",
+			lt:        expinfrav1.AWSLaunchTemplate{InstanceType: "g5.xlarge", AMI: infrav1.AMIReference{ID: aws.String("ami-1")}},
+			imageName: "my-gpu-image",
+		},
+		{
+			name:        "should report an AMI declared without GPU drivers",
+			lt:          expinfrav1.AWSLaunchTemplate{InstanceType: "g5.xlarge", AMI: infrav1.AMIReference{ID: aws.String("ami-1"), GPUCompatible: ptr.To[bool](false)}},
+			wantMessage: "Instance types g5.xlarge have NVIDIA GPUs but spec.awsLaunchTemplate.ami.gpuCompatible declares that the AMI lacks the GPU drivers, the nodes won't expose their GPUs",
+		},
+		{
+			name: "should not report an AMI declared with GPU drivers",
+			lt:   expinfrav1.AWSLaunchTemplate{InstanceType: "g5.xlarge", AMI: infrav1.AMIReference{GPUCompatible: ptr.To[bool](true)}},
+		},
+		{
+			name: "should not report the GPU variant of the default images",
+			lt:   expinfrav1.AWSLaunchTemplate{InstanceType: "g5.xlarge", AMIType: expinfrav1.AMITypeGPU},
+		},
+		{
+			name: "should not report instance types without NVIDIA GPUs",
+			lt:   expinfrav1.AWSLaunchTemplate{InstanceType: "g4ad.xlarge"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+			ec2Mock.EXPECT().DescribeInstanceTypesWithContext(context.TODO(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, input *ec2.DescribeInstanceTypesInput, _ ...request.Option) (*ec2.DescribeInstanceTypesOutput, error) {
+					out := &ec2.DescribeInstanceTypesOutput{}
+					for _, instanceType := range input.InstanceTypes {
+						out.InstanceTypes = append(out.InstanceTypes, instanceTypes[aws.StringValue(instanceType)])
+					}
+					return out, nil
+				})
+			if tt.imageName != "" {
+				ec2Mock.EXPECT().DescribeImagesWithContext(context.TODO(), &ec2.DescribeImagesInput{ImageIds: aws.StringSlice([]string{"ami-1"})}).
+					Return(&ec2.DescribeImagesOutput{Images: []*ec2.Image{{ImageId: aws.String("ami-1"), Name: aws.String(tt.imageName)}}}, nil)
+			}
+
+			clusterScope := cloudtest.NewClusterScope(t)
+			s := NewService(clusterScope, NewCache())
+			s.EC2Client = ec2Mock
+			machinePoolScope := &scope.MachinePoolScope{
+				InfraCluster: clusterScope,
+				AWSMachinePool: &expinfrav1.AWSMachinePool{
+					ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default"},
+					Spec: expinfrav1.AWSMachinePoolSpec{
+						AWSLaunchTemplate: tt.lt,
+					},
+				},
+			}
+			if tt.overrides != nil {
+				machinePoolScope.AWSMachinePool.Spec.MixedInstancesPolicy = &expinfrav1.MixedInstancesPolicy{Overrides: tt.overrides}
+			}
+
+			g.Expect(s.ReconcileGPUCompatibility(machinePoolScope)).To(Succeed())
+			if tt.wantMessage == "" {
+				g.Expect(conditions.Has(machinePoolScope.AWSMachinePool, expinfrav1.AMILacksGPUDriversCondition)).To(BeFalse())
+				return
+			}
+			g.Expect(conditions.GetMessage(machinePoolScope.AWSMachinePool, expinfrav1.AMILacksGPUDriversCondition)).To(Equal(tt.wantMessage))
+
+			// The instance types and the image are cached.
+			g.Expect(s.ReconcileGPUCompatibility(machinePoolScope)).To(Succeed())
+		})
+	}
+}
+
+func TestIsEKSOptimizedImageWithoutGPUDrivers(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{name: "amazon-eks-node-1.29-v20240117", want: true},
+		{name: "amazon-eks-arm64-node-1.29-v20240117", want: true},
+		{name: "amazon-eks-node-al2023-x86_64-standard-1.30-v20240625", want: true},
+		{name: "bottlerocket-aws-k8s-1.30-x86_64-v1.20.3-5d9ac849", want: true},
+		{name: "amazon-eks-gpu-node-1.29-v20240117", want: false},
+		{name: "amazon-eks-node-al2023-x86_64-nvidia-1.30-v20240625", want: false},
+		{name: "bottlerocket-aws-k8s-1.30-nvidia-x86_64-v1.20.3-5d9ac849", want: false},
+		{name: "capa-ami-ubuntu-24.04-1.30.0-00-1718000000", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(isEKSOptimizedImageWithoutGPUDrivers(tt.name)).To(Equal(tt.want))
+		})
+	}
+}
@@ -0,0 +1,42 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package gpu checks that the AMI of a machine pool launching instance types with NVIDIA GPUs ships
+// the GPU drivers, as the nodes join the cluster without their GPUs otherwise.
+package gpu
+
+import (
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+)
+
+// Service checks the GPU compatibility of the AMI of a machine pool.
+type Service struct {
+	scope     scope.EC2Scope
+	cache     *Cache
+	EC2Client ec2iface.EC2API
+}
+
+// NewService returns a new service given the EC2 scope and the cache of the described instance types and
+// images. The instance types and images are described on every check when the cache is nil.
+func NewService(ec2Scope scope.EC2Scope, cache *Cache) *Service {
+	return &Service{
+		scope:     ec2Scope,
+		cache:     cache,
+		EC2Client: scope.NewEC2Client(ec2Scope, ec2Scope, ec2Scope, ec2Scope.InfraCluster()),
+	}
+}
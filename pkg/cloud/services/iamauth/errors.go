@@ -25,4 +25,8 @@ var (
 	// ErrClientRequired defines an error for when a k8s client is required but
 	// not supplied.
 	ErrClientRequired = errors.New("k8s client required")
+
+	// ErrExternalManagementNotAcknowledged defines an error for when the aws-auth ConfigMap is managed
+	// outside of the controller while the cluster has self-managed nodes, without an acknowledgement.
+	ErrExternalManagementNotAcknowledged = errors.New("aws-auth is managed externally and the cluster has self-managed nodes, but acknowledgeExternalManagement isn't set")
 )
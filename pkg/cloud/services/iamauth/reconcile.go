@@ -30,6 +30,7 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
 	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	expclusterv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
 )
@@ -38,6 +39,10 @@ import (
 func (s *Service) ReconcileIAMAuthenticator(ctx context.Context) error {
 	s.scope.Info("Reconciling aws-iam-authenticator configuration", "cluster", klog.KRef(s.scope.Namespace(), s.scope.Name()))
 
+	if s.scope.IAMAuthConfig().IsManagedExternally() {
+		return s.checkExternalManagement(ctx)
+	}
+
 	remoteClient, err := s.scope.RemoteClient()
 	if err != nil {
 		s.scope.Error(err, "getting client for remote cluster")
@@ -92,6 +97,32 @@ func (s *Service) ReconcileIAMAuthenticator(ctx context.Context) error {
 	return nil
 }
 
+// checkExternalManagement is used instead of reconciling the aws-iam-authenticator configuration when it is
+// managed outside of the controller. The node roles of the self-managed nodes must then be mapped by the
+// external tool, which has to be acknowledged in the spec.
+func (s *Service) checkExternalManagement(ctx context.Context) error {
+	s.scope.Info("Skipping aws-iam-authenticator configuration as it is managed externally", "cluster", klog.KRef(s.scope.Namespace(), s.scope.Name()))
+
+	if s.scope.IAMAuthConfig().AcknowledgeExternalManagement {
+		return nil
+	}
+	selfManaged, err := s.hasSelfManagedNodes(ctx)
+	if err != nil {
+		return fmt.Errorf("checking for self-managed nodes: %w", err)
+	}
+	if selfManaged {
+		record.Warnf(s.scope.InfraCluster(), "ExternalManagementNotAcknowledged",
+			"aws-auth is managed externally, set spec.iamAuthenticatorConfig.acknowledgeExternalManagement once the roles of the self-managed nodes are mapped")
+		return ErrExternalManagementNotAcknowledged
+	}
+	return nil
+}
+
+// hasSelfManagedNodes returns whether the cluster has nodes whose role must be mapped in aws-auth.
+func (s *Service) hasSelfManagedNodes(ctx context.Context) (bool, error) {
+	return ekscontrolplanev1.HasSelfManagedNodes(ctx, s.client, s.scope.Namespace(), s.scope.Name())
+}
+
 func (s *Service) getARNForRole(role string) (string, error) {
 	input := &iam.GetRoleInput{
 		RoleName: aws.String(role),
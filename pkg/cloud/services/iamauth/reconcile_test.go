@@ -108,6 +108,46 @@ func TestReconcileIAMAuth(t *testing.T) {
 			g.Expect(testEnv.Cleanup(ctx, namespace, eksCluster, awsMP, mp, awsMachineTemplate, md, controllerIdentity)).To(Succeed())
 		})
 	})
+	t.Run("Should require an acknowledgement to skip aws-auth with self-managed nodes", func(t *testing.T) {
+		g := NewWithT(t)
+		setup(t)
+		namespace, err := testEnv.CreateNamespace(ctx, fmt.Sprintf("integ-test-%s", util.RandomString(5)))
+		g.Expect(err).To(BeNil())
+		ns := namespace.Name
+		name := "default"
+		eksCluster := createEKSCluster(name, ns)
+		eksCluster.Spec.IAMAuthenticatorConfig = &ekscontrolplanev1.IAMAuthenticatorConfig{
+			ManagementMode: ekscontrolplanev1.IAMAuthenticatorManagementModeNone,
+		}
+		g.Expect(testEnv.Create(ctx, eksCluster)).To(Succeed())
+		md := createMachineDeploymentForCluster(name, ns, eksCluster.Name, corev1.ObjectReference{
+			Kind:      "AWSMachineTemplate",
+			Name:      name,
+			Namespace: ns,
+		})
+		g.Expect(testEnv.Create(ctx, md)).To(Succeed())
+
+		managedScope, err := scope.NewManagedControlPlaneScope(scope.ManagedControlPlaneScopeParams{
+			Client:       testEnv,
+			ControlPlane: eksCluster,
+			Cluster: &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: ns,
+				},
+			},
+		})
+		g.Expect(err).To(BeNil(), "failed to create managedScope")
+		authService := NewService(managedScope, BackendTypeConfigMap, managedScope.Client)
+		g.Expect(authService.ReconcileIAMAuthenticator(ctx)).To(MatchError(ErrExternalManagementNotAcknowledged))
+
+		eksCluster.Spec.IAMAuthenticatorConfig.AcknowledgeExternalManagement = true
+		g.Expect(authService.ReconcileIAMAuthenticator(ctx)).To(Succeed())
+		defer teardown()
+		defer t.Cleanup(func() {
+			g.Expect(testEnv.Cleanup(ctx, namespace, eksCluster, md)).To(Succeed())
+		})
+	})
 }
 
 func createEKSCluster(name, namespace string) *ekscontrolplanev1.AWSManagedControlPlane {
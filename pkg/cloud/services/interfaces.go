@@ -43,13 +43,35 @@ type ASGInterface interface {
 	GetASGByName(scope *scope.MachinePoolScope) (*expinfrav1.AutoScalingGroup, error)
 	CreateASG(scope *scope.MachinePoolScope) (*expinfrav1.AutoScalingGroup, error)
 	UpdateASG(scope *scope.MachinePoolScope) error
-	StartASGInstanceRefresh(scope *scope.MachinePoolScope) error
+	StartASGInstanceRefresh(scope *scope.MachinePoolScope) (string, error)
 	CanStartASGInstanceRefresh(scope *scope.MachinePoolScope) (bool, error)
+	CancelASGInstanceRefresh(name string) error
+	DescribeLatestInstanceRefresh(name string) (*expinfrav1.InstanceRefreshStatus, error)
 	UpdateResourceTags(resourceID *string, create, remove map[string]string) error
 	DeleteASGAndWait(id string) error
+	// AdoptASG tags an existing ASG named after the machine pool as owned by the cluster.
+	AdoptASG(scope *scope.MachinePoolScope) error
+	// ReleaseASG restores the sizes of an adopted ASG and removes the tags marking it as owned by the cluster.
+	ReleaseASG(asg *expinfrav1.AutoScalingGroup, minSize, maxSize, desiredCapacity int32) error
 	SuspendProcesses(name string, processes []string) error
 	ResumeProcesses(name string, processes []string) error
+	EnterStandby(name string, instanceIDs []string) error
+	ExitStandby(name string, instanceIDs []string) error
+	SetInstanceProtection(name string, instanceIDs []string, protected bool) error
+	DetachInstances(name string, instanceIDs []string) error
+	TerminateInstanceInASG(instanceID string, decrementCapacity bool) error
+	EnableMetricsCollection(name, granularity string, metrics []string) error
+	DisableMetricsCollection(name string, metrics []string) error
 	SubnetIDs(scope *scope.MachinePoolScope) ([]string, error)
+	ReconcileNodeTerminationLifecycleHook(name string, enabled bool) error
+	ReconcileLifecycleHooks(name string, hooks []expinfrav1.AWSLifecycleHook) error
+	CompleteLifecycleAction(name, hookName, instanceID string, result expinfrav1.LifecycleActionResult) error
+	ReconcileScalingPolicies(name string, policies []expinfrav1.ScalingPolicy, current []expinfrav1.ScalingPolicyStatus) ([]expinfrav1.ScalingPolicyStatus, error)
+	ReconcileAZFailures(scope *scope.MachinePoolScope) error
+	ReconcileScaleEvents(scope *scope.MachinePoolScope) error
+	ReconcileTargetGroups(scope *scope.MachinePoolScope) error
+	ReconcileWarmPool(name string, warmPool *expinfrav1.WarmPool) error
+	GetPredictiveScalingForecast(name, policyName string) (*expinfrav1.PredictiveScalingForecast, error)
 }
 
 // EC2Interface encapsulates the methods exposed to the machine
@@ -76,16 +98,41 @@ type EC2Interface interface {
 	GetLaunchTemplateLatestVersion(id string) (string, error)
 	CreateLaunchTemplate(scope scope.LaunchTemplateScope, imageID *string, userDataSecretKey apimachinerytypes.NamespacedName, userData []byte) (string, error)
 	CreateLaunchTemplateVersion(id string, scope scope.LaunchTemplateScope, imageID *string, userDataSecretKey apimachinerytypes.NamespacedName, userData []byte) error
-	PruneLaunchTemplateVersions(id string) error
+	// CreateLaunchTemplateVersionFromVersion creates a version of a launch template copied from one of its
+	// versions, and returns the created version.
+	CreateLaunchTemplateVersionFromVersion(id string, sourceVersion string) (string, error)
+	PruneLaunchTemplateVersions(id string, keepVersion string) error
+	// PruneLaunchTemplateVersionsForQuota deletes old versions of a launch template once the launch template
+	// version quota is exceeded, and returns the number of deleted versions.
+	PruneLaunchTemplateVersionsForQuota(id string, keepVersions []string, instanceRefreshInFlight bool) (int, error)
+	// PruneLaunchTemplateVersionsForRetention deletes the versions of a launch template older than the number of
+	// most recent versions to retain, and returns the number of deleted versions.
+	PruneLaunchTemplateVersionsForRetention(id string, retain int, keepVersions []string, instanceRefreshInFlight bool) (int, error)
+	ValidateLaunchTemplateVersion(scope scope.LaunchTemplateScope, id string, version string) error
+	DeleteLaunchTemplateVersion(id string, version string) error
 	DeleteLaunchTemplate(id string) error
-	LaunchTemplateNeedsUpdate(scope scope.LaunchTemplateScope, incoming *expinfrav1.AWSLaunchTemplate, existing *expinfrav1.AWSLaunchTemplate) (bool, error)
+	// DeleteLaunchTemplateByName deletes a launch template by name, when its ID isn't known.
+	DeleteLaunchTemplateByName(name string) error
+	LaunchTemplateChangedFields(scope scope.LaunchTemplateScope, incoming *expinfrav1.AWSLaunchTemplate, existing *expinfrav1.AWSLaunchTemplate) ([]string, error)
+	// LaunchTemplateVersionChangedBeyondUserData returns whether two versions of a launch template differ in more
+	// than their userdata.
+	LaunchTemplateVersionChangedBeyondUserData(id string, version string, otherVersion string) (bool, error)
 	DeleteBastion() error
 	ReconcileBastion() error
+	// DescribeCapacityBlock returns the state and the window of a Capacity Block for ML.
+	DescribeCapacityBlock(capacityReservationID string) (*expinfrav1.CapacityBlockStatus, error)
+	// ReconcileSSHKeyPair imports the cluster's SSH key pair and reports drift of the imported key pair.
+	ReconcileSSHKeyPair(scope scope.SSHKeyPairScope) error
+	// DeleteSSHKeyPair deletes the cluster's SSH key pair if it's owned by the cluster.
+	DeleteSSHKeyPair(scope scope.SSHKeyPairScope) error
 	// ReconcileElasticIPFromPublicPool reconciles the elastic IP from a custom Public IPv4 Pool.
 	ReconcileElasticIPFromPublicPool(pool *infrav1.ElasticIPPool, instance *infrav1.Instance) (bool, error)
 
 	// ReleaseElasticIP reconciles the elastic IP from a custom Public IPv4 Pool.
 	ReleaseElasticIP(instanceID string) error
+
+	// ReconcileLegacyClusterTag updates the kubernetes.io/cluster/<name> tag of an instance and of its network interfaces.
+	ReconcileLegacyClusterTag(instance *infrav1.Instance) error
 }
 
 // MachinePoolReconcileInterface encapsulates high-level reconciliation functions regarding EC2 reconciliation. It is
@@ -111,6 +158,7 @@ type ELBInterface interface {
 	ReconcileLoadbalancers() error
 	IsInstanceRegisteredWithAPIServerELB(i *infrav1.Instance) (bool, error)
 	IsInstanceRegisteredWithAPIServerLB(i *infrav1.Instance, lb *infrav1.AWSLoadBalancerSpec) ([]string, bool, error)
+	GetAPIServerLBTargetHealth(i *infrav1.Instance, lb *infrav1.AWSLoadBalancerSpec) (string, error)
 	DeregisterInstanceFromAPIServerELB(i *infrav1.Instance) error
 	DeregisterInstanceFromAPIServerLB(targetGroupArn string, i *infrav1.Instance) error
 	RegisterInstanceWithAPIServerELB(i *infrav1.Instance) error
@@ -129,6 +177,8 @@ type NetworkInterface interface {
 type SecurityGroupInterface interface {
 	DeleteSecurityGroups() error
 	ReconcileSecurityGroups() error
+	DeleteDedicatedSecurityGroup(poolScope scope.DedicatedSecurityGroupScope) error
+	ReconcileDedicatedSecurityGroup(poolScope scope.DedicatedSecurityGroupScope) error
 }
 
 // ObjectStoreInterface encapsulates the methods exposed to the machine actuator.
@@ -51,6 +51,20 @@ func (m *MockASGInterface) EXPECT() *MockASGInterfaceMockRecorder {
 	return m.recorder
 }
 
+// AdoptASG mocks base method.
+func (m *MockASGInterface) AdoptASG(arg0 *scope.MachinePoolScope) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdoptASG", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdoptASG indicates an expected call of AdoptASG.
+func (mr *MockASGInterfaceMockRecorder) AdoptASG(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdoptASG", reflect.TypeOf((*MockASGInterface)(nil).AdoptASG), arg0)
+}
+
 // ASGIfExists mocks base method.
 func (m *MockASGInterface) ASGIfExists(arg0 *string) (*v1beta2.AutoScalingGroup, error) {
 	m.ctrl.T.Helper()
@@ -66,6 +80,20 @@ func (mr *MockASGInterfaceMockRecorder) ASGIfExists(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ASGIfExists", reflect.TypeOf((*MockASGInterface)(nil).ASGIfExists), arg0)
 }
 
+// CancelASGInstanceRefresh mocks base method.
+func (m *MockASGInterface) CancelASGInstanceRefresh(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelASGInstanceRefresh", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelASGInstanceRefresh indicates an expected call of CancelASGInstanceRefresh.
+func (mr *MockASGInterfaceMockRecorder) CancelASGInstanceRefresh(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelASGInstanceRefresh", reflect.TypeOf((*MockASGInterface)(nil).CancelASGInstanceRefresh), arg0)
+}
+
 // CanStartASGInstanceRefresh mocks base method.
 func (m *MockASGInterface) CanStartASGInstanceRefresh(arg0 *scope.MachinePoolScope) (bool, error) {
 	m.ctrl.T.Helper()
@@ -81,6 +109,20 @@ func (mr *MockASGInterfaceMockRecorder) CanStartASGInstanceRefresh(arg0 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanStartASGInstanceRefresh", reflect.TypeOf((*MockASGInterface)(nil).CanStartASGInstanceRefresh), arg0)
 }
 
+// CompleteLifecycleAction mocks base method.
+func (m *MockASGInterface) CompleteLifecycleAction(arg0, arg1, arg2 string, arg3 v1beta2.LifecycleActionResult) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteLifecycleAction", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteLifecycleAction indicates an expected call of CompleteLifecycleAction.
+func (mr *MockASGInterfaceMockRecorder) CompleteLifecycleAction(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteLifecycleAction", reflect.TypeOf((*MockASGInterface)(nil).CompleteLifecycleAction), arg0, arg1, arg2, arg3)
+}
+
 // CreateASG mocks base method.
 func (m *MockASGInterface) CreateASG(arg0 *scope.MachinePoolScope) (*v1beta2.AutoScalingGroup, error) {
 	m.ctrl.T.Helper()
@@ -110,6 +152,91 @@ func (mr *MockASGInterfaceMockRecorder) DeleteASGAndWait(arg0 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteASGAndWait", reflect.TypeOf((*MockASGInterface)(nil).DeleteASGAndWait), arg0)
 }
 
+// DescribeLatestInstanceRefresh mocks base method.
+func (m *MockASGInterface) DescribeLatestInstanceRefresh(arg0 string) (*v1beta2.InstanceRefreshStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeLatestInstanceRefresh", arg0)
+	ret0, _ := ret[0].(*v1beta2.InstanceRefreshStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeLatestInstanceRefresh indicates an expected call of DescribeLatestInstanceRefresh.
+func (mr *MockASGInterfaceMockRecorder) DescribeLatestInstanceRefresh(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeLatestInstanceRefresh", reflect.TypeOf((*MockASGInterface)(nil).DescribeLatestInstanceRefresh), arg0)
+}
+
+// DetachInstances mocks base method.
+func (m *MockASGInterface) DetachInstances(arg0 string, arg1 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DetachInstances", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DetachInstances indicates an expected call of DetachInstances.
+func (mr *MockASGInterfaceMockRecorder) DetachInstances(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetachInstances", reflect.TypeOf((*MockASGInterface)(nil).DetachInstances), arg0, arg1)
+}
+
+// DisableMetricsCollection mocks base method.
+func (m *MockASGInterface) DisableMetricsCollection(arg0 string, arg1 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableMetricsCollection", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableMetricsCollection indicates an expected call of DisableMetricsCollection.
+func (mr *MockASGInterfaceMockRecorder) DisableMetricsCollection(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableMetricsCollection", reflect.TypeOf((*MockASGInterface)(nil).DisableMetricsCollection), arg0, arg1)
+}
+
+// EnableMetricsCollection mocks base method.
+func (m *MockASGInterface) EnableMetricsCollection(arg0, arg1 string, arg2 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableMetricsCollection", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnableMetricsCollection indicates an expected call of EnableMetricsCollection.
+func (mr *MockASGInterfaceMockRecorder) EnableMetricsCollection(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableMetricsCollection", reflect.TypeOf((*MockASGInterface)(nil).EnableMetricsCollection), arg0, arg1, arg2)
+}
+
+// EnterStandby mocks base method.
+func (m *MockASGInterface) EnterStandby(arg0 string, arg1 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnterStandby", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnterStandby indicates an expected call of EnterStandby.
+func (mr *MockASGInterfaceMockRecorder) EnterStandby(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnterStandby", reflect.TypeOf((*MockASGInterface)(nil).EnterStandby), arg0, arg1)
+}
+
+// ExitStandby mocks base method.
+func (m *MockASGInterface) ExitStandby(arg0 string, arg1 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExitStandby", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExitStandby indicates an expected call of ExitStandby.
+func (mr *MockASGInterfaceMockRecorder) ExitStandby(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExitStandby", reflect.TypeOf((*MockASGInterface)(nil).ExitStandby), arg0, arg1)
+}
+
 // GetASGByName mocks base method.
 func (m *MockASGInterface) GetASGByName(arg0 *scope.MachinePoolScope) (*v1beta2.AutoScalingGroup, error) {
 	m.ctrl.T.Helper()
@@ -125,6 +252,134 @@ func (mr *MockASGInterfaceMockRecorder) GetASGByName(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetASGByName", reflect.TypeOf((*MockASGInterface)(nil).GetASGByName), arg0)
 }
 
+// GetPredictiveScalingForecast mocks base method.
+func (m *MockASGInterface) GetPredictiveScalingForecast(arg0, arg1 string) (*v1beta2.PredictiveScalingForecast, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPredictiveScalingForecast", arg0, arg1)
+	ret0, _ := ret[0].(*v1beta2.PredictiveScalingForecast)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPredictiveScalingForecast indicates an expected call of GetPredictiveScalingForecast.
+func (mr *MockASGInterfaceMockRecorder) GetPredictiveScalingForecast(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPredictiveScalingForecast", reflect.TypeOf((*MockASGInterface)(nil).GetPredictiveScalingForecast), arg0, arg1)
+}
+
+// ReconcileAZFailures mocks base method.
+func (m *MockASGInterface) ReconcileAZFailures(arg0 *scope.MachinePoolScope) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileAZFailures", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReconcileAZFailures indicates an expected call of ReconcileAZFailures.
+func (mr *MockASGInterfaceMockRecorder) ReconcileAZFailures(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileAZFailures", reflect.TypeOf((*MockASGInterface)(nil).ReconcileAZFailures), arg0)
+}
+
+// ReconcileLifecycleHooks mocks base method.
+func (m *MockASGInterface) ReconcileLifecycleHooks(arg0 string, arg1 []v1beta2.AWSLifecycleHook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileLifecycleHooks", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReconcileLifecycleHooks indicates an expected call of ReconcileLifecycleHooks.
+func (mr *MockASGInterfaceMockRecorder) ReconcileLifecycleHooks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileLifecycleHooks", reflect.TypeOf((*MockASGInterface)(nil).ReconcileLifecycleHooks), arg0, arg1)
+}
+
+// ReconcileNodeTerminationLifecycleHook mocks base method.
+func (m *MockASGInterface) ReconcileNodeTerminationLifecycleHook(arg0 string, arg1 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileNodeTerminationLifecycleHook", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReconcileNodeTerminationLifecycleHook indicates an expected call of ReconcileNodeTerminationLifecycleHook.
+func (mr *MockASGInterfaceMockRecorder) ReconcileNodeTerminationLifecycleHook(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileNodeTerminationLifecycleHook", reflect.TypeOf((*MockASGInterface)(nil).ReconcileNodeTerminationLifecycleHook), arg0, arg1)
+}
+
+// ReconcileScaleEvents mocks base method.
+func (m *MockASGInterface) ReconcileScaleEvents(arg0 *scope.MachinePoolScope) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileScaleEvents", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReconcileScaleEvents indicates an expected call of ReconcileScaleEvents.
+func (mr *MockASGInterfaceMockRecorder) ReconcileScaleEvents(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileScaleEvents", reflect.TypeOf((*MockASGInterface)(nil).ReconcileScaleEvents), arg0)
+}
+
+// ReconcileScalingPolicies mocks base method.
+func (m *MockASGInterface) ReconcileScalingPolicies(arg0 string, arg1 []v1beta2.ScalingPolicy, arg2 []v1beta2.ScalingPolicyStatus) ([]v1beta2.ScalingPolicyStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileScalingPolicies", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]v1beta2.ScalingPolicyStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReconcileScalingPolicies indicates an expected call of ReconcileScalingPolicies.
+func (mr *MockASGInterfaceMockRecorder) ReconcileScalingPolicies(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileScalingPolicies", reflect.TypeOf((*MockASGInterface)(nil).ReconcileScalingPolicies), arg0, arg1, arg2)
+}
+
+// ReconcileTargetGroups mocks base method.
+func (m *MockASGInterface) ReconcileTargetGroups(arg0 *scope.MachinePoolScope) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileTargetGroups", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReconcileTargetGroups indicates an expected call of ReconcileTargetGroups.
+func (mr *MockASGInterfaceMockRecorder) ReconcileTargetGroups(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileTargetGroups", reflect.TypeOf((*MockASGInterface)(nil).ReconcileTargetGroups), arg0)
+}
+
+// ReconcileWarmPool mocks base method.
+func (m *MockASGInterface) ReconcileWarmPool(arg0 string, arg1 *v1beta2.WarmPool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileWarmPool", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReconcileWarmPool indicates an expected call of ReconcileWarmPool.
+func (mr *MockASGInterfaceMockRecorder) ReconcileWarmPool(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileWarmPool", reflect.TypeOf((*MockASGInterface)(nil).ReconcileWarmPool), arg0, arg1)
+}
+
+// ReleaseASG mocks base method.
+func (m *MockASGInterface) ReleaseASG(arg0 *v1beta2.AutoScalingGroup, arg1, arg2, arg3 int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseASG", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseASG indicates an expected call of ReleaseASG.
+func (mr *MockASGInterfaceMockRecorder) ReleaseASG(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseASG", reflect.TypeOf((*MockASGInterface)(nil).ReleaseASG), arg0, arg1, arg2, arg3)
+}
+
 // ResumeProcesses mocks base method.
 func (m *MockASGInterface) ResumeProcesses(arg0 string, arg1 []string) error {
 	m.ctrl.T.Helper()
@@ -139,14 +394,29 @@ func (mr *MockASGInterfaceMockRecorder) ResumeProcesses(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumeProcesses", reflect.TypeOf((*MockASGInterface)(nil).ResumeProcesses), arg0, arg1)
 }
 
-// StartASGInstanceRefresh mocks base method.
-func (m *MockASGInterface) StartASGInstanceRefresh(arg0 *scope.MachinePoolScope) error {
+// SetInstanceProtection mocks base method.
+func (m *MockASGInterface) SetInstanceProtection(arg0 string, arg1 []string, arg2 bool) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "StartASGInstanceRefresh", arg0)
+	ret := m.ctrl.Call(m, "SetInstanceProtection", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
+// SetInstanceProtection indicates an expected call of SetInstanceProtection.
+func (mr *MockASGInterfaceMockRecorder) SetInstanceProtection(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetInstanceProtection", reflect.TypeOf((*MockASGInterface)(nil).SetInstanceProtection), arg0, arg1, arg2)
+}
+
+// StartASGInstanceRefresh mocks base method.
+func (m *MockASGInterface) StartASGInstanceRefresh(arg0 *scope.MachinePoolScope) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartASGInstanceRefresh", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // StartASGInstanceRefresh indicates an expected call of StartASGInstanceRefresh.
 func (mr *MockASGInterfaceMockRecorder) StartASGInstanceRefresh(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
@@ -182,6 +452,20 @@ func (mr *MockASGInterfaceMockRecorder) SuspendProcesses(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuspendProcesses", reflect.TypeOf((*MockASGInterface)(nil).SuspendProcesses), arg0, arg1)
 }
 
+// TerminateInstanceInASG mocks base method.
+func (m *MockASGInterface) TerminateInstanceInASG(arg0 string, arg1 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TerminateInstanceInASG", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TerminateInstanceInASG indicates an expected call of TerminateInstanceInASG.
+func (mr *MockASGInterfaceMockRecorder) TerminateInstanceInASG(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TerminateInstanceInASG", reflect.TypeOf((*MockASGInterface)(nil).TerminateInstanceInASG), arg0, arg1)
+}
+
 // UpdateASG mocks base method.
 func (m *MockASGInterface) UpdateASG(arg0 *scope.MachinePoolScope) error {
 	m.ctrl.T.Helper()
@@ -97,6 +97,21 @@ func (mr *MockEC2InterfaceMockRecorder) CreateLaunchTemplateVersion(arg0, arg1,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLaunchTemplateVersion", reflect.TypeOf((*MockEC2Interface)(nil).CreateLaunchTemplateVersion), arg0, arg1, arg2, arg3, arg4)
 }
 
+// CreateLaunchTemplateVersionFromVersion mocks base method.
+func (m *MockEC2Interface) CreateLaunchTemplateVersionFromVersion(arg0, arg1 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLaunchTemplateVersionFromVersion", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateLaunchTemplateVersionFromVersion indicates an expected call of CreateLaunchTemplateVersionFromVersion.
+func (mr *MockEC2InterfaceMockRecorder) CreateLaunchTemplateVersionFromVersion(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLaunchTemplateVersionFromVersion", reflect.TypeOf((*MockEC2Interface)(nil).CreateLaunchTemplateVersionFromVersion), arg0, arg1)
+}
+
 // DeleteBastion mocks base method.
 func (m *MockEC2Interface) DeleteBastion() error {
 	m.ctrl.T.Helper()
@@ -125,6 +140,63 @@ func (mr *MockEC2InterfaceMockRecorder) DeleteLaunchTemplate(arg0 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLaunchTemplate", reflect.TypeOf((*MockEC2Interface)(nil).DeleteLaunchTemplate), arg0)
 }
 
+// DeleteLaunchTemplateByName mocks base method.
+func (m *MockEC2Interface) DeleteLaunchTemplateByName(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLaunchTemplateByName", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLaunchTemplateByName indicates an expected call of DeleteLaunchTemplateByName.
+func (mr *MockEC2InterfaceMockRecorder) DeleteLaunchTemplateByName(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLaunchTemplateByName", reflect.TypeOf((*MockEC2Interface)(nil).DeleteLaunchTemplateByName), arg0)
+}
+
+// DeleteLaunchTemplateVersion mocks base method.
+func (m *MockEC2Interface) DeleteLaunchTemplateVersion(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLaunchTemplateVersion", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLaunchTemplateVersion indicates an expected call of DeleteLaunchTemplateVersion.
+func (mr *MockEC2InterfaceMockRecorder) DeleteLaunchTemplateVersion(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLaunchTemplateVersion", reflect.TypeOf((*MockEC2Interface)(nil).DeleteLaunchTemplateVersion), arg0, arg1)
+}
+
+// DeleteSSHKeyPair mocks base method.
+func (m *MockEC2Interface) DeleteSSHKeyPair(arg0 scope.SSHKeyPairScope) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSSHKeyPair", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSSHKeyPair indicates an expected call of DeleteSSHKeyPair.
+func (mr *MockEC2InterfaceMockRecorder) DeleteSSHKeyPair(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSSHKeyPair", reflect.TypeOf((*MockEC2Interface)(nil).DeleteSSHKeyPair), arg0)
+}
+
+// DescribeCapacityBlock mocks base method.
+func (m *MockEC2Interface) DescribeCapacityBlock(arg0 string) (*v1beta20.CapacityBlockStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeCapacityBlock", arg0)
+	ret0, _ := ret[0].(*v1beta20.CapacityBlockStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeCapacityBlock indicates an expected call of DescribeCapacityBlock.
+func (mr *MockEC2InterfaceMockRecorder) DescribeCapacityBlock(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeCapacityBlock", reflect.TypeOf((*MockEC2Interface)(nil).DescribeCapacityBlock), arg0)
+}
+
 // DetachSecurityGroupsFromNetworkInterface mocks base method.
 func (m *MockEC2Interface) DetachSecurityGroupsFromNetworkInterface(arg0 []string, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -276,19 +348,34 @@ func (mr *MockEC2InterfaceMockRecorder) InstanceIfExists(arg0 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstanceIfExists", reflect.TypeOf((*MockEC2Interface)(nil).InstanceIfExists), arg0)
 }
 
-// LaunchTemplateNeedsUpdate mocks base method.
-func (m *MockEC2Interface) LaunchTemplateNeedsUpdate(arg0 scope.LaunchTemplateScope, arg1, arg2 *v1beta20.AWSLaunchTemplate) (bool, error) {
+// LaunchTemplateChangedFields mocks base method.
+func (m *MockEC2Interface) LaunchTemplateChangedFields(arg0 scope.LaunchTemplateScope, arg1, arg2 *v1beta20.AWSLaunchTemplate) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LaunchTemplateChangedFields", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LaunchTemplateChangedFields indicates an expected call of LaunchTemplateChangedFields.
+func (mr *MockEC2InterfaceMockRecorder) LaunchTemplateChangedFields(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LaunchTemplateChangedFields", reflect.TypeOf((*MockEC2Interface)(nil).LaunchTemplateChangedFields), arg0, arg1, arg2)
+}
+
+// LaunchTemplateVersionChangedBeyondUserData mocks base method.
+func (m *MockEC2Interface) LaunchTemplateVersionChangedBeyondUserData(arg0, arg1, arg2 string) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "LaunchTemplateNeedsUpdate", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "LaunchTemplateVersionChangedBeyondUserData", arg0, arg1, arg2)
 	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// LaunchTemplateNeedsUpdate indicates an expected call of LaunchTemplateNeedsUpdate.
-func (mr *MockEC2InterfaceMockRecorder) LaunchTemplateNeedsUpdate(arg0, arg1, arg2 interface{}) *gomock.Call {
+// LaunchTemplateVersionChangedBeyondUserData indicates an expected call of LaunchTemplateVersionChangedBeyondUserData.
+func (mr *MockEC2InterfaceMockRecorder) LaunchTemplateVersionChangedBeyondUserData(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LaunchTemplateNeedsUpdate", reflect.TypeOf((*MockEC2Interface)(nil).LaunchTemplateNeedsUpdate), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LaunchTemplateVersionChangedBeyondUserData", reflect.TypeOf((*MockEC2Interface)(nil).LaunchTemplateVersionChangedBeyondUserData), arg0, arg1, arg2)
 }
 
 // ModifyInstanceMetadataOptions mocks base method.
@@ -306,17 +393,47 @@ func (mr *MockEC2InterfaceMockRecorder) ModifyInstanceMetadataOptions(arg0, arg1
 }
 
 // PruneLaunchTemplateVersions mocks base method.
-func (m *MockEC2Interface) PruneLaunchTemplateVersions(arg0 string) error {
+func (m *MockEC2Interface) PruneLaunchTemplateVersions(arg0, arg1 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PruneLaunchTemplateVersions", arg0)
+	ret := m.ctrl.Call(m, "PruneLaunchTemplateVersions", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // PruneLaunchTemplateVersions indicates an expected call of PruneLaunchTemplateVersions.
-func (mr *MockEC2InterfaceMockRecorder) PruneLaunchTemplateVersions(arg0 interface{}) *gomock.Call {
+func (mr *MockEC2InterfaceMockRecorder) PruneLaunchTemplateVersions(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneLaunchTemplateVersions", reflect.TypeOf((*MockEC2Interface)(nil).PruneLaunchTemplateVersions), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneLaunchTemplateVersions", reflect.TypeOf((*MockEC2Interface)(nil).PruneLaunchTemplateVersions), arg0, arg1)
+}
+
+// PruneLaunchTemplateVersionsForQuota mocks base method.
+func (m *MockEC2Interface) PruneLaunchTemplateVersionsForQuota(arg0 string, arg1 []string, arg2 bool) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PruneLaunchTemplateVersionsForQuota", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PruneLaunchTemplateVersionsForQuota indicates an expected call of PruneLaunchTemplateVersionsForQuota.
+func (mr *MockEC2InterfaceMockRecorder) PruneLaunchTemplateVersionsForQuota(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneLaunchTemplateVersionsForQuota", reflect.TypeOf((*MockEC2Interface)(nil).PruneLaunchTemplateVersionsForQuota), arg0, arg1, arg2)
+}
+
+// PruneLaunchTemplateVersionsForRetention mocks base method.
+func (m *MockEC2Interface) PruneLaunchTemplateVersionsForRetention(arg0 string, arg1 int, arg2 []string, arg3 bool) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PruneLaunchTemplateVersionsForRetention", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PruneLaunchTemplateVersionsForRetention indicates an expected call of PruneLaunchTemplateVersionsForRetention.
+func (mr *MockEC2InterfaceMockRecorder) PruneLaunchTemplateVersionsForRetention(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneLaunchTemplateVersionsForRetention", reflect.TypeOf((*MockEC2Interface)(nil).PruneLaunchTemplateVersionsForRetention), arg0, arg1, arg2, arg3)
 }
 
 // ReconcileBastion mocks base method.
@@ -348,6 +465,34 @@ func (mr *MockEC2InterfaceMockRecorder) ReconcileElasticIPFromPublicPool(arg0, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileElasticIPFromPublicPool", reflect.TypeOf((*MockEC2Interface)(nil).ReconcileElasticIPFromPublicPool), arg0, arg1)
 }
 
+// ReconcileLegacyClusterTag mocks base method.
+func (m *MockEC2Interface) ReconcileLegacyClusterTag(arg0 *v1beta2.Instance) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileLegacyClusterTag", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReconcileLegacyClusterTag indicates an expected call of ReconcileLegacyClusterTag.
+func (mr *MockEC2InterfaceMockRecorder) ReconcileLegacyClusterTag(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileLegacyClusterTag", reflect.TypeOf((*MockEC2Interface)(nil).ReconcileLegacyClusterTag), arg0)
+}
+
+// ReconcileSSHKeyPair mocks base method.
+func (m *MockEC2Interface) ReconcileSSHKeyPair(arg0 scope.SSHKeyPairScope) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileSSHKeyPair", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReconcileSSHKeyPair indicates an expected call of ReconcileSSHKeyPair.
+func (mr *MockEC2InterfaceMockRecorder) ReconcileSSHKeyPair(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileSSHKeyPair", reflect.TypeOf((*MockEC2Interface)(nil).ReconcileSSHKeyPair), arg0)
+}
+
 // ReleaseElasticIP mocks base method.
 func (m *MockEC2Interface) ReleaseElasticIP(arg0 string) error {
 	m.ctrl.T.Helper()
@@ -417,3 +562,17 @@ func (mr *MockEC2InterfaceMockRecorder) UpdateResourceTags(arg0, arg1, arg2 inte
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateResourceTags", reflect.TypeOf((*MockEC2Interface)(nil).UpdateResourceTags), arg0, arg1, arg2)
 }
+
+// ValidateLaunchTemplateVersion mocks base method.
+func (m *MockEC2Interface) ValidateLaunchTemplateVersion(arg0 scope.LaunchTemplateScope, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateLaunchTemplateVersion", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateLaunchTemplateVersion indicates an expected call of ValidateLaunchTemplateVersion.
+func (mr *MockEC2InterfaceMockRecorder) ValidateLaunchTemplateVersion(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateLaunchTemplateVersion", reflect.TypeOf((*MockEC2Interface)(nil).ValidateLaunchTemplateVersion), arg0, arg1, arg2)
+}
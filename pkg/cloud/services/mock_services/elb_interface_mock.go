@@ -92,6 +92,21 @@ func (mr *MockELBInterfaceMockRecorder) DeregisterInstanceFromAPIServerLB(arg0,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeregisterInstanceFromAPIServerLB", reflect.TypeOf((*MockELBInterface)(nil).DeregisterInstanceFromAPIServerLB), arg0, arg1)
 }
 
+// GetAPIServerLBTargetHealth mocks base method.
+func (m *MockELBInterface) GetAPIServerLBTargetHealth(arg0 *v1beta2.Instance, arg1 *v1beta2.AWSLoadBalancerSpec) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPIServerLBTargetHealth", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAPIServerLBTargetHealth indicates an expected call of GetAPIServerLBTargetHealth.
+func (mr *MockELBInterfaceMockRecorder) GetAPIServerLBTargetHealth(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIServerLBTargetHealth", reflect.TypeOf((*MockELBInterface)(nil).GetAPIServerLBTargetHealth), arg0, arg1)
+}
+
 // IsInstanceRegisteredWithAPIServerELB mocks base method.
 func (m *MockELBInterface) IsInstanceRegisteredWithAPIServerELB(arg0 *v1beta2.Instance) (bool, error) {
 	m.ctrl.T.Helper()
@@ -24,6 +24,7 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	scope "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 )
 
 // MockSecurityGroupInterface is a mock of SecurityGroupInterface interface.
@@ -49,6 +50,20 @@ func (m *MockSecurityGroupInterface) EXPECT() *MockSecurityGroupInterfaceMockRec
 	return m.recorder
 }
 
+// DeleteDedicatedSecurityGroup mocks base method.
+func (m *MockSecurityGroupInterface) DeleteDedicatedSecurityGroup(arg0 scope.DedicatedSecurityGroupScope) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDedicatedSecurityGroup", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDedicatedSecurityGroup indicates an expected call of DeleteDedicatedSecurityGroup.
+func (mr *MockSecurityGroupInterfaceMockRecorder) DeleteDedicatedSecurityGroup(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDedicatedSecurityGroup", reflect.TypeOf((*MockSecurityGroupInterface)(nil).DeleteDedicatedSecurityGroup), arg0)
+}
+
 // DeleteSecurityGroups mocks base method.
 func (m *MockSecurityGroupInterface) DeleteSecurityGroups() error {
 	m.ctrl.T.Helper()
@@ -63,6 +78,20 @@ func (mr *MockSecurityGroupInterfaceMockRecorder) DeleteSecurityGroups() *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSecurityGroups", reflect.TypeOf((*MockSecurityGroupInterface)(nil).DeleteSecurityGroups))
 }
 
+// ReconcileDedicatedSecurityGroup mocks base method.
+func (m *MockSecurityGroupInterface) ReconcileDedicatedSecurityGroup(arg0 scope.DedicatedSecurityGroupScope) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileDedicatedSecurityGroup", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReconcileDedicatedSecurityGroup indicates an expected call of ReconcileDedicatedSecurityGroup.
+func (mr *MockSecurityGroupInterfaceMockRecorder) ReconcileDedicatedSecurityGroup(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileDedicatedSecurityGroup", reflect.TypeOf((*MockSecurityGroupInterface)(nil).ReconcileDedicatedSecurityGroup), arg0)
+}
+
 // ReconcileSecurityGroups mocks base method.
 func (m *MockSecurityGroupInterface) ReconcileSecurityGroups() error {
 	m.ctrl.T.Helper()
@@ -36,7 +36,7 @@ import (
 )
 
 func (s *Service) reconcileCarrierGateway() error {
-	if s.scope.VPC().IsUnmanaged(s.scope.Name()) {
+	if s.scope.VPC().IsUnmanaged(s.scope.Name(), s.scope.OwnershipTagPrefixes()...) {
 		s.scope.Trace("Skipping carrier gateway reconcile in unmanaged mode")
 		return nil
 	}
@@ -50,7 +50,7 @@ func (s *Service) reconcileCarrierGateway() error {
 
 	cagw, err := s.describeVpcCarrierGateway()
 	if awserrors.IsNotFound(err) {
-		if s.scope.VPC().IsUnmanaged(s.scope.Name()) {
+		if s.scope.VPC().IsUnmanaged(s.scope.Name(), s.scope.OwnershipTagPrefixes()...) {
 			return errors.Errorf("failed to validate network: no carrier gateway found in VPC %q", s.scope.VPC().ID)
 		}
 
@@ -67,7 +67,8 @@ func (s *Service) reconcileCarrierGateway() error {
 
 	// Make sure tags are up-to-date.
 	if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
-		buildParams := s.getGatewayTagParams(*cagw.CarrierGatewayId)
+		buildParams := s.getGatewayTagParams(*cagw.CarrierGatewayId, infrav1.ResourceTypeCarrierGateway)
+		s.scope.ResourceNaming().KeepName(&buildParams, converters.TagsToMap(cagw.Tags))
 		tagsBuilder := tags.New(&buildParams, tags.WithEC2(s.EC2Client))
 		if err := tagsBuilder.Ensure(converters.TagsToMap(cagw.Tags)); err != nil {
 			return false, err
@@ -82,7 +83,7 @@ func (s *Service) reconcileCarrierGateway() error {
 }
 
 func (s *Service) deleteCarrierGateway() error {
-	if s.scope.VPC().IsUnmanaged(s.scope.Name()) {
+	if s.scope.VPC().IsUnmanaged(s.scope.Name(), s.scope.OwnershipTagPrefixes()...) {
 		s.scope.Trace("Skipping carrier gateway deletion in unmanaged mode")
 		return nil
 	}
@@ -113,7 +114,7 @@ func (s *Service) createCarrierGateway() (*ec2.CarrierGateway, error) {
 	ig, err := s.EC2Client.CreateCarrierGatewayWithContext(context.TODO(), &ec2.CreateCarrierGatewayInput{
 		VpcId: aws.String(s.scope.VPC().ID),
 		TagSpecifications: []*ec2.TagSpecification{
-			tags.BuildParamsToTagSpecification(ec2.ResourceTypeCarrierGateway, s.getGatewayTagParams(services.TemporaryResourceID)),
+			tags.BuildParamsToTagSpecification(ec2.ResourceTypeCarrierGateway, s.getGatewayTagParams(services.TemporaryResourceID, infrav1.ResourceTypeCarrierGateway)),
 		},
 	})
 	if err != nil {
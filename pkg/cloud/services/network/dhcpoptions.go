@@ -0,0 +1,295 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/converters"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/tags"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+)
+
+const (
+	dhcpOptionsDomainNameKey        = "domain-name"
+	dhcpOptionsDomainNameServersKey = "domain-name-servers"
+	dhcpOptionsNTPServersKey        = "ntp-servers"
+
+	// defaultDHCPOptionsID is the special id used to associate the AWS default DHCP options with a VPC.
+	defaultDHCPOptionsID = "default"
+)
+
+// reconcileDHCPOptions makes sure the managed VPC is associated with a DHCP options set matching
+// the spec. DHCP options sets cannot be modified, so a changed spec results in a new set being
+// created and associated with the VPC, and the previous set owned by the cluster being deleted.
+func (s *Service) reconcileDHCPOptions() error {
+	vpc := s.scope.VPC()
+	if vpc.IsUnmanaged(s.scope.Name(), s.scope.OwnershipTagPrefixes()...) {
+		if vpc.DHCPOptions != nil {
+			record.Warnf(s.scope.InfraCluster(), "IgnoredDHCPOptions", "Ignoring DHCP options for unmanaged VPC %q", vpc.ID)
+		}
+		s.scope.Trace("Skipping DHCP options reconcile in unmanaged mode")
+		return nil
+	}
+
+	if vpc.DHCPOptions == nil && vpc.DHCPOptionsID == nil {
+		return nil
+	}
+
+	s.scope.Debug("Reconciling DHCP options")
+
+	currentID, err := s.describeVPCDHCPOptionsID()
+	if err != nil {
+		return err
+	}
+
+	// The DHCP options have been removed from the spec, restore the default options and
+	// clean up the set previously created for the cluster.
+	if vpc.DHCPOptions == nil {
+		if currentID == aws.StringValue(vpc.DHCPOptionsID) {
+			if err := s.associateDHCPOptions(defaultDHCPOptionsID); err != nil {
+				return err
+			}
+		}
+		if err := s.deleteDHCPOptions(aws.StringValue(vpc.DHCPOptionsID)); err != nil {
+			return err
+		}
+		vpc.DHCPOptionsID = nil
+		return nil
+	}
+
+	var previous *ec2.DhcpOptions
+	desiredID := aws.StringValue(vpc.DHCPOptionsID)
+	if desiredID != "" {
+		existing, err := s.describeDHCPOptions(desiredID)
+		switch {
+		case awserrors.IsNotFound(err):
+			desiredID = ""
+		case err != nil:
+			return err
+		case !dhcpConfigurationsEqual(existing.DhcpConfigurations, vpc.DHCPOptions):
+			previous = existing
+			desiredID = ""
+		}
+	}
+
+	if desiredID == "" {
+		desiredID, err = s.createDHCPOptions(vpc.DHCPOptions)
+		if err != nil {
+			return err
+		}
+		vpc.DHCPOptionsID = aws.String(desiredID)
+	}
+
+	if currentID != desiredID {
+		if err := s.associateDHCPOptions(desiredID); err != nil {
+			return err
+		}
+	}
+
+	if previous != nil {
+		if err := s.deleteOwnedDHCPOptions(previous); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) describeVPCDHCPOptionsID() (string, error) {
+	out, err := s.EC2Client.DescribeVpcsWithContext(context.TODO(), &ec2.DescribeVpcsInput{
+		VpcIds: []*string{aws.String(s.scope.VPC().ID)},
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to describe vpc %q", s.scope.VPC().ID)
+	}
+
+	if len(out.Vpcs) == 0 {
+		return "", awserrors.NewNotFound(fmt.Sprintf("could not find vpc %q", s.scope.VPC().ID))
+	}
+
+	return aws.StringValue(out.Vpcs[0].DhcpOptionsId), nil
+}
+
+func (s *Service) describeDHCPOptions(id string) (*ec2.DhcpOptions, error) {
+	out, err := s.EC2Client.DescribeDhcpOptionsWithContext(context.TODO(), &ec2.DescribeDhcpOptionsInput{
+		DhcpOptionsIds: []*string{aws.String(id)},
+	})
+	if err != nil {
+		if awserrors.IsNotFound(err) {
+			return nil, awserrors.NewNotFound(fmt.Sprintf("could not find DHCP options %q", id))
+		}
+		return nil, errors.Wrapf(err, "failed to describe DHCP options %q", id)
+	}
+
+	if len(out.DhcpOptions) == 0 {
+		return nil, awserrors.NewNotFound(fmt.Sprintf("could not find DHCP options %q", id))
+	}
+
+	return out.DhcpOptions[0], nil
+}
+
+func (s *Service) createDHCPOptions(options *infrav1.DHCPOptions) (string, error) {
+	out, err := s.EC2Client.CreateDhcpOptionsWithContext(context.TODO(), &ec2.CreateDhcpOptionsInput{
+		DhcpConfigurations: toNewDHCPConfigurations(options),
+		TagSpecifications: []*ec2.TagSpecification{
+			tags.BuildParamsToTagSpecification(ec2.ResourceTypeDhcpOptions, s.getDHCPOptionsTagParams(services.TemporaryResourceID)),
+		},
+	})
+	if err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedCreateDHCPOptions", "Failed to create new managed DHCP options: %v", err)
+		return "", errors.Wrap(err, "failed to create DHCP options")
+	}
+
+	id := aws.StringValue(out.DhcpOptions.DhcpOptionsId)
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulCreateDHCPOptions", "Created new managed DHCP options %q", id)
+	s.scope.Info("Created DHCP options", "dhcp-options-id", id)
+
+	return id, nil
+}
+
+func (s *Service) associateDHCPOptions(id string) error {
+	if _, err := s.EC2Client.AssociateDhcpOptionsWithContext(context.TODO(), &ec2.AssociateDhcpOptionsInput{
+		DhcpOptionsId: aws.String(id),
+		VpcId:         aws.String(s.scope.VPC().ID),
+	}); err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedAssociateDHCPOptions", "Failed to associate DHCP options %q with VPC %q: %v", id, s.scope.VPC().ID, err)
+		return errors.Wrapf(err, "failed to associate DHCP options %q with vpc %q", id, s.scope.VPC().ID)
+	}
+
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulAssociateDHCPOptions", "Associated DHCP options %q with VPC %q", id, s.scope.VPC().ID)
+	s.scope.Info("Associated DHCP options with VPC", "dhcp-options-id", id, "vpc-id", s.scope.VPC().ID)
+
+	return nil
+}
+
+func (s *Service) deleteDHCPOptions(id string) error {
+	options, err := s.describeDHCPOptions(id)
+	switch {
+	case awserrors.IsNotFound(err):
+		return nil
+	case err != nil:
+		return err
+	}
+	return s.deleteOwnedDHCPOptions(options)
+}
+
+// deleteOwnedDHCPOptions deletes a DHCP options set created for the cluster. A set not tagged as owned by the
+// cluster, such as one whose ID was put in the spec by hand, may be used by other VPCs and is left in place.
+func (s *Service) deleteOwnedDHCPOptions(options *ec2.DhcpOptions) error {
+	id := aws.StringValue(options.DhcpOptionsId)
+	if !converters.TagsToMap(options.Tags).HasOwned(s.scope.Name(), s.scope.OwnershipTagPrefixes()...) {
+		s.scope.Info("Skipping deletion of DHCP options not owned by the cluster", "dhcp-options-id", id)
+		return nil
+	}
+
+	if _, err := s.EC2Client.DeleteDhcpOptionsWithContext(context.TODO(), &ec2.DeleteDhcpOptionsInput{
+		DhcpOptionsId: aws.String(id),
+	}); err != nil {
+		if awserrors.IsNotFound(err) {
+			return nil
+		}
+		record.Warnf(s.scope.InfraCluster(), "FailedDeleteDHCPOptions", "Failed to delete managed DHCP options %q: %v", id, err)
+		return errors.Wrapf(err, "failed to delete DHCP options %q", id)
+	}
+
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulDeleteDHCPOptions", "Deleted managed DHCP options %q", id)
+	s.scope.Info("Deleted DHCP options", "dhcp-options-id", id)
+
+	return nil
+}
+
+func (s *Service) getDHCPOptionsTagParams(id string) infrav1.BuildParams {
+	name := s.scope.ResourceNaming().Name(fmt.Sprintf("%s-dhcp-options", s.scope.Name()), infrav1.ResourceNameData{
+		ClusterName:  s.scope.Name(),
+		ResourceType: infrav1.ResourceTypeDHCPOptions,
+		Role:         infrav1.CommonRoleTagValue,
+	})
+
+	return infrav1.BuildParams{
+		ClusterName:        s.scope.Name(),
+		OwnershipTagPrefix: s.scope.OwnershipTagPrefix(),
+		ResourceID:         id,
+		Lifecycle:          infrav1.ResourceLifecycleOwned,
+		Name:               aws.String(name),
+		Role:               aws.String(infrav1.CommonRoleTagValue),
+		Additional:         s.scope.AdditionalTags(),
+	}
+}
+
+// dhcpOptionsToMap returns the DHCP configuration values keyed by the AWS option name.
+func dhcpOptionsToMap(options *infrav1.DHCPOptions) map[string][]string {
+	res := map[string][]string{}
+	if options.DomainName != nil && *options.DomainName != "" {
+		res[dhcpOptionsDomainNameKey] = []string{*options.DomainName}
+	}
+	if len(options.DomainNameServers) > 0 {
+		res[dhcpOptionsDomainNameServersKey] = options.DomainNameServers
+	}
+	if len(options.NTPServers) > 0 {
+		res[dhcpOptionsNTPServersKey] = options.NTPServers
+	}
+	return res
+}
+
+func toNewDHCPConfigurations(options *infrav1.DHCPOptions) []*ec2.NewDhcpConfiguration {
+	desired := dhcpOptionsToMap(options)
+
+	keys := make([]string, 0, len(desired))
+	for k := range desired {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	res := make([]*ec2.NewDhcpConfiguration, 0, len(keys))
+	for _, k := range keys {
+		res = append(res, &ec2.NewDhcpConfiguration{
+			Key:    aws.String(k),
+			Values: aws.StringSlice(desired[k]),
+		})
+	}
+	return res
+}
+
+func dhcpConfigurationsEqual(configurations []*ec2.DhcpConfiguration, options *infrav1.DHCPOptions) bool {
+	desired := dhcpOptionsToMap(options)
+	if len(configurations) != len(desired) {
+		return false
+	}
+
+	for _, c := range configurations {
+		values, ok := desired[aws.StringValue(c.Key)]
+		if !ok || len(values) != len(c.Values) {
+			return false
+		}
+		for i, v := range c.Values {
+			if aws.StringValue(v.Value) != values[i] {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,263 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestReconcileDHCPOptions(t *testing.T) {
+	dhcpOptions := &infrav1.DHCPOptions{
+		DomainName:        aws.String("corp.example.com"),
+		DomainNameServers: []string{"10.0.0.2", "10.0.0.3"},
+	}
+	matchingConfigurations := []*ec2.DhcpConfiguration{
+		{
+			Key:    aws.String("domain-name"),
+			Values: []*ec2.AttributeValue{{Value: aws.String("corp.example.com")}},
+		},
+		{
+			Key:    aws.String("domain-name-servers"),
+			Values: []*ec2.AttributeValue{{Value: aws.String("10.0.0.2")}, {Value: aws.String("10.0.0.3")}},
+		},
+	}
+	managedTags := infrav1.Tags{
+		infrav1.ClusterTagKey("test-cluster"): "owned",
+	}
+	ownedTags := []*ec2.Tag{{Key: aws.String(infrav1.ClusterTagKey("test-cluster")), Value: aws.String("owned")}}
+
+	testCases := []struct {
+		name          string
+		input         infrav1.VPCSpec
+		expect        func(m *mocks.MockEC2APIMockRecorder)
+		wantOptionsID *string
+	}{
+		{
+			name: "unmanaged vpc is never associated",
+			input: infrav1.VPCSpec{
+				ID:          "vpc-unmanaged",
+				DHCPOptions: dhcpOptions,
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {},
+		},
+		{
+			name: "no dhcp options",
+			input: infrav1.VPCSpec{
+				ID:   "vpc-dhcp",
+				Tags: managedTags,
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {},
+		},
+		{
+			name: "creates and associates dhcp options",
+			input: infrav1.VPCSpec{
+				ID:          "vpc-dhcp",
+				Tags:        managedTags,
+				DHCPOptions: dhcpOptions,
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeVpcsWithContext(context.TODO(), gomock.Eq(&ec2.DescribeVpcsInput{
+					VpcIds: []*string{aws.String("vpc-dhcp")},
+				})).Return(&ec2.DescribeVpcsOutput{
+					Vpcs: []*ec2.Vpc{{VpcId: aws.String("vpc-dhcp"), DhcpOptionsId: aws.String("dopt-default")}},
+				}, nil)
+				m.CreateDhcpOptionsWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.CreateDhcpOptionsInput{})).
+					DoAndReturn(func(_ context.Context, input *ec2.CreateDhcpOptionsInput, _ ...interface{}) (*ec2.CreateDhcpOptionsOutput, error) {
+						g := NewWithT(t)
+						g.Expect(input.DhcpConfigurations).To(Equal([]*ec2.NewDhcpConfiguration{
+							{Key: aws.String("domain-name"), Values: aws.StringSlice([]string{"corp.example.com"})},
+							{Key: aws.String("domain-name-servers"), Values: aws.StringSlice([]string{"10.0.0.2", "10.0.0.3"})},
+						}))
+						g.Expect(aws.StringValue(input.TagSpecifications[0].ResourceType)).To(Equal(ec2.ResourceTypeDhcpOptions))
+						return &ec2.CreateDhcpOptionsOutput{
+							DhcpOptions: &ec2.DhcpOptions{DhcpOptionsId: aws.String("dopt-new")},
+						}, nil
+					})
+				m.AssociateDhcpOptionsWithContext(context.TODO(), gomock.Eq(&ec2.AssociateDhcpOptionsInput{
+					DhcpOptionsId: aws.String("dopt-new"),
+					VpcId:         aws.String("vpc-dhcp"),
+				})).Return(&ec2.AssociateDhcpOptionsOutput{}, nil)
+			},
+			wantOptionsID: aws.String("dopt-new"),
+		},
+		{
+			name: "keeps matching dhcp options",
+			input: infrav1.VPCSpec{
+				ID:            "vpc-dhcp",
+				Tags:          managedTags,
+				DHCPOptions:   dhcpOptions,
+				DHCPOptionsID: aws.String("dopt-current"),
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeVpcsWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeVpcsInput{})).Return(&ec2.DescribeVpcsOutput{
+					Vpcs: []*ec2.Vpc{{VpcId: aws.String("vpc-dhcp"), DhcpOptionsId: aws.String("dopt-current")}},
+				}, nil)
+				m.DescribeDhcpOptionsWithContext(context.TODO(), gomock.Eq(&ec2.DescribeDhcpOptionsInput{
+					DhcpOptionsIds: []*string{aws.String("dopt-current")},
+				})).Return(&ec2.DescribeDhcpOptionsOutput{
+					DhcpOptions: []*ec2.DhcpOptions{{DhcpOptionsId: aws.String("dopt-current"), DhcpConfigurations: matchingConfigurations}},
+				}, nil)
+			},
+			wantOptionsID: aws.String("dopt-current"),
+		},
+		{
+			name: "replaces changed dhcp options",
+			input: infrav1.VPCSpec{
+				ID:            "vpc-dhcp",
+				Tags:          managedTags,
+				DHCPOptions:   dhcpOptions,
+				DHCPOptionsID: aws.String("dopt-current"),
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeVpcsWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeVpcsInput{})).Return(&ec2.DescribeVpcsOutput{
+					Vpcs: []*ec2.Vpc{{VpcId: aws.String("vpc-dhcp"), DhcpOptionsId: aws.String("dopt-current")}},
+				}, nil)
+				m.DescribeDhcpOptionsWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeDhcpOptionsInput{})).Return(&ec2.DescribeDhcpOptionsOutput{
+					DhcpOptions: []*ec2.DhcpOptions{{DhcpOptionsId: aws.String("dopt-current"), DhcpConfigurations: matchingConfigurations[:1], Tags: ownedTags}},
+				}, nil)
+				m.CreateDhcpOptionsWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.CreateDhcpOptionsInput{})).Return(&ec2.CreateDhcpOptionsOutput{
+					DhcpOptions: &ec2.DhcpOptions{DhcpOptionsId: aws.String("dopt-new")},
+				}, nil)
+				m.AssociateDhcpOptionsWithContext(context.TODO(), gomock.Eq(&ec2.AssociateDhcpOptionsInput{
+					DhcpOptionsId: aws.String("dopt-new"),
+					VpcId:         aws.String("vpc-dhcp"),
+				})).Return(&ec2.AssociateDhcpOptionsOutput{}, nil)
+				m.DeleteDhcpOptionsWithContext(context.TODO(), gomock.Eq(&ec2.DeleteDhcpOptionsInput{
+					DhcpOptionsId: aws.String("dopt-current"),
+				})).Return(&ec2.DeleteDhcpOptionsOutput{}, nil)
+			},
+			wantOptionsID: aws.String("dopt-new"),
+		},
+		{
+			name: "restores default dhcp options when removed from the spec",
+			input: infrav1.VPCSpec{
+				ID:            "vpc-dhcp",
+				Tags:          managedTags,
+				DHCPOptionsID: aws.String("dopt-current"),
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeVpcsWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeVpcsInput{})).Return(&ec2.DescribeVpcsOutput{
+					Vpcs: []*ec2.Vpc{{VpcId: aws.String("vpc-dhcp"), DhcpOptionsId: aws.String("dopt-current")}},
+				}, nil)
+				m.AssociateDhcpOptionsWithContext(context.TODO(), gomock.Eq(&ec2.AssociateDhcpOptionsInput{
+					DhcpOptionsId: aws.String("default"),
+					VpcId:         aws.String("vpc-dhcp"),
+				})).Return(&ec2.AssociateDhcpOptionsOutput{}, nil)
+				m.DescribeDhcpOptionsWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeDhcpOptionsInput{})).Return(&ec2.DescribeDhcpOptionsOutput{
+					DhcpOptions: []*ec2.DhcpOptions{{DhcpOptionsId: aws.String("dopt-current"), Tags: ownedTags}},
+				}, nil)
+				m.DeleteDhcpOptionsWithContext(context.TODO(), gomock.Eq(&ec2.DeleteDhcpOptionsInput{
+					DhcpOptionsId: aws.String("dopt-current"),
+				})).Return(&ec2.DeleteDhcpOptionsOutput{}, nil)
+			},
+		},
+		{
+			name: "keeps dhcp options not owned by the cluster when replaced",
+			input: infrav1.VPCSpec{
+				ID:            "vpc-dhcp",
+				Tags:          managedTags,
+				DHCPOptions:   dhcpOptions,
+				DHCPOptionsID: aws.String("dopt-shared"),
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeVpcsWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeVpcsInput{})).Return(&ec2.DescribeVpcsOutput{
+					Vpcs: []*ec2.Vpc{{VpcId: aws.String("vpc-dhcp"), DhcpOptionsId: aws.String("dopt-shared")}},
+				}, nil)
+				m.DescribeDhcpOptionsWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeDhcpOptionsInput{})).Return(&ec2.DescribeDhcpOptionsOutput{
+					DhcpOptions: []*ec2.DhcpOptions{{DhcpOptionsId: aws.String("dopt-shared"), DhcpConfigurations: matchingConfigurations[:1]}},
+				}, nil)
+				m.CreateDhcpOptionsWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.CreateDhcpOptionsInput{})).Return(&ec2.CreateDhcpOptionsOutput{
+					DhcpOptions: &ec2.DhcpOptions{DhcpOptionsId: aws.String("dopt-new")},
+				}, nil)
+				m.AssociateDhcpOptionsWithContext(context.TODO(), gomock.Eq(&ec2.AssociateDhcpOptionsInput{
+					DhcpOptionsId: aws.String("dopt-new"),
+					VpcId:         aws.String("vpc-dhcp"),
+				})).Return(&ec2.AssociateDhcpOptionsOutput{}, nil)
+			},
+			wantOptionsID: aws.String("dopt-new"),
+		},
+		{
+			name: "keeps dhcp options not owned by the cluster when removed from the spec",
+			input: infrav1.VPCSpec{
+				ID:            "vpc-dhcp",
+				Tags:          managedTags,
+				DHCPOptionsID: aws.String("dopt-shared"),
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeVpcsWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeVpcsInput{})).Return(&ec2.DescribeVpcsOutput{
+					Vpcs: []*ec2.Vpc{{VpcId: aws.String("vpc-dhcp"), DhcpOptionsId: aws.String("dopt-shared")}},
+				}, nil)
+				m.AssociateDhcpOptionsWithContext(context.TODO(), gomock.Eq(&ec2.AssociateDhcpOptionsInput{
+					DhcpOptionsId: aws.String("default"),
+					VpcId:         aws.String("vpc-dhcp"),
+				})).Return(&ec2.AssociateDhcpOptionsOutput{}, nil)
+				m.DescribeDhcpOptionsWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeDhcpOptionsInput{})).Return(&ec2.DescribeDhcpOptionsOutput{
+					DhcpOptions: []*ec2.DhcpOptions{{DhcpOptionsId: aws.String("dopt-shared")}},
+				}, nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Client: client,
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+				},
+				AWSCluster: &infrav1.AWSCluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test"},
+					Spec: infrav1.AWSClusterSpec{
+						NetworkSpec: infrav1.NetworkSpec{VPC: tc.input},
+					},
+				},
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			tc.expect(ec2Mock.EXPECT())
+
+			s := NewService(scope)
+			s.EC2Client = ec2Mock
+
+			g.Expect(s.reconcileDHCPOptions()).To(Succeed())
+			g.Expect(scope.VPC().DHCPOptionsID).To(Equal(tc.wantOptionsID))
+		})
+	}
+}
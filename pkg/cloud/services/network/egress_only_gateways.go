@@ -41,7 +41,7 @@ func (s *Service) reconcileEgressOnlyInternetGateways() error {
 		return nil
 	}
 
-	if s.scope.VPC().IsUnmanaged(s.scope.Name()) {
+	if s.scope.VPC().IsUnmanaged(s.scope.Name(), s.scope.OwnershipTagPrefixes()...) {
 		s.scope.Trace("Skipping egress only internet gateway reconcile in unmanaged mode")
 		return nil
 	}
@@ -69,6 +69,7 @@ func (s *Service) reconcileEgressOnlyInternetGateways() error {
 	// Make sure tags are up to date.
 	if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
 		buildParams := s.getEgressOnlyGatewayTagParams(*gateway.EgressOnlyInternetGatewayId)
+		s.scope.ResourceNaming().KeepName(&buildParams, converters.TagsToMap(gateway.Tags))
 		tagsBuilder := tags.New(&buildParams, tags.WithEC2(s.EC2Client))
 		if err := tagsBuilder.Ensure(converters.TagsToMap(gateway.Tags)); err != nil {
 			return false, err
@@ -88,7 +89,7 @@ func (s *Service) deleteEgressOnlyInternetGateways() error {
 		return nil
 	}
 
-	if s.scope.VPC().IsUnmanaged(s.scope.Name()) {
+	if s.scope.VPC().IsUnmanaged(s.scope.Name(), s.scope.OwnershipTagPrefixes()...) {
 		s.scope.Trace("Skipping egress only internet gateway deletion in unmanaged mode")
 		return nil
 	}
@@ -153,14 +154,19 @@ func (s *Service) describeEgressOnlyVpcInternetGateways() ([]*ec2.EgressOnlyInte
 }
 
 func (s *Service) getEgressOnlyGatewayTagParams(id string) infrav1.BuildParams {
-	name := fmt.Sprintf("%s-eigw", s.scope.Name())
+	name := s.scope.ResourceNaming().Name(fmt.Sprintf("%s-eigw", s.scope.Name()), infrav1.ResourceNameData{
+		ClusterName:  s.scope.Name(),
+		ResourceType: infrav1.ResourceTypeEgressOnlyInternetGateway,
+		Role:         infrav1.CommonRoleTagValue,
+	})
 
 	return infrav1.BuildParams{
-		ClusterName: s.scope.Name(),
-		ResourceID:  id,
-		Lifecycle:   infrav1.ResourceLifecycleOwned,
-		Name:        aws.String(name),
-		Role:        aws.String(infrav1.CommonRoleTagValue),
-		Additional:  s.scope.AdditionalTags(),
+		ClusterName:        s.scope.Name(),
+		OwnershipTagPrefix: s.scope.OwnershipTagPrefix(),
+		ResourceID:         id,
+		Lifecycle:          infrav1.ResourceLifecycleOwned,
+		Name:               aws.String(name),
+		Role:               aws.String(infrav1.CommonRoleTagValue),
+		Additional:         s.scope.AdditionalTags(),
 	}
 }
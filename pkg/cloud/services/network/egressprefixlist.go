@@ -0,0 +1,372 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/filter"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/tags"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+)
+
+const (
+	// egressPrefixListMinEntries is the minimum size of the egress prefix list, leaving room for NAT gateways
+	// being added without resizing the list.
+	egressPrefixListMinEntries = 10
+
+	egressPrefixListNATGatewayDescription   = "NAT gateway"
+	egressPrefixListLoadBalancerDescription = "API server load balancer"
+)
+
+// reconcileEgressPrefixList maintains a customer-managed prefix list holding the egress IPs of the cluster,
+// so other accounts can reference it instead of copying the IPs of the NAT gateways. The prefix list
+// created for the cluster is deleted when the feature is disabled.
+func (s *Service) reconcileEgressPrefixList() error {
+	if !s.scope.PublishEgressPrefixList() {
+		if s.scope.Network().EgressPrefixListID == "" {
+			return nil
+		}
+		return s.deleteEgressPrefixList()
+	}
+
+	s.scope.Debug("Reconciling egress prefix list")
+
+	desired, err := s.getEgressPrefixListEntries()
+	if err != nil {
+		return err
+	}
+
+	prefixList, err := s.describeEgressPrefixList()
+	if err != nil {
+		return err
+	}
+
+	if prefixList == nil {
+		id, err := s.createEgressPrefixList(desired)
+		if err != nil {
+			return err
+		}
+		s.scope.Network().EgressPrefixListID = id
+		return nil
+	}
+	s.scope.Network().EgressPrefixListID = aws.StringValue(prefixList.PrefixListId)
+
+	switch aws.StringValue(prefixList.State) {
+	case ec2.PrefixListStateCreateInProgress, ec2.PrefixListStateModifyInProgress, ec2.PrefixListStateRestoreInProgress:
+		s.scope.Debug("Egress prefix list is being updated, skipping", "prefix-list-id", aws.StringValue(prefixList.PrefixListId))
+		return nil
+	}
+
+	return s.updateEgressPrefixList(prefixList, desired)
+}
+
+// getEgressPrefixListEntries returns the CIDRs of the egress IPs of the cluster, keyed by CIDR with the
+// description of the entry as value.
+func (s *Service) getEgressPrefixListEntries() (map[string]string, error) {
+	entries := map[string]string{}
+	for _, ip := range s.scope.GetNatGatewaysIPs() {
+		entries[ip+"/32"] = egressPrefixListNATGatewayDescription
+	}
+
+	if !s.scope.EgressPrefixListIncludeAPIServerLB() {
+		return entries, nil
+	}
+
+	lbIPs, err := s.getAPIServerLoadBalancerIPs()
+	if err != nil {
+		return nil, err
+	}
+	for _, ip := range lbIPs {
+		entries[ip+"/32"] = egressPrefixListLoadBalancerDescription
+	}
+	return entries, nil
+}
+
+// getAPIServerLoadBalancerIPs returns the IPs of the network interfaces of the API server load balancer: the
+// public IPs of an internet-facing load balancer, and the private IPs of an internal one. The load balancer
+// is created after the network, so no IPs are returned until it exists.
+func (s *Service) getAPIServerLoadBalancerIPs() ([]string, error) {
+	lb := s.scope.Network().APIServerELB
+	description := ""
+	switch {
+	case lb.ARN != "":
+		// Network and application load balancers name their interfaces after the resource of their ARN,
+		// e.g. "ELB net/my-lb/50dc6c495c0c9188".
+		_, resource, ok := strings.Cut(lb.ARN, ":loadbalancer/")
+		if !ok {
+			return nil, nil
+		}
+		description = "ELB " + resource
+	case lb.Name != "":
+		description = "ELB " + lb.Name
+	default:
+		return nil, nil
+	}
+
+	out, err := s.EC2Client.DescribeNetworkInterfacesWithContext(context.TODO(), &ec2.DescribeNetworkInterfacesInput{
+		Filters: []*ec2.Filter{
+			filter.EC2.VPC(s.scope.VPC().ID),
+			{
+				Name:   aws.String("description"),
+				Values: aws.StringSlice([]string{description}),
+			},
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to describe network interfaces of load balancer %q", description)
+	}
+
+	ips := []string{}
+	for _, eni := range out.NetworkInterfaces {
+		if lb.Scheme == infrav1.ELBSchemeInternal {
+			if eni.PrivateIpAddress != nil {
+				ips = append(ips, aws.StringValue(eni.PrivateIpAddress))
+			}
+			continue
+		}
+		if eni.Association != nil && eni.Association.PublicIp != nil {
+			ips = append(ips, aws.StringValue(eni.Association.PublicIp))
+		}
+	}
+	return ips, nil
+}
+
+// describeEgressPrefixList returns the egress prefix list of the cluster, or nil if it doesn't exist.
+func (s *Service) describeEgressPrefixList() (*ec2.ManagedPrefixList, error) {
+	input := &ec2.DescribeManagedPrefixListsInput{}
+	if id := s.scope.Network().EgressPrefixListID; id != "" {
+		input.PrefixListIds = aws.StringSlice([]string{id})
+	} else {
+		input.Filters = []*ec2.Filter{
+			filter.EC2.ClusterOwned(s.scope.Name(), s.scope.OwnershipTagPrefix()),
+			{
+				Name:   aws.String("prefix-list-name"),
+				Values: aws.StringSlice([]string{s.getEgressPrefixListName()}),
+			},
+		}
+	}
+
+	out, err := s.EC2Client.DescribeManagedPrefixListsWithContext(context.TODO(), input)
+	if err != nil {
+		if awserrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "failed to describe egress prefix list")
+	}
+
+	for _, prefixList := range out.PrefixLists {
+		if aws.StringValue(prefixList.State) == ec2.PrefixListStateDeleteInProgress || aws.StringValue(prefixList.State) == ec2.PrefixListStateDeleteComplete {
+			continue
+		}
+		return prefixList, nil
+	}
+	return nil, nil
+}
+
+func (s *Service) createEgressPrefixList(desired map[string]string) (string, error) {
+	entries := make([]*ec2.AddPrefixListEntry, 0, len(desired))
+	for _, cidr := range sortedCIDRs(desired) {
+		entries = append(entries, &ec2.AddPrefixListEntry{
+			Cidr:        aws.String(cidr),
+			Description: aws.String(desired[cidr]),
+		})
+	}
+
+	out, err := s.EC2Client.CreateManagedPrefixListWithContext(context.TODO(), &ec2.CreateManagedPrefixListInput{
+		AddressFamily:  aws.String("IPv4"),
+		PrefixListName: aws.String(s.getEgressPrefixListName()),
+		MaxEntries:     aws.Int64(egressPrefixListMaxEntries(len(desired))),
+		Entries:        entries,
+		TagSpecifications: []*ec2.TagSpecification{
+			tags.BuildParamsToTagSpecification(ec2.ResourceTypePrefixList, s.getEgressPrefixListTagParams(services.TemporaryResourceID)),
+		},
+	})
+	if err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedCreateEgressPrefixList", "Failed to create egress prefix list: %v", err)
+		return "", errors.Wrap(err, "failed to create egress prefix list")
+	}
+
+	id := aws.StringValue(out.PrefixList.PrefixListId)
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulCreateEgressPrefixList", "Created egress prefix list %q", id)
+	s.scope.Info("Created egress prefix list", "prefix-list-id", id)
+
+	return id, nil
+}
+
+// updateEgressPrefixList adds and removes the entries of the prefix list so it matches the desired CIDRs.
+// The size of a prefix list can't be changed along with its entries, so it is grown first when needed.
+func (s *Service) updateEgressPrefixList(prefixList *ec2.ManagedPrefixList, desired map[string]string) error {
+	id := aws.StringValue(prefixList.PrefixListId)
+
+	current := map[string]struct{}{}
+	if err := s.EC2Client.GetManagedPrefixListEntriesPagesWithContext(context.TODO(), &ec2.GetManagedPrefixListEntriesInput{
+		PrefixListId: aws.String(id),
+	}, func(out *ec2.GetManagedPrefixListEntriesOutput, _ bool) bool {
+		for _, entry := range out.Entries {
+			current[aws.StringValue(entry.Cidr)] = struct{}{}
+		}
+		return true
+	}); err != nil {
+		return errors.Wrapf(err, "failed to get entries of egress prefix list %q", id)
+	}
+
+	input := &ec2.ModifyManagedPrefixListInput{
+		PrefixListId:   aws.String(id),
+		CurrentVersion: prefixList.Version,
+	}
+	for _, cidr := range sortedCIDRs(desired) {
+		if _, ok := current[cidr]; !ok {
+			input.AddEntries = append(input.AddEntries, &ec2.AddPrefixListEntry{
+				Cidr:        aws.String(cidr),
+				Description: aws.String(desired[cidr]),
+			})
+		}
+	}
+	for cidr := range current {
+		if _, ok := desired[cidr]; !ok {
+			input.RemoveEntries = append(input.RemoveEntries, &ec2.RemovePrefixListEntry{Cidr: aws.String(cidr)})
+		}
+	}
+	sort.Slice(input.RemoveEntries, func(i, j int) bool {
+		return aws.StringValue(input.RemoveEntries[i].Cidr) < aws.StringValue(input.RemoveEntries[j].Cidr)
+	})
+
+	if len(input.AddEntries) == 0 && len(input.RemoveEntries) == 0 {
+		return nil
+	}
+
+	if maxEntries := int64(len(current) + len(input.AddEntries)); maxEntries > aws.Int64Value(prefixList.MaxEntries) {
+		if _, err := s.EC2Client.ModifyManagedPrefixListWithContext(context.TODO(), &ec2.ModifyManagedPrefixListInput{
+			PrefixListId: aws.String(id),
+			MaxEntries:   aws.Int64(egressPrefixListMaxEntries(int(maxEntries))),
+		}); err != nil {
+			return errors.Wrapf(err, "failed to resize egress prefix list %q", id)
+		}
+		// The entries are updated once the list is resized, on a later reconciliation.
+		return nil
+	}
+
+	if _, err := s.EC2Client.ModifyManagedPrefixListWithContext(context.TODO(), input); err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedModifyEgressPrefixList", "Failed to update entries of egress prefix list %q: %v", id, err)
+		return errors.Wrapf(err, "failed to update entries of egress prefix list %q", id)
+	}
+
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulModifyEgressPrefixList", "Updated entries of egress prefix list %q", id)
+	s.scope.Info("Updated egress prefix list", "prefix-list-id", id, "added", len(input.AddEntries), "removed", len(input.RemoveEntries))
+
+	return nil
+}
+
+// deleteEgressPrefixList deletes the egress prefix list created for the cluster. A prefix list still
+// referenced by route tables or security groups, possibly of other accounts, can't be deleted: it is then
+// kept and reported, without blocking the deletion of the cluster.
+func (s *Service) deleteEgressPrefixList() error {
+	prefixList, err := s.describeEgressPrefixList()
+	if err != nil {
+		return err
+	}
+	if prefixList == nil || !isClusterOwned(prefixList.Tags, s.scope.Name(), s.scope.OwnershipTagPrefixes()) {
+		s.scope.Network().EgressPrefixListID = ""
+		return nil
+	}
+
+	id := aws.StringValue(prefixList.PrefixListId)
+	associations := []string{}
+	if err := s.EC2Client.GetManagedPrefixListAssociationsPagesWithContext(context.TODO(), &ec2.GetManagedPrefixListAssociationsInput{
+		PrefixListId: aws.String(id),
+	}, func(out *ec2.GetManagedPrefixListAssociationsOutput, _ bool) bool {
+		for _, association := range out.PrefixListAssociations {
+			associations = append(associations, aws.StringValue(association.ResourceId))
+		}
+		return true
+	}); err != nil {
+		return errors.Wrapf(err, "failed to get associations of egress prefix list %q", id)
+	}
+
+	if len(associations) > 0 {
+		record.Warnf(s.scope.InfraCluster(), "EgressPrefixListInUse", "Egress prefix list %q is still referenced by %s, it must be deleted manually", id, strings.Join(associations, ", "))
+		s.scope.Network().EgressPrefixListID = ""
+		return nil
+	}
+
+	if _, err := s.EC2Client.DeleteManagedPrefixListWithContext(context.TODO(), &ec2.DeleteManagedPrefixListInput{
+		PrefixListId: aws.String(id),
+	}); err != nil && !awserrors.IsNotFound(err) {
+		record.Warnf(s.scope.InfraCluster(), "FailedDeleteEgressPrefixList", "Failed to delete egress prefix list %q: %v", id, err)
+		return errors.Wrapf(err, "failed to delete egress prefix list %q", id)
+	}
+
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulDeleteEgressPrefixList", "Deleted egress prefix list %q", id)
+	s.scope.Info("Deleted egress prefix list", "prefix-list-id", id)
+	s.scope.Network().EgressPrefixListID = ""
+
+	return nil
+}
+
+func (s *Service) getEgressPrefixListName() string {
+	return fmt.Sprintf("%s-egress", s.scope.Name())
+}
+
+func (s *Service) getEgressPrefixListTagParams(id string) infrav1.BuildParams {
+	return infrav1.BuildParams{
+		ClusterName:        s.scope.Name(),
+		OwnershipTagPrefix: s.scope.OwnershipTagPrefix(),
+		ResourceID:         id,
+		Lifecycle:          infrav1.ResourceLifecycleOwned,
+		Name:               aws.String(s.getEgressPrefixListName()),
+		Role:               aws.String(infrav1.CommonRoleTagValue),
+		Additional:         s.scope.AdditionalTags(),
+	}
+}
+
+func isClusterOwned(ec2Tags []*ec2.Tag, clusterName string, ownershipTagPrefixes []string) bool {
+	for _, key := range infrav1.OwnershipTagKeys(clusterName, ownershipTagPrefixes...) {
+		for _, tag := range ec2Tags {
+			if aws.StringValue(tag.Key) == key && aws.StringValue(tag.Value) == string(infrav1.ResourceLifecycleOwned) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func egressPrefixListMaxEntries(entries int) int64 {
+	if entries < egressPrefixListMinEntries {
+		return egressPrefixListMinEntries
+	}
+	return int64(entries)
+}
+
+func sortedCIDRs(entries map[string]string) []string {
+	res := make([]string, 0, len(entries))
+	for cidr := range entries {
+		res = append(res, cidr)
+	}
+	sort.Strings(res)
+	return res
+}
@@ -0,0 +1,249 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestReconcileEgressPrefixList(t *testing.T) {
+	ownedTags := []*ec2.Tag{{Key: aws.String(infrav1.ClusterTagKey("test-cluster")), Value: aws.String("owned")}}
+
+	testCases := []struct {
+		name           string
+		networkSpec    infrav1.NetworkSpec
+		networkStatus  infrav1.NetworkStatus
+		expect         func(m *mocks.MockEC2APIMockRecorder)
+		wantPrefixList string
+	}{
+		{
+			name:        "disabled",
+			networkSpec: infrav1.NetworkSpec{},
+			expect:      func(m *mocks.MockEC2APIMockRecorder) {},
+		},
+		{
+			name:        "creates prefix list with the NAT gateway IPs",
+			networkSpec: infrav1.NetworkSpec{PublishEgressPrefixList: true},
+			networkStatus: infrav1.NetworkStatus{
+				NatGatewaysIPs: []string{"52.1.1.2", "52.1.1.1"},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeManagedPrefixListsWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeManagedPrefixListsInput{})).Return(&ec2.DescribeManagedPrefixListsOutput{}, nil)
+				m.CreateManagedPrefixListWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.CreateManagedPrefixListInput{})).
+					DoAndReturn(func(_ context.Context, input *ec2.CreateManagedPrefixListInput, _ ...request.Option) (*ec2.CreateManagedPrefixListOutput, error) {
+						g := NewWithT(t)
+						g.Expect(aws.StringValue(input.PrefixListName)).To(Equal("test-cluster-egress"))
+						g.Expect(aws.Int64Value(input.MaxEntries)).To(BeEquivalentTo(egressPrefixListMinEntries))
+						g.Expect(input.Entries).To(Equal([]*ec2.AddPrefixListEntry{
+							{Cidr: aws.String("52.1.1.1/32"), Description: aws.String("NAT gateway")},
+							{Cidr: aws.String("52.1.1.2/32"), Description: aws.String("NAT gateway")},
+						}))
+						return &ec2.CreateManagedPrefixListOutput{PrefixList: &ec2.ManagedPrefixList{PrefixListId: aws.String("pl-egress")}}, nil
+					})
+			},
+			wantPrefixList: "pl-egress",
+		},
+		{
+			name:        "replaces the IPs of replaced NAT gateways",
+			networkSpec: infrav1.NetworkSpec{PublishEgressPrefixList: true},
+			networkStatus: infrav1.NetworkStatus{
+				NatGatewaysIPs:     []string{"52.1.1.1", "52.1.1.3"},
+				EgressPrefixListID: "pl-egress",
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeManagedPrefixListsWithContext(context.TODO(), gomock.Eq(&ec2.DescribeManagedPrefixListsInput{
+					PrefixListIds: aws.StringSlice([]string{"pl-egress"}),
+				})).Return(&ec2.DescribeManagedPrefixListsOutput{
+					PrefixLists: []*ec2.ManagedPrefixList{{
+						PrefixListId: aws.String("pl-egress"),
+						State:        aws.String(ec2.PrefixListStateCreateComplete),
+						Version:      aws.Int64(1),
+						MaxEntries:   aws.Int64(10),
+						Tags:         ownedTags,
+					}},
+				}, nil)
+				m.GetManagedPrefixListEntriesPagesWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.GetManagedPrefixListEntriesInput{}), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ *ec2.GetManagedPrefixListEntriesInput, fn func(*ec2.GetManagedPrefixListEntriesOutput, bool) bool, _ ...request.Option) error {
+						fn(&ec2.GetManagedPrefixListEntriesOutput{Entries: []*ec2.PrefixListEntry{
+							{Cidr: aws.String("52.1.1.1/32")},
+							{Cidr: aws.String("52.1.1.2/32")},
+						}}, true)
+						return nil
+					})
+				m.ModifyManagedPrefixListWithContext(context.TODO(), gomock.Eq(&ec2.ModifyManagedPrefixListInput{
+					PrefixListId:   aws.String("pl-egress"),
+					CurrentVersion: aws.Int64(1),
+					AddEntries: []*ec2.AddPrefixListEntry{
+						{Cidr: aws.String("52.1.1.3/32"), Description: aws.String("NAT gateway")},
+					},
+					RemoveEntries: []*ec2.RemovePrefixListEntry{
+						{Cidr: aws.String("52.1.1.2/32")},
+					},
+				})).Return(&ec2.ModifyManagedPrefixListOutput{}, nil)
+			},
+			wantPrefixList: "pl-egress",
+		},
+		{
+			name: "adds the public IPs of the API server network load balancer",
+			networkSpec: infrav1.NetworkSpec{
+				PublishEgressPrefixList:            true,
+				EgressPrefixListIncludeAPIServerLB: true,
+			},
+			networkStatus: infrav1.NetworkStatus{
+				NatGatewaysIPs:     []string{"52.1.1.1"},
+				EgressPrefixListID: "pl-egress",
+				APIServerELB: infrav1.LoadBalancer{
+					ARN:    "arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/net/test-apiserver/50dc6c495c0c9188",
+					Scheme: infrav1.ELBSchemeInternetFacing,
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeNetworkInterfacesWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeNetworkInterfacesInput{})).
+					DoAndReturn(func(_ context.Context, input *ec2.DescribeNetworkInterfacesInput, _ ...request.Option) (*ec2.DescribeNetworkInterfacesOutput, error) {
+						NewWithT(t).Expect(input.Filters).To(ContainElement(&ec2.Filter{
+							Name:   aws.String("description"),
+							Values: aws.StringSlice([]string{"ELB net/test-apiserver/50dc6c495c0c9188"}),
+						}))
+						return &ec2.DescribeNetworkInterfacesOutput{NetworkInterfaces: []*ec2.NetworkInterface{{
+							PrivateIpAddress: aws.String("10.0.0.10"),
+							Association:      &ec2.NetworkInterfaceAssociation{PublicIp: aws.String("3.3.3.3")},
+						}}}, nil
+					})
+				m.DescribeManagedPrefixListsWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeManagedPrefixListsInput{})).Return(&ec2.DescribeManagedPrefixListsOutput{
+					PrefixLists: []*ec2.ManagedPrefixList{{
+						PrefixListId: aws.String("pl-egress"),
+						State:        aws.String(ec2.PrefixListStateModifyComplete),
+						Version:      aws.Int64(2),
+						MaxEntries:   aws.Int64(10),
+						Tags:         ownedTags,
+					}},
+				}, nil)
+				m.GetManagedPrefixListEntriesPagesWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.GetManagedPrefixListEntriesInput{}), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ *ec2.GetManagedPrefixListEntriesInput, fn func(*ec2.GetManagedPrefixListEntriesOutput, bool) bool, _ ...request.Option) error {
+						fn(&ec2.GetManagedPrefixListEntriesOutput{Entries: []*ec2.PrefixListEntry{{Cidr: aws.String("52.1.1.1/32")}}}, true)
+						return nil
+					})
+				m.ModifyManagedPrefixListWithContext(context.TODO(), gomock.Eq(&ec2.ModifyManagedPrefixListInput{
+					PrefixListId:   aws.String("pl-egress"),
+					CurrentVersion: aws.Int64(2),
+					AddEntries: []*ec2.AddPrefixListEntry{
+						{Cidr: aws.String("3.3.3.3/32"), Description: aws.String("API server load balancer")},
+					},
+				})).Return(&ec2.ModifyManagedPrefixListOutput{}, nil)
+			},
+			wantPrefixList: "pl-egress",
+		},
+		{
+			name:        "deletes the prefix list when disabled",
+			networkSpec: infrav1.NetworkSpec{},
+			networkStatus: infrav1.NetworkStatus{
+				EgressPrefixListID: "pl-egress",
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeManagedPrefixListsWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeManagedPrefixListsInput{})).Return(&ec2.DescribeManagedPrefixListsOutput{
+					PrefixLists: []*ec2.ManagedPrefixList{{
+						PrefixListId: aws.String("pl-egress"),
+						State:        aws.String(ec2.PrefixListStateCreateComplete),
+						Tags:         ownedTags,
+					}},
+				}, nil)
+				m.GetManagedPrefixListAssociationsPagesWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.GetManagedPrefixListAssociationsInput{}), gomock.Any()).Return(nil)
+				m.DeleteManagedPrefixListWithContext(context.TODO(), gomock.Eq(&ec2.DeleteManagedPrefixListInput{
+					PrefixListId: aws.String("pl-egress"),
+				})).Return(&ec2.DeleteManagedPrefixListOutput{}, nil)
+			},
+		},
+		{
+			name:        "keeps a prefix list which is still referenced",
+			networkSpec: infrav1.NetworkSpec{},
+			networkStatus: infrav1.NetworkStatus{
+				EgressPrefixListID: "pl-egress",
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeManagedPrefixListsWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeManagedPrefixListsInput{})).Return(&ec2.DescribeManagedPrefixListsOutput{
+					PrefixLists: []*ec2.ManagedPrefixList{{
+						PrefixListId: aws.String("pl-egress"),
+						State:        aws.String(ec2.PrefixListStateCreateComplete),
+						Tags:         ownedTags,
+					}},
+				}, nil)
+				m.GetManagedPrefixListAssociationsPagesWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.GetManagedPrefixListAssociationsInput{}), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ *ec2.GetManagedPrefixListAssociationsInput, fn func(*ec2.GetManagedPrefixListAssociationsOutput, bool) bool, _ ...request.Option) error {
+						fn(&ec2.GetManagedPrefixListAssociationsOutput{PrefixListAssociations: []*ec2.PrefixListAssociation{
+							{ResourceId: aws.String("sg-downstream"), ResourceOwner: aws.String("210987654321")},
+						}}, true)
+						return nil
+					})
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			networkSpec := tc.networkSpec
+			networkSpec.VPC = infrav1.VPCSpec{ID: "vpc-egress"}
+			scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Client: client,
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+				},
+				AWSCluster: &infrav1.AWSCluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test"},
+					Spec: infrav1.AWSClusterSpec{
+						NetworkSpec: networkSpec,
+					},
+					Status: infrav1.AWSClusterStatus{
+						Network: tc.networkStatus,
+					},
+				},
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			tc.expect(ec2Mock.EXPECT())
+
+			s := NewService(scope)
+			s.EC2Client = ec2Mock
+
+			g.Expect(s.reconcileEgressPrefixList()).To(Succeed())
+			g.Expect(scope.Network().EgressPrefixListID).To(Equal(tc.wantPrefixList))
+		})
+	}
+}
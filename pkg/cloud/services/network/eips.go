@@ -85,7 +85,7 @@ func (s *Service) allocateAddress(alloc *ec2.AllocateAddressInput) (string, erro
 }
 
 func (s *Service) describeAddresses(role string) (*ec2.DescribeAddressesOutput, error) {
-	x := []*ec2.Filter{filter.EC2.Cluster(s.scope.Name())}
+	x := []*ec2.Filter{filter.EC2.Cluster(s.scope.Name(), s.scope.OwnershipTagPrefixes()...)}
 	if role != "" {
 		x = append(x, filter.EC2.ProviderRole(role))
 	}
@@ -169,20 +169,25 @@ func (s *Service) releaseAddressesWithFilter(filters []*ec2.Filter) error {
 // releaseAddresses is default cluster release flow, discoverying and releasing all
 // addresses associated and owned by the cluster tag.
 func (s *Service) releaseAddresses() error {
-	filters := []*ec2.Filter{filter.EC2.Cluster(s.scope.Name())}
-	filters = append(filters, filter.EC2.ClusterOwned(s.scope.Name()))
+	filters := []*ec2.Filter{filter.EC2.Cluster(s.scope.Name(), s.scope.OwnershipTagPrefixes()...)}
+	filters = append(filters, filter.EC2.ClusterOwned(s.scope.Name(), s.scope.OwnershipTagPrefix()))
 	return s.releaseAddressesWithFilter(filters)
 }
 
 func (s *Service) getEIPTagParams(role string) infrav1.BuildParams {
-	name := fmt.Sprintf("%s-eip-%s", s.scope.Name(), role)
+	name := s.scope.ResourceNaming().Name(fmt.Sprintf("%s-eip-%s", s.scope.Name(), role), infrav1.ResourceNameData{
+		ClusterName:  s.scope.Name(),
+		ResourceType: infrav1.ResourceTypeElasticIP,
+		Role:         role,
+	})
 
 	return infrav1.BuildParams{
-		ClusterName: s.scope.Name(),
-		Lifecycle:   infrav1.ResourceLifecycleOwned,
-		Name:        aws.String(name),
-		Role:        aws.String(role),
-		Additional:  s.scope.AdditionalTags(),
+		ClusterName:        s.scope.Name(),
+		OwnershipTagPrefix: s.scope.OwnershipTagPrefix(),
+		Lifecycle:          infrav1.ResourceLifecycleOwned,
+		Name:               aws.String(name),
+		Role:               aws.String(role),
+		Additional:         s.scope.AdditionalTags(),
 	}
 }
 
@@ -199,7 +204,7 @@ func (s *Service) GetAddresses(role string) (*ec2.DescribeAddressesOutput, error
 // ReleaseAddressByRole releases EIP addresses filtering by tag CAPA provider role.
 func (s *Service) ReleaseAddressByRole(role string) error {
 	return s.releaseAddressesWithFilter([]*ec2.Filter{
-		filter.EC2.ClusterOwned(s.scope.Name()),
+		filter.EC2.ClusterOwned(s.scope.Name(), s.scope.OwnershipTagPrefix()),
 		filter.EC2.ProviderRole(role),
 	})
 }
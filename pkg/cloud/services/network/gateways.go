@@ -36,16 +36,21 @@ import (
 )
 
 func (s *Service) reconcileInternetGateways() error {
-	if s.scope.VPC().IsUnmanaged(s.scope.Name()) {
+	if s.scope.VPC().IsUnmanaged(s.scope.Name(), s.scope.OwnershipTagPrefixes()...) {
 		s.scope.Trace("Skipping internet gateways reconcile in unmanaged mode")
 		return nil
 	}
 
+	if s.scope.VPC().PrivateOnly {
+		s.scope.Trace("Skipping internet gateways reconcile in private only VPC")
+		return nil
+	}
+
 	s.scope.Debug("Reconciling internet gateways")
 
 	igs, err := s.describeVpcInternetGateways()
 	if awserrors.IsNotFound(err) {
-		if s.scope.VPC().IsUnmanaged(s.scope.Name()) {
+		if s.scope.VPC().IsUnmanaged(s.scope.Name(), s.scope.OwnershipTagPrefixes()...) {
 			return errors.Errorf("failed to validate network: no internet gateways found in VPC %q", s.scope.VPC().ID)
 		}
 
@@ -63,7 +68,8 @@ func (s *Service) reconcileInternetGateways() error {
 
 	// Make sure tags are up-to-date.
 	if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
-		buildParams := s.getGatewayTagParams(*gateway.InternetGatewayId)
+		buildParams := s.getGatewayTagParams(*gateway.InternetGatewayId, infrav1.ResourceTypeInternetGateway)
+		s.scope.ResourceNaming().KeepName(&buildParams, converters.TagsToMap(gateway.Tags))
 		tagsBuilder := tags.New(&buildParams, tags.WithEC2(s.EC2Client))
 		if err := tagsBuilder.Ensure(converters.TagsToMap(gateway.Tags)); err != nil {
 			return false, err
@@ -78,7 +84,7 @@ func (s *Service) reconcileInternetGateways() error {
 }
 
 func (s *Service) deleteInternetGateways() error {
-	if s.scope.VPC().IsUnmanaged(s.scope.Name()) {
+	if s.scope.VPC().IsUnmanaged(s.scope.Name(), s.scope.OwnershipTagPrefixes()...) {
 		s.scope.Trace("Skipping internet gateway deletion in unmanaged mode")
 		return nil
 	}
@@ -123,7 +129,7 @@ func (s *Service) deleteInternetGateways() error {
 func (s *Service) createInternetGateway() (*ec2.InternetGateway, error) {
 	ig, err := s.EC2Client.CreateInternetGatewayWithContext(context.TODO(), &ec2.CreateInternetGatewayInput{
 		TagSpecifications: []*ec2.TagSpecification{
-			tags.BuildParamsToTagSpecification(ec2.ResourceTypeInternetGateway, s.getGatewayTagParams(services.TemporaryResourceID)),
+			tags.BuildParamsToTagSpecification(ec2.ResourceTypeInternetGateway, s.getGatewayTagParams(services.TemporaryResourceID, infrav1.ResourceTypeInternetGateway)),
 		},
 	})
 	if err != nil {
@@ -169,15 +175,22 @@ func (s *Service) describeVpcInternetGateways() ([]*ec2.InternetGateway, error)
 	return out.InternetGateways, nil
 }
 
-func (s *Service) getGatewayTagParams(id string) infrav1.BuildParams {
-	name := fmt.Sprintf("%s-igw", s.scope.Name())
+// getGatewayTagParams returns the tag params of the internet gateway, and of the carrier gateway which is named
+// the same by default.
+func (s *Service) getGatewayTagParams(id, resourceType string) infrav1.BuildParams {
+	name := s.scope.ResourceNaming().Name(fmt.Sprintf("%s-igw", s.scope.Name()), infrav1.ResourceNameData{
+		ClusterName:  s.scope.Name(),
+		ResourceType: resourceType,
+		Role:         infrav1.CommonRoleTagValue,
+	})
 
 	return infrav1.BuildParams{
-		ClusterName: s.scope.Name(),
-		ResourceID:  id,
-		Lifecycle:   infrav1.ResourceLifecycleOwned,
-		Name:        aws.String(name),
-		Role:        aws.String(infrav1.CommonRoleTagValue),
-		Additional:  s.scope.AdditionalTags(),
+		ClusterName:        s.scope.Name(),
+		OwnershipTagPrefix: s.scope.OwnershipTagPrefix(),
+		ResourceID:         id,
+		Lifecycle:          infrav1.ResourceLifecycleOwned,
+		Name:               aws.String(name),
+		Role:               aws.String(infrav1.CommonRoleTagValue),
+		Additional:         s.scope.AdditionalTags(),
 	}
 }
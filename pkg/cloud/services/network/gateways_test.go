@@ -115,6 +115,19 @@ func TestReconcileInternetGateways(t *testing.T) {
 					Return(&ec2.AttachInternetGatewayOutput{}, nil)
 			},
 		},
+		{
+			name: "private only VPC, skips igw",
+			input: &infrav1.NetworkSpec{
+				VPC: infrav1.VPCSpec{
+					ID: "vpc-gateways",
+					Tags: infrav1.Tags{
+						infrav1.ClusterTagKey("test-cluster"): "owned",
+					},
+					PrivateOnly: true,
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {},
+		},
 	}
 
 	for _, tc := range testCases {
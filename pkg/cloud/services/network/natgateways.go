@@ -39,11 +39,21 @@ import (
 )
 
 func (s *Service) reconcileNatGateways() error {
-	if s.scope.VPC().IsUnmanaged(s.scope.Name()) {
+	if s.scope.VPC().IsUnmanaged(s.scope.Name(), s.scope.OwnershipTagPrefixes()...) {
 		s.scope.Trace("Skipping NAT gateway reconcile in unmanaged mode")
 		return nil
 	}
 
+	// The egress traffic of a private only VPC goes through a transit gateway or a proxy.
+	if s.scope.VPC().PrivateOnly {
+		s.scope.Trace("Skipping NAT gateway reconcile in private only VPC")
+		return nil
+	}
+
+	if s.scope.NatGatewaysSuspended() {
+		return s.suspendNatGateways()
+	}
+
 	s.scope.Debug("Reconciling NAT gateways")
 
 	if len(s.scope.Subnets().FilterPrivate().FilterNonCni()) == 0 {
@@ -85,7 +95,8 @@ func (s *Service) reconcileNatGateways() error {
 			}
 			// Make sure tags are up to date.
 			if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
-				buildParams := s.getNatGatewayTagParams(*ngw.NatGatewayId)
+				buildParams := s.getNatGatewayTagParams(*ngw.NatGatewayId, sn.AvailabilityZone)
+				s.scope.ResourceNaming().KeepName(&buildParams, converters.TagsToMap(ngw.Tags))
 				tagsBuilder := tags.New(&buildParams, tags.WithEC2(s.EC2Client))
 				if err := tagsBuilder.Ensure(converters.TagsToMap(ngw.Tags)); err != nil {
 					return false, err
@@ -134,7 +145,7 @@ func (s *Service) reconcileNatGateways() error {
 }
 
 func (s *Service) deleteNatGateways() error {
-	if s.scope.VPC().IsUnmanaged(s.scope.Name()) {
+	if s.scope.VPC().IsUnmanaged(s.scope.Name(), s.scope.OwnershipTagPrefixes()...) {
 		s.scope.Trace("Skipping NAT gateway deletion in unmanaged mode")
 		return nil
 	}
@@ -183,6 +194,60 @@ func (s *Service) deleteNatGateways() error {
 	return kerrors.NewAggregate(errs)
 }
 
+// suspendNatGateways deletes the NAT gateways and the routes to them, while keeping the Elastic IPs of the NAT
+// gateways to recreate them with the same public IPs on resume.
+func (s *Service) suspendNatGateways() error {
+	existing, err := s.describeNatGatewaysBySubnet()
+	if err != nil {
+		return err
+	}
+
+	allocations := map[string]string{}
+	for subnetID, allocationID := range s.scope.SuspendedNatGatewayAllocations() {
+		allocations[subnetID] = allocationID
+	}
+	natGatewayIDs := map[string]bool{}
+	for _, sn := range s.scope.Subnets().FilterPublic() {
+		ngw, ok := existing[sn.GetResourceID()]
+		if sn.GetResourceID() == "" || !ok {
+			continue
+		}
+		natGatewayIDs[aws.StringValue(ngw.NatGatewayId)] = true
+		if len(ngw.NatGatewayAddresses) > 0 && ngw.NatGatewayAddresses[0].AllocationId != nil {
+			allocations[sn.GetResourceID()] = aws.StringValue(ngw.NatGatewayAddresses[0].AllocationId)
+		}
+	}
+	// The allocations are recorded before anything is deleted, so that they aren't lost on failure.
+	s.scope.SetSuspendedNatGatewayAllocations(allocations)
+
+	if len(natGatewayIDs) > 0 {
+		if err := s.deleteNatGatewayRoutes(natGatewayIDs); err != nil {
+			return err
+		}
+		ids := make([]string, 0, len(natGatewayIDs))
+		for id := range natGatewayIDs {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		for _, id := range ids {
+			if err := s.deleteNatGateway(id); err != nil {
+				return err
+			}
+		}
+		record.Eventf(s.scope.InfraCluster(), "SuccessfulSuspendNATGateways", "Suspended NAT gateways %v", ids)
+	}
+
+	subnets := s.scope.Subnets()
+	for i := range subnets {
+		subnets[i].NatGatewayID = nil
+	}
+	s.scope.SetSubnets(subnets)
+
+	conditions.MarkFalse(s.scope.InfraCluster(), infrav1.NatGatewaysReadyCondition, infrav1.NatGatewaysSuspendedReason, clusterv1.ConditionSeverityInfo,
+		"NAT gateways are suspended by the cost savings schedule")
+	return nil
+}
+
 func (s *Service) describeNatGatewaysBySubnet() (map[string]*ec2.NatGateway, error) {
 	describeNatGatewayInput := &ec2.DescribeNatGatewaysInput{
 		Filter: []*ec2.Filter{
@@ -208,21 +273,27 @@ func (s *Service) describeNatGatewaysBySubnet() (map[string]*ec2.NatGateway, err
 	return gateways, nil
 }
 
-func (s *Service) getNatGatewayTagParams(id string) infrav1.BuildParams {
-	name := fmt.Sprintf("%s-nat", s.scope.Name())
+func (s *Service) getNatGatewayTagParams(id, zone string) infrav1.BuildParams {
+	name := s.scope.ResourceNaming().Name(fmt.Sprintf("%s-nat", s.scope.Name()), infrav1.ResourceNameData{
+		ClusterName:  s.scope.Name(),
+		ResourceType: infrav1.ResourceTypeNatGateway,
+		AZ:           zone,
+		Role:         infrav1.CommonRoleTagValue,
+	})
 
 	return infrav1.BuildParams{
-		ClusterName: s.scope.Name(),
-		ResourceID:  id,
-		Lifecycle:   infrav1.ResourceLifecycleOwned,
-		Name:        aws.String(name),
-		Role:        aws.String(infrav1.CommonRoleTagValue),
-		Additional:  s.scope.AdditionalTags(),
+		ClusterName:        s.scope.Name(),
+		OwnershipTagPrefix: s.scope.OwnershipTagPrefix(),
+		ResourceID:         id,
+		Lifecycle:          infrav1.ResourceLifecycleOwned,
+		Name:               aws.String(name),
+		Role:               aws.String(infrav1.CommonRoleTagValue),
+		Additional:         s.scope.AdditionalTags(),
 	}
 }
 
 func (s *Service) createNatGateways(subnetIDs []string) (natgateways []*ec2.NatGateway, err error) {
-	eips, err := s.getOrAllocateAddresses(len(subnetIDs), infrav1.CommonRoleTagValue, s.scope.VPC().GetElasticIPPool())
+	eips, err := s.getNatGatewayAddresses(subnetIDs)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to create one or more IP addresses for NAT gateways")
 	}
@@ -246,18 +317,69 @@ func (s *Service) createNatGateways(subnetIDs []string) (natgateways []*ec2.NatG
 		}
 		natgateways = append(natgateways, ngwResult.natGateway)
 	}
+
+	// The Elastic IPs of the NAT gateways suspended by the cost savings schedule are in use again.
+	s.scope.SetSuspendedNatGatewayAllocations(nil)
 	return natgateways, nil
 }
 
+// getNatGatewayAddresses returns the allocation IDs of the Elastic IPs of the NAT gateways to create in the subnets.
+// The NAT gateways suspended by the cost savings schedule are recreated with the Elastic IPs they had, so that their
+// public IPs don't change.
+func (s *Service) getNatGatewayAddresses(subnetIDs []string) ([]string, error) {
+	suspended := s.scope.SuspendedNatGatewayAllocations()
+	if len(suspended) == 0 {
+		return s.getOrAllocateAddresses(len(subnetIDs), infrav1.CommonRoleTagValue, s.scope.VPC().GetElasticIPPool())
+	}
+
+	reserved := map[string]bool{}
+	for _, allocationID := range suspended {
+		reserved[allocationID] = true
+	}
+	eips := make([]string, len(subnetIDs))
+	missing := 0
+	for i, subnetID := range subnetIDs {
+		if eips[i] = suspended[subnetID]; eips[i] == "" {
+			missing++
+		}
+	}
+	if missing == 0 {
+		return eips, nil
+	}
+
+	// The unassociated Elastic IPs include the ones kept for the suspended NAT gateways.
+	available, err := s.getOrAllocateAddresses(missing+len(reserved), infrav1.CommonRoleTagValue, s.scope.VPC().GetElasticIPPool())
+	if err != nil {
+		return nil, err
+	}
+	for i := range eips {
+		for eips[i] == "" && len(available) > 0 {
+			if !reserved[available[0]] {
+				eips[i] = available[0]
+			}
+			available = available[1:]
+		}
+		if eips[i] == "" {
+			return nil, errors.New("not enough Elastic IPs available")
+		}
+	}
+	return eips, nil
+}
+
 func (s *Service) createNatGateway(subnetID, ip string) (*ec2.NatGateway, error) {
 	var out *ec2.CreateNatGatewayOutput
 	var err error
 
+	var zone string
+	if sn := s.scope.Subnets().FindByID(subnetID); sn != nil {
+		zone = sn.AvailabilityZone
+	}
+
 	if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
 		if out, err = s.EC2Client.CreateNatGatewayWithContext(context.TODO(), &ec2.CreateNatGatewayInput{
 			SubnetId:          aws.String(subnetID),
 			AllocationId:      aws.String(ip),
-			TagSpecifications: []*ec2.TagSpecification{tags.BuildParamsToTagSpecification(ec2.ResourceTypeNatgateway, s.getNatGatewayTagParams(services.TemporaryResourceID))},
+			TagSpecifications: []*ec2.TagSpecification{tags.BuildParamsToTagSpecification(ec2.ResourceTypeNatgateway, s.getNatGatewayTagParams(services.TemporaryResourceID, zone))},
 		}); err != nil {
 			return false, err
 		}
@@ -35,6 +35,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
 )
 
 const (
@@ -999,3 +1000,146 @@ func TestGetdNatGatewayForEdgeSubnet(t *testing.T) {
 		})
 	}
 }
+
+func TestSuspendAndResumeNatGateways(t *testing.T) {
+	subnets := infrav1.Subnets{
+		{
+			ID:               "subnet-1",
+			AvailabilityZone: "us-east-1a",
+			CidrBlock:        "10.0.10.0/24",
+			IsPublic:         true,
+			NatGatewayID:     aws.String("natgateway"),
+		},
+		{
+			ID:               "subnet-2",
+			AvailabilityZone: "us-east-1a",
+			CidrBlock:        "10.0.12.0/24",
+			IsPublic:         false,
+		},
+	}
+	describeNatGatewaysInput := &ec2.DescribeNatGatewaysInput{
+		Filter: []*ec2.Filter{
+			{Name: aws.String("vpc-id"), Values: []*string{aws.String(subnetsVPCID)}},
+			{Name: aws.String("state"), Values: []*string{aws.String("pending"), aws.String("available")}},
+		},
+	}
+
+	testCases := []struct {
+		name            string
+		status          *infrav1.CostSavingsStatus
+		expect          func(m *mocks.MockEC2APIMockRecorder)
+		wantStatus      *infrav1.CostSavingsStatus
+		wantNatGateway  *string
+		wantReadyReason string
+	}{
+		{
+			name: "suspending deletes the NAT gateways and their routes, keeping their Elastic IPs",
+			status: &infrav1.CostSavingsStatus{
+				SuspendedResources: []infrav1.CostSavingsResource{infrav1.CostSavingsResourceNatGateways},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeNatGatewaysPagesWithContext(context.TODO(), gomock.Eq(describeNatGatewaysInput), gomock.Any()).
+					Do(func(_ context.Context, _ *ec2.DescribeNatGatewaysInput, fn func(*ec2.DescribeNatGatewaysOutput, bool) bool, _ ...request.Option) {
+						fn(&ec2.DescribeNatGatewaysOutput{NatGateways: []*ec2.NatGateway{{
+							NatGatewayId:        aws.String("natgateway"),
+							SubnetId:            aws.String("subnet-1"),
+							NatGatewayAddresses: []*ec2.NatGatewayAddress{{AllocationId: aws.String("eipalloc-1")}},
+						}}}, true)
+					}).Return(nil)
+				m.DescribeRouteTablesWithContext(context.TODO(), gomock.Any()).
+					Return(&ec2.DescribeRouteTablesOutput{RouteTables: []*ec2.RouteTable{{
+						RouteTableId: aws.String("rtb-1"),
+						Routes: []*ec2.Route{
+							{DestinationCidrBlock: aws.String("10.0.0.0/16"), GatewayId: aws.String("local")},
+							{DestinationCidrBlock: aws.String("0.0.0.0/0"), NatGatewayId: aws.String("natgateway")},
+						},
+					}}}, nil)
+				m.DeleteRouteWithContext(context.TODO(), &ec2.DeleteRouteInput{
+					RouteTableId:         aws.String("rtb-1"),
+					DestinationCidrBlock: aws.String("0.0.0.0/0"),
+				}).Return(&ec2.DeleteRouteOutput{}, nil)
+				m.DeleteNatGatewayWithContext(context.TODO(), &ec2.DeleteNatGatewayInput{NatGatewayId: aws.String("natgateway")}).
+					Return(&ec2.DeleteNatGatewayOutput{}, nil)
+				m.DescribeNatGatewaysWithContext(context.TODO(), &ec2.DescribeNatGatewaysInput{NatGatewayIds: []*string{aws.String("natgateway")}}).
+					Return(&ec2.DescribeNatGatewaysOutput{NatGateways: []*ec2.NatGateway{{
+						NatGatewayId: aws.String("natgateway"),
+						State:        aws.String(ec2.NatGatewayStateDeleted),
+					}}}, nil)
+			},
+			wantStatus: &infrav1.CostSavingsStatus{
+				SuspendedResources:    []infrav1.CostSavingsResource{infrav1.CostSavingsResourceNatGateways},
+				NatGatewayAllocations: map[string]string{"subnet-1": "eipalloc-1"},
+			},
+			wantReadyReason: infrav1.NatGatewaysSuspendedReason,
+		},
+		{
+			name: "resuming recreates the NAT gateways with their Elastic IPs",
+			status: &infrav1.CostSavingsStatus{
+				NatGatewayAllocations: map[string]string{"subnet-1": "eipalloc-1"},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeNatGatewaysPagesWithContext(context.TODO(), gomock.Eq(describeNatGatewaysInput), gomock.Any()).Return(nil)
+				m.CreateNatGatewayWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.CreateNatGatewayInput{})).
+					DoAndReturn(func(_ context.Context, input *ec2.CreateNatGatewayInput, _ ...request.Option) (*ec2.CreateNatGatewayOutput, error) {
+						if aws.StringValue(input.AllocationId) != "eipalloc-1" || aws.StringValue(input.SubnetId) != "subnet-1" {
+							t.Errorf("unexpected NAT gateway %s", input.GoString())
+						}
+						return &ec2.CreateNatGatewayOutput{NatGateway: &ec2.NatGateway{
+							NatGatewayId: aws.String("natgateway-2"),
+							SubnetId:     aws.String("subnet-1"),
+						}}, nil
+					})
+				m.WaitUntilNatGatewayAvailableWithContext(context.TODO(), &ec2.DescribeNatGatewaysInput{
+					NatGatewayIds: []*string{aws.String("natgateway-2")},
+				}).Return(nil)
+			},
+			wantStatus:     &infrav1.CostSavingsStatus{},
+			wantNatGateway: aws.String("natgateway-2"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			awsCluster := &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: infrav1.AWSClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						VPC: infrav1.VPCSpec{
+							ID: subnetsVPCID,
+							Tags: infrav1.Tags{
+								infrav1.ClusterTagKey("test-cluster"): "owned",
+							},
+						},
+						Subnets: subnets.DeepCopy(),
+					},
+				},
+				Status: infrav1.AWSClusterStatus{CostSavings: tc.status},
+			}
+			client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(awsCluster).WithStatusSubresource(awsCluster).Build()
+			clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+				},
+				AWSCluster: awsCluster,
+				Client:     client,
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+			tc.expect(ec2Mock.EXPECT())
+
+			s := NewService(clusterScope)
+			s.EC2Client = ec2Mock
+
+			g.Expect(s.reconcileNatGateways()).To(Succeed())
+			g.Expect(awsCluster.Status.CostSavings).To(Equal(tc.wantStatus))
+			g.Expect(clusterScope.Subnets().FindByID("subnet-1").NatGatewayID).To(Equal(tc.wantNatGateway))
+			if tc.wantReadyReason != "" {
+				g.Expect(conditions.GetReason(awsCluster, infrav1.NatGatewaysReadyCondition)).To(Equal(tc.wantReadyReason))
+			}
+		})
+	}
+}
@@ -35,6 +35,12 @@ func (s *Service) ReconcileNetwork() (err error) {
 		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.VpcReadyCondition, infrav1.VpcReconciliationFailedReason, infrautilconditions.ErrorConditionAfterInit(s.scope.ClusterObj()), err.Error())
 		return err
 	}
+
+	// DHCP options.
+	if err := s.reconcileDHCPOptions(); err != nil {
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.VpcReadyCondition, infrav1.DHCPOptionsReconciliationFailedReason, infrautilconditions.ErrorConditionAfterInit(s.scope.ClusterObj()), err.Error())
+		return err
+	}
 	conditions.MarkTrue(s.scope.InfraCluster(), infrav1.VpcReadyCondition)
 
 	// Secondary CIDRs
@@ -73,6 +79,12 @@ func (s *Service) ReconcileNetwork() (err error) {
 		return err
 	}
 
+	// Egress prefix list.
+	if err := s.reconcileEgressPrefixList(); err != nil {
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.NatGatewaysReadyCondition, infrav1.EgressPrefixListReconciliationFailedReason, infrautilconditions.ErrorConditionAfterInit(s.scope.ClusterObj()), err.Error())
+		return err
+	}
+
 	// Routing tables.
 	if err := s.reconcileRouteTables(); err != nil {
 		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.RouteTablesReadyCondition, infrav1.RouteTableReconciliationFailedReason, infrautilconditions.ErrorConditionAfterInit(s.scope.ClusterObj()), err.Error())
@@ -93,6 +105,13 @@ func (s *Service) ReconcileNetwork() (err error) {
 func (s *Service) DeleteNetwork() (err error) {
 	s.scope.Debug("Deleting network")
 
+	// Egress prefix list. It is not attached to the VPC, so it is deleted even if the VPC is already gone.
+	if s.scope.Network().EgressPrefixListID != "" {
+		if err := s.deleteEgressPrefixList(); err != nil {
+			return err
+		}
+	}
+
 	vpc := &infrav1.VPCSpec{}
 	// Get VPC used for the cluster
 	if s.scope.VPC().ID != "" {
@@ -109,6 +128,8 @@ func (s *Service) DeleteNetwork() (err error) {
 		s.scope.Error(err, "non-fatal: VPC ID is missing, ")
 	}
 
+	// The DHCP options set is not part of the described VPC, keep track of it before the spec is overwritten.
+	dhcpOptionsID := s.scope.VPC().DHCPOptionsID
 	vpc.DeepCopyInto(s.scope.VPC())
 
 	// VPC Endpoints.
@@ -216,6 +237,13 @@ func (s *Service) DeleteNetwork() (err error) {
 	}
 	conditions.MarkFalse(s.scope.InfraCluster(), infrav1.VpcReadyCondition, clusterv1.DeletedReason, clusterv1.ConditionSeverityInfo, "")
 
+	// DHCP options.
+	if dhcpOptionsID != nil && !s.scope.VPC().IsUnmanaged(s.scope.Name(), s.scope.OwnershipTagPrefixes()...) {
+		if err := s.deleteDHCPOptions(*dhcpOptionsID); err != nil {
+			return err
+		}
+	}
+
 	s.scope.Debug("Delete network completed successfully")
 	return nil
 }
@@ -40,7 +40,7 @@ const (
 )
 
 func (s *Service) reconcileRouteTables() error {
-	if s.scope.VPC().IsUnmanaged(s.scope.Name()) {
+	if s.scope.VPC().IsUnmanaged(s.scope.Name(), s.scope.OwnershipTagPrefixes()...) {
 		s.scope.Trace("Skipping routing tables reconcile in unmanaged mode")
 		return nil
 	}
@@ -83,9 +83,16 @@ func (s *Service) reconcileRouteTables() error {
 				}
 			}
 
+			// The routes to the NAT gateways are deleted while they are suspended by the cost savings schedule,
+			// and created again once the NAT gateways are recreated.
+			if err := s.createMissingNatGatewayRoutes(routes, rt); err != nil {
+				return err
+			}
+
 			// Make sure tags are up-to-date.
 			if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
 				buildParams := s.getRouteTableTagParams(*rt.RouteTableId, sn.IsPublic, sn.AvailabilityZone)
+				s.scope.ResourceNaming().KeepName(&buildParams, converters.TagsToMap(rt.Tags))
 				tagsBuilder := tags.New(&buildParams, tags.WithEC2(s.EC2Client))
 				if err := tagsBuilder.Ensure(converters.TagsToMap(rt.Tags)); err != nil {
 					return false, err
@@ -130,13 +137,15 @@ func (s *Service) fixMismatchedRouting(specRoute *ec2.CreateRouteInput, currentR
 	if specRoute.DestinationCidrBlock != nil {
 		if (currentRoute.DestinationCidrBlock != nil &&
 			*currentRoute.DestinationCidrBlock == *specRoute.DestinationCidrBlock) &&
-			((currentRoute.GatewayId != nil && *currentRoute.GatewayId != *specRoute.GatewayId) ||
-				(currentRoute.NatGatewayId != nil && *currentRoute.NatGatewayId != *specRoute.NatGatewayId)) {
+			((currentRoute.GatewayId != nil && *currentRoute.GatewayId != aws.StringValue(specRoute.GatewayId)) ||
+				(currentRoute.NatGatewayId != nil && *currentRoute.NatGatewayId != aws.StringValue(specRoute.NatGatewayId)) ||
+				(currentRoute.LocalGatewayId != nil && *currentRoute.LocalGatewayId != aws.StringValue(specRoute.LocalGatewayId))) {
 			input = &ec2.ReplaceRouteInput{
 				RouteTableId:         rt.RouteTableId,
 				DestinationCidrBlock: specRoute.DestinationCidrBlock,
 				GatewayId:            specRoute.GatewayId,
 				NatGatewayId:         specRoute.NatGatewayId,
+				LocalGatewayId:       specRoute.LocalGatewayId,
 			}
 		}
 	}
@@ -169,6 +178,68 @@ func (s *Service) fixMismatchedRouting(specRoute *ec2.CreateRouteInput, currentR
 	return nil
 }
 
+// createMissingNatGatewayRoutes creates the routes to NAT gateways of the spec which are missing from the route table.
+func (s *Service) createMissingNatGatewayRoutes(specRoutes []*ec2.CreateRouteInput, rt *ec2.RouteTable) error {
+	for _, specRoute := range specRoutes {
+		if specRoute.NatGatewayId == nil || specRoute.DestinationCidrBlock == nil {
+			continue
+		}
+		found := false
+		for _, currentRoute := range rt.Routes {
+			if aws.StringValue(currentRoute.DestinationCidrBlock) == *specRoute.DestinationCidrBlock {
+				found = true
+				break
+			}
+		}
+		if found {
+			continue
+		}
+
+		input := &ec2.CreateRouteInput{
+			RouteTableId:         rt.RouteTableId,
+			DestinationCidrBlock: specRoute.DestinationCidrBlock,
+			NatGatewayId:         specRoute.NatGatewayId,
+		}
+		if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
+			if _, err := s.EC2Client.CreateRouteWithContext(context.TODO(), input); err != nil {
+				return false, err
+			}
+			return true, nil
+		}, awserrors.NATGatewayNotFound); err != nil {
+			record.Warnf(s.scope.InfraCluster(), "FailedCreateRoute", "Failed to create route %s for RouteTable %q: %v", input.GoString(), *rt.RouteTableId, err)
+			return errors.Wrapf(err, "failed to create route in route table %q: %s", *rt.RouteTableId, input.GoString())
+		}
+		record.Eventf(s.scope.InfraCluster(), "SuccessfulCreateRoute", "Created route %s for RouteTable %q", input.GoString(), *rt.RouteTableId)
+	}
+	return nil
+}
+
+// deleteNatGatewayRoutes deletes the routes of the route tables of the cluster to the NAT gateways.
+func (s *Service) deleteNatGatewayRoutes(natGatewayIDs map[string]bool) error {
+	rts, err := s.describeVpcRouteTables()
+	if err != nil {
+		return err
+	}
+
+	for _, rt := range rts {
+		for _, route := range rt.Routes {
+			if !natGatewayIDs[aws.StringValue(route.NatGatewayId)] {
+				continue
+			}
+			if _, err := s.EC2Client.DeleteRouteWithContext(context.TODO(), &ec2.DeleteRouteInput{
+				RouteTableId:             rt.RouteTableId,
+				DestinationCidrBlock:     route.DestinationCidrBlock,
+				DestinationIpv6CidrBlock: route.DestinationIpv6CidrBlock,
+			}); err != nil {
+				record.Warnf(s.scope.InfraCluster(), "FailedDeleteRoute", "Failed to delete route to NAT gateway %q from RouteTable %q: %v", *route.NatGatewayId, *rt.RouteTableId, err)
+				return errors.Wrapf(err, "failed to delete route to NAT gateway %q from route table %q", *route.NatGatewayId, *rt.RouteTableId)
+			}
+			record.Eventf(s.scope.InfraCluster(), "SuccessfulDeleteRoute", "Deleted route to NAT gateway %q from RouteTable %q", *route.NatGatewayId, *rt.RouteTableId)
+		}
+	}
+	return nil
+}
+
 func (s *Service) describeVpcRouteTablesBySubnet() (map[string]*ec2.RouteTable, error) {
 	rts, err := s.describeVpcRouteTables()
 	if err != nil {
@@ -221,7 +292,7 @@ func (s *Service) deleteRouteTable(rt *ec2.RouteTable) error {
 }
 
 func (s *Service) deleteRouteTables() error {
-	if s.scope.VPC().IsUnmanaged(s.scope.Name()) {
+	if s.scope.VPC().IsUnmanaged(s.scope.Name(), s.scope.OwnershipTagPrefixes()...) {
 		s.scope.Trace("Skipping routing tables deletion in unmanaged mode")
 		return nil
 	}
@@ -245,8 +316,8 @@ func (s *Service) describeVpcRouteTables() ([]*ec2.RouteTable, error) {
 		filter.EC2.VPC(s.scope.VPC().ID),
 	}
 
-	if !s.scope.VPC().IsUnmanaged(s.scope.Name()) {
-		filters = append(filters, filter.EC2.Cluster(s.scope.Name()))
+	if !s.scope.VPC().IsUnmanaged(s.scope.Name(), s.scope.OwnershipTagPrefixes()...) {
+		filters = append(filters, filter.EC2.Cluster(s.scope.Name(), s.scope.OwnershipTagPrefixes()...))
 	}
 
 	out, err := s.EC2Client.DescribeRouteTablesWithContext(context.TODO(), &ec2.DescribeRouteTablesInput{
@@ -319,6 +390,13 @@ func (s *Service) getNatGatewayPrivateRoute(natGatewayID string) *ec2.CreateRout
 	}
 }
 
+func (s *Service) getLocalGatewayPrivateRoute(localGatewayID string) *ec2.CreateRouteInput {
+	return &ec2.CreateRouteInput{
+		LocalGatewayId:       aws.String(localGatewayID),
+		DestinationCidrBlock: aws.String(services.AnyIPv4CidrBlock),
+	}
+}
+
 func (s *Service) getEgressOnlyInternetGateway() *ec2.CreateRouteInput {
 	return &ec2.CreateRouteInput{
 		DestinationIpv6CidrBlock:    aws.String(services.AnyIPv6CidrBlock),
@@ -350,26 +428,33 @@ func (s *Service) getCarrierGatewayPublicIPv4Route() *ec2.CreateRouteInput {
 func (s *Service) getRouteTableTagParams(id string, public bool, zone string) infrav1.BuildParams {
 	var name strings.Builder
 
-	name.WriteString(s.scope.Name())
-	name.WriteString("-rt-")
+	role := infrav1.PrivateRoleTagValue
 	if public {
-		name.WriteString("public")
-	} else {
-		name.WriteString("private")
+		role = infrav1.PublicRoleTagValue
 	}
+	name.WriteString(s.scope.Name())
+	name.WriteString("-rt-")
+	name.WriteString(role)
 	name.WriteString("-")
 	name.WriteString(zone)
+	rtName := s.scope.ResourceNaming().Name(name.String(), infrav1.ResourceNameData{
+		ClusterName:  s.scope.Name(),
+		ResourceType: infrav1.ResourceTypeRouteTable,
+		AZ:           zone,
+		Role:         role,
+	})
 
 	additionalTags := s.scope.AdditionalTags()
 	additionalTags[infrav1.ClusterAWSCloudProviderTagKey(s.scope.KubernetesClusterName())] = string(infrav1.ResourceLifecycleOwned)
 
 	return infrav1.BuildParams{
-		ClusterName: s.scope.Name(),
-		ResourceID:  id,
-		Lifecycle:   infrav1.ResourceLifecycleOwned,
-		Name:        aws.String(name.String()),
-		Role:        aws.String(infrav1.CommonRoleTagValue),
-		Additional:  additionalTags,
+		ClusterName:        s.scope.Name(),
+		OwnershipTagPrefix: s.scope.OwnershipTagPrefix(),
+		ResourceID:         id,
+		Lifecycle:          infrav1.ResourceLifecycleOwned,
+		Name:               aws.String(rtName),
+		Role:               aws.String(infrav1.CommonRoleTagValue),
+		Additional:         additionalTags,
 	}
 }
 
@@ -400,18 +485,28 @@ func (s *Service) getRoutesToPublicSubnet(sn *infrav1.SubnetSpec) ([]*ec2.Create
 }
 
 func (s *Service) getRoutesToPrivateSubnet(sn *infrav1.SubnetSpec) (routes []*ec2.CreateRouteInput, err error) {
-	var natGatewayID string
+	var natGatewayID, localGatewayID string
 
 	if sn.IsEdge() && sn.IsIPv6 {
 		return nil, errors.Errorf("can't determine routes for unsupported ipv6 subnet in zone type %q", sn.ZoneType)
 	}
 
-	natGatewayID, err = s.getNatGatewayForSubnet(sn)
-	if err != nil {
-		return routes, err
+	// Subnets on an AWS Outpost egress through the Outpost's local gateway, NAT Gateways
+	// are not supported on Outposts.
+	if sn.IsOutpost() {
+		localGatewayID, err = s.getLocalGatewayForSubnet(sn)
+		if err != nil {
+			return routes, err
+		}
+		routes = append(routes, s.getLocalGatewayPrivateRoute(localGatewayID))
+	} else if !s.scope.NatGatewaysSuspended() && !s.scope.VPC().PrivateOnly {
+		// The default route of a private only VPC, e.g. to a transit gateway, is left to the user.
+		natGatewayID, err = s.getNatGatewayForSubnet(sn)
+		if err != nil {
+			return routes, err
+		}
+		routes = append(routes, s.getNatGatewayPrivateRoute(natGatewayID))
 	}
-
-	routes = append(routes, s.getNatGatewayPrivateRoute(natGatewayID))
 	if sn.IsIPv6 {
 		if !s.scope.VPC().IsIPv6Enabled() {
 			// Safety net because EgressOnlyInternetGateway needs the ID from the ipv6 block.
@@ -425,6 +520,16 @@ func (s *Service) getRoutesToPrivateSubnet(sn *infrav1.SubnetSpec) (routes []*ec
 	return routes, nil
 }
 
+// getLocalGatewayForSubnet returns the local gateway configured for the Outpost of the subnet.
+func (s *Service) getLocalGatewayForSubnet(sn *infrav1.SubnetSpec) (string, error) {
+	for _, lgwrt := range s.scope.LocalGatewayRouteTables() {
+		if lgwrt.OutpostARN == aws.StringValue(sn.OutpostARN) && lgwrt.LocalGatewayID != "" {
+			return lgwrt.LocalGatewayID, nil
+		}
+	}
+	return "", errors.Errorf("no local gateway available for outpost %q of private subnet %q", aws.StringValue(sn.OutpostARN), sn.GetResourceID())
+}
+
 func (s *Service) getRoutesForSubnet(sn *infrav1.SubnetSpec) ([]*ec2.CreateRouteInput, error) {
 	if sn.IsPublic {
 		return s.getRoutesToPublicSubnet(sn)
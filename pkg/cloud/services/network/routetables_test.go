@@ -973,6 +973,12 @@ func TestService_getRoutesForSubnet(t *testing.T) {
 			},
 		},
 		Subnets: defaultSubnets,
+		LocalGatewayRouteTables: []infrav1.LocalGatewayRouteTable{
+			{
+				OutpostARN:     "arn:aws:outposts:us-east-1:123456789012:outpost/op-0123456789abcdef0",
+				LocalGatewayID: "lgw-0123456789abcdef0",
+			},
+		},
 	}
 
 	tests := []struct {
@@ -1184,6 +1190,44 @@ func TestService_getRoutesForSubnet(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "private ipv4 subnet, outpost, must have ipv4 default route to local gateway",
+			inputSubnet: &infrav1.SubnetSpec{
+				ResourceID:       "subnet-op-1a-private",
+				AvailabilityZone: "us-east-1a",
+				OutpostARN:       aws.String("arn:aws:outposts:us-east-1:123456789012:outpost/op-0123456789abcdef0"),
+				IsPublic:         false,
+			},
+			want: []*ec2.CreateRouteInput{
+				{
+					DestinationCidrBlock: aws.String("0.0.0.0/0"),
+					LocalGatewayId:       aws.String("lgw-0123456789abcdef0"),
+				},
+			},
+		},
+		{
+			name: "private ipv4 subnet, outpost, must return error when no local gateway found",
+			inputSubnet: &infrav1.SubnetSpec{
+				ResourceID:       "subnet-op-1a-private",
+				AvailabilityZone: "us-east-1a",
+				OutpostARN:       aws.String("arn:aws:outposts:us-east-1:123456789012:outpost/op-unknown"),
+				IsPublic:         false,
+			},
+			wantErrMessage: `no local gateway available for outpost "arn:aws:outposts:us-east-1:123456789012:outpost/op-unknown" of private subnet "subnet-op-1a-private"`,
+		},
+		{
+			name: "private ipv4 subnet, private only VPC, must have no default route",
+			specOverrideNet: func() *infrav1.NetworkSpec {
+				net := defaultNetwork.DeepCopy()
+				net.VPC.PrivateOnly = true
+				return net
+			}(),
+			inputSubnet: &infrav1.SubnetSpec{
+				ResourceID:       "subnet-az-1a-private",
+				AvailabilityZone: "us-east-1a",
+				IsPublic:         false,
+			},
+		},
 		// egress-only subnet ipv6
 		{
 			name: "egress-only ipv6 subnet, availability zone, must have ipv6 default route to egress-only gateway",
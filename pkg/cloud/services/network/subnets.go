@@ -66,7 +66,7 @@ func (s *Service) reconcileSubnets() error {
 		}
 	}
 
-	unmanagedVPC := s.scope.VPC().IsUnmanaged(s.scope.Name())
+	unmanagedVPC := s.scope.VPC().IsUnmanaged(s.scope.Name(), s.scope.OwnershipTagPrefixes()...)
 
 	if len(subnets) == 0 {
 		if unmanagedVPC {
@@ -145,11 +145,16 @@ func (s *Service) reconcileSubnets() error {
 			// Make sure tags are up-to-date.
 			subnetTags := sub.Tags
 			if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
-				buildParams := s.getSubnetTagParams(unmanagedVPC, existingSubnet.GetResourceID(), existingSubnet.IsPublic, existingSubnet.AvailabilityZone, subnetTags, existingSubnet.IsEdge())
+				buildParams := s.getSubnetTagParams(unmanagedVPC, existingSubnet.GetResourceID(), existingSubnet.IsPublic, existingSubnet.AvailabilityZone, subnetTags, existingSubnet.IsEdge(), s.subnetIndex(existingSubnet))
 				tagsBuilder := tags.New(&buildParams, tags.WithEC2(s.EC2Client))
 				if err := tagsBuilder.Ensure(existingSubnet.Tags); err != nil {
 					return false, err
 				}
+				if !unmanagedVPC || s.scope.TagUnmanagedNetworkResources() {
+					if err := tags.RemoveLegacyClusterTag(s.EC2Client, s.scope.LegacyClusterTag(), s.scope.KubernetesClusterName(), existingSubnet.GetResourceID(), existingSubnet.Tags); err != nil {
+						return false, err
+					}
+				}
 				return true, nil
 			}, awserrors.SubnetNotFound); err != nil {
 				if !unmanagedVPC {
@@ -190,7 +195,8 @@ func (s *Service) reconcileSubnets() error {
 			record.Warnf(s.scope.InfraCluster(), "FailedNoPrivateSubnet", "Expected at least 1 private subnet but got 0")
 			return errors.New("expected at least 1 private subnet but got 0")
 		}
-		if len(subnets.FilterPublic()) < 1 {
+		// A private only VPC has no public subnets.
+		if len(subnets.FilterPublic()) < 1 && !s.scope.VPC().PrivateOnly {
 			record.Warnf(s.scope.InfraCluster(), "FailedNoPublicSubnet", "Expected at least 1 public subnet but got 0")
 			return errors.New("expected at least 1 public subnet but got 0")
 		}
@@ -275,6 +281,10 @@ func (s *Service) getDefaultSubnets() (infrav1.Subnets, error) {
 		s.scope.Debug("zones selected", "region", s.scope.Region(), "zones", zones)
 	}
 
+	if s.scope.VPC().PrivateOnly {
+		return s.getDefaultPrivateOnlySubnets(zones)
+	}
+
 	// 1 private subnet for each AZ plus 1 other subnet that will be further sub-divided for the public subnets or vice versa if
 	// the subnet schema is set to prefer public subnets.
 	// All subnets will have an ipv4 address for now as well. We aren't supporting ipv6-only yet.
@@ -362,8 +372,41 @@ func (s *Service) getDefaultSubnets() (infrav1.Subnets, error) {
 	return subnets, nil
 }
 
+// getDefaultPrivateOnlySubnets returns 1 private subnet for each AZ, splitting the whole VPC CIDR between them.
+func (s *Service) getDefaultPrivateOnlySubnets(zones []string) (infrav1.Subnets, error) {
+	subnetCIDRs, err := cidr.SplitIntoSubnetsIPv4(s.scope.VPC().CidrBlock, len(zones))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed splitting VPC CIDR %q into subnets", s.scope.VPC().CidrBlock)
+	}
+
+	var ipv6SubnetCIDRs []*net.IPNet
+	if s.scope.VPC().IsIPv6Enabled() {
+		ipv6SubnetCIDRs, err = cidr.SplitIntoSubnetsIPv6(s.scope.VPC().IPv6.CidrBlock, len(zones))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed splitting IPv6 VPC CIDR %q into subnets", s.scope.VPC().IPv6.CidrBlock)
+		}
+	}
+
+	subnets := infrav1.Subnets{}
+	for i, zone := range zones {
+		privateSubnet := infrav1.SubnetSpec{
+			ID:               fmt.Sprintf("%s-subnet-%s-%s", s.scope.Name(), infrav1.PrivateRoleTagValue, zone),
+			CidrBlock:        subnetCIDRs[i].String(),
+			AvailabilityZone: zone,
+			IsPublic:         false,
+		}
+		if s.scope.VPC().IsIPv6Enabled() {
+			privateSubnet.IPv6CidrBlock = ipv6SubnetCIDRs[i].String()
+			privateSubnet.IsIPv6 = true
+		}
+		subnets = append(subnets, privateSubnet)
+	}
+
+	return subnets, nil
+}
+
 func (s *Service) deleteSubnets() error {
-	if s.scope.VPC().IsUnmanaged(s.scope.Name()) {
+	if s.scope.VPC().IsUnmanaged(s.scope.Name(), s.scope.OwnershipTagPrefixes()...) {
 		s.scope.Trace("Skipping subnets deletion in unmanaged mode")
 		return nil
 	}
@@ -440,6 +483,12 @@ func (s *Service) describeVpcSubnets() (infrav1.Subnets, error) {
 			}
 		}
 
+		// ... unless it is on an AWS Outpost, where subnets are always private.
+		if aws.StringValue(ec2sn.OutpostArn) != "" {
+			spec.OutpostARN = ec2sn.OutpostArn
+			spec.IsPublic = false
+		}
+
 		ngw := natGateways[*ec2sn.SubnetId]
 		if ngw != nil {
 			spec.NatGatewayID = ngw.NatGatewayId
@@ -458,7 +507,7 @@ func (s *Service) describeSubnets() (*ec2.DescribeSubnetsOutput, error) {
 	}
 
 	if s.scope.VPC().ID == "" {
-		input.Filters = append(input.Filters, filter.EC2.Cluster(s.scope.Name()))
+		input.Filters = append(input.Filters, filter.EC2.Cluster(s.scope.Name(), s.scope.OwnershipTagPrefixes()...))
 	} else {
 		input.Filters = append(input.Filters, filter.EC2.VPC(s.scope.VPC().ID))
 	}
@@ -511,7 +560,7 @@ func (s *Service) createSubnet(sn *infrav1.SubnetSpec) (*infrav1.SubnetSpec, err
 		TagSpecifications: []*ec2.TagSpecification{
 			tags.BuildParamsToTagSpecification(
 				ec2.ResourceTypeSubnet,
-				s.getSubnetTagParams(false, services.TemporaryResourceID, sn.IsPublic, sn.AvailabilityZone, sn.Tags, sn.IsEdge()),
+				s.getSubnetTagParams(false, services.TemporaryResourceID, sn.IsPublic, sn.AvailabilityZone, sn.Tags, sn.IsEdge(), s.subnetIndex(sn)),
 			),
 		},
 	}
@@ -519,6 +568,9 @@ func (s *Service) createSubnet(sn *infrav1.SubnetSpec) (*infrav1.SubnetSpec, err
 		input.Ipv6CidrBlock = aws.String(sn.IPv6CidrBlock)
 		sn.IsIPv6 = true
 	}
+	if sn.IsOutpost() {
+		input.OutpostArn = sn.OutpostARN
+	}
 	out, err := s.EC2Client.CreateSubnetWithContext(context.TODO(), input)
 	if err != nil {
 		record.Warnf(s.scope.InfraCluster(), "FailedCreateSubnet", "Failed creating new managed Subnet %v", err)
@@ -602,6 +654,7 @@ func (s *Service) createSubnet(sn *infrav1.SubnetSpec) (*infrav1.SubnetSpec, err
 		CidrBlock:        *out.Subnet.CidrBlock, // TODO: this will panic in case of IPv6 only subnets...
 		IsPublic:         sn.IsPublic,
 		Tags:             sn.Tags,
+		OutpostARN:       sn.OutpostARN,
 	}
 	for _, set := range out.Subnet.Ipv6CidrBlockAssociationSet {
 		if *set.Ipv6CidrBlockState.State == ec2.SubnetCidrBlockStateCodeAssociated {
@@ -634,7 +687,22 @@ func (s *Service) deleteSubnet(id string) error {
 	return nil
 }
 
-func (s *Service) getSubnetTagParams(unmanagedVPC bool, id string, public bool, zone string, manualTags infrav1.Tags, isEdge bool) infrav1.BuildParams {
+// subnetIndex returns the position of a subnet among the subnets of the spec with the same role in its availability
+// zone, for the resource naming template.
+func (s *Service) subnetIndex(sn *infrav1.SubnetSpec) int {
+	index := 0
+	for _, other := range s.scope.Subnets() {
+		if other.CidrBlock == sn.CidrBlock {
+			break
+		}
+		if other.IsPublic == sn.IsPublic && other.AvailabilityZone == sn.AvailabilityZone {
+			index++
+		}
+	}
+	return index
+}
+
+func (s *Service) getSubnetTagParams(unmanagedVPC bool, id string, public bool, zone string, manualTags infrav1.Tags, isEdge bool, index int) infrav1.BuildParams {
 	var role string
 	additionalTags := make(map[string]string)
 
@@ -656,6 +724,7 @@ func (s *Service) getSubnetTagParams(unmanagedVPC bool, id string, public bool,
 		// Add tag needed for Service type=LoadBalancer
 		if unmanagedVPC {
 			additionalTags[infrav1.ClusterAWSCloudProviderTagKey(s.scope.KubernetesClusterName())] = string(infrav1.ResourceLifecycleShared)
+			s.scope.LegacyClusterTag().Apply(additionalTags, s.scope.KubernetesClusterName(), infrav1.ResourceLifecycleShared)
 		} else {
 			additionalTags[infrav1.ClusterAWSCloudProviderTagKey(s.scope.KubernetesClusterName())] = string(infrav1.ResourceLifecycleOwned)
 		}
@@ -665,26 +734,32 @@ func (s *Service) getSubnetTagParams(unmanagedVPC bool, id string, public bool,
 		for k, v := range manualTags {
 			additionalTags[k] = v
 		}
+		// The tags of existing subnets are read back into the spec, so the configuration of the legacy
+		// cluster tag is applied last.
+		s.scope.LegacyClusterTag().Apply(additionalTags, s.scope.KubernetesClusterName(), infrav1.ResourceLifecycleOwned)
 
 		// Prefer `Name` tag if given, else generate a name
 		var name strings.Builder
 		if manualTagName, ok := manualTags["Name"]; ok {
 			name.WriteString(manualTagName)
 		} else {
-			name.WriteString(s.scope.Name())
-			name.WriteString("-subnet-")
-			name.WriteString(role)
-			name.WriteString("-")
-			name.WriteString(zone)
+			name.WriteString(s.scope.ResourceNaming().Name(fmt.Sprintf("%s-subnet-%s-%s", s.scope.Name(), role, zone), infrav1.ResourceNameData{
+				ClusterName:  s.scope.Name(),
+				ResourceType: infrav1.ResourceTypeSubnet,
+				AZ:           zone,
+				Role:         role,
+				Index:        index,
+			}))
 		}
 
 		return infrav1.BuildParams{
-			ClusterName: s.scope.Name(),
-			ResourceID:  id,
-			Lifecycle:   infrav1.ResourceLifecycleOwned,
-			Name:        aws.String(name.String()),
-			Role:        aws.String(role),
-			Additional:  additionalTags,
+			ClusterName:        s.scope.Name(),
+			OwnershipTagPrefix: s.scope.OwnershipTagPrefix(),
+			ResourceID:         id,
+			Lifecycle:          infrav1.ResourceLifecycleOwned,
+			Name:               aws.String(name.String()),
+			Role:               aws.String(role),
+			Additional:         additionalTags,
 		}
 	}
 
@@ -37,6 +37,7 @@ import (
 	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
@@ -1682,6 +1683,109 @@ func TestReconcileSubnets(t *testing.T) {
 					}, nil)
 			},
 		},
+		{
+			name: "Managed private only VPC, no existing subnets exist, one az, expect one private from default",
+			input: NewClusterScope().WithNetwork(&infrav1.NetworkSpec{
+				VPC: infrav1.VPCSpec{
+					ID: subnetsVPCID,
+					Tags: infrav1.Tags{
+						infrav1.ClusterTagKey("test-cluster"): "owned",
+					},
+					CidrBlock:   defaultVPCCidr,
+					PrivateOnly: true,
+				},
+				Subnets: []infrav1.SubnetSpec{},
+			}),
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				describeCall := m.DescribeSubnetsWithContext(context.TODO(), gomock.Eq(&ec2.DescribeSubnetsInput{
+					Filters: []*ec2.Filter{
+						{
+							Name:   aws.String("state"),
+							Values: []*string{aws.String("pending"), aws.String("available")},
+						},
+						{
+							Name:   aws.String("vpc-id"),
+							Values: []*string{aws.String(subnetsVPCID)},
+						},
+					},
+				})).
+					Return(&ec2.DescribeSubnetsOutput{}, nil)
+
+				m.DescribeRouteTablesWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeRouteTablesInput{})).
+					Return(&ec2.DescribeRouteTablesOutput{}, nil)
+
+				m.DescribeNatGatewaysPagesWithContext(context.TODO(),
+					gomock.Eq(&ec2.DescribeNatGatewaysInput{
+						Filter: []*ec2.Filter{
+							{
+								Name:   aws.String("vpc-id"),
+								Values: []*string{aws.String(subnetsVPCID)},
+							},
+							{
+								Name:   aws.String("state"),
+								Values: []*string{aws.String("pending"), aws.String("available")},
+							},
+						},
+					}),
+					gomock.Any()).Return(nil)
+
+				m.DescribeAvailabilityZonesWithContext(context.TODO(), gomock.Any()).
+					Return(&ec2.DescribeAvailabilityZonesOutput{
+						AvailabilityZones: []*ec2.AvailabilityZone{
+							{
+								ZoneName: aws.String("us-east-1c"),
+								ZoneType: aws.String("availability-zone"),
+							},
+						},
+					}, nil).AnyTimes()
+
+				privateSubnet := m.CreateSubnetWithContext(context.TODO(), gomock.Eq(&ec2.CreateSubnetInput{
+					VpcId:            aws.String(subnetsVPCID),
+					CidrBlock:        aws.String("10.0.0.0/16"),
+					AvailabilityZone: aws.String("us-east-1c"),
+					TagSpecifications: []*ec2.TagSpecification{
+						{
+							ResourceType: aws.String("subnet"),
+							Tags: []*ec2.Tag{
+								{
+									Key:   aws.String("Name"),
+									Value: aws.String("test-cluster-subnet-private-us-east-1c"),
+								},
+								{
+									Key:   aws.String("kubernetes.io/cluster/test-cluster"),
+									Value: aws.String("owned"),
+								},
+								{
+									Key:   aws.String("kubernetes.io/role/internal-elb"),
+									Value: aws.String("1"),
+								},
+								{
+									Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/cluster/test-cluster"),
+									Value: aws.String("owned"),
+								},
+								{
+									Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/role"),
+									Value: aws.String("private"),
+								},
+							},
+						},
+					},
+				})).
+					Return(&ec2.CreateSubnetOutput{
+						Subnet: &ec2.Subnet{
+							VpcId:               aws.String(subnetsVPCID),
+							SubnetId:            aws.String("subnet-1"),
+							CidrBlock:           aws.String("10.0.0.0/16"),
+							AvailabilityZone:    aws.String("us-east-1c"),
+							MapPublicIpOnLaunch: aws.Bool(false),
+						},
+					}, nil).
+					After(describeCall)
+
+				m.WaitUntilSubnetAvailableWithContext(context.TODO(), gomock.Any()).
+					After(privateSubnet)
+			},
+		},
 		{
 			name: "Managed IPv6 VPC, no existing subnets exist, one az, expect one private and one public from default",
 			input: NewClusterScope().WithNetwork(&infrav1.NetworkSpec{
@@ -4036,6 +4140,92 @@ func TestDiscoverSubnets(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "provided VPC classifies outpost subnets as private",
+			input: &infrav1.NetworkSpec{
+				VPC: infrav1.VPCSpec{
+					ID: subnetsVPCID,
+				},
+				Subnets: []infrav1.SubnetSpec{
+					{
+						ID:               "subnet-1",
+						AvailabilityZone: "us-east-1a",
+						CidrBlock:        "10.0.10.0/24",
+						ZoneType:         ptr.To[infrav1.ZoneType]("availability-zone"),
+					},
+				},
+			},
+			mocks: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeSubnetsWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeSubnetsInput{})).
+					Return(&ec2.DescribeSubnetsOutput{
+						Subnets: []*ec2.Subnet{
+							{
+								VpcId:            aws.String(subnetsVPCID),
+								SubnetId:         aws.String("subnet-1"),
+								AvailabilityZone: aws.String("us-east-1a"),
+								CidrBlock:        aws.String("10.0.10.0/24"),
+								OutpostArn:       aws.String("arn:aws:outposts:us-east-1:123456789012:outpost/op-0123456789abcdef0"),
+								Tags: []*ec2.Tag{
+									{
+										Key:   aws.String("Name"),
+										Value: aws.String("provided-subnet-outpost"),
+									},
+								},
+							},
+						},
+					}, nil)
+
+				m.DescribeAvailabilityZonesWithContext(context.TODO(), gomock.Any()).
+					Return(&ec2.DescribeAvailabilityZonesOutput{
+						AvailabilityZones: []*ec2.AvailabilityZone{
+							{
+								ZoneName: aws.String("us-east-1a"),
+								ZoneType: aws.String("availability-zone"),
+							},
+						},
+					}, nil)
+
+				m.DescribeRouteTablesWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeRouteTablesInput{})).
+					Return(&ec2.DescribeRouteTablesOutput{
+						RouteTables: []*ec2.RouteTable{
+							{
+								Associations: []*ec2.RouteTableAssociation{
+									{
+										SubnetId: aws.String("subnet-1"),
+									},
+								},
+								Routes: []*ec2.Route{
+									{
+										DestinationCidrBlock: aws.String("0.0.0.0/0"),
+										GatewayId:            aws.String("igw-0"),
+									},
+								},
+								RouteTableId: aws.String("rtb-1"),
+							},
+						},
+					}, nil)
+
+				m.DescribeNatGatewaysPagesWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeNatGatewaysInput{}), gomock.Any()).Return(nil)
+
+				m.CreateTagsWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.CreateTagsInput{})).
+					Return(&ec2.CreateTagsOutput{}, nil).AnyTimes()
+			},
+			expect: []infrav1.SubnetSpec{
+				{
+					ID:               "subnet-1",
+					ResourceID:       "subnet-1",
+					AvailabilityZone: "us-east-1a",
+					CidrBlock:        "10.0.10.0/24",
+					IsPublic:         false,
+					RouteTableID:     aws.String("rtb-1"),
+					OutpostARN:       aws.String("arn:aws:outposts:us-east-1:123456789012:outpost/op-0123456789abcdef0"),
+					Tags: infrav1.Tags{
+						"Name": "provided-subnet-outpost",
+					},
+					ZoneType: ptr.To[infrav1.ZoneType]("availability-zone"),
+				},
+			},
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -4252,10 +4442,8 @@ func (b *ClusterScopeBuilder) WithTagUnmanagedNetworkResources(value bool) *Clus
 func (b *ClusterScopeBuilder) Build() (scope.NetworkScope, error) {
 	scheme := runtime.NewScheme()
 	_ = infrav1.AddToScheme(scheme)
-	client := fake.NewClientBuilder().WithScheme(scheme).Build()
 
 	param := &scope.ClusterScopeParams{
-		Client: client,
 		Cluster: &clusterv1.Cluster{
 			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
 		},
@@ -4269,6 +4457,9 @@ func (b *ClusterScopeBuilder) Build() (scope.NetworkScope, error) {
 		customizer(param)
 	}
 
+	// The AWSCluster is stored, as the conditions set while creating the session are patched with the subnets.
+	param.Client = fake.NewClientBuilder().WithScheme(scheme).WithObjects(param.AWSCluster).WithStatusSubresource(param.AWSCluster).Build()
+
 	return scope.NewClusterScope(*param)
 }
 
@@ -4302,10 +4493,8 @@ func (b *ManagedControlPlaneScopeBuilder) Build() (scope.NetworkScope, error) {
 	scheme := runtime.NewScheme()
 	_ = infrav1.AddToScheme(scheme)
 	_ = ekscontrolplanev1.AddToScheme(scheme)
-	client := fake.NewClientBuilder().WithScheme(scheme).Build()
 
 	param := &scope.ManagedControlPlaneScopeParams{
-		Client: client,
 		Cluster: &clusterv1.Cluster{
 			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
 		},
@@ -4319,6 +4508,9 @@ func (b *ManagedControlPlaneScopeBuilder) Build() (scope.NetworkScope, error) {
 		customizer(param)
 	}
 
+	// The control plane is stored, as the conditions set while creating the session are patched with the subnets.
+	param.Client = fake.NewClientBuilder().WithScheme(scheme).WithObjects(param.ControlPlane).WithStatusSubresource(param.ControlPlane).Build()
+
 	return scope.NewManagedControlPlaneScope(*param)
 }
 
@@ -4758,3 +4950,51 @@ func stubMockDescribeAvailabilityZonesWithContextCustomZones(m *mocks.MockEC2API
 			AvailabilityZones: zones,
 		}, nil).AnyTimes()
 }
+
+func TestGetTagParamsWithResourceNaming(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+	awsCluster := &infrav1.AWSCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec: infrav1.AWSClusterSpec{
+			NetworkSpec: infrav1.NetworkSpec{
+				VPC: infrav1.VPCSpec{ID: subnetsVPCID},
+				Subnets: infrav1.Subnets{
+					{CidrBlock: "10.0.0.0/24", AvailabilityZone: "us-east-1a"},
+					{CidrBlock: "10.0.1.0/24", AvailabilityZone: "us-east-1a", IsPublic: true},
+					{CidrBlock: "10.0.2.0/24", AvailabilityZone: "us-east-1a"},
+				},
+			},
+			ResourceNaming: &infrav1.ResourceNaming{
+				Template: "{{.ClusterName}}.{{.ResourceType}}{{with .Role}}.{{.}}{{end}}{{with .AZ}}.{{.}}{{end}}.{{.Index}}",
+			},
+		},
+	}
+	client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(awsCluster).Build()
+	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Cluster:    &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"}},
+		AWSCluster: awsCluster,
+		Client:     client,
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	s := NewService(clusterScope)
+
+	sn := &infrav1.SubnetSpec{CidrBlock: "10.0.2.0/24", AvailabilityZone: "us-east-1a"}
+	subnetParams := s.getSubnetTagParams(false, services.TemporaryResourceID, sn.IsPublic, sn.AvailabilityZone, nil, false, s.subnetIndex(sn))
+	g.Expect(*subnetParams.Name).To(Equal("test-cluster.subnet.private.us-east-1a.1"))
+
+	manualParams := s.getSubnetTagParams(false, services.TemporaryResourceID, sn.IsPublic, sn.AvailabilityZone, infrav1.Tags{"Name": "manual"}, false, s.subnetIndex(sn))
+	g.Expect(*manualParams.Name).To(Equal("manual"), "the Name tag of the subnet spec takes precedence over the template")
+
+	g.Expect(*s.getRouteTableTagParams(services.TemporaryResourceID, true, "us-east-1a").Name).To(Equal("test-cluster.route-table.public.us-east-1a.0"))
+	g.Expect(*s.getVPCTagParams(services.TemporaryResourceID).Name).To(Equal("test-cluster.vpc.common.0"))
+	g.Expect(*s.getGatewayTagParams(services.TemporaryResourceID, infrav1.ResourceTypeCarrierGateway).Name).To(Equal("test-cluster.carrier-gateway.common.0"))
+	g.Expect(*s.getNatGatewayTagParams(services.TemporaryResourceID, "us-east-1a").Name).To(Equal("test-cluster.nat-gateway.common.us-east-1a.0"))
+
+	// Existing resources keep their name.
+	vpcParams := s.getVPCTagParams(subnetsVPCID)
+	clusterScope.ResourceNaming().KeepName(&vpcParams, infrav1.Tags{"Name": "test-cluster-vpc"})
+	g.Expect(*vpcParams.Name).To(Equal("test-cluster-vpc"))
+}
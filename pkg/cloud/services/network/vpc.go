@@ -63,7 +63,7 @@ func (s *Service) reconcileVPC() error {
 		}
 
 		// If VPC is unmanaged, return early.
-		if vpc.IsUnmanaged(s.scope.Name()) {
+		if vpc.IsUnmanaged(s.scope.Name(), s.scope.OwnershipTagPrefixes()...) {
 			s.scope.Debug("Working on unmanaged VPC", "vpc-id", vpc.ID)
 			if err := s.scope.PatchObject(); err != nil {
 				return errors.Wrap(err, "failed to patch unmanaged VPC fields")
@@ -80,6 +80,7 @@ func (s *Service) reconcileVPC() error {
 		// **Only** do this for managed VPCs. Make sure this logic is below the above `vpc.IsUnmanaged` check.
 		if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
 			buildParams := s.getVPCTagParams(s.scope.VPC().ID)
+			s.scope.ResourceNaming().KeepName(&buildParams, s.scope.VPC().Tags)
 			tagsBuilder := tags.New(&buildParams, tags.WithEC2(s.EC2Client))
 			if err := tagsBuilder.Ensure(s.scope.VPC().Tags); err != nil {
 				return false, err
@@ -110,11 +111,11 @@ func (s *Service) reconcileVPC() error {
 	if err == nil {
 		// An VPC already exists with the desired name
 
-		if !vpc.Tags.HasOwned(s.scope.Name()) {
+		if !vpc.Tags.HasOwned(s.scope.Name(), s.scope.OwnershipTagPrefixes()...) {
 			return errors.Errorf(
 				"found VPC %q which cannot be managed by CAPA due to lack of tags (either tag the VPC manually with `%s=%s`, or provide the `vpc.id` field instead if you wish to bring your own VPC as shown in https://cluster-api-aws.sigs.k8s.io/topics/bring-your-own-aws-infrastructure)",
 				vpc.ID,
-				infrav1.ClusterTagKey(s.scope.Name()),
+				infrav1.OwnershipTagKey(s.scope.OwnershipTagPrefix(), s.scope.Name()),
 				infrav1.ResourceLifecycleOwned)
 		}
 	} else {
@@ -181,7 +182,7 @@ func (s *Service) describeVPCEndpoints(filters ...*ec2.Filter) ([]*ec2.VpcEndpoi
 // For more information, see: https://docs.aws.amazon.com/vpc/latest/privatelink/gateway-endpoints.html
 func (s *Service) reconcileVPCEndpoints() error {
 	// If the VPC is unmanaged or not yet populated, return early.
-	if s.scope.VPC().IsUnmanaged(s.scope.Name()) || s.scope.VPC().ID == "" {
+	if s.scope.VPC().IsUnmanaged(s.scope.Name(), s.scope.OwnershipTagPrefixes()...) || s.scope.VPC().ID == "" {
 		return nil
 	}
 
@@ -272,12 +273,12 @@ func (s *Service) reconcileVPCEndpoints() error {
 
 func (s *Service) deleteVPCEndpoints() error {
 	// If the VPC is unmanaged or not yet populated, return early.
-	if s.scope.VPC().IsUnmanaged(s.scope.Name()) || s.scope.VPC().ID == "" {
+	if s.scope.VPC().IsUnmanaged(s.scope.Name(), s.scope.OwnershipTagPrefixes()...) || s.scope.VPC().ID == "" {
 		return nil
 	}
 
 	// Get all existing endpoints.
-	endpoints, err := s.describeVPCEndpoints(filter.EC2.ClusterOwned(s.scope.Name()))
+	endpoints, err := s.describeVPCEndpoints(filter.EC2.ClusterOwned(s.scope.Name(), s.scope.OwnershipTagPrefix()))
 	if err != nil {
 		return errors.Wrap(err, "failed to describe vpc endpoints")
 	}
@@ -512,7 +513,7 @@ func (s *Service) createVPC() (*infrav1.VPCSpec, error) {
 func (s *Service) deleteVPC() error {
 	vpc := s.scope.VPC()
 
-	if vpc.IsUnmanaged(s.scope.Name()) {
+	if vpc.IsUnmanaged(s.scope.Name(), s.scope.OwnershipTagPrefixes()...) {
 		s.scope.Trace("Skipping VPC deletion in unmanaged mode")
 		return nil
 	}
@@ -643,23 +644,29 @@ func (s *Service) describeVPCByName() (*infrav1.VPCSpec, error) {
 }
 
 func (s *Service) getVPCTagParams(id string) infrav1.BuildParams {
-	name := fmt.Sprintf("%s-vpc", s.scope.Name())
+	name := s.scope.ResourceNaming().Name(fmt.Sprintf("%s-vpc", s.scope.Name()), infrav1.ResourceNameData{
+		ClusterName:  s.scope.Name(),
+		ResourceType: infrav1.ResourceTypeVPC,
+		Role:         infrav1.CommonRoleTagValue,
+	})
 
 	return infrav1.BuildParams{
-		ClusterName: s.scope.Name(),
-		ResourceID:  id,
-		Lifecycle:   infrav1.ResourceLifecycleOwned,
-		Name:        aws.String(name),
-		Role:        aws.String(infrav1.CommonRoleTagValue),
-		Additional:  s.scope.AdditionalTags(),
+		ClusterName:        s.scope.Name(),
+		OwnershipTagPrefix: s.scope.OwnershipTagPrefix(),
+		ResourceID:         id,
+		Lifecycle:          infrav1.ResourceLifecycleOwned,
+		Name:               aws.String(name),
+		Role:               aws.String(infrav1.CommonRoleTagValue),
+		Additional:         s.scope.AdditionalTags(),
 	}
 }
 
 func (s *Service) getVPCEndpointTagParams() infrav1.BuildParams {
 	return infrav1.BuildParams{
-		ClusterName: s.scope.Name(),
-		Lifecycle:   infrav1.ResourceLifecycleOwned,
-		Role:        aws.String(infrav1.CommonRoleTagValue),
-		Additional:  s.scope.AdditionalTags(),
+		ClusterName:        s.scope.Name(),
+		OwnershipTagPrefix: s.scope.OwnershipTagPrefix(),
+		Lifecycle:          infrav1.ResourceLifecycleOwned,
+		Role:               aws.String(infrav1.CommonRoleTagValue),
+		Additional:         s.scope.AdditionalTags(),
 	}
 }
@@ -0,0 +1,190 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package networksummary
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/filter"
+)
+
+// ReconcileNetworkSummary publishes the network resources of the cluster in the status of the AWSCluster. The
+// last update time of the summary only changes when the network resources changed.
+func (s *Service) ReconcileNetworkSummary() error {
+	awsCluster := s.scope.AWSCluster
+	vpcID := s.scope.VPC().ID
+	if vpcID == "" {
+		return nil
+	}
+
+	s.scope.Debug("Reconciling network summary")
+
+	vpc, err := s.describeVPC(vpcID)
+	if err != nil {
+		return err
+	}
+	natGateways, err := s.describeNatGateways(vpcID)
+	if err != nil {
+		return err
+	}
+
+	// The resources of a shared VPC belong to the account owning the VPC.
+	accountID := aws.StringValue(vpc.OwnerId)
+	summary := &infrav1.NetworkSummary{
+		Version:        infrav1.NetworkSummaryVersion,
+		VPC:            s.resource(accountID, "vpc", vpcID),
+		NatGatewaysIPs: s.scope.GetNatGatewaysIPs(),
+	}
+	for _, association := range vpc.CidrBlockAssociationSet {
+		if association.CidrBlockState != nil && aws.StringValue(association.CidrBlockState.State) == ec2.VpcCidrBlockStateCodeAssociated {
+			summary.CidrBlocks = append(summary.CidrBlocks, aws.StringValue(association.CidrBlock))
+		}
+	}
+
+	zones := map[string]*infrav1.NetworkSummaryZone{}
+	zone := func(name string) *infrav1.NetworkSummaryZone {
+		if _, ok := zones[name]; !ok {
+			zones[name] = &infrav1.NetworkSummaryZone{Name: name}
+		}
+		return zones[name]
+	}
+	subnetZones := map[string]string{}
+	for _, subnet := range s.scope.Subnets() {
+		subnetID := subnet.GetResourceID()
+		if subnetID == "" {
+			continue
+		}
+		subnetZones[subnetID] = subnet.AvailabilityZone
+		summarySubnet := infrav1.NetworkSummarySubnet{
+			NetworkSummaryResource: s.resource(accountID, "subnet", subnetID),
+			CidrBlock:              subnet.CidrBlock,
+			IPv6CidrBlock:          subnet.IPv6CidrBlock,
+		}
+		z := zone(subnet.AvailabilityZone)
+		if subnet.IsPublic {
+			z.PublicSubnets = append(z.PublicSubnets, summarySubnet)
+		} else {
+			z.PrivateSubnets = append(z.PrivateSubnets, summarySubnet)
+		}
+	}
+	for _, natGateway := range natGateways {
+		subnetID := aws.StringValue(natGateway.SubnetId)
+		azName, ok := subnetZones[subnetID]
+		if !ok {
+			continue
+		}
+		summaryNatGateway := infrav1.NetworkSummaryNatGateway{
+			NetworkSummaryResource: s.resource(accountID, "natgateway", aws.StringValue(natGateway.NatGatewayId)),
+			SubnetID:               subnetID,
+		}
+		if len(natGateway.NatGatewayAddresses) > 0 {
+			summaryNatGateway.PublicIP = aws.StringValue(natGateway.NatGatewayAddresses[0].PublicIp)
+		}
+		z := zone(azName)
+		z.NatGateways = append(z.NatGateways, summaryNatGateway)
+	}
+	for _, z := range zones {
+		sortZone(z)
+		summary.Zones = append(summary.Zones, *z)
+	}
+	sort.Slice(summary.Zones, func(i, j int) bool { return summary.Zones[i].Name < summary.Zones[j].Name })
+
+	for role, securityGroup := range s.scope.SecurityGroups() {
+		if securityGroup.ID == "" {
+			continue
+		}
+		summary.SecurityGroups = append(summary.SecurityGroups, infrav1.NetworkSummarySecurityGroup{
+			NetworkSummaryResource: s.resource(accountID, "security-group", securityGroup.ID),
+			Role:                   role,
+		})
+	}
+	sort.Slice(summary.SecurityGroups, func(i, j int) bool { return summary.SecurityGroups[i].Role < summary.SecurityGroups[j].Role })
+
+	if previous := awsCluster.Status.NetworkSummary; previous != nil {
+		summary.LastUpdateTime = previous.LastUpdateTime
+		if equality.Semantic.DeepEqual(previous, summary) {
+			return nil
+		}
+	}
+	summary.LastUpdateTime = metav1.NewTime(s.now())
+	awsCluster.Status.NetworkSummary = summary
+	return nil
+}
+
+// describeVPC returns the VPC of the cluster.
+func (s *Service) describeVPC(vpcID string) (*ec2.Vpc, error) {
+	out, err := s.EC2Client.DescribeVpcsWithContext(context.TODO(), &ec2.DescribeVpcsInput{
+		VpcIds: aws.StringSlice([]string{vpcID}),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to describe VPC %q", vpcID)
+	}
+	if len(out.Vpcs) == 0 {
+		return nil, errors.Errorf("VPC %q not found", vpcID)
+	}
+	return out.Vpcs[0], nil
+}
+
+// describeNatGateways returns the pending and available NAT gateways of the VPC of the cluster.
+func (s *Service) describeNatGateways(vpcID string) ([]*ec2.NatGateway, error) {
+	input := &ec2.DescribeNatGatewaysInput{
+		Filter: []*ec2.Filter{
+			filter.EC2.VPC(vpcID),
+			filter.EC2.NATGatewayStates(ec2.NatGatewayStatePending, ec2.NatGatewayStateAvailable),
+		},
+	}
+
+	natGateways := []*ec2.NatGateway{}
+	if err := s.EC2Client.DescribeNatGatewaysPagesWithContext(context.TODO(), input, func(out *ec2.DescribeNatGatewaysOutput, _ bool) bool {
+		natGateways = append(natGateways, out.NatGateways...)
+		return true
+	}); err != nil {
+		return nil, errors.Wrapf(err, "failed to describe NAT gateways of VPC %q", vpcID)
+	}
+	return natGateways, nil
+}
+
+// resource returns the summary of an EC2 resource of the given type.
+func (s *Service) resource(accountID, resourceType, id string) infrav1.NetworkSummaryResource {
+	return infrav1.NetworkSummaryResource{
+		ID: id,
+		ARN: arn.ARN{
+			Partition: s.scope.Partition(),
+			Service:   ec2.EndpointsID,
+			Region:    s.scope.Region(),
+			AccountID: accountID,
+			Resource:  fmt.Sprintf("%s/%s", resourceType, id),
+		}.String(),
+	}
+}
+
+// sortZone sorts the resources of an availability zone by ID, so that the summary is stable.
+func sortZone(zone *infrav1.NetworkSummaryZone) {
+	sort.Slice(zone.PublicSubnets, func(i, j int) bool { return zone.PublicSubnets[i].ID < zone.PublicSubnets[j].ID })
+	sort.Slice(zone.PrivateSubnets, func(i, j int) bool { return zone.PrivateSubnets[i].ID < zone.PrivateSubnets[j].ID })
+	sort.Slice(zone.NatGateways, func(i, j int) bool { return zone.NatGateways[i].ID < zone.NatGateways[j].ID })
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package networksummary
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloudtest"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+)
+
+func TestReconcileNetworkSummary(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+	ec2Mock.EXPECT().DescribeVpcsWithContext(context.TODO(), &ec2.DescribeVpcsInput{VpcIds: aws.StringSlice([]string{"vpc-1"})}).
+		Return(&ec2.DescribeVpcsOutput{Vpcs: []*ec2.Vpc{{
+			VpcId:   aws.String("vpc-1"),
+			OwnerId: aws.String("123456789012"),
+			CidrBlockAssociationSet: []*ec2.VpcCidrBlockAssociation{
+				{CidrBlock: aws.String("10.0.0.0/16"), CidrBlockState: &ec2.VpcCidrBlockState{State: aws.String(ec2.VpcCidrBlockStateCodeAssociated)}},
+				{CidrBlock: aws.String("10.1.0.0/16"), CidrBlockState: &ec2.VpcCidrBlockState{State: aws.String(ec2.VpcCidrBlockStateCodeDisassociated)}},
+			},
+		}}}, nil).Times(2)
+	ec2Mock.EXPECT().DescribeNatGatewaysPagesWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeNatGatewaysInput{}), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *ec2.DescribeNatGatewaysInput, fn func(*ec2.DescribeNatGatewaysOutput, bool) bool, _ ...request.Option) error {
+			fn(&ec2.DescribeNatGatewaysOutput{NatGateways: []*ec2.NatGateway{{
+				NatGatewayId:        aws.String("nat-1"),
+				SubnetId:            aws.String("subnet-public-a"),
+				NatGatewayAddresses: []*ec2.NatGatewayAddress{{PublicIp: aws.String("1.2.3.4")}},
+			}}}, true)
+			return nil
+		}).Times(2)
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clusterScope := cloudtest.NewClusterScope(t)
+	clusterScope.AWSCluster.Spec.NetworkSpec = infrav1.NetworkSpec{
+		VPC: infrav1.VPCSpec{ID: "vpc-1"},
+		Subnets: infrav1.Subnets{
+			{ID: "subnet-private-b", AvailabilityZone: "us-east-1b", CidrBlock: "10.0.2.0/24"},
+			{ID: "subnet-public-a", AvailabilityZone: "us-east-1a", CidrBlock: "10.0.0.0/24", IsPublic: true},
+			{ID: "subnet-private-a", AvailabilityZone: "us-east-1a", CidrBlock: "10.0.1.0/24"},
+		},
+	}
+	clusterScope.AWSCluster.Status.Network = infrav1.NetworkStatus{
+		SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+			infrav1.SecurityGroupNode:         {ID: "sg-node"},
+			infrav1.SecurityGroupControlPlane: {ID: "sg-cp"},
+		},
+		NatGatewaysIPs: []string{"1.2.3.4"},
+	}
+	s := NewService(clusterScope)
+	s.EC2Client = ec2Mock
+	s.now = func() time.Time { return now }
+
+	g.Expect(s.ReconcileNetworkSummary()).To(Succeed())
+	arn := func(resource string) string { return "arn:aws:ec2:us-east-1:123456789012:" + resource }
+	g.Expect(clusterScope.AWSCluster.Status.NetworkSummary).To(Equal(&infrav1.NetworkSummary{
+		Version:        infrav1.NetworkSummaryVersion,
+		LastUpdateTime: metav1.NewTime(now),
+		VPC:            infrav1.NetworkSummaryResource{ID: "vpc-1", ARN: arn("vpc/vpc-1")},
+		CidrBlocks:     []string{"10.0.0.0/16"},
+		Zones: []infrav1.NetworkSummaryZone{
+			{
+				Name: "us-east-1a",
+				PublicSubnets: []infrav1.NetworkSummarySubnet{{
+					NetworkSummaryResource: infrav1.NetworkSummaryResource{ID: "subnet-public-a", ARN: arn("subnet/subnet-public-a")},
+					CidrBlock:              "10.0.0.0/24",
+				}},
+				PrivateSubnets: []infrav1.NetworkSummarySubnet{{
+					NetworkSummaryResource: infrav1.NetworkSummaryResource{ID: "subnet-private-a", ARN: arn("subnet/subnet-private-a")},
+					CidrBlock:              "10.0.1.0/24",
+				}},
+				NatGateways: []infrav1.NetworkSummaryNatGateway{{
+					NetworkSummaryResource: infrav1.NetworkSummaryResource{ID: "nat-1", ARN: arn("natgateway/nat-1")},
+					SubnetID:               "subnet-public-a",
+					PublicIP:               "1.2.3.4",
+				}},
+			},
+			{
+				Name: "us-east-1b",
+				PrivateSubnets: []infrav1.NetworkSummarySubnet{{
+					NetworkSummaryResource: infrav1.NetworkSummaryResource{ID: "subnet-private-b", ARN: arn("subnet/subnet-private-b")},
+					CidrBlock:              "10.0.2.0/24",
+				}},
+			},
+		},
+		SecurityGroups: []infrav1.NetworkSummarySecurityGroup{
+			{NetworkSummaryResource: infrav1.NetworkSummaryResource{ID: "sg-cp", ARN: arn("security-group/sg-cp")}, Role: infrav1.SecurityGroupControlPlane},
+			{NetworkSummaryResource: infrav1.NetworkSummaryResource{ID: "sg-node", ARN: arn("security-group/sg-node")}, Role: infrav1.SecurityGroupNode},
+		},
+		NatGatewaysIPs: []string{"1.2.3.4"},
+	}))
+
+	// The last update time doesn't change while the network resources are unchanged.
+	now = now.Add(time.Hour)
+	g.Expect(s.ReconcileNetworkSummary()).To(Succeed())
+	g.Expect(clusterScope.AWSCluster.Status.NetworkSummary.LastUpdateTime.Time).To(Equal(now.Add(-time.Hour)))
+}
+
+func TestReconcileNetworkSummaryWithoutVPC(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+	clusterScope := cloudtest.NewClusterScope(t)
+	s := NewService(clusterScope)
+	s.EC2Client = ec2Mock
+
+	g.Expect(s.ReconcileNetworkSummary()).To(Succeed())
+	g.Expect(clusterScope.AWSCluster.Status.NetworkSummary).To(BeNil())
+}
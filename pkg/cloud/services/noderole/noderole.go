@@ -0,0 +1,422 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package noderole
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/converters"
+	iamv1 "sigs.k8s.io/cluster-api-provider-aws/v2/iam/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	eksiam "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/eks/iam"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+)
+
+// nodePolicyName is the name of the inline policy of the role granting the permissions required by the nodes.
+const nodePolicyName = "cluster-api-provider-aws-nodes"
+
+// ErrNodeRoleInUse is returned when the instance profile of the nodes can't be deleted because instances still use it.
+var ErrNodeRoleInUse = errors.New("the node instance profile is still used by instances")
+
+// ReconcileNodeRole creates the role and the instance profile of the nodes, and attaches the policies selected in
+// the cluster spec to the role, detaching the ones that are no longer selected. When the node role isn't created
+// anymore, the resources created previously are removed.
+func (s *Service) ReconcileNodeRole() error {
+	management := s.scope.NodeRoleManagement()
+	if management == nil || !management.Create {
+		if s.scope.NodeRoleStatus() == nil {
+			return nil
+		}
+		return s.DeleteNodeRole()
+	}
+
+	name := s.scope.NodeRoleName()
+	s.scope.Debug("Reconciling node role and instance profile", "name", name)
+
+	role, err := s.reconcileRole(name, management)
+	if err != nil {
+		return err
+	}
+
+	profile, err := s.reconcileInstanceProfile(name)
+	if err != nil {
+		return err
+	}
+
+	s.scope.SetNodeRoleStatus(&infrav1.NodeRoleStatus{
+		RoleName:            name,
+		RoleARN:             aws.StringValue(role.Arn),
+		InstanceProfileName: name,
+		InstanceProfileARN:  aws.StringValue(profile.Arn),
+	})
+	return nil
+}
+
+// DeleteNodeRole removes the instance profile and the role of the nodes, unless the instance profile is still used
+// by instances. The role and the instance profile which weren't created for the cluster are left untouched.
+func (s *Service) DeleteNodeRole() error {
+	roleName, profileName := s.scope.NodeRoleName(), s.scope.NodeRoleName()
+	if status := s.scope.NodeRoleStatus(); status != nil {
+		roleName, profileName = status.RoleName, status.InstanceProfileName
+	}
+	s.scope.Debug("Deleting node role and instance profile", "role", roleName, "instance-profile", profileName)
+
+	if err := s.deleteInstanceProfile(profileName); err != nil {
+		return err
+	}
+
+	role, err := s.GetIAMRole(roleName)
+	switch {
+	case isNotFound(err):
+	case err != nil:
+		return errors.Wrapf(err, "failed to get node role %q", roleName)
+	case s.IsUnmanaged(role, s.scope.Name()):
+		s.scope.Debug("Skipping node role deletion as the role is unmanaged", "role", roleName)
+	default:
+		if _, err := s.IAMClient.DeleteRolePolicy(&iam.DeleteRolePolicyInput{
+			RoleName:   aws.String(roleName),
+			PolicyName: aws.String(nodePolicyName),
+		}); err != nil && !isNotFound(err) {
+			return errors.Wrapf(err, "failed to delete the policy of node role %q", roleName)
+		}
+		if err := s.DeleteRole(roleName); err != nil {
+			record.Warnf(s.scope.InfraCluster(), "FailedDeleteNodeRole", "Failed to delete node role %q: %v", roleName, err)
+			return err
+		}
+		record.Eventf(s.scope.InfraCluster(), "SuccessfulDeleteNodeRole", "Deleted node role %q", roleName)
+	}
+
+	s.scope.SetNodeRoleStatus(nil)
+	return nil
+}
+
+func (s *Service) reconcileRole(name string, management *infrav1.NodeRoleManagement) (*iam.Role, error) {
+	trustRelationship := eksiam.NodegroupTrustRelationship()
+
+	role, err := s.GetIAMRole(name)
+	switch {
+	case isNotFound(err):
+		role, err = s.CreateRole(name, s.scope.Name(), trustRelationship, s.scope.AdditionalTags(), &infrav1.IAMRoleManagement{
+			PermissionsBoundary: management.PermissionsBoundary,
+		})
+		if err != nil {
+			record.Warnf(s.scope.InfraCluster(), "FailedCreateNodeRole", "Failed to create node role %q: %v", name, err)
+			return nil, err
+		}
+		record.Eventf(s.scope.InfraCluster(), "SuccessfulCreateNodeRole", "Created node role %q", name)
+	case err != nil:
+		return nil, errors.Wrapf(err, "failed to get node role %q", name)
+	case s.IsUnmanaged(role, s.scope.Name()):
+		return nil, errors.Errorf("IAM role %q already exists and wasn't created for the cluster", name)
+	}
+
+	if _, err := s.EnsureTagsAndPolicy(role, s.scope.Name(), trustRelationship, s.scope.AdditionalTags()); err != nil {
+		return nil, errors.Wrapf(err, "failed to ensure the tags and the trust policy of node role %q", name)
+	}
+	if _, err := s.EnsurePermissionsBoundary(role, management.PermissionsBoundary); err != nil {
+		return nil, err
+	}
+	if err := s.ensureNodePolicy(name); err != nil {
+		return nil, err
+	}
+	if _, err := s.EnsurePoliciesAttached(role, aws.StringSlice(s.managedPolicies(management))); err != nil {
+		return nil, errors.Wrapf(err, "failed to ensure the policies of node role %q", name)
+	}
+
+	return role, nil
+}
+
+// managedPolicies returns the ARNs of the policies attached to the role: the AWS managed policies selected in the
+// cluster spec followed by the additional policies.
+func (s *Service) managedPolicies(management *infrav1.NodeRoleManagement) []string {
+	policies := []string{}
+	if management.AttachCNIPolicy {
+		policies = append(policies, s.awsManagedPolicyARN("AmazonEKS_CNI_Policy"))
+	}
+	if management.AttachECRReadOnlyPolicy {
+		policies = append(policies, s.awsManagedPolicyARN("AmazonEC2ContainerRegistryReadOnly"))
+	}
+	if management.AttachSSMPolicy {
+		policies = append(policies, s.awsManagedPolicyARN("AmazonSSMManagedInstanceCore"))
+	}
+	for _, policy := range management.AdditionalPolicies {
+		if !contains(policies, policy) {
+			policies = append(policies, policy)
+		}
+	}
+	return policies
+}
+
+func (s *Service) awsManagedPolicyARN(name string) string {
+	return "arn:" + s.scope.Partition() + ":iam::aws:policy/" + name
+}
+
+// ensureNodePolicy sets the inline policy of the role granting the permissions required by the nodes, unless it
+// is already set.
+func (s *Service) ensureNodePolicy(roleName string) error {
+	policyJSON, err := converters.IAMPolicyDocumentToJSON(nodePolicy())
+	if err != nil {
+		return errors.Wrap(err, "failed to convert the node policy to json")
+	}
+
+	out, err := s.IAMClient.GetRolePolicy(&iam.GetRolePolicyInput{
+		RoleName:   aws.String(roleName),
+		PolicyName: aws.String(nodePolicyName),
+	})
+	switch {
+	case isNotFound(err):
+	case err != nil:
+		return errors.Wrapf(err, "failed to get the policy of node role %q", roleName)
+	default:
+		equal, err := policyDocumentsEqual(aws.StringValue(out.PolicyDocument), policyJSON)
+		if err != nil {
+			return err
+		}
+		if equal {
+			return nil
+		}
+	}
+
+	if _, err := s.IAMClient.PutRolePolicy(&iam.PutRolePolicyInput{
+		RoleName:       aws.String(roleName),
+		PolicyName:     aws.String(nodePolicyName),
+		PolicyDocument: aws.String(policyJSON),
+	}); err != nil {
+		return errors.Wrapf(err, "failed to put the policy of node role %q", roleName)
+	}
+	return nil
+}
+
+// policyDocumentsEqual compares the URL encoded policy document returned by IAM to a policy document.
+func policyDocumentsEqual(current, desired string) (bool, error) {
+	current, err := url.PathUnescape(current)
+	if err != nil {
+		return false, errors.Wrap(err, "couldn't decode policy document")
+	}
+
+	var currentDocument, desiredDocument iamv1.PolicyDocument
+	if err := json.Unmarshal([]byte(current), &currentDocument); err != nil {
+		return false, errors.Wrap(err, "couldn't unmarshal policy document")
+	}
+	if err := json.Unmarshal([]byte(desired), &desiredDocument); err != nil {
+		return false, errors.Wrap(err, "couldn't unmarshal policy document")
+	}
+	return cmp.Equal(currentDocument, desiredDocument), nil
+}
+
+// nodePolicy returns the permissions required by the nodes: the ones of the AWS cloud provider, and access to the
+// bootstrap data stored in AWS Secrets Manager or AWS Systems Manager Parameter Store.
+func nodePolicy() iamv1.PolicyDocument {
+	return iamv1.PolicyDocument{
+		Version: iamv1.CurrentVersion,
+		Statement: []iamv1.StatementEntry{
+			{
+				Effect:   iamv1.EffectAllow,
+				Resource: iamv1.Resources{iamv1.Any},
+				Action: iamv1.Actions{
+					"ec2:AssignIpv6Addresses",
+					"ec2:DescribeInstances",
+					"ec2:DescribeRegions",
+					"ec2:CreateTags",
+					"ec2:DescribeTags",
+					"ec2:DescribeNetworkInterfaces",
+					"ec2:DescribeInstanceTypes",
+				},
+			},
+			{
+				Effect:   iamv1.EffectAllow,
+				Resource: iamv1.Resources{"arn:*:secretsmanager:*:*:secret:aws.cluster.x-k8s.io/*"},
+				Action: iamv1.Actions{
+					"secretsmanager:DeleteSecret",
+					"secretsmanager:GetSecretValue",
+				},
+			},
+			{
+				Effect:   iamv1.EffectAllow,
+				Resource: iamv1.Resources{"arn:*:ssm:*:*:parameter/cluster.x-k8s.io/*"},
+				Action: iamv1.Actions{
+					"ssm:DeleteParameter",
+					"ssm:GetParameter",
+				},
+			},
+		},
+	}
+}
+
+func (s *Service) reconcileInstanceProfile(name string) (*iam.InstanceProfile, error) {
+	profile, err := s.getInstanceProfile(name)
+	switch {
+	case isNotFound(err):
+		out, err := s.IAMClient.CreateInstanceProfile(&iam.CreateInstanceProfileInput{
+			InstanceProfileName: aws.String(name),
+			Tags:                eksiam.RoleTags(s.scope.Name(), s.scope.AdditionalTags()),
+		})
+		if err != nil {
+			record.Warnf(s.scope.InfraCluster(), "FailedCreateInstanceProfile", "Failed to create node instance profile %q: %v", name, err)
+			return nil, errors.Wrapf(err, "failed to create node instance profile %q", name)
+		}
+		record.Eventf(s.scope.InfraCluster(), "SuccessfulCreateInstanceProfile", "Created node instance profile %q", name)
+		profile = out.InstanceProfile
+	case err != nil:
+		return nil, errors.Wrapf(err, "failed to get node instance profile %q", name)
+	case !s.isManaged(profile.Tags):
+		return nil, errors.Errorf("instance profile %q already exists and wasn't created for the cluster", name)
+	}
+
+	// An instance profile holds a single role.
+	hasRole := false
+	for _, role := range profile.Roles {
+		if aws.StringValue(role.RoleName) == name {
+			hasRole = true
+			continue
+		}
+		if _, err := s.IAMClient.RemoveRoleFromInstanceProfile(&iam.RemoveRoleFromInstanceProfileInput{
+			InstanceProfileName: aws.String(name),
+			RoleName:            role.RoleName,
+		}); err != nil {
+			return nil, errors.Wrapf(err, "failed to remove role %q from node instance profile %q", aws.StringValue(role.RoleName), name)
+		}
+	}
+	if !hasRole {
+		if _, err := s.IAMClient.AddRoleToInstanceProfile(&iam.AddRoleToInstanceProfileInput{
+			InstanceProfileName: aws.String(name),
+			RoleName:            aws.String(name),
+		}); err != nil {
+			return nil, errors.Wrapf(err, "failed to add role %q to node instance profile %q", name, name)
+		}
+	}
+
+	return profile, nil
+}
+
+func (s *Service) deleteInstanceProfile(name string) error {
+	profile, err := s.getInstanceProfile(name)
+	switch {
+	case isNotFound(err):
+		return nil
+	case err != nil:
+		return errors.Wrapf(err, "failed to get node instance profile %q", name)
+	case !s.isManaged(profile.Tags):
+		s.scope.Debug("Skipping node instance profile deletion as the instance profile is unmanaged", "instance-profile", name)
+		return nil
+	}
+
+	instanceIDs, err := s.instancesUsingProfile(aws.StringValue(profile.Arn))
+	if err != nil {
+		return err
+	}
+	if len(instanceIDs) > 0 {
+		record.Warnf(s.scope.InfraCluster(), "NodeRoleInUse", "Node instance profile %q is still used by instances %v", name, instanceIDs)
+		return errors.Wrapf(ErrNodeRoleInUse, "instance profile %q is used by instances %v", name, instanceIDs)
+	}
+
+	for _, role := range profile.Roles {
+		if _, err := s.IAMClient.RemoveRoleFromInstanceProfile(&iam.RemoveRoleFromInstanceProfileInput{
+			InstanceProfileName: aws.String(name),
+			RoleName:            role.RoleName,
+		}); err != nil && !isNotFound(err) {
+			return errors.Wrapf(err, "failed to remove role %q from node instance profile %q", aws.StringValue(role.RoleName), name)
+		}
+	}
+	if _, err := s.IAMClient.DeleteInstanceProfile(&iam.DeleteInstanceProfileInput{
+		InstanceProfileName: aws.String(name),
+	}); err != nil && !isNotFound(err) {
+		record.Warnf(s.scope.InfraCluster(), "FailedDeleteInstanceProfile", "Failed to delete node instance profile %q: %v", name, err)
+		return errors.Wrapf(err, "failed to delete node instance profile %q", name)
+	}
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulDeleteInstanceProfile", "Deleted node instance profile %q", name)
+	return nil
+}
+
+// instancesUsingProfile returns the IDs of the instances which aren't terminated and use the given instance profile.
+func (s *Service) instancesUsingProfile(profileARN string) ([]string, error) {
+	input := &ec2.DescribeInstancesInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("iam-instance-profile.arn"),
+				Values: aws.StringSlice([]string{profileARN}),
+			},
+			{
+				Name: aws.String("instance-state-name"),
+				Values: aws.StringSlice([]string{
+					ec2.InstanceStateNamePending,
+					ec2.InstanceStateNameRunning,
+					ec2.InstanceStateNameShuttingDown,
+					ec2.InstanceStateNameStopping,
+					ec2.InstanceStateNameStopped,
+				}),
+			},
+		},
+	}
+
+	instanceIDs := []string{}
+	if err := s.EC2Client.DescribeInstancesPagesWithContext(context.TODO(), input, func(out *ec2.DescribeInstancesOutput, _ bool) bool {
+		for _, reservation := range out.Reservations {
+			for _, instance := range reservation.Instances {
+				instanceIDs = append(instanceIDs, aws.StringValue(instance.InstanceId))
+			}
+		}
+		return true
+	}); err != nil {
+		return nil, errors.Wrapf(err, "failed to describe the instances using instance profile %q", profileARN)
+	}
+	return instanceIDs, nil
+}
+
+func (s *Service) getInstanceProfile(name string) (*iam.InstanceProfile, error) {
+	out, err := s.IAMClient.GetInstanceProfile(&iam.GetInstanceProfileInput{
+		InstanceProfileName: aws.String(name),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out.InstanceProfile, nil
+}
+
+// isManaged returns true if the tags mark the resource as created for the cluster.
+func (s *Service) isManaged(tags []*iam.Tag) bool {
+	key := infrav1.ClusterAWSCloudProviderTagKey(s.scope.Name())
+	for _, tag := range tags {
+		if aws.StringValue(tag.Key) == key && aws.StringValue(tag.Value) == string(infrav1.ResourceLifecycleOwned) {
+			return true
+		}
+	}
+	return false
+}
+
+func isNotFound(err error) bool {
+	code, ok := awserrors.Code(err)
+	return ok && code == iam.ErrCodeNoSuchEntityException
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,234 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package noderole
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/converters"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	eksiam "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/eks/iam"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/iamauth/mock_iamauth"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+const (
+	roleName   = "default-test-cluster-nodes"
+	roleARN    = "arn:aws:iam::123456789012:role/default-test-cluster-nodes"
+	profileARN = "arn:aws:iam::123456789012:instance-profile/default-test-cluster-nodes"
+	ssmPolicy  = "arn:aws:iam::aws:policy/AmazonSSMManagedInstanceCore"
+	cwPolicy   = "arn:aws:iam::aws:policy/CloudWatchAgentServerPolicy"
+)
+
+func TestReconcileNodeRole(t *testing.T) {
+	trustPolicy, err := converters.IAMPolicyDocumentToJSON(*eksiam.NodegroupTrustRelationship())
+	if err != nil {
+		t.Fatal(err)
+	}
+	nodePolicyJSON, err := converters.IAMPolicyDocumentToJSON(nodePolicy())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ownedTags := []*iam.Tag{{Key: aws.String(infrav1.ClusterAWSCloudProviderTagKey("test-cluster")), Value: aws.String(string(infrav1.ResourceLifecycleOwned))}}
+	managedRole := &iam.Role{RoleName: aws.String(roleName), Arn: aws.String(roleARN), AssumeRolePolicyDocument: aws.String(trustPolicy), Tags: ownedTags}
+	managedProfile := &iam.InstanceProfile{
+		InstanceProfileName: aws.String(roleName),
+		Arn:                 aws.String(profileARN),
+		Roles:               []*iam.Role{{RoleName: aws.String(roleName)}},
+		Tags:                ownedTags,
+	}
+	notFound := awserr.New(iam.ErrCodeNoSuchEntityException, "", nil)
+	status := &infrav1.NodeRoleStatus{RoleName: roleName, RoleARN: roleARN, InstanceProfileName: roleName, InstanceProfileARN: profileARN}
+	describeInstances := func(m *mocks.MockEC2APIMockRecorder, instanceIDs ...string) {
+		m.DescribeInstancesPagesWithContext(context.TODO(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, input *ec2.DescribeInstancesInput, fn func(*ec2.DescribeInstancesOutput, bool) bool, _ ...request.Option) error {
+				if aws.StringValue(input.Filters[0].Values[0]) != profileARN {
+					t.Errorf("unexpected instance profile filter %v", input.Filters[0])
+				}
+				instances := []*ec2.Instance{}
+				for _, id := range instanceIDs {
+					instances = append(instances, &ec2.Instance{InstanceId: aws.String(id)})
+				}
+				fn(&ec2.DescribeInstancesOutput{Reservations: []*ec2.Reservation{{Instances: instances}}}, true)
+				return nil
+			})
+	}
+
+	testCases := []struct {
+		name           string
+		spec           *infrav1.NodeRoleManagement
+		status         *infrav1.NodeRoleStatus
+		iamExpect      func(m *mock_iamauth.MockIAMAPIMockRecorder)
+		ec2Expect      func(m *mocks.MockEC2APIMockRecorder)
+		expectedStatus *infrav1.NodeRoleStatus
+		expectErr      bool
+	}{
+		{
+			name: "does nothing when not configured",
+		},
+		{
+			name: "creates the role and the instance profile",
+			spec: &infrav1.NodeRoleManagement{Create: true, AttachSSMPolicy: true},
+			iamExpect: func(m *mock_iamauth.MockIAMAPIMockRecorder) {
+				m.GetRole(&iam.GetRoleInput{RoleName: aws.String(roleName)}).Return(nil, notFound)
+				m.CreateRole(&iam.CreateRoleInput{
+					RoleName:                 aws.String(roleName),
+					AssumeRolePolicyDocument: aws.String(trustPolicy),
+					Tags:                     ownedTags,
+				}).Return(&iam.CreateRoleOutput{Role: managedRole}, nil)
+				m.GetRolePolicy(&iam.GetRolePolicyInput{RoleName: aws.String(roleName), PolicyName: aws.String(nodePolicyName)}).Return(nil, notFound)
+				m.PutRolePolicy(&iam.PutRolePolicyInput{
+					RoleName:       aws.String(roleName),
+					PolicyName:     aws.String(nodePolicyName),
+					PolicyDocument: aws.String(nodePolicyJSON),
+				}).Return(&iam.PutRolePolicyOutput{}, nil)
+				m.ListAttachedRolePolicies(&iam.ListAttachedRolePoliciesInput{RoleName: aws.String(roleName)}).Return(&iam.ListAttachedRolePoliciesOutput{}, nil)
+				m.GetPolicy(&iam.GetPolicyInput{PolicyArn: aws.String(ssmPolicy)}).Return(&iam.GetPolicyOutput{}, nil)
+				m.AttachRolePolicy(&iam.AttachRolePolicyInput{RoleName: aws.String(roleName), PolicyArn: aws.String(ssmPolicy)}).Return(&iam.AttachRolePolicyOutput{}, nil)
+				m.GetInstanceProfile(&iam.GetInstanceProfileInput{InstanceProfileName: aws.String(roleName)}).Return(nil, notFound)
+				m.CreateInstanceProfile(&iam.CreateInstanceProfileInput{InstanceProfileName: aws.String(roleName), Tags: ownedTags}).
+					Return(&iam.CreateInstanceProfileOutput{InstanceProfile: &iam.InstanceProfile{InstanceProfileName: aws.String(roleName), Arn: aws.String(profileARN)}}, nil)
+				m.AddRoleToInstanceProfile(&iam.AddRoleToInstanceProfileInput{InstanceProfileName: aws.String(roleName), RoleName: aws.String(roleName)}).
+					Return(&iam.AddRoleToInstanceProfileOutput{}, nil)
+			},
+			expectedStatus: status,
+		},
+		{
+			name: "attaches and detaches the additional policies of an existing role",
+			spec: &infrav1.NodeRoleManagement{Create: true, AdditionalPolicies: []string{cwPolicy}},
+			iamExpect: func(m *mock_iamauth.MockIAMAPIMockRecorder) {
+				m.GetRole(&iam.GetRoleInput{RoleName: aws.String(roleName)}).Return(&iam.GetRoleOutput{Role: managedRole}, nil)
+				m.GetRolePolicy(gomock.Any()).Return(&iam.GetRolePolicyOutput{PolicyDocument: aws.String(nodePolicyJSON)}, nil)
+				m.ListAttachedRolePolicies(&iam.ListAttachedRolePoliciesInput{RoleName: aws.String(roleName)}).
+					Return(&iam.ListAttachedRolePoliciesOutput{AttachedPolicies: []*iam.AttachedPolicy{{PolicyArn: aws.String(ssmPolicy)}}}, nil)
+				m.DetachRolePolicy(&iam.DetachRolePolicyInput{RoleName: aws.String(roleName), PolicyArn: aws.String(ssmPolicy)}).Return(&iam.DetachRolePolicyOutput{}, nil)
+				m.GetPolicy(&iam.GetPolicyInput{PolicyArn: aws.String(cwPolicy)}).Return(&iam.GetPolicyOutput{}, nil)
+				m.AttachRolePolicy(&iam.AttachRolePolicyInput{RoleName: aws.String(roleName), PolicyArn: aws.String(cwPolicy)}).Return(&iam.AttachRolePolicyOutput{}, nil)
+				m.GetInstanceProfile(&iam.GetInstanceProfileInput{InstanceProfileName: aws.String(roleName)}).
+					Return(&iam.GetInstanceProfileOutput{InstanceProfile: managedProfile}, nil)
+			},
+			expectedStatus: status,
+		},
+		{
+			name: "fails when the role wasn't created for the cluster",
+			spec: &infrav1.NodeRoleManagement{Create: true},
+			iamExpect: func(m *mock_iamauth.MockIAMAPIMockRecorder) {
+				m.GetRole(&iam.GetRoleInput{RoleName: aws.String(roleName)}).
+					Return(&iam.GetRoleOutput{Role: &iam.Role{RoleName: aws.String(roleName), Arn: aws.String(roleARN)}}, nil)
+			},
+			expectErr: true,
+		},
+		{
+			name:   "keeps the role while instances use the instance profile",
+			spec:   &infrav1.NodeRoleManagement{Create: false},
+			status: status,
+			iamExpect: func(m *mock_iamauth.MockIAMAPIMockRecorder) {
+				m.GetInstanceProfile(&iam.GetInstanceProfileInput{InstanceProfileName: aws.String(roleName)}).
+					Return(&iam.GetInstanceProfileOutput{InstanceProfile: managedProfile}, nil)
+			},
+			ec2Expect: func(m *mocks.MockEC2APIMockRecorder) {
+				describeInstances(m, "i-1")
+			},
+			expectErr: true,
+		},
+		{
+			name:   "deletes the role and the instance profile when no longer created",
+			spec:   &infrav1.NodeRoleManagement{Create: false},
+			status: status,
+			iamExpect: func(m *mock_iamauth.MockIAMAPIMockRecorder) {
+				m.GetInstanceProfile(&iam.GetInstanceProfileInput{InstanceProfileName: aws.String(roleName)}).
+					Return(&iam.GetInstanceProfileOutput{InstanceProfile: managedProfile}, nil)
+				m.RemoveRoleFromInstanceProfile(&iam.RemoveRoleFromInstanceProfileInput{InstanceProfileName: aws.String(roleName), RoleName: aws.String(roleName)}).
+					Return(&iam.RemoveRoleFromInstanceProfileOutput{}, nil)
+				m.DeleteInstanceProfile(&iam.DeleteInstanceProfileInput{InstanceProfileName: aws.String(roleName)}).Return(&iam.DeleteInstanceProfileOutput{}, nil)
+				m.GetRole(&iam.GetRoleInput{RoleName: aws.String(roleName)}).Return(&iam.GetRoleOutput{Role: managedRole}, nil)
+				m.DeleteRolePolicy(&iam.DeleteRolePolicyInput{RoleName: aws.String(roleName), PolicyName: aws.String(nodePolicyName)}).Return(&iam.DeleteRolePolicyOutput{}, nil)
+				m.ListAttachedRolePolicies(&iam.ListAttachedRolePoliciesInput{RoleName: aws.String(roleName)}).
+					Return(&iam.ListAttachedRolePoliciesOutput{AttachedPolicies: []*iam.AttachedPolicy{{PolicyArn: aws.String(ssmPolicy)}}}, nil)
+				m.DetachRolePolicy(&iam.DetachRolePolicyInput{RoleName: aws.String(roleName), PolicyArn: aws.String(ssmPolicy)}).Return(&iam.DetachRolePolicyOutput{}, nil)
+				m.DeleteRole(&iam.DeleteRoleInput{RoleName: aws.String(roleName)}).Return(&iam.DeleteRoleOutput{}, nil)
+			},
+			ec2Expect: func(m *mocks.MockEC2APIMockRecorder) {
+				describeInstances(m)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			iamMock := mock_iamauth.NewMockIAMAPI(mockCtrl)
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+			if tc.iamExpect != nil {
+				tc.iamExpect(iamMock.EXPECT())
+			}
+			if tc.ec2Expect != nil {
+				tc.ec2Expect(ec2Mock.EXPECT())
+			}
+
+			clusterScope, err := setupCluster(tc.spec, tc.status)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			s := &Service{
+				scope:      clusterScope,
+				EC2Client:  ec2Mock,
+				IAMService: eksiam.IAMService{Wrapper: clusterScope, IAMClient: iamMock},
+			}
+			err = s.ReconcileNodeRole()
+			if tc.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(clusterScope.NodeRoleStatus()).To(Equal(tc.status))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(clusterScope.NodeRoleStatus()).To(Equal(tc.expectedStatus))
+		})
+	}
+}
+
+func setupCluster(spec *infrav1.NodeRoleManagement, status *infrav1.NodeRoleStatus) (*scope.ClusterScope, error) {
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+	awsCluster := &infrav1.AWSCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec:       infrav1.AWSClusterSpec{Region: "us-east-1", NodeRoleManagement: spec},
+		Status:     infrav1.AWSClusterStatus{NodeRole: status},
+	}
+	client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(awsCluster).Build()
+	return scope.NewClusterScope(scope.ClusterScopeParams{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: "default"},
+		},
+		AWSCluster: awsCluster,
+		Client:     client,
+	})
+}
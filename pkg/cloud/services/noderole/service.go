@@ -0,0 +1,44 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package noderole provides a way to manage the IAM role and instance profile created for the nodes of a cluster.
+package noderole
+
+import (
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/eks/iam"
+)
+
+// Service manages the role and the instance profile of the nodes.
+type Service struct {
+	scope     scope.NodeRoleScope
+	EC2Client ec2iface.EC2API
+	iam.IAMService
+}
+
+// NewService returns a new service given the cluster scope.
+func NewService(clusterScope scope.NodeRoleScope) *Service {
+	return &Service{
+		scope:     clusterScope,
+		EC2Client: scope.NewEC2Client(clusterScope, clusterScope, clusterScope, clusterScope.InfraCluster()),
+		IAMService: iam.IAMService{
+			Wrapper:   clusterScope,
+			IAMClient: scope.NewIAMClient(clusterScope, clusterScope, clusterScope, clusterScope.InfraCluster()),
+		},
+	}
+}
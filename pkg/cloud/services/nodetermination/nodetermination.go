@@ -0,0 +1,358 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodetermination
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/eventbridge"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	iamv1 "sigs.k8s.io/cluster-api-provider-aws/v2/iam/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+)
+
+const (
+	// queueTargetID is the ID of the queue in the targets of the rules.
+	queueTargetID = "node-termination-handler-queue"
+
+	// maxRuleNameLength is the maximum length of the name of an EventBridge rule.
+	maxRuleNameLength = 64
+
+	// messageRetentionPeriod is how long, in seconds, events are kept in the queue. Older events
+	// aren't actionable anymore.
+	messageRetentionPeriod = "300"
+)
+
+// rules lists the managed rules in the order they're reconciled.
+var rules = []infrav1.NodeTerminationHandlingRule{
+	infrav1.NodeTerminationHandlingRuleSpotInterruption,
+	infrav1.NodeTerminationHandlingRuleRebalance,
+	infrav1.NodeTerminationHandlingRuleScheduledChange,
+	infrav1.NodeTerminationHandlingRuleASGLifecycle,
+}
+
+// ruleNameSuffixes are appended to the cluster name to form the names of the rules.
+var ruleNameSuffixes = map[infrav1.NodeTerminationHandlingRule]string{
+	infrav1.NodeTerminationHandlingRuleSpotInterruption: "spot-interruption",
+	infrav1.NodeTerminationHandlingRuleRebalance:        "rebalance",
+	infrav1.NodeTerminationHandlingRuleScheduledChange:  "scheduled-change",
+	infrav1.NodeTerminationHandlingRuleASGLifecycle:     "asg-lifecycle",
+}
+
+// eventPatterns are the event patterns of the rules, as documented by aws-node-termination-handler.
+var eventPatterns = map[infrav1.NodeTerminationHandlingRule]eventPattern{
+	infrav1.NodeTerminationHandlingRuleSpotInterruption: {
+		Source:     []string{"aws.ec2"},
+		DetailType: []string{"EC2 Spot Instance Interruption Warning"},
+	},
+	infrav1.NodeTerminationHandlingRuleRebalance: {
+		Source:     []string{"aws.ec2"},
+		DetailType: []string{"EC2 Instance Rebalance Recommendation"},
+	},
+	infrav1.NodeTerminationHandlingRuleScheduledChange: {
+		Source:     []string{"aws.health"},
+		DetailType: []string{"AWS Health Event"},
+		Detail: map[string][]string{
+			"service":           {"EC2"},
+			"eventTypeCategory": {"scheduledChange"},
+		},
+	},
+	infrav1.NodeTerminationHandlingRuleASGLifecycle: {
+		Source:     []string{"aws.autoscaling"},
+		DetailType: []string{"EC2 Instance-terminate Lifecycle Action"},
+	},
+}
+
+type eventPattern struct {
+	Source     []string            `json:"source"`
+	DetailType []string            `json:"detail-type"`
+	Detail     map[string][]string `json:"detail,omitempty"`
+}
+
+// ReconcileNodeTerminationHandling creates the queue and the rules selected in the cluster spec, and removes
+// the rules that are no longer selected. When node termination handling is disabled, the resources created
+// previously are removed.
+func (s *Service) ReconcileNodeTerminationHandling() error {
+	nth := s.scope.NodeTerminationHandling()
+	if nth == nil || !nth.Enabled {
+		if s.scope.NodeTerminationHandlingStatus() == nil {
+			return nil
+		}
+		return s.DeleteNodeTerminationHandling()
+	}
+
+	s.scope.Debug("Reconciling node termination handler queue and rules")
+
+	queueURL, queueARN, err := s.reconcileQueue()
+	if err != nil {
+		return err
+	}
+
+	for _, rule := range rules {
+		if !nth.HasRule(rule) {
+			if err := s.deleteRule(rule); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := s.reconcileRule(rule, queueARN); err != nil {
+			return err
+		}
+	}
+
+	s.scope.SetNodeTerminationHandlingStatus(&infrav1.NodeTerminationHandlingStatus{
+		QueueURL: queueURL,
+		QueueARN: queueARN,
+	})
+	return nil
+}
+
+// DeleteNodeTerminationHandling removes the rules and the queue of the node termination handler.
+func (s *Service) DeleteNodeTerminationHandling() error {
+	s.scope.Debug("Deleting node termination handler queue and rules")
+
+	for _, rule := range rules {
+		if err := s.deleteRule(rule); err != nil {
+			return err
+		}
+	}
+
+	// The queue name may have been changed while node termination handling was disabled, so prefer
+	// the URL of the queue that was actually created.
+	var queueURL *string
+	if status := s.scope.NodeTerminationHandlingStatus(); status != nil && status.QueueURL != "" {
+		queueURL = aws.String(status.QueueURL)
+	} else {
+		out, err := s.SQSClient.GetQueueUrl(&sqs.GetQueueUrlInput{QueueName: aws.String(s.scope.NodeTerminationHandlingQueueName())})
+		if err != nil && !queueNotFoundError(err) {
+			return errors.Wrap(err, "unable to get node termination handler queue URL")
+		}
+		if out != nil {
+			queueURL = out.QueueUrl
+		}
+	}
+
+	if queueURL != nil {
+		if _, err := s.SQSClient.DeleteQueue(&sqs.DeleteQueueInput{QueueUrl: queueURL}); err != nil && !queueNotFoundError(err) {
+			return errors.Wrap(err, "unable to delete node termination handler queue")
+		}
+	}
+
+	s.scope.SetNodeTerminationHandlingStatus(nil)
+	return nil
+}
+
+// reconcileQueue creates the queue if it doesn't exist and makes sure EventBridge is allowed to send
+// the events of the rules to it. It returns the URL and the ARN of the queue.
+func (s *Service) reconcileQueue() (string, string, error) {
+	queueName := s.scope.NodeTerminationHandlingQueueName()
+
+	var queueURL *string
+	urlOut, err := s.SQSClient.GetQueueUrl(&sqs.GetQueueUrlInput{QueueName: aws.String(queueName)})
+	switch {
+	case err == nil:
+		queueURL = urlOut.QueueUrl
+	case queueNotFoundError(err):
+		createOut, err := s.SQSClient.CreateQueue(&sqs.CreateQueueInput{
+			QueueName: aws.String(queueName),
+			Attributes: aws.StringMap(map[string]string{
+				sqs.QueueAttributeNameMessageRetentionPeriod: messageRetentionPeriod,
+				sqs.QueueAttributeNameSqsManagedSseEnabled:   "true",
+			}),
+		})
+		if err != nil {
+			return "", "", errors.Wrapf(err, "unable to create node termination handler queue %s", queueName)
+		}
+		s.scope.Info("Created node termination handler queue", "queue", queueName)
+		queueURL = createOut.QueueUrl
+	default:
+		return "", "", errors.Wrapf(err, "unable to get URL of node termination handler queue %s", queueName)
+	}
+
+	attrsOut, err := s.SQSClient.GetQueueAttributes(&sqs.GetQueueAttributesInput{
+		AttributeNames: aws.StringSlice([]string{sqs.QueueAttributeNameQueueArn, sqs.QueueAttributeNamePolicy}),
+		QueueUrl:       queueURL,
+	})
+	if err != nil {
+		return "", "", errors.Wrapf(err, "unable to get attributes of node termination handler queue %s", queueName)
+	}
+	queueARN := aws.StringValue(attrsOut.Attributes[sqs.QueueAttributeNameQueueArn])
+
+	policy, err := s.queuePolicy(queueARN)
+	if err != nil {
+		return "", "", err
+	}
+	if aws.StringValue(attrsOut.Attributes[sqs.QueueAttributeNamePolicy]) != policy {
+		_, err := s.SQSClient.SetQueueAttributes(&sqs.SetQueueAttributesInput{
+			QueueUrl:   queueURL,
+			Attributes: aws.StringMap(map[string]string{sqs.QueueAttributeNamePolicy: policy}),
+		})
+		if err != nil {
+			return "", "", errors.Wrapf(err, "unable to set policy of node termination handler queue %s", queueName)
+		}
+	}
+
+	return aws.StringValue(queueURL), queueARN, nil
+}
+
+// queuePolicy returns the policy allowing the rules of the cluster to send messages to the queue.
+func (s *Service) queuePolicy(queueARN string) (string, error) {
+	parsed, err := arn.Parse(queueARN)
+	if err != nil {
+		return "", errors.Wrapf(err, "unable to parse node termination handler queue ARN %q", queueARN)
+	}
+	rulesARN := arn.ARN{
+		Partition: parsed.Partition,
+		Service:   eventbridge.EndpointsID,
+		Region:    parsed.Region,
+		AccountID: parsed.AccountID,
+		Resource:  fmt.Sprintf("rule/%s*", s.ruleNamePrefix()),
+	}
+
+	policy := iamv1.PolicyDocument{
+		Version: iamv1.CurrentVersion,
+		ID:      queueARN,
+		Statement: iamv1.Statements{
+			iamv1.StatementEntry{
+				Sid:       "CAPANodeTerminationHandlerEvents",
+				Effect:    iamv1.EffectAllow,
+				Principal: iamv1.Principals{iamv1.PrincipalService: iamv1.PrincipalID{"events.amazonaws.com"}},
+				Action:    iamv1.Actions{"sqs:SendMessage"},
+				Resource:  iamv1.Resources{queueARN},
+				Condition: iamv1.Conditions{
+					"ArnLike": map[string]string{"aws:SourceArn": rulesARN.String()},
+				},
+			},
+		},
+	}
+	data, err := json.Marshal(policy)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to JSON marshal node termination handler queue policy")
+	}
+	return string(data), nil
+}
+
+// reconcileRule creates or updates the rule and adds the queue to its targets.
+func (s *Service) reconcileRule(rule infrav1.NodeTerminationHandlingRule, queueARN string) error {
+	name := s.ruleName(rule)
+	desired := eventPatterns[rule]
+
+	ruleOut, err := s.EventBridgeClient.DescribeRule(&eventbridge.DescribeRuleInput{Name: aws.String(name)})
+	if err != nil && !resourceNotFoundError(err) {
+		return errors.Wrapf(err, "unable to describe rule %s", name)
+	}
+
+	upToDate := false
+	if err == nil {
+		current := eventPattern{}
+		if jsonErr := json.Unmarshal([]byte(aws.StringValue(ruleOut.EventPattern)), &current); jsonErr == nil {
+			upToDate = cmp.Equal(current, desired) && aws.StringValue(ruleOut.State) == eventbridge.RuleStateEnabled
+		}
+	}
+
+	if !upToDate {
+		data, err := json.Marshal(desired)
+		if err != nil {
+			return err
+		}
+		if _, err := s.EventBridgeClient.PutRule(&eventbridge.PutRuleInput{
+			Name:         aws.String(name),
+			EventPattern: aws.String(string(data)),
+			State:        aws.String(eventbridge.RuleStateEnabled),
+		}); err != nil {
+			return errors.Wrapf(err, "unable to put rule %s", name)
+		}
+	}
+
+	targetsOut, err := s.EventBridgeClient.ListTargetsByRule(&eventbridge.ListTargetsByRuleInput{Rule: aws.String(name)})
+	if err != nil {
+		return errors.Wrapf(err, "unable to list targets for rule %s", name)
+	}
+	for _, target := range targetsOut.Targets {
+		if aws.StringValue(target.Id) == queueTargetID && aws.StringValue(target.Arn) == queueARN {
+			return nil
+		}
+	}
+
+	if _, err := s.EventBridgeClient.PutTargets(&eventbridge.PutTargetsInput{
+		Rule: aws.String(name),
+		Targets: []*eventbridge.Target{{
+			Id:  aws.String(queueTargetID),
+			Arn: aws.String(queueARN),
+		}},
+	}); err != nil {
+		return errors.Wrapf(err, "unable to add node termination handler queue to the targets of rule %s", name)
+	}
+	return nil
+}
+
+// deleteRule removes the queue from the targets of the rule and deletes the rule.
+func (s *Service) deleteRule(rule infrav1.NodeTerminationHandlingRule) error {
+	name := s.ruleName(rule)
+
+	_, err := s.EventBridgeClient.RemoveTargets(&eventbridge.RemoveTargetsInput{
+		Rule: aws.String(name),
+		Ids:  aws.StringSlice([]string{queueTargetID}),
+	})
+	if err != nil {
+		if resourceNotFoundError(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "unable to remove targets of rule %s", name)
+	}
+
+	if _, err := s.EventBridgeClient.DeleteRule(&eventbridge.DeleteRuleInput{Name: aws.String(name)}); err != nil && !resourceNotFoundError(err) {
+		return errors.Wrapf(err, "unable to delete rule %s", name)
+	}
+	return nil
+}
+
+// ruleNamePrefix returns the prefix of the names of the rules of the cluster, leaving room for the longest
+// rule name suffix.
+func (s *Service) ruleNamePrefix() string {
+	longest := 0
+	for _, suffix := range ruleNameSuffixes {
+		longest = max(longest, len(suffix))
+	}
+	return scope.NodeTerminationHandlingName(s.scope.Name(), "-nth-", maxRuleNameLength-longest)
+}
+
+func (s *Service) ruleName(rule infrav1.NodeTerminationHandlingRule) string {
+	return s.ruleNamePrefix() + ruleNameSuffixes[rule]
+}
+
+func resourceNotFoundError(err error) bool {
+	if aerr, ok := err.(awserr.Error); ok && aerr.Code() == eventbridge.ErrCodeResourceNotFoundException {
+		return true
+	}
+	return false
+}
+
+func queueNotFoundError(err error) bool {
+	if aerr, ok := err.(awserr.Error); ok && aerr.Code() == sqs.ErrCodeQueueDoesNotExist {
+		return true
+	}
+	return false
+}
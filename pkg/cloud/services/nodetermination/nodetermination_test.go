@@ -0,0 +1,253 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodetermination
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/eventbridge"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/instancestate/mock_eventbridgeiface"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/instancestate/mock_sqsiface"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+const (
+	queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/test-cluster-nth-queue"
+	queueARN = "arn:aws:sqs:us-east-1:123456789012:test-cluster-nth-queue"
+)
+
+func TestReconcileNodeTerminationHandling(t *testing.T) {
+	testCases := []struct {
+		name              string
+		spec              *infrav1.NodeTerminationHandling
+		status            *infrav1.NodeTerminationHandlingStatus
+		sqsExpect         func(m *mock_sqsiface.MockSQSAPIMockRecorder, policy string)
+		eventBridgeExpect func(m *mock_eventbridgeiface.MockEventBridgeAPIMockRecorder)
+		expectedStatus    *infrav1.NodeTerminationHandlingStatus
+		expectErr         bool
+	}{
+		{
+			name: "does nothing when not configured",
+		},
+		{
+			name: "creates the queue and all the rules when enabled",
+			spec: &infrav1.NodeTerminationHandling{Enabled: true},
+			sqsExpect: func(m *mock_sqsiface.MockSQSAPIMockRecorder, policy string) {
+				m.GetQueueUrl(&sqs.GetQueueUrlInput{QueueName: aws.String("test-cluster-nth-queue")}).
+					Return(nil, awserr.New(sqs.ErrCodeQueueDoesNotExist, "", nil))
+				m.CreateQueue(&sqs.CreateQueueInput{
+					QueueName: aws.String("test-cluster-nth-queue"),
+					Attributes: aws.StringMap(map[string]string{
+						sqs.QueueAttributeNameMessageRetentionPeriod: "300",
+						sqs.QueueAttributeNameSqsManagedSseEnabled:   "true",
+					}),
+				}).Return(&sqs.CreateQueueOutput{QueueUrl: aws.String(queueURL)}, nil)
+				m.GetQueueAttributes(&sqs.GetQueueAttributesInput{
+					AttributeNames: aws.StringSlice([]string{sqs.QueueAttributeNameQueueArn, sqs.QueueAttributeNamePolicy}),
+					QueueUrl:       aws.String(queueURL),
+				}).Return(&sqs.GetQueueAttributesOutput{Attributes: aws.StringMap(map[string]string{sqs.QueueAttributeNameQueueArn: queueARN})}, nil)
+				m.SetQueueAttributes(&sqs.SetQueueAttributesInput{
+					QueueUrl:   aws.String(queueURL),
+					Attributes: aws.StringMap(map[string]string{sqs.QueueAttributeNamePolicy: policy}),
+				}).Return(&sqs.SetQueueAttributesOutput{}, nil)
+			},
+			eventBridgeExpect: func(m *mock_eventbridgeiface.MockEventBridgeAPIMockRecorder) {
+				for _, rule := range rules {
+					name := "test-cluster-nth-" + ruleNameSuffixes[rule]
+					data, _ := json.Marshal(eventPatterns[rule])
+					m.DescribeRule(&eventbridge.DescribeRuleInput{Name: aws.String(name)}).
+						Return(nil, awserr.New(eventbridge.ErrCodeResourceNotFoundException, "", nil))
+					m.PutRule(&eventbridge.PutRuleInput{
+						Name:         aws.String(name),
+						EventPattern: aws.String(string(data)),
+						State:        aws.String(eventbridge.RuleStateEnabled),
+					}).Return(&eventbridge.PutRuleOutput{}, nil)
+					m.ListTargetsByRule(&eventbridge.ListTargetsByRuleInput{Rule: aws.String(name)}).
+						Return(&eventbridge.ListTargetsByRuleOutput{}, nil)
+					m.PutTargets(&eventbridge.PutTargetsInput{
+						Rule:    aws.String(name),
+						Targets: []*eventbridge.Target{{Id: aws.String(queueTargetID), Arn: aws.String(queueARN)}},
+					}).Return(&eventbridge.PutTargetsOutput{}, nil)
+				}
+			},
+			expectedStatus: &infrav1.NodeTerminationHandlingStatus{QueueURL: queueURL, QueueARN: queueARN},
+		},
+		{
+			name: "keeps up to date resources and removes rules that are no longer managed",
+			spec: &infrav1.NodeTerminationHandling{
+				Enabled:      true,
+				ManagedRules: []infrav1.NodeTerminationHandlingRule{infrav1.NodeTerminationHandlingRuleSpotInterruption},
+			},
+			sqsExpect: func(m *mock_sqsiface.MockSQSAPIMockRecorder, policy string) {
+				m.GetQueueUrl(&sqs.GetQueueUrlInput{QueueName: aws.String("test-cluster-nth-queue")}).
+					Return(&sqs.GetQueueUrlOutput{QueueUrl: aws.String(queueURL)}, nil)
+				m.GetQueueAttributes(gomock.Any()).Return(&sqs.GetQueueAttributesOutput{Attributes: aws.StringMap(map[string]string{
+					sqs.QueueAttributeNameQueueArn: queueARN,
+					sqs.QueueAttributeNamePolicy:   policy,
+				})}, nil)
+			},
+			eventBridgeExpect: func(m *mock_eventbridgeiface.MockEventBridgeAPIMockRecorder) {
+				m.DescribeRule(&eventbridge.DescribeRuleInput{Name: aws.String("test-cluster-nth-spot-interruption")}).
+					Return(&eventbridge.DescribeRuleOutput{
+						EventPattern: aws.String(`{"detail-type":["EC2 Spot Instance Interruption Warning"],"source":["aws.ec2"]}`),
+						State:        aws.String(eventbridge.RuleStateEnabled),
+					}, nil)
+				m.ListTargetsByRule(&eventbridge.ListTargetsByRuleInput{Rule: aws.String("test-cluster-nth-spot-interruption")}).
+					Return(&eventbridge.ListTargetsByRuleOutput{
+						Targets: []*eventbridge.Target{{Id: aws.String(queueTargetID), Arn: aws.String(queueARN)}},
+					}, nil)
+				m.RemoveTargets(&eventbridge.RemoveTargetsInput{
+					Rule: aws.String("test-cluster-nth-rebalance"),
+					Ids:  aws.StringSlice([]string{queueTargetID}),
+				}).Return(&eventbridge.RemoveTargetsOutput{}, nil)
+				m.DeleteRule(&eventbridge.DeleteRuleInput{Name: aws.String("test-cluster-nth-rebalance")}).
+					Return(&eventbridge.DeleteRuleOutput{}, nil)
+				m.RemoveTargets(&eventbridge.RemoveTargetsInput{
+					Rule: aws.String("test-cluster-nth-scheduled-change"),
+					Ids:  aws.StringSlice([]string{queueTargetID}),
+				}).Return(nil, awserr.New(eventbridge.ErrCodeResourceNotFoundException, "", nil))
+				m.RemoveTargets(&eventbridge.RemoveTargetsInput{
+					Rule: aws.String("test-cluster-nth-asg-lifecycle"),
+					Ids:  aws.StringSlice([]string{queueTargetID}),
+				}).Return(nil, awserr.New(eventbridge.ErrCodeResourceNotFoundException, "", nil))
+			},
+			expectedStatus: &infrav1.NodeTerminationHandlingStatus{QueueURL: queueURL, QueueARN: queueARN},
+		},
+		{
+			name:   "removes the queue and the rules when disabled",
+			spec:   &infrav1.NodeTerminationHandling{Enabled: false},
+			status: &infrav1.NodeTerminationHandlingStatus{QueueURL: queueURL, QueueARN: queueARN},
+			sqsExpect: func(m *mock_sqsiface.MockSQSAPIMockRecorder, _ string) {
+				m.DeleteQueue(&sqs.DeleteQueueInput{QueueUrl: aws.String(queueURL)}).Return(&sqs.DeleteQueueOutput{}, nil)
+			},
+			eventBridgeExpect: func(m *mock_eventbridgeiface.MockEventBridgeAPIMockRecorder) {
+				for _, rule := range rules {
+					name := "test-cluster-nth-" + ruleNameSuffixes[rule]
+					m.RemoveTargets(&eventbridge.RemoveTargetsInput{
+						Rule: aws.String(name),
+						Ids:  aws.StringSlice([]string{queueTargetID}),
+					}).Return(&eventbridge.RemoveTargetsOutput{}, nil)
+					m.DeleteRule(&eventbridge.DeleteRuleInput{Name: aws.String(name)}).Return(&eventbridge.DeleteRuleOutput{}, nil)
+				}
+			},
+		},
+		{
+			name: "returns an error when the queue can't be created",
+			spec: &infrav1.NodeTerminationHandling{Enabled: true, QueueName: "custom-queue"},
+			sqsExpect: func(m *mock_sqsiface.MockSQSAPIMockRecorder, _ string) {
+				m.GetQueueUrl(&sqs.GetQueueUrlInput{QueueName: aws.String("custom-queue")}).
+					Return(nil, awserr.New(sqs.ErrCodeQueueDoesNotExist, "", nil))
+				m.CreateQueue(gomock.Any()).Return(nil, awserr.New("AccessDenied", "", nil))
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			sqsMock := mock_sqsiface.NewMockSQSAPI(mockCtrl)
+			eventBridgeMock := mock_eventbridgeiface.NewMockEventBridgeAPI(mockCtrl)
+
+			clusterScope, err := setupCluster("test-cluster", tc.spec, tc.status)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			s := &Service{
+				scope:             clusterScope,
+				SQSClient:         sqsMock,
+				EventBridgeClient: eventBridgeMock,
+			}
+			if tc.sqsExpect != nil {
+				policy, err := s.queuePolicy(queueARN)
+				g.Expect(err).NotTo(HaveOccurred())
+				tc.sqsExpect(sqsMock.EXPECT(), policy)
+			}
+			if tc.eventBridgeExpect != nil {
+				tc.eventBridgeExpect(eventBridgeMock.EXPECT())
+			}
+
+			err = s.ReconcileNodeTerminationHandling()
+			if tc.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(clusterScope.NodeTerminationHandlingStatus()).To(Equal(tc.expectedStatus))
+		})
+	}
+}
+
+func TestQueuePolicy(t *testing.T) {
+	g := NewWithT(t)
+	clusterScope, err := setupCluster("test-cluster", nil, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	s := &Service{scope: clusterScope}
+	policy, err := s.queuePolicy(queueARN)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(policy).To(ContainSubstring(`"arn:aws:events:us-east-1:123456789012:rule/test-cluster-nth-*"`))
+	g.Expect(policy).To(ContainSubstring(`"events.amazonaws.com"`))
+
+	_, err = s.queuePolicy("not-an-arn")
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestRuleName(t *testing.T) {
+	g := NewWithT(t)
+	clusterScope, err := setupCluster(strings.Repeat("long.cluster.", 6)+"name", nil, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	s := &Service{scope: clusterScope}
+	for _, rule := range rules {
+		name := s.ruleName(rule)
+		g.Expect(len(name)).To(BeNumerically("<=", maxRuleNameLength))
+		g.Expect(name).To(MatchRegexp(`^[A-Za-z0-9_-]+$`))
+		g.Expect(name).To(HavePrefix(s.ruleNamePrefix()))
+	}
+}
+
+func setupCluster(clusterName string, spec *infrav1.NodeTerminationHandling, status *infrav1.NodeTerminationHandlingStatus) (*scope.ClusterScope, error) {
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+	awsCluster := &infrav1.AWSCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec:       infrav1.AWSClusterSpec{NodeTerminationHandling: spec},
+		Status:     infrav1.AWSClusterStatus{NodeTerminationHandling: status},
+	}
+	client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(awsCluster).Build()
+	return scope.NewClusterScope(scope.ClusterScopeParams{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: clusterName},
+		},
+		AWSCluster: awsCluster,
+		Client:     client,
+	})
+}
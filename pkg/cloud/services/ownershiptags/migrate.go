@@ -0,0 +1,123 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ownershiptags
+
+import (
+	"context"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	rgapi "github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/pkg/errors"
+)
+
+// tagResourcesBatchSize is the maximum number of resources a single TagResources call accepts.
+const tagResourcesBatchSize = 20
+
+// ReconcileOwnershipTags tags the cluster's resources that carry the ownership tag with the previous
+// prefix with the ownership tag using the current prefix. Once all of them are tagged, the migration
+// is recorded as complete on the cluster object. The previous tags are left in place.
+func (s *Service) ReconcileOwnershipTags() error {
+	previous := s.scope.PreviousOwnershipTagPrefix()
+	if previous == "" {
+		return nil
+	}
+	current := s.scope.OwnershipTagPrefix()
+
+	s.scope.Info("Migrating ownership tags", "from", previous, "to", current)
+
+	for _, name := range s.clusterNames() {
+		if err := s.migrateTags(previous+name, current+name); err != nil {
+			return err
+		}
+	}
+
+	s.scope.CompleteOwnershipTagMigration()
+	s.scope.Info("Migrated ownership tags", "prefix", current)
+	return nil
+}
+
+// clusterNames returns the names the cluster's resources are tagged with.
+func (s *Service) clusterNames() []string {
+	names := []string{s.scope.Name()}
+	if name := s.scope.KubernetesClusterName(); name != "" && name != s.scope.Name() {
+		names = append(names, name)
+	}
+	return names
+}
+
+func (s *Service) migrateTags(oldKey, newKey string) error {
+	// Group the resources by tag value, as TagResources applies the same tags to all the resources it's given.
+	arnsByValue := map[string][]string{}
+	input := &rgapi.GetResourcesInput{
+		TagFilters: []*rgapi.TagFilter{{Key: aws.String(oldKey)}},
+	}
+	err := s.ResourceTaggingClient.GetResourcesPagesWithContext(context.TODO(), input, func(out *rgapi.GetResourcesOutput, _ bool) bool {
+		for _, mapping := range out.ResourceTagMappingList {
+			var value string
+			migrated := false
+			for _, tag := range mapping.Tags {
+				switch aws.StringValue(tag.Key) {
+				case oldKey:
+					value = aws.StringValue(tag.Value)
+				case newKey:
+					migrated = true
+				}
+			}
+			if !migrated {
+				arnsByValue[value] = append(arnsByValue[value], aws.StringValue(mapping.ResourceARN))
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to get resources tagged with %q", oldKey)
+	}
+
+	values := make([]string, 0, len(arnsByValue))
+	for value := range arnsByValue {
+		values = append(values, value)
+	}
+	sort.Strings(values)
+
+	for _, value := range values {
+		arns := arnsByValue[value]
+		for start := 0; start < len(arns); start += tagResourcesBatchSize {
+			end := start + tagResourcesBatchSize
+			if end > len(arns) {
+				end = len(arns)
+			}
+			out, err := s.ResourceTaggingClient.TagResourcesWithContext(context.TODO(), &rgapi.TagResourcesInput{
+				ResourceARNList: aws.StringSlice(arns[start:end]),
+				Tags:            map[string]*string{newKey: aws.String(value)},
+			})
+			if err != nil {
+				return errors.Wrapf(err, "failed to tag resources with %q", newKey)
+			}
+			if len(out.FailedResourcesMap) > 0 {
+				failed := make([]string, 0, len(out.FailedResourcesMap))
+				for arn := range out.FailedResourcesMap {
+					failed = append(failed, arn)
+				}
+				sort.Strings(failed)
+				return errors.Errorf("failed to tag resources %v with %q", failed, newKey)
+			}
+			s.scope.Debug("Tagged resources", "key", newKey, "count", end-start)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ownershiptags
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	rgapi "github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloudtest"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+)
+
+const newPrefix = "example.com/cluster/"
+
+func TestReconcileOwnershipTags(t *testing.T) {
+	oldKey := infrav1.NameAWSProviderOwned + "test-cluster"
+	newKey := newPrefix + "test-cluster"
+
+	resources := func(mappings ...*rgapi.ResourceTagMapping) func(context.Context, *rgapi.GetResourcesInput, func(*rgapi.GetResourcesOutput, bool) bool, ...request.Option) error {
+		return func(_ context.Context, _ *rgapi.GetResourcesInput, fn func(*rgapi.GetResourcesOutput, bool) bool, _ ...request.Option) error {
+			fn(&rgapi.GetResourcesOutput{ResourceTagMappingList: mappings}, true)
+			return nil
+		}
+	}
+	mapping := func(arn string, tags map[string]string) *rgapi.ResourceTagMapping {
+		m := &rgapi.ResourceTagMapping{ResourceARN: aws.String(arn)}
+		for k, v := range tags {
+			m.Tags = append(m.Tags, &rgapi.Tag{Key: aws.String(k), Value: aws.String(v)})
+		}
+		return m
+	}
+	getResourcesInput := &rgapi.GetResourcesInput{
+		TagFilters: []*rgapi.TagFilter{{Key: aws.String(oldKey)}},
+	}
+
+	tests := []struct {
+		name              string
+		prefix            string
+		expect            func(m *mocks.MockResourceGroupsTaggingAPIAPIMockRecorder)
+		expectErr         bool
+		expectedCompleted bool
+	}{
+		{
+			name:   "no migration needed",
+			prefix: "",
+			expect: func(m *mocks.MockResourceGroupsTaggingAPIAPIMockRecorder) {},
+		},
+		{
+			name:   "resources are tagged with the new key",
+			prefix: newPrefix,
+			expect: func(m *mocks.MockResourceGroupsTaggingAPIAPIMockRecorder) {
+				m.GetResourcesPagesWithContext(context.TODO(), getResourcesInput, gomock.Any()).DoAndReturn(resources(
+					mapping("arn:aws:ec2:us-east-1:123456789012:vpc/vpc-1", map[string]string{oldKey: "owned"}),
+					mapping("arn:aws:ec2:us-east-1:123456789012:subnet/subnet-1", map[string]string{oldKey: "shared"}),
+					mapping("arn:aws:ec2:us-east-1:123456789012:subnet/subnet-2", map[string]string{oldKey: "owned", newKey: "owned"}),
+				))
+				m.TagResourcesWithContext(context.TODO(), &rgapi.TagResourcesInput{
+					ResourceARNList: aws.StringSlice([]string{"arn:aws:ec2:us-east-1:123456789012:vpc/vpc-1"}),
+					Tags:            map[string]*string{newKey: aws.String("owned")},
+				}).Return(&rgapi.TagResourcesOutput{}, nil)
+				m.TagResourcesWithContext(context.TODO(), &rgapi.TagResourcesInput{
+					ResourceARNList: aws.StringSlice([]string{"arn:aws:ec2:us-east-1:123456789012:subnet/subnet-1"}),
+					Tags:            map[string]*string{newKey: aws.String("shared")},
+				}).Return(&rgapi.TagResourcesOutput{}, nil)
+			},
+			expectedCompleted: true,
+		},
+		{
+			name:   "failure to tag a resource keeps the migration in progress",
+			prefix: newPrefix,
+			expect: func(m *mocks.MockResourceGroupsTaggingAPIAPIMockRecorder) {
+				m.GetResourcesPagesWithContext(context.TODO(), getResourcesInput, gomock.Any()).DoAndReturn(resources(
+					mapping("arn:aws:ec2:us-east-1:123456789012:vpc/vpc-1", map[string]string{oldKey: "owned"}),
+				))
+				m.TagResourcesWithContext(context.TODO(), gomock.Any()).Return(&rgapi.TagResourcesOutput{
+					FailedResourcesMap: map[string]*rgapi.FailureInfo{
+						"arn:aws:ec2:us-east-1:123456789012:vpc/vpc-1": {ErrorCode: aws.String("InternalServiceException")},
+					},
+				}, nil)
+			},
+			expectErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			rgapiMock := mocks.NewMockResourceGroupsTaggingAPIAPI(mockCtrl)
+			tc.expect(rgapiMock.EXPECT())
+
+			clusterScope := cloudtest.NewClusterScope(t)
+			clusterScope.AWSCluster.Spec.OwnershipTagPrefix = tc.prefix
+			s := NewService(clusterScope)
+			s.ResourceTaggingClient = rgapiMock
+
+			err := s.ReconcileOwnershipTags()
+			if tc.expectErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+
+			_, completed := clusterScope.AWSCluster.Annotations[infrav1.OwnershipTagPrefixAnnotation]
+			g.Expect(completed).To(Equal(tc.expectedCompleted))
+			if tc.expectedCompleted {
+				g.Expect(clusterScope.PreviousOwnershipTagPrefix()).To(BeEmpty())
+				g.Expect(clusterScope.OwnershipTagPrefixes()).To(Equal([]string{newPrefix}))
+			}
+		})
+	}
+}
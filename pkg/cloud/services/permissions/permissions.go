@@ -0,0 +1,287 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package permissions
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	bootstrapv1 "sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/api/bootstrap/v1beta1"
+	iamv1 "sigs.k8s.io/cluster-api-provider-aws/v2/iam/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/iam/policies"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+const (
+	// DefaultCheckInterval is the interval after which the permissions of a principal are simulated again.
+	DefaultCheckInterval = time.Hour
+
+	// resourcePlaceholder replaces wildcards in the resources of the controller policy when simulating it.
+	resourcePlaceholder = "capa-permission-check"
+)
+
+// Features are the controller features requiring permissions on top of the ones used by every cluster.
+type Features struct {
+	EKS                bool
+	MachinePool        bool
+	ExternalResourceGC bool
+	AccountQuotaCheck  bool
+}
+
+// Checker holds the results of the permission checks, so principals shared by clusters are
+// only simulated once per interval.
+type Checker struct {
+	features Features
+	interval time.Duration
+	now      func() time.Time
+
+	mu      sync.Mutex
+	results map[string]checkResult
+}
+
+type checkResult struct {
+	missing   []string
+	checkedAt time.Time
+}
+
+// NewChecker returns a checker for the given features, keeping the results for the given interval.
+func NewChecker(features Features, interval time.Duration) *Checker {
+	return &Checker{
+		features: features,
+		interval: interval,
+		now:      time.Now,
+		results:  map[string]checkResult{},
+	}
+}
+
+func (c *Checker) cached(key string) ([]string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	res, ok := c.results[key]
+	if !ok || c.now().Sub(res.checkedAt) >= c.interval {
+		return nil, false
+	}
+	return res.missing, true
+}
+
+func (c *Checker) store(key string, missing []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.results[key] = checkResult{missing: missing, checkedAt: c.now()}
+}
+
+// ReconcilePermissions simulates the actions required by the enabled features against the principal
+// used for the cluster, and publishes the result with the ControllerPermissionsCondition.
+func (s *Service) ReconcilePermissions() error {
+	s.scope.Debug("Checking controller permissions")
+
+	bucket := ""
+	if s.scope.Bucket() != nil {
+		bucket = s.scope.Bucket().Name
+	}
+
+	identity, err := s.STSClient.GetCallerIdentityWithContext(context.TODO(), &sts.GetCallerIdentityInput{})
+	if err != nil {
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.ControllerPermissionsCondition, infrav1.ControllerPermissionsCheckFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return errors.Wrap(err, "failed to get caller identity")
+	}
+
+	principal, err := s.principalARN(aws.StringValue(identity.Arn))
+	if err != nil {
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.ControllerPermissionsCondition, infrav1.ControllerPermissionsCheckFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return err
+	}
+
+	key := strings.Join([]string{principal.String(), s.scope.Region(), bucket}, "/")
+	missing, ok := s.checker.cached(key)
+	if !ok {
+		missing, err = s.missingActions(principal, bucket)
+		if err != nil {
+			conditions.MarkFalse(s.scope.InfraCluster(), infrav1.ControllerPermissionsCondition, infrav1.ControllerPermissionsCheckFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+			return err
+		}
+		s.checker.store(key, missing)
+	}
+
+	if len(missing) > 0 {
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.ControllerPermissionsCondition, infrav1.MissingControllerPermissionsReason, clusterv1.ConditionSeverityWarning,
+			"%s is missing permissions for actions: %s", principal.String(), strings.Join(missing, ", "))
+		return nil
+	}
+
+	conditions.MarkTrue(s.scope.InfraCluster(), infrav1.ControllerPermissionsCondition)
+	return nil
+}
+
+// principalARN returns the ARN of the IAM user or role to simulate. Sessions of assumed roles can't be
+// simulated, and their ARN doesn't contain the path of the role, so it is looked up.
+func (s *Service) principalARN(callerARN string) (arn.ARN, error) {
+	principal, err := arn.Parse(callerARN)
+	if err != nil {
+		return arn.ARN{}, errors.Wrapf(err, "failed to parse caller identity %q", callerARN)
+	}
+
+	if !strings.HasPrefix(principal.Resource, "assumed-role/") {
+		return principal, nil
+	}
+
+	roleName := strings.Split(principal.Resource, "/")[1]
+	out, err := s.IAMClient.GetRoleWithContext(context.TODO(), &iam.GetRoleInput{
+		RoleName: aws.String(roleName),
+	})
+	if err != nil {
+		return arn.ARN{}, errors.Wrapf(err, "failed to get role %q", roleName)
+	}
+
+	return arn.Parse(aws.StringValue(out.Role.Arn))
+}
+
+// missingActions simulates the policy of the principal, and returns the actions of the controller
+// policy which are not allowed.
+func (s *Service) missingActions(principal arn.ARN, bucket string) ([]string, error) {
+	missing := sets.New[string]()
+
+	for _, statement := range requiredStatements(s.checker.features, bucket) {
+		actions := sets.New[string]()
+		for _, action := range statement.Action {
+			if requiredForFeatures(action, s.checker.features) {
+				actions.Insert(action)
+			}
+		}
+		if actions.Len() == 0 {
+			continue
+		}
+
+		input := &iam.SimulatePrincipalPolicyInput{
+			PolicySourceArn: aws.String(principal.String()),
+			ActionNames:     aws.StringSlice(sets.List(actions)),
+		}
+		if !isAnyResource(statement.Resource) {
+			resources := make([]string, 0, len(statement.Resource))
+			for _, resource := range statement.Resource {
+				resources = append(resources, simulationResourceARN(resource, principal.Partition, s.scope.Region(), principal.AccountID))
+			}
+			input.ResourceArns = aws.StringSlice(resources)
+		}
+
+		if err := s.IAMClient.SimulatePrincipalPolicyPagesWithContext(context.TODO(), input, func(out *iam.SimulatePolicyResponse, _ bool) bool {
+			for _, result := range out.EvaluationResults {
+				if !isAllowed(result) {
+					missing.Insert(aws.StringValue(result.EvalActionName))
+				}
+			}
+			return true
+		}); err != nil {
+			return nil, errors.Wrapf(err, "failed to simulate policy of %q", principal.String())
+		}
+	}
+
+	return sets.List(missing), nil
+}
+
+// requiredStatements returns the unconditional statements of the controller policies which clusterawsadm
+// creates for the enabled features. Statements with conditions are skipped, as the simulation doesn't
+// know the context the actions are used in.
+func requiredStatements(features Features, bucket string) []iamv1.StatementEntry {
+	spec := bootstrapv1.NewAWSIAMConfiguration().Spec
+	spec.EKS.Disable = !features.EKS
+	if bucket != "" {
+		spec.S3Buckets.Enable = true
+		spec.S3Buckets.NamePrefix = bucket
+	}
+
+	statements := policies.ControllersPolicy(&spec).Statement
+	if features.EKS {
+		statements = append(statements, policies.ControllersPolicyEKS(&spec).Statement...)
+	}
+
+	res := []iamv1.StatementEntry{}
+	for _, statement := range statements {
+		if statement.Effect != iamv1.EffectAllow || len(statement.Condition) > 0 {
+			continue
+		}
+		res = append(res, statement)
+	}
+	return res
+}
+
+// requiredForFeatures returns whether an action of the controller policy is used with the enabled features.
+func requiredForFeatures(action string, features Features) bool {
+	switch {
+	case strings.HasPrefix(action, "autoscaling:"), strings.Contains(action, "LaunchTemplate"):
+		return features.MachinePool
+	case action == "tag:GetResources":
+		return features.ExternalResourceGC
+	case strings.HasPrefix(action, "servicequotas:"):
+		return features.AccountQuotaCheck
+	}
+	return true
+}
+
+func isAnyResource(resources iamv1.Resources) bool {
+	for _, resource := range resources {
+		if resource == iamv1.Any {
+			return true
+		}
+	}
+	return len(resources) == 0
+}
+
+// simulationResourceARN turns a resource of the controller policy into an ARN which can be simulated.
+// The partition, region and account are the ones of the principal and the cluster, a trailing wildcard
+// following a name prefix is matched by the prefix itself, and other wildcards are replaced by a placeholder.
+func simulationResourceARN(pattern, partition, region, accountID string) string {
+	parts := strings.SplitN(pattern, ":", 6)
+	if len(parts) != 6 {
+		return pattern
+	}
+
+	if parts[1] == iamv1.Any {
+		parts[1] = partition
+	}
+	if parts[3] == iamv1.Any {
+		parts[3] = region
+	}
+	if parts[4] == iamv1.Any {
+		parts[4] = accountID
+	}
+
+	resource := parts[5]
+	if len(resource) > 1 && strings.HasSuffix(resource, iamv1.Any) && !strings.HasSuffix(resource, "/*") && !strings.HasSuffix(resource, ":*") {
+		resource = strings.TrimSuffix(resource, iamv1.Any)
+	}
+	parts[5] = strings.ReplaceAll(resource, iamv1.Any, resourcePlaceholder)
+
+	return strings.Join(parts, ":")
+}
+
+func isAllowed(result *iam.EvaluationResult) bool {
+	return aws.StringValue(result.EvalDecision) == iam.PolicyEvaluationDecisionTypeAllowed
+}
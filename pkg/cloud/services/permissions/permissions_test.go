@@ -0,0 +1,184 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package permissions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/iamauth/mock_iamauth"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/sts/mock_stsiface"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloudtest"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestSimulationResourceARN(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    string
+	}{
+		{
+			pattern: "arn:*:autoscaling:*:*:autoScalingGroup:*:autoScalingGroupName/*",
+			want:    "arn:aws:autoscaling:us-east-1:123456789012:autoScalingGroup:capa-permission-check:autoScalingGroupName/capa-permission-check",
+		},
+		{
+			pattern: "arn:*:iam::*:role/*.cluster-api-provider-aws.sigs.k8s.io",
+			want:    "arn:aws:iam::123456789012:role/capa-permission-check.cluster-api-provider-aws.sigs.k8s.io",
+		},
+		{
+			pattern: "arn:*:s3:::my-bucket*",
+			want:    "arn:aws:s3:::my-bucket",
+		},
+		{
+			pattern: "arn:aws:eks:*:*:nodegroup/*/*/*",
+			want:    "arn:aws:eks:us-east-1:123456789012:nodegroup/capa-permission-check/capa-permission-check/capa-permission-check",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(simulationResourceARN(tt.pattern, "aws", "us-east-1", "123456789012")).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestRequiredForFeatures(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(requiredForFeatures("ec2:CreateVpc", Features{})).To(BeTrue())
+	g.Expect(requiredForFeatures("autoscaling:CreateAutoScalingGroup", Features{})).To(BeFalse())
+	g.Expect(requiredForFeatures("ec2:CreateLaunchTemplateVersion", Features{MachinePool: true})).To(BeTrue())
+	g.Expect(requiredForFeatures("tag:GetResources", Features{})).To(BeFalse())
+	g.Expect(requiredForFeatures("tag:GetResources", Features{ExternalResourceGC: true})).To(BeTrue())
+	g.Expect(requiredForFeatures("servicequotas:GetServiceQuota", Features{})).To(BeFalse())
+	g.Expect(requiredForFeatures("servicequotas:GetServiceQuota", Features{AccountQuotaCheck: true})).To(BeTrue())
+}
+
+func TestReconcilePermissions(t *testing.T) {
+	tests := []struct {
+		name          string
+		denied        []string
+		wantStatus    corev1.ConditionStatus
+		wantReason    string
+		wantInMessage string
+	}{
+		{
+			name:       "all actions are allowed",
+			wantStatus: corev1.ConditionTrue,
+		},
+		{
+			name:          "reports missing actions",
+			denied:        []string{"ec2:CreateVpc", "elasticloadbalancing:CreateLoadBalancer"},
+			wantStatus:    corev1.ConditionFalse,
+			wantReason:    infrav1.MissingControllerPermissionsReason,
+			wantInMessage: "arn:aws:iam::123456789012:role/path/controllers is missing permissions for actions: ec2:CreateVpc, elasticloadbalancing:CreateLoadBalancer",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			iamMock := mock_iamauth.NewMockIAMAPI(mockCtrl)
+			stsMock := mock_stsiface.NewMockSTSAPI(mockCtrl)
+
+			stsMock.EXPECT().GetCallerIdentityWithContext(context.TODO(), gomock.Any()).Return(&sts.GetCallerIdentityOutput{
+				Arn: aws.String("arn:aws:sts::123456789012:assumed-role/controllers/session"),
+			}, nil).Times(2)
+			iamMock.EXPECT().GetRoleWithContext(context.TODO(), gomock.Eq(&iam.GetRoleInput{RoleName: aws.String("controllers")})).Return(&iam.GetRoleOutput{
+				Role: &iam.Role{Arn: aws.String("arn:aws:iam::123456789012:role/path/controllers")},
+			}, nil).Times(2)
+			simulations := 0
+			iamMock.EXPECT().SimulatePrincipalPolicyPagesWithContext(context.TODO(), gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, input *iam.SimulatePrincipalPolicyInput, fn func(*iam.SimulatePolicyResponse, bool) bool, _ ...request.Option) error {
+					simulations++
+					g.Expect(aws.StringValue(input.PolicySourceArn)).To(Equal("arn:aws:iam::123456789012:role/path/controllers"))
+					out := &iam.SimulatePolicyResponse{}
+					for _, action := range input.ActionNames {
+						decision := iam.PolicyEvaluationDecisionTypeAllowed
+						for _, denied := range tt.denied {
+							if aws.StringValue(action) == denied {
+								decision = iam.PolicyEvaluationDecisionTypeImplicitDeny
+							}
+						}
+						out.EvaluationResults = append(out.EvaluationResults, &iam.EvaluationResult{
+							EvalActionName: action,
+							EvalDecision:   aws.String(decision),
+						})
+					}
+					fn(out, true)
+					return nil
+				}).MinTimes(1)
+
+			clusterScope := cloudtest.NewClusterScope(t)
+			s := &Service{
+				scope:     clusterScope,
+				checker:   NewChecker(Features{}, DefaultCheckInterval),
+				IAMClient: iamMock,
+				STSClient: stsMock,
+			}
+
+			// The second reconciliation uses the cached result.
+			firstSimulations := 0
+			for i := 0; i < 2; i++ {
+				g.Expect(s.ReconcilePermissions()).To(Succeed())
+				if i == 0 {
+					firstSimulations = simulations
+				}
+				g.Expect(simulations).To(Equal(firstSimulations))
+
+				condition := conditions.Get(clusterScope.AWSCluster, infrav1.ControllerPermissionsCondition)
+				g.Expect(condition).NotTo(BeNil())
+				g.Expect(condition.Status).To(Equal(tt.wantStatus))
+				g.Expect(condition.Reason).To(Equal(tt.wantReason))
+				g.Expect(condition.Message).To(ContainSubstring(tt.wantInMessage))
+			}
+		})
+	}
+}
+
+func TestReconcilePermissionsCheckFailed(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	stsMock := mock_stsiface.NewMockSTSAPI(mockCtrl)
+	stsMock.EXPECT().GetCallerIdentityWithContext(context.TODO(), gomock.Any()).Return(&sts.GetCallerIdentityOutput{
+		Arn: aws.String("not-an-arn"),
+	}, nil)
+
+	clusterScope := cloudtest.NewClusterScope(t)
+	s := &Service{
+		scope:     clusterScope,
+		checker:   NewChecker(Features{}, DefaultCheckInterval),
+		IAMClient: mock_iamauth.NewMockIAMAPI(mockCtrl),
+		STSClient: stsMock,
+	}
+
+	g.Expect(s.ReconcilePermissions()).NotTo(Succeed())
+	g.Expect(conditions.GetReason(clusterScope.AWSCluster, infrav1.ControllerPermissionsCondition)).To(Equal(infrav1.ControllerPermissionsCheckFailedReason))
+}
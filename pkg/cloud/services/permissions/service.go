@@ -0,0 +1,45 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package permissions provides a way to check that the principal used by the controllers
+// has the IAM permissions required to reconcile a cluster.
+package permissions
+
+import (
+	"github.com/aws/aws-sdk-go/service/iam/iamiface"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+)
+
+// Service checks the IAM permissions of the principal used for a cluster.
+type Service struct {
+	scope     *scope.ClusterScope
+	checker   *Checker
+	IAMClient iamiface.IAMAPI
+	STSClient stsiface.STSAPI
+}
+
+// NewService returns a new service given the cluster scope and the checker holding the results
+// of previous checks.
+func NewService(clusterScope *scope.ClusterScope, checker *Checker) *Service {
+	return &Service{
+		scope:     clusterScope,
+		checker:   checker,
+		IAMClient: scope.NewIAMClient(clusterScope, clusterScope, clusterScope, clusterScope.InfraCluster()),
+		STSClient: scope.NewSTSClient(clusterScope, clusterScope, clusterScope, clusterScope.InfraCluster()),
+	}
+}
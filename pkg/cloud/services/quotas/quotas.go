@@ -0,0 +1,271 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quotas
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/servicequotas"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+const (
+	// DefaultCheckInterval is the interval after which the quotas and the usage of an account are fetched again.
+	DefaultCheckInterval = time.Hour
+
+	// autoScalingServiceCode is the Service Quotas code of the Auto Scaling service.
+	autoScalingServiceCode = "autoscaling"
+	// autoScalingGroupsQuotaCode is the Service Quotas code of the number of Auto Scaling groups per region.
+	autoScalingGroupsQuotaCode = "L-CDE20ADC"
+	// launchTemplatesQuota is the number of launch templates per region, which isn't reported by Service Quotas.
+	launchTemplatesQuota = 5000
+)
+
+// Resource is a type of resource whose account quota is checked.
+type Resource string
+
+const (
+	// ResourceAutoScalingGroups are the Auto Scaling groups of an account and region.
+	ResourceAutoScalingGroups = Resource("AutoScalingGroups")
+	// ResourceLaunchTemplates are the launch templates of an account and region.
+	ResourceLaunchTemplates = Resource("LaunchTemplates")
+)
+
+// resources lists the checked resources in the order they're reported.
+var resources = []Resource{ResourceAutoScalingGroups, ResourceLaunchTemplates}
+
+var (
+	accountQuota = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: "aws",
+		Name:      "account_quota",
+		Help:      "Quota of a type of resource of an AWS account in a region",
+	}, []string{"account", "region", "resource"})
+	accountQuotaUsage = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: "aws",
+		Name:      "account_quota_usage",
+		Help:      "Number of the resources of an AWS account in a region counted against their quota",
+	}, []string{"account", "region", "resource"})
+	accountQuotaManagedUsage = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: "aws",
+		Name:      "account_quota_managed_usage",
+		Help:      "Number of the resources of an AWS account in a region counted against their quota which were created by the controllers",
+	}, []string{"account", "region", "resource"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(accountQuota, accountQuotaUsage, accountQuotaManagedUsage)
+}
+
+// Usage is the quota of a type of resource in an account and region, and the number of resources counted
+// against it.
+type Usage struct {
+	Quota int
+	Used  int
+	// Managed is the number of the used resources which were created by the controllers.
+	Managed int
+}
+
+// Checker holds the usage of the quotas of each account and region, so it is only fetched once per interval
+// for all the clusters sharing an account and region.
+type Checker struct {
+	interval time.Duration
+	now      func() time.Time
+
+	mu      sync.Mutex
+	results map[string]checkResult
+}
+
+type checkResult struct {
+	usage     map[Resource]Usage
+	checkedAt time.Time
+}
+
+// NewChecker returns a checker keeping the usage of the quotas for the given interval.
+func NewChecker(interval time.Duration) *Checker {
+	return &Checker{
+		interval: interval,
+		now:      time.Now,
+		results:  map[string]checkResult{},
+	}
+}
+
+// usage returns the usage of the account and region of the key, fetching it once the cached one expired.
+// The lock is held while fetching, so that the clusters reconciled concurrently wait for the same fetch.
+func (c *Checker) usage(key string, fetch func() (map[Resource]Usage, error)) (map[Resource]Usage, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if res, ok := c.results[key]; ok && c.now().Sub(res.checkedAt) < c.interval {
+		return res.usage, nil
+	}
+	usage, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	c.results[key] = checkResult{usage: usage, checkedAt: c.now()}
+	return usage, nil
+}
+
+// ReconcileQuotas checks that the resources the cluster is waiting for, counted by type in pending, fit in the
+// quotas of its account and region, and publishes the result with the AccountQuotasCondition. The quotas and
+// their usage are exported as metrics.
+func (s *Service) ReconcileQuotas(pending map[Resource]int) error {
+	s.scope.Debug("Checking account quotas")
+
+	identity, err := s.STSClient.GetCallerIdentityWithContext(context.TODO(), &sts.GetCallerIdentityInput{})
+	if err != nil {
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.AccountQuotasCondition, infrav1.AccountQuotasCheckFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return errors.Wrap(err, "failed to get caller identity")
+	}
+	account, region := aws.StringValue(identity.Account), s.scope.Region()
+
+	usage, err := s.checker.usage(account+"/"+region, func() (map[Resource]Usage, error) {
+		return s.fetchUsage(account, region)
+	})
+	if err != nil {
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.AccountQuotasCondition, infrav1.AccountQuotasCheckFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return err
+	}
+
+	var exceeded []string
+	for _, resource := range resources {
+		u := usage[resource]
+		if count := pending[resource]; count > 0 && u.Used+count > u.Quota {
+			exceeded = append(exceeded, fmt.Sprintf("%d %s for a quota of %d with %d used", count, resource, u.Quota, u.Used))
+		}
+	}
+	if len(exceeded) > 0 {
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.AccountQuotasCondition, infrav1.AccountQuotaExceededReason, clusterv1.ConditionSeverityWarning,
+			"Creating the pending resources would exceed the quotas of account %s in region %s: %s", account, region, strings.Join(exceeded, ", "))
+		return nil
+	}
+
+	conditions.MarkTrue(s.scope.InfraCluster(), infrav1.AccountQuotasCondition)
+	return nil
+}
+
+// fetchUsage fetches the quotas of the account and region and counts the resources counted against them.
+func (s *Service) fetchUsage(account, region string) (map[Resource]Usage, error) {
+	asgQuota, err := s.serviceQuota(autoScalingServiceCode, autoScalingGroupsQuotaCode)
+	if err != nil {
+		return nil, err
+	}
+	asgUsed, asgManaged, err := s.countAutoScalingGroups()
+	if err != nil {
+		return nil, err
+	}
+	ltUsed, ltManaged, err := s.countLaunchTemplates()
+	if err != nil {
+		return nil, err
+	}
+
+	usage := map[Resource]Usage{
+		ResourceAutoScalingGroups: {Quota: asgQuota, Used: asgUsed, Managed: asgManaged},
+		ResourceLaunchTemplates:   {Quota: launchTemplatesQuota, Used: ltUsed, Managed: ltManaged},
+	}
+	for resource, u := range usage {
+		accountQuota.WithLabelValues(account, region, string(resource)).Set(float64(u.Quota))
+		accountQuotaUsage.WithLabelValues(account, region, string(resource)).Set(float64(u.Used))
+		accountQuotaManagedUsage.WithLabelValues(account, region, string(resource)).Set(float64(u.Managed))
+	}
+	return usage, nil
+}
+
+// serviceQuota returns the value of a quota applied to the account, or its default value when the quota
+// was never adjusted for the account.
+func (s *Service) serviceQuota(serviceCode, quotaCode string) (int, error) {
+	out, err := s.ServiceQuotasClient.GetServiceQuotaWithContext(context.TODO(), &servicequotas.GetServiceQuotaInput{
+		ServiceCode: aws.String(serviceCode),
+		QuotaCode:   aws.String(quotaCode),
+	})
+	var aerr awserr.Error
+	if errors.As(err, &aerr) && aerr.Code() == servicequotas.ErrCodeNoSuchResourceException {
+		var defaultOut *servicequotas.GetAWSDefaultServiceQuotaOutput
+		defaultOut, err = s.ServiceQuotasClient.GetAWSDefaultServiceQuotaWithContext(context.TODO(), &servicequotas.GetAWSDefaultServiceQuotaInput{
+			ServiceCode: aws.String(serviceCode),
+			QuotaCode:   aws.String(quotaCode),
+		})
+		if err == nil {
+			return int(aws.Float64Value(defaultOut.Quota.Value)), nil
+		}
+	}
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to get quota %s of service %s", quotaCode, serviceCode)
+	}
+	return int(aws.Float64Value(out.Quota.Value)), nil
+}
+
+// countAutoScalingGroups returns the number of Auto Scaling groups of the region, and how many of them were
+// created by the controllers.
+func (s *Service) countAutoScalingGroups() (int, int, error) {
+	used, managed := 0, 0
+	err := s.ASGClient.DescribeAutoScalingGroupsPagesWithContext(context.TODO(), &autoscaling.DescribeAutoScalingGroupsInput{},
+		func(out *autoscaling.DescribeAutoScalingGroupsOutput, _ bool) bool {
+			for _, group := range out.AutoScalingGroups {
+				used++
+				for _, tag := range group.Tags {
+					if strings.HasPrefix(aws.StringValue(tag.Key), infrav1.NameAWSProviderPrefix) {
+						managed++
+						break
+					}
+				}
+			}
+			return true
+		})
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "failed to describe Auto Scaling groups")
+	}
+	return used, managed, nil
+}
+
+// countLaunchTemplates returns the number of launch templates of the region, and how many of them were created
+// by the controllers.
+func (s *Service) countLaunchTemplates() (int, int, error) {
+	used, managed := 0, 0
+	err := s.EC2Client.DescribeLaunchTemplatesPagesWithContext(context.TODO(), &ec2.DescribeLaunchTemplatesInput{},
+		func(out *ec2.DescribeLaunchTemplatesOutput, _ bool) bool {
+			for _, template := range out.LaunchTemplates {
+				used++
+				for _, tag := range template.Tags {
+					if strings.HasPrefix(aws.StringValue(tag.Key), infrav1.NameAWSProviderPrefix) {
+						managed++
+						break
+					}
+				}
+			}
+			return true
+		})
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "failed to describe launch templates")
+	}
+	return used, managed, nil
+}
@@ -0,0 +1,172 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quotas
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/autoscaling/autoscalingiface"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/aws/aws-sdk-go/service/servicequotas"
+	"github.com/aws/aws-sdk-go/service/servicequotas/servicequotasiface"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/sts/mock_stsiface"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloudtest"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+type fakeServiceQuotasClient struct {
+	servicequotasiface.ServiceQuotasAPI
+	applied      bool
+	value        float64
+	appliedCalls int
+}
+
+func (f *fakeServiceQuotasClient) GetServiceQuotaWithContext(_ aws.Context, _ *servicequotas.GetServiceQuotaInput, _ ...request.Option) (*servicequotas.GetServiceQuotaOutput, error) {
+	f.appliedCalls++
+	if !f.applied {
+		return nil, awserr.New(servicequotas.ErrCodeNoSuchResourceException, "not applied", nil)
+	}
+	return &servicequotas.GetServiceQuotaOutput{Quota: &servicequotas.ServiceQuota{Value: aws.Float64(f.value)}}, nil
+}
+
+func (f *fakeServiceQuotasClient) GetAWSDefaultServiceQuotaWithContext(_ aws.Context, _ *servicequotas.GetAWSDefaultServiceQuotaInput, _ ...request.Option) (*servicequotas.GetAWSDefaultServiceQuotaOutput, error) {
+	return &servicequotas.GetAWSDefaultServiceQuotaOutput{Quota: &servicequotas.ServiceQuota{Value: aws.Float64(f.value)}}, nil
+}
+
+type fakeASGClient struct {
+	autoscalingiface.AutoScalingAPI
+	groups []*autoscaling.Group
+}
+
+func (f *fakeASGClient) DescribeAutoScalingGroupsPagesWithContext(_ aws.Context, _ *autoscaling.DescribeAutoScalingGroupsInput, fn func(*autoscaling.DescribeAutoScalingGroupsOutput, bool) bool, _ ...request.Option) error {
+	fn(&autoscaling.DescribeAutoScalingGroupsOutput{AutoScalingGroups: f.groups}, true)
+	return nil
+}
+
+type fakeEC2Client struct {
+	ec2iface.EC2API
+	templates []*ec2.LaunchTemplate
+}
+
+func (f *fakeEC2Client) DescribeLaunchTemplatesPagesWithContext(_ aws.Context, _ *ec2.DescribeLaunchTemplatesInput, fn func(*ec2.DescribeLaunchTemplatesOutput, bool) bool, _ ...request.Option) error {
+	fn(&ec2.DescribeLaunchTemplatesOutput{LaunchTemplates: f.templates}, true)
+	return nil
+}
+
+func TestReconcileQuotas(t *testing.T) {
+	managedTag := &autoscaling.TagDescription{Key: aws.String(infrav1.ClusterTagKey("test-cluster")), Value: aws.String("owned")}
+	groups := []*autoscaling.Group{
+		{AutoScalingGroupName: aws.String("managed"), Tags: []*autoscaling.TagDescription{managedTag}},
+		{AutoScalingGroupName: aws.String("other")},
+	}
+	templates := []*ec2.LaunchTemplate{
+		{LaunchTemplateName: aws.String("managed"), Tags: []*ec2.Tag{{Key: aws.String(infrav1.ClusterTagKey("test-cluster")), Value: aws.String("owned")}}},
+	}
+
+	tests := []struct {
+		name          string
+		applied       bool
+		pending       map[Resource]int
+		wantStatus    corev1.ConditionStatus
+		wantReason    string
+		wantInMessage string
+	}{
+		{
+			name:       "pending resources fit in the applied quota",
+			applied:    true,
+			pending:    map[Resource]int{ResourceAutoScalingGroups: 1, ResourceLaunchTemplates: 1},
+			wantStatus: corev1.ConditionTrue,
+		},
+		{
+			name:          "pending resources exceed the default quota",
+			pending:       map[Resource]int{ResourceAutoScalingGroups: 2},
+			wantStatus:    corev1.ConditionFalse,
+			wantReason:    infrav1.AccountQuotaExceededReason,
+			wantInMessage: "quotas of account 123456789012 in region us-east-1: 2 AutoScalingGroups for a quota of 3 with 2 used",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			stsMock := mock_stsiface.NewMockSTSAPI(mockCtrl)
+			stsMock.EXPECT().GetCallerIdentityWithContext(context.TODO(), gomock.Any()).Return(&sts.GetCallerIdentityOutput{
+				Account: aws.String("123456789012"),
+			}, nil).Times(2)
+
+			quotasClient := &fakeServiceQuotasClient{applied: tt.applied, value: 3}
+			clusterScope := cloudtest.NewClusterScope(t)
+			s := &Service{
+				scope:               clusterScope,
+				checker:             NewChecker(DefaultCheckInterval),
+				ServiceQuotasClient: quotasClient,
+				ASGClient:           &fakeASGClient{groups: groups},
+				EC2Client:           &fakeEC2Client{templates: templates},
+				STSClient:           stsMock,
+			}
+
+			// The second reconciliation uses the cached usage.
+			for i := 0; i < 2; i++ {
+				g.Expect(s.ReconcileQuotas(tt.pending)).To(Succeed())
+				g.Expect(quotasClient.appliedCalls).To(Equal(1))
+
+				condition := conditions.Get(clusterScope.AWSCluster, infrav1.AccountQuotasCondition)
+				g.Expect(condition).NotTo(BeNil())
+				g.Expect(condition.Status).To(Equal(tt.wantStatus))
+				g.Expect(condition.Reason).To(Equal(tt.wantReason))
+				g.Expect(condition.Message).To(ContainSubstring(tt.wantInMessage))
+			}
+
+			usage := s.checker.results["123456789012/us-east-1"].usage
+			g.Expect(usage[ResourceAutoScalingGroups]).To(Equal(Usage{Quota: 3, Used: 2, Managed: 1}))
+			g.Expect(usage[ResourceLaunchTemplates]).To(Equal(Usage{Quota: launchTemplatesQuota, Used: 1, Managed: 1}))
+		})
+	}
+}
+
+func TestReconcileQuotasCheckFailed(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	stsMock := mock_stsiface.NewMockSTSAPI(mockCtrl)
+	stsMock.EXPECT().GetCallerIdentityWithContext(context.TODO(), gomock.Any()).Return(nil, awserr.New("AccessDenied", "denied", nil))
+
+	clusterScope := cloudtest.NewClusterScope(t)
+	s := &Service{
+		scope:     clusterScope,
+		checker:   NewChecker(DefaultCheckInterval),
+		STSClient: stsMock,
+	}
+
+	g.Expect(s.ReconcileQuotas(nil)).NotTo(Succeed())
+	g.Expect(conditions.GetReason(clusterScope.AWSCluster, infrav1.AccountQuotasCondition)).To(Equal(infrav1.AccountQuotasCheckFailedReason))
+}
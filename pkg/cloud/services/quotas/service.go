@@ -0,0 +1,51 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package quotas provides a way to report the consumption of the account quotas of the resources created
+// by the controllers, and to check that the resources a cluster is waiting for fit in them.
+package quotas
+
+import (
+	"github.com/aws/aws-sdk-go/service/autoscaling/autoscalingiface"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/aws/aws-sdk-go/service/servicequotas/servicequotasiface"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+)
+
+// Service checks the account quotas of a cluster.
+type Service struct {
+	scope               *scope.ClusterScope
+	checker             *Checker
+	ServiceQuotasClient servicequotasiface.ServiceQuotasAPI
+	ASGClient           autoscalingiface.AutoScalingAPI
+	EC2Client           ec2iface.EC2API
+	STSClient           stsiface.STSAPI
+}
+
+// NewService returns a new service given the cluster scope and the checker holding the usage fetched
+// for other clusters.
+func NewService(clusterScope *scope.ClusterScope, checker *Checker) *Service {
+	return &Service{
+		scope:               clusterScope,
+		checker:             checker,
+		ServiceQuotasClient: scope.NewServiceQuotasClient(clusterScope, clusterScope, clusterScope, clusterScope.InfraCluster()),
+		ASGClient:           scope.NewASGClient(clusterScope, clusterScope, clusterScope, clusterScope.InfraCluster()),
+		EC2Client:           scope.NewEC2Client(clusterScope, clusterScope, clusterScope, clusterScope.InfraCluster()),
+		STSClient:           scope.NewSTSClient(clusterScope, clusterScope, clusterScope, clusterScope.InfraCluster()),
+	}
+}
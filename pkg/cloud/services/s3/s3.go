@@ -303,11 +303,12 @@ func (s *Service) tagBucket(bucketName string) error {
 	}
 
 	tags := infrav1.Build(infrav1.BuildParams{
-		ClusterName: s.scope.Name(),
-		Lifecycle:   infrav1.ResourceLifecycleOwned,
-		Name:        nil,
-		Role:        aws.String("node"),
-		Additional:  s.scope.AdditionalTags(),
+		ClusterName:        s.scope.Name(),
+		OwnershipTagPrefix: s.scope.OwnershipTagPrefix(),
+		Lifecycle:          infrav1.ResourceLifecycleOwned,
+		Name:               nil,
+		Role:               aws.String("node"),
+		Additional:         s.scope.AdditionalTags(),
 	})
 
 	for key, value := range tags {
@@ -0,0 +1,129 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package securitygroup
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+)
+
+// cniPresetVersions lists the rules of the CNI presets, the first entry being version 1. The rules of a released
+// version must never change: a new version is appended instead, so that existing clusters keep the rules of the
+// version recorded in their CNIPresetAnnotation when the controller is upgraded.
+var cniPresetVersions = []map[infrav1.CNIPreset]infrav1.CNIIngressRules{
+	{
+		infrav1.CNIPresetCilium: {
+			{Description: "VXLAN overlay (cilium)", Protocol: infrav1.SecurityGroupProtocolUDP, FromPort: 8472, ToPort: 8472},
+			{Description: "health checks (cilium)", Protocol: infrav1.SecurityGroupProtocolTCP, FromPort: 4240, ToPort: 4240},
+			{Description: "hubble server (cilium)", Protocol: infrav1.SecurityGroupProtocolTCP, FromPort: 4244, ToPort: 4244},
+			{Description: "ICMP health checks (cilium)", Protocol: infrav1.SecurityGroupProtocolICMP, FromPort: 8, ToPort: 0},
+		},
+		infrav1.CNIPresetCalico: {
+			{Description: "bgp (calico)", Protocol: infrav1.SecurityGroupProtocolTCP, FromPort: 179, ToPort: 179},
+			{Description: "IP-in-IP (calico)", Protocol: infrav1.SecurityGroupProtocolIPinIP, FromPort: -1, ToPort: 65535},
+			{Description: "VXLAN overlay (calico)", Protocol: infrav1.SecurityGroupProtocolUDP, FromPort: 4789, ToPort: 4789},
+			{Description: "typha (calico)", Protocol: infrav1.SecurityGroupProtocolTCP, FromPort: 5473, ToPort: 5473},
+		},
+		infrav1.CNIPresetFlannel: {
+			{Description: "VXLAN overlay (flannel)", Protocol: infrav1.SecurityGroupProtocolUDP, FromPort: 8472, ToPort: 8472},
+		},
+		infrav1.CNIPresetNone: {},
+	},
+}
+
+// latestCNIPresetVersion is the version of the CNI preset rules applied to new clusters.
+var latestCNIPresetVersion = len(cniPresetVersions)
+
+// cniPresetAnnotationValue formats the value of the CNIPresetAnnotation.
+func cniPresetAnnotationValue(preset infrav1.CNIPreset, version int) string {
+	return fmt.Sprintf("%s/v%d", preset, version)
+}
+
+// cniPresetVersion returns the version of the preset rules of the cluster: the version recorded in its
+// CNIPresetAnnotation for the same preset, or the latest version when the annotation is missing, is for
+// another preset, or records an unknown version.
+func (s *Service) cniPresetVersion(preset infrav1.CNIPreset) int {
+	value, ok := s.scope.InfraCluster().GetAnnotations()[infrav1.CNIPresetAnnotation]
+	if !ok {
+		return latestCNIPresetVersion
+	}
+
+	recordedPreset, recordedVersion, found := strings.Cut(value, "/v")
+	if !found || infrav1.CNIPreset(recordedPreset) != preset {
+		return latestCNIPresetVersion
+	}
+	version, err := strconv.Atoi(recordedVersion)
+	if err != nil || version < 1 || version > latestCNIPresetVersion {
+		s.scope.Info("Ignoring invalid CNI preset annotation", "annotation", infrav1.CNIPresetAnnotation, "value", value)
+		return latestCNIPresetVersion
+	}
+	return version
+}
+
+// cniIngressRules returns the rules of the CNI preset of the cluster followed by the CNIIngressRules of the spec.
+// The preset rules covering the same protocol and ports as a rule of the spec are left out.
+func (s *Service) cniIngressRules() infrav1.CNIIngressRules {
+	specRules := s.scope.CNIIngressRules()
+	preset := s.scope.CNIPreset()
+	if preset == "" {
+		return specRules
+	}
+
+	rules := infrav1.CNIIngressRules{}
+	for _, presetRule := range cniPresetVersions[s.cniPresetVersion(preset)-1][preset] {
+		covered := false
+		for _, specRule := range specRules {
+			if specRule.Protocol == presetRule.Protocol && specRule.FromPort == presetRule.FromPort && specRule.ToPort == presetRule.ToPort {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			rules = append(rules, presetRule)
+		}
+	}
+	return append(rules, specRules...)
+}
+
+// recordCNIPreset records the CNI preset and the version of its rules applied to the security groups in the
+// CNIPresetAnnotation of the cluster, or removes the annotation when no preset is set.
+func (s *Service) recordCNIPreset() {
+	cluster := s.scope.InfraCluster()
+	annotations := cluster.GetAnnotations()
+
+	preset := s.scope.CNIPreset()
+	if preset == "" {
+		if _, ok := annotations[infrav1.CNIPresetAnnotation]; ok {
+			delete(annotations, infrav1.CNIPresetAnnotation)
+			cluster.SetAnnotations(annotations)
+		}
+		return
+	}
+
+	value := cniPresetAnnotationValue(preset, s.cniPresetVersion(preset))
+	if annotations[infrav1.CNIPresetAnnotation] == value {
+		return
+	}
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[infrav1.CNIPresetAnnotation] = value
+	cluster.SetAnnotations(annotations)
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package securitygroup
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestCNIIngressRules(t *testing.T) {
+	hubble := infrav1.CNIIngressRule{Description: "hubble relay", Protocol: infrav1.SecurityGroupProtocolTCP, FromPort: 4245, ToPort: 4245}
+	vxlan := infrav1.CNIIngressRule{Description: "vxlan", Protocol: infrav1.SecurityGroupProtocolUDP, FromPort: 8472, ToPort: 8472}
+
+	testCases := []struct {
+		name               string
+		cni                *infrav1.CNISpec
+		annotation         string
+		expectedRules      infrav1.CNIIngressRules
+		expectedAnnotation string
+	}{
+		{
+			name:          "no preset",
+			cni:           &infrav1.CNISpec{CNIIngressRules: infrav1.CNIIngressRules{hubble}},
+			annotation:    "cilium/v1",
+			expectedRules: infrav1.CNIIngressRules{hubble},
+		},
+		{
+			name: "preset merged with the rules of the spec",
+			cni:  &infrav1.CNISpec{Preset: infrav1.CNIPresetCilium, CNIIngressRules: infrav1.CNIIngressRules{vxlan, hubble}},
+			expectedRules: infrav1.CNIIngressRules{
+				cniPresetVersions[0][infrav1.CNIPresetCilium][1],
+				cniPresetVersions[0][infrav1.CNIPresetCilium][2],
+				cniPresetVersions[0][infrav1.CNIPresetCilium][3],
+				vxlan,
+				hubble,
+			},
+			expectedAnnotation: "cilium/v1",
+		},
+		{
+			name:               "preset without rules",
+			cni:                &infrav1.CNISpec{Preset: infrav1.CNIPresetNone},
+			expectedRules:      infrav1.CNIIngressRules{},
+			expectedAnnotation: "none/v1",
+		},
+		{
+			name:               "latest version after a change of preset",
+			cni:                &infrav1.CNISpec{Preset: infrav1.CNIPresetFlannel},
+			annotation:         "calico/v1",
+			expectedRules:      cniPresetVersions[0][infrav1.CNIPresetFlannel],
+			expectedAnnotation: "flannel/v1",
+		},
+		{
+			name:               "latest version when the annotation is invalid",
+			cni:                &infrav1.CNISpec{Preset: infrav1.CNIPresetFlannel},
+			annotation:         "flannel/v9",
+			expectedRules:      cniPresetVersions[0][infrav1.CNIPresetFlannel],
+			expectedAnnotation: "flannel/v1",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			s := NewService(newCNIPresetClusterScope(t, tc.cni, tc.annotation), testSecurityGroupRoles)
+			g.Expect(s.cniIngressRules()).To(Equal(tc.expectedRules))
+
+			s.recordCNIPreset()
+			annotation, ok := s.scope.InfraCluster().GetAnnotations()[infrav1.CNIPresetAnnotation]
+			g.Expect(ok).To(Equal(tc.expectedAnnotation != ""))
+			g.Expect(annotation).To(Equal(tc.expectedAnnotation))
+		})
+	}
+}
+
+func TestCNIPresetVersionIsKept(t *testing.T) {
+	g := NewWithT(t)
+
+	// Release a second version of the presets changing the rules of Flannel.
+	flannelV2 := infrav1.CNIIngressRules{{Description: "wireguard (flannel)", Protocol: infrav1.SecurityGroupProtocolUDP, FromPort: 51820, ToPort: 51820}}
+	defer func(versions []map[infrav1.CNIPreset]infrav1.CNIIngressRules, latest int) {
+		cniPresetVersions, latestCNIPresetVersion = versions, latest
+	}(cniPresetVersions, latestCNIPresetVersion)
+	cniPresetVersions = append(cniPresetVersions, map[infrav1.CNIPreset]infrav1.CNIIngressRules{infrav1.CNIPresetFlannel: flannelV2})
+	latestCNIPresetVersion = len(cniPresetVersions)
+
+	cni := &infrav1.CNISpec{Preset: infrav1.CNIPresetFlannel}
+
+	// The existing cluster keeps the rules of the version it was reconciled with.
+	s := NewService(newCNIPresetClusterScope(t, cni, "flannel/v1"), testSecurityGroupRoles)
+	g.Expect(s.cniIngressRules()).To(Equal(cniPresetVersions[0][infrav1.CNIPresetFlannel]))
+	s.recordCNIPreset()
+	g.Expect(s.scope.InfraCluster().GetAnnotations()).To(HaveKeyWithValue(infrav1.CNIPresetAnnotation, "flannel/v1"))
+
+	// New clusters, and clusters whose annotation is removed, get the latest version.
+	s = NewService(newCNIPresetClusterScope(t, cni, ""), testSecurityGroupRoles)
+	g.Expect(s.cniIngressRules()).To(Equal(flannelV2))
+	s.recordCNIPreset()
+	g.Expect(s.scope.InfraCluster().GetAnnotations()).To(HaveKeyWithValue(infrav1.CNIPresetAnnotation, "flannel/v2"))
+}
+
+func newCNIPresetClusterScope(t *testing.T, cni *infrav1.CNISpec, annotation string) *scope.ClusterScope {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+
+	awsCluster := &infrav1.AWSCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: infrav1.AWSClusterSpec{
+			NetworkSpec: infrav1.NetworkSpec{CNI: cni},
+		},
+	}
+	if annotation != "" {
+		awsCluster.Annotations = map[string]string{infrav1.CNIPresetAnnotation: annotation}
+	}
+
+	cs, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+		},
+		AWSCluster: awsCluster,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test context: %v", err)
+	}
+	return cs
+}
@@ -0,0 +1,191 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package securitygroup
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/filter"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/tags"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+)
+
+// ReconcileDedicatedSecurityGroup creates the security group owned by a machine pool when it is enabled,
+// and reconciles its ingress rules. A security group which is no longer enabled is kept until the machine
+// pool is deleted, as instances may still be attached to it.
+func (s *Service) ReconcileDedicatedSecurityGroup(poolScope scope.DedicatedSecurityGroupScope) error {
+	dedicatedSG := poolScope.GetDedicatedSecurityGroup()
+	if dedicatedSG == nil || !dedicatedSG.Enabled {
+		return nil
+	}
+
+	s.scope.Debug("Reconciling dedicated security group", "machine-pool", poolScope.Name())
+
+	sg, err := s.describeMachinePoolSecurityGroup(poolScope.Name())
+	if err != nil {
+		return err
+	}
+
+	if sg == nil {
+		sg, err = s.createMachinePoolSecurityGroup(poolScope.Name())
+		if err != nil {
+			return err
+		}
+	}
+	poolScope.SetDedicatedSecurityGroupIDStatus(sg.ID)
+
+	specRules, err := s.getMachinePoolSecurityGroupIngressRules(sg.ID, dedicatedSG.IngressRules)
+	if err != nil {
+		return err
+	}
+
+	return s.reconcileSecurityGroupIngressRules(*sg, specRules)
+}
+
+// DeleteDedicatedSecurityGroup deletes the security group owned by a machine pool. The instances of the
+// machine pool must be gone, as a security group can't be deleted while in use.
+func (s *Service) DeleteDedicatedSecurityGroup(poolScope scope.DedicatedSecurityGroupScope) error {
+	sg, err := s.describeMachinePoolSecurityGroup(poolScope.Name())
+	if err != nil {
+		return err
+	}
+
+	if sg != nil {
+		// Revoke the rules first, so that the security groups of machine pools referencing each other
+		// can be deleted.
+		if err := s.revokeAllSecurityGroupIngressRules(sg.ID); awserrors.IsIgnorableSecurityGroupError(err) != nil { //nolint:gocritic
+			return err
+		}
+
+		if err := s.deleteSecurityGroup(sg, "machine pool dedicated"); err != nil {
+			return err
+		}
+	}
+
+	poolScope.SetDedicatedSecurityGroupIDStatus("")
+	return nil
+}
+
+// describeMachinePoolSecurityGroup returns the security group owned by the given machine pool of the cluster,
+// or nil if it doesn't exist.
+func (s *Service) describeMachinePoolSecurityGroup(poolName string) (*infrav1.SecurityGroup, error) {
+	input := &ec2.DescribeSecurityGroupsInput{
+		Filters: []*ec2.Filter{
+			filter.EC2.VPC(s.scope.VPC().ID),
+			filter.EC2.ClusterOwned(s.scope.Name(), s.scope.OwnershipTagPrefix()),
+			filter.EC2.MachinePool(poolName),
+		},
+	}
+
+	out, err := s.EC2Client.DescribeSecurityGroupsWithContext(context.TODO(), input)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to describe security group of machine pool %q in vpc %q", poolName, s.scope.VPC().ID)
+	}
+
+	if len(out.SecurityGroups) == 0 {
+		return nil, nil
+	}
+
+	sg := s.ec2SecurityGroupToSecurityGroup(out.SecurityGroups[0])
+	return &sg, nil
+}
+
+func (s *Service) createMachinePoolSecurityGroup(poolName string) (*infrav1.SecurityGroup, error) {
+	name := s.getMachinePoolSecurityGroupName(poolName)
+	out, err := s.EC2Client.CreateSecurityGroupWithContext(context.TODO(), &ec2.CreateSecurityGroupInput{
+		VpcId:       aws.String(s.scope.VPC().ID),
+		GroupName:   aws.String(name),
+		Description: aws.String(fmt.Sprintf("Kubernetes cluster %s: machine pool %s", s.scope.Name(), poolName)),
+		TagSpecifications: []*ec2.TagSpecification{
+			tags.BuildParamsToTagSpecification(ec2.ResourceTypeSecurityGroup, s.getMachinePoolSecurityGroupTagParams(name, services.TemporaryResourceID, poolName)),
+		},
+	})
+	if err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedCreateSecurityGroup", "Failed to create managed SecurityGroup for machine pool %q: %v", poolName, err)
+		return nil, errors.Wrapf(err, "failed to create security group for machine pool %q in vpc %q", poolName, s.scope.VPC().ID)
+	}
+
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulCreateSecurityGroup", "Created managed SecurityGroup %q for machine pool %q", aws.StringValue(out.GroupId), poolName)
+	s.scope.Info("Created security group for machine pool", "security-group", aws.StringValue(out.GroupId), "machine-pool", poolName)
+
+	return &infrav1.SecurityGroup{
+		ID:   aws.StringValue(out.GroupId),
+		Name: name,
+	}, nil
+}
+
+// getMachinePoolSecurityGroupIngressRules returns the rules of a machine pool security group: the traffic from the
+// control plane, the nodes and the machine pool itself is always allowed, on top of the rules of the spec.
+// The machine pools the rules of the spec refer to are resolved to their own security group.
+func (s *Service) getMachinePoolSecurityGroupIngressRules(id string, specRules []expinfrav1.DedicatedSecurityGroupIngressRule) (infrav1.IngressRules, error) {
+	clusterSourceIDs := []string{id}
+	for _, role := range []infrav1.SecurityGroupRole{infrav1.SecurityGroupControlPlane, infrav1.SecurityGroupNode} {
+		if sg, ok := s.scope.SecurityGroups()[role]; ok && sg.ID != "" {
+			clusterSourceIDs = append(clusterSourceIDs, sg.ID)
+		}
+	}
+
+	ingressRules := make([]infrav1.IngressRule, 0, len(specRules))
+	for _, specRule := range specRules {
+		rule := *specRule.IngressRule.DeepCopy()
+		for _, poolName := range specRule.SourceMachinePools {
+			sg, err := s.describeMachinePoolSecurityGroup(poolName)
+			if err != nil {
+				return nil, err
+			}
+			if sg == nil {
+				return nil, awserrors.NewFailedDependency(fmt.Sprintf("security group of machine pool %q not available", poolName))
+			}
+			rule.SourceSecurityGroupIDs = append(rule.SourceSecurityGroupIDs, sg.ID)
+		}
+		ingressRules = append(ingressRules, rule)
+	}
+
+	additionalRules, err := s.processIngressRulesSGs(ingressRules)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := infrav1.IngressRules{
+		{
+			Description:            "Cluster traffic",
+			Protocol:               infrav1.SecurityGroupProtocolAll,
+			SourceSecurityGroupIDs: clusterSourceIDs,
+		},
+	}
+	return append(rules, additionalRules...), nil
+}
+
+func (s *Service) getMachinePoolSecurityGroupName(poolName string) string {
+	return fmt.Sprintf("%s-machinepool-%s", s.scope.Name(), poolName)
+}
+
+func (s *Service) getMachinePoolSecurityGroupTagParams(name, id, poolName string) infrav1.BuildParams {
+	params := s.getSecurityGroupTagParams(name, id, infrav1.SecurityGroupNode)
+	params.Additional[infrav1.NameAWSMachinePool] = poolName
+	return params
+}
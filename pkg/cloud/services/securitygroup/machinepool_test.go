@@ -0,0 +1,350 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package securitygroup
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/filter"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+type fakeDedicatedSecurityGroupScope struct {
+	name        string
+	dedicatedSG *expinfrav1.DedicatedSecurityGroup
+	id          string
+}
+
+func (f *fakeDedicatedSecurityGroupScope) Name() string {
+	return f.name
+}
+
+func (f *fakeDedicatedSecurityGroupScope) GetDedicatedSecurityGroup() *expinfrav1.DedicatedSecurityGroup {
+	return f.dedicatedSG
+}
+
+func (f *fakeDedicatedSecurityGroupScope) GetDedicatedSecurityGroupIDStatus() string {
+	return f.id
+}
+
+func (f *fakeDedicatedSecurityGroupScope) SetDedicatedSecurityGroupIDStatus(id string) {
+	f.id = id
+}
+
+func describeMachinePoolSecurityGroupInput(poolName string) *ec2.DescribeSecurityGroupsInput {
+	return &ec2.DescribeSecurityGroupsInput{
+		Filters: []*ec2.Filter{
+			filter.EC2.VPC("vpc-pools"),
+			filter.EC2.ClusterOwned("test-cluster", ""),
+			filter.EC2.MachinePool(poolName),
+		},
+	}
+}
+
+func TestReconcileDedicatedSecurityGroup(t *testing.T) {
+	clusterTrafficPermission := &ec2.IpPermission{
+		IpProtocol: aws.String("-1"),
+		UserIdGroupPairs: []*ec2.UserIdGroupPair{
+			{GroupId: aws.String("sg-pool"), Description: aws.String("Cluster traffic")},
+			{GroupId: aws.String("sg-cp"), Description: aws.String("Cluster traffic")},
+			{GroupId: aws.String("sg-node"), Description: aws.String("Cluster traffic")},
+		},
+	}
+	ingressRule := infrav1.IngressRule{
+		Description: "ingress",
+		Protocol:    infrav1.SecurityGroupProtocolTCP,
+		FromPort:    443,
+		ToPort:      443,
+	}
+
+	testCases := []struct {
+		name        string
+		dedicatedSG *expinfrav1.DedicatedSecurityGroup
+		expect      func(m *mocks.MockEC2APIMockRecorder)
+		wantID      string
+		wantErr     bool
+	}{
+		{
+			name:        "disabled dedicated security group is ignored",
+			dedicatedSG: &expinfrav1.DedicatedSecurityGroup{Enabled: false},
+			expect:      func(m *mocks.MockEC2APIMockRecorder) {},
+		},
+		{
+			name:        "creates the dedicated security group and authorizes the cluster traffic",
+			dedicatedSG: &expinfrav1.DedicatedSecurityGroup{Enabled: true},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeSecurityGroupsWithContext(context.TODO(), gomock.Eq(describeMachinePoolSecurityGroupInput("pool"))).
+					Return(&ec2.DescribeSecurityGroupsOutput{}, nil)
+				m.CreateSecurityGroupWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.CreateSecurityGroupInput{})).
+					DoAndReturn(func(_ context.Context, input *ec2.CreateSecurityGroupInput, _ ...interface{}) (*ec2.CreateSecurityGroupOutput, error) {
+						g := NewWithT(t)
+						g.Expect(aws.StringValue(input.GroupName)).To(Equal("test-cluster-machinepool-pool"))
+						g.Expect(input.TagSpecifications[0].Tags).To(ContainElement(&ec2.Tag{
+							Key:   aws.String(infrav1.NameAWSMachinePool),
+							Value: aws.String("pool"),
+						}))
+						return &ec2.CreateSecurityGroupOutput{GroupId: aws.String("sg-pool")}, nil
+					})
+				m.AuthorizeSecurityGroupIngressWithContext(context.TODO(), gomock.Eq(&ec2.AuthorizeSecurityGroupIngressInput{
+					GroupId: aws.String("sg-pool"),
+					IpPermissions: []*ec2.IpPermission{
+						{IpProtocol: aws.String("-1"), UserIdGroupPairs: clusterTrafficPermission.UserIdGroupPairs[:1]},
+						{IpProtocol: aws.String("-1"), UserIdGroupPairs: clusterTrafficPermission.UserIdGroupPairs[1:2]},
+						{IpProtocol: aws.String("-1"), UserIdGroupPairs: clusterTrafficPermission.UserIdGroupPairs[2:]},
+					},
+				})).Return(&ec2.AuthorizeSecurityGroupIngressOutput{}, nil)
+			},
+			wantID: "sg-pool",
+		},
+		{
+			name: "resolves machine pools and roles referenced by the rules",
+			dedicatedSG: &expinfrav1.DedicatedSecurityGroup{
+				Enabled: true,
+				IngressRules: []expinfrav1.DedicatedSecurityGroupIngressRule{{
+					IngressRule: func() infrav1.IngressRule {
+						rule := ingressRule
+						rule.SourceSecurityGroupRoles = []infrav1.SecurityGroupRole{infrav1.SecurityGroupControlPlane}
+						return rule
+					}(),
+					SourceMachinePools: []string{"ingress-pool"},
+				}},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeSecurityGroupsWithContext(context.TODO(), gomock.Eq(describeMachinePoolSecurityGroupInput("pool"))).
+					Return(&ec2.DescribeSecurityGroupsOutput{SecurityGroups: []*ec2.SecurityGroup{{
+						GroupId:       aws.String("sg-pool"),
+						GroupName:     aws.String("test-cluster-machinepool-pool"),
+						IpPermissions: []*ec2.IpPermission{clusterTrafficPermission},
+					}}}, nil)
+				m.DescribeSecurityGroupsWithContext(context.TODO(), gomock.Eq(describeMachinePoolSecurityGroupInput("ingress-pool"))).
+					Return(&ec2.DescribeSecurityGroupsOutput{SecurityGroups: []*ec2.SecurityGroup{{
+						GroupId:   aws.String("sg-ingress-pool"),
+						GroupName: aws.String("test-cluster-machinepool-ingress-pool"),
+					}}}, nil)
+				m.AuthorizeSecurityGroupIngressWithContext(context.TODO(), gomock.Eq(&ec2.AuthorizeSecurityGroupIngressInput{
+					GroupId: aws.String("sg-pool"),
+					IpPermissions: []*ec2.IpPermission{
+						{
+							IpProtocol:       aws.String("tcp"),
+							FromPort:         aws.Int64(443),
+							ToPort:           aws.Int64(443),
+							UserIdGroupPairs: []*ec2.UserIdGroupPair{{GroupId: aws.String("sg-cp"), Description: aws.String("ingress")}},
+						},
+						{
+							IpProtocol:       aws.String("tcp"),
+							FromPort:         aws.Int64(443),
+							ToPort:           aws.Int64(443),
+							UserIdGroupPairs: []*ec2.UserIdGroupPair{{GroupId: aws.String("sg-ingress-pool"), Description: aws.String("ingress")}},
+						},
+					},
+				})).Return(&ec2.AuthorizeSecurityGroupIngressOutput{}, nil)
+			},
+			wantID: "sg-pool",
+		},
+		{
+			name: "fails while a referenced machine pool has no security group",
+			dedicatedSG: &expinfrav1.DedicatedSecurityGroup{
+				Enabled: true,
+				IngressRules: []expinfrav1.DedicatedSecurityGroupIngressRule{{
+					IngressRule:        ingressRule,
+					SourceMachinePools: []string{"ingress-pool"},
+				}},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeSecurityGroupsWithContext(context.TODO(), gomock.Eq(describeMachinePoolSecurityGroupInput("pool"))).
+					Return(&ec2.DescribeSecurityGroupsOutput{SecurityGroups: []*ec2.SecurityGroup{{
+						GroupId:       aws.String("sg-pool"),
+						GroupName:     aws.String("test-cluster-machinepool-pool"),
+						IpPermissions: []*ec2.IpPermission{clusterTrafficPermission},
+					}}}, nil)
+				m.DescribeSecurityGroupsWithContext(context.TODO(), gomock.Eq(describeMachinePoolSecurityGroupInput("ingress-pool"))).
+					Return(&ec2.DescribeSecurityGroupsOutput{}, nil)
+			},
+			wantID:  "sg-pool",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+			tc.expect(ec2Mock.EXPECT())
+
+			s := NewService(newMachinePoolTestClusterScope(t), nil)
+			s.EC2Client = ec2Mock
+
+			poolScope := &fakeDedicatedSecurityGroupScope{name: "pool", dedicatedSG: tc.dedicatedSG}
+			err := s.ReconcileDedicatedSecurityGroup(poolScope)
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			g.Expect(poolScope.GetDedicatedSecurityGroupIDStatus()).To(Equal(tc.wantID))
+		})
+	}
+}
+
+func TestDeleteDedicatedSecurityGroup(t *testing.T) {
+	testCases := []struct {
+		name   string
+		expect func(m *mocks.MockEC2APIMockRecorder)
+	}{
+		{
+			name: "deletes the dedicated security group",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeSecurityGroupsWithContext(context.TODO(), gomock.Eq(describeMachinePoolSecurityGroupInput("pool"))).
+					Return(&ec2.DescribeSecurityGroupsOutput{SecurityGroups: []*ec2.SecurityGroup{{
+						GroupId:   aws.String("sg-pool"),
+						GroupName: aws.String("test-cluster-machinepool-pool"),
+					}}}, nil)
+				m.DescribeSecurityGroupsWithContext(context.TODO(), gomock.Eq(&ec2.DescribeSecurityGroupsInput{
+					GroupIds: []*string{aws.String("sg-pool")},
+				})).Return(&ec2.DescribeSecurityGroupsOutput{}, nil)
+				m.DeleteSecurityGroupWithContext(context.TODO(), gomock.Eq(&ec2.DeleteSecurityGroupInput{
+					GroupId: aws.String("sg-pool"),
+				})).Return(&ec2.DeleteSecurityGroupOutput{}, nil)
+			},
+		},
+		{
+			name: "dedicated security group already deleted",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeSecurityGroupsWithContext(context.TODO(), gomock.Eq(describeMachinePoolSecurityGroupInput("pool"))).
+					Return(&ec2.DescribeSecurityGroupsOutput{}, nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+			tc.expect(ec2Mock.EXPECT())
+
+			s := NewService(newMachinePoolTestClusterScope(t), nil)
+			s.EC2Client = ec2Mock
+
+			poolScope := &fakeDedicatedSecurityGroupScope{name: "pool", id: "sg-pool"}
+			g.Expect(s.DeleteDedicatedSecurityGroup(poolScope)).To(Succeed())
+			g.Expect(poolScope.GetDedicatedSecurityGroupIDStatus()).To(BeEmpty())
+		})
+	}
+}
+
+func newMachinePoolTestClusterScope(t *testing.T) *scope.ClusterScope {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+	client := fake.NewClientBuilder().WithScheme(scheme).Build()
+	cs, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Client: client,
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+		},
+		AWSCluster: &infrav1.AWSCluster{
+			Spec: infrav1.AWSClusterSpec{
+				NetworkSpec: infrav1.NetworkSpec{
+					VPC: infrav1.VPCSpec{ID: "vpc-pools"},
+				},
+			},
+			Status: infrav1.AWSClusterStatus{
+				Network: infrav1.NetworkStatus{
+					SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+						infrav1.SecurityGroupControlPlane: {ID: "sg-cp"},
+						infrav1.SecurityGroupNode:         {ID: "sg-node"},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test context: %v", err)
+	}
+	return cs
+}
+
+func TestWithMachinePoolSources(t *testing.T) {
+	rules := infrav1.IngressRules{
+		{
+			Description:            "Kubelet API",
+			Protocol:               infrav1.SecurityGroupProtocolTCP,
+			FromPort:               10250,
+			ToPort:                 10250,
+			SourceSecurityGroupIDs: []string{"sg-controlplane", "sg-node"},
+		},
+		{
+			Description:              "bgp (calico)",
+			Protocol:                 infrav1.SecurityGroupProtocolTCP,
+			FromPort:                 179,
+			ToPort:                   179,
+			SourceSecurityGroupRoles: []infrav1.SecurityGroupRole{infrav1.SecurityGroupNode},
+		},
+		{
+			Description:            "etcd",
+			Protocol:               infrav1.SecurityGroupProtocolTCP,
+			FromPort:               2379,
+			ToPort:                 2379,
+			SourceSecurityGroupIDs: []string{"sg-controlplane"},
+		},
+		{
+			Description: "Node Port Services",
+			Protocol:    infrav1.SecurityGroupProtocolTCP,
+			FromPort:    30000,
+			ToPort:      32767,
+			CidrBlocks:  []string{"0.0.0.0/0"},
+		},
+	}
+
+	t.Run("adds the machine pool security groups as sources of the rules allowing the nodes", func(t *testing.T) {
+		g := NewWithT(t)
+
+		got := withMachinePoolSources(rules, "sg-node", []string{"sg-pool-b", "sg-pool-a"})
+		g.Expect(got[0].SourceSecurityGroupIDs).To(Equal([]string{"sg-controlplane", "sg-node", "sg-pool-a", "sg-pool-b"}))
+		g.Expect(got[1].SourceSecurityGroupIDs).To(Equal([]string{"sg-pool-a", "sg-pool-b"}))
+		g.Expect(got[2].SourceSecurityGroupIDs).To(Equal([]string{"sg-controlplane"}))
+		g.Expect(got[3].SourceSecurityGroupIDs).To(BeEmpty())
+		// The rules passed in are left untouched.
+		g.Expect(rules[0].SourceSecurityGroupIDs).To(Equal([]string{"sg-controlplane", "sg-node"}))
+	})
+
+	t.Run("keeps the rules without machine pool security groups", func(t *testing.T) {
+		g := NewWithT(t)
+
+		g.Expect(withMachinePoolSources(rules, "sg-node", nil)).To(Equal(rules))
+	})
+}
@@ -19,6 +19,7 @@ package securitygroup
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -79,7 +80,7 @@ func (s *Service) ReconcileSecurityGroups() error {
 
 	// Security group overrides should not be specified for a managed VPC
 	// because VPC id should be provided during security group creation
-	if securityGroupOverrides != nil && s.scope.VPC().IsManaged(s.scope.Name()) {
+	if securityGroupOverrides != nil && s.scope.VPC().IsManaged(s.scope.Name(), s.scope.OwnershipTagPrefixes()...) {
 		return errors.Errorf("security group overrides provided for managed vpc %q", s.scope.Name())
 	}
 	sgs, err := s.describeSecurityGroupsByName()
@@ -87,6 +88,15 @@ func (s *Service) ReconcileSecurityGroups() error {
 		return err
 	}
 
+	// The instances of machine pools replacing the node security group with their dedicated one aren't attached
+	// to the node security group, so the dedicated security groups are sources of the rules allowing the nodes.
+	machinePoolSGIDs := []string{}
+	for _, sg := range sgs {
+		if sg.Tags[infrav1.NameAWSMachinePool] != "" {
+			machinePoolSGIDs = append(machinePoolSGIDs, sg.ID)
+		}
+	}
+
 	// Add security group overrides to known security group map
 	for _, securityGroupOverride := range securityGroupOverrides {
 		sg := s.ec2SecurityGroupToSecurityGroup(securityGroupOverride)
@@ -132,10 +142,16 @@ func (s *Service) ReconcileSecurityGroups() error {
 			// Make sure tags are up to date.
 			if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
 				buildParams := s.getSecurityGroupTagParams(existing.Name, existing.ID, role)
+				s.scope.ResourceNaming().KeepName(&buildParams, existing.Tags)
 				tagsBuilder := tags.New(&buildParams, tags.WithEC2(s.EC2Client))
 				if err := tagsBuilder.Ensure(existing.Tags); err != nil {
 					return false, err
 				}
+				if role == infrav1.SecurityGroupLB {
+					if err := tags.RemoveLegacyClusterTag(s.EC2Client, s.scope.LegacyClusterTag(), s.scope.Name(), existing.ID, existing.Tags); err != nil {
+						return false, err
+					}
+				}
 				return true, nil
 			}, awserrors.GroupNotFound); err != nil {
 				return errors.Wrapf(err, "failed to ensure tags on security group %q", existing.ID)
@@ -149,7 +165,7 @@ func (s *Service) ReconcileSecurityGroups() error {
 		sg := s.scope.SecurityGroups()[role]
 		s.scope.Debug("second pass security group reconciliation", "group-id", sg.ID, "name", sg.Name, "role", role)
 
-		if s.securityGroupIsAnOverride(sg.ID) {
+		if s.securityGroupIsAnOverride(sg.ID) && !s.scope.ApplyRulesToUnmanagedGroups() {
 			// skip rule/tag reconciliation on security groups that are overrides, assuming they're managed by another process
 			continue
 		}
@@ -158,44 +174,78 @@ func (s *Service) ReconcileSecurityGroups() error {
 			// skip rule reconciliation, as we expect the in-cluster cloud integration to manage them
 			continue
 		}
-		current := sg.IngressRules
 
 		specRules, err := s.getSecurityGroupIngressRules(role)
 		if err != nil {
 			return err
 		}
-		// Duplicate rules with multiple cidr blocks/source security groups so that we are comparing similar sets.
-		want := expandIngressRules(specRules)
+		specRules = withMachinePoolSources(specRules, s.scope.SecurityGroups()[infrav1.SecurityGroupNode].ID, machinePoolSGIDs)
 
-		toRevoke := current.Difference(want)
-		if len(toRevoke) > 0 {
-			if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
-				if err := s.revokeSecurityGroupIngressRules(sg.ID, toRevoke); err != nil {
-					return false, err
-				}
-				return true, nil
-			}, awserrors.GroupNotFound); err != nil {
-				return errors.Wrapf(err, "failed to revoke security group ingress rules for %q", sg.ID)
-			}
+		if err := s.reconcileSecurityGroupIngressRules(sg, specRules); err != nil {
+			return err
+		}
+	}
+	s.recordCNIPreset()
+	conditions.MarkTrue(s.scope.InfraCluster(), infrav1.ClusterSecurityGroupsReadyCondition)
+	return nil
+}
+
+// withMachinePoolSources returns the rules with the given security groups of machine pools added as sources of the
+// rules allowing the traffic from the node security group.
+func withMachinePoolSources(rules infrav1.IngressRules, nodeSGID string, machinePoolSGIDs []string) infrav1.IngressRules {
+	if nodeSGID == "" || len(machinePoolSGIDs) == 0 {
+		return rules
+	}
 
-			s.scope.Debug("Revoked ingress rules from security group", "revoked-ingress-rules", toRevoke, "security-group-id", sg.ID)
+	res := make(infrav1.IngressRules, 0, len(rules))
+	for _, rule := range rules {
+		rule := *rule.DeepCopy()
+		if slices.Contains(rule.SourceSecurityGroupIDs, nodeSGID) || slices.Contains(rule.SourceSecurityGroupRoles, infrav1.SecurityGroupNode) {
+			sourceIDs := sets.New(rule.SourceSecurityGroupIDs...)
+			sourceIDs.Insert(machinePoolSGIDs...)
+			rule.SourceSecurityGroupIDs = sets.List(sourceIDs)
 		}
+		res = append(res, rule)
+	}
+	return res
+}
 
-		toAuthorize := want.Difference(current)
-		if len(toAuthorize) > 0 {
-			if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
-				if err := s.authorizeSecurityGroupIngressRules(sg.ID, toAuthorize); err != nil {
-					return false, err
-				}
-				return true, nil
-			}, awserrors.GroupNotFound); err != nil {
-				return err
+// reconcileSecurityGroupIngressRules revokes the ingress rules of the security group which are not part of
+// the given rules, and authorizes the missing ones.
+func (s *Service) reconcileSecurityGroupIngressRules(sg infrav1.SecurityGroup, specRules infrav1.IngressRules) error {
+	current := sg.IngressRules
+
+	// Duplicate rules with multiple cidr blocks/source security groups so that we are comparing similar sets.
+	want := expandIngressRules(specRules)
+
+	toRevoke := current.Difference(want)
+	if len(toRevoke) > 0 {
+		if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
+			if err := s.revokeSecurityGroupIngressRules(sg.ID, toRevoke); err != nil {
+				return false, err
 			}
+			return true, nil
+		}, awserrors.GroupNotFound); err != nil {
+			return errors.Wrapf(err, "failed to revoke security group ingress rules for %q", sg.ID)
+		}
 
-			s.scope.Debug("Authorized ingress rules in security group", "authorized-ingress-rules", toAuthorize, "security-group-id", sg.ID)
+		s.scope.Debug("Revoked ingress rules from security group", "revoked-ingress-rules", toRevoke, "security-group-id", sg.ID)
+	}
+
+	toAuthorize := want.Difference(current)
+	if len(toAuthorize) > 0 {
+		if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
+			if err := s.authorizeSecurityGroupIngressRules(sg.ID, toAuthorize); err != nil {
+				return false, err
+			}
+			return true, nil
+		}, awserrors.GroupNotFound); err != nil {
+			return err
 		}
+
+		s.scope.Debug("Authorized ingress rules in security group", "authorized-ingress-rules", toAuthorize, "security-group-id", sg.ID)
 	}
-	conditions.MarkTrue(s.scope.InfraCluster(), infrav1.ClusterSecurityGroupsReadyCondition)
+
 	return nil
 }
 
@@ -327,6 +377,11 @@ func (s *Service) DeleteSecurityGroups() error {
 
 	for i := range clusterGroups {
 		sg := clusterGroups[i]
+		if s.securityGroupIsAnOverride(sg.ID) {
+			// never delete security groups that were provided by the user
+			s.scope.Debug("Skipping deletion of security group override", "security-group-id", sg.ID)
+			continue
+		}
 		current := sg.IngressRules
 		if err := s.revokeAllSecurityGroupIngressRules(sg.ID); awserrors.IsIgnorableSecurityGroupError(err) != nil { //nolint:gocritic
 			conditions.MarkFalse(s.scope.InfraCluster(), infrav1.ClusterSecurityGroupsReadyCondition, "DeletingFailed", clusterv1.ConditionSeverityWarning, err.Error())
@@ -369,7 +424,7 @@ func (s *Service) describeClusterOwnedSecurityGroups() ([]infrav1.SecurityGroup,
 	input := &ec2.DescribeSecurityGroupsInput{
 		Filters: []*ec2.Filter{
 			filter.EC2.VPC(s.scope.VPC().ID),
-			filter.EC2.ClusterOwned(s.scope.Name()),
+			filter.EC2.ClusterOwned(s.scope.Name(), s.scope.OwnershipTagPrefix()),
 		},
 	}
 
@@ -393,7 +448,7 @@ func (s *Service) describeSecurityGroupsByName() (map[string]infrav1.SecurityGro
 	input := &ec2.DescribeSecurityGroupsInput{
 		Filters: []*ec2.Filter{
 			filter.EC2.VPC(s.scope.VPC().ID),
-			filter.EC2.Cluster(s.scope.Name()),
+			filter.EC2.Cluster(s.scope.Name(), s.scope.OwnershipTagPrefixes()...),
 		},
 	}
 
@@ -578,8 +633,9 @@ func (s *Service) getSecurityGroupIngressRules(role infrav1.SecurityGroupRole) (
 	// Set source of CNI ingress rules to be control plane and node security groups
 	s.scope.Debug("getting security group ingress rules", "role", role)
 
-	cniRules := make(infrav1.IngressRules, len(s.scope.CNIIngressRules()))
-	for i, r := range s.scope.CNIIngressRules() {
+	cniIngressRules := s.cniIngressRules()
+	cniRules := make(infrav1.IngressRules, len(cniIngressRules))
+	for i, r := range cniIngressRules {
 		cniRules[i] = infrav1.IngressRule{
 			Description: r.Description,
 			Protocol:    r.Protocol,
@@ -634,6 +690,16 @@ func (s *Service) getSecurityGroupIngressRules(role infrav1.SecurityGroupRole) (
 			rules = append(rules, s.defaultSSHIngressRule(s.scope.SecurityGroups()[infrav1.SecurityGroupBastion].ID))
 		}
 
+		// Without a load balancer managed by CAPA, there is no load balancer security group to allow the clients
+		// of the API server from, the ingress rules of the load balancer apply to the control plane instead.
+		if lb := s.scope.ControlPlaneLoadBalancer(); lb != nil && lb.LoadBalancerType == infrav1.LoadBalancerTypeDisabled {
+			lbIngressRules, err := s.processIngressRulesSGs(lb.IngressRules)
+			if err != nil {
+				return nil, err
+			}
+			rules = append(rules, lbIngressRules...)
+		}
+
 		additionalIngressRules, err := s.processIngressRulesSGs(s.scope.AdditionalControlPlaneIngressRules())
 		if err != nil {
 			return nil, err
@@ -775,6 +841,7 @@ func (s *Service) getSecurityGroupTagParams(name, id string, role infrav1.Securi
 	cloudProviderTag := infrav1.ClusterAWSCloudProviderTagKey(s.scope.Name())
 	if role == infrav1.SecurityGroupLB {
 		additional[cloudProviderTag] = string(infrav1.ResourceLifecycleOwned)
+		s.scope.LegacyClusterTag().Apply(additional, s.scope.Name(), infrav1.ResourceLifecycleOwned)
 	} else if _, ok := additional[cloudProviderTag]; ok {
 		// If the cloud provider tag is set in more than one security group,
 		// the CCM will not be able to determine which security group to use;
@@ -784,13 +851,25 @@ func (s *Service) getSecurityGroupTagParams(name, id string, role infrav1.Securi
 			"tag", cloudProviderTag, "name", name, "role", role, "id", id)
 	}
 
+	// The resource naming template only changes the Name tag of the security groups being created, the security
+	// groups are still looked up by their group name.
+	tagName := name
+	if id == services.TemporaryResourceID {
+		tagName = s.scope.ResourceNaming().Name(name, infrav1.ResourceNameData{
+			ClusterName:  s.scope.Name(),
+			ResourceType: infrav1.ResourceTypeSecurityGroup,
+			Role:         string(role),
+		})
+	}
+
 	return infrav1.BuildParams{
-		ClusterName: s.scope.Name(),
-		Lifecycle:   infrav1.ResourceLifecycleOwned,
-		Name:        aws.String(name),
-		ResourceID:  id,
-		Role:        aws.String(string(role)),
-		Additional:  additional,
+		ClusterName:        s.scope.Name(),
+		OwnershipTagPrefix: s.scope.OwnershipTagPrefix(),
+		Lifecycle:          infrav1.ResourceLifecycleOwned,
+		Name:               aws.String(tagName),
+		ResourceID:         id,
+		Role:               aws.String(string(role)),
+		Additional:         additional,
 	}
 }
 
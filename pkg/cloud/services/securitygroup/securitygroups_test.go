@@ -18,8 +18,12 @@ package securitygroup
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -1189,6 +1193,46 @@ func TestControlPlaneSecurityGroupNotOpenToAnyCIDR(t *testing.T) {
 	}
 }
 
+func TestControlPlaneSecurityGroupWithExternalLoadBalancer(t *testing.T) {
+	g := NewWithT(t)
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+	client := fake.NewClientBuilder().WithScheme(scheme).Build()
+	apiRule := infrav1.IngressRule{
+		Description: "Kubernetes API from the GSLB",
+		Protocol:    infrav1.SecurityGroupProtocolTCP,
+		FromPort:    6443,
+		ToPort:      6443,
+		CidrBlocks:  []string{"192.168.0.0/16"},
+	}
+	cs, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Client: client,
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+		},
+		AWSCluster: &infrav1.AWSCluster{
+			Spec: infrav1.AWSClusterSpec{
+				ControlPlaneLoadBalancer: &infrav1.AWSLoadBalancerSpec{
+					LoadBalancerType: infrav1.LoadBalancerTypeDisabled,
+					IngressRules:     []infrav1.IngressRule{apiRule},
+				},
+			},
+		},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	s := NewService(cs, testSecurityGroupRoles)
+	rules, err := s.getSecurityGroupIngressRules(infrav1.SecurityGroupControlPlane)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(rules).To(ContainElement(apiRule))
+
+	// The ingress rules of a load balancer managed by CAPA apply to the load balancer only.
+	cs.AWSCluster.Spec.ControlPlaneLoadBalancer.LoadBalancerType = infrav1.LoadBalancerTypeNLB
+	rules, err = s.getSecurityGroupIngressRules(infrav1.SecurityGroupControlPlane)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(rules).NotTo(ContainElement(apiRule))
+}
+
 func TestAdditionalControlPlaneSecurityGroup(t *testing.T) {
 	scheme := runtime.NewScheme()
 	_ = infrav1.AddToScheme(scheme)
@@ -2438,3 +2482,63 @@ func TestNodePortServicesIngressRules(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcileSecurityGroupsReadOnly(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv("AWS_ACCESS_KEY_ID", "test")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "test")
+
+	var (
+		mu      sync.Mutex
+		actions []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		mu.Lock()
+		actions = append(actions, r.Form.Get("Action"))
+		mu.Unlock()
+		fmt.Fprintf(w, `<%[1]sResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/"></%[1]sResponse>`, r.Form.Get("Action"))
+	}))
+	defer server.Close()
+
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+	awsCluster := &infrav1.AWSCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-cluster",
+			Namespace:   "default",
+			Annotations: map[string]string{infrav1.ReadOnlyAnnotation: "true"},
+		},
+		Spec: infrav1.AWSClusterSpec{
+			Region: "us-east-1",
+			NetworkSpec: infrav1.NetworkSpec{
+				VPC: infrav1.VPCSpec{ID: "vpc-securitygroups"},
+			},
+		},
+	}
+	cs, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: "default"},
+		},
+		AWSCluster: awsCluster,
+		Endpoints: []scope.ServiceEndpoint{
+			{ServiceID: "ec2", URL: server.URL, SigningRegion: "us-east-1"},
+		},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	// The missing security groups aren't created, but the reconciliation carries on and populates the status.
+	s := NewService(cs, testSecurityGroupRoles)
+	g.Expect(s.ReconcileSecurityGroups()).To(Succeed())
+
+	for _, role := range testSecurityGroupRoles {
+		g.Expect(awsCluster.Status.Network.SecurityGroups).To(HaveKey(role))
+		g.Expect(awsCluster.Status.Network.SecurityGroups[role].Name).To(Equal(fmt.Sprintf("test-cluster-%s", role)))
+	}
+	for _, action := range actions {
+		g.Expect(scope.IsMutatingOperation(action)).To(BeFalse(), action)
+	}
+	g.Expect(actions).To(ContainElement("DescribeSecurityGroups"))
+	g.Expect(scope.PendingChanges(awsCluster)).To(ContainElements("EC2:CreateSecurityGroup", "EC2:AuthorizeSecurityGroupIngress"))
+}
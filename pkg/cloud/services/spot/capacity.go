@@ -0,0 +1,204 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spot
+
+import (
+	"context"
+	"sort"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+	"k8s.io/utils/ptr"
+
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+)
+
+// ReconcileCapacityMix records the lifecycle of the instances listed in the status of the machine pool, and
+// counts its spot and on-demand instances. As the ASG doesn't report the lifecycle of its instances, the instances
+// whose lifecycle isn't known yet are described when the pool may run spot instances. The current spot price of
+// the spot instances, whose instance type is reported by the ASG, is reported when the service has a price cache.
+// A warning event is emitted when there are fewer spot instances than expected from the spec.
+func (s *Service) ReconcileCapacityMix(pool *expinfrav1.AWSMachinePool, asg *expinfrav1.AutoScalingGroup) error {
+	spotIDs := map[string]bool{}
+	if mayUseSpot(pool) {
+		// The lifecycle of an instance never changes, so only the new instances are described.
+		var instanceIDs []string
+		for _, instance := range pool.Status.Instances {
+			switch instance.Lifecycle {
+			case expinfrav1.InstanceLifecycleSpot:
+				spotIDs[instance.InstanceID] = true
+			case "":
+				instanceIDs = append(instanceIDs, instance.InstanceID)
+			}
+		}
+
+		if len(instanceIDs) > 0 {
+			instances, err := s.describeInstances(instanceIDs)
+			if err != nil {
+				if awserrors.IsNotFound(err) {
+					// An instance was terminated since it was listed, the pool is reported again on the next reconcile.
+					s.scope.Debug("Skipping capacity mix as an instance wasn't found", "error", err)
+					return nil
+				}
+				return errors.Wrap(err, "failed to describe instances")
+			}
+			for _, instance := range instances {
+				if aws.StringValue(instance.InstanceLifecycle) == ec2.InstanceLifecycleTypeSpot {
+					spotIDs[aws.StringValue(instance.InstanceId)] = true
+				}
+			}
+		}
+	}
+
+	mix := &expinfrav1.CapacityMix{}
+	var spotInstances []expinfrav1.SpotPrice
+	for i := range pool.Status.Instances {
+		instance := &pool.Status.Instances[i]
+		if spotIDs[instance.InstanceID] {
+			instance.Lifecycle = expinfrav1.InstanceLifecycleSpot
+			mix.Spot++
+			spotInstances = append(spotInstances, expinfrav1.SpotPrice{
+				InstanceType:     asg.InstanceDetails[instance.InstanceID].InstanceType,
+				AvailabilityZone: instance.AvailabilityZone,
+			})
+		} else {
+			instance.Lifecycle = expinfrav1.InstanceLifecycleOnDemand
+			mix.OnDemand++
+		}
+	}
+	mix.ExpectedSpot = expectedSpot(pool, mix.Spot+mix.OnDemand)
+
+	previous := pool.Status.CapacityMix
+	if mix.Spot < mix.ExpectedSpot && (previous == nil || *previous != *mix) {
+		record.Warnf(pool, "SpotCapacityBelowExpectation", "%d of %d instances are spot instances, %d are expected",
+			mix.Spot, mix.Spot+mix.OnDemand, mix.ExpectedSpot)
+	}
+	pool.Status.CapacityMix = mix
+
+	if s.prices == nil || len(spotInstances) == 0 {
+		pool.Status.SpotPrice = nil
+		return nil
+	}
+
+	spotPrice, err := s.spotPriceStatus(spotInstances)
+	if err != nil {
+		return errors.Wrap(err, "failed to get spot prices")
+	}
+	pool.Status.SpotPrice = spotPrice
+	return nil
+}
+
+// mayUseSpot returns whether the machine pool may run spot instances. The market options of a launch template
+// managed outside of the controller are unknown.
+func mayUseSpot(pool *expinfrav1.AWSMachinePool) bool {
+	if policy := pool.Spec.MixedInstancesPolicy; policy != nil {
+		return policy.InstancesDistribution != nil && ptr.Deref(policy.InstancesDistribution.OnDemandPercentageAboveBaseCapacity, 100) < 100
+	}
+	return pool.Spec.AWSLaunchTemplate.SpotMarketOptions != nil || pool.Spec.AWSLaunchTemplate.Ref != nil
+}
+
+// expectedSpot returns the number of spot instances expected out of the instances of the machine pool. Auto
+// Scaling rounds the on-demand share of the capacity above the on-demand base capacity up, so the spot share
+// is rounded down.
+func expectedSpot(pool *expinfrav1.AWSMachinePool, instances int32) int32 {
+	policy := pool.Spec.MixedInstancesPolicy
+	if policy == nil {
+		if pool.Spec.AWSLaunchTemplate.SpotMarketOptions != nil {
+			return instances
+		}
+		return 0
+	}
+	if policy.InstancesDistribution == nil {
+		return 0
+	}
+
+	above := int64(instances) - ptr.Deref(policy.InstancesDistribution.OnDemandBaseCapacity, 0)
+	if above <= 0 {
+		return 0
+	}
+	onDemandPercentage := ptr.Deref(policy.InstancesDistribution.OnDemandPercentageAboveBaseCapacity, 100)
+	onDemand := (above*onDemandPercentage + 99) / 100
+	return int32(above - onDemand)
+}
+
+// spotPriceStatus returns the current price of the spot instances, given by their instance type and availability
+// zone, and their average price weighted by the number of instances of each instance type and availability zone.
+func (s *Service) spotPriceStatus(instances []expinfrav1.SpotPrice) (*expinfrav1.SpotPriceStatus, error) {
+	counts := map[expinfrav1.SpotPrice]int32{}
+	instanceTypesByZone := map[string][]string{}
+	for _, key := range instances {
+		if counts[key] == 0 {
+			instanceTypesByZone[key.AvailabilityZone] = append(instanceTypesByZone[key.AvailabilityZone], key.InstanceType)
+		}
+		counts[key]++
+	}
+
+	prices, err := s.getSpotPrices(instanceTypesByZone)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &expinfrav1.SpotPriceStatus{}
+	var total float64
+	var priced int32
+	for key, count := range counts {
+		price, ok := prices[key.AvailabilityZone][key.InstanceType]
+		if !ok {
+			continue
+		}
+		value, err := strconv.ParseFloat(price, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse the spot price %q of %s in %s", price, key.InstanceType, key.AvailabilityZone)
+		}
+		total += value * float64(count)
+		priced += count
+
+		key.HourlyPrice = price
+		key.Instances = count
+		status.Prices = append(status.Prices, key)
+	}
+	sort.Slice(status.Prices, func(i, j int) bool {
+		if status.Prices[i].AvailabilityZone != status.Prices[j].AvailabilityZone {
+			return status.Prices[i].AvailabilityZone < status.Prices[j].AvailabilityZone
+		}
+		return status.Prices[i].InstanceType < status.Prices[j].InstanceType
+	})
+	if priced > 0 {
+		status.WeightedHourlyPrice = strconv.FormatFloat(total/float64(priced), 'f', 6, 64)
+	}
+
+	return status, nil
+}
+
+func (s *Service) describeInstances(instanceIDs []string) ([]*ec2.Instance, error) {
+	input := &ec2.DescribeInstancesInput{
+		InstanceIds: aws.StringSlice(instanceIDs),
+	}
+
+	var instances []*ec2.Instance
+	err := s.EC2Client.DescribeInstancesPagesWithContext(context.TODO(), input, func(out *ec2.DescribeInstancesOutput, _ bool) bool {
+		for _, reservation := range out.Reservations {
+			instances = append(instances, reservation.Instances...)
+		}
+		return true
+	})
+	return instances, err
+}
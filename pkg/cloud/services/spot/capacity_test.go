@@ -0,0 +1,224 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spot
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloudtest"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+)
+
+func TestReconcileCapacityMix(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+	instance := func(id string, spot bool) *ec2.Instance {
+		i := &ec2.Instance{InstanceId: aws.String(id)}
+		if spot {
+			i.InstanceLifecycle = aws.String(ec2.InstanceLifecycleTypeSpot)
+		}
+		return i
+	}
+	ec2Mock.EXPECT().DescribeInstancesPagesWithContext(context.TODO(), &ec2.DescribeInstancesInput{InstanceIds: aws.StringSlice([]string{"i-1", "i-2", "i-3", "i-4"})}, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *ec2.DescribeInstancesInput, fn func(*ec2.DescribeInstancesOutput, bool) bool, _ ...request.Option) error {
+			fn(&ec2.DescribeInstancesOutput{Reservations: []*ec2.Reservation{{Instances: []*ec2.Instance{
+				instance("i-1", false),
+				instance("i-2", false),
+				instance("i-3", true),
+				instance("i-4", true),
+			}}}}, true)
+			return nil
+		}).Times(1)
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	priceHistory := func(zone string, prices map[string]string) {
+		input := &ec2.DescribeSpotPriceHistoryInput{
+			AvailabilityZone:    aws.String(zone),
+			ProductDescriptions: aws.StringSlice([]string{productDescription}),
+			StartTime:           aws.Time(now),
+		}
+		for instanceType := range prices {
+			input.InstanceTypes = append(input.InstanceTypes, aws.String(instanceType))
+		}
+		ec2Mock.EXPECT().DescribeSpotPriceHistoryPagesWithContext(context.TODO(), input, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ *ec2.DescribeSpotPriceHistoryInput, fn func(*ec2.DescribeSpotPriceHistoryOutput, bool) bool, _ ...request.Option) error {
+				out := &ec2.DescribeSpotPriceHistoryOutput{}
+				for instanceType, price := range prices {
+					out.SpotPriceHistory = append(out.SpotPriceHistory,
+						&ec2.SpotPrice{InstanceType: aws.String(instanceType), SpotPrice: aws.String("1.000000"), Timestamp: aws.Time(now.Add(-2 * time.Hour))},
+						&ec2.SpotPrice{InstanceType: aws.String(instanceType), SpotPrice: aws.String(price), Timestamp: aws.Time(now.Add(-time.Hour))},
+					)
+				}
+				fn(out, true)
+				return nil
+			}).Times(1)
+	}
+	priceHistory("us-east-1a", map[string]string{"m5.large": "0.030000"})
+	priceHistory("us-east-1b", map[string]string{"c5.large": "0.060000"})
+
+	prices := NewPriceCache(DefaultPriceCacheTTL)
+	prices.now = func() time.Time { return now }
+	s := NewService(cloudtest.NewClusterScope(t), prices)
+	s.EC2Client = ec2Mock
+
+	pool := &expinfrav1.AWSMachinePool{
+		ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default"},
+		Spec: expinfrav1.AWSMachinePoolSpec{
+			MixedInstancesPolicy: &expinfrav1.MixedInstancesPolicy{
+				InstancesDistribution: &expinfrav1.InstancesDistribution{
+					OnDemandBaseCapacity:                aws.Int64(1),
+					OnDemandPercentageAboveBaseCapacity: aws.Int64(25),
+				},
+			},
+		},
+		Status: expinfrav1.AWSMachinePoolStatus{
+			Instances: []expinfrav1.AWSMachinePoolInstanceStatus{
+				{InstanceID: "i-1", AvailabilityZone: "us-east-1a"},
+				{InstanceID: "i-2", AvailabilityZone: "us-east-1a"},
+				{InstanceID: "i-3", AvailabilityZone: "us-east-1a"},
+				{InstanceID: "i-4", AvailabilityZone: "us-east-1a"},
+				// The lifecycle of the instances already reported is known, they aren't described again.
+				{InstanceID: "i-5", AvailabilityZone: "us-east-1b", Lifecycle: expinfrav1.InstanceLifecycleSpot},
+			},
+		},
+	}
+	asg := &expinfrav1.AutoScalingGroup{
+		InstanceDetails: map[string]expinfrav1.ASGInstanceDetails{
+			"i-1": {InstanceType: "m5.large"},
+			"i-2": {InstanceType: "m5.large"},
+			"i-3": {InstanceType: "m5.large"},
+			"i-4": {InstanceType: "m5.large"},
+			"i-5": {InstanceType: "c5.large"},
+		},
+	}
+
+	g.Expect(s.ReconcileCapacityMix(pool, asg)).To(Succeed())
+	g.Expect(pool.Status.Instances[0].Lifecycle).To(Equal(expinfrav1.InstanceLifecycleOnDemand))
+	g.Expect(pool.Status.Instances[2].Lifecycle).To(Equal(expinfrav1.InstanceLifecycleSpot))
+	// 4 instances above the base capacity, of which 1 on-demand.
+	g.Expect(pool.Status.CapacityMix).To(Equal(&expinfrav1.CapacityMix{Spot: 3, OnDemand: 2, ExpectedSpot: 3}))
+	g.Expect(pool.Status.SpotPrice).To(Equal(&expinfrav1.SpotPriceStatus{
+		WeightedHourlyPrice: "0.040000",
+		Prices: []expinfrav1.SpotPrice{
+			{InstanceType: "m5.large", AvailabilityZone: "us-east-1a", HourlyPrice: "0.030000", Instances: 2},
+			{InstanceType: "c5.large", AvailabilityZone: "us-east-1b", HourlyPrice: "0.060000", Instances: 1},
+		},
+	}))
+
+	// The prices are cached, the instances aren't described again, and fewer spot instances are expected with a
+	// higher on-demand percentage.
+	pool.Spec.MixedInstancesPolicy.InstancesDistribution.OnDemandPercentageAboveBaseCapacity = aws.Int64(50)
+	g.Expect(s.ReconcileCapacityMix(pool, asg)).To(Succeed())
+	g.Expect(pool.Status.CapacityMix).To(Equal(&expinfrav1.CapacityMix{Spot: 3, OnDemand: 2, ExpectedSpot: 2}))
+	g.Expect(pool.Status.SpotPrice.WeightedHourlyPrice).To(Equal("0.040000"))
+}
+
+func TestReconcileCapacityMixWithoutSpot(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+	s := NewService(cloudtest.NewClusterScope(t), NewPriceCache(DefaultPriceCacheTTL))
+	s.EC2Client = ec2Mock
+
+	pool := &expinfrav1.AWSMachinePool{
+		ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default"},
+		Status: expinfrav1.AWSMachinePoolStatus{
+			Instances: []expinfrav1.AWSMachinePoolInstanceStatus{{InstanceID: "i-1"}, {InstanceID: "i-2"}},
+			SpotPrice: &expinfrav1.SpotPriceStatus{WeightedHourlyPrice: "0.030000"},
+		},
+	}
+
+	// The instances aren't described when the pool doesn't request spot instances.
+	g.Expect(s.ReconcileCapacityMix(pool, &expinfrav1.AutoScalingGroup{})).To(Succeed())
+	g.Expect(pool.Status.Instances[0].Lifecycle).To(Equal(expinfrav1.InstanceLifecycleOnDemand))
+	g.Expect(pool.Status.CapacityMix).To(Equal(&expinfrav1.CapacityMix{OnDemand: 2}))
+	g.Expect(pool.Status.SpotPrice).To(BeNil())
+}
+
+func TestExpectedSpot(t *testing.T) {
+	distribution := func(base, percentage int64) *expinfrav1.AWSMachinePool {
+		return &expinfrav1.AWSMachinePool{Spec: expinfrav1.AWSMachinePoolSpec{
+			MixedInstancesPolicy: &expinfrav1.MixedInstancesPolicy{
+				InstancesDistribution: &expinfrav1.InstancesDistribution{
+					OnDemandBaseCapacity:                aws.Int64(base),
+					OnDemandPercentageAboveBaseCapacity: aws.Int64(percentage),
+				},
+			},
+		}}
+	}
+
+	testCases := []struct {
+		name      string
+		pool      *expinfrav1.AWSMachinePool
+		instances int32
+		expected  int32
+	}{
+		{
+			name:      "on-demand pool",
+			pool:      &expinfrav1.AWSMachinePool{},
+			instances: 3,
+			expected:  0,
+		},
+		{
+			name: "spot market options",
+			pool: &expinfrav1.AWSMachinePool{Spec: expinfrav1.AWSMachinePoolSpec{
+				AWSLaunchTemplate: expinfrav1.AWSLaunchTemplate{SpotMarketOptions: &infrav1.SpotMarketOptions{}},
+			}},
+			instances: 3,
+			expected:  3,
+		},
+		{
+			name:      "spot only above the base capacity",
+			pool:      distribution(2, 0),
+			instances: 5,
+			expected:  3,
+		},
+		{
+			name:      "fewer instances than the base capacity",
+			pool:      distribution(2, 0),
+			instances: 1,
+			expected:  0,
+		},
+		{
+			name:      "on-demand share rounded up",
+			pool:      distribution(0, 30),
+			instances: 5,
+			expected:  3,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(expectedSpot(tc.pool, tc.instances)).To(Equal(tc.expected))
+		})
+	}
+}
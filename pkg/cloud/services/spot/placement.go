@@ -0,0 +1,261 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spot
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// DefaultPlacementScoreCacheTTL is the duration for which the spot placement scores of a configuration are reused
+// by all the machine pools requesting them. The API only allows a few requests per account and hour.
+const DefaultPlacementScoreCacheTTL = 15 * time.Minute
+
+// ErrNoPlacementScoreConfiguration is returned when a machine pool has neither instance types nor instance
+// requirements to fetch the spot placement scores of.
+var ErrNoPlacementScoreConfiguration = errors.New("the machine pool sets neither instance types nor instance requirements to score")
+
+// PlacementScoreCache holds the spot placement scores fetched by all the machine pools, so that the scores of a
+// configuration are only fetched once per TTL.
+type PlacementScoreCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu     sync.Mutex
+	scores map[string]cachedPlacementScores
+}
+
+type cachedPlacementScores struct {
+	scores    []expinfrav1.SpotPlacementScore
+	fetchedAt time.Time
+}
+
+// NewPlacementScoreCache returns a cache keeping the spot placement scores for the given TTL.
+func NewPlacementScoreCache(ttl time.Duration) *PlacementScoreCache {
+	return &PlacementScoreCache{
+		ttl:    ttl,
+		now:    time.Now,
+		scores: map[string]cachedPlacementScores{},
+	}
+}
+
+// get returns the scores of the key and when they were fetched, fetching them once the cached ones expired. The
+// lock is held while fetching, so that the pools requesting the same scores concurrently wait for the same request.
+func (c *PlacementScoreCache) get(key string, fetch func() ([]expinfrav1.SpotPlacementScore, error)) ([]expinfrav1.SpotPlacementScore, time.Time, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if cached, ok := c.scores[key]; ok && c.now().Sub(cached.fetchedAt) < c.ttl {
+		return cached.scores, cached.fetchedAt, nil
+	}
+	scores, err := fetch()
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	fetchedAt := c.now()
+	c.scores[key] = cachedPlacementScores{scores: scores, fetchedAt: fetchedAt}
+	return scores, fetchedAt, nil
+}
+
+// ReportPlacementScores fetches the spot placement scores of the instance types of the machine pool, or of its
+// instance requirements when it has no instance type, for the target capacity in each availability zone of the
+// region, and records them in the status. The scores are reused from the cache when it is set. When the API isn't
+// available in the partition of the region, the SpotPlacementScoresUnavailableCondition is set instead.
+func (s *Service) ReportPlacementScores(pool *expinfrav1.AWSMachinePool, targetCapacity int32, cache *PlacementScoreCache) error {
+	input, instanceTypes := placementScoresInput(pool, s.scope.Region(), targetCapacity)
+	if input == nil {
+		return ErrNoPlacementScoreConfiguration
+	}
+
+	fetch := func() ([]expinfrav1.SpotPlacementScore, error) {
+		return s.getPlacementScores(input)
+	}
+	var (
+		scores    []expinfrav1.SpotPlacementScore
+		fetchedAt time.Time
+		err       error
+	)
+	if cache != nil {
+		scores, fetchedAt, err = cache.get(input.String(), fetch)
+	} else {
+		scores, err = fetch()
+		fetchedAt = time.Now()
+	}
+	if err != nil {
+		if code, ok := awserrors.Code(errors.Cause(err)); ok && (code == "UnsupportedOperation" || code == "InvalidAction") {
+			conditions.MarkTrueWithNegativePolarity(pool, expinfrav1.SpotPlacementScoresUnavailableCondition, expinfrav1.SpotPlacementScoresUnsupportedReason,
+				clusterv1.ConditionSeverityWarning, "The spot placement scores aren't available in region %s: %s", s.scope.Region(), awserrors.Message(errors.Cause(err)))
+			return nil
+		}
+		return err
+	}
+
+	pool.Status.SpotPlacementScores = &expinfrav1.SpotPlacementScores{
+		TargetCapacity: targetCapacity,
+		InstanceTypes:  instanceTypes,
+		ScoredAt:       metav1.NewTime(fetchedAt),
+		Scores:         scores,
+	}
+	conditions.Delete(pool, expinfrav1.SpotPlacementScoresUnavailableCondition)
+	record.Eventf(pool, "SpotPlacementScoresFetched", "Fetched the spot placement scores of %d availability zones for %d instances", len(scores), targetCapacity)
+	return nil
+}
+
+// getPlacementScores fetches the spot placement scores of each availability zone, from the highest one, and names
+// their availability zones.
+func (s *Service) getPlacementScores(input *ec2.GetSpotPlacementScoresInput) ([]expinfrav1.SpotPlacementScore, error) {
+	var scores []expinfrav1.SpotPlacementScore
+	err := s.EC2Client.GetSpotPlacementScoresPagesWithContext(context.TODO(), input, func(out *ec2.GetSpotPlacementScoresOutput, _ bool) bool {
+		for _, score := range out.SpotPlacementScores {
+			scores = append(scores, expinfrav1.SpotPlacementScore{
+				AvailabilityZoneID: aws.StringValue(score.AvailabilityZoneId),
+				Score:              int32(aws.Int64Value(score.Score)),
+			})
+		}
+		return true
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get spot placement scores")
+	}
+	if len(scores) == 0 {
+		return nil, nil
+	}
+
+	zoneIDs := make([]string, 0, len(scores))
+	for _, score := range scores {
+		zoneIDs = append(zoneIDs, score.AvailabilityZoneID)
+	}
+	out, err := s.EC2Client.DescribeAvailabilityZonesWithContext(context.TODO(), &ec2.DescribeAvailabilityZonesInput{
+		ZoneIds: aws.StringSlice(zoneIDs),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to describe the availability zones of the spot placement scores")
+	}
+	names := make(map[string]string, len(out.AvailabilityZones))
+	for _, zone := range out.AvailabilityZones {
+		names[aws.StringValue(zone.ZoneId)] = aws.StringValue(zone.ZoneName)
+	}
+	for i := range scores {
+		scores[i].AvailabilityZone = names[scores[i].AvailabilityZoneID]
+	}
+
+	sort.SliceStable(scores, func(i, j int) bool {
+		if scores[i].Score != scores[j].Score {
+			return scores[i].Score > scores[j].Score
+		}
+		return scores[i].AvailabilityZoneID < scores[j].AvailabilityZoneID
+	})
+	return scores, nil
+}
+
+// placementScoresInput returns the request of the spot placement scores of the machine pool, and the instance types
+// it scores. The instance types of the overrides of the mixed instances policy replace the one of the launch
+// template, and the instance requirements of the first override setting them are scored when there is no instance
+// type. It returns nil when the machine pool has neither.
+func placementScoresInput(pool *expinfrav1.AWSMachinePool, region string, targetCapacity int32) (*ec2.GetSpotPlacementScoresInput, []string) {
+	input := &ec2.GetSpotPlacementScoresInput{
+		RegionNames:            aws.StringSlice([]string{region}),
+		SingleAvailabilityZone: aws.Bool(true),
+		TargetCapacity:         aws.Int64(int64(targetCapacity)),
+	}
+
+	var instanceTypes []string
+	var requirements *expinfrav1.InstanceRequirements
+	if policy := pool.Spec.MixedInstancesPolicy; policy != nil && len(policy.Overrides) > 0 {
+		seen := map[string]bool{}
+		for _, override := range policy.Overrides {
+			switch {
+			case override.InstanceType != "" && !seen[override.InstanceType]:
+				seen[override.InstanceType] = true
+				instanceTypes = append(instanceTypes, override.InstanceType)
+			case override.InstanceRequirements != nil && requirements == nil:
+				requirements = override.InstanceRequirements
+			}
+		}
+	} else if pool.Spec.AWSLaunchTemplate.InstanceType != "" {
+		instanceTypes = []string{pool.Spec.AWSLaunchTemplate.InstanceType}
+	}
+
+	switch {
+	case len(instanceTypes) > 0:
+		input.InstanceTypes = aws.StringSlice(instanceTypes)
+	case requirements != nil:
+		input.InstanceRequirementsWithMetadata = &ec2.InstanceRequirementsWithMetadataRequest{
+			InstanceRequirements: instanceRequirementsRequest(requirements),
+		}
+	default:
+		return nil, nil
+	}
+	return input, instanceTypes
+}
+
+// instanceRequirementsRequest converts the instance requirements of an override to the ones of an EC2 request.
+func instanceRequirementsRequest(r *expinfrav1.InstanceRequirements) *ec2.InstanceRequirementsRequest {
+	requirements := &ec2.InstanceRequirementsRequest{
+		VCpuCount: &ec2.VCpuCountRangeRequest{
+			Min: aws.Int64(r.VCPUCount.Min),
+			Max: r.VCPUCount.Max,
+		},
+		MemoryMiB: &ec2.MemoryMiBRequest{
+			Min: aws.Int64(r.MemoryMiB.Min),
+			Max: r.MemoryMiB.Max,
+		},
+		SpotMaxPricePercentageOverLowestPrice:     r.SpotMaxPricePercentageOverLowestPrice,
+		OnDemandMaxPricePercentageOverLowestPrice: r.OnDemandMaxPricePercentageOverLowestPrice,
+	}
+	if len(r.AllowedInstanceTypes) > 0 {
+		requirements.AllowedInstanceTypes = aws.StringSlice(r.AllowedInstanceTypes)
+	}
+	if len(r.ExcludedInstanceTypes) > 0 {
+		requirements.ExcludedInstanceTypes = aws.StringSlice(r.ExcludedInstanceTypes)
+	}
+	for _, manufacturer := range r.CPUManufacturers {
+		requirements.CpuManufacturers = append(requirements.CpuManufacturers, aws.String(string(manufacturer)))
+	}
+	for _, generation := range r.InstanceGenerations {
+		requirements.InstanceGenerations = append(requirements.InstanceGenerations, aws.String(string(generation)))
+	}
+	for _, acceleratorType := range r.AcceleratorTypes {
+		requirements.AcceleratorTypes = append(requirements.AcceleratorTypes, aws.String(string(acceleratorType)))
+	}
+	if r.BurstablePerformance != "" {
+		requirements.BurstablePerformance = aws.String(string(r.BurstablePerformance))
+	}
+	if r.BareMetal != "" {
+		requirements.BareMetal = aws.String(string(r.BareMetal))
+	}
+	if r.AcceleratorCount != nil {
+		requirements.AcceleratorCount = &ec2.AcceleratorCountRequest{
+			Min: r.AcceleratorCount.Min,
+			Max: r.AcceleratorCount.Max,
+		}
+	}
+	return requirements
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spot
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloudtest"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestReportPlacementScores(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+	ec2Mock.EXPECT().GetSpotPlacementScoresPagesWithContext(context.TODO(), &ec2.GetSpotPlacementScoresInput{
+		InstanceTypes:          aws.StringSlice([]string{"m5.large", "c5.large"}),
+		RegionNames:            aws.StringSlice([]string{"us-east-1"}),
+		SingleAvailabilityZone: aws.Bool(true),
+		TargetCapacity:         aws.Int64(10),
+	}, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *ec2.GetSpotPlacementScoresInput, fn func(*ec2.GetSpotPlacementScoresOutput, bool) bool, _ ...request.Option) error {
+			fn(&ec2.GetSpotPlacementScoresOutput{SpotPlacementScores: []*ec2.SpotPlacementScore{
+				{AvailabilityZoneId: aws.String("use1-az1"), Score: aws.Int64(3)},
+				{AvailabilityZoneId: aws.String("use1-az2"), Score: aws.Int64(9)},
+			}}, true)
+			return nil
+		}).Times(1)
+	ec2Mock.EXPECT().DescribeAvailabilityZonesWithContext(context.TODO(), &ec2.DescribeAvailabilityZonesInput{
+		ZoneIds: aws.StringSlice([]string{"use1-az1", "use1-az2"}),
+	}).Return(&ec2.DescribeAvailabilityZonesOutput{AvailabilityZones: []*ec2.AvailabilityZone{
+		{ZoneId: aws.String("use1-az1"), ZoneName: aws.String("us-east-1b")},
+		{ZoneId: aws.String("use1-az2"), ZoneName: aws.String("us-east-1a")},
+	}}, nil).Times(1)
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache := NewPlacementScoreCache(DefaultPlacementScoreCacheTTL)
+	cache.now = func() time.Time { return now }
+	s := NewService(cloudtest.NewClusterScope(t), nil)
+	s.EC2Client = ec2Mock
+
+	newPool := func(name string) *expinfrav1.AWSMachinePool {
+		return &expinfrav1.AWSMachinePool{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: expinfrav1.AWSMachinePoolSpec{
+				MixedInstancesPolicy: &expinfrav1.MixedInstancesPolicy{
+					Overrides: []expinfrav1.Overrides{{InstanceType: "m5.large"}, {InstanceType: "c5.large"}, {InstanceType: "m5.large"}},
+				},
+			},
+		}
+	}
+	want := &expinfrav1.SpotPlacementScores{
+		TargetCapacity: 10,
+		InstanceTypes:  []string{"m5.large", "c5.large"},
+		ScoredAt:       metav1.NewTime(now),
+		Scores: []expinfrav1.SpotPlacementScore{
+			{AvailabilityZoneID: "use1-az2", AvailabilityZone: "us-east-1a", Score: 9},
+			{AvailabilityZoneID: "use1-az1", AvailabilityZone: "us-east-1b", Score: 3},
+		},
+	}
+
+	pool := newPool("pool")
+	conditions.MarkTrueWithNegativePolarity(pool, expinfrav1.SpotPlacementScoresUnavailableCondition, expinfrav1.SpotPlacementScoresUnsupportedReason, "", "")
+	g.Expect(s.ReportPlacementScores(pool, 10, cache)).To(Succeed())
+	g.Expect(pool.Status.SpotPlacementScores).To(Equal(want))
+	g.Expect(conditions.Has(pool, expinfrav1.SpotPlacementScoresUnavailableCondition)).To(BeFalse())
+
+	// The scores of the same configuration are reused by another pool.
+	other := newPool("other")
+	g.Expect(s.ReportPlacementScores(other, 10, cache)).To(Succeed())
+	g.Expect(other.Status.SpotPlacementScores).To(Equal(want))
+}
+
+func TestReportPlacementScoresUnavailable(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+	ec2Mock.EXPECT().GetSpotPlacementScoresPagesWithContext(context.TODO(), gomock.Any(), gomock.Any()).
+		Return(awserr.New("UnsupportedOperation", "not supported in this partition", nil))
+
+	s := NewService(cloudtest.NewClusterScope(t), nil)
+	s.EC2Client = ec2Mock
+
+	pool := &expinfrav1.AWSMachinePool{
+		ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default"},
+		Spec: expinfrav1.AWSMachinePoolSpec{
+			AWSLaunchTemplate: expinfrav1.AWSLaunchTemplate{InstanceType: "m5.large"},
+		},
+	}
+
+	g.Expect(s.ReportPlacementScores(pool, 2, NewPlacementScoreCache(DefaultPlacementScoreCacheTTL))).To(Succeed())
+	g.Expect(pool.Status.SpotPlacementScores).To(BeNil())
+	condition := conditions.Get(pool, expinfrav1.SpotPlacementScoresUnavailableCondition)
+	g.Expect(condition).NotTo(BeNil())
+	g.Expect(condition.Status).To(Equal(corev1.ConditionTrue))
+	g.Expect(condition.Reason).To(Equal(expinfrav1.SpotPlacementScoresUnsupportedReason))
+}
+
+func TestPlacementScoresInput(t *testing.T) {
+	requirements := &expinfrav1.InstanceRequirements{
+		VCPUCount: expinfrav1.VCPUCountRequest{Min: 2, Max: aws.Int64(4)},
+		MemoryMiB: expinfrav1.MemoryMiBRequest{Min: 4096},
+	}
+
+	tests := []struct {
+		name              string
+		spec              expinfrav1.AWSMachinePoolSpec
+		wantNil           bool
+		wantInstanceTypes []string
+		wantRequirements  bool
+	}{
+		{
+			name:              "instance type of the launch template",
+			spec:              expinfrav1.AWSMachinePoolSpec{AWSLaunchTemplate: expinfrav1.AWSLaunchTemplate{InstanceType: "m5.large"}},
+			wantInstanceTypes: []string{"m5.large"},
+		},
+		{
+			name: "instance types of the overrides replace the one of the launch template",
+			spec: expinfrav1.AWSMachinePoolSpec{
+				AWSLaunchTemplate: expinfrav1.AWSLaunchTemplate{InstanceType: "m5.large"},
+				MixedInstancesPolicy: &expinfrav1.MixedInstancesPolicy{
+					Overrides: []expinfrav1.Overrides{{InstanceType: "c5.large"}, {InstanceRequirements: requirements}},
+				},
+			},
+			wantInstanceTypes: []string{"c5.large"},
+		},
+		{
+			name: "instance requirements of the overrides",
+			spec: expinfrav1.AWSMachinePoolSpec{
+				MixedInstancesPolicy: &expinfrav1.MixedInstancesPolicy{
+					Overrides: []expinfrav1.Overrides{{InstanceRequirements: requirements}},
+				},
+			},
+			wantRequirements: true,
+		},
+		{
+			name:    "nothing to score",
+			wantNil: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			input, instanceTypes := placementScoresInput(&expinfrav1.AWSMachinePool{Spec: tt.spec}, "us-east-1", 5)
+			if tt.wantNil {
+				g.Expect(input).To(BeNil())
+				return
+			}
+			g.Expect(instanceTypes).To(Equal(tt.wantInstanceTypes))
+			g.Expect(aws.StringValueSlice(input.InstanceTypes)).To(ConsistOf(tt.wantInstanceTypes))
+			g.Expect(input.InstanceRequirementsWithMetadata != nil).To(Equal(tt.wantRequirements))
+			g.Expect(aws.StringValueSlice(input.RegionNames)).To(Equal([]string{"us-east-1"}))
+			g.Expect(aws.Int64Value(input.TargetCapacity)).To(Equal(int64(5)))
+		})
+	}
+
+	g := NewWithT(t)
+	s := NewService(cloudtest.NewClusterScope(t), nil)
+	g.Expect(s.ReportPlacementScores(&expinfrav1.AWSMachinePool{}, 5, nil)).To(MatchError(ErrNoPlacementScoreConfiguration))
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spot
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+const (
+	// DefaultPriceCacheTTL is the duration after which the spot price of an instance type is fetched again.
+	DefaultPriceCacheTTL = time.Hour
+
+	// productDescription is the product the spot prices are fetched for.
+	productDescription = "Linux/UNIX"
+)
+
+// PriceCache holds the spot prices fetched by all the machine pools, so the price of an instance type
+// in an availability zone is only fetched once per TTL.
+type PriceCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu     sync.Mutex
+	prices map[string]cachedPrice
+}
+
+type cachedPrice struct {
+	price     string
+	fetchedAt time.Time
+}
+
+// NewPriceCache returns a cache keeping the spot prices for the given TTL.
+func NewPriceCache(ttl time.Duration) *PriceCache {
+	return &PriceCache{
+		ttl:    ttl,
+		now:    time.Now,
+		prices: map[string]cachedPrice{},
+	}
+}
+
+func (c *PriceCache) get(key string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cached, ok := c.prices[key]
+	if !ok || c.now().Sub(cached.fetchedAt) >= c.ttl {
+		return "", false
+	}
+	return cached.price, true
+}
+
+func (c *PriceCache) set(key, price string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.prices[key] = cachedPrice{price: price, fetchedAt: c.now()}
+}
+
+func priceKey(region, availabilityZone, instanceType string) string {
+	return region + "/" + availabilityZone + "/" + instanceType
+}
+
+// getSpotPrices returns the current spot price of the given instance types of each availability zone, keyed
+// by availability zone and instance type. The prices which aren't cached are fetched with one call per zone.
+// Instance types without a spot price are left out.
+func (s *Service) getSpotPrices(instanceTypesByZone map[string][]string) (map[string]map[string]string, error) {
+	region := s.scope.Region()
+	prices := map[string]map[string]string{}
+
+	zones := make([]string, 0, len(instanceTypesByZone))
+	for zone := range instanceTypesByZone {
+		zones = append(zones, zone)
+	}
+	sort.Strings(zones)
+
+	for _, zone := range zones {
+		prices[zone] = map[string]string{}
+		var missing []string
+		for _, instanceType := range instanceTypesByZone[zone] {
+			if price, ok := s.prices.get(priceKey(region, zone, instanceType)); ok {
+				prices[zone][instanceType] = price
+				continue
+			}
+			missing = append(missing, instanceType)
+		}
+		if len(missing) == 0 {
+			continue
+		}
+
+		fetched, err := s.describeSpotPrices(zone, missing)
+		if err != nil {
+			return nil, err
+		}
+		for instanceType, price := range fetched {
+			s.prices.set(priceKey(region, zone, instanceType), price)
+			prices[zone][instanceType] = price
+		}
+	}
+
+	return prices, nil
+}
+
+// describeSpotPrices returns the latest spot price of the instance types in the availability zone.
+func (s *Service) describeSpotPrices(availabilityZone string, instanceTypes []string) (map[string]string, error) {
+	input := &ec2.DescribeSpotPriceHistoryInput{
+		AvailabilityZone:    aws.String(availabilityZone),
+		InstanceTypes:       aws.StringSlice(instanceTypes),
+		ProductDescriptions: aws.StringSlice([]string{productDescription}),
+		StartTime:           aws.Time(s.prices.now()),
+	}
+
+	prices := map[string]string{}
+	latest := map[string]time.Time{}
+	err := s.EC2Client.DescribeSpotPriceHistoryPagesWithContext(context.TODO(), input, func(out *ec2.DescribeSpotPriceHistoryOutput, _ bool) bool {
+		for _, price := range out.SpotPriceHistory {
+			instanceType := aws.StringValue(price.InstanceType)
+			timestamp := aws.TimeValue(price.Timestamp)
+			if t, ok := latest[instanceType]; ok && !timestamp.After(t) {
+				continue
+			}
+			latest[instanceType] = timestamp
+			prices[instanceType] = aws.StringValue(price.SpotPrice)
+		}
+		return true
+	})
+	return prices, err
+}
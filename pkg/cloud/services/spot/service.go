@@ -0,0 +1,42 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package spot reports the spot and on-demand capacity of machine pools, the current
+// price of their spot instances, and the spot placement scores of their configuration.
+package spot
+
+import (
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+)
+
+// Service reports the capacity mix of a machine pool.
+type Service struct {
+	scope     scope.EC2Scope
+	prices    *PriceCache
+	EC2Client ec2iface.EC2API
+}
+
+// NewService returns a new service given the EC2 scope and the cache of the spot prices. The spot
+// prices aren't reported when the cache is nil.
+func NewService(ec2Scope scope.EC2Scope, prices *PriceCache) *Service {
+	return &Service{
+		scope:     ec2Scope,
+		prices:    prices,
+		EC2Client: scope.NewEC2Client(ec2Scope, ec2Scope, ec2Scope, ec2Scope.InfraCluster()),
+	}
+}
@@ -55,11 +55,12 @@ func (s *Service) Create(m *scope.MachineScope, data []byte) (string, int32, err
 	additionalTags := m.AdditionalTags()
 	additionalTags[infrav1.ClusterAWSCloudProviderTagKey(s.scope.Name())] = string(infrav1.ResourceLifecycleOwned)
 	tags := infrav1.Build(infrav1.BuildParams{
-		ClusterName: s.scope.Name(),
-		Lifecycle:   infrav1.ResourceLifecycleOwned,
-		Name:        aws.String(m.Name()),
-		Role:        aws.String(m.Role()),
-		Additional:  additionalTags,
+		ClusterName:        s.scope.Name(),
+		OwnershipTagPrefix: s.scope.OwnershipTagPrefix(),
+		Lifecycle:          infrav1.ResourceLifecycleOwned,
+		Name:               aws.String(m.Name()),
+		Role:               aws.String(m.Role()),
+		Additional:         additionalTags,
 	})
 
 	// Build the prefix.
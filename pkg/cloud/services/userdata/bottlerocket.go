@@ -0,0 +1,86 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userdata
+
+import (
+	"github.com/pelletier/go-toml/v2"
+	"github.com/pkg/errors"
+)
+
+// BottlerocketSettings are the settings merged into the TOML user data of Bottlerocket instances.
+type BottlerocketSettings struct {
+	// AdminContainer enables or disables the admin host container when set.
+	AdminContainer *bool
+	// NodeLabels are the labels the node registers with.
+	NodeLabels map[string]string
+	// NodeTaints are the taints the node registers with, by key, formatted as value:Effect.
+	NodeTaints map[string][]string
+	// HTTPSProxy is the proxy of the container runtime and the kubelet.
+	HTTPSProxy string
+	// NoProxy are the hosts reached without the proxy.
+	NoProxy []string
+}
+
+// MergeBottlerocketSettings merges the settings into the Bottlerocket TOML user data. The settings take precedence
+// over the ones of the user data, the node labels and taints are added to the ones of the user data. As Bottlerocket
+// has no shell, user data which isn't TOML, e.g. a cloud-init script, is rejected.
+func MergeBottlerocketSettings(data []byte, settings BottlerocketSettings) ([]byte, error) {
+	config := map[string]interface{}{}
+	if err := toml.Unmarshal(data, &config); err != nil {
+		return nil, errors.Wrap(err, "bootstrap data of Bottlerocket instances must be TOML settings, shell commands aren't supported")
+	}
+
+	root := tomlTable(config, "settings")
+	if len(settings.NodeLabels) > 0 {
+		labels := tomlTable(tomlTable(root, "kubernetes"), "node-labels")
+		for key, value := range settings.NodeLabels {
+			labels[key] = value
+		}
+	}
+	if len(settings.NodeTaints) > 0 {
+		taints := tomlTable(tomlTable(root, "kubernetes"), "node-taints")
+		for key, values := range settings.NodeTaints {
+			taints[key] = values
+		}
+	}
+	if settings.AdminContainer != nil {
+		tomlTable(tomlTable(root, "host-containers"), "admin")["enabled"] = *settings.AdminContainer
+	}
+	if settings.HTTPSProxy != "" {
+		network := tomlTable(root, "network")
+		network["https-proxy"] = settings.HTTPSProxy
+		if len(settings.NoProxy) > 0 {
+			network["no-proxy"] = settings.NoProxy
+		}
+	}
+
+	out, err := toml.Marshal(config)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal the Bottlerocket settings")
+	}
+	return out, nil
+}
+
+// tomlTable returns the table of the given key, adding it when it doesn't exist.
+func tomlTable(parent map[string]interface{}, key string) map[string]interface{} {
+	if table, ok := parent[key].(map[string]interface{}); ok {
+		return table
+	}
+	table := map[string]interface{}{}
+	parent[key] = table
+	return table
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userdata
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+)
+
+func TestMergeBottlerocketSettings(t *testing.T) {
+	bootstrapData := `[settings.kubernetes]
+api-server = "https://api.example.com"
+cluster-name = "capa"
+
+[settings.kubernetes.node-labels]
+team = "ml"
+
+[settings.host-containers.admin]
+enabled = true
+`
+
+	t.Run("should keep the bootstrap data without settings", func(t *testing.T) {
+		g := NewWithT(t)
+
+		data, err := MergeBottlerocketSettings([]byte(bootstrapData), BottlerocketSettings{})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(string(data)).To(ContainSubstring(`api-server = 'https://api.example.com'`))
+		g.Expect(string(data)).To(ContainSubstring(`team = 'ml'`))
+		g.Expect(string(data)).ToNot(ContainSubstring(`[settings.network]`))
+	})
+
+	t.Run("should merge the settings into the bootstrap data", func(t *testing.T) {
+		g := NewWithT(t)
+
+		settings := BottlerocketSettings{
+			AdminContainer: ptr.To(false),
+			NodeLabels:     map[string]string{"node.kubernetes.io/pool": "gpu"},
+			NodeTaints:     map[string][]string{"nvidia.com/gpu": {"true:NoSchedule"}},
+			HTTPSProxy:     "http://proxy.example.com:3128",
+			NoProxy:        []string{"localhost", "169.254.169.254"},
+		}
+		data, err := MergeBottlerocketSettings([]byte(bootstrapData), settings)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(string(data)).To(Equal(`[settings]
+[settings.host-containers]
+[settings.host-containers.admin]
+enabled = false
+
+[settings.kubernetes]
+api-server = 'https://api.example.com'
+cluster-name = 'capa'
+
+[settings.kubernetes.node-labels]
+'node.kubernetes.io/pool' = 'gpu'
+team = 'ml'
+
+[settings.kubernetes.node-taints]
+'nvidia.com/gpu' = ['true:NoSchedule']
+
+[settings.network]
+https-proxy = 'http://proxy.example.com:3128'
+no-proxy = ['localhost', '169.254.169.254']
+`))
+
+		// The user data is stable, so that the launch template isn't updated on every reconciliation.
+		again, err := MergeBottlerocketSettings([]byte(bootstrapData), settings)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(again).To(Equal(data))
+	})
+
+	t.Run("should reject bootstrap data which isn't TOML", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := MergeBottlerocketSettings([]byte("#!/bin/bash\n/etc/eks/bootstrap.sh capa\n"), BottlerocketSettings{})
+		g.Expect(err).To(MatchError(ContainSubstring("must be TOML settings")))
+	})
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userdata
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/textproto"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// kubeletEnvironmentFile is the environment file of the kubelet service read by the systemd drop-in of the
+	// kubeadm packages, which passes KUBELET_EXTRA_ARGS to the kubelet. An Environment= line of another drop-in
+	// would be overridden by it. RPM based distributions read rpmKubeletEnvironmentFile instead.
+	kubeletEnvironmentFile    = "/etc/default/kubelet"
+	rpmKubeletEnvironmentFile = "/etc/sysconfig/kubelet"
+
+	// kubeletArgsBoundary is the boundary of the MIME document adding the kubelet arguments to cloud-init user data.
+	// It is fixed so that the user data, and so its hash, don't change between reconciliations.
+	kubeletArgsBoundary = "capa-kubelet-node-args"
+
+	// jinjaTemplateHeader is the first line of cloud-init user data rendered as a jinja template.
+	jinjaTemplateHeader = "## template: jinja"
+
+	ignitionFormat = "ignition"
+)
+
+// KubeletNodeArgs returns the kubelet arguments registering the node with the given labels and taints, formatted
+// as key=value:Effect. The labels are sorted so that the arguments are stable.
+func KubeletNodeArgs(labels map[string]string, taints []string) string {
+	var args []string
+	if len(labels) > 0 {
+		keys := make([]string, 0, len(labels))
+		for key := range labels {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		pairs := make([]string, 0, len(keys))
+		for _, key := range keys {
+			pairs = append(pairs, key+"="+labels[key])
+		}
+		args = append(args, "--node-labels="+strings.Join(pairs, ","))
+	}
+	if len(taints) > 0 {
+		args = append(args, "--register-with-taints="+strings.Join(taints, ","))
+	}
+	return strings.Join(args, " ")
+}
+
+// AddKubeletExtraArgs adds to the bootstrap data the given arguments to KUBELET_EXTRA_ARGS in the environment file
+// of the kubelet service. Ignition configurations get the environment file in their storage, cloud-init user data is
+// wrapped in a MIME document with a script adding the arguments to the environment file, which runs before the
+// runcmd of the bootstrap data joins the node.
+func AddKubeletExtraArgs(data []byte, format string, args string) ([]byte, error) {
+	if args == "" {
+		return data, nil
+	}
+
+	if format == ignitionFormat {
+		return addIgnitionKubeletExtraArgs(data, args)
+	}
+	return addCloudInitKubeletExtraArgs(data, args)
+}
+
+func addIgnitionKubeletExtraArgs(data []byte, args string) ([]byte, error) {
+	config := map[string]interface{}{}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, errors.Wrap(err, "failed to parse the ignition configuration")
+	}
+
+	contents := fmt.Sprintf("KUBELET_EXTRA_ARGS=\"%s\"\n", args)
+	file := map[string]interface{}{
+		"path": kubeletEnvironmentFile,
+		"mode": 0o644,
+		"contents": map[string]interface{}{
+			"source": "data:;base64," + base64.StdEncoding.EncodeToString([]byte(contents)),
+		},
+	}
+	// Ignition 2.x needs the filesystem of the file, 3.x fails on existing files unless they are overwritten.
+	ignition, _ := config["ignition"].(map[string]interface{})
+	if version, _ := ignition["version"].(string); strings.HasPrefix(version, "2.") {
+		file["filesystem"] = "root"
+	} else {
+		file["overwrite"] = true
+	}
+
+	storage, _ := config["storage"].(map[string]interface{})
+	if storage == nil {
+		storage = map[string]interface{}{}
+	}
+	files, _ := storage["files"].([]interface{})
+	storage["files"] = append(files, file)
+	config["storage"] = storage
+
+	out, err := json.Marshal(config)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal the ignition configuration")
+	}
+	return out, nil
+}
+
+func addCloudInitKubeletExtraArgs(data []byte, args string) ([]byte, error) {
+	// The arguments are appended to the ones already set in the environment file, if any.
+	script := strings.Join([]string{
+		"#!/bin/sh",
+		"env_file=" + kubeletEnvironmentFile,
+		fmt.Sprintf("if [ -e %[1]s ]; then env_file=%[1]s; fi", rpmKubeletEnvironmentFile),
+		`current=$(sed -n 's/^KUBELET_EXTRA_ARGS=//p' "$env_file" 2>/dev/null | tail -n 1 | sed 's/^"\(.*\)"$/\1/')`,
+		`if [ -e "$env_file" ]; then sed -i '/^KUBELET_EXTRA_ARGS=/d' "$env_file"; fi`,
+		fmt.Sprintf(`echo "KUBELET_EXTRA_ARGS=\"${current:+$current }%s\"" >> "$env_file"`, args),
+		"",
+	}, "\n")
+
+	// Cloud-init only renders the bootstrap data as a jinja template in a MIME document when its part has the
+	// jinja content type.
+	contentType := "text/cloud-config"
+	if bytes.HasPrefix(data, []byte(jinjaTemplateHeader)) {
+		contentType = "text/jinja2"
+	}
+
+	var buf bytes.Buffer
+	mpWriter := multipart.NewWriter(&buf)
+	if err := mpWriter.SetBoundary(kubeletArgsBoundary); err != nil {
+		return nil, err
+	}
+	buf.WriteString(fmt.Sprintf("MIME-Version: 1.0\nContent-Type: multipart/mixed; boundary=\"%s\"\n\n", kubeletArgsBoundary))
+
+	parts := []struct {
+		contentType string
+		content     []byte
+	}{
+		{contentType: "text/x-shellscript", content: []byte(script)},
+		{contentType: contentType, content: data},
+	}
+	for _, part := range parts {
+		w, err := mpWriter.CreatePart(textproto.MIMEHeader{"Content-Type": {part.contentType}})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write(part.content); err != nil {
+			return nil, err
+		}
+	}
+	if err := mpWriter.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userdata
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestKubeletNodeArgs(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(KubeletNodeArgs(nil, nil)).To(BeEmpty())
+	g.Expect(KubeletNodeArgs(map[string]string{"team": "ml", "node.kubernetes.io/pool": "gpu"}, []string{"gpu=true:NoSchedule", "dedicated=ml:NoExecute"})).
+		To(Equal("--node-labels=node.kubernetes.io/pool=gpu,team=ml --register-with-taints=gpu=true:NoSchedule,dedicated=ml:NoExecute"))
+}
+
+func TestAddKubeletExtraArgs(t *testing.T) {
+	// mimeParts returns the content types and the contents of the parts of a MIME document.
+	mimeParts := func(g *WithT, data []byte) ([]string, []string) {
+		msg, err := mail.ReadMessage(bytes.NewReader(data))
+		g.Expect(err).ToNot(HaveOccurred())
+		mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(mediaType).To(Equal("multipart/mixed"))
+
+		reader := multipart.NewReader(msg.Body, params["boundary"])
+		var contentTypes, contents []string
+		for {
+			part, err := reader.NextPart()
+			if err == io.EOF {
+				break
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			content, err := io.ReadAll(part)
+			g.Expect(err).ToNot(HaveOccurred())
+			contentTypes = append(contentTypes, part.Header.Get("Content-Type"))
+			contents = append(contents, string(content))
+		}
+		return contentTypes, contents
+	}
+	// environmentFile is the content of the environment file written to ignition configurations, base64 encoded.
+	environmentFile := base64.StdEncoding.EncodeToString([]byte("KUBELET_EXTRA_ARGS=\"--node-labels=team=ml\"\n"))
+
+	t.Run("should leave the bootstrap data untouched without arguments", func(t *testing.T) {
+		g := NewWithT(t)
+
+		data, err := AddKubeletExtraArgs([]byte("#cloud-config\n"), "cloud-config", "")
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(string(data)).To(Equal("#cloud-config\n"))
+	})
+
+	t.Run("should add the arguments to the environment file of the kubelet before the cloud-config", func(t *testing.T) {
+		g := NewWithT(t)
+
+		cloudConfig := "#cloud-config\nruncmd:\n- kubeadm join\n"
+		data, err := AddKubeletExtraArgs([]byte(cloudConfig), "cloud-config", "--node-labels=team=ml")
+		g.Expect(err).ToNot(HaveOccurred())
+
+		// The user data is stable, so that the launch template isn't updated on every reconciliation.
+		again, err := AddKubeletExtraArgs([]byte(cloudConfig), "cloud-config", "--node-labels=team=ml")
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(again).To(Equal(data))
+
+		contentTypes, contents := mimeParts(g, data)
+		g.Expect(contentTypes).To(Equal([]string{"text/x-shellscript", "text/cloud-config"}))
+		g.Expect(contents[0]).To(ContainSubstring("env_file=/etc/default/kubelet\n"))
+		g.Expect(contents[0]).To(ContainSubstring("if [ -e /etc/sysconfig/kubelet ]; then env_file=/etc/sysconfig/kubelet; fi\n"))
+		g.Expect(contents[0]).To(ContainSubstring(`echo "KUBELET_EXTRA_ARGS=\"${current:+$current }--node-labels=team=ml\"" >> "$env_file"`))
+		g.Expect(contents[1]).To(Equal(cloudConfig))
+	})
+
+	t.Run("should keep the bootstrap data rendered as a jinja template", func(t *testing.T) {
+		g := NewWithT(t)
+
+		cloudConfig := "## template: jinja\n#cloud-config\nwrite_files:\n- path: /tmp/hostname\n  content: '{{ ds.meta_data.local_hostname }}'\n"
+		data, err := AddKubeletExtraArgs([]byte(cloudConfig), "cloud-config", "--node-labels=team=ml")
+		g.Expect(err).ToNot(HaveOccurred())
+
+		contentTypes, contents := mimeParts(g, data)
+		g.Expect(contentTypes).To(Equal([]string{"text/x-shellscript", "text/jinja2"}))
+		g.Expect(contents[1]).To(Equal(cloudConfig))
+	})
+
+	t.Run("should add the environment file of the kubelet to an ignition configuration", func(t *testing.T) {
+		g := NewWithT(t)
+
+		ignition := `{"ignition":{"version":"3.1.0"},"storage":{"files":[{"path":"/etc/hosts"}]},"systemd":{"units":[{"name":"kubelet.service","enabled":true}]}}`
+		data, err := AddKubeletExtraArgs([]byte(ignition), "ignition", "--node-labels=team=ml")
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(string(data)).To(MatchJSON(`{"ignition":{"version":"3.1.0"},"storage":{"files":[{"path":"/etc/hosts"},` +
+			`{"path":"/etc/default/kubelet","mode":420,"overwrite":true,"contents":{"source":"data:;base64,` + environmentFile + `"}}]},` +
+			`"systemd":{"units":[{"name":"kubelet.service","enabled":true}]}}`))
+	})
+
+	t.Run("should add the environment file of the kubelet to the root filesystem of an ignition 2 configuration", func(t *testing.T) {
+		g := NewWithT(t)
+
+		data, err := AddKubeletExtraArgs([]byte(`{"ignition":{"version":"2.3.0"}}`), "ignition", "--node-labels=team=ml")
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(string(data)).To(MatchJSON(`{"ignition":{"version":"2.3.0"},"storage":{"files":[` +
+			`{"filesystem":"root","path":"/etc/default/kubelet","mode":420,"contents":{"source":"data:;base64,` + environmentFile + `"}}]}}`))
+	})
+
+	t.Run("should return an error for an invalid ignition configuration", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := AddKubeletExtraArgs([]byte("#cloud-config\n"), "ignition", "--node-labels=team=ml")
+		g.Expect(err).To(HaveOccurred())
+	})
+}
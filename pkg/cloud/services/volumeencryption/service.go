@@ -0,0 +1,42 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package volumeencryption provides a way to report the encryption of the root volumes of the
+// instances of a cluster.
+package volumeencryption
+
+import (
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+)
+
+// Service reports the encryption of the root volumes of a cluster.
+type Service struct {
+	scope     *scope.ClusterScope
+	reporter  *Reporter
+	EC2Client ec2iface.EC2API
+}
+
+// NewService returns a new service given the cluster scope and the reporter holding the configuration
+// of the report.
+func NewService(clusterScope *scope.ClusterScope, reporter *Reporter) *Service {
+	return &Service{
+		scope:     clusterScope,
+		reporter:  reporter,
+		EC2Client: scope.NewEC2Client(clusterScope, clusterScope, clusterScope, clusterScope.InfraCluster()),
+	}
+}
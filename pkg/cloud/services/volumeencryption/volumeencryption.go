@@ -0,0 +1,222 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package volumeencryption
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/filter"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+const (
+	// DefaultReportInterval is the interval after which the root volumes of a cluster are checked again.
+	DefaultReportInterval = 6 * time.Hour
+
+	// maxVolumeIDsPerCall caps the volume IDs filtered by a DescribeVolumes call, which accepts at most
+	// 200 values per filter.
+	maxVolumeIDsPerCall = 200
+
+	// maxReportedInstances caps the instances listed in the status and the condition message, so that
+	// they stay readable for large clusters.
+	maxReportedInstances = 20
+
+	// controlPlaneRole is the value of the role tag of control plane instances.
+	controlPlaneRole = "control-plane"
+)
+
+// Reporter holds the interval of the volume encryption report.
+type Reporter struct {
+	interval time.Duration
+	now      func() time.Time
+}
+
+// NewReporter returns a reporter checking the root volumes of each cluster once per interval.
+func NewReporter(interval time.Duration) *Reporter {
+	return &Reporter{
+		interval: interval,
+		now:      time.Now,
+	}
+}
+
+// rootVolume is the root volume of an instance of the cluster.
+type rootVolume struct {
+	instanceID   string
+	controlPlane bool
+}
+
+// ReconcileVolumeEncryption checks whether the root volumes of the instances of the cluster are encrypted
+// once per interval, and reports the unencrypted ones in the status of the AWSCluster and with the
+// VolumeEncryptionCompliantCondition. Nothing is encrypted by the report.
+func (s *Service) ReconcileVolumeEncryption() error {
+	awsCluster := s.scope.AWSCluster
+	now := s.reporter.now()
+	if previous := awsCluster.Status.VolumeEncryption; previous != nil && now.Sub(previous.LastCheckTime.Time) < s.reporter.interval {
+		return nil
+	}
+
+	s.scope.Debug("Checking the encryption of the root volumes")
+
+	volumes, err := s.rootVolumes()
+	if err != nil {
+		conditions.MarkFalse(awsCluster, infrav1.VolumeEncryptionCompliantCondition, infrav1.VolumeEncryptionCheckFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return err
+	}
+
+	unencrypted, err := s.unencryptedVolumes(volumes)
+	if err != nil {
+		conditions.MarkFalse(awsCluster, infrav1.VolumeEncryptionCompliantCondition, infrav1.VolumeEncryptionCheckFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return err
+	}
+
+	status := &infrav1.VolumeEncryptionStatus{
+		LastCheckTime:  metav1.NewTime(now),
+		CheckedVolumes: int32(len(volumes)),
+	}
+	instanceIDs := make([]string, 0, len(unencrypted))
+	for _, volumeID := range unencrypted {
+		volume := volumes[volumeID]
+		if volume.controlPlane {
+			status.UnencryptedControlPlaneVolumes++
+		} else {
+			status.UnencryptedNodeVolumes++
+		}
+		instanceIDs = append(instanceIDs, volume.instanceID)
+	}
+	sort.Strings(instanceIDs)
+	if len(instanceIDs) > maxReportedInstances {
+		instanceIDs = instanceIDs[:maxReportedInstances]
+	}
+	if len(instanceIDs) > 0 {
+		status.UnencryptedInstanceIDs = instanceIDs
+	}
+	awsCluster.Status.VolumeEncryption = status
+
+	if len(unencrypted) > 0 {
+		conditions.MarkFalse(awsCluster, infrav1.VolumeEncryptionCompliantCondition, infrav1.UnencryptedVolumesReason, clusterv1.ConditionSeverityWarning,
+			"%d of %d root volumes are not encrypted, instances: %s", len(unencrypted), len(volumes), strings.Join(instanceIDs, ", "))
+		return nil
+	}
+
+	conditions.MarkTrue(awsCluster, infrav1.VolumeEncryptionCompliantCondition)
+	return nil
+}
+
+// rootVolumes returns the EBS root volumes of the instances tagged with the cluster, keyed by volume ID.
+// Terminated instances and instances whose root device is an instance store are left out.
+func (s *Service) rootVolumes() (map[string]rootVolume, error) {
+	input := &ec2.DescribeInstancesInput{
+		Filters: []*ec2.Filter{
+			filter.EC2.Cluster(s.scope.Name(), s.scope.OwnershipTagPrefixes()...),
+			filter.EC2.InstanceStates(
+				ec2.InstanceStateNamePending,
+				ec2.InstanceStateNameRunning,
+				ec2.InstanceStateNameStopping,
+				ec2.InstanceStateNameStopped,
+			),
+		},
+	}
+
+	volumes := map[string]rootVolume{}
+	if err := s.EC2Client.DescribeInstancesPagesWithContext(context.TODO(), input, func(out *ec2.DescribeInstancesOutput, _ bool) bool {
+		for _, reservation := range out.Reservations {
+			for _, instance := range reservation.Instances {
+				volumeID := rootVolumeID(instance)
+				if volumeID == "" {
+					continue
+				}
+				volumes[volumeID] = rootVolume{
+					instanceID:   aws.StringValue(instance.InstanceId),
+					controlPlane: isControlPlane(instance),
+				}
+			}
+		}
+		return true
+	}); err != nil {
+		return nil, errors.Wrap(err, "failed to describe instances")
+	}
+
+	return volumes, nil
+}
+
+// unencryptedVolumes returns the IDs of the given volumes which are not encrypted. The volumes are described
+// in batches, and the volumes deleted since they were listed are left out.
+func (s *Service) unencryptedVolumes(volumes map[string]rootVolume) ([]string, error) {
+	volumeIDs := make([]string, 0, len(volumes))
+	for volumeID := range volumes {
+		volumeIDs = append(volumeIDs, volumeID)
+	}
+	sort.Strings(volumeIDs)
+
+	unencrypted := []string{}
+	for start := 0; start < len(volumeIDs); start += maxVolumeIDsPerCall {
+		end := start + maxVolumeIDsPerCall
+		if end > len(volumeIDs) {
+			end = len(volumeIDs)
+		}
+
+		input := &ec2.DescribeVolumesInput{
+			Filters: []*ec2.Filter{
+				{
+					Name:   aws.String("volume-id"),
+					Values: aws.StringSlice(volumeIDs[start:end]),
+				},
+			},
+		}
+		if err := s.EC2Client.DescribeVolumesPagesWithContext(context.TODO(), input, func(out *ec2.DescribeVolumesOutput, _ bool) bool {
+			for _, volume := range out.Volumes {
+				if !aws.BoolValue(volume.Encrypted) {
+					unencrypted = append(unencrypted, aws.StringValue(volume.VolumeId))
+				}
+			}
+			return true
+		}); err != nil {
+			return nil, errors.Wrap(err, "failed to describe volumes")
+		}
+	}
+
+	return unencrypted, nil
+}
+
+// rootVolumeID returns the ID of the EBS volume attached as the root device of the instance.
+func rootVolumeID(instance *ec2.Instance) string {
+	for _, mapping := range instance.BlockDeviceMappings {
+		if mapping.Ebs != nil && aws.StringValue(mapping.DeviceName) == aws.StringValue(instance.RootDeviceName) {
+			return aws.StringValue(mapping.Ebs.VolumeId)
+		}
+	}
+	return ""
+}
+
+func isControlPlane(instance *ec2.Instance) bool {
+	for _, tag := range instance.Tags {
+		if aws.StringValue(tag.Key) == infrav1.NameAWSClusterAPIRole {
+			return aws.StringValue(tag.Value) == controlPlaneRole
+		}
+	}
+	return false
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package volumeencryption
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloudtest"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestReconcileVolumeEncryption(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+	instance := func(id, role, volumeID string) *ec2.Instance {
+		return &ec2.Instance{
+			InstanceId:     aws.String(id),
+			RootDeviceName: aws.String("/dev/xvda"),
+			BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
+				{DeviceName: aws.String("/dev/xvdb"), Ebs: &ec2.EbsInstanceBlockDevice{VolumeId: aws.String("vol-data")}},
+				{DeviceName: aws.String("/dev/xvda"), Ebs: &ec2.EbsInstanceBlockDevice{VolumeId: aws.String(volumeID)}},
+			},
+			Tags: []*ec2.Tag{{Key: aws.String(infrav1.NameAWSClusterAPIRole), Value: aws.String(role)}},
+		}
+	}
+	ec2Mock.EXPECT().DescribeInstancesPagesWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeInstancesInput{}), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *ec2.DescribeInstancesInput, fn func(*ec2.DescribeInstancesOutput, bool) bool, _ ...request.Option) error {
+			fn(&ec2.DescribeInstancesOutput{Reservations: []*ec2.Reservation{{Instances: []*ec2.Instance{
+				instance("i-1", "control-plane", "vol-1"),
+				instance("i-2", "control-plane", "vol-2"),
+			}}}}, false)
+			fn(&ec2.DescribeInstancesOutput{Reservations: []*ec2.Reservation{{Instances: []*ec2.Instance{
+				instance("i-3", "node", "vol-3"),
+				// The root device of the instance is an instance store.
+				{InstanceId: aws.String("i-4"), RootDeviceName: aws.String("/dev/sda1")},
+			}}}}, true)
+			return nil
+		}).Times(1)
+	ec2Mock.EXPECT().DescribeVolumesPagesWithContext(context.TODO(), &ec2.DescribeVolumesInput{
+		Filters: []*ec2.Filter{{Name: aws.String("volume-id"), Values: aws.StringSlice([]string{"vol-1", "vol-2", "vol-3"})}},
+	}, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *ec2.DescribeVolumesInput, fn func(*ec2.DescribeVolumesOutput, bool) bool, _ ...request.Option) error {
+			fn(&ec2.DescribeVolumesOutput{Volumes: []*ec2.Volume{
+				{VolumeId: aws.String("vol-1"), Encrypted: aws.Bool(true)},
+				{VolumeId: aws.String("vol-2"), Encrypted: aws.Bool(false)},
+				{VolumeId: aws.String("vol-3")},
+			}}, true)
+			return nil
+		}).Times(1)
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	reporter := NewReporter(DefaultReportInterval)
+	reporter.now = func() time.Time { return now }
+	clusterScope := cloudtest.NewClusterScope(t)
+	s := NewService(clusterScope, reporter)
+	s.EC2Client = ec2Mock
+
+	g.Expect(s.ReconcileVolumeEncryption()).To(Succeed())
+	g.Expect(clusterScope.AWSCluster.Status.VolumeEncryption).To(Equal(&infrav1.VolumeEncryptionStatus{
+		LastCheckTime:                  metav1.NewTime(now),
+		CheckedVolumes:                 3,
+		UnencryptedControlPlaneVolumes: 1,
+		UnencryptedNodeVolumes:         1,
+		UnencryptedInstanceIDs:         []string{"i-2", "i-3"},
+	}))
+	condition := conditions.Get(clusterScope.AWSCluster, infrav1.VolumeEncryptionCompliantCondition)
+	g.Expect(condition.Status).To(Equal(corev1.ConditionFalse))
+	g.Expect(condition.Reason).To(Equal(infrav1.UnencryptedVolumesReason))
+	g.Expect(condition.Message).To(Equal("2 of 3 root volumes are not encrypted, instances: i-2, i-3"))
+
+	// The volumes aren't described again before the interval elapsed.
+	now = now.Add(time.Hour)
+	g.Expect(s.ReconcileVolumeEncryption()).To(Succeed())
+	g.Expect(clusterScope.AWSCluster.Status.VolumeEncryption.LastCheckTime.Time).To(Equal(now.Add(-time.Hour)))
+}
+
+func TestReconcileVolumeEncryptionBatchesVolumes(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+	instances := []*ec2.Instance{}
+	for i := 0; i < maxVolumeIDsPerCall+1; i++ {
+		instances = append(instances, &ec2.Instance{
+			InstanceId:     aws.String(fmt.Sprintf("i-%03d", i)),
+			RootDeviceName: aws.String("/dev/xvda"),
+			BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
+				{DeviceName: aws.String("/dev/xvda"), Ebs: &ec2.EbsInstanceBlockDevice{VolumeId: aws.String(fmt.Sprintf("vol-%03d", i))}},
+			},
+		})
+	}
+	ec2Mock.EXPECT().DescribeInstancesPagesWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeInstancesInput{}), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *ec2.DescribeInstancesInput, fn func(*ec2.DescribeInstancesOutput, bool) bool, _ ...request.Option) error {
+			fn(&ec2.DescribeInstancesOutput{Reservations: []*ec2.Reservation{{Instances: instances}}}, true)
+			return nil
+		}).Times(1)
+
+	var batches []int
+	ec2Mock.EXPECT().DescribeVolumesPagesWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeVolumesInput{}), gomock.Any()).
+		DoAndReturn(func(_ context.Context, input *ec2.DescribeVolumesInput, fn func(*ec2.DescribeVolumesOutput, bool) bool, _ ...request.Option) error {
+			batches = append(batches, len(input.Filters[0].Values))
+			out := &ec2.DescribeVolumesOutput{}
+			for _, volumeID := range input.Filters[0].Values {
+				out.Volumes = append(out.Volumes, &ec2.Volume{VolumeId: volumeID, Encrypted: aws.Bool(true)})
+			}
+			fn(out, true)
+			return nil
+		}).Times(2)
+
+	clusterScope := cloudtest.NewClusterScope(t)
+	s := NewService(clusterScope, NewReporter(DefaultReportInterval))
+	s.EC2Client = ec2Mock
+
+	g.Expect(s.ReconcileVolumeEncryption()).To(Succeed())
+	g.Expect(batches).To(Equal([]int{maxVolumeIDsPerCall, 1}))
+	g.Expect(clusterScope.AWSCluster.Status.VolumeEncryption.CheckedVolumes).To(BeEquivalentTo(maxVolumeIDsPerCall + 1))
+	g.Expect(clusterScope.AWSCluster.Status.VolumeEncryption.UnencryptedInstanceIDs).To(BeEmpty())
+	g.Expect(conditions.IsTrue(clusterScope.AWSCluster, infrav1.VolumeEncryptionCompliantCondition)).To(BeTrue())
+}
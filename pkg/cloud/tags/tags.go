@@ -158,6 +158,22 @@ func WithEKS(eksclient eksiface.EKSAPI) BuilderOption {
 	}
 }
 
+// RemoveLegacyClusterTag deletes the kubernetes.io/cluster/<name> tag of the cluster from an EC2 resource having the
+// current tags, when the tag is disabled in the configuration.
+func RemoveLegacyClusterTag(ec2client ec2iface.EC2API, config *infrav1.LegacyClusterTag, clusterName, resourceID string, current infrav1.Tags) error {
+	if !config.Removes(current, clusterName) {
+		return nil
+	}
+
+	deleteTagsInput := &ec2.DeleteTagsInput{
+		Resources: aws.StringSlice([]string{resourceID}),
+		Tags:      []*ec2.Tag{{Key: aws.String(infrav1.ClusterAWSCloudProviderTagKey(clusterName))}},
+	}
+
+	_, err := ec2client.DeleteTagsWithContext(context.TODO(), deleteTagsInput)
+	return errors.Wrapf(err, "failed to remove the legacy cluster tag of resource %q", resourceID)
+}
+
 func computeDiff(current infrav1.Tags, buildParams infrav1.BuildParams) infrav1.Tags {
 	want := infrav1.Build(buildParams)
 
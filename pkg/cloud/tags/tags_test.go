@@ -218,6 +218,54 @@ func TestTagsEnsureWithEC2(t *testing.T) {
 	}
 }
 
+func TestRemoveLegacyClusterTag(t *testing.T) {
+	current := infrav1.Tags{"kubernetes.io/cluster/testcluster": "owned"}
+
+	tests := []struct {
+		name    string
+		config  *infrav1.LegacyClusterTag
+		current infrav1.Tags
+		expect  func(m *mocks.MockEC2APIMockRecorder)
+	}{
+		{
+			name:    "Should not remove the tag when it isn't configured",
+			current: current,
+		},
+		{
+			name:    "Should not remove the tag when it is enabled",
+			config:  &infrav1.LegacyClusterTag{Enabled: true},
+			current: current,
+		},
+		{
+			name:   "Should not remove the tag when the resource doesn't have it",
+			config: &infrav1.LegacyClusterTag{},
+		},
+		{
+			name:    "Should remove the tag when it is disabled",
+			config:  &infrav1.LegacyClusterTag{},
+			current: current,
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DeleteTagsWithContext(context.TODO(), gomock.Eq(&ec2.DeleteTagsInput{
+					Resources: aws.StringSlice([]string{"subnet-1"}),
+					Tags:      []*ec2.Tag{{Key: aws.String("kubernetes.io/cluster/testcluster")}},
+				})).Return(nil, nil)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+			if tc.expect != nil {
+				tc.expect(ec2Mock.EXPECT())
+			}
+			g.Expect(RemoveLegacyClusterTag(ec2Mock, tc.config, "testcluster", "subnet-1", tc.current)).To(Succeed())
+		})
+	}
+}
+
 func TestTagsEnsureWithEKS(t *testing.T) {
 	tests := []struct {
 		name    string
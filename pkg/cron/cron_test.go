@@ -0,0 +1,112 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cron
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		expression string
+		wantErr    bool
+	}{
+		{expression: "0 20 * * 1-5"},
+		{expression: "*/15 7,19 1-15/2 */3 0,7"},
+		{expression: "0 20 * *", wantErr: true},
+		{expression: "60 20 * * *", wantErr: true},
+		{expression: "0 20 * * 8", wantErr: true},
+		{expression: "0 20 5-1 * *", wantErr: true},
+		{expression: "*/0 20 * * *", wantErr: true},
+		{expression: "0 20 * JAN *", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			g := NewWithT(t)
+			_, err := Parse(tt.expression)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+		})
+	}
+}
+
+func TestActivationTimes(t *testing.T) {
+	// Wednesday.
+	now := time.Date(2024, 1, 3, 12, 30, 15, 0, time.UTC)
+	tests := []struct {
+		name       string
+		expression string
+		wantPrev   time.Time
+		wantNext   time.Time
+	}{
+		{
+			name:       "weekday evenings",
+			expression: "0 20 * * 1-5",
+			wantPrev:   time.Date(2024, 1, 2, 20, 0, 0, 0, time.UTC),
+			wantNext:   time.Date(2024, 1, 3, 20, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "weekday mornings across the weekend",
+			expression: "0 7 * * 1-5",
+			wantPrev:   time.Date(2024, 1, 3, 7, 0, 0, 0, time.UTC),
+			wantNext:   time.Date(2024, 1, 4, 7, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "sundays as 7",
+			expression: "0 0 * * 7",
+			wantPrev:   time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC),
+			wantNext:   time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "day of month or day of week",
+			expression: "0 0 15 * 5",
+			wantPrev:   time.Date(2023, 12, 29, 0, 0, 0, 0, time.UTC),
+			wantNext:   time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "every quarter of an hour",
+			expression: "*/15 * * * *",
+			wantPrev:   time.Date(2024, 1, 3, 12, 30, 0, 0, time.UTC),
+			wantNext:   time.Date(2024, 1, 3, 12, 45, 0, 0, time.UTC),
+		},
+		{
+			name:       "leap days",
+			expression: "0 0 29 2 *",
+			wantPrev:   time.Date(2020, 2, 29, 0, 0, 0, 0, time.UTC),
+			wantNext:   time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "never",
+			expression: "0 0 30 2 *",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			schedule, err := Parse(tt.expression)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(schedule.Prev(now)).To(Equal(tt.wantPrev))
+			g.Expect(schedule.Next(now)).To(Equal(tt.wantNext))
+		})
+	}
+}
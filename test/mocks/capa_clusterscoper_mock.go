@@ -291,6 +291,34 @@ func (mr *MockClusterScoperMockRecorder) Namespace() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Namespace", reflect.TypeOf((*MockClusterScoper)(nil).Namespace))
 }
 
+// OwnershipTagPrefix mocks base method.
+func (m *MockClusterScoper) OwnershipTagPrefix() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OwnershipTagPrefix")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// OwnershipTagPrefix indicates an expected call of OwnershipTagPrefix.
+func (mr *MockClusterScoperMockRecorder) OwnershipTagPrefix() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OwnershipTagPrefix", reflect.TypeOf((*MockClusterScoper)(nil).OwnershipTagPrefix))
+}
+
+// OwnershipTagPrefixes mocks base method.
+func (m *MockClusterScoper) OwnershipTagPrefixes() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OwnershipTagPrefixes")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// OwnershipTagPrefixes indicates an expected call of OwnershipTagPrefixes.
+func (mr *MockClusterScoperMockRecorder) OwnershipTagPrefixes() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OwnershipTagPrefixes", reflect.TypeOf((*MockClusterScoper)(nil).OwnershipTagPrefixes))
+}
+
 // PatchObject mocks base method.
 func (m *MockClusterScoper) PatchObject() error {
 	m.ctrl.T.Helper()
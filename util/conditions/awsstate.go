@@ -0,0 +1,42 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conditions
+
+import (
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// MarkAWSState sets a condition with negative polarity reporting a state of an AWS resource which operators may want
+// to alert on, e.g. a degraded EKS addon. A warning event with the reason of the condition is recorded when the object
+// enters the state or when the message changes.
+func MarkAWSState(to conditions.Setter, conditionType clusterv1.ConditionType, reason, message string) {
+	if !conditions.IsTrue(to, conditionType) || conditions.GetMessage(to, conditionType) != message {
+		record.Warnf(to, reason, "%s", message)
+	}
+	conditions.MarkTrueWithNegativePolarity(to, conditionType, reason, clusterv1.ConditionSeverityWarning, "%s", message)
+}
+
+// ClearAWSState removes a condition set by MarkAWSState once the AWS resource left the state, recording an event.
+func ClearAWSState(to conditions.Setter, conditionType clusterv1.ConditionType) {
+	if !conditions.Has(to, conditionType) {
+		return
+	}
+	conditions.Delete(to, conditionType)
+	record.Eventf(to, string(conditionType)+"Resolved", "%s is resolved", conditionType)
+}
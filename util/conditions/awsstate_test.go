@@ -0,0 +1,51 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conditions
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestAWSState(t *testing.T) {
+	g := NewWithT(t)
+	pool := &expinfrav1.AWSMachinePool{}
+
+	MarkAWSState(pool, expinfrav1.ASGSuspendedProcessesCondition, expinfrav1.SuspendedProcessesPresentReason, "Launch")
+	c := conditions.Get(pool, expinfrav1.ASGSuspendedProcessesCondition)
+	g.Expect(c).ToNot(BeNil())
+	g.Expect(c.Status).To(Equal(corev1.ConditionTrue))
+	g.Expect(c.Severity).To(Equal(clusterv1.ConditionSeverityWarning))
+	g.Expect(c.Reason).To(Equal(expinfrav1.SuspendedProcessesPresentReason))
+	g.Expect(c.Message).To(Equal("Launch"))
+
+	MarkAWSState(pool, expinfrav1.ASGSuspendedProcessesCondition, expinfrav1.SuspendedProcessesPresentReason, "Launch, Terminate")
+	g.Expect(conditions.GetMessage(pool, expinfrav1.ASGSuspendedProcessesCondition)).To(Equal("Launch, Terminate"))
+
+	ClearAWSState(pool, expinfrav1.ASGSuspendedProcessesCondition)
+	g.Expect(conditions.Has(pool, expinfrav1.ASGSuspendedProcessesCondition)).To(BeFalse())
+
+	// Clearing a state the object isn't in is a no-op.
+	ClearAWSState(pool, expinfrav1.ASGSuspendedProcessesCondition)
+	g.Expect(conditions.Has(pool, expinfrav1.ASGSuspendedProcessesCondition)).To(BeFalse())
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conditions
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/patch"
+)
+
+// MarkReconciliationSkipped sets the ReconciliationSkipped condition with the given reason on the object.
+// An event is recorded only when the object enters this state, not on every skipped reconciliation.
+func MarkReconciliationSkipped(recorder record.EventRecorder, to conditions.Setter, reason string, severity clusterv1.ConditionSeverity, messageFormat string, messageArgs ...interface{}) {
+	if conditions.IsTrue(to, infrav1.ReconciliationSkippedCondition) && conditions.GetReason(to, infrav1.ReconciliationSkippedCondition) == reason {
+		return
+	}
+	conditions.MarkTrueWithNegativePolarity(to, infrav1.ReconciliationSkippedCondition, reason, severity, messageFormat, messageArgs...)
+
+	eventType := corev1.EventTypeNormal
+	if severity == clusterv1.ConditionSeverityWarning || severity == clusterv1.ConditionSeverityError {
+		eventType = corev1.EventTypeWarning
+	}
+	recorder.Eventf(to, eventType, "ReconciliationSkipped", "Skipping reconciliation (%s): %s", reason, fmt.Sprintf(messageFormat, messageArgs...))
+}
+
+// ClearReconciliationSkipped removes the ReconciliationSkipped condition from the object,
+// recording an event if reconciliation was previously being skipped.
+func ClearReconciliationSkipped(recorder record.EventRecorder, to conditions.Setter) {
+	if conditions.Get(to, infrav1.ReconciliationSkippedCondition) == nil {
+		return
+	}
+	conditions.Delete(to, infrav1.ReconciliationSkippedCondition)
+	recorder.Eventf(to, corev1.EventTypeNormal, "ReconciliationResumed", "Resumed reconciliation")
+}
+
+// PatchReconciliationSkipped marks reconciliation of the object as skipped and persists the condition right away.
+// It is meant for early returns that happen before a scope, which would otherwise patch the object, is created.
+func PatchReconciliationSkipped(ctx context.Context, c client.Client, recorder record.EventRecorder, obj conditions.Setter, reason string, severity clusterv1.ConditionSeverity, messageFormat string, messageArgs ...interface{}) error {
+	patchHelper, err := patch.NewHelper(obj, c)
+	if err != nil {
+		return err
+	}
+	MarkReconciliationSkipped(recorder, obj, reason, severity, messageFormat, messageArgs...)
+	return patchHelper.Patch(ctx, obj, patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{
+		infrav1.ReconciliationSkippedCondition,
+	}})
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conditions
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestReconciliationSkipped(t *testing.T) {
+	g := NewWithT(t)
+	recorder := record.NewFakeRecorder(10)
+	awsMachine := &infrav1.AWSMachine{}
+
+	MarkReconciliationSkipped(recorder, awsMachine, infrav1.WaitingForBootstrapDataReason, clusterv1.ConditionSeverityInfo, "waiting")
+	c := conditions.Get(awsMachine, infrav1.ReconciliationSkippedCondition)
+	g.Expect(c).ToNot(BeNil())
+	g.Expect(c.Status).To(Equal(corev1.ConditionTrue))
+	g.Expect(c.Reason).To(Equal(infrav1.WaitingForBootstrapDataReason))
+	g.Expect(recorder.Events).To(Receive(HavePrefix("Normal ReconciliationSkipped")))
+
+	// Skipping again for the same reason doesn't record another event.
+	MarkReconciliationSkipped(recorder, awsMachine, infrav1.WaitingForBootstrapDataReason, clusterv1.ConditionSeverityInfo, "waiting")
+	g.Expect(recorder.Events).ToNot(Receive())
+
+	MarkReconciliationSkipped(recorder, awsMachine, infrav1.FailedStateReason, clusterv1.ConditionSeverityWarning, "failed")
+	g.Expect(conditions.GetReason(awsMachine, infrav1.ReconciliationSkippedCondition)).To(Equal(infrav1.FailedStateReason))
+	g.Expect(recorder.Events).To(Receive(HavePrefix("Warning ReconciliationSkipped")))
+
+	ClearReconciliationSkipped(recorder, awsMachine)
+	g.Expect(conditions.Has(awsMachine, infrav1.ReconciliationSkippedCondition)).To(BeFalse())
+	g.Expect(recorder.Events).To(Receive(HavePrefix("Normal ReconciliationResumed")))
+
+	// Clearing an object that wasn't skipped is a no-op.
+	ClearReconciliationSkipped(recorder, awsMachine)
+	g.Expect(recorder.Events).ToNot(Receive())
+}
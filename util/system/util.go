@@ -82,7 +82,11 @@ func GetPartitionFromRegion(region string) string {
 		return endpoints.AwsIsoPartitionID
 	case endpoints.UsIsobEast1RegionID:
 		return endpoints.AwsIsoBPartitionID
-	default:
-		return endpoints.AwsPartitionID
 	}
+
+	// Regions which are not listed above are matched against the regions known to the SDK.
+	if partition, ok := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), region); ok {
+		return partition.ID()
+	}
+	return endpoints.AwsPartitionID
 }
@@ -68,3 +68,25 @@ func TestGetNamespaceFromFile(t *testing.T) {
 	g.Expect(GetNamespaceFromFile(nsPath)).To(Equal("different-ns"))
 	g.Expect(os.Remove(nsPath)).NotTo(HaveOccurred())
 }
+
+func TestGetPartitionFromRegion(t *testing.T) {
+	tests := []struct {
+		region string
+		want   string
+	}{
+		{region: "us-east-1", want: "aws"},
+		{region: "eu-west-3", want: "aws"},
+		{region: "us-gov-west-1", want: "aws-us-gov"},
+		{region: "cn-northwest-1", want: "aws-cn"},
+		{region: "us-iso-east-1", want: "aws-iso"},
+		{region: "us-isob-east-1", want: "aws-iso-b"},
+		{region: "cn-south-1", want: "aws-cn"},
+		{region: "", want: "aws"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.region, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(GetPartitionFromRegion(tt.region)).To(Equal(tt.want))
+		})
+	}
+}